@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
 type Market struct {
@@ -119,13 +121,19 @@ func main() {
 	fmt.Printf("Signal 1 - MARKET FAVORITE:  %d-%d°F (highest price: %d¢)\n",
 		brackets[0].Floor, brackets[0].Cap, brackets[0].MidPrice)
 
-	// Signal 2: METAR prediction
-	metarBracket := (metar / 2) * 2 // Round down to even
-	if metar%2 == 1 {
-		metarBracket = metar - 1
+	// Signal 2: METAR prediction, from the listed market strikes
+	strikes := make(market.Strikes, len(brackets))
+	for i, b := range brackets {
+		strikes[i] = market.NewStrike(b.Floor, b.Cap)
+	}
+	metarBracket := -1
+	if i := strikes.Index(float64(metar), market.RoundNearest); i >= 0 {
+		metarBracket = brackets[i].Floor
+		fmt.Printf("Signal 2 - METAR PREDICTION: %d-%d°F (current temp: %d°F)\n",
+			brackets[i].Floor, brackets[i].Cap, metar)
+	} else {
+		fmt.Printf("Signal 2 - METAR PREDICTION: no listed bracket (current temp: %d°F)\n", metar)
 	}
-	fmt.Printf("Signal 2 - METAR PREDICTION: %d-%d°F (current temp: %d°F)\n",
-		metarBracket, metarBracket+1, metar)
 
 	// Signal 3: 2nd best
 	secondBest := brackets[1].Floor
@@ -312,39 +320,10 @@ func getMarketData(eventTicker string) []BracketInfo {
 }
 
 func getMETARMax(date time.Time) (int, error) {
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=LAX&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=America/Los_Angeles&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		date.Year(), int(date.Month()), date.Day(),
-		date.Year(), int(date.Month()), date.Day()+1,
-	)
-
-	resp, err := httpClient.Get(url)
+	data, err := weather.FetchMETARMax(weather.Stations["LAX"], date)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	lines := strings.Split(string(body), "\n")
-	maxTemp := 0.0
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, "LAX,") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > maxTemp {
-					maxTemp = temp
-				}
-			}
-		}
-	}
-
-	if maxTemp == 0 {
-		return 0, fmt.Errorf("no data")
-	}
-
-	return int(math.Round(maxTemp)), nil
+	return int(data.MaxTemp), nil
 }
 
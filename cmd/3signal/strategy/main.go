@@ -2,36 +2,19 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"math"
 	"net/http"
 	"os"
 	"sort"
 	"strings"
 	"time"
-)
-
-type Trade struct {
-	CreatedTime time.Time `json:"created_time"`
-	YesPrice    int       `json:"yes_price"`
-}
-
-type TradesResponse struct {
-	Trades []Trade `json:"trades"`
-}
 
-type Market struct {
-	Ticker      string `json:"ticker"`
-	FloorStrike int    `json:"floor_strike"`
-	CapStrike   int    `json:"cap_strike"`
-	Result      string `json:"result"`
-}
-
-type MarketsResponse struct {
-	Markets []Market `json:"markets"`
-}
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
 
 type BracketData struct {
 	Floor      int
@@ -43,6 +26,7 @@ type DayData struct {
 	Date        time.Time
 	METARMax    int
 	Brackets    []BracketData
+	Markets     []rest.Market // Every listed market, for strike lookups
 	WinnerFloor int
 }
 
@@ -59,6 +43,7 @@ type TradeResult struct {
 
 var loc *time.Location
 var httpClient = &http.Client{Timeout: 15 * time.Second}
+var client = rest.NewPublic(rest.WithHTTPClient(httpClient), rest.WithRateLimit(rest.DefaultRateLimits()))
 var outputFile *os.File
 
 func init() {
@@ -149,19 +134,19 @@ func fetchDayData(date time.Time) (DayData, error) {
 		return dayData, fmt.Errorf("no winner")
 	}
 
-	dayData.WinnerFloor = winner.FloorStrike
+	dayData.WinnerFloor = int(winner.FloorStrike)
+	dayData.Markets = markets
 
 	for _, m := range markets {
-		if m.FloorStrike >= 55 && m.FloorStrike <= 80 {
+		if floor := int(m.FloorStrike); floor >= 55 && floor <= 80 {
 			price, err := getFirstTradePrice(m.Ticker)
 			if err == nil && price > 0 {
 				dayData.Brackets = append(dayData.Brackets, BracketData{
-					Floor:      m.FloorStrike,
+					Floor:      floor,
 					FirstPrice: price,
 					Won:        m.FloorStrike == winner.FloorStrike,
 				})
 			}
-			time.Sleep(150 * time.Millisecond)
 		}
 	}
 
@@ -188,8 +173,8 @@ func runEnsembleDetailed(data []DayData) []TradeResult {
 		// Signal 1: Market favorite
 		marketPick := d.Brackets[0].Floor
 
-		// Signal 2: METAR prediction (round to bracket)
-		metarPick := ((d.METARMax) / 2) * 2
+		// Signal 2: METAR prediction (bracket containing the METAR max)
+		metarPick := market.BracketFloors(d.Markets, strikesOf, d.METARMax, 0, 1)[0]
 
 		// Signal 3: 2nd best bracket
 		secondPick := d.Brackets[1].Floor
@@ -471,93 +456,51 @@ func generateRecommendations(results []TradeResult) {
 }
 
 func getMETARMax(date time.Time) (int, error) {
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=LAX&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=America/Los_Angeles&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		date.Year(), int(date.Month()), date.Day(),
-		date.Year(), int(date.Month()), date.Day()+1,
-	)
-
-	resp, err := httpClient.Get(url)
+	data, err := weather.FetchMETARMax(weather.Stations["LAX"], date)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	lines := strings.Split(string(body), "\n")
-	maxTemp := 0.0
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, "LAX,") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > maxTemp {
-					maxTemp = temp
-				}
-			}
-		}
-	}
-
-	if maxTemp == 0 {
-		return 0, fmt.Errorf("no data")
-	}
-
-	return int(math.Round(maxTemp)), nil
+	return int(data.MaxTemp), nil
 }
 
-func getWinnerAndMarkets(eventTicker string) (*Market, []Market, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets?event_ticker=%s&limit=100", eventTicker)
-
-	resp, err := httpClient.Get(url)
+func getWinnerAndMarkets(eventTicker string) (*rest.Market, []rest.Market, error) {
+	markets, err := client.GetMarkets(eventTicker)
 	if err != nil {
 		return nil, nil, err
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-
-	var result MarketsResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, nil, err
-	}
-
-	var winner *Market
-	for i := range result.Markets {
-		if result.Markets[i].Result == "yes" {
-			winner = &result.Markets[i]
+	var winner *rest.Market
+	for i := range markets {
+		if markets[i].Result == "yes" {
+			winner = &markets[i]
 			break
 		}
 	}
 
-	return winner, result.Markets, nil
+	return winner, markets, nil
 }
 
+// getFirstTradePrice returns the YES price of the market's first trade;
+// trades are listed newest first, so every page is read
 func getFirstTradePrice(ticker string) (int, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets/trades?ticker=%s&limit=500", ticker)
-
-	resp, err := httpClient.Get(url)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var result TradesResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return 0, err
+	var first *rest.Trade
+	for trade, err := range client.IterTrades(context.Background(), rest.GetTradesParams{Ticker: ticker, Limit: 1000}) {
+		if err != nil {
+			return 0, err
+		}
+		if first == nil || trade.CreatedTime.Before(first.CreatedTime) {
+			first = &trade
+		}
 	}
 
-	if len(result.Trades) == 0 {
+	if first == nil {
 		return 0, fmt.Errorf("no trades")
 	}
 
-	sort.Slice(result.Trades, func(i, j int) bool {
-		return result.Trades[i].CreatedTime.Before(result.Trades[j].CreatedTime)
-	})
-
-	return result.Trades[0].YesPrice, nil
+	return first.YesPrice, nil
 }
 
+// strikesOf returns a market's floor and cap strikes, for market.BracketFloors
+func strikesOf(m rest.Market) (int, int) {
+	return int(m.FloorStrike), int(m.CapStrike)
+}
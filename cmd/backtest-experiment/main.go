@@ -0,0 +1,656 @@
+// Package main runs backtests as saved experiments and compares two of them
+// trade by trade: the days on which their decisions diverged and how the P&L
+// difference splits into positions added, dropped and changed. It shows what
+// a parameter change actually does beyond the headline profit.
+//
+// Usage:
+//
+//	go run ./cmd/backtest-experiment run -strategy threshold -data history.json.gz
+//	go run ./cmd/backtest-experiment run -strategy threshold -data history.json.gz -set Margin=3 -set MaxNoPrice=85
+//	go run ./cmd/backtest-experiment run -strategy fade -data history.json.gz -set Exits.StopLoss=10 -set Exits.FlattenHour=15
+//	go run ./cmd/backtest-experiment run -strategy dualside -data history.json.gz -books books.jsonl -set Imbalance.MinPressure=-0.2 -set Imbalance.MaxWait=2h
+//	go run ./cmd/backtest-experiment update -data history.json.gz threshold-1a2b3c4d
+//	go run ./cmd/backtest-experiment capacity -out capacity.json threshold-1a2b3c4d
+//	go run ./cmd/backtest-experiment list
+//	go run ./cmd/backtest-experiment diff threshold-1a2b3c4d threshold-5e6f7a8b
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/examples/ensemble"
+	"github.com/brendanplayford/kalshi-go/examples/marketmaking"
+	"github.com/brendanplayford/kalshi-go/examples/threshold"
+	"github.com/brendanplayford/kalshi-go/examples/valuebet"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/dualside"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/fade"
+)
+
+const defaultDir = "results/experiments"
+
+// builder creates a strategy from its default configuration with overrides
+// applied, returning the effective configuration too
+type builder func(set map[string]string) (strategy.Strategy, any, error)
+
+var strategies = map[string]builder{
+	"threshold": func(set map[string]string) (strategy.Strategy, any, error) {
+		cfg := threshold.DefaultConfig()
+		err := apply(&cfg, set)
+		return threshold.New(cfg), cfg, err
+	},
+	"ensemble": func(set map[string]string) (strategy.Strategy, any, error) {
+		cfg := ensemble.DefaultConfig()
+		err := apply(&cfg, set)
+		return ensemble.New(cfg), cfg, err
+	},
+	"valuebet": func(set map[string]string) (strategy.Strategy, any, error) {
+		cfg := valuebet.DefaultConfig()
+		err := apply(&cfg, set)
+		return valuebet.New(cfg), cfg, err
+	},
+	"marketmaking": func(set map[string]string) (strategy.Strategy, any, error) {
+		cfg := marketmaking.DefaultConfig()
+		err := apply(&cfg, set)
+		return marketmaking.New(cfg), cfg, err
+	},
+	"dualside": func(set map[string]string) (strategy.Strategy, any, error) {
+		cfg := dualside.DefaultConfig()
+		err := apply(&cfg, set)
+		return dualside.New(cfg), cfg, err
+	},
+	"fade": func(set map[string]string) (strategy.Strategy, any, error) {
+		cfg := fade.DefaultConfig()
+		err := apply(&cfg, set)
+		return fade.New(cfg), cfg, err
+	},
+}
+
+// Keys of -set for the wrappers any strategy can run in: exit rules and
+// order book entry timing, set whole (-set 'Exits={"StopLoss":10}') or by
+// field (-set Exits.StopLoss=10, -set Imbalance.MaxWait=2h)
+const (
+	exitKey      = "Exits"
+	imbalanceKey = "Imbalance"
+)
+
+// buildWrapped builds the strategy inside the wrappers set configures,
+// recording their settings with its configuration. The model exit uses the
+// fade strategy's model at its defaults
+func buildWrapped(build builder, set map[string]string) (strategy.Strategy, any, error) {
+	var rules strategy.ExitRules
+	var timing *strategy.ImbalanceTiming
+	rest := make(map[string]string)
+	for k, v := range set {
+		switch {
+		case wrapperKey(k, imbalanceKey):
+			timing = &strategy.ImbalanceTiming{}
+		case !wrapperKey(k, exitKey):
+			rest[k] = v
+		}
+	}
+	if err := wrapperConfig(set, exitKey, &rules); err != nil {
+		return nil, nil, err
+	}
+	if timing != nil {
+		if err := wrapperConfig(set, imbalanceKey, timing); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	s, params, err := build(rest)
+	if err != nil || (!rules.Enabled() && timing == nil) {
+		return s, params, err
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, nil, err
+	}
+	if timing != nil {
+		s = strategy.WithImbalance(s, *timing)
+		if merged[imbalanceKey], err = json.Marshal(timing); err != nil {
+			return nil, nil, err
+		}
+	}
+	if rules.Enabled() {
+		s = strategy.WithExits(s, rules, fade.New(fade.DefaultConfig()).Probability)
+		if merged[exitKey], err = json.Marshal(rules); err != nil {
+			return nil, nil, err
+		}
+	}
+	return s, merged, nil
+}
+
+// wrapperKey reports whether the -set key k configures the wrapper key
+func wrapperKey(k, key string) bool {
+	return strings.EqualFold(k, key) || len(k) > len(key)+1 && strings.EqualFold(k[:len(key)+1], key+".")
+}
+
+// wrapperConfig applies the -set values of the wrapper key to cfg: the key
+// itself as a JSON object, then key.Field values. Durations may be given as
+// e.g. 2h
+func wrapperConfig(set map[string]string, key string, cfg any) error {
+	fields := make(map[string]string)
+	for k, v := range set {
+		switch {
+		case strings.EqualFold(k, key):
+			if err := json.Unmarshal([]byte(v), cfg); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+		case wrapperKey(k, key):
+			if d, err := time.ParseDuration(v); err == nil && d != 0 {
+				v = strconv.FormatInt(int64(d), 10)
+			}
+			fields[k[len(key)+1:]] = v
+		}
+	}
+	if err := apply(cfg, fields); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	switch os.Args[1] {
+	case "run":
+		run(os.Args[2:])
+	case "update":
+		update(os.Args[2:])
+	case "capacity":
+		capacity(os.Args[2:])
+	case "list":
+		list(os.Args[2:])
+	case "diff":
+		diff(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: backtest-experiment run -strategy NAME -data FILE [-set Key=Value]... [-books FILE] [-dir DIR]")
+	fmt.Fprintln(os.Stderr, "       backtest-experiment update [-data FILE] [-books FILE] [-dir DIR] ID")
+	fmt.Fprintln(os.Stderr, "       backtest-experiment capacity [-data FILE] [-dir DIR] [-participation F] [-percentile P] [-out FILE] ID")
+	fmt.Fprintln(os.Stderr, "       backtest-experiment list [-dir DIR]")
+	fmt.Fprintln(os.Stderr, "       backtest-experiment diff [-dir DIR] ID_A ID_B")
+	os.Exit(2)
+}
+
+// setFlags collects repeated -set Key=Value flags
+type setFlags map[string]string
+
+func (s setFlags) String() string { return fmt.Sprint(map[string]string(s)) }
+
+func (s setFlags) Set(v string) error {
+	key, value, ok := strings.Cut(v, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("want Key=Value, got %q", v)
+	}
+	s[key] = value
+	return nil
+}
+
+func run(args []string) {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	name := fs.String("strategy", "", "Strategy: "+strings.Join(strategyNames(), ", "))
+	data := fs.String("data", "", "Dataset file exported by cmd/backtest-fixtures")
+	books := fs.String("books", "", "Recorded order book snapshots to quote (BOOK_RECORD_FILE)")
+	dir := fs.String("dir", defaultDir, "Experiment directory")
+	export := fs.String("export", "", "Also export trades, days and summary to this directory, labelled with the experiment ID")
+	format := fs.String("format", "csv", "Export formats: csv, json or both")
+	runs := fs.String("runs", backtest.DefaultRunsDir, "Record the run in this registry (\"\" = don't)")
+	set := setFlags{}
+	fs.Var(set, "set", "Override a config field, e.g. -set Margin=3, or an exit rule, e.g. -set Exits.StopLoss=10 (repeatable; values are JSON or plain strings)")
+	fs.Parse(args)
+
+	formats, err := backtest.ParseExportFormats(*format)
+	if err != nil {
+		log.Fatalf("Invalid -format: %v", err)
+	}
+
+	build, ok := strategies[*name]
+	if !ok {
+		log.Fatalf("Unknown strategy %q (want one of %s)", *name, strings.Join(strategyNames(), ", "))
+	}
+	s, params, err := buildWrapped(build, set)
+	if err != nil {
+		log.Fatalf("Invalid -set: %v", err)
+	}
+
+	ds, dataset, err := loadDataset(*data)
+	if err != nil {
+		log.Fatalf("Failed to load dataset: %v", err)
+	}
+	addBooks(ds, *books)
+
+	cfg := backtest.DefaultConfig()
+	r := backtest.Run(ds, s, cfg)
+
+	exp, err := backtest.NewExperiment(*name, params, dataset, cfg, r)
+	if err != nil {
+		log.Fatalf("Failed to record experiment: %v", err)
+	}
+	path, err := exp.Save(*dir)
+	if err != nil {
+		log.Fatalf("Failed to save experiment: %v", err)
+	}
+
+	fmt.Printf("%s  %d trades, win %.1f%%, profit %s  (%s)\n",
+		exp.ID, len(r.Trades), r.WinRate, money(r.TotalProfit), path)
+	printIntervals(r)
+	printBaselines(r)
+	recordRun(*runs, *name, params, dataset, ds, cfg, r)
+
+	if *export != "" {
+		paths, err := r.Export(*export, exp.ID, formats...)
+		if err != nil {
+			log.Fatalf("Failed to export results: %v", err)
+		}
+		fmt.Printf("\nExported %s\n", strings.Join(paths, ", "))
+	}
+}
+
+// recordRun records the run in the registry at dir ("" = don't)
+func recordRun(dir, name string, params any, dataset string, ds *backtest.Dataset, cfg backtest.Config, r *backtest.Result) {
+	if dir == "" {
+		return
+	}
+	from, to := ds.Span()
+	run, err := backtest.NewRunRecord("backtest-experiment", name, params, dataset, from, to, cfg.Seed)
+	if err != nil {
+		log.Fatalf("Failed to record run: %v", err)
+	}
+	run.AddResult(r)
+	if _, err := run.Save(dir); err != nil {
+		log.Fatalf("Failed to save run: %v", err)
+	}
+	fmt.Printf("\nRecorded run %s\n", run.ID)
+}
+
+// printIntervals prints the run's metrics with their bootstrap intervals,
+// which are wide on the few dozen trades of a short dataset
+func printIntervals(r *backtest.Result) {
+	sum := r.Bootstrap(stats.DefaultBootstrapConfig())
+	fmt.Printf("  Win rate:     %s\n", sum.WinRate.Format("%.1f%%"))
+	fmt.Printf("  EV/trade:     %s\n", sum.EV.Format("$%.2f"))
+	fmt.Printf("  Sharpe:       %s\n", sum.Sharpe.Format("%.2f"))
+	fmt.Printf("  Max drawdown: %s\n", sum.MaxDrawdown.Format("$%.2f"))
+}
+
+// printBaselines compares the run with the naive baselines over the same
+// days, per dollar staked and per dollar-hour deployed
+func printBaselines(r *backtest.Result) {
+	if len(r.Baselines) == 0 {
+		return
+	}
+	results := append([]*backtest.Result{r}, r.Baselines...)
+	fmt.Printf("\n%-28s %7s %7s %9s %12s %8s %8s %10s\n", "Strategy", "Trades", "Win", "Win CI", "Profit", "ROI", "Per $·h", "Excess")
+	fmt.Println(strings.Repeat("-", 96))
+	for i, c := range r.Compare() {
+		excess := "-"
+		if i > 0 {
+			excess = fmt.Sprintf("%+.1f%%", c.Excess*100)
+		}
+		fmt.Printf("%-28s %7d %6.1f%% %9s %12s %7.1f%% %7.2f%% %10s\n",
+			c.Strategy, c.Trades, c.WinRate, winCI(results[i]), money(c.Profit), c.ROI*100, c.PerHour*100, excess)
+	}
+}
+
+// update appends the days of the dataset after the experiment's last
+// replayed date to its saved result, so a nightly refresh replays one day
+// instead of the whole history
+func update(args []string) {
+	fs := flag.NewFlagSet("update", flag.ExitOnError)
+	data := fs.String("data", "", "Dataset file (default: the experiment's dataset)")
+	books := fs.String("books", "", "Recorded order book snapshots to quote (BOOK_RECORD_FILE)")
+	dir := fs.String("dir", defaultDir, "Experiment directory")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		usage()
+	}
+
+	exp, err := backtest.LoadExperiment(*dir, fs.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	build, ok := strategies[exp.Strategy]
+	if !ok {
+		log.Fatalf("Experiment %s uses unknown strategy %q", exp.ID, exp.Strategy)
+	}
+	s, _, err := buildWrapped(build, savedParams(exp.Params))
+	if err != nil {
+		log.Fatalf("Failed to restore parameters: %v", err)
+	}
+
+	// Experiments run on the synthetic test fixture have no file to reload
+	path := *data
+	if path == "" && exp.Dataset != "fixtures.LAXNYC" {
+		path = exp.Dataset
+	}
+	ds, _, err := loadDataset(path)
+	if err != nil {
+		log.Fatalf("Failed to load dataset: %v", err)
+	}
+	addBooks(ds, *books)
+
+	// The fee schedule is not saved with the experiment
+	cfg := exp.Config
+	cfg.Fees = backtest.DefaultConfig().Fees
+
+	start := time.Now()
+	r := exp.Result
+	trades := len(r.Trades)
+	n := backtest.Append(r, ds, s, cfg)
+	if n == 0 {
+		fmt.Printf("%s  up to date through %s\n", exp.ID, r.Through)
+		return
+	}
+	saved, err := exp.Save(*dir)
+	if err != nil {
+		log.Fatalf("Failed to save experiment: %v", err)
+	}
+
+	fmt.Printf("%s  +%d days through %s, +%d trades in %s\n",
+		exp.ID, n, r.Through, len(r.Trades)-trades, time.Since(start).Round(time.Millisecond))
+	fmt.Printf("%s  %d trades, win %.1f%%, profit %s, Sharpe %.2f  (%s)\n",
+		exp.ID, len(r.Trades), r.WinRate, money(r.TotalProfit), r.Sharpe, saved)
+	printIntervals(r)
+	printBaselines(r)
+}
+
+// savedParams turns an experiment's saved configuration into -set
+// overrides, so the strategy is rebuilt exactly as it ran
+func savedParams(params json.RawMessage) map[string]string {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(params, &fields) != nil {
+		return nil
+	}
+	set := make(map[string]string, len(fields))
+	for k, v := range fields {
+		set[k] = string(v)
+	}
+	return set
+}
+
+// capacity estimates how far the experiment's strategy can be scaled up
+// before its orders would move prices, from the dataset's archived volume.
+// -out saves the estimate for the production bot's CAPACITY_FILE
+func capacity(args []string) {
+	fs := flag.NewFlagSet("capacity", flag.ExitOnError)
+	data := fs.String("data", "", "Dataset file (default: the experiment's dataset)")
+	dir := fs.String("dir", defaultDir, "Experiment directory")
+	participation := fs.Float64("participation", backtest.DefaultCapacityConfig().Participation, "Share of the volume after each entry the strategy may take")
+	percentile := fs.Float64("percentile", backtest.DefaultCapacityConfig().Percentile, "Percentile of the per-trade capacities reported")
+	out := fs.String("out", "", "Save the estimate as JSON to this file")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		usage()
+	}
+
+	exp, err := backtest.LoadExperiment(*dir, fs.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Experiments run on the synthetic test fixture have no file to reload
+	path := *data
+	if path == "" && exp.Dataset != "fixtures.LAXNYC" {
+		path = exp.Dataset
+	}
+	ds, _, err := loadDataset(path)
+	if err != nil {
+		log.Fatalf("Failed to load dataset: %v", err)
+	}
+
+	c := backtest.EstimateCapacity(ds, exp.Result, backtest.CapacityConfig{
+		Participation: *participation,
+		Percentile:    *percentile,
+	})
+	if c.Trades == 0 {
+		log.Fatalf("%s: no trades in markets with archived volume", exp.ID)
+	}
+	fmt.Printf("%s  %d of %d trades with volume, taking %.0f%% of it (p%.0f)\n",
+		exp.ID, c.Trades, len(exp.Result.Trades), *participation*100, *percentile)
+	fmt.Printf("  Contracts per order: %d\n", c.Contracts)
+	fmt.Printf("  Scale:               %.2fx the backtested sizes\n", c.Scale)
+	fmt.Printf("  Daily stake:         %s\n", money(c.Dollars))
+
+	if *out != "" {
+		b, err := json.MarshalIndent(c, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*out, append(b, '\n'), 0o644); err != nil {
+			log.Fatalf("Failed to save capacity: %v", err)
+		}
+		fmt.Printf("  Saved to %s\n", *out)
+	}
+}
+
+func list(args []string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	dir := fs.String("dir", defaultDir, "Experiment directory")
+	fs.Parse(args)
+
+	exps, err := backtest.ListExperiments(*dir)
+	if err != nil {
+		log.Fatalf("Failed to list experiments: %v", err)
+	}
+	fmt.Printf("%-24s %-17s %-20s %7s %12s\n", "ID", "Created", "Dataset", "Trades", "Profit")
+	fmt.Println(strings.Repeat("-", 84))
+	for _, e := range exps {
+		fmt.Printf("%-24s %-17s %-20s %7d %12s\n",
+			e.ID, e.Created.Local().Format("2006-01-02 15:04"), e.Dataset, len(e.Result.Trades), money(e.Result.TotalProfit))
+	}
+}
+
+func diff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	dir := fs.String("dir", defaultDir, "Experiment directory")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		usage()
+	}
+
+	a, err := backtest.LoadExperiment(*dir, fs.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	b, err := backtest.LoadExperiment(*dir, fs.Arg(1))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("A: %s  %s on %s\n", a.ID, a.Strategy, a.Dataset)
+	fmt.Printf("B: %s  %s on %s\n", b.ID, b.Strategy, b.Dataset)
+	if a.Dataset != b.Dataset {
+		fmt.Println("⚠️  The experiments ran on different datasets")
+	}
+	for _, c := range paramChanges(a.Params, b.Params) {
+		fmt.Printf("   %s\n", c)
+	}
+
+	d := backtest.Diff(a.Result, b.Result)
+	fmt.Printf("\nProfit: A %s, B %s, delta %s\n", money(a.Result.TotalProfit), money(b.Result.TotalProfit), money(d.Delta))
+	fmt.Printf("Days: %d diverged, %d identical\n", len(d.Days), d.Identical)
+	fmt.Printf("Attribution: added %s, dropped %s, changed %s\n",
+		money(d.Attribution.Added), money(d.Attribution.Dropped), money(d.Attribution.Changed))
+	if len(d.Days) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%-12s %-6s %8s %8s %10s %10s %10s\n", "Date", "City", "Trades A", "Trades B", "P&L A", "P&L B", "Delta")
+	fmt.Println(strings.Repeat("-", 70))
+	for _, day := range d.Days {
+		fmt.Printf("%-12s %-6s %8d %8d %10s %10s %10s\n",
+			day.Date, day.City, len(day.A), len(day.B), money(day.ProfitA), money(day.ProfitB), money(day.Delta()))
+		for _, line := range tradeChanges(day) {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+}
+
+// paramChanges lists the top-level config fields that differ between two
+// experiments
+func paramChanges(a, b json.RawMessage) []string {
+	var pa, pb map[string]json.RawMessage
+	if json.Unmarshal(a, &pa) != nil || json.Unmarshal(b, &pb) != nil {
+		return nil
+	}
+	keys := make(map[string]bool)
+	for k := range pa {
+		keys[k] = true
+	}
+	for k := range pb {
+		keys[k] = true
+	}
+
+	var changes []string
+	for k := range keys {
+		if string(pa[k]) != string(pb[k]) {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", k, orNone(pa[k]), orNone(pb[k])))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+func orNone(v json.RawMessage) string {
+	if v == nil {
+		return "(none)"
+	}
+	return string(v)
+}
+
+// tradeChanges describes a day's positions that only one run held (-/+) or
+// that both held with different fills (~)
+func tradeChanges(day backtest.DayDiff) []string {
+	type position struct{ ticker, side string }
+	fills := func(trades []backtest.Trade) map[position]string {
+		m := make(map[position]string)
+		for _, t := range trades {
+			p := position{t.Ticker, t.Side}
+			if m[p] != "" {
+				m[p] += ", "
+			}
+			m[p] += fmt.Sprintf("%d@%d¢→%d¢ %s", t.Quantity, t.Price, t.ExitPrice, money(t.Profit))
+		}
+		return m
+	}
+	fa, fb := fills(day.A), fills(day.B)
+
+	var lines []string
+	for p, a := range fa {
+		switch b, ok := fb[p]; {
+		case !ok:
+			lines = append(lines, fmt.Sprintf("- %s %s %s", p.ticker, p.side, a))
+		case a != b:
+			lines = append(lines, fmt.Sprintf("~ %s %s %s  ⇒  %s", p.ticker, p.side, a, b))
+		}
+	}
+	for p, b := range fb {
+		if _, ok := fa[p]; !ok {
+			lines = append(lines, fmt.Sprintf("+ %s %s %s", p.ticker, p.side, b))
+		}
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i][2:] < lines[j][2:] })
+	return lines
+}
+
+// apply sets config fields from Key=Value overrides. Values are parsed as
+// JSON, falling back to a plain string
+func apply(cfg any, set map[string]string) error {
+	if len(set) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	for key, value := range set {
+		field := ""
+		for f := range fields {
+			if strings.EqualFold(f, key) {
+				field = f
+			}
+		}
+		if field == "" {
+			return fmt.Errorf("unknown config field %q", key)
+		}
+		raw := json.RawMessage(value)
+		if !json.Valid(raw) {
+			raw, _ = json.Marshal(value)
+		}
+		fields[field] = raw
+	}
+
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, cfg)
+}
+
+func strategyNames() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func loadDataset(path string) (*backtest.Dataset, string, error) {
+	if path == "" {
+		return nil, "", errors.New("no dataset: pass -data with history exported by cmd/backtest-fixtures (the bundled synthetic set is for tests only)")
+	}
+	ds, err := backtest.Load(path)
+	return ds, path, err
+}
+
+// addBooks attaches the recorded book snapshots at path ("" = none) to ds
+func addBooks(ds *backtest.Dataset, path string) {
+	if path == "" {
+		return
+	}
+	snapshots, err := backtest.LoadBookSnapshots(path)
+	if err != nil {
+		log.Fatalf("Failed to load book snapshots: %v", err)
+	}
+	fmt.Printf("%d of %d book snapshots matched the dataset\n", ds.AddBooks(snapshots), len(snapshots))
+}
+
+// winCI formats the 95% bootstrap interval of a result's win rate
+func winCI(r *backtest.Result) string {
+	iv := r.Bootstrap(stats.DefaultBootstrapConfig()).WinRate
+	return fmt.Sprintf("%.0f-%.0f%%", iv.Lo, iv.Hi)
+}
+
+func money(v float64) string {
+	if v < 0 {
+		return fmt.Sprintf("-$%.2f", -v)
+	}
+	return fmt.Sprintf("$%.2f", v)
+}
@@ -0,0 +1,494 @@
+// Package main exports historical market days into the pkg/backtest dataset
+// format, or generates the deterministic synthetic fixture bundled with the
+// repository. Fetched history is cached in a SQLite database (-cache), so
+// re-exporting a range only downloads days not seen before; -refresh
+// refetches everything. -discover also looks for each day under the series
+// the exchange lists for the city today, so a range spanning a series
+// rename is exported whole.
+//
+// Usage:
+//
+//	go run ./cmd/backtest-fixtures -cities LAX,NYC -start 2025-08-01 -end 2025-11-30 -out data/lax_nyc.json.gz
+//	go run ./cmd/backtest-fixtures -cities LAX -start 2025-08-01 -end 2025-11-30 -refresh -out data/lax.json.gz
+//	go run ./cmd/backtest-fixtures -cities DEN,CHI -low -start 2025-11-01 -end 2025-11-30 -out data/den_chi_low.json.gz
+//	go run ./cmd/backtest-fixtures -cities LAX -discover -start 2025-01-01 -end 2025-11-30 -out data/lax.json.gz
+//	go run ./cmd/backtest-fixtures -cities LAX,NYC,CHI,MIA -workers 16 -start 2025-01-01 -end 2025-11-30 -out data/four.json.gz
+//	go run ./cmd/backtest-fixtures -synthetic -out pkg/backtest/fixtures/lax_nyc.json.gz
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/datastore"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+var client = rest.NewPublic(
+	rest.WithHTTPClient(&http.Client{Timeout: 15 * time.Second}),
+	rest.WithRateLimit(rest.DefaultRateLimits()),
+)
+
+// Temperature series listed on the exchange (nil = the registry's only)
+var catalog *market.Catalog
+
+func main() {
+	cities := flag.String("cities", "LAX,NYC", "Comma-separated station codes")
+	start := flag.String("start", "2025-08-01", "First date (YYYY-MM-DD)")
+	end := flag.String("end", "2025-11-30", "Last date (YYYY-MM-DD)")
+	out := flag.String("out", "lax_nyc.json.gz", "Output file (.json or .json.gz)")
+	synthetic := flag.Bool("synthetic", false, "Generate synthetic days instead of fetching history")
+	seed := flag.Uint64("seed", 1, "Random seed for -synthetic")
+	cache := flag.String("cache", "data/history.db", "SQLite cache of fetched history")
+	refresh := flag.Bool("refresh", false, "Refetch history even if cached")
+	low := flag.Bool("low", false, "Also export the cities' LOW (KXLOWT*) events")
+	workers := flag.Int("workers", backtest.DefaultFetchWorkers, "Days fetched at once (the rate limiter paces them)")
+	discover := flag.Bool("discover", false, "Also look for days under the series the exchange lists for the cities, e.g. after a rename")
+	flag.Parse()
+
+	from, err := time.Parse("2006-01-02", *start)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -start: %v\n", err)
+		os.Exit(1)
+	}
+	to, err := time.Parse("2006-01-02", *end)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -end: %v\n", err)
+		os.Exit(1)
+	}
+
+	var stations []*weather.Station
+	for _, code := range strings.Split(*cities, ",") {
+		station := weather.StationByCode(strings.ToUpper(strings.TrimSpace(code)))
+		if station == nil {
+			fmt.Fprintf(os.Stderr, "Unknown station %q\n", code)
+			os.Exit(1)
+		}
+		stations = append(stations, station)
+	}
+
+	var ds *backtest.Dataset
+	if *synthetic {
+		ds = generateSynthetic(stations, from, to, *seed)
+	} else {
+		store, err := datastore.Open(*cache)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open cache: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+		store.Refresh = *refresh
+		if *discover {
+			cat, err := market.Discover(client)
+			if cat == nil {
+				fmt.Fprintf(os.Stderr, "Series discovery failed: %v\n", err)
+				os.Exit(1)
+			}
+			if err != nil {
+				fmt.Printf("Series discovery incomplete: %v\n", err)
+			}
+			catalog = cat
+		}
+		types := []weather.MarketType{weather.MarketTypeHigh}
+		if *low {
+			types = append(types, weather.MarketTypeLow)
+		}
+		ds = exportHistory(store, stations, types, from, to, *workers)
+	}
+	ds.Sort()
+
+	if err := ds.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Dataset failed validation: %v\n", err)
+		os.Exit(1)
+	}
+	if err := ds.Save(*out); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", *out, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %d days (%s) to %s\n", len(ds.Days), ds.Source, *out)
+}
+
+// ============================================================================
+// Historical export (Kalshi public market data + IEM METAR archive)
+// ============================================================================
+
+// exportHistory exports every station's days from from to to, workers at a
+// time, in station and date order
+func exportHistory(store *datastore.Store, stations []*weather.Station, types []weather.MarketType, from, to time.Time, workers int) *backtest.Dataset {
+	ds := &backtest.Dataset{
+		Source:      "kalshi+iem",
+		Description: "Kalshi settlements and trade prints with IEM hourly METAR and NWS forecast discussions",
+	}
+	observations := store.Weather(weather.ASOS)
+
+	var jobs []exportJob
+	for _, station := range stations {
+		loc := station.Location()
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			date := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+			for _, marketType := range types {
+				jobs = append(jobs, exportJob{station, marketType, date})
+			}
+		}
+	}
+
+	cfg := backtest.FetchConfig{Workers: workers}
+	days, errs := backtest.Fetch(context.Background(), jobs, cfg, func(ctx context.Context, j exportJob) (*backtest.Day, error) {
+		return exportDay(ctx, store, observations, j.station, j.marketType, j.date)
+	})
+	for i, j := range jobs {
+		if errs[i] != nil {
+			fmt.Printf("  %s %s %s: skipped (%v)\n", j.station.ID, j.marketType, j.date.Format("2006-01-02"), errs[i])
+			continue
+		}
+		ds.Days = append(ds.Days, *days[i])
+		fmt.Printf("  %s %s %s: settled %d°\n", j.station.ID, j.marketType, days[i].Date, days[i].Settlement)
+	}
+
+	return ds
+}
+
+// exportJob is one station-day of one market type to export
+type exportJob struct {
+	station    *weather.Station
+	marketType weather.MarketType
+	date       time.Time
+}
+
+func exportDay(ctx context.Context, store *datastore.Store, observations weather.Provider, station *weather.Station, marketType weather.MarketType, date time.Time) (*backtest.Day, error) {
+	var series, eventTicker string
+	var markets []rest.Market
+	for _, s := range seriesTickers(station, marketType) {
+		series, eventTicker = s, strings.ToUpper(s+"-"+date.Format("06Jan02"))
+		var err error
+		markets, err = store.Markets(ctx, client, eventTicker)
+		if err != nil {
+			return nil, err
+		}
+		if len(markets) > 0 {
+			break
+		}
+	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("no markets for %s", eventTicker)
+	}
+
+	metar, err := weather.DailyMax(ctx, observations, station, date)
+	if err != nil {
+		return nil, err
+	}
+
+	day := &backtest.Day{
+		City:        stationCode(station),
+		Series:      series,
+		EventTicker: eventTicker,
+		Date:        date.Format("2006-01-02"),
+		Timezone:    station.Timezone,
+	}
+	for _, o := range metar.Observations {
+		day.METAR = append(day.METAR, backtest.Observation{Time: o.Time, TempF: o.Temp})
+	}
+	// The forecaster's discussion is context only; a day without one is kept
+	if discussions, err := store.Discussions(ctx, station, date); err == nil {
+		for _, d := range discussions {
+			day.Discussions = append(day.Discussions, backtest.Discussion{Issued: d.Issued, Text: d.Text})
+		}
+	}
+
+	dayEnd := station.LocalDay(date).AddDate(0, 0, 1)
+	for _, m := range markets {
+		// The open bounds match backtest.OpenFloor and backtest.OpenCap
+		strike, err := market.MarketStrike(m)
+		if err != nil {
+			return nil, err
+		}
+		b := backtest.Bracket{
+			Ticker: m.Ticker,
+			Floor:  strike.Floor,
+			Cap:    strike.Cap,
+			Result: m.Result,
+		}
+		b.Ticks = tradeTicks(ctx, store, m)
+		if len(b.Ticks) > 0 {
+			b.FirstYesPrice = b.Ticks[0].YesPrice
+		}
+		// A market that stopped trading during the day expired early
+		if closed, err := time.Parse(time.RFC3339, m.CloseTime); err == nil && closed.Before(dayEnd) {
+			b.DeterminedAt = closed
+		}
+		day.Brackets = append(day.Brackets, b)
+
+		if m.Result == "yes" {
+			if v, err := strconv.ParseFloat(m.ExpirationValue, 64); err == nil {
+				day.Settlement = int(math.Round(v))
+			} else if b.Floor != backtest.OpenFloor {
+				day.Settlement = b.Floor
+			} else {
+				day.Settlement = b.Cap
+			}
+		}
+	}
+	sortBrackets(day.Brackets)
+
+	if day.Winner() == nil {
+		return nil, fmt.Errorf("%s not settled", eventTicker)
+	}
+	return day, nil
+}
+
+// seriesTickers returns the series a station's events of the market type
+// may be listed under: the registry's, then with -discover the others the
+// exchange lists for the city
+func seriesTickers(station *weather.Station, marketType weather.MarketType) []string {
+	tickers := []string{station.SeriesTicker(marketType)}
+	if catalog == nil {
+		return tickers
+	}
+	code := stationCode(station)
+	for _, s := range catalog.Series {
+		if s.Code == code && s.Type == marketType && s.Ticker != tickers[0] {
+			tickers = append(tickers, s.Ticker)
+		}
+	}
+	return tickers
+}
+
+// tradeTicks returns every trade print of a market in time order
+func tradeTicks(ctx context.Context, store *datastore.Store, m rest.Market) []backtest.Tick {
+	trades, err := store.Trades(ctx, client, m)
+	if err != nil {
+		return nil
+	}
+	ticks := make([]backtest.Tick, 0, len(trades))
+	for _, t := range trades {
+		ticks = append(ticks, backtest.Tick{Time: t.CreatedTime, YesPrice: t.YesPrice, Count: t.Count})
+	}
+	sort.SliceStable(ticks, func(i, j int) bool { return ticks[i].Time.Before(ticks[j].Time) })
+	return ticks
+}
+
+// ============================================================================
+// Synthetic generation
+// ============================================================================
+
+// Per-city weather parameters for the synthetic generator
+type climate struct {
+	anomalySD  float64 // Day-to-day standard deviation of the high vs climatology
+	diurnal    float64 // Typical high minus overnight low
+	peakHour   float64 // Local hour of the daily maximum
+	forecastSD float64 // Market's error on the eventual high
+}
+
+var climates = map[string]climate{
+	"LAX": {anomalySD: 4, diurnal: 12, peakHour: 13, forecastSD: 1.2},
+	"NYC": {anomalySD: 7, diurnal: 14, peakHour: 15, forecastSD: 1.6},
+}
+
+var defaultClimate = climate{anomalySD: 6, diurnal: 15, peakHour: 15, forecastSD: 2.5}
+
+// CLI - METAR calibration distribution observed on LAX (results/deep_analysis_results.txt)
+var calibration = []struct {
+	offset int
+	prob   float64
+}{
+	{-1, 0.29}, {0, 0.53}, {1, 0.06}, {2, 0.12},
+}
+
+func generateSynthetic(stations []*weather.Station, from, to time.Time, seed uint64) *backtest.Dataset {
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	// Ticks draw from their own stream so the days themselves do not depend
+	// on the tick model,
+	tickRNG := rand.New(rand.NewPCG(seed+1, seed^0x6a09e667f3bcc909))
+	// and print sizes from theirs, so the prices do not depend on them
+	volumeRNG := rand.New(rand.NewPCG(seed+2, seed^0xbb67ae8584caa73b))
+	ds := &backtest.Dataset{
+		Source: "synthetic",
+		Description: fmt.Sprintf("Synthetic days (seed %d) drawn from station climatology, "+
+			"an AR(1) anomaly, whole-degree-Celsius METAR rounding and the LAX CLI-METAR "+
+			"calibration distribution, with hourly ticks converging on the METAR max. "+
+			"Not market history.", seed),
+	}
+
+	for _, station := range stations {
+		c, ok := climates[stationCode(station)]
+		if !ok {
+			c = defaultClimate
+		}
+		loc := station.Location()
+		anomaly := 0.0
+
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			date := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+			anomaly = 0.6*anomaly + rng.NormFloat64()*c.anomalySD*0.8
+			trueHigh := station.ClimatologyHigh(date.Month()) + anomaly
+			ds.Days = append(ds.Days, syntheticDay(rng, tickRNG, volumeRNG, station, c, date, trueHigh))
+		}
+	}
+
+	return ds
+}
+
+func syntheticDay(rng, tickRNG, volumeRNG *rand.Rand, station *weather.Station, c climate, date time.Time, trueHigh float64) backtest.Day {
+	day := backtest.Day{
+		City:        stationCode(station),
+		Series:      station.EventPrefix,
+		EventTicker: station.EventPrefix + "-" + strings.ToUpper(date.Format("06Jan02")),
+		Date:        date.Format("2006-01-02"),
+		Timezone:    station.Timezone,
+	}
+
+	// Hourly routine METARs at :53, reported in whole degrees Celsius
+	for h := 0; h < 24; h++ {
+		shape := math.Exp(-math.Pow((float64(h)-c.peakHour)/5, 2))
+		tempF := trueHigh - c.diurnal*(1-shape) + rng.NormFloat64()*0.8
+		tempC := math.Round((tempF - 32) * 5 / 9)
+		day.METAR = append(day.METAR, backtest.Observation{
+			Time:  date.Add(time.Duration(h)*time.Hour + 53*time.Minute),
+			TempF: math.Round((tempC*9/5+32)*10) / 10,
+		})
+	}
+
+	// Official high = METAR max + calibration offset
+	u := rng.Float64()
+	offset := calibration[len(calibration)-1].offset
+	for _, cal := range calibration {
+		if u < cal.prob {
+			offset = cal.offset
+			break
+		}
+		u -= cal.prob
+	}
+	day.Settlement = day.METARMax() + offset
+
+	// Brackets centered on the market's (noisy) expectation of the high
+	mean := float64(day.Settlement) + rng.NormFloat64()*c.forecastSD
+	center := int(math.Round(mean))
+	lo := center - 4
+
+	day.Brackets = append(day.Brackets, backtest.Bracket{
+		Ticker: fmt.Sprintf("%s-T%d", day.EventTicker, lo),
+		Floor:  backtest.OpenFloor,
+		Cap:    lo - 1,
+	})
+	for i := 0; i < 4; i++ {
+		floor := lo + 2*i
+		day.Brackets = append(day.Brackets, backtest.Bracket{
+			Ticker: fmt.Sprintf("%s-B%.1f", day.EventTicker, float64(floor)+0.5),
+			Floor:  floor,
+			Cap:    floor + 1,
+		})
+	}
+	day.Brackets = append(day.Brackets, backtest.Bracket{
+		Ticker: fmt.Sprintf("%s-T%d", day.EventTicker, lo+7),
+		Floor:  lo + 8,
+		Cap:    backtest.OpenCap,
+	})
+
+	// First trade prices from the market's normal belief, plus noise
+	sd := c.forecastSD + 0.3
+	for i := range day.Brackets {
+		b := &day.Brackets[i]
+		lower, upper := math.Inf(-1), math.Inf(1)
+		if b.Floor != backtest.OpenFloor {
+			lower = float64(b.Floor) - 0.5
+		}
+		if b.Cap != backtest.OpenCap {
+			upper = float64(b.Cap) + 0.5
+		}
+		p := normalCDF((upper-mean)/sd) - normalCDF((lower-mean)/sd)
+		price := int(math.Round(100*p + rng.NormFloat64()*3))
+		b.FirstYesPrice = min(max(price, 1), 99)
+
+		b.Result = "no"
+		if b.Contains(day.Settlement) {
+			b.Result = "yes"
+		}
+	}
+
+	syntheticTicks(tickRNG, volumeRNG, &day, c, date, mean, sd)
+	return day
+}
+
+// syntheticTicks adds hourly trade prints whose belief narrows from the
+// opening forecast onto the METAR max as the afternoon peak passes. The
+// market cannot see the CLI report until the next morning, so the belief
+// keeps the width of the CLI - METAR calibration distribution. Print sizes
+// are largest for brackets priced near 50¢, whose outcome is most in doubt.
+func syntheticTicks(rng, volumeRNG *rand.Rand, day *backtest.Day, c climate, date time.Time, mean, sd float64) {
+	const firstHour, lastHour = 6, 22
+	const calibrationSD = 0.9
+	settledBy := c.peakHour + 2
+	metarMax := float64(day.METARMax())
+
+	for i := range day.Brackets {
+		b := &day.Brackets[i]
+		b.Ticks = []backtest.Tick{{
+			Time:     date.Add(firstHour*time.Hour + time.Duration(rng.IntN(60))*time.Minute),
+			YesPrice: b.FirstYesPrice,
+			Count:    printSize(volumeRNG, b.FirstYesPrice),
+		}}
+	}
+
+	for h := firstHour + 1; h <= lastHour; h++ {
+		progress := min(max((float64(h)-firstHour)/(settledBy-firstHour), 0), 1)
+		belief := mean + (metarMax-mean)*progress + rng.NormFloat64()*0.5*(1-progress)
+		spread := sd*(1-progress) + calibrationSD*progress
+		at := date.Add(time.Duration(h)*time.Hour + time.Duration(rng.IntN(60))*time.Minute)
+
+		for i := range day.Brackets {
+			b := &day.Brackets[i]
+			lower, upper := math.Inf(-1), math.Inf(1)
+			if b.Floor != backtest.OpenFloor {
+				lower = float64(b.Floor) - 0.5
+			}
+			if b.Cap != backtest.OpenCap {
+				upper = float64(b.Cap) + 0.5
+			}
+			p := normalCDF((upper-belief)/spread) - normalCDF((lower-belief)/spread)
+			price := min(max(int(math.Round(100*p+rng.NormFloat64()*2)), 1), 99)
+			if price != b.Ticks[len(b.Ticks)-1].YesPrice {
+				b.Ticks = append(b.Ticks, backtest.Tick{Time: at, YesPrice: price, Count: printSize(volumeRNG, price)})
+			}
+		}
+	}
+}
+
+// printSize draws the contracts traded by a synthetic print at price
+func printSize(rng *rand.Rand, price int) int {
+	p := float64(price) / 100
+	return 1 + rng.IntN(10+int(400*p*(1-p)))
+}
+
+func normalCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+// stationCode returns the registry key of a station (e.g. "NYC" for KJFK)
+func stationCode(station *weather.Station) string {
+	for code, s := range weather.Stations {
+		if s == station {
+			return code
+		}
+	}
+	return strings.TrimPrefix(station.ID, "K")
+}
+
+func sortBrackets(brackets []backtest.Bracket) {
+	for i := 1; i < len(brackets); i++ {
+		for j := i; j > 0 && brackets[j].Floor < brackets[j-1].Floor; j-- {
+			brackets[j], brackets[j-1] = brackets[j-1], brackets[j]
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Package main verifies the "crossed threshold means locked" rule against
+// history. For each station it counts the days the running METAR max passed a
+// bracket's cap by the margin and how often a passed bracket still settled
+// YES (the official CLI high can differ from the METAR max), and reports the
+// resulting lock probability. It then backtests the threshold strategy
+// assuming certainty and using the verified probabilities.
+//
+// Usage:
+//
+//	go run ./cmd/backtest-lockin -data data/lax_nyc.json.gz
+//	go run ./cmd/backtest-lockin -data data/lax_nyc.json.gz -margin 1
+//	go run ./cmd/backtest-lockin -data data/lax_nyc.json.gz -export results/lockin -format csv,json
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/brendanplayford/kalshi-go/examples/threshold"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+)
+
+func main() {
+	def := threshold.DefaultConfig()
+	data := flag.String("data", "", "Dataset file exported by cmd/backtest-fixtures")
+	margin := flag.Int("margin", def.Margin, "Degrees the METAR max must exceed a bracket's cap")
+	export := flag.String("export", "", "Directory to export both runs' trades, days and summary to (default: none)")
+	format := flag.String("format", "csv", "Export formats: csv, json or both")
+	runs := flag.String("runs", backtest.DefaultRunsDir, "Record both runs in this registry (\"\" = don't)")
+	flag.Parse()
+
+	formats, err := backtest.ParseExportFormats(*format)
+	if err != nil {
+		log.Fatalf("Invalid -format: %v", err)
+	}
+
+	ds, err := loadDataset(*data)
+	if err != nil {
+		log.Fatalf("Failed to load dataset: %v", err)
+	}
+	dataset := *data
+	from, to := ds.Span()
+
+	lockIns := backtest.VerifyLockIns(ds, *margin)
+
+	fmt.Printf("Lock-in verification: %d days, margin %d°F\n\n", len(ds.Days), *margin)
+	fmt.Printf("%-6s %6s %10s %9s %12s  %s\n", "City", "Days", "Crossings", "Failures", "P(locked)", "Failed")
+	fmt.Println(strings.Repeat("-", 70))
+	for _, l := range lockIns {
+		prob := "-"
+		if l.Crossings > 0 {
+			prob = fmt.Sprintf("%.1f%%", l.Probability()*100)
+		}
+		fmt.Printf("%-6s %6d %10d %9d %12s  %s\n",
+			l.City, l.Days, l.Crossings, l.Failures, prob, dates(l.Failed, 5))
+	}
+
+	certain := def
+	certain.Margin = *margin
+	verified := certain
+	verified.LockProbability = backtest.LockProbabilities(lockIns)
+
+	fmt.Printf("\n%-24s %8s %8s %9s %12s\n", "Threshold strategy", "Trades", "Win", "Win CI", "Profit")
+	fmt.Println(strings.Repeat("-", 66))
+	var baselines []*backtest.Result // The same for both runs
+	var records backtest.Records
+	var recorded []string
+	for _, run := range []struct {
+		name string
+		cfg  threshold.Config
+	}{
+		{"Assume certainty", certain},
+		{"Verified probability", verified},
+	} {
+		btCfg := backtest.DefaultConfig()
+		r := backtest.Run(ds, threshold.New(run.cfg), btCfg)
+		fmt.Printf("%-24s %8d %7.1f%% %9s %12s\n", run.name, len(r.Trades), r.WinRate, winCI(r), money(r.TotalProfit))
+		baselines = r.Baselines
+		records.Add(r.Records(run.name))
+
+		if *runs != "" {
+			rec, err := backtest.NewRunRecord("backtest-lockin", "Threshold", run.cfg, dataset, from, to, btCfg.Seed)
+			if err != nil {
+				log.Fatalf("Failed to record run: %v", err)
+			}
+			rec.AddResult(r)
+			if _, err := rec.Save(*runs); err != nil {
+				log.Fatalf("Failed to save run: %v", err)
+			}
+			recorded = append(recorded, rec.ID)
+		}
+	}
+	for _, b := range baselines {
+		fmt.Printf("%-24s %8d %7.1f%% %9s %12s\n", strings.TrimPrefix(b.Strategy, "Baseline: "), len(b.Trades), b.WinRate, winCI(b), money(b.TotalProfit))
+	}
+
+	if len(recorded) > 0 {
+		fmt.Printf("\nRecorded runs %s\n", strings.Join(recorded, ", "))
+	}
+
+	if *export != "" {
+		paths, err := records.Export(*export, formats...)
+		if err != nil {
+			log.Fatalf("Failed to export results: %v", err)
+		}
+		fmt.Printf("\nExported %s\n", strings.Join(paths, ", "))
+	}
+}
+
+func loadDataset(path string) (*backtest.Dataset, error) {
+	if path == "" {
+		return nil, errors.New("no dataset: pass -data with history exported by cmd/backtest-fixtures (the bundled synthetic set is for tests only)")
+	}
+	return backtest.Load(path)
+}
+
+// dates lists up to n dates, noting how many more there are
+func dates(ds []string, n int) string {
+	if len(ds) <= n {
+		return strings.Join(ds, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(ds[:n], ", "), len(ds)-n)
+}
+
+// winCI formats the 95% bootstrap interval of a result's win rate
+func winCI(r *backtest.Result) string {
+	iv := r.Bootstrap(stats.DefaultBootstrapConfig()).WinRate
+	return fmt.Sprintf("%.0f-%.0f%%", iv.Lo, iv.Hi)
+}
+
+func money(v float64) string {
+	if v < 0 {
+		return fmt.Sprintf("-$%.2f", -v)
+	}
+	return fmt.Sprintf("$%.2f", v)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/cmd/dualside-bot/production/engine"
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+)
+
+// dashboard is everything the page shows (also written as data.json)
+type dashboard struct {
+	Title       string              `json:"title"`
+	GeneratedAt time.Time           `json:"generated_at"`
+	Summary     summary             `json:"summary"`
+	Days        []dayRow            `json:"days"`
+	Calibration []calibrationBucket `json:"calibration"`
+	Positions   []position          `json:"positions"`
+	Chart       string              `json:"-"` // SVG polyline points of cumulative P&L
+}
+
+type summary struct {
+	Days        int     `json:"days"`
+	Trades      int     `json:"trades"`
+	Wins        int     `json:"wins"`
+	WinRate     float64 `json:"win_rate"`
+	TotalPnL    float64 `json:"total_pnl"`
+	AvgDailyPnL float64 `json:"avg_daily_pnl"`
+	Sharpe      float64 `json:"sharpe"` // Annualized over 365 trading days
+	MaxDrawdown float64 `json:"max_drawdown"`
+}
+
+type dayRow struct {
+	Date       string   `json:"date"`
+	Trades     int      `json:"trades"`
+	Wins       int      `json:"wins"`
+	PnL        float64  `json:"pnl"`
+	Cumulative float64  `json:"cumulative"`
+	Notes      []string `json:"notes,omitempty"`
+}
+
+// calibrationBucket compares the price paid (the market's implied
+// probability of the side bought) with how often that side won
+type calibrationBucket struct {
+	Range    string  `json:"range"` // e.g. "50-59¢"
+	Trades   int     `json:"trades"`
+	Implied  float64 `json:"implied"`  // Mean price / 100
+	Realized float64 `json:"realized"` // Win rate
+}
+
+type position struct {
+	Opened   time.Time `json:"opened"`
+	City     string    `json:"city"`
+	Bracket  string    `json:"bracket"`
+	Side     string    `json:"side"`
+	Price    int       `json:"price"`
+	Quantity int       `json:"quantity"`
+	Cost     float64   `json:"cost"`
+}
+
+// build summarizes day reports (oldest first) and open positions
+func build(title string, reports []engine.DayReport, open []engine.Trade, now time.Time) *dashboard {
+	d := &dashboard{Title: title, GeneratedAt: now}
+
+	var daily []float64
+	var priceSum [10]float64
+	var bucketTrades, bucketWins [10]int
+	cum := 0.0
+	for _, r := range reports {
+		row := dayRow{Date: r.Date, Trades: len(r.Trades), PnL: r.PnL}
+		for _, t := range r.Trades {
+			won := t.Profit > 0
+			if won {
+				row.Wins++
+			}
+			b := min(max(t.Price, 0)/10, 9)
+			bucketTrades[b]++
+			priceSum[b] += float64(t.Price)
+			if won {
+				bucketWins[b]++
+			}
+		}
+		for _, n := range r.Notes {
+			row.Notes = append(row.Notes, n.Text)
+		}
+		cum += r.PnL
+		row.Cumulative = cum
+
+		d.Days = append(d.Days, row)
+		daily = append(daily, r.PnL)
+		d.Summary.Trades += row.Trades
+		d.Summary.Wins += row.Wins
+	}
+
+	d.Summary.Days = len(reports)
+	d.Summary.TotalPnL = cum
+	d.Summary.AvgDailyPnL = stats.Mean(daily)
+	d.Summary.Sharpe = stats.Sharpe(daily, 365)
+	d.Summary.MaxDrawdown = stats.MaxDrawdown(daily)
+	if d.Summary.Trades > 0 {
+		d.Summary.WinRate = float64(d.Summary.Wins) / float64(d.Summary.Trades)
+	}
+
+	for b := range bucketTrades {
+		if bucketTrades[b] == 0 {
+			continue
+		}
+		d.Calibration = append(d.Calibration, calibrationBucket{
+			Range:    fmt.Sprintf("%d-%d¢", b*10, b*10+9),
+			Trades:   bucketTrades[b],
+			Implied:  priceSum[b] / float64(bucketTrades[b]) / 100,
+			Realized: float64(bucketWins[b]) / float64(bucketTrades[b]),
+		})
+	}
+
+	for _, t := range open {
+		d.Positions = append(d.Positions, position{
+			Opened:   t.Timestamp,
+			City:     t.City,
+			Bracket:  t.Bracket,
+			Side:     strings.ToUpper(t.Side),
+			Price:    t.Price,
+			Quantity: t.Quantity,
+			Cost:     t.Cost,
+		})
+	}
+
+	d.Chart = chartPoints(d.Days, 600, 200)
+	return d
+}
+
+// chartPoints scales cumulative P&L into SVG polyline points for a
+// width x height viewBox
+func chartPoints(days []dayRow, width, height float64) string {
+	if len(days) == 0 {
+		return ""
+	}
+	lo, hi := 0.0, 0.0
+	for _, d := range days {
+		lo, hi = min(lo, d.Cumulative), max(hi, d.Cumulative)
+	}
+	if hi == lo {
+		hi = lo + 1
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "0,%.1f", height-(0-lo)/(hi-lo)*height)
+	for i, d := range days {
+		x := float64(i+1) / float64(len(days)) * width
+		y := height - (d.Cumulative-lo)/(hi-lo)*height
+		fmt.Fprintf(&b, " %.1f,%.1f", x, y)
+	}
+	return b.String()
+}
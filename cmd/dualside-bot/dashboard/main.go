@@ -0,0 +1,127 @@
+// Package main renders the production bot's recent performance, price
+// calibration and open positions into a static HTML bundle (index.html plus
+// data.json) that can be published to S3 or GitHub Pages. It reads the day
+// reports the bot saves to $DATA_DIR/reports.jsonl and, optionally, the
+// read-only /positions endpoint; nothing in the bundle links back to the
+// control API.
+//
+// Usage:
+//
+//	go run ./cmd/dualside-bot/dashboard -data-dir ./data -days 30 -out ./public
+//	go run ./cmd/dualside-bot/dashboard -positions-url http://localhost:8080/positions
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/cmd/dualside-bot/production/engine"
+)
+
+func main() {
+	dataDir := flag.String("data-dir", "./data", "Bot data directory (reads reports.jsonl)")
+	days := flag.Int("days", 30, "Days of history to include")
+	positionsURL := flag.String("positions-url", "", "Bot /positions endpoint for open positions (optional)")
+	out := flag.String("out", "./dashboard", "Output directory")
+	title := flag.String("title", "Dual-Side Bot Performance", "Page title")
+	withNotes := flag.Bool("notes", false, "Include operator journal notes (they may be private)")
+	flag.Parse()
+
+	reports, err := engine.LoadReports(filepath.Join(*dataDir, "reports.jsonl"))
+	if err != nil {
+		log.Fatalf("Failed to load reports: %v", err)
+	}
+	cutoff := time.Now().AddDate(0, 0, -*days).Format("2006-01-02")
+	var recent []engine.DayReport
+	for _, r := range reports {
+		if r.Date > cutoff {
+			if !*withNotes {
+				r.Notes = nil
+			}
+			recent = append(recent, r)
+		}
+	}
+
+	var positions []engine.Trade
+	if *positionsURL != "" {
+		positions, err = fetchPositions(*positionsURL)
+		if err != nil {
+			log.Fatalf("Failed to fetch positions: %v", err)
+		}
+	}
+
+	d := build(*title, recent, positions, time.Now())
+
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+	if err := writeHTML(filepath.Join(*out, "index.html"), d); err != nil {
+		log.Fatalf("Failed to write index.html: %v", err)
+	}
+	if err := writeJSON(filepath.Join(*out, "data.json"), d); err != nil {
+		log.Fatalf("Failed to write data.json: %v", err)
+	}
+
+	fmt.Printf("Wrote %d days, %d open positions to %s\n", len(d.Days), len(d.Positions), *out)
+}
+
+// fetchPositions reads open positions from the bot's read-only endpoint
+func fetchPositions(url string) ([]engine.Trade, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Positions []engine.Trade `json:"positions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return body.Positions, nil
+}
+
+func writeHTML(path string, d *dashboard) error {
+	tmpl, err := template.New("dashboard").Funcs(template.FuncMap{
+		"money": func(v float64) string {
+			if v < 0 {
+				return fmt.Sprintf("-$%.2f", -v)
+			}
+			return fmt.Sprintf("$%.2f", v)
+		},
+		"pct": func(v float64) string { return fmt.Sprintf("%.1f%%", v*100) },
+	}).Parse(pageTemplate)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(f, d); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeJSON(path string, d *dashboard) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
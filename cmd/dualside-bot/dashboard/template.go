@@ -0,0 +1,68 @@
+package main
+
+// pageTemplate is a self-contained page: inline CSS and SVG, no scripts
+const pageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, system-ui, sans-serif; max-width: 960px; margin: 2em auto; padding: 0 1em; color: #222; }
+h1 { margin-bottom: 0; }
+.meta { color: #777; margin-top: .2em; }
+.cards { display: flex; flex-wrap: wrap; gap: 1em; margin: 1.5em 0; }
+.card { border: 1px solid #ddd; border-radius: 6px; padding: .8em 1.2em; min-width: 120px; }
+.card .v { font-size: 1.4em; font-weight: 600; }
+.card .k { color: #777; font-size: .85em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { text-align: right; padding: .35em .6em; border-bottom: 1px solid #eee; }
+th:first-child, td:first-child, td.l { text-align: left; }
+.pos { color: #1a7f37; } .neg { color: #cf222e; }
+.notes { color: #555; font-size: .85em; text-align: left; }
+svg { width: 100%; height: auto; border: 1px solid #eee; margin-bottom: 2em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}} · last {{.Summary.Days}} settled days</p>
+
+<div class="cards">
+<div class="card"><div class="v {{if lt .Summary.TotalPnL 0.0}}neg{{else}}pos{{end}}">{{money .Summary.TotalPnL}}</div><div class="k">Total P&amp;L</div></div>
+<div class="card"><div class="v">{{money .Summary.AvgDailyPnL}}</div><div class="k">Avg daily P&amp;L</div></div>
+<div class="card"><div class="v">{{.Summary.Trades}}</div><div class="k">Trades</div></div>
+<div class="card"><div class="v">{{pct .Summary.WinRate}}</div><div class="k">Win rate</div></div>
+<div class="card"><div class="v">{{printf "%.2f" .Summary.Sharpe}}</div><div class="k">Sharpe (annualized)</div></div>
+<div class="card"><div class="v">{{money .Summary.MaxDrawdown}}</div><div class="k">Max drawdown</div></div>
+</div>
+
+{{if .Chart}}
+<h2>Cumulative P&amp;L</h2>
+<svg viewBox="0 0 600 200" preserveAspectRatio="none"><polyline fill="none" stroke="#0969da" stroke-width="2" points="{{.Chart}}"/></svg>
+{{end}}
+
+<h2>Open Positions</h2>
+{{if .Positions}}
+<table>
+<tr><th>Opened</th><th>City</th><th>Bracket</th><th>Side</th><th>Price</th><th>Qty</th><th>Cost</th></tr>
+{{range .Positions}}<tr><td>{{.Opened.Format "2006-01-02 15:04"}}</td><td>{{.City}}</td><td>{{.Bracket}}</td><td>{{.Side}}</td><td>{{.Price}}¢</td><td>{{.Quantity}}</td><td>{{money .Cost}}</td></tr>
+{{end}}</table>
+{{else}}<p>None.</p>{{end}}
+
+<h2>Calibration</h2>
+<p class="meta">Entry price (the market's implied probability of the side bought) against how often that side won.</p>
+{{if .Calibration}}
+<table>
+<tr><th>Price</th><th>Trades</th><th>Implied</th><th>Realized</th></tr>
+{{range .Calibration}}<tr><td>{{.Range}}</td><td>{{.Trades}}</td><td>{{pct .Implied}}</td><td>{{pct .Realized}}</td></tr>
+{{end}}</table>
+{{else}}<p>No settled trades.</p>{{end}}
+
+<h2>Daily Results</h2>
+<table>
+<tr><th>Date</th><th>Trades</th><th>Wins</th><th>P&amp;L</th><th>Cumulative</th><th class="notes">Notes</th></tr>
+{{range .Days}}<tr><td>{{.Date}}</td><td>{{.Trades}}</td><td>{{.Wins}}</td><td class="{{if lt .PnL 0.0}}neg{{else}}pos{{end}}">{{money .PnL}}</td><td>{{money .Cumulative}}</td><td class="notes">{{range $i, $n := .Notes}}{{if $i}}; {{end}}{{$n}}{{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`
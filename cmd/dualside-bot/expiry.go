@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/notify"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+var (
+	// No entries near a market's close, warnings for positions held into
+	// its thin end-of-day book
+	expiryGuard = strategy.DefaultExpiryGuard()
+	expiryWatch *strategy.ExpiryWatch
+)
+
+func init() {
+	flag.DurationVar(&expiryGuard.NoEntry, "no-entry-before-close", expiryGuard.NoEntry, "Open no positions this close to a market's close (0 = off)")
+	flag.DurationVar(&expiryGuard.ThinBook, "thin-book-warning", expiryGuard.ThinBook, "Warn about positions still held this close to a market's close (0 = off)")
+}
+
+// watchExpiry warns once per held market side as it comes within the
+// thin-book window of its close. Positions are held to settlement, so this
+// only flags them; a late trading window is the usual cause
+func watchExpiry(now time.Time) {
+	if expiryWatch == nil {
+		return
+	}
+	held := make(map[string]int)
+	closes := make(map[string]TradeRecord)
+	for _, trades := range state.OpenPositions {
+		for _, t := range trades {
+			if t.Closes.IsZero() {
+				continue
+			}
+			key := t.Ticker + "/" + t.Side
+			held[key] += t.Quantity
+			closes[key] = t
+		}
+	}
+
+	keys := make([]string, 0, len(held))
+	for key := range held {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		t := closes[key]
+		if !expiryWatch.Check(key, t.Closes, now) {
+			continue
+		}
+		left := t.Closes.Sub(now).Round(time.Minute)
+		marketLog.Warn("Holding into thin book", "city", t.City, "ticker", t.Ticker, "side", t.Side,
+			"contracts", held[key], "closes_in", left.String())
+		msg := notify.Threshold(t.Ticker, "time to close", left.String(), "thin book "+expiryGuard.ThinBook.String())
+		msg.Fields = append(msg.Fields, notify.Field{Name: "Holding", Value: fmt.Sprintf("%d %s", held[key], strings.ToUpper(t.Side))})
+		alert(msg)
+	}
+}
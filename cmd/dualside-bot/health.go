@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/service"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// Health components of the bot
+const (
+	healthMETAR  = "metar"
+	healthKalshi = "kalshi"
+)
+
+var (
+	healthStale time.Duration
+	botHealth   *service.Health // Served with the metrics (nil = off)
+)
+
+func init() {
+	flag.DurationVar(&healthStale, "health-stale", 30*time.Minute, "Report unhealthy on /health after this long without METAR or Kalshi data")
+}
+
+// newHealth tracks the Kalshi API, polled every cycle, and METAR, polled
+// only while a city's trading window is open and critical only then
+func newHealth() *service.Health {
+	h := service.NewHealth()
+	h.Feed(healthKalshi, max(healthStale, 2*pollInterval), true)
+	h.Feed(healthMETAR, max(healthStale, 2*pollInterval), false)
+	return h
+}
+
+// recordTradingWindow reports each city's phase and whether any may enter,
+// making METAR critical while one can
+func recordTradingWindow() {
+	if botHealth == nil {
+		return
+	}
+	open := false
+	phases := make(map[string]strategy.Phase)
+	for _, lc := range lifecycles {
+		for _, p := range lc.Phases() {
+			phases[p.Event] = p.Phase
+			if p.Phase.CanEnter() {
+				open = true
+			}
+		}
+	}
+	botHealth.SetInfo("trading_window", map[string]any{"open": open, "phases": phases})
+	botHealth.SetCritical(healthMETAR, open)
+}
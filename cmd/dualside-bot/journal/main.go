@@ -0,0 +1,152 @@
+// Package main adds and lists operator notes on a running production bot
+// through its control API. Notes attach to a trading day, optionally to a
+// city or a single trade (by order ID), and appear in the bot's settled day
+// reports. Adding a note needs the bot's control token, read from
+// CONTROL_TOKEN or -token.
+//
+// Usage:
+//
+//	go run ./cmd/dualside-bot/journal add -city LAX "marine layer burned off late"
+//	go run ./cmd/dualside-bot/journal add -date 2025-12-05 -order abc123 "also traded manually in UI"
+//	go run ./cmd/dualside-bot/journal list -date 2025-12-05
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// note mirrors the bot's journal entry
+type note struct {
+	ID      int    `json:"id,omitempty"`
+	Date    string `json:"date,omitempty"`
+	City    string `json:"city,omitempty"`
+	OrderID string `json:"order_id,omitempty"`
+	Text    string `json:"text"`
+}
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "add":
+		err = add(os.Args[2:])
+	case "list":
+		err = list(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: journal add [-addr URL] [-token TOKEN] [-date YYYY-MM-DD] [-city CODE] [-order ID] text...")
+	fmt.Fprintln(os.Stderr, "       journal list [-addr URL] [-date YYYY-MM-DD]")
+	os.Exit(2)
+}
+
+func add(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ExitOnError)
+	addr := fs.String("addr", "http://localhost:8080", "Bot control API address")
+	date := fs.String("date", "", "Trading day (default: today)")
+	city := fs.String("city", "", "City code the note is about")
+	orderID := fs.String("order", "", "Order ID of the trade the note is about")
+	token := fs.String("token", os.Getenv("CONTROL_TOKEN"), "Bot control token")
+	fs.Parse(args)
+
+	body, err := json.Marshal(note{
+		Date:    *date,
+		City:    *city,
+		OrderID: *orderID,
+		Text:    strings.Join(fs.Args(), " "),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, *addr+"/control/notes", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+*token)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	var n note
+	if err := decode(resp, &n); err != nil {
+		return err
+	}
+
+	fmt.Printf("Added note %d on %s\n", n.ID, n.Date)
+	return nil
+}
+
+func list(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	addr := fs.String("addr", "http://localhost:8080", "Bot control API address")
+	date := fs.String("date", "", "Trading day (default: all)")
+	fs.Parse(args)
+
+	url := *addr + "/control/notes"
+	if *date != "" {
+		url += "?date=" + *date
+	}
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	var out struct {
+		Notes []note `json:"notes"`
+	}
+	if err := decode(resp, &out); err != nil {
+		return err
+	}
+
+	for _, n := range out.Notes {
+		scope := ""
+		if n.City != "" {
+			scope += " [" + n.City + "]"
+		}
+		if n.OrderID != "" {
+			scope += " (order " + n.OrderID + ")"
+		}
+		fmt.Printf("%3d  %s%s  %s\n", n.ID, n.Date, scope, n.Text)
+	}
+	return nil
+}
+
+// decode reads a control API response into v, surfacing its error message
+func decode(resp *http.Response, v any) error {
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &e) == nil && e.Error != "" {
+			return fmt.Errorf("%s", e.Error)
+		}
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return json.Unmarshal(data, v)
+}
@@ -3,19 +3,24 @@
 package main
 
 import (
-	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"log"
-	"math"
-	"net/http"
+	"log/slog"
+	"os"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/brendanplayford/kalshi-go/internal/config"
+	"github.com/brendanplayford/kalshi-go/internal/crash"
+	"github.com/brendanplayford/kalshi-go/internal/instancelock"
+	"github.com/brendanplayford/kalshi-go/internal/logging"
+	"github.com/brendanplayford/kalshi-go/pkg/notify"
 	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
 // Configuration
@@ -27,6 +32,11 @@ var (
 	maxNoTrades   int
 	minNoPrice    int
 	maxNoPrice    int
+	lockDir       string
+	stateFile     string
+	resetState    bool
+	cancelOnPanic bool
+	logConfig     logging.Config
 )
 
 // Station configuration
@@ -55,6 +65,7 @@ type Market struct {
 	FloorStrike int     `json:"floor_strike"`
 	CapStrike   int     `json:"cap_strike"`
 	Status      string  `json:"status"`
+	CloseTime   string  `json:"close_time"`
 	YesBid      float64 `json:"yes_bid"`
 	YesAsk      float64 `json:"yes_ask"`
 	NoBid       float64 `json:"no_bid"`
@@ -62,10 +73,6 @@ type Market struct {
 	Volume      int     `json:"volume"`
 }
 
-type MarketsResponse struct {
-	Markets []Market `json:"markets"`
-}
-
 // Trade tracking
 type TradeRecord struct {
 	Timestamp   time.Time
@@ -79,10 +86,15 @@ type TradeRecord struct {
 	Quantity    int
 	Cost        float64
 	OrderID     string
+	Status      string    // Order status when last checked ("resting", "executed", ...)
+	Closes      time.Time // Market close time (zero = unknown)
 }
 
+// BotState is saved after every trade and loaded on start, so a restart
+// doesn't enter events already traded
 type BotState struct {
 	StartTime      time.Time
+	Day            string // Date the daily counters are for, in stateLocation
 	YesTrades      int
 	NoTrades       int
 	OpenPositions  map[string][]TradeRecord // EventTicker -> trades
@@ -91,9 +103,12 @@ type BotState struct {
 }
 
 var (
-	client     *rest.Client
-	httpClient = &http.Client{Timeout: 15 * time.Second}
-	state      BotState
+	client *rest.Client
+	state  BotState
+
+	weatherLog = logging.For(logging.Weather)
+	marketLog  = logging.For(logging.Market)
+	execLog    = logging.For(logging.Execution)
 )
 
 func init() {
@@ -104,11 +119,26 @@ func init() {
 	flag.IntVar(&maxNoTrades, "max-no", 3, "Maximum NO trades per event")
 	flag.IntVar(&minNoPrice, "min-no-price", 50, "Minimum NO price to trade (cents)")
 	flag.IntVar(&maxNoPrice, "max-no-price", 90, "Maximum NO price to trade (cents)")
+	flag.StringVar(&lockDir, "lock-dir", "./data", "Directory for the instance lock (share with the production bot's DATA_DIR)")
+	flag.StringVar(&stateFile, "state", "", "State file (default: dualside-state.json in -lock-dir, or dualside-state-dryrun.json for dry runs)")
+	flag.BoolVar(&resetState, "reset", false, "Discard the saved state and start fresh")
+	flag.BoolVar(&cancelOnPanic, "cancel-on-panic", true, "Cancel the bot's resting orders before exiting on a panic")
+	logConfig.RegisterFlags(flag.CommandLine)
 }
 
 func main() {
 	flag.Parse()
 
+	closeLog, err := logging.Setup(logConfig)
+	if err != nil {
+		log.Fatalf("Invalid logging config: %v", err)
+	}
+	defer closeLog()
+
+	if err := loadSettings(); err != nil {
+		log.Fatalf("Invalid settings: %v", err)
+	}
+
 	printBanner()
 
 	// Load configuration
@@ -121,14 +151,64 @@ func main() {
 		log.Fatalf("Invalid config: %v", err)
 	}
 
+	// Refuse to run a second copy against the same account
+	instance, err := instancelock.Acquire(lockDir, cfg.APIKey, "dualside")
+	switch {
+	case errors.Is(err, instancelock.ErrUnsupported):
+		log.Printf("⚠️  %v; not guarding against a second copy", err)
+	case err != nil:
+		log.Fatalf("Another instance is running: %v", err)
+	default:
+		defer instance.Release()
+	}
+
 	// Initialize client
 	client = rest.New(cfg.APIKey, cfg.PrivateKey)
+	setupNotifier()
+	setupMetrics()
+	lifecycleLog := logging.For("lifecycle")
+	for _, lc := range lifecycles {
+		lc.SetLogger(func(format string, args ...any) { lifecycleLog.Info(fmt.Sprintf(format, args...)) })
+	}
+
+	// Resume the saved state, unless asked to start fresh
+	path := statePath()
+	if resetState {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Fatalf("Failed to reset state: %v", err)
+		}
+		slog.Info("Reset state", "path", path)
+	}
+	state, err = loadState(path, time.Now())
+	if err != nil {
+		log.Fatalf("Failed to load state: %v", err)
+	}
+	state.StartTime = time.Now()
+	if len(state.OpenPositions) > 0 {
+		slog.Info("Resumed state", "events", len(state.OpenPositions), "open_orders", openOrders(), "path", path)
+	}
 
-	// Initialize state
-	state = BotState{
-		StartTime:     time.Now(),
-		OpenPositions: make(map[string][]TradeRecord),
+	// A panic saves the state, cancels the bot's resting orders, alerts
+	// with the stack and exits with crash.ExitCode, for the supervisor to
+	// restart the bot from the saved state
+	crashReport := strings.TrimSuffix(path, ".json") + "-crash.json"
+	if prev, err := crash.Previous(crashReport); err != nil {
+		slog.Warn("Failed to read crash report", "err", err)
+	} else if prev != nil {
+		slog.Warn("Restarted after a panic", "goroutine", prev.Goroutine, "at", prev.Time, "panic", prev.Panic)
+		alert(notify.Error("Restart", fmt.Sprintf("Restarted after a panic in %s at %s: %s",
+			prev.Goroutine, prev.Time.Format(time.RFC3339), prev.Panic)))
+	}
+	hooks := crash.Hooks{
+		Flush:  []func() error{func() error { return saveState(path) }},
+		Alert:  alertPanic,
+		Report: crashReport,
+	}
+	if cancelOnPanic && !dryRun {
+		hooks.Cancel = cancelOpenOrders
 	}
+	panics := crash.New(hooks)
+	defer panics.Recover("trading loop")
 
 	// Get initial balance
 	balance, err := client.GetBalance()
@@ -137,30 +217,49 @@ func main() {
 	}
 	state.CurrentBalance = float64(balance.Balance) / 100.0
 
-	fmt.Printf("\n💰 Starting Balance: $%.2f\n", state.CurrentBalance)
-	fmt.Printf("📊 YES Bet: $%.0f | NO Bet: $%.0f (max %d per event)\n", betSizeYes, betSizeNo, maxNoTrades)
-	fmt.Printf("📊 NO Price Range: %d¢ - %d¢\n", minNoPrice, maxNoPrice)
-	fmt.Printf("🔄 Poll Interval: %v\n", pollInterval)
+	console := logging.Console()
+	fmt.Fprintf(console, "\n💰 Starting Balance: $%.2f\n", state.CurrentBalance)
+	fmt.Fprintf(console, "📊 YES Bet: $%.0f | NO Bet: $%.0f (max %d per event)\n", betSizeYes, betSizeNo, maxNoTrades)
+	fmt.Fprintf(console, "📊 NO Price Range: %d¢ - %d¢\n", minNoPrice, maxNoPrice)
+	fmt.Fprintf(console, "🔄 Poll Interval: %v\n", pollInterval)
+	fmt.Fprintf(console, "⏰ Expiry: %s\n", expiryGuard)
+	if configPath != "" {
+		fmt.Fprintf(console, "⚙️  Settings: %s\n", configPath)
+	}
+	for _, station := range Stations {
+		tc := cityTrading[station.Code]
+		switch {
+		case tc.Disabled:
+			fmt.Fprintf(console, "   %s: disabled\n", station.Code)
+		case tc != defaultTrading:
+			fmt.Fprintf(console, "   %s: YES $%.0f at %d-%d¢ | NO $%.0f at %d-%d¢ (max %d) | %d:00-%d:00\n", station.Code,
+				tc.BetYes, tc.MinYesPrice, tc.MaxYesPrice, tc.BetNo, tc.MinNoPrice, tc.MaxNoPrice, tc.MaxNo, tc.StartHour, tc.EndHour)
+		}
+	}
 
 	if dryRun {
-		fmt.Println("\n⚠️  DRY RUN MODE - No real trades will be executed")
+		slog.Warn("DRY RUN MODE - No real trades will be executed")
 	}
 
-	fmt.Println("\n" + strings.Repeat("═", 80))
-	fmt.Println("Starting dual-side trading loop...")
-	fmt.Println(strings.Repeat("═", 80))
+	fmt.Fprintln(console, "\n"+strings.Repeat("═", 80))
+	slog.Info("Starting dual-side trading loop", "balance", state.CurrentBalance,
+		"bet_yes", betSizeYes, "bet_no", betSizeNo, "max_no", maxNoTrades,
+		"min_no_price", minNoPrice, "max_no_price", maxNoPrice, "poll", pollInterval, "dry_run", dryRun)
+	fmt.Fprintln(console, strings.Repeat("═", 80))
 
 	// Main trading loop
 	runTradingLoop()
 }
 
+// printBanner prints to the console view only, keeping JSON logs clean
 func printBanner() {
-	fmt.Println()
-	fmt.Println("╔══════════════════════════════════════════════════════════════════════════════╗")
-	fmt.Println("║                    DUAL-SIDE TEMPERATURE TRADING BOT                        ║")
-	fmt.Println("║                    YES + NO Strategy for Maximum Liquidity                  ║")
-	fmt.Println("║                    7 HIGH Temperature Markets                               ║")
-	fmt.Println("╚══════════════════════════════════════════════════════════════════════════════╝")
+	w := logging.Console()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "╔══════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintln(w, "║                    DUAL-SIDE TEMPERATURE TRADING BOT                        ║")
+	fmt.Fprintln(w, "║                    YES + NO Strategy for Maximum Liquidity                  ║")
+	fmt.Fprintln(w, "║                    7 HIGH Temperature Markets                               ║")
+	fmt.Fprintln(w, "╚══════════════════════════════════════════════════════════════════════════════╝")
 }
 
 func runTradingLoop() {
@@ -177,45 +276,60 @@ func runTradingLoop() {
 
 func analyzeAndTrade() {
 	now := time.Now()
-	fmt.Printf("\n[%s] Analyzing markets...\n", now.Format("15:04:05"))
+	fmt.Fprintln(logging.Console())
+	marketLog.Info("Analyzing markets")
+
+	if day := stateDay(now); state.Day != day {
+		alertDaySummary(&state)
+	}
+	state.rollover(now)
+	if !dryRun {
+		refreshOrders()
+	}
+	recordAccount(now)
 
 	for _, station := range Stations {
 		analyzeCity(station, now)
 	}
+	watchExpiry(now)
+	recordTradingWindow()
 
 	printStatus()
 }
 
 func analyzeCity(station Station, now time.Time) {
+	cityLog := marketLog.With("city", station.City)
+	tc := cityTrading[station.Code]
+	if tc.Disabled {
+		return
+	}
 	loc, err := time.LoadLocation(station.Timezone)
 	if err != nil {
-		log.Printf("  %s: Failed to load timezone: %v", station.City, err)
+		cityLog.Error("Failed to load timezone", "err", err)
 		return
 	}
 
 	localTime := now.In(loc)
-	localHour := localTime.Hour()
-
-	// Trading window: 7 AM - 2 PM local
-	if localHour < 7 || localHour >= 14 {
-		fmt.Printf("  %s: Outside trading window (%d:00 local)\n", station.City, localHour)
-		return
-	}
 
 	// Get today's event
 	dateCode := strings.ToUpper(localTime.Format("06Jan02"))
 	eventTicker := fmt.Sprintf("%s-%s", station.EventPrefix, dateCode)
 
+	if phase := lifecycles[station.Code].Advance(eventTicker, localTime, localTime); !phase.CanEnter() {
+		cityLog.Info("Outside trading window", "phase", phase, "local_hour", localTime.Hour())
+		return
+	}
+
 	// Check if we already have positions
 	if _, exists := state.OpenPositions[eventTicker]; exists {
-		fmt.Printf("  %s: Already have positions in %s\n", station.City, eventTicker)
+		cityLog.Info("Already have positions", "event", eventTicker)
 		return
 	}
 
 	// Fetch markets
 	markets, err := fetchMarkets(eventTicker)
 	if err != nil {
-		fmt.Printf("  %s: No market (%v)\n", station.City, err)
+		cityLog.Warn("No market", "event", eventTicker, "err", err)
 		return
 	}
 
@@ -232,6 +346,10 @@ func analyzeCity(station Station, now time.Time) {
 		if m.Status != "active" {
 			continue
 		}
+		if err := expiryGuard.CheckEntry(strategy.ParseCloseTime(m.CloseTime), now); err != nil {
+			cityLog.Info("Skip: near close", "ticker", m.Ticker, "err", err)
+			continue
+		}
 		yesPrice := int(m.YesBid * 100)
 		noPrice := int(m.NoBid * 100)
 		if noPrice == 0 {
@@ -249,7 +367,7 @@ func analyzeCity(station Station, now time.Time) {
 	}
 
 	if len(brackets) == 0 {
-		fmt.Printf("  %s: No active brackets\n", station.City)
+		cityLog.Info("No active brackets", "event", eventTicker)
 		return
 	}
 
@@ -263,7 +381,7 @@ func analyzeCity(station Station, now time.Time) {
 	// Get METAR
 	metarMax, err := getMETARMax(station, localTime)
 	if err != nil {
-		fmt.Printf("  %s: No METAR data (%v)\n", station.City, err)
+		weatherLog.Warn("No METAR data", "city", station.City, "err", err)
 		return
 	}
 
@@ -278,27 +396,28 @@ func analyzeCity(station Station, now time.Time) {
 
 	// Check signal agreement
 	signalsAgree := favorite.Bracket == metarBracket
+	watchBracket(station.City, metarBracket, metarMax)
 
-	fmt.Printf("  %s: Fav=%s@%d¢ METAR=%d°→%s Agree=%v\n",
-		station.City, favorite.Bracket, favorite.YesPrice, metarMax, metarBracket, signalsAgree)
+	cityLog.Info("Signals", "favorite", favorite.Bracket, "yes_price", favorite.YesPrice,
+		"metar_max", metarMax, "metar_bracket", metarBracket, "agree", signalsAgree)
 
 	if !signalsAgree {
-		fmt.Printf("    → SKIP: Signals don't agree\n")
+		cityLog.Info("Skip: signals don't agree")
 		return
 	}
 
-	if favorite.YesPrice < 20 || favorite.YesPrice > 95 {
-		fmt.Printf("    → SKIP: YES price out of range (%d¢)\n", favorite.YesPrice)
+	if favorite.YesPrice < tc.MinYesPrice || favorite.YesPrice > tc.MaxYesPrice {
+		cityLog.Info("Skip: YES price out of range", "yes_price", favorite.YesPrice)
 		return
 	}
 
-	// Execute trades
-	var trades []TradeRecord
+	// Execute trades, saving each as it's placed
 
 	// 1. BUY YES on favorite
 	yesTrade := executeYesTrade(station, eventTicker, favorite.Market, favorite.Bracket, favorite.YesPrice)
 	if yesTrade != nil {
-		trades = append(trades, *yesTrade)
+		recordTrade(*yesTrade)
+		alertTrade(*yesTrade)
 	}
 
 	// 2. BUY NO on losing brackets
@@ -307,38 +426,35 @@ func analyzeCity(station Station, now time.Time) {
 		if b.Bracket == favorite.Bracket {
 			continue // Skip favorite
 		}
-		if noCount >= maxNoTrades {
+		if noCount >= tc.MaxNo {
 			break
 		}
-		if b.NoPrice < minNoPrice || b.NoPrice > maxNoPrice {
+		if b.NoPrice < tc.MinNoPrice || b.NoPrice > tc.MaxNoPrice {
 			continue // Skip if NO price out of range
 		}
 
 		noTrade := executeNoTrade(station, eventTicker, b.Market, b.Bracket, b.NoPrice)
 		if noTrade != nil {
-			trades = append(trades, *noTrade)
+			recordTrade(*noTrade)
+			alertTrade(*noTrade)
 			noCount++
 		}
 	}
-
-	// Record positions
-	if len(trades) > 0 {
-		state.OpenPositions[eventTicker] = trades
-	}
 }
 
 func executeYesTrade(station Station, eventTicker string, market Market, bracket string, price int) *TradeRecord {
-	contracts := int(betSizeYes * 100 / float64(price))
+	contracts := int(cityTrading[station.Code].BetYes * 100 / float64(price))
 	if contracts < 1 {
 		contracts = 1
 	}
 
 	cost := float64(contracts*price) / 100.0
 
-	fmt.Printf("    → YES: BUY %d contracts of %s @ %d¢ ($%.2f)\n",
-		contracts, bracket, price, cost)
+	orderLog := execLog.With("city", station.City, "ticker", market.Ticker, "side", "yes")
+	orderLog.Info("BUY", "bracket", bracket, "count", contracts, "price", price, "cost", cost)
 
 	if dryRun {
+		tradingMetrics.OrderPlaced(station.Code, "yes", false)
 		state.YesTrades++
 		state.TotalCost += cost
 		return &TradeRecord{
@@ -353,6 +469,8 @@ func executeYesTrade(station Station, eventTicker string, market Market, bracket
 			Quantity:    contracts,
 			Cost:        cost,
 			OrderID:     "DRY-RUN",
+			Status:      "dry-run",
+			Closes:      strategy.ParseCloseTime(market.CloseTime),
 		}
 	}
 
@@ -367,12 +485,14 @@ func executeYesTrade(station Station, eventTicker string, market Market, bracket
 	}
 
 	resp, err := client.CreateOrder(order)
+	recordOrder(station, string(order.Side), resp, err)
 	if err != nil {
-		fmt.Printf("    → ERROR: %v\n", err)
+		orderLog.Error("Order failed", "err", err)
+		alert(notify.Error("Order "+market.Ticker, err.Error()))
 		return nil
 	}
 
-	fmt.Printf("    → SUCCESS: Order %s\n", resp.OrderID)
+	orderLog.Info("Order placed", "order_id", resp.OrderID)
 	state.YesTrades++
 	state.TotalCost += cost
 
@@ -388,21 +508,24 @@ func executeYesTrade(station Station, eventTicker string, market Market, bracket
 		Quantity:    contracts,
 		Cost:        cost,
 		OrderID:     resp.OrderID,
+		Status:      string(resp.Status),
+		Closes:      strategy.ParseCloseTime(market.CloseTime),
 	}
 }
 
 func executeNoTrade(station Station, eventTicker string, market Market, bracket string, price int) *TradeRecord {
-	contracts := int(betSizeNo * 100 / float64(price))
+	contracts := int(cityTrading[station.Code].BetNo * 100 / float64(price))
 	if contracts < 1 {
 		contracts = 1
 	}
 
 	cost := float64(contracts*price) / 100.0
 
-	fmt.Printf("    → NO:  BUY %d contracts of %s @ %d¢ ($%.2f)\n",
-		contracts, bracket, price, cost)
+	orderLog := execLog.With("city", station.City, "ticker", market.Ticker, "side", "no")
+	orderLog.Info("BUY", "bracket", bracket, "count", contracts, "price", price, "cost", cost)
 
 	if dryRun {
+		tradingMetrics.OrderPlaced(station.Code, "no", false)
 		state.NoTrades++
 		state.TotalCost += cost
 		return &TradeRecord{
@@ -417,6 +540,8 @@ func executeNoTrade(station Station, eventTicker string, market Market, bracket
 			Quantity:    contracts,
 			Cost:        cost,
 			OrderID:     "DRY-RUN",
+			Status:      "dry-run",
+			Closes:      strategy.ParseCloseTime(market.CloseTime),
 		}
 	}
 
@@ -431,12 +556,14 @@ func executeNoTrade(station Station, eventTicker string, market Market, bracket
 	}
 
 	resp, err := client.CreateOrder(order)
+	recordOrder(station, string(order.Side), resp, err)
 	if err != nil {
-		fmt.Printf("    → ERROR: %v\n", err)
+		orderLog.Error("Order failed", "err", err)
+		alert(notify.Error("Order "+market.Ticker, err.Error()))
 		return nil
 	}
 
-	fmt.Printf("    → SUCCESS: Order %s\n", resp.OrderID)
+	orderLog.Info("Order placed", "order_id", resp.OrderID)
 	state.NoTrades++
 	state.TotalCost += cost
 
@@ -452,31 +579,31 @@ func executeNoTrade(station Station, eventTicker string, market Market, bracket
 		Quantity:    contracts,
 		Cost:        cost,
 		OrderID:     resp.OrderID,
+		Status:      string(resp.Status),
+		Closes:      strategy.ParseCloseTime(market.CloseTime),
 	}
 }
 
+// fetchMarkets returns eventTicker's bracket markets by floor, reporting the
+// call to the health check
 func fetchMarkets(eventTicker string) ([]Market, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets?event_ticker=%s&limit=100", eventTicker)
+	markets, err := fetchBrackets(eventTicker)
+	botHealth.Observe(healthKalshi, err)
+	return markets, err
+}
 
-	resp, err := httpClient.Get(url)
+func fetchBrackets(eventTicker string) ([]Market, error) {
+	markets, err := client.GetAllMarkets(rest.GetMarketsParams{EventTicker: eventTicker})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var result MarketsResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, err
-	}
 
 	// Filter to bracket markets
 	var brackets []Market
-	for _, m := range result.Markets {
+	for _, m := range markets {
 		parts := strings.Split(m.Ticker, "-")
 		if len(parts) >= 3 && strings.HasPrefix(parts[len(parts)-1], "B") {
-			brackets = append(brackets, m)
+			brackets = append(brackets, newMarket(m))
 		}
 	}
 
@@ -491,53 +618,46 @@ func fetchMarkets(eventTicker string) ([]Market, error) {
 	return brackets, nil
 }
 
+// newMarket converts a market fetched through the REST client
+func newMarket(m rest.Market) Market {
+	return Market{
+		Ticker:      m.Ticker,
+		EventTicker: m.EventTicker,
+		FloorStrike: int(m.FloorStrike),
+		CapStrike:   int(m.CapStrike),
+		Status:      m.Status,
+		CloseTime:   m.CloseTime,
+		YesBid:      float64(m.YesBid),
+		YesAsk:      float64(m.YesAsk),
+		NoBid:       float64(m.NoBid),
+		NoAsk:       float64(m.NoAsk),
+		Volume:      m.Volume,
+	}
+}
+
 func getMETARMax(station Station, date time.Time) (int, error) {
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=%s&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=%s&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		station.METAR,
-		date.Year(), int(date.Month()), date.Day(),
-		date.Year(), int(date.Month()), date.Day()+1,
-		station.Timezone,
-	)
-
-	resp, err := httpClient.Get(url)
+	ws := weather.StationByCode(station.Code)
+	data, err := weather.FetchMETARMax(ws, date)
+	botHealth.Observe(healthMETAR, err)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	lines := strings.Split(string(body), "\n")
-	maxTemp := -999.0
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, station.METAR+",") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > maxTemp {
-					maxTemp = temp
-				}
-			}
-		}
+	if n := len(data.Observations); n > 0 {
+		tradingMetrics.SetTemperature(ws.ID, "current", data.Observations[n-1].Temp)
 	}
-
-	if maxTemp == -999.0 {
-		return 0, fmt.Errorf("no data")
-	}
-
-	return int(math.Round(maxTemp)), nil
+	tradingMetrics.SetTemperature(ws.ID, "max", data.MaxTemp)
+	return int(data.MaxTemp), nil
 }
 
 func printStatus() {
-	fmt.Println()
-	fmt.Println(strings.Repeat("─", 80))
-	fmt.Printf("📊 Status: YES=%d NO=%d | Total positions: %d events | Cost: $%.2f\n",
-		state.YesTrades, state.NoTrades, len(state.OpenPositions), state.TotalCost)
+	w := logging.Console()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, strings.Repeat("─", 80))
+	slog.Info("Today", "yes_trades", state.YesTrades, "no_trades", state.NoTrades, "cost", state.TotalCost,
+		"events", len(state.OpenPositions), "open_orders", openOrders())
 
 	if len(state.OpenPositions) > 0 {
-		fmt.Println("Open positions:")
+		fmt.Fprintln(w, "Open positions:")
 		for event, trades := range state.OpenPositions {
 			yesCost := 0.0
 			noCost := 0.0
@@ -548,11 +668,11 @@ func printStatus() {
 					noCost += t.Cost
 				}
 			}
-			fmt.Printf("  • %s: YES=$%.0f NO=$%.0f\n", event, yesCost, noCost)
+			fmt.Fprintf(w, "  • %s: YES=$%.0f NO=$%.0f\n", event, yesCost, noCost)
 		}
 	}
 
-	fmt.Println(strings.Repeat("─", 80))
+	fmt.Fprintln(w, strings.Repeat("─", 80))
 }
 
 
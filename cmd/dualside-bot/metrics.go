@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/metrics"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+var (
+	metricsAddr    string
+	tradingMetrics *metrics.Trading // nil = metrics off
+)
+
+func init() {
+	flag.StringVar(&metricsAddr, "metrics-addr", "", "Serve Prometheus metrics on /metrics and health on /health at this address, e.g. :9090")
+}
+
+// setupMetrics serves Prometheus metrics if -metrics-addr is set, with the
+// same names as the production bot's, and health
+func setupMetrics() {
+	if metricsAddr == "" {
+		return
+	}
+	registry := metrics.NewRegistry()
+	tradingMetrics = metrics.NewTrading(registry)
+	botHealth = newHealth()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", registry.Handler())
+	mux.Handle("/health", botHealth.Handler())
+	go func() {
+		if err := http.ListenAndServe(metricsAddr, mux); err != nil {
+			slog.Error("Metrics server stopped", "err", err)
+		}
+	}()
+	slog.Info("Serving metrics", "addr", metricsAddr)
+}
+
+// recordOrder counts an order placed, or failed with err
+func recordOrder(station Station, side string, resp *rest.Order, err error) {
+	switch {
+	case errors.Is(err, rest.ErrOrderBounds):
+		tradingMetrics.OrderRejected(station.Code, "order_bounds")
+		return
+	case err != nil:
+		reason := "api_error"
+		if class, ok := rest.ClassifyRejection(err); ok {
+			reason = string(class)
+		}
+		tradingMetrics.OrderRejected(station.Code, reason)
+		return
+	}
+	tradingMetrics.OrderPlaced(station.Code, side, resp.Status == rest.OrderStatusExecuted)
+}
+
+// recordAccount sets the balance gauge, and the exposure gauge to the cost
+// of today's orders that weren't canceled
+func recordAccount(now time.Time) {
+	if tradingMetrics == nil {
+		return
+	}
+	balance, err := client.GetBalance()
+	botHealth.Observe(healthKalshi, err)
+	if err == nil {
+		tradingMetrics.SetBalance(float64(balance.Balance) / 100)
+	}
+	day := now.Format("2006-01-02")
+	exposure := 0.0
+	for _, trades := range state.OpenPositions {
+		for _, t := range trades {
+			if t.Timestamp.Format("2006-01-02") == day && t.Status != string(rest.OrderStatusCanceled) {
+				exposure += t.Cost
+			}
+		}
+	}
+	tradingMetrics.SetExposure(exposure)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/crash"
+	"github.com/brendanplayford/kalshi-go/pkg/notify"
+)
+
+var (
+	notifyDedup  time.Duration
+	notifyMax    int
+	notifier     notify.Notifier = notify.Multi()
+	lastBrackets                 = make(map[string]string) // City -> bracket of the METAR max
+)
+
+func init() {
+	flag.DurationVar(&notifyDedup, "notify-dedup", 15*time.Minute, "Suppress repeats of an alert for this long")
+	flag.IntVar(&notifyMax, "notify-max", 30, "Alerts sent per hour at most (0 = unlimited)")
+}
+
+// setupNotifier sends alerts to the channels configured in the environment,
+// the same variables the production bot reads
+func setupNotifier() {
+	var to []string
+	for _, addr := range strings.Split(os.Getenv("EMAIL_TO"), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+	notifier = notify.NewThrottle(notify.Multi(
+		notify.NewSlack(os.Getenv("SLACK_WEBHOOK_URL")),
+		notify.NewDiscord(os.Getenv("DISCORD_WEBHOOK_URL")),
+		notify.NewEmail(os.Getenv("SMTP_ADDR"), os.Getenv("EMAIL_FROM"), to, os.Getenv("SMTP_USER"), os.Getenv("SMTP_PASSWORD")),
+	), notify.ThrottleConfig{Dedup: notifyDedup, Burst: notifyMax, Per: time.Hour})
+}
+
+// alert sends msg, logging failures; dropped repeats are expected
+func alert(msg notify.Message) {
+	if err := notifier.Notify(context.Background(), msg); err != nil && !errors.Is(err, notify.ErrSuppressed) {
+		slog.Warn("Failed to send alert", "kind", msg.Kind, "err", err)
+	}
+}
+
+// alertTrade announces a placed order
+func alertTrade(t TradeRecord) {
+	alert(notify.Trade(t.City, t.Ticker, t.Side, t.Price, t.Quantity, t.Cost, t.OrderID))
+}
+
+// watchBracket alerts when a city's METAR max moves into another bracket
+func watchBracket(city, bracket string, metarMax int) {
+	prev, seen := lastBrackets[city]
+	lastBrackets[city] = bracket
+	if !seen || prev == bracket || bracket == "" {
+		return
+	}
+	msg := notify.Threshold(city, "METAR max", fmt.Sprintf("%d°F", metarMax), "into "+bracket)
+	msg.Fields = append(msg.Fields, notify.Field{Name: "From", Value: prev})
+	alert(msg)
+}
+
+// alertDaySummary sends the day's counters before they are reset
+func alertDaySummary(s *BotState) {
+	if s.Day == "" || s.YesTrades+s.NoTrades == 0 {
+		return
+	}
+	alert(notify.Message{
+		Kind:  notify.KindSummary,
+		Title: "📊 Daily Trading Summary " + s.Day,
+		Fields: []notify.Field{
+			{Name: "YES trades", Value: fmt.Sprint(s.YesTrades)},
+			{Name: "NO trades", Value: fmt.Sprint(s.NoTrades)},
+			{Name: "Cost", Value: fmt.Sprintf("$%.2f", s.TotalCost)},
+			{Name: "Open orders", Value: fmt.Sprint(openOrders())},
+		},
+		Key: "summary\x00" + s.Day,
+	})
+}
+
+// alertPanic sends a panic's report, with as much of the stack as fits
+func alertPanic(r crash.Report) {
+	stack := r.Stack
+	if len(stack) > 1500 {
+		stack = stack[:1500] + "\n..."
+	}
+	msg := notify.Error("Panic", fmt.Sprintf("Panic in %s: %s\n```%s```", r.Goroutine, r.Panic, stack))
+	msg.Fields = append(msg.Fields, notify.Field{Name: "Resting orders canceled", Value: fmt.Sprint(r.Canceled)})
+	alert(msg)
+}
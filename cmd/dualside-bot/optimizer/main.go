@@ -2,14 +2,23 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
 	"math"
 	"net/http"
+	"runtime"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	bt "github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
 type Market struct {
@@ -54,14 +63,21 @@ var Stations = []Station{
 type DayData struct {
 	Date           time.Time
 	City           string
+	Series         string
 	WinningBracket string
 	METARMax       int
 	METARBracket   string
-	BracketPrices  map[string]struct{ Yes, No int }
+	Brackets       []BracketPrice // Traded brackets, highest YES price first
 	FavBracket     string
 	FavPrice       int
 }
 
+// BracketPrice is a bracket's first traded prices (cents)
+type BracketPrice struct {
+	Bracket string
+	Yes, No int
+}
+
 type Parameters struct {
 	BetYes      float64
 	BetNo       float64
@@ -72,6 +88,12 @@ type Parameters struct {
 	MaxNoTrades int
 }
 
+// String summarizes the parameters, e.g. "YES $500 50-95¢, NO $150 40-95¢ ×4"
+func (p Parameters) String() string {
+	return fmt.Sprintf("YES $%.0f %d-%d¢, NO $%.0f %d-%d¢ ×%d",
+		p.BetYes, p.MinYesPrice, p.MaxYesPrice, p.BetNo, p.MinNoPrice, p.MaxNoPrice, p.MaxNoTrades)
+}
+
 type Result struct {
 	Params      Parameters
 	Trades      int
@@ -83,11 +105,40 @@ type Result struct {
 	MaxDrawdown float64
 	YesProfit   float64
 	NoProfit    float64
+	AvgStake    float64   // Mean dollars staked per traded day
+	Profits     []float64 // Each traded event's P&L, in data order
+	Returns     []float64 // Each calendar day's P&L / stake (0 when not traded)
 }
 
-var httpClient = &http.Client{Timeout: 15 * time.Second}
+// client fetches the history; its rate limiter paces the fetch workers
+var client = rest.NewPublic(
+	rest.WithHTTPClient(&http.Client{Timeout: 15 * time.Second}),
+	rest.WithRateLimit(rest.DefaultRateLimits()),
+)
+
+// feeSchedule prices every simulated trade (same schedule as the live bot)
+var feeSchedule = fees.DefaultSchedule()
 
 func main() {
+	feesFile := flag.String("fees", "", "JSON fee schedule file (default: 7% of winnings)")
+	bankroll := flag.Float64("bankroll", 5000, "Starting bankroll for the annual projection")
+	maxStake := flag.Float64("max-stake", 2000, "Daily stake cap for the capped sizing policy")
+	workers := flag.Int("workers", 0, "Parameter sets backtested at once (default: one per CPU)")
+	train := flag.Int("train", 12, "Days in each walk-forward training window (0 skips walk-forward validation)")
+	test := flag.Int("test", 3, "Days in each walk-forward test window")
+	fetchWorkers := flag.Int("fetch-workers", bt.DefaultFetchWorkers, "Days fetched at once")
+	runs := flag.String("runs", bt.DefaultRunsDir, "Record the recommended parameters' run in this registry (\"\" = don't)")
+	flag.Parse()
+
+	if *feesFile != "" {
+		schedule, err := fees.LoadSchedule(*feesFile)
+		if err != nil {
+			fmt.Printf("Failed to load fee schedule: %v\n", err)
+			return
+		}
+		feeSchedule = schedule
+	}
+
 	fmt.Println("╔══════════════════════════════════════════════════════════════════════════════╗")
 	fmt.Println("║           DUAL-SIDE STRATEGY PARAMETER OPTIMIZER                            ║")
 	fmt.Println("╚══════════════════════════════════════════════════════════════════════════════╝")
@@ -95,7 +146,7 @@ func main() {
 
 	// Collect historical data first
 	fmt.Println("📊 Collecting historical data (21 days, 7 cities)...")
-	data := collectData(21)
+	data := collectData(21, *fetchWorkers)
 	fmt.Printf("   Collected %d tradable days\n\n", len(data))
 
 	if len(data) == 0 {
@@ -112,12 +163,7 @@ func main() {
 	maxNoPrices := []int{85, 90, 95}
 	maxNoTradesCounts := []int{1, 2, 3, 4}
 
-	var results []Result
-	totalTests := len(betYesSizes) * len(betNoSizes) * len(minYesPrices) * len(maxYesPrices) * len(minNoPrices) * len(maxNoPrices) * len(maxNoTradesCounts)
-
-	fmt.Printf("🔬 Testing %d parameter combinations...\n\n", totalTests)
-
-	tested := 0
+	var grid []Parameters
 	for _, betYes := range betYesSizes {
 		for _, betNo := range betNoSizes {
 			for _, minYes := range minYesPrices {
@@ -131,7 +177,7 @@ func main() {
 								continue
 							}
 							for _, maxNoTrades := range maxNoTradesCounts {
-								params := Parameters{
+								grid = append(grid, Parameters{
 									BetYes:      betYes,
 									BetNo:       betNo,
 									MinYesPrice: minYes,
@@ -139,20 +185,35 @@ func main() {
 									MinNoPrice:  minNo,
 									MaxNoPrice:  maxNo,
 									MaxNoTrades: maxNoTrades,
-								}
-
-								result := backtest(data, params)
-								if result.Trades > 0 {
-									results = append(results, result)
-								}
-								tested++
+								})
 							}
 						}
 					}
 				}
 			}
 		}
-		fmt.Printf("   Progress: %d/%d...\n", tested, totalTests)
+	}
+
+	fmt.Printf("🔬 Testing %d parameter combinations on %d workers...\n\n", len(grid), nworkers(*workers))
+
+	// The days are read-only from here on, so parameter sets run in parallel
+	start := time.Now()
+	var tested atomic.Int64
+	step := int64(max(len(grid)/10, 1))
+	all := bt.Parallel(grid, *workers, func(params Parameters) Result {
+		result := backtest(data, params)
+		if n := tested.Add(1); n%step == 0 {
+			fmt.Printf("   Progress: %d/%d...\n", n, len(grid))
+		}
+		return result
+	})
+	fmt.Printf("   Done in %s\n", time.Since(start).Round(time.Millisecond))
+
+	var results []Result
+	for _, r := range all {
+		if r.Trades > 0 {
+			results = append(results, r)
+		}
 	}
 
 	// Sort by profit
@@ -231,39 +292,122 @@ func main() {
 		fmt.Printf("  Max NO:      %d trades per event\n", best.Params.MaxNoTrades)
 		fmt.Println()
 		fmt.Printf("  📊 Results over %d days:\n", len(data))
+		// 95% bootstrap intervals over the traded events: the grid picked
+		// the best of thousands, so expect the truth toward the low end
+		sum := stats.Sample{Profits: best.Profits, Wins: best.Wins, Daily: best.Profits, PeriodsPerYear: 252}.
+			Bootstrap(stats.DefaultBootstrapConfig())
 		fmt.Printf("     Trades:    %d\n", best.Trades)
-		fmt.Printf("     Win Rate:  %.1f%%\n", best.WinRate)
+		fmt.Printf("     Win Rate:  %s\n", sum.WinRate.Format("%.1f%%"))
 		fmt.Printf("     Profit:    $%.2f\n", best.TotalProfit)
-		fmt.Printf("     Sharpe:    %.2f\n", best.Sharpe)
+		fmt.Printf("     EV/trade:  %s\n", sum.EV.Format("$%.2f"))
+		fmt.Printf("     Sharpe:    %s\n", sum.Sharpe.Format("%.2f"))
+		fmt.Printf("     Max DD:    %s\n", sum.MaxDrawdown.Format("$%.2f"))
 		fmt.Printf("     YES P/L:   $%.2f\n", best.YesProfit)
 		fmt.Printf("     NO P/L:    $%.2f\n", best.NoProfit)
 
 		// Annual projection
 		annual := best.TotalProfit / 21.0 * 365.0
 		fmt.Println()
-		fmt.Printf("  💰 Annual Projection: $%.0f\n", annual)
+		fmt.Printf("  💰 Annual Projection (linear, fixed bets): $%.0f\n", annual)
+		printBankrollProjection(best, *bankroll, *maxStake)
+
+		if *runs != "" {
+			recordBest(*runs, best, data, *feesFile, sum)
+		}
+	}
+
+	if *train > 0 {
+		printWalkForward(data, grid, bt.WalkForwardConfig{Train: *train, Test: *test, Workers: *workers})
 	}
 
 	fmt.Println()
 }
 
-func collectData(days int) []DayData {
-	var data []DayData
+// recordBest records the recommended parameters and their metrics over data
+// in the run registry at dir, so the parameters deployed can be traced back
+// to the run that chose them
+func recordBest(dir string, best Result, data []DayData, feesFile string, sum stats.Summary) {
+	var from, to string
+	series := make(map[string]bool)
+	for _, d := range data {
+		date := d.Date.Format("2006-01-02")
+		if from == "" || date < from {
+			from = date
+		}
+		to = max(to, date)
+		series[d.Series] = true
+	}
+	names := make([]string, 0, len(series))
+	for s := range series {
+		names = append(names, s)
+	}
+	sort.Strings(names)
+
+	params := struct {
+		Parameters
+		Fees string `json:",omitempty"` // Fee schedule file
+	}{best.Params, feesFile}
+	run, err := bt.NewRunRecord("dualside-optimizer", "DualSide", params, "kalshi:"+strings.Join(names, ","), from, to, stats.DefaultBootstrapConfig().Seed)
+	if err != nil {
+		fmt.Printf("  ❌ Failed to record run: %v\n", err)
+		return
+	}
+	run.Metrics = map[string]float64{
+		"trades":       float64(best.Trades),
+		"profit":       best.TotalProfit,
+		"win_rate":     best.WinRate,
+		"win_rate_lo":  sum.WinRate.Lo,
+		"win_rate_hi":  sum.WinRate.Hi,
+		"ev":           sum.EV.Estimate,
+		"ev_lo":        sum.EV.Lo,
+		"ev_hi":        sum.EV.Hi,
+		"sharpe":       best.Sharpe,
+		"max_drawdown": best.MaxDrawdown,
+		"yes_profit":   best.YesProfit,
+		"no_profit":    best.NoProfit,
+	}
+	if _, err := run.Save(dir); err != nil {
+		fmt.Printf("  ❌ Failed to save run: %v\n", err)
+		return
+	}
+	fmt.Printf("  📝 Recorded run %s\n", run.ID)
+}
+
+// stationDay is one day of history to fetch
+type stationDay struct {
+	station Station
+	date    time.Time
+}
 
+// collectData fetches the last days of every station, workers days at a
+// time, in station and date order
+func collectData(days, workers int) []DayData {
+	var jobs []stationDay
 	for _, station := range Stations {
 		loc, _ := time.LoadLocation(station.Timezone)
 		today := time.Now().In(loc)
-
 		for i := 1; i <= days; i++ {
-			date := today.AddDate(0, 0, -i)
-			dayData := fetchDayData(station, date)
-			if dayData != nil && dayData.FavPrice > 0 {
-				data = append(data, *dayData)
-			}
-			time.Sleep(100 * time.Millisecond)
+			jobs = append(jobs, stationDay{station, today.AddDate(0, 0, -i)})
 		}
 	}
 
+	cfg := bt.FetchConfig{
+		Workers: workers,
+		Progress: func(done, total int) {
+			fmt.Printf("\r   Fetched %d/%d days", done, total)
+		},
+	}
+	fetched, _ := bt.Fetch(context.Background(), jobs, cfg, func(_ context.Context, j stationDay) (*DayData, error) {
+		return fetchDayData(j.station, j.date), nil
+	})
+	fmt.Println()
+
+	var data []DayData
+	for _, dayData := range fetched {
+		if dayData != nil && dayData.FavPrice > 0 {
+			data = append(data, *dayData)
+		}
+	}
 	return data
 }
 
@@ -305,32 +449,30 @@ func fetchDayData(station Station, date time.Time) *DayData {
 		}
 	}
 
-	// Get first trade prices
-	bracketPrices := make(map[string]struct{ Yes, No int })
+	// Get first trade prices, favorite first
+	var brackets []BracketPrice
 	for _, m := range markets {
 		yesPrice, noPrice := getFirstTradePrices(m.Ticker)
 		if yesPrice > 0 {
-			bracketPrices[formatBracket(&m)] = struct{ Yes, No int }{yesPrice, noPrice}
+			brackets = append(brackets, BracketPrice{formatBracket(&m), yesPrice, noPrice})
 		}
 	}
+	sort.SliceStable(brackets, func(i, j int) bool { return brackets[i].Yes > brackets[j].Yes })
 
-	// Find favorite
 	var favBracket string
 	var favPrice int
-	for bracket, prices := range bracketPrices {
-		if prices.Yes > favPrice {
-			favPrice = prices.Yes
-			favBracket = bracket
-		}
+	if len(brackets) > 0 {
+		favBracket, favPrice = brackets[0].Bracket, brackets[0].Yes
 	}
 
 	return &DayData{
 		Date:           date,
 		City:           station.City,
+		Series:         station.EventPrefix,
 		WinningBracket: winningBracket,
 		METARMax:       metarMax,
 		METARBracket:   metarBracket,
-		BracketPrices:  bracketPrices,
+		Brackets:       brackets,
 		FavBracket:     favBracket,
 		FavPrice:       favPrice,
 	}
@@ -338,7 +480,8 @@ func fetchDayData(station Station, date time.Time) *DayData {
 
 func backtest(data []DayData, params Parameters) Result {
 	result := Result{Params: params}
-	var profits []float64
+	dayProfit := make(map[string]float64)
+	dayStake := make(map[string]float64)
 
 	for _, day := range data {
 		// Check signal agreement
@@ -352,23 +495,23 @@ func backtest(data []DayData, params Parameters) Result {
 		}
 
 		result.Trades++
-		dayProfit := 0.0
+		profit, stake := 0.0, params.BetYes
+		rule := feeSchedule.Rule(day.Series, day.Date)
 
 		// YES trade
 		yesContracts := params.BetYes / float64(day.FavPrice) * 100
-		if day.WinningBracket == day.FavBracket {
+		yesWon := day.WinningBracket == day.FavBracket
+		if yesWon {
 			result.Wins++
-			yesProfit := yesContracts - params.BetYes
-			result.YesProfit += yesProfit
-			dayProfit += yesProfit
-		} else {
-			result.YesProfit -= params.BetYes
-			dayProfit -= params.BetYes
 		}
+		yesProfit := rule.NetProfit(fees.Maker, yesContracts, day.FavPrice, yesWon)
+		result.YesProfit += yesProfit
+		profit += yesProfit
 
-		// NO trades
+		// NO trades, in YES price order as the live strategy places them
 		noCount := 0
-		for bracket, prices := range day.BracketPrices {
+		for _, prices := range day.Brackets {
+			bracket := prices.Bracket
 			if bracket == day.FavBracket {
 				continue
 			}
@@ -380,22 +523,40 @@ func backtest(data []DayData, params Parameters) Result {
 			}
 
 			noContracts := params.BetNo / float64(prices.No) * 100
-			if day.WinningBracket != bracket {
-				noProfit := noContracts - params.BetNo
-				result.NoProfit += noProfit
-				dayProfit += noProfit
-			} else {
-				result.NoProfit -= params.BetNo
-				dayProfit -= params.BetNo
-			}
+			noProfit := rule.NetProfit(fees.Maker, noContracts, prices.No, day.WinningBracket != bracket)
+			result.NoProfit += noProfit
+			profit += noProfit
+			stake += params.BetNo
 			noCount++
 		}
 
-		profits = append(profits, dayProfit)
-		result.TotalProfit += dayProfit
+		result.Profits = append(result.Profits, profit)
+		result.TotalProfit += profit
+		date := day.Date.Format("2006-01-02")
+		dayProfit[date] += profit
+		dayStake[date] += stake
+	}
+
+	// Returns on stake per calendar day, for the bankroll projection
+	seen := make(map[string]bool)
+	for _, day := range data {
+		date := day.Date.Format("2006-01-02")
+		if seen[date] {
+			continue
+		}
+		seen[date] = true
+		r := 0.0
+		if dayStake[date] > 0 {
+			r = dayProfit[date] / dayStake[date]
+			result.AvgStake += dayStake[date]
+		}
+		result.Returns = append(result.Returns, r)
+	}
+	if len(dayStake) > 0 {
+		result.AvgStake /= float64(len(dayStake))
 	}
 
-	if result.Trades > 0 {
+	if profits := result.Profits; len(profits) > 0 {
 		result.WinRate = float64(result.Wins) / float64(result.Trades) * 100
 		result.AvgProfit = result.TotalProfit / float64(result.Trades)
 
@@ -430,16 +591,19 @@ func backtest(data []DayData, params Parameters) Result {
 	return result
 }
 
-func fetchMarkets(eventTicker string) ([]Market, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets?event_ticker=%s&limit=100", eventTicker)
+// nworkers is the number of goroutines bt.Parallel uses for the -workers flag
+func nworkers(n int) int {
+	if n <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return n
+}
 
-	resp, err := httpClient.Get(url)
+func fetchMarkets(eventTicker string) ([]Market, error) {
+	body, err := client.Get(fmt.Sprintf("/markets?event_ticker=%s&limit=100", eventTicker))
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
 	var result MarketsResponse
 	if err := json.Unmarshal(body, &result); err != nil {
@@ -458,15 +622,10 @@ func fetchMarkets(eventTicker string) ([]Market, error) {
 }
 
 func getFirstTradePrices(ticker string) (yesPrice, noPrice int) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets/trades?ticker=%s&limit=100", ticker)
-
-	resp, err := httpClient.Get(url)
+	body, err := client.Get(fmt.Sprintf("/markets/trades?ticker=%s&limit=100", ticker))
 	if err != nil {
 		return 0, 0
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
 	var result TradesResponse
 	if err := json.Unmarshal(body, &result); err != nil {
@@ -491,44 +650,96 @@ func getFirstTradePrices(ticker string) (yesPrice, noPrice int) {
 }
 
 func getMETARMax(station Station, date time.Time) (int, error) {
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=%s&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=%s&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		station.METAR,
-		date.Year(), int(date.Month()), date.Day(),
-		date.Year(), int(date.Month()), date.Day()+1,
-		station.Timezone,
-	)
-
-	resp, err := httpClient.Get(url)
+	data, err := weather.FetchMETARMax(weather.StationByCode(station.Code), date)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
+	return int(data.MaxTemp), nil
+}
 
-	body, _ := io.ReadAll(resp.Body)
-	lines := strings.Split(string(body), "\n")
-	maxTemp := -999.0
+func formatBracket(m *Market) string {
+	return fmt.Sprintf("%d-%d°", m.FloorStrike, m.CapStrike)
+}
 
-	for _, line := range lines {
-		if strings.HasPrefix(line, station.METAR+",") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > maxTemp {
-					maxTemp = temp
-				}
-			}
-		}
+// printBankrollProjection replaces the linear extrapolation with a year of
+// bootstrapped days under fixed, compounding and capped sizing, each starting
+// at the backtest's average daily stake
+func printBankrollProjection(best Result, bankroll, maxStake float64) {
+	cfg := bt.DefaultBankrollConfig()
+	cfg.Start = bankroll
+	cfg.RuinAt = bankroll * 0.1
+
+	policies := []struct {
+		name   string
+		sizing bt.Sizing
+	}{
+		{"Fixed", bt.Sizing{Stake: best.AvgStake}},
+		{"Compounding", bt.Sizing{Fraction: best.AvgStake / bankroll}},
+		{fmt.Sprintf("Capped $%.0f", maxStake), bt.Sizing{Fraction: best.AvgStake / bankroll, MaxStake: maxStake}},
 	}
 
-	if maxTemp == -999.0 {
-		return 0, fmt.Errorf("no data")
+	fmt.Println()
+	fmt.Printf("  📈 Year-end bankroll from $%.0f (%d runs, %d days resampled):\n", bankroll, cfg.Runs, len(best.Returns))
+	fmt.Printf("     %-14s %10s %10s %10s %8s %8s\n", "Sizing", "P5", "Median", "P95", "Ruin", "Med DD")
+	for _, p := range policies {
+		cfg.Sizing = p.sizing
+		r := bt.SimulateBankroll(best.Returns, cfg)
+		fmt.Printf("     %-14s $%9.0f $%9.0f $%9.0f %7.1f%% %7.1f%%\n",
+			p.name, r.Percentile(5), r.Median(), r.Percentile(95), r.RuinRate*100, r.MedianDrawdown()*100)
 	}
-
-	return int(math.Round(maxTemp)), nil
 }
 
-func formatBracket(m *Market) string {
-	return fmt.Sprintf("%d-%d°", m.FloorStrike, m.CapStrike)
+// printWalkForward chooses parameters by profit per day on each training
+// window, as the grid search above does on all the days, and reports how
+// they do on the test days after it. The recommended set's backtest is in
+// sample; the out-of-sample profit is what to expect from it
+func printWalkForward(data []DayData, grid []Parameters, cfg bt.WalkForwardConfig) {
+	fmt.Println()
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Printf("  WALK-FORWARD VALIDATION (%d training days, %d test days)\n", cfg.Train, cfg.Test)
+	fmt.Println("═══════════════════════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	dates := make([]string, len(data))
+	for i, day := range data {
+		dates[i] = day.Date.Format("2006-01-02")
+	}
+	r, err := bt.WalkForward(dates, grid, cfg, func(p Parameters, w bt.Window) float64 {
+		var days []DayData
+		for i, day := range data {
+			if w.Contains(dates[i]) {
+				days = append(days, day)
+			}
+		}
+		result := backtest(days, p)
+		if result.Trades == 0 {
+			return math.NaN()
+		}
+		return result.TotalProfit / float64(w.Days)
+	})
+	if err != nil {
+		fmt.Printf("  Skipped: %v\n", err)
+		return
+	}
+
+	fmt.Printf("  %-12s %-12s %-36s %9s %9s\n", "Train", "Test", "Chosen", "In $/day", "Out $/day")
+	for _, f := range r.Folds {
+		fmt.Printf("  %-12s %-12s %-36s %9.0f %9.0f\n",
+			f.Train.From[5:]+".."+f.Train.To[5:], f.Test.From[5:]+".."+f.Test.To[5:], f.Params, f.InSample, f.OutOfSample)
+	}
+	fmt.Println()
+	fmt.Printf("  In sample:      $%.0f/day\n", r.InSample)
+	fmt.Printf("  Out of sample:  $%.0f/day\n", r.OutOfSample)
+	fmt.Printf("  Degradation:    %.0f%%\n", r.Degradation()*100)
+	if !r.Stable(Parameters.String) {
+		fmt.Println("  Folds chose different parameters: the optimum moves with the days tested")
+	}
+	fmt.Println()
+	fmt.Printf("  💰 Annual Projection (out of sample): $%.0f\n", r.OutOfSample*365)
+	switch {
+	case r.OutOfSample <= 0:
+		fmt.Println("  ⚠️  Chosen parameters lose money out of sample: the recommended set is overfit to these days")
+	case r.Degradation() > 0.5:
+		fmt.Println("  ⚠️  Over half the in-sample profit is lost out of sample: expect the recommended set to fall well short of its backtest")
+	}
 }
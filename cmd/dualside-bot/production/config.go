@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/dualside"
 )
 
 // Config holds all production bot configuration
@@ -21,16 +25,181 @@ type Config struct {
 	TradingStartHour int
 	TradingEndHour   int
 
+	// Also trade the LOW temperature events
+	TradeLow bool
+
+	// Also trade the fade-the-favorite strategy on the events dualside
+	// passes on, with this many dollars on each runner-up YES
+	TradeFade bool
+	FadeBet   float64
+
+	// Hold entries while the order book of the favorite and adjacent
+	// brackets shows sell pressure on the side bought, until the pressure
+	// reaches ImbalanceMinPressure or ImbalanceMaxWait minutes pass
+	ImbalanceTiming      bool
+	ImbalanceMinPressure float64
+	ImbalanceMaxWait     int
+
+	// Append the book depths the bot reads to this file for backtests
+	// ("" = off)
+	BookRecordFile string
+
+	// Replace the main strategy's orders on the events it backs with the
+	// hedge set the model rates best: YES on the thesis and its neighbour,
+	// and NO legs on HedgeNoShare of the budget, losing money at most
+	// HedgeMaxLossProb of the time
+	Hedge            bool
+	HedgeBudget      float64
+	HedgeNoShare     float64
+	HedgeMaxLossProb float64
+
+	// EV gate at the model's probability of each order winning, expected
+	// win probability for sizing and ranking, and optional fee schedule file
+	EVGate          bool
+	ExpectedWinRate float64
+	FeeScheduleFile string
+
+	// Take-profit on near-certain positions (0 price disables)
+	TakeProfitPrice    int     // Sell when the held side is bid at or above this (cents)
+	TakeProfitFraction float64 // Share of the position to sell
+	TakeProfitMinHours float64 // Only while at least this many hours remain before close
+
+	// Exits that sell the whole position before settlement (0 disables)
+	StopLossPrice int     // Sell when the held side is bid at or below this (cents)
+	ExitMinProb   float64 // Sell when the model gives the held side less than this (0-1)
+	FlattenHour   int     // Sell whatever is still held from this local hour on
+
+	// Expiry guards in minutes before a market's close (0 disables)
+	NoEntryBeforeClose int // No new positions this close to the close
+	ThinBookWarning    int // Alert on positions still held this close to the close
+
+	// External signals posted to /control/signals: source name -> ensemble
+	// weight (1 = as much as a built-in signal), and the share of the weighted
+	// vote that must back the favorite
+	ExternalSignals    map[string]float64
+	MinSignalAgreement float64
+
+	// Per-phase polling cadence, entry order types and sizes, and exits, as
+	// strategy.ParsePhaseBehaviors rules over the defaults (empty = defaults)
+	PhaseRules string
+
+	// Markets disabled at startup (e.g. "DEN:LOW", "MIA")
+	DisabledMarkets []string
+
 	// Polling (fallback when WS unavailable)
 	PollInterval int // seconds
 
+	// Minutes without a successful METAR fetch or Kalshi API call before
+	// /health reports the bot unhealthy (at least two poll intervals)
+	HealthStaleAfter int
+
+	// Performance guard (backtest expectation for daily P&L)
+	ExpectedDailyPnL    float64
+	ExpectedDailyStdDev float64
+	GuardThreshold      float64 // CUSUM threshold in standard deviations
+	GuardMinDays        int
+
+	// Trade frequency throttle (0 = unlimited)
+	MaxPositionsPerDay  int     // New positions per rolling 24h
+	MaxPositionsPerWeek int     // New positions per rolling 7 days
+	MaxRiskPerWeek      float64 // Dollars of new positions per rolling 7 days
+
+	// Concentration limits as a share of bankroll (0 = unlimited)
+	MaxEventFraction float64 // Open cost in one event
+	MaxCityFraction  float64 // Open cost in one city's events on one day
+
+	// Share of bankroll kept as cash; bets shrink to fit above it (0 = none)
+	CashReserve float64
+
+	// This bot's hard limits; a breach cancels resting orders and halts
+	// trading until reset (0 = unlimited). Open cost per event and city is
+	// capped by MaxEventFraction and MaxCityFraction
+	MaxDailyLoss    float64 // Dollars of realized loss in a day
+	MaxTradesPerDay int     // Orders placed in a day
+	KillSwitchFile  string  // Halts trading while present (empty = DataDir/KILL); may be shared with other bots
+
+	// Failsafe bounds the REST client enforces on every order, far above the
+	// bets (0 = not enforced)
+	OrderMaxContracts int
+	OrderMaxPrice     int     // Cents
+	OrderMaxCost      float64 // Dollars
+
+	// Alternate API base URL orders fail over to after FailoverAfter failed
+	// attempts in a row on the primary, for FailoverCooldown minutes
+	// (empty = primary only)
+	FallbackAPIURL   string
+	FailoverAfter    int
+	FailoverCooldown int
+
+	// Synthetic weather in place of the live feed, as a pkg/weather scenario
+	// (e.g. "front=14:-8" or "default"); dry runs only, for demos (empty = live)
+	WeatherScenario string
+
+	// Orders are capped at CapacityFraction of the strategy's estimated
+	// capacity, saved by backtest-experiment capacity -out (empty = no cap)
+	CapacityFile     string
+	CapacityFraction float64
+
+	// Position sizing at the model's probability in place of the fixed bets,
+	// e.g. "kelly:0.25" or "fixed:0.02" (empty = BetYes/BetNo)
+	Sizing       string
+	MaxTradeRisk float64 // Dollars per order when sizing (0 = no cap)
+	MaxDailyRisk float64 // Dollars of new positions per day when sizing (0 = no cap)
+
+	// Weekly campaign: spread CampaignBudget over the week by the model's
+	// confidence in each day, nothing to the days below
+	// CampaignMinConfidence and at most CampaignMaxDay a day. The days
+	// ahead are planned at CampaignAhead until they come (0 budget = off)
+	CampaignBudget        float64
+	CampaignMinConfidence float64
+	CampaignMaxDay        float64 // 0 = no cap
+	CampaignAhead         float64
+	CampaignWeekStart     time.Weekday
+
+	// Compare the exchange's positions with the trades every tick, pausing
+	// entries in a market that differs by more than PositionTolerance
+	// contracts until acknowledged. Turn it off, or raise the tolerance,
+	// when other bots or hand trades share the account's weather markets
+	PositionCheck     bool
+	PositionTolerance int
+
+	// Dry runs: dollars a new paper account starts with (0 = the account's
+	// balance). The paper account is kept in DataDir across restarts
+	PaperBalance float64
+
+	// Look up the temperature series on the exchange at startup and point
+	// the stations at any that were renamed or newly listed
+	DiscoverSeries bool
+
+	// A/B test: trade a variant of the strategy, the ABVariant overrides of
+	// BET_YES, BET_NO, MIN_YES_PRICE, MAX_YES_PRICE, MIN_NO_PRICE,
+	// MAX_NO_PRICE, MAX_NO_TRADES and MIN_SIGNAL_AGREEMENT (e.g.
+	// "MIN_YES_PRICE=60,BET_NO=100"), on alternating days ("day") or half
+	// the cities each day ("city") chosen at random with ABSeed ("" = off)
+	ABTest    string
+	ABSeed    uint64
+	ABVariant string
+
 	// Notifications
 	SlackWebhookURL   string
 	DiscordWebhookURL string
+	SMTPAddr          string // SMTP server for email alerts, host:port ("" = no email)
+	SMTPUser          string // SMTP username ("" = no authentication)
+	SMTPPassword      string
+	EmailFrom         string
+	EmailTo           []string
+	NotifyDedup       int // Minutes a repeated alert is suppressed for
+	NotifyMaxPerHour  int // Alerts sent per hour at most (0 = unlimited)
+
+	// Cancel the resting orders before exiting on a panic
+	CancelOnPanic bool
 
 	// Server
-	HTTPPort int
-	LogLevel string
+	HTTPPort     int
+	ControlToken string // Bearer token control requests that change state need ("" = refuse them)
+	LogLevel     string
+	LogFormat string // console, text or json
+	LogFile   string // Also append JSON logs here ("" = none)
 
 	// Persistence
 	DataDir string
@@ -52,12 +221,97 @@ func DefaultConfig() *Config {
 		TradingStartHour: 7,
 		TradingEndHour:   14,
 
+		// Fade-the-favorite: off, $100 per runner-up when on
+		FadeBet: 100,
+
+		// Book timing: off; when on, wait up to an hour for the selling to
+		// ease to a mild imbalance
+		ImbalanceMinPressure: -0.2,
+		ImbalanceMaxWait:     60,
+
+		// Hedging: off; when on, $200 sets with up to 30% on NO legs
+		HedgeBudget:      200,
+		HedgeNoShare:     0.3,
+		HedgeMaxLossProb: 1.0 / 3,
+
+		// EV gate; sizing and ranking at the 95.8% backtest win rate
+		EVGate:          true,
+		ExpectedWinRate: 0.958,
+
+		// Take-profit: bank the last few cents' risk on locked positions
+		TakeProfitPrice:    97,
+		TakeProfitFraction: 1,
+		TakeProfitMinHours: 2,
+
+		// Expiry guards: the book thins out into the evening close
+		NoEntryBeforeClose: 30,
+		ThinBookWarning:    120,
+
+		// Signal agreement: unanimous, as the favorite and METAR must agree
+		MinSignalAgreement: 1,
+
 		// Polling
 		PollInterval: 60, // 1 minute
 
+		// Liveness: restart after half an hour without data
+		HealthStaleAfter: 30,
+
+		// Performance guard ($5,635 over 21 backtest days)
+		ExpectedDailyPnL:    268,
+		ExpectedDailyStdDev: 400,
+		GuardThreshold:      5,
+		GuardMinDays:        3,
+
+		// Throttle (7 cities x 1 YES + 4 NO = 35 positions on a full day)
+		MaxPositionsPerDay:  40,
+		MaxPositionsPerWeek: 200,
+		MaxRiskPerWeek:      60000,
+
+		// Concentration (a full YES + 4 NO stack is $1,100)
+		MaxEventFraction: 0.2,
+		MaxCityFraction:  0.25,
+
+		// Cash reserve (the full $1,100 stack fits above it from $1,375)
+		CashReserve: 0.2,
+
+		// Weekly campaign: off; when on, weeks from Monday with the days
+		// ahead at 50% and nothing below 35%
+		CampaignMinConfidence: 0.35,
+		CampaignAhead:         0.5,
+		CampaignWeekStart:     time.Monday,
+
+		// Position check: on, any difference counts
+		PositionCheck: true,
+
+		// Series discovery: on
+		DiscoverSeries: true,
+
+		// A/B test: off, seeded with 1 when turned on
+		ABSeed: 1,
+
+		// Failsafe order bounds, as rest.DefaultOrderBounds
+		OrderMaxContracts: 2000,
+		OrderMaxPrice:     97,
+		OrderMaxCost:      1000,
+
+		// Fail over on the second failed attempt, for 10 minutes
+		FailoverAfter:    2,
+		FailoverCooldown: 10,
+
+		// Half of what the archived volume says one order can take
+		CapacityFraction: 0.5,
+
+		// Alerts: a flapping signal raises one per 15 minutes
+		NotifyDedup:      15,
+		NotifyMaxPerHour: 30,
+
+		// Nothing fills while a crashed bot restarts
+		CancelOnPanic: true,
+
 		// Server
-		HTTPPort: 8080,
-		LogLevel: "info",
+		HTTPPort:  8080,
+		LogLevel:  "info",
+		LogFormat: "console",
 
 		// Persistence
 		DataDir: "./data",
@@ -115,25 +369,384 @@ func LoadConfig() (*Config, error) {
 			cfg.TradingEndHour = i
 		}
 	}
+	if v := os.Getenv("TRADE_LOW"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.TradeLow = b
+		}
+	}
+	if v := os.Getenv("TRADE_FADE"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.TradeFade = b
+		}
+	}
+	if v := os.Getenv("FADE_BET"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.FadeBet = f
+		}
+	}
+	if v := os.Getenv("IMBALANCE_TIMING"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.ImbalanceTiming = b
+		}
+	}
+	if v := os.Getenv("IMBALANCE_MIN_PRESSURE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.ImbalanceMinPressure = f
+		}
+	}
+	if v := os.Getenv("IMBALANCE_MAX_WAIT"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.ImbalanceMaxWait = i
+		}
+	}
+	if v := os.Getenv("BOOK_RECORD_FILE"); v != "" {
+		cfg.BookRecordFile = v
+	}
+	if v := os.Getenv("HEDGE"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Hedge = b
+		}
+	}
+	if v := os.Getenv("HEDGE_BUDGET"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.HedgeBudget = f
+		}
+	}
+	if v := os.Getenv("HEDGE_NO_SHARE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.HedgeNoShare = f
+		}
+	}
+	if v := os.Getenv("HEDGE_MAX_LOSS_PROB"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.HedgeMaxLossProb = f
+		}
+	}
+	if v := os.Getenv("EV_GATE"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.EVGate = b
+		}
+	}
+	if v := os.Getenv("EXPECTED_WIN_RATE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.ExpectedWinRate = f
+		}
+	}
+	if v := os.Getenv("TAKE_PROFIT_PRICE"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.TakeProfitPrice = i
+		}
+	}
+	if v := os.Getenv("TAKE_PROFIT_FRACTION"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.TakeProfitFraction = f
+		}
+	}
+	if v := os.Getenv("TAKE_PROFIT_MIN_HOURS"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.TakeProfitMinHours = f
+		}
+	}
+	if v := os.Getenv("STOP_LOSS_PRICE"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.StopLossPrice = i
+		}
+	}
+	if v := os.Getenv("EXIT_MIN_PROB"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.ExitMinProb = f
+		}
+	}
+	if v := os.Getenv("FLATTEN_HOUR"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.FlattenHour = i
+		}
+	}
+	if v := os.Getenv("NO_ENTRY_BEFORE_CLOSE"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.NoEntryBeforeClose = i
+		}
+	}
+	if v := os.Getenv("THIN_BOOK_WARNING"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.ThinBookWarning = i
+		}
+	}
+	if v := os.Getenv("FEE_SCHEDULE_FILE"); v != "" {
+		cfg.FeeScheduleFile = v
+	}
+	if v := os.Getenv("DISABLED_MARKETS"); v != "" {
+		for _, key := range strings.Split(v, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				cfg.DisabledMarkets = append(cfg.DisabledMarkets, key)
+			}
+		}
+	}
+	if v := os.Getenv("EXTERNAL_SIGNALS"); v != "" {
+		cfg.ExternalSignals = make(map[string]float64)
+		for _, entry := range strings.Split(v, ",") {
+			if entry = strings.TrimSpace(entry); entry == "" {
+				continue
+			}
+			name, weight, found := strings.Cut(entry, ":")
+			w := 1.0
+			if found {
+				f, err := strconv.ParseFloat(weight, 64)
+				if err != nil || f < 0 {
+					return nil, fmt.Errorf("invalid EXTERNAL_SIGNALS weight %q for %s", weight, name)
+				}
+				w = f
+			}
+			cfg.ExternalSignals[strings.TrimSpace(name)] = w
+		}
+	}
+	if v := os.Getenv("MIN_SIGNAL_AGREEMENT"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.MinSignalAgreement = f
+		}
+	}
+	if v := os.Getenv("PHASE_RULES"); v != "" {
+		cfg.PhaseRules = v
+	}
 	if v := os.Getenv("POLL_INTERVAL"); v != "" {
 		if i, err := strconv.Atoi(v); err == nil {
 			cfg.PollInterval = i
 		}
 	}
+	if v := os.Getenv("HEALTH_STALE_AFTER"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.HealthStaleAfter = i
+		}
+	}
+	if v := os.Getenv("EXPECTED_DAILY_PNL"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.ExpectedDailyPnL = f
+		}
+	}
+	if v := os.Getenv("EXPECTED_DAILY_STDDEV"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.ExpectedDailyStdDev = f
+		}
+	}
+	if v := os.Getenv("GUARD_THRESHOLD"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.GuardThreshold = f
+		}
+	}
+	if v := os.Getenv("GUARD_MIN_DAYS"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.GuardMinDays = i
+		}
+	}
+	if v := os.Getenv("MAX_POSITIONS_PER_DAY"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.MaxPositionsPerDay = i
+		}
+	}
+	if v := os.Getenv("MAX_POSITIONS_PER_WEEK"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.MaxPositionsPerWeek = i
+		}
+	}
+	if v := os.Getenv("MAX_RISK_PER_WEEK"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.MaxRiskPerWeek = f
+		}
+	}
+	if v := os.Getenv("MAX_EVENT_FRACTION"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.MaxEventFraction = f
+		}
+	}
+	if v := os.Getenv("MAX_CITY_FRACTION"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.MaxCityFraction = f
+		}
+	}
+	if v := os.Getenv("CASH_RESERVE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.CashReserve = f
+		}
+	}
+	if v := os.Getenv("MAX_DAILY_LOSS"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.MaxDailyLoss = f
+		}
+	}
+	if v := os.Getenv("MAX_TRADES_PER_DAY"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.MaxTradesPerDay = i
+		}
+	}
+	if v := os.Getenv("KILL_SWITCH_FILE"); v != "" {
+		cfg.KillSwitchFile = v
+	}
+	if v := os.Getenv("ORDER_MAX_CONTRACTS"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.OrderMaxContracts = i
+		}
+	}
+	if v := os.Getenv("ORDER_MAX_PRICE"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.OrderMaxPrice = i
+		}
+	}
+	if v := os.Getenv("ORDER_MAX_COST"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.OrderMaxCost = f
+		}
+	}
+	if v := os.Getenv("FALLBACK_API_URL"); v != "" {
+		cfg.FallbackAPIURL = v
+	}
+	if v := os.Getenv("FAILOVER_AFTER"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.FailoverAfter = i
+		}
+	}
+	if v := os.Getenv("FAILOVER_COOLDOWN"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.FailoverCooldown = i
+		}
+	}
+	if v := os.Getenv("WEATHER_SCENARIO"); v != "" {
+		cfg.WeatherScenario = v
+	}
+	if v := os.Getenv("CAPACITY_FILE"); v != "" {
+		cfg.CapacityFile = v
+	}
+	if v := os.Getenv("CAPACITY_FRACTION"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.CapacityFraction = f
+		}
+	}
+	if v := os.Getenv("SIZING"); v != "" {
+		cfg.Sizing = v
+	}
+	if v := os.Getenv("MAX_TRADE_RISK"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.MaxTradeRisk = f
+		}
+	}
+	if v := os.Getenv("MAX_DAILY_RISK"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.MaxDailyRisk = f
+		}
+	}
+	if v := os.Getenv("CAMPAIGN_BUDGET"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.CampaignBudget = f
+		}
+	}
+	if v := os.Getenv("CAMPAIGN_MIN_CONFIDENCE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.CampaignMinConfidence = f
+		}
+	}
+	if v := os.Getenv("CAMPAIGN_MAX_DAY"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.CampaignMaxDay = f
+		}
+	}
+	if v := os.Getenv("CAMPAIGN_AHEAD"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.CampaignAhead = f
+		}
+	}
+	if v := os.Getenv("CAMPAIGN_WEEK_START"); v != "" {
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.EqualFold(v, d.String()) {
+				cfg.CampaignWeekStart = d
+			}
+		}
+	}
+	if v := os.Getenv("POSITION_CHECK"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.PositionCheck = b
+		}
+	}
+	if v := os.Getenv("POSITION_TOLERANCE"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.PositionTolerance = i
+		}
+	}
+	if v := os.Getenv("PAPER_BALANCE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			cfg.PaperBalance = f
+		}
+	}
+	if v := os.Getenv("DISCOVER_SERIES"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.DiscoverSeries = b
+		}
+	}
+	if v := os.Getenv("AB_TEST"); v != "" {
+		cfg.ABTest = v
+	}
+	if v := os.Getenv("AB_SEED"); v != "" {
+		if i, err := strconv.ParseUint(v, 10, 64); err == nil {
+			cfg.ABSeed = i
+		}
+	}
+	if v := os.Getenv("AB_VARIANT"); v != "" {
+		cfg.ABVariant = v
+	}
 	if v := os.Getenv("SLACK_WEBHOOK_URL"); v != "" {
 		cfg.SlackWebhookURL = v
 	}
 	if v := os.Getenv("DISCORD_WEBHOOK_URL"); v != "" {
 		cfg.DiscordWebhookURL = v
 	}
+	if v := os.Getenv("SMTP_ADDR"); v != "" {
+		cfg.SMTPAddr = v
+	}
+	if v := os.Getenv("SMTP_USER"); v != "" {
+		cfg.SMTPUser = v
+	}
+	if v := os.Getenv("SMTP_PASSWORD"); v != "" {
+		cfg.SMTPPassword = v
+	}
+	if v := os.Getenv("EMAIL_FROM"); v != "" {
+		cfg.EmailFrom = v
+	}
+	if v := os.Getenv("EMAIL_TO"); v != "" {
+		cfg.EmailTo = nil
+		for _, addr := range strings.Split(v, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				cfg.EmailTo = append(cfg.EmailTo, addr)
+			}
+		}
+	}
+	if v := os.Getenv("NOTIFY_DEDUP_MINUTES"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.NotifyDedup = i
+		}
+	}
+	if v := os.Getenv("NOTIFY_MAX_PER_HOUR"); v != "" {
+		if i, err := strconv.Atoi(v); err == nil {
+			cfg.NotifyMaxPerHour = i
+		}
+	}
+	if v := os.Getenv("CANCEL_ON_PANIC"); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.CancelOnPanic = b
+		}
+	}
 	if v := os.Getenv("HTTP_PORT"); v != "" {
 		if i, err := strconv.Atoi(v); err == nil {
 			cfg.HTTPPort = i
 		}
 	}
+	cfg.ControlToken = os.Getenv("CONTROL_TOKEN")
 	if v := os.Getenv("LOG_LEVEL"); v != "" {
 		cfg.LogLevel = v
 	}
+	if v := os.Getenv("LOG_FORMAT"); v != "" {
+		cfg.LogFormat = v
+	}
+	if v := os.Getenv("LOG_FILE"); v != "" {
+		cfg.LogFile = v
+	}
 	if v := os.Getenv("DATA_DIR"); v != "" {
 		cfg.DataDir = v
 	}
@@ -141,6 +754,48 @@ func LoadConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// parseVariant applies the KEY=VALUE overrides of an A/B test variant,
+// named as the environment variables, to the strategy parameters in base
+func parseVariant(s string, base dualside.Config) (dualside.Config, error) {
+	for _, kv := range strings.Split(s, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		key, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return base, fmt.Errorf("%q: want KEY=VALUE", kv)
+		}
+		key, v = strings.ToUpper(strings.TrimSpace(key)), strings.TrimSpace(v)
+
+		var err error
+		switch key {
+		case "BET_YES":
+			base.BetYes, err = strconv.ParseFloat(v, 64)
+		case "BET_NO":
+			base.BetNo, err = strconv.ParseFloat(v, 64)
+		case "MIN_YES_PRICE":
+			base.MinYesPrice, err = strconv.Atoi(v)
+		case "MAX_YES_PRICE":
+			base.MaxYesPrice, err = strconv.Atoi(v)
+		case "MIN_NO_PRICE":
+			base.MinNoPrice, err = strconv.Atoi(v)
+		case "MAX_NO_PRICE":
+			base.MaxNoPrice, err = strconv.Atoi(v)
+		case "MAX_NO_TRADES":
+			base.MaxNoTrades, err = strconv.Atoi(v)
+		case "MIN_SIGNAL_AGREEMENT":
+			base.MinSignalAgreement, err = strconv.ParseFloat(v, 64)
+		default:
+			return base, fmt.Errorf("%s can't be varied", key)
+		}
+		if err != nil {
+			return base, fmt.Errorf("%s: invalid value %q", key, v)
+		}
+	}
+	return base, nil
+}
+
 // String returns a safe string representation (no secrets)
 func (c *Config) String() string {
 	return fmt.Sprintf(
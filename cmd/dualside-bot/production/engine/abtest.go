@@ -0,0 +1,151 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/dualside"
+)
+
+// ABConfig trades a second variant of the dualside strategy, B, on the
+// city-days Test assigns to it, and the configured one, A, on the rest
+type ABConfig struct {
+	Test strategy.ABTest
+
+	// B's parameters. Its trading window and phases are A's, so both
+	// variants enter and exit on the same lifecycle
+	Variant dualside.Config
+}
+
+// ABLog holds the outcome of each city-day traded under an A/B test,
+// persisted to a JSON file. A city-day is recorded, at $0, as soon as its
+// variant is consulted, so days it passed on count too
+type ABLog struct {
+	mu       sync.RWMutex
+	path     string
+	outcomes []strategy.ABOutcome
+}
+
+// NewABLog loads the outcomes from path (if present)
+func NewABLog(path string) (*ABLog, error) {
+	l := &ABLog{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return l, nil
+		}
+		return nil, fmt.Errorf("read A/B log: %w", err)
+	}
+	if err := json.Unmarshal(data, &l.outcomes); err != nil {
+		return nil, fmt.Errorf("parse A/B log: %w", err)
+	}
+	return l, nil
+}
+
+// Outcomes returns the recorded city-days, oldest first
+func (l *ABLog) Outcomes() []strategy.ABOutcome {
+	if l == nil {
+		return nil
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return append([]strategy.ABOutcome(nil), l.outcomes...)
+}
+
+// Add adds pnl to the city-day's outcome, recording it under variant if it
+// is new. A nil log records nothing
+func (l *ABLog) Add(city, date, variant string, pnl float64) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	i := sort.Search(len(l.outcomes), func(i int) bool {
+		o := l.outcomes[i]
+		return o.Date > date || o.Date == date && o.City >= city
+	})
+	if i < len(l.outcomes) && l.outcomes[i].Date == date && l.outcomes[i].City == city {
+		if pnl == 0 {
+			l.mu.Unlock()
+			return nil
+		}
+		l.outcomes[i].PnL += pnl
+	} else {
+		l.outcomes = append(l.outcomes, strategy.ABOutcome{})
+		copy(l.outcomes[i+1:], l.outcomes[i:])
+		l.outcomes[i] = strategy.ABOutcome{City: city, Date: date, Variant: variant, PnL: pnl}
+	}
+	snapshot := append([]strategy.ABOutcome(nil), l.outcomes...)
+	l.mu.Unlock()
+
+	return l.save(snapshot)
+}
+
+func (l *ABLog) save(snapshot []strategy.ABOutcome) error {
+	if l.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.path)
+}
+
+// SetABLog attaches the log of the A/B test's city-days (nil = kept in
+// memory until restart)
+func (e *Engine) SetABLog(abLog *ABLog) {
+	e.abLog = abLog
+}
+
+// abVariant returns the variant the station trades on its local day, and
+// records the city-day as tested ("" = no test)
+func (e *Engine) abVariant(station Station, localTime time.Time) string {
+	if e.config.AB == nil {
+		return ""
+	}
+	variant := e.config.AB.Test.Assign(station.Code, localTime)
+	if err := e.abLog.Add(station.Code, localTime.Format("2006-01-02"), variant, 0); err != nil {
+		log.Printf("[Engine] Failed to save A/B log: %v", err)
+	}
+	return variant
+}
+
+// recordAB adds a settled event's P&L to its city-day under the A/B test
+func (e *Engine) recordAB(eventTicker string, trades []Trade, pnl float64) {
+	if e.config.AB == nil || len(trades) == 0 || trades[0].Variant == "" {
+		return
+	}
+	station, day, ok := eventDay(eventTicker)
+	if !ok {
+		return
+	}
+	if err := e.abLog.Add(station.Code, day.Format("2006-01-02"), trades[0].Variant, pnl); err != nil {
+		log.Printf("[Engine] Failed to save A/B log: %v", err)
+	}
+}
+
+// ABReport compares the A/B test's variants on the city-days recorded so
+// far (nil = no test)
+func (e *Engine) ABReport() *strategy.ABReport {
+	if e.config.AB == nil {
+		return nil
+	}
+	r := e.config.AB.Test.Compare(e.abLog.Outcomes(), stats.DefaultBootstrapConfig())
+	return &r
+}
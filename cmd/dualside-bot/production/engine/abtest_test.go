@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+func TestABLog_Add(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ab.json")
+	l, err := NewABLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range []struct {
+		city, date, variant string
+		pnl                 float64
+	}{
+		{"LAX", "2026-03-10", "B", 0},
+		{"NYC", "2026-03-09", "A", 0},
+		{"LAX", "2026-03-10", "A", 2.5}, // Added to the day's first record
+		{"LAX", "2026-03-09", "A", -1},
+	} {
+		if err := l.Add(a.city, a.date, a.variant, a.pnl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []strategy.ABOutcome{
+		{City: "LAX", Date: "2026-03-09", Variant: "A", PnL: -1},
+		{City: "NYC", Date: "2026-03-09", Variant: "A"},
+		{City: "LAX", Date: "2026-03-10", Variant: "B", PnL: 2.5},
+	}
+	if got := l.Outcomes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Outcomes() = %v, want %v", got, want)
+	}
+	reloaded, err := NewABLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Outcomes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("reloaded Outcomes() = %v, want %v", got, want)
+	}
+
+	var none *ABLog
+	if err := none.Add("LAX", "2026-03-10", "A", 1); err != nil {
+		t.Errorf("nil log: Add() error = %v", err)
+	}
+	if none.Outcomes() != nil {
+		t.Errorf("nil log: Outcomes() = %v, want nil", none.Outcomes())
+	}
+}
@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/sizing"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// defaultLockHours is how long an entry is assumed to tie up its capital
+// when its market's close time is unknown
+const defaultLockHours = 24
+
+// candidate is one of the strategy's orders waiting for capital
+type candidate struct {
+	station     Station
+	eventTicker string
+	market      Market
+	bracket     string
+	order       strategy.Order
+	override    Override
+	overridden  bool
+	strategy    string             // Strategy that gave the order ("" = dualside)
+	variant     string             // A/B test variant ("" = no test)
+	metar       *weather.METARData // Reading the order was decided on
+	prob        float64            // Model's probability the order wins, for the EV gate
+	hours       float64            // Until the market closes and frees the capital
+	score       float64            // Expected return per dollar-hour (0 without a win rate)
+}
+
+// newCandidate scores an order by its expected return per dollar-hour at
+// the configured win rate, so capital goes to the trades that turn it over
+// fastest: a 90¢ NO locked up until the evening close earns little per hour
+// however likely it is to win
+func (e *Engine) newCandidate(station Station, eventTicker string, m Market, o strategy.Order, now time.Time) candidate {
+	c := candidate{
+		station:     station,
+		eventTicker: eventTicker,
+		market:      m,
+		bracket:     m.Strike.String(),
+		order:       o,
+		hours:       defaultLockHours,
+	}
+	if closes := strategy.ParseCloseTime(m.CloseTime); !closes.IsZero() {
+		c.hours = closes.Sub(now).Hours()
+	}
+	if e.config.WinRate > 0 {
+		rule := e.fees.RuleForTicker(m.Ticker, now)
+		c.score = sizing.ReturnPerDollarHour(sizing.Bet{
+			Prob:   e.config.WinRate,
+			Price:  o.Price,
+			Fee:    rule.EntryFee(fees.Maker, 100, o.Price),
+			WinFee: rule.SettlementFee(fees.Maker, 100, o.Price, true),
+		}, c.hours)
+	}
+	return c
+}
+
+// allocate places the tick's orders across all stations best capital
+// turnover first: highest expected return per dollar-hour, then the
+// soonest close. Orders further down the list are the ones the cash reserve,
+// daily budget and risk limits cut when capital runs short. Each station's
+// orders keep their relative order on ties, so a stack's legs stay in the
+// order the strategy gave them
+func (e *Engine) allocate(candidates []candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].hours < candidates[j].hours
+	})
+	if len(candidates) > 1 {
+		ranked := make([]string, len(candidates))
+		for i, c := range candidates {
+			ranked[i] = fmt.Sprintf("%s %s %d¢ %.2f%%/$·h over %.1fh",
+				c.station.Code, strings.ToUpper(c.order.Side), c.order.Price, c.score*100, c.hours)
+		}
+		log.Printf("[Engine] Allocating %d orders by return per dollar-hour: %s", len(candidates), strings.Join(ranked, ", "))
+	}
+
+	// Positions are recorded as they fill so the concentration limits see
+	// the earlier legs of the stack
+	for i, c := range candidates {
+		e.mu.RLock()
+		halted := e.entriesHalted
+		e.mu.RUnlock()
+		if halted {
+			log.Printf("[Engine] Entries halted by insufficient funds, skipping %d orders until the next tick", len(candidates)-i)
+			break
+		}
+		trade, err := e.executeOrder(c.station, c.eventTicker, c.market, c.bracket, c.strategy, c.order, c.prob, c.metar)
+		if err != nil {
+			log.Printf("[Engine] %s: %s trade failed: %v", c.station.City, strings.ToUpper(c.order.Side), err)
+			if e.onError != nil && !errors.Is(err, ErrRiskLimit) {
+				e.onError(err)
+			}
+			continue
+		}
+		if trade == nil {
+			continue
+		}
+		if c.overridden {
+			trade.Override = c.override.String()
+		}
+		trade.Variant = c.variant
+		e.mu.Lock()
+		e.positions[c.eventTicker] = append(e.positions[c.eventTicker], *trade)
+		e.mu.Unlock()
+		if e.onTrade != nil {
+			e.onTrade(*trade)
+		}
+	}
+}
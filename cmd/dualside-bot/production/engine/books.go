@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// quoteBooks reads the order books of the favorite and its adjacent
+// brackets into their quotes' depths, for the book timing, and records them
+// to BookRecordFile. It does nothing unless one of them is on
+func (e *Engine) quoteBooks(data *strategy.MarketData, now time.Time) {
+	if e.timing == nil && e.config.BookRecordFile == "" {
+		return
+	}
+	fav := data.Favorite()
+	if fav == nil {
+		return
+	}
+
+	var snapshots []backtest.BookSnapshot
+	for i := range data.Quotes {
+		if data.Quotes[i].Ticker != fav.Ticker {
+			continue
+		}
+		for j := max(i-1, 0); j <= min(i+1, len(data.Quotes)-1); j++ {
+			q := &data.Quotes[j]
+			book, err := e.executor.client.GetOrderbook(q.Ticker, 0)
+			if err != nil {
+				log.Printf("[Engine] %s: Failed to fetch order book: %v", q.Ticker, err)
+				continue
+			}
+			q.YesDepth = book.Depth("yes", strategy.DepthLevels)
+			q.NoDepth = book.Depth("no", strategy.DepthLevels)
+			snapshots = append(snapshots, backtest.BookSnapshot{Time: now, Ticker: q.Ticker, YesDepth: q.YesDepth, NoDepth: q.NoDepth})
+		}
+		break
+	}
+
+	if p, ok := data.Pressure(fav.Ticker); ok {
+		log.Printf("[Engine] %s: Book pressure on favorite %s: %+.2f", data.EventTicker, fav.Ticker, p)
+	}
+	if e.config.BookRecordFile != "" {
+		if err := appendBooks(e.config.BookRecordFile, snapshots); err != nil {
+			log.Printf("[Engine] Failed to record order books: %v", err)
+		}
+	}
+}
+
+// appendBooks appends book snapshots to a JSON-lines file, as
+// backtest.LoadBookSnapshots reads them
+func appendBooks(path string, snapshots []backtest.BookSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, s := range snapshots {
+		if err := enc.Encode(s); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
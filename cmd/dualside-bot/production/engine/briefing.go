@@ -0,0 +1,192 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sizeTolerance is how far, as a share of the plan, the contracts bought
+// on a planned leg may stray before the day report calls it resized
+const sizeTolerance = 0.2
+
+// PlannedTrade is an order the morning briefing expects the main strategy
+// to place
+type PlannedTrade struct {
+	City        string `json:"city"`
+	EventTicker string `json:"event_ticker"`
+	Ticker      string `json:"ticker"`
+	Bracket     string `json:"bracket"`
+	Side        string `json:"side"`
+	Price       int    `json:"price"` // Cents; the average paid for trades
+	Quantity    int    `json:"quantity"`
+}
+
+// Briefing is the morning plan of a local trading day: what the main
+// strategy would buy on each event if the signals backed the market
+// favorite, planned on the prices when the event was first seen that day
+type Briefing struct {
+	Date   string
+	Trades []PlannedTrade
+	Events map[string]time.Time // EventTicker -> when it was planned
+}
+
+// Resize is a planned leg bought at a size off the plan
+type Resize struct {
+	PlannedTrade
+	Bought int `json:"bought"`
+}
+
+// PlanDiff compares what was traded on a day with its briefing
+type PlanDiff struct {
+	Planned   int            `json:"planned"`             // Legs planned
+	Skipped   []PlannedTrade `json:"skipped,omitempty"`   // Planned, never bought
+	Unplanned []PlannedTrade `json:"unplanned,omitempty"` // Bought on a briefed event without a plan
+	Resized   []Resize       `json:"resized,omitempty"`   // Bought more than sizeTolerance off the plan
+	Unbriefed []string       `json:"unbriefed,omitempty"` // Events traded without a briefing, e.g. after a restart
+}
+
+// brief plans an event into its day's briefing the first time it is seen
+// without a position. A restart re-plans the events it has no position on
+// yet; those it has are left out of the diff as unbriefed
+func (e *Engine) brief(station Station, city, marketType, eventTicker string, localTime, now time.Time) {
+	date := localTime.Format("2006-01-02")
+	e.mu.RLock()
+	_, planned := e.briefings[date].events()[eventTicker]
+	_, hasPosition := e.positions[eventTicker]
+	e.mu.RUnlock()
+	if planned || hasPosition {
+		return
+	}
+
+	markets, err := e.fetchMarkets(eventTicker)
+	if err != nil || len(markets) == 0 {
+		return
+	}
+	data, byTicker := e.quote(station, marketType, eventTicker, markets, localTime, now)
+	var trades []PlannedTrade
+	for _, o := range e.strategy.Plan(data) {
+		m := byTicker[o.Ticker]
+		trades = append(trades, PlannedTrade{
+			City:        station.City,
+			EventTicker: eventTicker,
+			Ticker:      o.Ticker,
+			Bracket:     m.Strike.String(),
+			Side:        o.Side,
+			Price:       o.Price,
+			Quantity:    o.Quantity,
+		})
+	}
+
+	e.mu.Lock()
+	b := e.briefings[date]
+	if b == nil {
+		b = &Briefing{Date: date, Events: make(map[string]time.Time)}
+		e.briefings[date] = b
+		// Days without a settled position never report; drop them
+		for d := range e.briefings {
+			if d < localTime.AddDate(0, 0, -7).Format("2006-01-02") {
+				delete(e.briefings, d)
+			}
+		}
+	}
+	b.Events[eventTicker] = now
+	b.Trades = append(b.Trades, trades...)
+	e.mu.Unlock()
+
+	if len(trades) == 0 {
+		log.Printf("[Engine] %s: Briefing for %s: no trade planned", city, eventTicker)
+		return
+	}
+	legs := make([]string, len(trades))
+	for i, t := range trades {
+		legs[i] = fmt.Sprintf("%s %s %d @ %d¢", strings.ToUpper(t.Side), t.Bracket, t.Quantity, t.Price)
+	}
+	log.Printf("[Engine] %s: Briefing for %s: if the signals agree, %s", city, eventTicker, strings.Join(legs, ", "))
+}
+
+// events returns the events planned; nil for a nil Briefing
+func (b *Briefing) events() map[string]time.Time {
+	if b == nil {
+		return nil
+	}
+	return b.Events
+}
+
+// Diff compares trades, the day's buys, with the briefing; nil for a nil
+// Briefing
+func (b *Briefing) Diff(trades []Trade) *PlanDiff {
+	if b == nil {
+		return nil
+	}
+	type leg struct{ event, ticker, side string }
+	bought := make(map[leg]PlannedTrade)
+	var order []leg
+	unbriefed := make(map[string]bool)
+	for _, t := range trades {
+		if t.Action != "buy" || t.Status == "error" {
+			continue
+		}
+		if _, ok := b.Events[t.EventTicker]; !ok {
+			unbriefed[t.EventTicker] = true
+			continue
+		}
+		k := leg{t.EventTicker, t.Ticker, t.Side}
+		p, ok := bought[k]
+		if !ok {
+			p = PlannedTrade{City: t.City, EventTicker: t.EventTicker, Ticker: t.Ticker, Bracket: t.Bracket, Side: t.Side}
+			order = append(order, k)
+		}
+		// Price holds the cost in cents until the average is taken below
+		p.Price += t.Price * t.Quantity
+		p.Quantity += t.Quantity
+		bought[k] = p
+	}
+
+	d := &PlanDiff{Planned: len(b.Trades)}
+	for _, p := range b.Trades {
+		k := leg{p.EventTicker, p.Ticker, p.Side}
+		got, ok := bought[k]
+		delete(bought, k)
+		switch {
+		case !ok || got.Quantity == 0:
+			d.Skipped = append(d.Skipped, p)
+		case math.Abs(float64(got.Quantity-p.Quantity)) > sizeTolerance*float64(p.Quantity):
+			d.Resized = append(d.Resized, Resize{PlannedTrade: p, Bought: got.Quantity})
+		}
+	}
+	for _, k := range order {
+		if p, ok := bought[k]; ok && p.Quantity > 0 {
+			p.Price = int(math.Round(float64(p.Price) / float64(p.Quantity)))
+			d.Unplanned = append(d.Unplanned, p)
+		}
+	}
+	for event := range unbriefed {
+		d.Unbriefed = append(d.Unbriefed, event)
+	}
+	sort.Strings(d.Unbriefed)
+	return d
+}
+
+// String formats the diff for the day report, e.g. "Plan: 4 legs, 1
+// skipped, 2 unplanned"
+func (d *PlanDiff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Plan: %d legs, %d skipped, %d unplanned, %d resized", d.Planned, len(d.Skipped), len(d.Unplanned), len(d.Resized))
+	for _, t := range d.Skipped {
+		fmt.Fprintf(&b, "\n  ⏭ skipped %s %s %s %d @ %d¢", t.City, strings.ToUpper(t.Side), t.Bracket, t.Quantity, t.Price)
+	}
+	for _, t := range d.Unplanned {
+		fmt.Fprintf(&b, "\n  ➕ unplanned %s %s %s %d @ %d¢", t.City, strings.ToUpper(t.Side), t.Bracket, t.Quantity, t.Price)
+	}
+	for _, r := range d.Resized {
+		fmt.Fprintf(&b, "\n  📏 resized %s %s %s: %d planned, %d bought", r.City, strings.ToUpper(r.Side), r.Bracket, r.Quantity, r.Bought)
+	}
+	if len(d.Unbriefed) > 0 {
+		fmt.Fprintf(&b, "\n  ❔ not briefed: %s", strings.Join(d.Unbriefed, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// calibrationBackfillDays is how many days before a station's latest
+// CLI-posted day its housekeeping looks for settled days the calibration is
+// missing: a market can settle hours after its CLI report posts
+const calibrationBackfillDays = 3
+
+// SetCalibration learns the METAR to CLI calibration per station and regime
+// from each station-day as its markets settle (nil = no learning). The
+// callers of Calibration persist it for the other bots
+func (e *Engine) SetCalibration(c *model.Calibration) {
+	e.calibration = c
+}
+
+// Calibration returns the learned calibration, or nil
+func (e *Engine) Calibration() *model.Calibration {
+	return e.calibration
+}
+
+// calibrate records the station's settled HIGH and LOW days up to day in
+// the calibration, reporting whether any was new
+func (e *Engine) calibrate(station Station, day time.Time) bool {
+	if e.calibration == nil {
+		return false
+	}
+	added := false
+	for i := range calibrationBackfillDays {
+		d := day.AddDate(0, 0, -i)
+		for _, marketType := range []string{MarketHigh, MarketLow} {
+			if station.prefix(marketType) == "" {
+				continue
+			}
+			settled, err := e.settledDay(station, marketType, d)
+			if err != nil {
+				log.Printf("[Engine] %s: Calibration skipped %s %s: %v", station.City, marketType, d.Format("2006-01-02"), err)
+				continue
+			}
+			if settled == nil || !e.calibration.Observe(*settled) {
+				continue
+			}
+			added = true
+			date, _ := time.Parse("2006-01-02", settled.Date)
+			est := e.calibration.Estimate(station.Code, settled.Low, model.RegimeOf(date, settled.METAR))
+			log.Printf("[Engine] %s: %s %s settled at %d° (METAR %d°), calibration for %s now %+.2f° (%+.2f to %+.2f, %d days)",
+				station.City, marketType, settled.Date, settled.CLI, settled.METAR, est.Regime, est.Offset, est.Lower, est.Upper, est.Days)
+			if ladder, ok := e.calibration.Close(station.Code, settled.Low, settled.Date); ok {
+				modelBrier, marketBrier := ladder.Brier(settled.CLI)
+				log.Printf("[Engine] %s: %s %s closing ladder Brier: model %.3f, market %.3f (lower is better)",
+					station.City, marketType, settled.Date, modelBrier, marketBrier)
+			}
+		}
+	}
+	return added
+}
+
+// settledDay returns the official value and METAR extreme of the station's
+// event of marketType on day, or nil if it is already recorded or its
+// markets haven't settled
+func (e *Engine) settledDay(station Station, marketType string, day time.Time) (*model.SettledDay, error) {
+	date := day.Format("2006-01-02")
+	low := marketType == MarketLow
+	if e.calibration.Has(station.Code, low, date) {
+		return nil, nil
+	}
+
+	eventTicker := fmt.Sprintf("%s-%s", station.prefix(marketType), strings.ToUpper(day.Format("06Jan02")))
+	markets, err := e.fetchBrackets(eventTicker)
+	if err != nil {
+		return nil, err
+	}
+	var official float64
+	settled := false
+	for _, m := range markets {
+		if v, err := strconv.ParseFloat(m.ExpirationValue, 64); err == nil {
+			official, settled = v, true
+			break
+		}
+	}
+	if !settled {
+		return nil, nil
+	}
+
+	ws := weather.StationByCode(station.Code)
+	if ws == nil {
+		return nil, fmt.Errorf("no weather station %s", station.Code)
+	}
+	data, err := weather.DailyMax(context.Background(), e.observations, ws, day)
+	if err != nil {
+		return nil, fmt.Errorf("METAR: %w", err)
+	}
+	metar := data.MaxTemp
+	if low {
+		metar = data.MinTemp
+	}
+	return &model.SettledDay{
+		Station: station.Code,
+		Date:    date,
+		Low:     low,
+		METAR:   int(math.Round(metar)),
+		CLI:     int(math.Round(official)),
+	}, nil
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/sizing"
+)
+
+// CampaignConfig spreads a weekly budget over the days of the week by the
+// model's confidence in each, and caps each day's new positions at its share
+type CampaignConfig struct {
+	sizing.Campaign
+	WeekStart time.Weekday // First day of the campaign's week
+	Ahead     float64      // Confidence assumed for the days after today, which the model can't see yet
+}
+
+// campaignDay is the campaign as planned on a day
+type campaignDay struct {
+	date   string
+	budget float64 // Today's share
+	plan   sizing.CampaignPlan
+}
+
+// campaignSize cuts an order to what is left of today's campaign budget at
+// price; 0 means the day's budget is spent
+func (e *Engine) campaignSize(station Station, ticker string, contracts, price int) int {
+	if e.config.Campaign == nil || price <= 0 {
+		return contracts
+	}
+	now := time.Now()
+	left := e.campaignDay(now).budget - e.risk.SpentSince(midnight(now))
+	affordable := max(int(left*100/float64(price)), 0)
+	if affordable < contracts {
+		log.Printf("[Engine] %s: Sizing %s down to %d contracts, $%.2f left of today's campaign budget",
+			station.City, ticker, affordable, max(left, 0))
+		return affordable
+	}
+	return contracts
+}
+
+// campaignDay returns today's plan, planning the rest of the week on the
+// day's first order, when the model has the morning's observations to go on
+func (e *Engine) campaignDay(now time.Time) *campaignDay {
+	today := now.Format("2006-01-02")
+	e.mu.RLock()
+	day := e.campaign
+	e.mu.RUnlock()
+	if day != nil && day.date == today {
+		return day
+	}
+
+	c := e.config.Campaign
+	start := midnight(now)
+	week := sizing.WeekStart(now, c.WeekStart)
+	var days []sizing.CampaignDay
+	for d := start; d.Before(week.AddDate(0, 0, 7)); d = d.AddDate(0, 0, 1) {
+		days = append(days, sizing.CampaignDay{Date: d.Format("2006-01-02"), Confidence: c.Ahead})
+	}
+	if confidence, ok := e.modelConfidence(now); ok {
+		days[0].Confidence = confidence
+	}
+
+	// What was spent today is counted against today's share, not before it
+	spent := e.risk.SpentSince(week) - e.risk.SpentSince(start)
+	plan := c.Plan(days, spent)
+	day = &campaignDay{date: today, budget: plan.Days[0].Budget, plan: plan}
+	e.mu.Lock()
+	e.campaign = day
+	e.mu.Unlock()
+
+	log.Printf("[Engine] Campaign: $%.2f today at %.0f%% confidence, of $%.2f left this week over %d days ($%.2f held back)",
+		day.budget, days[0].Confidence*100, plan.Budget, len(days), plan.Held)
+	return day
+}
+
+// modelConfidence returns the model's confidence in today: its probability
+// of the likeliest bracket of each enabled station's HIGH event, averaged
+func (e *Engine) modelConfidence(now time.Time) (float64, bool) {
+	total, n := 0.0, 0
+	for _, station := range DefaultStations {
+		if !e.toggles.IsEnabled(station.Code, MarketHigh) {
+			continue
+		}
+		loc, err := time.LoadLocation(station.Timezone)
+		if err != nil {
+			continue
+		}
+		day := midnight(now.In(loc))
+		eventTicker := fmt.Sprintf("%s-%s", station.prefix(MarketHigh), strings.ToUpper(day.Format("06Jan02")))
+		markets, err := e.fetchBrackets(eventTicker)
+		if err != nil || len(markets) == 0 {
+			continue
+		}
+		ladder, err := e.closingLadder(station, MarketHigh, eventTicker, day, markets, now)
+		if err != nil {
+			continue
+		}
+		top := 0.0
+		for _, b := range ladder.Brackets {
+			top = max(top, b.Model)
+		}
+		total += top
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return total / float64(n), true
+}
+
+// midnight returns the start of t's day in its location
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// closingWindow is how long before an event's close its bracket ladder is
+// compared with the market's each tick; the last comparison before the
+// close is the closing ladder
+const closingWindow = 30 * time.Minute
+
+// closingSnapshot is what watchClose knows of an event: when it closes and
+// its latest ladder in the closing window
+type closingSnapshot struct {
+	closes time.Time
+	ladder *model.ClosingLadder
+	done   bool // Recorded, or closed before a ladder was taken
+}
+
+// watchClose compares the model's distribution over each traded event's
+// brackets with the market's, from its prices, through the closing window,
+// and records the last comparison in the calibration once the event closes.
+// The events of the day before are watched too, for markets that close
+// after local midnight
+func (e *Engine) watchClose(now time.Time) {
+	if e.calibration == nil {
+		return
+	}
+	watched := make(map[string]bool)
+	for _, station := range DefaultStations {
+		loc, err := time.LoadLocation(station.Timezone)
+		if err != nil {
+			continue
+		}
+		local := now.In(loc)
+		today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		for _, marketType := range []string{MarketHigh, MarketLow} {
+			if station.prefix(marketType) == "" || (marketType == MarketLow && !e.config.TradeLow) {
+				continue
+			}
+			for _, day := range []time.Time{today.AddDate(0, 0, -1), today} {
+				eventTicker := fmt.Sprintf("%s-%s", station.prefix(marketType), strings.ToUpper(day.Format("06Jan02")))
+				watched[eventTicker] = true
+				e.watchEventClose(station, marketType, eventTicker, day, now)
+			}
+		}
+	}
+
+	e.mu.Lock()
+	for eventTicker := range e.closing {
+		if !watched[eventTicker] {
+			delete(e.closing, eventTicker)
+		}
+	}
+	e.mu.Unlock()
+}
+
+// watchEventClose advances one event: its close time is fetched once, its
+// ladder each tick of the closing window, and the last ladder is recorded
+// at the close
+func (e *Engine) watchEventClose(station Station, marketType, eventTicker string, day, now time.Time) {
+	e.mu.RLock()
+	snap, ok := e.closing[eventTicker]
+	e.mu.RUnlock()
+	switch {
+	case ok && snap.done:
+		return
+	case ok && !now.Before(snap.closes):
+		e.recordClose(station, eventTicker, snap)
+		return
+	case ok && now.Before(snap.closes.Add(-closingWindow)):
+		return
+	}
+
+	markets, err := e.fetchBrackets(eventTicker)
+	if err != nil || len(markets) == 0 {
+		return // Not listed, or retried next tick
+	}
+	if !ok {
+		snap = &closingSnapshot{}
+		for _, m := range markets {
+			if closes := strategy.ParseCloseTime(m.CloseTime); closes.After(snap.closes) {
+				snap.closes = closes
+			}
+		}
+		// Closed already, e.g. before a restart: its prices are gone
+		snap.done = snap.closes.IsZero() || !now.Before(snap.closes)
+		e.mu.Lock()
+		e.closing[eventTicker] = snap
+		e.mu.Unlock()
+		if snap.done || now.Before(snap.closes.Add(-closingWindow)) {
+			return
+		}
+	}
+
+	ladder, err := e.closingLadder(station, marketType, eventTicker, day, markets, now)
+	if err != nil {
+		log.Printf("[Engine] %s: Closing ladder of %s skipped: %v", station.City, eventTicker, err)
+		return
+	}
+	e.mu.Lock()
+	snap.ladder = &ladder
+	e.mu.Unlock()
+}
+
+// closingLadder returns the model's and the market's probabilities of each
+// of the event's brackets now. The market's is the midpoint of the YES bid
+// and ask, or the bid alone without an ask
+func (e *Engine) closingLadder(station Station, marketType, eventTicker string, day time.Time, markets []Market, now time.Time) (model.ClosingLadder, error) {
+	metar, err := e.getMETAR(station, day)
+	if err != nil {
+		return model.ClosingLadder{}, fmt.Errorf("METAR: %w", err)
+	}
+	update := strategy.WeatherUpdate{
+		Time:     now,
+		City:     station.Code,
+		MaxTempF: metar.MaxTemp,
+		MinTempF: metar.MinTemp,
+	}
+
+	// The model is asked at the hour of the event's own day: after
+	// midnight its day is over
+	local := now.In(day.Location())
+	if end := day.AddDate(0, 0, 1).Add(-time.Minute); local.After(end) {
+		local = end
+	}
+	data := strategy.MarketData{Time: local, City: station.Code, EventTicker: eventTicker, Type: weather.MarketType(marketType)}
+
+	strikes := make(market.Strikes, len(markets))
+	probs := make([]float64, len(markets))
+	prices := make([]float64, len(markets))
+	for i, m := range markets {
+		strikes[i] = m.Strike
+		probs[i] = e.exitModel.Probability(data, update, strategy.Quote{Ticker: m.Ticker, Floor: strikes[i].Floor, Cap: strikes[i].Cap})
+		prices[i] = float64(m.YesBid)
+		if m.YesAsk > 0 {
+			prices[i] = float64(m.YesBid+m.YesAsk) / 2
+		}
+	}
+	return model.NewClosingLadder(station.Code, day.Format("2006-01-02"), marketType == MarketLow, strikes, probs, prices), nil
+}
+
+// recordClose records the event's last ladder in the calibration, and logs
+// how the model's expected value compared with the market's, that day and
+// over all of the station's closing ladders
+func (e *Engine) recordClose(station Station, eventTicker string, snap *closingSnapshot) {
+	e.mu.Lock()
+	snap.done = true
+	ladder := snap.ladder
+	e.mu.Unlock()
+	if ladder == nil {
+		return
+	}
+
+	e.calibration.ObserveClose(*ladder)
+	modelMean, marketMean := ladder.Means()
+	bias := e.calibration.ClosingBias(station.Code, ladder.Low)
+	systematic := ""
+	if bias.Days >= 2 && bias.Systematic() {
+		systematic = ", systematic"
+	}
+	log.Printf("[Engine] %s: %s closed, model %.1f° vs market %.1f° (%+.1f°); bias over %d days %+.2f° (%+.2f to %+.2f%s)",
+		station.City, eventTicker, modelMean, marketMean, ladder.Bias(), bias.Days, bias.Bias, bias.Lower, bias.Upper, systematic)
+}
@@ -0,0 +1,382 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// StrategyDoc describes the strategy as configured, in plain words: what it
+// enters, how much it stakes, when it exits, the limits it trades under and
+// its schedule. It is generated from the running configuration and stored
+// with the day reports, so the trades recorded under it can be read later
+// without the code of the day
+type StrategyDoc struct {
+	Version  string       `json:"version"` // Hash of the sections: changes whenever the description does
+	Sections []DocSection `json:"sections"`
+}
+
+// DocSection is one part of a StrategyDoc
+type DocSection struct {
+	Title string   `json:"title"`
+	Lines []string `json:"lines"`
+}
+
+// Describe generates the description of the strategy as configured now,
+// including the sizer, limits, guard and market toggles attached
+func (e *Engine) Describe() StrategyDoc {
+	c := e.config
+	doc := StrategyDoc{Sections: []DocSection{
+		{"Entries", e.describeEntries()},
+		{"Sizing", e.describeSizing()},
+		{"Exits", e.describeExits()},
+		{"Risk limits", e.describeLimits()},
+		{"Schedule", e.describeSchedule()},
+		{"Markets", e.describeMarkets()},
+	}}
+	if c.Fade != nil || c.Hedge != nil {
+		doc.Sections = append(doc.Sections, DocSection{"Other strategies", e.describeExtras()})
+	}
+
+	h := sha256.New()
+	for _, s := range doc.Sections {
+		fmt.Fprintf(h, "%s\x00%s\x00", s.Title, strings.Join(s.Lines, "\n"))
+	}
+	doc.Version = hex.EncodeToString(h.Sum(nil))[:8]
+	return doc
+}
+
+func (e *Engine) describeEntries() []string {
+	c := e.config
+	markets := "HIGH temperature events, on the running METAR max"
+	if c.TradeLow {
+		markets = "HIGH and LOW temperature events, on the running METAR max and min"
+	}
+	lines := []string{
+		"Trades " + markets,
+		fmt.Sprintf("Buys YES on the market favorite at %d-%d¢ when %s of the weighted vote (favorite, METAR and healthy external signals) backs it",
+			c.MinYesPrice, c.MaxYesPrice, agreement(c.MinSignalAgreement)),
+		fmt.Sprintf("Buys NO on up to %d brackets the favorite beats, at %d-%d¢", c.MaxNoTrades, c.MinNoPrice, c.MaxNoPrice),
+	}
+	if c.EVGate {
+		lines = append(lines, "Skips orders without positive expected value after fees at the model's probability of the order winning")
+	}
+	if c.Imbalance != nil {
+		wait := "until the pressure eases"
+		if c.Imbalance.MaxWait > 0 {
+			wait = "for at most " + duration(c.Imbalance.MaxWait)
+		}
+		lines = append(lines, fmt.Sprintf("Holds entries while the book shows sell pressure below %+.2f, %s", c.Imbalance.MinPressure, wait))
+	}
+	if c.Hedge != nil {
+		lines = append(lines, "Replaces the orders on the events it backs with the hedge set the model rates best")
+	}
+	if ab := c.AB; ab != nil {
+		unit := "one day of each pair of days"
+		if ab.Test.Unit == strategy.ABCities {
+			unit = "half the cities each day"
+		}
+		v := ab.Variant
+		lines = append(lines, fmt.Sprintf("A/B tests a variant on %s, chosen at random: YES at %d-%d¢ for %s, NO on up to %d brackets at %d-%d¢ for %s, with %s of the vote",
+			unit, v.MinYesPrice, v.MaxYesPrice, money(v.BetYes), v.MaxNoTrades, v.MinNoPrice, v.MaxNoPrice, money(v.BetNo), agreement(v.MinSignalAgreement)))
+	}
+	return lines
+}
+
+func (e *Engine) describeSizing() []string {
+	c := e.config
+	var lines []string
+	if e.sizer != nil {
+		lines = append(lines, fmt.Sprintf("Stakes by %s against the bankroll, at the model's probability of each order winning", e.sizer.Method()))
+		if l := e.sizer.Limits(); l.MaxTrade > 0 || l.MaxDay > 0 || l.MaxContracts > 0 {
+			lines = append(lines, "Stakes at most "+caps(
+				clause{money(l.MaxTrade) + " per trade", l.MaxTrade > 0},
+				clause{money(l.MaxDay) + " a day", l.MaxDay > 0},
+				clause{fmt.Sprintf("%d contracts per trade", l.MaxContracts), l.MaxContracts > 0}))
+		}
+	} else {
+		lines = append(lines, fmt.Sprintf("Stakes a fixed %s on the favorite's YES and %s on each NO", money(c.BetYes), money(c.BetNo)))
+	}
+	if c.CashReserve > 0 {
+		lines = append(lines, fmt.Sprintf("Keeps %.0f%% of the bankroll as cash", c.CashReserve*100))
+	}
+	if c.Capacity.Trades > 0 && c.CapacityFraction > 0 {
+		lines = append(lines, fmt.Sprintf("Caps orders at %.0f%% of the estimated capacity of %d contracts", c.CapacityFraction*100, c.Capacity.Contracts))
+	}
+	if cp := c.Campaign; cp != nil {
+		line := fmt.Sprintf("Spreads %s a week (from %s) over the days by the model's confidence; days under %.0f%% get nothing",
+			money(cp.Budget), cp.WeekStart, cp.MinConfidence*100)
+		if cp.MaxDay > 0 {
+			line += ", and none more than " + money(cp.MaxDay)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (e *Engine) describeExits() []string {
+	c := e.config
+	var lines []string
+	if c.TakeProfitFraction > 0 && c.TakeProfitPrice > 0 {
+		lines = append(lines, fmt.Sprintf("Take-profit: sells %.0f%% of a position bid at %d¢ or more with at least %gh to the close",
+			c.TakeProfitFraction*100, c.TakeProfitPrice, c.TakeProfitMinHours))
+	}
+	if c.Exits.StopLoss > 0 {
+		lines = append(lines, fmt.Sprintf("Stop-loss: sells a position bid at %d¢ or less", c.Exits.StopLoss))
+	}
+	if c.Exits.MinProb > 0 {
+		lines = append(lines, fmt.Sprintf("Model exit: sells a position the model gives less than %.0f%%", c.Exits.MinProb*100))
+	}
+	if c.Exits.FlattenHour > 0 {
+		lines = append(lines, fmt.Sprintf("Flattens whatever is still held from %02d:00 local", c.Exits.FlattenHour))
+	}
+	if len(lines) == 0 {
+		return []string{"Holds every position to settlement"}
+	}
+	return append(lines, "Holds anything else to settlement")
+}
+
+func (e *Engine) describeLimits() []string {
+	var lines []string
+	if e.limits != nil {
+		l := e.limits.Status().Limits
+		if l.MaxDailyLoss > 0 || l.MaxTradesPerDay > 0 {
+			lines = append(lines, "Halts trading at "+caps(
+				clause{money(l.MaxDailyLoss) + " of loss in a day", l.MaxDailyLoss > 0},
+				clause{fmt.Sprintf("%d orders in a day", l.MaxTradesPerDay), l.MaxTradesPerDay > 0}))
+		}
+	}
+	if e.risk != nil {
+		l := e.risk.limits
+		if l.MaxPositionsPerDay > 0 || l.MaxPositionsPerWeek > 0 || l.MaxRiskPerWeek > 0 {
+			lines = append(lines, "Opens at most "+caps(
+				clause{fmt.Sprintf("%d positions in 24 hours", l.MaxPositionsPerDay), l.MaxPositionsPerDay > 0},
+				clause{fmt.Sprintf("%d positions in 7 days", l.MaxPositionsPerWeek), l.MaxPositionsPerWeek > 0},
+				clause{money(l.MaxRiskPerWeek) + " of new cost in 7 days", l.MaxRiskPerWeek > 0}))
+		}
+		if l.MaxEventFraction > 0 || l.MaxCityFraction > 0 {
+			lines = append(lines, "Holds at most "+caps(
+				clause{fmt.Sprintf("%.0f%% of the bankroll in one event", l.MaxEventFraction*100), l.MaxEventFraction > 0},
+				clause{fmt.Sprintf("%.0f%% in one city-day", l.MaxCityFraction*100), l.MaxCityFraction > 0}))
+		}
+	}
+	if e.guard != nil {
+		s := e.guard.Status()
+		lines = append(lines, fmt.Sprintf("Switches to shadow mode when daily P&L falls behind the expected %s a day (CUSUM threshold %g)",
+			money(s.ExpectedMean), s.Threshold))
+	}
+	if len(lines) == 0 {
+		return []string{"None"}
+	}
+	return lines
+}
+
+func (e *Engine) describeSchedule() []string {
+	c := e.config
+	lines := []string{fmt.Sprintf("Enters from %02d:00 to %02d:00 local time at each station; exits may run until the close",
+		c.TradingStartHour, c.TradingEndHour)}
+	if c.Expiry.NoEntry > 0 {
+		lines = append(lines, fmt.Sprintf("No entries in the last %s before a market closes", duration(c.Expiry.NoEntry)))
+	}
+	if c.Expiry.ThinBook > 0 {
+		lines = append(lines, fmt.Sprintf("Warns of positions held into the last %s", duration(c.Expiry.ThinBook)))
+	}
+	phases := e.strategy.Phases()
+	for _, phase := range []strategy.Phase{strategy.PhaseForecastEntry, strategy.PhaseIntraday, strategy.PhaseLockWindow} {
+		b := phases.For(phase)
+		if !b.Enters() && len(b.Exits) == 0 {
+			continue
+		}
+		line := fmt.Sprintf("%s: ", phase)
+		if b.Enters() {
+			line += "entries by " + strings.Join(b.OrderTypes, ", ")
+			if b.SizeFactor > 0 && b.SizeFactor != 1 {
+				line += fmt.Sprintf(" at %gx size", b.SizeFactor)
+			}
+		} else {
+			line += "no entries"
+		}
+		if len(b.Exits) > 0 {
+			exits := make([]string, len(b.Exits))
+			for i, x := range b.Exits {
+				exits[i] = string(x)
+			}
+			line += "; exits " + strings.Join(exits, ", ")
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (e *Engine) describeMarkets() []string {
+	var on, off []string
+	for _, s := range DefaultStations {
+		for _, marketType := range []string{MarketHigh, MarketLow} {
+			if marketType == MarketLow && (!e.config.TradeLow || s.LowPrefix == "") {
+				continue
+			}
+			name := s.Code + " " + marketType
+			if e.toggles.IsEnabled(s.Code, marketType) {
+				on = append(on, name)
+			} else {
+				off = append(off, name)
+			}
+		}
+	}
+	lines := []string{"Trading " + listOrNone(on)}
+	if len(off) > 0 {
+		lines = append(lines, "Switched off: "+strings.Join(off, ", "))
+	}
+	return lines
+}
+
+func (e *Engine) describeExtras() []string {
+	c := e.config
+	var lines []string
+	if f := c.Fade; f != nil {
+		lines = append(lines, fmt.Sprintf("Fade: on events dualside passes on, %s on the runner-up's YES at %d-%d¢ when the favorite is overpriced by %.0f points and the runner-up has %.0f points of edge",
+			money(f.Bet), f.MinPrice, f.MaxPrice, f.MinOverprice*100, f.MinEdge*100))
+	}
+	if h := c.Hedge; h != nil {
+		line := fmt.Sprintf("Hedge: %s across the legs, %.0f%% of it on up to %d NO legs", money(h.Budget), h.NoShare*100, h.MaxNoLegs)
+		if h.MaxLossProb > 0 {
+			line += fmt.Sprintf("; sets losing with more than %.0f%% probability are skipped", h.MaxLossProb*100)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// String formats the description as Markdown
+func (d StrategyDoc) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Strategy %s\n", d.Version)
+	for _, s := range d.Sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", s.Title)
+		for _, line := range s.Lines {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+	return b.String()
+}
+
+// SaveStrategyDoc writes the description as Markdown to path and, when its
+// version differs from the last one appended, appends it to the JSON-lines
+// history next to it, so every configuration the bot ran is kept. It
+// reports whether the version is new
+func SaveStrategyDoc(path, historyPath string, doc StrategyDoc) (bool, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(doc.String()), 0644); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return false, err
+	}
+
+	history, err := LoadStrategyDocs(historyPath)
+	if err != nil {
+		return false, err
+	}
+	if n := len(history); n > 0 && history[n-1].Version == doc.Version {
+		return false, nil
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return false, err
+	}
+	f, err := os.OpenFile(historyPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return false, err
+	}
+	return true, f.Close()
+}
+
+// LoadStrategyDocs reads a history written by SaveStrategyDoc, oldest first
+// (a missing file is an empty history)
+func LoadStrategyDocs(path string) ([]StrategyDoc, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read strategy history: %w", err)
+	}
+
+	var docs []StrategyDoc
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var d StrategyDoc
+		if err := json.Unmarshal([]byte(line), &d); err != nil {
+			return nil, fmt.Errorf("parse strategy history line %d: %w", i+1, err)
+		}
+		docs = append(docs, d)
+	}
+	return docs, nil
+}
+
+// agreement phrases a MinSignalAgreement share
+func agreement(share float64) string {
+	if share >= 1 {
+		return "all"
+	}
+	return fmt.Sprintf("%.0f%%", share*100)
+}
+
+// clause is a phrase of a description, left out unless on
+type clause struct {
+	text string
+	on   bool
+}
+
+// caps joins the clauses that are on, e.g. "$100.00 per trade or $500.00 a day"
+func caps(clauses ...clause) string {
+	var parts []string
+	for _, c := range clauses {
+		if c.on {
+			parts = append(parts, c.text)
+		}
+	}
+	return strings.Join(parts, " or ")
+}
+
+// duration formats d without zero units, e.g. "30m" or "1h30m"
+func duration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "nothing"
+	}
+	return strings.Join(items, ", ")
+}
+
+func money(v float64) string {
+	if v < 0 {
+		return fmt.Sprintf("-$%.2f", -v)
+	}
+	return fmt.Sprintf("$%.2f", v)
+}
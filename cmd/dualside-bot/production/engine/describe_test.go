@@ -0,0 +1,114 @@
+package engine
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/sizing"
+)
+
+func TestEngine_Describe(t *testing.T) {
+	x := newTestExchange(t, 1)
+	e := NewEngine(TradingConfig{CashReserve: 0.2, BetYes: 5, BetNo: 2}, newTestExecutor(t, x))
+
+	fixed := e.Describe()
+	var titles []string
+	for _, s := range fixed.Sections {
+		titles = append(titles, s.Title)
+	}
+	if want := []string{"Entries", "Sizing", "Exits", "Risk limits", "Schedule", "Markets"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("Describe() sections = %v, want %v", titles, want)
+	}
+	if want := []string{"Stakes a fixed $5.00 on the favorite's YES and $2.00 on each NO", "Keeps 20% of the bankroll as cash"}; !reflect.DeepEqual(fixed.Sections[1].Lines, want) {
+		t.Errorf("Describe() sizing = %q, want %q", fixed.Sections[1].Lines, want)
+	}
+	if again := e.Describe(); again.Version != fixed.Version {
+		t.Errorf("Describe() version = %s, then %s; want it stable", fixed.Version, again.Version)
+	}
+
+	e.SetSizer(sizing.New(sizing.Kelly{Fraction: 0.25}, sizing.Limits{MaxTrade: 10, MaxContracts: 50}))
+	kelly := e.Describe()
+	want := []string{
+		"Stakes by 0.25 kelly against the bankroll, at the model's probability of each order winning",
+		"Stakes at most $10.00 per trade or 50 contracts per trade",
+		"Keeps 20% of the bankroll as cash",
+	}
+	if !reflect.DeepEqual(kelly.Sections[1].Lines, want) {
+		t.Errorf("Describe() sizing = %q, want %q", kelly.Sections[1].Lines, want)
+	}
+	if kelly.Version == fixed.Version {
+		t.Errorf("Describe() version = %s with and without the sizer, want it changed", kelly.Version)
+	}
+}
+
+func TestEngine_SettleReportsStrategy(t *testing.T) {
+	x := newTestExchange(t, 1)
+	e := NewEngine(TradingConfig{BetYes: 5, BetNo: 2}, newTestExecutor(t, x))
+	var reports []DayReport
+	e.SetReportCallback(func(r DayReport) { reports = append(reports, r) })
+
+	const eventTicker = "KXHIGHLAX-26MAR10"
+	e.positions[eventTicker] = []Trade{{City: "Los Angeles", EventTicker: eventTicker, Ticker: testTicker, Side: "yes", Price: 40, Quantity: 10, Cost: 4}}
+	now := time.Date(2026, 3, 12, 12, 0, 0, 0, time.UTC)
+
+	// Unsettled on the exchange: no report yet
+	e.settlePositions(now)
+	if len(reports) != 0 {
+		t.Fatalf("settlePositions() reported %d days before the market settled, want none", len(reports))
+	}
+
+	x.AddMarket(rest.Market{Ticker: testTicker, EventTicker: eventTicker, Status: "settled", Result: "yes"})
+	e.settlePositions(now)
+	if len(reports) != 1 {
+		t.Fatalf("settlePositions() reported %d days, want 1", len(reports))
+	}
+	r := reports[0]
+	if r.Date != "2026-03-10" || len(r.Trades) != 1 || r.PnL <= 0 {
+		t.Errorf("settlePositions() report = %+v, want the winning 2026-03-10 trade", r)
+	}
+	if want := e.Describe(); r.Strategy == nil || !reflect.DeepEqual(*r.Strategy, want) {
+		t.Errorf("settlePositions() report strategy = %+v, want %+v", r.Strategy, want)
+	}
+}
+
+func TestSaveStrategyDoc(t *testing.T) {
+	dir := t.TempDir()
+	path, historyPath := filepath.Join(dir, "docs", "strategy.md"), filepath.Join(dir, "docs", "strategies.jsonl")
+	e := NewEngine(TradingConfig{BetYes: 5, BetNo: 2}, nil)
+	first := e.Describe()
+	e.SetSizer(sizing.New(sizing.Kelly{}, sizing.Limits{}))
+	second := e.Describe()
+
+	tests := []struct {
+		doc     StrategyDoc
+		isNew   bool
+		history int
+	}{
+		{first, true, 1},
+		{first, false, 1},
+		{second, true, 2},
+		{first, true, 3},
+	}
+	for i, tt := range tests {
+		isNew, err := SaveStrategyDoc(path, historyPath, tt.doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isNew != tt.isNew {
+			t.Errorf("save %d: SaveStrategyDoc() = %v, want %v", i, isNew, tt.isNew)
+		}
+		history, err := LoadStrategyDocs(historyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(history) != tt.history || !reflect.DeepEqual(history[len(history)-1], tt.doc) {
+			t.Errorf("save %d: LoadStrategyDocs() = %d docs, want %d ending with version %s", i, len(history), tt.history, tt.doc.Version)
+		}
+	}
+	if docs, err := LoadStrategyDocs(filepath.Join(dir, "missing.jsonl")); err != nil || docs != nil {
+		t.Errorf("LoadStrategyDocs(missing) = %v, %v; want an empty history", docs, err)
+	}
+}
@@ -0,0 +1,250 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// divergenceChecks is how many checks in a row must disagree before a
+// divergence is raised, so a fill landing between the positions and orders
+// reads doesn't alert
+const divergenceChecks = 2
+
+// PositionCheck compares the contracts the exchange reports held in the
+// stations' markets with what the engine's trades account for
+type PositionCheck struct {
+	Tolerance int // Contracts either way that aren't a divergence
+}
+
+// Divergence is a market side where the exchange holds a different number
+// of contracts than the engine's trades account for: a trade placed by hand
+// in the UI, a fill the engine missed, or an order it thinks failed. Entries
+// in the market are paused until the operator acknowledges it
+type Divergence struct {
+	City         string    `json:"city,omitempty"`
+	EventTicker  string    `json:"event_ticker"`
+	Ticker       string    `json:"ticker"`
+	Side         string    `json:"side"`
+	Expected     int       `json:"expected"` // Contracts the engine's trades account for
+	Held         int       `json:"held"`     // Contracts the exchange reports
+	Since        time.Time `json:"since"`
+	Acknowledged bool      `json:"acknowledged"`
+
+	acked int // Held less Expected when acknowledged
+}
+
+// Diff returns the contracts held beyond what the trades account for,
+// negative when fewer are held
+func (d Divergence) Diff() int {
+	return d.Held - d.Expected
+}
+
+func (d Divergence) String() string {
+	city := d.City
+	if city == "" {
+		city = d.EventTicker
+	}
+	return fmt.Sprintf("%s: exchange holds %d %s %s, trades account for %d (%+d)",
+		city, d.Held, strings.ToUpper(d.Side), d.Ticker, d.Expected, d.Diff())
+}
+
+// SetDivergenceCallback sets callback for market sides whose exchange
+// position diverges from the engine's trades
+func (e *Engine) SetDivergenceCallback(fn func(Divergence)) {
+	e.onDivergence = fn
+}
+
+// Divergences returns the market sides whose exchange position diverges
+// from the engine's trades, by ticker
+func (e *Engine) Divergences() []Divergence {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.divergenceList()
+}
+
+// divergenceList returns a copy of the divergences, by ticker. Callers hold
+// e.mu
+func (e *Engine) divergenceList() []Divergence {
+	list := make([]Divergence, 0, len(e.divergences))
+	for _, d := range e.divergences {
+		list = append(list, *d)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Ticker != list[j].Ticker {
+			return list[i].Ticker < list[j].Ticker
+		}
+		return list[i].Side < list[j].Side
+	})
+	return list
+}
+
+// AcknowledgeDivergence accepts the exchange's current position in ticker
+// as intended and resumes entries there. A further change to the
+// difference raises the divergence again
+func (e *Engine) AcknowledgeDivergence(ticker string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	found := false
+	for _, d := range e.divergences {
+		if d.Ticker == ticker {
+			d.Acknowledged = true
+			d.acked = d.Diff()
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("no divergence in %s", ticker)
+	}
+	return nil
+}
+
+// divergent reports whether entries in ticker are paused by an
+// unacknowledged position divergence
+func (e *Engine) divergent(ticker string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	for _, d := range e.divergences {
+		if d.Ticker == ticker && !d.Acknowledged {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPositions compares the exchange's positions in the stations' markets
+// with the contracts the engine's trades hold: bought, less sold, less what
+// is still resting unfilled. Markets past their close are left to
+// settlement. A side that differs by more than the tolerance on
+// divergenceChecks checks in a row is raised as a divergence
+func (e *Engine) checkPositions(now time.Time) {
+	if e.config.Positions == nil {
+		return
+	}
+	if e.executor.IsDryRun() && e.executor.Paper() == nil {
+		return // Nothing is held anywhere
+	}
+	positions, err := e.executor.Positions()
+	if err != nil {
+		log.Printf("[Engine] Failed to fetch positions: %v", err)
+		return
+	}
+	orders, err := e.executor.RestingOrders()
+	if err != nil {
+		log.Printf("[Engine] Failed to fetch resting orders: %v", err)
+		return
+	}
+	unfilled := make(map[string]int)
+	for _, o := range orders {
+		unfilled[o.OrderID] = o.RemainingCount
+	}
+
+	counts := make(map[string]*Divergence)
+	count := func(key string, d Divergence) *Divergence {
+		c, ok := counts[key]
+		if !ok {
+			c = &d
+			counts[key] = c
+		}
+		return c
+	}
+	closed := make(map[string]bool)
+
+	e.mu.RLock()
+	for eventTicker, trades := range e.positions {
+		for _, t := range trades {
+			if t.Settled || t.Status == "shadow" || t.Status == "error" {
+				continue
+			}
+			if t.Determined || (!t.Closes.IsZero() && !now.Before(t.Closes)) {
+				closed[t.Ticker] = true
+				continue
+			}
+			c := count(t.Ticker+"/"+t.Side, Divergence{City: t.City, EventTicker: eventTicker, Ticker: t.Ticker, Side: t.Side})
+			c.Expected += t.Quantity - t.Sold - unfilled[t.OrderID]
+		}
+	}
+	e.mu.RUnlock()
+
+	for _, p := range positions {
+		station, ok := e.stationOf(p.Ticker)
+		if !ok || p.Count <= 0 || closed[p.Ticker] {
+			continue
+		}
+		c := count(p.Ticker+"/"+string(p.Side), Divergence{City: station.City, EventTicker: p.EventTicker, Ticker: p.Ticker, Side: string(p.Side)})
+		c.Held += p.Count
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var raised []Divergence
+	e.mu.Lock()
+	for _, key := range keys {
+		c := counts[key]
+		diff := c.Diff()
+		if diff >= -e.config.Positions.Tolerance && diff <= e.config.Positions.Tolerance {
+			delete(e.mismatches, key)
+			if _, ok := e.divergences[key]; ok {
+				delete(e.divergences, key)
+				log.Printf("[Engine] %s: %s %s position matches the trades again", c.City, strings.ToUpper(c.Side), c.Ticker)
+			}
+			continue
+		}
+
+		if d, ok := e.divergences[key]; ok {
+			d.Expected, d.Held = c.Expected, c.Held
+			if d.Acknowledged && diff != d.acked {
+				d.Acknowledged = false
+				d.Since = now
+				raised = append(raised, *d)
+			}
+			continue
+		}
+		e.mismatches[key]++
+		if e.mismatches[key] < divergenceChecks {
+			continue
+		}
+		delete(e.mismatches, key)
+		c.Since = now
+		e.divergences[key] = c
+		raised = append(raised, *c)
+	}
+	// Sides no longer held or traded at all
+	for key, d := range e.divergences {
+		if _, ok := counts[key]; !ok {
+			delete(e.divergences, key)
+			log.Printf("[Engine] %s: %s %s position matches the trades again", d.City, strings.ToUpper(d.Side), d.Ticker)
+		}
+	}
+	for key := range e.mismatches {
+		if _, ok := counts[key]; !ok {
+			delete(e.mismatches, key)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, d := range raised {
+		log.Printf("[Engine] %s, pausing entries in %s until acknowledged", d, d.Ticker)
+		if e.onDivergence != nil {
+			e.onDivergence(d)
+		}
+	}
+}
+
+// stationOf returns the station whose HIGH events, or LOW events when
+// traded, ticker belongs to
+func (e *Engine) stationOf(ticker string) (Station, bool) {
+	prefix, _, _ := strings.Cut(ticker, "-")
+	for _, s := range DefaultStations {
+		if prefix == s.EventPrefix || (e.config.TradeLow && s.LowPrefix != "" && prefix == s.LowPrefix) {
+			return s, true
+		}
+	}
+	return Station{}, false
+}
@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/paper"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+func TestEngine_CheckPositions(t *testing.T) {
+	const ticker = "KXHIGHLAX-26MAR10-B70.5"
+	now := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	sim := paper.New(nil, 100000)
+	buy := func(count int) {
+		t.Helper()
+		req := &rest.CreateOrderRequest{
+			Ticker: ticker, Action: rest.OrderActionBuy, Side: rest.SideYes,
+			Type: rest.OrderTypeLimit, Count: count, YesPrice: 43,
+		}
+		if _, err := sim.Place(req, &rest.Orderbook{No: [][2]int{{57, 100}}}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e := NewEngine(TradingConfig{Positions: &PositionCheck{Tolerance: 1}}, &Executor{dryRun: true, paper: sim})
+	var raised []Divergence
+	e.SetDivergenceCallback(func(d Divergence) { raised = append(raised, d) })
+	e.positions["KXHIGHLAX-26MAR10"] = []Trade{{City: "Los Angeles", Ticker: ticker, Side: "yes", Quantity: 5, Closes: now.Add(6 * time.Hour)}}
+
+	// Within tolerance
+	buy(6)
+	e.checkPositions(now)
+	e.checkPositions(now)
+	if len(e.Divergences()) != 0 {
+		t.Fatalf("divergences = %v, want none within tolerance", e.Divergences())
+	}
+
+	// Two contracts bought by hand; raised on the second check in a row
+	buy(2)
+	e.checkPositions(now)
+	if len(raised) != 0 || e.divergent(ticker) {
+		t.Fatal("raised after one check, want two")
+	}
+	e.checkPositions(now)
+	if len(raised) != 1 || raised[0].Held != 8 || raised[0].Expected != 5 {
+		t.Fatalf("raised = %v, want 8 held against 5", raised)
+	}
+	// The trades' and the exchange's counts meet under the trades' label
+	if raised[0].City != "Los Angeles" {
+		t.Errorf("raised city = %q, want %q", raised[0].City, "Los Angeles")
+	}
+	if !e.divergent(ticker) {
+		t.Error("entries not paused in the divergent market")
+	}
+
+	// Acknowledged: entries resume, until the difference changes again
+	if err := e.AcknowledgeDivergence(ticker); err != nil {
+		t.Fatal(err)
+	}
+	e.checkPositions(now)
+	if e.divergent(ticker) || len(raised) != 1 {
+		t.Error("acknowledged divergence still pauses or raised again")
+	}
+	buy(1)
+	e.checkPositions(now)
+	if !e.divergent(ticker) || len(raised) != 2 {
+		t.Error("changed difference not raised again")
+	}
+
+	// The trades catch up with the exchange
+	e.positions["KXHIGHLAX-26MAR10"][0].Quantity = 9
+	e.checkPositions(now)
+	if e.divergent(ticker) || len(e.Divergences()) != 0 {
+		t.Errorf("divergences = %v, want none once the trades match", e.Divergences())
+	}
+	if err := e.AcknowledgeDivergence(ticker); err == nil {
+		t.Error("AcknowledgeDivergence() succeeded with no divergence")
+	}
+}
+
+func TestEngine_GetStatsDuringTick(t *testing.T) {
+	const ticker = "KXHIGHNY-26MAR10-B50.5"
+	now := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	sim := paper.New(nil, 100000)
+	req := &rest.CreateOrderRequest{
+		Ticker: ticker, Action: rest.OrderActionBuy, Side: rest.SideYes,
+		Type: rest.OrderTypeLimit, Count: 3, YesPrice: 43,
+	}
+	if _, err := sim.Place(req, &rest.Orderbook{No: [][2]int{{57, 100}}}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	// Bought by hand: no trades account for it
+	e := NewEngine(TradingConfig{Positions: &PositionCheck{}}, &Executor{dryRun: true, paper: sim})
+	e.checkPositions(now)
+	e.checkPositions(now)
+	stats := e.GetStats()
+	divergences, _ := stats["divergences"].([]Divergence)
+	if len(divergences) != 1 || divergences[0].City != "New York" {
+		t.Fatalf("GetStats() divergences = %v, want the New York position", stats["divergences"])
+	}
+
+	// A tick takes the write lock while the stats are read: a writer queued
+	// between two read locks of one GetStats call would block both
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				e.checkPositions(now)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				e.mu.Lock()
+				e.totalTrades++
+				e.mu.Unlock()
+			}
+		}()
+		for r := 0; r < 4; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 2000; i++ {
+					e.GetStats()
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("GetStats() deadlocked with the position check")
+	}
+}
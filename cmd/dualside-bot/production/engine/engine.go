@@ -2,16 +2,28 @@ package engine
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"math"
-	"net/http"
 	"sort"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/service"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/metrics"
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/risk"
+	"github.com/brendanplayford/kalshi-go/pkg/sizing"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/dualside"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/fade"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/hedge"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
 // Station represents a weather station for trading
@@ -21,17 +33,28 @@ type Station struct {
 	METAR       string
 	EventPrefix string
 	Timezone    string
+	LowPrefix   string // LOW temperature events ("" = none)
 }
 
-// DefaultStations returns all supported HIGH temperature markets
+// DefaultStations returns all supported HIGH temperature markets, and the
+// LOW markets of the cities that have them
 var DefaultStations = []Station{
-	{"LAX", "Los Angeles", "LAX", "KXHIGHLAX", "America/Los_Angeles"},
-	{"NYC", "New York", "JFK", "KXHIGHNY", "America/New_York"},
-	{"CHI", "Chicago", "ORD", "KXHIGHCHI", "America/Chicago"},
-	{"MIA", "Miami", "MIA", "KXHIGHMIA", "America/New_York"},
-	{"AUS", "Austin", "AUS", "KXHIGHAUS", "America/Chicago"},
-	{"PHIL", "Philadelphia", "PHL", "KXHIGHPHIL", "America/New_York"},
-	{"DEN", "Denver", "DEN", "KXHIGHDEN", "America/Denver"},
+	{"LAX", "Los Angeles", "LAX", "KXHIGHLAX", "America/Los_Angeles", "KXLOWTLAX"},
+	{"NYC", "New York", "JFK", "KXHIGHNY", "America/New_York", ""},
+	{"CHI", "Chicago", "ORD", "KXHIGHCHI", "America/Chicago", "KXLOWTCHI"},
+	{"MIA", "Miami", "MIA", "KXHIGHMIA", "America/New_York", "KXLOWTMIA"},
+	{"AUS", "Austin", "AUS", "KXHIGHAUS", "America/Chicago", "KXLOWTAUS"},
+	{"PHIL", "Philadelphia", "PHL", "KXHIGHPHIL", "America/New_York", "KXLOWTPHIL"},
+	{"DEN", "Denver", "DEN", "KXHIGHDEN", "America/Denver", "KXLOWTDEN"},
+}
+
+// prefix returns the station's event prefix for a market type (MarketHigh
+// or MarketLow)
+func (s Station) prefix(marketType string) string {
+	if marketType == MarketLow {
+		return s.LowPrefix
+	}
+	return s.EventPrefix
 }
 
 // TradingConfig holds trading parameters
@@ -45,30 +68,198 @@ type TradingConfig struct {
 	MaxNoTrades      int
 	TradingStartHour int
 	TradingEndHour   int
+	WinRate          float64 // Expected win probability used by ranking
+	EVGate           bool    // Skip orders without positive EV after fees at the model's probability
+
+	// Also trade the stations' LOW events, on the running METAR min
+	TradeLow bool
+
+	// Take-profit on near-certain positions (0 price disables)
+	TakeProfitPrice    int     // Sell when the held side is bid at or above this (cents)
+	TakeProfitFraction float64 // Share of the position to sell
+	TakeProfitMinHours float64 // Only while at least this long remains before close
+
+	// Stop-loss, model and end-of-day exits, which sell the whole position
+	// (zero fields = off; TakeProfit is taken from TakeProfitPrice)
+	Exits strategy.ExitRules
+
+	// No entries near a market's close, and a warning for positions held
+	// into the thin end-of-day book (zero = off)
+	Expiry strategy.ExpiryGuard
+
+	// Share of the weighted vote (favorite, METAR and healthy external
+	// signals) that must back the favorite; 1 = unanimous
+	MinSignalAgreement float64
+
+	// What may be done in each lifecycle phase (nil = defaults)
+	Phases strategy.PhaseBehaviors
+
+	// Share of bankroll (cash plus open cost) kept as cash: bets shrink to
+	// the cash above it, after entry fees (0 = spend the whole balance)
+	CashReserve float64
+
+	// Estimated capacity of the strategy (see backtest.EstimateCapacity):
+	// orders are capped at CapacityFraction of what one order can take
+	// without moving the price (zero Capacity = no cap)
+	Capacity         backtest.Capacity
+	CapacityFraction float64
+
+	// Also trade the fade-the-favorite strategy, on the events dualside
+	// passes on (nil = off)
+	Fade *fade.Config
+
+	// Hold the main strategy's entries while the books of the favorite and
+	// adjacent brackets show sell pressure (nil = off)
+	Imbalance *strategy.ImbalanceTiming
+
+	// Append the book depths read to this file, for backtests ("" = off)
+	BookRecordFile string
+
+	// Replace the main strategy's orders on the events it backs with the
+	// hedge set the model rates best (nil = off)
+	Hedge *hedge.Config
+
+	// Spread a weekly budget over the days by the model's confidence,
+	// capping each day's new positions at its share (nil = off)
+	Campaign *CampaignConfig
+
+	// Compare the exchange's positions with the trades every tick, pausing
+	// entries in a market that diverges until acknowledged (nil = off)
+	Positions *PositionCheck
+
+	// Trade a variant of the strategy on the city-days an A/B test assigns
+	// to it (nil = off)
+	AB *ABConfig
 }
 
 // Engine is the core trading engine
 type Engine struct {
-	config     TradingConfig
-	executor   *Executor
-	httpClient *http.Client
+	config       TradingConfig
+	executor     *Executor
+	observations weather.Provider // Temperature reports for the running max
 
 	// State
-	mu            sync.RWMutex
-	positions     map[string][]Trade // EventTicker -> trades
-	dailyPnL      float64
-	totalTrades   int
+	mu             sync.RWMutex
+	positions      map[string][]Trade // EventTicker -> trades
+	dailyPnL       float64
+	totalTrades    int
 	totalYesTrades int
 	totalNoTrades  int
 
+	// Fee schedule for the EV gate and realized P&L (nil = no fees)
+	fees *fees.Schedule
+
+	// Runtime city/market toggles (nil = all enabled)
+	toggles *MarketToggles
+
+	// Operator overrides of model inputs (nil = none)
+	overrides *Overrides
+
+	// Predictions posted by external systems, voting with the built-in
+	// signals (nil = none)
+	external *strategy.ExternalSignals
+
+	// Position sizing in place of the strategy's fixed bets (nil = fixed bets)
+	sizer *sizing.Sizer
+
+	// Hard limits shared by the account's bots (nil = none)
+	limits *risk.Guard
+
+	// Metrics served for scraping (nil = none)
+	metrics *metrics.Trading
+
+	// Readiness checks the engine reports to (nil = none)
+	ready *service.Gate
+
+	// Liveness of the feeds the engine reports to (nil = none)
+	health *service.Health
+
+	// Trade frequency throttle (nil = unlimited)
+	risk      *RiskManager
+	throttled bool               // A risk limit is currently blocking orders
+	cash      float64            // Last fetched balance, less orders placed since
+	cashKnown bool               // cash has been fetched at least once
+	resting   map[string]float64 // Event ticker -> cost of resting buy orders not tracked as positions
+
+	// Decides what to trade; shared with the backtests
+	strategy *dualside.Strategy
+
+	// Variant B of an A/B test, and its book timing (nil = no test)
+	variant       *dualside.Strategy
+	variantTiming *strategy.Imbalance
+	abLog         *ABLog
+
+	// Contrarian strategy for the events the main one passes on (nil = off)
+	fade *fade.Strategy
+
+	// Model of the model exit: the fade strategy's
+	exitModel *fade.Strategy
+
+	// Book timing of the main strategy's entries (nil = off)
+	timing *strategy.Imbalance
+
+	// Phase of each station-day, from the strategy's trading window
+	lifecycle *strategy.Lifecycle
+
+	// Performance guard (nil = always live)
+	guard        *strategy.PerformanceGuard
+	settledByDay map[string]float64 // Local date -> realized P&L of settled events
+
+	// Operator notes and settled trades for day reports
+	journal       *Journal
+	settledTrades map[string][]Trade // Local date -> settled trades
+
 	// Channels
 	tradeChan chan Trade
 	errorChan chan error
 	stopChan  chan struct{}
 
 	// Callbacks
-	onTrade func(Trade)
-	onError func(error)
+	onTrade  func(Trade)
+	onError  func(error)
+	onReport func(DayReport)
+
+	// Threshold watch: last running max by station code
+	onCrossing func(Crossing)
+	lastMax    map[string]runningMax
+
+	// Bracket layout: what was last found on each city's event
+	layouts map[string]layoutCheck
+
+	// Morning briefings by local trading day, diffed against what was
+	// traded in the day report
+	briefings map[string]*Briefing
+
+	// Positions held into the thin end-of-day book
+	expiry   *strategy.ExpiryWatch
+	onExpiry func(ExpiryWarning)
+
+	// Housekeeping: last station-day run, by station code
+	housekept      map[string]string
+	onHousekeeping func(StationDay)
+
+	// Exchange rejections: markets skipped until a time, and whether
+	// insufficient funds halted entries for the rest of the tick
+	paused        map[string]time.Time
+	entriesHalted bool
+
+	// METAR to CLI calibration learned from settled days (nil = none)
+	calibration *model.Calibration
+
+	// Events watched into their close for the closing ladder, by ticker
+	closing map[string]*closingSnapshot
+
+	// Today's share of the weekly campaign budget (nil = not planned yet)
+	campaign *campaignDay
+
+	// Exchange positions diverging from the trades, by ticker/side, and
+	// the checks in a row each side has disagreed without being raised yet
+	divergences  map[string]*Divergence
+	mismatches   map[string]int
+	onDivergence func(Divergence)
+
+	// NWS forecasts for entry snapshots, by event ticker
+	forecasts map[string]forecastReading
 }
 
 // Trade represents a executed trade
@@ -84,36 +275,101 @@ type Trade struct {
 	Quantity    int
 	Cost        float64
 	OrderID     string
-	Status      string // "pending", "filled", "error"
+	Status      string // "pending", "filled", "shadow", "error"
+	Profit      float64
+	Settled     bool
+	Override    string         // Operator override that influenced the trade ("" = none)
+	Sold        int            // Contracts sold before settlement by an exit rule
+	SoldPrice   int            // Exit price of the sold contracts in cents
+	Determined  bool           // Market stopped trading before close; held to settlement
+	Closes      time.Time      // Market close time (zero = unknown)
+	Strategy    string         // Strategy that placed it ("" = dualside)
+	Route       string         // API route the order went through: RoutePrimary, RouteFallback ("" = not sent)
+	Exit        strategy.Exit  // Rule that sold the Sold contracts ("" = take-profit)
+	Entry       *EntrySnapshot // Market and weather when the order was sent (nil = not captured)
+	Variant     string         // A/B test variant it was entered under ("" = no test)
 }
 
-// Market data types
+// Market is an event's market as fetched, with the whole-degree range it
+// settles YES on
 type Market struct {
-	Ticker      string  `json:"ticker"`
-	EventTicker string  `json:"event_ticker"`
-	FloorStrike int     `json:"floor_strike"`
-	CapStrike   int     `json:"cap_strike"`
-	Status      string  `json:"status"`
-	YesBid      float64 `json:"yes_bid"`
-	YesAsk      float64 `json:"yes_ask"`
-	NoBid       float64 `json:"no_bid"`
-	NoAsk       float64 `json:"no_ask"`
-}
-
-type MarketsResponse struct {
-	Markets []Market `json:"markets"`
+	rest.Market
+	Strike market.Strike
 }
 
 // NewEngine creates a new trading engine
 func NewEngine(config TradingConfig, executor *Executor) *Engine {
+	strat := dualside.New(dualside.Config{
+		BetYes:             config.BetYes,
+		BetNo:              config.BetNo,
+		MinYesPrice:        config.MinYesPrice,
+		MaxYesPrice:        config.MaxYesPrice,
+		MinNoPrice:         config.MinNoPrice,
+		MaxNoPrice:         config.MaxNoPrice,
+		MaxNoTrades:        config.MaxNoTrades,
+		TradingStartHour:   config.TradingStartHour,
+		TradingEndHour:     config.TradingEndHour,
+		MinSignalAgreement: config.MinSignalAgreement,
+		Phases:             config.Phases,
+	})
+	strat.SetLogger(func(format string, args ...any) { log.Printf("[Engine] "+format, args...) })
+	lifecycle := strategy.NewLifecycle(strat.Schedule())
+	lifecycle.SetLogger(func(format string, args ...any) { log.Printf("[Lifecycle] "+format, args...) })
+
+	var fader *fade.Strategy
+	exitModel := fade.New(fade.DefaultConfig())
+	if config.Fade != nil {
+		fader = fade.New(*config.Fade)
+		fader.SetLogger(func(format string, args ...any) { log.Printf("[Fade] "+format, args...) })
+		exitModel = fader
+	}
+	var timing *strategy.Imbalance
+	if config.Imbalance != nil {
+		timing = strategy.WithImbalance(strat, *config.Imbalance)
+	}
+	var variant *dualside.Strategy
+	var variantTiming *strategy.Imbalance
+	var abLog *ABLog
+	if config.AB != nil {
+		vc := config.AB.Variant
+		vc.TradingStartHour, vc.TradingEndHour = config.TradingStartHour, config.TradingEndHour
+		vc.Phases = config.Phases
+		variant = dualside.New(vc)
+		variant.SetLogger(func(format string, args ...any) { log.Printf("[Engine] [B] "+format, args...) })
+		if config.Imbalance != nil {
+			variantTiming = strategy.WithImbalance(variant, *config.Imbalance)
+		}
+		abLog = &ABLog{}
+	}
+
 	return &Engine{
-		config:     config,
-		executor:   executor,
-		httpClient: &http.Client{Timeout: 15 * time.Second},
-		positions:  make(map[string][]Trade),
-		tradeChan:  make(chan Trade, 100),
-		errorChan:  make(chan error, 100),
-		stopChan:   make(chan struct{}),
+		config:        config,
+		strategy:      strat,
+		fade:          fader,
+		exitModel:     exitModel,
+		timing:        timing,
+		variant:       variant,
+		variantTiming: variantTiming,
+		abLog:         abLog,
+		lifecycle:     lifecycle,
+		executor:      executor,
+		observations:  weather.NewCache(weather.ASOS, time.Minute),
+		positions:     make(map[string][]Trade),
+		settledByDay:  make(map[string]float64),
+		settledTrades: make(map[string][]Trade),
+		lastMax:       make(map[string]runningMax),
+		layouts:       make(map[string]layoutCheck),
+		briefings:     make(map[string]*Briefing),
+		closing:       make(map[string]*closingSnapshot),
+		housekept:     make(map[string]string),
+		paused:        make(map[string]time.Time),
+		divergences:   make(map[string]*Divergence),
+		mismatches:    make(map[string]int),
+		forecasts:     make(map[string]forecastReading),
+		expiry:        strategy.NewExpiryWatch(config.Expiry),
+		tradeChan:     make(chan Trade, 100),
+		errorChan:     make(chan error, 100),
+		stopChan:      make(chan struct{}),
 	}
 }
 
@@ -127,6 +383,75 @@ func (e *Engine) SetErrorCallback(fn func(error)) {
 	e.onError = fn
 }
 
+// SetReportCallback sets callback for settled day reports
+func (e *Engine) SetReportCallback(fn func(DayReport)) {
+	e.onReport = fn
+}
+
+// SetJournal attaches operator notes to include in day reports
+func (e *Engine) SetJournal(journal *Journal) {
+	e.journal = journal
+}
+
+// SetGuard attaches a performance guard that can switch the engine to shadow mode
+func (e *Engine) SetGuard(guard *strategy.PerformanceGuard) {
+	e.guard = guard
+}
+
+// SetFeeSchedule sets the fee schedule used to price trades
+func (e *Engine) SetFeeSchedule(schedule *fees.Schedule) {
+	e.fees = schedule
+}
+
+// SetToggles attaches runtime per-city market toggles
+func (e *Engine) SetToggles(toggles *MarketToggles) {
+	e.toggles = toggles
+}
+
+// SetOverrides attaches operator overrides of model inputs
+func (e *Engine) SetOverrides(overrides *Overrides) {
+	e.overrides = overrides
+}
+
+// SetExternalSignals attaches predictions posted by external systems as extra
+// ensemble members
+func (e *Engine) SetExternalSignals(external *strategy.ExternalSignals) {
+	e.external = external
+	e.strategy.SetExternalSignals(external)
+	if e.variant != nil {
+		e.variant.SetExternalSignals(external)
+	}
+}
+
+// SetRiskManager attaches the trade frequency throttle
+func (e *Engine) SetRiskManager(risk *RiskManager) {
+	e.risk = risk
+}
+
+// SetLimits attaches the account's hard limits and kill switch
+func (e *Engine) SetLimits(limits *risk.Guard) {
+	e.limits = limits
+}
+
+// SetObservations replaces the live ASOS feed the running max is read from
+func (e *Engine) SetObservations(p weather.Provider) {
+	e.observations = p
+}
+
+// SetSizer sizes orders at the expected win rate against the bankroll in
+// place of the strategy's fixed bets
+func (e *Engine) SetSizer(sizer *sizing.Sizer) {
+	e.sizer = sizer
+}
+
+// Mode returns the current execution mode
+func (e *Engine) Mode() strategy.Mode {
+	if e.guard == nil {
+		return strategy.ModeLive
+	}
+	return e.guard.Mode()
+}
+
 // Run starts the trading engine
 func (e *Engine) Run(ctx context.Context, pollInterval time.Duration) {
 	log.Println("[Engine] Starting trading engine...")
@@ -139,6 +464,8 @@ func (e *Engine) Run(ctx context.Context, pollInterval time.Duration) {
 
 	// Run immediately
 	e.tick()
+	interval := e.pollInterval(pollInterval)
+	ticker.Reset(interval)
 
 	for {
 		select {
@@ -150,10 +477,35 @@ func (e *Engine) Run(ctx context.Context, pollInterval time.Duration) {
 			return
 		case <-ticker.C:
 			e.tick()
+			// Poll at the cadence of the busiest phase any market is in
+			if next := e.pollInterval(pollInterval); next != interval {
+				log.Printf("[Engine] Poll interval %v -> %v", interval, next)
+				interval = next
+				ticker.Reset(interval)
+			}
 		}
 	}
 }
 
+// pollInterval returns the shortest cadence configured for the phases of the
+// markets being tracked, or def
+func (e *Engine) pollInterval(def time.Duration) time.Duration {
+	var phases []strategy.Phase
+	for _, p := range e.lifecycle.Phases() {
+		phases = append(phases, p.Phase)
+	}
+	return e.strategy.Phases().Poll(phases, def)
+}
+
+// eventPhase advances eventTicker's lifecycle to now and returns its phase.
+// The station-day is read from the ticker (e.g. KXHIGHLAX-25DEC05)
+func (e *Engine) eventPhase(eventTicker string, now time.Time) strategy.Phase {
+	if _, day, ok := eventDay(eventTicker); ok {
+		return e.lifecycle.Advance(eventTicker, day, now.In(day.Location()))
+	}
+	return e.lifecycle.Phase(eventTicker)
+}
+
 // Stop gracefully stops the engine
 func (e *Engine) Stop() {
 	close(e.stopChan)
@@ -164,44 +516,114 @@ func (e *Engine) GetStats() map[string]interface{} {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
-	return map[string]interface{}{
+	stats := map[string]interface{}{
 		"total_trades":     e.totalTrades,
 		"yes_trades":       e.totalYesTrades,
 		"no_trades":        e.totalNoTrades,
 		"daily_pnl":        e.dailyPnL,
 		"open_positions":   len(e.positions),
 		"positions":        e.positions,
+		"mode":             e.Mode(),
+		"disabled_markets": e.toggles.Disabled(),
+		"risk":             e.risk.Usage(time.Now()),
+		"resting_exposure": e.restingExposure(),
+		"overrides":        e.overrides.Active(time.Now()),
+		"external_signals": e.external.Status(time.Now()),
+		"phases":           e.lifecycle.Phases(),
+	}
+	if e.limits != nil {
+		stats["limits"] = e.limits.Status()
 	}
+	if e.calibration != nil {
+		stats["calibration"] = e.calibration.Estimates()
+		stats["closing_bias"] = e.calibration.ClosingBiases()
+	}
+	if e.campaign != nil {
+		stats["campaign"] = e.campaign.plan
+	}
+	if e.config.Positions != nil {
+		stats["divergences"] = e.divergenceList()
+	}
+	return stats
+}
+
+// OpenPositions returns the unsettled trades, oldest first
+func (e *Engine) OpenPositions() []Trade {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var trades []Trade
+	for _, events := range e.positions {
+		trades = append(trades, events...)
+	}
+	sort.Slice(trades, func(i, j int) bool { return trades[i].Timestamp.Before(trades[j].Timestamp) })
+	return trades
 }
 
 func (e *Engine) tick() {
 	now := time.Now()
 	log.Printf("[Engine] Tick at %s", now.Format("15:04:05"))
 
+	e.CheckFeeds(now)
+	e.settlePositions(now)
+	e.housekeep(now)
+	e.exitPositions(now)
+	e.watchThresholds(now)
+	e.watchExpiry(now)
+	e.watchClose(now)
+	e.checkPositions(now)
+	e.refreshBankroll()
+
+	e.mu.Lock()
+	e.entriesHalted = false
+	e.mu.Unlock()
+
+	var candidates []candidate
 	for _, station := range DefaultStations {
-		e.analyzeStation(station, now)
+		candidates = append(candidates, e.analyzeStation(station, MarketHigh, now)...)
+		if e.config.TradeLow && station.LowPrefix != "" {
+			candidates = append(candidates, e.analyzeStation(station, MarketLow, now)...)
+		}
 	}
+	e.allocate(candidates)
+	e.recordExposure()
+	e.recordTradingWindow()
 }
 
-func (e *Engine) analyzeStation(station Station, now time.Time) {
+// analyzeStation returns the strategy's orders for the station's current
+// event of marketType (MarketHigh or MarketLow), to be placed by allocate
+func (e *Engine) analyzeStation(station Station, marketType string, now time.Time) []candidate {
+	// LOW events are logged as e.g. "Denver LOW"
+	city := station.City
+	if marketType == MarketLow {
+		city += " LOW"
+	}
+
 	loc, err := time.LoadLocation(station.Timezone)
 	if err != nil {
-		log.Printf("[Engine] %s: Failed to load timezone: %v", station.City, err)
-		return
+		log.Printf("[Engine] %s: Failed to load timezone: %v", city, err)
+		return nil
 	}
 
-	localTime := now.In(loc)
-	localHour := localTime.Hour()
-
-	// Check trading window
-	if localHour < e.config.TradingStartHour || localHour >= e.config.TradingEndHour {
-		log.Printf("[Engine] %s: Outside trading window (%d:00 local)", station.City, localHour)
-		return
+	if !e.toggles.IsEnabled(station.Code, marketType) {
+		log.Printf("[Engine] %s: Disabled by runtime toggle", city)
+		return nil
 	}
 
+	localTime := now.In(loc)
+
 	// Build event ticker
 	dateCode := strings.ToUpper(localTime.Format("06Jan02"))
-	eventTicker := fmt.Sprintf("%s-%s", station.EventPrefix, dateCode)
+	eventTicker := fmt.Sprintf("%s-%s", station.prefix(marketType), dateCode)
+
+	// The morning briefing plans the event the first time it is seen
+	e.brief(station, city, marketType, eventTicker, localTime, now)
+
+	// Only enter in a phase that allows entries
+	if phase := e.lifecycle.Advance(eventTicker, localTime, localTime); !e.strategy.Behavior(phase).Enters() {
+		log.Printf("[Engine] %s: %s, not entering (%d:00 local)", city, phase, localTime.Hour())
+		return nil
+	}
 
 	// Check existing positions
 	e.mu.RLock()
@@ -209,170 +631,207 @@ func (e *Engine) analyzeStation(station Station, now time.Time) {
 	e.mu.RUnlock()
 
 	if hasPosition {
-		log.Printf("[Engine] %s: Already have position in %s", station.City, eventTicker)
-		return
+		log.Printf("[Engine] %s: Already have position in %s", city, eventTicker)
+		return nil
 	}
 
-	// Fetch markets
-	markets, err := e.fetchMarkets(eventTicker)
+	// Fetch markets; the tails are only used to check the layout
+	all, err := e.fetchEventMarkets(eventTicker)
 	if err != nil {
-		log.Printf("[Engine] %s: Failed to fetch markets: %v", station.City, err)
-		return
+		log.Printf("[Engine] %s: Failed to fetch markets: %v", city, err)
+		return nil
 	}
+	markets := brackets(all)
 
 	if len(markets) == 0 {
 		log.Printf("[Engine] %s: No active markets", station.City)
-		return
-	}
-
-	// Get bracket info
-	type BracketInfo struct {
-		Market   Market
-		Bracket  string
-		YesPrice int
-		NoPrice  int
+		return nil
 	}
 
-	var brackets []BracketInfo
-	for _, m := range markets {
-		if m.Status != "active" {
-			continue
-		}
-		yesPrice := int(m.YesBid * 100)
-		noPrice := 100 - yesPrice
-		if m.NoBid > 0 {
-			noPrice = int(m.NoBid * 100)
-		}
+	data, byTicker := e.quote(station, marketType, eventTicker, markets, localTime, now)
 
-		if yesPrice > 0 {
-			brackets = append(brackets, BracketInfo{
-				Market:   m,
-				Bracket:  fmt.Sprintf("%d-%d°", m.FloorStrike, m.CapStrike),
-				YesPrice: yesPrice,
-				NoPrice:  noPrice,
-			})
-		}
+	// Get METAR (an operator override replaces the max for the day)
+	metar, err := e.getMETAR(station, localTime)
+	var override Override
+	var overridden bool
+	if marketType == MarketHigh {
+		override, overridden = e.overrides.Get(station.Code, localTime.Format("2006-01-02"), InputMaxTemp)
 	}
-
-	if len(brackets) == 0 {
-		log.Printf("[Engine] %s: No priced brackets", station.City)
-		return
+	if overridden {
+		if err == nil {
+			log.Printf("[Engine] %s: Operator override %s replaces METAR max %.0f°", city, override, metar.MaxTemp)
+		} else {
+			log.Printf("[Engine] %s: Operator override %s (METAR unavailable: %v)", city, override, err)
+		}
+		metar = &weather.METARData{MaxTemp: math.Round(override.Value)}
+		err = nil
 	}
-
-	// Sort by YES price (favorite first)
-	sort.Slice(brackets, func(i, j int) bool {
-		return brackets[i].YesPrice > brackets[j].YesPrice
-	})
-
-	favorite := brackets[0]
-
-	// Get METAR
-	metarMax, err := e.getMETARMax(station, localTime)
 	if err != nil {
-		log.Printf("[Engine] %s: Failed to get METAR: %v", station.City, err)
-		return
+		log.Printf("[Engine] %s: Failed to get METAR: %v", city, err)
+		return nil
 	}
 
-	// Find METAR bracket
-	var metarBracket string
-	for _, b := range brackets {
-		if b.Market.FloorStrike <= metarMax && b.Market.CapStrike >= metarMax {
-			metarBracket = b.Bracket
-			break
-		}
+	update := strategy.WeatherUpdate{
+		Time:     now,
+		City:     station.Code,
+		MaxTempF: metar.MaxTemp,
+		MinTempF: metar.MinTemp,
 	}
-
-	// Check signal agreement
-	signalsAgree := favorite.Bracket == metarBracket
-
-	log.Printf("[Engine] %s: Fav=%s@%d¢ METAR=%d°→%s Agree=%v",
-		station.City, favorite.Bracket, favorite.YesPrice, metarMax, metarBracket, signalsAgree)
-
-	if !signalsAgree {
-		log.Printf("[Engine] %s: Signals don't agree, skipping", station.City)
-		return
+	e.checkLayout(city, data, all, update)
+	e.quoteBooks(&data, now)
+
+	// Under an A/B test the city-day trades the variant it is assigned
+	entries, timing := strategy.Strategy(e.strategy), e.timing
+	variant := e.abVariant(station, localTime)
+	if variant == strategy.VariantB {
+		entries, timing = e.variant, e.variantTiming
 	}
-
-	// Check YES price range
-	if favorite.YesPrice < e.config.MinYesPrice || favorite.YesPrice > e.config.MaxYesPrice {
-		log.Printf("[Engine] %s: YES price %d¢ out of range [%d-%d]",
-			station.City, favorite.YesPrice, e.config.MinYesPrice, e.config.MaxYesPrice)
-		return
+	if timing != nil {
+		entries = timing
 	}
-
-	// Execute trades
-	var trades []Trade
-
-	// 1. BUY YES on favorite
-	yesTrade, err := e.executeYesTrade(station, eventTicker, favorite.Market, favorite.Bracket, favorite.YesPrice)
-	if err != nil {
-		log.Printf("[Engine] %s: YES trade failed: %v", station.City, err)
-		if e.onError != nil {
-			e.onError(err)
+	entries.OnWeatherUpdate(update)
+	entries.OnMarketData(data)
+	orders := entries.GenerateOrders(now)
+
+	// One strategy per event: the fade only bets against the favorite on
+	// events the main strategy, which backs it, passes on (or is waiting
+	// out sell pressure on)
+	name := ""
+	backed := len(orders) > 0
+	if e.config.Hedge != nil && backed {
+		if hedged, ok := e.hedgeOrders(city, data, update, now); ok {
+			orders, name = hedged, "hedge"
 		}
-	} else if yesTrade != nil {
-		trades = append(trades, *yesTrade)
-		if e.onTrade != nil {
-			e.onTrade(*yesTrade)
+	}
+	if e.fade != nil {
+		e.fade.OnWeatherUpdate(update)
+		if !backed && !timing.Waiting(eventTicker) {
+			e.fade.OnMarketData(data)
+			if orders = e.fade.GenerateOrders(now); len(orders) > 0 {
+				name = e.fade.Name()
+			}
 		}
 	}
 
-	// 2. BUY NO on losing brackets
-	noCount := 0
-	for _, b := range brackets {
-		if b.Bracket == favorite.Bracket {
+	// Orders wait for the other stations' so capital goes to the best
+	// turnover across all of them
+	var candidates []candidate
+	for _, o := range orders {
+		m, ok := byTicker[o.Ticker]
+		if !ok {
 			continue
 		}
-		if noCount >= e.config.MaxNoTrades {
-			break
-		}
-		if b.NoPrice < e.config.MinNoPrice || b.NoPrice > e.config.MaxNoPrice {
+		if err := e.config.Expiry.CheckEntry(strategy.ParseCloseTime(m.CloseTime), now); err != nil {
+			log.Printf("[Engine] %s: Skipping %s on %s: %v", city, strings.ToUpper(o.Side), m.Ticker, err)
 			continue
 		}
+		c := e.newCandidate(station, eventTicker, m, o, now)
+		c.override, c.overridden = override, overridden
+		c.metar = metar
+		c.prob = e.winProbability(data, update, m, o.Side)
+		c.strategy = name
+		c.variant = variant
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
 
-		noTrade, err := e.executeNoTrade(station, eventTicker, b.Market, b.Bracket, b.NoPrice)
-		if err != nil {
-			log.Printf("[Engine] %s: NO trade failed: %v", station.City, err)
-			if e.onError != nil {
-				e.onError(err)
-			}
-		} else if noTrade != nil {
-			trades = append(trades, *noTrade)
-			noCount++
-			if e.onTrade != nil {
-				e.onTrade(*noTrade)
-			}
+// quote returns the strategy's snapshot of an event's active brackets, and
+// those markets by ticker
+func (e *Engine) quote(station Station, marketType, eventTicker string, markets []Market, localTime, now time.Time) (strategy.MarketData, map[string]Market) {
+	data := strategy.MarketData{Time: localTime, City: station.Code, EventTicker: eventTicker, Type: weather.MarketType(marketType)}
+	byTicker := make(map[string]Market)
+	for _, m := range markets {
+		if m.Status != "active" || e.marketPaused(m.Ticker, now) || e.divergent(m.Ticker) {
+			continue
 		}
+		data.Quotes = append(data.Quotes, strategy.Quote{
+			Ticker: m.Ticker,
+			Floor:  m.Strike.Floor,
+			Cap:    m.Strike.Cap,
+			YesBid: m.YesBid,
+			YesAsk: m.YesAsk,
+			NoBid:  m.NoBid,
+			NoAsk:  m.NoAsk,
+		})
+		byTicker[m.Ticker] = m
 	}
+	sort.Slice(data.Quotes, func(i, j int) bool { return data.Quotes[i].Floor < data.Quotes[j].Floor })
+	return data, byTicker
+}
 
-	// Record positions
-	if len(trades) > 0 {
-		e.mu.Lock()
-		e.positions[eventTicker] = trades
-		e.mu.Unlock()
+// executeOrder places one of the strategy's buy orders, sized by the sizer
+// if set, cut to the capacity cap, the cash above the reserve and the day's
+// campaign budget, and subject to the EV gate; nil means it was skipped
+func (e *Engine) executeOrder(station Station, eventTicker string, market Market, bracket, strategyName string, o strategy.Order, prob float64, metar *weather.METARData) (*Trade, error) {
+	price, err := e.conformPrice(market.Ticker, o.Price)
+	if err != nil {
+		return nil, err
 	}
-}
+	side := strings.ToUpper(o.Side)
 
-func (e *Engine) executeYesTrade(station Station, eventTicker string, market Market, bracket string, price int) (*Trade, error) {
-	contracts := int(e.config.BetYes * 100 / float64(price))
-	if contracts < 1 {
-		contracts = 1
+	quantity := e.quantity(station, eventTicker, market.Ticker, o, price, prob)
+	if quantity == 0 && e.sizer != nil {
+		log.Printf("[Engine] %s: Skipping %s on %s, no edge or daily budget left for %s sizing",
+			station.City, side, market.Ticker, e.sizer.Method())
+		return nil, nil
+	}
+	contracts := e.size(station, eventTicker, market.Ticker, quantity, price)
+	if contracts == 0 {
+		log.Printf("[Engine] %s: Skipping %s on %s, no cash above the %.0f%% reserve",
+			station.City, side, market.Ticker, e.config.CashReserve*100)
+		return nil, nil
+	}
+	if contracts = e.campaignSize(station, market.Ticker, contracts, price); contracts == 0 {
+		log.Printf("[Engine] %s: Skipping %s on %s, today's campaign budget is spent",
+			station.City, side, market.Ticker)
+		return nil, nil
 	}
 	cost := float64(contracts*price) / 100.0
 
-	log.Printf("[Engine] %s: Executing YES BUY %d @ %d¢ ($%.2f)",
-		station.City, contracts, price, cost)
+	e.metrics.SetEdge(market.Ticker, o.Side, prob-float64(price)/100)
+	if !e.passesEVGate(station, market.Ticker, contracts, price, prob) {
+		return nil, nil
+	}
 
-	orderID, err := e.executor.ExecuteOrder(ExecuteOrderRequest{
+	log.Printf("[Engine] %s: Executing %s BUY %d @ %d¢ ($%.2f) — %s",
+		station.City, side, contracts, price, cost, o.Reason)
+
+	entry := e.entrySnapshot(station, eventTicker, market, metar, time.Now())
+	req := ExecuteOrderRequest{
 		Ticker:   market.Ticker,
-		Side:     "yes",
+		Side:     o.Side,
 		Action:   "buy",
 		Price:    price,
 		Quantity: contracts,
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("order failed: %w", err)
+		Strategy: strategyName,
+	}
+	var orderID, route, status string
+	for attempt := 0; ; attempt++ {
+		orderID, route, status, err = e.placeOrder(station, eventTicker, req)
+		if err == nil {
+			break
+		}
+		e.metrics.OrderRejected(station.Code, rejectReason(err))
+		class, ok := rest.ClassifyRejection(err)
+		if !ok || attempt == maxRemedies {
+			return nil, fmt.Errorf("order failed: %w", err)
+		}
+		var retry bool
+		if req, retry = e.remedy(station, class, req, o.Price, time.Now()); !retry {
+			if class == rest.RejectMarketPaused || class == rest.RejectFunds {
+				return nil, nil // Handled; not worth an alert
+			}
+			return nil, fmt.Errorf("order failed: %w", err)
+		}
+	}
+	price, contracts = req.Price, req.Quantity
+	cost = float64(contracts*price) / 100.0
+	if status != "shadow" {
+		e.metrics.OrderPlaced(station.Code, o.Side, status == "filled")
+	}
+	if route == RouteFallback {
+		log.Printf("[Engine] %s: Order %s placed via the fallback API", station.City, orderID)
 	}
 
 	trade := &Trade{
@@ -381,135 +840,502 @@ func (e *Engine) executeYesTrade(station Station, eventTicker string, market Mar
 		EventTicker: eventTicker,
 		Bracket:     bracket,
 		Ticker:      market.Ticker,
-		Side:        "yes",
+		Side:        o.Side,
 		Action:      "buy",
 		Price:       price,
 		Quantity:    contracts,
 		Cost:        cost,
 		OrderID:     orderID,
-		Status:      "filled",
+		Status:      status,
+		Route:       route,
+		Closes:      strategy.ParseCloseTime(market.CloseTime),
+		Strategy:    strategyName,
+		Entry:       entry,
 	}
 
 	e.mu.Lock()
 	e.totalTrades++
-	e.totalYesTrades++
+	if o.Side == "yes" {
+		e.totalYesTrades++
+	} else {
+		e.totalNoTrades++
+	}
 	e.mu.Unlock()
 
 	return trade, nil
 }
 
-func (e *Engine) executeNoTrade(station Station, eventTicker string, market Market, bracket string, price int) (*Trade, error) {
-	contracts := int(e.config.BetNo * 100 / float64(price))
-	if contracts < 1 {
-		contracts = 1
+// conformPrice rounds a buy price down onto the market's tick grid, so sizing,
+// the EV gate and the recorded trade use the price actually sent
+func (e *Engine) conformPrice(ticker string, price int) (int, error) {
+	grid := e.executor.PriceGrid(ticker)
+	rounded := grid.RoundDown(price)
+	if rounded > price {
+		return 0, fmt.Errorf("%w: %d¢ below minimum %d¢ on %s", rest.ErrInvalidPrice, price, grid.Min(), ticker)
 	}
-	cost := float64(contracts*price) / 100.0
+	if rounded != price {
+		log.Printf("[Engine] %s: Rounded %d¢ to %d¢ (tick grid)", ticker, price, rounded)
+	}
+	return rounded, nil
+}
 
-	log.Printf("[Engine] %s: Executing NO BUY %d @ %d¢ ($%.2f)",
-		station.City, contracts, price, cost)
+// quantity returns the contracts to bet on one of the strategy's orders: its
+// fixed size, or with a sizer the size for prob, the model's probability the
+// order wins, at price against the bankroll, once the balance is known
+func (e *Engine) quantity(station Station, eventTicker, ticker string, o strategy.Order, price int, prob float64) int {
+	e.mu.RLock()
+	known := e.cashKnown
+	e.mu.RUnlock()
+	if e.sizer == nil || !known {
+		return o.Quantity
+	}
 
-	orderID, err := e.executor.ExecuteOrder(ExecuteOrderRequest{
-		Ticker:   market.Ticker,
-		Side:     "no",
-		Action:   "buy",
+	_, _, bankroll := e.exposure(station, eventTicker)
+	rule := e.fees.RuleForTicker(ticker, time.Now())
+	// Fees on 100 contracts in dollars are the per-contract fees in cents,
+	// without the rounding of a single contract's fee
+	contracts := e.sizer.Contracts(sizing.Bet{
+		Prob:     prob,
 		Price:    price,
-		Quantity: contracts,
-	})
+		Fee:      rule.EntryFee(fees.Maker, 100, price),
+		WinFee:   rule.SettlementFee(fees.Maker, 100, price, true),
+		Bankroll: bankroll,
+	}, time.Now())
+	if contracts != o.Quantity {
+		log.Printf("[Engine] %s: Sizing %s at %d contracts (%s at %.0f%%, $%.2f bankroll) instead of %d",
+			station.City, ticker, contracts, e.sizer.Method(), prob*100, bankroll, o.Quantity)
+	}
+	return contracts
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("order failed: %w", err)
+// size returns how many of the strategy's contracts to buy at price. Orders
+// are first capped at the configured share of the estimated capacity. With a
+// cash reserve the order shrinks to the cash above the reserve, net of entry
+// fees, so sizing tapers as the balance runs down instead of failing on
+// fees; 0 means the reserve is reached
+func (e *Engine) size(station Station, eventTicker, ticker string, contracts, price int) int {
+	if capped := e.config.Capacity.Limit(contracts, e.config.CapacityFraction); capped < contracts {
+		log.Printf("[Engine] %s: Sizing %s down to %d contracts, %.0f%% of the estimated capacity of %d",
+			station.City, ticker, capped, e.config.CapacityFraction*100, e.config.Capacity.Contracts)
+		contracts = capped
 	}
 
-	trade := &Trade{
-		Timestamp:   time.Now(),
-		City:        station.City,
-		EventTicker: eventTicker,
-		Bracket:     bracket,
-		Ticker:      market.Ticker,
-		Side:        "no",
-		Action:      "buy",
-		Price:       price,
-		Quantity:    contracts,
-		Cost:        cost,
-		OrderID:     orderID,
-		Status:      "filled",
+	e.mu.RLock()
+	cash, known := e.cash, e.cashKnown
+	e.mu.RUnlock()
+	if e.config.CashReserve <= 0 || !known {
+		return contracts
+	}
+
+	_, _, bankroll := e.exposure(station, eventTicker)
+	spendable := cash - e.config.CashReserve*bankroll
+	rule := e.fees.RuleForTicker(ticker, time.Now())
+	affordable := int(spendable * 100 / float64(price))
+	for affordable > 0 && float64(affordable*price)/100+rule.EntryFee(fees.Maker, float64(affordable), price) > spendable {
+		affordable--
+	}
+	if affordable < contracts {
+		log.Printf("[Engine] %s: Sizing %s down to %d contracts, $%.2f spendable above the reserve",
+			station.City, ticker, max(affordable, 0), spendable)
+		return max(affordable, 0)
+	}
+	return contracts
+}
+
+// winProbability returns the model's probability that the order's side of
+// the market wins, on the weather the order was decided on
+func (e *Engine) winProbability(data strategy.MarketData, update strategy.WeatherUpdate, m Market, side string) float64 {
+	prob := e.exitModel.Probability(data, update, strategy.Quote{Ticker: m.Ticker, Floor: m.Strike.Floor, Cap: m.Strike.Cap})
+	if side == "no" {
+		prob = 1 - prob
+	}
+	return prob
+}
+
+// passesEVGate returns true if buying contracts at price has positive
+// expected value after fees at prob, the model's probability the order wins
+func (e *Engine) passesEVGate(station Station, ticker string, contracts, price int, prob float64) bool {
+	if !e.config.EVGate {
+		return true
+	}
+	rule := e.fees.RuleForTicker(ticker, time.Now())
+	ev := rule.ExpectedValue(fees.Maker, float64(contracts), price, prob)
+	if ev <= 0 {
+		log.Printf("[Engine] %s: Skipping %s @ %d¢, EV $%.2f after fees at a %.0f%% model probability",
+			station.City, ticker, price, ev, prob*100)
+		return false
 	}
+	return true
+}
 
+// placeOrder sends the order to the executor in live mode, subject to the
+// risk limits, returning its order ID, route and status; in shadow mode the
+// decision is only recorded
+func (e *Engine) placeOrder(station Station, eventTicker string, req ExecuteOrderRequest) (string, string, string, error) {
+	if e.Mode() == strategy.ModeShadow {
+		orderID := fmt.Sprintf("SHADOW-%d", time.Now().UnixNano())
+		log.Printf("[Engine] SHADOW: %s %s %d @ %d¢ on %s (not sent)",
+			req.Action, req.Side, req.Quantity, req.Price, req.Ticker)
+		return orderID, "", "shadow", nil
+	}
+
+	now := time.Now()
+	cost := float64(req.Quantity*req.Price) / 100
+	if err := e.risk.Check(now, cost); err != nil {
+		e.reportThrottle(err)
+		return "", "", "", err
+	}
+	eventCost, cityCost, bankroll := e.exposure(station, eventTicker)
+	if err := e.risk.CheckConcentration(bankroll, eventCost, cityCost, cost); err != nil {
+		e.reportThrottle(err)
+		return "", "", "", err
+	}
+	if e.limits != nil {
+		if err := e.limits.Check(risk.Order{Ticker: req.Ticker, Cost: cost}); err != nil {
+			return "", "", "", err
+		}
+	}
 	e.mu.Lock()
-	e.totalTrades++
-	e.totalNoTrades++
+	e.throttled = false
 	e.mu.Unlock()
 
-	return trade, nil
+	orderID, route, err := e.executor.ExecuteOrder(req)
+	if err != nil {
+		return "", "", "", err
+	}
+	// Spend from the cached balance until the next refresh, so later legs of
+	// the stack are sized against what is left
+	e.mu.Lock()
+	e.cash -= cost
+	e.mu.Unlock()
+	if err := e.risk.Record(now, cost); err != nil {
+		log.Printf("[Engine] Failed to persist risk log: %v", err)
+	}
+	if e.sizer != nil {
+		e.sizer.Record(cost, now)
+	}
+	if e.limits != nil {
+		e.limits.Record(risk.Order{Ticker: req.Ticker, Cost: cost})
+	}
+	return orderID, route, "filled", nil
 }
 
-func (e *Engine) fetchMarkets(eventTicker string) ([]Market, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets?event_ticker=%s&limit=100", eventTicker)
+// exposure returns the open cost in an event and in all of the station's
+// events on the same day, and the bankroll (cash plus open cost). Resting buy
+// orders count as open cost: the balance excludes the cash they reserve, and
+// they become positions if they fill
+func (e *Engine) exposure(station Station, eventTicker string) (eventCost, cityCost, bankroll float64) {
+	dateCode := eventTicker[strings.LastIndex(eventTicker, "-")+1:]
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
 
-	resp, err := e.httpClient.Get(url)
+	open := 0.0
+	for event, trades := range e.positions {
+		for _, t := range trades {
+			if t.Settled || t.Status == "shadow" {
+				continue
+			}
+			cost := t.Cost * float64(t.Quantity-t.Sold) / float64(t.Quantity)
+			open += cost
+			if event == eventTicker {
+				eventCost += cost
+			}
+			if t.City == station.City && strings.HasSuffix(event, "-"+dateCode) {
+				cityCost += cost
+			}
+		}
+	}
+	for event, cost := range e.resting {
+		open += cost
+		if event == eventTicker {
+			eventCost += cost
+		}
+		if event == station.EventPrefix+"-"+dateCode || (station.LowPrefix != "" && event == station.LowPrefix+"-"+dateCode) {
+			cityCost += cost
+		}
+	}
+	return eventCost, cityCost, e.cash + open
+}
+
+// restingExposure returns the total cost of resting buy orders not tracked
+// as positions. Callers hold e.mu
+func (e *Engine) restingExposure() float64 {
+	total := 0.0
+	for _, cost := range e.resting {
+		total += cost
+	}
+	return total
+}
+
+// refreshBankroll updates the cash balance and resting order exposure used
+// by the concentration limits, the cash reserve, the sizer and the metrics
+func (e *Engine) refreshBankroll() {
+	if !e.risk.HasConcentrationLimits() && e.config.CashReserve <= 0 && e.sizer == nil && e.metrics == nil {
+		return
+	}
+	if err := e.Reconcile(); err != nil {
+		log.Printf("[Engine] Failed to refresh the account: %v", err)
+	}
+}
+
+// Reconcile fetches the cash balance and the resting orders not tracked as
+// positions, reporting the result as the readiness state check
+func (e *Engine) Reconcile() error {
+	err := e.syncAccount()
+	e.ready.Report(CheckState, err)
+	return err
+}
+
+func (e *Engine) syncAccount() error {
+	cash, err := e.executor.GetBalance()
+	e.health.Observe(HealthKalshi, err)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("balance: %w", err)
+	}
+	e.metrics.SetBalance(cash)
+	orders, err := e.executor.RestingOrders()
+	if err != nil {
+		err = fmt.Errorf("resting orders: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cash = cash
+	e.cashKnown = true
+	if err != nil {
+		return err // Keep the last known resting exposure
+	}
+
+	// Orders the engine placed are already counted as positions
+	tracked := make(map[string]bool)
+	for _, trades := range e.positions {
+		for _, t := range trades {
+			tracked[t.OrderID] = true
+		}
+	}
+	e.resting = make(map[string]float64)
+	for _, o := range orders {
+		if tracked[o.OrderID] {
+			continue
+		}
+		if cost := o.RestingCost(); cost > 0 {
+			event := o.Ticker[:max(strings.LastIndex(o.Ticker, "-"), 0)]
+			e.resting[event] += float64(cost) / 100
+		}
+	}
+	return nil
+}
+
+// reportThrottle passes the first risk limit hit of a run to the error
+// callback; later hits are only logged until an order gets through again
+func (e *Engine) reportThrottle(err error) {
+	e.mu.Lock()
+	first := !e.throttled
+	e.throttled = true
+	e.mu.Unlock()
+
+	if first && e.onError != nil {
+		e.onError(err)
 	}
-	defer resp.Body.Close()
+}
 
-	body, _ := io.ReadAll(resp.Body)
+// settlePositions realizes P&L for events once their station's CLI report
+// has posted and their markets have a result, and feeds completed days to
+// the performance guard. Days are the events' local days, so a day is
+// reported once its last station has settled
+func (e *Engine) settlePositions(now time.Time) {
+	e.mu.RLock()
+	events := make([]string, 0, len(e.positions))
+	for eventTicker := range e.positions {
+		events = append(events, eventTicker)
+	}
+	e.mu.RUnlock()
 
-	var result MarketsResponse
-	if err := json.Unmarshal(body, &result); err != nil {
+	for _, eventTicker := range events {
+		e.mu.RLock()
+		trades := append([]Trade(nil), e.positions[eventTicker]...)
+		e.mu.RUnlock()
+		if len(trades) == 0 {
+			continue
+		}
+
+		day := tradeDate(eventTicker, trades[0])
+		if !settlementDue(eventTicker, trades[0], now) {
+			continue
+		}
+
+		settled := true
+		eventPnL, soldPnL := 0.0, 0.0
+		for i := range trades {
+			result, err := e.executor.GetMarketResult(trades[i].Ticker)
+			if err != nil || result == "" {
+				settled = false
+				break
+			}
+			trades[i].Profit = e.tradeProfit(trades[i], result)
+			trades[i].Settled = true
+			eventPnL += trades[i].Profit
+			soldPnL += e.soldProfit(trades[i])
+		}
+		if !settled {
+			continue
+		}
+
+		log.Printf("[Engine] Settled %s: P&L $%.2f", eventTicker, eventPnL)
+		e.eventPhase(eventTicker, now)
+		e.lifecycle.Settle(eventTicker, now)
+		e.recordAB(eventTicker, trades, eventPnL)
+		if e.limits != nil {
+			// Contracts sold by exits counted toward the limits when they sold
+			e.limits.Settle(eventTicker, eventPnL-soldPnL)
+		}
+
+		e.mu.Lock()
+		delete(e.positions, eventTicker)
+		e.settledByDay[day] += eventPnL
+		e.settledTrades[day] = append(e.settledTrades[day], trades...)
+		dayComplete := true
+		for event, open := range e.positions {
+			if len(open) > 0 && tradeDate(event, open[0]) == day {
+				dayComplete = false
+				break
+			}
+		}
+		dayPnL := e.settledByDay[day]
+		dayTrades := e.settledTrades[day]
+		var briefing *Briefing
+		if dayComplete {
+			e.dailyPnL = dayPnL
+			briefing = e.briefings[day]
+			delete(e.settledByDay, day)
+			delete(e.settledTrades, day)
+			delete(e.briefings, day)
+		}
+		e.mu.Unlock()
+
+		if dayComplete {
+			doc := e.Describe()
+			report := DayReport{Date: day, PnL: dayPnL, Trades: dayTrades, Notes: e.journal.Notes(day), Plan: briefing.Diff(dayTrades), Strategy: &doc}
+			log.Printf("[Engine] %s", report)
+			if e.onReport != nil {
+				e.onReport(report)
+			}
+		}
+
+		if dayComplete && e.guard != nil {
+			date, _ := time.Parse("2006-01-02", day)
+			e.guard.RecordDay(date, dayPnL)
+		}
+	}
+}
+
+// tradeProfit returns the settlement P&L of a buy after fees given the
+// market result, including any contracts sold early by an exit rule
+func (e *Engine) tradeProfit(t Trade, result string) float64 {
+	rule := e.fees.RuleForTicker(t.Ticker, t.Timestamp)
+	held := t.Quantity - t.Sold
+	return rule.NetProfit(fees.Maker, float64(held), t.Price, t.Side == result) + e.soldProfit(t)
+}
+
+// soldProfit returns the P&L after fees of the contracts of a buy sold
+// early by an exit rule
+func (e *Engine) soldProfit(t Trade) float64 {
+	if t.Sold == 0 {
+		return 0
+	}
+	rule := e.fees.RuleForTicker(t.Ticker, t.Timestamp)
+	return rule.ExitProfit(fees.Maker, fees.Taker, float64(t.Sold), t.Price, t.SoldPrice)
+}
+
+// fetchMarkets returns eventTicker's bracket markets by floor, reporting the
+// call to the health check
+func (e *Engine) fetchMarkets(eventTicker string) ([]Market, error) {
+	markets, err := e.fetchEventMarkets(eventTicker)
+	return brackets(markets), err
+}
+
+// fetchEventMarkets returns all of eventTicker's markets, tails included,
+// reporting the call to the health check
+func (e *Engine) fetchEventMarkets(eventTicker string) ([]Market, error) {
+	markets, err := e.fetchEvent(eventTicker)
+	e.health.Observe(HealthKalshi, err)
+	return markets, err
+}
+
+func (e *Engine) fetchBrackets(eventTicker string) ([]Market, error) {
+	markets, err := e.fetchEvent(eventTicker)
+	return brackets(markets), err
+}
+
+func (e *Engine) fetchEvent(eventTicker string) ([]Market, error) {
+	list, err := e.executor.Markets(eventTicker)
+	if err != nil {
 		return nil, err
 	}
+	markets := make([]Market, 0, len(list))
+	for _, m := range list {
+		strike, err := market.MarketStrike(m)
+		if err != nil {
+			// One unreadable market leaves the rest of the event tradable
+			log.Printf("[Engine] Skipping %s: %v", m.Ticker, err)
+			continue
+		}
+		markets = append(markets, Market{Market: m, Strike: strike})
+	}
+	return markets, nil
+}
 
-	var brackets []Market
-	for _, m := range result.Markets {
+// brackets returns the event's bracket markets (tickers ending in a B
+// strike), leaving out the tails, by floor
+func brackets(markets []Market) []Market {
+	var list []Market
+	for _, m := range markets {
 		parts := strings.Split(m.Ticker, "-")
 		if len(parts) >= 3 && strings.HasPrefix(parts[len(parts)-1], "B") {
-			brackets = append(brackets, m)
+			list = append(list, m)
 		}
 	}
 
-	sort.Slice(brackets, func(i, j int) bool {
-		return brackets[i].FloorStrike < brackets[j].FloorStrike
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Strike.Floor < list[j].Strike.Floor
 	})
 
-	return brackets, nil
+	return list
 }
 
 func (e *Engine) getMETARMax(station Station, date time.Time) (int, error) {
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=%s&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=%s&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		station.METAR,
-		date.Year(), int(date.Month()), date.Day(),
-		date.Year(), int(date.Month()), date.Day()+1,
-		station.Timezone,
-	)
-
-	resp, err := e.httpClient.Get(url)
+	data, err := e.getMETAR(station, date)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	lines := strings.Split(string(body), "\n")
-	maxTemp := -999.0
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, station.METAR+",") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > maxTemp {
-					maxTemp = temp
-				}
-			}
-		}
+	return int(data.MaxTemp), nil
+}
+
+// getMETAR returns the station's reports so far on date's local day, with
+// their running max and min
+func (e *Engine) getMETAR(station Station, date time.Time) (*weather.METARData, error) {
+	ws := weather.StationByCode(station.Code)
+	if ws == nil {
+		return nil, fmt.Errorf("no weather station %s", station.Code)
 	}
 
-	if maxTemp == -999.0 {
-		return 0, fmt.Errorf("no METAR data")
+	// The provider drops future-dated and off-day reports; also refuse a
+	// stale feed, whose running max may have missed the high
+	data, err := weather.DailyMax(context.Background(), e.observations, ws, date)
+	if err != nil {
+		e.health.Observe(HealthMETAR, err)
+		return nil, err
 	}
+	latest := data.Observations[len(data.Observations)-1]
+	err = weather.CheckObservationTime(latest.Time, time.Now())
+	e.health.Observe(HealthMETAR, err)
+	if err != nil {
+		return nil, err
+	}
+	e.metrics.SetTemperature(ws.ID, "current", latest.Temp)
+	e.metrics.SetTemperature(ws.ID, "max", data.MaxTemp)
+	e.metrics.SetTemperature(ws.ID, "min", data.MinTemp)
 
-	return int(math.Round(maxTemp)), nil
+	return data, nil
 }
-
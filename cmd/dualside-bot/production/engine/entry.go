@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// forecastTTL is how long a station-day's NWS forecast is reused for entry
+// snapshots before it is fetched again
+const forecastTTL = time.Hour
+
+// EntrySnapshot is the market and weather a trade was entered on, kept with
+// the trade so winners and losers can be compared on their entry context.
+// Prices are in cents and temperatures in °F
+type EntrySnapshot struct {
+	YesBid     int     `json:"yes_bid"`
+	YesAsk     int     `json:"yes_ask"`
+	NoBid      int     `json:"no_bid"`
+	NoAsk      int     `json:"no_ask"`
+	YesDepth   int     `json:"yes_depth"`           // Contracts bid in the best strategy.DepthLevels levels
+	NoDepth    int     `json:"no_depth"`            // (both 0 when the book couldn't be fetched)
+	Volume     int     `json:"volume"`              // Contracts traded in the market so far
+	SinceTrade float64 `json:"minutes_since_trade"` // Minutes since the market last traded (-1 = never or unknown)
+	TempF      float64 `json:"temp_f"`              // Latest METAR report (0 = unavailable)
+	RunningF   float64 `json:"running_f"`           // Running max, or min for LOW events
+	ForecastF  float64 `json:"forecast_f"`          // NWS forecast high, or low for LOW events (0 = unavailable)
+}
+
+// forecastReading is a station-day's NWS forecast and when it was fetched
+type forecastReading struct {
+	temp    float64
+	fetched time.Time
+}
+
+// entrySnapshot captures the market's quotes, book depth, volume and last
+// trade, and the station's weather, just before an order is sent. metar is
+// the reading the strategy decided on (nil = unavailable). Lookups that
+// fail leave their fields at the unavailable value; the order goes ahead
+func (e *Engine) entrySnapshot(station Station, eventTicker string, m Market, metar *weather.METARData, now time.Time) *EntrySnapshot {
+	low := station.LowPrefix != "" && strings.HasPrefix(eventTicker, station.LowPrefix+"-")
+	snap := &EntrySnapshot{
+		YesBid:     m.YesBid,
+		YesAsk:     m.YesAsk,
+		NoBid:      m.NoBid,
+		NoAsk:      m.NoAsk,
+		Volume:     m.Volume,
+		SinceTrade: -1,
+	}
+
+	if book, err := e.executor.client.GetOrderbook(m.Ticker, 0); err == nil {
+		snap.YesDepth = book.Depth("yes", strategy.DepthLevels)
+		snap.NoDepth = book.Depth("no", strategy.DepthLevels)
+	} else {
+		log.Printf("[Engine] %s: Entry snapshot without the order book: %v", m.Ticker, err)
+	}
+	if resp, err := e.executor.client.GetTrades(rest.GetTradesParams{Ticker: m.Ticker, Limit: 1}); err == nil && len(resp.Trades) > 0 {
+		snap.SinceTrade = now.Sub(resp.Trades[0].CreatedTime).Minutes()
+	} else if err != nil {
+		log.Printf("[Engine] %s: Entry snapshot without the last trade: %v", m.Ticker, err)
+	}
+
+	if metar != nil {
+		if n := len(metar.Observations); n > 0 {
+			snap.TempF = metar.Observations[n-1].Temp
+		}
+		snap.RunningF = metar.MaxTemp
+		if low {
+			snap.RunningF = metar.MinTemp
+		}
+	}
+	snap.ForecastF = e.entryForecast(station, eventTicker, low, now)
+	return snap
+}
+
+// entryForecast returns the NWS forecast high, or low, of the event's day,
+// fetched at most every forecastTTL, or 0 if it can't be had
+func (e *Engine) entryForecast(station Station, eventTicker string, low bool, now time.Time) float64 {
+	e.mu.RLock()
+	r, ok := e.forecasts[eventTicker]
+	e.mu.RUnlock()
+	if ok && now.Sub(r.fetched) < forecastTTL {
+		return r.temp
+	}
+
+	ws := weather.StationByCode(station.Code)
+	_, day, dated := eventDay(eventTicker)
+	if ws == nil || !dated {
+		return 0
+	}
+	var temp float64
+	if low {
+		f, err := weather.FetchLowForecastForDate(ws, day)
+		if err != nil {
+			log.Printf("[Engine] %s: Entry snapshot without the forecast: %v", station.City, err)
+			return 0
+		}
+		temp = f.LowTemp
+	} else {
+		f, err := weather.FetchForecastForDate(ws, day)
+		if err != nil {
+			log.Printf("[Engine] %s: Entry snapshot without the forecast: %v", station.City, err)
+			return 0
+		}
+		temp = f.HighTemp
+	}
+
+	e.mu.Lock()
+	for t, r := range e.forecasts {
+		if now.Sub(r.fetched) > 24*time.Hour {
+			delete(e.forecasts, t)
+		}
+	}
+	e.forecasts[eventTicker] = forecastReading{temp: temp, fetched: now}
+	e.mu.Unlock()
+	return temp
+}
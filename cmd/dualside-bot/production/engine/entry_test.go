@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/mockexchange"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+func TestEngine_EntrySnapshot(t *testing.T) {
+	chi := DefaultStations[2]
+	now := time.Date(2026, 1, 20, 18, 0, 0, 0, time.UTC)
+	metar := &weather.METARData{
+		Observations: []weather.Observation{{Time: now.Add(-time.Hour), Temp: 1}, {Time: now.Add(-5 * time.Minute), Temp: 2}},
+		MaxTemp:      3,
+		MinTemp:      -4,
+	}
+
+	x := mockexchange.New(1)
+	t.Cleanup(x.Close)
+	for _, eventTicker := range []string{"KXHIGHCHI-26JAN20", "KXLOWTCHI-26JAN20"} {
+		for _, m := range []rest.Market{
+			{Ticker: eventTicker + "-T0", StrikeType: "less", CapStrike: 0.5, YesSubTitle: "0° or below", YesAsk: 4, Volume: 120},
+			{Ticker: eventTicker + "-B1.5", StrikeType: "between", FloorStrike: 0.5, CapStrike: 2.5, YesSubTitle: "1° to 2°", YesBid: 30, YesAsk: 33, Volume: 4100},
+			{Ticker: eventTicker + "-T2", StrikeType: "greater", FloorStrike: 2, YesSubTitle: "3° or above", YesBid: 50, YesAsk: 54, Volume: 2600},
+		} {
+			m.EventTicker = eventTicker
+			x.AddMarket(m)
+		}
+	}
+	e := NewEngine(TradingConfig{TradeLow: true}, newTestExecutor(t, x))
+
+	// Forecasts fetched within the hour are reused rather than fetched again
+	e.forecasts["KXHIGHCHI-26JAN20"] = forecastReading{temp: 4, fetched: now.Add(-10 * time.Minute)}
+	e.forecasts["KXLOWTCHI-26JAN20"] = forecastReading{temp: -6, fetched: now.Add(-10 * time.Minute)}
+
+	tests := []struct {
+		ticker string
+		strike market.Strike
+		want   EntrySnapshot
+	}{
+		{"KXHIGHCHI-26JAN20-T0", market.Strike{Floor: market.OpenFloor, Cap: 0},
+			EntrySnapshot{YesAsk: 4, NoBid: 96, NoDepth: 1000, Volume: 120, SinceTrade: -1, TempF: 2, RunningF: 3, ForecastF: 4}},
+		{"KXHIGHCHI-26JAN20-B1.5", market.Strike{Floor: 1, Cap: 2},
+			EntrySnapshot{YesBid: 30, YesAsk: 33, NoBid: 67, NoAsk: 70, YesDepth: 1000, NoDepth: 1000, Volume: 4100, SinceTrade: -1, TempF: 2, RunningF: 3, ForecastF: 4}},
+		{"KXHIGHCHI-26JAN20-T2", market.Strike{Floor: 3, Cap: market.OpenCap},
+			EntrySnapshot{YesBid: 50, YesAsk: 54, NoBid: 46, NoAsk: 50, YesDepth: 1000, NoDepth: 1000, Volume: 2600, SinceTrade: -1, TempF: 2, RunningF: 3, ForecastF: 4}},
+		// LOW events run on the minimum and the forecast low
+		{"KXLOWTCHI-26JAN20-B1.5", market.Strike{Floor: 1, Cap: 2},
+			EntrySnapshot{YesBid: 30, YesAsk: 33, NoBid: 67, NoAsk: 70, YesDepth: 1000, NoDepth: 1000, Volume: 4100, SinceTrade: -1, TempF: 2, RunningF: -4, ForecastF: -6}},
+	}
+	fetched := make(map[string]Market)
+	for _, eventTicker := range []string{"KXHIGHCHI-26JAN20", "KXLOWTCHI-26JAN20"} {
+		markets, err := e.fetchEventMarkets(eventTicker)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range markets {
+			fetched[m.Ticker] = m
+		}
+	}
+	for _, tt := range tests {
+		m, ok := fetched[tt.ticker]
+		if !ok {
+			t.Errorf("%s: not fetched", tt.ticker)
+			continue
+		}
+		if m.Strike != tt.strike {
+			t.Errorf("%s: Strike = %v, want %v", tt.ticker, m.Strike, tt.strike)
+		}
+		// The mock exchange has no trade history: the last trade is unknown
+		if got := e.entrySnapshot(chi, m.EventTicker, m, metar, now); got == nil || *got != tt.want {
+			t.Errorf("%s: entrySnapshot() = %+v, want %+v", tt.ticker, got, tt.want)
+		}
+	}
+
+	// Without a METAR reading the weather fields are left unavailable
+	got := e.entrySnapshot(chi, "KXHIGHCHI-26JAN20", fetched["KXHIGHCHI-26JAN20-B1.5"], nil, now)
+	if got.TempF != 0 || got.RunningF != 0 || got.ForecastF != 4 {
+		t.Errorf("entrySnapshot(no METAR) = %+v, want no METAR temperatures", got)
+	}
+}
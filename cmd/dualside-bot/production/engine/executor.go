@@ -1,14 +1,21 @@
 package engine
 
 import (
+	"context"
 	"crypto/rsa"
+	"errors"
 	"fmt"
-	"log"
+	"sync"
 	"time"
 
+	"github.com/brendanplayford/kalshi-go/internal/logging"
+	"github.com/brendanplayford/kalshi-go/pkg/paper"
+	"github.com/brendanplayford/kalshi-go/pkg/portfolio"
 	"github.com/brendanplayford/kalshi-go/pkg/rest"
 )
 
+var execLog = logging.For(logging.Execution)
+
 // ExecuteOrderRequest represents an order to execute
 type ExecuteOrderRequest struct {
 	Ticker   string
@@ -16,6 +23,7 @@ type ExecuteOrderRequest struct {
 	Action   string // "buy" or "sell"
 	Price    int    // in cents
 	Quantity int
+	Strategy string // Tags the client order ID ("" = dualside)
 }
 
 // Executor handles order execution with retry logic
@@ -24,11 +32,23 @@ type Executor struct {
 	dryRun     bool
 	maxRetries int
 	retryDelay time.Duration
+
+	gridsMu sync.Mutex
+	grids   map[string]rest.PriceGrid // Ticker -> valid order prices
+
+	// Paper account simulating fills in dry-run mode (nil = dry-run orders
+	// are assumed filled at their price), and where it is saved ("" = not)
+	paper     *paper.Simulator
+	paperPath string
+
+	// Alternate API host orders fail over to (nil = primary only)
+	fallback *failover
 }
 
-// NewExecutor creates a new order executor
-func NewExecutor(apiKey string, privateKey *rsa.PrivateKey, dryRun bool) (*Executor, error) {
-	client := rest.New(apiKey, privateKey)
+// NewExecutor creates a new order executor; opts configure its REST client,
+// e.g. with the failsafe order bounds
+func NewExecutor(apiKey string, privateKey *rsa.PrivateKey, dryRun bool, opts ...rest.Option) (*Executor, error) {
+	client := rest.New(apiKey, privateKey, opts...)
 
 	// Verify connection
 	_, err := client.GetBalance()
@@ -41,11 +61,38 @@ func NewExecutor(apiKey string, privateKey *rsa.PrivateKey, dryRun bool) (*Execu
 		dryRun:     dryRun,
 		maxRetries: 3,
 		retryDelay: 2 * time.Second,
+		grids:      make(map[string]rest.PriceGrid),
 	}, nil
 }
 
-// GetBalance returns current account balance
+// SetPaper simulates dry-run orders on a paper account: queued behind the
+// book, filled by the market's trades, and charged fees. The account is
+// saved to path ("" = not saved) as it changes, so it carries over restarts
+func (e *Executor) SetPaper(sim *paper.Simulator, path string) {
+	e.paper = sim
+	e.paperPath = path
+}
+
+// savePaper saves the paper account, if it is kept
+func (e *Executor) savePaper() {
+	if e.paperPath == "" {
+		return
+	}
+	if err := e.paper.Save(e.paperPath); err != nil {
+		execLog.Error("PAPER: Failed to save the account", "err", err)
+	}
+}
+
+// Paper returns the paper account, or nil
+func (e *Executor) Paper() *paper.Simulator {
+	return e.paper
+}
+
+// GetBalance returns current account balance, or the paper account's
 func (e *Executor) GetBalance() (float64, error) {
+	if e.dryRun && e.paper != nil {
+		return float64(e.paper.Balance()) / 100.0, nil
+	}
 	balance, err := e.client.GetBalance()
 	if err != nil {
 		return 0, err
@@ -53,34 +100,179 @@ func (e *Executor) GetBalance() (float64, error) {
 	return float64(balance.Balance) / 100.0, nil
 }
 
-// ExecuteOrder executes an order with retry logic
-func (e *Executor) ExecuteOrder(req ExecuteOrderRequest) (string, error) {
+// RestingOrders returns the account's resting orders, or the paper account's
+func (e *Executor) RestingOrders() ([]rest.Order, error) {
+	if e.dryRun && e.paper != nil {
+		return e.paper.Orders(), nil
+	}
+	return e.client.GetOrders("", rest.OrderStatusResting)
+}
+
+// Positions returns the contracts the account holds per market side, or the
+// paper account's. Dry-run orders without a paper account hold nothing on
+// the exchange, so it returns nil
+func (e *Executor) Positions() ([]portfolio.Position, error) {
+	if e.dryRun {
+		if e.paper == nil {
+			return nil, nil
+		}
+		return e.paper.Portfolio().Positions(), nil
+	}
+	positions, err := e.client.GetPositions()
+	if err != nil {
+		return nil, err
+	}
+	var held []portfolio.Position
+	for _, p := range positions {
+		if p.YesPosition > 0 {
+			held = append(held, portfolio.Position{Ticker: p.Ticker, EventTicker: p.EventTicker, Side: rest.SideYes, Count: p.YesPosition})
+		}
+		if p.NoPosition > 0 {
+			held = append(held, portfolio.Position{Ticker: p.Ticker, EventTicker: p.EventTicker, Side: rest.SideNo, Count: p.NoPosition})
+		}
+	}
+	return held, nil
+}
+
+// PriceGrid returns the market's valid order prices (tick size and bounds),
+// fetched once per ticker. If the market can't be fetched the default 1-99¢
+// grid is returned and not cached
+func (e *Executor) PriceGrid(ticker string) rest.PriceGrid {
+	return e.priceGrid(e.client, ticker)
+}
+
+// priceGrid is PriceGrid, fetched through client: the fallback host's while
+// orders are failing over to it
+func (e *Executor) priceGrid(client *rest.Client, ticker string) rest.PriceGrid {
+	e.gridsMu.Lock()
+	grid, ok := e.grids[ticker]
+	e.gridsMu.Unlock()
+	if ok {
+		return grid
+	}
+
+	market, err := client.GetMarket(ticker)
+	if err != nil {
+		execLog.Warn("Failed to fetch price grid, assuming 1-99¢", "ticker", ticker, "err", err)
+		return rest.DefaultPriceGrid()
+	}
+	grid = market.PriceGrid()
+
+	e.gridsMu.Lock()
+	e.grids[ticker] = grid
+	e.gridsMu.Unlock()
+	return grid
+}
+
+// ForgetPriceGrid drops the market's cached price grid, so the next order
+// refetches it
+func (e *Executor) ForgetPriceGrid(ticker string) {
+	e.gridsMu.Lock()
+	delete(e.grids, ticker)
+	e.gridsMu.Unlock()
+}
+
+// SetFallback places orders through client, an alternate API host, once
+// after attempts in a row have failed on the primary, for cooldown (at least
+// a minute) before the primary is tried again
+func (e *Executor) SetFallback(client *rest.Client, after int, cooldown time.Duration) {
+	e.fallback = &failover{client: client, after: max(after, 1), cooldown: max(cooldown, time.Minute)}
+}
+
+// ExecuteOrder executes an order with retry logic, returning its order ID
+// and the route it was placed through (RoutePrimary or RouteFallback; "" in
+// dry-run). Exchange rejections (rest.ClassifyRejection) fail the same way on
+// a retry and are returned at once. With a fallback set, an order the
+// primary keeps failing moves to the fallback and gets its retries there
+func (e *Executor) ExecuteOrder(req ExecuteOrderRequest) (string, string, error) {
+	if e.dryRun && e.paper != nil {
+		orderID, err := e.executePaper(req)
+		return orderID, "", err
+	}
 	if e.dryRun {
 		orderID := fmt.Sprintf("DRY-%d", time.Now().UnixNano())
-		log.Printf("[Executor] DRY RUN: %s %s %d @ %d¢ → %s",
-			req.Action, req.Side, req.Quantity, req.Price, orderID)
-		return orderID, nil
+		execLog.Info("DRY RUN order", "ticker", req.Ticker, "action", req.Action, "side", req.Side,
+			"count", req.Quantity, "price", req.Price, "order_id", orderID)
+		return orderID, "", nil
 	}
 
+	// Tagged so the order is attributed to this bot's strategy in a shared
+	// account. One ID for every attempt, so an attempt that reached the
+	// exchange despite an error is found instead of placed twice
+	tag := req.Strategy
+	if tag == "" {
+		tag = "dualside"
+	}
+	clientOrderID := portfolio.OrderTag(tag)
+
 	var lastErr error
+	var lastRoute string
+	tries, failedOver := 0, false
 	for attempt := 1; attempt <= e.maxRetries; attempt++ {
-		orderID, err := e.executeOnce(req)
+		tries++
+		route := e.fallback.route(time.Now())
+		client := e.client
+		if route == RouteFallback {
+			client = e.fallback.client
+		}
+		// Credited to the route of the attempt that errored after all
+		if lastErr != nil {
+			if orderID, ok := placed(client, req.Ticker, clientOrderID); ok {
+				execLog.Info("Order found placed after a failed attempt", "ticker", req.Ticker,
+					"order_id", orderID, "route", lastRoute)
+				return orderID, lastRoute, nil
+			}
+		}
+
+		orderID, err := e.executeOnce(client, req, clientOrderID)
 		if err == nil {
-			return orderID, nil
+			e.fallback.succeeded(route)
+			execLog.Info("Order placed", "ticker", req.Ticker, "action", req.Action, "side", req.Side,
+				"count", req.Quantity, "price", req.Price, "order_id", orderID, "route", route)
+			return orderID, route, nil
 		}
 
-		lastErr = err
-		log.Printf("[Executor] Attempt %d/%d failed: %v", attempt, e.maxRetries, err)
+		if errors.Is(err, rest.ErrOrderBounds) {
+			return "", "", err // Refused by the failsafe; retrying can't help
+		}
+		if _, ok := rest.ClassifyRejection(err); ok {
+			return "", "", err // Rejected by the exchange; the caller remedies it
+		}
+		lastErr, lastRoute = err, route
+		execLog.Warn("Order attempt failed", "ticker", req.Ticker, "attempt", attempt, "max", e.maxRetries,
+			"route", route, "err", err)
 
+		if e.fallback.failed(route, time.Now()) && !failedOver {
+			failedOver = true
+			execLog.Warn("Failing orders over to the fallback API", "ticker", req.Ticker,
+				"cooldown", e.fallback.cooldown)
+			attempt = 0 // The fallback gets its own retries, right away
+			continue
+		}
 		if attempt < e.maxRetries {
 			time.Sleep(e.retryDelay * time.Duration(attempt)) // Exponential backoff
 		}
 	}
 
-	return "", fmt.Errorf("all %d attempts failed: %w", e.maxRetries, lastErr)
+	return "", "", fmt.Errorf("all %d attempts failed: %w", tries, lastErr)
+}
+
+// placed returns the ID of the order on ticker with the client order ID, if
+// one was placed
+func placed(client *rest.Client, ticker, clientOrderID string) (string, bool) {
+	orders, err := client.GetOrders(ticker, "")
+	if err != nil {
+		return "", false
+	}
+	for _, o := range orders {
+		if o.ClientOrderID == clientOrderID {
+			return o.OrderID, true
+		}
+	}
+	return "", false
 }
 
-func (e *Executor) executeOnce(req ExecuteOrderRequest) (string, error) {
+func (e *Executor) executeOnce(client *rest.Client, req ExecuteOrderRequest, clientOrderID string) (string, error) {
 	// Convert string action/side to rest types
 	var action rest.OrderAction
 	if req.Action == "buy" {
@@ -97,11 +289,12 @@ func (e *Executor) executeOnce(req ExecuteOrderRequest) (string, error) {
 	}
 
 	order := &rest.CreateOrderRequest{
-		Ticker: req.Ticker,
-		Action: action,
-		Side:   side,
-		Type:   rest.OrderTypeLimit,
-		Count:  req.Quantity,
+		Ticker:        req.Ticker,
+		Action:        action,
+		Side:          side,
+		Type:          rest.OrderTypeLimit,
+		Count:         req.Quantity,
+		ClientOrderID: clientOrderID,
 	}
 
 	if req.Side == "yes" {
@@ -109,22 +302,87 @@ func (e *Executor) executeOnce(req ExecuteOrderRequest) (string, error) {
 	} else {
 		order.NoPrice = req.Price
 	}
+	if err := order.Conform(e.priceGrid(client, req.Ticker)); err != nil {
+		return "", err
+	}
 
-	resp, err := e.client.CreateOrder(order)
+	resp, err := client.CreateOrder(order)
 	if err != nil {
 		return "", err
 	}
+	return resp.OrderID, nil
+}
 
-	log.Printf("[Executor] Order placed: %s %s %d @ %d¢ → %s",
-		req.Action, req.Side, req.Quantity, req.Price, resp.OrderID)
+// executePaper places an order on the paper account against the market's
+// current order book
+func (e *Executor) executePaper(req ExecuteOrderRequest) (string, error) {
+	book, err := e.client.GetOrderbook(req.Ticker, 0)
+	if err != nil {
+		return "", fmt.Errorf("get order book: %w", err)
+	}
+	order := &rest.CreateOrderRequest{
+		Ticker: req.Ticker,
+		Action: rest.OrderAction(req.Action),
+		Side:   rest.Side(req.Side),
+		Type:   rest.OrderTypeLimit,
+		Count:  req.Quantity,
+	}
+	if order.Side == rest.SideYes {
+		order.YesPrice = req.Price
+	} else {
+		order.NoPrice = req.Price
+	}
+	placed, err := e.paper.Place(order, book, time.Now())
+	if err != nil {
+		return "", err
+	}
+	e.savePaper()
+	execLog.Info("PAPER order", "ticker", req.Ticker, "action", req.Action, "side", req.Side,
+		"count", req.Quantity, "price", req.Price, "order_id", placed.OrderID,
+		"filled", placed.TakerFillCount, "resting", placed.RemainingCount)
+	return placed.OrderID, nil
+}
 
-	return resp.OrderID, nil
+// RunPaper fills the paper account's resting orders from the markets' trades
+// and settles its markets as they resolve, every interval until ctx is done
+func (e *Executor) RunPaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := e.paper.Poll(e.client); err != nil {
+			execLog.Error("PAPER: Failed to fetch trades", "err", err)
+		}
+		for _, t := range e.paper.Held() {
+			result, err := e.GetMarketResult(t)
+			if err != nil || result == "" {
+				continue
+			}
+			e.paper.Settle(t, result, time.Now())
+			execLog.Info("PAPER settled", "ticker", t, "result", result,
+				"pnl", float64(e.paper.Portfolio().Ticker(t).Realized)/100)
+		}
+		e.savePaper()
+	}
 }
 
 // CancelOrder cancels an order
 func (e *Executor) CancelOrder(orderID string) error {
+	if e.dryRun && e.paper != nil {
+		if err := e.paper.Cancel(orderID); err != nil {
+			return err
+		}
+		e.savePaper()
+		return nil
+	}
 	if e.dryRun {
-		log.Printf("[Executor] DRY RUN: Cancel order %s", orderID)
+		execLog.Info("DRY RUN cancel", "order_id", orderID)
 		return nil
 	}
 
@@ -132,8 +390,61 @@ func (e *Executor) CancelOrder(orderID string) error {
 	return err
 }
 
+// Filled returns how many of an order's contracts have filled, read back
+// through the route it was placed on. Dry-run orders without a paper account
+// are assumed filled in full (quantity)
+func (e *Executor) Filled(orderID, route string, quantity int) (int, error) {
+	if e.dryRun && e.paper != nil {
+		filled := 0
+		for _, f := range e.paper.Fills() {
+			if f.OrderID == orderID {
+				filled += f.Count
+			}
+		}
+		return filled, nil
+	}
+	if e.dryRun {
+		return quantity, nil
+	}
+
+	client := e.client
+	if route == RouteFallback {
+		client = e.fallback.client
+	}
+	order, err := client.GetOrder(orderID)
+	if err != nil {
+		return 0, err
+	}
+	return order.TakerFillCount + order.MakerFillCount, nil
+}
+
+// Markets returns every market in an event, through the fallback host while
+// orders are failing over to it, or when the primary fails
+func (e *Executor) Markets(eventTicker string) ([]rest.Market, error) {
+	params := rest.GetMarketsParams{EventTicker: eventTicker}
+	route := e.fallback.route(time.Now())
+	if route == RouteFallback {
+		return e.fallback.client.GetAllMarkets(params)
+	}
+	markets, err := e.client.GetAllMarkets(params)
+	if err != nil && e.fallback != nil {
+		execLog.Warn("Failed to fetch markets, trying the fallback API", "event", eventTicker, "err", err)
+		return e.fallback.client.GetAllMarkets(params)
+	}
+	return markets, err
+}
+
+// GetMarketResult returns the settlement result ("yes", "no") of a market,
+// or an empty string if it has not settled yet
+func (e *Executor) GetMarketResult(ticker string) (string, error) {
+	market, err := e.client.GetMarket(ticker)
+	if err != nil {
+		return "", err
+	}
+	return market.Result, nil
+}
+
 // IsDryRun returns true if in dry run mode
 func (e *Executor) IsDryRun() bool {
 	return e.dryRun
 }
-
@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/mockexchange"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+const testTicker = "KXHIGHLAX-26MAR10-B70.5"
+
+// newTestExchange starts a mock exchange listing testTicker at 40/42¢
+func newTestExchange(t *testing.T, seed uint64) *mockexchange.Exchange {
+	t.Helper()
+	x := mockexchange.New(seed)
+	t.Cleanup(x.Close)
+	x.AddMarket(rest.Market{Ticker: testTicker, EventTicker: "KXHIGHLAX-26MAR10", Status: "active", YesBid: 40, YesAsk: 42})
+	return x
+}
+
+// newTestClient returns a client of x
+func newTestClient(t *testing.T, x *mockexchange.Exchange) *rest.Client {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rest.New("test-key", privateKey, rest.WithBaseURL(x.URL()))
+}
+
+// newTestExecutor returns a live executor trading on x
+func newTestExecutor(t *testing.T, x *mockexchange.Exchange) *Executor {
+	t.Helper()
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor, err := NewExecutor("test-key", privateKey, false, rest.WithBaseURL(x.URL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor.retryDelay = time.Millisecond
+	return executor
+}
+
+var testOrder = ExecuteOrderRequest{Ticker: testTicker, Side: "yes", Action: "buy", Price: 45, Quantity: 1}
+
+func TestExecutor_LostResponses(t *testing.T) {
+	x := newTestExchange(t, 7)
+	executor := newTestExecutor(t, x)
+	// Orders are placed but their responses lost, as on a timeout after the
+	// exchange accepted them
+	x.SetFaults(mockexchange.Faults{LostResponseRate: 0.4})
+
+	placed := make(map[string]bool)
+	failed := 0
+	for range 30 {
+		orderID, route, err := executor.ExecuteOrder(testOrder)
+		if err != nil {
+			failed++
+			continue
+		}
+		if route != RoutePrimary {
+			t.Errorf("route = %q, want primary", route)
+		}
+		if placed[orderID] {
+			t.Errorf("order %s returned twice", orderID)
+		}
+		placed[orderID] = true
+	}
+	if x.FaultStats().LostResponses == 0 {
+		t.Fatal("no responses lost; pick another seed")
+	}
+
+	// Every order reported placed is on the exchange, and each call placed
+	// at most one: a retry found the order instead of placing another
+	orders := x.Orders()
+	for orderID := range placed {
+		found := false
+		for _, o := range orders {
+			found = found || o.OrderID == orderID
+		}
+		if !found {
+			t.Errorf("order %s reported placed, not on the exchange", orderID)
+		}
+	}
+	if len(orders) < len(placed) || len(orders) > len(placed)+failed {
+		t.Errorf("%d orders on the exchange for %d placed and %d failed calls", len(orders), len(placed), failed)
+	}
+	tags := make(map[string]bool)
+	for _, o := range orders {
+		if tags[o.ClientOrderID] {
+			t.Errorf("client order ID %s placed twice", o.ClientOrderID)
+		}
+		tags[o.ClientOrderID] = true
+	}
+}
+
+func TestExecutor_Failover(t *testing.T) {
+	primary, fallback := newTestExchange(t, 1), newTestExchange(t, 2)
+	executor := newTestExecutor(t, primary)
+	executor.maxRetries = 2
+	executor.SetFallback(newTestClient(t, fallback), 2, time.Minute)
+	primary.SetFaults(mockexchange.Faults{ErrorRate: 1})
+
+	// Two failures on the primary trip the failover; the order is placed on
+	// the fallback with retries of its own
+	orderID, route, err := executor.ExecuteOrder(testOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route != RouteFallback {
+		t.Errorf("route = %q, want fallback", route)
+	}
+	if orders := fallback.Orders(); len(orders) != 1 || orders[0].OrderID != orderID {
+		t.Errorf("fallback orders = %v, want %s", orders, orderID)
+	}
+	if n := len(primary.Orders()); n != 0 {
+		t.Errorf("%d orders on the primary, want 0", n)
+	}
+
+	// Until the cooldown passes, orders go straight to the fallback
+	tried := primary.FaultStats().Errors
+	if _, route, err := executor.ExecuteOrder(testOrder); err != nil || route != RouteFallback {
+		t.Errorf("during cooldown: route, err = %q, %v, want fallback", route, err)
+	}
+	if primary.FaultStats().Errors != tried {
+		t.Error("primary tried during the cooldown")
+	}
+
+	// Once it expires the primary is tried again
+	primary.SetFaults(mockexchange.Faults{})
+	executor.fallback.mu.Lock()
+	executor.fallback.until = time.Now()
+	executor.fallback.mu.Unlock()
+	if _, route, err := executor.ExecuteOrder(testOrder); err != nil || route != RoutePrimary {
+		t.Errorf("after cooldown: route, err = %q, %v, want primary", route, err)
+	}
+	if n := len(primary.Orders()); n != 1 {
+		t.Errorf("%d orders on the primary after the cooldown, want 1", n)
+	}
+}
+
+func TestFailover(t *testing.T) {
+	now := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	f := &failover{after: 3, cooldown: 5 * time.Minute}
+
+	for i := range 2 {
+		if f.failed(RoutePrimary, now) {
+			t.Fatalf("tripped after %d failures, want 3", i+1)
+		}
+	}
+	// A success ends the streak
+	f.succeeded(RoutePrimary)
+	for i := range 2 {
+		if f.failed(RoutePrimary, now) {
+			t.Fatalf("tripped after %d failures following a success", i+1)
+		}
+	}
+	// Fallback failures don't count toward tripping
+	if f.failed(RouteFallback, now) || f.route(now) != RoutePrimary {
+		t.Fatal("a fallback failure tripped the failover")
+	}
+	if !f.failed(RoutePrimary, now) {
+		t.Fatal("third failure in a row didn't trip the failover")
+	}
+
+	tests := []struct {
+		at   time.Duration
+		want string
+	}{
+		{0, RouteFallback},
+		{5*time.Minute - time.Second, RouteFallback},
+		{5 * time.Minute, RoutePrimary},
+	}
+	for _, tt := range tests {
+		if got := f.route(now.Add(tt.at)); got != tt.want {
+			t.Errorf("route %v after tripping = %q, want %q", tt.at, got, tt.want)
+		}
+	}
+
+	var none *failover
+	if none.route(now) != RoutePrimary || none.failed(RoutePrimary, now) {
+		t.Error("nil failover left the primary")
+	}
+}
+
+func TestExecutor_MarketsFallback(t *testing.T) {
+	primary, fallback := newTestExchange(t, 1), newTestExchange(t, 2)
+	executor := newTestExecutor(t, primary)
+
+	markets, err := executor.Markets("KXHIGHLAX-26MAR10")
+	if err != nil || len(markets) != 1 {
+		t.Fatalf("Markets() = %v, %v, want the listed market", markets, err)
+	}
+
+	primary.SetFaults(mockexchange.Faults{ErrorRate: 1})
+	if _, err := executor.Markets("KXHIGHLAX-26MAR10"); err == nil {
+		t.Error("Markets() succeeded with the primary down and no fallback")
+	}
+	executor.SetFallback(newTestClient(t, fallback), 1, time.Minute)
+	if markets, err := executor.Markets("KXHIGHLAX-26MAR10"); err != nil || len(markets) != 1 {
+		t.Errorf("Markets() with a fallback = %v, %v, want the listed market", markets, err)
+	}
+}
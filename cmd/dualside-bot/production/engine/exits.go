@@ -0,0 +1,236 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// exitPositions sells open positions an exit rule closes, at the held side's
+// bid. Take-profit sells TakeProfitFraction of a position bid at or above
+// TakeProfitPrice while at least TakeProfitMinHours remain before the market
+// closes: at 97-99¢ holding to settlement risks nearly the whole stake (and
+// the settlement fee) for the last few cents, so near-certain positions are
+// banked early. The stop-loss, model and flatten rules of Exits sell the
+// whole position. A market determined before its close can no longer be
+// sold: its positions are marked and held to settlement
+func (e *Engine) exitPositions(now time.Time) {
+	rules := e.config.Exits
+	if e.config.TakeProfitFraction > 0 {
+		rules.TakeProfit = e.config.TakeProfitPrice
+	}
+	if !rules.Enabled() {
+		return
+	}
+
+	e.mu.RLock()
+	events := make(map[string][]Trade, len(e.positions))
+	for eventTicker, trades := range e.positions {
+		events[eventTicker] = append([]Trade(nil), trades...)
+	}
+	e.mu.RUnlock()
+
+	for eventTicker, trades := range events {
+		behavior := e.strategy.Behavior(e.eventPhase(eventTicker, now))
+		if len(behavior.Exits) == 0 {
+			continue
+		}
+
+		var markets map[string]Market
+		var w *strategy.WeatherUpdate
+		for _, t := range trades {
+			if t.Settled || t.Sold > 0 || t.Determined || t.Status == "shadow" {
+				continue
+			}
+
+			// Fetch the event's quotes once, and only if something could be sold
+			if markets == nil {
+				list, err := e.fetchMarkets(eventTicker)
+				if err != nil {
+					log.Printf("[Engine] %s: Failed to fetch markets for exits: %v", eventTicker, err)
+					break
+				}
+				markets = make(map[string]Market, len(list))
+				for _, m := range list {
+					markets[m.Ticker] = m
+				}
+			}
+
+			m, ok := markets[t.Ticker]
+			if !ok {
+				continue
+			}
+			if rest.StatusDetermined(m.Status) {
+				e.markDetermined(t, m.Status)
+				continue
+			}
+			if m.Status != rest.MarketStatusActive {
+				continue
+			}
+			station, day, ok := eventDay(eventTicker)
+			if !ok {
+				continue
+			}
+			local := now.In(day.Location())
+
+			q := strategy.Quote{
+				Ticker: m.Ticker,
+				Floor:  m.Strike.Floor,
+				Cap:    m.Strike.Cap,
+				YesBid: m.YesBid,
+				NoBid:  m.NoBid,
+			}
+
+			// The model exit needs the event's weather, fetched once
+			prob := -1.0
+			if rules.MinProb > 0 && behavior.AllowsExit(strategy.ExitModel) {
+				if w == nil {
+					w = e.exitWeather(station, local)
+				}
+				if w.City != "" {
+					marketType := MarketHigh
+					if station.LowPrefix != "" && strings.HasPrefix(eventTicker, station.LowPrefix+"-") {
+						marketType = MarketLow
+					}
+					data := strategy.MarketData{Time: local, City: station.Code, EventTicker: eventTicker, Type: weather.MarketType(marketType)}
+					if prob = e.exitModel.Probability(data, *w, q); t.Side == "no" {
+						prob = 1 - prob
+					}
+				}
+			}
+
+			exit, bid, ok := rules.Check(behavior, t.Side, q, prob, local)
+			if !ok {
+				continue
+			}
+			closes, err := time.Parse(time.RFC3339, m.CloseTime)
+			if exit == strategy.ExitTakeProfit && (err != nil || closes.Sub(now) < time.Duration(e.config.TakeProfitMinHours*float64(time.Hour))) {
+				continue
+			}
+
+			quantity := t.Quantity
+			if exit == strategy.ExitTakeProfit {
+				quantity = min(int(math.Ceil(float64(t.Quantity)*e.config.TakeProfitFraction)), t.Quantity)
+			}
+			e.sellPosition(t, exit, quantity, bid, prob, closes.Sub(now))
+		}
+	}
+}
+
+// exitWeather returns the station's running extremes for the model exit;
+// its City is empty when METAR is unavailable
+func (e *Engine) exitWeather(station Station, local time.Time) *strategy.WeatherUpdate {
+	metar, err := e.getMETAR(station, local)
+	if err != nil {
+		log.Printf("[Engine] %s: No METAR for the model exit: %v", station.City, err)
+		return &strategy.WeatherUpdate{}
+	}
+	return &strategy.WeatherUpdate{
+		Time:     local,
+		City:     station.Code,
+		MaxTempF: metar.MaxTemp,
+		MinTempF: metar.MinTemp,
+	}
+}
+
+// sellPosition sells quantity contracts of a position at bid and records
+// the contracts that filled as the exit on the open trade. The unfilled rest
+// of the sell is canceled: with nothing filled the position is left open for
+// the next tick's exits, and a partial fill holds the rest to settlement. The
+// sold contracts' P&L counts toward the hard limits at once, and their cost
+// no longer does
+func (e *Engine) sellPosition(t Trade, exit strategy.Exit, quantity, bid int, prob float64, remaining time.Duration) {
+	label := exitLabel(exit)
+	if e.Mode() == strategy.ModeShadow {
+		log.Printf("[Engine] SHADOW: %s %s %s %d @ %d¢ (not sent)", label, t.Ticker, t.Side, quantity, bid)
+		return
+	}
+
+	why := fmt.Sprintf("%s to close", remaining.Round(time.Minute))
+	if exit == strategy.ExitModel {
+		why = fmt.Sprintf("model %.0f%%, %s", prob*100, why)
+	}
+	log.Printf("[Engine] %s: Exit (%s) SELL %s %s %d @ %d¢ (bought @ %d¢, %s)",
+		t.City, label, t.Bracket, t.Side, quantity, bid, t.Price, why)
+
+	orderID, route, err := e.executor.ExecuteOrder(ExecuteOrderRequest{
+		Ticker:   t.Ticker,
+		Side:     t.Side,
+		Action:   "sell",
+		Price:    bid,
+		Quantity: quantity,
+		Strategy: t.Strategy,
+	})
+	if err != nil {
+		log.Printf("[Engine] %s: Exit (%s) sell failed: %v", t.City, label, err)
+		if e.onError != nil {
+			e.onError(err)
+		}
+		return
+	}
+
+	filled, err := e.executor.Filled(orderID, route, quantity)
+	if err != nil {
+		// Canceled unread: the position reconciliation reports a sell that
+		// filled meanwhile as a difference from the trades
+		log.Printf("[Engine] %s: Exit (%s) sell %s placed, failed to read its fills: %v", t.City, label, orderID, err)
+		filled = 0
+	}
+	if filled < quantity {
+		if err := e.executor.CancelOrder(orderID); err != nil {
+			log.Printf("[Engine] %s: Failed to cancel the unfilled rest of exit sell %s: %v", t.City, orderID, err)
+		}
+		log.Printf("[Engine] %s: Exit (%s) sold %d of %d @ %d¢", t.City, label, filled, quantity, bid)
+	}
+	if filled == 0 {
+		return
+	}
+
+	e.mu.Lock()
+	trades := e.positions[t.EventTicker]
+	for i := range trades {
+		if trades[i].OrderID == t.OrderID {
+			trades[i].Sold = filled
+			trades[i].SoldPrice = bid
+			trades[i].Exit = exit
+		}
+	}
+	e.mu.Unlock()
+
+	if e.limits != nil {
+		t.Sold, t.SoldPrice = filled, bid
+		e.limits.RecordPnL(e.soldProfit(t))
+		e.limits.Release(t.EventTicker, t.Cost*float64(filled)/float64(t.Quantity))
+	}
+}
+
+// exitLabel names an exit rule as the logs and journal show it, e.g.
+// "stop-loss"; trades sold before the rule was recorded were take-profits
+func exitLabel(exit strategy.Exit) string {
+	if exit == "" {
+		exit = strategy.ExitTakeProfit
+	}
+	return strings.ReplaceAll(string(exit), "_", "-")
+}
+
+// markDetermined flags a position whose market stopped trading before close,
+// so no further exits are attempted
+func (e *Engine) markDetermined(t Trade, status string) {
+	log.Printf("[Engine] %s: %s is %s before close; holding %s %d to settlement",
+		t.City, t.Bracket, status, t.Side, t.Quantity-t.Sold)
+
+	e.mu.Lock()
+	trades := e.positions[t.EventTicker]
+	for i := range trades {
+		if trades[i].OrderID == t.OrderID {
+			trades[i].Determined = true
+		}
+	}
+	e.mu.Unlock()
+}
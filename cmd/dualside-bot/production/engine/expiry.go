@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExpiryWarning is a position still held as its market's close nears, when
+// the book is thin and exiting costs the most
+type ExpiryWarning struct {
+	City        string
+	EventTicker string
+	Ticker      string
+	Side        string
+	Contracts   int
+	Left        time.Duration // Until the market closes
+}
+
+// SetExpiryCallback sets callback for positions entering the thin-book window
+// before their market's close
+func (e *Engine) SetExpiryCallback(fn func(ExpiryWarning)) {
+	e.onExpiry = fn
+}
+
+// watchExpiry warns once per held market side when it comes within the
+// guard's thin-book window of its close. Positions are held to settlement by
+// design, so this is a warning rather than an exit: it flags positions a
+// late trading window or take-profit left open into the evening book
+func (e *Engine) watchExpiry(now time.Time) {
+	if e.config.Expiry.ThinBook <= 0 {
+		return
+	}
+
+	type holding struct {
+		ExpiryWarning
+		closes time.Time
+	}
+	held := make(map[string]*holding)
+	e.mu.RLock()
+	for eventTicker, trades := range e.positions {
+		for _, t := range trades {
+			n := t.Quantity - t.Sold
+			if t.Settled || t.Status == "shadow" || n <= 0 || t.Closes.IsZero() {
+				continue
+			}
+			key := t.Ticker + "/" + t.Side
+			h, ok := held[key]
+			if !ok {
+				h = &holding{ExpiryWarning: ExpiryWarning{City: t.City, EventTicker: eventTicker, Ticker: t.Ticker, Side: t.Side}, closes: t.Closes}
+				held[key] = h
+			}
+			h.Contracts += n
+		}
+	}
+	e.mu.RUnlock()
+
+	keys := make([]string, 0, len(held))
+	for key := range held {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		h := held[key]
+		if !e.expiry.Check(key, h.closes, now) {
+			continue
+		}
+		h.Left = h.closes.Sub(now)
+		log.Printf("[Engine] %s: Holding %d %s %s into the thin book, %s before close",
+			h.City, h.Contracts, strings.ToUpper(h.Side), h.Ticker, h.Left.Round(time.Minute))
+		if e.onExpiry != nil {
+			e.onExpiry(h.ExpiryWarning)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+// Routes an order can be placed through, recorded on its trade
+const (
+	RoutePrimary  = "primary"  // The configured API host
+	RouteFallback = "fallback" // The alternate host, while the primary keeps failing
+)
+
+// failover sends orders to a fallback API host once after attempts in a row
+// have failed on the primary, until cooldown passes and the primary is tried
+// again. A locked-threshold entry is worth most in the minutes after the
+// crossing, so an order shouldn't wait out an outage on the primary
+type failover struct {
+	client   *rest.Client
+	after    int
+	cooldown time.Duration
+
+	mu       sync.Mutex
+	failures int       // Consecutive failed attempts on the primary
+	until    time.Time // Orders go to the fallback until then
+}
+
+// route returns the route the next attempt takes: the fallback while the
+// failover is tripped, else the primary
+func (f *failover) route(now time.Time) string {
+	if f == nil {
+		return RoutePrimary
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if now.Before(f.until) {
+		return RouteFallback
+	}
+	return RoutePrimary
+}
+
+// failed records a failed attempt on route and returns true if it trips the
+// failover, sending the order's next attempt to the fallback
+func (f *failover) failed(route string, now time.Time) bool {
+	if f == nil || route != RoutePrimary {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures++
+	if f.failures < f.after {
+		return false
+	}
+	f.failures = 0
+	f.until = now.Add(f.cooldown)
+	return true
+}
+
+// succeeded records an order placed on route; the primary's failure streak
+// ends with it
+func (f *failover) succeeded(route string) {
+	if f == nil || route != RoutePrimary {
+		return
+	}
+	f.mu.Lock()
+	f.failures = 0
+	f.mu.Unlock()
+}
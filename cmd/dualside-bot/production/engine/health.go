@@ -0,0 +1,37 @@
+package engine
+
+import "github.com/brendanplayford/kalshi-go/internal/service"
+
+// Health components the engine reports
+const (
+	HealthMETAR  = "metar"  // A METAR fetch succeeded for some station
+	HealthKalshi = "kalshi" // A Kalshi API call succeeded
+)
+
+// SetHealth reports each METAR fetch and Kalshi API call to health, and the
+// trading window's state on every tick
+func (e *Engine) SetHealth(health *service.Health) {
+	e.health = health
+}
+
+// recordTradingWindow reports whether any tracked station-day is in a phase
+// that may enter, and each one's phase
+func (e *Engine) recordTradingWindow() {
+	if e.health == nil {
+		return
+	}
+	open := false
+	phases := make(map[string]string)
+	for _, p := range e.lifecycle.Phases() {
+		phases[p.Event] = string(p.Phase)
+		if e.strategy.Phases().For(p.Phase).Enters() {
+			open = true
+		}
+	}
+	e.health.SetInfo("trading_window", map[string]any{
+		"open":       open,
+		"start_hour": e.config.TradingStartHour,
+		"end_hour":   e.config.TradingEndHour,
+		"phases":     phases,
+	})
+}
@@ -0,0 +1,38 @@
+package engine
+
+import (
+	"log"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/hedge"
+)
+
+// hedgeOrders builds the hedge set for an event the main strategy backs,
+// on the model's probability of each of its brackets. ok is false when the
+// quoted brackets do not cover every outcome, so the strategy's own orders
+// stand; a set that is not worth buying returns no orders
+func (e *Engine) hedgeOrders(city string, data strategy.MarketData, update strategy.WeatherUpdate, now time.Time) ([]strategy.Order, bool) {
+	strikes := make(market.Strikes, len(data.Quotes))
+	probs := make([]float64, len(data.Quotes))
+	for i, q := range data.Quotes {
+		strikes[i] = market.Strike{Floor: q.Floor, Cap: q.Cap}
+		probs[i] = e.exitModel.Probability(data, update, q)
+	}
+	if err := market.InferLayout(strikes).Err(); err != nil {
+		log.Printf("[Engine] %s: Not hedging, the quoted brackets are incomplete (%v)", city, err)
+		return nil, false
+	}
+
+	cfg := *e.config.Hedge
+	cfg.Fees = e.fees.RuleForTicker(data.EventTicker, now)
+	plan, err := hedge.Build(data.Quotes, probs, cfg)
+	if err != nil {
+		log.Printf("[Engine] %s: No hedge set worth buying: %v", city, err)
+		return nil, true
+	}
+	log.Printf("[Engine] %s: Hedge set of %d legs (%.0f%% protection): $%.2f for EV $%.2f, losing money %.0f%% of the time, worst $%.2f",
+		city, len(plan.Legs), plan.Protection*100, plan.Cost, plan.EV, plan.LossProb*100, plan.Worst)
+	return plan.Orders(data.EventTicker, "hedge"), true
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// StationDay is a station's local trading day whose NWS climate report
+// (CLI) has posted
+type StationDay struct {
+	Station Station
+	Date    string // Local trading day, YYYY-MM-DD
+}
+
+// SetHousekeepingCallback sets the callback run once per station-day after
+// its CLI report has typically posted. The stations span four timezones, so
+// New York's day is ready hours before Los Angeles'; work that needs the
+// day's settlement hangs off this instead of a fixed UTC hour
+func (e *Engine) SetHousekeepingCallback(fn func(StationDay)) {
+	e.onHousekeeping = fn
+}
+
+// eventDay returns the station and local day of an event ticker (e.g.
+// KXHIGHLAX-25DEC05 or KXLOWTLAX-25DEC05)
+func eventDay(eventTicker string) (Station, time.Time, bool) {
+	for _, station := range DefaultStations {
+		code, ok := strings.CutPrefix(eventTicker, station.EventPrefix+"-")
+		if !ok && station.LowPrefix != "" {
+			code, ok = strings.CutPrefix(eventTicker, station.LowPrefix+"-")
+		}
+		if !ok {
+			continue
+		}
+		loc, err := time.LoadLocation(station.Timezone)
+		if err != nil {
+			return Station{}, time.Time{}, false
+		}
+		day, err := time.ParseInLocation("06Jan02", code, loc)
+		if err != nil {
+			return Station{}, time.Time{}, false
+		}
+		return station, day, true
+	}
+	return Station{}, time.Time{}, false
+}
+
+// cliPosted returns when the CLI report for the station-day starting at day
+// has typically posted
+func cliPosted(station Station, day time.Time) time.Time {
+	if ws := weather.StationByCode(station.Code); ws != nil {
+		return ws.CLIReportTime(day)
+	}
+	return day.AddDate(0, 0, 1).Add(weather.CLIReportDelay)
+}
+
+// settlementDue reports whether eventTicker's CLI report has posted by now,
+// so its markets are worth asking for a result. Events the ticker doesn't
+// place are due once the day of their first trade is over
+func settlementDue(eventTicker string, first Trade, now time.Time) bool {
+	if station, day, ok := eventDay(eventTicker); ok {
+		return !now.Before(cliPosted(station, day))
+	}
+	loc := first.Timestamp.Location()
+	return first.Timestamp.Format("2006-01-02") != now.In(loc).Format("2006-01-02")
+}
+
+// tradeDate returns the local trading day of eventTicker's positions: the
+// event's day, or the day of its first trade for tickers it doesn't place
+func tradeDate(eventTicker string, first Trade) string {
+	if _, day, ok := eventDay(eventTicker); ok {
+		return day.Format("2006-01-02")
+	}
+	return first.Timestamp.Format("2006-01-02")
+}
+
+// housekeep learns the calibration from, and runs the housekeeping callback
+// once for, each station's latest day whose CLI report has posted by now. A
+// restart runs it again for the latest day, so the callback must be safe to
+// repeat
+func (e *Engine) housekeep(now time.Time) {
+	for _, station := range DefaultStations {
+		loc, err := time.LoadLocation(station.Timezone)
+		if err != nil {
+			continue
+		}
+		local := now.In(loc)
+		day := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, loc)
+		if now.Before(cliPosted(station, day)) {
+			day = day.AddDate(0, 0, -1)
+		}
+		date := day.Format("2006-01-02")
+
+		e.mu.Lock()
+		done := e.housekept[station.Code] == date
+		e.housekept[station.Code] = date
+		e.mu.Unlock()
+		if done {
+			continue
+		}
+
+		log.Printf("[Engine] %s: CLI report for %s posted, running housekeeping", station.City, date)
+		e.calibrate(station, day)
+		if e.onHousekeeping != nil {
+			e.onHousekeeping(StationDay{Station: station, Date: date})
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Note is an operator annotation on a trading day, optionally narrowed to a
+// city or a single trade (by order ID)
+type Note struct {
+	ID        int       `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Date      string    `json:"date"` // Local trading day, YYYY-MM-DD
+	City      string    `json:"city,omitempty"`
+	OrderID   string    `json:"order_id,omitempty"`
+	Text      string    `json:"text"`
+}
+
+// Journal holds operator notes, persisted to a JSON file
+type Journal struct {
+	mu    sync.RWMutex
+	path  string
+	notes []Note
+}
+
+// NewJournal loads notes from path (if present)
+func NewJournal(path string) (*Journal, error) {
+	j := &Journal{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return j, nil
+		}
+		return nil, fmt.Errorf("read journal: %w", err)
+	}
+	if err := json.Unmarshal(data, &j.notes); err != nil {
+		return nil, fmt.Errorf("parse journal: %w", err)
+	}
+	return j, nil
+}
+
+// Add validates and stores a note, assigning its ID and creation time. An
+// empty date defaults to today
+func (j *Journal) Add(note Note) (Note, error) {
+	note.Text = strings.TrimSpace(note.Text)
+	if note.Text == "" {
+		return Note{}, fmt.Errorf("note text is empty")
+	}
+	if note.Date == "" {
+		note.Date = time.Now().Format("2006-01-02")
+	}
+	if _, err := time.Parse("2006-01-02", note.Date); err != nil {
+		return Note{}, fmt.Errorf("invalid date %q (want YYYY-MM-DD)", note.Date)
+	}
+	if note.City != "" {
+		note.City = strings.ToUpper(strings.TrimSpace(note.City))
+		if !knownCity(note.City) {
+			return Note{}, fmt.Errorf("unknown city %q", note.City)
+		}
+	}
+	note.CreatedAt = time.Now()
+
+	j.mu.Lock()
+	note.ID = 1
+	if n := len(j.notes); n > 0 {
+		note.ID = j.notes[n-1].ID + 1
+	}
+	j.notes = append(j.notes, note)
+	snapshot := append([]Note(nil), j.notes...)
+	j.mu.Unlock()
+
+	return note, j.save(snapshot)
+}
+
+// Notes returns the notes for a trading day (all notes if date is empty),
+// oldest first
+func (j *Journal) Notes(date string) []Note {
+	if j == nil {
+		return nil
+	}
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	var notes []Note
+	for _, n := range j.notes {
+		if date == "" || n.Date == date {
+			notes = append(notes, n)
+		}
+	}
+	return notes
+}
+
+// Flush writes the notes to disk again. Add saves as it goes, so this only
+// matters after a failed save
+func (j *Journal) Flush() error {
+	j.mu.RLock()
+	snapshot := append([]Note(nil), j.notes...)
+	j.mu.RUnlock()
+	return j.save(snapshot)
+}
+
+func (j *Journal) save(snapshot []Note) error {
+	if j.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// DayReport is the post-mortem of a fully settled trading day
+type DayReport struct {
+	Date   string  `json:"date"`
+	PnL    float64 `json:"pnl"`
+	Trades []Trade `json:"trades"`
+	Notes  []Note  `json:"notes,omitempty"`
+
+	// What was traded against the morning briefing (nil = no briefing)
+	Plan *PlanDiff `json:"plan,omitempty"`
+
+	// The strategy as configured when the day settled (nil = not recorded)
+	Strategy *StrategyDoc `json:"strategy,omitempty"`
+}
+
+// AppendReport appends a day report to a JSON-lines history file
+func AppendReport(path string, r DayReport) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadReports reads a history file written by AppendReport, ordered by date
+// (a missing file is an empty history)
+func LoadReports(path string) ([]DayReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read reports: %w", err)
+	}
+
+	var reports []DayReport
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var r DayReport
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			return nil, fmt.Errorf("parse reports line %d: %w", i+1, err)
+		}
+		reports = append(reports, r)
+	}
+	sort.SliceStable(reports, func(i, k int) bool { return reports[i].Date < reports[k].Date })
+	return reports, nil
+}
+
+// String formats the report for logs and notifications
+func (r DayReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Day %s settled: %d trades, P&L $%.2f", r.Date, len(r.Trades), r.PnL)
+
+	traded := make(map[string]bool, len(r.Trades))
+	for _, t := range r.Trades {
+		traded[t.OrderID] = true
+	}
+
+	// Trade notes go under their trade; notes on orders outside the report
+	// (e.g. traded manually in the UI) are listed with the day's notes
+	byOrder := make(map[string][]Note)
+	var general []Note
+	for _, n := range r.Notes {
+		if n.OrderID != "" && traded[n.OrderID] {
+			byOrder[n.OrderID] = append(byOrder[n.OrderID], n)
+		} else {
+			general = append(general, n)
+		}
+	}
+
+	trades := append([]Trade(nil), r.Trades...)
+	sort.SliceStable(trades, func(i, k int) bool { return trades[i].Timestamp.Before(trades[k].Timestamp) })
+	for _, t := range trades {
+		fmt.Fprintf(&b, "\n  %s %s %s %d @ %d¢: $%.2f", t.City, strings.ToUpper(t.Side), t.Bracket, t.Quantity, t.Price, t.Profit)
+		if t.Sold > 0 {
+			fmt.Fprintf(&b, "\n    💰 %s: sold %d @ %d¢ before close", exitLabel(t.Exit), t.Sold, t.SoldPrice)
+		}
+		if t.Determined {
+			fmt.Fprintf(&b, "\n    🔒 determined before close; held to settlement")
+		}
+		if t.Override != "" {
+			fmt.Fprintf(&b, "\n    ✋ operator override: %s", t.Override)
+		}
+		if t.Route == RouteFallback {
+			fmt.Fprintf(&b, "\n    🔀 placed via the fallback API")
+		}
+		for _, n := range byOrder[t.OrderID] {
+			fmt.Fprintf(&b, "\n    📝 %s", n.Text)
+		}
+	}
+
+	for _, n := range general {
+		if n.City != "" {
+			fmt.Fprintf(&b, "\n  📝 [%s] %s", n.City, n.Text)
+		} else {
+			fmt.Fprintf(&b, "\n  📝 %s", n.Text)
+		}
+	}
+
+	if r.Plan != nil {
+		fmt.Fprintf(&b, "\n  📋 %s", strings.ReplaceAll(r.Plan.String(), "\n", "\n  "))
+	}
+	if r.Strategy != nil {
+		fmt.Fprintf(&b, "\n  📘 Strategy %s", r.Strategy.Version)
+	}
+	return b.String()
+}
+
+// knownCity reports whether code is one of DefaultStations
+func knownCity(code string) bool {
+	for _, s := range DefaultStations {
+		if s.Code == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournal_Add(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	j, err := NewJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []Note{
+		{Text: "  "},
+		{Date: "10/03/2026", Text: "marine layer"},
+		{City: "XYZ", Text: "marine layer"},
+	} {
+		if _, err := j.Add(n); err == nil {
+			t.Errorf("Add(%+v) succeeded, want an error", n)
+		}
+	}
+
+	first, err := j.Add(Note{Date: "2026-03-10", City: " lax", Text: " marine layer burned off late "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID != 1 || first.City != "LAX" || first.Text != "marine layer burned off late" || first.CreatedAt.IsZero() {
+		t.Errorf("first note = %+v, want ID 1, city LAX and trimmed text", first)
+	}
+	today, err := j.Add(Note{OrderID: "ord-1", Text: "traded manually in UI too"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Now().Format("2006-01-02"); today.ID != 2 || today.Date != want {
+		t.Errorf("second note = %+v, want ID 2 dated %s", today, want)
+	}
+
+	// Saved as they are added
+	reloaded, err := NewJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Notes("2026-03-10"); len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("reloaded Notes(2026-03-10) = %+v, want the first note", got)
+	}
+	if got := reloaded.Notes(""); len(got) != 2 {
+		t.Errorf("reloaded Notes() = %d notes, want 2", len(got))
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewJournal(path); err == nil {
+		t.Error("corrupt journal loaded")
+	}
+}
+
+func TestReports(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports.jsonl")
+	if reports, err := LoadReports(path); err != nil || reports != nil {
+		t.Fatalf("LoadReports(missing) = %v, %v; want an empty history", reports, err)
+	}
+
+	day := DayReport{
+		Date:   "2026-03-10",
+		PnL:    4.5,
+		Trades: []Trade{{OrderID: "ord-1", City: "Los Angeles", Side: "yes", Bracket: "70-71°", Quantity: 10, Price: 40, Profit: 6}},
+		Notes: []Note{
+			{OrderID: "ord-1", Text: "entered before the sea breeze"},
+			{OrderID: "ord-9", Text: "traded manually in UI too"},
+			{City: "LAX", Text: "marine layer burned off late"},
+		},
+	}
+	for _, r := range []DayReport{day, {Date: "2026-03-09", PnL: -1}} {
+		if err := AppendReport(path, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reports, err := LoadReports(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 || reports[0].Date != "2026-03-09" || len(reports[1].Notes) != 3 {
+		t.Fatalf("LoadReports() = %+v, want both days by date with the notes", reports)
+	}
+
+	// The trade's note goes under it; the note on an order outside the
+	// report is listed with the day's
+	lines := strings.Split(reports[1].String(), "\n")
+	want := []string{
+		"Day 2026-03-10 settled: 1 trades, P&L $4.50",
+		"  Los Angeles YES 70-71° 10 @ 40¢: $6.00",
+		"    📝 entered before the sea breeze",
+		"  📝 traded manually in UI too",
+		"  📝 [LAX] marine layer burned off late",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("String() =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("not json\n")
+	f.Close()
+	if _, err := LoadReports(path); err == nil {
+		t.Error("corrupt history loaded")
+	}
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"log"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// checkLayout infers the bracket layout of an event from all of its
+// markets, tails included, active or not, and checks the model's probabilities of them sum
+// to 1. Layouts other than uniform 2° brackets with both tails, and
+// probabilities that miss, are logged when the event is loaded and again
+// whenever what is found changes, so an unexpected layout is never traded on
+// silently; the strategy adapts its METAR bracket and NO basket to it
+func (e *Engine) checkLayout(city string, data strategy.MarketData, markets []Market, update strategy.WeatherUpdate) {
+	strikes := make(market.Strikes, len(markets))
+	probs := make([]float64, len(markets))
+	for i, m := range markets {
+		strikes[i] = m.Strike
+		q := strategy.Quote{Ticker: m.Ticker, Floor: strikes[i].Floor, Cap: strikes[i].Cap}
+		probs[i] = e.exitModel.Probability(data, update, q)
+	}
+	layout := market.InferLayout(strikes)
+
+	found := ""
+	if err := layout.CheckMass(probs); err != nil {
+		found = err.Error()
+	} else if !layout.Standard() {
+		found = "non-standard"
+	}
+
+	e.mu.Lock()
+	last := e.layouts[city]
+	e.layouts[city] = layoutCheck{event: data.EventTicker, found: found}
+	e.mu.Unlock()
+	if last.event != data.EventTicker {
+		last = layoutCheck{}
+	}
+	if last.found == found {
+		return
+	}
+	switch found {
+	case "":
+		log.Printf("[Engine] %s: Bracket layout of %s is now standard (%s)", city, data.EventTicker, layout)
+	case "non-standard":
+		log.Printf("[Engine] %s: Non-standard bracket layout on %s: %s", city, data.EventTicker, layout)
+	default:
+		log.Printf("[Engine] %s: Unexpected bracket layout on %s (%s): %s", city, data.EventTicker, layout, found)
+	}
+}
+
+// layoutCheck is what checkLayout last found on a city's event ("" =
+// nothing to report)
+type layoutCheck struct {
+	event string
+	found string
+}
@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/mockexchange"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// eventPayload is the exchange's GET /markets response for one HIGH event:
+// four 2° brackets between the two tails
+const eventPayload = `{"markets": [
+	{"ticker": "KXHIGHNY-25JAN10-T35", "event_ticker": "KXHIGHNY-25JAN10", "market_type": "binary", "status": "active",
+	 "subtitle": "34° or below", "yes_sub_title": "34° or below", "strike_type": "less", "cap_strike": 35,
+	 "yes_bid": 3, "yes_ask": 5, "no_bid": 95, "no_ask": 97, "volume": 1520, "close_time": "2025-01-11T04:59:00Z"},
+	{"ticker": "KXHIGHNY-25JAN10-B35.5", "event_ticker": "KXHIGHNY-25JAN10", "market_type": "binary", "status": "active",
+	 "subtitle": "35° to 36°", "yes_sub_title": "35° to 36°", "strike_type": "between", "floor_strike": 35, "cap_strike": 36,
+	 "yes_bid": 18, "yes_ask": 21, "no_bid": 79, "no_ask": 82, "volume": 4210, "close_time": "2025-01-11T04:59:00Z"},
+	{"ticker": "KXHIGHNY-25JAN10-B37.5", "event_ticker": "KXHIGHNY-25JAN10", "market_type": "binary", "status": "active",
+	 "subtitle": "37° to 38°", "yes_sub_title": "37° to 38°", "strike_type": "between", "floor_strike": 37, "cap_strike": 38,
+	 "yes_bid": 41, "yes_ask": 44, "no_bid": 56, "no_ask": 59, "volume": 8833, "close_time": "2025-01-11T04:59:00Z"},
+	{"ticker": "KXHIGHNY-25JAN10-B39.5", "event_ticker": "KXHIGHNY-25JAN10", "market_type": "binary", "status": "active",
+	 "subtitle": "39° to 40°", "yes_sub_title": "39° to 40°", "strike_type": "between", "floor_strike": 39, "cap_strike": 40,
+	 "yes_bid": 24, "yes_ask": 27, "no_bid": 73, "no_ask": 76, "volume": 5120, "close_time": "2025-01-11T04:59:00Z"},
+	{"ticker": "KXHIGHNY-25JAN10-B41.5", "event_ticker": "KXHIGHNY-25JAN10", "market_type": "binary", "status": "active",
+	 "subtitle": "41° to 42°", "yes_sub_title": "41° to 42°", "strike_type": "between", "floor_strike": 41, "cap_strike": 42,
+	 "yes_bid": 7, "yes_ask": 9, "no_bid": 91, "no_ask": 93, "volume": 2004, "close_time": "2025-01-11T04:59:00Z"},
+	{"ticker": "KXHIGHNY-25JAN10-T42", "event_ticker": "KXHIGHNY-25JAN10", "market_type": "binary", "status": "active",
+	 "subtitle": "43° or above", "yes_sub_title": "43° or above", "strike_type": "greater", "floor_strike": 42,
+	 "yes_bid": 1, "yes_ask": 3, "no_bid": 97, "no_ask": 99, "volume": 880, "close_time": "2025-01-11T04:59:00Z"}
+], "cursor": ""}`
+
+func TestEngine_CheckLayout(t *testing.T) {
+	var payload rest.GetMarketsResponse
+	if err := json.Unmarshal([]byte(eventPayload), &payload); err != nil {
+		t.Fatal(err)
+	}
+	x := mockexchange.New(1)
+	t.Cleanup(x.Close)
+	for _, m := range payload.Markets {
+		x.AddMarket(m)
+	}
+	e := NewEngine(TradingConfig{}, newTestExecutor(t, x))
+
+	const eventTicker = "KXHIGHNY-25JAN10"
+	all, err := e.fetchEventMarkets(eventTicker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 6 || len(brackets(all)) != 4 {
+		t.Fatalf("fetched %d markets, %d brackets; want 6 and 4", len(all), len(brackets(all)))
+	}
+
+	data := strategy.MarketData{Time: time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC), City: "NYC", EventTicker: eventTicker}
+	update := strategy.WeatherUpdate{Time: data.Time, City: "NYC", MaxTempF: 37}
+	e.checkLayout("New York", data, all, update)
+	if found := e.layouts["New York"].found; found != "" {
+		t.Errorf("layout with both tails: found %q, want standard", found)
+	}
+
+	// Without the tails the same event reads as incomplete
+	e.checkLayout("New York", data, brackets(all), update)
+	if found := e.layouts["New York"].found; found == "" {
+		t.Error("layout without the tails: found standard")
+	}
+}
+
+func TestEngine_FetchEvent(t *testing.T) {
+	const eventTicker = "KXHIGHCHI-26JAN20"
+	x := mockexchange.New(1)
+	t.Cleanup(x.Close)
+	for _, m := range []rest.Market{
+		{Ticker: eventTicker + "-T0", StrikeType: "less", CapStrike: 0.5, YesSubTitle: "0° or below", YesBid: 12, YesAsk: 15},
+		{Ticker: eventTicker + "-B1.5", StrikeType: "between", FloorStrike: 0.5, CapStrike: 2.5, YesSubTitle: "1° to 2°", YesBid: 30, YesAsk: 33},
+		{Ticker: eventTicker + "-T2", StrikeType: "greater", FloorStrike: 2, YesSubTitle: "3° or above", YesBid: 50, YesAsk: 54},
+		{Ticker: eventTicker + "-X", YesSubTitle: "Snow"}, // Unreadable; skipped
+	} {
+		m.EventTicker = eventTicker
+		x.AddMarket(m)
+	}
+	e := NewEngine(TradingConfig{}, newTestExecutor(t, x))
+
+	markets, err := e.fetchEventMarkets(eventTicker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]market.Strike{
+		eventTicker + "-T0":   {Floor: market.OpenFloor, Cap: 0},
+		eventTicker + "-B1.5": {Floor: 1, Cap: 2},
+		eventTicker + "-T2":   {Floor: 3, Cap: market.OpenCap},
+	}
+	if len(markets) != len(want) {
+		t.Fatalf("fetched %d markets, want %d", len(markets), len(want))
+	}
+	for _, m := range markets {
+		if m.Strike != want[m.Ticker] {
+			t.Errorf("%s: Strike = %v, want %v", m.Ticker, m.Strike, want[m.Ticker])
+		}
+	}
+
+	// Quotes are in cents, as fetched
+	data, _ := e.quote(DefaultStations[0], MarketHigh, eventTicker, markets, time.Now(), time.Now())
+	if len(data.Quotes) != 3 || data.Quotes[1].YesBid != 30 || data.Quotes[1].YesAsk != 33 {
+		t.Errorf("quotes = %+v, want three with the bracket at 30/33¢", data.Quotes)
+	}
+}
@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"errors"
+
+	"github.com/brendanplayford/kalshi-go/pkg/metrics"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/risk"
+)
+
+// SetMetrics records orders, the balance, exposure, METAR readings and edges
+// to the metrics served on /metrics
+func (e *Engine) SetMetrics(m *metrics.Trading) {
+	e.metrics = m
+}
+
+// rejectReason labels why an order wasn't placed: a local check, the
+// exchange's rejection class, or api_error for any other failure
+func rejectReason(err error) string {
+	switch {
+	case errors.Is(err, ErrRiskLimit):
+		return "risk_limit"
+	case errors.Is(err, risk.ErrHalted):
+		return "halted"
+	case errors.Is(err, rest.ErrOrderBounds):
+		return "order_bounds"
+	case errors.Is(err, rest.ErrInvalidPrice):
+		return "invalid_price"
+	}
+	if class, ok := rest.ClassifyRejection(err); ok {
+		return string(class)
+	}
+	return "api_error"
+}
+
+// recordExposure sets the exposure gauge to the open cost of live positions
+// and resting orders
+func (e *Engine) recordExposure() {
+	if e.metrics == nil {
+		return
+	}
+	e.mu.RLock()
+	open := e.restingExposure()
+	for _, trades := range e.positions {
+		for _, t := range trades {
+			if t.Settled || t.Status == "shadow" {
+				continue
+			}
+			open += t.Cost * float64(t.Quantity-t.Sold) / float64(t.Quantity)
+		}
+	}
+	e.mu.RUnlock()
+	e.metrics.SetExposure(open)
+}
@@ -0,0 +1,327 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Model inputs that can be overridden by the operator
+const (
+	// InputMaxTemp replaces the observed METAR max when picking the bracket
+	// the weather signal agrees with (e.g. a forecast high the METAR hasn't
+	// reached yet)
+	InputMaxTemp = "max_temp"
+)
+
+// Bounds of a plausible temperature override in °F, past the records of every
+// traded city
+const (
+	minOverrideTemp = -40
+	maxOverrideTemp = 130
+)
+
+// Override is an operator-supplied value for a model input, valid for one
+// city on one local trading day
+type Override struct {
+	City      string    `json:"city"`
+	Date      string    `json:"date"` // Local trading day, YYYY-MM-DD
+	Input     string    `json:"input"`
+	Value     float64   `json:"value"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UnmarshalJSON decodes an override, requiring its value: a missing one would
+// otherwise read as 0°F
+func (o *Override) UnmarshalJSON(data []byte) error {
+	type override Override
+	var v struct {
+		override
+		Value *float64 `json:"value"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Value == nil {
+		return fmt.Errorf("override value is missing")
+	}
+	*o = Override(v.override)
+	o.Value = *v.Value
+	return nil
+}
+
+// String formats the override for logs and trade annotations
+func (o Override) String() string {
+	return fmt.Sprintf("%s=%g (%s)", o.Input, o.Value, o.Reason)
+}
+
+// Overrides holds operator overrides, persisted to a JSON file. The file may
+// also be edited by hand; changes are picked up on the next lookup. Overrides
+// expire when their city's trading day ends
+type Overrides struct {
+	mu        sync.Mutex
+	path      string
+	modTime   time.Time
+	overrides []Override
+}
+
+// NewOverrides loads overrides from path (if present)
+func NewOverrides(path string) (*Overrides, error) {
+	o := &Overrides{path: path}
+	if err := o.reload(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Set validates and stores an override, replacing any for the same city, day
+// and input. An empty date defaults to the city's current trading day and an
+// empty input to InputMaxTemp
+func (o *Overrides) Set(ov Override) (Override, error) {
+	ov.City = strings.ToUpper(strings.TrimSpace(ov.City))
+	station, ok := stationByCode(ov.City)
+	if !ok {
+		return Override{}, fmt.Errorf("unknown city %q", ov.City)
+	}
+	if ov.Input == "" {
+		ov.Input = InputMaxTemp
+	}
+	if ov.Input != InputMaxTemp {
+		return Override{}, fmt.Errorf("unknown input %q (want %s)", ov.Input, InputMaxTemp)
+	}
+	if ov.Value < minOverrideTemp || ov.Value > maxOverrideTemp {
+		return Override{}, fmt.Errorf("implausible %s %g°F (want %d to %d)", ov.Input, ov.Value, minOverrideTemp, maxOverrideTemp)
+	}
+	ov.Reason = strings.TrimSpace(ov.Reason)
+	if ov.Reason == "" {
+		return Override{}, fmt.Errorf("override reason is empty")
+	}
+
+	today, err := localDate(station, time.Now())
+	if err != nil {
+		return Override{}, err
+	}
+	if ov.Date == "" {
+		ov.Date = today
+	}
+	if _, err := time.Parse("2006-01-02", ov.Date); err != nil {
+		return Override{}, fmt.Errorf("invalid date %q (want YYYY-MM-DD)", ov.Date)
+	}
+	if ov.Date < today {
+		return Override{}, fmt.Errorf("date %s has already ended in %s", ov.Date, station.City)
+	}
+	ov.CreatedAt = time.Now()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.refresh()
+	kept := o.overrides[:0]
+	for _, existing := range o.overrides {
+		if existing.City != ov.City || existing.Date != ov.Date || existing.Input != ov.Input {
+			kept = append(kept, existing)
+		}
+	}
+	o.overrides = append(kept, ov)
+	o.prune(time.Now())
+
+	return ov, o.save()
+}
+
+// Remove deletes the override for a city, day and input (an empty date is the
+// city's current trading day), reporting whether one existed
+func (o *Overrides) Remove(city, date, input string) (bool, error) {
+	city = strings.ToUpper(strings.TrimSpace(city))
+	station, ok := stationByCode(city)
+	if !ok {
+		return false, fmt.Errorf("unknown city %q", city)
+	}
+	if input == "" {
+		input = InputMaxTemp
+	}
+	if date == "" {
+		today, err := localDate(station, time.Now())
+		if err != nil {
+			return false, err
+		}
+		date = today
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.refresh()
+	removed := false
+	kept := o.overrides[:0]
+	for _, existing := range o.overrides {
+		if existing.City == city && existing.Date == date && existing.Input == input {
+			removed = true
+			continue
+		}
+		kept = append(kept, existing)
+	}
+	o.overrides = kept
+
+	if !removed {
+		return false, nil
+	}
+	return true, o.save()
+}
+
+// Get returns the override for a city's input on a local trading day
+func (o *Overrides) Get(city, date, input string) (Override, bool) {
+	if o == nil {
+		return Override{}, false
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.refresh()
+
+	for _, ov := range o.overrides {
+		if ov.City == city && ov.Date == date && ov.Input == input {
+			return ov, true
+		}
+	}
+	return Override{}, false
+}
+
+// Active returns the overrides that have not yet expired, by city
+func (o *Overrides) Active(now time.Time) []Override {
+	if o == nil {
+		return nil
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.refresh()
+
+	var active []Override
+	for _, ov := range o.overrides {
+		if !expired(ov, now) {
+			active = append(active, ov)
+		}
+	}
+	sort.SliceStable(active, func(i, k int) bool { return active[i].City < active[k].City })
+	return active
+}
+
+// refresh reloads the file if it was edited since it was last read, keeping
+// the current overrides if the edit doesn't parse
+func (o *Overrides) refresh() {
+	if o.path == "" {
+		return
+	}
+	info, err := os.Stat(o.path)
+	if err != nil || info.ModTime().Equal(o.modTime) {
+		return
+	}
+	if err := o.reload(); err != nil {
+		o.modTime = info.ModTime()
+		log.Printf("[Overrides] Ignoring edit to %s: %v", o.path, err)
+	}
+}
+
+func (o *Overrides) reload() error {
+	if o.path == "" {
+		return nil
+	}
+	info, err := os.Stat(o.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read overrides: %w", err)
+	}
+	data, err := os.ReadFile(o.path)
+	if err != nil {
+		return fmt.Errorf("read overrides: %w", err)
+	}
+	var overrides []Override
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return fmt.Errorf("parse overrides: %w", err)
+	}
+	for i := range overrides {
+		overrides[i].City = strings.ToUpper(overrides[i].City)
+		if overrides[i].Input == "" {
+			overrides[i].Input = InputMaxTemp
+		}
+	}
+	o.overrides = overrides
+	o.modTime = info.ModTime()
+	return nil
+}
+
+// prune drops overrides whose trading day has ended
+func (o *Overrides) prune(now time.Time) {
+	kept := o.overrides[:0]
+	for _, ov := range o.overrides {
+		if !expired(ov, now) {
+			kept = append(kept, ov)
+		}
+	}
+	o.overrides = kept
+}
+
+// save writes the overrides to the file. The caller holds o.mu, so concurrent
+// saves never share the temporary file
+func (o *Overrides) save() error {
+	if o.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(o.overrides, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(o.path), 0755); err != nil {
+		return err
+	}
+	tmp := o.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, o.path); err != nil {
+		return err
+	}
+
+	// Our own write is not an operator edit
+	if info, err := os.Stat(o.path); err == nil {
+		o.modTime = info.ModTime()
+	}
+	return nil
+}
+
+// expired reports whether the override's day has ended in its city
+func expired(ov Override, now time.Time) bool {
+	station, ok := stationByCode(ov.City)
+	if !ok {
+		return true
+	}
+	today, err := localDate(station, now)
+	if err != nil {
+		return false
+	}
+	return ov.Date < today
+}
+
+// localDate returns the station's trading day at now
+func localDate(station Station, now time.Time) (string, error) {
+	loc, err := time.LoadLocation(station.Timezone)
+	if err != nil {
+		return "", fmt.Errorf("load timezone %s: %w", station.Timezone, err)
+	}
+	return now.In(loc).Format("2006-01-02"), nil
+}
+
+// stationByCode returns the DefaultStations entry for a city code
+func stationByCode(code string) (Station, bool) {
+	for _, s := range DefaultStations {
+		if s.Code == code {
+			return s, true
+		}
+	}
+	return Station{}, false
+}
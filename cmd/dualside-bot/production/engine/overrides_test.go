@@ -0,0 +1,180 @@
+package engine
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOverrides_Set(t *testing.T) {
+	o, err := NewOverrides(filepath.Join(t.TempDir(), "overrides.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lax := DefaultStations[0]
+	today, err := localDate(lax, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ov := range []Override{
+		{City: "XYZ", Value: 71, Reason: "Santa Ana winds"},
+		{City: lax.Code, Input: "min_temp", Value: 71, Reason: "Santa Ana winds"},
+		{City: lax.Code, Value: 71},
+		{City: lax.Code, Value: 71, Reason: "Santa Ana winds", Date: "03/10/2026"},
+		{City: lax.Code, Value: 71, Reason: "Santa Ana winds", Date: "2020-01-01"},
+		{City: lax.Code, Value: 710, Reason: "typo"},
+		{City: lax.Code, Value: -41, Reason: "typo"},
+	} {
+		if _, err := o.Set(ov); err == nil {
+			t.Errorf("Set(%+v) succeeded, want an error", ov)
+		}
+	}
+
+	set, err := o.Set(Override{City: " lax", Value: 71, Reason: "Santa Ana winds"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.City != lax.Code || set.Date != today || set.Input != InputMaxTemp {
+		t.Errorf("Set() = %+v, want %s today's %s", set, lax.Code, InputMaxTemp)
+	}
+	// A second override for the day replaces the first
+	if _, err := o.Set(Override{City: lax.Code, Value: 0, Reason: "cold snap"}); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := o.Get(lax.Code, today, InputMaxTemp)
+	if !ok || got.Value != 0 || got.Reason != "cold snap" {
+		t.Errorf("Get() = %+v, %v; want the 0°F replacement", got, ok)
+	}
+	if n := len(o.Active(time.Now())); n != 1 {
+		t.Errorf("Active() = %d overrides, want 1", n)
+	}
+}
+
+func TestOverrides_Remove(t *testing.T) {
+	o, err := NewOverrides(filepath.Join(t.TempDir(), "overrides.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lax := DefaultStations[0]
+	if _, err := o.Set(Override{City: lax.Code, Value: 71, Reason: "Santa Ana winds"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []bool{true, false} {
+		removed, err := o.Remove(lax.Code, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if removed != want {
+			t.Errorf("Remove() = %v, want %v", removed, want)
+		}
+	}
+	if len(o.Active(time.Now())) != 0 {
+		t.Error("override still active after Remove()")
+	}
+	if _, err := o.Remove("XYZ", "", ""); err == nil {
+		t.Error("Remove(XYZ) succeeded, want an error")
+	}
+}
+
+func TestOverrides_Expiry(t *testing.T) {
+	lax := DefaultStations[0]
+	loc, err := time.LoadLocation(lax.Timezone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ov := Override{City: lax.Code, Date: "2026-03-10", Input: InputMaxTemp}
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{"during the day", time.Date(2026, 3, 10, 15, 0, 0, 0, loc), false},
+		{"last minute", time.Date(2026, 3, 10, 23, 59, 0, 0, loc), false},
+		{"next day", time.Date(2026, 3, 11, 0, 0, 0, 0, loc), true},
+		// Already the 11th in UTC, still the 10th in Los Angeles
+		{"UTC date ahead", time.Date(2026, 3, 11, 5, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := expired(ov, tt.now); got != tt.want {
+			t.Errorf("%s: expired() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	o := &Overrides{overrides: []Override{ov}}
+	if n := len(o.Active(tests[0].now)); n != 1 {
+		t.Errorf("Active() during the day = %d overrides, want 1", n)
+	}
+	if n := len(o.Active(tests[2].now)); n != 0 {
+		t.Errorf("Active() the next day = %d overrides, want 0", n)
+	}
+}
+
+func TestOverrides_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	o, err := NewOverrides(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lax := DefaultStations[0]
+	today, err := localDate(lax, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	day, err := time.Parse("2006-01-02", today)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Concurrent sets each save the full list
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			date := day.AddDate(0, 0, i).Format("2006-01-02")
+			if _, err := o.Set(Override{City: lax.Code, Date: date, Value: float64(60 + i), Reason: "forecast"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	reloaded, err := NewOverrides(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(reloaded.Active(time.Now())); n != 8 {
+		t.Fatalf("reloaded %d overrides, want 8", n)
+	}
+	if got, ok := reloaded.Get(lax.Code, today, InputMaxTemp); !ok || got.Value != 60 {
+		t.Errorf("reloaded Get() = %+v, %v; want 60°F", got, ok)
+	}
+
+	// A hand edit without a value is ignored, not read as 0°F
+	edit := `[{"city": "LAX", "date": "` + today + `", "reason": "no value"}]`
+	if err := os.WriteFile(path, []byte(edit), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := reloaded.Get(lax.Code, today, InputMaxTemp); !ok || got.Value != 60 {
+		t.Errorf("Get() after a bad edit = %+v, %v; want the 60°F override kept", got, ok)
+	}
+	if _, err := NewOverrides(path); err == nil {
+		t.Error("override without a value loaded")
+	}
+
+	var ov Override
+	if err := json.Unmarshal([]byte(`{"city": "LAX", "value": 0, "reason": "cold snap"}`), &ov); err != nil || ov.Value != 0 || ov.City != "LAX" {
+		t.Errorf("decoding a 0°F override = %+v, %v", ov, err)
+	}
+	if err := json.Unmarshal([]byte(`{"city": "LAX", "reason": "no value"}`), &ov); err == nil {
+		t.Error("override without a value decoded")
+	}
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/service"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// Readiness checks the engine reports
+const (
+	CheckState   = "state"   // Balance and resting orders reconciled with the account
+	CheckWeather = "weather" // A station's latest METAR is fresh
+	CheckMarkets = "markets" // The markets API answers
+)
+
+// SetReadiness reports the readiness checks to gate: the state check from
+// Reconcile, the feed checks from CheckFeeds and on every tick
+func (e *Engine) SetReadiness(gate *service.Gate) {
+	e.ready = gate
+}
+
+// RunOnce runs a single decision cycle: settle, take profits, and analyze
+// and trade every station
+func (e *Engine) RunOnce() {
+	e.tick()
+}
+
+// CheckFeeds reports whether the weather and market feeds are healthy. The
+// weather feed is healthy if any station's latest report is fresh, so one
+// station's outage doesn't hold back the others
+func (e *Engine) CheckFeeds(now time.Time) {
+	if e.ready == nil {
+		return
+	}
+
+	var errs []error
+	weatherOK := false
+	for _, station := range DefaultStations {
+		err := e.checkWeather(station, now)
+		if err == nil {
+			weatherOK = true
+			break
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", station.Code, err))
+	}
+	if weatherOK {
+		e.ready.Report(CheckWeather, nil)
+	} else {
+		e.ready.Report(CheckWeather, errors.Join(errs...))
+	}
+
+	station := DefaultStations[0]
+	loc, err := time.LoadLocation(station.Timezone)
+	if err == nil {
+		eventTicker := fmt.Sprintf("%s-%s", station.EventPrefix, strings.ToUpper(now.In(loc).Format("06Jan02")))
+		_, err = e.fetchMarkets(eventTicker)
+	}
+	e.ready.Report(CheckMarkets, err)
+}
+
+// checkWeather returns an error unless the station's latest report is fresh.
+// Shortly after local midnight the day has no reports yet, so the previous
+// day's last one is checked
+func (e *Engine) checkWeather(station Station, now time.Time) error {
+	ws := weather.StationByCode(station.Code)
+	if ws == nil {
+		return fmt.Errorf("no weather station %s", station.Code)
+	}
+	day := now.In(ws.Location())
+	obs, err := e.observations.Observations(context.Background(), ws, day)
+	if (err == nil && len(obs) == 0) || errors.Is(err, weather.ErrNoObservations) {
+		obs, err = e.observations.Observations(context.Background(), ws, day.AddDate(0, 0, -1))
+	}
+	if err == nil {
+		obs, _ = weather.SaneObservations(obs, time.Time{}, now)
+		if len(obs) == 0 {
+			err = weather.ErrNoObservations
+		} else {
+			err = weather.CheckObservationTime(obs[len(obs)-1].Time, now)
+		}
+	}
+	e.health.Observe(HealthMETAR, err)
+	return err
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"log"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+const (
+	// maxRemedies is how many times one order is retried after the
+	// exchange rejects it
+	maxRemedies = 2
+
+	// marketPauseCooldown is how long a market is skipped after the
+	// exchange rejects an order because it is paused or closed
+	marketPauseCooldown = 15 * time.Minute
+)
+
+// remedy applies the automatic response to an order the exchange rejected
+// as class (see rest.ClassifyRejection). It returns the order to retry, or
+// false to drop it:
+//   - price out of bounds: refetch the market's price grid and retry at the
+//     strategy's price conformed to it, if that changes the price
+//   - market paused: skip the market for marketPauseCooldown
+//   - insufficient funds: refetch the balance and halt entries for the rest
+//     of the tick
+//   - size too large: retry with half the contracts
+func (e *Engine) remedy(station Station, class rest.Rejection, req ExecuteOrderRequest, want int, now time.Time) (ExecuteOrderRequest, bool) {
+	switch class.Remedy() {
+	case rest.RemedyReprice:
+		e.executor.ForgetPriceGrid(req.Ticker)
+		price, err := e.conformPrice(req.Ticker, want)
+		if err != nil || price == req.Price {
+			log.Printf("[Engine] %s: %s rejected at %d¢ (%s), no other valid price", station.City, req.Ticker, req.Price, class)
+			return req, false
+		}
+		log.Printf("[Engine] %s: %s rejected at %d¢ (%s), re-pricing at %d¢", station.City, req.Ticker, req.Price, class, price)
+		req.Price = price
+		return req, true
+
+	case rest.RemedySkip:
+		e.mu.Lock()
+		e.paused[req.Ticker] = now.Add(marketPauseCooldown)
+		e.mu.Unlock()
+		log.Printf("[Engine] %s: %s rejected (%s), skipping the market for %s", station.City, req.Ticker, class, marketPauseCooldown)
+		return req, false
+
+	case rest.RemedyHalt:
+		e.mu.Lock()
+		e.entriesHalted = true
+		e.mu.Unlock()
+		if err := e.syncAccount(); err != nil {
+			log.Printf("[Engine] Failed to refresh the account: %v", err)
+		}
+		log.Printf("[Engine] %s: %s rejected (%s), halting entries until the next tick", station.City, req.Ticker, class)
+		return req, false
+
+	case rest.RemedyReduce:
+		if req.Quantity <= 1 {
+			log.Printf("[Engine] %s: %s rejected at 1 contract (%s)", station.City, req.Ticker, class)
+			return req, false
+		}
+		log.Printf("[Engine] %s: %s rejected at %d contracts (%s), retrying with %d", station.City, req.Ticker, req.Quantity, class, req.Quantity/2)
+		req.Quantity /= 2
+		return req, true
+	}
+	return req, false
+}
+
+// marketPaused reports whether ticker is skipped after a paused-market
+// rejection
+func (e *Engine) marketPaused(ticker string, now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	until, ok := e.paused[ticker]
+	if ok && !now.Before(until) {
+		delete(e.paused, ticker)
+		return false
+	}
+	return ok
+}
@@ -0,0 +1,205 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrRiskLimit is returned by RiskManager.Check when a new position would
+// exceed a throttle
+var ErrRiskLimit = errors.New("risk limit reached")
+
+// Throttle windows (rolling, so a burst at midnight can't double a cap)
+const (
+	riskDay  = 24 * time.Hour
+	riskWeek = 7 * 24 * time.Hour
+)
+
+// RiskLimits caps how quickly the engine may open new positions, so a bug or
+// a pathological market can't open dozens of positions in minutes, and how
+// much of the bankroll one event or city can take. A zero limit is not
+// enforced
+type RiskLimits struct {
+	MaxPositionsPerDay  int     // New positions in any rolling 24 hours
+	MaxPositionsPerWeek int     // New positions in any rolling 7 days
+	MaxRiskPerWeek      float64 // Dollars of new cost in any rolling 7 days
+	MaxEventFraction    float64 // Open cost in one event, as a share of bankroll
+	MaxCityFraction     float64 // Open cost in one city's events on one day, as a share of bankroll
+}
+
+// RiskUsage is the current use of each limit
+type RiskUsage struct {
+	Limits            RiskLimits `json:"limits"`
+	PositionsToday    int        `json:"positions_24h"`
+	PositionsThisWeek int        `json:"positions_7d"`
+	RiskThisWeek      float64    `json:"risk_7d"`
+}
+
+// riskEntry is one opened position
+type riskEntry struct {
+	Time time.Time `json:"time"`
+	Cost float64   `json:"cost"`
+}
+
+// RiskManager enforces RiskLimits on new positions. Opened positions are
+// persisted to a JSON file so a restart (or crash loop) doesn't reset the
+// windows
+type RiskManager struct {
+	mu      sync.Mutex
+	path    string
+	limits  RiskLimits
+	entries []riskEntry
+}
+
+// NewRiskManager loads the positions opened in the last week from path (if
+// present)
+func NewRiskManager(path string, limits RiskLimits) (*RiskManager, error) {
+	r := &RiskManager{path: path, limits: limits}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("read risk log: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.entries); err != nil {
+		return nil, fmt.Errorf("parse risk log: %w", err)
+	}
+	return r, nil
+}
+
+// Check returns an error wrapping ErrRiskLimit if opening a position costing
+// cost at now would exceed a limit
+func (r *RiskManager) Check(now time.Time, cost float64) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	u := r.usage(now)
+	r.mu.Unlock()
+
+	switch {
+	case u.Limits.MaxPositionsPerDay > 0 && u.PositionsToday >= u.Limits.MaxPositionsPerDay:
+		return fmt.Errorf("%w: %d new positions in 24h (max %d)", ErrRiskLimit, u.PositionsToday, u.Limits.MaxPositionsPerDay)
+	case u.Limits.MaxPositionsPerWeek > 0 && u.PositionsThisWeek >= u.Limits.MaxPositionsPerWeek:
+		return fmt.Errorf("%w: %d new positions in 7d (max %d)", ErrRiskLimit, u.PositionsThisWeek, u.Limits.MaxPositionsPerWeek)
+	case u.Limits.MaxRiskPerWeek > 0 && u.RiskThisWeek+cost > u.Limits.MaxRiskPerWeek:
+		return fmt.Errorf("%w: $%.2f new risk in 7d + $%.2f exceeds $%.2f", ErrRiskLimit, u.RiskThisWeek, cost, u.Limits.MaxRiskPerWeek)
+	}
+	return nil
+}
+
+// CheckConcentration returns an error wrapping ErrRiskLimit if adding cost
+// to an event with eventCost open (and its city's day with cityCost open)
+// would exceed the share of bankroll allowed for either
+func (r *RiskManager) CheckConcentration(bankroll, eventCost, cityCost, cost float64) error {
+	if r == nil || bankroll <= 0 {
+		return nil
+	}
+	l := r.limits
+	switch {
+	case l.MaxEventFraction > 0 && eventCost+cost > l.MaxEventFraction*bankroll:
+		return fmt.Errorf("%w: $%.2f in event + $%.2f exceeds %.0f%% of $%.2f bankroll",
+			ErrRiskLimit, eventCost, cost, l.MaxEventFraction*100, bankroll)
+	case l.MaxCityFraction > 0 && cityCost+cost > l.MaxCityFraction*bankroll:
+		return fmt.Errorf("%w: $%.2f in city today + $%.2f exceeds %.0f%% of $%.2f bankroll",
+			ErrRiskLimit, cityCost, cost, l.MaxCityFraction*100, bankroll)
+	}
+	return nil
+}
+
+// HasConcentrationLimits reports whether a per-event or per-city cap is set
+func (r *RiskManager) HasConcentrationLimits() bool {
+	return r != nil && (r.limits.MaxEventFraction > 0 || r.limits.MaxCityFraction > 0)
+}
+
+// Record counts a newly opened position and persists the log
+func (r *RiskManager) Record(now time.Time, cost float64) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	r.prune(now)
+	r.entries = append(r.entries, riskEntry{Time: now, Cost: cost})
+	snapshot := append([]riskEntry(nil), r.entries...)
+	r.mu.Unlock()
+
+	return r.save(snapshot)
+}
+
+// Usage returns the current use of each limit
+func (r *RiskManager) Usage(now time.Time) RiskUsage {
+	if r == nil {
+		return RiskUsage{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.usage(now)
+}
+
+// SpentSince returns the cost of the positions opened since a time, up to a
+// week back
+func (r *RiskManager) SpentSince(since time.Time) float64 {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	spent := 0.0
+	for _, e := range r.entries {
+		if !e.Time.Before(since) {
+			spent += e.Cost
+		}
+	}
+	return spent
+}
+
+func (r *RiskManager) usage(now time.Time) RiskUsage {
+	u := RiskUsage{Limits: r.limits}
+	for _, e := range r.entries {
+		age := now.Sub(e.Time)
+		if age < riskDay {
+			u.PositionsToday++
+		}
+		if age < riskWeek {
+			u.PositionsThisWeek++
+			u.RiskThisWeek += e.Cost
+		}
+	}
+	return u
+}
+
+// prune drops entries older than the longest window
+func (r *RiskManager) prune(now time.Time) {
+	kept := r.entries[:0]
+	for _, e := range r.entries {
+		if now.Sub(e.Time) < riskWeek {
+			kept = append(kept, e)
+		}
+	}
+	r.entries = kept
+}
+
+func (r *RiskManager) save(snapshot []riskEntry) error {
+	if r.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRiskManager_Check(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		limits  RiskLimits
+		opened  []time.Duration // Ages of the positions already opened
+		cost    float64
+		wantErr bool
+	}{
+		{"no limits", RiskLimits{}, []time.Duration{time.Hour, 2 * time.Hour}, 1000, false},
+		{"under the daily cap", RiskLimits{MaxPositionsPerDay: 3}, []time.Duration{time.Hour, 2 * time.Hour}, 5, false},
+		{"at the daily cap", RiskLimits{MaxPositionsPerDay: 2}, []time.Duration{time.Hour, 2 * time.Hour}, 5, true},
+		{"daily window rolls", RiskLimits{MaxPositionsPerDay: 2}, []time.Duration{time.Hour, 25 * time.Hour}, 5, false},
+		{"at the weekly cap", RiskLimits{MaxPositionsPerWeek: 2}, []time.Duration{30 * time.Hour, 50 * time.Hour}, 5, true},
+		{"weekly window rolls", RiskLimits{MaxPositionsPerWeek: 2}, []time.Duration{30 * time.Hour, 8 * 24 * time.Hour}, 5, false},
+		{"risk fits", RiskLimits{MaxRiskPerWeek: 30}, []time.Duration{time.Hour, 3 * 24 * time.Hour}, 10, false},
+		{"risk exceeds", RiskLimits{MaxRiskPerWeek: 30}, []time.Duration{time.Hour, 3 * 24 * time.Hour}, 10.01, true},
+	}
+	for _, tt := range tests {
+		r, err := NewRiskManager("", tt.limits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, age := range tt.opened {
+			if err := r.Record(now.Add(-age), 10); err != nil {
+				t.Fatal(err)
+			}
+		}
+		err = r.Check(now, tt.cost)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("%s: Check() error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrRiskLimit) {
+			t.Errorf("%s: Check() error = %v, want ErrRiskLimit", tt.name, err)
+		}
+	}
+
+	var none *RiskManager
+	if err := none.Check(now, 1000); err != nil {
+		t.Errorf("nil manager: Check() error = %v", err)
+	}
+}
+
+func TestRiskManager_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "risk.json")
+	limits := RiskLimits{MaxPositionsPerDay: 2, MaxPositionsPerWeek: 3}
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	r, err := NewRiskManager(path, limits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, at := range []time.Time{now.Add(-8 * 24 * time.Hour), now.Add(-2 * 24 * time.Hour), now.Add(-time.Hour)} {
+		if err := r.Record(at, 5); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A restart keeps the windows; the entry older than a week was pruned
+	reloaded, err := NewRiskManager(path, limits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := RiskUsage{Limits: limits, PositionsToday: 1, PositionsThisWeek: 2, RiskThisWeek: 10}
+	if got := reloaded.Usage(now); got != want {
+		t.Errorf("reloaded usage = %+v, want %+v", got, want)
+	}
+	if got := reloaded.SpentSince(now.Add(-24 * time.Hour)); got != 5 {
+		t.Errorf("SpentSince(24h ago) = %v, want 5", got)
+	}
+	if err := reloaded.Record(now, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloaded.Check(now, 5); !errors.Is(err, ErrRiskLimit) {
+		t.Errorf("after the third position: Check() error = %v, want ErrRiskLimit", err)
+	}
+}
+
+func TestRiskManager_CheckConcentration(t *testing.T) {
+	tests := []struct {
+		name                string
+		limits              RiskLimits
+		bankroll            float64
+		eventCost, cityCost float64
+		cost                float64
+		wantErr             bool
+	}{
+		{"no limits", RiskLimits{}, 100, 90, 90, 50, false},
+		{"event fits", RiskLimits{MaxEventFraction: 0.2}, 100, 10, 10, 10, false},
+		{"event exceeds", RiskLimits{MaxEventFraction: 0.2}, 100, 10, 10, 10.5, true},
+		{"city fits", RiskLimits{MaxEventFraction: 0.2, MaxCityFraction: 0.3}, 100, 5, 20, 10, false},
+		{"city exceeds", RiskLimits{MaxEventFraction: 0.2, MaxCityFraction: 0.3}, 100, 5, 25, 10, true},
+		{"unknown bankroll", RiskLimits{MaxEventFraction: 0.2}, 0, 10, 10, 50, false},
+	}
+	for _, tt := range tests {
+		r, err := NewRiskManager("", tt.limits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = r.CheckConcentration(tt.bankroll, tt.eventCost, tt.cityCost, tt.cost)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("%s: CheckConcentration() error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrRiskLimit) {
+			t.Errorf("%s: CheckConcentration() error = %v, want ErrRiskLimit", tt.name, err)
+		}
+	}
+}
+
+func TestEngine_Exposure(t *testing.T) {
+	e := NewEngine(TradingConfig{}, nil)
+	lax := DefaultStations[0]
+	e.cash = 100
+	e.positions = map[string][]Trade{
+		"KXHIGHLAX-26MAR10": {
+			{City: lax.City, Quantity: 10, Cost: 4},
+			{City: lax.City, Quantity: 10, Sold: 5, Cost: 6},          // Half still open
+			{City: lax.City, Quantity: 10, Cost: 8, Settled: true},    // Settled
+			{City: lax.City, Quantity: 10, Cost: 8, Status: "shadow"}, // Never sent
+		},
+		"KXLOWTLAX-26MAR10": {{City: lax.City, Quantity: 5, Cost: 2}},
+		"KXHIGHLAX-26MAR11": {{City: lax.City, Quantity: 5, Cost: 3}},
+		"KXHIGHNY-26MAR10":  {{City: "New York", Quantity: 5, Cost: 5}},
+	}
+	e.resting = map[string]float64{
+		"KXHIGHLAX-26MAR10": 1,
+		"KXLOWTLAX-26MAR10": 1.5,
+		"KXHIGHNY-26MAR10":  2,
+	}
+
+	eventCost, cityCost, bankroll := e.exposure(lax, "KXHIGHLAX-26MAR10")
+	for _, c := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"event cost", eventCost, 4 + 3 + 1},
+		{"city cost", cityCost, 4 + 3 + 2 + 1 + 1.5},
+		{"bankroll", bankroll, 100 + 4 + 3 + 2 + 3 + 5 + 1 + 1.5 + 2},
+	} {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestEngine_PlaceOrderConcentration(t *testing.T) {
+	e := NewEngine(TradingConfig{}, nil)
+	risk, err := NewRiskManager("", RiskLimits{MaxEventFraction: 0.1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.SetRiskManager(risk)
+	var reported []error
+	e.SetErrorCallback(func(err error) { reported = append(reported, err) })
+
+	lax := DefaultStations[0]
+	e.cash = 100
+	e.positions["KXHIGHLAX-26MAR10"] = []Trade{{City: lax.City, Quantity: 20, Cost: 8}}
+
+	// $8 open + $3 new is over 10% of the $108 bankroll; the executor is
+	// never reached
+	req := ExecuteOrderRequest{Ticker: "KXHIGHLAX-26MAR10-B70.5", Side: "yes", Action: "buy", Price: 30, Quantity: 10}
+	for range 2 {
+		if _, _, _, err := e.placeOrder(lax, "KXHIGHLAX-26MAR10", req); !errors.Is(err, ErrRiskLimit) {
+			t.Errorf("placeOrder() error = %v, want ErrRiskLimit", err)
+		}
+	}
+	if len(reported) != 1 {
+		t.Errorf("reported %d throttle errors, want only the first", len(reported))
+	}
+}
@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// ApplySeries points DefaultStations at the series the exchange lists for
+// them (see market.Catalog.Apply) and returns the changes made. A city
+// without LOW events gets them once the exchange lists a LOW series for it.
+// Call it before the engine starts
+func ApplySeries(cat *market.Catalog) []market.SeriesChange {
+	noLow := make(map[string]bool)
+	stations := make(map[string]*weather.Station, len(DefaultStations))
+	for _, s := range DefaultStations {
+		stations[s.Code] = &weather.Station{EventPrefix: s.EventPrefix, LowPrefix: s.LowPrefix}
+		noLow[s.Code] = s.LowPrefix == ""
+	}
+	var changes []market.SeriesChange
+	for _, c := range cat.Apply(stations) {
+		if c.Type == weather.MarketTypeLow && noLow[c.Code] {
+			continue // Compared with the derived ticker, which the city didn't trade
+		}
+		changes = append(changes, c)
+	}
+
+	for i, s := range DefaultStations {
+		st := stations[s.Code]
+		DefaultStations[i].EventPrefix = st.EventPrefix
+		if s.LowPrefix != "" {
+			DefaultStations[i].LowPrefix = st.SeriesTicker(weather.MarketTypeLow)
+			continue
+		}
+		if low, ok := cat.SeriesFor(s.Code, weather.MarketTypeLow); ok {
+			DefaultStations[i].LowPrefix = low.Ticker
+			changes = append(changes, market.SeriesChange{Code: s.Code, Type: weather.MarketTypeLow, To: low.Ticker})
+		}
+	}
+	return changes
+}
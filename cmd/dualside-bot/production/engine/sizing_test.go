@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/mockexchange"
+	"github.com/brendanplayford/kalshi-go/pkg/sizing"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+func TestEngine_Size(t *testing.T) {
+	lax := DefaultStations[0]
+	const eventTicker = "KXHIGHLAX-26MAR10"
+	spread := fees.NewSchedule(fees.Rule{Series: fees.AnySeries, Basis: fees.BasisSpread, TakerRate: 0.07, MakerRate: 0.07})
+
+	tests := []struct {
+		name      string
+		reserve   float64
+		fees      *fees.Schedule
+		cash      float64
+		known     bool
+		open      float64 // Cost of positions in another city
+		contracts int
+		price     int
+		want      int
+	}{
+		{"no reserve", 0, nil, 5, true, 0, 50, 50, 50},
+		{"unknown cash", 0.2, nil, 5, false, 0, 50, 50, 50},
+		{"well above the reserve", 0.2, nil, 100, true, 0, 10, 50, 10},
+		// $20.60 cash + $80 open: $0.48 above the $20.12 reserve
+		{"just above the reserve", 0.2, nil, 20.6, true, 80, 10, 40, 1},
+		// $19.90 cash + $80 open is under the $19.98 reserve
+		{"just below the reserve", 0.2, nil, 19.9, true, 80, 10, 40, 0},
+		// $30 cash + $70 open leaves $10 above the reserve: 20 contracts
+		// at 50¢, or 19 once each pays its 1.75¢ entry fee
+		{"no entry fee", 0.2, nil, 30, true, 70, 50, 50, 20},
+		{"fee taper", 0.2, spread, 30, true, 70, 50, 50, 19},
+	}
+	for _, tt := range tests {
+		e := NewEngine(TradingConfig{CashReserve: tt.reserve}, nil)
+		e.SetFeeSchedule(tt.fees)
+		e.cash, e.cashKnown = tt.cash, tt.known
+		if tt.open > 0 {
+			e.positions["KXHIGHNY-26MAR10"] = []Trade{{City: "New York", Quantity: 10, Cost: tt.open}}
+		}
+		if got := e.size(lax, eventTicker, eventTicker+"-B70.5", tt.contracts, tt.price); got != tt.want {
+			t.Errorf("%s: size() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEngine_SizeBalanceError(t *testing.T) {
+	x := newTestExchange(t, 1)
+	e := NewEngine(TradingConfig{CashReserve: 0.2}, newTestExecutor(t, x))
+	x.SetFaults(mockexchange.Faults{ErrorRate: 1})
+
+	// The balance was never read: orders keep their size rather than being
+	// sized against a $0 balance
+	if err := e.syncAccount(); err == nil {
+		t.Fatal("syncAccount() succeeded against a failing exchange")
+	}
+	if got := e.size(DefaultStations[0], "KXHIGHLAX-26MAR10", testTicker, 10, 45); got != 10 {
+		t.Errorf("size() with unknown cash = %d, want 10", got)
+	}
+}
+
+func TestEngine_Quantity(t *testing.T) {
+	lax := DefaultStations[0]
+	const eventTicker = "KXHIGHLAX-26MAR10"
+	o := strategy.Order{Ticker: eventTicker + "-B70.5", Side: "yes", Price: 50, Quantity: 7}
+
+	e := NewEngine(TradingConfig{WinRate: 0.958}, nil)
+	e.SetSizer(sizing.New(sizing.Kelly{Fraction: 1}, sizing.Limits{}))
+
+	// Before the balance is known the strategy's size stands
+	if got := e.quantity(lax, eventTicker, o.Ticker, o, 50, 0.8); got != o.Quantity {
+		t.Errorf("unknown cash: quantity() = %d, want %d", got, o.Quantity)
+	}
+
+	// Kelly at 50¢ stakes 2p-1 of the $100 bankroll: the model's
+	// probability decides, not the configured win rate
+	e.cash, e.cashKnown = 100, true
+	tests := []struct {
+		prob float64
+		want int
+	}{
+		{0.6, 40},
+		{0.8, 120},
+		{0.5, 0}, // No edge
+	}
+	for _, tt := range tests {
+		if got := e.quantity(lax, eventTicker, o.Ticker, o, 50, tt.prob); got != tt.want {
+			t.Errorf("quantity() at %.0f%% = %d, want %d", tt.prob*100, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SavePositions writes the unsettled positions to path, replacing the file
+// atomically, so a restart can pick them up with LoadPositions
+func (e *Engine) SavePositions(path string) error {
+	e.mu.RLock()
+	data, err := json.MarshalIndent(e.positions, "", "  ")
+	e.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadPositions restores the positions saved by SavePositions (none if path
+// doesn't exist), returning how many events they cover. Restored events are
+// not entered again and settle as usual
+func (e *Engine) LoadPositions(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read positions: %w", err)
+	}
+	var positions map[string][]Trade
+	if err := json.Unmarshal(data, &positions); err != nil {
+		return 0, fmt.Errorf("parse positions: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for eventTicker, trades := range positions {
+		if len(trades) > 0 {
+			e.positions[eventTicker] = trades
+		}
+	}
+	return len(positions), nil
+}
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+)
+
+// Crossing is a city's running max moving into or out of a held bracket
+type Crossing struct {
+	City        string
+	EventTicker string
+	Bracket     string
+	Side        string // Side held: "yes" or "no"
+	MaxTemp     int
+	Entered     bool // Into the bracket; else past its cap
+}
+
+func (c Crossing) String() string {
+	dir := "out of"
+	if c.Entered {
+		dir = "into"
+	}
+	return fmt.Sprintf("%s: running max %d° moved %s %s (holding %s)", c.City, c.MaxTemp, dir, c.Bracket, strings.ToUpper(c.Side))
+}
+
+// runningMax is the last METAR max seen for a station's event
+type runningMax struct {
+	event string
+	temp  int
+}
+
+// SetCrossingCallback sets callback for the running max crossing the bounds
+// of a held bracket
+func (e *Engine) SetCrossingCallback(fn func(Crossing)) {
+	e.onCrossing = fn
+}
+
+// watchThresholds compares each city's running max with the brackets held in
+// today's event, reporting a crossing when it moves into or out of one.
+// Operator overrides are ignored: they replace the METAR max for trading,
+// not the observed weather
+func (e *Engine) watchThresholds(now time.Time) {
+	if e.onCrossing == nil {
+		return
+	}
+
+	for _, station := range DefaultStations {
+		loc, err := time.LoadLocation(station.Timezone)
+		if err != nil {
+			continue
+		}
+		localTime := now.In(loc)
+		eventTicker := fmt.Sprintf("%s-%s", station.EventPrefix, strings.ToUpper(localTime.Format("06Jan02")))
+
+		e.mu.RLock()
+		trades := append([]Trade(nil), e.positions[eventTicker]...)
+		e.mu.RUnlock()
+		if len(trades) == 0 {
+			continue
+		}
+
+		maxTemp, err := e.getMETARMax(station, localTime)
+		if err != nil {
+			continue
+		}
+		e.mu.Lock()
+		prev, seen := e.lastMax[station.Code]
+		e.lastMax[station.Code] = runningMax{event: eventTicker, temp: maxTemp}
+		e.mu.Unlock()
+		if !seen || prev.event != eventTicker || prev.temp == maxTemp {
+			continue
+		}
+
+		checked := make(map[string]bool)
+		for _, t := range trades {
+			if checked[t.Ticker] || t.Status == "shadow" {
+				continue
+			}
+			checked[t.Ticker] = true
+
+			var floor, cap int
+			if _, err := fmt.Sscanf(t.Bracket, "%d-%d", &floor, &cap); err != nil {
+				continue
+			}
+			strike := market.NewStrike(floor, cap)
+			was, is := strike.Contains(prev.temp), strike.Contains(maxTemp)
+			if was != is {
+				e.onCrossing(Crossing{
+					City:        t.City,
+					EventTicker: eventTicker,
+					Bracket:     t.Bracket,
+					Side:        t.Side,
+					MaxTemp:     maxTemp,
+					Entered:     is,
+				})
+			}
+		}
+	}
+}
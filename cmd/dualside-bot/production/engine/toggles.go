@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Market types that can be toggled per city
+const (
+	MarketHigh = "HIGH"
+	MarketLow  = "LOW"
+)
+
+// ErrInvalidMarket is returned for a toggle key that isn't a known city or
+// city:HIGH|LOW
+var ErrInvalidMarket = errors.New("invalid market")
+
+// MarketToggles holds runtime enable/disable switches for cities and
+// city market types (e.g. "DEN" or "DEN:LOW"), persisted to a JSON file
+type MarketToggles struct {
+	mu       sync.RWMutex
+	path     string
+	disabled map[string]bool
+}
+
+// NewMarketToggles loads toggles from path (if present) and disables the
+// keys disabled in configuration on top: a key in initiallyDisabled starts
+// disabled even if it was enabled at runtime before the restart
+func NewMarketToggles(path string, initiallyDisabled []string) (*MarketToggles, error) {
+	t := &MarketToggles{
+		path:     path,
+		disabled: make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read toggles: %w", err)
+	}
+	if err == nil {
+		var persisted map[string]bool
+		if err := json.Unmarshal(data, &persisted); err != nil {
+			return nil, fmt.Errorf("parse toggles: %w", err)
+		}
+		for key, disabled := range persisted {
+			k, err := normalizeToggleKey(key)
+			if err != nil {
+				continue
+			}
+			t.disabled[k] = disabled
+		}
+	}
+
+	for _, key := range initiallyDisabled {
+		k, err := normalizeToggleKey(key)
+		if err != nil {
+			return nil, err
+		}
+		t.disabled[k] = true
+	}
+	return t, nil
+}
+
+// IsEnabled returns true if trading is enabled for the city and market type
+func (t *MarketToggles) IsEnabled(city, marketType string) bool {
+	if t == nil {
+		return true
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	city = strings.ToUpper(city)
+	if t.disabled[city] {
+		return false
+	}
+	return !t.disabled[city+":"+strings.ToUpper(marketType)]
+}
+
+// Set enables or disables a key and persists the new state. The toggle
+// only changes once it is saved: an invalid key returns ErrInvalidMarket,
+// and a failed save leaves the toggle as it was
+func (t *MarketToggles) Set(key string, enabled bool) error {
+	k, err := normalizeToggleKey(key)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	snapshot := make(map[string]bool, len(t.disabled)+1)
+	for key, disabled := range t.disabled {
+		snapshot[key] = disabled
+	}
+	snapshot[k] = !enabled
+	if err := t.save(snapshot); err != nil {
+		return fmt.Errorf("save toggles: %w", err)
+	}
+	t.disabled = snapshot
+	return nil
+}
+
+// Disabled returns the sorted list of disabled keys
+func (t *MarketToggles) Disabled() []string {
+	if t == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	keys := make([]string, 0, len(t.disabled))
+	for key, disabled := range t.disabled {
+		if disabled {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (t *MarketToggles) save(snapshot map[string]bool) error {
+	if t.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return err
+	}
+	tmp := t.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, t.path)
+}
+
+// normalizeToggleKey validates keys of the form CITY or CITY:HIGH|LOW
+func normalizeToggleKey(key string) (string, error) {
+	key = strings.ToUpper(strings.TrimSpace(key))
+	city, marketType, hasType := strings.Cut(key, ":")
+
+	if !knownCity(city) {
+		return "", fmt.Errorf("%w: unknown city %q", ErrInvalidMarket, city)
+	}
+	if hasType && marketType != MarketHigh && marketType != MarketLow {
+		return "", fmt.Errorf("%w: unknown market type %q (want HIGH or LOW)", ErrInvalidMarket, marketType)
+	}
+	return key, nil
+}
@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMarketToggles_Set(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "toggles.json")
+	toggles, err := NewMarketToggles(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key      string
+		enabled  bool
+		wantErr  bool
+		disabled []string
+	}{
+		{"den:low", false, false, []string{"DEN:LOW"}},
+		{"MIA", false, false, []string{"DEN:LOW", "MIA"}},
+		{"XYZ", false, true, []string{"DEN:LOW", "MIA"}},
+		{"DEN:MID", false, true, []string{"DEN:LOW", "MIA"}},
+		{"MIA", true, false, []string{"DEN:LOW"}},
+	}
+	for _, tt := range tests {
+		err := toggles.Set(tt.key, tt.enabled)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("Set(%q, %v) error = %v, want error %v", tt.key, tt.enabled, err, tt.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidMarket) {
+			t.Errorf("Set(%q, %v) error = %v, want ErrInvalidMarket", tt.key, tt.enabled, err)
+		}
+		if got := toggles.Disabled(); !reflect.DeepEqual(got, tt.disabled) {
+			t.Errorf("after Set(%q, %v): disabled = %v, want %v", tt.key, tt.enabled, got, tt.disabled)
+		}
+	}
+
+	if toggles.IsEnabled("DEN", MarketLow) || !toggles.IsEnabled("DEN", MarketHigh) {
+		t.Error("want DEN:LOW off and DEN:HIGH on")
+	}
+
+	reloaded, err := NewMarketToggles(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Disabled(); !reflect.DeepEqual(got, []string{"DEN:LOW"}) {
+		t.Errorf("reloaded disabled = %v, want [DEN:LOW]", got)
+	}
+}
+
+func TestMarketToggles_SetSaveFails(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	toggles, err := NewMarketToggles(filepath.Join(dir, "toggles.json"), []string{"MIA"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A file where the data directory should be makes every save fail
+	if err := os.WriteFile(dir, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		key     string
+		enabled bool
+	}{{"DEN", false}, {"MIA", true}} {
+		err := toggles.Set(tt.key, tt.enabled)
+		if err == nil || errors.Is(err, ErrInvalidMarket) {
+			t.Errorf("Set(%q, %v) error = %v, want a save error", tt.key, tt.enabled, err)
+		}
+		if got := toggles.Disabled(); !reflect.DeepEqual(got, []string{"MIA"}) {
+			t.Errorf("after failed Set(%q, %v): disabled = %v, want [MIA] unchanged", tt.key, tt.enabled, got)
+		}
+	}
+}
+
+func TestNewMarketToggles(t *testing.T) {
+	tests := []struct {
+		name      string
+		persisted string // Contents of toggles.json ("" = no file)
+		config    []string
+		disabled  []string
+		wantErr   bool
+	}{
+		{"config only", "", []string{"den:low", "MIA"}, []string{"DEN:LOW", "MIA"}, false},
+		{"file only", `{"DEN": true, "MIA": false}`, nil, []string{"DEN"}, false},
+		{"config disables on top of the file", `{"MIA": false, "DEN": true}`, []string{"MIA"}, []string{"DEN", "MIA"}, false},
+		{"unknown keys in the file skipped", `{"XYZ": true, "DEN:LOW": true}`, nil, []string{"DEN:LOW"}, false},
+		{"unknown key in config", "", []string{"XYZ"}, nil, true},
+		{"corrupt file", `{"DEN":`, nil, nil, true},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "toggles.json")
+		if tt.persisted != "" {
+			if err := os.WriteFile(path, []byte(tt.persisted), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		toggles, err := NewMarketToggles(path, tt.config)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want an error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := toggles.Disabled(); !reflect.DeepEqual(got, tt.disabled) {
+			t.Errorf("%s: disabled = %v, want %v", tt.name, got, tt.disabled)
+		}
+	}
+}
@@ -3,15 +3,16 @@ package feeds
 import (
 	"context"
 	"fmt"
-	"io"
-	"log"
 	"math"
-	"net/http"
-	"strings"
 	"sync"
 	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/logging"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
+var weatherLog = logging.For(logging.Weather)
+
 // METARStation represents a weather station
 type METARStation struct {
 	Code     string
@@ -25,13 +26,15 @@ type METARData struct {
 	MaxTemp    int       // Running max temperature (°F)
 	LastTemp   int       // Last observed temperature (°F)
 	Updated    time.Time
+	Observed   time.Time // Time of the last observation
+	Stale      bool      // Last observation is too old to trust
 	Readings   int       // Number of readings today
 }
 
 // METARFeed provides temperature data from METAR observations
 type METARFeed struct {
-	httpClient *http.Client
-	stations   []METARStation
+	provider weather.Provider
+	stations []METARStation
 
 	mu   sync.RWMutex
 	data map[string]*METARData // Station code -> data
@@ -43,7 +46,7 @@ type METARFeed struct {
 // NewMETARFeed creates a new METAR feed
 func NewMETARFeed(stations []METARStation, pollInterval time.Duration) *METARFeed {
 	return &METARFeed{
-		httpClient:   &http.Client{Timeout: 15 * time.Second},
+		provider:     weather.ASOS,
 		stations:     stations,
 		data:         make(map[string]*METARData),
 		pollInterval: pollInterval,
@@ -53,8 +56,7 @@ func NewMETARFeed(stations []METARStation, pollInterval time.Duration) *METARFee
 
 // Start begins polling for METAR data
 func (f *METARFeed) Start(ctx context.Context) {
-	log.Printf("[METAR] Starting feed with %d stations, poll interval %v",
-		len(f.stations), f.pollInterval)
+	weatherLog.Info("Starting METAR feed", "stations", len(f.stations), "poll", f.pollInterval)
 
 	// Initial fetch
 	f.fetchAll()
@@ -98,76 +100,59 @@ func (f *METARFeed) GetMaxTemp(stationCode string) int {
 func (f *METARFeed) fetchAll() {
 	for _, station := range f.stations {
 		if err := f.fetchStation(station); err != nil {
-			log.Printf("[METAR] Error fetching %s: %v", station.Code, err)
+			weatherLog.Error("Failed to fetch METAR", "station", station.Code, "err", err)
 		}
 	}
 }
 
 func (f *METARFeed) fetchStation(station METARStation) error {
-	loc, err := time.LoadLocation(station.Timezone)
-	if err != nil {
-		return err
-	}
-
-	now := time.Now().In(loc)
-
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=%s&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=%s&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		station.Code,
-		now.Year(), int(now.Month()), now.Day(),
-		now.Year(), int(now.Month()), now.Day()+1,
-		station.Timezone,
-	)
-
-	resp, err := f.httpClient.Get(url)
-	if err != nil {
-		return err
+	ws := weather.StationByMETAR("K" + station.Code)
+	if ws == nil {
+		return fmt.Errorf("no weather station %s", station.Code)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	data, err := weather.DailyMax(context.Background(), f.provider, ws, time.Now())
 	if err != nil {
 		return err
 	}
 
-	lines := strings.Split(string(body), "\n")
-	maxTemp := -999.0
+	maxTemp := data.MaxTemp
 	lastTemp := -999.0
 	readings := 0
+	var lastTime time.Time
 
-	for _, line := range lines {
-		if strings.HasPrefix(line, station.Code+",") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 && parts[2] != "M" {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > -100 && temp < 150 {
-					lastTemp = temp
-					readings++
-					if temp > maxTemp {
-						maxTemp = temp
-					}
-				}
-			}
+	for _, o := range data.Observations {
+		if o.Temp > -100 && o.Temp < 150 {
+			lastTemp = o.Temp
+			lastTime = o.Time
+			readings++
 		}
 	}
 
-	if maxTemp == -999.0 {
+	if readings == 0 {
 		return fmt.Errorf("no valid readings")
 	}
 
+	stale := false
+	if err := weather.CheckObservationTime(lastTime, time.Now()); err != nil {
+		weatherLog.Warn("Stale METAR", "station", station.Code, "err", err)
+		stale = true
+	}
+
 	f.mu.Lock()
 	f.data[station.Code] = &METARData{
 		Station:  station.Code,
 		MaxTemp:  int(math.Round(maxTemp)),
 		LastTemp: int(math.Round(lastTemp)),
 		Updated:  time.Now(),
+		Observed: lastTime,
+		Stale:    stale,
 		Readings: readings,
 	}
 	f.mu.Unlock()
 
-	log.Printf("[METAR] %s: Max=%d°F, Last=%d°F, Readings=%d",
-		station.Code, int(math.Round(maxTemp)), int(math.Round(lastTemp)), readings)
+	weatherLog.Info("METAR", "station", station.Code, "max_f", int(math.Round(maxTemp)),
+		"last_f", int(math.Round(lastTemp)), "readings", readings)
 
 	return nil
 }
@@ -4,22 +4,14 @@ import (
 	"context"
 	"crypto/rsa"
 	"encoding/json"
-	"log"
 	"sync"
 	"time"
 
+	"github.com/brendanplayford/kalshi-go/internal/logging"
 	"github.com/brendanplayford/kalshi-go/pkg/ws"
 )
 
-// Orderbook represents an orderbook for a market
-type Orderbook struct {
-	Ticker   string
-	YesBid   int // Best YES bid price (cents)
-	YesAsk   int // Best YES ask price (cents)
-	NoBid    int // Best NO bid price (cents)
-	NoAsk    int // Best NO ask price (cents)
-	Updated  time.Time
-}
+var marketLog = logging.For(logging.Market)
 
 // TickerData represents ticker data for a market
 type TickerData struct {
@@ -58,18 +50,18 @@ func NewKalshiFeed(apiKey string, privateKey *rsa.PrivateKey) *KalshiFeed {
 // Connect establishes the WebSocket connection
 func (f *KalshiFeed) Connect(ctx context.Context) error {
 	f.client = ws.New(
-		ws.WithAPIKeyOption(f.apiKey, f.privKey),
+		ws.WithAPIKey(f.apiKey, f.privKey),
 		ws.WithCallbacks(
 			func() {
-				log.Println("[WSFeed] Connected")
+				marketLog.Info("WebSocket connected")
 				f.connected = true
 			},
 			func(err error) {
-				log.Printf("[WSFeed] Disconnected: %v", err)
+				marketLog.Warn("WebSocket disconnected", "err", err)
 				f.connected = false
 			},
 			func(err error) {
-				log.Printf("[WSFeed] Error: %v", err)
+				marketLog.Error("WebSocket error", "err", err)
 			},
 		),
 	)
@@ -82,7 +74,7 @@ func (f *KalshiFeed) Connect(ctx context.Context) error {
 		return err
 	}
 
-	log.Println("[WSFeed] Connected to Kalshi WebSocket")
+	marketLog.Info("Connected to Kalshi WebSocket")
 
 	// Start reconnection monitor
 	go f.monitorConnection(ctx)
@@ -105,11 +97,16 @@ func (f *KalshiFeed) Subscribe(ctx context.Context, ticker string) error {
 		return err
 	}
 
+	// And maintain its orderbook for real spread and depth
+	if _, err := f.client.SubscribeOrderbooks(ctx, ticker); err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	f.subscribed[ticker] = sid
 	f.mu.Unlock()
 
-	log.Printf("[WSFeed] Subscribed to %s (SID: %d)", ticker, sid)
+	marketLog.Info("Subscribed", "ticker", ticker, "sid", sid)
 	return nil
 }
 
@@ -130,7 +127,7 @@ func (f *KalshiFeed) Unsubscribe(ctx context.Context, ticker string) error {
 		return err
 	}
 
-	log.Printf("[WSFeed] Unsubscribed from %s", ticker)
+	marketLog.Info("Unsubscribed", "ticker", ticker)
 	return nil
 }
 
@@ -150,6 +147,15 @@ func (f *KalshiFeed) GetBestYesBid(ticker string) int {
 	return data.YesBid
 }
 
+// GetOrderbook returns the live orderbook for a market; ok is false until its
+// snapshot arrives and while it resyncs after a missed update
+func (f *KalshiFeed) GetOrderbook(ticker string) (ws.Orderbook, bool) {
+	if f.client == nil {
+		return ws.Orderbook{}, false
+	}
+	return f.client.Orderbooks().Get(ticker)
+}
+
 // IsConnected returns true if WebSocket is connected
 func (f *KalshiFeed) IsConnected() bool {
 	return f.connected && f.client.IsConnected()
@@ -171,10 +177,10 @@ func (f *KalshiFeed) handleMessage(resp *ws.Response) {
 	case ws.MessageTypeData:
 		f.handleDataMessage(resp)
 	case ws.MessageTypeSubscribed:
-		log.Printf("[WSFeed] Subscription confirmed: SID=%d", resp.SID)
+		marketLog.Debug("Subscription confirmed", "sid", resp.SID)
 	case ws.MessageTypeError:
 		if errMsg, err := ws.ParseErrorMsg(resp.Msg); err == nil {
-			log.Printf("[WSFeed] Error message: %s", errMsg.Msg)
+			marketLog.Error("WebSocket error message", "msg", errMsg.Msg)
 		}
 	}
 }
@@ -218,8 +224,8 @@ func (f *KalshiFeed) handleDataMessage(resp *ws.Response) {
 	}
 	f.mu.Unlock()
 
-	log.Printf("[WSFeed] Ticker update: %s YesBid=%d¢ YesAsk=%d¢",
-		tickerUpdate.MarketTicker, tickerUpdate.YesBid, tickerUpdate.YesAsk)
+	marketLog.Debug("Ticker update", "ticker", tickerUpdate.MarketTicker,
+		"yes_bid", tickerUpdate.YesBid, "yes_ask", tickerUpdate.YesAsk)
 }
 
 func (f *KalshiFeed) monitorConnection(ctx context.Context) {
@@ -234,9 +240,9 @@ func (f *KalshiFeed) monitorConnection(ctx context.Context) {
 			return
 		case <-ticker.C:
 			if !f.IsConnected() {
-				log.Println("[WSFeed] Connection lost, attempting reconnect...")
+				marketLog.Warn("Connection lost, attempting reconnect")
 				if err := f.Connect(ctx); err != nil {
-					log.Printf("[WSFeed] Reconnect failed: %v", err)
+					marketLog.Error("Reconnect failed", "err", err)
 				} else {
 					// Resubscribe to all markets
 					f.mu.RLock()
@@ -248,7 +254,7 @@ func (f *KalshiFeed) monitorConnection(ctx context.Context) {
 
 					for _, t := range tickers {
 						if err := f.Subscribe(ctx, t); err != nil {
-							log.Printf("[WSFeed] Resubscribe failed for %s: %v", t, err)
+							marketLog.Error("Resubscribe failed", "ticker", t, "err", err)
 						}
 					}
 				}
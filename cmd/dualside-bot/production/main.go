@@ -3,29 +3,81 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"math"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/brendanplayford/kalshi-go/cmd/dualside-bot/production/engine"
 	"github.com/brendanplayford/kalshi-go/internal/config"
+	"github.com/brendanplayford/kalshi-go/internal/crash"
+	"github.com/brendanplayford/kalshi-go/internal/instancelock"
+	"github.com/brendanplayford/kalshi-go/internal/logging"
+	"github.com/brendanplayford/kalshi-go/internal/service"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/metrics"
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/notify"
+	"github.com/brendanplayford/kalshi-go/pkg/paper"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/risk"
+	"github.com/brendanplayford/kalshi-go/pkg/sizing"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/dualside"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/fade"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy/hedge"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
 var (
-	dryRun bool
+	dryRun  bool
+	runOpts service.Options
 )
 
+// readyRetry is how often readiness is probed until the bot is ready
+const readyRetry = 10 * time.Second
+
 func init() {
 	flag.BoolVar(&dryRun, "dry-run", false, "Simulate trades without executing")
+	runOpts.RegisterFlags(flag.CommandLine)
 }
 
 func main() {
 	flag.Parse()
+	mode, err := runOpts.Mode()
+	if err != nil {
+		log.Fatalf("Invalid run mode: %v", err)
+	}
+
+	// Load production bot configuration
+	cfg, err := LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	// A daemon's logs are read by machines, unless LOG_FORMAT says otherwise
+	if mode == service.Daemon && os.Getenv("LOG_FORMAT") == "" {
+		cfg.LogFormat = logging.FormatJSON
+	}
+
+	// Structured logs: the console view for people, JSON for analysis
+	closeLog, err := logging.Setup(logging.Config{Level: cfg.LogLevel, Format: cfg.LogFormat, File: cfg.LogFile})
+	if err != nil {
+		log.Fatalf("Invalid logging config: %v", err)
+	}
+	defer closeLog()
 
 	printBanner()
 
@@ -38,12 +90,6 @@ func main() {
 		log.Fatalf("Invalid Kalshi config: %v", err)
 	}
 
-	// Load production bot configuration
-	cfg, err := LoadConfig()
-	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
-	}
-
 	log.Printf("[Main] Configuration: %s", cfg)
 
 	// Create data directory
@@ -51,11 +97,53 @@ func main() {
 		log.Fatalf("Failed to create data directory: %v", err)
 	}
 
-	// Initialize executor with parsed private key
-	executor, err := engine.NewExecutor(kalshiCfg.APIKey, kalshiCfg.PrivateKey, dryRun)
+	// Refuse to run a second copy against the same account
+	instance, err := instancelock.Acquire(cfg.DataDir, kalshiCfg.APIKey, "dualside")
+	switch {
+	case errors.Is(err, instancelock.ErrUnsupported):
+		log.Printf("[Main] ⚠️  %v; not guarding against a second copy", err)
+	case err != nil:
+		log.Fatalf("Another instance is running: %v", err)
+	default:
+		defer instance.Release()
+		log.Printf("[Main] Holding instance lock %s", instance.Path())
+	}
+
+	// Initialize executor with parsed private key; the client refuses any
+	// order outside the failsafe bounds, whatever sizing asks for
+	bounds := rest.WithOrderBounds(rest.OrderBounds{
+		MaxContracts: cfg.OrderMaxContracts,
+		MaxPrice:     cfg.OrderMaxPrice,
+		MaxNotional:  int(math.Round(cfg.OrderMaxCost * 100)),
+	})
+	executor, err := engine.NewExecutor(kalshiCfg.APIKey, kalshiCfg.PrivateKey, dryRun, bounds)
 	if err != nil {
 		log.Fatalf("Failed to initialize executor: %v", err)
 	}
+	if cfg.FallbackAPIURL != "" {
+		fallback := rest.New(kalshiCfg.APIKey, kalshiCfg.PrivateKey, rest.WithBaseURL(cfg.FallbackAPIURL), bounds)
+		executor.SetFallback(fallback, cfg.FailoverAfter, time.Duration(cfg.FailoverCooldown)*time.Minute)
+		log.Printf("[Main] Orders fail over to %s after %d failed attempts", cfg.FallbackAPIURL, cfg.FailoverAfter)
+	}
+
+	// Follow renamed or newly listed temperature series; the built-in
+	// tickers stand if the exchange can't be asked
+	if cfg.DiscoverSeries {
+		cat, err := market.Discover(rest.New(kalshiCfg.APIKey, kalshiCfg.PrivateKey))
+		if cat == nil {
+			log.Printf("[Main] ⚠️  Series discovery failed, keeping the built-in tickers: %v", err)
+		} else {
+			if err != nil {
+				log.Printf("[Main] ⚠️  Series discovery incomplete: %v", err)
+			}
+			for _, change := range engine.ApplySeries(cat) {
+				log.Printf("[Main] Series moved: %s", change)
+			}
+			for _, s := range cat.Unmatched() {
+				log.Printf("[Main] Series %s (%s) has no station, not traded", s.Ticker, s.Title)
+			}
+		}
+	}
 
 	// Get initial balance
 	balance, err := executor.GetBalance()
@@ -68,7 +156,109 @@ func main() {
 		log.Println("[Main] ⚠️  DRY RUN MODE - No real trades will be executed")
 	}
 
+	// Estimated capacity of the strategy, capping order sizes
+	var capacity backtest.Capacity
+	if cfg.CapacityFile != "" {
+		b, err := os.ReadFile(cfg.CapacityFile)
+		if err == nil {
+			err = json.Unmarshal(b, &capacity)
+		}
+		if err != nil {
+			log.Fatalf("Failed to load capacity: %v", err)
+		}
+		log.Printf("[Main] Capping orders at %.0f%% of %s's capacity of %d contracts (%s)",
+			cfg.CapacityFraction*100, capacity.Strategy, capacity.Contracts, cfg.CapacityFile)
+	}
+
+	// What the strategy may do in each phase of a market day
+	phases, err := strategy.ParsePhaseBehaviors(cfg.PhaseRules)
+	if err != nil {
+		log.Fatalf("Invalid PHASE_RULES: %v", err)
+	}
+
 	// Create trading engine
+	// Fade-the-favorite on the events dualside passes on
+	var fadeConfig *fade.Config
+	if cfg.TradeFade {
+		fc := fade.DefaultConfig()
+		fc.Bet = cfg.FadeBet
+		fc.Phases = phases
+		fadeConfig = &fc
+		log.Printf("Fade-the-favorite: on, $%.0f per runner-up", fc.Bet)
+	}
+
+	var timing *strategy.ImbalanceTiming
+	if cfg.ImbalanceTiming {
+		timing = &strategy.ImbalanceTiming{
+			MinPressure: cfg.ImbalanceMinPressure,
+			MaxWait:     time.Duration(cfg.ImbalanceMaxWait) * time.Minute,
+		}
+		log.Printf("Book timing: on, entries wait for pressure %+.2f (at most %s)", timing.MinPressure, timing.MaxWait)
+	}
+
+	var campaign *engine.CampaignConfig
+	if cfg.CampaignBudget > 0 {
+		campaign = &engine.CampaignConfig{
+			Campaign: sizing.Campaign{
+				Budget:        cfg.CampaignBudget,
+				MinConfidence: cfg.CampaignMinConfidence,
+				MaxDay:        cfg.CampaignMaxDay,
+			},
+			WeekStart: cfg.CampaignWeekStart,
+			Ahead:     cfg.CampaignAhead,
+		}
+		log.Printf("Campaign: on, $%.0f a week from %s by the model's confidence, nothing below %.0f%%",
+			cfg.CampaignBudget, cfg.CampaignWeekStart, cfg.CampaignMinConfidence*100)
+	}
+
+	var positionCheck *engine.PositionCheck
+	if cfg.PositionCheck {
+		positionCheck = &engine.PositionCheck{Tolerance: cfg.PositionTolerance}
+		log.Printf("Position check: on, pausing a market whose exchange position differs from the trades by more than %d contracts", cfg.PositionTolerance)
+	}
+
+	var hedgeConfig *hedge.Config
+	if cfg.Hedge {
+		hc := hedge.DefaultConfig()
+		hc.Budget = cfg.HedgeBudget
+		hc.NoShare = cfg.HedgeNoShare
+		hc.MaxLossProb = cfg.HedgeMaxLossProb
+		hedgeConfig = &hc
+		log.Printf("Hedging: on, $%.0f sets with up to %.0f%% on NO legs, losing at most %.0f%% of the time",
+			hc.Budget, hc.NoShare*100, hc.MaxLossProb*100)
+	}
+
+	// A/B test of a variant of the strategy on randomly assigned city-days
+	var abConfig *engine.ABConfig
+	if cfg.ABTest != "" {
+		unit, err := strategy.ParseABUnit(cfg.ABTest)
+		if err != nil {
+			log.Fatalf("Invalid AB_TEST: %v", err)
+		}
+		variant, err := parseVariant(cfg.ABVariant, dualside.Config{
+			BetYes:             cfg.BetYes,
+			BetNo:              cfg.BetNo,
+			MinYesPrice:        cfg.MinYesPrice,
+			MaxYesPrice:        cfg.MaxYesPrice,
+			MinNoPrice:         cfg.MinNoPrice,
+			MaxNoPrice:         cfg.MaxNoPrice,
+			MaxNoTrades:        cfg.MaxNoTrades,
+			MinSignalAgreement: cfg.MinSignalAgreement,
+		})
+		if err != nil {
+			log.Fatalf("Invalid AB_VARIANT: %v", err)
+		}
+		cities := make([]string, 0, len(engine.DefaultStations))
+		for _, s := range engine.DefaultStations {
+			cities = append(cities, s.Code)
+		}
+		abConfig = &engine.ABConfig{
+			Test:    strategy.ABTest{Unit: unit, Seed: cfg.ABSeed, Cities: cities},
+			Variant: variant,
+		}
+		log.Printf("A/B test: on, by %s with seed %d, B trading %q", unit, cfg.ABSeed, cfg.ABVariant)
+	}
+
 	tradingEngine := engine.NewEngine(engine.TradingConfig{
 		BetYes:           cfg.BetYes,
 		BetNo:            cfg.BetNo,
@@ -79,30 +269,432 @@ func main() {
 		MaxNoTrades:      cfg.MaxNoTrades,
 		TradingStartHour: cfg.TradingStartHour,
 		TradingEndHour:   cfg.TradingEndHour,
+		WinRate:          cfg.ExpectedWinRate,
+		EVGate:           cfg.EVGate,
+		TradeLow:         cfg.TradeLow,
+
+		TakeProfitPrice:    cfg.TakeProfitPrice,
+		TakeProfitFraction: cfg.TakeProfitFraction,
+		TakeProfitMinHours: cfg.TakeProfitMinHours,
+		Exits: strategy.ExitRules{
+			StopLoss:    cfg.StopLossPrice,
+			MinProb:     cfg.ExitMinProb,
+			FlattenHour: cfg.FlattenHour,
+		},
+
+		Expiry: strategy.ExpiryGuard{
+			NoEntry:  time.Duration(cfg.NoEntryBeforeClose) * time.Minute,
+			ThinBook: time.Duration(cfg.ThinBookWarning) * time.Minute,
+		},
+
+		MinSignalAgreement: cfg.MinSignalAgreement,
+		Phases:             phases,
+		CashReserve:        cfg.CashReserve,
+		Capacity:           capacity,
+		CapacityFraction:   cfg.CapacityFraction,
+		Fade:               fadeConfig,
+		Imbalance:          timing,
+		BookRecordFile:     cfg.BookRecordFile,
+		Hedge:              hedgeConfig,
+		Campaign:           campaign,
+		Positions:          positionCheck,
+		AB:                 abConfig,
 	}, executor)
 
+	// Fee schedule for the EV gate (defaults to 7% of winnings)
+	feeSchedule := fees.DefaultSchedule()
+	if cfg.FeeScheduleFile != "" {
+		feeSchedule, err = fees.LoadSchedule(cfg.FeeScheduleFile)
+		if err != nil {
+			log.Fatalf("Failed to load fee schedule: %v", err)
+		}
+		log.Printf("[Main] Loaded fee schedule from %s", cfg.FeeScheduleFile)
+	}
+	tradingEngine.SetFeeSchedule(feeSchedule)
+
+	// Dry runs trade a paper copy of the account, filled as the market's
+	// trades reach the orders' place in the queue. It carries over restarts
+	// and days until its file is removed
+	var sim *paper.Simulator
+	if dryRun {
+		paperPath := filepath.Join(cfg.DataDir, "paper.json")
+		sim, err = paper.Load(paperPath, feeSchedule)
+		switch {
+		case err == nil:
+			sum := sim.Summary()
+			log.Printf("[Main] Paper account resumed: $%.2f from $%.2f, %d markets held (see /paper)",
+				float64(sum.Balance)/100, float64(sum.Start)/100, len(sim.Held()))
+		case errors.Is(err, os.ErrNotExist):
+			start := balance
+			if cfg.PaperBalance > 0 {
+				start = cfg.PaperBalance
+			}
+			sim = paper.New(feeSchedule, int(math.Round(start*100)))
+			log.Printf("[Main] Paper trading from $%.2f (see /paper)", start)
+		default:
+			log.Fatalf("Failed to load paper account: %v", err)
+		}
+		executor.SetPaper(sim, paperPath)
+	}
+
+	// Synthetic weather for demos; never trade real money on it
+	if cfg.WeatherScenario != "" {
+		if !dryRun {
+			log.Fatalf("WEATHER_SCENARIO needs --dry-run")
+		}
+		scenario, err := weather.ParseScenario(cfg.WeatherScenario)
+		if err != nil {
+			log.Fatalf("Invalid WEATHER_SCENARIO: %v", err)
+		}
+		tradingEngine.SetObservations(weather.NewSynthetic(scenario))
+		log.Printf("[Main] ⚠️  Synthetic weather (%s) in place of the live feed", cfg.WeatherScenario)
+	}
+
+	// Size positions at the model's probability instead of the fixed bets
+	if cfg.Sizing != "" {
+		method, err := sizing.ParseMethod(cfg.Sizing)
+		if err != nil {
+			log.Fatalf("Invalid SIZING: %v", err)
+		}
+		tradingEngine.SetSizer(sizing.New(method, sizing.Limits{
+			MaxTrade: cfg.MaxTradeRisk,
+			MaxDay:   cfg.MaxDailyRisk,
+		}))
+		log.Printf("[Main] Sizing positions by %s at the model's probability", method)
+	}
+
+	// Alerts to Slack, Discord and email, deduplicated and rate-limited so a
+	// flapping signal doesn't spam the channels
+	notifier := notify.NewThrottle(notify.Multi(
+		notify.NewSlack(cfg.SlackWebhookURL),
+		notify.NewDiscord(cfg.DiscordWebhookURL),
+		notify.NewEmail(cfg.SMTPAddr, cfg.EmailFrom, cfg.EmailTo, cfg.SMTPUser, cfg.SMTPPassword),
+	), notify.ThrottleConfig{
+		Dedup: time.Duration(cfg.NotifyDedup) * time.Minute,
+		Burst: cfg.NotifyMaxPerHour,
+		Per:   time.Hour,
+	})
+	alert := func(msg notify.Message) {
+		if err := notifier.Notify(context.Background(), msg); err != nil && !errors.Is(err, notify.ErrSuppressed) {
+			log.Printf("[Notify] Failed to send %s alert: %v", msg.Kind, err)
+		}
+	}
+
+	// Switch to shadow mode if live P&L deteriorates vs the backtest
+	guard := strategy.NewPerformanceGuard("dualside", strategy.Expectation{
+		MeanDailyPnL:   cfg.ExpectedDailyPnL,
+		StdDevDailyPnL: cfg.ExpectedDailyStdDev,
+	}, strategy.GuardConfig{
+		Slack:     strategy.DefaultGuardConfig().Slack,
+		Threshold: cfg.GuardThreshold,
+		MinDays:   cfg.GuardMinDays,
+	})
+	guard.OnDisable(func(status strategy.GuardStatus) {
+		log.Printf("[Guard] ⛔ %s switched to SHADOW mode: %s", status.Strategy, status.Reason)
+		alert(notify.Error("PerformanceGuard", fmt.Sprintf("%s switched to shadow mode: %s", status.Strategy, status.Reason)))
+	})
+	if err := guard.Restore(filepath.Join(cfg.DataDir, "guard.json")); err != nil {
+		log.Fatalf("Failed to load performance guard: %v", err)
+	}
+	if !guard.IsLive() {
+		log.Printf("[Guard] ⛔ Still in SHADOW mode since %s: %s (POST /control/guard to resume)",
+			guard.Status().DisabledAt.Format(time.RFC3339), guard.Status().Reason)
+	}
+	tradingEngine.SetGuard(guard)
+
+	// Runtime per-city toggles, persisted across restarts
+	toggles, err := engine.NewMarketToggles(filepath.Join(cfg.DataDir, "toggles.json"), cfg.DisabledMarkets)
+	if err != nil {
+		log.Fatalf("Failed to load market toggles: %v", err)
+	}
+	if disabled := toggles.Disabled(); len(disabled) > 0 {
+		log.Printf("[Main] Disabled markets: %v", disabled)
+	}
+	tradingEngine.SetToggles(toggles)
+	if cfg.ControlToken == "" {
+		log.Printf("[Control] CONTROL_TOKEN not set: control requests that change state will be refused")
+	}
+
+	// Hard limits: a breach cancels the resting orders and halts trading
+	// until reset from /control/halt. The usage is this bot's own; only the
+	// kill switch file can be shared with the account's other bots
+	killSwitch := cfg.KillSwitchFile
+	if killSwitch == "" {
+		killSwitch = filepath.Join(cfg.DataDir, "KILL")
+	}
+	limits, err := risk.New(risk.Config{
+		Limits: risk.Limits{
+			MaxDailyLoss:    cfg.MaxDailyLoss,
+			MaxTradesPerDay: cfg.MaxTradesPerDay,
+		},
+		StatePath:  filepath.Join(cfg.DataDir, "limits.json"),
+		KillSwitch: killSwitch,
+	})
+	if err != nil {
+		log.Fatalf("Failed to load risk limits: %v", err)
+	}
+	limits.OnHalt(func(reason string) {
+		log.Printf("[Risk] ⛔ Trading halted: %s", reason)
+		canceled, _ := cancelResting(executor)
+		alert(notify.Error("RiskHalt", fmt.Sprintf("Trading halted: %s (%d resting orders canceled)", reason, canceled)))
+	})
+	if status := limits.Status(); status.Halted {
+		log.Printf("[Main] ⛔ Trading halted since %s: %s", status.Since.Format(time.RFC3339), status.Reason)
+	}
+	tradingEngine.SetLimits(limits)
+
+	// Trade frequency throttle, persisted so a restart doesn't reset it
+	risk, err := engine.NewRiskManager(filepath.Join(cfg.DataDir, "risk.json"), engine.RiskLimits{
+		MaxPositionsPerDay:  cfg.MaxPositionsPerDay,
+		MaxPositionsPerWeek: cfg.MaxPositionsPerWeek,
+		MaxRiskPerWeek:      cfg.MaxRiskPerWeek,
+		MaxEventFraction:    cfg.MaxEventFraction,
+		MaxCityFraction:     cfg.MaxCityFraction,
+	})
+	if err != nil {
+		log.Fatalf("Failed to load risk log: %v", err)
+	}
+	tradingEngine.SetRiskManager(risk)
+
+	// Operator overrides of model inputs, expiring at the end of each city's day
+	overrides, err := engine.NewOverrides(filepath.Join(cfg.DataDir, "overrides.json"))
+	if err != nil {
+		log.Fatalf("Failed to load overrides: %v", err)
+	}
+	for _, o := range overrides.Active(time.Now()) {
+		log.Printf("[Main] Override %s %s: %s", o.City, o.Date, o)
+	}
+	tradingEngine.SetOverrides(overrides)
+
+	// Predictions posted by external systems, voting alongside the built-in signals
+	external := strategy.NewExternalSignals(cfg.ExternalSignals, strategy.DefaultHealthConfig())
+	for _, s := range external.Status(time.Now()) {
+		log.Printf("[Main] External signal %s (weight %.2f)", s.Source, s.Weight)
+	}
+	tradingEngine.SetExternalSignals(external)
+
+	// Orders, balance, exposure, METAR readings and edges for Prometheus
+	registry := metrics.NewRegistry()
+	tradingEngine.SetMetrics(metrics.NewTrading(registry))
+
+	// Operator notes, attached to day reports
+	journal, err := engine.NewJournal(filepath.Join(cfg.DataDir, "journal.json"))
+	if err != nil {
+		log.Fatalf("Failed to load journal: %v", err)
+	}
+	tradingEngine.SetJournal(journal)
+	if abConfig != nil {
+		abLog, err := engine.NewABLog(filepath.Join(cfg.DataDir, "ab.json"))
+		if err != nil {
+			log.Fatalf("Failed to load A/B log: %v", err)
+		}
+		tradingEngine.SetABLog(abLog)
+	}
+	reportsPath := filepath.Join(cfg.DataDir, "reports.jsonl")
+	tradingEngine.SetReportCallback(func(report engine.DayReport) {
+		if err := engine.AppendReport(reportsPath, report); err != nil {
+			log.Printf("[Main] Failed to save day report: %v", err)
+		}
+		alert(notify.DailySummary(daySummary(report)))
+	})
+
 	// Set up trade callback
 	tradingEngine.SetTradeCallback(func(trade engine.Trade) {
-		log.Printf("[Trade] %s: %s %s %d @ %d¢ = $%.2f",
-			trade.City, trade.Side, trade.Bracket, trade.Quantity, trade.Price, trade.Cost)
-		// TODO: Send notification
+		tradeLog := logging.For(logging.Execution).With("city", trade.City)
+		tradeLog.Info("Trade", "side", trade.Side, "bracket", trade.Bracket,
+			"count", trade.Quantity, "price", trade.Price, "cost", trade.Cost)
+		if trade.Override != "" {
+			tradeLog.Info("Operator override", "override", trade.Override)
+		}
+		if trade.Status != "shadow" {
+			alert(notify.Trade(trade.City, trade.Ticker, trade.Side, trade.Price, trade.Quantity, trade.Cost, trade.OrderID))
+		}
+	})
+
+	// Alert when the running max moves into or out of a held bracket
+	tradingEngine.SetCrossingCallback(func(c engine.Crossing) {
+		logging.For(logging.Weather).Info("Threshold crossed", "city", c.City, "max_f", c.MaxTemp,
+			"bracket", c.Bracket, "entered", c.Entered)
+		dir := "out of "
+		if c.Entered {
+			dir = "into "
+		}
+		msg := notify.Threshold(c.City, "running max", fmt.Sprintf("%d°F", c.MaxTemp), dir+c.Bracket)
+		msg.Fields = append(msg.Fields, notify.Field{Name: "Holding", Value: strings.ToUpper(c.Side)})
+		alert(msg)
+	})
+
+	// Alert on positions held into the thin end-of-day book
+	tradingEngine.SetExpiryCallback(func(w engine.ExpiryWarning) {
+		logging.For(logging.Market).Warn("Holding into thin book", "city", w.City, "ticker", w.Ticker,
+			"side", w.Side, "contracts", w.Contracts, "closes_in", w.Left.Round(time.Minute).String())
+		msg := notify.Threshold(w.Ticker, "time to close", w.Left.Round(time.Minute).String(), "thin book "+(time.Duration(cfg.ThinBookWarning)*time.Minute).String())
+		msg.Fields = append(msg.Fields, notify.Field{Name: "Holding", Value: fmt.Sprintf("%d %s", w.Contracts, strings.ToUpper(w.Side))})
+		alert(msg)
+	})
+
+	// Alert when the exchange's position in a market stops matching the
+	// trades; entries there wait for an acknowledgement
+	tradingEngine.SetDivergenceCallback(func(d engine.Divergence) {
+		alert(notify.Error("PositionMismatch", fmt.Sprintf("%s. Entries in %s are paused until acknowledged at /control/positions", d, d.Ticker)))
 	})
 
 	// Set up error callback
 	tradingEngine.SetErrorCallback(func(err error) {
-		log.Printf("[Error] %v", err)
-		// TODO: Send alert
+		slog.Error("Engine error", "err", err)
+		if errors.Is(err, engine.ErrRiskLimit) {
+			alert(notify.Error("RiskLimit", fmt.Sprintf("New positions blocked: %v", err)))
+			return
+		}
+		alert(notify.Error("Engine", err.Error()))
 	})
 
+	// METAR to CLI calibration learned from settled days, shared with the
+	// other bots through the file
+	calibrationPath := filepath.Join(cfg.DataDir, "calibration.json")
+	calibration, err := model.LoadCalibration(calibrationPath)
+	if err != nil {
+		log.Fatalf("Failed to load calibration: %v", err)
+	}
+	tradingEngine.SetCalibration(calibration)
+
+	// Pick up the positions of the last run, so a restart neither enters
+	// an event twice nor forgets to settle one
+	positionsPath := filepath.Join(cfg.DataDir, "positions.json")
+	if n, err := tradingEngine.LoadPositions(positionsPath); err != nil {
+		log.Fatalf("Failed to load positions: %v", err)
+	} else if n > 0 {
+		log.Printf("[Main] Restored positions in %d events from %s", n, positionsPath)
+	}
+
+	// Once a station's CLI report has posted and its events settled, drop
+	// them from the saved positions, save the calibration and flush the
+	// journal
+	tradingEngine.SetHousekeepingCallback(func(d engine.StationDay) {
+		if err := tradingEngine.SavePositions(positionsPath); err != nil {
+			log.Printf("[Main] %s %s: Failed to save positions: %v", d.Station.Code, d.Date, err)
+		}
+		if err := calibration.Save(calibrationPath); err != nil {
+			log.Printf("[Main] %s %s: Failed to save calibration: %v", d.Station.Code, d.Date, err)
+		}
+		if err := journal.Flush(); err != nil {
+			log.Printf("[Main] %s %s: Failed to save journal: %v", d.Station.Code, d.Date, err)
+		}
+	})
+
+	// A panic saves the positions and journal, cancels the resting orders
+	// (CANCEL_ON_PANIC), alerts with the stack and exits with crash.ExitCode
+	// for the supervisor to restart the bot into the saved state
+	crashReport := filepath.Join(cfg.DataDir, "crash.json")
+	if prev, err := crash.Previous(crashReport); err != nil {
+		log.Printf("[Main] Failed to read crash report: %v", err)
+	} else if prev != nil {
+		log.Printf("[Main] ⚠️  Restarted after a panic in %s at %s: %s",
+			prev.Goroutine, prev.Time.Format(time.RFC3339), prev.Panic)
+		alert(notify.Error("Restart", fmt.Sprintf("Restarted after a panic in %s at %s: %s",
+			prev.Goroutine, prev.Time.Format(time.RFC3339), prev.Panic)))
+	}
+	hooks := crash.Hooks{
+		Flush: []func() error{
+			func() error { return tradingEngine.SavePositions(positionsPath) },
+			journal.Flush,
+		},
+		Alert: func(r crash.Report) {
+			stack := r.Stack
+			if len(stack) > 1500 {
+				stack = stack[:1500] + "\n..."
+			}
+			msg := notify.Error("Panic", fmt.Sprintf("Panic in %s: %s\n```%s```", r.Goroutine, r.Panic, stack))
+			msg.Fields = append(msg.Fields, notify.Field{Name: "Resting orders canceled", Value: fmt.Sprint(r.Canceled)})
+			alert(msg)
+		},
+		Report: crashReport,
+	}
+	if cfg.CancelOnPanic {
+		hooks.Cancel = func() error {
+			_, err := cancelResting(executor)
+			return err
+		}
+	}
+	panics := crash.New(hooks)
+	defer panics.Recover("main")
+
+	// Ready once the account is reconciled and the feeds are healthy; the
+	// engine keeps reporting the feeds on every tick
+	gate := service.NewGate(engine.CheckState, engine.CheckWeather, engine.CheckMarkets)
+	tradingEngine.SetReadiness(gate)
+	probe := func() {
+		if err := tradingEngine.Reconcile(); err != nil {
+			log.Printf("[Main] Failed to reconcile the account: %v", err)
+		}
+		tradingEngine.CheckFeeds(time.Now())
+	}
+
+	// Live while METAR and Kalshi calls keep succeeding; a long-running bot
+	// whose feeds go stale answers 503 on /health to be restarted
+	health := service.NewHealth()
+	staleAfter := max(time.Duration(cfg.HealthStaleAfter)*time.Minute, 2*time.Duration(cfg.PollInterval)*time.Second)
+	health.Feed(engine.HealthMETAR, staleAfter, true)
+	health.Feed(engine.HealthKalshi, staleAfter, true)
+	health.SetInfo("mode", string(mode))
+	tradingEngine.SetHealth(health)
+
+	// The strategy as configured, in plain words, kept with every version
+	// the bot has run so old trades can be read without old code
+	doc := tradingEngine.Describe()
+	for _, line := range strings.Split(strings.TrimSpace(doc.String()), "\n") {
+		if line != "" {
+			log.Printf("[Main] %s", line)
+		}
+	}
+	if isNew, err := engine.SaveStrategyDoc(filepath.Join(cfg.DataDir, "strategy.md"), filepath.Join(cfg.DataDir, "strategies.jsonl"), doc); err != nil {
+		log.Printf("[Main] Failed to save strategy description: %v", err)
+	} else if isNew {
+		log.Printf("[Main] New strategy version %s", doc.Version)
+	}
+
+	if mode == service.Oneshot {
+		if err := runOneshot(tradingEngine, gate, probe, runOpts.ReadyTimeout); err != nil {
+			log.Fatalf("Oneshot run failed: %v", err)
+		}
+		if err := tradingEngine.SavePositions(positionsPath); err != nil {
+			log.Fatalf("Failed to save positions: %v", err)
+		}
+		if err := journal.Flush(); err != nil {
+			log.Fatalf("Failed to save journal: %v", err)
+		}
+		log.Println("[Main] Oneshot run complete")
+		return
+	}
+
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Start HTTP server for health checks
-	httpServer := startHTTPServer(cfg.HTTPPort, tradingEngine)
+	// Start HTTP server for health checks; /ready answers 503 until ready
+	httpServer := startHTTPServer(cfg.HTTPPort, cfg.ControlToken, tradingEngine, toggles, journal, overrides, external, limits, guard, sim, registry, gate, health)
+
+	// Don't trade until ready; a signal meanwhile stops the wait
+	waitCtx, stopWaiting := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	log.Println("[Main] Waiting until ready...")
+	err = gate.Await(waitCtx, readyRetry, probe)
+	stopWaiting()
+	if err != nil {
+		log.Printf("[Main] Stopped before ready: %v", err)
+		httpServer.Close()
+		return
+	}
+	if mode == service.Daemon {
+		if _, err := service.NotifyReady("trading"); err != nil {
+			log.Printf("[Main] %v", err)
+		}
+	}
 
 	// Start trading engine in goroutine
-	go tradingEngine.Run(ctx, time.Duration(cfg.PollInterval)*time.Second)
+	panics.Go("engine", func() { tradingEngine.Run(ctx, time.Duration(cfg.PollInterval)*time.Second) })
+	if sim != nil {
+		panics.Go("paper", func() { executor.RunPaper(ctx, time.Duration(cfg.PollInterval)*time.Second) })
+	}
 
 	log.Println("[Main] ✅ Bot is running. Press Ctrl+C to stop.")
 
@@ -112,6 +704,9 @@ func main() {
 	<-sigChan
 
 	log.Println("[Main] Shutdown signal received...")
+	if mode == service.Daemon {
+		service.NotifyStopping()
+	}
 
 	// Graceful shutdown
 	cancel()
@@ -124,6 +719,10 @@ func main() {
 		log.Printf("[Main] HTTP server shutdown error: %v", err)
 	}
 
+	if err := tradingEngine.SavePositions(positionsPath); err != nil {
+		log.Printf("[Main] Failed to save positions: %v", err)
+	}
+
 	// Print final stats
 	stats := tradingEngine.GetStats()
 	log.Printf("[Main] Final stats: %d trades, $%.2f daily P&L",
@@ -132,42 +731,371 @@ func main() {
 	log.Println("[Main] Goodbye!")
 }
 
+// runOneshot waits until ready, at most timeout, then runs one decision
+// cycle
+func runOneshot(eng *engine.Engine, gate *service.Gate, probe func(), timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := gate.Await(ctx, readyRetry, probe); err != nil {
+		return err
+	}
+
+	log.Println("[Main] Ready, running one cycle")
+	eng.RunOnce()
+	return nil
+}
+
+// cancelResting cancels the account's resting orders, returning how many
+func cancelResting(executor *engine.Executor) (int, error) {
+	orders, err := executor.RestingOrders()
+	if err != nil {
+		log.Printf("[Risk] Failed to fetch resting orders: %v", err)
+		return 0, err
+	}
+	canceled := 0
+	var errs []error
+	for _, o := range orders {
+		if err := executor.CancelOrder(o.OrderID); err != nil {
+			log.Printf("[Risk] Failed to cancel %s: %v", o.OrderID, err)
+			errs = append(errs, err)
+			continue
+		}
+		canceled++
+	}
+	return canceled, errors.Join(errs...)
+}
+
+// printBanner prints to the console view only, keeping JSON logs clean
 func printBanner() {
-	fmt.Println()
-	fmt.Println("╔══════════════════════════════════════════════════════════════════════════════╗")
-	fmt.Println("║              PRODUCTION DUAL-SIDE TRADING BOT                               ║")
-	fmt.Println("║              Autonomous Temperature Market Trading                          ║")
-	fmt.Println("║              7 Markets • YES + NO Strategy • 95.8% Win Rate                 ║")
-	fmt.Println("╚══════════════════════════════════════════════════════════════════════════════╝")
-	fmt.Println()
+	w := logging.Console()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "╔══════════════════════════════════════════════════════════════════════════════╗")
+	fmt.Fprintln(w, "║              PRODUCTION DUAL-SIDE TRADING BOT                               ║")
+	fmt.Fprintln(w, "║              Autonomous Temperature Market Trading                          ║")
+	fmt.Fprintln(w, "║              7 Markets • YES + NO Strategy • 95.8% Win Rate                 ║")
+	fmt.Fprintln(w, "╚══════════════════════════════════════════════════════════════════════════════╝")
+	fmt.Fprintln(w)
 }
 
-func startHTTPServer(port int, eng *engine.Engine) *http.Server {
+func startHTTPServer(port int, controlToken string, eng *engine.Engine, toggles *engine.MarketToggles, journal *engine.Journal, overrides *engine.Overrides, external *strategy.ExternalSignals, limits *risk.Guard, guard *strategy.PerformanceGuard, sim *paper.Simulator, registry *metrics.Registry, gate *service.Gate, health *service.Health) *http.Server {
 	mux := http.NewServeMux()
 
-	// Health check endpoint
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"status":"ok","timestamp":"%s"}`, time.Now().Format(time.RFC3339))
-	})
+	// Liveness: 503 once a critical feed is stale
+	mux.Handle("/health", health.Handler())
+
+	// Readiness: state reconciled and feeds healthy
+	mux.Handle("/ready", gate.Handler())
+
+	// Prometheus metrics
+	mux.Handle("/metrics", registry.Handler())
 
 	// Stats endpoint
 	mux.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
 		stats := eng.GetStats()
+		disabled, _ := json.Marshal(toggles.Disabled())
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"total_trades":%d,"yes_trades":%d,"no_trades":%d,"daily_pnl":%.2f,"open_positions":%d}`,
+		fmt.Fprintf(w, `{"total_trades":%d,"yes_trades":%d,"no_trades":%d,"daily_pnl":%.2f,"open_positions":%d,"mode":"%s","disabled_markets":%s}`,
 			stats["total_trades"],
 			stats["yes_trades"],
 			stats["no_trades"],
 			stats["daily_pnl"],
-			stats["open_positions"])
+			stats["open_positions"],
+			stats["mode"],
+			disabled)
+	})
+
+	// The strategy as configured, as Markdown (?format=json for the sections)
+	mux.HandleFunc("/strategy", func(w http.ResponseWriter, r *http.Request) {
+		doc := eng.Describe()
+		if r.URL.Query().Get("format") == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(doc)
+			return
+		}
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		fmt.Fprint(w, doc)
+	})
+
+	// Open positions (read-only)
+	mux.HandleFunc("/positions", func(w http.ResponseWriter, r *http.Request) {
+		positions := eng.OpenPositions()
+		if positions == nil {
+			positions = []engine.Trade{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"positions": positions})
+	})
+
+	// Control endpoint: list position divergences, or acknowledge one to
+	// resume entries in its market
+	mux.HandleFunc("/control/positions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var req struct {
+				Ticker string `json:"ticker"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+				return
+			}
+			if err := eng.AcknowledgeDivergence(req.Ticker); err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			log.Printf("[Control] Acknowledged the position divergence in %s, resuming entries", req.Ticker)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"divergences": eng.Divergences()})
+	})
+
+	// Control endpoint: list or change per-city market toggles
+	mux.HandleFunc("/control/markets", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var req struct {
+				Market  string `json:"market"`
+				Enabled bool   `json:"enabled"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+				return
+			}
+			if err := toggles.Set(req.Market, req.Enabled); err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, engine.ErrInvalidMarket) {
+					status = http.StatusBadRequest
+				}
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			log.Printf("[Control] %s enabled=%v", req.Market, req.Enabled)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"disabled": toggles.Disabled()})
+	})
+
+	// Control endpoint: list (?date=YYYY-MM-DD) or add journal notes
+	mux.HandleFunc("/control/notes", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+			notes := journal.Notes(r.URL.Query().Get("date"))
+			if notes == nil {
+				notes = []engine.Note{}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"notes": notes})
+		case http.MethodPost:
+			var req engine.Note
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+				return
+			}
+			note, err := journal.Add(req)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			log.Printf("[Control] Note %d on %s: %s", note.ID, note.Date, note.Text)
+			json.NewEncoder(w).Encode(note)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Control endpoint: list, set or remove operator overrides of model inputs
+	mux.HandleFunc("/control/overrides", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var req engine.Override
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "invalid JSON body: " + err.Error()})
+				return
+			}
+			override, err := overrides.Set(req)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			log.Printf("[Control] Override %s %s: %s", override.City, override.Date, override)
+		case http.MethodDelete:
+			q := r.URL.Query()
+			removed, err := overrides.Remove(q.Get("city"), q.Get("date"), q.Get("input"))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			if !removed {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]string{"error": "no such override"})
+				return
+			}
+			log.Printf("[Control] Override removed for %s", q.Get("city"))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		active := overrides.Active(time.Now())
+		if active == nil {
+			active = []engine.Override{}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"overrides": active})
+	})
+
+	// Control endpoint: post external signal predictions or list sources' health
+	mux.HandleFunc("/control/signals", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var req strategy.ExternalPrediction
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+				return
+			}
+			prediction, err := external.Post(req)
+			if err != nil {
+				status := http.StatusBadRequest
+				if errors.Is(err, strategy.ErrUnknownSource) {
+					status = http.StatusNotFound
+				}
+				w.WriteHeader(status)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+			log.Printf("[Control] Signal %s: %s %s %s %.1f°",
+				prediction.Source, prediction.Station, prediction.MarketType, prediction.Date, prediction.Temperature)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{"sources": external.Status(time.Now())})
+	})
+
+	// Paper account of a dry run: fills, fill rate, balance and P&L
+	mux.HandleFunc("/paper", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if sim == nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "not a dry run"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"summary":   sim.Summary(),
+			"orders":    sim.Orders(),
+			"positions": sim.Portfolio().Positions(),
+			"days":      sim.Days(time.Local),
+		})
+	})
+
+	// A/B test: each variant's city-days and P&L, and the paired comparison
+	mux.HandleFunc("/ab", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		report := eng.ABReport()
+		if report == nil {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"error": "no A/B test running"})
+			return
+		}
+		json.NewEncoder(w).Encode(report)
+	})
+
+	// Control endpoint: show the hard limits, or halt or resume trading
+	mux.HandleFunc("/control/halt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var req struct {
+				Halt   bool   `json:"halt"`
+				Reason string `json:"reason"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, `{"error":"invalid JSON body"}`, http.StatusBadRequest)
+				return
+			}
+			if req.Halt {
+				if req.Reason == "" {
+					req.Reason = "operator"
+				}
+				log.Printf("[Control] Halting trading: %s", req.Reason)
+				limits.Halt(req.Reason)
+			} else {
+				log.Printf("[Control] Resuming trading")
+				if err := limits.Reset(); err != nil {
+					w.WriteHeader(http.StatusInternalServerError)
+					json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+					return
+				}
+			}
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		json.NewEncoder(w).Encode(limits.Status())
+	})
+
+	// Control endpoint: show the performance guard, or return a strategy it
+	// switched to shadow mode to live trading
+	mux.HandleFunc("/control/guard", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var req struct {
+				Enable bool `json:"enable"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !req.Enable {
+				http.Error(w, `{"error":"want {\"enable\":true}"}`, http.StatusBadRequest)
+				return
+			}
+			log.Printf("[Control] Returning %s to live trading", guard.Status().Strategy)
+			if err := guard.Enable(); err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		json.NewEncoder(w).Encode(guard.Status())
 	})
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf(":%d", port),
-		Handler: mux,
+		Handler: requireControlToken(controlToken, mux),
 	}
 
 	go func() {
@@ -180,3 +1108,32 @@ func startHTTPServer(port int, eng *engine.Engine) *http.Server {
 	return server
 }
 
+// requireControlToken refuses control requests that change state (anything
+// but GET under /control/) unless they carry the token as
+// "Authorization: Bearer <token>". With no token configured they are all
+// refused, so a bot can't be halted or re-enabled by whoever reaches its port
+func requireControlToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/control/") && r.Method != http.MethodGet {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if token == "" || !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				log.Printf("[Control] Refused %s %s from %s: missing or wrong token", r.Method, r.URL.Path, r.RemoteAddr)
+				http.Error(w, `{"error":"control requests need Authorization: Bearer $CONTROL_TOKEN"}`, http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// daySummary condenses a settled day report for the daily summary alert
+func daySummary(report engine.DayReport) notify.Summary {
+	s := notify.Summary{Day: report.Date, Trades: len(report.Trades), PnL: report.PnL, Notes: report.String()}
+	for _, t := range report.Trades {
+		s.Cost += t.Cost
+		if t.Profit > 0 {
+			s.Wins++
+		}
+	}
+	return s
+}
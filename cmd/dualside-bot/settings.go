@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/config"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// botSettings is the bot's configuration: the flags' values, overridden by
+// a -config file, DUALSIDE_* environment variables and the flags set, in
+// that order
+type botSettings struct {
+	config.Trading
+	Interval config.Duration `json:"interval"`
+	DryRun   bool            `json:"dry_run"`
+
+	// Expiry guards, for all cities
+	NoEntryBeforeClose config.Duration `json:"no_entry_before_close"`
+	ThinBookWarning    config.Duration `json:"thin_book_warning"`
+}
+
+var (
+	configPath string
+
+	// Parameters of cities the file doesn't override
+	defaultTrading config.Trading
+
+	// Each city's parameters, with the file's per-city overrides
+	cityTrading = make(map[string]config.Trading)
+
+	// Each city's lifecycle, on its own trading window
+	lifecycles = make(map[string]*strategy.Lifecycle)
+)
+
+func init() {
+	flag.StringVar(&configPath, "config", "", "YAML or TOML file of settings, with per-city overrides (see README)")
+}
+
+// loadSettings merges the configuration layers and validates every city's
+// parameters, so a bad file fails at startup
+func loadSettings() error {
+	defaults := botSettings{
+		Trading: config.Trading{
+			BetYes:      betSizeYes,
+			BetNo:       betSizeNo,
+			MinYesPrice: 20,
+			MaxYesPrice: 95,
+			MinNoPrice:  minNoPrice,
+			MaxNoPrice:  maxNoPrice,
+			MaxNo:       maxNoTrades,
+			StartHour:   7,
+			EndHour:     14,
+		},
+		Interval:           config.Duration(pollInterval),
+		DryRun:             dryRun,
+		NoEntryBeforeClose: config.Duration(expiryGuard.NoEntry),
+		ThinBookWarning:    config.Duration(expiryGuard.ThinBook),
+	}
+	s, err := config.LoadSettings(configPath, defaults, "DUALSIDE", flag.CommandLine)
+	if err != nil {
+		return err
+	}
+
+	var global botSettings
+	if err := s.Decode(&global); err != nil {
+		return err
+	}
+	if global.Interval <= 0 {
+		return fmt.Errorf("interval %v must be positive", time.Duration(global.Interval))
+	}
+	betSizeYes, betSizeNo, maxNoTrades = global.BetYes, global.BetNo, global.MaxNo
+	minNoPrice, maxNoPrice = global.MinNoPrice, global.MaxNoPrice
+	pollInterval, dryRun = time.Duration(global.Interval), global.DryRun
+	defaultTrading = global.Trading
+	expiryGuard = strategy.ExpiryGuard{
+		NoEntry:  time.Duration(global.NoEntryBeforeClose),
+		ThinBook: time.Duration(global.ThinBookWarning),
+	}
+	expiryWatch = strategy.NewExpiryWatch(expiryGuard)
+
+	for _, code := range s.Cities() {
+		if stationByCode(code) == nil {
+			return fmt.Errorf("%s: unknown city %s", s.Path(), code)
+		}
+	}
+	var invalid []string
+	for _, station := range Stations {
+		var city botSettings
+		if err := s.City(station.Code, &city); err != nil {
+			return err
+		}
+		if err := city.Validate(); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %s", station.Code, strings.ReplaceAll(err.Error(), "\n", "; ")))
+		}
+		cityTrading[station.Code] = city.Trading
+		lifecycles[station.Code] = strategy.NewLifecycle(strategy.Schedule{StartHour: city.StartHour, EndHour: city.EndHour})
+	}
+	if len(invalid) > 0 {
+		return errors.New(strings.Join(invalid, "; "))
+	}
+	return nil
+}
+
+// stationByCode returns the station with code, or nil
+func stationByCode(code string) *Station {
+	for i := range Stations {
+		if Stations[i].Code == code {
+			return &Stations[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+// stateRetention is how long traded events are remembered. Event tickers are
+// dated, so an event can't be entered again once its day has passed
+const stateRetention = 7 * 24 * time.Hour
+
+// stateLocation sets the day boundaries of the daily counters: the
+// exchange's time zone (America/New_York), not the host's
+var stateLocation = exchangeLocation()
+
+func exchangeLocation() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// stateDay returns the date the daily counters are kept for at now
+func stateDay(now time.Time) string {
+	return now.In(stateLocation).Format(time.DateOnly)
+}
+
+// statePath returns the state file to use: dry runs keep their own, so
+// simulated trades never block the live bot from an event
+func statePath() string {
+	if stateFile != "" {
+		return stateFile
+	}
+	if dryRun {
+		return filepath.Join(lockDir, "dualside-state-dryrun.json")
+	}
+	return filepath.Join(lockDir, "dualside-state.json")
+}
+
+// loadState reads the saved state, or returns a fresh one if there is none
+func loadState(path string, now time.Time) (BotState, error) {
+	s := BotState{OpenPositions: make(map[string][]TradeRecord)}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		s.rollover(now)
+		return s, nil
+	case err != nil:
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+	if s.OpenPositions == nil {
+		s.OpenPositions = make(map[string][]TradeRecord)
+	}
+	s.rollover(now)
+	return s, nil
+}
+
+// saveState writes the state, replacing the file atomically
+func saveState(path string) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// rollover resets the daily counters on a new day in stateLocation and
+// forgets events traded more than stateRetention ago
+func (s *BotState) rollover(now time.Time) {
+	day := stateDay(now)
+	if s.Day != day {
+		s.Day = day
+		s.YesTrades = 0
+		s.NoTrades = 0
+		s.TotalCost = 0
+	}
+	for event, trades := range s.OpenPositions {
+		latest := time.Time{}
+		for _, t := range trades {
+			if t.Timestamp.After(latest) {
+				latest = t.Timestamp
+			}
+		}
+		if now.Sub(latest) > stateRetention {
+			delete(s.OpenPositions, event)
+		}
+	}
+}
+
+// recordTrade adds a trade to its event and saves the state at once, so a
+// crash between orders can't lead to the event being entered twice
+func recordTrade(t TradeRecord) {
+	state.OpenPositions[t.EventTicker] = append(state.OpenPositions[t.EventTicker], t)
+	if err := saveState(statePath()); err != nil {
+		slog.Warn("Failed to save state", "err", err)
+	}
+}
+
+// refreshOrders updates the status of orders last seen resting
+func refreshOrders() {
+	changed := false
+	for event, trades := range state.OpenPositions {
+		for i, t := range trades {
+			if t.Status != string(rest.OrderStatusResting) {
+				continue
+			}
+			order, err := client.GetOrder(t.OrderID)
+			if err != nil {
+				execLog.Error("Failed to refresh order", "order_id", t.OrderID, "err", err)
+				continue
+			}
+			if order.Status != rest.OrderStatusResting {
+				trades[i].Status = string(order.Status)
+				changed = true
+			}
+		}
+		state.OpenPositions[event] = trades
+	}
+	if changed {
+		if err := saveState(statePath()); err != nil {
+			slog.Warn("Failed to save state", "err", err)
+		}
+	}
+}
+
+// openOrders returns the number of orders last seen resting
+func openOrders() int {
+	n := 0
+	for _, trades := range state.OpenPositions {
+		for _, t := range trades {
+			if t.Status == string(rest.OrderStatusResting) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// cancelOpenOrders cancels the orders last seen resting, leaving the
+// account's other bots alone
+func cancelOpenOrders() error {
+	var errs []error
+	for _, trades := range state.OpenPositions {
+		for _, t := range trades {
+			if t.Status != string(rest.OrderStatusResting) {
+				continue
+			}
+			if _, err := client.CancelOrder(t.OrderID); err != nil {
+				errs = append(errs, fmt.Errorf("cancel %s: %w", t.OrderID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
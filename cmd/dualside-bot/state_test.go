@@ -0,0 +1,162 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/mockexchange"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+func TestBotState_Rollover(t *testing.T) {
+	// 03:30 UTC on the 10th is still the 9th in New York
+	now := time.Date(2026, 3, 10, 3, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		day      string
+		trades   int
+		wantDay  string
+		wantKept bool
+	}{
+		{"same exchange day", "2026-03-09", 3, "2026-03-09", true},
+		{"UTC date is not the exchange's", "2026-03-10", 3, "2026-03-09", false},
+		{"new day", "2026-03-08", 3, "2026-03-09", false},
+		{"first run", "", 0, "2026-03-09", false},
+	}
+	for _, tt := range tests {
+		s := BotState{Day: tt.day, YesTrades: tt.trades, NoTrades: tt.trades, TotalCost: 50, OpenPositions: map[string][]TradeRecord{}}
+		s.rollover(now)
+		if s.Day != tt.wantDay {
+			t.Errorf("%s: Day = %q, want %q", tt.name, s.Day, tt.wantDay)
+		}
+		if kept := s.YesTrades == tt.trades && s.NoTrades == tt.trades && s.TotalCost == 50; kept != tt.wantKept && tt.trades > 0 {
+			t.Errorf("%s: counters %d/%d/$%.0f, want kept %v", tt.name, s.YesTrades, s.NoTrades, s.TotalCost, tt.wantKept)
+		}
+	}
+}
+
+func TestBotState_RolloverRetention(t *testing.T) {
+	now := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	s := BotState{OpenPositions: map[string][]TradeRecord{
+		"KXHIGHLAX-26MAR09": {{Timestamp: now.Add(-24 * time.Hour)}},
+		"KXHIGHLAX-26MAR02": {{Timestamp: now.Add(-8 * 24 * time.Hour)}},
+		// The latest trade of an event decides
+		"KXHIGHNY-26MAR03": {{Timestamp: now.Add(-9 * 24 * time.Hour)}, {Timestamp: now.Add(-6 * 24 * time.Hour)}},
+	}}
+	s.rollover(now)
+
+	var events []string
+	for event := range s.OpenPositions {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	if want := []string{"KXHIGHLAX-26MAR09", "KXHIGHNY-26MAR03"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("events kept = %v, want %v", events, want)
+	}
+}
+
+func TestLoadState(t *testing.T) {
+	now := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+
+	s, err := loadState(filepath.Join(dir, "missing.json"), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Day != "2026-03-10" || s.OpenPositions == nil {
+		t.Errorf("fresh state = %+v, want today and an empty position map", s)
+	}
+
+	path := filepath.Join(dir, "state.json")
+	saved := `{"Day": "2026-03-10", "YesTrades": 2, "OpenPositions": {
+		"KXHIGHLAX-26MAR10": [{"Timestamp": "2026-03-10T15:00:00Z", "OrderID": "a"}],
+		"KXHIGHLAX-26MAR01": [{"Timestamp": "2026-03-01T15:00:00Z", "OrderID": "b"}]
+	}}`
+	if err := os.WriteFile(path, []byte(saved), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err = loadState(path, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.YesTrades != 2 || len(s.OpenPositions) != 1 || s.OpenPositions["KXHIGHLAX-26MAR10"] == nil {
+		t.Errorf("loaded state = %+v, want today's counters and only the recent event", s)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"Day":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadState(path, now); err == nil {
+		t.Error("corrupt state loaded")
+	}
+}
+
+func TestRefreshOrders(t *testing.T) {
+	const ticker = "KXHIGHLAX-26MAR10-B70.5"
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := mockexchange.New(1)
+	t.Cleanup(x.Close)
+	x.AddMarket(rest.Market{Ticker: ticker, EventTicker: "KXHIGHLAX-26MAR10", YesBid: 40, YesAsk: 42})
+
+	oldClient, oldState, oldStateFile := client, state, stateFile
+	t.Cleanup(func() { client, state, stateFile = oldClient, oldState, oldStateFile })
+	client = rest.New("test-key", privateKey, rest.WithBaseURL(x.URL()))
+	stateFile = filepath.Join(t.TempDir(), "state.json")
+
+	// Both rest below the NO ask of 60
+	var ids []string
+	for range 2 {
+		o, err := client.BuyNo(ticker, 5, 55)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, o.OrderID)
+	}
+	if _, err := client.CancelOrder(ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	// The YES bid moving to 45 puts the NO ask at 55, filling the first
+	if err := x.SetQuote(ticker, 45, 47); err != nil {
+		t.Fatal(err)
+	}
+
+	resting, now := string(rest.OrderStatusResting), time.Now()
+	state = BotState{OpenPositions: map[string][]TradeRecord{"KXHIGHLAX-26MAR10": {
+		{Timestamp: now, OrderID: ids[0], Status: resting},
+		{Timestamp: now, OrderID: ids[1], Status: resting},
+		{Timestamp: now, OrderID: "unknown", Status: string(rest.OrderStatusExecuted)}, // Not refetched
+	}}}
+	refreshOrders()
+
+	statuses := func(s BotState) []string {
+		var list []string
+		for _, tr := range s.OpenPositions["KXHIGHLAX-26MAR10"] {
+			list = append(list, tr.Status)
+		}
+		return list
+	}
+	want := []string{string(rest.OrderStatusExecuted), string(rest.OrderStatusCanceled), string(rest.OrderStatusExecuted)}
+	if got := statuses(state); !reflect.DeepEqual(got, want) {
+		t.Errorf("statuses = %v, want %v", got, want)
+	}
+	if openOrders() != 0 {
+		t.Errorf("openOrders() = %d, want 0", openOrders())
+	}
+
+	saved, err := loadState(stateFile, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := statuses(saved); !reflect.DeepEqual(got, want) {
+		t.Errorf("saved statuses = %v, want %v", got, want)
+	}
+}
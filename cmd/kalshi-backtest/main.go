@@ -0,0 +1,19 @@
+// Command kalshi-backtest inspects the registry of backtest and optimization
+// runs the backtest tools record.
+//
+// It is kept for compatibility and is the same as `kalshi backtest`:
+//
+//	kalshi-backtest runs list [-dir results/runs] [-tool lahigh-optimizer] [-strategy DualSide]
+//	kalshi-backtest runs show [-dir results/runs] ID
+//	kalshi-backtest runs compare [-dir results/runs] ID_A ID_B
+package main
+
+import (
+	"os"
+
+	"github.com/brendanplayford/kalshi-go/internal/cli/backtest"
+)
+
+func main() {
+	os.Exit(backtest.Main(os.Args[1:]))
+}
@@ -45,7 +45,7 @@ func run() error {
 
 	// Add authentication if credentials are available.
 	if cfg.IsAuthenticated() {
-		opts = append(opts, ws.WithAPIKeyOption(cfg.APIKey, cfg.PrivateKey))
+		opts = append(opts, ws.WithAPIKey(cfg.APIKey, cfg.PrivateKey))
 		log.Println("→ using authenticated connection")
 	} else {
 		log.Println("→ using unauthenticated connection (public channels only)")
@@ -53,7 +53,7 @@ func run() error {
 
 	// Override base URL if configured.
 	if cfg.BaseURL != "" {
-		opts = append(opts, ws.WithBaseURLOption(cfg.BaseURL))
+		opts = append(opts, ws.WithBaseURL(cfg.BaseURL))
 	}
 
 	// Create WebSocket client.
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/analog"
+	"github.com/brendanplayford/kalshi-go/pkg/datastore"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// runAnalog lists the past days whose mornings looked most like today's and
+// how far each finished above its morning max
+func runAnalog(args []string) int {
+	fs := flag.NewFlagSet("analog", flag.ExitOnError)
+	stationCode := fs.String("station", "LAX", "Station code")
+	dateFlag := fs.String("date", "", "Day to find analogs for (YYYY-MM-DD, default today)")
+	days := fs.Int("days", 365, "Days of history to search")
+	k := fs.Int("k", 10, "Number of analogs")
+	cutoff := fs.Duration("cutoff", analog.DefaultCutoff, "Local time of day the morning is read at")
+	cache := fs.String("cache", "data/history.db", "SQLite cache of fetched history")
+	refresh := fs.Bool("refresh", false, "Refetch history even if cached")
+	fs.Parse(args)
+
+	station := weather.StationByCode(strings.ToUpper(*stationCode))
+	if station == nil {
+		fmt.Printf("❌ Unknown station %q\n", *stationCode)
+		return 1
+	}
+	now := time.Now().In(station.Location())
+	day := station.LocalDay(now)
+	if *dateFlag != "" {
+		d, err := time.ParseInLocation("2006-01-02", *dateFlag, station.Location())
+		if err != nil {
+			fmt.Printf("❌ Invalid -date: %v\n", err)
+			return 1
+		}
+		day = d
+	}
+
+	store, err := datastore.Open(*cache)
+	if err != nil {
+		fmt.Printf("❌ Failed to open cache: %v\n", err)
+		return 1
+	}
+	defer store.Close()
+	store.Refresh = *refresh
+
+	ctx := context.Background()
+	archive := store.Weather(weather.ASOS)
+
+	// Today's morning, from the freshest feed
+	obs, err := weather.Fallback(weather.METAR, archive).Observations(ctx, station, day)
+	if err != nil {
+		fmt.Printf("❌ Failed to fetch %s observations: %v\n", station.ID, err)
+		return 1
+	}
+	today, ok := analog.Morning(day, obs, *cutoff)
+	if !ok {
+		fmt.Printf("❌ No %s reports before %s on %s\n", station.ID, fmtCutoff(*cutoff), day.Format("Jan 2"))
+		return 1
+	}
+	if day.Equal(station.LocalDay(now)) {
+		if f, err := weather.NWS.Forecast(ctx, station, day); err == nil {
+			today.Forecast = f.HighTemp
+			if err := store.RecordForecast(f); err != nil {
+				fmt.Printf("⚠ Failed to record forecast: %v\n", err)
+			}
+		}
+	} else if f, err := store.Forecast(station, day); err == nil && f != nil {
+		today.Forecast = f.HighTemp
+	}
+
+	fmt.Printf("Loading %d days of %s history (cached in %s)...\n", *days, station.ID, *cache)
+	client := rest.NewPublic(rest.WithRateLimit(rest.DefaultRateLimits()))
+	var history []analog.Day
+	for i := 1; i <= *days; i++ {
+		d := day.AddDate(0, 0, -i)
+		if h, ok := historyDay(ctx, store, client, archive, station, d, *cutoff); ok {
+			history = append(history, h)
+		}
+	}
+
+	matches := analog.Find(history, today, *k, analog.DefaultWeights())
+	if len(matches) == 0 {
+		fmt.Printf("❌ No settled history found for %s\n", station.ID)
+		return 1
+	}
+
+	fmt.Println()
+	fmt.Printf("%s %s at %s: %.0f°F now, morning max %.0f°F, wind %s, forecast %s\n",
+		station.ID, day.Format("Mon Jan 2"), fmtCutoff(*cutoff), today.MorningTemp, today.MorningMax,
+		fmtWind(today.WindSpeed, today.WindDir), fmtTemp(today.Forecast))
+	fmt.Println()
+	fmt.Printf("  %-12s %7s %7s %9s %8s %7s %5s %6s\n", "Date", "Now", "Max", "Wind", "Fcst", "Settled", "Δ", "Dist")
+	for _, m := range matches {
+		fmt.Printf("  %-12s %6.0f° %6.0f° %9s %8s %6d° %+4d° %6.2f\n",
+			m.Date.Format("2006-01-02"), m.MorningTemp, m.MorningMax, fmtWind(m.WindSpeed, m.WindDir),
+			fmtTemp(m.Forecast), m.Settlement, m.Delta(), m.Distance)
+	}
+
+	s := analog.Summarize(matches)
+	base := int(math.Round(today.MorningMax))
+	fmt.Println()
+	fmt.Printf("Analogs finished %+.1f°F above their morning max on average\n", s.MeanDelta)
+	deltas := make([]int, 0, len(s.Deltas))
+	for delta := range s.Deltas {
+		deltas = append(deltas, delta)
+	}
+	sort.Ints(deltas)
+	for _, delta := range deltas {
+		share := float64(s.Deltas[delta]) / float64(s.N)
+		fmt.Printf("  %+3d° → %3d°F  %3.0f%%  %s\n", delta, base+delta, share*100, strings.Repeat("█", s.Deltas[delta]))
+	}
+	return 0
+}
+
+// historyDay reads one settled day's morning and settlement; ok is false if
+// the day has not settled or has no morning reports
+func historyDay(ctx context.Context, store *datastore.Store, client *rest.Client, archive weather.Provider,
+	station *weather.Station, day time.Time, cutoff time.Duration) (analog.Day, bool) {
+	eventTicker := station.EventPrefix + "-" + strings.ToUpper(day.Format("06Jan02"))
+	markets, err := store.Markets(ctx, client, eventTicker)
+	if err != nil {
+		return analog.Day{}, false
+	}
+	settlement, ok := 0, false
+	for _, m := range markets {
+		if m.Result != "yes" {
+			continue
+		}
+		if v, err := strconv.ParseFloat(m.ExpirationValue, 64); err == nil {
+			settlement, ok = int(math.Round(v)), true
+		}
+	}
+	if !ok {
+		return analog.Day{}, false
+	}
+
+	obs, err := archive.Observations(ctx, station, day)
+	if err != nil {
+		return analog.Day{}, false
+	}
+	f, ok := analog.Morning(day, obs, cutoff)
+	if !ok {
+		return analog.Day{}, false
+	}
+	if rf, err := store.Forecast(station, day); err == nil && rf != nil {
+		f.Forecast = rf.HighTemp
+	}
+	return analog.Day{Features: f, Settlement: settlement}, true
+}
+
+func fmtCutoff(d time.Duration) string {
+	return time.Time{}.Add(d).Format("15:04")
+}
+
+func fmtTemp(f float64) string {
+	if math.IsNaN(f) {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f°", f)
+}
+
+func fmtWind(speed, dir float64) string {
+	switch {
+	case math.IsNaN(speed):
+		return "-"
+	case speed == 0:
+		return "calm"
+	case dir == 0:
+		return fmt.Sprintf("VRB%.0fkt", speed)
+	}
+	return fmt.Sprintf("%03.0f@%.0fkt", dir, speed)
+}
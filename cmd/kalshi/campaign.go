@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/sizing"
+)
+
+// runCampaign plans how a week's budget is spread over the days left in it
+// by the forecast's confidence in each, as the production bot's weekly
+// campaign does each morning
+func runCampaign(args []string) int {
+	fs := flag.NewFlagSet("campaign", flag.ExitOnError)
+	budget := fs.Float64("budget", 1000, "Dollars to deploy over the week")
+	spent := fs.Float64("spent", 0, "Dollars already deployed this week")
+	confidence := fs.String("confidence", "", "Comma-separated confidence (0-1) of each day from -start, e.g. 0.55,0.8,0.4")
+	start := fs.String("start", time.Now().Format("2006-01-02"), "First day planned (YYYY-MM-DD)")
+	minConfidence := fs.Float64("min", 0.35, "Days less confident than this get nothing")
+	maxDay := fs.Float64("max-day", 0, "Dollars a day at most (0 = no cap)")
+	exponent := fs.Float64("exponent", 2, "A day's weight is its confidence to this power")
+	fs.Parse(args)
+
+	from, err := time.Parse("2006-01-02", *start)
+	if err != nil {
+		fmt.Printf("❌ Invalid -start: %v\n", err)
+		return 2
+	}
+	if *confidence == "" {
+		fmt.Println("❌ -confidence is required, one value per day")
+		return 2
+	}
+	var days []sizing.CampaignDay
+	for i, v := range strings.Split(*confidence, ",") {
+		c, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || c < 0 || c > 1 {
+			fmt.Printf("❌ Invalid confidence %q: want 0-1\n", v)
+			return 2
+		}
+		days = append(days, sizing.CampaignDay{Date: from.AddDate(0, 0, i).Format("2006-01-02"), Confidence: c})
+	}
+
+	c := sizing.Campaign{Budget: *budget, MinConfidence: *minConfidence, Exponent: *exponent, MaxDay: *maxDay}
+	plan := c.Plan(days, *spent)
+
+	fmt.Printf("$%.2f left of $%.2f over %d days\n\n", plan.Budget, *budget, len(days))
+	fmt.Printf("%-14s %10s %10s %6s\n", "Day", "Confidence", "Budget", "Share")
+	fmt.Println(strings.Repeat("-", 43))
+	even := plan.Budget / float64(len(days))
+	for _, d := range plan.Days {
+		date, _ := time.Parse("2006-01-02", d.Date)
+		share := 0.0
+		if plan.Budget > 0 {
+			share = d.Budget / plan.Budget
+		}
+		marker := ""
+		switch {
+		case d.Budget == 0:
+			marker = "  held back"
+		case d.Budget > even*1.05:
+			marker = "  ▲"
+		}
+		fmt.Printf("%-14s %9.0f%% %10s %5.0f%%%s\n",
+			date.Format("Mon Jan 2"), d.Confidence*100, fmt.Sprintf("$%.2f", d.Budget), share*100, marker)
+	}
+	fmt.Println(strings.Repeat("-", 43))
+	if plan.Held > 0 {
+		fmt.Printf("$%.2f held: no day is confident enough or all are at -max-day\n", plan.Held)
+	}
+	fmt.Printf("An even split would risk $%.2f a day\n", even)
+	return 0
+}
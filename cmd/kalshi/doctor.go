@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/config"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// Clock skew beyond which request signatures may be rejected
+const maxClockSkew = 5 * time.Second
+
+// apiKeyPattern matches the UUID format of Kalshi API key IDs
+var apiKeyPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// checkStatus is the outcome of one doctor check
+type checkStatus int
+
+const (
+	statusOK checkStatus = iota
+	statusWarn
+	statusFail
+	statusSkip
+)
+
+func (s checkStatus) symbol() string {
+	return [...]string{"✓", "⚠", "✗", "-"}[s]
+}
+
+// doctor runs the checks in order and remembers the worst outcome
+type doctor struct {
+	failed bool
+}
+
+// report prints one check result with an optional hint on how to fix it
+func (d *doctor) report(status checkStatus, name, detail, hint string) {
+	fmt.Printf("  %s %-22s %s\n", status.symbol(), name, detail)
+	if hint != "" && (status == statusWarn || status == statusFail) {
+		fmt.Printf("    → %s\n", hint)
+	}
+	if status == statusFail {
+		d.failed = true
+	}
+}
+
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	demo := fs.Bool("demo", false, "Check against the demo environment")
+	stationCode := fs.String("station", "LAX", "Station used for the weather provider checks")
+	fs.Parse(args)
+
+	restURL, wsURL := rest.ProdBaseURL, ws.DefaultBaseURL
+	if *demo {
+		restURL, wsURL = rest.DemoBaseURL, ws.DemoBaseURL
+	}
+
+	d := &doctor{}
+
+	fmt.Println("Configuration")
+	cfg := d.checkConfig()
+	if cfg != nil && cfg.BaseURL != "" {
+		wsURL = cfg.BaseURL
+	}
+
+	fmt.Println("\nKalshi")
+	d.checkExchange(restURL)
+	d.checkBalance(cfg, restURL)
+	d.checkWebSocket(cfg, wsURL)
+
+	fmt.Println("\nWeather providers")
+	d.checkWeather(strings.ToUpper(*stationCode))
+
+	fmt.Println()
+	if d.failed {
+		fmt.Println("Some checks failed; fix the ✗ items above and run `kalshi doctor` again.")
+		return 1
+	}
+	fmt.Println("All required checks passed.")
+	return 0
+}
+
+// checkConfig loads .env/environment configuration and validates the key
+// material, returning nil if it can't be loaded
+func (d *doctor) checkConfig() *config.Config {
+	if _, err := os.Stat(".env"); err == nil {
+		d.report(statusOK, ".env file", "found in working directory", "")
+	} else {
+		d.report(statusSkip, ".env file", "not found; using environment variables only", "")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		hint := "KALSHI_PRIVATE_KEY must be the PEM RSA key downloaded from Kalshi; in .env paste it unquoted, from -----BEGIN to -----END on their own lines"
+		d.report(statusFail, "config", err.Error(), hint)
+		return nil
+	}
+
+	switch {
+	case cfg.APIKey == "":
+		d.report(statusFail, "KALSHI_API_KEY", "not set", "create an API key under Account → API Keys and set KALSHI_API_KEY")
+	case !apiKeyPattern.MatchString(strings.TrimSpace(cfg.APIKey)):
+		d.report(statusWarn, "KALSHI_API_KEY", "set, but not a UUID", "the key ID looks like 8-4-4-4-12 hex digits; check for quotes or stray whitespace")
+	default:
+		d.report(statusOK, "KALSHI_API_KEY", maskKey(cfg.APIKey), "")
+	}
+
+	switch {
+	case cfg.PrivateKey == nil:
+		d.report(statusFail, "KALSHI_PRIVATE_KEY", "not set", "set KALSHI_PRIVATE_KEY to the PEM private key that belongs to the API key")
+	case cfg.PrivateKey.N.BitLen() < 2048:
+		d.report(statusWarn, "KALSHI_PRIVATE_KEY", fmt.Sprintf("RSA %d bits", cfg.PrivateKey.N.BitLen()), "Kalshi issues 2048-bit keys; this may not be the right key")
+	default:
+		d.report(statusOK, "KALSHI_PRIVATE_KEY", fmt.Sprintf("RSA %d bits", cfg.PrivateKey.N.BitLen()), "")
+	}
+	return cfg
+}
+
+// checkExchange checks public REST connectivity and compares the system clock
+// with the exchange's Date header; signed requests carry a timestamp and are
+// rejected when the clock drifts
+func (d *doctor) checkExchange(restURL string) {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	start := time.Now()
+	resp, err := httpClient.Get(restURL + "/exchange/status")
+	if err != nil {
+		d.report(statusFail, "REST API", err.Error(), "check network access, proxies and firewalls for "+restURL)
+		d.report(statusSkip, "clock skew", "skipped (exchange unreachable)", "")
+		return
+	}
+	defer resp.Body.Close()
+	rtt := time.Since(start)
+
+	var status rest.ExchangeStatus
+	switch {
+	case resp.StatusCode != http.StatusOK:
+		d.report(statusFail, "REST API", fmt.Sprintf("HTTP %d from %s", resp.StatusCode, restURL), "the exchange may be down; see https://status.kalshi.com")
+	case json.NewDecoder(resp.Body).Decode(&status) != nil:
+		d.report(statusFail, "REST API", "unexpected response from "+restURL, "a proxy may be intercepting the request")
+	case !status.TradingActive:
+		d.report(statusWarn, "REST API", fmt.Sprintf("reachable (exchange active=%v, trading active=false)", status.ExchangeActive),
+			"trading is paused; orders will be rejected until it resumes")
+	default:
+		d.report(statusOK, "REST API", "reachable (trading active)", "")
+	}
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		d.report(statusSkip, "clock skew", "exchange sent no Date header", "")
+		return
+	}
+	skew := start.Add(rtt / 2).Sub(serverTime)
+	detail := fmt.Sprintf("%+.1fs vs exchange", skew.Seconds())
+	if skew.Abs() > maxClockSkew+time.Second { // Date has 1s resolution
+		d.report(statusFail, "clock skew", detail, "sync the system clock (e.g. enable NTP: timedatectl set-ntp true)")
+		return
+	}
+	d.report(statusOK, "clock skew", detail, "")
+}
+
+// checkBalance checks that the credentials are accepted by an authenticated
+// endpoint
+func (d *doctor) checkBalance(cfg *config.Config, restURL string) {
+	if cfg == nil || !cfg.IsAuthenticated() {
+		d.report(statusSkip, "balance", "skipped (no credentials)", "")
+		return
+	}
+	client := rest.New(cfg.APIKey, cfg.PrivateKey, rest.WithBaseURL(restURL))
+	balance, err := client.GetBalance()
+	if err != nil {
+		hint := "the API key and private key must be a matching pair, for the same environment (-demo for demo keys)"
+		var apiErr *rest.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			hint = "credentials rejected: " + hint + "; a skewed clock also causes 401s"
+		}
+		d.report(statusFail, "balance", err.Error(), hint)
+		return
+	}
+	d.report(statusOK, "balance", fmt.Sprintf("$%.2f available", float64(balance.Balance)/100), "")
+}
+
+// checkWebSocket opens and closes a WebSocket connection
+func (d *doctor) checkWebSocket(cfg *config.Config, wsURL string) {
+	opts := []ws.Option{ws.WithBaseURL(wsURL), ws.WithAutoReconnect(false, 0)}
+	mode := "unauthenticated"
+	if cfg != nil && cfg.IsAuthenticated() {
+		opts = append(opts, ws.WithAPIKey(cfg.APIKey, cfg.PrivateKey))
+		mode = "authenticated"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	client := ws.New(opts...)
+	if err := client.Connect(ctx); err != nil {
+		d.report(statusFail, "WebSocket", err.Error(), "check that outbound WebSocket (wss://) traffic to "+wsURL+" is allowed")
+		return
+	}
+	client.Close()
+	d.report(statusOK, "WebSocket", "connected ("+mode+")", "")
+}
+
+// checkWeather checks each weather data provider for one station. Provider
+// outages are warnings: the bots degrade rather than fail without them
+func (d *doctor) checkWeather(code string) {
+	station := weather.StationByCode(code)
+	if station == nil {
+		d.report(statusFail, "station", fmt.Sprintf("unknown station %q", code), "use a station code such as LAX, NYC, CHI, MIA, AUS, PHIL or DEN")
+		return
+	}
+
+	if obs, err := weather.FetchAWCObservations(station, 3); err != nil {
+		d.report(statusWarn, "AWC METAR", err.Error(), "aviationweather.gov is the live METAR feed; check access to it")
+	} else if len(obs) == 0 {
+		d.report(statusWarn, "AWC METAR", "no reports in the last 3 hours for "+station.ID, "the feed may be delayed; compare with cmd/metar-crosscheck")
+	} else {
+		latest := obs[len(obs)-1]
+		d.report(statusOK, "AWC METAR", fmt.Sprintf("%s %.0f°F at %s", station.ID, latest.Temp, latest.Time.Format("15:04 MST")), "")
+	}
+
+	if data, err := weather.FetchMETARMax(station, time.Now().In(station.Location())); err != nil {
+		d.report(statusWarn, "IEM METAR history", err.Error(), "mesonet.agron.iastate.edu backs backtests and settlement checks; check access to it")
+	} else {
+		d.report(statusOK, "IEM METAR history", fmt.Sprintf("%d reports today", len(data.Observations)), "")
+	}
+
+	if g, err := weather.FetchGridPoint(station); err != nil {
+		d.report(statusWarn, "NWS API", err.Error(), "api.weather.gov provides forecasts; check access to it")
+	} else {
+		d.report(statusOK, "NWS API", "grid point "+g.String(), "")
+	}
+}
+
+// maskKey shows only the start of an API key
+func maskKey(key string) string {
+	if len(key) <= 8 {
+		return strings.Repeat("*", len(key))
+	}
+	return key[:8] + strings.Repeat("*", len(key)-8)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/cli"
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/portfolio"
+)
+
+// runLedger splits the account's recent fills between the strategies that
+// placed them, by the tags on their client order IDs, and reports each
+// strategy's P&L and exposure and any difference from the account's totals
+func runLedger(args []string) int {
+	fs := flag.NewFlagSet("ledger", flag.ExitOnError)
+	var exchange cli.Exchange
+	exchange.Register(fs)
+	days := fs.Int("days", 7, "Days of fills to include")
+	fs.Parse(args)
+
+	cfg, err := exchange.Config()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	client := exchange.REST(cfg)
+
+	since := time.Now().AddDate(0, 0, -*days)
+	ledger := portfolio.NewLedger(fees.DefaultSchedule())
+	if err := ledger.Sync(client, since); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	// Value open positions at the current bids
+	marked := make(map[string]bool)
+	for _, name := range ledger.Strategies() {
+		for _, pos := range ledger.Strategy(name).Positions() {
+			if pos.Count == 0 || pos.Settled || marked[pos.Ticker] {
+				continue
+			}
+			marked[pos.Ticker] = true
+			if m, err := client.GetMarket(pos.Ticker); err == nil {
+				ledger.Mark(pos.Ticker, m.YesBid)
+			}
+		}
+	}
+
+	found, err := ledger.Reconcile(client, since)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Fills since %s, by strategy\n\n", since.Format("Jan 2 15:04"))
+	fmt.Printf("%-16s %10s %11s %9s %10s %10s\n", "Strategy", "Realized", "Unrealized", "Fees", "Exposure", "Total")
+	fmt.Println(strings.Repeat("-", 71))
+	row := func(name string, p portfolio.PnL) {
+		fmt.Printf("%-16s %10s %11s %9s %10s %10s\n",
+			name, dollars(p.Realized), dollars(p.Unrealized), dollars(p.Fees), dollars(p.Exposure), dollars(p.Total()))
+	}
+	for _, name := range ledger.Strategies() {
+		row(name, ledger.Strategy(name).Total())
+	}
+	fmt.Println(strings.Repeat("-", 71))
+	row("account", ledger.Total())
+
+	if len(found) == 0 {
+		fmt.Println("\n✓ Strategies add up to the account")
+		return 0
+	}
+	fmt.Println("\n⚠ Strategies differ from the account:")
+	for _, d := range found {
+		fmt.Printf("  %s\n", d)
+	}
+	return 1
+}
+
+func dollars(cents int) string {
+	if cents < 0 {
+		return fmt.Sprintf("-$%.2f", float64(-cents)/100)
+	}
+	return fmt.Sprintf("$%.2f", float64(cents)/100)
+}
@@ -0,0 +1,79 @@
+// Command kalshi is the kalshi-go command line tool.
+//
+// Usage:
+//
+//	kalshi trade [-demo] [-event KXHIGHLAX-25DEC27 | -all] [-auto] [-max-risk 50]
+//	kalshi monitor [-market KXHIGHLAX-25DEC27] [-ws] [-demo]
+//	kalshi predict
+//	kalshi backtest
+//	kalshi backtest runs list|show|compare [-dir results/runs] ...
+//	kalshi doctor [-demo] [-station LAX]
+//	kalshi series [-demo] [-json]
+//	kalshi model-rpc [-addr 127.0.0.1:8765] [-fees schedule.json]
+//	kalshi analog [-station LAX] [-k 10] [-days 365] [-cutoff 10h]
+//	kalshi residuals -dataset days.json.gz [-out data/residuals.json] [-cutoff 10h]
+//	kalshi tsdb-export -url http://localhost:8428/write [-stations LAX,NYC] [-interval 1m]
+//	kalshi ledger [-demo] [-days 7]
+//	kalshi campaign -budget 1000 -confidence 0.55,0.8,0.4,0.6,0.7 [-spent 0] [-min 0.35] [-max-day 0]
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/brendanplayford/kalshi-go/internal/cli/backtest"
+	"github.com/brendanplayford/kalshi-go/internal/cli/monitor"
+	"github.com/brendanplayford/kalshi-go/internal/cli/predict"
+	"github.com/brendanplayford/kalshi-go/internal/cli/trade"
+)
+
+// command is a kalshi subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+var commands = []command{
+	{"trade", "Trade the daily temperature markets on weather signals", trade.Main},
+	{"monitor", "Watch a temperature market and its weather on market day", monitor.Main},
+	{"predict", "Predict tomorrow's LA high against the market's prices", predict.Main},
+	{"backtest", "Backtest the LA high strategy, or inspect recorded runs", backtest.Main},
+	{"doctor", "Check credentials, clock, connectivity and data providers", runDoctor},
+	{"series", "List the exchange's daily temperature series and their stations", runSeries},
+	{"model-rpc", "Serve the probability model and EV calculator over JSON-RPC", runModelRPC},
+	{"analog", "Find the past days most like today and how they settled", runAnalog},
+	{"residuals", "Build the empirical forecast shape per station and month from a backtest archive", runResiduals},
+	{"tsdb-export", "Push temperatures, model probabilities and prices to InfluxDB/VictoriaMetrics", runTSDBExport},
+	{"ledger", "Split the account's P&L between the strategies trading it", runLedger},
+	{"campaign", "Spread a week's budget over its days by the forecast's confidence", runCampaign},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	for _, c := range commands {
+		if c.name == name {
+			os.Exit(c.run(os.Args[2:]))
+		}
+	}
+
+	if name != "help" && name != "-h" && name != "--help" {
+		fmt.Fprintf(os.Stderr, "kalshi: unknown command %q\n\n", name)
+	}
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: kalshi <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", c.name, c.summary)
+	}
+}
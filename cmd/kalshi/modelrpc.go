@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+)
+
+// runModelRPC serves the probability model and EV calculator over JSON-RPC so
+// notebooks call the production implementation
+func runModelRPC(args []string) int {
+	fs := flag.NewFlagSet("model-rpc", flag.ExitOnError)
+	addr := fs.String("addr", "127.0.0.1:8765", "Listen address (keep it local; the server has no auth)")
+	feeFile := fs.String("fees", "", "JSON fee schedule by series (default: 7% of winnings)")
+	fs.Parse(args)
+
+	schedule := fees.DefaultSchedule()
+	if *feeFile != "" {
+		var err error
+		schedule, err = fees.LoadSchedule(*feeFile)
+		if err != nil {
+			fmt.Printf("❌ Failed to load fee schedule: %v\n", err)
+			return 1
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/rpc", model.NewRPCServer(schedule))
+
+	fmt.Printf("Model RPC listening on http://%s/rpc\n", *addr)
+	fmt.Println("Methods: model.forecast, model.low_forecast, model.probability, model.probabilities, model.ensemble, ev.evaluate")
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		log.Printf("model-rpc: %v", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// runResiduals builds the histograms of settlement minus morning prediction
+// per station and month from a backtest archive, for lahigh-trader
+// -residuals, and compares each month's tails with the normal's
+func runResiduals(args []string) int {
+	fs := flag.NewFlagSet("residuals", flag.ExitOnError)
+	datasetPath := fs.String("dataset", "", "Backtest dataset to build from (.json or .json.gz)")
+	out := fs.String("out", "data/residuals.json", "File to write the histograms to")
+	cutoff := fs.Duration("cutoff", model.DefaultResidualCutoff, "Local time of day the morning prediction is made at")
+	calibration := fs.Float64("calibration", model.DefaultCalibration, "METAR to CLI adjustment of the prediction, °F")
+	fs.Parse(args)
+
+	if *datasetPath == "" {
+		fmt.Println("❌ -dataset is required")
+		return 2
+	}
+	ds, err := backtest.Load(*datasetPath)
+	if err != nil {
+		fmt.Printf("❌ Failed to load dataset: %v\n", err)
+		return 1
+	}
+
+	r := model.ResidualsFromDataset(ds.Days, *cutoff, *calibration)
+	if err := r.Save(*out); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	type group struct {
+		station string
+		low     bool
+		month   time.Month
+	}
+	seen := make(map[group]bool)
+	var groups []group
+	for i := range ds.Days {
+		d := &ds.Days[i]
+		g := group{d.City, d.MarketType() == weather.MarketTypeLow, d.Time().Month()}
+		if !seen[g] {
+			seen[g] = true
+			groups = append(groups, g)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.station != b.station {
+			return a.station < b.station
+		}
+		if a.low != b.low {
+			return !a.low
+		}
+		return a.month < b.month
+	})
+
+	fmt.Printf("Residuals of %d days at %s written to %s\n", len(ds.Days), fmtCutoff(*cutoff), *out)
+	fmt.Println()
+	fmt.Printf("  %-8s %-5s %-5s %5s %10s %10s\n", "Station", "Kind", "Month", "Days", "P(|Z|>2)", "Normal")
+	for _, g := range groups {
+		kind := "HIGH"
+		if g.low {
+			kind = "LOW"
+		}
+		s := r.Shape(g.station, g.low, g.month)
+		if s.Days == 0 {
+			fmt.Printf("  %-8s %-5s %-5s %5s %10s\n", g.station, kind, g.month.String()[:3], "-", "normal")
+			continue
+		}
+		tail := s.CDF(-2) + 1 - s.CDF(2)
+		normal := model.Shape{}.CDF(-2) * 2
+		fmt.Printf("  %-8s %-5s %-5s %5d %9.1f%% %9.1f%%\n", g.station, kind, g.month.String()[:3], s.Days, tail*100, normal*100)
+	}
+	return 0
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/brendanplayford/kalshi-go/internal/cli"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// runSeries lists the daily temperature series the exchange has open, the
+// station each settles on and its bracket layout, and which registry
+// tickers discovery would move
+func runSeries(args []string) int {
+	fs := flag.NewFlagSet("series", flag.ExitOnError)
+	var exchange cli.Exchange
+	exchange.Register(fs)
+	asJSON := fs.Bool("json", false, "Print the catalog as JSON")
+	fs.Parse(args)
+
+	cfg, err := exchange.Config()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	cat, err := market.Discover(exchange.REST(cfg))
+	if cat == nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Discovery incomplete: %v\n", err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(cat); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Printf("%-14s %-5s %-8s %6s  %s\n", "Series", "Type", "Station", "Events", "Layout")
+	fmt.Println(strings.Repeat("-", 60))
+	for _, s := range cat.Series {
+		code, layout := s.Code, "-"
+		if code == "" {
+			code = "?"
+		}
+		if len(s.Events) > 0 {
+			layout = s.Layout.String()
+		}
+		fmt.Printf("%-14s %-5s %-8s %6d  %s\n", s.Ticker, s.Type, code, len(s.Events), layout)
+	}
+
+	// Applied to a copy; the registry is only changed by the bots
+	stations := make(map[string]*weather.Station, len(weather.Stations))
+	for code, s := range weather.Stations {
+		st := *s
+		stations[code] = &st
+	}
+	if changes := cat.Apply(stations); len(changes) > 0 {
+		fmt.Println("\nMoved series:")
+		for _, c := range changes {
+			fmt.Printf("  %s\n", c)
+		}
+	}
+	if unmatched := cat.Unmatched(); len(unmatched) > 0 {
+		fmt.Println("\nNo station registered:")
+		for _, s := range unmatched {
+			fmt.Printf("  %s  %s\n", s.Ticker, s.Title)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/tsdb"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// runTSDBExport pushes each station's reports, the model's forecast and every
+// bracket's model probability and prices to a time-series database on an
+// interval
+func runTSDBExport(args []string) int {
+	fs := flag.NewFlagSet("tsdb-export", flag.ExitOnError)
+	endpoint := fs.String("url", os.Getenv("TSDB_URL"), "Line protocol write URL (or TSDB_URL)")
+	token := fs.String("token", os.Getenv("TSDB_TOKEN"), "InfluxDB 2.x API token (or TSDB_TOKEN)")
+	stations := fs.String("stations", "LAX", "Comma-separated station codes")
+	interval := fs.Duration("interval", time.Minute, "Time between exports")
+	once := fs.Bool("once", false, "Export once and exit")
+	fs.Parse(args)
+
+	if *endpoint == "" {
+		fmt.Println("❌ -url or TSDB_URL is required, e.g. http://localhost:8428/write")
+		return 2
+	}
+	var list []*weather.Station
+	for _, code := range strings.Split(*stations, ",") {
+		s := weather.StationByCode(strings.ToUpper(strings.TrimSpace(code)))
+		if s == nil {
+			fmt.Printf("❌ Unknown station %q\n", code)
+			return 1
+		}
+		list = append(list, s)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	e := &exporter{
+		writer: tsdb.NewWriter(*endpoint, *token),
+		client: rest.NewPublic(rest.WithRateLimit(rest.DefaultRateLimits())),
+		sent:   make(map[string]time.Time),
+	}
+	fmt.Printf("Exporting %s to %s every %s\n", *stations, *endpoint, *interval)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		failed := false
+		for _, s := range list {
+			n, err := e.export(ctx, s, time.Now())
+			if err != nil {
+				fmt.Printf("⚠ %s: %v\n", s.ID, err)
+				failed = true
+				continue
+			}
+			fmt.Printf("%s %s: %d points\n", time.Now().Format("15:04:05"), s.ID, n)
+		}
+		if *once {
+			if failed {
+				return 1
+			}
+			return 0
+		}
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-ticker.C:
+		}
+	}
+}
+
+type exporter struct {
+	writer *tsdb.Writer
+	client *rest.Client
+	sent   map[string]time.Time // Station ID -> latest report exported
+}
+
+// export writes one station's points as of now and returns how many
+func (e *exporter) export(ctx context.Context, station *weather.Station, now time.Time) (int, error) {
+	day := station.LocalDay(now)
+	local := now.In(station.Location())
+
+	obs, err := weather.METAR.Observations(ctx, station, day)
+	if err != nil {
+		return 0, fmt.Errorf("observations: %w", err)
+	}
+	var points []tsdb.Point
+	runningMax := math.Inf(-1)
+	latest := e.sent[station.ID]
+	for _, o := range obs {
+		runningMax = max(runningMax, o.Temp)
+		if o.Time.After(e.sent[station.ID]) {
+			points = append(points, tsdb.Temperature(station, weather.METAR.Name(), o))
+			latest = o.Time
+		}
+	}
+
+	eventTicker := station.HighEventTicker(day)
+	markets, err := e.client.GetMarkets(eventTicker)
+	if err != nil {
+		return 0, fmt.Errorf("markets: %w", err)
+	}
+
+	if !math.IsInf(runningMax, -1) {
+		nwsHigh := math.NaN()
+		if f, err := weather.NWS.Forecast(ctx, station, day); err == nil {
+			nwsHigh = f.HighTemp
+		}
+		nws := int(math.Round(runningMax))
+		if !math.IsNaN(nwsHigh) {
+			nws = int(math.Round(nwsHigh))
+		}
+		f := model.HighForecast(int(math.Round(runningMax)), nws, model.DefaultCalibration, local.Hour())
+		points = append(points, tsdb.Forecast(station, eventTicker, f, runningMax, nwsHigh, now))
+		for _, m := range markets {
+			points = append(points, tsdb.Bracket(station, m, f, now))
+		}
+	}
+
+	if err := e.writer.Write(ctx, points); err != nil {
+		return 0, err
+	}
+	e.sent[station.ID] = latest
+	return len(points), nil
+}
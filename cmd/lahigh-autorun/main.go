@@ -3,12 +3,11 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
 	"os"
 	"os/signal"
 	"sort"
@@ -17,10 +16,14 @@ import (
 	"time"
 
 	"github.com/brendanplayford/kalshi-go/internal/config"
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/portfolio"
 	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
-// Configuration
+// Configuration defaults; see loadSettings
 var (
 	maxPositionSize  = 10   // Max contracts per position
 	maxRiskCents     = 5000 // Max $50 at risk
@@ -30,6 +33,14 @@ var (
 	pollInterval     = 30 * time.Second
 	tradingStartHour = 7  // 7 AM PT - start trading
 	tradingEndHour   = 12 // 12 PM PT - stop adding positions
+
+	// No entries near a market's close; positions held into its thin book
+	// are flagged
+	expiryGuard = strategy.DefaultExpiryGuard()
+	expiryWatch *strategy.ExpiryWatch
+
+	// Target day's phase: trading from tradingStartHour to tradingEndHour
+	lifecycle *strategy.Lifecycle
 )
 
 type MarketState struct {
@@ -46,27 +57,35 @@ type MarketState struct {
 func main() {
 	// Parse flags
 	eventTicker := flag.String("event", "", "Event ticker (e.g., KXHIGHLAX-25DEC27)")
-	maxRisk := flag.Int("max-risk", 50, "Maximum risk per trade in dollars")
+	flag.Int("max-risk", 50, "Maximum risk per trade in dollars")
 	dryRun := flag.Bool("dry-run", false, "Simulate trades without executing")
+	statePath := flag.String("state", "", "Save the session's positions and P&L here and resume from it on restart")
+	flag.DurationVar(&expiryGuard.NoEntry, "no-entry-before-close", expiryGuard.NoEntry, "Open no positions this close to a market's close (0 = off)")
+	flag.DurationVar(&expiryGuard.ThinBook, "thin-book-warning", expiryGuard.ThinBook, "Warn about positions still held this close to a market's close (0 = off)")
+	configPath := flag.String("config", "", "YAML or TOML file of settings (min_edge, max_yes_price, start_hour, end_hour, calibration, ...)")
 	flag.Parse()
 
+	if err := loadSettings(*configPath); err != nil {
+		fmt.Printf("❌ Invalid settings: %v\n", err)
+		os.Exit(1)
+	}
+
 	if *eventTicker == "" {
 		// Auto-detect tomorrow's market
 		tomorrow := time.Now().AddDate(0, 0, 1)
 		*eventTicker = fmt.Sprintf("KXHIGHLAX-%s", strings.ToUpper(tomorrow.Format("06Jan02")))
 	}
 
-	maxRiskCents = *maxRisk * 100
-
 	fmt.Println(strings.Repeat("=", 70))
 	fmt.Println("🤖 LA HIGH TEMPERATURE - AUTO TRADING BOT")
 	fmt.Println(strings.Repeat("=", 70))
 	fmt.Println()
 	fmt.Printf("📅 Target Market: %s\n", *eventTicker)
-	fmt.Printf("💵 Max Risk: $%d per trade\n", *maxRisk)
+	fmt.Printf("💵 Max Risk: $%d per trade\n", maxRiskCents/100)
 	fmt.Printf("📈 Min Edge: %.0f%%\n", minEdge*100)
 	fmt.Printf("💰 Max Entry Price: %d¢\n", maxEntryPrice)
 	fmt.Printf("⏱️  Poll Interval: %v\n", pollInterval)
+	fmt.Printf("⏰ Expiry: %s\n", expiryGuard)
 	if *dryRun {
 		fmt.Println("🧪 DRY RUN MODE - No real trades")
 	}
@@ -79,6 +98,8 @@ func main() {
 		os.Exit(1)
 	}
 
+	lifecycle.SetLogger(func(format string, args ...any) { fmt.Printf("⏱️  "+format+"\n", args...) })
+
 	// Connect to Kalshi
 	client := rest.New(cfg.APIKey, cfg.PrivateKey)
 
@@ -110,7 +131,19 @@ func main() {
 
 	// State
 	var tradedBrackets = make(map[string]bool)
-	var totalPnL float64
+	start := time.Now()
+	pf := portfolio.New(fees.DefaultSchedule())
+	if *statePath != "" {
+		if loaded, err := portfolio.Load(*statePath, fees.DefaultSchedule()); err == nil {
+			pf = loaded
+			for _, pos := range pf.Positions() {
+				tradedBrackets[pos.Ticker] = true
+			}
+			fmt.Printf("✅ Resumed %d positions from %s\n", len(pf.Positions()), *statePath)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("⚠️  Failed to load %s: %v\n", *statePath, err)
+		}
+	}
 
 	// Signal handling
 	sigCh := make(chan os.Signal, 1)
@@ -126,12 +159,14 @@ func main() {
 	fmt.Println()
 
 	// Initial check
-	checkAndTrade(client, *eventTicker, targetDate, tradedBrackets, &totalPnL, *dryRun, balance.Balance)
+	checkAndTrade(client, *eventTicker, targetDate, tradedBrackets, pf, *dryRun, balance.Balance)
+	syncPortfolio(client, pf, start, *dryRun, *statePath)
 
 	for {
 		select {
 		case <-ticker.C:
-			checkAndTrade(client, *eventTicker, targetDate, tradedBrackets, &totalPnL, *dryRun, balance.Balance)
+			checkAndTrade(client, *eventTicker, targetDate, tradedBrackets, pf, *dryRun, balance.Balance)
+			syncPortfolio(client, pf, start, *dryRun, *statePath)
 
 		case <-sigCh:
 			fmt.Println("\n→ Shutting down...")
@@ -140,13 +175,27 @@ func main() {
 			fmt.Println("SESSION SUMMARY")
 			fmt.Println(strings.Repeat("=", 70))
 			fmt.Printf("Brackets traded: %d\n", len(tradedBrackets))
-			fmt.Printf("Estimated P&L: $%.2f (pending settlement)\n", totalPnL)
+			if !*dryRun {
+				found, err := pf.Reconcile(client, start)
+				if err != nil {
+					fmt.Printf("⚠️  Settlement reconciliation failed: %v\n", err)
+				}
+				for _, d := range found {
+					fmt.Printf("⚠️  %s\n", d)
+				}
+			}
+			printPortfolio(pf)
+			if *statePath != "" {
+				if err := pf.Save(*statePath); err != nil {
+					fmt.Printf("⚠️  Failed to save %s: %v\n", *statePath, err)
+				}
+			}
 			return
 		}
 	}
 }
 
-func checkAndTrade(client *rest.Client, eventTicker string, targetDate time.Time, tradedBrackets map[string]bool, totalPnL *float64, dryRun bool, balance int) {
+func checkAndTrade(client *rest.Client, eventTicker string, targetDate time.Time, tradedBrackets map[string]bool, pf *portfolio.Portfolio, dryRun bool, balance int) {
 	la, _ := time.LoadLocation("America/Los_Angeles")
 	now := time.Now().In(la)
 
@@ -155,29 +204,24 @@ func checkAndTrade(client *rest.Client, eventTicker string, targetDate time.Time
 
 	// Determine trading window status
 	var tradingStatus string
-	var canTrade bool
+	phase := lifecycle.Advance(eventTicker, targetDate, now)
+	canTrade := phase.CanEnter()
 
-	if !isTargetToday {
-		// Target is in the future - monitor only
+	switch phase {
+	case strategy.PhasePreMarket:
+		// Target is in the future or the window hasn't opened - monitor only
 		targetStart := time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(),
 			tradingStartHour, 0, 0, 0, la)
 		until := targetStart.Sub(now).Round(time.Minute)
 		tradingStatus = fmt.Sprintf("⏳ WAITING - Trading starts in %v", until)
-		canTrade = false
-	} else if now.Hour() < tradingStartHour {
-		// Before trading window
-		minsUntil := (tradingStartHour-now.Hour())*60 - now.Minute()
-		tradingStatus = fmt.Sprintf("⏳ WAITING - Trading starts in %d minutes", minsUntil)
-		canTrade = false
-	} else if now.Hour() >= tradingEndHour {
-		// After trading window
-		tradingStatus = "🔒 HOLDING - Trading window closed (after 12 PM)"
-		canTrade = false
-	} else {
+	case strategy.PhaseIntraday:
 		// In trading window!
 		minsLeft := (tradingEndHour-now.Hour())*60 - now.Minute()
 		tradingStatus = fmt.Sprintf("🟢 TRADING WINDOW ACTIVE (%d min remaining)", minsLeft)
-		canTrade = true
+	case strategy.PhaseLockWindow:
+		tradingStatus = fmt.Sprintf("🔒 HOLDING - Trading window closed (after %d:00)", tradingEndHour)
+	default:
+		tradingStatus = fmt.Sprintf("🏁 %s - Market day over", phase)
 	}
 
 	// Get expected temperature
@@ -218,8 +262,25 @@ func checkAndTrade(client *rest.Client, eventTicker string, targetDate time.Time
 		stdDev = 1.5 // Less uncertainty as we approach
 	}
 
+	var nearClose error
 	for _, m := range markets {
-		if m.YesSubTitle == "" {
+		// Value open positions at what they could be sold for now
+		pf.Mark(m.Ticker, m.YesBid)
+
+		// A determined market no longer trades, even before its close
+		if m.YesSubTitle == "" || m.Determined() {
+			continue
+		}
+
+		// Flag positions held into the thin end-of-day book, and open
+		// nothing new near the close
+		closes := strategy.ParseCloseTime(m.CloseTime)
+		if pf.Ticker(m.Ticker).Exposure > 0 && expiryWatch.Check(m.Ticker, closes, now) {
+			fmt.Printf("[%s] ⚠️  Holding %s into the thin book, %v before close\n",
+				now.Format("15:04:05"), m.Ticker, closes.Sub(now).Round(time.Minute))
+		}
+		if err := expiryGuard.CheckEntry(closes, now); err != nil {
+			nearClose = err
 			continue
 		}
 
@@ -264,6 +325,10 @@ func checkAndTrade(client *rest.Client, eventTicker string, targetDate time.Time
 			bestOpp.Strike, bestOpp.YesAsk, bestOpp.Edge*100)
 	}
 
+	if nearClose != nil && canTrade {
+		fmt.Printf("         Entries closed: %v\n", nearClose)
+	}
+
 	// Only execute trades during trading window
 	if !canTrade {
 		if bestOpp != nil {
@@ -308,7 +373,10 @@ func checkAndTrade(client *rest.Client, eventTicker string, targetDate time.Time
 			if dryRun {
 				fmt.Println("   🧪 DRY RUN - Would execute trade")
 				tradedBrackets[s.Ticker] = true
-				*totalPnL += float64(100-s.YesAsk) * float64(contracts) * 0.93 / 100 // Estimated profit
+				pf.ApplyFill(rest.Fill{
+					TradeID: "dry-run-" + s.Ticker, Ticker: s.Ticker, Side: rest.SideYes, Action: rest.OrderActionBuy,
+					Count: contracts, YesPrice: s.YesAsk, NoPrice: 100 - s.YesAsk, IsTaker: true, CreatedTime: now,
+				})
 			} else {
 				// Execute trade
 				order, err := client.BuyYes(s.Ticker, contracts, s.YesAsk)
@@ -317,7 +385,6 @@ func checkAndTrade(client *rest.Client, eventTicker string, targetDate time.Time
 				} else {
 					fmt.Printf("   ✅ ORDER PLACED! ID: %s\n", order.OrderID)
 					tradedBrackets[s.Ticker] = true
-					*totalPnL += float64(100-s.YesAsk) * float64(contracts) * 0.93 / 100
 				}
 			}
 			fmt.Println(strings.Repeat("!", 70))
@@ -327,66 +394,55 @@ func checkAndTrade(client *rest.Client, eventTicker string, targetDate time.Time
 	}
 }
 
-func fetchMETAR() int {
-	resp, err := http.Get("https://aviationweather.gov/api/data/metar?ids=KLAX&hours=24&format=json")
-	if err != nil {
-		return 60 // Default
+// syncPortfolio applies the session's fills to the portfolio and saves it
+func syncPortfolio(client *rest.Client, pf *portfolio.Portfolio, start time.Time, dryRun bool, statePath string) {
+	if !dryRun {
+		fills, err := client.GetAllFills(rest.GetFillsParams{MinTS: start})
+		if err != nil {
+			fmt.Printf("⚠️  Failed to fetch fills: %v\n", err)
+			return
+		}
+		pf.ApplyFills(fills)
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	var obs []struct {
-		Temp float64 `json:"temp"`
+	if statePath != "" {
+		if err := pf.Save(statePath); err != nil {
+			fmt.Printf("⚠️  Failed to save %s: %v\n", statePath, err)
+		}
 	}
-	json.Unmarshal(body, &obs)
+}
 
-	// Find max
-	var maxTemp float64
-	for _, o := range obs {
-		tempF := o.Temp*9/5 + 32
-		if tempF > maxTemp {
-			maxTemp = tempF
+// printPortfolio prints the session's positions and P&L
+func printPortfolio(pf *portfolio.Portfolio) {
+	for _, pos := range pf.Positions() {
+		status := fmt.Sprintf("%d held @ %.1f¢", pos.Count, pos.AvgPrice())
+		if pos.Settled {
+			status = "settled"
 		}
+		fmt.Printf("  %-28s %-3s %-20s realized $%.2f\n", pos.Ticker, pos.Side, status, float64(pos.Realized)/100)
+	}
+	total := pf.Total()
+	fmt.Printf("Realized P&L: $%.2f (fees $%.2f)\n", float64(total.Realized)/100, float64(total.Fees)/100)
+	if total.Exposure > 0 {
+		fmt.Printf("Unrealized P&L: $%.2f on $%.2f open (pending settlement)\n",
+			float64(total.Unrealized)/100, float64(total.Exposure)/100)
 	}
+	fmt.Printf("Session P&L: $%.2f\n", float64(total.Total())/100)
+}
 
-	return int(maxTemp)
+func fetchMETAR() int {
+	data, err := weather.DailyMax(context.Background(), weather.METAR, weather.Stations["LAX"], time.Now())
+	if err != nil {
+		return 60 // Default
+	}
+	return int(data.MaxTemp)
 }
 
 func fetchNWSForecast(targetDate time.Time) int {
-	resp, err := http.Get("https://api.weather.gov/gridpoints/LOX/154,44/forecast")
+	f, err := weather.NWS.Forecast(context.Background(), weather.Stations["LAX"], targetDate)
 	if err != nil {
 		return 62 // Default
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	var forecast struct {
-		Properties struct {
-			Periods []struct {
-				Name        string `json:"name"`
-				Temperature int    `json:"temperature"`
-				IsDaytime   bool   `json:"isDaytime"`
-			} `json:"periods"`
-		} `json:"properties"`
-	}
-	json.Unmarshal(body, &forecast)
-
-	// Find the forecast for target date
-	targetStr := strings.ToLower(targetDate.Format("Monday"))
-	for _, p := range forecast.Properties.Periods {
-		if p.IsDaytime && strings.Contains(strings.ToLower(p.Name), targetStr) {
-			return p.Temperature
-		}
-	}
-
-	// Fallback to first daytime
-	for _, p := range forecast.Properties.Periods {
-		if p.IsDaytime {
-			return p.Temperature
-		}
-	}
-
-	return 62
+	return int(f.HighTemp)
 }
 
 func parseBracket(bracket string) (int, int) {
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/config"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// autorunSettings is the bot's configuration: the defaults above,
+// overridden by a -config file (with its LAX overrides), AUTORUN_*
+// environment variables and the flags set, in that order. Of the shared
+// trading parameters the bot uses max_yes_price as the entry price cap,
+// the trading window and the calibration
+type autorunSettings struct {
+	config.Trading
+	MinEdge     float64         `json:"min_edge"`
+	MaxPosition int             `json:"max_position"` // Contracts per position
+	MaxRisk     int             `json:"max_risk"`     // Dollars per trade
+	Interval    config.Duration `json:"interval"`
+
+	NoEntryBeforeClose config.Duration `json:"no_entry_before_close"`
+	ThinBookWarning    config.Duration `json:"thin_book_warning"`
+}
+
+// settingsCity is the city whose overrides the bot reads
+const settingsCity = "LAX"
+
+// loadSettings sets the configuration from path and the flags
+func loadSettings(path string) error {
+	defaults := autorunSettings{
+		Trading: config.Trading{
+			MaxYesPrice: maxEntryPrice,
+			StartHour:   tradingStartHour,
+			EndHour:     tradingEndHour,
+			Calibration: cliCalibration,
+		},
+		MinEdge:     minEdge,
+		MaxPosition: maxPositionSize,
+		MaxRisk:     maxRiskCents / 100,
+		Interval:    config.Duration(pollInterval),
+
+		NoEntryBeforeClose: config.Duration(expiryGuard.NoEntry),
+		ThinBookWarning:    config.Duration(expiryGuard.ThinBook),
+	}
+	s, err := config.LoadSettings(path, defaults, "AUTORUN", flag.CommandLine)
+	if err != nil {
+		return err
+	}
+	var cfg autorunSettings
+	if err := s.City(settingsCity, &cfg); err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if cfg.Interval <= 0 || cfg.MaxPosition <= 0 || cfg.MaxRisk <= 0 {
+		return fmt.Errorf("interval, max_position and max_risk must be positive")
+	}
+
+	maxEntryPrice, minEdge, cliCalibration = cfg.MaxYesPrice, cfg.MinEdge, cfg.Calibration
+	maxPositionSize, maxRiskCents = cfg.MaxPosition, cfg.MaxRisk*100
+	pollInterval = time.Duration(cfg.Interval)
+	tradingStartHour, tradingEndHour = cfg.StartHour, cfg.EndHour
+	expiryGuard = strategy.ExpiryGuard{NoEntry: time.Duration(cfg.NoEntryBeforeClose), ThinBook: time.Duration(cfg.ThinBookWarning)}
+	expiryWatch = strategy.NewExpiryWatch(expiryGuard)
+	lifecycle = strategy.NewLifecycle(strategy.Schedule{StartHour: tradingStartHour, EndHour: tradingEndHour})
+	return nil
+}
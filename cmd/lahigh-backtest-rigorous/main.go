@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
 type Trade struct {
@@ -126,30 +128,14 @@ func analyzeDay(targetDate time.Time) (DayAnalysis, error) {
 	// Model: METAR max + 1°F = CLI estimate
 	predictedCLI := metarMax + 1
 	
-	// Find bracket containing predicted CLI
-	predictedFloor := 0
-	for _, m := range allMarkets {
-		if m.FloorStrike <= predictedCLI && m.CapStrike >= predictedCLI {
-			predictedFloor = m.FloorStrike
-			break
-		}
-	}
-	
-	if predictedFloor == 0 {
-		// Fallback: round to nearest 2-degree bracket
-		predictedFloor = (predictedCLI / 2) * 2
-	}
-
-	// Find our prediction market and protection market
+	// Find the listed bracket containing predicted CLI, and the one below it
+	// for protection
+	strikes := marketStrikes(allMarkets)
 	var predictedMarket, protectMarket *Market
-	for i := range allMarkets {
-		m := &allMarkets[i]
-		if m.FloorStrike == predictedFloor {
-			predictedMarket = m
-		}
-		// Protection: bracket below
-		if m.FloorStrike == predictedFloor-2 {
-			protectMarket = m
+	if i := strikes.Index(float64(predictedCLI), market.RoundNearest); i >= 0 {
+		predictedMarket = &allMarkets[i]
+		if j := strikes.Below(i); j >= 0 {
+			protectMarket = &allMarkets[j]
 		}
 	}
 
@@ -162,7 +148,7 @@ func analyzeDay(targetDate time.Time) (DayAnalysis, error) {
 			analysis.ThesisPrice = price
 		}
 	} else {
-		analysis.PredictedBracket = fmt.Sprintf("%d-%d°", predictedFloor, predictedFloor+1)
+		analysis.PredictedBracket = fmt.Sprintf("%d° (no bracket)", predictedCLI)
 	}
 
 	if protectMarket != nil {
@@ -198,40 +184,11 @@ func analyzeDay(targetDate time.Time) (DayAnalysis, error) {
 }
 
 func getMETARMax(date time.Time) (int, error) {
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=LAX&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=America/Los_Angeles&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		date.Year(), int(date.Month()), date.Day(),
-		date.Year(), int(date.Month()), date.Day()+1,
-	)
-
-	resp, err := httpClient.Get(url)
+	data, err := weather.FetchMETARMax(weather.Stations["LAX"], date)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	lines := strings.Split(string(body), "\n")
-	maxTemp := 0.0
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, "LAX,") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > maxTemp {
-					maxTemp = temp
-				}
-			}
-		}
-	}
-
-	if maxTemp == 0 {
-		return 0, fmt.Errorf("no data")
-	}
-
-	return int(math.Round(maxTemp)), nil
+	return int(data.MaxTemp), nil
 }
 
 func getWinnerAndMarkets(eventTicker string) (*Market, []Market, error) {
@@ -402,4 +359,12 @@ func printResults(results []DayAnalysis) {
 	}
 }
 
-
+// marketStrikes returns the settlement range of each market, index-aligned
+// with markets
+func marketStrikes(markets []Market) market.Strikes {
+	strikes := make(market.Strikes, len(markets))
+	for i, m := range markets {
+		strikes[i] = market.NewStrike(m.FloorStrike, m.CapStrike)
+	}
+	return strikes
+}
@@ -1,50 +1,45 @@
 // Package main provides a validated backtesting system using actual Kalshi trade prices.
 // This validates our edge hypothesis against real market data.
+//
+// Settled markets and their trades are cached in a SQLite database (-cache),
+// so reruns only fetch events settled since the last run; -refresh refetches
+// everything.
+//
+// Entries are priced at any local times of day (-entries, e.g. 9:45,10:30)
+// from the winning market's price reconstructed from its trades: the last
+// trade before the entry, the VWAP or the best price seen over the -window
+// before it (-price).
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"flag"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
 	"os"
+	"os/signal"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/datastore"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
 )
 
 const (
 	kalshiFee = 0.07 // 7% fee on winnings
 )
 
-// Trade from Kalshi API
-type KalshiTrade struct {
-	TradeID     string `json:"trade_id"`
-	Ticker      string `json:"ticker"`
-	CreatedTime string `json:"created_time"`
-	YesPrice    int    `json:"yes_price"`
-	NoPrice     int    `json:"no_price"`
-	Count       int    `json:"count"`
-	TakerSide   string `json:"taker_side"`
-}
-
-type TradesResponse struct {
-	Trades []KalshiTrade `json:"trades"`
-	Cursor string        `json:"cursor"`
-}
-
-type Market struct {
-	Ticker      string `json:"ticker"`
-	Result      string `json:"result"`
-	YesSubTitle string `json:"yes_sub_title"`
-	Status      string `json:"status"`
-}
+var client = rest.NewPublic(rest.WithRateLimit(rest.DefaultRateLimits()))
 
-type MarketsResponse struct {
-	Markets []Market `json:"markets"`
-}
+// Entry pricing, from the flags
+var (
+	entryTimes  []time.Duration      // Local times of day entries are priced at
+	priceMethod backtest.PriceMethod // How an entry is priced from the trades
+	priceWindow time.Duration        // Trades before an entry its VWAP or best price covers
+)
 
 // DayAnalysis holds the analysis for one day
 type DayAnalysis struct {
@@ -53,19 +48,11 @@ type DayAnalysis struct {
 	WinningTicker  string
 	WinningBracket string
 
-	// Prices at key hours (LA time)
-	Price7AM  int
-	Price9AM  int
-	Price10AM int
-	Price11AM int
-	Price12PM int
-	Price1PM  int
+	// Winning market's price at each entry time (LA time, 0 = no trade yet)
+	Prices []int
 
-	// Trade counts
-	Trades7AM  int
-	Trades9AM  int
-	Trades10AM int
-	Trades11AM int
+	// Contracts traded in the window before each entry time
+	Volumes []int
 
 	// Edge analysis
 	BestEntryHour   string
@@ -85,15 +72,47 @@ type SimulatedTrade struct {
 }
 
 func main() {
+	cache := flag.String("cache", "data/history.db", "SQLite cache of fetched history")
+	refresh := flag.Bool("refresh", false, "Refetch history even if cached")
+	entries := flag.String("entries", "7:00,9:00,10:00,11:00", "Comma-separated local times of day to price entries at, to the minute")
+	method := flag.String("price", string(backtest.PriceLast), "Entry price: last (trade before the entry), vwap or best (seen over -window)")
+	flag.DurationVar(&priceWindow, "window", 15*time.Minute, "Trades before an entry that -price vwap and best cover")
+	flag.Parse()
+
+	var err error
+	if entryTimes, err = parseEntryTimes(*entries); err != nil {
+		fmt.Printf("❌ Invalid -entries: %v\n", err)
+		os.Exit(1)
+	}
+	priceMethod = backtest.PriceMethod(*method)
+	switch priceMethod {
+	case backtest.PriceLast, backtest.PriceVWAP, backtest.PriceBest:
+	default:
+		fmt.Printf("❌ Invalid -price %q: want last, vwap or best\n", *method)
+		os.Exit(1)
+	}
+
+	store, err := datastore.Open(*cache)
+	if err != nil {
+		fmt.Printf("❌ Failed to open cache: %v\n", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+	store.Refresh = *refresh
+
 	fmt.Println(strings.Repeat("=", 80))
 	fmt.Println("📊 LA HIGH TEMPERATURE - VALIDATED BACKTEST")
 	fmt.Println("    Using Actual Kalshi Trade Prices")
 	fmt.Println(strings.Repeat("=", 80))
 	fmt.Println()
 
+	// Ctrl-C stops fetching and analyzes what has been fetched so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	// Fetch all closed events
 	fmt.Println("→ Fetching closed markets from Kalshi...")
-	events, err := fetchClosedEvents()
+	events, err := fetchClosedEvents(ctx)
 	if err != nil {
 		fmt.Printf("❌ Failed to fetch events: %v\n", err)
 		os.Exit(1)
@@ -106,20 +125,21 @@ func main() {
 	var analyses []DayAnalysis
 
 	for i, event := range events {
+		if ctx.Err() != nil {
+			fmt.Printf("  Interrupted after %d/%d events\n", i, len(events))
+			break
+		}
 		if i > 0 && i%10 == 0 {
 			fmt.Printf("  Processed %d/%d events...\n", i, len(events))
 		}
 
-		analysis, err := analyzeEvent(event)
+		analysis, err := analyzeEvent(ctx, store, event)
 		if err != nil {
 			continue
 		}
-		if analysis.Price10AM > 0 || analysis.Price9AM > 0 {
+		if analysis.BestEntryHour != "" {
 			analyses = append(analyses, analysis)
 		}
-
-		// Rate limiting
-		time.Sleep(100 * time.Millisecond)
 	}
 
 	fmt.Printf("✓ Analyzed %d days with trade data\n", len(analyses))
@@ -132,67 +152,38 @@ func main() {
 	runSimulatedStrategies(analyses)
 }
 
-func fetchClosedEvents() ([]string, error) {
+func fetchClosedEvents(ctx context.Context) ([]string, error) {
 	var events []string
 
-	resp, err := http.Get("https://api.elections.kalshi.com/trade-api/v2/events?series_ticker=KXHIGHLAX&limit=100")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var eventsResp struct {
-		Events []struct {
-			EventTicker string `json:"event_ticker"`
-		} `json:"events"`
-	}
-	json.Unmarshal(body, &eventsResp)
-
-	for _, e := range eventsResp.Events {
-		// Check if market is closed (has a result)
-		resp2, err := http.Get("https://api.elections.kalshi.com/trade-api/v2/markets?event_ticker=" + e.EventTicker)
+	for e, err := range client.IterEvents(ctx, rest.GetEventsParams{SeriesTicker: "KXHIGHLAX", Status: "settled", Limit: 200}) {
 		if err != nil {
-			continue
-		}
-		body2, _ := io.ReadAll(resp2.Body)
-		resp2.Body.Close()
-
-		var marketsResp MarketsResponse
-		json.Unmarshal(body2, &marketsResp)
-
-		for _, m := range marketsResp.Markets {
-			if m.Result == "yes" || m.Result == "no" {
-				events = append(events, e.EventTicker)
-				break
+			if ctx.Err() != nil {
+				break // Keep the events found so far
 			}
+			return nil, err
 		}
-		time.Sleep(50 * time.Millisecond)
+		events = append(events, e.EventTicker)
 	}
 
 	return events, nil
 }
 
-func analyzeEvent(eventTicker string) (DayAnalysis, error) {
+func analyzeEvent(ctx context.Context, store *datastore.Store, eventTicker string) (DayAnalysis, error) {
 	analysis := DayAnalysis{
 		EventTicker: eventTicker,
 		Date:        parseEventDate(eventTicker),
 	}
 
 	// Get winning market
-	resp, err := http.Get("https://api.elections.kalshi.com/trade-api/v2/markets?event_ticker=" + eventTicker)
+	markets, err := store.Markets(ctx, client, eventTicker)
 	if err != nil {
 		return analysis, err
 	}
-	defer resp.Body.Close()
 
-	body, _ := io.ReadAll(resp.Body)
-	var marketsResp MarketsResponse
-	json.Unmarshal(body, &marketsResp)
-
-	for _, m := range marketsResp.Markets {
+	var winner rest.Market
+	for _, m := range markets {
 		if m.Result == "yes" {
+			winner = m
 			analysis.WinningTicker = m.Ticker
 			analysis.WinningBracket = m.YesSubTitle
 			break
@@ -204,66 +195,32 @@ func analyzeEvent(eventTicker string) (DayAnalysis, error) {
 	}
 
 	// Fetch all trades for the winning market
-	trades, err := fetchAllTrades(analysis.WinningTicker)
+	trades, err := store.Trades(ctx, client, winner)
 	if err != nil {
 		return analysis, err
 	}
 
-	// Group trades by hour (LA time = UTC-8)
-	hourlyPrices := make(map[int][]int)
-	hourlyCounts := make(map[int]int)
-
-	for _, trade := range trades {
-		hour := parseTradeHour(trade.CreatedTime, analysis.Date)
-		if hour >= 0 {
-			hourlyPrices[hour] = append(hourlyPrices[hour], trade.YesPrice)
-			hourlyCounts[hour] += trade.Count
-		}
-	}
-
-	// Calculate average prices
-	avgPrice := func(hour int) int {
-		if prices, ok := hourlyPrices[hour]; ok && len(prices) > 0 {
-			sum := 0
-			for _, p := range prices {
-				sum += p
-			}
-			return sum / len(prices)
-		}
-		return 0
+	// Price each entry from the market's trades as of that minute
+	la, _ := time.LoadLocation("America/Los_Angeles")
+	day, err := time.ParseInLocation("2006-01-02", analysis.Date, la)
+	if err != nil {
+		return analysis, err
 	}
-
-	analysis.Price7AM = avgPrice(7)
-	analysis.Price9AM = avgPrice(9)
-	analysis.Price10AM = avgPrice(10)
-	analysis.Price11AM = avgPrice(11)
-	analysis.Price12PM = avgPrice(12)
-	analysis.Price1PM = avgPrice(13)
-
-	analysis.Trades7AM = hourlyCounts[7]
-	analysis.Trades9AM = hourlyCounts[9]
-	analysis.Trades10AM = hourlyCounts[10]
-	analysis.Trades11AM = hourlyCounts[11]
+	series := backtest.NewPriceSeries(backtest.TicksFromTrades(trades))
 
 	// Find best entry point
 	bestPrice := 100
 	bestHour := ""
 
-	if analysis.Price7AM > 0 && analysis.Price7AM < bestPrice {
-		bestPrice = analysis.Price7AM
-		bestHour = "7 AM"
-	}
-	if analysis.Price9AM > 0 && analysis.Price9AM < bestPrice {
-		bestPrice = analysis.Price9AM
-		bestHour = "9 AM"
-	}
-	if analysis.Price10AM > 0 && analysis.Price10AM < bestPrice {
-		bestPrice = analysis.Price10AM
-		bestHour = "10 AM"
-	}
-	if analysis.Price11AM > 0 && analysis.Price11AM < bestPrice {
-		bestPrice = analysis.Price11AM
-		bestHour = "11 AM"
+	for _, at := range entryTimes {
+		snapshot := series.At(day.Add(at), priceWindow)
+		price := snapshot.Price(priceMethod, true)
+		analysis.Prices = append(analysis.Prices, price)
+		analysis.Volumes = append(analysis.Volumes, snapshot.Volume)
+		if price > 0 && price < bestPrice {
+			bestPrice = price
+			bestHour = fmtEntryTime(at)
+		}
 	}
 
 	analysis.BestEntryHour = bestHour
@@ -274,40 +231,6 @@ func analyzeEvent(eventTicker string) (DayAnalysis, error) {
 	return analysis, nil
 }
 
-func fetchAllTrades(ticker string) ([]KalshiTrade, error) {
-	var allTrades []KalshiTrade
-	cursor := ""
-
-	for i := 0; i < 20; i++ {
-		url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets/trades?ticker=%s&limit=100", ticker)
-		if cursor != "" {
-			url += "&cursor=" + cursor
-		}
-
-		resp, err := http.Get(url)
-		if err != nil {
-			return allTrades, err
-		}
-
-		body, _ := io.ReadAll(resp.Body)
-		resp.Body.Close()
-
-		var tradesResp TradesResponse
-		json.Unmarshal(body, &tradesResp)
-
-		allTrades = append(allTrades, tradesResp.Trades...)
-
-		if tradesResp.Cursor == "" {
-			break
-		}
-		cursor = tradesResp.Cursor
-
-		time.Sleep(50 * time.Millisecond)
-	}
-
-	return allTrades, nil
-}
-
 func parseEventDate(ticker string) string {
 	// KXHIGHLAX-25DEC25 -> 2025-12-25
 	re := regexp.MustCompile(`(\d{2})([A-Z]{3})(\d{2})$`)
@@ -328,24 +251,39 @@ func parseEventDate(ticker string) string {
 	return fmt.Sprintf("%s-%s-%s", year, month, day)
 }
 
-func parseTradeHour(timestamp, expectedDate string) int {
-	// Parse: "2025-12-25T18:30:00Z" -> LA hour
-	t, err := time.Parse(time.RFC3339, timestamp)
-	if err != nil {
-		return -1
+// parseEntryTimes parses comma-separated local times of day such as 7:00 or
+// 10:37
+func parseEntryTimes(list string) ([]time.Duration, error) {
+	var times []time.Duration
+	for _, field := range strings.Split(list, ",") {
+		t, err := time.Parse("15:04", strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a time of day like 10:30", field)
+		}
+		times = append(times, time.Duration(t.Hour())*time.Hour+time.Duration(t.Minute())*time.Minute)
 	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	return times, nil
+}
 
-	// Convert to LA time
-	la, _ := time.LoadLocation("America/Los_Angeles")
-	laTime := t.In(la)
-
-	// Only count trades on the expected date
-	dateStr := laTime.Format("2006-01-02")
-	if dateStr != expectedDate {
-		return -1
+// fmtEntryTime formats a time of day, e.g. 10 AM or 10:37 AM
+func fmtEntryTime(at time.Duration) string {
+	t := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Add(at)
+	if t.Minute() == 0 {
+		return t.Format("3 PM")
 	}
+	return t.Format("3:04 PM")
+}
 
-	return laTime.Hour()
+// priceLabel describes how entries are priced
+func priceLabel() string {
+	switch priceMethod {
+	case backtest.PriceVWAP:
+		return fmt.Sprintf("VWAP of the %s before each entry", priceWindow)
+	case backtest.PriceBest:
+		return fmt.Sprintf("lowest trade in the %s before each entry", priceWindow)
+	}
+	return "last trade before each entry"
 }
 
 func printDetailedAnalysis(analyses []DayAnalysis) {
@@ -354,10 +292,16 @@ func printDetailedAnalysis(analyses []DayAnalysis) {
 	fmt.Println(strings.Repeat("=", 80))
 	fmt.Println()
 
-	fmt.Printf("%-12s %-12s %7s %7s %7s %7s %10s %8s\n",
-		"Date", "Winner", "7 AM", "9 AM", "10 AM", "11 AM", "Best Entry", "Profit")
-	fmt.Printf("%-12s %-12s %7s %7s %7s %7s %10s %8s\n",
-		"----", "------", "----", "----", "-----", "-----", "----------", "------")
+	fmt.Printf("Entries priced at the %s\n\n", priceLabel())
+
+	header := fmt.Sprintf("%-12s %-12s", "Date", "Winner")
+	rule := fmt.Sprintf("%-12s %-12s", "----", "------")
+	for _, at := range entryTimes {
+		header += fmt.Sprintf(" %8s", fmtEntryTime(at))
+		rule += fmt.Sprintf(" %8s", strings.Repeat("-", len(fmtEntryTime(at))))
+	}
+	fmt.Printf("%s %10s %8s\n", header, "Best Entry", "Profit")
+	fmt.Printf("%s %10s %8s\n", rule, "----------", "------")
 
 	// Sort by date descending
 	sort.Slice(analyses, func(i, j int) bool {
@@ -365,21 +309,13 @@ func printDetailedAnalysis(analyses []DayAnalysis) {
 	})
 
 	for _, a := range analyses {
-		p7 := "-"
-		if a.Price7AM > 0 {
-			p7 = fmt.Sprintf("%d¢", a.Price7AM)
-		}
-		p9 := "-"
-		if a.Price9AM > 0 {
-			p9 = fmt.Sprintf("%d¢", a.Price9AM)
-		}
-		p10 := "-"
-		if a.Price10AM > 0 {
-			p10 = fmt.Sprintf("%d¢", a.Price10AM)
-		}
-		p11 := "-"
-		if a.Price11AM > 0 {
-			p11 = fmt.Sprintf("%d¢", a.Price11AM)
+		row := fmt.Sprintf("%-12s %-12s", a.Date, a.WinningBracket)
+		for _, price := range a.Prices {
+			cell := "-"
+			if price > 0 {
+				cell = fmt.Sprintf("%d¢", price)
+			}
+			row += fmt.Sprintf(" %8s", cell)
 		}
 
 		profit := fmt.Sprintf("+%d¢", a.PotentialProfit)
@@ -389,8 +325,7 @@ func printDetailedAnalysis(analyses []DayAnalysis) {
 			profit = fmt.Sprintf("+%d¢ 🎯", a.PotentialProfit)
 		}
 
-		fmt.Printf("%-12s %-12s %7s %7s %7s %7s %10s %8s\n",
-			a.Date, a.WinningBracket, p7, p9, p10, p11, a.BestEntryHour, profit)
+		fmt.Printf("%s %10s %8s\n", row, a.BestEntryHour, profit)
 	}
 	fmt.Println()
 
@@ -433,21 +368,24 @@ func runSimulatedStrategies(analyses []DayAnalysis) {
 	fmt.Println(strings.Repeat("=", 80))
 	fmt.Println()
 
-	// Strategy 1: Always buy at 10 AM
-	strategy10AM := simulateStrategy(analyses, func(a DayAnalysis) (int, bool) {
-		if a.Price10AM > 0 {
-			return a.Price10AM, true
-		}
-		return 0, false
-	}, "Buy at 10 AM")
+	// Strategy 1: Always buy at each entry time
+	var atEntry []StrategyResult
+	for i, at := range entryTimes {
+		atEntry = append(atEntry, simulateStrategy(analyses, func(a DayAnalysis) (int, bool) {
+			if a.Prices[i] > 0 {
+				return a.Prices[i], true
+			}
+			return 0, false
+		}, "Buy at "+fmtEntryTime(at)))
+	}
 
-	// Strategy 2: Buy at best available price (7-11 AM)
+	// Strategy 2: Buy at best available price of the entries
 	strategyBest := simulateStrategy(analyses, func(a DayAnalysis) (int, bool) {
 		if a.BestEntryPrice > 0 && a.BestEntryPrice < 100 {
 			return a.BestEntryPrice, true
 		}
 		return 0, false
-	}, "Buy at best price (7-11 AM)")
+	}, fmt.Sprintf("Buy at best price (%s-%s)", fmtEntryTime(entryTimes[0]), fmtEntryTime(entryTimes[len(entryTimes)-1])))
 
 	// Strategy 3: Only buy when edge > 50% (<50¢)
 	strategyEdge := simulateStrategy(analyses, func(a DayAnalysis) (int, bool) {
@@ -471,7 +409,9 @@ func runSimulatedStrategies(analyses []DayAnalysis) {
 	fmt.Printf("%-30s %7s %7s %9s %12s %8s\n",
 		strings.Repeat("-", 30), "------", "----", "--------", "----------", "------")
 
-	printStrategyResult(strategy10AM)
+	for _, r := range atEntry {
+		printStrategyResult(r)
+	}
 	printStrategyResult(strategyBest)
 	printStrategyResult(strategyEdge)
 	printStrategyResult(strategyModerate)
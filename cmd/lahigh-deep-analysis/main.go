@@ -2,25 +2,18 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
 	"os"
 	"sort"
 	"strings"
 	"time"
-)
-
-type Trade struct {
-	CreatedTime time.Time `json:"created_time"`
-	YesPrice    int       `json:"yes_price"`
-}
 
-type TradesResponse struct {
-	Trades []Trade `json:"trades"`
-}
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
 
 type Market struct {
 	Ticker      string `json:"ticker"`
@@ -29,10 +22,6 @@ type Market struct {
 	Result      string `json:"result"`
 }
 
-type MarketsResponse struct {
-	Markets []Market `json:"markets"`
-}
-
 type DayData struct {
 	Date           time.Time
 	METARMax       int
@@ -44,7 +33,7 @@ type DayData struct {
 }
 
 var loc *time.Location
-var httpClient = &http.Client{Timeout: 15 * time.Second}
+var client = rest.NewPublic(rest.WithRateLimit(rest.DefaultRateLimits()))
 var outputFile *os.File
 
 func init() {
@@ -165,7 +154,6 @@ func fetchDayData(date time.Time) (DayData, error) {
 					dayData.MarketFavorite = m.FloorStrike
 				}
 			}
-			time.Sleep(150 * time.Millisecond)
 		}
 	}
 
@@ -415,7 +403,7 @@ func analyzeTemperatureRanges(data []DayData) {
 			cal = int(math.Round(highSum / float64(len(highTemp))))
 		}
 
-		predictedFloor := ((d.METARMax + cal) / 2) * 2
+		predictedFloor := market.BracketFloors(d.AllMarkets, strikesOf, d.METARMax+cal, 0, 1)[0]
 		if d.WinningFloor == predictedFloor {
 			wins++
 		}
@@ -498,7 +486,7 @@ func createOptimalStrategy(data []DayData) {
 			cal = highCal
 		}
 
-		predictedFloor := ((d.METARMax + cal) / 2) * 2
+		predictedFloor := market.BracketFloors(d.AllMarkets, strikesOf, d.METARMax+cal, 0, 1)[0]
 		price, ok := d.FirstPrices[predictedFloor]
 		if !ok || price == 0 {
 			price = 50
@@ -537,8 +525,8 @@ func createOptimalStrategy(data []DayData) {
 			cal = highCal
 		}
 
-		predictedFloor := ((d.METARMax + cal) / 2) * 2
-		protectFloor := predictedFloor - 2
+		predictedFloor := market.BracketFloors(d.AllMarkets, strikesOf, d.METARMax+cal, 0, 1)[0]
+		protectFloor := market.BracketFloors(d.AllMarkets, strikesOf, d.METARMax+cal, -1, 1)[0]
 
 		thesisPrice, ok1 := d.FirstPrices[predictedFloor]
 		protectPrice, ok2 := d.FirstPrices[protectFloor]
@@ -596,94 +584,53 @@ func createOptimalStrategy(data []DayData) {
 }
 
 func getMETARMax(date time.Time) (int, error) {
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=LAX&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=America/Los_Angeles&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		date.Year(), int(date.Month()), date.Day(),
-		date.Year(), int(date.Month()), date.Day()+1,
-	)
-
-	resp, err := httpClient.Get(url)
+	data, err := weather.FetchMETARMax(weather.Stations["LAX"], date)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	lines := strings.Split(string(body), "\n")
-	maxTemp := 0.0
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, "LAX,") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > maxTemp {
-					maxTemp = temp
-				}
-			}
-		}
-	}
-
-	if maxTemp == 0 {
-		return 0, fmt.Errorf("no data")
-	}
-
-	return int(math.Round(maxTemp)), nil
+	return int(data.MaxTemp), nil
 }
 
 func getWinnerAndMarkets(eventTicker string) (*Market, []Market, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets?event_ticker=%s&limit=100", eventTicker)
-
-	resp, err := httpClient.Get(url)
+	list, err := client.GetMarkets(eventTicker)
 	if err != nil {
 		return nil, nil, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
-	var result MarketsResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, nil, err
+	markets := make([]Market, len(list))
+	for i, m := range list {
+		markets[i] = Market{Ticker: m.Ticker, FloorStrike: int(m.FloorStrike), CapStrike: int(m.CapStrike), Result: m.Result}
 	}
 
 	var winner *Market
-	for i := range result.Markets {
-		if result.Markets[i].Result == "yes" {
-			winner = &result.Markets[i]
+	for i := range markets {
+		if markets[i].Result == "yes" {
+			winner = &markets[i]
 			break
 		}
 	}
 
-	return winner, result.Markets, nil
+	return winner, markets, nil
 }
 
+// getFirstTradePrice returns the YES price of the market's first trade;
+// trades are listed newest first, so every page is read
 func getFirstTradePrice(ticker string) (int, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets/trades?ticker=%s&limit=500", ticker)
-
-	resp, err := httpClient.Get(url)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var result TradesResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return 0, err
+	var first *rest.Trade
+	for trade, err := range client.IterTrades(context.Background(), rest.GetTradesParams{Ticker: ticker, Limit: 1000}) {
+		if err != nil {
+			return 0, err
+		}
+		if first == nil || trade.CreatedTime.Before(first.CreatedTime) {
+			first = &trade
+		}
 	}
 
-	if len(result.Trades) == 0 {
+	if first == nil {
 		return 0, fmt.Errorf("no trades")
 	}
 
-	sort.Slice(result.Trades, func(i, j int) bool {
-		return result.Trades[i].CreatedTime.Before(result.Trades[j].CreatedTime)
-	})
-
-	return result.Trades[0].YesPrice, nil
+	return first.YesPrice, nil
 }
 
 func minInt(nums []int) int {
@@ -712,4 +659,7 @@ func maxInt(nums []int) int {
 	return max
 }
 
-
+// strikesOf returns a market's floor and cap strikes, for market.BracketFloors
+func strikesOf(m Market) (int, int) {
+	return m.FloorStrike, m.CapStrike
+}
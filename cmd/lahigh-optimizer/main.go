@@ -2,15 +2,22 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
 	"math"
 	"net/http"
 	"os"
 	"sort"
 	"strings"
 	"time"
+
+	bt "github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
 type Trade struct {
@@ -50,10 +57,17 @@ type StrategyResult struct {
 	MaxDrawdown    float64
 	SharpeRatio    float64
 	DaysAnalyzed   int
+	Profits        []float64 // Each trade's P&L
+	Wins           int
 }
 
 var loc *time.Location
-var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// client fetches the history; its rate limiter paces the fetch workers
+var client = rest.NewPublic(
+	rest.WithHTTPClient(&http.Client{Timeout: 15 * time.Second}),
+	rest.WithRateLimit(rest.DefaultRateLimits()),
+)
 var results []StrategyResult
 var outputFile *os.File
 
@@ -66,8 +80,18 @@ func init() {
 }
 
 func main() {
+	export := flag.String("export", "", "Directory to export every strategy's summary to (default: none)")
+	format := flag.String("format", "csv", "Export formats: csv, json or both")
+	runs := flag.String("runs", bt.DefaultRunsDir, "Record the best strategy's run in this registry (\"\" = don't)")
+	flag.Parse()
+	formats, err := bt.ParseExportFormats(*format)
+	if err != nil {
+		fmt.Printf("Invalid -format: %v\n", err)
+		return
+	}
+	started := time.Now()
+
 	// Create output file
-	var err error
 	outputFile, err = os.Create("optimization_results.txt")
 	if err != nil {
 		fmt.Printf("Error creating output file: %v\n", err)
@@ -112,8 +136,19 @@ func main() {
 	testAdaptiveStrategies(data)
 	log("")
 
+	// Step 7: Choose on past days, test on the days after
+	log("PHASE 7: Walk-forward validation...")
+	testWalkForward(data)
+	log("")
+
 	// Print final rankings
 	printFinalRankings()
+	if *export != "" {
+		exportResults(*export, started.Format("20060102-150405"), formats)
+	}
+	if *runs != "" {
+		recordBest(*runs, data)
+	}
 
 	log("")
 	log("=" + strings.Repeat("=", 79))
@@ -128,22 +163,27 @@ func log(msg string) {
 	outputFile.WriteString(msg + "\n")
 }
 
+// fetchAllData fetches the last days, bt.DefaultFetchWorkers at a time,
+// most recent first
 func fetchAllData(days int) []DayData {
-	var data []DayData
 	today := time.Now().In(loc)
+	dates := make([]time.Time, days)
+	for i := range dates {
+		dates[i] = today.AddDate(0, 0, -i-1)
+	}
+	fetched, errs := bt.Fetch(context.Background(), dates, bt.FetchConfig{}, func(_ context.Context, date time.Time) (DayData, error) {
+		return fetchDayData(date)
+	})
 
-	for i := 1; i <= days; i++ {
-		targetDate := today.AddDate(0, 0, -i)
-		fmt.Printf("  Fetching %s... ", targetDate.Format("Jan 2"))
-
-		dayData, err := fetchDayData(targetDate)
-		if err != nil {
-			fmt.Printf("❌ %v\n", err)
+	var data []DayData
+	for i, dayData := range fetched {
+		fmt.Printf("  Fetching %s... ", dates[i].Format("Jan 2"))
+		if errs[i] != nil {
+			fmt.Printf("❌ %v\n", errs[i])
 			continue
 		}
 		fmt.Printf("✅ METAR=%d°, Winner=%d°\n", dayData.METARMax, dayData.WinningFloor)
 		data = append(data, dayData)
-		time.Sleep(600 * time.Millisecond) // Rate limiting
 	}
 
 	return data
@@ -184,16 +224,20 @@ func fetchDayData(date time.Time) (DayData, error) {
 			if err == nil && price > 0 {
 				dayData.FirstPrices[m.FloorStrike] = price
 			}
-			time.Sleep(200 * time.Millisecond)
 		}
 	}
 
 	return dayData, nil
 }
 
-func testCalibrations(data []DayData) {
-	calibrations := []int{-1, 0, 1, 2, 3}
+// The parameter values each phase tests
+var (
+	calibrations = []int{-1, 0, 1, 2, 3}
+	hedgeRatios  = [][2]int{{100, 0}, {80, 20}, {70, 30}, {60, 40}, {50, 50}}
+	spreads      = []int{2, 3, 4, 5}
+)
 
+func testCalibrations(data []DayData) {
 	for _, cal := range calibrations {
 		result := runCalibrationTest(data, cal)
 		results = append(results, result)
@@ -208,10 +252,7 @@ func runCalibrationTest(data []DayData, calibration int) StrategyResult {
 	wins := 0
 
 	for _, d := range data {
-		predictedFloor := ((d.METARMax + calibration) / 2) * 2
-		if (d.METARMax+calibration)%2 == 1 {
-			predictedFloor = d.METARMax + calibration - 1
-		}
+		predictedFloor := market.BracketFloors(d.AllMarkets, strikesOf, d.METARMax+calibration, 0, 1)[0]
 
 		price, ok := d.FirstPrices[predictedFloor]
 		if !ok || price == 0 {
@@ -232,9 +273,7 @@ func runCalibrationTest(data []DayData, calibration int) StrategyResult {
 }
 
 func testHedgeRatios(data []DayData) {
-	ratios := [][2]int{{100, 0}, {80, 20}, {70, 30}, {60, 40}, {50, 50}}
-
-	for _, ratio := range ratios {
+	for _, ratio := range hedgeRatios {
 		result := runHedgeTest(data, ratio[0], ratio[1])
 		results = append(results, result)
 		log(fmt.Sprintf("  Hedge %d/%d: Accuracy=%.1f%%, Profit=$%.2f, Sharpe=%.2f",
@@ -252,8 +291,8 @@ func runHedgeTest(data []DayData, thesisPct, protectPct int) StrategyResult {
 
 	for _, d := range data {
 		// Use +1°F calibration
-		predictedFloor := ((d.METARMax + 1) / 2) * 2
-		protectFloor := predictedFloor - 2
+		floors := market.BracketFloors(d.AllMarkets, strikesOf, d.METARMax+1, -1, 2)
+		protectFloor, predictedFloor := floors[0], floors[1]
 
 		thesisPrice, ok1 := d.FirstPrices[predictedFloor]
 		protectPrice, ok2 := d.FirstPrices[protectFloor]
@@ -286,8 +325,6 @@ func runHedgeTest(data []DayData, thesisPct, protectPct int) StrategyResult {
 
 func testMultiBracket(data []DayData) {
 	// Test spreading across multiple brackets
-	spreads := []int{2, 3, 4, 5}
-
 	for _, spread := range spreads {
 		result := runMultiBracketTest(data, spread)
 		results = append(results, result)
@@ -304,14 +341,12 @@ func runMultiBracketTest(data []DayData, numBrackets int) StrategyResult {
 	budgetPerBracket := 14.0 / float64(numBrackets)
 
 	for _, d := range data {
-		predictedFloor := ((d.METARMax + 1) / 2) * 2
-		startFloor := predictedFloor - 2*(numBrackets/2)
+		floors := market.BracketFloors(d.AllMarkets, strikesOf, d.METARMax+1, -(numBrackets / 2), numBrackets)
 
 		var totalCost float64
 		var payout float64
 
-		for i := 0; i < numBrackets; i++ {
-			floor := startFloor + i*2
+		for _, floor := range floors {
 			price, ok := d.FirstPrices[floor]
 			if !ok || price == 0 {
 				price = 50
@@ -333,6 +368,13 @@ func runMultiBracketTest(data []DayData, numBrackets int) StrategyResult {
 }
 
 func testMarketFollowing(data []DayData) {
+	result := runMarketFavorite(data)
+	results = append(results, result)
+	log(fmt.Sprintf("  Market favorite: Accuracy=%.1f%%, Profit=$%.2f, Sharpe=%.2f",
+		result.WinRate*100, result.TotalProfit, result.SharpeRatio))
+}
+
+func runMarketFavorite(data []DayData) StrategyResult {
 	// Strategy: bet on the bracket with lowest first price (market thinks most likely)
 	var profits []float64
 	hits := 0
@@ -361,10 +403,7 @@ func testMarketFollowing(data []DayData) {
 		}
 	}
 
-	result := calculateStats("Market_Favorite", "Bet on bracket with lowest first price", profits, hits)
-	results = append(results, result)
-	log(fmt.Sprintf("  Market favorite: Accuracy=%.1f%%, Profit=$%.2f, Sharpe=%.2f",
-		result.WinRate*100, result.TotalProfit, result.SharpeRatio))
+	return calculateStats("Market_Favorite", "Bet on bracket with lowest first price", profits, hits)
 }
 
 func testAdaptiveStrategies(data []DayData) {
@@ -398,7 +437,7 @@ func runAdaptiveCalibration(data []DayData) StrategyResult {
 			calibration = 2
 		}
 
-		predictedFloor := ((d.METARMax + calibration) / 2) * 2
+		predictedFloor := market.BracketFloors(d.AllMarkets, strikesOf, d.METARMax+calibration, 0, 1)[0]
 
 		price, ok := d.FirstPrices[predictedFloor]
 		if !ok || price == 0 {
@@ -422,8 +461,8 @@ func runConservativeHedge(data []DayData) StrategyResult {
 	hits := 0
 
 	for _, d := range data {
-		predictedFloor := ((d.METARMax + 1) / 2) * 2
-		adjacentFloor := predictedFloor + 2
+		floors := market.BracketFloors(d.AllMarkets, strikesOf, d.METARMax+1, 0, 2)
+		predictedFloor, adjacentFloor := floors[0], floors[1]
 
 		price1, ok1 := d.FirstPrices[predictedFloor]
 		price2, ok2 := d.FirstPrices[adjacentFloor]
@@ -461,7 +500,7 @@ func runValueBetting(data []DayData) StrategyResult {
 	trades := 0
 
 	for _, d := range data {
-		predictedFloor := ((d.METARMax + 1) / 2) * 2
+		predictedFloor := market.BracketFloors(d.AllMarkets, strikesOf, d.METARMax+1, 0, 1)[0]
 
 		price, ok := d.FirstPrices[predictedFloor]
 		if !ok || price == 0 || price >= 30 {
@@ -484,6 +523,74 @@ func runValueBetting(data []DayData) StrategyResult {
 	return result
 }
 
+// candidate is one strategy the phases test, by name
+type candidate struct {
+	name string
+	run  func(data []DayData) StrategyResult
+}
+
+// candidates returns every strategy the phases test
+func candidates() []candidate {
+	var cs []candidate
+	for _, cal := range calibrations {
+		cs = append(cs, candidate{fmt.Sprintf("Calibration_%+d", cal), func(data []DayData) StrategyResult {
+			return runCalibrationTest(data, cal)
+		}})
+	}
+	for _, ratio := range hedgeRatios {
+		cs = append(cs, candidate{fmt.Sprintf("Hedge_%d_%d", ratio[0], ratio[1]), func(data []DayData) StrategyResult {
+			return runHedgeTest(data, ratio[0], ratio[1])
+		}})
+	}
+	for _, spread := range spreads {
+		cs = append(cs, candidate{fmt.Sprintf("Spread_%d_brackets", spread), func(data []DayData) StrategyResult {
+			return runMultiBracketTest(data, spread)
+		}})
+	}
+	return append(cs,
+		candidate{"Market_Favorite", runMarketFavorite},
+		candidate{"Adaptive_Calibration", runAdaptiveCalibration},
+		candidate{"Conservative_2bracket", runConservativeHedge},
+		candidate{"Value_Betting", runValueBetting},
+	)
+}
+
+// testWalkForward picks the strategy with the highest profit per day on
+// each 12-day training window and trades it on the 3 days after. The
+// rankings below are in sample: every strategy is scored on the days it
+// is ranked on
+func testWalkForward(data []DayData) {
+	dates := make([]string, len(data))
+	for i, d := range data {
+		dates[i] = d.Date.In(loc).Format("2006-01-02")
+	}
+	r, err := bt.WalkForward(dates, candidates(), bt.DefaultWalkForwardConfig(), func(c candidate, w bt.Window) float64 {
+		var days []DayData
+		for i, d := range data {
+			if w.Contains(dates[i]) {
+				days = append(days, d)
+			}
+		}
+		return c.run(days).TotalProfit / float64(w.Days)
+	})
+	if err != nil {
+		log(fmt.Sprintf("  Skipped: %v", err))
+		return
+	}
+
+	for _, f := range r.Folds {
+		log(fmt.Sprintf("  Train %s..%s → %-22s In=$%.2f/day, Test %s..%s Out=$%.2f/day",
+			f.Train.From[5:], f.Train.To[5:], f.Params.name, f.InSample, f.Test.From[5:], f.Test.To[5:], f.OutOfSample))
+	}
+	log(fmt.Sprintf("  In sample $%.2f/day, out of sample $%.2f/day: %.0f%% degradation",
+		r.InSample, r.OutOfSample, r.Degradation()*100))
+	if r.OutOfSample <= 0 {
+		log("  ⚠️  Strategies chosen in sample lose money out of sample; treat the rankings below as overfit")
+	} else if !r.Stable(func(c candidate) string { return c.name }) {
+		log("  ⚠️  Folds chose different strategies; the best below may not stay best")
+	}
+}
+
 func calculateStats(name, desc string, profits []float64, wins int) StrategyResult {
 	if len(profits) == 0 {
 		return StrategyResult{Name: name, Description: desc}
@@ -528,6 +635,8 @@ func calculateStats(name, desc string, profits []float64, wins int) StrategyResu
 		MaxDrawdown:  maxDD,
 		SharpeRatio:  sharpe,
 		DaysAnalyzed: len(profits),
+		Profits:      profits,
+		Wins:         wins,
 	}
 }
 
@@ -565,66 +674,112 @@ func printFinalRankings() {
 	log("TOP 3 STRATEGIES - DETAILED")
 	log(strings.Repeat("=", 80))
 
+	// 95% bootstrap intervals: three weeks of days can't tell the top
+	// strategies apart when they overlap
 	for i := 0; i < 3 && i < len(results); i++ {
 		r := results[i]
+		sum := stats.Sample{Profits: r.Profits, Wins: r.Wins, Daily: r.Profits, PeriodsPerYear: 1}.
+			Bootstrap(stats.DefaultBootstrapConfig())
 		log("")
 		log(fmt.Sprintf("#%d: %s", i+1, r.Name))
 		log(fmt.Sprintf("    Description: %s", r.Description))
-		log(fmt.Sprintf("    Win Rate: %.1f%% (%d days)", r.WinRate*100, r.DaysAnalyzed))
+		log(fmt.Sprintf("    Win Rate: %s (%d days)", sum.WinRate.Format("%.1f%%"), r.DaysAnalyzed))
 		log(fmt.Sprintf("    Total Profit: $%.2f", r.TotalProfit))
-		log(fmt.Sprintf("    Avg Profit/Day: $%.2f", r.AvgProfit))
-		log(fmt.Sprintf("    Max Drawdown: $%.2f", r.MaxDrawdown))
-		log(fmt.Sprintf("    Sharpe Ratio: %.2f", r.SharpeRatio))
+		log(fmt.Sprintf("    Avg Profit/Day: %s", sum.EV.Format("$%.2f")))
+		log(fmt.Sprintf("    Max Drawdown: %s", sum.MaxDrawdown.Format("$%.2f")))
+		log(fmt.Sprintf("    Sharpe Ratio: %.2f (95%% CI %.2f to %.2f)", r.SharpeRatio, sum.Sharpe.Lo, sum.Sharpe.Hi))
 	}
 }
 
-func getMETARMax(date time.Time) (int, error) {
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=LAX&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=America/Los_Angeles&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		date.Year(), int(date.Month()), date.Day(),
-		date.Year(), int(date.Month()), date.Day()+1,
-	)
-
-	resp, err := httpClient.Get(url)
+// exportResults writes every strategy's summary, labelled with run, for
+// comparing optimizer runs in pandas or DuckDB
+func exportResults(dir, run string, formats []bt.ExportFormat) {
+	var records bt.Records
+	for _, r := range results {
+		sum := stats.Sample{Profits: r.Profits, Wins: r.Wins, Daily: r.Profits, PeriodsPerYear: 1}.
+			Bootstrap(stats.DefaultBootstrapConfig())
+		records.Summary = append(records.Summary, bt.SummaryRecord{
+			Run:           run,
+			Strategy:      r.Name,
+			Days:          r.DaysAnalyzed,
+			Trades:        len(r.Profits),
+			Profit:        r.TotalProfit,
+			WinRate:       r.WinRate * 100,
+			WinRateLo:     sum.WinRate.Lo,
+			WinRateHi:     sum.WinRate.Hi,
+			EV:            r.AvgProfit,
+			EVLo:          sum.EV.Lo,
+			EVHi:          sum.EV.Hi,
+			Sharpe:        r.SharpeRatio,
+			SharpeLo:      sum.Sharpe.Lo,
+			SharpeHi:      sum.Sharpe.Hi,
+			MaxDrawdown:   r.MaxDrawdown,
+			MaxDrawdownLo: sum.MaxDrawdown.Lo,
+			MaxDrawdownHi: sum.MaxDrawdown.Hi,
+		})
+	}
+	paths, err := records.Export(dir, formats...)
 	if err != nil {
-		return 0, err
+		log(fmt.Sprintf("Failed to export results: %v", err))
+		return
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	lines := strings.Split(string(body), "\n")
-	maxTemp := 0.0
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, "LAX,") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > maxTemp {
-					maxTemp = temp
-				}
-			}
-		}
+	log("Exported " + strings.Join(paths, ", "))
+}
+
+// recordBest records the top-ranked strategy and its metrics over data in
+// the run registry at dir
+func recordBest(dir string, data []DayData) {
+	if len(results) == 0 || len(data) == 0 {
+		return
+	}
+	best := results[0]
+	from, to := data[0].Date.In(loc).Format("2006-01-02"), data[0].Date.In(loc).Format("2006-01-02")
+	for _, d := range data {
+		date := d.Date.In(loc).Format("2006-01-02")
+		from, to = min(from, date), max(to, date)
 	}
 
-	if maxTemp == 0 {
-		return 0, fmt.Errorf("no data")
+	params := map[string]string{"Strategy": best.Name, "Description": best.Description}
+	run, err := bt.NewRunRecord("lahigh-optimizer", best.Name, params, "kalshi:KXHIGHLAX", from, to, stats.DefaultBootstrapConfig().Seed)
+	if err != nil {
+		log(fmt.Sprintf("Failed to record run: %v", err))
+		return
+	}
+	sum := stats.Sample{Profits: best.Profits, Wins: best.Wins, Daily: best.Profits, PeriodsPerYear: 1}.
+		Bootstrap(stats.DefaultBootstrapConfig())
+	run.Metrics = map[string]float64{
+		"trades":       float64(len(best.Profits)),
+		"profit":       best.TotalProfit,
+		"win_rate":     best.WinRate * 100,
+		"win_rate_lo":  sum.WinRate.Lo,
+		"win_rate_hi":  sum.WinRate.Hi,
+		"ev":           best.AvgProfit,
+		"ev_lo":        sum.EV.Lo,
+		"ev_hi":        sum.EV.Hi,
+		"sharpe":       best.SharpeRatio,
+		"max_drawdown": best.MaxDrawdown,
+		"candidates":   float64(len(results)),
+	}
+	if _, err := run.Save(dir); err != nil {
+		log(fmt.Sprintf("Failed to save run: %v", err))
+		return
 	}
+	log("Recorded run " + run.ID)
+}
 
-	return int(math.Round(maxTemp)), nil
+func getMETARMax(date time.Time) (int, error) {
+	data, err := weather.FetchMETARMax(weather.Stations["LAX"], date)
+	if err != nil {
+		return 0, err
+	}
+	return int(data.MaxTemp), nil
 }
 
 func getWinnerAndMarkets(eventTicker string) (*Market, []Market, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets?event_ticker=%s&limit=100", eventTicker)
-
-	resp, err := httpClient.Get(url)
+	body, err := client.Get(fmt.Sprintf("/markets?event_ticker=%s&limit=100", eventTicker))
 	if err != nil {
 		return nil, nil, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
 	var result MarketsResponse
 	if err := json.Unmarshal(body, &result); err != nil {
@@ -643,15 +798,10 @@ func getWinnerAndMarkets(eventTicker string) (*Market, []Market, error) {
 }
 
 func getFirstTradePrice(ticker string) (int, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets/trades?ticker=%s&limit=500", ticker)
-
-	resp, err := httpClient.Get(url)
+	body, err := client.Get(fmt.Sprintf("/markets/trades?ticker=%s&limit=500", ticker))
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
 	var result TradesResponse
 	if err := json.Unmarshal(body, &result); err != nil {
@@ -669,4 +819,7 @@ func getFirstTradePrice(ticker string) (int, error) {
 	return result.Trades[0].YesPrice, nil
 }
 
-
+// strikesOf returns a market's floor and cap strikes, for market.BracketFloors
+func strikesOf(m Market) (int, int) {
+	return m.FloorStrike, m.CapStrike
+}
@@ -2,14 +2,18 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"sort"
 	"strings"
 	"time"
+
+	bt "github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
 )
 
 type Trade struct {
@@ -44,7 +48,13 @@ type DayData struct {
 }
 
 var loc *time.Location
-var httpClient = &http.Client{Timeout: 15 * time.Second}
+
+// client fetches the history; its rate limiter paces the fetch workers
+var client = rest.NewPublic(
+	rest.WithHTTPClient(&http.Client{Timeout: 15 * time.Second}),
+	rest.WithRateLimit(rest.DefaultRateLimits()),
+)
+
 var outputFile *os.File
 
 func init() {
@@ -111,6 +121,8 @@ func main() {
 	log(fmt.Sprintf("BEST THRESHOLD: >=%d¢ with profit $%.2f", bestThreshold, bestProfit))
 	log("")
 
+	walkForward(data)
+
 	// Now test Kelly criterion position sizing
 	log("=" + strings.Repeat("=", 60))
 	log("POSITION SIZING ANALYSIS (at optimal threshold)")
@@ -173,22 +185,27 @@ func log(msg string) {
 	outputFile.WriteString(msg + "\n")
 }
 
+// fetchAllData fetches the last days, bt.DefaultFetchWorkers at a time,
+// most recent first
 func fetchAllData(days int) []DayData {
-	var data []DayData
 	today := time.Now().In(loc)
+	dates := make([]time.Time, days)
+	for i := range dates {
+		dates[i] = today.AddDate(0, 0, -i-1)
+	}
+	fetched, errs := bt.Fetch(context.Background(), dates, bt.FetchConfig{}, func(_ context.Context, date time.Time) (DayData, error) {
+		return fetchDayData(date)
+	})
 
-	for i := 1; i <= days; i++ {
-		targetDate := today.AddDate(0, 0, -i)
-		fmt.Printf("Fetching %s... ", targetDate.Format("Jan 2"))
-
-		dayData, err := fetchDayData(targetDate)
-		if err != nil {
+	var data []DayData
+	for i, dayData := range fetched {
+		fmt.Printf("Fetching %s... ", dates[i].Format("Jan 2"))
+		if errs[i] != nil {
 			fmt.Printf("❌\n")
 			continue
 		}
 		fmt.Printf("✅\n")
 		data = append(data, dayData)
-		time.Sleep(500 * time.Millisecond)
 	}
 	return data
 }
@@ -217,7 +234,6 @@ func fetchDayData(date time.Time) (DayData, error) {
 					Won:        m.FloorStrike == winner.FloorStrike,
 				})
 			}
-			time.Sleep(150 * time.Millisecond)
 		}
 	}
 
@@ -252,6 +268,57 @@ func testThreshold(data []DayData, threshold int) (wins, trades int, profit floa
 	return
 }
 
+// walkForward picks the most profitable threshold on each training window
+// and trades it on the days after, so the best threshold above can be
+// judged on days it wasn't chosen on
+func walkForward(data []DayData) {
+	log("=" + strings.Repeat("=", 60))
+	log("WALK-FORWARD VALIDATION (threshold chosen on 12 days, traded on the next 3)")
+	log("=" + strings.Repeat("=", 60))
+	log("")
+
+	var thresholds []int
+	for threshold := 30; threshold <= 85; threshold += 5 {
+		thresholds = append(thresholds, threshold)
+	}
+	dates := make([]string, len(data))
+	for i, d := range data {
+		dates[i] = d.Date.Format("2006-01-02")
+	}
+	r, err := bt.WalkForward(dates, thresholds, bt.DefaultWalkForwardConfig(), func(threshold int, w bt.Window) float64 {
+		var days []DayData
+		for i, d := range data {
+			if w.Contains(dates[i]) {
+				days = append(days, d)
+			}
+		}
+		_, _, profit := testThreshold(days, threshold)
+		return profit / float64(w.Days)
+	})
+	if err != nil {
+		log(fmt.Sprintf("Skipped: %v", err))
+		log("")
+		return
+	}
+
+	log(fmt.Sprintf("%-14s %-14s %10s %10s %10s", "Train", "Test", "Threshold", "In $/day", "Out $/day"))
+	log(strings.Repeat("-", 62))
+	for _, f := range r.Folds {
+		log(fmt.Sprintf("%-14s %-14s    >=%3d¢ $%9.2f $%9.2f",
+			f.Train.From[5:]+".."+f.Train.To[5:], f.Test.From[5:]+".."+f.Test.To[5:], f.Params, f.InSample, f.OutOfSample))
+	}
+	log("")
+	log(fmt.Sprintf("In sample:     $%.2f/day", r.InSample))
+	log(fmt.Sprintf("Out of sample: $%.2f/day", r.OutOfSample))
+	log(fmt.Sprintf("Degradation:   %.0f%%", r.Degradation()*100))
+	if r.OutOfSample <= 0 {
+		log("WARNING: thresholds chosen in sample lose money out of sample; the best threshold is overfit")
+	} else if r.Degradation() > 0.5 {
+		log("WARNING: over half the in-sample profit is lost out of sample")
+	}
+	log("")
+}
+
 func getAverageOdds(data []DayData, threshold int) int {
 	total := 0
 	count := 0
@@ -402,11 +469,22 @@ func calculateRiskMetrics(data []DayData, threshold int) {
 		}
 	}
 
+	// 95% bootstrap intervals around the metrics of so few trades
+	wins := 0
+	for _, p := range profits {
+		if p > 0 {
+			wins++
+		}
+	}
+	ci := stats.Sample{Profits: profits, Wins: wins, Daily: profits, PeriodsPerYear: 250}.
+		Bootstrap(stats.DefaultBootstrapConfig())
+
 	log(fmt.Sprintf("Number of trades: %d", len(profits)))
-	log(fmt.Sprintf("Mean profit/trade: $%.2f", mean))
+	log(fmt.Sprintf("Win rate: %s", ci.WinRate.Format("%.1f%%")))
+	log(fmt.Sprintf("Mean profit/trade: $%.2f (95%% CI $%.2f to $%.2f)", mean, ci.EV.Lo, ci.EV.Hi))
 	log(fmt.Sprintf("Std deviation: $%.2f", stdDev))
-	log(fmt.Sprintf("Sharpe ratio (annualized): %.2f", sharpe))
-	log(fmt.Sprintf("Max drawdown: $%.2f", maxDD))
+	log(fmt.Sprintf("Sharpe ratio (annualized): %.2f (95%% CI %.2f to %.2f)", sharpe, ci.Sharpe.Lo, ci.Sharpe.Hi))
+	log(fmt.Sprintf("Max drawdown: $%.2f (95%% CI $%.2f to $%.2f)", maxDD, ci.MaxDrawdown.Lo, ci.MaxDrawdown.Hi))
 	log(fmt.Sprintf("Max win streak: %d", maxWinStreak))
 	log(fmt.Sprintf("Max loss streak: %d", maxLossStreak))
 }
@@ -424,15 +502,10 @@ func sqrt(x float64) float64 {
 }
 
 func getWinnerAndMarkets(eventTicker string) (*Market, []Market, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets?event_ticker=%s&limit=100", eventTicker)
-
-	resp, err := httpClient.Get(url)
+	body, err := client.Get(fmt.Sprintf("/markets?event_ticker=%s&limit=100", eventTicker))
 	if err != nil {
 		return nil, nil, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
 	var result MarketsResponse
 	if err := json.Unmarshal(body, &result); err != nil {
@@ -451,15 +524,10 @@ func getWinnerAndMarkets(eventTicker string) (*Market, []Market, error) {
 }
 
 func getFirstTradePrice(ticker string) (int, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets/trades?ticker=%s&limit=500", ticker)
-
-	resp, err := httpClient.Get(url)
+	body, err := client.Get(fmt.Sprintf("/markets/trades?ticker=%s&limit=500", ticker))
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
 	var result TradesResponse
 	if err := json.Unmarshal(body, &result); err != nil {
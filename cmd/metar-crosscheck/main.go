@@ -0,0 +1,153 @@
+// Package main compares daily maximum temperatures computed from the Iowa
+// State (IEM) ASOS archive and the Aviation Weather Center (AWC) METAR feed
+// for the same station and day, and reports systematic differences per
+// station.
+//
+// Backtests and calibrations are built on IEM while several live bots read
+// AWC; this job quantifies the mismatch. It exits non-zero when any station's
+// mean absolute difference exceeds -threshold, so it can run from cron.
+//
+// Usage:
+//
+//	go run ./cmd/metar-crosscheck -cities LAX,NYC,MIA -days 7
+//	go run ./cmd/metar-crosscheck -days 14 -json > crosscheck.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+func main() {
+	cities := flag.String("cities", "", "Comma-separated station codes (default: all stations)")
+	days := flag.Int("days", 7, "Complete days to compare, ending yesterday (AWC keeps about 14)")
+	threshold := flag.Float64("threshold", 0.5, "Fail when a station's mean |AWC - IEM| exceeds this (°F)")
+	asJSON := flag.Bool("json", false, "Print daily comparisons and summary as JSON")
+	flag.Parse()
+
+	codes := stationCodes(*cities)
+	maxDays := weather.AWCMaxHours/24 - 1
+	if *days < 1 || *days > maxDays {
+		fmt.Fprintf(os.Stderr, "-days must be between 1 and %d\n", maxDays)
+		os.Exit(1)
+	}
+
+	var comparisons []weather.DailyMaxComparison
+	for _, code := range codes {
+		station := weather.StationByCode(code)
+		if station == nil {
+			fmt.Fprintf(os.Stderr, "Unknown station %q\n", code)
+			os.Exit(1)
+		}
+		results, err := compareStation(code, station, *days)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", code, err)
+			continue
+		}
+		comparisons = append(comparisons, results...)
+	}
+
+	summary := weather.SummarizeBias(comparisons)
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(struct {
+			Days    []weather.DailyMaxComparison `json:"days"`
+			Summary []weather.SourceBias         `json:"summary"`
+		}{comparisons, summary})
+	} else {
+		printReport(comparisons, summary)
+	}
+
+	failed := false
+	for _, b := range summary {
+		if b.MeanAbsDiff > *threshold {
+			fmt.Fprintf(os.Stderr, "%s: mean |AWC - IEM| %.2f°F exceeds %.2f°F\n", b.Station, b.MeanAbsDiff, *threshold)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(2)
+	}
+}
+
+// compareStation fetches both sources and compares each of the last days
+func compareStation(code string, station *weather.Station, days int) ([]weather.DailyMaxComparison, error) {
+	loc := station.Location()
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	hours := int(now.Sub(today.AddDate(0, 0, -days)).Hours()) + 1
+	awc, err := weather.FetchAWCObservations(station, hours)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []weather.DailyMaxComparison
+	for d := days; d >= 1; d-- {
+		date := today.AddDate(0, 0, -d)
+		iem, err := weather.FetchMETARMax(station, date)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s: %v\n", code, date.Format("2006-01-02"), err)
+			continue
+		}
+		c, err := weather.CompareDailyMax(code, date, iem.Observations, awc)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			continue
+		}
+		results = append(results, c)
+		time.Sleep(200 * time.Millisecond)
+	}
+	return results, nil
+}
+
+func printReport(comparisons []weather.DailyMaxComparison, summary []weather.SourceBias) {
+	fmt.Println("IEM vs AWC daily METAR maximum")
+	fmt.Println(strings.Repeat("=", 64))
+	fmt.Printf("%-6s %-10s %8s %8s %7s %9s\n", "City", "Date", "IEM °F", "AWC °F", "Diff", "Reports")
+	for _, c := range comparisons {
+		flag := ""
+		if c.Mismatch() {
+			flag = "  ← different bracket risk"
+		}
+		fmt.Printf("%-6s %-10s %8.1f %8.1f %+7.1f %4d/%-4d%s\n",
+			c.Station, c.Date.Format("2006-01-02"), c.IEMMax, c.AWCMax, c.Diff(),
+			c.IEMReports, c.AWCReports, flag)
+	}
+
+	fmt.Println()
+	fmt.Println("Per-station bias (AWC - IEM)")
+	fmt.Println(strings.Repeat("=", 64))
+	fmt.Printf("%-6s %5s %9s %9s %9s %11s\n", "City", "Days", "Mean", "Mean|d|", "Max|d|", "Mismatched")
+	for _, b := range summary {
+		fmt.Printf("%-6s %5d %+9.2f %9.2f %9.2f %5d (%3.0f%%)\n",
+			b.Station, b.Days, b.MeanDiff, b.MeanAbsDiff, b.MaxAbsDiff, b.Mismatches, b.MismatchRate()*100)
+	}
+}
+
+// stationCodes parses -cities, defaulting to every registered station
+func stationCodes(cities string) []string {
+	var codes []string
+	if cities == "" {
+		for code := range weather.Stations {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		return codes
+	}
+	for _, c := range strings.Split(cities, ",") {
+		if c = strings.ToUpper(strings.TrimSpace(c)); c != "" {
+			codes = append(codes, c)
+		}
+	}
+	return codes
+}
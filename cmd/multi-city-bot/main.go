@@ -3,19 +3,16 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"log"
-	"math"
-	"net/http"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/brendanplayford/kalshi-go/internal/config"
 	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
 // Configuration
@@ -60,10 +57,6 @@ type Market struct {
 	Volume      int     `json:"volume"`
 }
 
-type MarketsResponse struct {
-	Markets []Market `json:"markets"`
-}
-
 // Trade tracking
 type TradeRecord struct {
 	Timestamp   time.Time
@@ -88,9 +81,8 @@ type BotState struct {
 }
 
 var (
-	client     *rest.Client
-	httpClient = &http.Client{Timeout: 15 * time.Second}
-	state      BotState
+	client *rest.Client
+	state  BotState
 )
 
 func init() {
@@ -370,27 +362,26 @@ func executeTrade(station Station, eventTicker string, market Market, bracket st
 }
 
 func fetchMarkets(eventTicker string) ([]Market, error) {
-	url := fmt.Sprintf("https://api.elections.kalshi.com/trade-api/v2/markets?event_ticker=%s&limit=100", eventTicker)
-	
-	resp, err := httpClient.Get(url)
+	markets, err := client.GetAllMarkets(rest.GetMarketsParams{EventTicker: eventTicker})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	
-	body, _ := io.ReadAll(resp.Body)
-	
-	var result MarketsResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, err
-	}
 	
 	// Filter to bracket markets only
 	var brackets []Market
-	for _, m := range result.Markets {
+	for _, m := range markets {
 		parts := strings.Split(m.Ticker, "-")
 		if len(parts) >= 3 && strings.HasPrefix(parts[len(parts)-1], "B") {
-			brackets = append(brackets, m)
+			brackets = append(brackets, Market{
+				Ticker:      m.Ticker,
+				EventTicker: m.EventTicker,
+				FloorStrike: int(m.FloorStrike),
+				CapStrike:   int(m.CapStrike),
+				Status:      m.Status,
+				YesBid:      float64(m.YesBid),
+				YesAsk:      float64(m.YesAsk),
+				Volume:      m.Volume,
+			})
 		}
 	}
 	
@@ -407,42 +398,11 @@ func fetchMarkets(eventTicker string) ([]Market, error) {
 }
 
 func getMETARMax(station Station, date time.Time) (int, error) {
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=%s&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=%s&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		station.METAR,
-		date.Year(), int(date.Month()), date.Day(),
-		date.Year(), int(date.Month()), date.Day()+1,
-		station.Timezone,
-	)
-	
-	resp, err := httpClient.Get(url)
+	data, err := weather.FetchMETARMax(weather.StationByCode(station.Code), date)
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
-	
-	body, _ := io.ReadAll(resp.Body)
-	lines := strings.Split(string(body), "\n")
-	maxTemp := -999.0
-	
-	for _, line := range lines {
-		if strings.HasPrefix(line, station.METAR+",") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > maxTemp {
-					maxTemp = temp
-				}
-			}
-		}
-	}
-	
-	if maxTemp == -999.0 {
-		return 0, fmt.Errorf("no data")
-	}
-	
-	return int(math.Round(maxTemp)), nil
+	return int(data.MaxTemp), nil
 }
 
 func printStatus() {
@@ -6,12 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"os"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
 )
 
 // Market types
@@ -83,6 +84,7 @@ type DayResult struct {
 	
 	// Data fetched
 	METARMax         int
+	METARMin         int
 	WinningBracket   string
 	WinningFloor     int
 	AllBrackets      []Market
@@ -328,20 +330,21 @@ func analyzeDay(station *Station, marketType MarketType, date time.Time) DayResu
 	result.Signal2_Price = secondPrice
 
 	// Signal 3: METAR-based prediction
-	metarMax, err := getMETARMax(station, date)
+	metar, err := getMETAR(station, date)
 	if err != nil {
 		result.Error = fmt.Sprintf("METAR: %v", err)
 		return result
 	}
-	result.METARMax = metarMax
-	result.Signal3_Temp = metarMax
+	result.METARMax = int(metar.MaxTemp)
+	result.METARMin = int(metar.MinTemp)
 
-	// For HIGH markets, METAR max is the prediction
-	// For LOW markets, we'd need METAR min (simplified: use METAR max - 20 as rough low estimate)
-	predictedTemp := metarMax
+	// For HIGH markets the METAR max is the prediction, for LOW markets the
+	// METAR min, the overnight low
+	predictedTemp := result.METARMax
 	if marketType == MarketTypeLow {
-		predictedTemp = metarMax - 20 // Very rough approximation
+		predictedTemp = result.METARMin
 	}
+	result.Signal3_Temp = predictedTemp
 
 	// Find bracket containing predicted temp
 	for _, m := range markets {
@@ -455,43 +458,9 @@ func getFirstTradePrice(ticker string) (int, error) {
 	return earliest.YesPrice, nil
 }
 
-func getMETARMax(station *Station, date time.Time) (int, error) {
-	url := fmt.Sprintf(
-		"https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?station=%s&data=tmpf&year1=%d&month1=%d&day1=%d&year2=%d&month2=%d&day2=%d&tz=%s&format=onlycomma&latlon=no&elev=no&missing=M&trace=T&direct=no&report_type=3",
-		station.METAR,
-		date.Year(), int(date.Month()), date.Day(),
-		date.Year(), int(date.Month()), date.Day()+1,
-		station.Timezone,
-	)
-
-	resp, err := httpClient.Get(url)
-	if err != nil {
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-	lines := strings.Split(string(body), "\n")
-	maxTemp := -999.0
-
-	for _, line := range lines {
-		if strings.HasPrefix(line, station.METAR+",") {
-			parts := strings.Split(line, ",")
-			if len(parts) >= 3 {
-				var temp float64
-				fmt.Sscanf(parts[2], "%f", &temp)
-				if temp > maxTemp {
-					maxTemp = temp
-				}
-			}
-		}
-	}
-
-	if maxTemp == -999.0 {
-		return 0, fmt.Errorf("no data")
-	}
-
-	return int(math.Round(maxTemp)), nil
+// getMETAR fetches the day's METAR reports with their max and min
+func getMETAR(station *Station, date time.Time) (*weather.METARData, error) {
+	return weather.FetchMETARMax(weather.StationByCode(station.Code), date)
 }
 
 func formatBracket(m *Market) string {
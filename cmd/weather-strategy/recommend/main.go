@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/brendanplayford/kalshi-go/internal/config"
@@ -34,6 +36,16 @@ func main() {
 	// Initialize Kalshi client
 	client := rest.New(cfg.APIKey, cfg.PrivateKey)
 
+	// Resolve NWS grid points from station coordinates (NWS re-grids without
+	// notice, which would silently break the forecast signal)
+	changes, err := weather.ResolveGridPoints(weather.AllStations(), gridCachePath())
+	if err != nil {
+		fmt.Printf("⚠️  NWS grid points: %v (using cached/built-in values)\n", err)
+	}
+	for _, c := range changes {
+		fmt.Printf("🚨 %s\n", c)
+	}
+
 	// Get tomorrow's date
 	tomorrow := time.Now().AddDate(0, 0, 1)
 	fmt.Printf("📅 Analyzing markets for: %s\n\n", tomorrow.Format("Monday, January 2, 2006"))
@@ -76,6 +88,7 @@ func main() {
 					fmt.Printf("   ⚪ HIGH: %s\n", rec.Reason)
 				}
 			}
+			printExcluded(result)
 		}
 
 		// Try LOW temperature market
@@ -99,6 +112,7 @@ func main() {
 					fmt.Printf("   ⚪ LOW:  %s\n", rec.Reason)
 				}
 			}
+			printExcluded(result)
 		}
 
 		fmt.Println()
@@ -183,3 +197,22 @@ func main() {
 	os.Exit(0)
 }
 
+// printExcluded lists the signals left out of the vote for poor health
+func printExcluded(result *strategy.EnsembleResult) {
+	if result == nil {
+		return
+	}
+	for _, sig := range result.Excluded {
+		h := result.Health[sig.Name]
+		fmt.Printf("      ⚠️  %s excluded (health %.2f: %s)\n", sig.Name, h.Score, strings.Join(h.Reasons, ", "))
+	}
+}
+
+// gridCachePath returns where resolved NWS grid points are cached
+func gridCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "kalshi-go", "nws_gridpoints.json")
+}
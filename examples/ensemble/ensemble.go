@@ -0,0 +1,151 @@
+// Package ensemble buys the YES side of a bracket when enough independent
+// signals agree on it: the market favorite, the bracket of the running METAR
+// maximum and (when available) the forecast high
+//
+// Each signal is health-scored first (data age, wide spreads) and unhealthy
+// ones don't vote; the agreeing signals' average health scales the position.
+//
+// It is the signal-agreement core of the dualside and 3signal bots expressed as
+// a strategy.Strategy.
+package ensemble
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// Config configures the strategy
+type Config struct {
+	MinAgreement int     // Signals that must name the same bracket
+	TradeHour    int     // Earliest local hour to trade (METAR max mostly in)
+	MinPrice     int     // Cheapest YES ask to buy (cents)
+	MaxPrice     int     // Most expensive YES ask to buy (cents)
+	Budget       float64 // Dollars per trade
+
+	Health strategy.HealthConfig // Signal health thresholds
+}
+
+// DefaultConfig returns the reference configuration
+func DefaultConfig() Config {
+	return Config{
+		MinAgreement: 2,
+		TradeHour:    14,
+		MinPrice:     30,
+		MaxPrice:     90,
+		Budget:       100,
+		Health:       strategy.DefaultHealthConfig(),
+	}
+}
+
+// Strategy implements strategy.Strategy
+type Strategy struct {
+	config  Config
+	weather map[string]strategy.WeatherUpdate // City -> latest weather
+	markets map[string]strategy.MarketData    // City -> latest snapshot
+	traded  map[string]bool                   // EventTicker -> already traded
+}
+
+// New creates the strategy
+func New(config Config) *Strategy {
+	return &Strategy{
+		config:  config,
+		weather: make(map[string]strategy.WeatherUpdate),
+		markets: make(map[string]strategy.MarketData),
+		traded:  make(map[string]bool),
+	}
+}
+
+func (s *Strategy) Name() string { return "ensemble" }
+
+func (s *Strategy) OnMarketData(data strategy.MarketData) {
+	s.markets[data.City] = data
+}
+
+func (s *Strategy) OnWeatherUpdate(update strategy.WeatherUpdate) {
+	s.weather[update.City] = update
+}
+
+func (s *Strategy) GenerateOrders(now time.Time) []strategy.Order {
+	var orders []strategy.Order
+
+	for city, data := range s.markets {
+		if s.traded[data.EventTicker] || data.Time.Hour() < s.config.TradeHour {
+			continue
+		}
+
+		b := newBallot(s.config.Health)
+		if fav := data.Favorite(); fav != nil {
+			b.vote(fav.Ticker, strategy.SignalQuality{Age: now.Sub(data.Time), Spread: fav.Spread()})
+		}
+		if w, ok := s.weather[city]; ok {
+			if q := data.QuoteFor(int(math.Round(w.MaxTempF))); q != nil {
+				b.vote(q.Ticker, strategy.SignalQuality{Age: now.Sub(w.Time)})
+			}
+			if w.ForecastHighF != 0 {
+				if q := data.QuoteFor(int(math.Round(w.ForecastHighF))); q != nil {
+					quality := strategy.SignalQuality{}
+					if !w.ForecastIssued.IsZero() {
+						quality.Age = now.Sub(w.ForecastIssued)
+					}
+					b.vote(q.Ticker, quality)
+				}
+			}
+		}
+
+		for _, q := range data.Quotes {
+			votes := b.votes[q.Ticker]
+			if votes < s.config.MinAgreement {
+				continue
+			}
+			if q.YesAsk < s.config.MinPrice || q.YesAsk > s.config.MaxPrice {
+				continue
+			}
+
+			s.traded[data.EventTicker] = true
+			orders = append(orders, strategy.Order{
+				EventTicker: data.EventTicker,
+				Ticker:      q.Ticker,
+				Side:        "yes",
+				Action:      "buy",
+				Price:       q.YesAsk,
+				Quantity:    strategy.ContractsFor(s.config.Budget*b.health(q.Ticker), q.YesAsk),
+				Reason:      fmt.Sprintf("%d signals agree", votes),
+			})
+			break
+		}
+	}
+
+	return orders
+}
+
+// ballot tallies health-scored votes per bracket ticker
+type ballot struct {
+	config  strategy.HealthConfig
+	votes   map[string]int
+	weights map[string]float64
+}
+
+func newBallot(config strategy.HealthConfig) *ballot {
+	return &ballot{config: config, votes: make(map[string]int), weights: make(map[string]float64)}
+}
+
+// vote counts a signal for ticker unless it is unhealthy
+func (b *ballot) vote(ticker string, quality strategy.SignalQuality) {
+	h := b.config.Score(quality)
+	if !b.config.Healthy(h) {
+		return
+	}
+	b.votes[ticker]++
+	b.weights[ticker] += h.Score
+}
+
+// health returns the average health of the signals voting for ticker
+func (b *ballot) health(ticker string) float64 {
+	if b.votes[ticker] == 0 {
+		return 0
+	}
+	return b.weights[ticker] / float64(b.votes[ticker])
+}
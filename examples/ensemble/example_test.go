@@ -0,0 +1,15 @@
+package ensemble_test
+
+import (
+	"github.com/brendanplayford/kalshi-go/examples/ensemble"
+	"github.com/brendanplayford/kalshi-go/examples/internal/exampletest"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+// Backtest on the bundled LAX/NYC fixture. The fixture is synthetic, so the
+// output checks the strategy still behaves as it did, not that it makes money
+func Example() {
+	exampletest.Backtest(ensemble.New(ensemble.DefaultConfig()), backtest.DefaultConfig())
+	// Output:
+	// ensemble on synthetic data: 226 trades, 65.9% win, profit $1407.50, fees $683.96
+}
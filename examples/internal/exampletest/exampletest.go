@@ -0,0 +1,27 @@
+// Package exampletest runs the example strategies' testable examples: each
+// backtests its strategy on the synthetic LAX/NYC fixture and prints the
+// same one-line summary
+package exampletest
+
+import (
+	"fmt"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest/fixtures"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// Backtest replays s on the synthetic fixture under cfg and prints its
+// trades, win rate, profit and fees. The numbers are regression values for
+// generated data, not the strategy's live performance, and the summary says
+// so
+func Backtest(s strategy.Strategy, cfg backtest.Config) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		panic(err)
+	}
+
+	r := backtest.Run(ds, s, cfg)
+	fmt.Printf("%s on synthetic data: %d trades, %.1f%% win, profit $%.2f, fees $%.2f\n",
+		r.Strategy, len(r.Trades), r.WinRate, r.TotalProfit, r.TotalFees)
+}
@@ -0,0 +1,27 @@
+package marketmaking_test
+
+import (
+	"github.com/brendanplayford/kalshi-go/examples/internal/exampletest"
+	"github.com/brendanplayford/kalshi-go/examples/marketmaking"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+)
+
+// Backtest on the bundled LAX/NYC fixture, then again under a schedule with
+// no maker fees. The fixture is synthetic, so the output checks the strategy
+// still behaves as it did, not that it makes money
+func Example() {
+	exampletest.Backtest(marketmaking.New(marketmaking.DefaultConfig()), backtest.DefaultConfig())
+
+	cfg := backtest.DefaultConfig()
+	cfg.Fees = fees.NewSchedule(fees.Rule{
+		Series:    fees.AnySeries,
+		Basis:     fees.BasisSpread,
+		TakerRate: 0.07,
+		MakerRate: 0,
+	})
+	exampletest.Backtest(marketmaking.New(marketmaking.DefaultConfig()), cfg)
+	// Output:
+	// market-making on synthetic data: 976 trades, 50.0% win, profit $-648.00, fees $1624.00
+	// market-making on synthetic data: 976 trades, 50.0% win, profit $976.00, fees $0.00
+}
@@ -0,0 +1,106 @@
+// Package marketmaking posts passive bids on both sides of the most liquid
+// brackets, aiming to collect the spread: a YES bid plus a NO bid on the same
+// bracket costs less than the $1 one of them is guaranteed to pay
+//
+// Whether this is profitable depends almost entirely on the maker fee tier, so
+// it is the reference strategy for comparing fee schedules.
+package marketmaking
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// Config configures the strategy
+type Config struct {
+	Brackets  int // Number of highest-priced brackets to quote
+	MinSpread int // Minimum spread (cents) between YES bid and 100 - NO bid
+	Quantity  int // Contracts per side
+}
+
+// DefaultConfig returns the reference configuration
+func DefaultConfig() Config {
+	return Config{
+		Brackets:  2,
+		MinSpread: 2,
+		Quantity:  100,
+	}
+}
+
+// Strategy implements strategy.Strategy
+type Strategy struct {
+	config  Config
+	markets map[string]strategy.MarketData
+	quoted  map[string]bool // EventTicker -> already quoted
+}
+
+// New creates the strategy
+func New(config Config) *Strategy {
+	return &Strategy{
+		config:  config,
+		markets: make(map[string]strategy.MarketData),
+		quoted:  make(map[string]bool),
+	}
+}
+
+func (s *Strategy) Name() string { return "market-making" }
+
+func (s *Strategy) OnMarketData(data strategy.MarketData) {
+	s.markets[data.City] = data
+}
+
+func (s *Strategy) OnWeatherUpdate(update strategy.WeatherUpdate) {}
+
+func (s *Strategy) GenerateOrders(now time.Time) []strategy.Order {
+	var orders []strategy.Order
+
+	for _, data := range s.markets {
+		if s.quoted[data.EventTicker] {
+			continue
+		}
+		s.quoted[data.EventTicker] = true
+
+		quotes := append([]strategy.Quote(nil), data.Quotes...)
+		sort.Slice(quotes, func(i, j int) bool {
+			return quotes[i].YesBid > quotes[j].YesBid
+		})
+
+		for i := 0; i < len(quotes) && i < s.config.Brackets; i++ {
+			q := quotes[i]
+			if q.Determined || q.YesBid == 0 || q.NoBid == 0 {
+				continue
+			}
+			spread := 100 - q.YesBid - q.NoBid
+			if spread < s.config.MinSpread {
+				continue
+			}
+
+			reason := fmt.Sprintf("quote %d/%d, spread %d¢", q.YesBid, q.NoBid, spread)
+			orders = append(orders,
+				strategy.Order{
+					EventTicker: data.EventTicker,
+					Ticker:      q.Ticker,
+					Side:        "yes",
+					Action:      "buy",
+					Price:       q.YesBid,
+					Quantity:    s.config.Quantity,
+					Reason:      reason,
+				},
+				strategy.Order{
+					EventTicker: data.EventTicker,
+					Ticker:      q.Ticker,
+					Side:        "no",
+					Action:      "buy",
+					Price:       q.NoBid,
+					Quantity:    s.config.Quantity,
+					Reason:      reason,
+				},
+			)
+		}
+	}
+
+	return orders
+}
@@ -0,0 +1,15 @@
+package threshold_test
+
+import (
+	"github.com/brendanplayford/kalshi-go/examples/internal/exampletest"
+	"github.com/brendanplayford/kalshi-go/examples/threshold"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+// Backtest on the bundled LAX/NYC fixture. The fixture is synthetic, so the
+// output checks the strategy still behaves as it did, not that it makes money
+func Example() {
+	exampletest.Backtest(threshold.New(threshold.DefaultConfig()), backtest.DefaultConfig())
+	// Output:
+	// threshold-crossing on synthetic data: 39 trades, 100.0% win, profit $846.54, fees $63.72
+}
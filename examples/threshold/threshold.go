@@ -0,0 +1,106 @@
+// Package threshold is a threshold-crossing strategy: once the running METAR
+// maximum has climbed past a bracket (plus a calibration margin), that bracket
+// can no longer settle YES, so its NO side is bought while it is still cheap
+//
+// This is the "dead bracket" idea from the lahigh analysis tools expressed as a
+// strategy.Strategy. The official high can differ from the METAR max, so the
+// rule isn't certain; with LockProbability set (see backtest.VerifyLockIns)
+// the NO side is only bought below its verified fair value.
+package threshold
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// Config configures the strategy
+type Config struct {
+	Margin     int     // Degrees the METAR max must exceed a bracket's cap (covers CLI-METAR calibration)
+	MaxNoPrice int     // Highest NO price worth paying (cents)
+	Budget     float64 // Dollars per trade
+
+	// LockProbability is the verified probability, by city, that a passed
+	// bracket really loses. Cities without an entry assume certainty
+	LockProbability map[string]float64
+	MinEdge         int // Cents below fair value required when LockProbability applies
+}
+
+// DefaultConfig returns the reference configuration
+func DefaultConfig() Config {
+	return Config{
+		Margin:     2,
+		MaxNoPrice: 90,
+		Budget:     100,
+		MinEdge:    2,
+	}
+}
+
+// Strategy implements strategy.Strategy
+type Strategy struct {
+	config  Config
+	maxTemp map[string]float64             // City -> running METAR max
+	markets map[string]strategy.MarketData // City -> latest snapshot
+	traded  map[string]bool                // Ticker -> already bought
+}
+
+// New creates the strategy
+func New(config Config) *Strategy {
+	return &Strategy{
+		config:  config,
+		maxTemp: make(map[string]float64),
+		markets: make(map[string]strategy.MarketData),
+		traded:  make(map[string]bool),
+	}
+}
+
+func (s *Strategy) Name() string { return "threshold-crossing" }
+
+func (s *Strategy) OnMarketData(data strategy.MarketData) {
+	s.markets[data.City] = data
+}
+
+func (s *Strategy) OnWeatherUpdate(update strategy.WeatherUpdate) {
+	s.maxTemp[update.City] = update.MaxTempF
+}
+
+func (s *Strategy) GenerateOrders(now time.Time) []strategy.Order {
+	var orders []strategy.Order
+
+	for city, data := range s.markets {
+		maxTemp, ok := s.maxTemp[city]
+		if !ok {
+			continue
+		}
+		maxPrice := s.config.MaxNoPrice
+		if p, ok := s.config.LockProbability[city]; ok {
+			maxPrice = min(maxPrice, int(p*100)-s.config.MinEdge)
+		}
+
+		for _, q := range data.Quotes {
+			if q.Cap == strategy.OpenCap || s.traded[q.Ticker] {
+				continue
+			}
+			if maxTemp < float64(q.Cap+s.config.Margin) {
+				continue
+			}
+			if q.NoAsk == 0 || q.NoAsk > maxPrice {
+				continue
+			}
+
+			s.traded[q.Ticker] = true
+			orders = append(orders, strategy.Order{
+				EventTicker: data.EventTicker,
+				Ticker:      q.Ticker,
+				Side:        "no",
+				Action:      "buy",
+				Price:       q.NoAsk,
+				Quantity:    strategy.ContractsFor(s.config.Budget, q.NoAsk),
+				Reason:      fmt.Sprintf("METAR max %.0f° crossed cap %d°", maxTemp, q.Cap),
+			})
+		}
+	}
+
+	return orders
+}
@@ -0,0 +1,15 @@
+package valuebet_test
+
+import (
+	"github.com/brendanplayford/kalshi-go/examples/internal/exampletest"
+	"github.com/brendanplayford/kalshi-go/examples/valuebet"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+// Backtest on the bundled LAX/NYC fixture. The fixture is synthetic, so the
+// output checks the strategy still behaves as it did, not that it makes money
+func Example() {
+	exampletest.Backtest(valuebet.New(valuebet.DefaultConfig()), backtest.DefaultConfig())
+	// Output:
+	// value-betting on synthetic data: 67 trades, 14.9% win, profit $-1869.83, fees $73.46
+}
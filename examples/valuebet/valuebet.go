@@ -0,0 +1,123 @@
+// Package valuebet buys brackets whose modeled probability exceeds the
+// market's price by a minimum edge
+//
+// The model is the normal-CDF approach of lahigh-trader (bracket probabilities
+// come from pkg/model): the final high is centered on the running METAR
+// maximum plus the typical rise still to come, with an uncertainty that
+// shrinks as the afternoon peak passes.
+package valuebet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// Config configures the strategy
+type Config struct {
+	MinEdge     float64 // Required model probability minus price (0-1)
+	MinPrice    int     // Cheapest YES ask to consider (cents)
+	StartHour   int     // Earliest local hour to trade
+	PeakHour    int     // Local hour after which the high is usually set
+	RisePerHour float64 // Expected °F rise per hour before PeakHour
+	Calibration float64 // Mean official high minus METAR max (°F)
+	Budget      float64 // Dollars per trade
+}
+
+// DefaultConfig returns the reference configuration
+func DefaultConfig() Config {
+	return Config{
+		MinEdge:     0.15,
+		MinPrice:    5,
+		StartHour:   15,
+		PeakHour:    15,
+		RisePerHour: 1.0,
+		Calibration: 0.1,
+		Budget:      50,
+	}
+}
+
+// Strategy implements strategy.Strategy
+type Strategy struct {
+	config  Config
+	weather map[string]strategy.WeatherUpdate
+	markets map[string]strategy.MarketData
+	traded  map[string]bool // EventTicker -> already traded
+}
+
+// New creates the strategy
+func New(config Config) *Strategy {
+	return &Strategy{
+		config:  config,
+		weather: make(map[string]strategy.WeatherUpdate),
+		markets: make(map[string]strategy.MarketData),
+		traded:  make(map[string]bool),
+	}
+}
+
+func (s *Strategy) Name() string { return "value-betting" }
+
+func (s *Strategy) OnMarketData(data strategy.MarketData) {
+	s.markets[data.City] = data
+}
+
+func (s *Strategy) OnWeatherUpdate(update strategy.WeatherUpdate) {
+	s.weather[update.City] = update
+}
+
+func (s *Strategy) GenerateOrders(now time.Time) []strategy.Order {
+	var orders []strategy.Order
+
+	for city, data := range s.markets {
+		w, ok := s.weather[city]
+		if !ok || s.traded[data.EventTicker] || data.Time.Hour() < s.config.StartHour {
+			continue
+		}
+		mean, sd := s.model(w, data.Time.Hour())
+
+		// Take the single largest edge on the event
+		var best *strategy.Quote
+		bestEdge := s.config.MinEdge
+		for i, q := range data.Quotes {
+			if q.YesAsk < s.config.MinPrice {
+				continue
+			}
+			edge := Probability(q, mean, sd) - float64(q.YesAsk)/100
+			if edge >= bestEdge {
+				best, bestEdge = &data.Quotes[i], edge
+			}
+		}
+		if best == nil {
+			continue
+		}
+
+		s.traded[data.EventTicker] = true
+		orders = append(orders, strategy.Order{
+			EventTicker: data.EventTicker,
+			Ticker:      best.Ticker,
+			Side:        "yes",
+			Action:      "buy",
+			Price:       best.YesAsk,
+			Quantity:    strategy.ContractsFor(s.config.Budget, best.YesAsk),
+			Reason:      fmt.Sprintf("model %.0f±%.1f°, edge %.0f%%", mean, sd, bestEdge*100),
+		})
+	}
+
+	return orders
+}
+
+// model returns the mean and standard deviation of the final official high
+func (s *Strategy) model(w strategy.WeatherUpdate, hour int) (float64, float64) {
+	remaining := float64(max(s.config.PeakHour-hour, 0))
+	mean := w.MaxTempF + remaining*s.config.RisePerHour + s.config.Calibration
+	sd := 1.0 + 0.5*remaining
+	return mean, sd
+}
+
+// Probability returns P(low <= high <= cap) for a normal(mean, sd) high with a
+// half-degree continuity correction
+func Probability(q strategy.Quote, mean, sd float64) float64 {
+	return model.Forecast{Mean: mean, StdDev: sd}.Probability(q.Floor, q.Cap)
+}
@@ -4,4 +4,4 @@ go 1.24.6
 
 require github.com/gorilla/websocket v1.5.3
 
-require github.com/mattn/go-sqlite3 v1.14.32 // indirect
+require github.com/mattn/go-sqlite3 v1.14.32
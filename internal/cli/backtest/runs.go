@@ -0,0 +1,203 @@
+package backtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+// runRuns dispatches the runs subcommands
+func runRuns(args []string) int {
+	if len(args) < 1 {
+		runsUsage()
+		return 2
+	}
+	switch args[0] {
+	case "list":
+		return listRuns(args[1:])
+	case "show":
+		return showRun(args[1:])
+	case "compare":
+		return compareRuns(args[1:])
+	}
+	runsUsage()
+	return 2
+}
+
+func runsUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: kalshi backtest runs list [-dir DIR] [-tool NAME] [-strategy NAME]")
+	fmt.Fprintln(os.Stderr, "       kalshi backtest runs show [-dir DIR] ID")
+	fmt.Fprintln(os.Stderr, "       kalshi backtest runs compare [-dir DIR] ID_A ID_B")
+}
+
+// listRuns prints the recorded runs, oldest first
+func listRuns(args []string) int {
+	fs := flag.NewFlagSet("runs list", flag.ExitOnError)
+	dir := fs.String("dir", backtest.DefaultRunsDir, "Run registry directory")
+	tool := fs.String("tool", "", "Only runs of this command")
+	strategyName := fs.String("strategy", "", "Only runs of this strategy")
+	fs.Parse(args)
+
+	runs, err := backtest.ListRunRecords(*dir)
+	if err != nil {
+		fmt.Printf("❌ Failed to list runs: %v\n", err)
+		return 1
+	}
+	fmt.Printf("%-30s %-17s %-14s %-16s %-22s %7s %12s\n", "ID", "Created", "Commit", "Strategy", "Window", "Trades", "Profit")
+	fmt.Println(strings.Repeat("-", 124))
+	for _, r := range runs {
+		if (*tool != "" && r.Tool != *tool) || (*strategyName != "" && !strings.EqualFold(r.Strategy, *strategyName)) {
+			continue
+		}
+		fmt.Printf("%-30s %-17s %-14s %-16s %-22s %7s %12s\n",
+			r.ID, r.Created.Local().Format("2006-01-02 15:04"), shortCommit(r.Commit), r.Strategy,
+			r.From+".."+r.To, metric(r, "trades", "%.0f"), metric(r, "profit", "$%.2f"))
+	}
+	return 0
+}
+
+// showRun prints a run in full, parameters included
+func showRun(args []string) int {
+	fs := flag.NewFlagSet("runs show", flag.ExitOnError)
+	dir := fs.String("dir", backtest.DefaultRunsDir, "Run registry directory")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		runsUsage()
+		return 2
+	}
+
+	r, err := backtest.LoadRunRecord(*dir, fs.Arg(0))
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	fmt.Printf("ID:       %s\n", r.ID)
+	fmt.Printf("Tool:     %s\n", r.Tool)
+	fmt.Printf("Strategy: %s\n", r.Strategy)
+	fmt.Printf("Commit:   %s\n", orUnknown(r.Commit))
+	fmt.Printf("Dataset:  %s, %s to %s\n", r.Dataset, r.From, r.To)
+	fmt.Printf("Seed:     %d\n", r.Seed)
+	fmt.Printf("Created:  %s\n", r.Created.Local().Format("2006-01-02 15:04:05"))
+
+	var params bytes.Buffer
+	if err := json.Indent(&params, r.Params, "  ", "  "); err == nil {
+		fmt.Printf("\nParameters:\n  %s\n", params.String())
+	}
+	fmt.Println("\nMetrics:")
+	names := make([]string, 0, len(r.Metrics))
+	for name := range r.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %-16s %12.4f\n", name, r.Metrics[name])
+	}
+	return 0
+}
+
+// compareRuns prints how two runs' code, data, parameters and metrics differ
+func compareRuns(args []string) int {
+	fs := flag.NewFlagSet("runs compare", flag.ExitOnError)
+	dir := fs.String("dir", backtest.DefaultRunsDir, "Run registry directory")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		runsUsage()
+		return 2
+	}
+
+	a, err := backtest.LoadRunRecord(*dir, fs.Arg(0))
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	b, err := backtest.LoadRunRecord(*dir, fs.Arg(1))
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("A: %s  %s by %s at %s on %s, %s to %s\n", a.ID, a.Strategy, a.Tool, shortCommit(a.Commit), a.Dataset, a.From, a.To)
+	fmt.Printf("B: %s  %s by %s at %s on %s, %s to %s\n", b.ID, b.Strategy, b.Tool, shortCommit(b.Commit), b.Dataset, b.From, b.To)
+	c := backtest.CompareRunRecords(a, b)
+	if !c.SameCode {
+		fmt.Println("⚠️  The runs are of different commits: a difference may be the code's, not the parameters'")
+	}
+	if !c.SameData {
+		fmt.Println("⚠️  The runs are over different data: their metrics aren't directly comparable")
+	}
+	if a.Seed != b.Seed {
+		fmt.Printf("⚠️  The runs drew with different seeds (%d, %d)\n", a.Seed, b.Seed)
+	}
+
+	if len(c.Params) == 0 {
+		fmt.Println("\nParameters: identical")
+	} else {
+		fmt.Println("\nParameters:")
+		for _, p := range c.Params {
+			fmt.Printf("  %s: %s → %s\n", p.Path, orNone(p.A), orNone(p.B))
+		}
+	}
+
+	fmt.Printf("\n%-16s %12s %12s %12s\n", "Metric", "A", "B", "Delta")
+	fmt.Println(strings.Repeat("-", 55))
+	for _, m := range c.Metrics {
+		delta := "-"
+		if d := m.Delta(); !math.IsNaN(d) {
+			delta = fmt.Sprintf("%+.4f", d)
+		}
+		fmt.Printf("%-16s %12s %12s %12s\n", m.Name, value(m.A), value(m.B), delta)
+	}
+	return 0
+}
+
+// metric formats one of a run's metrics, or "-" when it has none by name
+func metric(r *backtest.RunRecord, name, format string) string {
+	v, ok := r.Metrics[name]
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf(format, v)
+}
+
+func value(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	return fmt.Sprintf("%.4f", v)
+}
+
+// shortCommit abbreviates a commit hash, keeping a -dirty suffix
+func shortCommit(commit string) string {
+	if commit == "" {
+		return "unknown"
+	}
+	hash, dirty, _ := strings.Cut(commit, "-")
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+	if dirty != "" {
+		return hash + "-" + dirty
+	}
+	return hash
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+// Package cli holds what the kalshi command's subcommands share: the
+// exchange flags, loading the credentials and building the API clients.
+// Each subcommand lives in a package of its own under internal/cli with a
+// Main(args []string) int entry point, which the kalshi command and the
+// compatibility wrappers under cmd/ both call.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/brendanplayford/kalshi-go/internal/config"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// ErrNoCredentials is returned when the API key or private key is not
+// configured.
+var ErrNoCredentials = errors.New("KALSHI_API_KEY and KALSHI_PRIVATE_KEY are required (run `kalshi doctor`)")
+
+// Exchange is the Kalshi environment a command talks to.
+type Exchange struct {
+	// Demo selects the demo environment, where no real money is at risk.
+	Demo bool
+}
+
+// Register adds the exchange flags to fs.
+func (x *Exchange) Register(fs *flag.FlagSet) {
+	fs.BoolVar(&x.Demo, "demo", false, "Use the demo environment (no real money)")
+}
+
+// Config loads the credentials from the environment and .env, failing if
+// they are missing.
+func (x *Exchange) Config() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+	if !cfg.IsAuthenticated() {
+		return nil, ErrNoCredentials
+	}
+	return cfg, nil
+}
+
+// REST returns a REST client for the environment, signed with cfg's key and
+// paced by the default rate limits. opts are applied after these.
+func (x *Exchange) REST(cfg *config.Config, opts ...rest.Option) *rest.Client {
+	base := []rest.Option{rest.WithRateLimit(rest.DefaultRateLimits())}
+	if x.Demo {
+		base = append(base, rest.WithDemo())
+	}
+	return rest.New(cfg.APIKey, cfg.PrivateKey, append(base, opts...)...)
+}
+
+// WS returns a WebSocket client for the environment, signed with cfg's key.
+// opts are applied after these.
+func (x *Exchange) WS(cfg *config.Config, opts ...ws.Option) *ws.Client {
+	base := []ws.Option{ws.WithAPIKey(cfg.APIKey, cfg.PrivateKey), ws.WithBaseURL(x.WSURL(cfg))}
+	return ws.New(append(base, opts...)...)
+}
+
+// WSURL returns the WebSocket URL of the environment: KALSHI_WS_URL when
+// set, else the demo or production URL.
+func (x *Exchange) WSURL(cfg *config.Config) string {
+	switch {
+	case cfg != nil && cfg.BaseURL != "":
+		return cfg.BaseURL
+	case x.Demo:
+		return ws.DemoBaseURL
+	}
+	return ws.DefaultBaseURL
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/internal/config"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+func TestExchange_WSURL(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		cfg  *config.Config
+		want string
+	}{
+		{"production", nil, &config.Config{}, ws.DefaultBaseURL},
+		{"demo", []string{"-demo"}, &config.Config{}, ws.DemoBaseURL},
+		{"env override", []string{"-demo"}, &config.Config{BaseURL: "wss://localhost:8080/ws"}, "wss://localhost:8080/ws"},
+		{"no config", nil, nil, ws.DefaultBaseURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			var x Exchange
+			x.Register(fs)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := x.WSURL(tt.cfg); got != tt.want {
+				t.Errorf("WSURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package trade
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+)
+
+// calibrationReload is how often the -calibration file is reread for the
+// days the production bot has learned from since
+const calibrationReload = time.Hour
+
+// learned is the calibration read from -calibration (nil = cliCalibration
+// everywhere)
+var learned atomic.Pointer[model.Calibration]
+
+// loadCalibration reads the calibration at path now and every
+// calibrationReload until ctx is canceled
+func loadCalibration(ctx context.Context, path string) error {
+	c, err := model.LoadCalibration(path)
+	if err != nil {
+		return err
+	}
+	learned.Store(c)
+
+	go func() {
+		ticker := time.NewTicker(calibrationReload)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			c, err := model.LoadCalibration(path)
+			if err != nil {
+				fmt.Printf("⚠ Keeping the last calibration: %v\n", err)
+				continue
+			}
+			learned.Store(c)
+		}
+	}()
+	return nil
+}
+
+// calibration returns the METAR to CLI adjustment for the event: learned for
+// its station, kind and regime with -calibration, else cliCalibration
+func (s *TradingState) calibration() float64 {
+	if c := learned.Load(); c != nil {
+		return c.Offset(s.City, s.Low, s.Date, s.running())
+	}
+	return cliCalibration
+}
+
+// cliOffset returns the calibration in whole degrees, as the CLI reports
+func (s *TradingState) cliOffset() int {
+	return int(math.Round(s.calibration()))
+}
@@ -0,0 +1,107 @@
+package trade
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// OpportunityEvent is an Opportunity as a structured JSON event. Events are
+// written one per line (JSON Lines) so external systems (Zapier, dashboards)
+// can consume signals without parsing the console output
+type OpportunityEvent struct {
+	Type       string      `json:"type"` // Always "opportunity"
+	Time       time.Time   `json:"time"`
+	Event      string      `json:"event"`
+	Market     string      `json:"market"`
+	Strike     string      `json:"strike"`
+	Side       string      `json:"side"`  // "yes" or "no"
+	Price      int         `json:"price"` // Limit price in cents
+	Size       int         `json:"size"`  // Contracts
+	Edge       float64     `json:"edge"`  // Model probability minus implied probability, signed toward YES
+	Confidence string      `json:"confidence"`
+	Model      ModelInputs `json:"model"`
+}
+
+// ModelInputs are the weather and market inputs behind an opportunity
+type ModelInputs struct {
+	CurrentTempF int       `json:"current_temp_f"`
+	RunningMaxF  int       `json:"running_max_f"`
+	NWSForecastF int       `json:"nws_forecast_f"`
+	ExpectedMaxF int       `json:"expected_max_f"`
+	Low          bool      `json:"low"`            // A LOW event, settling on the minimum
+	RunningMinF  int       `json:"running_min_f"`  // LOW events only
+	ExpectedMinF int       `json:"expected_min_f"` // LOW events only
+	StdDevF      float64   `json:"std_dev_f"`
+	LowBound     int       `json:"low_bound"`
+	HighBound    int       `json:"high_bound"` // 999 for "X or above"
+	ModelProb    float64   `json:"model_prob"`
+	ImpliedProb  float64   `json:"implied_prob"` // From the YES ask
+	YesBid       int       `json:"yes_bid"`
+	YesAsk       int       `json:"yes_ask"`
+	NoBid        int       `json:"no_bid"`
+	NoAsk        int       `json:"no_ask"`
+	ObservedAt   time.Time `json:"observed_at"` // Time of the latest METAR
+}
+
+// eventWriter appends opportunity events to a file or named pipe
+type eventWriter struct {
+	mu     sync.Mutex
+	file   *os.File
+	enc    *json.Encoder
+	failed bool
+}
+
+// openEvents opens path for appending, creating it if needed. A named pipe
+// (mkfifo) works too, for streaming events to another process
+func openEvents(path string) (*eventWriter, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open events file: %w", err)
+	}
+	return &eventWriter{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Emit writes one event per opportunity. Write errors are reported once and
+// never stop trading
+func (w *eventWriter) Emit(eventTicker string, opps []Opportunity) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now().UTC()
+	for _, opp := range opps {
+		err := w.enc.Encode(OpportunityEvent{
+			Type:       "opportunity",
+			Time:       now,
+			Event:      eventTicker,
+			Market:     opp.Ticker,
+			Strike:     opp.Strike,
+			Side:       string(opp.Side),
+			Price:      opp.Price,
+			Size:       opp.Contracts,
+			Edge:       opp.Edge,
+			Confidence: opp.Confidence,
+			Model:      opp.Model,
+		})
+		if err != nil {
+			if !w.failed {
+				fmt.Printf("⚠ Failed to write opportunity event: %v\n", err)
+				w.failed = true
+			}
+			return
+		}
+	}
+	w.failed = false
+}
+
+func (w *eventWriter) Close() error {
+	if w == nil {
+		return nil
+	}
+	return w.file.Close()
+}
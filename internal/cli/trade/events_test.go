@@ -0,0 +1,142 @@
+package trade
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/sizing"
+)
+
+func TestFindOpportunities_Events(t *testing.T) {
+	oldSizer := sizer
+	t.Cleanup(func() { sizer = oldSizer })
+	sizer = sizing.New(sizing.FixedRisk{Dollars: 10}, sizing.Limits{})
+
+	state := &TradingState{
+		Event:        "KXHIGHLAX-25DEC27",
+		RunningMaxF:  63,
+		NWSForecastF: 62,
+		ExpectedMaxF: 64,
+		ModelStdDevF: 1.5,
+		Balance:      10000,
+		Markets: map[string]*MarketState{
+			// The model says 17% against the 28¢ ask: buy NO at 74¢
+			"KXHIGHLAX-25DEC27-B60.5": {Ticker: "KXHIGHLAX-25DEC27-B60.5", Strike: "60° to 61°", LowBound: 60, HighBound: 61,
+				YesBid: 26, YesAsk: 28, NoBid: 72, NoAsk: 74, ModelProb: 0.17, Edge: -0.11},
+			// Within the minimum edge: no event
+			"KXHIGHLAX-25DEC27-B62.5": {Ticker: "KXHIGHLAX-25DEC27-B62.5", Strike: "62° to 63°", LowBound: 62, HighBound: 63,
+				YesBid: 40, YesAsk: 42, NoBid: 58, NoAsk: 60, ModelProb: 0.44, Edge: 0.02},
+		},
+	}
+	opps := findOpportunities(state)
+	if len(opps) != 1 {
+		t.Fatalf("found %d opportunities, want 1", len(opps))
+	}
+
+	path := filepath.Join(t.TempDir(), "opportunities.jsonl")
+	w, err := openEvents(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Emit(state.Event, opps)
+	w.Emit(state.Event, nil) // Nothing to write
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening appends
+	w, err = openEvents(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Emit(state.Event, opps)
+	w.Close()
+
+	events := readEvents(t, path)
+	if len(events) != 2 {
+		t.Fatalf("wrote %d events, want 2", len(events))
+	}
+	got := events[0]
+	if got.Time.IsZero() {
+		t.Error("event time not set")
+	}
+	want := OpportunityEvent{
+		Type:       "opportunity",
+		Time:       got.Time,
+		Event:      "KXHIGHLAX-25DEC27",
+		Market:     "KXHIGHLAX-25DEC27-B60.5",
+		Strike:     "60° to 61°",
+		Side:       string(rest.SideNo),
+		Price:      74,
+		Size:       opps[0].Contracts,
+		Edge:       -0.11,
+		Confidence: "MEDIUM",
+		Model: ModelInputs{
+			RunningMaxF: 63, NWSForecastF: 62, ExpectedMaxF: 64, RunningMinF: 62, StdDevF: 1.5,
+			LowBound: 60, HighBound: 61, ModelProb: 0.17, ImpliedProb: 0.28,
+			YesBid: 26, YesAsk: 28, NoBid: 72, NoAsk: 74,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("event = %+v, want %+v", got, want)
+	}
+	if got.Size == 0 {
+		t.Error("event size = 0, want the sized contracts")
+	}
+}
+
+func TestEventWriter_Errors(t *testing.T) {
+	if _, err := openEvents(filepath.Join(t.TempDir(), "missing", "events.jsonl")); err == nil {
+		t.Error("openEvents() in a missing directory succeeded")
+	}
+
+	// A nil writer (no -events) does nothing
+	var none *eventWriter
+	none.Emit("KXHIGHLAX-25DEC27", []Opportunity{{Ticker: "KXHIGHLAX-25DEC27-B60.5"}})
+	if err := none.Close(); err != nil {
+		t.Errorf("nil writer: Close() error = %v", err)
+	}
+
+	// A failed write is reported once and never panics
+	w, err := openEvents(filepath.Join(t.TempDir(), "events.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	opps := []Opportunity{{Ticker: "KXHIGHLAX-25DEC27-B60.5"}, {Ticker: "KXHIGHLAX-25DEC27-B62.5"}}
+	for range 2 {
+		w.Emit("KXHIGHLAX-25DEC27", opps)
+		if !w.failed {
+			t.Error("write to a closed file not flagged as failed")
+		}
+	}
+}
+
+// readEvents decodes the JSON Lines file at path
+func readEvents(t *testing.T, path string) []OpportunityEvent {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var events []OpportunityEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e OpportunityEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
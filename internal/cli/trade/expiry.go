@@ -0,0 +1,48 @@
+package trade
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+var (
+	// No entries near a market's close; positions held into its thin
+	// end-of-day book are flagged
+	expiryGuard = strategy.DefaultExpiryGuard()
+	expiryWatch *strategy.ExpiryWatch
+)
+
+// watchExpiry warns once per held market as it comes within the thin-book
+// window of its close. Positions are only fetched once a market is in the
+// window
+func watchExpiry(state *TradingState, client *rest.Client) {
+	now := time.Now()
+	thin := false
+	for _, m := range state.Markets {
+		if expiryGuard.InThinBook(m.Closes, now) {
+			thin = true
+			break
+		}
+	}
+	if !thin {
+		return
+	}
+
+	positions, err := client.GetPositions()
+	if err != nil {
+		return
+	}
+	for _, p := range positions {
+		m, ok := state.Markets[p.Ticker]
+		if !ok || (p.YesPosition == 0 && p.NoPosition == 0) {
+			continue
+		}
+		if expiryWatch.Check(p.Ticker, m.Closes, now) {
+			fmt.Printf("\n⚠️  [%s] Holding %s (YES=%d, NO=%d) into the thin book, %v before close\n",
+				state.Event, m.Strike, p.YesPosition, p.NoPosition, m.Closes.Sub(now).Round(time.Minute))
+		}
+	}
+}
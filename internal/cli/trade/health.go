@@ -0,0 +1,28 @@
+package trade
+
+import (
+	"errors"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/service"
+)
+
+// Health components of the trader
+const (
+	healthMETAR     = "metar"
+	healthKalshi    = "kalshi"
+	healthWebSocket = "websocket"
+)
+
+// newHealth tracks the METAR and Kalshi feeds, stale after staleAfter, and
+// the WebSocket, which may be down that long while reconnecting
+func newHealth(staleAfter time.Duration) *service.Health {
+	h := service.NewHealth()
+	h.Feed(healthMETAR, staleAfter, true)
+	h.Feed(healthKalshi, staleAfter, true)
+	h.Connection(healthWebSocket, staleAfter, true)
+	return h
+}
+
+// errNoObservations is a METAR response without reports
+var errNoObservations = errors.New("no METAR observations")
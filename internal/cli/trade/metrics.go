@@ -0,0 +1,69 @@
+package trade
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/brendanplayford/kalshi-go/internal/service"
+	"github.com/brendanplayford/kalshi-go/pkg/metrics"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/risk"
+)
+
+// serveMetrics serves Prometheus metrics and health on addr and returns the
+// metrics for recording
+func serveMetrics(addr string, health *service.Health) *metrics.Trading {
+	registry := metrics.NewRegistry()
+	m := metrics.NewTrading(registry)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", registry.Handler())
+	mux.Handle("/health", health.Handler())
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			fmt.Printf("⚠ Metrics server stopped: %v\n", err)
+		}
+	}()
+	return m
+}
+
+// rejectReason labels why an order wasn't placed: a local check, the
+// exchange's rejection class, or api_error for any other failure
+func rejectReason(err error) string {
+	switch {
+	case errors.Is(err, risk.ErrHalted):
+		return "halted"
+	case errors.Is(err, rest.ErrOrderBounds):
+		return "order_bounds"
+	case errors.Is(err, rest.ErrInvalidPrice):
+		return "invalid_price"
+	}
+	if class, ok := rest.ClassifyRejection(err); ok {
+		return string(class)
+	}
+	return "api_error"
+}
+
+// recordAccount sets the balance and exposure gauges from the account
+func recordAccount(client *rest.Client) {
+	if tradingMetrics == nil {
+		return
+	}
+	balance, err := client.GetBalance()
+	traderHealth.Observe(healthKalshi, err)
+	if err == nil {
+		tradingMetrics.SetBalance(float64(balance.Balance) / 100)
+	}
+	positions, err := client.GetPositions()
+	if err != nil {
+		return
+	}
+	exposure := 0
+	for _, p := range positions {
+		if p.YesPosition > 0 || p.NoPosition > 0 {
+			exposure += p.TotalCost
+		}
+	}
+	tradingMetrics.SetExposure(float64(exposure) / 100)
+}
@@ -0,0 +1,897 @@
+// Package trade is the kalshi trade command, an automated trading bot for
+// the daily high temperature markets, LA's by default. It monitors weather
+// data and places trades when conditions are met, on one event, a list of
+// them, or every open event.
+package trade
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/internal/cli"
+	"github.com/brendanplayford/kalshi-go/internal/service"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/metrics"
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/risk"
+	"github.com/brendanplayford/kalshi-go/pkg/sizing"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// Configuration
+var (
+	sizer          *sizing.Sizer              // Position sizing within the risk caps
+	limits         *risk.Guard                // Hard limits, and a kill switch file other bots can share
+	tradingMetrics *metrics.Trading           // Served on -metrics-addr (nil = off)
+	traderHealth   *service.Health            // Served on -metrics-addr (nil = off)
+	residuals      *model.Residuals           // Empirical shape of the forecast per station and month (nil = normal)
+	minEdge        = 0.05                     // Minimum 5% edge to trade
+	cliCalibration = model.DefaultCalibration // METAR to CLI adjustment without -calibration
+	pollInterval   = 30 * time.Second         // Fast polling for price changes
+)
+
+// Trading state
+type TradingState struct {
+	// Event
+	Event   string           // Event ticker, e.g. KXHIGHLAX-25DEC27
+	City    string           // Station code, e.g. LAX
+	Station *weather.Station // Where the event settles
+	Date    time.Time        // The event's day, midnight at the station
+	Low     bool             // A KXLOWT* event, settling on the day's minimum
+
+	// Weather
+	CurrentTempF      int
+	RunningMaxF       int
+	ExpectedMaxF      int
+	RunningMinF       int  // LOW events only
+	HaveMin           bool // RunningMinF is set by a report from the event's day
+	ExpectedMinF      int  // LOW events only
+	NWSForecastF      int  // Forecast high, or overnight low for LOW events
+	LastWeatherUpdate time.Time
+	ModelStdDevF      float64
+
+	// Market
+	Markets   map[string]*MarketState
+	Positions map[string]*rest.Position
+	Balance   int // cents
+
+	// Bracket layout inferred from the strikes on load, and what the last
+	// check of the model's probabilities against it found ("" = sound)
+	Layout      market.Layout
+	LayoutCheck string
+
+	// Trading
+	PendingOrders map[string]*rest.Order
+	ExecutedToday int
+}
+
+type MarketState struct {
+	Ticker    string
+	Strike    string
+	LowBound  int
+	HighBound int
+	YesBid    int
+	YesAsk    int
+	NoBid     int
+	NoAsk     int
+	LastPrice int
+	ModelProb float64
+	Edge      float64
+	Signal    string
+	Crossed   bool
+	CrossedAt time.Time
+	Closes    time.Time // Market close time (zero = unknown)
+}
+
+// METAR observation
+type METARObservation struct {
+	IcaoID   string  `json:"icaoId"`
+	ObsTime  int64   `json:"obsTime"`
+	Temp     float64 `json:"temp"`
+	WxString string  `json:"wxString"`
+}
+
+// Recent METAR reports of a station
+const metarAPIURL = "https://aviationweather.gov/api/data/metar?ids=%s&hours=3&format=json"
+
+// Main runs the command with args, the arguments after its name, and
+// returns the exit code.
+func Main(args []string) int {
+	// Parse flags
+	fs := flag.NewFlagSet("trade", flag.ExitOnError)
+	var exchange cli.Exchange
+	exchange.Register(fs)
+	eventTicker := fs.String("event", "KXHIGHLAX-25DEC27", "Event ticker, or a comma-separated list of them")
+	all := fs.Bool("all", false, "Trade every open daily temperature event, discovered through the series endpoint, instead of -event")
+	discoverEvery := fs.Duration("discover", 15*time.Minute, "With -all, look for newly opened events this often")
+	autoTrade := fs.Bool("auto", false, "Enable auto-trading (default: manual confirmation)")
+	maxRisk := fs.Int("max-risk", 50, "Maximum risk per trade in dollars")
+	maxContracts := fs.Int("max-contracts", 10, "Maximum contracts per position")
+	maxDay := fs.Float64("max-day", 0, "Maximum dollars of new positions per day across all events (0 = no cap; required with more than one event)")
+	sizingFlag := fs.String("sizing", "", "Sizing: kelly, kelly:F (fraction of Kelly), fixed:F (of balance) or fixed:$D (default: the max risk on every trade)")
+	pollSecs := fs.Int("poll", 30, "Polling interval in seconds (default: 30)")
+	maxEvent := fs.Float64("max-event", 0, "Hard limit on dollars open in one event; a breach cancels resting orders and halts (0 = none; required with more than one event)")
+	maxCity := fs.Float64("max-city", 0, "Hard limit on dollars open in one city's HIGH and LOW events of a day; a breach cancels resting orders and halts (0 = none)")
+	maxTrades := fs.Int("max-trades", 0, "Hard limit on orders per day; a breach cancels resting orders and halts (0 = none)")
+	killSwitch := fs.String("kill-switch", "", "Halt trading while this file exists, e.g. the production bot's data/KILL")
+	limitsPath := fs.String("limits-state", "", "Keep the hard limits' usage and any halt in this file across restarts")
+	eventsPath := fs.String("events", "", "Append opportunities as JSON Lines to this file or named pipe")
+	metricsAddr := fs.String("metrics-addr", "", "Serve Prometheus metrics on /metrics and health on /health at this address, e.g. :9090")
+	fs.DurationVar(&expiryGuard.NoEntry, "no-entry-before-close", expiryGuard.NoEntry, "Open no positions this close to a market's close (0 = off)")
+	fs.DurationVar(&expiryGuard.ThinBook, "thin-book-warning", expiryGuard.ThinBook, "Warn about positions still held this close to a market's close (0 = off)")
+	calibrationPath := fs.String("calibration", "", "Use the METAR to CLI calibration learned per station and regime in this file, e.g. the production bot's data/calibration.json (reread hourly)")
+	residualsPath := fs.String("residuals", "", "Shape the forecast by the station and month's archived residuals in this file (from kalshi residuals) instead of the normal distribution")
+	healthStale := fs.Duration("health-stale", 30*time.Minute, "Report unhealthy on /health after this long without METAR or Kalshi data, or the WebSocket down")
+	fs.Parse(args)
+
+	pollInterval = time.Duration(*pollSecs) * time.Second
+	expiryWatch = strategy.NewExpiryWatch(expiryGuard)
+
+	// Every event stakes from the same bankroll, so several need caps on
+	// what they can put at risk together
+	if (*all || strings.Contains(*eventTicker, ",")) && (*maxDay <= 0 || *maxEvent <= 0) {
+		fmt.Println("❌ Trading more than one event needs -max-day and -max-event")
+		return 1
+	}
+
+	method := sizing.Method(sizing.FixedRisk{Dollars: float64(*maxRisk)})
+	if *sizingFlag != "" {
+		m, err := sizing.ParseMethod(*sizingFlag)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return 1
+		}
+		method = m
+	}
+	sizer = sizing.New(method, sizing.Limits{
+		MaxContracts: *maxContracts,
+		MaxTrade:     float64(*maxRisk),
+		MaxDay:       *maxDay,
+	})
+
+	var err error
+	limits, err = risk.New(risk.Config{
+		Limits:     risk.Limits{MaxEventExposure: *maxEvent, MaxCityExposure: *maxCity, MaxTradesPerDay: *maxTrades},
+		StatePath:  *limitsPath,
+		KillSwitch: *killSwitch,
+	})
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	// Header
+	fmt.Println(strings.Repeat("=", 80))
+	if *all {
+		fmt.Println("🤖 DAILY TEMPERATURE - AUTOMATED TRADER")
+	} else {
+		fmt.Println("🤖 LA HIGH TEMPERATURE - AUTOMATED TRADER")
+	}
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println()
+
+	// Load config
+	cfg, err := exchange.Config()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	if exchange.Demo {
+		fmt.Println("🧪 DEMO MODE - No real money at risk")
+	} else {
+		fmt.Println("💰 PRODUCTION MODE - Real money trading")
+	}
+
+	if *autoTrade {
+		fmt.Println("🤖 AUTO-TRADE ENABLED - Orders will be placed automatically")
+	} else {
+		fmt.Println("👤 MANUAL MODE - You will confirm each trade")
+	}
+
+	fmt.Printf("💵 Max Risk: $%d per trade\n", *maxRisk)
+	fmt.Printf("📊 Max Contracts: %d per position\n", *maxContracts)
+	fmt.Printf("📐 Sizing: %s\n", method)
+	if *maxDay > 0 {
+		fmt.Printf("📅 Max Daily Risk: $%.0f\n", *maxDay)
+	}
+	if *maxEvent > 0 {
+		fmt.Printf("🧱 Max per Event: $%.0f\n", *maxEvent)
+	}
+	if *maxCity > 0 {
+		fmt.Printf("🏙️  Max per City and Day: $%.0f\n", *maxCity)
+	}
+	fmt.Printf("📈 Min Edge: %.0f%%\n", minEdge*100)
+	fmt.Printf("⏱️  Poll Interval: %v\n", pollInterval)
+	fmt.Printf("⏰ Expiry: %s\n", expiryGuard)
+
+	var events *eventWriter
+	if *eventsPath != "" {
+		events, err = openEvents(*eventsPath)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return 1
+		}
+		defer events.Close()
+		fmt.Printf("📝 Opportunity events: %s\n", *eventsPath)
+	}
+	if *metricsAddr != "" {
+		traderHealth = newHealth(*healthStale)
+		if *all {
+			traderHealth.SetInfo("event", "all")
+		} else {
+			traderHealth.SetInfo("event", *eventTicker)
+		}
+		traderHealth.SetInfo("auto_trade", *autoTrade)
+		tradingMetrics = serveMetrics(*metricsAddr, traderHealth)
+		fmt.Printf("📊 Metrics: http://%s/metrics, health: http://%s/health\n", *metricsAddr, *metricsAddr)
+	}
+	fmt.Println()
+
+	client := exchange.REST(cfg)
+	limits.OnHalt(func(reason string) {
+		fmt.Printf("\n⛔ TRADING HALTED: %s\n", reason)
+		n, err := risk.CancelOpenOrders(client)
+		if err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+		}
+		fmt.Printf("  Canceled %d resting orders\n", n)
+	})
+	if status := limits.Status(); status.Halted {
+		fmt.Printf("⛔ Trading halted since %s: %s (delete %s or reset %s to resume)\n",
+			status.Since.Format("Jan 2 15:04"), status.Reason, *killSwitch, *limitsPath)
+	}
+
+	// Verify connection and get balance
+	fmt.Println("→ Connecting to Kalshi...")
+	balance, err := client.GetBalance()
+	if err != nil {
+		fmt.Printf("❌ Failed to connect: %v\n", err)
+		return 1
+	}
+	recordAccount(client)
+	fmt.Printf("✓ Connected! Balance: $%.2f\n", float64(balance.Balance)/100)
+	fmt.Println()
+
+	// Fetch the events' markets
+	var states []*TradingState
+	seen := make(map[string]bool)
+	if *all {
+		fmt.Println("→ Discovering open temperature events...")
+		states, err = discoverEvents(client, seen)
+		if err != nil {
+			fmt.Printf("⚠ Event discovery incomplete: %v\n", err)
+		}
+		if len(states) == 0 {
+			fmt.Println("❌ No open temperature events with a known station")
+			return 1
+		}
+	} else {
+		for _, event := range strings.Split(*eventTicker, ",") {
+			event = strings.TrimSpace(event)
+			fmt.Printf("→ Fetching markets for %s...\n", event)
+			markets, err := client.GetMarkets(event)
+			if err != nil {
+				fmt.Printf("❌ Failed to fetch markets: %v\n", err)
+				return 1
+			}
+			state, err := newTradingState(event, markets)
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				fmt.Println("   Try a different event ticker, e.g., KXHIGHLAX-25DEC27")
+				return 1
+			}
+			states = append(states, state)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if *calibrationPath != "" {
+		if err := loadCalibration(ctx, *calibrationPath); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return 1
+		}
+		fmt.Printf("📏 Calibration: learned from %s\n", *calibrationPath)
+	}
+	if *residualsPath != "" {
+		r, err := model.LoadResiduals(*residualsPath)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return 1
+		}
+		residuals = r
+		fmt.Printf("📊 Forecast shape: empirical residuals from %s\n", *residualsPath)
+	}
+
+	// Set up WebSocket for real-time market updates
+	wsClient := exchange.WS(cfg,
+		ws.WithCallbacks(
+			func() {
+				tradingMetrics.SetConnected(true)
+				traderHealth.SetConnected(healthWebSocket, true)
+			},
+			func(error) {
+				tradingMetrics.SetConnected(false)
+				traderHealth.SetConnected(healthWebSocket, false)
+			},
+			nil,
+		),
+	)
+	if err := wsClient.Connect(ctx); err != nil {
+		tradingMetrics.SetConnected(false)
+		traderHealth.SetConnected(healthWebSocket, false)
+		fmt.Printf("⚠ WebSocket connection failed: %v\n", err)
+	}
+	defer wsClient.Close()
+
+	// Each event trades in its own loop; the bankroll, the sizer's daily
+	// budget and the hard limits are shared
+	run := &traders{
+		client:  client,
+		ws:      wsClient,
+		auto:    *autoTrade,
+		events:  events,
+		reader:  bufio.NewReader(os.Stdin),
+		balance: balance.Balance,
+		states:  make(map[string]*TradingState),
+	}
+	for _, state := range states {
+		run.start(ctx, state)
+	}
+
+	// Set up signal handling
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+
+	// Account gauges, and new events with -all
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	lastDiscovery := time.Now()
+
+	fmt.Println()
+	if len(states) == 1 {
+		fmt.Println("📡 Trading bot started. Press Ctrl+C to stop.")
+	} else {
+		fmt.Printf("📡 Trading bot started on %d events. Press Ctrl+C to stop.\n", len(states))
+	}
+	fmt.Println(strings.Repeat("=", 80))
+
+	for {
+		select {
+		case <-ticker.C:
+			recordAccount(client)
+			if err := run.refreshBalance(); err != nil {
+				fmt.Printf("⚠ Balance refresh failed, trading on the last one: %v\n", err)
+			}
+			if !*all || time.Since(lastDiscovery) < *discoverEvery {
+				continue
+			}
+			lastDiscovery = time.Now()
+			found, err := discoverEvents(client, seen)
+			if err != nil {
+				fmt.Printf("⚠ Event discovery incomplete: %v\n", err)
+			}
+			for _, state := range found {
+				run.start(ctx, state)
+			}
+
+		case <-sigCh:
+			fmt.Println("\n→ Shutting down...")
+			cancel()
+			run.wait()
+			printFinalSummary(run.list(), client)
+			return 0
+		}
+	}
+}
+
+func updateWeather(state *TradingState) {
+	loc := state.Station.Location()
+
+	// Fetch latest METAR
+	resp, err := http.Get(fmt.Sprintf(metarAPIURL, state.Station.ID))
+	if err != nil {
+		traderHealth.Observe(healthMETAR, err)
+		fmt.Printf("⚠ [%s] METAR fetch failed: %v\n", state.Event, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	var observations []METARObservation
+	err = json.Unmarshal(body, &observations)
+	if err == nil && len(observations) == 0 {
+		err = errNoObservations
+	}
+	traderHealth.Observe(healthMETAR, err)
+
+	if len(observations) > 0 {
+		obs := observations[0]
+		state.CurrentTempF = int((obs.Temp * 9.0 / 5.0) + 32.5)
+		state.LastWeatherUpdate = time.Unix(obs.ObsTime, 0).In(loc)
+
+		// Only reports from the event's day count toward its high and
+		// low; an event for tomorrow trades on the forecast alone
+		for _, o := range observations {
+			observed := time.Unix(o.ObsTime, 0).In(loc)
+			if !state.Station.LocalDay(observed).Equal(state.Date) {
+				continue
+			}
+			tempF := int((o.Temp * 9.0 / 5.0) + 32.5)
+			if tempF > state.RunningMaxF {
+				state.RunningMaxF = tempF
+			}
+			if !state.HaveMin || tempF < state.RunningMinF {
+				state.RunningMinF, state.HaveMin = tempF, true
+			}
+		}
+		tradingMetrics.SetTemperature(obs.IcaoID, "current", float64(state.CurrentTempF))
+		tradingMetrics.SetTemperature(obs.IcaoID, "max", float64(state.RunningMaxF))
+		if state.HaveMin {
+			tradingMetrics.SetTemperature(obs.IcaoID, "min", float64(state.RunningMinF))
+		}
+	}
+
+	// Fetch the NWS forecast for the event's day: the overnight low into
+	// it for LOW events
+	if state.Low {
+		if forecast, err := weather.FetchLowForecastForDate(state.Station, state.Date); err == nil {
+			state.NWSForecastF = int(forecast.LowTemp)
+		}
+	} else if forecast, err := weather.FetchForecastForDate(state.Station, state.Date); err == nil && forecast.HighTemp > 0 {
+		state.NWSForecastF = int(forecast.HighTemp)
+	}
+
+	// Expected CLI
+	state.ExpectedMaxF = int(math.Max(float64(state.RunningMaxF), float64(state.NWSForecastF)) + state.calibration())
+	state.ExpectedMinF = int(math.Min(float64(state.runningMin()), float64(state.NWSForecastF)) - state.calibration())
+}
+
+// runningMin returns the running METAR min, or the NWS forecast low before
+// the event's day has a report
+func (s *TradingState) runningMin() int {
+	if !s.HaveMin {
+		return s.NWSForecastF
+	}
+	return s.RunningMinF
+}
+
+// running returns the running METAR extreme the event settles on: the min
+// for LOW events, the max otherwise
+func (s *TradingState) running() int {
+	if s.Low {
+		return s.runningMin()
+	}
+	return s.RunningMaxF
+}
+
+func updateMarketProbabilities(state *TradingState) {
+	// Uncertainty narrows with the time of day, and is widest before the
+	// event's day starts
+	now := time.Now().In(state.Station.Location())
+	hour := now.Hour()
+	if now.Before(state.Date) {
+		hour = 0
+	}
+	forecast := model.HighForecast(state.RunningMaxF, state.NWSForecastF, state.calibration(), hour)
+	if state.Low {
+		forecast = model.LowForecast(state.runningMin(), state.NWSForecastF, state.calibration(), hour)
+	}
+	state.ModelStdDevF = forecast.StdDev
+
+	// The archived residuals' shape fattens the tails the NO side sells
+	shaped := residuals.Empirical(forecast, state.City, state.Low, state.Date)
+
+	var probs []float64
+	for _, m := range state.Markets {
+		strike := m.strike()
+		prob := shaped.Probability(strike.Floor, strike.Cap)
+		m.ModelProb = prob
+		probs = append(probs, prob)
+
+		// Calculate edge vs market
+		if m.YesAsk > 0 {
+			impliedProb := float64(m.YesAsk) / 100.0
+			m.Edge = prob - impliedProb
+			tradingMetrics.SetEdge(m.Ticker, "yes", m.Edge)
+		}
+		if m.NoAsk > 0 {
+			tradingMetrics.SetEdge(m.Ticker, "no", 1-prob-float64(m.NoAsk)/100)
+		}
+
+		// Determine signal
+		if m.Edge > minEdge {
+			m.Signal = "🟢 BUY YES"
+		} else if m.Edge < -minEdge {
+			m.Signal = "🔴 BUY NO"
+		} else {
+			m.Signal = "⚪ HOLD"
+		}
+
+		// Check if threshold crossed
+		if !m.Crossed && crossed(state, m, state.running()) {
+			m.Crossed = true
+			m.CrossedAt = time.Now()
+		}
+	}
+
+	// Probabilities that do not sum to 1 mean the layout or the model is off
+	check := ""
+	if err := state.Layout.CheckMass(probs); err != nil {
+		check = err.Error()
+	}
+	if check != "" && check != state.LayoutCheck {
+		fmt.Printf("⚠️  [%s] Model probabilities don't fit the brackets: %s\n", state.Event, check)
+	}
+	state.LayoutCheck = check
+}
+
+// strike returns the market's settlement range
+func (m *MarketState) strike() market.Strike {
+	floor, cap := m.LowBound, m.HighBound
+	if floor <= 0 {
+		floor = market.OpenFloor
+	}
+	if cap >= 999 {
+		cap = market.OpenCap
+	}
+	return market.Strike{Floor: floor, Cap: cap}
+}
+
+func refreshMarketPrices(state *TradingState, client *rest.Client) {
+	markets, err := client.GetMarkets(state.Event)
+	traderHealth.Observe(healthKalshi, err)
+	if err != nil {
+		return
+	}
+
+	for _, m := range markets {
+		if ms, ok := state.Markets[m.Ticker]; ok {
+			ms.YesBid = m.YesBid
+			ms.YesAsk = m.YesAsk
+			ms.NoBid = m.NoBid
+			ms.NoAsk = m.NoAsk
+			ms.LastPrice = m.LastPrice
+		}
+	}
+}
+
+// crossed reports whether a running METAR extreme has crossed the
+// market's strike: the max above its floor, or for LOW events the min below
+// it, which rules the bracket out
+func crossed(state *TradingState, m *MarketState, running int) bool {
+	if state.Low {
+		return m.LowBound > 0 && running-state.cliOffset() < m.LowBound
+	}
+	return running+state.cliOffset() > m.LowBound
+}
+
+func checkThresholds(state *TradingState, prev int) {
+	for _, m := range state.Markets {
+		if crossed(state, m, prev) || !crossed(state, m, state.running()) {
+			continue
+		}
+		fmt.Println()
+		fmt.Println(strings.Repeat("!", 80))
+		if state.Low {
+			cliMin := state.running() - state.cliOffset()
+			fmt.Printf("🚨 [%s] THRESHOLD CROSSED: %d°F (CLI) < %s strike!\n", state.Event, cliMin, m.Strike)
+			fmt.Printf("   → %s is now LOCKED IN for NO\n", m.Strike)
+		} else {
+			cliMax := state.running() + state.cliOffset()
+			fmt.Printf("🚨 [%s] THRESHOLD CROSSED: %d°F (CLI) > %s strike!\n", state.Event, cliMax, m.Strike)
+			fmt.Printf("   → %s is now LOCKED IN for YES\n", m.Strike)
+		}
+		fmt.Println(strings.Repeat("!", 80))
+	}
+}
+
+type Opportunity struct {
+	Ticker      string
+	Strike      string
+	Action      string // "BUY_YES" or "BUY_NO"
+	Side        rest.Side
+	Price       int // in cents
+	Contracts   int
+	Edge        float64
+	Description string
+	Confidence  string
+	Model       ModelInputs
+}
+
+func findOpportunities(state *TradingState) []Opportunity {
+	// Overlapping brackets mean a strike was misread: no edge is real
+	if len(state.Layout.Overlaps) > 0 {
+		return nil
+	}
+	var opps []Opportunity
+
+	now := time.Now()
+	for _, m := range state.Markets {
+		// Skip if already crossed (YES is locked)
+		if m.Crossed && m.Edge > 0 {
+			continue
+		}
+		if expiryGuard.CheckEntry(m.Closes, now) != nil {
+			continue
+		}
+
+		absEdge := math.Abs(m.Edge)
+		if absEdge < minEdge {
+			continue
+		}
+
+		var opp Opportunity
+		opp.Ticker = m.Ticker
+		opp.Strike = m.Strike
+		opp.Edge = m.Edge
+		opp.Model = ModelInputs{
+			CurrentTempF: state.CurrentTempF,
+			RunningMaxF:  state.RunningMaxF,
+			NWSForecastF: state.NWSForecastF,
+			ExpectedMaxF: state.ExpectedMaxF,
+			Low:          state.Low,
+			RunningMinF:  state.runningMin(),
+			ExpectedMinF: state.ExpectedMinF,
+			StdDevF:      state.ModelStdDevF,
+			LowBound:     m.LowBound,
+			HighBound:    m.HighBound,
+			ModelProb:    m.ModelProb,
+			ImpliedProb:  float64(m.YesAsk) / 100,
+			YesBid:       m.YesBid,
+			YesAsk:       m.YesAsk,
+			NoBid:        m.NoBid,
+			NoAsk:        m.NoAsk,
+			ObservedAt:   state.LastWeatherUpdate,
+		}
+
+		if m.Edge > 0 {
+			// BUY YES
+			opp.Action = "BUY_YES"
+			opp.Side = rest.SideYes
+			opp.Price = m.YesAsk
+			if opp.Price == 0 {
+				continue
+			}
+			opp.Contracts = calculatePosition(m.ModelProb, opp.Price, state.Balance)
+			opp.Description = fmt.Sprintf("BUY YES on \"%s\" @ %d¢ (Edge: +%.0f%%)",
+				m.Strike, opp.Price, m.Edge*100)
+		} else {
+			// BUY NO
+			opp.Action = "BUY_NO"
+			opp.Side = rest.SideNo
+			opp.Price = m.NoAsk
+			if opp.Price == 0 {
+				continue
+			}
+			opp.Contracts = calculatePosition(1-m.ModelProb, opp.Price, state.Balance)
+			opp.Description = fmt.Sprintf("BUY NO on \"%s\" @ %d¢ (Edge: +%.0f%%)",
+				m.Strike, opp.Price, absEdge*100)
+		}
+
+		if opp.Contracts > 0 {
+			// Confidence level
+			switch {
+			case absEdge > 0.20:
+				opp.Confidence = "HIGH"
+			case absEdge > 0.10:
+				opp.Confidence = "MEDIUM"
+			default:
+				opp.Confidence = "LOW"
+			}
+			opps = append(opps, opp)
+		}
+	}
+
+	return opps
+}
+
+// calculatePosition sizes a buy at priceCents of a side the model gives
+// probability prob, against the account balance
+func calculatePosition(prob float64, priceCents, balanceCents int) int {
+	return sizer.Contracts(sizing.Bet{
+		Prob:     prob,
+		Price:    priceCents,
+		Bankroll: float64(balanceCents) / 100,
+	}, time.Now())
+}
+
+// executeTrade places opp within the hard limits, reporting whether the
+// order was placed
+func executeTrade(client *rest.Client, state *TradingState, opp Opportunity) bool {
+	fmt.Printf("\n→ [%s] Executing: %s\n", state.Event, opp.Description)
+	fmt.Printf("  Contracts: %d @ %d¢ = $%.2f\n", opp.Contracts, opp.Price,
+		float64(opp.Contracts*opp.Price)/100)
+
+	cost := float64(opp.Contracts*opp.Price) / 100
+	if err := limits.Check(risk.Order{Ticker: opp.Ticker, Cost: cost}); err != nil {
+		tradingMetrics.OrderRejected(state.City, rejectReason(err))
+		fmt.Printf("  ⛔ %v\n", err)
+		return false
+	}
+
+	var order *rest.Order
+	var err error
+
+	if opp.Side == rest.SideYes {
+		order, err = client.BuyYes(opp.Ticker, opp.Contracts, opp.Price)
+	} else {
+		order, err = client.BuyNo(opp.Ticker, opp.Contracts, opp.Price)
+	}
+
+	if err != nil {
+		tradingMetrics.OrderRejected(state.City, rejectReason(err))
+		fmt.Printf("  ❌ Order failed: %v\n", err)
+		return false
+	}
+	tradingMetrics.OrderPlaced(state.City, string(opp.Side), order.Status == rest.OrderStatusExecuted)
+
+	fmt.Printf("  ✅ Order placed! ID: %s\n", order.OrderID)
+	fmt.Printf("     Status: %s\n", order.Status)
+
+	state.PendingOrders[order.OrderID] = order
+	state.ExecutedToday++
+	sizer.Record(cost, time.Now())
+	limits.Record(risk.Order{Ticker: opp.Ticker, Cost: cost})
+	return true
+}
+
+func printStatus(state *TradingState, positions []rest.Position) {
+	now := time.Now().In(state.Station.Location())
+
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("CURRENT STATUS: %s (%s)\n", state.Event, state.Station.City)
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("📅 %s\n", now.Format("Monday, January 2, 2006 3:04 PM MST"))
+	fmt.Println()
+
+	fmt.Println("WEATHER:")
+	fmt.Printf("  🌡️  Current: %d°F\n", state.CurrentTempF)
+	if state.Low {
+		fmt.Printf("  📉 Running Min: %d°F (METAR) → %d°F (Est. CLI)\n",
+			state.runningMin(), state.runningMin()-state.cliOffset())
+		fmt.Printf("  🌙 NWS Forecast Low: %d°F\n", state.NWSForecastF)
+		fmt.Printf("  🎯 Expected CLI: %d°F\n", state.ExpectedMinF)
+	} else {
+		fmt.Printf("  📈 Running Max: %d°F (METAR) → %d°F (Est. CLI)\n",
+			state.RunningMaxF, state.RunningMaxF+state.cliOffset())
+		fmt.Printf("  🌤️  NWS Forecast: %d°F\n", state.NWSForecastF)
+		fmt.Printf("  🎯 Expected CLI: %d°F\n", state.ExpectedMaxF)
+	}
+	fmt.Println()
+
+	fmt.Println("MARKETS:")
+	fmt.Printf("%-18s %-8s %-8s %-10s %-8s %-12s\n",
+		"Strike", "Bid", "Ask", "Model", "Edge", "Signal")
+	fmt.Printf("%-18s %-8s %-8s %-10s %-8s %-12s\n",
+		"------", "---", "---", "-----", "----", "------")
+
+	for _, m := range getSortedMarkets(state) {
+		edgeStr := fmt.Sprintf("%+.0f%%", m.Edge*100)
+		fmt.Printf("%-18s %-8d %-8d %-10.0f%% %-8s %-12s\n",
+			m.Strike, m.YesBid, m.YesAsk, m.ModelProb*100, edgeStr, m.Signal)
+	}
+	fmt.Println()
+
+	// Show existing positions in the event
+	var held []rest.Position
+	for _, p := range positions {
+		if _, ok := state.Markets[p.Ticker]; ok && (p.YesPosition > 0 || p.NoPosition > 0) {
+			held = append(held, p)
+		}
+	}
+	if len(held) > 0 {
+		fmt.Println("POSITIONS:")
+		for _, p := range held {
+			fmt.Printf("  %s: YES=%d, NO=%d, Cost=$%.2f\n",
+				p.Ticker, p.YesPosition, p.NoPosition, float64(p.TotalCost)/100)
+		}
+		fmt.Println()
+	}
+}
+
+func printOpportunities(state *TradingState, opps []Opportunity) {
+	fmt.Println()
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("🎯 TRADING OPPORTUNITIES: %s\n", state.Event)
+	fmt.Println(strings.Repeat("=", 80))
+
+	for _, opp := range opps {
+		fmt.Printf("\n%s\n", opp.Description)
+		fmt.Printf("  Contracts: %d\n", opp.Contracts)
+		fmt.Printf("  Confidence: %s\n", opp.Confidence)
+	}
+}
+
+func printUpdate(state *TradingState) {
+	now := time.Now().In(state.Station.Location())
+
+	// Find best opportunity
+	var bestEdge float64
+	var bestStrike string
+	for _, m := range state.Markets {
+		if math.Abs(m.Edge) > math.Abs(bestEdge) {
+			bestEdge = m.Edge
+			bestStrike = m.Strike
+		}
+	}
+
+	extreme, running, expected := "Max", state.RunningMaxF, state.ExpectedMaxF
+	if state.Low {
+		extreme, running, expected = "Min", state.runningMin(), state.ExpectedMinF
+	}
+	fmt.Printf("[%s %s] Temp: %d°F | %s: %d°F | Expected: %d°F | Best: %s (%+.0f%%)\n",
+		now.Format("15:04"),
+		state.Event,
+		state.CurrentTempF,
+		extreme,
+		running,
+		expected,
+		bestStrike,
+		bestEdge*100)
+}
+
+func printFinalSummary(states []*TradingState, client *rest.Client) {
+	fmt.Println()
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Println("TRADING SESSION SUMMARY")
+	fmt.Println(strings.Repeat("=", 80))
+
+	executed := 0
+	for _, state := range states {
+		executed += state.ExecutedToday
+		if len(states) > 1 && state.ExecutedToday > 0 {
+			fmt.Printf("  %s: %d orders\n", state.Event, state.ExecutedToday)
+		}
+	}
+	fmt.Printf("📊 Orders Executed: %d\n", executed)
+
+	// Get final balance
+	balance, err := client.GetBalance()
+	if err == nil {
+		fmt.Printf("💰 Current Balance: $%.2f\n", float64(balance.Balance)/100)
+	}
+
+	// Show positions
+	positions, err := client.GetPositions()
+	if err == nil && len(positions) > 0 {
+		fmt.Println("\n📈 Open Positions:")
+		for _, p := range positions {
+			if p.YesPosition > 0 || p.NoPosition > 0 {
+				fmt.Printf("  %s\n", p.Ticker)
+				fmt.Printf("    YES: %d, NO: %d\n", p.YesPosition, p.NoPosition)
+				fmt.Printf("    Cost: $%.2f, Realized P&L: $%.2f\n",
+					float64(p.TotalCost)/100, float64(p.RealizedPnl)/100)
+			}
+		}
+	}
+	fmt.Println()
+}
+
+func getSortedMarkets(state *TradingState) []*MarketState {
+	result := make([]*MarketState, 0, len(state.Markets))
+	for _, m := range state.Markets {
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LowBound < result[j].LowBound
+	})
+	return result
+}
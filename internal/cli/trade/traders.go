@@ -0,0 +1,298 @@
+package trade
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// discoverEvents returns a trading state for every open daily temperature
+// event (see market.Discover) not yet in seen, and adds every event it
+// looks at to seen so skipped events are reported once. Stations whose
+// series the exchange has renamed are pointed at the new ones; series of
+// cities without a registered station are reported once and skipped
+func discoverEvents(client *rest.Client, seen map[string]bool) ([]*TradingState, error) {
+	cat, err := market.Discover(client)
+	if cat == nil {
+		return nil, err
+	}
+	for _, change := range cat.Apply(weather.Stations) {
+		fmt.Printf("  🔀 Series moved: %s\n", change)
+	}
+
+	var states []*TradingState
+	for _, s := range cat.Series {
+		if s.Station == nil {
+			if !seen[s.Ticker] {
+				seen[s.Ticker] = true
+				fmt.Printf("  ⏭  Skipping %s (%s): no station registered\n", s.Ticker, s.Title)
+			}
+			continue
+		}
+		for _, e := range s.Events {
+			if seen[e.EventTicker] {
+				continue
+			}
+			seen[e.EventTicker] = true
+			state, err := newTradingState(e.EventTicker, e.Markets)
+			if err != nil {
+				fmt.Printf("  ⏭  Skipping %v\n", err)
+				continue
+			}
+			states = append(states, state)
+		}
+	}
+	return states, err
+}
+
+// newTradingState sets up trading an event's markets
+func newTradingState(eventTicker string, markets []rest.Market) (*TradingState, error) {
+	series, date, _ := strings.Cut(eventTicker, "-")
+	city, station, marketType := weather.StationBySeries(series)
+	if station == nil {
+		return nil, fmt.Errorf("%s: no station registered for series %s", eventTicker, series)
+	}
+	day, err := time.ParseInLocation("06Jan02", date, station.Location())
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid event date %q", eventTicker, date)
+	}
+	if len(markets) == 0 {
+		return nil, fmt.Errorf("no markets found for event %s", eventTicker)
+	}
+
+	state := &TradingState{
+		Event:         eventTicker,
+		City:          city,
+		Station:       station,
+		Date:          day,
+		Low:           marketType == weather.MarketTypeLow,
+		Markets:       make(map[string]*MarketState),
+		Positions:     make(map[string]*rest.Position),
+		PendingOrders: make(map[string]*rest.Order),
+	}
+	fmt.Printf("✓ %s (%s): %d markets\n", eventTicker, station.City, len(markets))
+	for _, m := range markets {
+		bracket, err := market.MarketStrike(m)
+		if err != nil {
+			fmt.Printf("  ⚠️  Skipping %v\n", err)
+			continue
+		}
+		low, high := bracket.Floor, bracket.Cap
+		if low == market.OpenFloor {
+			low = 0
+		}
+		strike := m.YesSubTitle
+		if strike == "" {
+			strike = bracket.String()
+		}
+		state.Markets[m.Ticker] = &MarketState{
+			Ticker:    m.Ticker,
+			Strike:    strike,
+			LowBound:  low,
+			HighBound: high,
+			YesBid:    m.YesBid,
+			YesAsk:    m.YesAsk,
+			NoBid:     m.NoBid,
+			NoAsk:     m.NoAsk,
+			LastPrice: m.LastPrice,
+			Closes:    strategy.ParseCloseTime(m.CloseTime),
+		}
+		fmt.Printf("  📊 %s: %s (Bid: %d¢, Ask: %d¢)\n", m.Ticker, strike, m.YesBid, m.YesAsk)
+	}
+
+	// Infer the layout rather than assume 2° brackets with both tails
+	strikes := make(market.Strikes, 0, len(state.Markets))
+	for _, m := range state.Markets {
+		strikes = append(strikes, m.strike())
+	}
+	state.Layout = market.InferLayout(strikes)
+	switch err := state.Layout.Err(); {
+	case err != nil:
+		fmt.Printf("  ⚠️  Unexpected bracket layout (%s): %v\n", state.Layout, err)
+	case !state.Layout.Standard():
+		fmt.Printf("  📐 Non-standard bracket layout: %s\n", state.Layout)
+	}
+	return state, nil
+}
+
+// closed reports whether every market of the event has closed by now. An
+// unknown close time counts as open
+func (s *TradingState) closed(now time.Time) bool {
+	for _, m := range s.Markets {
+		if m.Closes.IsZero() || now.Before(m.Closes) {
+			return false
+		}
+	}
+	return true
+}
+
+// traders runs one trading loop per event. The loops share the client, the
+// bankroll, the sizer's daily budget and the hard limits, and take turns
+// deciding so their output and confirmations don't interleave
+type traders struct {
+	client *rest.Client
+	ws     *ws.Client
+	auto   bool
+	events *eventWriter
+	reader *bufio.Reader
+
+	turn    sync.Mutex // Held while an event decides and trades
+	balance int        // Cents to trade with: the last balance read, less the orders placed since. Held under turn
+	wg      sync.WaitGroup
+
+	mu     sync.Mutex
+	states map[string]*TradingState // Every event started, by ticker
+}
+
+// start runs state's event in its own loop until ctx is canceled or its
+// markets close. Events already started are ignored
+func (t *traders) start(ctx context.Context, state *TradingState) {
+	t.mu.Lock()
+	if _, ok := t.states[state.Event]; ok {
+		t.mu.Unlock()
+		return
+	}
+	t.states[state.Event] = state
+	t.mu.Unlock()
+
+	for ticker := range state.Markets {
+		t.ws.Subscribe(ctx, ticker, ws.ChannelTicker)
+	}
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		t.run(ctx, state)
+	}()
+}
+
+// wait blocks until every loop has stopped
+func (t *traders) wait() {
+	t.wg.Wait()
+}
+
+// list returns every event started, by ticker
+func (t *traders) list() []*TradingState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make([]*TradingState, 0, len(t.states))
+	for _, s := range t.states {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Event < result[j].Event })
+	return result
+}
+
+// run polls the event's weather and prices and trades on them
+func (t *traders) run(ctx context.Context, state *TradingState) {
+	updateWeather(state)
+	updateMarketProbabilities(state)
+	positions, _ := t.client.GetPositions()
+	t.turn.Lock()
+	printStatus(state, positions)
+	t.turn.Unlock()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if state.closed(time.Now()) {
+			fmt.Printf("🏁 [%s] All markets closed, stopping\n", state.Event)
+			return
+		}
+
+		// Update weather and refresh market prices
+		prev := state.running()
+		updateWeather(state)
+		refreshMarketPrices(state, t.client)
+		updateMarketProbabilities(state)
+
+		t.decide(state, prev)
+	}
+}
+
+// refreshBalance replaces the shared bankroll with the exchange's balance,
+// keeping the last one if it can't be read
+func (t *traders) refreshBalance() error {
+	balance, err := t.client.GetBalance()
+	if err != nil {
+		return err
+	}
+	t.turn.Lock()
+	t.balance = balance.Balance
+	t.turn.Unlock()
+	return nil
+}
+
+// decide looks for opportunities in the event and trades them. Orders are
+// sized against the bankroll the events share, and each is checked against
+// the hard limits with the other events' orders already recorded. prev is
+// the running METAR extreme before this poll
+func (t *traders) decide(state *TradingState, prev int) {
+	t.turn.Lock()
+	defer t.turn.Unlock()
+
+	// Check for threshold crossings
+	checkThresholds(state, prev)
+	watchExpiry(state, t.client)
+
+	// Look for trading opportunities
+	state.Balance = t.balance
+	opportunities := findOpportunities(state)
+	if len(opportunities) > 0 {
+		printOpportunities(state, opportunities)
+		t.events.Emit(state.Event, opportunities)
+
+		for _, opp := range opportunities {
+			if t.auto {
+				t.execute(state, opp)
+				continue
+			}
+			fmt.Printf("\n🔔 TRADING OPPORTUNITY [%s]: %s\n", state.Event, opp.Description)
+			fmt.Printf("   Execute trade? (y/n): ")
+
+			input, _ := t.reader.ReadString('\n')
+			input = strings.TrimSpace(strings.ToLower(input))
+
+			if input == "y" || input == "yes" {
+				t.execute(state, opp)
+			} else {
+				fmt.Println("   Skipped.")
+			}
+		}
+	}
+
+	printUpdate(state)
+}
+
+// execute places opp, cut to the bankroll left by the orders already placed,
+// and takes its cost out of the bankroll. Callers hold t.turn
+func (t *traders) execute(state *TradingState, opp Opportunity) {
+	if affordable := t.balance / opp.Price; affordable < opp.Contracts {
+		if affordable <= 0 {
+			fmt.Printf("\n⏭  [%s] Skipping %s: no balance left ($%.2f)\n", state.Event, opp.Ticker, float64(t.balance)/100)
+			return
+		}
+		fmt.Printf("\n→ [%s] Sizing %s down to %d contracts, the $%.2f balance left\n",
+			state.Event, opp.Ticker, affordable, float64(t.balance)/100)
+		opp.Contracts = affordable
+	}
+	if executeTrade(t.client, state, opp) {
+		t.balance -= opp.Contracts * opp.Price
+		state.Balance = t.balance
+	}
+}
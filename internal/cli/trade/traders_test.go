@@ -0,0 +1,134 @@
+package trade
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/mockexchange"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/risk"
+	"github.com/brendanplayford/kalshi-go/pkg/sizing"
+)
+
+// testEvents are two cities' events, each with one bracket quoted at 38/40¢
+var testEvents = []string{"KXHIGHLAX-26MAR10", "KXHIGHNY-26MAR10"}
+
+// newTestTraders lists testEvents on x and returns auto-trading loops on
+// them with balance cents to share, sized and limited as given
+func newTestTraders(t *testing.T, x *mockexchange.Exchange, balance int, method sizing.Method, sizeLimits sizing.Limits, hardLimits risk.Limits) (*traders, []*TradingState) {
+	t.Helper()
+	oldSizer, oldLimits := sizer, limits
+	t.Cleanup(func() { sizer, limits = oldSizer, oldLimits })
+	sizer = sizing.New(method, sizeLimits)
+	var err error
+	if limits, err = risk.New(risk.Config{Limits: hardLimits}); err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := &traders{
+		client:  rest.New("test-key", privateKey, rest.WithBaseURL(x.URL())),
+		auto:    true,
+		balance: balance,
+		states:  make(map[string]*TradingState),
+	}
+
+	var states []*TradingState
+	for _, event := range testEvents {
+		m := rest.Market{
+			Ticker: event + "-B70.5", EventTicker: event, Status: "active",
+			StrikeType: "between", FloorStrike: 70, CapStrike: 71, YesSubTitle: "70° to 71°",
+			YesBid: 38, YesAsk: 40, NoBid: 60, NoAsk: 62,
+		}
+		x.AddMarket(m)
+		state, err := newTradingState(event, []rest.Market{m})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The model makes YES a 90% bet at 40¢
+		state.Markets[m.Ticker].ModelProb, state.Markets[m.Ticker].Edge = 0.9, 0.5
+		states = append(states, state)
+	}
+	return run, states
+}
+
+// spent returns the cents of the orders on x, by event
+func spent(x *mockexchange.Exchange) map[string]int {
+	cost := make(map[string]int)
+	for _, o := range x.Orders() {
+		event := o.Ticker[:len(o.Ticker)-len("-B70.5")]
+		cost[event] += o.PlaceCount * o.YesPrice
+	}
+	return cost
+}
+
+func TestTraders_SharedBankroll(t *testing.T) {
+	x := mockexchange.New(1)
+	t.Cleanup(x.Close)
+	// $8 a trade, with $10 between the two events
+	run, states := newTestTraders(t, x, 1000, sizing.FixedRisk{Dollars: 8}, sizing.Limits{}, risk.Limits{})
+	for _, state := range states {
+		run.decide(state, 0)
+	}
+
+	cost := spent(x)
+	if cost[testEvents[0]] != 800 || cost[testEvents[1]] != 200 {
+		t.Errorf("spent %v, want $8 on the first event and the $2 left on the second", cost)
+	}
+	if run.balance != 0 {
+		t.Errorf("bankroll = %d¢ after both events, want 0", run.balance)
+	}
+
+	// Nothing left: the next poll places nothing
+	n := len(x.Orders())
+	run.decide(states[0], 0)
+	if len(x.Orders()) != n {
+		t.Error("order placed with the bankroll spent")
+	}
+
+	// The exchange's balance replaces the running one
+	x.SetBalance(5000)
+	if err := run.refreshBalance(); err != nil {
+		t.Fatal(err)
+	}
+	if run.balance != 5000 {
+		t.Errorf("bankroll after refresh = %d¢, want 5000", run.balance)
+	}
+	x.SetFaults(mockexchange.Faults{ErrorRate: 1})
+	if err := run.refreshBalance(); err == nil || run.balance != 5000 {
+		t.Errorf("failed refresh: error %v, bankroll %d¢; want an error and the last balance", err, run.balance)
+	}
+}
+
+func TestTraders_SharedCaps(t *testing.T) {
+	tests := []struct {
+		name       string
+		sizeLimits sizing.Limits
+		hardLimits risk.Limits
+		want       int // Cents spent on both events together
+	}{
+		// $8 each would be $16
+		{"daily budget", sizing.Limits{MaxDay: 10}, risk.Limits{}, 1000},
+		{"trades per day", sizing.Limits{}, risk.Limits{MaxTradesPerDay: 1}, 800},
+	}
+	for _, tt := range tests {
+		x := mockexchange.New(1)
+		t.Cleanup(x.Close)
+		run, states := newTestTraders(t, x, 100000, sizing.FixedRisk{Dollars: 8}, tt.sizeLimits, tt.hardLimits)
+		for _, state := range states {
+			run.decide(state, 0)
+		}
+
+		total := 0
+		for _, c := range spent(x) {
+			total += c
+		}
+		if total != tt.want {
+			t.Errorf("%s: spent %d¢ on both events, want %d", tt.name, total, tt.want)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// citiesKey is the file section holding per-city overrides.
+const citiesKey = "cities"
+
+// Settings is a bot's configuration assembled from, in increasing
+// precedence, its defaults, a YAML or TOML file, environment variables and
+// the flags set on the command line. Keys are the snake_case JSON names of
+// the defaults' fields; the environment variable of a key is the prefix and
+// the key in upper case (DUALSIDE_BET_YES) and its flag the key with dashes
+// (-bet-yes).
+//
+// The file may also override keys per city under a cities section, which
+// take precedence over everything else for that city:
+//
+//	bet_yes: 300
+//	cities:
+//	  MIA:
+//	    bet_yes: 150
+//	    max_no_price: 85
+type Settings struct {
+	path   string
+	base   map[string]any
+	cities map[string]map[string]any
+}
+
+// LoadSettings reads the file at path (none if empty) over defaults, a
+// struct or pointer to one, then applies the environment variables with
+// prefix and the flags set in fs (nil for none). Keys the defaults don't
+// have are errors, so a misspelled setting fails at startup rather than
+// being ignored.
+func LoadSettings(path string, defaults any, prefix string, fs *flag.FlagSet) (*Settings, error) {
+	base, err := toMap(defaults)
+	if err != nil {
+		return nil, fmt.Errorf("config: defaults: %w", err)
+	}
+	s := &Settings{path: path, base: base, cities: make(map[string]map[string]any)}
+
+	if path != "" {
+		file, err := ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if cities, ok := file[citiesKey]; ok {
+			m, ok := cities.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("config: %s: %s must be a map of city codes", path, citiesKey)
+			}
+			for city, v := range m {
+				o, ok := v.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("config: %s: %s.%s must be a map", path, citiesKey, city)
+				}
+				s.cities[strings.ToUpper(city)] = o
+			}
+			delete(file, citiesKey)
+		}
+		for k, v := range file {
+			if _, ok := base[k]; !ok {
+				return nil, fmt.Errorf("config: %s: unknown setting %q", path, k)
+			}
+			base[k] = v
+		}
+	}
+
+	for k, def := range base {
+		if _, isMap := def.(map[string]any); isMap {
+			continue
+		}
+		env := strings.ToUpper(prefix + "_" + k)
+		if v, ok := os.LookupEnv(env); ok {
+			if base[k], err = convert(v, def); err != nil {
+				return nil, fmt.Errorf("config: %s: %w", env, err)
+			}
+		}
+	}
+
+	if fs != nil {
+		fs.Visit(func(f *flag.Flag) {
+			k := strings.ReplaceAll(f.Name, "-", "_")
+			def, ok := base[k]
+			if !ok || err != nil {
+				return
+			}
+			if base[k], err = convert(f.Value.String(), def); err != nil {
+				err = fmt.Errorf("config: -%s: %w", f.Name, err)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Path returns the file the settings were read from, or "".
+func (s *Settings) Path() string {
+	return s.path
+}
+
+// Decode decodes the settings into v, a pointer to the defaults' type.
+func (s *Settings) Decode(v any) error {
+	return s.decode(s.base, v, "")
+}
+
+// Cities returns the cities the file overrides, sorted.
+func (s *Settings) Cities() []string {
+	cities := make([]string, 0, len(s.cities))
+	for c := range s.cities {
+		cities = append(cities, c)
+	}
+	sort.Strings(cities)
+	return cities
+}
+
+// City decodes the settings with the city's overrides into v.
+func (s *Settings) City(code string, v any) error {
+	overrides := s.cities[strings.ToUpper(code)]
+	if len(overrides) == 0 {
+		return s.Decode(v)
+	}
+	m := make(map[string]any, len(s.base))
+	for k, val := range s.base {
+		m[k] = val
+	}
+	for k, val := range overrides {
+		if _, ok := s.base[k]; !ok {
+			return fmt.Errorf("config: %s: %s.%s: unknown setting %q", s.path, citiesKey, code, k)
+		}
+		m[k] = val
+	}
+	return s.decode(m, v, citiesKey+"."+code+": ")
+}
+
+func (s *Settings) decode(m map[string]any, v any, where string) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("config: %s%w", where, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			err = fmt.Errorf("%s: want a %s, got a %s", typeErr.Field, typeErr.Type, typeErr.Value)
+		}
+		if s.path != "" {
+			return fmt.Errorf("config: %s: %s%w", s.path, where, err)
+		}
+		return fmt.Errorf("config: %s%w", where, err)
+	}
+	return nil
+}
+
+// ReadFile parses a YAML (.yaml, .yml), TOML (.toml) or JSON (.json) file
+// into a map.
+func ReadFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	var m map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		m, err = parseYAML(data)
+	case ".toml":
+		m, err = parseTOML(data)
+	case ".json":
+		err = json.Unmarshal(data, &m)
+	default:
+		return nil, fmt.Errorf("config: %s: unknown format %q (want .yaml, .toml or .json)", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// toMap returns the JSON fields of v.
+func toMap(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// convert parses an environment variable or flag to the JSON type of def.
+func convert(s string, def any) (any, error) {
+	switch def.(type) {
+	case float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("want a number, got %q", s)
+		}
+		return f, nil
+	case bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("want true or false, got %q", s)
+		}
+		return b, nil
+	case []any:
+		var list []any
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		return list, nil
+	}
+	return s, nil
+}
+
+// Duration is a time.Duration written as a string such as "5m" or "90s".
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("want a duration such as \"5m\", got %s", data)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("want a duration such as \"5m\", got %q", s)
+	}
+	*d = Duration(v)
+	return nil
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type botSettings struct {
+	Trading
+	Interval Duration `json:"interval"`
+	DryRun   bool     `json:"dry_run"`
+	Cities   []string `json:"enabled"`
+}
+
+func defaultBot() botSettings {
+	return botSettings{
+		Trading:  Trading{BetYes: 300, BetNo: 100, MinNoPrice: 50, MaxNoPrice: 90, MaxNo: 3, StartHour: 7, EndHour: 14},
+		Interval: Duration(5 * time.Minute),
+	}
+}
+
+const yamlFile = `# Dual-side bot
+bet_yes: 250
+interval: "2m"   # Poll faster
+enabled:
+  - LAX
+  - MIA
+cities:
+  mia:
+    bet_yes: 150
+    max_no_price: 85
+  DEN:
+    disabled: true
+`
+
+const tomlFile = `# Dual-side bot
+bet_yes = 250
+interval = "2m"   # Poll faster
+enabled = ["LAX", "MIA"]
+
+[cities.mia]
+bet_yes = 150
+max_no_price = 85
+
+[cities.DEN]
+disabled = true
+`
+
+func write(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadSettings(t *testing.T) {
+	for _, file := range []string{write(t, "bot.yaml", yamlFile), write(t, "bot.toml", tomlFile)} {
+		t.Setenv("DUALSIDE_BET_NO", "120")
+		t.Setenv("DUALSIDE_MAX_NO", "2")
+
+		fs := flag.NewFlagSet("bot", flag.ContinueOnError)
+		fs.Int("max-no", 3, "")
+		fs.Bool("dry-run", false, "")
+		fs.Float64("bet-yes", 300, "")
+		if err := fs.Parse([]string{"-max-no", "4", "-dry-run"}); err != nil {
+			t.Fatal(err)
+		}
+
+		s, err := LoadSettings(file, defaultBot(), "DUALSIDE", fs)
+		if err != nil {
+			t.Fatalf("%s: LoadSettings() error = %v", file, err)
+		}
+
+		// Defaults < file < env < flags; the unset -bet-yes flag doesn't count
+		var got botSettings
+		if err := s.Decode(&got); err != nil {
+			t.Fatalf("%s: Decode() error = %v", file, err)
+		}
+		want := defaultBot()
+		want.BetYes, want.BetNo, want.MaxNo, want.DryRun = 250, 120, 4, true
+		want.Interval = Duration(2 * time.Minute)
+		want.Cities = []string{"LAX", "MIA"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Decode() = %+v, want %+v", file, got, want)
+		}
+
+		// City overrides win over everything
+		var mia botSettings
+		if err := s.City("MIA", &mia); err != nil {
+			t.Fatalf("%s: City(MIA) error = %v", file, err)
+		}
+		want.BetYes, want.MaxNoPrice = 150, 85
+		if !reflect.DeepEqual(mia, want) {
+			t.Errorf("%s: City(MIA) = %+v, want %+v", file, mia, want)
+		}
+		if got := s.Cities(); !reflect.DeepEqual(got, []string{"DEN", "MIA"}) {
+			t.Errorf("%s: Cities() = %v", file, got)
+		}
+	}
+}
+
+func TestLoadSettings_Errors(t *testing.T) {
+	tests := []struct {
+		name, content, env, want string
+	}{
+		{"bot.yaml", "bet_yes: 250\nbet_yse: 100\n", "", `unknown setting "bet_yse"`},
+		{"bot.yaml", "cities:\n  MIA:\n    max_no_prise: 85\n", "", `unknown setting "max_no_prise"`},
+		{"bot.yaml", "bet_yes: lots\n", "", "bet_yes: want a float64"},
+		{"bot.yaml", "bet_yes: 1\n  bet_no: 2\n", "", "line 2: unexpected indentation"},
+		{"bot.toml", "[cities.MIA]\n[cities.MIA]\n", "", "defined twice"},
+		{"bot.toml", "bet_yes = 1\nbet_yes = 2\n", "", `duplicate key "bet_yes"`},
+		{"bot.ini", "bet_yes=1\n", "", "unknown format"},
+		{"bot.yaml", "", "lots", "DUALSIDE_BET_YES: want a number"},
+	}
+	for _, tt := range tests {
+		t.Setenv("DUALSIDE_BET_YES", tt.env)
+		if tt.env == "" {
+			os.Unsetenv("DUALSIDE_BET_YES")
+		}
+		s, err := LoadSettings(write(t, tt.name, tt.content), defaultBot(), "DUALSIDE", nil)
+		if err == nil {
+			var b botSettings
+			err = s.Decode(&b)
+			if err == nil {
+				err = s.City("MIA", &b)
+			}
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s %q: error = %v, want %q", tt.name, tt.content, err, tt.want)
+		}
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	got, err := parseYAML([]byte(`
+name: "kalshi # bot"
+url: http://localhost:8080/x
+hours: [7, 14]
+ratio: 0.5
+empty:
+stations:
+  - code: LAX
+    tz: 'America/Los_Angeles'
+  - code: NYC
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"name":  "kalshi # bot",
+		"url":   "http://localhost:8080/x",
+		"hours": []any{int64(7), int64(14)},
+		"ratio": 0.5,
+		"empty": nil,
+		"stations": []any{
+			map[string]any{"code": "LAX", "tz": "America/Los_Angeles"},
+			map[string]any{"code": "NYC"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYAML() = %#v, want %#v", got, want)
+	}
+}
+
+func TestTradingValidate(t *testing.T) {
+	if err := defaultBot().Validate(); err != nil {
+		t.Errorf("Validate(defaults) = %v", err)
+	}
+	bad := defaultBot().Trading
+	bad.MinNoPrice, bad.MaxNoPrice = 90, 50
+	bad.StartHour, bad.EndHour = 15, 14
+	bad.BetYes = -1
+	err := bad.Validate()
+	for _, want := range []string{"bet_yes", "min_no_price 90¢ above max_no_price 50¢", "start_hour 15 not before end_hour 14"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, want %q", err, want)
+		}
+	}
+}
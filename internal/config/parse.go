@@ -0,0 +1,380 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The parsers below read the subset of YAML and TOML that configuration
+// files need: nested maps (YAML blocks, TOML tables), scalars (strings,
+// numbers, booleans) and lists of scalars. Anchors, multi-line strings,
+// YAML flow maps and TOML arrays of tables are rejected rather than
+// misread.
+
+// yamlLine is a non-blank YAML line with its comment removed.
+type yamlLine struct {
+	num    int // 1-based
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML parses a YAML document whose top level is a map.
+func parseYAML(data []byte) (map[string]any, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(string(data), "\n") {
+		raw = strings.TrimRight(stripComment(raw), " \t\r")
+		text := strings.TrimLeft(raw, " ")
+		if text == "" || text == "---" {
+			continue
+		}
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("line %d: tabs can't indent YAML", i+1)
+		}
+		lines = append(lines, yamlLine{num: i + 1, indent: len(raw) - len(text), text: text})
+	}
+	if len(lines) == 0 {
+		return map[string]any{}, nil
+	}
+
+	p := &yamlParser{lines: lines}
+	if isListItem(lines[0].text) {
+		return nil, fmt.Errorf("line %d: top level must be a map", lines[0].num)
+	}
+	m, err := p.parseMap(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].num)
+	}
+	return m, nil
+}
+
+// parseBlock parses the map or list starting at the current line.
+func (p *yamlParser) parseBlock(indent int) (any, error) {
+	if isListItem(p.lines[p.pos].text) {
+		return p.parseList(indent)
+	}
+	return p.parseMap(indent)
+}
+
+func (p *yamlParser) parseMap(indent int) (map[string]any, error) {
+	m := make(map[string]any)
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent {
+		line := p.lines[p.pos]
+		if isListItem(line.text) {
+			return nil, fmt.Errorf("line %d: list item in a map", line.num)
+		}
+		key, rest, ok := cutKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: want key: value, got %q", line.num, line.text)
+		}
+		if _, dup := m[key]; dup {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.num, key)
+		}
+		p.pos++
+
+		if rest != "" {
+			v, err := parseScalar(rest)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line.num, err)
+			}
+			m[key] = v
+			continue
+		}
+		// A nested block, indented or (for lists) at the same indent
+		var v any
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			if next.indent > indent || (next.indent == indent && isListItem(next.text)) {
+				var err error
+				if v, err = p.parseBlock(next.indent); err != nil {
+					return nil, err
+				}
+			}
+		}
+		m[key] = v
+	}
+	if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].num)
+	}
+	return m, nil
+}
+
+func (p *yamlParser) parseList(indent int) ([]any, error) {
+	var list []any
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isListItem(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		item := strings.TrimLeft(line.text[1:], " ")
+		switch {
+		case item == "":
+			p.pos++
+			if p.pos >= len(p.lines) || p.lines[p.pos].indent <= indent {
+				list = append(list, nil)
+				continue
+			}
+			v, err := p.parseBlock(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		case isMapEntry(item):
+			// "- key: value" starts a map indented to the key
+			p.lines[p.pos] = yamlLine{num: line.num, indent: line.indent + len(line.text) - len(item), text: item}
+			v, err := p.parseMap(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		default:
+			v, err := parseScalar(item)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line.num, err)
+			}
+			list = append(list, v)
+			p.pos++
+		}
+	}
+	return list, nil
+}
+
+func isListItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func isMapEntry(text string) bool {
+	if text[0] == '[' {
+		return false
+	}
+	_, _, ok := cutKey(text)
+	return ok
+}
+
+// cutKey splits "key: value" (the value may be empty) outside quotes.
+func cutKey(text string) (key, rest string, ok bool) {
+	if text[0] == '"' || text[0] == '\'' {
+		end := strings.IndexByte(text[1:], text[0])
+		if end < 0 {
+			return "", "", false
+		}
+		key, rest = text[1:end+1], text[end+2:]
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(rest[1:]), true
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:]), i > 0
+		}
+	}
+	return "", "", false
+}
+
+// parseTOML parses a TOML document.
+func parseTOML(data []byte) (map[string]any, error) {
+	root := make(map[string]any)
+	table := root
+	defined := make(map[string]bool)
+
+	for i, raw := range strings.Split(string(data), "\n") {
+		num := i + 1
+		line := strings.TrimSpace(stripComment(raw))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("line %d: arrays of tables aren't supported", num)
+			}
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated table header", num)
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			keys, err := splitDotted(name)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", num, err)
+			}
+			path := strings.Join(keys, ".")
+			if defined[path] {
+				return nil, fmt.Errorf("line %d: table [%s] defined twice", num, path)
+			}
+			defined[path] = true
+			if table, err = subtable(root, keys); err != nil {
+				return nil, fmt.Errorf("line %d: %w", num, err)
+			}
+			continue
+		}
+
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: want key = value, got %q", num, line)
+		}
+		keys, err := splitDotted(strings.TrimSpace(k))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", num, err)
+		}
+		value, err := parseScalar(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", num, err)
+		}
+		parent, err := subtable(table, keys[:len(keys)-1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", num, err)
+		}
+		last := keys[len(keys)-1]
+		if _, dup := parent[last]; dup {
+			return nil, fmt.Errorf("line %d: duplicate key %q", num, last)
+		}
+		parent[last] = value
+	}
+	return root, nil
+}
+
+// subtable returns the table at keys under t, creating missing ones.
+func subtable(t map[string]any, keys []string) (map[string]any, error) {
+	for _, k := range keys {
+		switch v := t[k].(type) {
+		case nil:
+			next := make(map[string]any)
+			t[k] = next
+			t = next
+		case map[string]any:
+			t = v
+		default:
+			return nil, fmt.Errorf("key %q is not a table", k)
+		}
+	}
+	return t, nil
+}
+
+// splitDotted splits a TOML key such as cities.LAX or cities."LAX".
+func splitDotted(s string) ([]string, error) {
+	var keys []string
+	for _, part := range splitOutsideQuotes(s, '.') {
+		part = strings.TrimSpace(part)
+		if len(part) >= 2 && (part[0] == '"' || part[0] == '\'') && part[len(part)-1] == part[0] {
+			part = part[1 : len(part)-1]
+		}
+		if part == "" {
+			return nil, fmt.Errorf("empty key in %q", s)
+		}
+		keys = append(keys, part)
+	}
+	return keys, nil
+}
+
+// parseScalar parses a value shared by both formats: a quoted string, a
+// boolean, a number, a list of those in brackets, or (YAML) a plain string.
+func parseScalar(s string) (any, error) {
+	switch {
+	case s == "":
+		return nil, nil
+	case s[0] == '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("bad string %s", s)
+		}
+		return v, nil
+	case s[0] == '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return nil, fmt.Errorf("bad string %s", s)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	case s[0] == '[':
+		if s[len(s)-1] != ']' {
+			return nil, fmt.Errorf("unterminated list %s", s)
+		}
+		list := []any{}
+		inner := strings.TrimSpace(s[1 : len(s)-1])
+		if inner == "" {
+			return list, nil
+		}
+		for _, item := range splitOutsideQuotes(inner, ',') {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue // Trailing comma
+			}
+			if item[0] == '[' {
+				return nil, fmt.Errorf("nested lists aren't supported")
+			}
+			v, err := parseScalar(item)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	case s[0] == '{', s[0] == '&', s[0] == '*', s[0] == '|', s[0] == '>':
+		return nil, fmt.Errorf("unsupported value %s", s)
+	}
+
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null", "~":
+		return nil, nil
+	}
+	n := strings.ReplaceAll(s, "_", "")
+	if i, err := strconv.ParseInt(n, 10, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(n, 64); err == nil {
+		return f, nil
+	}
+	return s, nil
+}
+
+// stripComment removes a # comment that isn't inside quotes.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// splitOutsideQuotes splits s at sep where it isn't quoted.
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
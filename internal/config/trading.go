@@ -0,0 +1,53 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Trading is the per-city trading parameters the bots share. Load it with
+// Settings.City so each city gets the file's overrides; zero values of the
+// optional fields leave the corresponding check off.
+type Trading struct {
+	BetYes      float64 `json:"bet_yes"`       // Dollars per YES entry
+	BetNo       float64 `json:"bet_no"`        // Dollars per NO entry
+	MinYesPrice int     `json:"min_yes_price"` // Cents
+	MaxYesPrice int     `json:"max_yes_price"` // Cents
+	MinNoPrice  int     `json:"min_no_price"`  // Cents
+	MaxNoPrice  int     `json:"max_no_price"`  // Cents
+	MaxNo       int     `json:"max_no"`        // NO entries per event
+	StartHour   int     `json:"start_hour"`    // Local hour entries open
+	EndHour     int     `json:"end_hour"`      // Local hour entries stop
+	Calibration float64 `json:"calibration"`   // °F added to the METAR max to expect the CLI high
+	Disabled    bool    `json:"disabled"`      // Don't trade the city
+}
+
+// Validate reports every inconsistent parameter.
+func (t Trading) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	price := func(name string, p int) {
+		check(p >= 0 && p <= 99, "%s %d¢ outside 0-99¢", name, p)
+	}
+
+	check(t.BetYes >= 0, "bet_yes $%.2f is negative", t.BetYes)
+	check(t.BetNo >= 0, "bet_no $%.2f is negative", t.BetNo)
+	price("min_yes_price", t.MinYesPrice)
+	price("max_yes_price", t.MaxYesPrice)
+	price("min_no_price", t.MinNoPrice)
+	price("max_no_price", t.MaxNoPrice)
+	check(t.MaxYesPrice == 0 || t.MinYesPrice <= t.MaxYesPrice,
+		"min_yes_price %d¢ above max_yes_price %d¢", t.MinYesPrice, t.MaxYesPrice)
+	check(t.MaxNoPrice == 0 || t.MinNoPrice <= t.MaxNoPrice,
+		"min_no_price %d¢ above max_no_price %d¢", t.MinNoPrice, t.MaxNoPrice)
+	check(t.MaxNo >= 0, "max_no %d is negative", t.MaxNo)
+	check(t.StartHour >= 0 && t.StartHour <= 23, "start_hour %d outside 0-23", t.StartHour)
+	check(t.EndHour >= 0 && t.EndHour <= 24, "end_hour %d outside 0-24", t.EndHour)
+	check(t.StartHour < t.EndHour, "start_hour %d not before end_hour %d", t.StartHour, t.EndHour)
+	check(t.Calibration >= -5 && t.Calibration <= 5, "calibration %+.1f°F beyond ±5°F", t.Calibration)
+	return errors.Join(errs...)
+}
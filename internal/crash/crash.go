@@ -0,0 +1,205 @@
+// Package crash turns a panic in one of a bot's goroutines into an orderly
+// exit that leaves the account safe.
+//
+// A Guard runs goroutines (Go) and recovers panics in them (Recover). On the
+// first panic it runs the hooks: it flushes state to disk, optionally cancels
+// the account's resting orders so nothing fills while the bot is down, and
+// sends an alert with the stack trace. It records the panic in a report file
+// and exits with ExitCode, so a supervisor (systemd, Docker) restarts the bot,
+// which can read the report with Previous and reconcile from the flushed
+// state:
+//
+//	g := crash.New(crash.Hooks{
+//		Flush:  []func() error{saveState},
+//		Cancel: cancelRestingOrders,
+//		Alert:  func(r crash.Report) { notifier.Notify(ctx, notify.Error("Panic", r.String())) },
+//		Report: "data/crash.json",
+//	})
+//	defer g.Recover("main")
+//	g.Go("engine", func() { engine.Run(ctx) })
+package crash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// ExitCode is the status a bot exits with after a panic: EX_SOFTWARE, distinct
+// from the 1 of an ordinary fatal error so a supervisor can tell them apart.
+const ExitCode = 70
+
+// DefaultTimeout bounds the hooks when Hooks.Timeout is 0.
+const DefaultTimeout = 30 * time.Second
+
+// Hooks are run after a panic, in field order, before the exit.
+type Hooks struct {
+	// Flush persist the bot's state, e.g. its journal and open positions.
+	// Each runs even if an earlier one fails.
+	Flush []func() error
+	// Cancel cancels the account's resting orders (nil = leave them).
+	Cancel func() error
+	// Alert sends the report, e.g. to the operators' chat.
+	Alert func(Report)
+	// Report is the file the panic is recorded in ("" = none).
+	Report string
+	// Timeout bounds all the hooks together (0 = DefaultTimeout); a hook
+	// stuck on a dead network doesn't keep the bot from restarting.
+	Timeout time.Duration
+}
+
+// Report describes a panic.
+type Report struct {
+	Time      time.Time `json:"time"`
+	Goroutine string    `json:"goroutine"` // Name given to Go or Recover
+	Panic     string    `json:"panic"`
+	Stack     string    `json:"stack"`
+	Canceled  bool      `json:"canceled"` // Resting orders were canceled
+	Errors    []string  `json:"errors,omitempty"`
+}
+
+func (r Report) String() string {
+	return fmt.Sprintf("panic in %s at %s: %s\n%s", r.Goroutine, r.Time.Format(time.RFC3339), r.Panic, r.Stack)
+}
+
+// Guard handles panics in a bot's goroutines.
+type Guard struct {
+	hooks Hooks
+	once  sync.Once
+	done  chan struct{}
+	exit  func(code int)
+}
+
+// New returns a Guard running hooks on a panic.
+func New(hooks Hooks) *Guard {
+	if hooks.Timeout == 0 {
+		hooks.Timeout = DefaultTimeout
+	}
+	return &Guard{hooks: hooks, done: make(chan struct{}), exit: os.Exit}
+}
+
+// Go runs fn in a new goroutine, handling a panic in it.
+func (g *Guard) Go(name string, fn func()) {
+	go func() {
+		defer g.Recover(name)
+		fn()
+	}()
+}
+
+// Recover handles a panic in the calling goroutine. It must be deferred
+// directly, e.g. at the top of main:
+//
+//	defer g.Recover("main")
+func (g *Guard) Recover(name string) {
+	if r := recover(); r != nil {
+		g.handle(name, r, debug.Stack())
+	}
+}
+
+// handle runs the hooks and exits. A panic in another goroutine meanwhile
+// waits for the first to finish, and the exit.
+func (g *Guard) handle(name string, value any, stack []byte) {
+	first := false
+	g.once.Do(func() { first = true })
+	if !first {
+		<-g.done
+		return
+	}
+
+	report := Report{Time: time.Now(), Goroutine: name, Panic: fmt.Sprint(value), Stack: string(stack)}
+	slog.Error("Panic", "component", "crash", "goroutine", name, "panic", report.Panic, "stack", report.Stack)
+
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		g.runHooks(&report)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(g.hooks.Timeout):
+		slog.Error("Panic hooks timed out", "component", "crash", "timeout", g.hooks.Timeout)
+	}
+
+	close(g.done)
+	g.exit(ExitCode)
+}
+
+func (g *Guard) runHooks(report *Report) {
+	fail := func(what string, err error) {
+		slog.Error("Panic hook failed", "component", "crash", "hook", what, "err", err)
+		report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", what, err))
+	}
+	for _, flush := range g.hooks.Flush {
+		if err := safely(flush); err != nil {
+			fail("flush", err)
+		}
+	}
+	if g.hooks.Cancel != nil {
+		if err := safely(g.hooks.Cancel); err != nil {
+			fail("cancel", err)
+		} else {
+			report.Canceled = true
+		}
+	}
+	if g.hooks.Report != "" {
+		if err := writeReport(g.hooks.Report, *report); err != nil {
+			fail("report", err)
+		}
+	}
+	if g.hooks.Alert != nil {
+		safely(func() error { g.hooks.Alert(*report); return nil })
+	}
+}
+
+// safely runs a hook, turning a panic in it into an error.
+func safely(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn()
+}
+
+func writeReport(path string, r Report) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Previous returns the report of the panic that ended the last run, if any,
+// and archives it beside path with its time, so it is returned once. It
+// returns nil, nil if the last run didn't panic.
+func Previous(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("crash: %w", err)
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("crash: %s: %w", path, err)
+	}
+	archived := fmt.Sprintf("%s.%s", path, r.Time.UTC().Format("20060102T150405Z"))
+	if err := os.Rename(path, archived); err != nil {
+		return nil, fmt.Errorf("crash: %w", err)
+	}
+	return &r, nil
+}
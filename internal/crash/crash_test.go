@@ -0,0 +1,88 @@
+package crash
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGuard_Panic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crash.json")
+	var calls []string
+	var alerted Report
+	g := New(Hooks{
+		Flush: []func() error{
+			func() error { calls = append(calls, "flush journal"); return errors.New("disk full") },
+			func() error { calls = append(calls, "flush positions"); return nil },
+		},
+		Cancel: func() error { calls = append(calls, "cancel"); return nil },
+		Alert:  func(r Report) { calls = append(calls, "alert"); alerted = r },
+		Report: path,
+	})
+	exited := make(chan int, 1)
+	g.exit = func(code int) { exited <- code }
+
+	g.Go("engine", func() {
+		var m map[string]int
+		m["boom"]++
+	})
+	select {
+	case code := <-exited:
+		if code != ExitCode {
+			t.Errorf("exit code = %d, want %d", code, ExitCode)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no exit after a panic")
+	}
+
+	if got := strings.Join(calls, ", "); got != "flush journal, flush positions, cancel, alert" {
+		t.Errorf("hooks ran as %q", got)
+	}
+	if alerted.Goroutine != "engine" || !strings.Contains(alerted.Panic, "nil map") ||
+		!strings.Contains(alerted.Stack, "crash_test.go") || !alerted.Canceled || len(alerted.Errors) != 1 {
+		t.Errorf("alerted %+v", alerted)
+	}
+
+	// The next run sees the report once
+	prev, err := Previous(path)
+	if err != nil || prev == nil || prev.Goroutine != "engine" {
+		t.Fatalf("Previous() = %+v, %v", prev, err)
+	}
+	if prev, err := Previous(path); prev != nil || err != nil {
+		t.Errorf("Previous() again = %+v, %v, want nil", prev, err)
+	}
+	if matches, _ := filepath.Glob(path + ".*"); len(matches) != 1 {
+		t.Errorf("archived reports = %v, want 1", matches)
+	}
+}
+
+func TestGuard_HookTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	g := New(Hooks{
+		Cancel:  func() error { <-block; return nil },
+		Timeout: 10 * time.Millisecond,
+	})
+	exited := make(chan int, 1)
+	g.exit = func(code int) { exited <- code }
+
+	g.Go("feed", func() { panic("stuck") })
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a stuck hook kept the bot from exiting")
+	}
+}
+
+func TestGuard_NoPanic(t *testing.T) {
+	g := New(Hooks{})
+	g.exit = func(code int) { t.Errorf("exit(%d) without a panic", code) }
+	done := make(chan struct{})
+	g.Go("worker", func() { close(done) })
+	<-done
+	func() {
+		defer g.Recover("main")
+	}()
+}
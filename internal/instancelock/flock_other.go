@@ -0,0 +1,13 @@
+//go:build !unix
+
+package instancelock
+
+import "os"
+
+func tryLock(f *os.File) (held bool, err error) {
+	return false, ErrUnsupported
+}
+
+func unlock(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,131 @@
+// Package instancelock prevents two copies of a bot from trading the same
+// account with the same strategy at once.
+//
+// The lock is an exclusive advisory lock on a file in a shared directory
+// (e.g. the bot's data volume). The operating system releases it when the
+// process exits, so a crashed bot never leaves a stale lock behind. The file
+// records who holds the lock so a refused second copy can report it.
+package instancelock
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrUnsupported is returned by Acquire on platforms without file locks.
+var ErrUnsupported = errors.New("instancelock: file locks not supported on this platform")
+
+// Holder describes the process holding a lock.
+type Holder struct {
+	PID      int       `json:"pid"`
+	Host     string    `json:"host"`
+	Strategy string    `json:"strategy"`
+	Since    time.Time `json:"since"`
+}
+
+// HeldError is returned by Acquire when another process holds the lock.
+type HeldError struct {
+	Path   string
+	Holder Holder // Zero if the holder could not be read
+}
+
+func (e *HeldError) Error() string {
+	if e.Holder.PID == 0 {
+		return fmt.Sprintf("instancelock: %s is held by another process", e.Path)
+	}
+	return fmt.Sprintf("instancelock: %s is held by pid %d on %s since %s",
+		e.Path, e.Holder.PID, e.Holder.Host, e.Holder.Since.Format(time.RFC3339))
+}
+
+// Lock is a held instance lock.
+type Lock struct {
+	path string
+	file *os.File
+}
+
+// Path returns the lock file for an account and strategy in dir. The
+// account (an API key ID) is hashed so it does not appear in file names.
+func Path(dir, account, strategy string) string {
+	sum := sha256.Sum256([]byte(account))
+	return filepath.Join(dir, fmt.Sprintf("%s-%s.lock", strategy, hex.EncodeToString(sum[:6])))
+}
+
+// Acquire takes the lock for account and strategy in dir without blocking.
+// It returns a *HeldError if another process already holds it.
+func Acquire(dir, account, strategy string) (*Lock, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("instancelock: %w", err)
+	}
+	path := Path(dir, account, strategy)
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("instancelock: %w", err)
+	}
+
+	held, err := tryLock(f)
+	if errors.Is(err, ErrUnsupported) {
+		f.Close()
+		return nil, err
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("instancelock: %w", err)
+	}
+	if held {
+		holder, _ := readHolder(path)
+		f.Close()
+		return nil, &HeldError{Path: path, Holder: holder}
+	}
+
+	host, _ := os.Hostname()
+	data, err := json.Marshal(Holder{PID: os.Getpid(), Host: host, Strategy: strategy, Since: time.Now()})
+	if err == nil {
+		err = f.Truncate(0)
+	}
+	if err == nil {
+		_, err = f.WriteAt(data, 0)
+	}
+	if err != nil {
+		unlock(f)
+		f.Close()
+		return nil, fmt.Errorf("instancelock: record holder: %w", err)
+	}
+
+	return &Lock{path: path, file: f}, nil
+}
+
+// Release gives up the lock.
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	l.file.Truncate(0)
+	err := unlock(l.file)
+	if cerr := l.file.Close(); err == nil {
+		err = cerr
+	}
+	l.file = nil
+	return err
+}
+
+// Path returns the lock file path.
+func (l *Lock) Path() string {
+	return l.path
+}
+
+func readHolder(path string) (Holder, error) {
+	var h Holder
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return h, err
+	}
+	err = json.Unmarshal(data, &h)
+	return h, err
+}
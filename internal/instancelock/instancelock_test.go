@@ -0,0 +1,55 @@
+package instancelock
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAcquire_RefusesSecondCopy(t *testing.T) {
+	dir := t.TempDir()
+
+	first, err := Acquire(dir, "key-1", "dualside")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	_, err = Acquire(dir, "key-1", "dualside")
+	var held *HeldError
+	if !errors.As(err, &held) {
+		t.Fatalf("second Acquire() error = %v, want *HeldError", err)
+	}
+	if held.Holder.PID != os.Getpid() || held.Holder.Strategy != "dualside" {
+		t.Errorf("Holder = %+v, want this process running dualside", held.Holder)
+	}
+
+	// Other accounts and strategies are independent
+	for _, key := range [][2]string{{"key-2", "dualside"}, {"key-1", "ensemble"}} {
+		l, err := Acquire(dir, key[0], key[1])
+		if err != nil {
+			t.Errorf("Acquire(%s, %s) error = %v", key[0], key[1], err)
+			continue
+		}
+		l.Release()
+	}
+
+	if err := first.Release(); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	again, err := Acquire(dir, "key-1", "dualside")
+	if err != nil {
+		t.Fatalf("Acquire() after Release error = %v", err)
+	}
+	again.Release()
+}
+
+func TestPath_HidesAccount(t *testing.T) {
+	p := Path("/data", "secret-key-id", "dualside")
+	if p != Path("/data", "secret-key-id", "dualside") {
+		t.Errorf("Path() is not stable")
+	}
+	if len(p) == 0 || strings.Contains(p, "secret") {
+		t.Errorf("Path() = %q, want hashed account", p)
+	}
+}
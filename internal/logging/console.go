@@ -0,0 +1,173 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConsoleHandler writes records for a person at a terminal:
+//
+//	09:15:00 [execution] Order placed ticker=KXHIGHLAX-25DEC05-B60.5 count=731
+//	09:15:01 WARN [weather] Stale METAR age=3h10m
+//
+// Info records carry no level label, so a quiet bot reads like the plain
+// output it replaced.
+type ConsoleHandler struct {
+	opts      slog.HandlerOptions
+	component string
+	attrs     []slog.Attr
+	groups    []string
+
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+// NewConsoleHandler returns a console handler writing to w.
+func NewConsoleHandler(w io.Writer, opts *slog.HandlerOptions) *ConsoleHandler {
+	h := &ConsoleHandler{w: w, mu: &sync.Mutex{}}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+func (h *ConsoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	min := slog.LevelInfo
+	if h.opts.Level != nil {
+		min = h.opts.Level.Level()
+	}
+	return level >= min
+}
+
+func (h *ConsoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+	buf.WriteString(r.Time.Format("15:04:05"))
+	if r.Level != slog.LevelInfo {
+		buf.WriteString(" " + r.Level.String())
+	}
+
+	component := h.component
+	var attrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == "component" && len(h.groups) == 0 {
+			component = a.Value.String()
+		} else {
+			attrs = append(attrs, a)
+		}
+		return true
+	})
+	if component != "" {
+		buf.WriteString(" [" + component + "]")
+	}
+	buf.WriteString(" " + r.Message)
+
+	// Attributes added by With are already qualified by their groups
+	for _, a := range h.attrs {
+		writeAttr(&buf, "", a)
+	}
+	prefix := strings.Join(h.groups, ".")
+	if prefix != "" {
+		prefix += "."
+	}
+	for _, a := range attrs {
+		writeAttr(&buf, prefix, a)
+	}
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+func writeAttr(buf *bytes.Buffer, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		for _, g := range a.Value.Group() {
+			writeAttr(buf, prefix+a.Key+".", g)
+		}
+		return
+	}
+	val := a.Value.String()
+	if a.Value.Kind() == slog.KindDuration {
+		val = a.Value.Duration().Round(time.Second).String()
+	}
+	if strings.ContainsAny(val, " \t\"=") {
+		val = fmt.Sprintf("%q", val)
+	}
+	fmt.Fprintf(buf, " %s%s=%s", prefix, a.Key, val)
+}
+
+func (h *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := *h
+	out.attrs = append([]slog.Attr(nil), h.attrs...)
+	for _, a := range attrs {
+		if a.Key == "component" && len(h.groups) == 0 {
+			out.component = a.Value.String()
+			continue
+		}
+		if len(h.groups) > 0 {
+			a.Key = strings.Join(h.groups, ".") + "." + a.Key
+		}
+		out.attrs = append(out.attrs, a)
+	}
+	return &out
+}
+
+func (h *ConsoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	out := *h
+	out.groups = append(append([]string(nil), h.groups...), name)
+	return &out
+}
+
+// bridge is the standard log package's output. Each write is one log call:
+// a leading "[Engine]" becomes the component, and the level is read from the
+// message (errors and failures at error, warnings at warn, else info).
+type bridge struct {
+	handler slog.Handler
+}
+
+func (b *bridge) Write(p []byte) (int, error) {
+	msg := strings.TrimRight(string(p), "\n")
+	component := ""
+	if strings.HasPrefix(msg, "[") {
+		if end := strings.Index(msg, "]"); end > 0 {
+			component = strings.ToLower(msg[1:end])
+			msg = strings.TrimSpace(msg[end+1:])
+		}
+	}
+
+	level := bridgeLevel(msg)
+	ctx := context.Background()
+	if !b.handler.Enabled(ctx, level) {
+		return len(p), nil
+	}
+	r := slog.NewRecord(time.Now(), level, msg, 0)
+	if component != "" {
+		r.AddAttrs(slog.String("component", component))
+	}
+	return len(p), b.handler.Handle(ctx, r)
+}
+
+func bridgeLevel(msg string) slog.Level {
+	lower := strings.ToLower(msg)
+	switch {
+	case strings.Contains(lower, "error") || strings.Contains(lower, "failed") || strings.Contains(lower, "fatal"):
+		return slog.LevelError
+	case strings.Contains(lower, "warning") || strings.Contains(msg, "⚠"):
+		return slog.LevelWarn
+	}
+	return slog.LevelInfo
+}
@@ -0,0 +1,237 @@
+// Package logging sets up structured, leveled logging for the commands.
+//
+// Logs go to the console in one of three formats: a human-friendly console
+// view (the default), slog's key=value text, or JSON lines. They can also be
+// written as JSON to a file at the same time, so a bot watched in a terminal
+// still leaves machine-readable logs for later analysis. Each part of a bot
+// logs through its own component logger (see For), and output from the
+// standard log package is routed through the same handlers, its "[Engine]"
+// style prefixes becoming components.
+package logging
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Components shared across the commands.
+const (
+	Weather   = "weather"
+	Market    = "market"
+	Execution = "execution"
+)
+
+// Formats of the console sink.
+const (
+	FormatConsole = "console"
+	FormatText    = "text"
+	FormatJSON    = "json"
+)
+
+// Config configures logging.
+type Config struct {
+	Level  string    // debug, info, warn or error (default info)
+	Format string    // console, text or json (default console)
+	File   string    // Also append JSON logs to this file ("" = none)
+	Output io.Writer // Console sink (default os.Stderr)
+}
+
+// ConfigFromEnv returns the configuration in LOG_LEVEL, LOG_FORMAT and
+// LOG_FILE.
+func ConfigFromEnv() Config {
+	return Config{
+		Level:  os.Getenv("LOG_LEVEL"),
+		Format: os.Getenv("LOG_FORMAT"),
+		File:   os.Getenv("LOG_FILE"),
+	}
+}
+
+// RegisterFlags adds -log-level, -log-format and -log-file to fs, defaulting
+// to the environment.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	env := ConfigFromEnv()
+	fs.StringVar(&c.Level, "log-level", env.Level, "Log level: debug, info, warn or error (default info, env LOG_LEVEL)")
+	fs.StringVar(&c.Format, "log-format", env.Format, "Log format: console, text or json (default console, env LOG_FORMAT)")
+	fs.StringVar(&c.File, "log-file", env.File, "Also append JSON logs to this file (env LOG_FILE)")
+}
+
+// ParseLevel parses a level name; "" is info.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("logging: unknown level %q", s)
+	}
+	return level, nil
+}
+
+var (
+	mu      sync.Mutex
+	console io.Writer = os.Stderr
+)
+
+// Setup installs the configured handlers as slog's default logger and routes
+// the standard log package through them. The returned function closes the
+// log file, if any.
+func Setup(cfg Config) (func() error, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	out := cfg.Output
+	if out == nil {
+		out = os.Stderr
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handlers []slog.Handler
+	cons := io.Discard
+	switch strings.ToLower(cfg.Format) {
+	case "", FormatConsole:
+		handlers = append(handlers, NewConsoleHandler(out, opts))
+		cons = out
+	case FormatText:
+		handlers = append(handlers, slog.NewTextHandler(out, opts))
+	case FormatJSON:
+		handlers = append(handlers, slog.NewJSONHandler(out, opts))
+	default:
+		return nil, fmt.Errorf("logging: unknown format %q", cfg.Format)
+	}
+
+	closeFn := func() error { return nil }
+	if cfg.File != "" {
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("logging: %w", err)
+		}
+		handlers = append(handlers, slog.NewJSONHandler(f, opts))
+		closeFn = f.Close
+	}
+
+	var h slog.Handler = handlers[0]
+	if len(handlers) > 1 {
+		h = multiHandler(handlers)
+	}
+	slog.SetDefault(slog.New(h))
+
+	// slog.SetDefault points the standard logger at the handler at info;
+	// route it through the bridge instead for components and levels
+	log.SetFlags(0)
+	log.SetPrefix("")
+	log.SetOutput(&bridge{handler: h})
+
+	mu.Lock()
+	console = cons
+	mu.Unlock()
+	return closeFn, nil
+}
+
+// For returns the logger of a component. It logs through whichever default
+// logger is installed when it is used, so it may be created before Setup,
+// for example in a package-level variable.
+func For(component string) *slog.Logger {
+	return slog.New(deferred{}).With("component", component)
+}
+
+// Console returns the writer for human-only output such as banners and
+// tables: the console in the console format, else io.Discard so they don't
+// corrupt machine-readable logs.
+func Console() io.Writer {
+	mu.Lock()
+	defer mu.Unlock()
+	return console
+}
+
+// Fatal logs msg at error level and exits with status 1.
+func Fatal(logger *slog.Logger, msg string, args ...any) {
+	logger.Error(msg, args...)
+	os.Exit(1)
+}
+
+// multiHandler sends each record to every handler that accepts its level.
+type multiHandler []slog.Handler
+
+func (m multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range m {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range m {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(multiHandler, len(m))
+	for i, h := range m {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (m multiHandler) WithGroup(name string) slog.Handler {
+	out := make(multiHandler, len(m))
+	for i, h := range m {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
+
+// deferred hands records to slog's default handler at the time they are
+// logged, replaying the attributes and groups added to it.
+type deferred struct {
+	wrap func(slog.Handler) slog.Handler
+}
+
+func (d deferred) handler() slog.Handler {
+	h := slog.Default().Handler()
+	if d.wrap != nil {
+		h = d.wrap(h)
+	}
+	return h
+}
+
+func (d deferred) Enabled(ctx context.Context, level slog.Level) bool {
+	return slog.Default().Handler().Enabled(ctx, level)
+}
+
+func (d deferred) Handle(ctx context.Context, r slog.Record) error {
+	return d.handler().Handle(ctx, r)
+}
+
+func (d deferred) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return deferred{wrap: func(h slog.Handler) slog.Handler {
+		if d.wrap != nil {
+			h = d.wrap(h)
+		}
+		return h.WithAttrs(attrs)
+	}}
+}
+
+func (d deferred) WithGroup(name string) slog.Handler {
+	return deferred{wrap: func(h slog.Handler) slog.Handler {
+		if d.wrap != nil {
+			h = d.wrap(h)
+		}
+		return h.WithGroup(name)
+	}}
+}
@@ -0,0 +1,132 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsoleHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewConsoleHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	logger.With("component", Execution).Info("Order placed", "ticker", "KXHIGHLAX-25DEC05-B60.5", "count", 731)
+	logger.Warn("Stale METAR", "age", 3*time.Hour+10*time.Minute+4*time.Second, "note", "feed down")
+	logger.WithGroup("order").With("id", "o1").Info("Filled", "price", 41)
+	logger.Debug("hidden")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		" [execution] Order placed ticker=KXHIGHLAX-25DEC05-B60.5 count=731",
+		` WARN Stale METAR age=3h10m4s note="feed down"`,
+		" Filled order.id=o1 order.price=41",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	for i, line := range lines {
+		// Lines start with the time, HH:MM:SS
+		if len(line) < 8 || line[8:] != want[i] {
+			t.Errorf("line %d = %q, want time + %q", i, line, want[i])
+		}
+	}
+}
+
+// restoreDefaults undoes Setup's global changes when the test ends.
+func restoreDefaults(t *testing.T) {
+	logger, out, flags := slog.Default(), log.Writer(), log.Flags()
+	t.Cleanup(func() {
+		slog.SetDefault(logger)
+		log.SetOutput(out)
+		log.SetFlags(flags)
+		mu.Lock()
+		console = os.Stderr
+		mu.Unlock()
+	})
+}
+
+func TestSetup_JSON(t *testing.T) {
+	restoreDefaults(t)
+	weather := For(Weather) // before Setup, as a package-level logger would be
+	var buf bytes.Buffer
+	file := filepath.Join(t.TempDir(), "bot.log")
+	closeFn, err := Setup(Config{Level: "debug", Format: FormatJSON, File: file, Output: &buf})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	weather.Debug("METAR", "temp_f", 61.2)
+	log.Printf("[Engine] Failed to place order: %v", "rate limited")
+	if err := closeFn(); err != nil {
+		t.Fatalf("close error = %v", err)
+	}
+	if Console() != io.Discard {
+		t.Error("Console() in json format is not io.Discard")
+	}
+
+	fromFile, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string][]byte{"console": buf.Bytes(), "file": fromFile} {
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("%s: got %d lines, want 2:\n%s", name, len(lines), data)
+		}
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if rec["level"] != "DEBUG" || rec["component"] != Weather || rec["temp_f"] != 61.2 {
+			t.Errorf("%s: record = %v", name, rec)
+		}
+		if err := json.Unmarshal([]byte(lines[1]), &rec); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if rec["level"] != "ERROR" || rec["component"] != "engine" || rec["msg"] != "Failed to place order: rate limited" {
+			t.Errorf("%s: bridged record = %v", name, rec)
+		}
+	}
+}
+
+func TestSetup_Errors(t *testing.T) {
+	restoreDefaults(t)
+	if _, err := Setup(Config{Format: "xml"}); err == nil {
+		t.Error("Setup() with an unknown format error = nil")
+	}
+	if _, err := Setup(Config{Level: "loud"}); err == nil {
+		t.Error("Setup() with an unknown level error = nil")
+	}
+}
+
+func TestBridgeLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"Order placed":                slog.LevelInfo,
+		"Error fetching markets: EOF": slog.LevelError,
+		"Warning: no forecast":        slog.LevelWarn,
+		"⚠️ Position limit reached":   slog.LevelWarn,
+	}
+	for msg, want := range tests {
+		if got := bridgeLevel(msg); got != want {
+			t.Errorf("bridgeLevel(%q) = %v, want %v", msg, got, want)
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for s, want := range map[string]slog.Level{"": slog.LevelInfo, "debug": slog.LevelDebug, "WARN": slog.LevelWarn, "error": slog.LevelError} {
+		if got, err := ParseLevel(s); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("ParseLevel(\"loud\") error = nil")
+	}
+}
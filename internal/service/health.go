@@ -0,0 +1,212 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Health tracks the dependencies of a long-running bot for liveness probes:
+// when each feed last succeeded and whether its connections are up. A
+// critical feed that hasn't succeeded within its maximum age, or a critical
+// connection that is down, makes the bot unhealthy, so Docker or Kubernetes
+// can restart it. A nil Health ignores reports.
+//
+// Unlike a Gate, which holds a bot back until it can trade, Health reports
+// whether a running bot has stopped being able to.
+type Health struct {
+	mu         sync.Mutex
+	started    time.Time
+	components map[string]*Component
+	info       map[string]any
+	now        func() time.Time
+}
+
+// Component is the health of one feed or connection.
+type Component struct {
+	Name        string        `json:"name"`
+	Kind        string        `json:"kind"` // "feed" or "connection"
+	Critical    bool          `json:"critical"`
+	Healthy     bool          `json:"healthy"`
+	MaxAge      time.Duration `json:"-"`
+	MaxAgeSecs  float64       `json:"max_age_seconds,omitempty"`
+	LastSuccess time.Time     `json:"last_success,omitzero"`
+	AgeSecs     float64       `json:"age_seconds,omitempty"` // Since LastSuccess
+	LastError   string        `json:"last_error,omitempty"`
+	LastErrorAt time.Time     `json:"last_error_at,omitzero"`
+	Connected   bool          `json:"connected,omitempty"`
+}
+
+// HealthReport is the bot's health at a point in time.
+type HealthReport struct {
+	Status     string         `json:"status"` // "ok" or "unhealthy"
+	Timestamp  time.Time      `json:"timestamp"`
+	UptimeSecs float64        `json:"uptime_seconds"`
+	Components []Component    `json:"components"`
+	Info       map[string]any `json:"info,omitempty"`
+}
+
+// NewHealth returns a Health with no components.
+func NewHealth() *Health {
+	return &Health{
+		started:    time.Now(),
+		components: make(map[string]*Component),
+		info:       make(map[string]any),
+		now:        time.Now,
+	}
+}
+
+// Feed registers a feed, healthy while it last succeeded within maxAge. A
+// feed that hasn't succeeded yet is healthy for maxAge after NewHealth, so a
+// starting bot isn't restarted before its first fetch.
+func (h *Health) Feed(name string, maxAge time.Duration, critical bool) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.components[name] = &Component{Name: name, Kind: "feed", Critical: critical, MaxAge: maxAge}
+}
+
+// Connection registers a connection, healthy while connected or for maxAge
+// after it drops, time to reconnect. Like a feed, it is given maxAge after
+// NewHealth to connect.
+func (h *Health) Connection(name string, maxAge time.Duration, critical bool) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.components[name] = &Component{Name: name, Kind: "connection", Critical: critical, MaxAge: maxAge}
+}
+
+// SetCritical changes whether a component's health is the bot's, e.g. for
+// a feed polled only while the trading window is open.
+func (h *Health) SetCritical(name string, critical bool) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if c, ok := h.components[name]; ok {
+		c.Critical = critical
+	}
+}
+
+// Observe records the result of a fetch from a feed: a success if err is
+// nil. Unregistered names are ignored.
+func (h *Health) Observe(name string, err error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	c, ok := h.components[name]
+	if !ok {
+		return
+	}
+	now := h.now()
+	if err != nil {
+		c.LastError, c.LastErrorAt = err.Error(), now
+		return
+	}
+	c.LastSuccess = now
+}
+
+// SetConnected records a connection going up or down.
+func (h *Health) SetConnected(name string, connected bool) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	c, ok := h.components[name]
+	if !ok {
+		return
+	}
+	c.Connected = connected
+	if connected {
+		c.LastSuccess = h.now()
+	} else {
+		c.LastErrorAt = h.now()
+		c.LastError = "disconnected"
+	}
+}
+
+// SetInfo adds state to the report that doesn't affect health, e.g. whether
+// the trading window is open.
+func (h *Health) SetInfo(key string, value any) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.info[key] = value
+}
+
+// Report returns the health of every component, sorted by name.
+func (h *Health) Report() HealthReport {
+	if h == nil {
+		return HealthReport{Status: "ok", Timestamp: time.Now()}
+	}
+	now := h.now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	r := HealthReport{Status: "ok", Timestamp: now, UptimeSecs: now.Sub(h.started).Seconds()}
+	for _, c := range h.components {
+		s := *c
+		s.MaxAgeSecs = s.MaxAge.Seconds()
+		if !s.LastSuccess.IsZero() {
+			s.AgeSecs = now.Sub(s.LastSuccess).Seconds()
+		}
+		// Time the component has been failing: a feed since it last
+		// succeeded, a connection since it dropped, both at least since
+		// the start
+		since := s.LastSuccess
+		if s.Kind == "connection" {
+			switch {
+			case s.Connected:
+				since = now
+			case !s.LastSuccess.IsZero():
+				since = s.LastErrorAt
+			}
+		}
+		if since.IsZero() {
+			since = h.started
+		}
+		s.Healthy = s.MaxAge <= 0 || now.Sub(since) <= s.MaxAge
+		if s.Critical && !s.Healthy {
+			r.Status = "unhealthy"
+		}
+		r.Components = append(r.Components, s)
+	}
+	sort.Slice(r.Components, func(i, j int) bool { return r.Components[i].Name < r.Components[j].Name })
+	if len(h.info) > 0 {
+		r.Info = make(map[string]any, len(h.info))
+		for k, v := range h.info {
+			r.Info[k] = v
+		}
+	}
+	return r
+}
+
+// Healthy returns false if a critical component is unhealthy.
+func (h *Health) Healthy() bool {
+	return h.Report().Status == "ok"
+}
+
+// Handler answers liveness probes: 200 while healthy, else 503, with the
+// report as JSON.
+func (h *Health) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		r := h.Report()
+		w.Header().Set("Content-Type", "application/json")
+		if r.Status != "ok" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(r)
+	})
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealthFeed(t *testing.T) {
+	start := time.Date(2025, 12, 5, 9, 0, 0, 0, time.UTC)
+	now := start
+	h := NewHealth()
+	h.started, h.now = start, func() time.Time { return now }
+	h.Feed("metar", 30*time.Minute, true)
+	h.Feed("forecast", 30*time.Minute, false)
+
+	// Healthy during the grace period before the first fetch
+	now = start.Add(10 * time.Minute)
+	if !h.Healthy() {
+		t.Errorf("unhealthy in the grace period: %+v", h.Report())
+	}
+
+	h.Observe("metar", nil)
+	now = now.Add(25 * time.Minute)
+	h.Observe("metar", errors.New("timeout"))
+	r := h.Report()
+	if r.Status != "ok" || r.Components[1].Name != "metar" || r.Components[1].LastError != "timeout" || r.Components[1].AgeSecs != 1500 {
+		t.Errorf("Report() = %+v", r)
+	}
+
+	// Stale past the max age since the last success
+	now = now.Add(10 * time.Minute)
+	if h.Healthy() {
+		t.Error("healthy with a stale critical feed")
+	}
+	h.SetCritical("metar", false)
+	if !h.Healthy() {
+		t.Error("unhealthy with only non-critical feeds stale")
+	}
+	h.SetCritical("metar", true)
+
+	// A stale non-critical feed is reported but doesn't fail the check
+	h.Observe("metar", nil)
+	r = h.Report()
+	if r.Status != "ok" || r.Components[0].Healthy {
+		t.Errorf("Report() = %+v, want ok with forecast unhealthy", r)
+	}
+}
+
+func TestHealthConnection(t *testing.T) {
+	start := time.Date(2025, 12, 5, 9, 0, 0, 0, time.UTC)
+	now := start
+	h := NewHealth()
+	h.started, h.now = start, func() time.Time { return now }
+	h.Connection("websocket", time.Minute, true)
+
+	h.SetConnected("websocket", true)
+	now = start.Add(time.Hour)
+	if !h.Healthy() {
+		t.Error("unhealthy while connected")
+	}
+
+	h.SetConnected("websocket", false)
+	now = now.Add(30 * time.Second)
+	if !h.Healthy() {
+		t.Error("unhealthy while reconnecting")
+	}
+	now = now.Add(time.Minute)
+	if h.Healthy() {
+		t.Error("healthy a minute after disconnecting")
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	now := time.Now()
+	h := NewHealth()
+	h.now = func() time.Time { return now }
+	h.Feed("kalshi", time.Minute, true)
+	h.SetInfo("trading_window", "open")
+	h.Observe("kalshi", nil)
+
+	get := func() (int, HealthReport) {
+		rec := httptest.NewRecorder()
+		h.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/health", nil))
+		var r HealthReport
+		if err := json.NewDecoder(rec.Body).Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		return rec.Code, r
+	}
+
+	code, r := get()
+	if code != http.StatusOK || r.Status != "ok" || r.Info["trading_window"] != "open" || len(r.Components) != 1 {
+		t.Errorf("healthy: %d %+v", code, r)
+	}
+	now = now.Add(2 * time.Minute)
+	if code, r := get(); code != http.StatusServiceUnavailable || r.Status != "unhealthy" {
+		t.Errorf("stale: %d %+v", code, r)
+	}
+
+	var nilHealth *Health
+	nilHealth.Observe("kalshi", errors.New("x"))
+	if !nilHealth.Healthy() {
+		t.Error("nil Health unhealthy")
+	}
+}
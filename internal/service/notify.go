@@ -0,0 +1,40 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+// Notify sends state to systemd by the sd_notify protocol, e.g. "READY=1",
+// for units with Type=notify. It returns false, nil outside systemd, where
+// NOTIFY_SOCKET is unset.
+func Notify(state string) (bool, error) {
+	path := os.Getenv("NOTIFY_SOCKET")
+	if path == "" {
+		return false, nil
+	}
+	if path[0] == '@' {
+		path = "\x00" + path[1:] // Abstract socket
+	}
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return false, fmt.Errorf("service: notify: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return false, fmt.Errorf("service: notify: %w", err)
+	}
+	return true, nil
+}
+
+// NotifyReady tells systemd the bot is ready, with status shown by
+// systemctl status.
+func NotifyReady(status string) (bool, error) {
+	return Notify("READY=1\nSTATUS=" + status)
+}
+
+// NotifyStopping tells systemd the bot is shutting down.
+func NotifyStopping() (bool, error) {
+	return Notify("STOPPING=1")
+}
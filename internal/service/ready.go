@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Check is the last result of one readiness check.
+type Check struct {
+	Name    string    `json:"name"`
+	OK      bool      `json:"ok"`
+	Error   string    `json:"error,omitempty"`
+	Checked time.Time `json:"checked,omitzero"` // Zero until first reported
+}
+
+// Gate tracks the checks a bot must pass to be ready, e.g. "state" once it is
+// reconciled with the account and "weather" while its feed is fresh. It is
+// ready while every check last passed; a check not reported yet fails. A nil
+// Gate is always ready and ignores reports.
+type Gate struct {
+	mu     sync.Mutex
+	checks []Check
+	now    func() time.Time
+}
+
+// NewGate returns a Gate over the named checks.
+func NewGate(names ...string) *Gate {
+	g := &Gate{now: time.Now}
+	for _, name := range names {
+		g.checks = append(g.checks, Check{Name: name, Error: "not checked yet"})
+	}
+	return g
+}
+
+// Report records the result of a check: passed if err is nil. Reports of
+// checks the Gate wasn't created with are ignored.
+func (g *Gate) Report(name string, err error) {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for i := range g.checks {
+		c := &g.checks[i]
+		if c.Name != name {
+			continue
+		}
+		c.OK, c.Error, c.Checked = err == nil, "", g.now()
+		if err != nil {
+			c.Error = err.Error()
+		}
+	}
+}
+
+// Ready returns true if every check last passed.
+func (g *Gate) Ready() bool {
+	if g == nil {
+		return true
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.readyLocked()
+}
+
+func (g *Gate) readyLocked() bool {
+	for _, c := range g.checks {
+		if !c.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// Checks returns the checks' last results, in the order given to NewGate.
+func (g *Gate) Checks() []Check {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Check(nil), g.checks...)
+}
+
+// Err describes the failing checks, or returns nil if ready.
+func (g *Gate) Err() error {
+	var failing []string
+	for _, c := range g.Checks() {
+		if !c.OK {
+			failing = append(failing, fmt.Sprintf("%s: %s", c.Name, c.Error))
+		}
+	}
+	if len(failing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("service: not ready: %s", strings.Join(failing, "; "))
+}
+
+// Await runs probe, then again every interval, until the Gate is ready or
+// ctx is done. The probe reports to the Gate, e.g. by reconciling the
+// account and fetching from each feed. It returns the failing checks if ctx
+// ends first.
+func (g *Gate) Await(ctx context.Context, interval time.Duration, probe func()) error {
+	if g == nil {
+		return nil
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		probe()
+		if g.Ready() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (%v)", g.Err(), ctx.Err())
+		case <-t.C:
+		}
+	}
+}
+
+// Handler answers readiness probes: 200 while ready, else 503, with the
+// checks as JSON.
+func (g *Gate) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		ready := g.Ready()
+		w.Header().Set("Content-Type", "application/json")
+		if !ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(struct {
+			Ready  bool    `json:"ready"`
+			Checks []Check `json:"checks"`
+		}{ready, g.Checks()})
+	})
+}
@@ -0,0 +1,61 @@
+// Package service lets a bot run under a supervisor (systemd, Docker,
+// Kubernetes) that manages its lifecycle.
+//
+// A bot runs in one of three modes. In the foreground, the default, it trades
+// until stopped and logs for a person watching. As a daemon it does the same
+// under a supervisor: it logs JSON and tells systemd when it is ready and when
+// it is stopping (see Notify). In oneshot mode it runs one decision cycle and
+// exits, for a cron job or systemd timer.
+//
+// In every mode a Gate holds the bot back until it is ready: its state is
+// reconciled with the account and its feeds are healthy. The Gate answers
+// readiness probes (see Gate.Handler) with 503 until then.
+package service
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+// Mode is how a bot runs.
+type Mode string
+
+// Modes.
+const (
+	Foreground Mode = "foreground"
+	Daemon     Mode = "daemon"
+	Oneshot    Mode = "oneshot"
+)
+
+// DefaultReadyTimeout bounds the wait for readiness in oneshot mode.
+const DefaultReadyTimeout = 2 * time.Minute
+
+// Options are the run mode flags.
+type Options struct {
+	Oneshot bool
+	Daemon  bool
+	// ReadyTimeout bounds the wait for readiness in oneshot mode, after
+	// which the run fails; a daemon waits as long as its supervisor lets it.
+	ReadyTimeout time.Duration
+}
+
+// RegisterFlags adds -oneshot, -daemon and -ready-timeout to fs.
+func (o *Options) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&o.Oneshot, "oneshot", false, "Run one trading cycle once ready, then exit")
+	fs.BoolVar(&o.Daemon, "daemon", false, "Run under a supervisor: JSON logs and systemd readiness notification")
+	fs.DurationVar(&o.ReadyTimeout, "ready-timeout", DefaultReadyTimeout, "With -oneshot, fail if not ready within this long")
+}
+
+// Mode returns the mode the flags select.
+func (o Options) Mode() (Mode, error) {
+	switch {
+	case o.Oneshot && o.Daemon:
+		return "", errors.New("service: -oneshot and -daemon are exclusive")
+	case o.Oneshot:
+		return Oneshot, nil
+	case o.Daemon:
+		return Daemon, nil
+	}
+	return Foreground, nil
+}
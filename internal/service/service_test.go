@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptionsMode(t *testing.T) {
+	tests := []struct {
+		args []string
+		want Mode
+		err  bool
+	}{
+		{nil, Foreground, false},
+		{[]string{"-daemon"}, Daemon, false},
+		{[]string{"-oneshot", "-ready-timeout", "30s"}, Oneshot, false},
+		{[]string{"-oneshot", "-daemon"}, "", true},
+	}
+	for _, tt := range tests {
+		var o Options
+		fs := flag.NewFlagSet("bot", flag.ContinueOnError)
+		o.RegisterFlags(fs)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+		got, err := o.Mode()
+		if got != tt.want || (err != nil) != tt.err {
+			t.Errorf("Mode(%v) = %q, %v, want %q", tt.args, got, err, tt.want)
+		}
+	}
+}
+
+func TestGate(t *testing.T) {
+	g := NewGate("state", "weather")
+	if g.Ready() || g.Err() == nil {
+		t.Fatal("Ready() before any report")
+	}
+
+	g.Report("state", nil)
+	g.Report("weather", errors.New("stale"))
+	g.Report("unknown", errors.New("ignored"))
+	if g.Ready() {
+		t.Error("Ready() with a failing check")
+	}
+	if err := g.Err(); err == nil || !strings.Contains(err.Error(), "weather: stale") || strings.Contains(err.Error(), "state") {
+		t.Errorf("Err() = %v", err)
+	}
+
+	g.Report("weather", nil)
+	if !g.Ready() || g.Err() != nil {
+		t.Errorf("Ready() = false after all passed: %v", g.Err())
+	}
+
+	// A feed going stale makes the bot unready again
+	g.Report("weather", errors.New("stale"))
+	if g.Ready() {
+		t.Error("Ready() after a check failed again")
+	}
+
+	var nilGate *Gate
+	nilGate.Report("state", errors.New("x"))
+	if !nilGate.Ready() {
+		t.Error("nil Gate not ready")
+	}
+}
+
+func TestGateAwait(t *testing.T) {
+	g := NewGate("state")
+	probes := 0
+	err := g.Await(context.Background(), time.Millisecond, func() {
+		probes++
+		if probes == 3 {
+			g.Report("state", nil)
+		} else {
+			g.Report("state", errors.New("balance unavailable"))
+		}
+	})
+	if err != nil || probes != 3 {
+		t.Errorf("Await() = %v after %d probes, want nil after 3", err, probes)
+	}
+
+	g = NewGate("state")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = g.Await(ctx, time.Millisecond, func() { g.Report("state", errors.New("balance unavailable")) })
+	if err == nil || !strings.Contains(err.Error(), "balance unavailable") {
+		t.Errorf("Await() = %v, want the failing check", err)
+	}
+}
+
+func TestGateHandler(t *testing.T) {
+	g := NewGate("state", "markets")
+	g.Report("state", nil)
+
+	get := func() (int, bool, []Check) {
+		rec := httptest.NewRecorder()
+		g.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/ready", nil))
+		var body struct {
+			Ready  bool    `json:"ready"`
+			Checks []Check `json:"checks"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		return rec.Code, body.Ready, body.Checks
+	}
+
+	code, ready, checks := get()
+	if code != http.StatusServiceUnavailable || ready || len(checks) != 2 || !checks[0].OK || checks[1].OK {
+		t.Errorf("not ready: %d %v %+v", code, ready, checks)
+	}
+	g.Report("markets", nil)
+	if code, ready, _ := get(); code != http.StatusOK || !ready {
+		t.Errorf("ready: %d %v", code, ready)
+	}
+}
+
+func TestNotify(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	if sent, err := Notify("READY=1"); sent || err != nil {
+		t.Errorf("Notify() outside systemd = %v, %v", sent, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	defer conn.Close()
+	t.Setenv("NOTIFY_SOCKET", path)
+
+	if sent, err := NotifyReady("trading"); !sent || err != nil {
+		t.Fatalf("NotifyReady() = %v, %v", sent, err)
+	}
+	buf := make([]byte, 256)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "READY=1\nSTATUS=trading" {
+		t.Errorf("sent %q", got)
+	}
+}
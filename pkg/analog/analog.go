@@ -0,0 +1,218 @@
+// Package analog finds the historical days most like today — by morning
+// temperatures, forecast, wind and season — and reports how they settled
+// relative to their morning METAR max.
+//
+// "The eight closest mornings all finished 3-5°F above where they stood at
+// 10am" is an intuitive secondary signal next to the probability model, for
+// manual trading and as a strategy input.
+package analog
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// DefaultCutoff is the local time of day the morning features are read at.
+const DefaultCutoff = 10 * time.Hour
+
+// Features describe a station-day as of the morning cutoff. NaN marks an
+// unknown feature, which is left out of distances.
+type Features struct {
+	Date        time.Time // Local midnight of the day
+	MorningTemp float64   // Latest report at the cutoff, °F
+	MorningMax  float64   // Highest report up to the cutoff, °F
+	Forecast    float64   // Forecast high, °F
+	WindSpeed   float64   // Knots at the cutoff
+	WindDir     float64   // Degrees true at the cutoff (0 = calm or variable)
+}
+
+// Morning reads the features of day (local midnight) from its reports up to
+// cutoff. The forecast is left unknown. ok is false if nothing was reported
+// before the cutoff.
+func Morning(day time.Time, obs []weather.Observation, cutoff time.Duration) (f Features, ok bool) {
+	f = Features{
+		Date:        day,
+		MorningTemp: math.NaN(),
+		MorningMax:  math.Inf(-1),
+		Forecast:    math.NaN(),
+		WindSpeed:   math.NaN(),
+		WindDir:     math.NaN(),
+	}
+	end := day.Add(cutoff)
+	for _, o := range obs {
+		if o.Time.Before(day) || o.Time.After(end) {
+			continue
+		}
+		ok = true
+		f.MorningTemp = o.Temp
+		f.MorningMax = max(f.MorningMax, o.Temp)
+		f.WindSpeed, f.WindDir = o.WindSpeed, o.WindDir
+	}
+	if !ok {
+		f.MorningMax = math.NaN()
+	}
+	return f, ok
+}
+
+// Day is a historical station-day and how it settled.
+type Day struct {
+	Features
+	Settlement int // Official (CLI) high in °F
+}
+
+// Delta is how far the settlement finished above the morning max.
+func (d Day) Delta() int {
+	return d.Settlement - int(math.Round(d.MorningMax))
+}
+
+// FromDataset reads the morning features of backtest days. The datasets
+// carry neither wind nor forecasts, so those stay unknown.
+func FromDataset(days []backtest.Day, cutoff time.Duration) []Day {
+	var history []Day
+	for _, d := range days {
+		loc, err := time.LoadLocation(d.Timezone)
+		if err != nil {
+			continue
+		}
+		date, err := time.ParseInLocation("2006-01-02", d.Date, loc)
+		if err != nil {
+			continue
+		}
+		obs := make([]weather.Observation, 0, len(d.METAR))
+		for _, o := range d.METAR {
+			obs = append(obs, weather.Observation{Time: o.Time, Temp: o.TempF})
+		}
+		f, ok := Morning(date, obs, cutoff)
+		if !ok {
+			continue
+		}
+		f.WindSpeed, f.WindDir = math.NaN(), math.NaN()
+		history = append(history, Day{Features: f, Settlement: d.Settlement})
+	}
+	return history
+}
+
+// Weights set how much each feature counts toward the distance between two
+// days. Differences are first scaled to 2°F of temperature, 10 kt of wind
+// and 30 days of season.
+type Weights struct {
+	MorningTemp float64
+	MorningMax  float64
+	Forecast    float64
+	Wind        float64
+	Season      float64
+}
+
+// DefaultWeights favor the forecast and the morning max, the features most
+// tied to the afternoon high.
+func DefaultWeights() Weights {
+	return Weights{MorningTemp: 1, MorningMax: 1.5, Forecast: 1.5, Wind: 0.5, Season: 0.5}
+}
+
+// Distance is the weighted RMS of the scaled feature differences known on
+// both days, or +Inf if no feature is.
+func Distance(a, b Features, w Weights) float64 {
+	var sum, total float64
+	add := func(weight, diff float64) {
+		if weight <= 0 || math.IsNaN(diff) {
+			return
+		}
+		sum += weight * diff * diff
+		total += weight
+	}
+
+	add(w.MorningTemp, (a.MorningTemp-b.MorningTemp)/2)
+	add(w.MorningMax, (a.MorningMax-b.MorningMax)/2)
+	add(w.Forecast, (a.Forecast-b.Forecast)/2)
+
+	// Wind as a vector, so 350° and 10° are close and calm is near light air
+	au, av := windVector(a)
+	bu, bv := windVector(b)
+	add(w.Wind, math.Hypot(au-bu, av-bv)/10)
+
+	if !a.Date.IsZero() && !b.Date.IsZero() {
+		days := math.Abs(float64(a.Date.YearDay() - b.Date.YearDay()))
+		add(w.Season, min(days, 365-days)/30)
+	}
+
+	if total == 0 {
+		return math.Inf(1)
+	}
+	return math.Sqrt(sum / total)
+}
+
+func windVector(f Features) (u, v float64) {
+	rad := f.WindDir * math.Pi / 180
+	return f.WindSpeed * math.Sin(rad), f.WindSpeed * math.Cos(rad)
+}
+
+// Match is a historical day and its distance from today.
+type Match struct {
+	Day
+	Distance float64
+}
+
+// Find returns the k history days closest to today, nearest first. Only days
+// before today are considered, so backtests cannot see the future.
+func Find(history []Day, today Features, k int, w Weights) []Match {
+	var matches []Match
+	for _, d := range history {
+		if !d.Date.Before(today.Date) {
+			continue
+		}
+		dist := Distance(today, d.Features, w)
+		if math.IsInf(dist, 1) {
+			continue
+		}
+		matches = append(matches, Match{Day: d, Distance: dist})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return matches[i].Date.After(matches[j].Date) // Prefer recent days
+	})
+	if k > 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+// Summary is how a set of analogs settled relative to their morning max.
+type Summary struct {
+	N         int
+	MeanDelta float64     // Mean settlement minus morning max, °F
+	Deltas    map[int]int // Analogs per delta
+}
+
+// Summarize tallies the deltas of matches.
+func Summarize(matches []Match) Summary {
+	s := Summary{N: len(matches), Deltas: make(map[int]int)}
+	for _, m := range matches {
+		s.Deltas[m.Delta()]++
+		s.MeanDelta += float64(m.Delta())
+	}
+	if s.N > 0 {
+		s.MeanDelta /= float64(s.N)
+	}
+	return s
+}
+
+// Chance is the share of analogs whose delta fell in [lo, hi]. For today's
+// morning max M, Chance(floor-M, cap-M) is the analogs' vote for a bracket.
+func (s Summary) Chance(lo, hi int) float64 {
+	if s.N == 0 {
+		return 0
+	}
+	n := 0
+	for delta, count := range s.Deltas {
+		if delta >= lo && delta <= hi {
+			n += count
+		}
+	}
+	return float64(n) / float64(s.N)
+}
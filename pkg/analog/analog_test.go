@@ -0,0 +1,141 @@
+package analog
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest/fixtures"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+var la, _ = time.LoadLocation("America/Los_Angeles")
+
+func date(s string) time.Time {
+	t, _ := time.ParseInLocation("2006-01-02", s, la)
+	return t
+}
+
+func features(day string, morningMax, forecast float64) Features {
+	return Features{
+		Date:        date(day),
+		MorningTemp: morningMax - 1,
+		MorningMax:  morningMax,
+		Forecast:    forecast,
+		WindSpeed:   math.NaN(),
+		WindDir:     math.NaN(),
+	}
+}
+
+func TestMorning(t *testing.T) {
+	day := date("2025-12-05")
+	obs := []weather.Observation{
+		{Time: day.Add(-7 * time.Minute), Temp: 70}, // Previous day
+		{Time: day.Add(7*time.Hour + 53*time.Minute), Temp: 58, WindDir: 90, WindSpeed: 4},
+		{Time: day.Add(8*time.Hour + 53*time.Minute), Temp: 61, WindDir: 250, WindSpeed: 8},
+		{Time: day.Add(9*time.Hour + 53*time.Minute), Temp: 60, WindDir: 260, WindSpeed: 11},
+		{Time: day.Add(13*time.Hour + 53*time.Minute), Temp: 66}, // After the cutoff
+	}
+
+	f, ok := Morning(day, obs, DefaultCutoff)
+	if !ok {
+		t.Fatal("Morning() ok = false, want true")
+	}
+	if f.MorningTemp != 60 || f.MorningMax != 61 || f.WindDir != 260 || f.WindSpeed != 11 || !math.IsNaN(f.Forecast) {
+		t.Errorf("Morning() = %+v, want 60°F now, 61°F max, 260@11, no forecast", f)
+	}
+
+	if _, ok := Morning(day, obs[:1], DefaultCutoff); ok {
+		t.Error("Morning() with no reports that day: ok = true, want false")
+	}
+}
+
+func TestDistance(t *testing.T) {
+	w := DefaultWeights()
+	a := features("2025-12-05", 62, 66)
+	if d := Distance(a, a, w); d != 0 {
+		t.Errorf("Distance(a, a) = %v, want 0", d)
+	}
+
+	// Unknown features are skipped rather than counted as differences
+	b := features("2025-12-05", 62, math.NaN())
+	if d := Distance(a, b, w); d != 0 {
+		t.Errorf("Distance() with the forecast unknown = %v, want 0", d)
+	}
+
+	// 350° and 10° are 20° apart, not 340°
+	north1, north2, south := a, a, a
+	north1.WindDir, north1.WindSpeed = 350, 10
+	north2.WindDir, north2.WindSpeed = 10, 10
+	south.WindDir, south.WindSpeed = 170, 10
+	if Distance(north1, north2, w) >= Distance(north1, south, w) {
+		t.Error("Distance() treats wind direction as linear")
+	}
+
+	// Late December is close to early January
+	dec, jan, jul := a, a, a
+	dec.Date, jan.Date, jul.Date = date("2025-12-28"), date("2026-01-03"), date("2025-07-01")
+	if Distance(dec, jan, w) >= Distance(dec, jul, w) {
+		t.Error("Distance() treats the season as linear")
+	}
+
+	if d := Distance(Features{MorningTemp: math.NaN(), MorningMax: math.NaN(), Forecast: math.NaN(), WindSpeed: math.NaN()}, a, w); !math.IsInf(d, 1) {
+		t.Errorf("Distance() with nothing known = %v, want +Inf", d)
+	}
+}
+
+func TestFind(t *testing.T) {
+	history := []Day{
+		{Features: features("2025-11-20", 61, 65), Settlement: 66},
+		{Features: features("2025-11-28", 62, 66), Settlement: 65},
+		{Features: features("2025-12-01", 70, 75), Settlement: 76},
+		{Features: features("2025-12-05", 62, 66), Settlement: 70}, // Today
+		{Features: features("2025-12-06", 62, 66), Settlement: 60}, // Future
+	}
+	today := features("2025-12-05", 62, 66)
+
+	matches := Find(history, today, 2, DefaultWeights())
+	if len(matches) != 2 {
+		t.Fatalf("Find() = %d matches, want 2", len(matches))
+	}
+	if got := matches[0].Date.Format("2006-01-02"); got != "2025-11-28" {
+		t.Errorf("nearest = %s, want 2025-11-28", got)
+	}
+	if got := matches[1].Date.Format("2006-01-02"); got != "2025-11-20" {
+		t.Errorf("second = %s, want 2025-11-20", got)
+	}
+
+	s := Summarize(matches)
+	if s.N != 2 || s.MeanDelta != 4 || s.Deltas[3] != 1 || s.Deltas[5] != 1 {
+		t.Errorf("Summarize() = %+v, want deltas 3 and 5", s)
+	}
+	if c := s.Chance(4, 5); c != 0.5 {
+		t.Errorf("Chance(4, 5) = %v, want 0.5", c)
+	}
+}
+
+func TestFromDataset(t *testing.T) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lax := ds.City("LAX")
+	history := FromDataset(lax.Days, DefaultCutoff)
+	if len(history) != len(lax.Days) {
+		t.Fatalf("FromDataset() = %d days, want %d", len(history), len(lax.Days))
+	}
+
+	today := history[len(history)-1]
+	matches := Find(history, today.Features, 10, DefaultWeights())
+	if len(matches) != 10 {
+		t.Fatalf("Find() = %d matches, want 10", len(matches))
+	}
+	for _, m := range matches {
+		if !m.Date.Before(today.Date) {
+			t.Errorf("match on %s is not before %s", m.Date, today.Date)
+		}
+		if math.Abs(m.MorningMax-today.MorningMax) > 6 {
+			t.Errorf("match on %s started at %v°F, today %v°F", m.Date.Format("Jan 2"), m.MorningMax, today.MorningMax)
+		}
+	}
+}
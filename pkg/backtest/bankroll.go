@@ -0,0 +1,135 @@
+package backtest
+
+import (
+	"math/rand/v2"
+	"sort"
+
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+)
+
+// Sizing is the policy that sets each day's stake from the current bankroll.
+// The zero Fraction stakes Stake every day regardless of the bankroll.
+type Sizing struct {
+	Stake    float64 // Dollars staked per day when Fraction is 0
+	Fraction float64 // Stake this share of the current bankroll (compounding)
+	MaxStake float64 // Cap on the stake, e.g. what the book can absorb; 0 for none
+}
+
+// stake returns the dollars staked on a day starting with bankroll.
+func (s Sizing) stake(bankroll float64) float64 {
+	stake := s.Stake
+	if s.Fraction > 0 {
+		stake = s.Fraction * bankroll
+	}
+	if s.MaxStake > 0 {
+		stake = min(stake, s.MaxStake)
+	}
+	return max(min(stake, bankroll), 0)
+}
+
+// BankrollConfig configures a bankroll simulation.
+type BankrollConfig struct {
+	Start  float64 // Starting bankroll in dollars
+	Days   int     // Days simulated per run
+	Runs   int     // Number of runs
+	Seed   uint64  // Seeds the day sampler so runs are reproducible
+	RuinAt float64 // A run stops once its bankroll falls to or below this
+	Sizing Sizing
+}
+
+// DefaultBankrollConfig returns a one-year, 10,000-run simulation of a
+// $1,000 bankroll staking 2% a day, ruined below $100.
+func DefaultBankrollConfig() BankrollConfig {
+	return BankrollConfig{
+		Start:  1000,
+		Days:   365,
+		Runs:   10000,
+		Seed:   1,
+		RuinAt: 100,
+		Sizing: Sizing{Fraction: 0.02},
+	}
+}
+
+// BankrollResult is the distribution of end-of-period bankrolls.
+type BankrollResult struct {
+	Start     float64
+	Days      int
+	Finals    []float64 // Final bankroll of each run, ascending
+	Mean      float64
+	RuinRate  float64   // Share of runs that hit RuinAt
+	Drawdowns []float64 // Each run's largest peak-to-trough decline as a share of the peak, ascending
+
+	// Linear is the naive projection the simulation replaces: the starting
+	// stake times the mean daily return, added up over Days.
+	Linear float64
+}
+
+// Percentile returns the p-th percentile (0-100) final bankroll.
+func (r *BankrollResult) Percentile(p float64) float64 {
+	return stats.Percentile(r.Finals, p)
+}
+
+// Median returns the median final bankroll.
+func (r *BankrollResult) Median() float64 {
+	return r.Percentile(50)
+}
+
+// MedianDrawdown returns the median of the runs' largest drawdowns.
+func (r *BankrollResult) MedianDrawdown() float64 {
+	return stats.Percentile(r.Drawdowns, 50)
+}
+
+// SimulateBankroll bootstraps daily returns on stake (P&L divided by the
+// dollars staked, 0 on days without trades) into Runs paths of Days days,
+// sizing each day's stake from the bankroll at the start of the day.
+func SimulateBankroll(returns []float64, cfg BankrollConfig) *BankrollResult {
+	result := &BankrollResult{Start: cfg.Start, Days: cfg.Days}
+	if len(returns) == 0 || cfg.Runs <= 0 || cfg.Start <= 0 {
+		return result
+	}
+	result.Linear = cfg.Start + cfg.Sizing.stake(cfg.Start)*stats.Mean(returns)*float64(cfg.Days)
+
+	rng := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x9e3779b97f4a7c15))
+	ruined := 0
+	for range cfg.Runs {
+		bankroll, peak, maxDD := cfg.Start, cfg.Start, 0.0
+		for range cfg.Days {
+			bankroll += cfg.Sizing.stake(bankroll) * returns[rng.IntN(len(returns))]
+			peak = max(peak, bankroll)
+			maxDD = max(maxDD, (peak-bankroll)/peak)
+			if bankroll <= cfg.RuinAt {
+				ruined++
+				break
+			}
+		}
+		result.Finals = append(result.Finals, bankroll)
+		result.Drawdowns = append(result.Drawdowns, maxDD)
+	}
+
+	sort.Float64s(result.Finals)
+	sort.Float64s(result.Drawdowns)
+	result.Mean = stats.Mean(result.Finals)
+	result.RuinRate = float64(ruined) / float64(cfg.Runs)
+	return result
+}
+
+// DailyReturns returns each replayed date's P&L as a share of the premium
+// staked that date, with 0 for replayed dates without trades, for
+// SimulateBankroll.
+func (r *Result) DailyReturns() []float64 {
+	staked := make(map[string]float64)
+	for _, t := range r.Trades {
+		staked[t.Date] += t.Cost()
+	}
+
+	var returns []float64
+	for _, d := range r.TradedDays() {
+		if staked[d] > 0 {
+			returns = append(returns, r.DailyPnL[d]/staked[d])
+		}
+	}
+	for range max(r.Dates-len(returns), 0) {
+		returns = append(returns, 0)
+	}
+	return returns
+}
@@ -0,0 +1,82 @@
+package backtest_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+func TestSimulateBankroll_FixedStakeIsLinear(t *testing.T) {
+	cfg := backtest.BankrollConfig{Start: 1000, Days: 100, Runs: 10, Sizing: backtest.Sizing{Stake: 50}}
+	r := backtest.SimulateBankroll([]float64{0.1}, cfg)
+
+	if r.Linear != 1500 {
+		t.Errorf("Linear = %v, want 1500", r.Linear)
+	}
+	if math.Abs(r.Median()-1500) > 1e-9 {
+		t.Errorf("Median() = %v, want 1500", r.Median())
+	}
+}
+
+func TestSimulateBankroll_Compounds(t *testing.T) {
+	cfg := backtest.BankrollConfig{Start: 1000, Days: 100, Runs: 10, Sizing: backtest.Sizing{Fraction: 0.05}}
+	r := backtest.SimulateBankroll([]float64{0.1}, cfg)
+
+	want := 1000 * math.Pow(1.005, 100)
+	if math.Abs(r.Median()-want) > 1e-6 {
+		t.Errorf("Median() = %v, want %v", r.Median(), want)
+	}
+	if r.Median() <= r.Linear {
+		t.Errorf("compounded %v should beat linear %v", r.Median(), r.Linear)
+	}
+
+	// A cap at the starting stake brings it back to linear
+	cfg.Sizing.MaxStake = 50
+	capped := backtest.SimulateBankroll([]float64{0.1}, cfg)
+	if math.Abs(capped.Median()-1500) > 1e-9 {
+		t.Errorf("capped Median() = %v, want 1500", capped.Median())
+	}
+}
+
+func TestSimulateBankroll_Ruin(t *testing.T) {
+	cfg := backtest.BankrollConfig{Start: 1000, Days: 365, Runs: 1000, Seed: 7, RuinAt: 100, Sizing: backtest.Sizing{Fraction: 0.5}}
+	r := backtest.SimulateBankroll([]float64{1, -1}, cfg)
+
+	if r.RuinRate < 0.9 {
+		t.Errorf("RuinRate = %v, want most runs ruined staking half on a coin flip", r.RuinRate)
+	}
+	if r.MedianDrawdown() < 0.9 {
+		t.Errorf("MedianDrawdown() = %v, want > 0.9", r.MedianDrawdown())
+	}
+	if len(r.Finals) != 1000 || r.Percentile(5) > r.Percentile(95) {
+		t.Errorf("Finals = %d runs, P5 %v, P95 %v", len(r.Finals), r.Percentile(5), r.Percentile(95))
+	}
+
+	again := backtest.SimulateBankroll([]float64{1, -1}, cfg)
+	if again.Mean != r.Mean {
+		t.Errorf("same seed gave Mean %v then %v", r.Mean, again.Mean)
+	}
+}
+
+func TestResult_DailyReturns(t *testing.T) {
+	r := &backtest.Result{
+		Dates: 3,
+		Trades: []backtest.Trade{
+			{Date: "2025-12-05", Price: 50, Quantity: 10},
+			{Date: "2025-12-05", Price: 30, Quantity: 10},
+		},
+		DailyPnL: map[string]float64{"2025-12-05": 4},
+	}
+
+	got := r.DailyReturns()
+	want := []float64{0.5, 0, 0}
+	if len(got) != len(want) {
+		t.Fatalf("DailyReturns() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("DailyReturns()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package backtest
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// Baseline strategy names.
+const (
+	BaselineFavorite = "Baseline: always favorite"
+	BaselineMETAR    = "Baseline: METAR bracket"
+	BaselineRandom   = "Baseline: random bracket"
+	BaselineCheapest = "Baseline: cheapest, held"
+)
+
+// BaselineStake is the dollars each baseline stakes per event.
+const BaselineStake = 100.0
+
+// baselineMETARHour is the local hour from which the METAR baseline buys,
+// once the running max is near the day's high.
+const baselineMETARHour = 12
+
+// Baselines returns the naive strategies a backtest is measured against,
+// each buying one bracket's YES per event at the ask and holding it to
+// settlement:
+//
+//   - always favorite: the bracket with the highest bid, at the first
+//     decision point;
+//   - METAR bracket: the bracket holding the running METAR max, at the first
+//     decision point from noon local;
+//   - random: a bracket drawn from seed and the event ticker, at the first
+//     decision point;
+//   - cheapest, held: the bracket with the lowest ask, at the first decision
+//     point.
+//
+// A strategy that can't beat them on return per dollar staked has no edge
+// worth its complexity.
+func Baselines(seed uint64) []strategy.Strategy {
+	names := []string{BaselineFavorite, BaselineMETAR, BaselineRandom, BaselineCheapest}
+	out := make([]strategy.Strategy, len(names))
+	for i, name := range names {
+		out[i] = newBaseline(name, seed)
+	}
+	return out
+}
+
+// baseline buys one bracket per event, chosen by pick from the latest
+// quotes and observation (nil before the day's first).
+type baseline struct {
+	name   string
+	seed   uint64
+	pick   func(b *baseline, data strategy.MarketData, wx *strategy.WeatherUpdate) *strategy.Quote
+	data   strategy.MarketData
+	wx     *strategy.WeatherUpdate
+	bought map[string]bool
+}
+
+func newBaseline(name string, seed uint64) *baseline {
+	b := &baseline{name: name, seed: seed, bought: make(map[string]bool)}
+	switch name {
+	case BaselineFavorite:
+		b.pick = func(_ *baseline, data strategy.MarketData, _ *strategy.WeatherUpdate) *strategy.Quote {
+			return data.Favorite()
+		}
+	case BaselineMETAR:
+		b.pick = func(_ *baseline, data strategy.MarketData, wx *strategy.WeatherUpdate) *strategy.Quote {
+			if wx == nil || data.Time.Hour() < baselineMETARHour {
+				return nil
+			}
+			return data.QuoteFor(int(wx.Running(data.Type)))
+		}
+	case BaselineRandom:
+		b.pick = (*baseline).random
+	case BaselineCheapest:
+		b.pick = func(_ *baseline, data strategy.MarketData, _ *strategy.WeatherUpdate) *strategy.Quote {
+			var cheapest *strategy.Quote
+			for i, q := range data.Quotes {
+				if q.YesAsk > 0 && (cheapest == nil || q.YesAsk < cheapest.YesAsk) {
+					cheapest = &data.Quotes[i]
+				}
+			}
+			return cheapest
+		}
+	default:
+		return nil
+	}
+	return b
+}
+
+// random draws a bracket from the ones quoted, seeded by the event so the
+// draw doesn't depend on which other days are replayed.
+func (b *baseline) random(data strategy.MarketData, _ *strategy.WeatherUpdate) *strategy.Quote {
+	var quoted []int
+	for i, q := range data.Quotes {
+		if q.YesAsk > 0 {
+			quoted = append(quoted, i)
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	h := fnv.New64a()
+	h.Write([]byte(data.EventTicker))
+	rng := rand.New(rand.NewPCG(b.seed, h.Sum64()))
+	return &data.Quotes[quoted[rng.IntN(len(quoted))]]
+}
+
+func (b *baseline) Name() string { return b.name }
+
+func (b *baseline) OnMarketData(data strategy.MarketData) {
+	if data.EventTicker != b.data.EventTicker {
+		b.wx = nil
+	}
+	b.data = data
+}
+
+func (b *baseline) OnWeatherUpdate(update strategy.WeatherUpdate) {
+	b.wx = &update
+}
+
+func (b *baseline) GenerateOrders(time.Time) []strategy.Order {
+	event := b.data.EventTicker
+	if b.bought[event] {
+		return nil
+	}
+	q := b.pick(b, b.data, b.wx)
+	if q == nil || q.Determined || q.YesAsk <= 0 {
+		return nil
+	}
+	b.bought[event] = true
+	return []strategy.Order{{
+		EventTicker: event,
+		Ticker:      q.Ticker,
+		Side:        "yes",
+		Action:      "buy",
+		Price:       q.YesAsk,
+		Quantity:    strategy.ContractsFor(BaselineStake, q.YesAsk),
+		Reason:      b.name,
+	}}
+}
+
+// Comparison is a strategy's result next to one baseline's over the same
+// days.
+type Comparison struct {
+	Strategy string
+	Trades   int
+	WinRate  float64
+	Profit   float64
+	ROI      float64 // Profit per dollar staked
+	PerHour  float64 // Profit per dollar-hour deployed
+	Sharpe   float64
+	Excess   float64 // The compared result's ROI less this one's
+}
+
+// ROI returns the profit per dollar staked, 0 without trades.
+func (r *Result) ROI() float64 {
+	cost := 0.0
+	for _, t := range r.Trades {
+		cost += t.Cost()
+	}
+	if cost == 0 {
+		return 0
+	}
+	return r.TotalProfit / cost
+}
+
+// DollarHours returns the capital the trades tied up: each premium times
+// the hours it was held, summed.
+func (r *Result) DollarHours() float64 {
+	total := 0.0
+	for _, t := range r.Trades {
+		total += t.DollarHours()
+	}
+	return total
+}
+
+// ReturnPerDollarHour returns the profit per dollar-hour deployed, 0 without
+// trades. Unlike ROI it charges for how long capital was locked up, so a
+// 90¢ NO held from morning to settlement compares fairly with a trade that
+// frees its capital at noon.
+func (r *Result) ReturnPerDollarHour() float64 {
+	dh := r.DollarHours()
+	if dh == 0 {
+		return 0
+	}
+	return r.TotalProfit / dh
+}
+
+// Compare lists the result and then each of its baselines, with the
+// result's excess ROI over each. Returns are compared per dollar staked,
+// since a baseline's stake differs from the strategy's.
+func (r *Result) Compare() []Comparison {
+	row := func(res *Result, excess float64) Comparison {
+		return Comparison{
+			Strategy: res.Strategy,
+			Trades:   len(res.Trades),
+			WinRate:  res.WinRate,
+			Profit:   res.TotalProfit,
+			ROI:      res.ROI(),
+			PerHour:  res.ReturnPerDollarHour(),
+			Sharpe:   res.Sharpe,
+			Excess:   excess,
+		}
+	}
+	roi := r.ROI()
+	rows := []Comparison{row(r, 0)}
+	for _, b := range r.Baselines {
+		rows = append(rows, row(b, roi-b.ROI()))
+	}
+	return rows
+}
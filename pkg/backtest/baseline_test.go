@@ -0,0 +1,104 @@
+package backtest_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest/fixtures"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+func TestRun_Baselines(t *testing.T) {
+	ds := testDay()
+	r := backtest.Run(ds, &scripted{
+		seen:   make(map[string]bool),
+		orders: []strategy.Order{{Ticker: "C", Side: "yes", Action: "buy", Price: 45, Quantity: 10}},
+	}, backtest.DefaultConfig())
+
+	// Quotes are A 19/21, B 39/41, C 39/41; at noon the METAR max is 61
+	want := map[string]string{
+		backtest.BaselineFavorite: "B",
+		backtest.BaselineMETAR:    "A",
+		backtest.BaselineCheapest: "A",
+	}
+	if len(r.Baselines) != 4 {
+		t.Fatalf("Baselines = %d results, want 4", len(r.Baselines))
+	}
+	for _, b := range r.Baselines {
+		if len(b.Trades) != 1 {
+			t.Errorf("%s: %d trades, want 1", b.Strategy, len(b.Trades))
+			continue
+		}
+		tr := b.Trades[0]
+		if ticker, ok := want[b.Strategy]; ok && tr.Ticker != ticker {
+			t.Errorf("%s bought %s, want %s", b.Strategy, tr.Ticker, ticker)
+		}
+		if tr.Side != "yes" || !tr.Settled || tr.Quantity != strategy.ContractsFor(backtest.BaselineStake, tr.Price) {
+			t.Errorf("%s trade = %+v, want a held $%.0f YES buy", b.Strategy, tr, backtest.BaselineStake)
+		}
+	}
+
+	rows := r.Compare()
+	if len(rows) != 5 || rows[0].Strategy != "scripted" || rows[0].Excess != 0 {
+		t.Fatalf("Compare() = %+v", rows)
+	}
+	for _, row := range rows[1:] {
+		if math.Abs(row.Excess-(rows[0].ROI-row.ROI)) > 1e-9 {
+			t.Errorf("%s excess = %.3f, want %.3f", row.Strategy, row.Excess, rows[0].ROI-row.ROI)
+		}
+	}
+
+	cfg := backtest.DefaultConfig()
+	cfg.Baselines = false
+	if r := backtest.Run(ds, &scripted{seen: make(map[string]bool)}, cfg); r.Baselines != nil {
+		t.Errorf("Baselines = %d results with Baselines off, want none", len(r.Baselines))
+	}
+}
+
+func TestAppend_Baselines(t *testing.T) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		t.Fatalf("SyntheticLAXNYC() error = %v", err)
+	}
+	cfg := backtest.DefaultConfig()
+	cfg.Seed = 7
+	full := backtest.Run(ds, &favorite{seen: make(map[string]bool)}, cfg)
+
+	r := backtest.Run(ds.Between("2025-08-01", "2025-11-29"), &favorite{seen: make(map[string]bool)}, cfg)
+	backtest.Append(r, ds, &favorite{seen: make(map[string]bool)}, cfg)
+
+	for i, b := range r.Baselines {
+		f := full.Baselines[i]
+		if b.Through != f.Through || len(b.Trades) != len(f.Trades) || math.Abs(b.TotalProfit-f.TotalProfit) > 1e-9 {
+			t.Errorf("%s appended: %d trades through %s, profit %.2f; want %d through %s, %.2f",
+				b.Strategy, len(b.Trades), b.Through, b.TotalProfit, len(f.Trades), f.Through, f.TotalProfit)
+		}
+	}
+}
+
+func TestResult_ReturnPerDollarHour(t *testing.T) {
+	morning := time.Date(2025, 12, 5, 9, 0, 0, 0, time.UTC)
+	r := &backtest.Result{
+		Trades: []backtest.Trade{
+			// $90 of NO locked up 14 hours to make $10
+			{Time: morning, ExitTime: morning.Add(14 * time.Hour), Price: 90, Quantity: 100, Profit: 10},
+			// $40 of YES sold 3 hours later for $8
+			{Time: morning, ExitTime: morning.Add(3 * time.Hour), Price: 40, Quantity: 100, Profit: 8},
+		},
+		TotalProfit: 18,
+	}
+	if got, want := r.DollarHours(), 90.0*14+40*3; math.Abs(got-want) > 1e-9 {
+		t.Errorf("DollarHours() = %.2f, want %.2f", got, want)
+	}
+	if got, want := r.ReturnPerDollarHour(), 18/1380.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("ReturnPerDollarHour() = %.5f, want %.5f", got, want)
+	}
+	if got := r.Compare()[0].PerHour; got != r.ReturnPerDollarHour() {
+		t.Errorf("Compare() PerHour = %.5f, want %.5f", got, r.ReturnPerDollarHour())
+	}
+	if got := (&backtest.Result{}).ReturnPerDollarHour(); got != 0 {
+		t.Errorf("ReturnPerDollarHour() without trades = %v, want 0", got)
+	}
+}
@@ -0,0 +1,84 @@
+package backtest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// BookSnapshot is the recorded depth of a bracket's order book: the
+// contracts bid on each side in its top strategy.DepthLevels levels.
+type BookSnapshot struct {
+	Time     time.Time `json:"time"`
+	Ticker   string    `json:"ticker,omitempty"` // Set in recordings; empty in a Bracket
+	YesDepth int       `json:"yes_depth"`
+	NoDepth  int       `json:"no_depth"`
+}
+
+// BookAt returns the bracket's last book snapshot at or before t; ok is false
+// before the first or without any.
+func (b Bracket) BookAt(t time.Time) (BookSnapshot, bool) {
+	i := sort.Search(len(b.Books), func(i int) bool { return b.Books[i].Time.After(t) })
+	if i == 0 {
+		return BookSnapshot{}, false
+	}
+	return b.Books[i-1], true
+}
+
+// LoadBookSnapshots reads recorded book snapshots, one JSON object per line,
+// as the production bot's BOOK_RECORD_FILE writes them.
+func LoadBookSnapshots(path string) ([]BookSnapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var snapshots []BookSnapshot
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var s BookSnapshot
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, scanner.Err()
+}
+
+// AddBooks attaches recorded snapshots to the brackets of the dataset by
+// ticker, in time order, so Run quotes their depths. It returns the number
+// of snapshots attached; snapshots of markets not in the dataset are
+// skipped.
+func (ds *Dataset) AddBooks(snapshots []BookSnapshot) int {
+	brackets := make(map[string]*Bracket)
+	for i := range ds.Days {
+		for j := range ds.Days[i].Brackets {
+			b := &ds.Days[i].Brackets[j]
+			brackets[b.Ticker] = b
+		}
+	}
+
+	n := 0
+	touched := make(map[*Bracket]bool)
+	for _, s := range snapshots {
+		b, ok := brackets[s.Ticker]
+		if !ok {
+			continue
+		}
+		s.Ticker = ""
+		b.Books = append(b.Books, s)
+		touched[b] = true
+		n++
+	}
+	for b := range touched {
+		sort.SliceStable(b.Books, func(i, j int) bool { return b.Books[i].Time.Before(b.Books[j].Time) })
+	}
+	return n
+}
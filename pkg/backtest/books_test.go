@@ -0,0 +1,56 @@
+package backtest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+func TestDataset_AddBooks(t *testing.T) {
+	ds := testDay()
+	base := ds.Days[0].Time()
+	path := filepath.Join(t.TempDir(), "books.jsonl")
+	recording := `{"time":"` + base.Add(10*time.Hour+30*time.Minute).Format(time.RFC3339) + `","ticker":"C","yes_depth":50,"no_depth":150}
+{"time":"` + base.Add(9*time.Hour).Format(time.RFC3339) + `","ticker":"C","yes_depth":200,"no_depth":100}
+
+{"time":"` + base.Add(9*time.Hour).Format(time.RFC3339) + `","ticker":"OTHER","yes_depth":1,"no_depth":1}
+`
+	if err := os.WriteFile(path, []byte(recording), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := backtest.LoadBookSnapshots(path)
+	if err != nil {
+		t.Fatalf("LoadBookSnapshots() error = %v", err)
+	}
+	if n := ds.AddBooks(snapshots); n != 2 {
+		t.Errorf("AddBooks() = %d, want 2", n)
+	}
+	c := ds.Days[0].Brackets[2]
+	if _, ok := c.BookAt(base.Add(8 * time.Hour)); ok {
+		t.Error("BookAt() before the first snapshot ok = true")
+	}
+	if b, ok := c.BookAt(base.Add(11 * time.Hour)); !ok || b.YesDepth != 50 || b.Ticker != "" {
+		t.Errorf("BookAt(11:00) = %+v, %v, want the 10:30 snapshot", b, ok)
+	}
+
+	// Run quotes the depths of the last snapshot before each decision
+	s := &timed{}
+	cfg := backtest.DefaultConfig()
+	cfg.Baselines = false
+	backtest.Run(ds, s, cfg)
+	depths := make(map[int]int)
+	for _, m := range s.markets {
+		depths[m.Time.Hour()] = m.Quotes[2].YesDepth
+	}
+	if depths[8] != 0 || depths[9] != 200 || depths[10] != 200 || depths[11] != 50 {
+		t.Errorf("quoted YES depths by hour = %v, want 0, 200, 200, 50 from 8:00", depths)
+	}
+
+	if _, err := backtest.LoadBookSnapshots(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("LoadBookSnapshots(missing) error = nil")
+	}
+}
@@ -0,0 +1,111 @@
+package backtest
+
+import (
+	"sort"
+
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+)
+
+// CapacityConfig configures EstimateCapacity.
+type CapacityConfig struct {
+	// Participation is the share of the contracts a market trades after an
+	// entry that the strategy can take without moving its price (default 0.1).
+	Participation float64
+	// Percentile of the per-trade capacities reported: low percentiles size
+	// to the thin markets the strategy trades (default 25).
+	Percentile float64
+}
+
+// DefaultCapacityConfig returns a configuration taking a tenth of the volume
+// and sizing to the first quartile of the strategy's trades.
+func DefaultCapacityConfig() CapacityConfig {
+	return CapacityConfig{Participation: 0.1, Percentile: 25}
+}
+
+// Capacity estimates how large a strategy can trade before its own orders
+// move prices.
+type Capacity struct {
+	Strategy  string
+	Trades    int     // Trades in markets with archived volume
+	Contracts int     // Contracts one order can take
+	Scale     float64 // Multiple of the backtested order sizes that fits
+	Dollars   float64 // Daily stake that fits: Scale times the median daily stake
+}
+
+// Limit caps an order of contracts at fraction of the estimated capacity of
+// one order, never below one contract. A capacity estimated without volume
+// data limits nothing.
+func (c Capacity) Limit(contracts int, fraction float64) int {
+	if c.Trades == 0 || fraction <= 0 {
+		return contracts
+	}
+	return min(contracts, max(int(fraction*float64(c.Contracts)), 1))
+}
+
+// EstimateCapacity estimates the capacity of the strategy behind r from the
+// archived trade volume of ds, the dataset r was replayed on. Each trade may
+// take Participation of the contracts its market traded from the entry until
+// trading stopped; the estimate is the Percentile of those allowances.
+//
+// Markets without tick counts are skipped. The archive holds trade prints
+// only, not book depth, so volume stands in for the liquidity a patient
+// order would find.
+func EstimateCapacity(ds *Dataset, r *Result, cfg CapacityConfig) Capacity {
+	if cfg.Participation <= 0 {
+		cfg.Participation = DefaultCapacityConfig().Participation
+	}
+	if cfg.Percentile <= 0 {
+		cfg.Percentile = DefaultCapacityConfig().Percentile
+	}
+
+	brackets := make(map[string]*Bracket)
+	for i := range ds.Days {
+		for j := range ds.Days[i].Brackets {
+			b := &ds.Days[i].Brackets[j]
+			brackets[b.Ticker] = b
+		}
+	}
+
+	c := Capacity{Strategy: r.Strategy}
+	var allowed, scales []float64
+	staked := make(map[string]float64)
+	for _, t := range r.Trades {
+		staked[t.Date] += t.Cost()
+		b, ok := brackets[t.Ticker]
+		if !ok || t.Quantity <= 0 {
+			continue
+		}
+		volume, known := 0, false
+		for _, tick := range b.Ticks {
+			if tick.Count > 0 {
+				known = true
+			}
+			if !tick.Time.Before(t.Time) {
+				volume += tick.Count
+			}
+		}
+		if !known {
+			continue
+		}
+		a := cfg.Participation * float64(volume)
+		allowed = append(allowed, a)
+		scales = append(scales, a/float64(t.Quantity))
+	}
+	if len(allowed) == 0 {
+		return c
+	}
+
+	sort.Float64s(allowed)
+	sort.Float64s(scales)
+	daily := make([]float64, 0, len(staked))
+	for _, s := range staked {
+		daily = append(daily, s)
+	}
+	sort.Float64s(daily)
+
+	c.Trades = len(allowed)
+	c.Contracts = int(stats.Percentile(allowed, cfg.Percentile))
+	c.Scale = stats.Percentile(scales, cfg.Percentile)
+	c.Dollars = c.Scale * stats.Percentile(daily, 50)
+	return c
+}
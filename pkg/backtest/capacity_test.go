@@ -0,0 +1,46 @@
+package backtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+func TestEstimateCapacity(t *testing.T) {
+	at := time.Date(2025, 12, 5, 18, 0, 0, 0, time.UTC)
+	ds := testDay()
+	// 300 contracts trade in B from the entry on; C has no counts
+	ds.Days[0].Brackets[1].Ticks = []backtest.Tick{
+		{Time: at.Add(-time.Hour), YesPrice: 40, Count: 1000},
+		{Time: at, YesPrice: 41, Count: 100},
+		{Time: at.Add(time.Hour), YesPrice: 43, Count: 200},
+	}
+	ds.Days[0].Brackets[2].Ticks = []backtest.Tick{{Time: at, YesPrice: 40}}
+
+	r := &backtest.Result{Strategy: "scripted", Trades: []backtest.Trade{
+		{Time: at, Date: "2025-12-05", Ticker: "B", Side: "no", Price: 60, Quantity: 10},
+		{Time: at.Add(time.Hour), Date: "2025-12-05", Ticker: "B", Side: "no", Price: 57, Quantity: 5},
+		{Time: at, Date: "2025-12-05", Ticker: "C", Side: "yes", Price: 40, Quantity: 10},
+	}}
+
+	c := backtest.EstimateCapacity(ds, r, backtest.CapacityConfig{Participation: 0.1, Percentile: 25})
+	// Allowances of 30 and 20 contracts, 3x and 4x the orders, a quarter of
+	// the way up; the stake was $12.85 on the only day
+	if c.Trades != 2 || c.Contracts != 22 || c.Scale != 3.25 {
+		t.Errorf("capacity = %+v, want 2 trades, 22 contracts, 3.25x", c)
+	}
+	if want := 3.25 * 12.85; c.Dollars < want-1e-9 || c.Dollars > want+1e-9 {
+		t.Errorf("Dollars = %.4f, want %.4f", c.Dollars, want)
+	}
+
+	if got := c.Limit(40, 0.5); got != 11 {
+		t.Errorf("Limit(40, 0.5) = %d, want 11", got)
+	}
+	if got := c.Limit(5, 0.5); got != 5 {
+		t.Errorf("Limit(5, 0.5) = %d, want 5", got)
+	}
+	if got := (backtest.Capacity{}).Limit(40, 0.5); got != 40 {
+		t.Errorf("Limit() without volume = %d, want 40", got)
+	}
+}
@@ -0,0 +1,413 @@
+package backtest
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// Bracket bounds used for the open-ended tail markets, matching pkg/market.
+const (
+	OpenFloor = -999
+	OpenCap   = 999
+)
+
+// Dataset is a collection of settled market days.
+type Dataset struct {
+	// Source describes where the data came from (e.g. "kalshi+iem" for
+	// exported history or "synthetic" for generated fixtures).
+	Source      string `json:"source"`
+	Description string `json:"description,omitempty"`
+	Days        []Day  `json:"days"`
+}
+
+// Day is one settled temperature event for one city.
+type Day struct {
+	City        string        `json:"city"`         // Station code, e.g. "LAX"
+	Series      string        `json:"series"`       // Series ticker, e.g. "KXHIGHLAX" or "KXLOWTLAX"
+	EventTicker string        `json:"event_ticker"` // e.g. "KXHIGHLAX-25DEC05"
+	Date        string        `json:"date"`         // Local date, YYYY-MM-DD
+	Timezone    string        `json:"timezone"`     // IANA timezone of the station
+	METAR       []Observation `json:"metar"`        // Hourly observations in time order
+	Settlement  int           `json:"settlement"`   // Official (CLI) high in °F, the low for LOW series
+	Brackets    []Bracket     `json:"brackets"`     // Ordered by Floor
+
+	// Discussions are the NWS area forecast discussions issued that day
+	// (optional), kept as context for reviewing misses. Strategies are not
+	// shown them.
+	Discussions []Discussion `json:"discussions,omitempty"`
+}
+
+// Discussion is the text of one NWS area forecast discussion.
+type Discussion struct {
+	Issued time.Time `json:"issued"`
+	Text   string    `json:"text"`
+}
+
+// Observation is a single METAR temperature reading.
+type Observation struct {
+	Time  time.Time `json:"time"`
+	TempF float64   `json:"temp_f"`
+}
+
+// Bracket is one market of a day's event.
+type Bracket struct {
+	Ticker        string `json:"ticker"`
+	Floor         int    `json:"floor"`           // Inclusive lower bound, OpenFloor for "below"
+	Cap           int    `json:"cap"`             // Inclusive upper bound, OpenCap for "above"
+	FirstYesPrice int    `json:"first_yes_price"` // Price of the first trade in cents (0 = never traded)
+	Result        string `json:"result"`          // "yes" or "no"
+	Ticks         []Tick `json:"ticks,omitempty"` // Archived trade prints in time order (optional)
+
+	// Books are recorded order book depths in time order (optional); see
+	// Dataset.AddBooks.
+	Books []BookSnapshot `json:"books,omitempty"`
+
+	// DeterminedAt is when the outcome became known and trading stopped, if
+	// that was before the end of the day (zero = traded all day).
+	DeterminedAt time.Time `json:"determined_at,omitzero"`
+}
+
+// Tick is an archived trade print of one bracket.
+type Tick struct {
+	Time     time.Time `json:"time"`
+	YesPrice int       `json:"yes_price"`
+	Count    int       `json:"count,omitempty"` // Contracts traded (0 = unknown)
+}
+
+// PriceAt returns the YES price of the last tick at or before t. Before the
+// first tick, or for brackets without ticks, it returns FirstYesPrice.
+func (b Bracket) PriceAt(t time.Time) int {
+	price := b.FirstYesPrice
+	for _, tick := range b.Ticks {
+		if tick.Time.After(t) {
+			break
+		}
+		price = tick.YesPrice
+	}
+	return price
+}
+
+// DeterminedBy reports whether the bracket had stopped trading at t.
+func (b Bracket) DeterminedBy(t time.Time) bool {
+	return !b.DeterminedAt.IsZero() && !t.Before(b.DeterminedAt)
+}
+
+// Contains reports whether temp falls inside the bracket.
+func (b Bracket) Contains(temp int) bool {
+	return temp >= b.Floor && temp <= b.Cap
+}
+
+// Label returns a human-readable bracket label such as "62-63°" or ">69°".
+func (b Bracket) Label() string {
+	switch {
+	case b.Floor == OpenFloor:
+		return fmt.Sprintf("<%d°", b.Cap+1)
+	case b.Cap == OpenCap:
+		return fmt.Sprintf(">%d°", b.Floor-1)
+	}
+	return fmt.Sprintf("%d-%d°", b.Floor, b.Cap)
+}
+
+// Time returns the day's date at midnight in the station's timezone.
+func (d *Day) Time() time.Time {
+	loc, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	t, _ := time.ParseInLocation("2006-01-02", d.Date, loc)
+	return t
+}
+
+// MarketType returns whether the day's event settles on the high or the low.
+func (d *Day) MarketType() weather.MarketType {
+	if strings.HasPrefix(d.Series, "KXLOWT") {
+		return weather.MarketTypeLow
+	}
+	return weather.MarketTypeHigh
+}
+
+// METARMax returns the rounded maximum METAR temperature for the day.
+func (d *Day) METARMax() int {
+	return d.METARMaxBefore(time.Time{})
+}
+
+// METARMaxBefore returns the rounded maximum of observations strictly before
+// t (all observations if t is zero), or 0 if there are none.
+func (d *Day) METARMaxBefore(t time.Time) int {
+	maxTemp := math.Inf(-1)
+	for _, o := range d.METAR {
+		if !t.IsZero() && !o.Time.Before(t) {
+			break
+		}
+		if o.TempF > maxTemp {
+			maxTemp = o.TempF
+		}
+	}
+	if math.IsInf(maxTemp, -1) {
+		return 0
+	}
+	return int(math.Round(maxTemp))
+}
+
+// METARMin returns the rounded minimum METAR temperature for the day.
+func (d *Day) METARMin() int {
+	return d.METARMinBefore(time.Time{})
+}
+
+// METARMinBefore returns the rounded minimum of observations strictly before
+// t (all observations if t is zero), or 0 if there are none.
+func (d *Day) METARMinBefore(t time.Time) int {
+	minTemp := math.Inf(1)
+	for _, o := range d.METAR {
+		if !t.IsZero() && !o.Time.Before(t) {
+			break
+		}
+		minTemp = math.Min(minTemp, o.TempF)
+	}
+	if math.IsInf(minTemp, 1) {
+		return 0
+	}
+	return int(math.Round(minTemp))
+}
+
+// Winner returns the bracket that settled YES, or nil.
+func (d *Day) Winner() *Bracket {
+	for i := range d.Brackets {
+		if d.Brackets[i].Result == "yes" {
+			return &d.Brackets[i]
+		}
+	}
+	return nil
+}
+
+// BracketFor returns the bracket containing temp, or nil.
+func (d *Day) BracketFor(temp int) *Bracket {
+	for i := range d.Brackets {
+		if d.Brackets[i].Contains(temp) {
+			return &d.Brackets[i]
+		}
+	}
+	return nil
+}
+
+// Favorite returns the bracket with the highest first trade price, or nil.
+func (d *Day) Favorite() *Bracket {
+	var fav *Bracket
+	for i := range d.Brackets {
+		b := &d.Brackets[i]
+		if b.FirstYesPrice > 0 && (fav == nil || b.FirstYesPrice > fav.FirstYesPrice) {
+			fav = b
+		}
+	}
+	return fav
+}
+
+// Cities returns the sorted station codes present in the dataset.
+func (ds *Dataset) Cities() []string {
+	seen := make(map[string]bool)
+	var cities []string
+	for _, d := range ds.Days {
+		if !seen[d.City] {
+			seen[d.City] = true
+			cities = append(cities, d.City)
+		}
+	}
+	sort.Strings(cities)
+	return cities
+}
+
+// Filter returns a dataset with the days for which keep returns true.
+func (ds *Dataset) Filter(keep func(d *Day) bool) *Dataset {
+	out := &Dataset{Source: ds.Source, Description: ds.Description}
+	for i := range ds.Days {
+		if keep(&ds.Days[i]) {
+			out.Days = append(out.Days, ds.Days[i])
+		}
+	}
+	return out
+}
+
+// City returns the days of a single city.
+func (ds *Dataset) City(code string) *Dataset {
+	code = strings.ToUpper(code)
+	return ds.Filter(func(d *Day) bool { return d.City == code })
+}
+
+// Between returns the days with from <= date <= to (YYYY-MM-DD).
+func (ds *Dataset) Between(from, to string) *Dataset {
+	return ds.Filter(func(d *Day) bool { return d.Date >= from && d.Date <= to })
+}
+
+// Sort orders days by date, then city.
+func (ds *Dataset) Sort() {
+	sort.SliceStable(ds.Days, func(i, j int) bool {
+		if ds.Days[i].Date != ds.Days[j].Date {
+			return ds.Days[i].Date < ds.Days[j].Date
+		}
+		return ds.Days[i].City < ds.Days[j].City
+	})
+}
+
+// Validate checks that every day has observations and exactly one winning
+// bracket that contains the settlement temperature, and that ticks are in
+// time order with valid prices.
+func (ds *Dataset) Validate() error {
+	for _, d := range ds.Days {
+		if len(d.METAR) == 0 {
+			return fmt.Errorf("%s: no METAR observations", d.EventTicker)
+		}
+		winners := 0
+		for _, b := range d.Brackets {
+			for i, tick := range b.Ticks {
+				if tick.YesPrice < 1 || tick.YesPrice > 99 {
+					return fmt.Errorf("%s: tick price %d¢ out of range", b.Ticker, tick.YesPrice)
+				}
+				if i > 0 && tick.Time.Before(b.Ticks[i-1].Time) {
+					return fmt.Errorf("%s: ticks out of time order", b.Ticker)
+				}
+			}
+			if b.Result == "yes" {
+				winners++
+				if !b.Contains(d.Settlement) {
+					return fmt.Errorf("%s: winner %s does not contain settlement %d°", d.EventTicker, b.Ticker, d.Settlement)
+				}
+			}
+		}
+		if winners != 1 {
+			return fmt.Errorf("%s: %d winning brackets, want 1", d.EventTicker, winners)
+		}
+	}
+	return nil
+}
+
+// Read decodes a dataset from JSON, transparently handling gzip, and
+// compacts it.
+func Read(r io.Reader) (*Dataset, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("open gzip: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	} else {
+		r = br
+	}
+
+	var ds Dataset
+	if err := json.NewDecoder(r).Decode(&ds); err != nil {
+		return nil, fmt.Errorf("parse dataset: %w", err)
+	}
+	ds.Compact()
+	return &ds, nil
+}
+
+// Compact reduces the memory a dataset holds without changing what a replay
+// sees, so multi-year, multi-city datasets fit on a laptop: repeated strings
+// (cities, series, time zones, results) share one copy, ticks that repeat the
+// previous price are dropped (PriceAt is unchanged) and slices are trimmed to
+// their length. Read compacts what it loads.
+func (ds *Dataset) Compact() {
+	strs := make(map[string]string)
+	intern := func(s string) string {
+		if v, ok := strs[s]; ok {
+			return v
+		}
+		strs[s] = s
+		return s
+	}
+
+	ds.Days = clip(ds.Days)
+	for i := range ds.Days {
+		d := &ds.Days[i]
+		d.City = intern(d.City)
+		d.Series = intern(d.Series)
+		d.Timezone = intern(d.Timezone)
+		d.METAR = clip(d.METAR)
+		d.Brackets = clip(d.Brackets)
+		for j := range d.Brackets {
+			b := &d.Brackets[j]
+			b.Result = intern(b.Result)
+			b.Ticks = clip(priceChanges(b.Ticks))
+		}
+	}
+}
+
+// DropDiscussions frees the days' forecast discussions. Replays never read
+// them, and in exported history they outweigh everything else.
+func (ds *Dataset) DropDiscussions() {
+	for i := range ds.Days {
+		ds.Days[i].Discussions = nil
+	}
+}
+
+// priceChanges returns the ticks that change the price, in place, adding the
+// count of each dropped print to the tick it repeats. Ticks out of time order
+// are kept for Validate to report.
+func priceChanges(ticks []Tick) []Tick {
+	out := ticks[:0]
+	for i, t := range ticks {
+		if i == 0 || t.YesPrice != out[len(out)-1].YesPrice || t.Time.Before(out[len(out)-1].Time) {
+			out = append(out, t)
+		} else {
+			out[len(out)-1].Count += t.Count
+		}
+	}
+	return out
+}
+
+// clip returns s in a slice of exactly its length, releasing spare capacity
+// left by decoding.
+func clip[T any](s []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	if cap(s) == len(s) {
+		return s
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
+
+// Load reads a dataset file (.json or .json.gz).
+func Load(path string) (*Dataset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Save writes the dataset to path, gzip-compressed if path ends in ".gz".
+func (ds *Dataset) Save(path string) error {
+	data, err := json.Marshal(ds)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasSuffix(path, ".gz") {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
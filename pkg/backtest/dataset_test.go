@@ -0,0 +1,183 @@
+package backtest_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest/fixtures"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+func TestFixtures_SyntheticLAXNYC(t *testing.T) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		t.Fatalf("SyntheticLAXNYC() error = %v", err)
+	}
+	if err := ds.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if got := ds.Cities(); len(got) != 2 || got[0] != "LAX" || got[1] != "NYC" {
+		t.Errorf("Cities() = %v, want [LAX NYC]", got)
+	}
+	if got := len(ds.City("lax").Days); got != 122 {
+		t.Errorf("len(City(LAX).Days) = %d, want 122", got)
+	}
+	if got := len(ds.Between("2025-09-01", "2025-09-30").Days); got != 60 {
+		t.Errorf("len(Between(September).Days) = %d, want 60", got)
+	}
+}
+
+func TestDay_METARAndBrackets(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Los_Angeles")
+	base := time.Date(2025, 12, 5, 0, 0, 0, 0, loc)
+	day := backtest.Day{
+		Date:     "2025-12-05",
+		Timezone: "America/Los_Angeles",
+		METAR: []backtest.Observation{
+			{Time: base.Add(9 * time.Hour), TempF: 60.1},
+			{Time: base.Add(12 * time.Hour), TempF: 64.4},
+			{Time: base.Add(15 * time.Hour), TempF: 62.6},
+		},
+		Settlement: 65,
+		Brackets: []backtest.Bracket{
+			{Ticker: "T62", Floor: backtest.OpenFloor, Cap: 61, FirstYesPrice: 10, Result: "no"},
+			{Ticker: "B62.5", Floor: 62, Cap: 63, FirstYesPrice: 30, Result: "no"},
+			{Ticker: "B64.5", Floor: 64, Cap: 65, FirstYesPrice: 45, Result: "yes"},
+			{Ticker: "T65", Floor: 66, Cap: backtest.OpenCap, FirstYesPrice: 15, Result: "no"},
+		},
+	}
+
+	if got := day.METARMax(); got != 64 {
+		t.Errorf("METARMax() = %d, want 64", got)
+	}
+	if got := day.METARMaxBefore(base.Add(10 * time.Hour)); got != 60 {
+		t.Errorf("METARMaxBefore(10:00) = %d, want 60", got)
+	}
+	if got := day.METARMin(); got != 60 {
+		t.Errorf("METARMin() = %d, want 60", got)
+	}
+	if got := day.METARMinBefore(base.Add(13 * time.Hour)); got != 60 {
+		t.Errorf("METARMinBefore(13:00) = %d, want 60", got)
+	}
+	if got := day.MarketType(); got != weather.MarketTypeHigh {
+		t.Errorf("MarketType() = %s, want HIGH", got)
+	}
+	if low := (backtest.Day{Series: "KXLOWTLAX"}); low.MarketType() != weather.MarketTypeLow {
+		t.Errorf("MarketType(KXLOWTLAX) = %s, want LOW", low.MarketType())
+	}
+	if got := day.Winner(); got == nil || got.Ticker != "B64.5" {
+		t.Errorf("Winner() = %v, want B64.5", got)
+	}
+	if got := day.Favorite(); got == nil || got.Ticker != "B64.5" {
+		t.Errorf("Favorite() = %v, want B64.5", got)
+	}
+	if got := day.BracketFor(58); got == nil || got.Label() != "<62°" {
+		t.Errorf("BracketFor(58) = %v, want <62°", got)
+	}
+	if got := day.BracketFor(70).Label(); got != ">65°" {
+		t.Errorf("BracketFor(70).Label() = %q, want >65°", got)
+	}
+	if !day.Time().Equal(base) {
+		t.Errorf("Time() = %v, want %v", day.Time(), base)
+	}
+}
+
+func TestBracket_PriceAt(t *testing.T) {
+	base := time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)
+	b := backtest.Bracket{
+		FirstYesPrice: 40,
+		Ticks: []backtest.Tick{
+			{Time: base.Add(7 * time.Hour), YesPrice: 40},
+			{Time: base.Add(12 * time.Hour), YesPrice: 65},
+		},
+	}
+
+	tests := []struct {
+		at   time.Duration
+		want int
+	}{
+		{6 * time.Hour, 40},
+		{11 * time.Hour, 40},
+		{12 * time.Hour, 65},
+		{20 * time.Hour, 65},
+	}
+	for _, tt := range tests {
+		if got := b.PriceAt(base.Add(tt.at)); got != tt.want {
+			t.Errorf("PriceAt(+%v) = %d, want %d", tt.at, got, tt.want)
+		}
+	}
+
+	b.Ticks[0], b.Ticks[1] = b.Ticks[1], b.Ticks[0]
+	ds := &backtest.Dataset{Days: []backtest.Day{{
+		EventTicker: "E",
+		METAR:       []backtest.Observation{{Time: base, TempF: 60}},
+		Settlement:  60,
+		Brackets:    []backtest.Bracket{{Ticker: "B", Floor: 60, Cap: 61, Result: "yes", Ticks: b.Ticks}},
+	}}}
+	if err := ds.Validate(); err == nil {
+		t.Error("Validate() accepted ticks out of time order")
+	}
+}
+
+func TestDataset_Compact(t *testing.T) {
+	base := time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)
+	ticks := make([]backtest.Tick, 0, 16)
+	for i, p := range []int{40, 40, 41, 41, 41, 39, 40} {
+		ticks = append(ticks, backtest.Tick{Time: base.Add(time.Duration(i+7) * time.Hour), YesPrice: p, Count: 10})
+	}
+	orig := backtest.Bracket{FirstYesPrice: 40, Ticks: append([]backtest.Tick(nil), ticks...)}
+	ds := &backtest.Dataset{Days: []backtest.Day{{City: "LAX", Brackets: []backtest.Bracket{{FirstYesPrice: 40, Ticks: ticks}}}}}
+
+	ds.Compact()
+	b := ds.Days[0].Brackets[0]
+	if len(b.Ticks) != 4 || cap(b.Ticks) != 4 {
+		t.Errorf("Compact() kept %d ticks (cap %d), want the 4 price changes", len(b.Ticks), cap(b.Ticks))
+	}
+	volume := 0
+	for _, tick := range b.Ticks {
+		volume += tick.Count
+	}
+	if volume != 70 || b.Ticks[1].Count != 30 {
+		t.Errorf("Compact() volume = %d (41¢ tick %d), want 70 (30)", volume, b.Ticks[1].Count)
+	}
+	for h := 0; h < 24; h++ {
+		at := base.Add(time.Duration(h) * time.Hour)
+		if got, want := b.PriceAt(at), orig.PriceAt(at); got != want {
+			t.Errorf("PriceAt(%02d:00) = %d after Compact, want %d", h, got, want)
+		}
+	}
+}
+
+// BenchmarkLoad loads the bundled fixture and reports the heap it keeps per
+// day, to size multi-year backtests: at ~5 KB a day, five years of 20 cities
+// take ~180 MB (more with real trade prints and discussions).
+func BenchmarkLoad(b *testing.B) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		b.Fatal(err)
+	}
+	path := filepath.Join(b.TempDir(), "days.json.gz")
+	if err := ds.Save(path); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := backtest.Load(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	ds, _ = backtest.Load(path)
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/float64(len(ds.Days)), "heap-B/day")
+	runtime.KeepAlive(ds)
+}
@@ -0,0 +1,148 @@
+package backtest
+
+import "sort"
+
+// Attribution splits a P&L difference between two runs by cause.
+type Attribution struct {
+	Added   float64 // Profit of positions only the second run took
+	Dropped float64 // Minus the profit of positions only the first run took
+	Changed float64 // Profit change on positions both took at a different price, size or exit
+}
+
+// Total returns the P&L difference the attribution explains.
+func (a Attribution) Total() float64 {
+	return a.Added + a.Dropped + a.Changed
+}
+
+func (a *Attribution) add(b Attribution) {
+	a.Added += b.Added
+	a.Dropped += b.Dropped
+	a.Changed += b.Changed
+}
+
+// DayDiff is a city-day on which two runs traded differently.
+type DayDiff struct {
+	Date        string
+	City        string
+	A, B        []Trade // Each run's trades that day
+	ProfitA     float64
+	ProfitB     float64
+	Attribution Attribution
+}
+
+// Delta returns the second run's profit that day minus the first's.
+func (d DayDiff) Delta() float64 {
+	return d.ProfitB - d.ProfitA
+}
+
+// RunDiff is a trade-level comparison of two backtest runs, so a parameter
+// change can be reviewed by the decisions it changed rather than a single
+// headline number.
+type RunDiff struct {
+	Days        []DayDiff // City-days on which the runs diverged, by date then city
+	Identical   int       // City-days both runs traded identically
+	Delta       float64   // Second run's total profit minus the first's
+	Attribution Attribution
+}
+
+// Diff compares run a with run b. Trades are matched by city, date, market
+// and side; a position counts as changed if its fills (price, size and exit)
+// differ.
+func Diff(a, b *Result) *RunDiff {
+	type dayKey struct{ date, city string }
+	days := make(map[dayKey][2][]Trade)
+	for i, r := range []*Result{a, b} {
+		for _, t := range r.Trades {
+			k := dayKey{t.Date, t.City}
+			d := days[k]
+			d[i] = append(d[i], t)
+			days[k] = d
+		}
+	}
+
+	diff := &RunDiff{Delta: b.TotalProfit - a.TotalProfit}
+	for k, trades := range days {
+		attr, diverged := attribute(trades[0], trades[1])
+		if !diverged {
+			diff.Identical++
+			continue
+		}
+		diff.Days = append(diff.Days, DayDiff{
+			Date:        k.date,
+			City:        k.city,
+			A:           trades[0],
+			B:           trades[1],
+			ProfitA:     profit(trades[0]),
+			ProfitB:     profit(trades[1]),
+			Attribution: attr,
+		})
+		diff.Attribution.add(attr)
+	}
+
+	sort.Slice(diff.Days, func(i, j int) bool {
+		if diff.Days[i].Date != diff.Days[j].Date {
+			return diff.Days[i].Date < diff.Days[j].Date
+		}
+		return diff.Days[i].City < diff.Days[j].City
+	})
+	return diff
+}
+
+// attribute matches one day's positions of two runs and attributes the P&L
+// difference, reporting whether any position differs.
+func attribute(a, b []Trade) (Attribution, bool) {
+	type position struct{ ticker, side string }
+	group := func(trades []Trade) map[position][]Trade {
+		m := make(map[position][]Trade)
+		for _, t := range trades {
+			p := position{t.Ticker, t.Side}
+			m[p] = append(m[p], t)
+		}
+		return m
+	}
+	pa, pb := group(a), group(b)
+
+	var attr Attribution
+	diverged := false
+	for p, ta := range pa {
+		tb, ok := pb[p]
+		switch {
+		case !ok:
+			attr.Dropped -= profit(ta)
+			diverged = true
+		case !sameFills(ta, tb):
+			attr.Changed += profit(tb) - profit(ta)
+			diverged = true
+		}
+	}
+	for p, tb := range pb {
+		if _, ok := pa[p]; !ok {
+			attr.Added += profit(tb)
+			diverged = true
+		}
+	}
+	return attr, diverged
+}
+
+// sameFills reports whether two runs filled a position identically.
+func sameFills(a, b []Trade) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Price != b[i].Price || a[i].Quantity != b[i].Quantity ||
+			a[i].ExitPrice != b[i].ExitPrice || a[i].Settled != b[i].Settled ||
+			!a[i].Time.Equal(b[i].Time) || !a[i].ExitTime.Equal(b[i].ExitTime) {
+			return false
+		}
+	}
+	return true
+}
+
+func profit(trades []Trade) float64 {
+	var p float64
+	for _, t := range trades {
+		p += t.Profit
+	}
+	return p
+}
@@ -0,0 +1,58 @@
+package backtest_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+func TestDiff(t *testing.T) {
+	trade := func(date, city, ticker, side string, price int, profit float64) backtest.Trade {
+		return backtest.Trade{Date: date, City: city, Ticker: ticker, Side: side, Price: price, Quantity: 10, Profit: profit}
+	}
+	result := func(trades ...backtest.Trade) *backtest.Result {
+		r := &backtest.Result{Trades: trades}
+		for _, t := range trades {
+			r.TotalProfit += t.Profit
+		}
+		return r
+	}
+
+	a := result(
+		trade("2025-12-05", "LAX", "A", "no", 80, 2),  // Identical day
+		trade("2025-12-06", "LAX", "B", "no", 70, 3),  // Dropped by b
+		trade("2025-12-06", "LAX", "C", "yes", 40, 6), // Same position, b paid more
+		trade("2025-12-06", "NYC", "D", "no", 90, 1),  // Identical day
+	)
+	b := result(
+		trade("2025-12-05", "LAX", "A", "no", 80, 2),
+		trade("2025-12-06", "LAX", "C", "yes", 45, 5.5),
+		trade("2025-12-06", "NYC", "D", "no", 90, 1),
+		trade("2025-12-07", "LAX", "E", "no", 60, -6), // Only b traded that day
+	)
+
+	diff := backtest.Diff(a, b)
+	if diff.Identical != 2 || len(diff.Days) != 2 {
+		t.Fatalf("Diff() = %d identical, %d diverged days, want 2 and 2", diff.Identical, len(diff.Days))
+	}
+
+	d := diff.Days[0]
+	if d.Date != "2025-12-06" || d.City != "LAX" || len(d.A) != 2 || len(d.B) != 1 {
+		t.Errorf("Days[0] = %s %s with %d/%d trades, want 2025-12-06 LAX with 2/1", d.Date, d.City, len(d.A), len(d.B))
+	}
+	if d.Attribution.Dropped != -3 || d.Attribution.Changed != -0.5 || d.Delta() != -3.5 {
+		t.Errorf("Days[0] attribution = %+v, delta %v, want dropped -3, changed -0.5, delta -3.5", d.Attribution, d.Delta())
+	}
+	if d := diff.Days[1]; d.Date != "2025-12-07" || d.Attribution.Added != -6 || len(d.A) != 0 {
+		t.Errorf("Days[1] = %+v, want 2025-12-07 added -6", d)
+	}
+
+	if diff.Delta != -9.5 || math.Abs(diff.Attribution.Total()-diff.Delta) > 1e-9 {
+		t.Errorf("Delta = %v, attribution total = %v, want -9.5 fully attributed", diff.Delta, diff.Attribution.Total())
+	}
+
+	if same := backtest.Diff(a, a); len(same.Days) != 0 || same.Delta != 0 {
+		t.Errorf("Diff(a, a) = %d diverged days, delta %v, want none", len(same.Days), same.Delta)
+	}
+}
@@ -0,0 +1,25 @@
+// Package backtest provides the historical day dataset and the replay engine
+// used to evaluate strategies built on strategy.Strategy offline.
+//
+// A Dataset holds settled station-days (hourly METAR, settlement, brackets
+// and their trade prints); Load reads one exported by cmd/backtest-fixtures
+// and package fixtures bundles a synthetic LAX/NYC set for tests. Run replays a
+// strategy over a dataset into a Result, alongside the naive Baselines, and
+// Diff, Robustness, EstimateCapacity and SimulateBankroll look at a result
+// from other angles. PriceSeries reconstructs a market's price at any
+// minute from its trades, and Dataset.AddBooks attaches recorded order book
+// depths for strategies that time entries on them. WalkForward chooses
+// parameters on past days and scores them only on the days after. Fetch
+// collects the history behind a dataset on a bounded pool of workers, and
+// Result.Export writes a result's trades, days and summary as CSV or JSON.
+// RunRecord keeps a registry of runs: the parameters, commit, data window,
+// seed and metrics of each, to compare with CompareRunRecords.
+//
+// # Stability
+//
+// backtest is part of the module's public API and follows semantic
+// versioning: exported identifiers keep their meaning within a major
+// version, and renamed ones stay as deprecated wrappers until the next
+// major release. The dataset file format only gains fields within a major
+// version. See CHANGELOG.md.
+package backtest
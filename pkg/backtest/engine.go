@@ -0,0 +1,586 @@
+package backtest
+
+import (
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// Config configures a backtest run.
+type Config struct {
+	// Fees prices every fill; nil charges no fees. It is not serialized.
+	Fees *fees.Schedule `json:"-"`
+	// DecisionHours are the local hours at which the strategy receives
+	// weather and market updates and is asked for orders (default 8-16).
+	DecisionHours []int
+	// HalfSpread is added to / subtracted from the last trade price to
+	// derive the bid and ask quoted to the strategy (default 1¢).
+	HalfSpread int
+	// MissFill is the probability that an order which would fill is
+	// rejected instead, simulating fills lost to queue position or latency.
+	MissFill float64
+	// Seed seeds the MissFill draws and the random baseline.
+	Seed uint64
+	// Baselines also replays the naive strategies of Baselines over the
+	// same days into Result.Baselines, so a strategy's result is read
+	// against them. It is not serialized.
+	Baselines bool `json:"-"`
+}
+
+// DefaultConfig returns the standard backtest configuration: the default fee
+// schedule, hourly decisions from 8 AM to 4 PM local and a 2¢ spread, with
+// the baselines.
+func DefaultConfig() Config {
+	return Config{
+		Fees:          fees.DefaultSchedule(),
+		DecisionHours: []int{8, 9, 10, 11, 12, 13, 14, 15, 16},
+		HalfSpread:    1,
+		Baselines:     true,
+	}
+}
+
+// Trade is a simulated round trip: an entry fill closed either by a sell
+// before settlement or by settlement itself. A buy closed by several sells
+// (or partly held to settlement) is split into one Trade per closing fill.
+type Trade struct {
+	Time          time.Time // Entry time
+	City          string
+	Date          string
+	EventTicker   string
+	Ticker        string
+	Side          string
+	Price         int // Entry price in cents
+	Quantity      int
+	Liquidity     fees.Liquidity // Entry liquidity
+	ExitTime      time.Time      // Sell time, or the end of the day when settled
+	ExitPrice     int            // Sell price in cents, or 100 / 0 when settled
+	ExitLiquidity fees.Liquidity // Empty when settled
+	Settled       bool           // Held to settlement
+	Won           bool           // Settled in the money, or exited at a net profit
+	Fees          float64
+	Profit        float64 // Net of fees
+	Reason        string  // Entry order reason
+	ExitReason    string  // Exit order reason, empty when settled
+}
+
+// Cost returns the premium paid for the trade in dollars.
+func (t Trade) Cost() float64 {
+	return float64(t.Quantity*t.Price) / 100
+}
+
+// Hours returns how long the premium was locked up: from entry to the exit
+// sell, or to the end of the day when settled.
+func (t Trade) Hours() float64 {
+	return t.ExitTime.Sub(t.Time).Hours()
+}
+
+// DollarHours returns the capital the trade tied up, the premium times the
+// hours it was held.
+func (t Trade) DollarHours() float64 {
+	return t.Cost() * t.Hours()
+}
+
+// Result summarizes a backtest run.
+type Result struct {
+	Strategy    string
+	Days        int                // Days replayed
+	Dates       int                // Distinct dates replayed (Days counts each city)
+	Through     string             // Latest date replayed
+	Trades      []Trade            // All fills in time order
+	Rejected    int                // Orders that could not be filled
+	DailyPnL    map[string]float64 // Date -> P&L summed across cities
+	TotalProfit float64
+	TotalFees   float64
+	WinRate     float64 // Percentage of winning trades
+	Sharpe      float64 // Annualized over trading days
+	MaxDrawdown float64
+	Baselines   []*Result `json:",omitempty"` // Naive strategies over the same days
+}
+
+// TradedDays returns the sorted dates with at least one trade.
+func (r *Result) TradedDays() []string {
+	days := make([]string, 0, len(r.DailyPnL))
+	for d := range r.DailyPnL {
+		days = append(days, d)
+	}
+	sort.Strings(days)
+	return days
+}
+
+// Bootstrap returns the result's win rate, EV per trade, Sharpe ratio and
+// max drawdown with bootstrap confidence intervals: trades are resampled
+// for the first two and traded days for the others.
+func (r *Result) Bootstrap(cfg stats.BootstrapConfig) stats.Summary {
+	s := stats.Sample{PeriodsPerYear: 252}
+	for _, t := range r.Trades {
+		s.Profits = append(s.Profits, t.Profit)
+		if t.Won {
+			s.Wins++
+		}
+	}
+	for _, d := range r.TradedDays() {
+		s.Daily = append(s.Daily, r.DailyPnL[d])
+	}
+	return s.Bootstrap(cfg)
+}
+
+// Run replays the dataset through the strategy. At each decision point the
+// strategy is quoted around each bracket's last archived tick (or its first
+// trade price if the dataset has no ticks). A buy at or above the ask fills
+// as taker at the ask and a buy between bid and ask is assumed to fill as
+// maker at its limit; sells mirror this against the bid and may only close
+// contracts already held. Anything else is rejected, as is a MissFill share
+// of the orders that would fill. Open positions are closed oldest first and
+// whatever is still held at the end of the day is settled. A bracket whose
+// outcome was determined early is quoted without prices from then on, so
+// neither entries nor exits fill; positions in it are held to settlement.
+// Brackets with recorded books are quoted with the depths of the last
+// snapshot before each decision point.
+func Run(ds *Dataset, s strategy.Strategy, cfg Config) *Result {
+	if len(cfg.DecisionHours) == 0 {
+		cfg.DecisionHours = DefaultConfig().DecisionHours
+	}
+
+	result := &Result{
+		Strategy: s.Name(),
+		DailyPnL: make(map[string]float64),
+	}
+
+	var rng *rand.Rand
+	if cfg.MissFill > 0 {
+		rng = rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x9e3779b97f4a7c15))
+	}
+
+	days := sortedDays(ds.Days)
+	replayDays(result, days, s, cfg, rng)
+	summarize(result)
+
+	if cfg.Baselines {
+		base := cfg
+		base.MissFill = 0
+		for _, b := range Baselines(cfg.Seed) {
+			br := &Result{Strategy: b.Name(), DailyPnL: make(map[string]float64)}
+			replayDays(br, days, b, base, nil)
+			summarize(br)
+			result.Baselines = append(result.Baselines, br)
+		}
+	}
+	return result
+}
+
+// Append replays the days of ds dated after r.Through through s and adds
+// them to r, so a nightly update replays only the new day instead of the
+// whole history. The summary is recomputed from the stored trades and daily
+// P&L. It returns the number of days appended.
+//
+// s should be a fresh strategy. The result matches a full Run when the
+// strategy carries no state from one date to the next and cfg.MissFill is 0;
+// otherwise missed fills are drawn from a new stream seeded by cfg.Seed and
+// the number of days already replayed. Baselines in r are appended to as
+// well, whatever cfg.Baselines.
+func Append(r *Result, ds *Dataset, s strategy.Strategy, cfg Config) int {
+	if len(cfg.DecisionHours) == 0 {
+		cfg.DecisionHours = DefaultConfig().DecisionHours
+	}
+	if r.DailyPnL == nil {
+		r.DailyPnL = make(map[string]float64)
+	}
+
+	through := r.Through
+	if through == "" {
+		// Saved before Through was recorded: the last traded date is the
+		// best estimate of how far the run got
+		if traded := r.TradedDays(); len(traded) > 0 {
+			through = traded[len(traded)-1]
+		}
+	}
+	var days []Day
+	for _, d := range ds.Days {
+		if d.Date > through {
+			days = append(days, d)
+		}
+	}
+	if len(days) == 0 {
+		return 0
+	}
+
+	var rng *rand.Rand
+	if cfg.MissFill > 0 {
+		seed := cfg.Seed + uint64(r.Days)
+		rng = rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	}
+
+	days = sortedDays(days)
+	replayDays(r, days, s, cfg, rng)
+	summarize(r)
+
+	base := cfg
+	base.MissFill = 0
+	for _, br := range r.Baselines {
+		if b := newBaseline(br.Strategy, cfg.Seed); b != nil {
+			replayDays(br, days, b, base, nil)
+			summarize(br)
+		}
+	}
+	return len(days)
+}
+
+// sortedDays returns a copy of days in date order
+func sortedDays(days []Day) []Day {
+	days = append([]Day(nil), days...)
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+	return days
+}
+
+// replayDays replays days, sorted by date, into result
+func replayDays(result *Result, days []Day, s strategy.Strategy, cfg Config, rng *rand.Rand) {
+	result.Days += len(days)
+	for i := range days {
+		if i == 0 || days[i].Date != days[i-1].Date {
+			result.Dates++
+		}
+	}
+
+	for i := range days {
+		r := &replay{
+			day:    &days[i],
+			cfg:    cfg,
+			rng:    rng,
+			result: result,
+			won:    make(map[string]bool),
+			open:   make(map[string][]lot),
+		}
+		r.run(s)
+	}
+	if len(days) > 0 {
+		result.Through = days[len(days)-1].Date
+	}
+}
+
+// lot is an open position from one buy fill
+type lot struct {
+	seq    int // Fill order within the day
+	time   time.Time
+	ticker string
+	side   string
+	price  int
+	qty    int
+	liq    fees.Liquidity
+	reason string
+}
+
+// replay holds the state of one day's replay
+type replay struct {
+	day    *Day
+	cfg    Config
+	rng    *rand.Rand // Draws missed fills; nil when MissFill is 0
+	result *Result
+	won    map[string]bool  // Ticker -> settled YES
+	open   map[string][]lot // Ticker/side -> open lots, oldest first
+	fills  int
+}
+
+func (r *replay) run(s strategy.Strategy) {
+	start := r.day.Time()
+	for _, b := range r.day.Brackets {
+		r.won[b.Ticker] = b.Result == "yes"
+	}
+	observer, _ := s.(strategy.FillObserver)
+	first := len(r.result.Trades)
+
+	for _, hour := range r.cfg.DecisionHours {
+		now := start.Add(time.Duration(hour) * time.Hour)
+
+		if update, ok := weatherAt(r.day, now); ok {
+			s.OnWeatherUpdate(update)
+		}
+		data := marketDataAt(r.day, now, r.cfg.HalfSpread)
+		s.OnMarketData(data)
+
+		quotes := make(map[string]strategy.Quote, len(data.Quotes))
+		for _, q := range data.Quotes {
+			quotes[q.Ticker] = q
+		}
+
+		for _, o := range s.GenerateOrders(now) {
+			fill, ok := r.execute(o, quotes, now)
+			if !ok {
+				r.result.Rejected++
+				continue
+			}
+			if observer != nil {
+				observer.OnFill(fill)
+			}
+		}
+	}
+
+	r.settle(start.AddDate(0, 0, 1))
+
+	// Report the day's round trips in entry order
+	trades := r.result.Trades[first:]
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Time.Before(trades[j].Time)
+	})
+}
+
+// execute fills an order against the quotes, opening or closing lots
+func (r *replay) execute(o strategy.Order, quotes map[string]strategy.Quote, now time.Time) (strategy.Fill, bool) {
+	q, ok := quotes[o.Ticker]
+	if !ok || o.Quantity <= 0 || (o.Side != "yes" && o.Side != "no") {
+		return strategy.Fill{}, false
+	}
+	key := o.Ticker + "/" + o.Side
+
+	var price int
+	var liq fees.Liquidity
+	switch o.Action {
+	case "buy":
+		price, liq, ok = fillBuy(o, q)
+	case "sell":
+		if held(r.open[key]) < o.Quantity {
+			return strategy.Fill{}, false
+		}
+		price, liq, ok = fillSell(o, q)
+	default:
+		ok = false
+	}
+	if !ok || (r.rng != nil && r.rng.Float64() < r.cfg.MissFill) {
+		return strategy.Fill{}, false
+	}
+
+	if o.Action == "buy" {
+		r.fills++
+		r.open[key] = append(r.open[key], lot{
+			seq:    r.fills,
+			time:   now,
+			ticker: o.Ticker,
+			side:   o.Side,
+			price:  price,
+			qty:    o.Quantity,
+			liq:    liq,
+			reason: o.Reason,
+		})
+	} else {
+		r.close(key, o.Quantity, now, price, liq, o.Reason)
+	}
+
+	return strategy.Fill{
+		Time:        now,
+		EventTicker: r.day.EventTicker,
+		Ticker:      o.Ticker,
+		Side:        o.Side,
+		Action:      o.Action,
+		Price:       price,
+		Quantity:    o.Quantity,
+	}, true
+}
+
+// close sells qty contracts from the oldest open lots of key
+func (r *replay) close(key string, qty int, now time.Time, price int, liq fees.Liquidity, reason string) {
+	lots := r.open[key]
+	for qty > 0 && len(lots) > 0 {
+		l := &lots[0]
+		n := min(qty, l.qty)
+
+		rule := r.cfg.Fees.RuleForTicker(l.ticker, l.time)
+		profit := rule.ExitProfit(l.liq, liq, float64(n), l.price, price)
+		gross := float64(n*(price-l.price)) / 100
+
+		t := r.trade(*l, n)
+		t.ExitTime = now
+		t.ExitPrice = price
+		t.ExitLiquidity = liq
+		t.Won = profit > 0
+		t.Fees = gross - profit
+		t.Profit = profit
+		t.ExitReason = reason
+		r.record(t)
+
+		l.qty -= n
+		qty -= n
+		if l.qty == 0 {
+			lots = lots[1:]
+		}
+	}
+	r.open[key] = lots
+}
+
+// settle closes every open lot at settlement, in fill order
+func (r *replay) settle(at time.Time) {
+	var lots []lot
+	for _, open := range r.open {
+		lots = append(lots, open...)
+	}
+	sort.Slice(lots, func(i, j int) bool { return lots[i].seq < lots[j].seq })
+
+	for _, l := range lots {
+		won := r.won[l.ticker] == (l.side == "yes")
+		rule := r.cfg.Fees.RuleForTicker(l.ticker, l.time)
+		profit := rule.NetProfit(l.liq, float64(l.qty), l.price, won)
+		gross := float64(l.qty*(100-l.price)) / 100
+		exit := 100
+		if !won {
+			gross = -float64(l.qty*l.price) / 100
+			exit = 0
+		}
+
+		t := r.trade(l, l.qty)
+		t.ExitTime = at
+		t.ExitPrice = exit
+		t.Settled = true
+		t.Won = won
+		t.Fees = gross - profit
+		t.Profit = profit
+		r.record(t)
+	}
+	r.open = make(map[string][]lot)
+}
+
+// trade returns the entry side of a round trip for qty contracts of l
+func (r *replay) trade(l lot, qty int) Trade {
+	return Trade{
+		Time:        l.time,
+		City:        r.day.City,
+		Date:        r.day.Date,
+		EventTicker: r.day.EventTicker,
+		Ticker:      l.ticker,
+		Side:        l.side,
+		Price:       l.price,
+		Quantity:    qty,
+		Liquidity:   l.liq,
+		Reason:      l.reason,
+	}
+}
+
+func (r *replay) record(t Trade) {
+	r.result.Trades = append(r.result.Trades, t)
+	r.result.DailyPnL[r.day.Date] += t.Profit
+}
+
+// held returns the number of contracts in lots
+func held(lots []lot) int {
+	n := 0
+	for _, l := range lots {
+		n += l.qty
+	}
+	return n
+}
+
+// marketDataAt quotes every bracket around its price at now
+func marketDataAt(day *Day, now time.Time, halfSpread int) strategy.MarketData {
+	data := strategy.MarketData{Time: now, City: day.City, EventTicker: day.EventTicker, Type: day.MarketType()}
+	for _, b := range day.Brackets {
+		if b.DeterminedBy(now) {
+			data.Quotes = append(data.Quotes, strategy.Quote{Ticker: b.Ticker, Floor: b.Floor, Cap: b.Cap, Determined: true})
+			continue
+		}
+		q := quoteFor(b, b.PriceAt(now), halfSpread)
+		if book, ok := b.BookAt(now); ok {
+			q.YesDepth, q.NoDepth = book.YesDepth, book.NoDepth
+		}
+		data.Quotes = append(data.Quotes, q)
+	}
+	return data
+}
+
+// quoteFor derives a two-sided quote from a trade price
+func quoteFor(b Bracket, price, halfSpread int) strategy.Quote {
+	q := strategy.Quote{Ticker: b.Ticker, Floor: b.Floor, Cap: b.Cap}
+	if price <= 0 {
+		return q
+	}
+	q.YesBid = clampPrice(price - halfSpread)
+	q.YesAsk = clampPrice(price + halfSpread)
+	q.NoBid = 100 - q.YesAsk
+	q.NoAsk = 100 - q.YesBid
+	return q
+}
+
+// fillBuy returns the execution price and liquidity of a buy order
+func fillBuy(o strategy.Order, q strategy.Quote) (int, fees.Liquidity, bool) {
+	bid, ask := sideQuote(o.Side, q)
+	switch {
+	case ask == 0:
+		return 0, "", false
+	case o.Price >= ask:
+		return ask, fees.Taker, true
+	case o.Price >= bid && o.Price > 0:
+		return o.Price, fees.Maker, true
+	}
+	return 0, "", false
+}
+
+// fillSell returns the execution price and liquidity of a sell order
+func fillSell(o strategy.Order, q strategy.Quote) (int, fees.Liquidity, bool) {
+	bid, ask := sideQuote(o.Side, q)
+	switch {
+	case ask == 0 || o.Price <= 0:
+		return 0, "", false
+	case o.Price <= bid:
+		return bid, fees.Taker, true
+	case o.Price <= ask:
+		return o.Price, fees.Maker, true
+	}
+	return 0, "", false
+}
+
+// sideQuote returns the bid and ask of one side of a quote
+func sideQuote(side string, q strategy.Quote) (int, int) {
+	if side == "no" {
+		return q.NoBid, q.NoAsk
+	}
+	return q.YesBid, q.YesAsk
+}
+
+// weatherAt returns the latest observation before now
+func weatherAt(day *Day, now time.Time) (strategy.WeatherUpdate, bool) {
+	var last *Observation
+	for i := range day.METAR {
+		if !day.METAR[i].Time.Before(now) {
+			break
+		}
+		last = &day.METAR[i]
+	}
+	if last == nil {
+		return strategy.WeatherUpdate{}, false
+	}
+	return strategy.WeatherUpdate{
+		Time:     last.Time,
+		City:     day.City,
+		TempF:    last.TempF,
+		MaxTempF: float64(day.METARMaxBefore(now)),
+		MinTempF: float64(day.METARMinBefore(now)),
+	}, true
+}
+
+func summarize(r *Result) {
+	r.TotalProfit, r.TotalFees, r.WinRate = 0, 0, 0
+	wins := 0
+	for _, t := range r.Trades {
+		r.TotalProfit += t.Profit
+		r.TotalFees += t.Fees
+		if t.Won {
+			wins++
+		}
+	}
+	if len(r.Trades) > 0 {
+		r.WinRate = float64(wins) / float64(len(r.Trades)) * 100
+	}
+
+	var daily []float64
+	for _, d := range r.TradedDays() {
+		daily = append(daily, r.DailyPnL[d])
+	}
+	r.Sharpe = stats.Sharpe(daily, 252)
+	r.MaxDrawdown = stats.MaxDrawdown(daily)
+}
+
+func clampPrice(p int) int {
+	return min(max(p, 1), 99)
+}
@@ -0,0 +1,384 @@
+package backtest_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest/fixtures"
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// scripted emits a fixed set of orders at the first decision point of each event
+type scripted struct {
+	orders  []strategy.Order
+	seen    map[string]bool
+	markets []strategy.MarketData
+	weather []strategy.WeatherUpdate
+}
+
+func (s *scripted) Name() string { return "scripted" }
+
+func (s *scripted) OnMarketData(data strategy.MarketData) { s.markets = append(s.markets, data) }
+
+func (s *scripted) OnWeatherUpdate(update strategy.WeatherUpdate) {
+	s.weather = append(s.weather, update)
+}
+
+func (s *scripted) GenerateOrders(now time.Time) []strategy.Order {
+	event := s.markets[len(s.markets)-1].EventTicker
+	if s.seen[event] {
+		return nil
+	}
+	s.seen[event] = true
+	return s.orders
+}
+
+func testDay() *backtest.Dataset {
+	loc, _ := time.LoadLocation("America/Los_Angeles")
+	base := time.Date(2025, 12, 5, 0, 0, 0, 0, loc)
+	return &backtest.Dataset{Days: []backtest.Day{{
+		City:        "LAX",
+		Series:      "KXHIGHLAX",
+		EventTicker: "KXHIGHLAX-25DEC05",
+		Date:        "2025-12-05",
+		Timezone:    "America/Los_Angeles",
+		METAR: []backtest.Observation{
+			{Time: base.Add(8*time.Hour + 53*time.Minute), TempF: 60.8},
+			{Time: base.Add(13*time.Hour + 53*time.Minute), TempF: 64.4},
+		},
+		Settlement: 64,
+		Brackets: []backtest.Bracket{
+			{Ticker: "A", Floor: backtest.OpenFloor, Cap: 61, FirstYesPrice: 20, Result: "no"},
+			{Ticker: "B", Floor: 62, Cap: 63, FirstYesPrice: 40, Result: "no"},
+			{Ticker: "C", Floor: 64, Cap: backtest.OpenCap, FirstYesPrice: 40, Result: "yes"},
+		},
+	}}}
+}
+
+func TestRun_FillModel(t *testing.T) {
+	s := &scripted{
+		seen: make(map[string]bool),
+		orders: []strategy.Order{
+			{Ticker: "C", Side: "yes", Action: "buy", Price: 45, Quantity: 10},  // taker at ask 41
+			{Ticker: "B", Side: "no", Action: "buy", Price: 60, Quantity: 10},   // maker at 60 (bid 59, ask 61)
+			{Ticker: "A", Side: "yes", Action: "buy", Price: 10, Quantity: 10},  // below bid: rejected
+			{Ticker: "C", Side: "yes", Action: "sell", Price: 50, Quantity: 10}, // sells before the buy settles: nothing held
+			{Ticker: "Z", Side: "yes", Action: "buy", Price: 50, Quantity: 10},  // unknown ticker
+		},
+	}
+	cfg := backtest.DefaultConfig()
+	cfg.Fees = nil
+
+	r := backtest.Run(testDay(), s, cfg)
+
+	if len(r.Trades) != 2 || r.Rejected != 3 {
+		t.Fatalf("got %d trades, %d rejected, want 2, 3", len(r.Trades), r.Rejected)
+	}
+
+	yes, no := r.Trades[0], r.Trades[1]
+	if yes.Price != 41 || yes.Liquidity != fees.Taker || !yes.Won {
+		t.Errorf("yes trade = %+v, want taker fill at 41 that wins", yes)
+	}
+	if no.Price != 60 || no.Liquidity != fees.Maker || !no.Won {
+		t.Errorf("no trade = %+v, want maker fill at 60 that wins", no)
+	}
+	if want := 5.9 + 4.0; math.Abs(r.TotalProfit-want) > 1e-9 {
+		t.Errorf("TotalProfit = %v, want %v", r.TotalProfit, want)
+	}
+	if r.WinRate != 100 {
+		t.Errorf("WinRate = %v, want 100", r.WinRate)
+	}
+}
+
+func TestRun_WeatherHasNoLookahead(t *testing.T) {
+	s := &scripted{seen: make(map[string]bool)}
+	cfg := backtest.DefaultConfig()
+	cfg.DecisionHours = []int{8, 12, 16}
+
+	backtest.Run(testDay(), s, cfg)
+
+	// 08:00 has no observation yet; 12:00 sees only the 08:53 report
+	if len(s.weather) != 2 {
+		t.Fatalf("got %d weather updates, want 2", len(s.weather))
+	}
+	if s.weather[0].MaxTempF != 61 || s.weather[1].MaxTempF != 64 {
+		t.Errorf("MaxTempF = %v, %v, want 61, 64", s.weather[0].MaxTempF, s.weather[1].MaxTempF)
+	}
+}
+
+func TestRun_Fees(t *testing.T) {
+	s := &scripted{
+		seen:   make(map[string]bool),
+		orders: []strategy.Order{{Ticker: "C", Side: "yes", Action: "buy", Price: 41, Quantity: 100}},
+	}
+
+	r := backtest.Run(testDay(), s, backtest.DefaultConfig())
+
+	// $59 gross winnings, 7% fee
+	if math.Abs(r.TotalFees-4.13) > 1e-9 || math.Abs(r.TotalProfit-54.87) > 1e-9 {
+		t.Errorf("TotalFees = %v, TotalProfit = %v, want 4.13, 54.87", r.TotalFees, r.TotalProfit)
+	}
+}
+
+// timed emits orders at given local hours and records its fills
+type timed struct {
+	orders  map[int][]strategy.Order
+	fills   []strategy.Fill
+	markets []strategy.MarketData
+}
+
+func (s *timed) Name() string                                  { return "timed" }
+func (s *timed) OnMarketData(data strategy.MarketData)         { s.markets = append(s.markets, data) }
+func (s *timed) OnWeatherUpdate(update strategy.WeatherUpdate) {}
+func (s *timed) OnFill(fill strategy.Fill)                     { s.fills = append(s.fills, fill) }
+
+func (s *timed) GenerateOrders(now time.Time) []strategy.Order {
+	return s.orders[now.Hour()]
+}
+
+// tickDay is testDay with archived prints on bracket C
+func tickDay() *backtest.Dataset {
+	ds := testDay()
+	day := &ds.Days[0]
+	base := day.Time()
+	day.Brackets[2].Ticks = []backtest.Tick{
+		{Time: base.Add(7 * time.Hour), YesPrice: 40},
+		{Time: base.Add(10*time.Hour + 30*time.Minute), YesPrice: 70},
+		{Time: base.Add(13 * time.Hour), YesPrice: 30},
+	}
+	return ds
+}
+
+func TestRun_QuotesFollowTicks(t *testing.T) {
+	s := &timed{orders: map[int][]strategy.Order{
+		8:  {{Ticker: "C", Side: "yes", Action: "buy", Price: 99, Quantity: 1}},
+		11: {{Ticker: "C", Side: "yes", Action: "buy", Price: 99, Quantity: 1}},
+		14: {{Ticker: "C", Side: "yes", Action: "buy", Price: 99, Quantity: 1}},
+	}}
+	cfg := backtest.DefaultConfig()
+	cfg.Fees = nil
+
+	r := backtest.Run(tickDay(), s, cfg)
+
+	if len(r.Trades) != 3 {
+		t.Fatalf("got %d trades, want 3", len(r.Trades))
+	}
+	for i, want := range []int{41, 71, 31} {
+		if r.Trades[i].Price != want {
+			t.Errorf("Trades[%d].Price = %d, want %d", i, r.Trades[i].Price, want)
+		}
+	}
+}
+
+func TestRun_ExitsAndReentries(t *testing.T) {
+	s := &timed{orders: map[int][]strategy.Order{
+		8: {
+			{Ticker: "C", Side: "yes", Action: "buy", Price: 41, Quantity: 10, Reason: "entry 1"},
+			{Ticker: "C", Side: "yes", Action: "buy", Price: 41, Quantity: 10, Reason: "entry 2"},
+		},
+		// Harvest 15 at the 69¢ bid: closes entry 1 and half of entry 2
+		11: {{Ticker: "C", Side: "yes", Action: "sell", Price: 60, Quantity: 15, Reason: "harvest"}},
+		// Cannot sell more than the 5 still held
+		12: {{Ticker: "C", Side: "yes", Action: "sell", Price: 60, Quantity: 6}},
+		// Re-enter after the drop; the rest settles
+		14: {{Ticker: "C", Side: "yes", Action: "buy", Price: 31, Quantity: 10, Reason: "re-entry"}},
+	}}
+	cfg := backtest.DefaultConfig()
+	cfg.Fees = nil
+
+	r := backtest.Run(tickDay(), s, cfg)
+
+	if r.Rejected != 1 {
+		t.Errorf("Rejected = %d, want 1", r.Rejected)
+	}
+	if len(s.fills) != 4 {
+		t.Errorf("got %d fills, want 4", len(s.fills))
+	}
+	if len(r.Trades) != 4 {
+		t.Fatalf("got %d trades, want 4: %+v", len(r.Trades), r.Trades)
+	}
+
+	exit1, exit2, settled2, reentry := r.Trades[0], r.Trades[1], r.Trades[2], r.Trades[3]
+	if exit1.Reason != "entry 1" || exit1.Quantity != 10 || exit1.ExitPrice != 69 || exit1.Settled {
+		t.Errorf("first trade = %+v, want entry 1 exited 10 @ 69", exit1)
+	}
+	if exit1.ExitLiquidity != fees.Taker || exit1.ExitReason != "harvest" || exit1.ExitTime.Hour() != 11 {
+		t.Errorf("first trade exit = %s %q at %v, want taker harvest at 11:00", exit1.ExitLiquidity, exit1.ExitReason, exit1.ExitTime)
+	}
+	if exit2.Reason != "entry 2" || exit2.Quantity != 5 || exit2.Settled {
+		t.Errorf("second trade = %+v, want 5 of entry 2 exited", exit2)
+	}
+	if settled2.Reason != "entry 2" || settled2.Quantity != 5 || !settled2.Settled || settled2.ExitPrice != 100 {
+		t.Errorf("third trade = %+v, want 5 of entry 2 settled at 100", settled2)
+	}
+	if reentry.Price != 31 || !reentry.Won {
+		t.Errorf("re-entry = %+v, want fill at 31 that wins", reentry)
+	}
+
+	// 15 * 28¢ harvested + 5 * 59¢ + 10 * 69¢ settled
+	if want := 4.2 + 2.95 + 6.9; math.Abs(r.TotalProfit-want) > 1e-9 {
+		t.Errorf("TotalProfit = %v, want %v", r.TotalProfit, want)
+	}
+}
+
+func TestRun_ExitFees(t *testing.T) {
+	s := &timed{orders: map[int][]strategy.Order{
+		8:  {{Ticker: "C", Side: "yes", Action: "buy", Price: 41, Quantity: 100}},
+		11: {{Ticker: "C", Side: "yes", Action: "sell", Price: 69, Quantity: 100}},
+	}}
+
+	r := backtest.Run(tickDay(), s, backtest.DefaultConfig())
+
+	// $28 gain, 7% = $1.96
+	if math.Abs(r.TotalFees-1.96) > 1e-9 || math.Abs(r.TotalProfit-26.04) > 1e-9 {
+		t.Errorf("TotalFees = %v, TotalProfit = %v, want 1.96, 26.04", r.TotalFees, r.TotalProfit)
+	}
+}
+
+func TestRun_DeterminedEarly(t *testing.T) {
+	s := &timed{orders: map[int][]strategy.Order{
+		8: {{Ticker: "C", Side: "yes", Action: "buy", Price: 41, Quantity: 10}},
+		// C was determined at 10:30: no exits or new entries after that
+		11: {
+			{Ticker: "C", Side: "yes", Action: "sell", Price: 60, Quantity: 10},
+			{Ticker: "C", Side: "no", Action: "buy", Price: 99, Quantity: 10},
+		},
+		// Other brackets still trade
+		12: {{Ticker: "B", Side: "no", Action: "buy", Price: 61, Quantity: 10}},
+	}}
+	ds := tickDay()
+	day := &ds.Days[0]
+	day.Brackets[2].DeterminedAt = day.Time().Add(10*time.Hour + 30*time.Minute)
+	cfg := backtest.DefaultConfig()
+	cfg.Fees = nil
+
+	r := backtest.Run(ds, s, cfg)
+
+	if r.Rejected != 2 || len(r.Trades) != 2 {
+		t.Fatalf("got %d trades, %d rejected, want 2, 2", len(r.Trades), r.Rejected)
+	}
+	if held := r.Trades[0]; held.Ticker != "C" || !held.Settled || held.ExitPrice != 100 {
+		t.Errorf("C trade = %+v, want held to settlement at 100", held)
+	}
+
+	var quote strategy.Quote
+	for _, q := range s.markets[3].Quotes {
+		if q.Ticker == "C" {
+			quote = q
+		}
+	}
+	if !quote.Determined || quote.YesAsk != 0 || quote.NoAsk != 0 {
+		t.Errorf("C quote at 11:00 = %+v, want determined with no prices", quote)
+	}
+}
+
+// favorite buys the market's favorite bracket once per event
+type favorite struct {
+	data strategy.MarketData
+	seen map[string]bool
+}
+
+func (s *favorite) Name() string                                  { return "favorite" }
+func (s *favorite) OnMarketData(data strategy.MarketData)         { s.data = data }
+func (s *favorite) OnWeatherUpdate(update strategy.WeatherUpdate) {}
+
+func (s *favorite) GenerateOrders(now time.Time) []strategy.Order {
+	if s.seen[s.data.EventTicker] || now.Hour() < 12 {
+		return nil
+	}
+	s.seen[s.data.EventTicker] = true
+	var best strategy.Quote
+	for _, q := range s.data.Quotes {
+		if q.YesAsk > best.YesAsk && q.YesAsk < 90 {
+			best = q
+		}
+	}
+	if best.Ticker == "" {
+		return nil
+	}
+	return []strategy.Order{{Ticker: best.Ticker, Side: "yes", Action: "buy", Price: best.YesAsk, Quantity: 5}}
+}
+
+func TestAppend(t *testing.T) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		t.Fatalf("SyntheticLAXNYC() error = %v", err)
+	}
+	cfg := backtest.DefaultConfig()
+	full := backtest.Run(ds, &favorite{seen: make(map[string]bool)}, cfg)
+
+	r := backtest.Run(ds.Between("2025-08-01", "2025-11-29"), &favorite{seen: make(map[string]bool)}, cfg)
+	if n := backtest.Append(r, ds, &favorite{seen: make(map[string]bool)}, cfg); n != 2 {
+		t.Errorf("Append() = %d days, want 2", n)
+	}
+	if n := backtest.Append(r, ds, &favorite{seen: make(map[string]bool)}, cfg); n != 0 {
+		t.Errorf("Append() again = %d days, want 0", n)
+	}
+
+	if r.Days != full.Days || r.Dates != full.Dates || r.Through != "2025-11-30" || len(r.Trades) != len(full.Trades) {
+		t.Errorf("appended: %d days, %d dates through %s, %d trades; want %d, %d through 2025-11-30, %d",
+			r.Days, r.Dates, r.Through, len(r.Trades), full.Days, full.Dates, len(full.Trades))
+	}
+	if math.Abs(r.TotalProfit-full.TotalProfit) > 1e-9 || r.WinRate != full.WinRate ||
+		math.Abs(r.Sharpe-full.Sharpe) > 1e-9 || math.Abs(r.MaxDrawdown-full.MaxDrawdown) > 1e-9 {
+		t.Errorf("appended profit %.2f, win %.1f%%, Sharpe %.3f, drawdown %.2f; want %.2f, %.1f%%, %.3f, %.2f",
+			r.TotalProfit, r.WinRate, r.Sharpe, r.MaxDrawdown, full.TotalProfit, full.WinRate, full.Sharpe, full.MaxDrawdown)
+	}
+	if d := backtest.Diff(full, r); len(d.Days) != 0 {
+		t.Errorf("Diff(full, appended) = %d diverged days, want 0", len(d.Days))
+	}
+}
+
+func TestResult_Bootstrap(t *testing.T) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		t.Fatalf("SyntheticLAXNYC() error = %v", err)
+	}
+	r := backtest.Run(ds, &favorite{seen: make(map[string]bool)}, backtest.DefaultConfig())
+	sum := r.Bootstrap(stats.DefaultBootstrapConfig())
+
+	if sum.Trades != len(r.Trades) || sum.WinRate.Estimate != r.WinRate ||
+		math.Abs(sum.Sharpe.Estimate-r.Sharpe) > 1e-9 || math.Abs(sum.MaxDrawdown.Estimate-r.MaxDrawdown) > 1e-9 ||
+		math.Abs(sum.EV.Estimate*float64(len(r.Trades))-r.TotalProfit) > 1e-6 {
+		t.Errorf("Bootstrap() = %+v, want the estimates of %d trades, win %.1f%%, Sharpe %.3f, drawdown %.2f, profit %.2f",
+			sum, len(r.Trades), r.WinRate, r.Sharpe, r.MaxDrawdown, r.TotalProfit)
+	}
+	for name, iv := range map[string]stats.Interval{"WinRate": sum.WinRate, "EV": sum.EV, "Sharpe": sum.Sharpe, "MaxDrawdown": sum.MaxDrawdown} {
+		if iv.Lo > iv.Estimate || iv.Hi < iv.Estimate || iv.Lo == iv.Hi {
+			t.Errorf("%s = %v, want an interval around the estimate", name, iv)
+		}
+	}
+}
+
+func TestRun_WithExits(t *testing.T) {
+	entry := map[int][]strategy.Order{8: {{Ticker: "C", Side: "yes", Action: "buy", Price: 41, Quantity: 10}}}
+	tests := []struct {
+		name      string
+		rules     strategy.ExitRules
+		wantPrice int
+		wantHour  int
+		wantExit  string
+	}{
+		// C trades at 70 from 10:30 and at 30 from 13:00
+		{"take-profit", strategy.ExitRules{TakeProfit: 65}, 69, 11, "take_profit exit at 69¢"},
+		{"stop-loss", strategy.ExitRules{StopLoss: 35}, 29, 13, "stop_loss exit at 29¢"},
+		{"flatten", strategy.ExitRules{FlattenHour: 15}, 29, 15, "flatten exit at 29¢"},
+	}
+	for _, tt := range tests {
+		cfg := backtest.DefaultConfig()
+		cfg.Fees = nil
+		r := backtest.Run(tickDay(), strategy.WithExits(&timed{orders: entry}, tt.rules, nil), cfg)
+
+		if len(r.Trades) != 1 {
+			t.Fatalf("%s: got %d trades, want 1", tt.name, len(r.Trades))
+		}
+		tr := r.Trades[0]
+		if tr.Settled || tr.ExitPrice != tt.wantPrice || tr.ExitTime.Hour() != tt.wantHour || tr.ExitReason != tt.wantExit {
+			t.Errorf("%s: trade = %+v, want %q at %d:00", tt.name, tr, tt.wantExit, tt.wantHour)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package backtest_test
+
+import (
+	"fmt"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest/fixtures"
+)
+
+// Replay a strategy, here the always-favorite baseline, over the bundled
+// fixture's LAX days. Any strategy.Strategy runs the same way; see examples/
+// for reference strategies.
+func Example() {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		panic(err)
+	}
+
+	favorite := backtest.Baselines(1)[0]
+	r := backtest.Run(ds.City("LAX"), favorite, backtest.DefaultConfig())
+	fmt.Printf("%s: %d trades, profit $%.2f\n", r.Strategy, len(r.Trades), r.TotalProfit)
+	// Output: Baseline: always favorite: 122 trades, profit $2535.59
+}
@@ -0,0 +1,138 @@
+package backtest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Experiment is a saved backtest run: the strategy, its effective parameters,
+// the dataset and the result, so two runs can be compared trade by trade
+// later.
+type Experiment struct {
+	ID       string
+	Strategy string
+	Params   json.RawMessage // Effective strategy configuration
+	Dataset  string          // Dataset file, or the name of a bundled fixture
+	Config   Config          // Execution settings; the fee schedule is not saved
+	Created  time.Time
+	Result   *Result
+}
+
+// NewExperiment records a run. The ID is derived from the strategy, its
+// parameters, the dataset and the execution settings, so rerunning the same
+// configuration yields the same ID and a parameter change a new one.
+func NewExperiment(strategyName string, params any, dataset string, cfg Config, r *Result) (*Experiment, error) {
+	p, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("marshal params: %w", err)
+	}
+	c, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+
+	h := sha256.New()
+	for _, part := range [][]byte{[]byte(strategyName), p, []byte(dataset), c} {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+	id := fmt.Sprintf("%s-%s", slug(strategyName), hex.EncodeToString(h.Sum(nil))[:8])
+
+	return &Experiment{
+		ID:       id,
+		Strategy: strategyName,
+		Params:   p,
+		Dataset:  dataset,
+		Config:   cfg,
+		Created:  time.Now().UTC(),
+		Result:   r,
+	}, nil
+}
+
+// slug makes a strategy name safe for a file name.
+func slug(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, name)
+}
+
+// Save writes the experiment to dir/<ID>.json.
+func (e *Experiment) Save(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create experiment directory: %w", err)
+	}
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal experiment: %w", err)
+	}
+	path := filepath.Join(dir, e.ID+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", fmt.Errorf("write experiment: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("write experiment: %w", err)
+	}
+	return path, nil
+}
+
+// LoadExperiment reads the experiment with the given ID (or a unique prefix
+// of it) from dir.
+func LoadExperiment(dir, id string) (*Experiment, error) {
+	path := filepath.Join(dir, id+".json")
+	if _, err := os.Stat(path); err != nil {
+		matches, _ := filepath.Glob(filepath.Join(dir, id+"*.json"))
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("experiment %q not found in %s", id, dir)
+		case 1:
+			path = matches[0]
+		default:
+			return nil, fmt.Errorf("experiment %q is ambiguous (%d matches)", id, len(matches))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read experiment: %w", err)
+	}
+	var e Experiment
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("parse experiment %s: %w", path, err)
+	}
+	if e.Result == nil {
+		return nil, fmt.Errorf("experiment %s has no result", e.ID)
+	}
+	return &e, nil
+}
+
+// ListExperiments returns the experiments saved in dir, oldest first.
+func ListExperiments(dir string) ([]*Experiment, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var exps []*Experiment
+	for _, path := range paths {
+		e, err := LoadExperiment(dir, strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		exps = append(exps, e)
+	}
+	sort.Slice(exps, func(i, j int) bool { return exps[i].Created.Before(exps[j].Created) })
+	return exps, nil
+}
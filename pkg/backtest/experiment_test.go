@@ -0,0 +1,54 @@
+package backtest_test
+
+import (
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+func TestExperiment_SaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	ds := testDay()
+	cfg := backtest.DefaultConfig()
+	r := backtest.Run(ds, &scripted{
+		seen:   make(map[string]bool),
+		orders: []strategy.Order{{Ticker: "C", Side: "yes", Action: "buy", Price: 45, Quantity: 10}},
+	}, cfg)
+
+	params := map[string]int{"Margin": 2}
+	exp, err := backtest.NewExperiment("Threshold", params, "fixture", cfg, r)
+	if err != nil {
+		t.Fatalf("NewExperiment() error = %v", err)
+	}
+	again, _ := backtest.NewExperiment("Threshold", params, "fixture", cfg, r)
+	changed, _ := backtest.NewExperiment("Threshold", map[string]int{"Margin": 3}, "fixture", cfg, r)
+	if exp.ID != again.ID || exp.ID == changed.ID {
+		t.Errorf("IDs = %s, %s, %s, want equal for the same params and different otherwise", exp.ID, again.ID, changed.ID)
+	}
+
+	if _, err := exp.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if _, err := changed.Save(dir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := backtest.LoadExperiment(dir, exp.ID[:len("threshold-")+4])
+	if err != nil {
+		t.Fatalf("LoadExperiment(prefix) error = %v", err)
+	}
+	if loaded.ID != exp.ID || len(loaded.Result.Trades) != len(r.Trades) || loaded.Result.TotalProfit != r.TotalProfit {
+		t.Errorf("LoadExperiment() = %s with %d trades, want %s with %d", loaded.ID, len(loaded.Result.Trades), exp.ID, len(r.Trades))
+	}
+	if diff := backtest.Diff(r, loaded.Result); len(diff.Days) != 0 {
+		t.Errorf("Diff(run, loaded) = %d diverged days, want 0", len(diff.Days))
+	}
+
+	if _, err := backtest.LoadExperiment(dir, "threshold-"); err == nil {
+		t.Errorf("LoadExperiment(ambiguous prefix) error = nil, want error")
+	}
+	if exps, err := backtest.ListExperiments(dir); err != nil || len(exps) != 2 {
+		t.Errorf("ListExperiments() = %d, %v, want 2", len(exps), err)
+	}
+}
@@ -0,0 +1,354 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+)
+
+// ExportFormat is a file format Export writes records in.
+type ExportFormat string
+
+// Export formats. Both write one file per table with one record per trade,
+// day or strategy, the layout pandas and DuckDB read directly.
+const (
+	FormatCSV  ExportFormat = "csv"  // With a header row
+	FormatJSON ExportFormat = "json" // An array of objects
+)
+
+// ParseExportFormats parses a comma-separated list of formats, e.g.
+// "csv,json".
+func ParseExportFormats(s string) ([]ExportFormat, error) {
+	var formats []ExportFormat
+	for _, f := range strings.Split(s, ",") {
+		switch format := ExportFormat(strings.ToLower(strings.TrimSpace(f))); format {
+		case FormatCSV, FormatJSON:
+			formats = append(formats, format)
+		case "parquet":
+			return nil, fmt.Errorf("parquet is not supported: export csv and convert it, e.g. with DuckDB's COPY ... (FORMAT parquet)")
+		default:
+			return nil, fmt.Errorf("unknown export format %q (want csv or json)", f)
+		}
+	}
+	return formats, nil
+}
+
+// TradeRecord is one trade of an exported result.
+type TradeRecord struct {
+	Run         string    `json:"run"`
+	Strategy    string    `json:"strategy"`
+	City        string    `json:"city"`
+	Date        string    `json:"date"`
+	EventTicker string    `json:"event_ticker"`
+	Ticker      string    `json:"ticker"`
+	Side        string    `json:"side"`
+	EntryTime   time.Time `json:"entry_time"`
+	Price       int       `json:"price"` // Cents
+	Quantity    int       `json:"quantity"`
+	Cost        float64   `json:"cost"` // Dollars
+	Liquidity   string    `json:"liquidity"`
+	ExitTime    time.Time `json:"exit_time"`
+	ExitPrice   int       `json:"exit_price"` // Cents; 100 or 0 when settled
+	Settled     bool      `json:"settled"`
+	Won         bool      `json:"won"`
+	Fees        float64   `json:"fees"`
+	Profit      float64   `json:"profit"` // Net of fees
+	HoursHeld   float64   `json:"hours_held"`
+	Reason      string    `json:"reason"`
+	ExitReason  string    `json:"exit_reason"`
+}
+
+// DayRecord is one traded date of an exported result, summed across cities.
+type DayRecord struct {
+	Run        string  `json:"run"`
+	Strategy   string  `json:"strategy"`
+	Date       string  `json:"date"`
+	Trades     int     `json:"trades"`
+	Wins       int     `json:"wins"`
+	Cost       float64 `json:"cost"`
+	Fees       float64 `json:"fees"`
+	Profit     float64 `json:"profit"`
+	Cumulative float64 `json:"cumulative"` // Profit through the date
+	Drawdown   float64 `json:"drawdown"`   // Below the cumulative peak so far
+}
+
+// SummaryRecord is the metrics of an exported result or one of its
+// baselines, with the 95% bootstrap intervals of Result.Bootstrap.
+type SummaryRecord struct {
+	Run           string  `json:"run"`
+	Strategy      string  `json:"strategy"`
+	Baseline      bool    `json:"baseline"`
+	Days          int     `json:"days"`
+	Through       string  `json:"through"`
+	Trades        int     `json:"trades"`
+	Rejected      int     `json:"rejected"`
+	Profit        float64 `json:"profit"`
+	Fees          float64 `json:"fees"`
+	ROI           float64 `json:"roi"`             // Profit per dollar staked
+	PerDollarHour float64 `json:"per_dollar_hour"` // Profit per dollar-hour deployed
+	WinRate       float64 `json:"win_rate"`        // Percent
+	WinRateLo     float64 `json:"win_rate_lo"`
+	WinRateHi     float64 `json:"win_rate_hi"`
+	EV            float64 `json:"ev"` // Mean profit per trade
+	EVLo          float64 `json:"ev_lo"`
+	EVHi          float64 `json:"ev_hi"`
+	Sharpe        float64 `json:"sharpe"`
+	SharpeLo      float64 `json:"sharpe_lo"`
+	SharpeHi      float64 `json:"sharpe_hi"`
+	MaxDrawdown   float64 `json:"max_drawdown"`
+	MaxDrawdownLo float64 `json:"max_drawdown_lo"`
+	MaxDrawdownHi float64 `json:"max_drawdown_hi"`
+}
+
+// Records is a result flattened into tables for export.
+type Records struct {
+	Trades  []TradeRecord
+	Days    []DayRecord
+	Summary []SummaryRecord // The result's, then each baseline's
+}
+
+// Records flattens the result into trade, day and summary tables, each
+// record labelled with run so the tables of several runs can be
+// concatenated and compared.
+func (r *Result) Records(run string) Records {
+	var rec Records
+	for _, t := range r.Trades {
+		rec.Trades = append(rec.Trades, TradeRecord{
+			Run:         run,
+			Strategy:    r.Strategy,
+			City:        t.City,
+			Date:        t.Date,
+			EventTicker: t.EventTicker,
+			Ticker:      t.Ticker,
+			Side:        t.Side,
+			EntryTime:   t.Time,
+			Price:       t.Price,
+			Quantity:    t.Quantity,
+			Cost:        t.Cost(),
+			Liquidity:   string(t.Liquidity),
+			ExitTime:    t.ExitTime,
+			ExitPrice:   t.ExitPrice,
+			Settled:     t.Settled,
+			Won:         t.Won,
+			Fees:        t.Fees,
+			Profit:      t.Profit,
+			HoursHeld:   t.ExitTime.Sub(t.Time).Hours(),
+			Reason:      t.Reason,
+			ExitReason:  t.ExitReason,
+		})
+	}
+
+	byDate := make(map[string]*DayRecord)
+	for _, t := range r.Trades {
+		d, ok := byDate[t.Date]
+		if !ok {
+			d = &DayRecord{Run: run, Strategy: r.Strategy, Date: t.Date}
+			byDate[t.Date] = d
+		}
+		d.Trades++
+		if t.Won {
+			d.Wins++
+		}
+		d.Cost += t.Cost()
+		d.Fees += t.Fees
+	}
+	cumulative, peak := 0.0, 0.0
+	for _, date := range r.TradedDays() {
+		d, ok := byDate[date]
+		if !ok {
+			d = &DayRecord{Run: run, Strategy: r.Strategy, Date: date}
+		}
+		d.Profit = r.DailyPnL[date]
+		cumulative += d.Profit
+		peak = max(peak, cumulative)
+		d.Cumulative, d.Drawdown = cumulative, peak-cumulative
+		rec.Days = append(rec.Days, *d)
+	}
+
+	rec.Summary = append(rec.Summary, r.summary(run, false))
+	for _, b := range r.Baselines {
+		rec.Summary = append(rec.Summary, b.summary(run, true))
+	}
+	return rec
+}
+
+// Add appends another run's records to rec, so one export compares them.
+func (rec *Records) Add(other Records) {
+	rec.Trades = append(rec.Trades, other.Trades...)
+	rec.Days = append(rec.Days, other.Days...)
+	rec.Summary = append(rec.Summary, other.Summary...)
+}
+
+// summary returns the result's metrics as a summary record.
+func (r *Result) summary(run string, baseline bool) SummaryRecord {
+	sum := r.Bootstrap(stats.DefaultBootstrapConfig())
+	return SummaryRecord{
+		Run:           run,
+		Strategy:      r.Strategy,
+		Baseline:      baseline,
+		Days:          r.Days,
+		Through:       r.Through,
+		Trades:        len(r.Trades),
+		Rejected:      r.Rejected,
+		Profit:        r.TotalProfit,
+		Fees:          r.TotalFees,
+		ROI:           r.ROI(),
+		PerDollarHour: r.ReturnPerDollarHour(),
+		WinRate:       r.WinRate,
+		WinRateLo:     sum.WinRate.Lo,
+		WinRateHi:     sum.WinRate.Hi,
+		EV:            sum.EV.Estimate,
+		EVLo:          sum.EV.Lo,
+		EVHi:          sum.EV.Hi,
+		Sharpe:        r.Sharpe,
+		SharpeLo:      sum.Sharpe.Lo,
+		SharpeHi:      sum.Sharpe.Hi,
+		MaxDrawdown:   r.MaxDrawdown,
+		MaxDrawdownLo: sum.MaxDrawdown.Lo,
+		MaxDrawdownHi: sum.MaxDrawdown.Hi,
+	}
+}
+
+// Export writes the result's records to dir, labelled with run: see
+// Records.Export.
+func (r *Result) Export(dir, run string, formats ...ExportFormat) ([]string, error) {
+	return r.Records(run).Export(dir, formats...)
+}
+
+// Export writes the tables to dir as trades, days and summary files in each
+// format (CSV when none is given), replacing earlier exports, and returns
+// the paths written.
+func (rec Records) Export(dir string, formats ...ExportFormat) ([]string, error) {
+	if len(formats) == 0 {
+		formats = []ExportFormat{FormatCSV}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create export directory: %w", err)
+	}
+
+	tables := []struct {
+		name   string
+		header []string
+		rows   [][]string
+		value  any
+	}{
+		{"trades", tradeHeader, tradeRows(rec.Trades), nonNil(rec.Trades)},
+		{"days", dayHeader, dayRows(rec.Days), nonNil(rec.Days)},
+		{"summary", summaryHeader, summaryRows(rec.Summary), nonNil(rec.Summary)},
+	}
+	var paths []string
+	for _, format := range formats {
+		for _, t := range tables {
+			path := filepath.Join(dir, t.name+"."+string(format))
+			var err error
+			switch format {
+			case FormatCSV:
+				err = writeCSV(path, t.header, t.rows)
+			case FormatJSON:
+				err = writeJSON(path, t.value)
+			default:
+				err = fmt.Errorf("unknown export format %q", format)
+			}
+			if err != nil {
+				return paths, fmt.Errorf("export %s: %w", path, err)
+			}
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// nonNil returns an empty slice for nil, so an empty table exports as [].
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+var (
+	tradeHeader = []string{"run", "strategy", "city", "date", "event_ticker", "ticker", "side", "entry_time",
+		"price", "quantity", "cost", "liquidity", "exit_time", "exit_price", "settled", "won", "fees", "profit",
+		"hours_held", "reason", "exit_reason"}
+	dayHeader     = []string{"run", "strategy", "date", "trades", "wins", "cost", "fees", "profit", "cumulative", "drawdown"}
+	summaryHeader = []string{"run", "strategy", "baseline", "days", "through", "trades", "rejected", "profit", "fees",
+		"roi", "per_dollar_hour", "win_rate", "win_rate_lo", "win_rate_hi", "ev", "ev_lo", "ev_hi",
+		"sharpe", "sharpe_lo", "sharpe_hi", "max_drawdown", "max_drawdown_lo", "max_drawdown_hi"}
+)
+
+func tradeRows(trades []TradeRecord) [][]string {
+	rows := make([][]string, 0, len(trades))
+	for _, t := range trades {
+		rows = append(rows, []string{t.Run, t.Strategy, t.City, t.Date, t.EventTicker, t.Ticker, t.Side,
+			timestamp(t.EntryTime), strconv.Itoa(t.Price), strconv.Itoa(t.Quantity), number(t.Cost), t.Liquidity,
+			timestamp(t.ExitTime), strconv.Itoa(t.ExitPrice), strconv.FormatBool(t.Settled), strconv.FormatBool(t.Won),
+			number(t.Fees), number(t.Profit), number(t.HoursHeld), t.Reason, t.ExitReason})
+	}
+	return rows
+}
+
+func dayRows(days []DayRecord) [][]string {
+	rows := make([][]string, 0, len(days))
+	for _, d := range days {
+		rows = append(rows, []string{d.Run, d.Strategy, d.Date, strconv.Itoa(d.Trades), strconv.Itoa(d.Wins),
+			number(d.Cost), number(d.Fees), number(d.Profit), number(d.Cumulative), number(d.Drawdown)})
+	}
+	return rows
+}
+
+func summaryRows(summary []SummaryRecord) [][]string {
+	rows := make([][]string, 0, len(summary))
+	for _, s := range summary {
+		rows = append(rows, []string{s.Run, s.Strategy, strconv.FormatBool(s.Baseline), strconv.Itoa(s.Days), s.Through,
+			strconv.Itoa(s.Trades), strconv.Itoa(s.Rejected), number(s.Profit), number(s.Fees),
+			number(s.ROI), number(s.PerDollarHour), number(s.WinRate), number(s.WinRateLo), number(s.WinRateHi),
+			number(s.EV), number(s.EVLo), number(s.EVHi), number(s.Sharpe), number(s.SharpeLo), number(s.SharpeHi),
+			number(s.MaxDrawdown), number(s.MaxDrawdownLo), number(s.MaxDrawdownHi)})
+	}
+	return rows
+}
+
+// number formats v to at most six decimals, without float noise such as
+// 0.30000000000000004.
+func number(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+}
+
+// timestamp formats t as RFC 3339, or empty for the zero time.
+func timestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func writeCSV(path string, header []string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	w.Write(header)
+	w.WriteAll(rows)
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
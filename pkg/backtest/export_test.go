@@ -0,0 +1,88 @@
+package backtest_test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest/fixtures"
+)
+
+func TestResult_Export(t *testing.T) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		t.Fatalf("SyntheticLAXNYC() error = %v", err)
+	}
+	r := backtest.Run(ds, &favorite{seen: make(map[string]bool)}, backtest.DefaultConfig())
+	dir := t.TempDir()
+	paths, err := r.Export(dir, "run-1", backtest.FormatCSV, backtest.FormatJSON)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(paths) != 6 {
+		t.Errorf("Export() wrote %v, want 3 tables in 2 formats", paths)
+	}
+
+	f, err := os.Open(filepath.Join(dir, "trades.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("trades.csv: %v", err)
+	}
+	if len(rows) != len(r.Trades)+1 || rows[0][0] != "run" || rows[1][0] != "run-1" || rows[1][3] != r.Trades[0].Date {
+		t.Errorf("trades.csv = %d rows starting %v, want a header and %d trades of run-1", len(rows), rows[:min(len(rows), 2)], len(r.Trades))
+	}
+
+	var days []backtest.DayRecord
+	data, err := os.ReadFile(filepath.Join(dir, "days.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &days); err != nil {
+		t.Fatalf("days.json: %v", err)
+	}
+	total, drawdown := 0.0, 0.0
+	for _, d := range days {
+		total += d.Profit
+		drawdown = max(drawdown, d.Drawdown)
+	}
+	if len(days) != len(r.TradedDays()) || math.Abs(total-r.TotalProfit) > 1e-6 ||
+		math.Abs(days[len(days)-1].Cumulative-r.TotalProfit) > 1e-6 || math.Abs(drawdown-r.MaxDrawdown) > 1e-6 {
+		t.Errorf("days.json: %d days, profit %.2f, drawdown %.2f; want %d, %.2f, %.2f",
+			len(days), total, drawdown, len(r.TradedDays()), r.TotalProfit, r.MaxDrawdown)
+	}
+
+	var summary []backtest.SummaryRecord
+	data, err = os.ReadFile(filepath.Join(dir, "summary.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("summary.json: %v", err)
+	}
+	if len(summary) != 1+len(r.Baselines) || summary[0].Baseline || !summary[1].Baseline || summary[0].Trades != len(r.Trades) {
+		t.Errorf("summary.json = %+v, want the result then %d baselines", summary, len(r.Baselines))
+	}
+	if s := summary[0]; s.WinRateLo > s.WinRate || s.WinRateHi < s.WinRate {
+		t.Errorf("win rate %.1f%% outside its interval %.1f%% to %.1f%%", s.WinRate, s.WinRateLo, s.WinRateHi)
+	}
+}
+
+func TestParseExportFormats(t *testing.T) {
+	formats, err := backtest.ParseExportFormats("csv, JSON")
+	if err != nil || len(formats) != 2 || formats[0] != backtest.FormatCSV || formats[1] != backtest.FormatJSON {
+		t.Errorf("ParseExportFormats(csv, JSON) = %v, %v", formats, err)
+	}
+	for _, s := range []string{"parquet", "xml", ""} {
+		if _, err := backtest.ParseExportFormats(s); err == nil {
+			t.Errorf("ParseExportFormats(%q) succeeded, want an error", s)
+		}
+	}
+}
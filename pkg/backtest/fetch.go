@@ -0,0 +1,62 @@
+package backtest
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultFetchWorkers is how many fetches Fetch runs at once by default:
+// enough to keep the Basic tier's 20 reads a second busy through request
+// latency, few enough that the workers don't queue long on the limiter.
+const DefaultFetchWorkers = 8
+
+// FetchConfig configures Fetch.
+type FetchConfig struct {
+	Workers int // Fetches at once (0 = DefaultFetchWorkers)
+
+	// Progress, if set, is called as each fetch finishes with the number
+	// finished so far, one call at a time.
+	Progress func(done, total int)
+}
+
+// Fetch calls fetch for every input on a bounded pool of workers and
+// returns the results and errors in input order, so a dataset is collected
+// the same however many workers fetch it. Once ctx is done, the inputs not
+// yet started fail with its error.
+//
+// Fetch bounds how many requests are in flight, not their rate: the workers
+// should share one client built with rest.WithRateLimit, whose limiter
+// paces them all, instead of sleeping between calls.
+func Fetch[In, Out any](ctx context.Context, inputs []In, cfg FetchConfig, fetch func(context.Context, In) (Out, error)) ([]Out, []error) {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = DefaultFetchWorkers
+	}
+
+	type result struct {
+		out Out
+		err error
+	}
+	var mu sync.Mutex
+	done := 0
+	results := Parallel(inputs, workers, func(in In) result {
+		var r result
+		if r.err = ctx.Err(); r.err == nil {
+			r.out, r.err = fetch(ctx, in)
+		}
+		if cfg.Progress != nil {
+			mu.Lock()
+			done++
+			cfg.Progress(done, len(inputs))
+			mu.Unlock()
+		}
+		return r
+	})
+
+	outs := make([]Out, len(results))
+	errs := make([]error, len(results))
+	for i, r := range results {
+		outs[i], errs[i] = r.out, r.err
+	}
+	return outs, errs
+}
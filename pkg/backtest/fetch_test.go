@@ -0,0 +1,68 @@
+package backtest_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+func TestFetch(t *testing.T) {
+	in := make([]int, 40)
+	for i := range in {
+		in[i] = i
+	}
+	var inFlight, peak atomic.Int32
+	var calls []int
+	cfg := backtest.FetchConfig{
+		Workers:  4,
+		Progress: func(done, total int) { calls = append(calls, done) },
+	}
+	errOdd := errors.New("odd")
+	out, errs := backtest.Fetch(context.Background(), in, cfg, func(_ context.Context, n int) (int, error) {
+		now := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if now <= p || peak.CompareAndSwap(p, now) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		if n%2 == 1 {
+			return 0, errOdd
+		}
+		return n * n, nil
+	})
+
+	for i := range in {
+		switch {
+		case i%2 == 1 && errs[i] != errOdd:
+			t.Errorf("errs[%d] = %v, want %v", i, errs[i], errOdd)
+		case i%2 == 0 && (errs[i] != nil || out[i] != i*i):
+			t.Errorf("out[%d], errs[%d] = %d, %v, want %d", i, i, out[i], errs[i], i*i)
+		}
+	}
+	if p := peak.Load(); p > 4 {
+		t.Errorf("%d fetches at once, want at most 4", p)
+	}
+	if len(calls) != len(in) || calls[len(calls)-1] != len(in) {
+		t.Errorf("Progress called %d times ending at %v, want %d", len(calls), calls, len(in))
+	}
+}
+
+func TestFetch_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	fetched := 0
+	_, errs := backtest.Fetch(ctx, []string{"a", "b"}, backtest.FetchConfig{Workers: 1}, func(context.Context, string) (string, error) {
+		fetched++
+		return "", nil
+	})
+	if fetched != 0 || !errors.Is(errs[0], context.Canceled) || !errors.Is(errs[1], context.Canceled) {
+		t.Errorf("cancelled Fetch ran %d fetches, errs %v, want none and context.Canceled", fetched, errs)
+	}
+}
@@ -0,0 +1,23 @@
+// Package fixtures embeds the synthetic backtest dataset used by the
+// backtest engine's tests and the example strategies, so they run without
+// scraping the APIs. It is a test fixture only: it is generated, not
+// recorded (see README.md), and results on it say nothing about live
+// profitability. Backtest real history exported with cmd/backtest-fixtures,
+// which also regenerates this file.
+package fixtures
+
+import (
+	"bytes"
+	_ "embed"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+//go:embed lax_nyc.json.gz
+var laxNYC []byte
+
+// SyntheticLAXNYC returns generated LAX and NYC HIGH temperature days for
+// 2025-08-01 through 2025-11-30.
+func SyntheticLAXNYC() (*backtest.Dataset, error) {
+	return backtest.Read(bytes.NewReader(laxNYC))
+}
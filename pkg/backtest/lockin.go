@@ -0,0 +1,92 @@
+package backtest
+
+import (
+	"sort"
+
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// LockIn verifies the threshold "lock-in" rule for one station: once the
+// running METAR max has passed a bracket's cap by a margin, the official (CLI)
+// high is assumed to be above the cap too, so the bracket is treated as a
+// certain loser. The CLI high can differ from the METAR max, so the rule is a
+// heuristic; LockIn counts how often it actually held.
+type LockIn struct {
+	City      string
+	Days      int      // Days in the dataset
+	Crossings int      // Days the METAR max passed at least one bracket's cap by the margin
+	Failures  int      // Crossing days on which a passed bracket still settled YES
+	Failed    []string // Dates of the failures
+}
+
+// Probability returns the estimated probability that the lock holds, with a
+// Laplace prior so a short clean history doesn't claim certainty. It is 0
+// when there were no crossings.
+func (l LockIn) Probability() float64 {
+	if l.Crossings == 0 {
+		return 0
+	}
+	return float64(l.Crossings-l.Failures+1) / float64(l.Crossings+2)
+}
+
+// VerifyLockIns replays the lock-in rule with the given margin (degrees the
+// METAR max must exceed a cap) over every day of the dataset and returns the
+// outcome per station, ordered by city. Only the highest passed cap of a day
+// is at risk of failing, so each day counts once. The rule is about highs:
+// LOW days are skipped.
+func VerifyLockIns(ds *Dataset, margin int) []LockIn {
+	byCity := make(map[string]*LockIn)
+	for i := range ds.Days {
+		day := &ds.Days[i]
+		if day.MarketType() == weather.MarketTypeLow {
+			continue
+		}
+		l, ok := byCity[day.City]
+		if !ok {
+			l = &LockIn{City: day.City}
+			byCity[day.City] = l
+		}
+		l.Days++
+
+		if len(day.METAR) == 0 {
+			continue
+		}
+		metarMax := day.METARMax()
+		crossed, failed := false, false
+		for _, b := range day.Brackets {
+			if b.Cap == OpenCap || metarMax < b.Cap+margin {
+				continue
+			}
+			crossed = true
+			if b.Result == "yes" {
+				failed = true
+			}
+		}
+		if crossed {
+			l.Crossings++
+		}
+		if failed {
+			l.Failures++
+			l.Failed = append(l.Failed, day.Date)
+		}
+	}
+
+	out := make([]LockIn, 0, len(byCity))
+	for _, l := range byCity {
+		out = append(out, *l)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].City < out[j].City })
+	return out
+}
+
+// LockProbabilities maps each station with at least one crossing to its
+// verified lock probability.
+func LockProbabilities(lockIns []LockIn) map[string]float64 {
+	probs := make(map[string]float64)
+	for _, l := range lockIns {
+		if l.Crossings > 0 {
+			probs[l.City] = l.Probability()
+		}
+	}
+	return probs
+}
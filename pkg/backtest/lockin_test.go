@@ -0,0 +1,50 @@
+package backtest_test
+
+import (
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+func TestVerifyLockIns(t *testing.T) {
+	ds := testDay()
+
+	// A second day where the METAR max passed bracket A by the margin but the
+	// official high still settled inside it
+	day := ds.Days[0]
+	day.Date, day.Settlement = "2025-12-06", 61
+	day.Brackets = append([]backtest.Bracket(nil), day.Brackets...)
+	day.Brackets[0].Result, day.Brackets[2].Result = "yes", "no"
+	ds.Days = append(ds.Days, day)
+
+	// And a NYC day the METAR never got past a cap
+	nyc := ds.Days[0]
+	nyc.City = "NYC"
+	nyc.Brackets = []backtest.Bracket{{Ticker: "N", Floor: backtest.OpenFloor, Cap: 70, Result: "yes"}}
+	ds.Days = append(ds.Days, nyc)
+
+	got := backtest.VerifyLockIns(ds, 2)
+	if len(got) != 2 || got[0].City != "LAX" || got[1].City != "NYC" {
+		t.Fatalf("VerifyLockIns() = %+v, want LAX and NYC", got)
+	}
+	lax, nycLock := got[0], got[1]
+	if lax.Days != 2 || lax.Crossings != 2 || lax.Failures != 1 || len(lax.Failed) != 1 || lax.Failed[0] != "2025-12-06" {
+		t.Errorf("LAX = %+v, want 2 crossings, 1 failure on 2025-12-06", lax)
+	}
+	if p := lax.Probability(); p != 0.5 {
+		t.Errorf("LAX Probability() = %v, want 0.5", p)
+	}
+	if nycLock.Crossings != 0 || nycLock.Probability() != 0 {
+		t.Errorf("NYC = %+v, want no crossings", nycLock)
+	}
+
+	probs := backtest.LockProbabilities(got)
+	if _, ok := probs["NYC"]; ok || probs["LAX"] != 0.5 {
+		t.Errorf("LockProbabilities() = %v, want only LAX at 0.5", probs)
+	}
+
+	// A wider margin leaves nothing crossed
+	if got := backtest.VerifyLockIns(ds, 4); got[0].Crossings != 0 {
+		t.Errorf("margin 4: LAX crossings = %d, want 0", got[0].Crossings)
+	}
+}
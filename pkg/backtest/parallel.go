@@ -0,0 +1,38 @@
+package backtest
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Parallel evaluates eval on every input across workers goroutines (0 or
+// less means one per CPU) and returns the results in input order, so a
+// parameter sweep gives the same output however many cores it runs on.
+//
+// eval must not modify shared state: datasets, fee schedules and other
+// inputs are read concurrently, and each call should build its own strategy.
+func Parallel[In, Out any](inputs []In, workers int, eval func(In) Out) []Out {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(inputs))
+
+	out := make([]Out, len(inputs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				out[i] = eval(inputs[i])
+			}
+		}()
+	}
+	for i := range inputs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return out
+}
@@ -0,0 +1,46 @@
+package backtest_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+func TestParallel(t *testing.T) {
+	in := make([]int, 1000)
+	for i := range in {
+		in[i] = i
+	}
+	for _, workers := range []int{0, 1, 7, 5000} {
+		out := backtest.Parallel(in, workers, func(n int) int { return n * n })
+		for i, v := range out {
+			if v != i*i {
+				t.Fatalf("workers=%d: out[%d] = %d, want %d", workers, i, v, i*i)
+			}
+		}
+	}
+	if out := backtest.Parallel(nil, 4, func(n int) int { return n }); len(out) != 0 {
+		t.Errorf("Parallel(nil) = %v, want empty", out)
+	}
+}
+
+func TestRobustness_Workers(t *testing.T) {
+	newStrategy := func() strategy.Strategy {
+		return &scripted{
+			seen:   make(map[string]bool),
+			orders: []strategy.Order{{Ticker: "C", Side: "yes", Action: "buy", Price: 45, Quantity: 10}},
+		}
+	}
+	rc := backtest.RobustnessConfig{Runs: 50, Seed: 7, Perturbation: backtest.DefaultPerturbation()}
+
+	rc.Workers = 1
+	serial := backtest.Robustness(testDay(), newStrategy, backtest.DefaultConfig(), rc)
+	rc.Workers = 8
+	parallel := backtest.Robustness(testDay(), newStrategy, backtest.DefaultConfig(), rc)
+
+	if !slices.Equal(serial.Profits, parallel.Profits) {
+		t.Errorf("profits with 8 workers differ from 1:\n%v\n%v", parallel.Profits, serial.Profits)
+	}
+}
@@ -0,0 +1,132 @@
+package backtest
+
+import (
+	"sort"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+// PriceSeries reconstructs a market's YES price at any minute from its trade
+// prints, so a backtest can enter and exit at a strategy's actual decision
+// times rather than at a few sampled hours.
+type PriceSeries struct {
+	ticks []Tick // In time order
+}
+
+// NewPriceSeries returns the series of ticks, which need not be in time
+// order.
+func NewPriceSeries(ticks []Tick) PriceSeries {
+	sorted := append([]Tick(nil), ticks...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+	return PriceSeries{ticks: sorted}
+}
+
+// TicksFromTrades converts trades from the trades endpoint (rest.GetTrades,
+// newest first) to ticks in time order.
+func TicksFromTrades(trades []rest.Trade) []Tick {
+	ticks := make([]Tick, 0, len(trades))
+	for _, t := range trades {
+		ticks = append(ticks, Tick{Time: t.CreatedTime, YesPrice: t.YesPrice, Count: t.Count})
+	}
+	sort.SliceStable(ticks, func(i, j int) bool { return ticks[i].Time.Before(ticks[j].Time) })
+	return ticks
+}
+
+// Prices returns the bracket's trade prints as a series. Datasets loaded by
+// Load keep only the prints that change the price, with the contracts of
+// repeats added to the print they repeat, so a VWAP over one is exact but
+// the volume of a window may be counted at an earlier print.
+func (b Bracket) Prices() PriceSeries {
+	return PriceSeries{ticks: b.Ticks}
+}
+
+// PriceSnapshot is a market's reconstructed price at a time, over the
+// window of trades ending then.
+type PriceSnapshot struct {
+	Time   time.Time
+	Last   int // Price of the last trade at or before Time (0 = none yet)
+	VWAP   int // Volume-weighted average price of the window (0 = no trades)
+	Low    int // Lowest price traded in the window: the best a YES buyer saw
+	High   int // Highest price traded in the window: the best a YES seller saw
+	Trades int // Prints in the window
+	Volume int // Contracts traded in the window
+}
+
+// Traded reports whether the window had a trade.
+func (p PriceSnapshot) Traded() bool {
+	return p.Trades > 0
+}
+
+// At returns the price at t, with the window of trades in (t-window, t].
+// Prints without a count weigh one contract in the VWAP.
+func (s PriceSeries) At(t time.Time, window time.Duration) PriceSnapshot {
+	p := PriceSnapshot{Time: t}
+	from := t.Add(-window)
+	var notional, weight int
+	for _, tick := range s.ticks {
+		if tick.Time.After(t) {
+			break
+		}
+		p.Last = tick.YesPrice
+		if !tick.Time.After(from) {
+			continue
+		}
+		if p.Trades == 0 || tick.YesPrice < p.Low {
+			p.Low = tick.YesPrice
+		}
+		if tick.YesPrice > p.High {
+			p.High = tick.YesPrice
+		}
+		p.Trades++
+		p.Volume += tick.Count
+		w := max(tick.Count, 1)
+		notional += w * tick.YesPrice
+		weight += w
+	}
+	if weight > 0 {
+		p.VWAP = (notional + weight/2) / weight
+	}
+	return p
+}
+
+// Last returns the price of the last trade at or before t; ok is false
+// before the first trade.
+func (s PriceSeries) Last(t time.Time) (price int, ok bool) {
+	p := s.At(t, 0)
+	return p.Last, p.Last > 0
+}
+
+// Minutes returns the price at each minute from from to to, inclusive, with
+// the window of trades ending at each.
+func (s PriceSeries) Minutes(from, to time.Time, window time.Duration) []PriceSnapshot {
+	var snapshots []PriceSnapshot
+	for t := from.Truncate(time.Minute); !t.After(to); t = t.Add(time.Minute) {
+		snapshots = append(snapshots, s.At(t, window))
+	}
+	return snapshots
+}
+
+// PriceMethod picks the price an entry or exit is filled at from a
+// snapshot.
+type PriceMethod string
+
+const (
+	PriceLast PriceMethod = "last" // Last trade at or before the decision
+	PriceVWAP PriceMethod = "vwap" // VWAP of the window, else the last trade
+	PriceBest PriceMethod = "best" // Best price seen in the window for the side, else the last trade
+)
+
+// Price returns the YES price a buyer (buy = true) or seller fills at by
+// method, or 0 if the market hadn't traded.
+func (p PriceSnapshot) Price(method PriceMethod, buy bool) int {
+	switch {
+	case method == PriceVWAP && p.Traded():
+		return p.VWAP
+	case method == PriceBest && p.Traded() && buy:
+		return p.Low
+	case method == PriceBest && p.Traded():
+		return p.High
+	}
+	return p.Last
+}
@@ -0,0 +1,88 @@
+package backtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+func TestPriceSeries_At(t *testing.T) {
+	base := time.Date(2025, 12, 5, 10, 0, 0, 0, time.UTC)
+	at := func(minute int) time.Time { return base.Add(time.Duration(minute) * time.Minute) }
+	s := backtest.NewPriceSeries([]backtest.Tick{
+		{Time: at(12), YesPrice: 44, Count: 30},
+		{Time: at(2), YesPrice: 40, Count: 10},
+		{Time: at(7), YesPrice: 38, Count: 10},
+		{Time: at(14), YesPrice: 46}, // Unknown count
+	})
+
+	if p := s.At(at(1), 15*time.Minute); p.Last != 0 || p.Traded() {
+		t.Errorf("before the first trade = %+v, want no price", p)
+	}
+
+	tests := []struct {
+		minute int
+		window time.Duration
+		want   backtest.PriceSnapshot
+	}{
+		{5, 0, backtest.PriceSnapshot{Last: 40}},
+		{7, 0, backtest.PriceSnapshot{Last: 38}},
+		{10, 10 * time.Minute, backtest.PriceSnapshot{Last: 38, VWAP: 39, Low: 38, High: 40, Trades: 2, Volume: 20}},
+		// (40*10 + 38*10 + 44*30 + 46*1) / 51 = 42.1
+		{15, 15 * time.Minute, backtest.PriceSnapshot{Last: 46, VWAP: 42, Low: 38, High: 46, Trades: 4, Volume: 50}},
+		// The window excludes its start
+		{12, 5 * time.Minute, backtest.PriceSnapshot{Last: 44, VWAP: 44, Low: 44, High: 44, Trades: 1, Volume: 30}},
+		{40, 10 * time.Minute, backtest.PriceSnapshot{Last: 46}},
+	}
+	for _, tt := range tests {
+		tt.want.Time = at(tt.minute)
+		if got := s.At(at(tt.minute), tt.window); got != tt.want {
+			t.Errorf("At(:%02d, %s) = %+v, want %+v", tt.minute, tt.window, got, tt.want)
+		}
+	}
+
+	if got := s.Minutes(at(6), at(8), 0); len(got) != 3 || got[0].Last != 40 || got[1].Last != 38 || got[2].Last != 38 {
+		t.Errorf("Minutes(:06, :08) = %+v, want 40, 38, 38", got)
+	}
+}
+
+func TestPriceSnapshot_Price(t *testing.T) {
+	traded := backtest.PriceSnapshot{Last: 46, VWAP: 43, Low: 38, High: 46, Trades: 4}
+	quiet := backtest.PriceSnapshot{Last: 46}
+	tests := []struct {
+		p      backtest.PriceSnapshot
+		method backtest.PriceMethod
+		buy    bool
+		want   int
+	}{
+		{traded, backtest.PriceLast, true, 46},
+		{traded, backtest.PriceVWAP, true, 43},
+		{traded, backtest.PriceBest, true, 38},
+		{traded, backtest.PriceBest, false, 46},
+		{quiet, backtest.PriceVWAP, true, 46},
+		{quiet, backtest.PriceBest, true, 46},
+		{backtest.PriceSnapshot{}, backtest.PriceVWAP, true, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Price(tt.method, tt.buy); got != tt.want {
+			t.Errorf("%+v.Price(%s, buy=%v) = %d, want %d", tt.p, tt.method, tt.buy, got, tt.want)
+		}
+	}
+}
+
+func TestTicksFromTrades(t *testing.T) {
+	base := time.Date(2025, 12, 5, 18, 0, 0, 0, time.UTC)
+	trades := []rest.Trade{ // Newest first, as the endpoint returns them
+		{YesPrice: 52, Count: 3, CreatedTime: base.Add(2 * time.Minute)},
+		{YesPrice: 50, Count: 5, CreatedTime: base},
+	}
+	ticks := backtest.TicksFromTrades(trades)
+	if len(ticks) != 2 || ticks[0].YesPrice != 50 || ticks[1].YesPrice != 52 || ticks[1].Count != 3 {
+		t.Fatalf("TicksFromTrades = %+v, want 50 then 52", ticks)
+	}
+	if got, ok := backtest.NewPriceSeries(ticks).Last(base.Add(time.Minute)); !ok || got != 50 {
+		t.Errorf("Last(18:01) = %d, %v, want 50", got, ok)
+	}
+}
@@ -0,0 +1,134 @@
+package backtest
+
+import (
+	"math/rand/v2"
+	"sort"
+
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// Perturbation is the data and execution noise applied to each run of a
+// robustness test. Offsets are drawn uniformly per day (temperature) or per
+// bracket (price); settlements are never changed.
+type Perturbation struct {
+	TempF      int     // Shift each day's METAR readings by up to ±TempF whole degrees
+	PriceCents int     // Shift each bracket's prices by up to ±PriceCents
+	MissFill   float64 // Probability an order that would fill is missed
+}
+
+// DefaultPerturbation returns realistic noise: METAR max ±1°F, entry prices
+// ±3¢ and one fill in ten missed.
+func DefaultPerturbation() Perturbation {
+	return Perturbation{TempF: 1, PriceCents: 3, MissFill: 0.1}
+}
+
+// Apply returns a copy of ds with the METAR and price offsets drawn from rng.
+func (p Perturbation) Apply(ds *Dataset, rng *rand.Rand) *Dataset {
+	out := &Dataset{Source: ds.Source, Description: ds.Description, Days: make([]Day, len(ds.Days))}
+	for i, day := range ds.Days {
+		shift := float64(offset(rng, p.TempF))
+		day.METAR = append([]Observation(nil), day.METAR...)
+		for j := range day.METAR {
+			day.METAR[j].TempF += shift
+		}
+
+		day.Brackets = append([]Bracket(nil), day.Brackets...)
+		for j := range day.Brackets {
+			b := &day.Brackets[j]
+			shift := offset(rng, p.PriceCents)
+			if b.FirstYesPrice > 0 {
+				b.FirstYesPrice = clampPrice(b.FirstYesPrice + shift)
+			}
+			b.Ticks = append([]Tick(nil), b.Ticks...)
+			for k := range b.Ticks {
+				b.Ticks[k].YesPrice = clampPrice(b.Ticks[k].YesPrice + shift)
+			}
+		}
+		out.Days[i] = day
+	}
+	return out
+}
+
+// offset draws a whole number uniformly from [-n, n].
+func offset(rng *rand.Rand, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return rng.IntN(2*n+1) - n
+}
+
+// RobustnessConfig configures a robustness test.
+type RobustnessConfig struct {
+	Runs         int
+	Seed         uint64
+	Perturbation Perturbation
+	Workers      int // Runs backtested at once; 0 = one per CPU
+}
+
+// DefaultRobustnessConfig returns 200 runs of DefaultPerturbation.
+func DefaultRobustnessConfig() RobustnessConfig {
+	return RobustnessConfig{Runs: 200, Seed: 1, Perturbation: DefaultPerturbation()}
+}
+
+// RobustnessResult is the spread of a strategy's profit under perturbation.
+type RobustnessResult struct {
+	Strategy   string
+	Baseline   float64   // Profit on the unperturbed dataset
+	Profits    []float64 // Profit of each perturbed run, ascending
+	Mean       float64
+	StdDev     float64
+	Profitable float64 // Share of runs with a positive profit
+}
+
+// Percentile returns the p-th percentile (0-100) perturbed profit.
+func (r *RobustnessResult) Percentile(p float64) float64 {
+	return stats.Percentile(r.Profits, p)
+}
+
+// Retained returns the mean perturbed profit as a share of the baseline: 1
+// means the edge survives the noise, 0 or below that it was an artifact of
+// the exact data. It is 0 when the baseline is not profitable.
+func (r *RobustnessResult) Retained() float64 {
+	if r.Baseline <= 0 {
+		return 0
+	}
+	return r.Mean / r.Baseline
+}
+
+// Robustness backtests a fresh strategy from newStrategy on the dataset
+// once unperturbed and then Runs times under perturbation. Each run draws
+// its noise from its own seed, so results do not depend on Workers.
+func Robustness(ds *Dataset, newStrategy func() strategy.Strategy, cfg Config, rc RobustnessConfig) *RobustnessResult {
+	cfg.Baselines = false
+	base := Run(ds, newStrategy(), cfg)
+	result := &RobustnessResult{Strategy: base.Strategy, Baseline: base.TotalProfit}
+
+	rng := rand.New(rand.NewPCG(rc.Seed, rc.Seed^0x9e3779b97f4a7c15))
+	seeds := make([]uint64, max(rc.Runs, 0))
+	for i := range seeds {
+		seeds[i] = rng.Uint64()
+	}
+	result.Profits = Parallel(seeds, rc.Workers, func(seed uint64) float64 {
+		run := cfg
+		run.MissFill = rc.Perturbation.MissFill
+		run.Seed = seed
+		data := rc.Perturbation.Apply(ds, rand.New(rand.NewPCG(seed, ^seed)))
+		return Run(data, newStrategy(), run).TotalProfit
+	})
+	profitable := 0
+	for _, p := range result.Profits {
+		if p > 0 {
+			profitable++
+		}
+	}
+	if rc.Runs <= 0 {
+		return result
+	}
+
+	sort.Float64s(result.Profits)
+	result.Mean = stats.Mean(result.Profits)
+	result.StdDev = stats.StdDev(result.Profits)
+	result.Profitable = float64(profitable) / float64(rc.Runs)
+	return result
+}
@@ -0,0 +1,77 @@
+package backtest_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+func TestPerturbation_Apply(t *testing.T) {
+	ds := testDay()
+	p := backtest.Perturbation{TempF: 1, PriceCents: 3}
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for range 50 {
+		out := p.Apply(ds, rng)
+		day, orig := out.Days[0], ds.Days[0]
+
+		shift := day.METAR[0].TempF - orig.METAR[0].TempF
+		if shift < -1.0001 || shift > 1.0001 || day.METAR[1].TempF-orig.METAR[1].TempF != shift {
+			t.Fatalf("METAR shifted by %v and %v, want one offset within ±1", shift, day.METAR[1].TempF-orig.METAR[1].TempF)
+		}
+		for i, b := range day.Brackets {
+			if d := b.FirstYesPrice - orig.Brackets[i].FirstYesPrice; d < -3 || d > 3 {
+				t.Errorf("bracket %s price shifted by %d, want within ±3", b.Ticker, d)
+			}
+		}
+		if day.Settlement != orig.Settlement || day.Winner().Ticker != "C" {
+			t.Errorf("settlement changed: %d, winner %s", day.Settlement, day.Winner().Ticker)
+		}
+	}
+
+	// The source dataset is untouched
+	if ds.Days[0].METAR[0].TempF != 60.8 || ds.Days[0].Brackets[1].FirstYesPrice != 40 {
+		t.Errorf("Apply modified its input: %+v", ds.Days[0])
+	}
+}
+
+func TestRun_MissFill(t *testing.T) {
+	orders := []strategy.Order{{Ticker: "C", Side: "yes", Action: "buy", Price: 45, Quantity: 10}}
+	cfg := backtest.DefaultConfig()
+	cfg.MissFill = 1
+
+	r := backtest.Run(testDay(), &scripted{seen: make(map[string]bool), orders: orders}, cfg)
+	if len(r.Trades) != 0 || r.Rejected != 1 {
+		t.Errorf("got %d trades, %d rejected, want every fill missed", len(r.Trades), r.Rejected)
+	}
+}
+
+func TestRobustness(t *testing.T) {
+	newStrategy := func() strategy.Strategy {
+		return &scripted{
+			seen:   make(map[string]bool),
+			orders: []strategy.Order{{Ticker: "C", Side: "yes", Action: "buy", Price: 45, Quantity: 10}},
+		}
+	}
+	cfg := backtest.DefaultConfig()
+	cfg.Fees = nil
+	rc := backtest.RobustnessConfig{Runs: 100, Seed: 3, Perturbation: backtest.Perturbation{PriceCents: 3, MissFill: 0.5}}
+
+	r := backtest.Robustness(testDay(), newStrategy, cfg, rc)
+
+	if r.Strategy != "scripted" || r.Baseline != 5.9 {
+		t.Fatalf("baseline = %s $%v, want scripted $5.9", r.Strategy, r.Baseline)
+	}
+	if len(r.Profits) != 100 {
+		t.Fatalf("got %d runs, want 100", len(r.Profits))
+	}
+	// About half the runs miss the only fill and make nothing
+	if r.Profitable < 0.3 || r.Profitable > 0.7 {
+		t.Errorf("Profitable = %v, want about 0.5", r.Profitable)
+	}
+	if r.Percentile(0) != 0 || r.Retained() <= 0 || r.Retained() >= 1 {
+		t.Errorf("P0 = %v, Retained() = %v, want 0 and between 0 and 1", r.Percentile(0), r.Retained())
+	}
+}
@@ -0,0 +1,308 @@
+package backtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+)
+
+// DefaultRunsDir is where the backtest and optimizer commands record their
+// runs.
+const DefaultRunsDir = "results/runs"
+
+// RunRecord is a recorded backtest or optimization run: the command,
+// strategy and parameters, the code and data it ran on, and what it scored.
+// Unlike an Experiment it keeps no trades, so every run can be recorded; the
+// registry is a directory of JSON files, one per run.
+type RunRecord struct {
+	ID       string
+	Tool     string          // Command that ran it, e.g. "backtest-dualside"
+	Strategy string          // Strategy, or for an optimizer the one it chose
+	Params   json.RawMessage // Effective parameters; for an optimizer, the chosen set
+	Commit   string          // Git commit of the code, with "-dirty" for uncommitted changes
+	Dataset  string          // Dataset file, bundled fixture or live source
+	From     string          // First date of the data window, YYYY-MM-DD
+	To       string          // Last date
+	Seed     uint64          // Seed of every random draw
+	Created  time.Time
+	Metrics  map[string]float64
+}
+
+// NewRunRecord records a run at the current commit. The ID is derived from the
+// command, strategy, parameters, dataset, window, seed and commit: running
+// the same thing on the same code yields the same ID, and so the same
+// metrics, while a parameter, data or code change yields a new one.
+// Settings that aren't parameters are fixed by the commit.
+func NewRunRecord(tool, strategyName string, params any, dataset, from, to string, seed uint64) (*RunRecord, error) {
+	p, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("marshal params: %w", err)
+	}
+	run := &RunRecord{
+		Tool:     tool,
+		Strategy: strategyName,
+		Params:   p,
+		Commit:   GitCommit(),
+		Dataset:  dataset,
+		From:     from,
+		To:       to,
+		Seed:     seed,
+		Created:  time.Now().UTC(),
+		Metrics:  make(map[string]float64),
+	}
+
+	h := sha256.New()
+	for _, part := range []string{tool, strategyName, string(p), dataset, from, to, strconv.FormatUint(seed, 10), run.Commit} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	run.ID = fmt.Sprintf("%s-%s", slug(tool), hex.EncodeToString(h.Sum(nil))[:8])
+	return run, nil
+}
+
+// AddResult adds the metrics of a backtest result: see ResultMetrics.
+func (r *RunRecord) AddResult(res *Result) {
+	for k, v := range ResultMetrics(res) {
+		r.Metrics[k] = v
+	}
+}
+
+// ResultMetrics returns a result's headline metrics by name, with the 95%
+// bootstrap intervals of the win rate and EV per trade.
+func ResultMetrics(r *Result) map[string]float64 {
+	sum := r.Bootstrap(stats.DefaultBootstrapConfig())
+	return map[string]float64{
+		"trades":          float64(len(r.Trades)),
+		"profit":          r.TotalProfit,
+		"fees":            r.TotalFees,
+		"roi":             r.ROI(),
+		"per_dollar_hour": r.ReturnPerDollarHour(),
+		"win_rate":        r.WinRate,
+		"win_rate_lo":     sum.WinRate.Lo,
+		"win_rate_hi":     sum.WinRate.Hi,
+		"ev":              sum.EV.Estimate,
+		"ev_lo":           sum.EV.Lo,
+		"ev_hi":           sum.EV.Hi,
+		"sharpe":          r.Sharpe,
+		"max_drawdown":    r.MaxDrawdown,
+	}
+}
+
+// Save writes the run to dir/<ID>.json, replacing an earlier run with the
+// same ID.
+func (r *RunRecord) Save(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create runs directory: %w", err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal run: %w", err)
+	}
+	path := filepath.Join(dir, r.ID+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", fmt.Errorf("write run: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return "", fmt.Errorf("write run: %w", err)
+	}
+	return path, nil
+}
+
+// LoadRunRecord reads the run with the given ID (or a unique prefix of it)
+// from dir.
+func LoadRunRecord(dir, id string) (*RunRecord, error) {
+	path := filepath.Join(dir, id+".json")
+	if _, err := os.Stat(path); err != nil {
+		matches, _ := filepath.Glob(filepath.Join(dir, id+"*.json"))
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("run %q not found in %s", id, dir)
+		case 1:
+			path = matches[0]
+		default:
+			return nil, fmt.Errorf("run %q is ambiguous (%d matches)", id, len(matches))
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read run: %w", err)
+	}
+	var r RunRecord
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse run %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// ListRunRecords returns the runs recorded in dir, oldest first.
+func ListRunRecords(dir string) ([]*RunRecord, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var runs []*RunRecord
+	for _, path := range paths {
+		r, err := LoadRunRecord(dir, strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, r)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Created.Before(runs[j].Created) })
+	return runs, nil
+}
+
+// RunComparison is how two runs differ.
+type RunComparison struct {
+	SameCode bool          // Same commit
+	SameData bool          // Same dataset and window
+	Params   []ParamChange // Parameters that differ, by path
+	Metrics  []MetricDelta // Every metric of either run, by name
+}
+
+// ParamChange is a parameter that differs between two runs. A or B is empty
+// when the parameter is absent from that run.
+type ParamChange struct {
+	Path string // Dotted path into the parameters, e.g. Exits.StopLoss
+	A, B string // JSON values
+}
+
+// MetricDelta is a metric of two runs. A or B is NaN when that run lacks it.
+type MetricDelta struct {
+	Name string
+	A, B float64
+}
+
+// Delta returns B less A.
+func (m MetricDelta) Delta() float64 {
+	return m.B - m.A
+}
+
+// CompareRunRecords compares run a with run b.
+func CompareRunRecords(a, b *RunRecord) RunComparison {
+	c := RunComparison{
+		SameCode: a.Commit == b.Commit,
+		SameData: a.Dataset == b.Dataset && a.From == b.From && a.To == b.To,
+	}
+
+	pa, pb := flattenParams(a.Params), flattenParams(b.Params)
+	for path := range union(pa, pb) {
+		if pa[path] != pb[path] {
+			c.Params = append(c.Params, ParamChange{Path: path, A: pa[path], B: pb[path]})
+		}
+	}
+	sort.Slice(c.Params, func(i, j int) bool { return c.Params[i].Path < c.Params[j].Path })
+
+	for name := range union(a.Metrics, b.Metrics) {
+		m := MetricDelta{Name: name, A: math.NaN(), B: math.NaN()}
+		if v, ok := a.Metrics[name]; ok {
+			m.A = v
+		}
+		if v, ok := b.Metrics[name]; ok {
+			m.B = v
+		}
+		c.Metrics = append(c.Metrics, m)
+	}
+	sort.Slice(c.Metrics, func(i, j int) bool { return c.Metrics[i].Name < c.Metrics[j].Name })
+	return c
+}
+
+// flattenParams maps each leaf of a JSON object to its dotted path. Nulls
+// are left out, so a nil map compares equal to an absent one.
+func flattenParams(raw json.RawMessage) map[string]string {
+	leaves := make(map[string]string)
+	var walk func(prefix string, v json.RawMessage)
+	walk = func(prefix string, v json.RawMessage) {
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(v, &fields) != nil || (prefix != "" && len(fields) == 0) {
+			if leaf := string(bytes.TrimSpace(v)); leaf != "null" {
+				leaves[prefix] = leaf
+			}
+			return
+		}
+		for k, f := range fields {
+			path := k
+			if prefix != "" {
+				path = prefix + "." + k
+			}
+			walk(path, f)
+		}
+	}
+	if len(raw) > 0 {
+		walk("", raw)
+	}
+	return leaves
+}
+
+func union[V any](a, b map[string]V) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
+
+// GitCommit returns the git commit of the code running, from the build's
+// version control stamp or else from git in the working directory, with
+// "-dirty" when there are uncommitted changes; empty outside a checkout.
+func GitCommit() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		var revision string
+		modified := false
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				revision = s.Value
+			case "vcs.modified":
+				modified = s.Value == "true"
+			}
+		}
+		if revision != "" {
+			if modified {
+				revision += "-dirty"
+			}
+			return revision
+		}
+	}
+
+	out, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	revision := strings.TrimSpace(string(out))
+	if status, err := exec.Command("git", "status", "--porcelain", "--untracked-files=no").Output(); err == nil && len(bytes.TrimSpace(status)) > 0 {
+		revision += "-dirty"
+	}
+	return revision
+}
+
+// Span returns the first and last dates of the dataset's days.
+func (ds *Dataset) Span() (from, to string) {
+	for _, d := range ds.Days {
+		if from == "" || d.Date < from {
+			from = d.Date
+		}
+		if d.Date > to {
+			to = d.Date
+		}
+	}
+	return from, to
+}
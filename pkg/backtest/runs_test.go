@@ -0,0 +1,82 @@
+package backtest_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest/fixtures"
+)
+
+func TestRunRecord_SaveLoad(t *testing.T) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		t.Fatalf("SyntheticLAXNYC() error = %v", err)
+	}
+	from, to := ds.Span()
+	if from == "" || from > to {
+		t.Fatalf("Span() = %s, %s", from, to)
+	}
+
+	type params struct {
+		Margin int
+		Exits  struct{ StopLoss float64 }
+	}
+	p := params{Margin: 2}
+	run, err := backtest.NewRunRecord("backtest-dualside", "DualSide", p, "lax-nyc", from, to, 1)
+	if err != nil {
+		t.Fatalf("NewRunRecord() error = %v", err)
+	}
+	again, _ := backtest.NewRunRecord("backtest-dualside", "DualSide", p, "lax-nyc", from, to, 1)
+	reseeded, _ := backtest.NewRunRecord("backtest-dualside", "DualSide", p, "lax-nyc", from, to, 2)
+	p.Exits.StopLoss = 0.5
+	changed, _ := backtest.NewRunRecord("backtest-dualside", "DualSide", p, "lax-nyc", from, to, 1)
+	if run.ID != again.ID || run.ID == reseeded.ID || run.ID == changed.ID {
+		t.Errorf("IDs = %s, %s, %s, %s, want equal for the same inputs and different otherwise",
+			run.ID, again.ID, reseeded.ID, changed.ID)
+	}
+
+	run.AddResult(backtest.Run(ds, &favorite{seen: make(map[string]bool)}, backtest.DefaultConfig()))
+	changed.Metrics["profit"] = run.Metrics["profit"] + 10
+	dir := t.TempDir()
+	for _, r := range []*backtest.RunRecord{run, changed} {
+		if _, err := r.Save(dir); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	loaded, err := backtest.LoadRunRecord(dir, run.ID[:len("backtest-dualside-")+4])
+	if err != nil {
+		t.Fatalf("LoadRunRecord(prefix) error = %v", err)
+	}
+	if loaded.ID != run.ID || loaded.From != from || loaded.Seed != 1 || loaded.Metrics["trades"] != run.Metrics["trades"] {
+		t.Errorf("LoadRunRecord() = %+v, want %+v", loaded, run)
+	}
+	if _, err := backtest.LoadRunRecord(dir, "backtest-dualside"); err == nil {
+		t.Error("LoadRunRecord(ambiguous prefix) succeeded, want an error")
+	}
+	runs, err := backtest.ListRunRecords(dir)
+	if err != nil || len(runs) != 2 {
+		t.Fatalf("ListRunRecords() = %d runs, %v, want 2", len(runs), err)
+	}
+
+	c := backtest.CompareRunRecords(loaded, changed)
+	if !c.SameCode || !c.SameData {
+		t.Errorf("CompareRunRecords() = %+v, want the same code and data", c)
+	}
+	if len(c.Params) != 1 || c.Params[0].Path != "Exits.StopLoss" || c.Params[0].A != "0" || c.Params[0].B != "0.5" {
+		t.Errorf("Params = %+v, want only Exits.StopLoss 0 to 0.5", c.Params)
+	}
+	for _, m := range c.Metrics {
+		switch m.Name {
+		case "profit":
+			if math.Abs(m.Delta()-10) > 1e-9 {
+				t.Errorf("profit delta = %.2f, want 10", m.Delta())
+			}
+		case "trades":
+			if !math.IsNaN(m.B) {
+				t.Errorf("trades = %v, want NaN for the run without it", m.B)
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// WalkForwardConfig configures a walk-forward analysis: parameters are
+// chosen on a training window of days and judged only on the test window
+// that follows it, then both windows move on.
+type WalkForwardConfig struct {
+	Train    int  // Days in each training window
+	Test     int  // Days in each test window
+	Step     int  // Days the windows move between folds; 0 = Test, so no day is tested twice
+	Anchored bool // Grow each training window from the first day instead of rolling it
+	Workers  int  // Parameter sets scored at once; 0 = one per CPU
+}
+
+// DefaultWalkForwardConfig returns rolling 12-day training and 3-day test
+// windows, three folds over the optimizers' 21 days.
+func DefaultWalkForwardConfig() WalkForwardConfig {
+	return WalkForwardConfig{Train: 12, Test: 3}
+}
+
+// Window is a run of consecutive days with data, by local date
+// (YYYY-MM-DD).
+type Window struct {
+	From, To string
+	Days     int // Days with data in the window
+}
+
+// Contains reports whether date (YYYY-MM-DD) falls in the window.
+func (w Window) Contains(date string) bool {
+	return date >= w.From && date <= w.To
+}
+
+// String formats the window, e.g. "2025-12-01..2025-12-12 (12 days)".
+func (w Window) String() string {
+	return fmt.Sprintf("%s..%s (%d days)", w.From, w.To, w.Days)
+}
+
+// Fold is one training window and the test window after it.
+type Fold struct {
+	Train, Test Window
+}
+
+// Folds splits dates, in any order and with repeats (one per city-day, say),
+// into folds. Only complete test windows are used, so the last days may go
+// untested.
+func (c WalkForwardConfig) Folds(dates []string) ([]Fold, error) {
+	if c.Train <= 0 || c.Test <= 0 {
+		return nil, fmt.Errorf("training and test windows must be at least a day, not %d and %d", c.Train, c.Test)
+	}
+	step := c.Step
+	if step <= 0 {
+		step = c.Test
+	}
+
+	seen := make(map[string]bool)
+	var days []string
+	for _, d := range dates {
+		if !seen[d] {
+			seen[d] = true
+			days = append(days, d)
+		}
+	}
+	sort.Strings(days)
+	if len(days) < c.Train+c.Test {
+		return nil, fmt.Errorf("%d days is too few for %d training and %d test days", len(days), c.Train, c.Test)
+	}
+
+	window := func(from, to int) Window {
+		return Window{From: days[from], To: days[to-1], Days: to - from}
+	}
+	var folds []Fold
+	for start := 0; start+c.Train+c.Test <= len(days); start += step {
+		split := start + c.Train
+		from := start
+		if c.Anchored {
+			from = 0
+		}
+		folds = append(folds, Fold{Train: window(from, split), Test: window(split, split+c.Test)})
+	}
+	return folds, nil
+}
+
+// FoldResult is the parameter set chosen on a fold's training window and
+// how it scored on both windows.
+type FoldResult[P any] struct {
+	Fold
+	Params      P
+	InSample    float64 // Score on the training window
+	OutOfSample float64 // Score on the test window
+}
+
+// WalkForwardResult is the outcome of a walk-forward analysis.
+type WalkForwardResult[P any] struct {
+	Folds       []FoldResult[P]
+	InSample    float64 // Mean in-sample score of the chosen sets
+	OutOfSample float64 // Mean out-of-sample score of the chosen sets
+}
+
+// Degradation returns the share of the in-sample score lost out of sample:
+// near 0 when the chosen parameters hold up on unseen days, 1 when their
+// edge disappears and above 1 when they lose. It is 0 when the in-sample
+// score is not positive.
+func (r *WalkForwardResult[P]) Degradation() float64 {
+	if r.InSample <= 0 {
+		return 0
+	}
+	return 1 - r.OutOfSample/r.InSample
+}
+
+// Stable reports whether every fold chose the same parameter set, by the
+// key given for each.
+func (r *WalkForwardResult[P]) Stable(key func(P) string) bool {
+	for _, f := range r.Folds {
+		if key(f.Params) != key(r.Folds[0].Params) {
+			return false
+		}
+	}
+	return true
+}
+
+// WalkForward chooses the parameter set in grid with the highest score on
+// each fold's training window, and scores it again on the fold's test
+// window, which played no part in the choice. score is called for any
+// window and should be a rate, such as profit per day, so windows of
+// different lengths compare. Ties go to the earlier set in grid; NaN
+// scores are never chosen.
+func WalkForward[P any](dates []string, grid []P, cfg WalkForwardConfig, score func(p P, w Window) float64) (*WalkForwardResult[P], error) {
+	if len(grid) == 0 {
+		return nil, errors.New("no parameter sets to choose from")
+	}
+	folds, err := cfg.Folds(dates)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WalkForwardResult[P]{}
+	for _, f := range folds {
+		scores := Parallel(grid, cfg.Workers, func(p P) float64 { return score(p, f.Train) })
+		best := -1
+		for i, s := range scores {
+			if !math.IsNaN(s) && (best < 0 || s > scores[best]) {
+				best = i
+			}
+		}
+		if best < 0 {
+			return nil, fmt.Errorf("no parameter set scored on %s", f.Train)
+		}
+		fr := FoldResult[P]{Fold: f, Params: grid[best], InSample: scores[best], OutOfSample: score(grid[best], f.Test)}
+		result.Folds = append(result.Folds, fr)
+		result.InSample += fr.InSample / float64(len(folds))
+		result.OutOfSample += fr.OutOfSample / float64(len(folds))
+	}
+	return result, nil
+}
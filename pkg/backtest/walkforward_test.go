@@ -0,0 +1,102 @@
+package backtest_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+)
+
+// days returns dates 2025-12-01 onward, each listed twice as two cities'
+// days would be
+func days(n int) []string {
+	var dates []string
+	for i := range n {
+		d := fmt.Sprintf("2025-12-%02d", i+1)
+		dates = append(dates, d, d)
+	}
+	return dates
+}
+
+func TestWalkForwardConfig_Folds(t *testing.T) {
+	folds, err := backtest.DefaultWalkForwardConfig().Folds(days(22))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []backtest.Fold{
+		{Train: backtest.Window{From: "2025-12-01", To: "2025-12-12", Days: 12}, Test: backtest.Window{From: "2025-12-13", To: "2025-12-15", Days: 3}},
+		{Train: backtest.Window{From: "2025-12-04", To: "2025-12-15", Days: 12}, Test: backtest.Window{From: "2025-12-16", To: "2025-12-18", Days: 3}},
+		{Train: backtest.Window{From: "2025-12-07", To: "2025-12-18", Days: 12}, Test: backtest.Window{From: "2025-12-19", To: "2025-12-21", Days: 3}},
+	}
+	if fmt.Sprint(folds) != fmt.Sprint(want) {
+		t.Errorf("Folds = %v, want %v", folds, want)
+	}
+
+	anchored := backtest.WalkForwardConfig{Train: 12, Test: 3, Step: 1, Anchored: true}
+	folds, err = anchored.Folds(days(21))
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := folds[len(folds)-1]
+	if len(folds) != 7 || last.Train.From != "2025-12-01" || last.Train.Days != 18 || last.Test.To != "2025-12-21" {
+		t.Errorf("anchored Folds = %v, want 7 folds growing from 2025-12-01", folds)
+	}
+
+	if _, err := backtest.DefaultWalkForwardConfig().Folds(days(14)); err == nil {
+		t.Error("Folds of 14 days succeeded, want too few days")
+	}
+	if _, err := (backtest.WalkForwardConfig{Train: 12}).Folds(days(21)); err == nil {
+		t.Error("Folds without a test window succeeded")
+	}
+}
+
+func TestWalkForward(t *testing.T) {
+	// "steady" earns $10 every day; "lucky" earns $30 a day for the first
+	// 12 days and loses $10 a day after, so it wins in sample and fails out
+	// of it
+	daily := func(p string, day int) float64 {
+		switch {
+		case p == "steady":
+			return 10
+		case day <= 12:
+			return 30
+		}
+		return -10
+	}
+	score := func(p string, w backtest.Window) float64 {
+		var total float64
+		for day := 1; day <= 21; day++ {
+			if w.Contains(fmt.Sprintf("2025-12-%02d", day)) {
+				total += daily(p, day)
+			}
+		}
+		return total / float64(w.Days)
+	}
+
+	r, err := backtest.WalkForward(days(21), []string{"steady", "lucky"}, backtest.DefaultWalkForwardConfig(), score)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chosen []string
+	for _, f := range r.Folds {
+		chosen = append(chosen, fmt.Sprintf("%s %.0f/%.0f", f.Params, f.InSample, f.OutOfSample))
+	}
+	// The third training window ties at $10 a day, and the earlier set wins
+	if want := "[lucky 30/-10 lucky 20/-10 steady 10/10]"; fmt.Sprint(chosen) != want {
+		t.Errorf("folds chose %v, want %s", chosen, want)
+	}
+	if math.Abs(r.InSample-20) > 1e-9 || math.Abs(r.OutOfSample+10.0/3) > 1e-9 {
+		t.Errorf("InSample, OutOfSample = %.2f, %.2f, want 20, -3.33", r.InSample, r.OutOfSample)
+	}
+	if d := r.Degradation(); math.Abs(d-7.0/6) > 1e-9 {
+		t.Errorf("Degradation() = %.3f, want 1.167", d)
+	}
+	if r.Stable(func(p string) string { return p }) {
+		t.Error("Stable() = true, want false")
+	}
+
+	if _, err := backtest.WalkForward(days(21), nil, backtest.DefaultWalkForwardConfig(), score); err == nil {
+		t.Error("WalkForward of an empty grid succeeded")
+	}
+}
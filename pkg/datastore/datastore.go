@@ -0,0 +1,356 @@
+// Package datastore caches historical Kalshi market data, weather
+// observations and NWS forecast discussions in SQLite, so repeat backtests
+// run in seconds and offline.
+//
+// Only history that can no longer change is cached: markets and trades once
+// the markets are determined, observations and discussions once the
+// station-day has settled.
+// Cached entries therefore never expire; set Store.Refresh to refetch them.
+//
+// NWS does not archive its forecasts, so the store also keeps the ones
+// recorded with RecordForecast.
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// Store is a read-through cache of historical data in a SQLite database.
+type Store struct {
+	// Refresh ignores cached entries: everything is refetched and the cache
+	// overwritten.
+	Refresh bool
+
+	db  *sql.DB
+	now func() time.Time
+}
+
+// Settlement is the outcome of a determined market.
+type Settlement struct {
+	Ticker          string
+	EventTicker     string
+	Result          string // "yes" or "no"
+	ExpirationValue string // Settlement value, e.g. the observed high
+}
+
+// Open opens the cache database at path, creating it if needed.
+func Open(path string) (*Store, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create cache directory: %w", err)
+		}
+	}
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("open cache: %w", err)
+	}
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("enable WAL: %w", err)
+	}
+
+	s := &Store{db: db, now: time.Now}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate cache: %w", err)
+	}
+	return s, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) migrate() error {
+	_, err := s.db.Exec(`
+	CREATE TABLE IF NOT EXISTS markets (
+		key TEXT PRIMARY KEY, -- Event ticker
+		data TEXT NOT NULL,
+		fetched_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS trades (
+		key TEXT PRIMARY KEY, -- Market ticker
+		data TEXT NOT NULL,
+		fetched_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS observations (
+		key TEXT PRIMARY KEY, -- Provider/station/date
+		data TEXT NOT NULL,
+		fetched_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS discussions (
+		key TEXT PRIMARY KEY, -- Station/date
+		data TEXT NOT NULL,
+		fetched_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS forecasts (
+		key TEXT PRIMARY KEY, -- Station/date
+		data TEXT NOT NULL,
+		fetched_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS settlements (
+		ticker TEXT PRIMARY KEY,
+		event_ticker TEXT NOT NULL,
+		result TEXT NOT NULL,
+		expiration_value TEXT NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_settlements_event ON settlements(event_ticker);
+	`)
+	return err
+}
+
+// Markets returns every market of an event, from the cache once all of them
+// are determined. Their settlements are recorded too.
+func (s *Store) Markets(ctx context.Context, c *rest.Client, eventTicker string) ([]rest.Market, error) {
+	var markets []rest.Market
+	if ok, err := s.get("markets", eventTicker, &markets); ok || err != nil {
+		return markets, err
+	}
+
+	markets, err := collect(c.IterMarkets(ctx, rest.GetMarketsParams{EventTicker: eventTicker}))
+	if err != nil {
+		return nil, err
+	}
+	if len(markets) == 0 {
+		return markets, nil
+	}
+	for _, m := range markets {
+		if !m.Determined() {
+			return markets, nil
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	for _, m := range markets {
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO settlements (ticker, event_ticker, result, expiration_value)
+			VALUES (?, ?, ?, ?)`, m.Ticker, m.EventTicker, m.Result, m.ExpirationValue); err != nil {
+			return nil, fmt.Errorf("cache settlement: %w", err)
+		}
+	}
+	if err := s.put(tx, "markets", eventTicker, markets); err != nil {
+		return nil, err
+	}
+	return markets, tx.Commit()
+}
+
+// Settlements returns the recorded outcomes of an event's markets, or none if
+// the event has not been cached.
+func (s *Store) Settlements(eventTicker string) ([]Settlement, error) {
+	rows, err := s.db.Query(`SELECT ticker, event_ticker, result, expiration_value
+		FROM settlements WHERE event_ticker = ? ORDER BY ticker`, eventTicker)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var settlements []Settlement
+	for rows.Next() {
+		var st Settlement
+		if err := rows.Scan(&st.Ticker, &st.EventTicker, &st.Result, &st.ExpirationValue); err != nil {
+			return nil, err
+		}
+		settlements = append(settlements, st)
+	}
+	return settlements, rows.Err()
+}
+
+// Trades returns every trade print of a market, from the cache once the
+// market is determined.
+func (s *Store) Trades(ctx context.Context, c *rest.Client, m rest.Market) ([]rest.Trade, error) {
+	var trades []rest.Trade
+	if ok, err := s.get("trades", m.Ticker, &trades); ok || err != nil {
+		return trades, err
+	}
+
+	trades, err := collect(c.IterTrades(ctx, rest.GetTradesParams{Ticker: m.Ticker, Limit: 1000}))
+	if err != nil {
+		return nil, err
+	}
+	if m.Determined() {
+		if err := s.put(s.db, "trades", m.Ticker, trades); err != nil {
+			return nil, err
+		}
+	}
+	return trades, nil
+}
+
+// Weather wraps a weather provider, caching the reports of settled
+// station-days.
+func (s *Store) Weather(p weather.Provider) weather.Provider {
+	return &cachedProvider{store: s, provider: p}
+}
+
+type cachedProvider struct {
+	store    *Store
+	provider weather.Provider
+}
+
+func (c *cachedProvider) Name() string { return c.provider.Name() }
+
+func (c *cachedProvider) Observations(ctx context.Context, station *weather.Station, date time.Time) ([]weather.Observation, error) {
+	day := station.LocalDay(date)
+	key := c.provider.Name() + "/" + station.ID + "/" + day.Format("2006-01-02")
+
+	var obs []weather.Observation
+	if ok, err := c.store.get("observations", key, &obs); ok || err != nil {
+		return obs, err
+	}
+
+	obs, err := c.provider.Observations(ctx, station, day)
+	if err != nil {
+		return nil, err
+	}
+	if c.store.settled(day) && len(obs) > 0 {
+		if err := c.store.put(c.store.db, "observations", key, obs); err != nil {
+			return nil, err
+		}
+	}
+	return obs, nil
+}
+
+// fetchDiscussions is replaced in tests.
+var fetchDiscussions = weather.FetchDiscussions
+
+// Discussions returns the NWS area forecast discussions issued for the
+// station on date's calendar day, from the cache once the day has settled.
+func (s *Store) Discussions(ctx context.Context, station *weather.Station, date time.Time) ([]weather.Discussion, error) {
+	day := station.LocalDay(date)
+	key := station.ID + "/" + day.Format("2006-01-02")
+
+	var discussions []weather.Discussion
+	if ok, err := s.get("discussions", key, &discussions); ok || err != nil {
+		return discussions, err
+	}
+
+	discussions, err := fetchDiscussions(ctx, station, day)
+	if err != nil {
+		return nil, err
+	}
+	if s.settled(day) && len(discussions) > 0 {
+		if err := s.put(s.db, "discussions", key, discussions); err != nil {
+			return nil, err
+		}
+	}
+	return discussions, nil
+}
+
+// RecordedForecast is a forecast high as recorded on the day.
+type RecordedForecast struct {
+	HighTemp float64   // °F
+	Issued   time.Time // When NWS issued it, zero if unknown
+}
+
+// RecordForecast keeps the first forecast recorded for its station-day, so
+// history holds the forecast as it stood that morning. Later calls for the
+// same day are ignored.
+func (s *Store) RecordForecast(f *weather.Forecast) error {
+	key := f.Station.ID + "/" + f.Station.LocalDay(f.Date).Format("2006-01-02")
+	data, err := json.Marshal(RecordedForecast{HighTemp: f.HighTemp, Issued: f.Issued})
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.Exec("INSERT OR IGNORE INTO forecasts (key, data, fetched_at) VALUES (?, ?, ?)",
+		key, string(data), s.now().UTC()); err != nil {
+		return fmt.Errorf("write forecasts cache: %w", err)
+	}
+	return nil
+}
+
+// Forecast returns the forecast recorded for the station on date's calendar
+// day, or nil if none was.
+func (s *Store) Forecast(station *weather.Station, date time.Time) (*RecordedForecast, error) {
+	key := station.ID + "/" + station.LocalDay(date).Format("2006-01-02")
+	var data string
+	err := s.db.QueryRow("SELECT data FROM forecasts WHERE key = ?", key).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read forecasts cache: %w", err)
+	}
+	var f RecordedForecast
+	if err := json.Unmarshal([]byte(data), &f); err != nil {
+		return nil, fmt.Errorf("decode cached forecast %s: %w", key, err)
+	}
+	return &f, nil
+}
+
+// settled reports whether late corrections to the station-day starting at
+// day have stopped arriving.
+func (s *Store) settled(day time.Time) bool {
+	return s.now().After(day.AddDate(0, 0, 1).Add(weather.CacheSettleDelay))
+}
+
+// collect drains a cursor iterator, stopping at the first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var all []T
+	for v, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, v)
+	}
+	return all, nil
+}
+
+// execer is a *sql.DB or *sql.Tx
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// get decodes the cached entry of key into v, reporting whether there was
+// one. It always misses when Refresh is set.
+func (s *Store) get(table, key string, v any) (bool, error) {
+	if s.Refresh {
+		return false, nil
+	}
+	var data string
+	err := s.db.QueryRow("SELECT data FROM "+table+" WHERE key = ?", key).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read %s cache: %w", table, err)
+	}
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		return false, fmt.Errorf("decode cached %s %s: %w", table, key, err)
+	}
+	return true, nil
+}
+
+// put stores v as the entry of key.
+func (s *Store) put(db execer, table, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec("INSERT OR REPLACE INTO "+table+" (key, data, fetched_at) VALUES (?, ?, ?)",
+		key, string(data), s.now().UTC()); err != nil {
+		return fmt.Errorf("write %s cache: %w", table, err)
+	}
+	return nil
+}
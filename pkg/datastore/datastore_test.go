@@ -0,0 +1,206 @@
+package datastore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// fakeExchange serves canned markets and trades, counting requests
+func fakeExchange(t *testing.T, markets string) (*rest.Client, map[string]int) {
+	t.Helper()
+	calls := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/trade-api/v2/markets":
+			w.Write([]byte(markets))
+		case "/trade-api/v2/markets/trades":
+			w.Write([]byte(`{"trades":[{"trade_id":"t1","ticker":"KXHIGHLAX-25DEC05-B62.5","count":3,"yes_price":41}],"cursor":""}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return rest.NewPublic(rest.WithBaseURL(server.URL + "/trade-api/v2")), calls
+}
+
+func openStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "cache", "history.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestStore_Markets(t *testing.T) {
+	settled := `{"markets":[
+		{"ticker":"KXHIGHLAX-25DEC05-B62.5","event_ticker":"KXHIGHLAX-25DEC05","status":"finalized","result":"yes","expiration_value":"63"},
+		{"ticker":"KXHIGHLAX-25DEC05-T64","event_ticker":"KXHIGHLAX-25DEC05","status":"finalized","result":"no","expiration_value":"63"}
+	],"cursor":""}`
+	client, calls := fakeExchange(t, settled)
+	s := openStore(t)
+
+	for range 2 {
+		markets, err := s.Markets(context.Background(), client, "KXHIGHLAX-25DEC05")
+		if err != nil || len(markets) != 2 {
+			t.Fatalf("Markets() = %d markets, %v, want 2", len(markets), err)
+		}
+	}
+	if n := calls["/trade-api/v2/markets"]; n != 1 {
+		t.Errorf("fetched markets %d times, want 1", n)
+	}
+
+	settlements, err := s.Settlements("KXHIGHLAX-25DEC05")
+	if err != nil || len(settlements) != 2 {
+		t.Fatalf("Settlements() = %v, %v, want 2", settlements, err)
+	}
+	if st := settlements[0]; st.Ticker != "KXHIGHLAX-25DEC05-B62.5" || st.Result != "yes" || st.ExpirationValue != "63" {
+		t.Errorf("Settlements()[0] = %+v, want B62.5 settled yes at 63", st)
+	}
+
+	s.Refresh = true
+	if _, err := s.Markets(context.Background(), client, "KXHIGHLAX-25DEC05"); err != nil {
+		t.Fatalf("Markets() with Refresh error = %v", err)
+	}
+	if n := calls["/trade-api/v2/markets"]; n != 2 {
+		t.Errorf("with Refresh: fetched markets %d times, want 2", n)
+	}
+}
+
+func TestStore_OpenMarketsAreNotCached(t *testing.T) {
+	client, calls := fakeExchange(t, `{"markets":[
+		{"ticker":"KXHIGHLAX-25DEC05-B62.5","event_ticker":"KXHIGHLAX-25DEC05","status":"active"}
+	],"cursor":""}`)
+	s := openStore(t)
+
+	for range 2 {
+		markets, err := s.Markets(context.Background(), client, "KXHIGHLAX-25DEC05")
+		if err != nil || len(markets) != 1 {
+			t.Fatalf("Markets() = %d markets, %v, want 1", len(markets), err)
+		}
+		if _, err := s.Trades(context.Background(), client, markets[0]); err != nil {
+			t.Fatalf("Trades() error = %v", err)
+		}
+	}
+	if calls["/trade-api/v2/markets"] != 2 || calls["/trade-api/v2/markets/trades"] != 2 {
+		t.Errorf("fetched markets %d and trades %d times, want 2 each", calls["/trade-api/v2/markets"], calls["/trade-api/v2/markets/trades"])
+	}
+}
+
+func TestStore_Trades(t *testing.T) {
+	client, calls := fakeExchange(t, `{"markets":[],"cursor":""}`)
+	s := openStore(t)
+	m := rest.Market{Ticker: "KXHIGHLAX-25DEC05-B62.5", Status: "finalized", Result: "yes"}
+
+	for range 2 {
+		trades, err := s.Trades(context.Background(), client, m)
+		if err != nil || len(trades) != 1 || trades[0].YesPrice != 41 {
+			t.Fatalf("Trades() = %+v, %v, want one trade at 41¢", trades, err)
+		}
+	}
+	if n := calls["/trade-api/v2/markets/trades"]; n != 1 {
+		t.Errorf("fetched trades %d times, want 1", n)
+	}
+}
+
+// countingProvider serves one report per day and counts fetches
+type countingProvider struct{ calls int }
+
+func (p *countingProvider) Name() string { return "fake" }
+
+func (p *countingProvider) Observations(ctx context.Context, station *weather.Station, date time.Time) ([]weather.Observation, error) {
+	p.calls++
+	return []weather.Observation{{Time: date.Add(14 * time.Hour), Temp: 64.4}}, nil
+}
+
+func TestStore_Weather(t *testing.T) {
+	s := openStore(t)
+	station := weather.Stations["LAX"]
+	now := time.Date(2025, 12, 6, 10, 0, 0, 0, station.Location())
+	s.now = func() time.Time { return now }
+
+	p := &countingProvider{}
+	cached := s.Weather(p)
+	ctx := context.Background()
+
+	yesterday, today := now.AddDate(0, 0, -1), now
+	for range 2 {
+		obs, err := cached.Observations(ctx, station, yesterday)
+		if err != nil || len(obs) != 1 || obs[0].Temp != 64.4 || !obs[0].Time.Equal(station.LocalDay(yesterday).Add(14*time.Hour)) {
+			t.Fatalf("Observations(yesterday) = %+v, %v, want the 14:00 report", obs, err)
+		}
+		if _, err := cached.Observations(ctx, station, today); err != nil {
+			t.Fatalf("Observations(today) error = %v", err)
+		}
+	}
+	// Yesterday is settled and cached; today is fetched every time
+	if p.calls != 3 {
+		t.Errorf("provider called %d times, want 3", p.calls)
+	}
+}
+
+func TestStore_Discussions(t *testing.T) {
+	s := openStore(t)
+	station := weather.Stations["LAX"]
+	now := time.Date(2025, 12, 6, 10, 0, 0, 0, station.Location())
+	s.now = func() time.Time { return now }
+
+	calls := 0
+	defer func(f func(context.Context, *weather.Station, time.Time) ([]weather.Discussion, error)) {
+		fetchDiscussions = f
+	}(fetchDiscussions)
+	fetchDiscussions = func(ctx context.Context, station *weather.Station, date time.Time) ([]weather.Discussion, error) {
+		calls++
+		return []weather.Discussion{{Office: "LOX", Issued: date.Add(3 * time.Hour), Text: "Marine layer 1500 ft deep"}}, nil
+	}
+
+	yesterday := now.AddDate(0, 0, -1)
+	for range 2 {
+		discussions, err := s.Discussions(context.Background(), station, yesterday)
+		if err != nil || len(discussions) != 1 || discussions[0].Text != "Marine layer 1500 ft deep" {
+			t.Fatalf("Discussions() = %+v, %v, want the LOX discussion", discussions, err)
+		}
+	}
+	if _, err := s.Discussions(context.Background(), station, now); err != nil {
+		t.Fatalf("Discussions(today) error = %v", err)
+	}
+	if _, err := s.Discussions(context.Background(), station, now); err != nil {
+		t.Fatalf("Discussions(today) error = %v", err)
+	}
+	// Yesterday is settled and cached; today is fetched every time
+	if calls != 3 {
+		t.Errorf("fetched %d times, want 3", calls)
+	}
+}
+
+func TestStore_RecordForecast(t *testing.T) {
+	s := openStore(t)
+	station := weather.Stations["LAX"]
+	day := time.Date(2025, 12, 5, 0, 0, 0, 0, station.Location())
+
+	if f, err := s.Forecast(station, day); err != nil || f != nil {
+		t.Fatalf("Forecast() before recording = %+v, %v, want nil", f, err)
+	}
+	morning := &weather.Forecast{Station: station, Date: day.Add(6 * time.Hour), HighTemp: 66}
+	afternoon := &weather.Forecast{Station: station, Date: day.Add(15 * time.Hour), HighTemp: 68}
+	for _, f := range []*weather.Forecast{morning, afternoon} {
+		if err := s.RecordForecast(f); err != nil {
+			t.Fatalf("RecordForecast() error = %v", err)
+		}
+	}
+
+	f, err := s.Forecast(station, day)
+	if err != nil || f == nil || f.HighTemp != 66 {
+		t.Errorf("Forecast() = %+v, %v, want the morning's 66°F", f, err)
+	}
+}
@@ -0,0 +1,380 @@
+// Package execution manages the lifecycle of the account's orders: it tracks
+// every order it places until the order is executed or canceled, reports
+// fills from the WebSocket fill channel or from polling, cancels orders left
+// unfilled past a time to live and amends or replaces them on request.
+//
+//	m := execution.New(client, execution.DefaultConfig())
+//	go m.Run(ctx)
+//	order, err := m.Place(&rest.CreateOrderRequest{...})
+//	for e := range m.Events() {
+//		switch e.Type {
+//		case execution.EventFill:
+//			log.Printf("%s filled %d @ %d¢", e.Order.Ticker, e.Count, e.Price)
+//		case execution.EventExpired:
+//			log.Printf("%s expired with %d unfilled", e.Order.Ticker, e.Order.DecreaseCount)
+//		}
+//	}
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// ErrNotTracked is returned when amending, replacing or canceling an order
+// the manager is not tracking: one it did not place, or one already
+// executed or canceled.
+var ErrNotTracked = errors.New("execution: order not tracked")
+
+// ErrExecuted is returned by Replace when the order filled completely before
+// it could be canceled.
+var ErrExecuted = errors.New("execution: order already executed")
+
+// EventType identifies an order event.
+type EventType string
+
+const (
+	EventPlaced   EventType = "placed"   // The order was accepted
+	EventFill     EventType = "fill"     // Some of the order filled
+	EventAmended  EventType = "amended"  // The order's price or size changed
+	EventExecuted EventType = "executed" // The order filled completely
+	EventCanceled EventType = "canceled" // The order was canceled before filling completely
+	EventExpired  EventType = "expired"  // The manager canceled the order after its TTL
+	EventError    EventType = "error"    // Polling or canceling the order failed
+)
+
+// OrderEvent reports a change in one tracked order.
+type OrderEvent struct {
+	Type  EventType
+	Order rest.Order // Order state after the event
+	Count int        // Contracts filled, for fill events
+	Price int        // Average fill price in cents on the order's side, for fill events
+	Err   error      // For error events
+	Time  time.Time
+}
+
+// Config configures a Manager.
+type Config struct {
+	// TTL is how long an order may rest before the manager cancels what is
+	// left of it; 0 leaves orders resting until they fill or are canceled.
+	TTL time.Duration
+	// PollInterval is the time between polls of the tracked orders (default
+	// 5s). Fills arrive sooner when HandleFill is fed the fill channel.
+	PollInterval time.Duration
+	// Buffer is the size of the event channel (default 256).
+	Buffer int
+}
+
+// DefaultConfig returns a configuration that cancels orders left unfilled
+// for five minutes and polls every five seconds.
+func DefaultConfig() Config {
+	return Config{
+		TTL:          5 * time.Minute,
+		PollInterval: 5 * time.Second,
+		Buffer:       256,
+	}
+}
+
+// tracked is the manager's state of one open order.
+type tracked struct {
+	order   rest.Order
+	filled  int // Contracts reported in fill events
+	cost    int // Cents reported in fill events
+	expires time.Time
+}
+
+// Manager tracks the orders it places until they are executed or canceled.
+//
+// Events are delivered on a buffered channel that never blocks the manager:
+// when the consumer falls behind, events are dropped and counted by Dropped.
+type Manager struct {
+	client *rest.Client
+	cfg    Config
+	events chan OrderEvent
+
+	mu      sync.Mutex
+	open    map[string]*tracked // Order ID -> state
+	dropped atomic.Int64
+}
+
+// New creates a manager placing orders through client.
+func New(client *rest.Client, cfg Config) *Manager {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultConfig().PollInterval
+	}
+	if cfg.Buffer <= 0 {
+		cfg.Buffer = DefaultConfig().Buffer
+	}
+	return &Manager{
+		client: client,
+		cfg:    cfg,
+		events: make(chan OrderEvent, cfg.Buffer),
+		open:   make(map[string]*tracked),
+	}
+}
+
+// Events returns the stream of order events.
+func (m *Manager) Events() <-chan OrderEvent {
+	return m.events
+}
+
+// Dropped returns the number of events dropped because the stream was full.
+func (m *Manager) Dropped() int64 {
+	return m.dropped.Load()
+}
+
+// Open returns the tracked orders, oldest first.
+func (m *Manager) Open() []rest.Order {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	orders := make([]rest.Order, 0, len(m.open))
+	for _, t := range m.open {
+		orders = append(orders, t.order)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].CreatedTime != orders[j].CreatedTime {
+			return orders[i].CreatedTime < orders[j].CreatedTime
+		}
+		return orders[i].OrderID < orders[j].OrderID
+	})
+	return orders
+}
+
+// Place places an order and tracks it until it is executed or canceled. An
+// order that fills on placement is reported and never tracked.
+func (m *Manager) Place(req *rest.CreateOrderRequest) (*rest.Order, error) {
+	order, err := m.client.CreateOrder(req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := &tracked{order: *order}
+	if m.cfg.TTL > 0 {
+		t.expires = time.Now().Add(m.cfg.TTL)
+	}
+	m.open[order.OrderID] = t
+	m.emit(OrderEvent{Type: EventPlaced, Order: *order})
+	m.update(t, *order, EventCanceled)
+	return order, nil
+}
+
+// Cancel cancels a tracked order.
+func (m *Manager) Cancel(orderID string) error {
+	_, err := m.cancel(orderID)
+	return err
+}
+
+// cancel cancels a tracked order and returns its final state.
+func (m *Manager) cancel(orderID string) (*rest.Order, error) {
+	if _, ok := m.get(orderID); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotTracked, orderID)
+	}
+	order, err := m.client.CancelOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if t, ok := m.open[orderID]; ok {
+		m.update(t, *order, EventCanceled)
+	}
+	return order, nil
+}
+
+// Amend changes a tracked order's price (in cents on its side) and the
+// number of contracts left to fill. The order keeps its ID and, unlike
+// Replace, its place in the tracking: the TTL still counts from placement.
+func (m *Manager) Amend(orderID string, price, remaining int) (*rest.Order, error) {
+	t, ok := m.get(orderID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotTracked, orderID)
+	}
+
+	req := &rest.AmendOrderRequest{
+		Ticker: t.order.Ticker,
+		Action: t.order.Action,
+		Side:   t.order.Side,
+		Count:  t.order.TakerFillCount + t.order.MakerFillCount + remaining,
+	}
+	if t.order.Side == rest.SideNo {
+		req.NoPrice = price
+	} else {
+		req.YesPrice = price
+	}
+	order, err := m.client.AmendOrder(orderID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if t, ok := m.open[orderID]; ok {
+		m.emit(OrderEvent{Type: EventAmended, Order: *order})
+		m.update(t, *order, EventCanceled)
+	}
+	return order, nil
+}
+
+// Replace cancels a tracked order and places req in its place. If the order
+// filled completely before the cancel took effect, nothing is placed and
+// ErrExecuted is returned.
+func (m *Manager) Replace(orderID string, req *rest.CreateOrderRequest) (*rest.Order, error) {
+	old, err := m.cancel(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if old.Status == rest.OrderStatusExecuted {
+		return nil, fmt.Errorf("%w: %s", ErrExecuted, orderID)
+	}
+	return m.Place(req)
+}
+
+// HandleFill reports a fill received on the WebSocket fill channel. Fills
+// of orders the manager is not tracking are ignored.
+func (m *Manager) HandleFill(msg ws.FillMsg) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.open[msg.OrderID]
+	if !ok || msg.Count <= 0 {
+		return
+	}
+	price := msg.YesPrice
+	if t.order.Side == rest.SideNo {
+		price = msg.NoPrice
+	}
+
+	t.filled += msg.Count
+	t.cost += msg.Count * price
+	t.order.RemainingCount = max(t.order.RemainingCount-msg.Count, 0)
+	if msg.IsTaker {
+		t.order.TakerFillCount += msg.Count
+		t.order.TakerFillCost += msg.Count * price
+	} else {
+		t.order.MakerFillCount += msg.Count
+		t.order.MakerFillCost += msg.Count * price
+	}
+	m.emit(OrderEvent{Type: EventFill, Order: t.order, Count: msg.Count, Price: price, Time: msg.Time()})
+
+	if t.order.RemainingCount == 0 {
+		t.order.Status = rest.OrderStatusExecuted
+		delete(m.open, msg.OrderID)
+		m.emit(OrderEvent{Type: EventExecuted, Order: t.order})
+	}
+}
+
+// Poll refreshes every tracked order from the exchange, reporting fills and
+// cancellations not yet seen, and cancels the orders past their TTL.
+func (m *Manager) Poll() {
+	m.mu.Lock()
+	ids := make([]string, 0, len(m.open))
+	for id := range m.open {
+		ids = append(ids, id)
+	}
+	m.mu.Unlock()
+	sort.Strings(ids)
+
+	now := time.Now()
+	for _, id := range ids {
+		t, ok := m.get(id)
+		if !ok {
+			continue // Executed or canceled meanwhile
+		}
+
+		expired := !t.expires.IsZero() && now.After(t.expires)
+		var order *rest.Order
+		var err error
+		if expired {
+			order, err = m.client.CancelOrder(id)
+		} else {
+			order, err = m.client.GetOrder(id)
+		}
+
+		m.mu.Lock()
+		if t, ok := m.open[id]; ok {
+			switch {
+			case err != nil:
+				m.emit(OrderEvent{Type: EventError, Order: t.order, Err: err})
+			case expired:
+				m.update(t, *order, EventExpired)
+			default:
+				m.update(t, *order, EventCanceled)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+// Run polls the tracked orders every PollInterval until ctx is done.
+func (m *Manager) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			m.Poll()
+		}
+	}
+}
+
+// get returns a copy of a tracked order's state.
+func (m *Manager) get(orderID string) (tracked, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.open[orderID]
+	if !ok {
+		return tracked{}, false
+	}
+	return *t, true
+}
+
+// update applies an order's state from the exchange: fills not yet reported
+// become a fill event at their average price, and an order no longer resting
+// is reported as executed or with canceled and stops being tracked. Callers
+// hold m.mu.
+func (m *Manager) update(t *tracked, order rest.Order, canceled EventType) {
+	filled := order.TakerFillCount + order.MakerFillCount
+	cost := order.TakerFillCost + order.MakerFillCost
+	t.order = order
+	if n := filled - t.filled; n > 0 {
+		m.emit(OrderEvent{Type: EventFill, Order: order, Count: n, Price: (cost - t.cost) / n})
+		t.filled, t.cost = filled, cost
+	}
+
+	switch order.Status {
+	case rest.OrderStatusExecuted:
+		delete(m.open, order.OrderID)
+		m.emit(OrderEvent{Type: EventExecuted, Order: order})
+	case rest.OrderStatusCanceled:
+		delete(m.open, order.OrderID)
+		m.emit(OrderEvent{Type: canceled, Order: order})
+	}
+}
+
+// emit delivers an event without blocking. Callers hold m.mu.
+func (m *Manager) emit(e OrderEvent) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	select {
+	case m.events <- e:
+	default:
+		m.dropped.Add(1)
+	}
+}
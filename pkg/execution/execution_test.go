@@ -0,0 +1,171 @@
+package execution
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/mockexchange"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+const testTicker = "KXHIGHLAX-25DEC05-B62.5"
+
+func newTestManager(t *testing.T, cfg Config) (*mockexchange.Exchange, *Manager) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	x := mockexchange.New(1)
+	t.Cleanup(x.Close)
+	x.AddMarket(rest.Market{Ticker: testTicker, EventTicker: "KXHIGHLAX-25DEC05", YesBid: 40, YesAsk: 42})
+
+	return x, New(rest.New("test-key", privateKey, rest.WithBaseURL(x.URL())), cfg)
+}
+
+// drain returns the events delivered so far.
+func drain(m *Manager) []OrderEvent {
+	var events []OrderEvent
+	for {
+		select {
+		case e := <-m.Events():
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func types(events []OrderEvent) []EventType {
+	var ts []EventType
+	for _, e := range events {
+		ts = append(ts, e.Type)
+	}
+	return ts
+}
+
+func buyYes(count, price int) *rest.CreateOrderRequest {
+	return &rest.CreateOrderRequest{
+		Ticker: testTicker, Action: rest.OrderActionBuy, Side: rest.SideYes,
+		Type: rest.OrderTypeLimit, Count: count, YesPrice: price,
+	}
+}
+
+func TestManager_PollFills(t *testing.T) {
+	x, m := newTestManager(t, Config{})
+
+	order, err := m.Place(buyYes(10, 41))
+	if err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if open := m.Open(); len(open) != 1 || open[0].OrderID != order.OrderID {
+		t.Fatalf("Open() = %+v, want the resting order", open)
+	}
+
+	x.SetQuote(testTicker, 39, 41)
+	m.Poll()
+
+	events := drain(m)
+	if want := []EventType{EventPlaced, EventFill, EventExecuted}; !slices.Equal(types(events), want) {
+		t.Fatalf("events = %v, want %v", types(events), want)
+	}
+	if fill := events[1]; fill.Count != 10 || fill.Price != 41 {
+		t.Errorf("fill = %d @ %d, want 10 @ 41", fill.Count, fill.Price)
+	}
+	if open := m.Open(); len(open) != 0 {
+		t.Errorf("Open() after execution = %d orders, want 0", len(open))
+	}
+}
+
+func TestManager_FilledOnPlacement(t *testing.T) {
+	_, m := newTestManager(t, Config{})
+
+	if _, err := m.Place(buyYes(5, 45)); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	events := drain(m)
+	if want := []EventType{EventPlaced, EventFill, EventExecuted}; !slices.Equal(types(events), want) {
+		t.Fatalf("events = %v, want %v", types(events), want)
+	}
+	if fill := events[1]; fill.Count != 5 || fill.Price != 42 {
+		t.Errorf("fill = %d @ %d, want 5 @ 42", fill.Count, fill.Price)
+	}
+}
+
+func TestManager_HandleFillThenPoll(t *testing.T) {
+	x, m := newTestManager(t, Config{})
+
+	order, err := m.Place(buyYes(10, 41))
+	if err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	x.SetQuote(testTicker, 39, 41)
+
+	// The fill channel reports part of the execution before the poll sees
+	// all of it: only the rest is reported again
+	m.HandleFill(ws.FillMsg{OrderID: order.OrderID, MarketTicker: testTicker, Side: "yes", YesPrice: 41, NoPrice: 59, Count: 4})
+	m.Poll()
+
+	events := drain(m)
+	if want := []EventType{EventPlaced, EventFill, EventFill, EventExecuted}; !slices.Equal(types(events), want) {
+		t.Fatalf("events = %v, want %v", types(events), want)
+	}
+	if events[1].Count != 4 || events[2].Count != 6 || events[2].Price != 41 {
+		t.Errorf("fills = %d, %d @ %d, want 4, 6 @ 41", events[1].Count, events[2].Count, events[2].Price)
+	}
+}
+
+func TestManager_TTL(t *testing.T) {
+	_, m := newTestManager(t, Config{TTL: time.Millisecond})
+
+	if _, err := m.Place(buyYes(10, 38)); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	m.Poll()
+
+	events := drain(m)
+	if want := []EventType{EventPlaced, EventExpired}; !slices.Equal(types(events), want) {
+		t.Fatalf("events = %v, want %v", types(events), want)
+	}
+	if o := events[1].Order; o.Status != rest.OrderStatusCanceled || o.DecreaseCount != 10 {
+		t.Errorf("expired order = %+v, want canceled with 10 unfilled", o)
+	}
+}
+
+func TestManager_AmendAndReplace(t *testing.T) {
+	_, m := newTestManager(t, Config{})
+
+	order, err := m.Place(buyYes(10, 38))
+	if err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	amended, err := m.Amend(order.OrderID, 39, 6)
+	if err != nil {
+		t.Fatalf("Amend() error = %v", err)
+	}
+	if amended.YesPrice != 39 || amended.RemainingCount != 6 {
+		t.Errorf("amended = %d @ %d, want 6 @ 39", amended.RemainingCount, amended.YesPrice)
+	}
+
+	replaced, err := m.Replace(order.OrderID, buyYes(6, 40))
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if open := m.Open(); len(open) != 1 || open[0].OrderID != replaced.OrderID {
+		t.Errorf("Open() = %+v, want only the replacement", open)
+	}
+	if got, want := types(drain(m)), []EventType{EventPlaced, EventAmended, EventCanceled, EventPlaced}; !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	if _, err := m.Amend(order.OrderID, 40, 6); !errors.Is(err, ErrNotTracked) {
+		t.Errorf("Amend(canceled order) error = %v, want ErrNotTracked", err)
+	}
+}
@@ -0,0 +1,237 @@
+// Package fees models Kalshi trading fees so live EV checks and backtests
+// price trades the same way.
+//
+// A Schedule holds fee rules keyed by series ticker with effective dates, so a
+// fee change (or a series with a different fee tier, or maker rebates) only
+// needs a new rule rather than code changes.
+package fees
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Basis is how a fee rate is applied.
+type Basis string
+
+const (
+	// BasisWinnings charges the rate on the gross profit of winning contracts
+	// at settlement (the 7% model used by the backtests).
+	BasisWinnings Basis = "winnings"
+	// BasisSpread charges rate * contracts * P * (1-P) at fill, rounded up
+	// to the next cent (Kalshi's published trading fee formula).
+	BasisSpread Basis = "spread"
+)
+
+// Liquidity is whether an order added or removed liquidity.
+type Liquidity string
+
+const (
+	Taker Liquidity = "taker"
+	Maker Liquidity = "maker"
+)
+
+// AnySeries is the series key of rules that apply to every series.
+const AnySeries = "*"
+
+// Rule is the fee tier of one series from its effective date onwards.
+// A negative MakerRate is a maker rebate.
+type Rule struct {
+	Series    string
+	Effective time.Time
+	Basis     Basis
+	TakerRate float64
+	MakerRate float64
+}
+
+// Rate returns the fee rate for the given liquidity.
+func (r Rule) Rate(liq Liquidity) float64 {
+	if liq == Maker {
+		return r.MakerRate
+	}
+	return r.TakerRate
+}
+
+// EntryFee returns the fee in dollars charged when the order fills.
+func (r Rule) EntryFee(liq Liquidity, contracts float64, priceCents int) float64 {
+	if r.Basis != BasisSpread {
+		return 0
+	}
+	p := float64(priceCents) / 100
+	return math.Ceil(r.Rate(liq)*contracts*p*(1-p)*100) / 100
+}
+
+// SettlementFee returns the fee in dollars charged when the position settles.
+func (r Rule) SettlementFee(liq Liquidity, contracts float64, priceCents int, won bool) float64 {
+	if r.Basis != BasisWinnings || !won {
+		return 0
+	}
+	return r.Rate(liq) * contracts * float64(100-priceCents) / 100
+}
+
+// NetProfit returns the settled P&L in dollars of buying contracts at
+// priceCents, after fees.
+func (r Rule) NetProfit(liq Liquidity, contracts float64, priceCents int, won bool) float64 {
+	gross := -contracts * float64(priceCents) / 100
+	if won {
+		gross = contracts * float64(100-priceCents) / 100
+	}
+	return gross - r.EntryFee(liq, contracts, priceCents) - r.SettlementFee(liq, contracts, priceCents, won)
+}
+
+// ExitProfit returns the P&L in dollars of buying contracts at entryCents and
+// selling them before settlement at exitCents, after fees. Both fills pay the
+// per-fill fee; under BasisWinnings the rate of the exit fill is charged on
+// any gross gain, as if the position had settled for that amount.
+func (r Rule) ExitProfit(entryLiq, exitLiq Liquidity, contracts float64, entryCents, exitCents int) float64 {
+	gross := contracts * float64(exitCents-entryCents) / 100
+	fee := r.EntryFee(entryLiq, contracts, entryCents) + r.EntryFee(exitLiq, contracts, exitCents)
+	if r.Basis == BasisWinnings && gross > 0 {
+		fee += r.Rate(exitLiq) * gross
+	}
+	return gross - fee
+}
+
+// ExpectedValue returns the expected P&L in dollars of buying contracts at
+// priceCents when the contract wins with probability winProb.
+func (r Rule) ExpectedValue(liq Liquidity, contracts float64, priceCents int, winProb float64) float64 {
+	return winProb*r.NetProfit(liq, contracts, priceCents, true) +
+		(1-winProb)*r.NetProfit(liq, contracts, priceCents, false)
+}
+
+// Schedule is a set of fee rules keyed by series and effective date.
+type Schedule struct {
+	rules []Rule
+}
+
+// NewSchedule creates a schedule from rules.
+func NewSchedule(rules ...Rule) *Schedule {
+	s := &Schedule{rules: append([]Rule(nil), rules...)}
+	sort.SliceStable(s.rules, func(i, j int) bool {
+		return s.rules[i].Effective.Before(s.rules[j].Effective)
+	})
+	return s
+}
+
+// DefaultSchedule returns the flat 7%-of-winnings schedule the backtests
+// have historically assumed for every series.
+func DefaultSchedule() *Schedule {
+	return NewSchedule(Rule{
+		Series:    AnySeries,
+		Basis:     BasisWinnings,
+		TakerRate: 0.07,
+		MakerRate: 0.07,
+	})
+}
+
+// Rule returns the rule in effect for series at the given time. Rules for the
+// exact series take precedence over AnySeries rules; a schedule with no
+// matching rule charges no fees.
+func (s *Schedule) Rule(series string, at time.Time) Rule {
+	if s == nil {
+		return Rule{Series: series}
+	}
+	series = strings.ToUpper(series)
+
+	var exact, wildcard *Rule
+	for i := range s.rules {
+		r := &s.rules[i]
+		if r.Effective.After(at) {
+			break
+		}
+		switch r.Series {
+		case series:
+			exact = r
+		case AnySeries:
+			wildcard = r
+		}
+	}
+
+	switch {
+	case exact != nil:
+		return *exact
+	case wildcard != nil:
+		return *wildcard
+	}
+	return Rule{Series: series}
+}
+
+// RuleForTicker returns the rule in effect for the series of a market or
+// event ticker.
+func (s *Schedule) RuleForTicker(ticker string, at time.Time) Rule {
+	return s.Rule(SeriesFromTicker(ticker), at)
+}
+
+// SeriesFromTicker returns the series of a market or event ticker, e.g.
+// "KXHIGHLAX" for "KXHIGHLAX-25DEC05-B62.5".
+func SeriesFromTicker(ticker string) string {
+	series, _, _ := strings.Cut(strings.ToUpper(ticker), "-")
+	return series
+}
+
+// ruleJSON is the on-disk form of a Rule.
+type ruleJSON struct {
+	Series    string  `json:"series"`
+	Effective string  `json:"effective"` // YYYY-MM-DD; empty = always
+	Basis     Basis   `json:"basis"`
+	TakerRate float64 `json:"taker_rate"`
+	MakerRate float64 `json:"maker_rate"`
+}
+
+// ParseSchedule parses a JSON array of rules:
+//
+//	[
+//	  {"series": "*", "basis": "winnings", "taker_rate": 0.07, "maker_rate": 0.07},
+//	  {"series": "KXHIGHNY", "effective": "2025-02-01", "basis": "spread", "taker_rate": 0.07, "maker_rate": -0.0025}
+//	]
+func ParseSchedule(data []byte) (*Schedule, error) {
+	var raw []ruleJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse fee schedule: %w", err)
+	}
+
+	rules := make([]Rule, 0, len(raw))
+	for i, r := range raw {
+		if r.Series == "" {
+			return nil, fmt.Errorf("fee rule %d: missing series", i)
+		}
+		switch r.Basis {
+		case BasisWinnings, BasisSpread:
+		case "":
+			r.Basis = BasisWinnings
+		default:
+			return nil, fmt.Errorf("fee rule %d: unknown basis %q", i, r.Basis)
+		}
+
+		rule := Rule{
+			Series:    strings.ToUpper(r.Series),
+			Basis:     r.Basis,
+			TakerRate: r.TakerRate,
+			MakerRate: r.MakerRate,
+		}
+		if r.Effective != "" {
+			t, err := time.Parse("2006-01-02", r.Effective)
+			if err != nil {
+				return nil, fmt.Errorf("fee rule %d: invalid effective date: %w", i, err)
+			}
+			rule.Effective = t
+		}
+		rules = append(rules, rule)
+	}
+
+	return NewSchedule(rules...), nil
+}
+
+// LoadSchedule reads a fee schedule file (see ParseSchedule).
+func LoadSchedule(path string) (*Schedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read fee schedule: %w", err)
+	}
+	return ParseSchedule(data)
+}
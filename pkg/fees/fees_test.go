@@ -0,0 +1,121 @@
+package fees
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestRule_Winnings(t *testing.T) {
+	r := DefaultSchedule().Rule("KXHIGHLAX", time.Now())
+
+	// 100 contracts at 60¢: win $40 gross, 7% fee = $2.80
+	if got := r.NetProfit(Taker, 100, 60, true); !approx(got, 37.2) {
+		t.Errorf("NetProfit(win) = %v, want 37.2", got)
+	}
+	if got := r.NetProfit(Taker, 100, 60, false); !approx(got, -60) {
+		t.Errorf("NetProfit(loss) = %v, want -60", got)
+	}
+}
+
+func TestRule_SpreadRoundsUp(t *testing.T) {
+	r := Rule{Basis: BasisSpread, TakerRate: 0.07}
+
+	// 0.07 * 10 * 0.5 * 0.5 = 0.175 -> $0.18
+	if got := r.EntryFee(Taker, 10, 50); !approx(got, 0.18) {
+		t.Errorf("EntryFee() = %v, want 0.18", got)
+	}
+}
+
+func TestRule_MakerRebate(t *testing.T) {
+	r := Rule{Basis: BasisSpread, TakerRate: 0.07, MakerRate: -0.01}
+
+	if got := r.EntryFee(Maker, 100, 50); got >= 0 {
+		t.Errorf("EntryFee(Maker) = %v, want rebate < 0", got)
+	}
+	if maker, taker := r.NetProfit(Maker, 100, 50, true), r.NetProfit(Taker, 100, 50, true); maker <= taker {
+		t.Errorf("maker profit %v <= taker profit %v", maker, taker)
+	}
+}
+
+func TestRule_ExitProfit(t *testing.T) {
+	winnings := DefaultSchedule().Rule("KXHIGHLAX", time.Now())
+
+	// 100 contracts 40¢ -> 70¢: $30 gross, 7% = $2.10
+	if got := winnings.ExitProfit(Taker, Taker, 100, 40, 70); !approx(got, 27.9) {
+		t.Errorf("ExitProfit(gain) = %v, want 27.9", got)
+	}
+	// Losses pay no winnings fee
+	if got := winnings.ExitProfit(Taker, Taker, 100, 40, 25); !approx(got, -15) {
+		t.Errorf("ExitProfit(loss) = %v, want -15", got)
+	}
+
+	// Spread basis charges both fills: 0.07*10*.25 -> $0.18 each
+	spread := Rule{Basis: BasisSpread, TakerRate: 0.07}
+	if got := spread.ExitProfit(Taker, Taker, 10, 50, 50); !approx(got, -0.36) {
+		t.Errorf("ExitProfit(spread) = %v, want -0.36", got)
+	}
+}
+
+func TestSchedule_EffectiveDatesAndSeries(t *testing.T) {
+	s, err := ParseSchedule([]byte(`[
+		{"series": "*", "basis": "winnings", "taker_rate": 0.07, "maker_rate": 0.07},
+		{"series": "*", "effective": "2025-06-01", "basis": "winnings", "taker_rate": 0.05, "maker_rate": 0.05},
+		{"series": "kxhighny", "effective": "2025-03-01", "basis": "spread", "taker_rate": 0.07, "maker_rate": 0}
+	]`))
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	tests := []struct {
+		ticker string
+		date   string
+		basis  Basis
+		rate   float64
+	}{
+		{"KXHIGHLAX-25JAN05-B62.5", "2025-01-05", BasisWinnings, 0.07},
+		{"KXHIGHLAX-25JUL05-B62.5", "2025-07-05", BasisWinnings, 0.05},
+		{"KXHIGHNY-25FEB05-B40.5", "2025-02-05", BasisWinnings, 0.07},
+		{"KXHIGHNY-25MAR05-B40.5", "2025-03-05", BasisSpread, 0.07},
+		{"KXHIGHNY-25JUL05-B80.5", "2025-07-05", BasisSpread, 0.07},
+	}
+
+	for _, tt := range tests {
+		at, _ := time.Parse("2006-01-02", tt.date)
+		r := s.RuleForTicker(tt.ticker, at)
+		if r.Basis != tt.basis || r.TakerRate != tt.rate {
+			t.Errorf("RuleForTicker(%s, %s) = %s/%v, want %s/%v",
+				tt.ticker, tt.date, r.Basis, r.TakerRate, tt.basis, tt.rate)
+		}
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	inputs := []string{
+		`{}`,
+		`[{"basis": "winnings"}]`,
+		`[{"series": "*", "basis": "bogus"}]`,
+		`[{"series": "*", "effective": "June"}]`,
+	}
+	for _, in := range inputs {
+		if _, err := ParseSchedule([]byte(in)); err == nil {
+			t.Errorf("ParseSchedule(%s) error = nil, want error", in)
+		}
+	}
+}
+
+func TestRule_ExpectedValue(t *testing.T) {
+	r := DefaultSchedule().Rule(AnySeries, time.Now())
+
+	// At 95¢ a 90% favorite loses money; at 95.8% it is marginally positive.
+	if ev := r.ExpectedValue(Taker, 100, 95, 0.90); ev >= 0 {
+		t.Errorf("ExpectedValue(p=0.90) = %v, want < 0", ev)
+	}
+	if ev := r.ExpectedValue(Taker, 100, 95, 0.958); ev <= 0 {
+		t.Errorf("ExpectedValue(p=0.958) = %v, want > 0", ev)
+	}
+}
@@ -0,0 +1,185 @@
+package market
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+// MarketStrike returns the whole-degree range a bracket market settles YES
+// on. The exchange's floor_strike and cap_strike are used when the market
+// gives a strike_type to read them by (tails are exclusive unless it says
+// _or_equal, and fractional bounds round inward); otherwise the range is
+// parsed from the YES subtitle, the subtitle (see ParseBracket) or a
+// B<mid> ticker suffix such as B56.5
+func MarketStrike(m rest.Market) (Strike, error) {
+	switch m.StrikeType {
+	case "between":
+		return rangeStrike(m.FloorStrike, m.CapStrike)
+	case "greater":
+		return Strike{Floor: floorAbove(m.FloorStrike, false), Cap: OpenCap}, nil
+	case "greater_or_equal":
+		return Strike{Floor: floorAbove(m.FloorStrike, true), Cap: OpenCap}, nil
+	case "less":
+		return Strike{Floor: OpenFloor, Cap: capBelow(m.CapStrike, false)}, nil
+	case "less_or_equal":
+		return Strike{Floor: OpenFloor, Cap: capBelow(m.CapStrike, true)}, nil
+	}
+
+	// Older payloads leave the type out, and there an absent bound and a 0°
+	// one both decode as 0, so the subtitle decides
+
+	var errs []string
+	for _, text := range []string{m.YesSubTitle, m.Subtitle} {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		s, err := ParseBracket(text)
+		if err == nil {
+			return s, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	if i := strings.LastIndex(m.Ticker, "-"); i >= 0 && strings.HasPrefix(m.Ticker[i+1:], "B") {
+		if mid, err := strconv.ParseFloat(m.Ticker[i+2:], 64); err == nil {
+			return rangeStrike(mid-0.5, mid+0.5)
+		}
+	}
+	if len(errs) == 0 {
+		return Strike{}, fmt.Errorf("market %s: no strikes or subtitle", m.Ticker)
+	}
+	return Strike{}, fmt.Errorf("market %s: %s", m.Ticker, strings.Join(errs, "; "))
+}
+
+var (
+	// Degree signs and words, dropped before parsing
+	degreePattern = regexp.MustCompile(`°|º|˚|\b(degrees?|deg|fahrenheit)\b`)
+	// A bare F after a number ("57F", "57 F")
+	fahrenheitPattern = regexp.MustCompile(`(\d)\s*f\b`)
+	// Celsius, which no temperature series settles in
+	celsiusPattern = regexp.MustCompile(`(°|º|˚)\s*c\b|\bdeg(rees?)?\s*c\b|\bcelsius\b|\d\s*c\b`)
+	// A number, signed unless the minus joins a range ("56-57")
+	numberPattern = regexp.MustCompile(`(^|[^\d.])(-?)(\d+(?:\.\d+)?)`)
+)
+
+// Phrases of the tails, inclusive ones first so "55 or below" isn't read as
+// "below 55"
+var (
+	belowInclusive = []string{"or below", "or lower", "or less", "or under", "and below", "and under", "at most", "no more than", "≤", "<="}
+	belowStrict    = []string{"below", "under", "less than", "lower than", "<"}
+	aboveInclusive = []string{"or above", "or higher", "or more", "or over", "and above", "and over", "at least", "no less than", "≥", ">=", "+"}
+	aboveStrict    = []string{"above", "over", "greater than", "more than", "higher than", ">"}
+)
+
+// ParseBracket parses a bracket subtitle into the whole-degree range it
+// settles YES on. It reads ranges ("56-57", "56° to 57°", "between 56 and
+// 57"), tails ("55° or below", "below 56", "<56", "64 or above", "64+",
+// "greater than 63"), single degrees ("56°") and half-degree bounds
+// ("55.5° to 57.5°" is 56-57), with or without °, F or "degrees". Strict
+// tails exclude their bound and fractional bounds round inward
+func ParseBracket(text string) (Strike, error) {
+	s := strings.ToLower(strings.TrimSpace(text))
+	for _, dash := range []string{"−", "–", "—"} {
+		s = strings.ReplaceAll(s, dash, "-")
+	}
+	if celsiusPattern.MatchString(s) {
+		return Strike{}, fmt.Errorf("bracket %q: not in °F", text)
+	}
+	s = degreePattern.ReplaceAllString(s, " ")
+	s = fahrenheitPattern.ReplaceAllString(s, "$1")
+
+	var nums []float64
+	for _, m := range numberPattern.FindAllStringSubmatch(s, -1) {
+		v, err := strconv.ParseFloat(m[2]+m[3], 64)
+		if err != nil {
+			return Strike{}, fmt.Errorf("bracket %q: %w", text, err)
+		}
+		nums = append(nums, v)
+	}
+
+	switch len(nums) {
+	case 2:
+		if nums[0] > nums[1] {
+			nums[0], nums[1] = nums[1], nums[0]
+		}
+		strike, err := rangeStrike(nums[0], nums[1])
+		if err != nil {
+			return Strike{}, fmt.Errorf("bracket %q: %w", text, err)
+		}
+		return strike, nil
+	case 1:
+	default:
+		return Strike{}, fmt.Errorf("bracket %q: want one or two temperatures, found %d", text, len(nums))
+	}
+
+	v := nums[0]
+	switch {
+	case containsAny(s, belowInclusive):
+		return Strike{Floor: OpenFloor, Cap: capBelow(v, true)}, nil
+	case containsAny(s, aboveInclusive):
+		return Strike{Floor: floorAbove(v, true), Cap: OpenCap}, nil
+	case containsAny(s, belowStrict):
+		return Strike{Floor: OpenFloor, Cap: capBelow(v, false)}, nil
+	case containsAny(s, aboveStrict):
+		return Strike{Floor: floorAbove(v, false), Cap: OpenCap}, nil
+	}
+	strike, err := rangeStrike(v, v)
+	if err != nil {
+		return Strike{}, fmt.Errorf("bracket %q: %w", text, err)
+	}
+	return strike, nil
+}
+
+// String returns the bracket as the exchange words it, e.g. "56-57°",
+// "55° or below" or "64° or above"
+func (s Strike) String() string {
+	switch {
+	case s.Floor == OpenFloor && s.Cap == OpenCap:
+		return "any"
+	case s.Floor == OpenFloor:
+		return fmt.Sprintf("%d° or below", s.Cap)
+	case s.Cap == OpenCap:
+		return fmt.Sprintf("%d° or above", s.Floor)
+	case s.Floor == s.Cap:
+		return fmt.Sprintf("%d°", s.Floor)
+	}
+	return fmt.Sprintf("%d-%d°", s.Floor, s.Cap)
+}
+
+// rangeStrike returns the whole degrees from lo to hi inclusive
+func rangeStrike(lo, hi float64) (Strike, error) {
+	s := Strike{Floor: int(math.Ceil(lo)), Cap: int(math.Floor(hi))}
+	if s.Floor > s.Cap {
+		return Strike{}, fmt.Errorf("no whole degree from %g to %g", lo, hi)
+	}
+	return s, nil
+}
+
+// floorAbove returns the lowest whole degree above v, or at v if inclusive
+func floorAbove(v float64, inclusive bool) int {
+	if v == math.Trunc(v) && !inclusive {
+		return int(v) + 1
+	}
+	return int(math.Ceil(v))
+}
+
+// capBelow returns the highest whole degree below v, or at v if inclusive
+func capBelow(v float64, inclusive bool) int {
+	if v == math.Trunc(v) && !inclusive {
+		return int(v) - 1
+	}
+	return int(math.Floor(v))
+}
+
+func containsAny(s string, phrases []string) bool {
+	for _, p := range phrases {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+	return false
+}
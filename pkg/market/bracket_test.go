@@ -0,0 +1,179 @@
+package market
+
+import (
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+func TestParseBracket(t *testing.T) {
+	tests := []struct {
+		text string
+		want Strike
+	}{
+		// Middle brackets
+		{"56-57", Strike{56, 57}},
+		{"56° to 57°", Strike{56, 57}},
+		{"56°F - 57°F", Strike{56, 57}},
+		{"56F-57F", Strike{56, 57}},
+		{"56 F to 57 F", Strike{56, 57}},
+		{"56–57°", Strike{56, 57}},
+		{"56 — 57", Strike{56, 57}},
+		{"56º to 57º", Strike{56, 57}},
+		{"56 degrees to 57 degrees", Strike{56, 57}},
+		{"56 to 57 degrees Fahrenheit", Strike{56, 57}},
+		{"Between 56 and 57", Strike{56, 57}},
+		{"57-56", Strike{56, 57}},
+		{"  56° TO 57°  ", Strike{56, 57}},
+		{"56°", Strike{56, 56}},
+
+		// Half-degree bounds round inward
+		{"55.5° to 57.5°", Strike{56, 57}},
+		{"55.5-56.5", Strike{56, 56}},
+		{"56.0 to 57.0", Strike{56, 57}},
+
+		// Lower tails
+		{"55° or below", Strike{OpenFloor, 55}},
+		{"55 or below", Strike{OpenFloor, 55}},
+		{"55°F or lower", Strike{OpenFloor, 55}},
+		{"55 or less", Strike{OpenFloor, 55}},
+		{"55 degrees and under", Strike{OpenFloor, 55}},
+		{"at most 55°", Strike{OpenFloor, 55}},
+		{"≤55°", Strike{OpenFloor, 55}},
+		{"<=55", Strike{OpenFloor, 55}},
+		{"55.5° or below", Strike{OpenFloor, 55}},
+		{"below 56", Strike{OpenFloor, 55}},
+		{"Less than 56°", Strike{OpenFloor, 55}},
+		{"under 56°F", Strike{OpenFloor, 55}},
+		{"<56", Strike{OpenFloor, 55}},
+		{"below 55.5°", Strike{OpenFloor, 55}},
+
+		// Upper tails
+		{"64° or above", Strike{64, OpenCap}},
+		{"64 or higher", Strike{64, OpenCap}},
+		{"64°F or more", Strike{64, OpenCap}},
+		{"64 degrees and above", Strike{64, OpenCap}},
+		{"at least 64", Strike{64, OpenCap}},
+		{"≥64°", Strike{64, OpenCap}},
+		{">=64", Strike{64, OpenCap}},
+		{"64+", Strike{64, OpenCap}},
+		{"64°+", Strike{64, OpenCap}},
+		{"63.5° or above", Strike{64, OpenCap}},
+		{"above 63", Strike{64, OpenCap}},
+		{"Greater than 63°", Strike{64, OpenCap}},
+		{"more than 63", Strike{64, OpenCap}},
+		{"over 63°F", Strike{64, OpenCap}},
+		{">63", Strike{64, OpenCap}},
+		{"above 63.5°", Strike{64, OpenCap}},
+
+		// Below zero, as LOW brackets in the north can be
+		{"-5 to -4", Strike{-5, -4}},
+		{"-5°--4°", Strike{-5, -4}},
+		{"−1° to 0°", Strike{-1, 0}},
+		{"-3° or below", Strike{OpenFloor, -3}},
+		{"<-2", Strike{OpenFloor, -3}},
+		{"0 or above", Strike{0, OpenCap}},
+	}
+	for _, tt := range tests {
+		got, err := ParseBracket(tt.text)
+		if err != nil {
+			t.Errorf("ParseBracket(%q) error: %v", tt.text, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseBracket(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseBracket_Errors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"Will it be hot?",
+		"56 to 57 to 58",
+		"13° to 14°C",
+		"13 degrees C or above",
+		"Below 10 Celsius",
+		"56.2 to 56.8",
+	} {
+		if got, err := ParseBracket(text); err == nil {
+			t.Errorf("ParseBracket(%q) = %v, want an error", text, got)
+		}
+	}
+}
+
+func TestMarketStrike(t *testing.T) {
+	tests := []struct {
+		name   string
+		market rest.Market
+		want   Strike
+	}{
+		{"between", rest.Market{StrikeType: "between", FloorStrike: 56, CapStrike: 57}, Strike{56, 57}},
+		{"between half degrees", rest.Market{StrikeType: "between", FloorStrike: 55.5, CapStrike: 57.5}, Strike{56, 57}},
+		{"between below zero", rest.Market{StrikeType: "between", FloorStrike: -2, CapStrike: -1}, Strike{-2, -1}},
+		{"between from zero", rest.Market{StrikeType: "between", FloorStrike: 0, CapStrike: 1}, Strike{0, 1}},
+		{"greater", rest.Market{StrikeType: "greater", FloorStrike: 63}, Strike{64, OpenCap}},
+		{"greater half degree", rest.Market{StrikeType: "greater", FloorStrike: 63.5}, Strike{64, OpenCap}},
+		{"greater or equal", rest.Market{StrikeType: "greater_or_equal", FloorStrike: 64}, Strike{64, OpenCap}},
+		{"greater than zero", rest.Market{StrikeType: "greater", FloorStrike: 0}, Strike{1, OpenCap}},
+		{"less", rest.Market{StrikeType: "less", CapStrike: 56}, Strike{OpenFloor, 55}},
+		{"less half degree", rest.Market{StrikeType: "less", CapStrike: 55.5}, Strike{OpenFloor, 55}},
+		{"less or equal", rest.Market{StrikeType: "less_or_equal", CapStrike: 55}, Strike{OpenFloor, 55}},
+		{"no type, range", rest.Market{FloorStrike: 56, CapStrike: 57, Subtitle: "56-57"}, Strike{56, 57}},
+		{"no type, upper tail", rest.Market{FloorStrike: 63, Subtitle: "64° or above"}, Strike{64, OpenCap}},
+		{"no type, zero bound", rest.Market{CapStrike: 1, Subtitle: "0° or below"}, Strike{OpenFloor, 0}},
+		{"no type, zero range", rest.Market{CapStrike: 1, Subtitle: "0° to 1°"}, Strike{0, 1}},
+		{"yes subtitle", rest.Market{YesSubTitle: "55° or below", Subtitle: "56-57"}, Strike{OpenFloor, 55}},
+		{"subtitle", rest.Market{Subtitle: "64° or above"}, Strike{64, OpenCap}},
+		{"unreadable yes subtitle", rest.Market{YesSubTitle: "Hot", Subtitle: "58-59"}, Strike{58, 59}},
+		{"ticker", rest.Market{Ticker: "KXHIGHLAX-25DEC18-B56.5"}, Strike{56, 57}},
+		{"unknown type", rest.Market{StrikeType: "custom", YesSubTitle: "60° to 61°"}, Strike{60, 61}},
+	}
+	for _, tt := range tests {
+		got, err := MarketStrike(tt.market)
+		if err != nil {
+			t.Errorf("%s: MarketStrike() error: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: MarketStrike() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	for _, m := range []rest.Market{
+		{Ticker: "KXHIGHLAX-25DEC18-T63"},
+		{Ticker: "KXHIGHLAX-25DEC18-X", YesSubTitle: "Hot"},
+		{StrikeType: "between", FloorStrike: 56.2, CapStrike: 56.8},
+		{FloorStrike: 56, CapStrike: 57}, // No type and nothing to read it from
+	} {
+		if got, err := MarketStrike(m); err == nil {
+			t.Errorf("MarketStrike(%+v) = %v, want an error", m, got)
+		}
+	}
+}
+
+func TestStrike_String(t *testing.T) {
+	tests := []struct {
+		strike Strike
+		want   string
+	}{
+		{Strike{56, 57}, "56-57°"},
+		{Strike{56, 56}, "56°"},
+		{Strike{OpenFloor, 55}, "55° or below"},
+		{Strike{64, OpenCap}, "64° or above"},
+		{Strike{OpenFloor, OpenCap}, "any"},
+		{Strike{-5, -4}, "-5--4°"},
+	}
+	for _, tt := range tests {
+		if got := tt.strike.String(); got != tt.want {
+			t.Errorf("%v.String() = %q, want %q", [2]int{tt.strike.Floor, tt.strike.Cap}, got, tt.want)
+		}
+		if tt.strike.Floor == OpenFloor && tt.strike.Cap == OpenCap {
+			continue
+		}
+		// What String prints parses back
+		if got, err := ParseBracket(tt.strike.String()); err != nil || got != tt.strike {
+			t.Errorf("ParseBracket(%q) = %v, %v, want %v", tt.strike.String(), got, err, tt.strike)
+		}
+	}
+}
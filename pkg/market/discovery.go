@@ -0,0 +1,277 @@
+package market
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// TempSeries is a daily temperature series listed on the exchange, matched
+// to the station it settles on
+type TempSeries struct {
+	Ticker string             // Series ticker, e.g. KXHIGHLAX
+	Title  string             // e.g. "Highest temperature in Los Angeles today?"
+	Type   weather.MarketType // HIGH or LOW
+	Code   string             // Station code, e.g. LAX ("" = no registered station)
+	Layout Layout             // Brackets of the first open event (zero without one)
+
+	Station *weather.Station        `json:"-"` // nil = no registered station
+	Events  []rest.EventWithMarkets `json:"-"` // Open events with their markets, by ticker
+}
+
+// Catalog is the daily temperature series found on the exchange
+type Catalog struct {
+	Series []TempSeries // By ticker
+	Found  time.Time
+}
+
+// Discover lists the exchange's series, keeps the daily temperature ones
+// (see ClassifySeries), matches each to its station (see MatchStation) and
+// reads its open events and their bracket layout. A series whose events
+// can't be fetched is kept without them and its error joined into the
+// returned one, so a partial catalog is still usable
+func Discover(client *rest.Client) (*Catalog, error) {
+	series, err := client.GetSeriesList("", "")
+	if err != nil {
+		return nil, fmt.Errorf("list series: %w", err)
+	}
+
+	cat := &Catalog{Found: time.Now()}
+	var errs []error
+	for _, s := range series {
+		marketType, ok := ClassifySeries(s)
+		if !ok {
+			continue
+		}
+		ts := TempSeries{Ticker: s.Ticker, Title: s.Title, Type: marketType}
+		ts.Code, ts.Station = MatchStation(s, marketType)
+
+		events, err := client.GetAllEvents(rest.GetEventsParams{
+			SeriesTicker:      s.Ticker,
+			Status:            "open",
+			WithNestedMarkets: true,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("events of %s: %w", s.Ticker, err))
+		}
+		sort.Slice(events, func(i, j int) bool { return events[i].EventTicker < events[j].EventTicker })
+		ts.Events = events
+		for _, e := range events {
+			if len(e.Markets) > 0 {
+				ts.Layout = InferLayout(EventStrikes(e.Markets))
+				break
+			}
+		}
+		cat.Series = append(cat.Series, ts)
+	}
+	sort.Slice(cat.Series, func(i, j int) bool { return cat.Series[i].Ticker < cat.Series[j].Ticker })
+	return cat, errors.Join(errs...)
+}
+
+// EventStrikes returns the strikes of an event's markets, leaving out any
+// whose bracket can't be read (see MarketStrike)
+func EventStrikes(markets []rest.Market) Strikes {
+	strikes := make(Strikes, 0, len(markets))
+	for _, m := range markets {
+		if s, err := MarketStrike(m); err == nil {
+			strikes = append(strikes, s)
+		}
+	}
+	return strikes
+}
+
+// Series ticker prefixes of the daily temperature markets, longest first
+var (
+	highPrefixes = []string{"KXHIGH", "HIGH"}
+	lowPrefixes  = []string{"KXLOWT", "KXLOW", "LOWT", "LOW"}
+)
+
+// ClassifySeries reports whether s is a daily temperature series and
+// whether it settles on the day's high or low. A series is one if its
+// ticker has a KXHIGH or KXLOWT prefix or its title speaks of temperature;
+// the type comes from the ticker prefix (KXHIGH*, KXLOWT*, HIGH*, LOW*) or
+// else the title ("Highest temperature in ..."). Series listed at another
+// frequency, e.g. monthly highs, are not
+func ClassifySeries(s rest.Series) (weather.MarketType, bool) {
+	if s.Frequency != "" && !strings.EqualFold(s.Frequency, "daily") {
+		return "", false
+	}
+	ticker, title := strings.ToUpper(s.Ticker), strings.ToLower(s.Title)
+	if !strings.HasPrefix(ticker, "KXHIGH") && !strings.HasPrefix(ticker, "KXLOWT") && !strings.Contains(title, "temp") {
+		return "", false
+	}
+
+	switch {
+	case seriesCity(ticker, weather.MarketTypeHigh) != "":
+		return weather.MarketTypeHigh, true
+	case seriesCity(ticker, weather.MarketTypeLow) != "":
+		return weather.MarketTypeLow, true
+	}
+	for _, w := range []string{"highest", "high temp", "maximum"} {
+		if strings.Contains(title, w) {
+			return weather.MarketTypeHigh, true
+		}
+	}
+	for _, w := range []string{"lowest", "low temp", "minimum"} {
+		if strings.Contains(title, w) {
+			return weather.MarketTypeLow, true
+		}
+	}
+	return "", false
+}
+
+// MatchStation returns the code and station of the registry (see
+// weather.Stations) a temperature series settles on, or a nil station. It
+// matches in order of confidence: the city in the ticker (KXHIGHLAX and
+// KXLOWTLAX are LAX), the NWS climate report in the settlement sources
+// (issuedby=LAX), then the city named in the title
+func MatchStation(s rest.Series, marketType weather.MarketType) (string, *weather.Station) {
+	codes := make([]string, 0, len(weather.Stations))
+	for code := range weather.Stations {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	city := seriesCity(s.Ticker, marketType)
+	for _, code := range codes {
+		st := weather.Stations[code]
+		if city != "" && (city == code || city == seriesCity(st.EventPrefix, weather.MarketTypeHigh)) {
+			return code, st
+		}
+	}
+
+	for _, src := range s.SettlementSources {
+		u, err := url.Parse(src.URL)
+		if err != nil {
+			continue
+		}
+		issuer := strings.ToUpper(u.Query().Get("issuedby"))
+		if issuer == "" {
+			continue
+		}
+		for _, code := range codes {
+			if st := weather.Stations[code]; issuer == code || "K"+issuer == st.ID {
+				return code, st
+			}
+		}
+	}
+
+	title := strings.ToLower(s.Title)
+	for _, code := range codes {
+		if st := weather.Stations[code]; st.City != "" && strings.Contains(title, strings.ToLower(st.City)) {
+			return code, st
+		}
+	}
+	return "", nil
+}
+
+// seriesCity returns what follows the type prefix of a series ticker, e.g.
+// LAX for KXHIGHLAX, or "" without a known prefix
+func seriesCity(ticker string, marketType weather.MarketType) string {
+	prefixes := highPrefixes
+	if marketType == weather.MarketTypeLow {
+		prefixes = lowPrefixes
+	}
+	ticker = strings.ToUpper(ticker)
+	for _, p := range prefixes {
+		if city, ok := strings.CutPrefix(ticker, p); ok {
+			return city
+		}
+	}
+	return ""
+}
+
+// SeriesFor returns the station's series of the market type. When several
+// are listed, e.g. while Kalshi moves a city to a new series, the one with
+// the most open events is chosen
+func (c *Catalog) SeriesFor(code string, marketType weather.MarketType) (TempSeries, bool) {
+	var best TempSeries
+	found := false
+	for _, s := range c.Series {
+		if s.Code != code || s.Type != marketType {
+			continue
+		}
+		if !found || len(s.Events) > len(best.Events) {
+			best, found = s, true
+		}
+	}
+	return best, found
+}
+
+// Lookup returns the series with the ticker
+func (c *Catalog) Lookup(ticker string) (TempSeries, bool) {
+	for _, s := range c.Series {
+		if s.Ticker == ticker {
+			return s, true
+		}
+	}
+	return TempSeries{}, false
+}
+
+// Unmatched returns the series no registered station settles, e.g. a new
+// city's
+func (c *Catalog) Unmatched() []TempSeries {
+	var out []TempSeries
+	for _, s := range c.Series {
+		if s.Station == nil {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// SeriesChange is a station pointed at another series by Apply
+type SeriesChange struct {
+	Code     string
+	Type     weather.MarketType
+	From, To string // Series tickers ("" = none)
+}
+
+func (c SeriesChange) String() string {
+	from := c.From
+	if from == "" {
+		from = "none"
+	}
+	return fmt.Sprintf("%s %s: %s → %s", c.Code, c.Type, from, c.To)
+}
+
+// Apply points the stations at the series found for them, setting
+// EventPrefix and LowPrefix where the exchange lists a different ticker
+// than the registry, and returns the changes made. A station keeps its
+// series while the exchange still lists it with as many open events, and
+// stations without a series found keep theirs
+func (c *Catalog) Apply(stations map[string]*weather.Station) []SeriesChange {
+	codes := make([]string, 0, len(stations))
+	for code := range stations {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var changes []SeriesChange
+	for _, code := range codes {
+		st := stations[code]
+		for _, t := range []weather.MarketType{weather.MarketTypeHigh, weather.MarketTypeLow} {
+			s, ok := c.SeriesFor(code, t)
+			if !ok || s.Ticker == st.SeriesTicker(t) {
+				continue
+			}
+			if cur, ok := c.Lookup(st.SeriesTicker(t)); ok && cur.Code == code && len(cur.Events) >= len(s.Events) {
+				continue // Still listed and as active
+			}
+			changes = append(changes, SeriesChange{Code: code, Type: t, From: st.SeriesTicker(t), To: s.Ticker})
+			if t == weather.MarketTypeLow {
+				st.LowPrefix = s.Ticker
+			} else {
+				st.LowPrefix = st.SeriesTicker(weather.MarketTypeLow) // Not derived from the new ticker
+				st.EventPrefix = s.Ticker
+			}
+		}
+	}
+	return changes
+}
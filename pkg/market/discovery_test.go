@@ -0,0 +1,127 @@
+package market
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+func TestClassifySeries(t *testing.T) {
+	tests := []struct {
+		series rest.Series
+		want   weather.MarketType
+		ok     bool
+	}{
+		{rest.Series{Ticker: "KXHIGHLAX", Title: "Highest temperature in Los Angeles today?", Frequency: "daily"}, weather.MarketTypeHigh, true},
+		{rest.Series{Ticker: "KXLOWTCHI", Title: "Lowest temperature in Chicago today?", Frequency: "daily"}, weather.MarketTypeLow, true},
+		{rest.Series{Ticker: "HIGHNY", Title: "Highest temperature in NYC today?"}, weather.MarketTypeHigh, true},
+		{rest.Series{Ticker: "KXTEMPSEA", Title: "Minimum temperature in Seattle today?", Frequency: "daily"}, weather.MarketTypeLow, true},
+		{rest.Series{Ticker: "KXHIGHLAXM", Title: "Highest temperature in Los Angeles this month?", Frequency: "monthly"}, "", false},
+		{rest.Series{Ticker: "HIGHFED", Title: "Fed funds rate high?", Frequency: "daily"}, "", false},
+		{rest.Series{Ticker: "KXRAINNYC", Title: "Rain in NYC today?", Frequency: "daily"}, "", false},
+		{rest.Series{Ticker: "KXTEMPSEA", Title: "Temperature in Seattle today?", Frequency: "daily"}, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ClassifySeries(tt.series)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ClassifySeries(%s) = %q, %v, want %q, %v", tt.series.Ticker, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMatchStation(t *testing.T) {
+	tests := []struct {
+		name   string
+		series rest.Series
+		t      weather.MarketType
+		want   string
+	}{
+		{"ticker", rest.Series{Ticker: "KXHIGHLAX"}, weather.MarketTypeHigh, "LAX"},
+		{"ticker as event prefix", rest.Series{Ticker: "KXLOWTNY"}, weather.MarketTypeLow, "NYC"},
+		{
+			name: "settlement source",
+			series: rest.Series{Ticker: "KXHIGHTMIA", SettlementSources: []rest.SettlementSource{
+				{Name: "National Weather Service", URL: "https://forecast.weather.gov/product.php?site=MFL&product=CLI&issuedby=MIA"},
+			}},
+			t:    weather.MarketTypeHigh,
+			want: "MIA",
+		},
+		{"title", rest.Series{Ticker: "KXTEMPDENMAX", Title: "Highest temperature in Denver today?"}, weather.MarketTypeHigh, "DEN"},
+		{"unknown city", rest.Series{Ticker: "KXHIGHSEA", Title: "Highest temperature in Seattle today?"}, weather.MarketTypeHigh, ""},
+	}
+	for _, tt := range tests {
+		code, st := MatchStation(tt.series, tt.t)
+		if code != tt.want || (st == nil) != (tt.want == "") {
+			t.Errorf("%s: MatchStation() = %q, %v, want %q", tt.name, code, st, tt.want)
+		}
+	}
+}
+
+func TestCatalog_Apply(t *testing.T) {
+	events := func(n int) []rest.EventWithMarkets { return make([]rest.EventWithMarkets, n) }
+	tests := []struct {
+		name   string
+		series []TempSeries
+		want   []SeriesChange
+		high   string
+		low    string
+	}{
+		{
+			name: "unchanged",
+			series: []TempSeries{
+				{Ticker: "KXHIGHLAX", Type: weather.MarketTypeHigh, Code: "LAX", Events: events(1)},
+				{Ticker: "KXLOWTLAX", Type: weather.MarketTypeLow, Code: "LAX", Events: events(1)},
+			},
+			high: "KXHIGHLAX",
+			low:  "KXLOWTLAX",
+		},
+		{
+			name: "high renamed",
+			series: []TempSeries{
+				{Ticker: "KXHIGHLAX", Type: weather.MarketTypeHigh, Code: "LAX"},
+				{Ticker: "KXHIGHTLAX", Type: weather.MarketTypeHigh, Code: "LAX", Events: events(2)},
+				{Ticker: "KXLOWTLAX", Type: weather.MarketTypeLow, Code: "LAX", Events: events(1)},
+			},
+			want: []SeriesChange{{Code: "LAX", Type: weather.MarketTypeHigh, From: "KXHIGHLAX", To: "KXHIGHTLAX"}},
+			high: "KXHIGHTLAX",
+			low:  "KXLOWTLAX",
+		},
+		{
+			name: "low renamed",
+			series: []TempSeries{
+				{Ticker: "KXLOWLAX", Type: weather.MarketTypeLow, Code: "LAX", Events: events(1)},
+			},
+			want: []SeriesChange{{Code: "LAX", Type: weather.MarketTypeLow, From: "KXLOWTLAX", To: "KXLOWLAX"}},
+			high: "KXHIGHLAX",
+			low:  "KXLOWLAX",
+		},
+		{
+			name: "old series as active",
+			series: []TempSeries{
+				{Ticker: "KXHIGHLAX", Type: weather.MarketTypeHigh, Code: "LAX", Events: events(1)},
+				{Ticker: "KXHIGHTLAX", Type: weather.MarketTypeHigh, Code: "LAX", Events: events(1)},
+			},
+			high: "KXHIGHLAX",
+			low:  "KXLOWTLAX",
+		},
+	}
+	for _, tt := range tests {
+		lax := *weather.Stations["LAX"]
+		stations := map[string]*weather.Station{"LAX": &lax}
+		got := (&Catalog{Series: tt.series}).Apply(stations)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Apply() = %v, want %v", tt.name, got, tt.want)
+		}
+		if high := lax.SeriesTicker(weather.MarketTypeHigh); high != tt.high {
+			t.Errorf("%s: HIGH series = %s, want %s", tt.name, high, tt.high)
+		}
+		if low := lax.SeriesTicker(weather.MarketTypeLow); low != tt.low {
+			t.Errorf("%s: LOW series = %s, want %s", tt.name, low, tt.low)
+		}
+	}
+	if weather.Stations["LAX"].EventPrefix != "KXHIGHLAX" {
+		t.Errorf("Apply() changed the registry: %s", weather.Stations["LAX"].EventPrefix)
+	}
+}
@@ -0,0 +1,176 @@
+package market
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// StandardWidth is the width in degrees of the middle brackets most events
+// list: 60-61, 62-63, ...
+const StandardWidth = 2
+
+// MassTolerance is how far from 1 the probabilities of a complete layout's
+// brackets may sum before CheckMass rejects them
+const MassTolerance = 0.01
+
+// Layout is the shape of an event's brackets, inferred from their strikes
+// when the event is loaded rather than assumed. Some events list wider or
+// narrower brackets, or only one tail ("74+")
+type Layout struct {
+	Brackets int   // Brackets listed
+	Width    int   // Most common width of the middle brackets in degrees, 0 without any
+	Uniform  bool  // Every middle bracket is Width wide
+	LowTail  bool  // A "below" bracket is listed
+	HighTail bool  // An "above" bracket is listed
+	Gaps     []int // Degrees between the lowest and highest bound no bracket contains
+	Overlaps []int // Degrees more than one bracket contains
+}
+
+// InferLayout infers the layout of an event's brackets from their strikes,
+// in any order
+func InferLayout(s Strikes) Layout {
+	l := Layout{Brackets: len(s)}
+	widths := make(map[int]int)
+	lo, hi := OpenCap, OpenFloor
+	for _, strike := range s {
+		switch {
+		case strike.Floor == OpenFloor && strike.Cap == OpenCap:
+		case strike.Floor == OpenFloor:
+			l.LowTail = true
+		case strike.Cap == OpenCap:
+			l.HighTail = true
+		default:
+			widths[strike.Cap-strike.Floor+1]++
+		}
+		for _, bound := range []int{strike.Floor, strike.Cap} {
+			if bound != OpenFloor && bound != OpenCap {
+				lo, hi = min(lo, bound), max(hi, bound)
+			}
+		}
+	}
+	for w, n := range widths {
+		if n > widths[l.Width] || (n == widths[l.Width] && w < l.Width) {
+			l.Width = w
+		}
+	}
+	l.Uniform = len(widths) <= 1
+
+	for t := lo; t <= hi; t++ {
+		n := 0
+		for _, strike := range s {
+			if strike.Contains(t) {
+				n++
+			}
+		}
+		switch {
+		case n == 0:
+			l.Gaps = append(l.Gaps, t)
+		case n > 1:
+			l.Overlaps = append(l.Overlaps, t)
+		}
+	}
+	return l
+}
+
+// Complete reports whether every whole degree settles exactly one bracket,
+// so the probabilities of the brackets sum to 1
+func (l Layout) Complete() bool {
+	return l.Err() == nil
+}
+
+// Standard reports whether the layout is complete with uniform
+// StandardWidth brackets, as the strategies were tuned on
+func (l Layout) Standard() bool {
+	return l.Complete() && l.Uniform && l.Width == StandardWidth
+}
+
+// Err describes why the layout is not complete, or returns nil
+func (l Layout) Err() error {
+	if l.Brackets == 0 {
+		return errors.New("no brackets")
+	}
+	var problems []string
+	if !l.LowTail {
+		problems = append(problems, "no below tail")
+	}
+	if !l.HighTail {
+		problems = append(problems, "no above tail")
+	}
+	if len(l.Gaps) > 0 {
+		problems = append(problems, "no bracket for "+degrees(l.Gaps))
+	}
+	if len(l.Overlaps) > 0 {
+		problems = append(problems, "overlapping brackets on "+degrees(l.Overlaps))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(problems, ", "))
+}
+
+// String describes the layout, e.g. "7 brackets of 2°, both tails"
+func (l Layout) String() string {
+	width := fmt.Sprintf("of %d°", l.Width)
+	switch {
+	case l.Width == 0:
+		width = "without middle brackets"
+	case !l.Uniform:
+		width = fmt.Sprintf("of mixed widths (mostly %d°)", l.Width)
+	}
+	tails := "no tails"
+	switch {
+	case l.LowTail && l.HighTail:
+		tails = "both tails"
+	case l.LowTail:
+		tails = "below tail only"
+	case l.HighTail:
+		tails = "above tail only"
+	}
+	return fmt.Sprintf("%d brackets %s, %s", l.Brackets, width, tails)
+}
+
+// CheckMass checks that probs, the probabilities of the layout's brackets,
+// sum to 1 within MassTolerance. An incomplete layout fails whatever the
+// probabilities, since some outcomes settle no bracket or several
+func (l Layout) CheckMass(probs []float64) error {
+	if err := l.Err(); err != nil {
+		return err
+	}
+	var sum float64
+	for _, p := range probs {
+		sum += p
+	}
+	if math.Abs(sum-1) > MassTolerance {
+		return fmt.Errorf("bracket probabilities sum to %.3f, not 1", sum)
+	}
+	return nil
+}
+
+// Scale converts a count of brackets tuned on StandardWidth brackets to the
+// count covering as many degrees in this layout (at least 1 if n is)
+func (l Layout) Scale(n int) int {
+	if n <= 0 || l.Width <= 0 {
+		return n
+	}
+	return max(1, int(math.Round(float64(n*StandardWidth)/float64(l.Width))))
+}
+
+// degrees formats whole degrees as runs, e.g. "62-63°, 70°"
+func degrees(ts []int) string {
+	var runs []string
+	for i := 0; i < len(ts); {
+		j := i
+		for j+1 < len(ts) && ts[j+1] == ts[j]+1 {
+			j++
+		}
+		if i == j {
+			runs = append(runs, fmt.Sprintf("%d°", ts[i]))
+		} else {
+			runs = append(runs, fmt.Sprintf("%d-%d°", ts[i], ts[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(runs, ", ")
+}
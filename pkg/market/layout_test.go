@@ -0,0 +1,89 @@
+package market
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		strikes  Strikes
+		want     Layout
+		standard bool
+		err      string
+	}{
+		{
+			name: "standard",
+			strikes: Strikes{
+				NewStrike(0, 58), NewStrike(58, 59), NewStrike(60, 61), NewStrike(62, 63), NewStrike(63, 0),
+			},
+			want:     Layout{Brackets: 5, Width: 2, Uniform: true, LowTail: true, HighTail: true},
+			standard: true,
+		},
+		{
+			name:    "wide brackets and an above tail only",
+			strikes: Strikes{NewStrike(70, 0), NewStrike(65, 67), NewStrike(68, 70)},
+			want:    Layout{Brackets: 3, Width: 3, Uniform: true, HighTail: true},
+			err:     "no below tail",
+		},
+		{
+			name: "missing and overlapping brackets",
+			strikes: Strikes{
+				NewStrike(0, 58), NewStrike(58, 59), NewStrike(62, 63), NewStrike(63, 65), NewStrike(65, 0),
+			},
+			want: Layout{Brackets: 5, Width: 2, LowTail: true, HighTail: true,
+				Gaps: []int{60, 61}, Overlaps: []int{63}},
+			err: "no bracket for 60-61°, overlapping brackets on 63°",
+		},
+		{
+			name: "none",
+			want: Layout{Uniform: true},
+			err:  "no brackets",
+		},
+	}
+	for _, tt := range tests {
+		got := InferLayout(tt.strikes)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: InferLayout() = %#v, want %#v", tt.name, got, tt.want)
+		}
+		if got.Standard() != tt.standard {
+			t.Errorf("%s: Standard() = %v, want %v", tt.name, got.Standard(), tt.standard)
+		}
+		err := got.Err()
+		if (err == nil && tt.err != "") || (err != nil && err.Error() != tt.err) {
+			t.Errorf("%s: Err() = %v, want %q", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestLayout_CheckMass(t *testing.T) {
+	l := InferLayout(Strikes{NewStrike(0, 60), NewStrike(60, 61), NewStrike(61, 0)})
+	if err := l.CheckMass([]float64{0.2, 0.5, 0.295}); err != nil {
+		t.Errorf("CheckMass(0.995) error = %v", err)
+	}
+	if err := l.CheckMass([]float64{0.2, 0.5, 0.1}); err == nil {
+		t.Error("CheckMass(0.8) error = nil")
+	}
+	missing := InferLayout(Strikes{NewStrike(60, 61), NewStrike(61, 0)})
+	if err := missing.CheckMass([]float64{0.5, 0.5}); err == nil {
+		t.Error("CheckMass() without a below tail error = nil")
+	}
+}
+
+func TestLayout_Scale(t *testing.T) {
+	tests := []struct {
+		width, n, want int
+	}{
+		{2, 4, 4},
+		{1, 4, 8},
+		{3, 4, 3},
+		{5, 1, 1},
+		{0, 4, 4},
+	}
+	for _, tt := range tests {
+		if got := (Layout{Width: tt.width}).Scale(tt.n); got != tt.want {
+			t.Errorf("Layout{Width: %d}.Scale(%d) = %d, want %d", tt.width, tt.n, got, tt.want)
+		}
+	}
+}
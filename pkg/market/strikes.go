@@ -0,0 +1,141 @@
+package market
+
+import (
+	"fmt"
+	"math"
+)
+
+// Open bracket bounds for the tail markets, matching Bracket
+const (
+	OpenFloor = -999
+	OpenCap   = 999
+)
+
+// Rounding is how a fractional temperature is converted to the whole degree
+// that settles a market
+type Rounding string
+
+const (
+	// RoundNearest rounds half up, as the NWS climate report does
+	RoundNearest Rounding = "nearest"
+	// RoundDown truncates toward negative infinity
+	RoundDown Rounding = "down"
+	// RoundUp rounds toward positive infinity
+	RoundUp Rounding = "up"
+)
+
+// ParseRounding parses a rounding policy name ("" means RoundNearest)
+func ParseRounding(s string) (Rounding, error) {
+	switch r := Rounding(s); r {
+	case "":
+		return RoundNearest, nil
+	case RoundNearest, RoundDown, RoundUp:
+		return r, nil
+	}
+	return "", fmt.Errorf("unknown rounding %q (want nearest, down or up)", s)
+}
+
+// Apply rounds temp to a whole degree
+func (r Rounding) Apply(temp float64) int {
+	switch r {
+	case RoundDown:
+		return int(math.Floor(temp))
+	case RoundUp:
+		return int(math.Ceil(temp))
+	}
+	return int(math.Floor(temp + 0.5))
+}
+
+// Strike is the inclusive settlement range of one bracket market
+type Strike struct {
+	Floor int // OpenFloor for the "below" tail
+	Cap   int // OpenCap for the "above" tail
+}
+
+// NewStrike converts the floor_strike / cap_strike of a Kalshi market (0 when
+// absent) into an inclusive range. Middle brackets list both bounds
+// inclusively; tails are exclusive, so ">63" has floor strike 63 and
+// settles on 64 and above
+func NewStrike(floorStrike, capStrike int) Strike {
+	switch {
+	case floorStrike != 0 && capStrike != 0:
+		return Strike{Floor: floorStrike, Cap: capStrike}
+	case floorStrike != 0:
+		return Strike{Floor: floorStrike + 1, Cap: OpenCap}
+	case capStrike != 0:
+		return Strike{Floor: OpenFloor, Cap: capStrike - 1}
+	}
+	return Strike{Floor: OpenFloor, Cap: OpenCap}
+}
+
+// Contains reports whether the whole-degree temp settles the market YES
+func (s Strike) Contains(temp int) bool {
+	return temp >= s.Floor && temp <= s.Cap
+}
+
+// Strikes are the brackets of one event, in any order
+type Strikes []Strike
+
+// Index returns the index of the bracket that settles YES when the official
+// temperature is temp rounded with r, or -1 if no listed bracket contains it.
+// Brackets come from the exchange's strikes, so widths and offsets need not
+// follow any pattern
+func (s Strikes) Index(temp float64, r Rounding) int {
+	t := r.Apply(temp)
+	for i, strike := range s {
+		if strike.Contains(t) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Below returns the index of the bracket immediately below bracket i, or -1
+// if i is the lowest
+func (s Strikes) Below(i int) int {
+	if i < 0 || i >= len(s) || s[i].Floor == OpenFloor {
+		return -1
+	}
+	return s.Index(float64(s[i].Floor-1), RoundNearest)
+}
+
+// Above returns the index of the bracket immediately above bracket i, or -1
+// if i is the highest
+func (s Strikes) Above(i int) int {
+	if i < 0 || i >= len(s) || s[i].Cap == OpenCap {
+		return -1
+	}
+	return s.Index(float64(s[i].Cap+1), RoundNearest)
+}
+
+// BracketFloors returns the floor strikes of n consecutive listed markets,
+// starting offset brackets from the one that settles YES at temp (negative
+// offsets are below it). Positions with no listed market are -1. strikes
+// gives a market's floor_strike and cap_strike, which the brackets come from
+// rather than assuming even-numbered 2° ranges
+func BracketFloors[M any](markets []M, strikes func(M) (floorStrike, capStrike int), temp, offset, n int) []int {
+	s := make(Strikes, len(markets))
+	floors := make([]int, len(markets))
+	for i, m := range markets {
+		floorStrike, capStrike := strikes(m)
+		s[i], floors[i] = NewStrike(floorStrike, capStrike), floorStrike
+	}
+
+	i := s.Index(float64(temp), RoundNearest)
+	for ; offset < 0 && i >= 0; offset++ {
+		i = s.Below(i)
+	}
+	for ; offset > 0 && i >= 0; offset-- {
+		i = s.Above(i)
+	}
+
+	list := make([]int, n)
+	for k := range list {
+		list[k] = -1
+		if i >= 0 {
+			list[k] = floors[i]
+			i = s.Above(i)
+		}
+	}
+	return list
+}
@@ -0,0 +1,105 @@
+package market
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRounding_Apply(t *testing.T) {
+	tests := []struct {
+		r    Rounding
+		temp float64
+		want int
+	}{
+		{RoundNearest, 64.5, 65},
+		{RoundNearest, 64.49, 64},
+		{RoundNearest, -3.5, -3},
+		{RoundDown, 64.9, 64},
+		{RoundUp, 64.1, 65},
+	}
+	for _, tt := range tests {
+		if got := tt.r.Apply(tt.temp); got != tt.want {
+			t.Errorf("%s.Apply(%v) = %d, want %d", tt.r, tt.temp, got, tt.want)
+		}
+	}
+
+	if _, err := ParseRounding("banker"); err == nil {
+		t.Error("ParseRounding(banker): want error")
+	}
+}
+
+func TestStrikes_Index(t *testing.T) {
+	// Odd-floored 2° brackets, which the old ((t/2)*2) arithmetic mispredicted
+	strikes := Strikes{
+		NewStrike(0, 57),  // <57: up to 56
+		NewStrike(57, 58), // 57-58
+		NewStrike(59, 60), // 59-60
+		NewStrike(60, 0),  // >60: 61 and up
+	}
+
+	tests := []struct {
+		temp float64
+		want int
+	}{
+		{50, 0},
+		{56.4, 0},
+		{57, 1},
+		{58.4, 1},
+		{58.5, 2},
+		{60, 2},
+		{61, 3},
+		{99, 3},
+	}
+	for _, tt := range tests {
+		if got := strikes.Index(tt.temp, RoundNearest); got != tt.want {
+			t.Errorf("Index(%v) = %d, want %d", tt.temp, got, tt.want)
+		}
+	}
+
+	if got := strikes.Below(2); got != 1 {
+		t.Errorf("Below(2) = %d, want 1", got)
+	}
+	if got := strikes.Below(0); got != -1 {
+		t.Errorf("Below(0) = %d, want -1", got)
+	}
+	if got := strikes.Above(1); got != 2 {
+		t.Errorf("Above(1) = %d, want 2", got)
+	}
+	if got := strikes.Above(3); got != -1 {
+		t.Errorf("Above(3) = %d, want -1", got)
+	}
+	if got := (Strikes{NewStrike(60, 61)}).Index(59, RoundNearest); got != -1 {
+		t.Errorf("Index outside listed brackets = %d, want -1", got)
+	}
+}
+
+func TestBracketFloors(t *testing.T) {
+	// Odd-floored 2° brackets between the tails, listed out of order
+	type listed struct{ floor, cap int }
+	markets := []listed{{59, 60}, {0, 57}, {60, 0}, {57, 58}}
+	strikes := func(m listed) (int, int) { return m.floor, m.cap }
+
+	tests := []struct {
+		name         string
+		temp, offset int
+		n            int
+		want         []int
+	}{
+		{"bracket", 58, 0, 1, []int{57}},
+		{"lower tail", 50, 0, 1, []int{0}},
+		{"upper tail", 70, 0, 1, []int{60}},
+		{"run from below", 59, -1, 3, []int{57, 59, 60}},
+		{"run past the top", 58, 1, 3, []int{59, 60, -1}},
+		{"below the lowest", 50, -1, 2, []int{-1, -1}},
+	}
+	for _, tt := range tests {
+		got := BracketFloors(markets, strikes, tt.temp, tt.offset, tt.n)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: BracketFloors(%d, %d, %d) = %v, want %v", tt.name, tt.temp, tt.offset, tt.n, got, tt.want)
+		}
+	}
+
+	if got := BracketFloors(markets[:1], strikes, 50, 0, 1); got[0] != -1 {
+		t.Errorf("unlisted temperature: BracketFloors() = %v, want [-1]", got)
+	}
+}
@@ -22,6 +22,7 @@ type TempMarket struct {
 	// Market state
 	IsOpen     bool
 	ClosesAt   time.Time
+	FetchedAt  time.Time // When the prices were read
 }
 
 // Bracket represents a single temperature bracket in a market
@@ -35,6 +36,15 @@ type Bracket struct {
 	Description string  // Human-readable description (e.g., "60-61°F")
 }
 
+// Spread returns the YES bid/ask spread in cents (the YES ask is 100 minus the
+// NO bid), or 100 when the book is one-sided
+func (b *Bracket) Spread() int {
+	if b.YesPrice <= 0 || b.NoPrice <= 0 {
+		return 100
+	}
+	return 100 - b.NoPrice - b.YesPrice
+}
+
 // FetchTempMarket fetches market data for a station, market type, and date
 func FetchTempMarket(client *rest.Client, station *weather.Station, marketType weather.MarketType, date time.Time) (*TempMarket, error) {
 	eventTicker := station.EventTickerForType(date, marketType)
@@ -55,6 +65,7 @@ func FetchTempMarket(client *rest.Client, station *weather.Station, marketType w
 		Date:        date,
 		EventTicker: eventTicker,
 		IsOpen:      markets[0].Status == "active",
+		FetchedAt:   time.Now(),
 	}
 
 	// Parse brackets from markets
@@ -0,0 +1,246 @@
+// Package metrics exposes counters and gauges in the Prometheus text format,
+// so a Prometheus server can scrape the bots and Grafana chart and alert on
+// them. It implements just what the bots need, without the client library.
+//
+// Metrics are registered once on a Registry and updated by label values:
+//
+//	reg := metrics.NewRegistry()
+//	orders := reg.Counter("kalshi_orders_placed_total", "Orders placed.", "city", "side")
+//	orders.Inc("LAX", "yes")
+//	balance := reg.Gauge("kalshi_balance_dollars", "Account balance.")
+//	balance.Set(1042.50)
+//	http.Handle("/metrics", reg.Handler())
+//
+// Every method is safe on a nil metric, so code can record unconditionally
+// and a bot without metrics passes nil.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Registry holds metrics and writes them for scraping.
+type Registry struct {
+	mu       sync.Mutex
+	families []*family
+	names    map[string]bool
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+type family struct {
+	name, help, kind string
+	labels           []string
+
+	mu     sync.Mutex
+	series map[string]*series // By joined label values
+	fn     func() float64     // GaugeFunc
+}
+
+type series struct {
+	values []string
+	value  float64
+}
+
+func (r *Registry) register(name, help, kind string, labels []string) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.names[name] {
+		panic(fmt.Sprintf("metrics: %s registered twice", name))
+	}
+	r.names[name] = true
+	f := &family{name: name, help: help, kind: kind, labels: labels, series: make(map[string]*series)}
+	r.families = append(r.families, f)
+	return f
+}
+
+// Counter registers a counter partitioned by labels. It panics if name is
+// already registered.
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	return &Counter{r.register(name, help, "counter", labels)}
+}
+
+// Gauge registers a gauge partitioned by labels. It panics if name is
+// already registered.
+func (r *Registry) Gauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{r.register(name, help, "gauge", labels)}
+}
+
+// GaugeFunc registers a gauge read from fn at each scrape.
+func (r *Registry) GaugeFunc(name, help string, fn func() float64) {
+	f := r.register(name, help, "gauge", nil)
+	f.fn = fn
+}
+
+// Counter is a value that only goes up, e.g. orders placed.
+type Counter struct{ f *family }
+
+// Inc adds 1 to the series of the label values.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds v, which must not be negative, to the series of the label values.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if c == nil || v < 0 {
+		return
+	}
+	c.f.update(labelValues, func(s *series) { s.value += v })
+}
+
+// Gauge is a value that goes up and down, e.g. the account balance.
+type Gauge struct{ f *family }
+
+// Set sets the series of the label values to v.
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	if g == nil {
+		return
+	}
+	g.f.update(labelValues, func(s *series) { s.value = v })
+}
+
+// Add adds v to the series of the label values.
+func (g *Gauge) Add(v float64, labelValues ...string) {
+	if g == nil {
+		return
+	}
+	g.f.update(labelValues, func(s *series) { s.value += v })
+}
+
+// SetBool sets the series to 1 if b, else 0.
+func (g *Gauge) SetBool(b bool, labelValues ...string) {
+	v := 0.0
+	if b {
+		v = 1
+	}
+	g.Set(v, labelValues...)
+}
+
+// Delete removes the series of the label values, e.g. a market that closed.
+func (g *Gauge) Delete(labelValues ...string) {
+	if g == nil {
+		return
+	}
+	g.f.mu.Lock()
+	delete(g.f.series, strings.Join(labelValues, "\xff"))
+	g.f.mu.Unlock()
+}
+
+func (f *family) update(values []string, fn func(*series)) {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{values: append([]string(nil), values...)}
+		f.series[key] = s
+	}
+	fn(s)
+}
+
+// WriteTo writes every metric in the Prometheus text exposition format,
+// series sorted by label values.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	families := append([]*family(nil), r.families...)
+	r.mu.Unlock()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for _, f := range families {
+		fmt.Fprintf(cw, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		fmt.Fprintf(cw, "# TYPE %s %s\n", f.name, f.kind)
+		if f.fn != nil {
+			fmt.Fprintf(cw, "%s %s\n", f.name, formatValue(f.fn()))
+			continue
+		}
+
+		f.mu.Lock()
+		keys := make([]string, 0, len(f.series))
+		for k := range f.series {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			s := f.series[k]
+			cw.WriteString(f.name)
+			if len(f.labels) > 0 {
+				cw.WriteString("{")
+				for i, l := range f.labels {
+					if i > 0 {
+						cw.WriteString(",")
+					}
+					fmt.Fprintf(cw, "%s=\"%s\"", l, escapeLabel(s.values[i]))
+				}
+				cw.WriteString("}")
+			}
+			fmt.Fprintf(cw, " %s\n", formatValue(s.value))
+		}
+		f.mu.Unlock()
+	}
+	if err := cw.w.Flush(); err != nil && cw.err == nil {
+		cw.err = err
+	}
+	return cw.n, cw.err
+}
+
+// Handler serves the metrics for scraping.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteTo(w)
+	})
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
+
+func (c *countingWriter) WriteString(s string) {
+	c.Write([]byte(s))
+}
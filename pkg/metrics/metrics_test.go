@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	reg := NewRegistry()
+	orders := reg.Counter("orders_total", "Orders placed.", "city", "side")
+	balance := reg.Gauge("balance_dollars", "Account balance.")
+	reg.GaugeFunc("up", "Always 1.", func() float64 { return 1 })
+
+	orders.Inc("NYC", "yes")
+	orders.Inc("LAX", "no")
+	orders.Add(2, "LAX", "no")
+	orders.Add(-1, "LAX", "no") // Ignored
+	balance.Set(1042.5)
+
+	var b strings.Builder
+	if _, err := reg.WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	want := `# HELP orders_total Orders placed.
+# TYPE orders_total counter
+orders_total{city="LAX",side="no"} 3
+orders_total{city="NYC",side="yes"} 1
+# HELP balance_dollars Account balance.
+# TYPE balance_dollars gauge
+balance_dollars 1042.5
+# HELP up Always 1.
+# TYPE up gauge
+up 1
+`
+	if b.String() != want {
+		t.Errorf("WriteTo() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestGauge(t *testing.T) {
+	reg := NewRegistry()
+	g := reg.Gauge("g", "A gauge.", "market")
+	g.Set(5, "a")
+	g.Add(-2, "a")
+	g.SetBool(true, "b")
+	g.Set(1, "c")
+	g.Delete("c")
+
+	var b strings.Builder
+	reg.WriteTo(&b)
+	if !strings.Contains(b.String(), `g{market="a"} 3`) || !strings.Contains(b.String(), `g{market="b"} 1`) {
+		t.Errorf("WriteTo() = %s", b.String())
+	}
+	if strings.Contains(b.String(), `market="c"`) {
+		t.Errorf("deleted series written: %s", b.String())
+	}
+}
+
+func TestEscape(t *testing.T) {
+	reg := NewRegistry()
+	reg.Gauge("g", "Line\nbreak and \\.", "l").Set(1, "say \"hi\"\n")
+
+	var b strings.Builder
+	reg.WriteTo(&b)
+	for _, want := range []string{`# HELP g Line\nbreak and \\.`, `g{l="say \"hi\"\n"} 1`} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("WriteTo() = %s, want %s", b.String(), want)
+		}
+	}
+}
+
+func TestLabelCountPanics(t *testing.T) {
+	reg := NewRegistry()
+	c := reg.Counter("c", "A counter.", "city")
+	defer func() {
+		if recover() == nil {
+			t.Error("Inc() with wrong label count did not panic")
+		}
+	}()
+	c.Inc("NYC", "yes")
+}
+
+func TestHandler(t *testing.T) {
+	reg := NewRegistry()
+	tr := NewTrading(reg)
+	tr.OrderPlaced("LAX", "yes", true)
+	tr.OrderPlaced("LAX", "yes", false)
+	tr.OrderRejected("LAX", "risk_limit")
+	tr.SetTemperature("KLAX", "max", 71)
+	tr.SetConnected(true)
+
+	rec := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	for _, want := range []string{
+		`kalshi_orders_placed_total{city="LAX",side="yes"} 2`,
+		`kalshi_fills_total{city="LAX",side="yes"} 1`,
+		`kalshi_order_rejections_total{city="LAX",reason="risk_limit"} 1`,
+		`kalshi_metar_temp_f{station="KLAX",kind="max"} 71`,
+		`kalshi_websocket_connected 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("body missing %s:\n%s", want, body)
+		}
+	}
+}
+
+func TestNilTrading(t *testing.T) {
+	var tr *Trading
+	tr.OrderPlaced("LAX", "yes", true)
+	tr.SetBalance(1)
+	tr.SetConnected(false)
+}
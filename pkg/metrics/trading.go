@@ -0,0 +1,111 @@
+package metrics
+
+// Trading is the metric set the bots share, so dashboards and alerts work
+// for either bot.
+type Trading struct {
+	// OrdersPlaced counts orders accepted by the exchange, by city and side.
+	OrdersPlaced *Counter
+	// Fills counts orders filled on placement, by city and side.
+	Fills *Counter
+	// Rejections counts orders not placed, by city and reason, e.g.
+	// "risk_limit", a rest.Rejection class such as "market_paused", or
+	// "api_error".
+	Rejections *Counter
+	// Exposure is the cost of the open positions in dollars.
+	Exposure *Gauge
+	// Balance is the account balance in dollars.
+	Balance *Gauge
+	// Temperature is the latest METAR reading in °F, by station and kind
+	// ("current" or "max").
+	Temperature *Gauge
+	// Edge is the model probability minus the price of each bracket, by
+	// market and side.
+	Edge *Gauge
+	// WebSocketConnected is 1 while the market data stream is connected.
+	WebSocketConnected *Gauge
+}
+
+// NewTrading registers the trading metrics on reg. A nil *Trading records
+// nothing.
+func NewTrading(reg *Registry) *Trading {
+	return &Trading{
+		OrdersPlaced: reg.Counter("kalshi_orders_placed_total",
+			"Orders accepted by the exchange.", "city", "side"),
+		Fills: reg.Counter("kalshi_fills_total",
+			"Orders filled on placement.", "city", "side"),
+		Rejections: reg.Counter("kalshi_order_rejections_total",
+			"Orders not placed, by reason.", "city", "reason"),
+		Exposure: reg.Gauge("kalshi_exposure_dollars",
+			"Cost of the open positions in dollars."),
+		Balance: reg.Gauge("kalshi_balance_dollars",
+			"Account balance in dollars."),
+		Temperature: reg.Gauge("kalshi_metar_temp_f",
+			"Latest METAR temperature in degrees Fahrenheit.", "station", "kind"),
+		Edge: reg.Gauge("kalshi_model_edge",
+			"Model probability minus price of a bracket.", "market", "side"),
+		WebSocketConnected: reg.Gauge("kalshi_websocket_connected",
+			"1 while the market data WebSocket is connected."),
+	}
+}
+
+// The methods below make a nil *Trading a no-op, so the bots record
+// unconditionally whether or not metrics are enabled.
+
+// OrderPlaced records an accepted order, and a fill if filled.
+func (t *Trading) OrderPlaced(city, side string, filled bool) {
+	if t == nil {
+		return
+	}
+	t.OrdersPlaced.Inc(city, side)
+	if filled {
+		t.Fills.Inc(city, side)
+	}
+}
+
+// OrderRejected records an order that wasn't placed.
+func (t *Trading) OrderRejected(city, reason string) {
+	if t == nil {
+		return
+	}
+	t.Rejections.Inc(city, reason)
+}
+
+// SetExposure records the cost of the open positions.
+func (t *Trading) SetExposure(dollars float64) {
+	if t == nil {
+		return
+	}
+	t.Exposure.Set(dollars)
+}
+
+// SetBalance records the account balance.
+func (t *Trading) SetBalance(dollars float64) {
+	if t == nil {
+		return
+	}
+	t.Balance.Set(dollars)
+}
+
+// SetTemperature records a METAR reading of kind "current", "max" or "min".
+func (t *Trading) SetTemperature(station, kind string, tempF float64) {
+	if t == nil {
+		return
+	}
+	t.Temperature.Set(tempF, station, kind)
+}
+
+// SetEdge records the model's edge on one side of a market.
+func (t *Trading) SetEdge(market, side string, edge float64) {
+	if t == nil {
+		return
+	}
+	t.Edge.Set(edge, market, side)
+}
+
+// SetConnected records the WebSocket connection status.
+func (t *Trading) SetConnected(connected bool) {
+	if t == nil {
+		return
+	}
+	t.WebSocketConnected.SetBool(connected)
+}
@@ -0,0 +1,123 @@
+package mockexchange
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Faults configures the failures the exchange injects. Rates are
+// probabilities in [0, 1]; the zero value injects nothing.
+type Faults struct {
+	// Latency delays every REST response.
+	Latency time.Duration
+
+	// SpikeRate is the probability of an additional LatencySpike delay.
+	SpikeRate    float64
+	LatencySpike time.Duration
+
+	// ErrorRate is the probability a REST request is rejected with a 503
+	// before it is processed.
+	ErrorRate float64
+
+	// LostResponseRate is the probability a REST request is processed (an
+	// order is placed or canceled) but answered with a 500, as when a
+	// response is lost in transit. Clients must reconcile before retrying.
+	LostResponseRate float64
+
+	// PartialFillRate is the probability a marketable order fills only part
+	// of its count; the remainder rests.
+	PartialFillRate float64
+
+	// ReorderRate is the probability a WebSocket message is held back and
+	// delivered after the next one on the same connection.
+	ReorderRate float64
+}
+
+// FaultStats counts the faults injected so far.
+type FaultStats struct {
+	Spikes        int
+	Errors        int
+	LostResponses int
+	PartialFills  int
+	ReorderedMsgs int
+}
+
+// injector draws faults from a seeded source so runs are reproducible.
+type injector struct {
+	mu     sync.Mutex
+	faults Faults
+	rng    *rand.Rand
+	stats  FaultStats
+}
+
+func newInjector(seed uint64) *injector {
+	return &injector{rng: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))}
+}
+
+func (in *injector) set(f Faults) {
+	in.mu.Lock()
+	in.faults = f
+	in.mu.Unlock()
+}
+
+func (in *injector) snapshot() FaultStats {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.stats
+}
+
+// roll reports whether an event with probability p happens.
+func (in *injector) roll(p float64) bool {
+	return p > 0 && in.rng.Float64() < p
+}
+
+// delay returns how long to hold a REST response.
+func (in *injector) delay() time.Duration {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	d := in.faults.Latency
+	if in.roll(in.faults.SpikeRate) {
+		d += in.faults.LatencySpike
+		in.stats.Spikes++
+	}
+	return d
+}
+
+// fail decides whether a REST request is rejected outright (before) or has
+// its response lost (after processing).
+func (in *injector) fail() (before, after bool) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.roll(in.faults.ErrorRate) {
+		in.stats.Errors++
+		return true, false
+	}
+	if in.roll(in.faults.LostResponseRate) {
+		in.stats.LostResponses++
+		return false, true
+	}
+	return false, false
+}
+
+// partial returns how many of count available contracts to fill.
+func (in *injector) partial(count int) int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if count < 2 || !in.roll(in.faults.PartialFillRate) {
+		return count
+	}
+	in.stats.PartialFills++
+	return 1 + in.rng.IntN(count-1)
+}
+
+// reorder reports whether to hold back the next WebSocket message.
+func (in *injector) reorder() bool {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if in.roll(in.faults.ReorderRate) {
+		in.stats.ReorderedMsgs++
+		return true
+	}
+	return false
+}
@@ -0,0 +1,340 @@
+// Package mockexchange is an in-process fake of the Kalshi trade API, serving
+// the REST endpoints used by pkg/rest and the WebSocket channels used by
+// pkg/ws, with configurable fault injection (latency spikes, 5xx errors,
+// lost responses, partial fills and out-of-order WebSocket messages).
+//
+// It lets bots' retry, reconciliation and circuit-breaker paths be exercised
+// in tests without network access:
+//
+//	x := mockexchange.New(1)
+//	defer x.Close()
+//	x.AddMarket(rest.Market{Ticker: "KXHIGHLAX-25DEC05-B62.5", YesBid: 40, YesAsk: 42})
+//	x.SetFaults(mockexchange.Faults{ErrorRate: 0.2, PartialFillRate: 0.5})
+//
+//	client := rest.New("key", privateKey, rest.WithBaseURL(x.URL()))
+//	stream := ws.New(ws.WithBaseURL(x.WSURL()))
+//
+// Signatures are not verified. Every market has unlimited depth at its top of
+// book; orders that don't cross rest until SetQuote moves the market
+// through them.
+package mockexchange
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// DefaultBalance is the starting account balance in cents.
+const DefaultBalance = 1_000_000
+
+// Exchange is a running mock exchange.
+type Exchange struct {
+	server *httptest.Server
+	faults *injector
+
+	mu        sync.Mutex
+	markets   map[string]*rest.Market
+	orders    map[string]*rest.Order
+	orderSeq  int
+	tradeSeq  int
+	positions map[string]*rest.Position
+	balance   int
+
+	hub *hub
+}
+
+// New starts a mock exchange. seed makes fault injection reproducible.
+func New(seed uint64) *Exchange {
+	x := &Exchange{
+		faults:    newInjector(seed),
+		markets:   make(map[string]*rest.Market),
+		orders:    make(map[string]*rest.Order),
+		positions: make(map[string]*rest.Position),
+		balance:   DefaultBalance,
+	}
+	x.hub = newHub(x.faults)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trade-api/ws/v2", x.hub.serve)
+	mux.Handle("/trade-api/v2/", http.StripPrefix("/trade-api/v2", x.restHandler()))
+	x.server = httptest.NewServer(mux)
+	return x
+}
+
+// Close shuts the exchange down, dropping WebSocket connections.
+func (x *Exchange) Close() {
+	x.hub.closeAll()
+	x.server.Close()
+}
+
+// URL returns the REST base URL, for rest.WithBaseURL.
+func (x *Exchange) URL() string {
+	return x.server.URL + "/trade-api/v2"
+}
+
+// WSURL returns the WebSocket URL, for ws.WithBaseURL.
+func (x *Exchange) WSURL() string {
+	return "ws" + strings.TrimPrefix(x.server.URL, "http") + "/trade-api/ws/v2"
+}
+
+// SetFaults replaces the injected faults.
+func (x *Exchange) SetFaults(f Faults) {
+	x.faults.set(f)
+}
+
+// FaultStats returns the faults injected so far.
+func (x *Exchange) FaultStats() FaultStats {
+	return x.faults.snapshot()
+}
+
+// SetBalance sets the account balance in cents.
+func (x *Exchange) SetBalance(cents int) {
+	x.mu.Lock()
+	x.balance = cents
+	x.mu.Unlock()
+}
+
+// AddMarket lists a market. NoBid and NoAsk are derived from YesAsk and
+// YesBid when zero, and Status defaults to "active".
+func (x *Exchange) AddMarket(m rest.Market) {
+	if m.Status == "" {
+		m.Status = "active"
+	}
+	fillNoSide(&m)
+
+	x.mu.Lock()
+	x.markets[m.Ticker] = &m
+	x.mu.Unlock()
+}
+
+// SetQuote moves a market's top of book, filling any resting orders it
+// crosses, and publishes a ticker update.
+func (x *Exchange) SetQuote(ticker string, yesBid, yesAsk int) error {
+	x.mu.Lock()
+	m, ok := x.markets[ticker]
+	if !ok {
+		x.mu.Unlock()
+		return fmt.Errorf("mockexchange: unknown market %s", ticker)
+	}
+	m.YesBid, m.YesAsk = yesBid, yesAsk
+	m.NoBid, m.NoAsk = 0, 0
+	fillNoSide(m)
+
+	var fills []fill
+	for _, id := range x.restingOrders(ticker) {
+		o := x.orders[id]
+		fills = append(fills, x.match(o, m, false)...)
+	}
+	tick := tickerMsg(m)
+	x.mu.Unlock()
+
+	x.hub.publish(ws.ChannelTicker, ticker, tick)
+	x.publishFills(fills)
+	return nil
+}
+
+// Orders returns all orders in placement order.
+func (x *Exchange) Orders() []rest.Order {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	orders := make([]rest.Order, 0, len(x.orders))
+	for _, o := range x.orders {
+		orders = append(orders, *o)
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].OrderID < orders[j].OrderID })
+	return orders
+}
+
+// Flush delivers WebSocket messages held back by ReorderRate.
+func (x *Exchange) Flush() {
+	x.hub.flush()
+}
+
+// fill is one execution of an order.
+type fill struct {
+	tradeID string
+	order   rest.Order
+	taker   bool
+	price   int // Price of the order's side in cents
+	count   int
+}
+
+// restingOrders returns the IDs of a market's resting orders, oldest first.
+// Callers hold x.mu.
+func (x *Exchange) restingOrders(ticker string) []string {
+	var ids []string
+	for id, o := range x.orders {
+		if o.Ticker == ticker && o.Status == rest.OrderStatusResting {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// match fills as much of o as crosses m's top of book, updating the order,
+// position and balance. Callers hold x.mu.
+func (x *Exchange) match(o *rest.Order, m *rest.Market, taker bool) []fill {
+	if o.RemainingCount == 0 {
+		return nil
+	}
+	limit := o.YesPrice
+	if o.Side == rest.SideNo {
+		limit = o.NoPrice
+	}
+	bid, ask := m.YesBid, m.YesAsk
+	if o.Side == rest.SideNo {
+		bid, ask = m.NoBid, m.NoAsk
+	}
+
+	var price int
+	switch {
+	case o.Action == rest.OrderActionBuy && ask > 0 && limit >= ask:
+		price = ask
+	case o.Action == rest.OrderActionSell && bid > 0 && limit <= bid:
+		price = bid
+	default:
+		return nil
+	}
+
+	pos := x.position(o.Ticker, m.EventTicker)
+	count := o.RemainingCount
+	if o.Action == rest.OrderActionSell {
+		held := pos.YesPosition
+		if o.Side == rest.SideNo {
+			held = pos.NoPosition
+		}
+		count = min(count, held)
+	} else if price > 0 {
+		count = min(count, x.balance/price)
+	}
+	if taker {
+		count = x.faults.partial(count)
+	}
+	if count <= 0 {
+		return nil
+	}
+
+	signed, cost := count, count*price
+	if o.Action == rest.OrderActionSell {
+		signed, cost = -count, -count*price
+	}
+	if o.Side == rest.SideNo {
+		pos.NoPosition += signed
+	} else {
+		pos.YesPosition += signed
+	}
+	pos.TotalCost += cost
+	x.balance -= cost
+	m.Volume += count
+	m.LastPrice = price
+	if o.Side == rest.SideNo {
+		m.LastPrice = 100 - price
+	}
+
+	o.RemainingCount -= count
+	if taker {
+		o.TakerFillCount += count
+		o.TakerFillCost += count * price
+	} else {
+		o.MakerFillCount += count
+		o.MakerFillCost += count * price
+	}
+	if o.RemainingCount == 0 {
+		o.Status = rest.OrderStatusExecuted
+	}
+	o.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+
+	x.tradeSeq++
+	return []fill{{
+		tradeID: fmt.Sprintf("mock-trade-%d", x.tradeSeq),
+		order:   *o,
+		taker:   taker,
+		price:   price,
+		count:   count,
+	}}
+}
+
+// position returns the position in ticker, creating it. Callers hold x.mu.
+func (x *Exchange) position(ticker, eventTicker string) *rest.Position {
+	p, ok := x.positions[ticker]
+	if !ok {
+		p = &rest.Position{Ticker: ticker, EventTicker: eventTicker}
+		x.positions[ticker] = p
+	}
+	return p
+}
+
+// publishFills sends fill and public trade messages for executions.
+func (x *Exchange) publishFills(fills []fill) {
+	now := time.Now().Unix()
+	for _, f := range fills {
+		yes := f.price
+		if f.order.Side == rest.SideNo {
+			yes = 100 - f.price
+		}
+		x.hub.publish(ws.ChannelFill, f.order.Ticker, map[string]any{
+			"trade_id":      f.tradeID,
+			"order_id":      f.order.OrderID,
+			"market_ticker": f.order.Ticker,
+			"is_taker":      f.taker,
+			"side":          f.order.Side,
+			"action":        f.order.Action,
+			"yes_price":     yes,
+			"no_price":      100 - yes,
+			"count":         f.count,
+			"ts":            now,
+		})
+		takerSide := f.order.Side
+		if !f.taker {
+			takerSide = opposite(f.order.Side)
+		}
+		x.hub.publish(ws.ChannelTrade, f.order.Ticker, map[string]any{
+			"trade_id":      f.tradeID,
+			"market_ticker": f.order.Ticker,
+			"yes_price":     yes,
+			"no_price":      100 - yes,
+			"count":         f.count,
+			"taker_side":    takerSide,
+			"ts":            now,
+		})
+	}
+}
+
+func tickerMsg(m *rest.Market) map[string]any {
+	return map[string]any{
+		"market_ticker": m.Ticker,
+		"price":         m.LastPrice,
+		"yes_bid":       m.YesBid,
+		"yes_ask":       m.YesAsk,
+		"volume":        m.Volume,
+		"open_interest": m.OpenInterest,
+		"ts":            time.Now().Unix(),
+	}
+}
+
+// fillNoSide derives the NO quotes from the YES quotes where unset.
+func fillNoSide(m *rest.Market) {
+	if m.NoBid == 0 && m.YesAsk > 0 {
+		m.NoBid = 100 - m.YesAsk
+	}
+	if m.NoAsk == 0 && m.YesBid > 0 {
+		m.NoAsk = 100 - m.YesBid
+	}
+}
+
+func opposite(side rest.Side) rest.Side {
+	if side == rest.SideYes {
+		return rest.SideNo
+	}
+	return rest.SideYes
+}
@@ -0,0 +1,275 @@
+package mockexchange
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+const testTicker = "KXHIGHLAX-25DEC05-B62.5"
+
+func newTestExchange(t *testing.T) (*Exchange, *rest.Client) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	x := New(1)
+	t.Cleanup(x.Close)
+	x.AddMarket(rest.Market{Ticker: testTicker, EventTicker: "KXHIGHLAX-25DEC05", YesBid: 40, YesAsk: 42})
+
+	return x, rest.New("test-key", privateKey, rest.WithBaseURL(x.URL()))
+}
+
+func TestOrders_FillAndRest(t *testing.T) {
+	x, client := newTestExchange(t)
+
+	filled, err := client.BuyYes(testTicker, 10, 45)
+	if err != nil {
+		t.Fatalf("BuyYes failed: %v", err)
+	}
+	if filled.Status != rest.OrderStatusExecuted || filled.TakerFillCount != 10 || filled.TakerFillCost != 420 {
+		t.Errorf("marketable order = %+v, want executed 10 @ 42", filled)
+	}
+
+	resting, err := client.BuyNo(testTicker, 5, 55)
+	if err != nil {
+		t.Fatalf("BuyNo failed: %v", err)
+	}
+	if resting.Status != rest.OrderStatusResting {
+		t.Fatalf("Status = %s, want resting (NO ask is 60)", resting.Status)
+	}
+
+	// Moving YES bid to 45 puts the NO ask at 55, crossing the resting order
+	if err := x.SetQuote(testTicker, 45, 47); err != nil {
+		t.Fatalf("SetQuote failed: %v", err)
+	}
+	order, err := client.GetOrder(resting.OrderID)
+	if err != nil {
+		t.Fatalf("GetOrder failed: %v", err)
+	}
+	if order.Status != rest.OrderStatusExecuted || order.MakerFillCount != 5 {
+		t.Errorf("resting order after cross = %+v, want executed maker fill of 5", order)
+	}
+
+	pos, err := client.GetPosition(testTicker)
+	if err != nil {
+		t.Fatalf("GetPosition failed: %v", err)
+	}
+	if pos.YesPosition != 10 || pos.NoPosition != 5 {
+		t.Errorf("position = %d YES / %d NO, want 10 / 5", pos.YesPosition, pos.NoPosition)
+	}
+	balance, err := client.GetBalance()
+	if err != nil {
+		t.Fatalf("GetBalance failed: %v", err)
+	}
+	if want := DefaultBalance - 420 - 275; balance.Balance != want {
+		t.Errorf("Balance = %d, want %d", balance.Balance, want)
+	}
+}
+
+func TestOrders_Amend(t *testing.T) {
+	_, client := newTestExchange(t)
+
+	resting, err := client.BuyYes(testTicker, 10, 38)
+	if err != nil {
+		t.Fatalf("BuyYes failed: %v", err)
+	}
+	amended, err := client.AmendOrder(resting.OrderID, &rest.AmendOrderRequest{
+		Ticker: testTicker, Side: rest.SideYes, Action: rest.OrderActionBuy, Count: 6, YesPrice: 39,
+	})
+	if err != nil {
+		t.Fatalf("AmendOrder failed: %v", err)
+	}
+	if amended.OrderID != resting.OrderID || amended.Status != rest.OrderStatusResting || amended.YesPrice != 39 || amended.RemainingCount != 6 {
+		t.Errorf("amended order = %+v, want the same order resting 6 @ 39", amended)
+	}
+
+	// Amending through the ask fills as taker
+	amended, err = client.AmendOrder(resting.OrderID, &rest.AmendOrderRequest{
+		Ticker: testTicker, Side: rest.SideYes, Action: rest.OrderActionBuy, Count: 6, YesPrice: 42,
+	})
+	if err != nil {
+		t.Fatalf("AmendOrder failed: %v", err)
+	}
+	if amended.Status != rest.OrderStatusExecuted || amended.TakerFillCount != 6 {
+		t.Errorf("crossing amendment = %+v, want executed taker fill of 6", amended)
+	}
+
+	if _, err := client.AmendOrder(resting.OrderID, &rest.AmendOrderRequest{
+		Ticker: testTicker, Side: rest.SideYes, Action: rest.OrderActionBuy, Count: 8, YesPrice: 40,
+	}); err == nil {
+		t.Error("AmendOrder(executed order) error = nil, want error")
+	}
+}
+
+func TestFaults_Latency(t *testing.T) {
+	x, client := newTestExchange(t)
+	x.SetFaults(Faults{Latency: 20 * time.Millisecond, SpikeRate: 1, LatencySpike: 30 * time.Millisecond})
+
+	start := time.Now()
+	if _, err := client.GetMarket(testTicker); err != nil {
+		t.Fatalf("GetMarket failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("request took %v, want at least 50ms", elapsed)
+	}
+	if got := x.FaultStats().Spikes; got != 1 {
+		t.Errorf("Spikes = %d, want 1", got)
+	}
+}
+
+func TestFaults_ServerError(t *testing.T) {
+	x, client := newTestExchange(t)
+	x.SetFaults(Faults{ErrorRate: 1})
+
+	_, err := client.BuyYes(testTicker, 1, 45)
+	var apiErr *rest.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Fatalf("BuyYes error = %v, want 503 APIError", err)
+	}
+
+	x.SetFaults(Faults{})
+	if orders := x.Orders(); len(orders) != 0 {
+		t.Errorf("rejected request placed %d orders, want 0", len(orders))
+	}
+}
+
+func TestFaults_LostResponse(t *testing.T) {
+	x, client := newTestExchange(t)
+	x.SetFaults(Faults{LostResponseRate: 1})
+
+	_, err := client.CreateOrder(&rest.CreateOrderRequest{
+		Ticker:        testTicker,
+		Action:        rest.OrderActionBuy,
+		Side:          rest.SideYes,
+		Type:          rest.OrderTypeLimit,
+		Count:         3,
+		YesPrice:      42,
+		ClientOrderID: "retry-1",
+	})
+	var apiErr *rest.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 500 {
+		t.Fatalf("CreateOrder error = %v, want 500 APIError", err)
+	}
+
+	// The order went through; a reconciling client finds it
+	x.SetFaults(Faults{})
+	orders, err := client.GetOrders(testTicker, "")
+	if err != nil {
+		t.Fatalf("GetOrders failed: %v", err)
+	}
+	if len(orders) != 1 || orders[0].ClientOrderID != "retry-1" {
+		t.Fatalf("orders = %+v, want the lost order", orders)
+	}
+
+	// A blind retry with the same client order ID is rejected
+	_, err = client.CreateOrder(&rest.CreateOrderRequest{
+		Ticker:        testTicker,
+		Action:        rest.OrderActionBuy,
+		Side:          rest.SideYes,
+		Type:          rest.OrderTypeLimit,
+		Count:         3,
+		YesPrice:      42,
+		ClientOrderID: "retry-1",
+	})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 409 {
+		t.Errorf("retry error = %v, want 409 APIError", err)
+	}
+}
+
+func TestFaults_PartialFill(t *testing.T) {
+	x, client := newTestExchange(t)
+	x.SetFaults(Faults{PartialFillRate: 1})
+
+	order, err := client.BuyYes(testTicker, 10, 45)
+	if err != nil {
+		t.Fatalf("BuyYes failed: %v", err)
+	}
+	if order.Status != rest.OrderStatusResting || order.RemainingCount == 0 || order.TakerFillCount == 0 {
+		t.Errorf("order = %+v, want a partial fill with the remainder resting", order)
+	}
+	if order.TakerFillCount+order.RemainingCount != 10 {
+		t.Errorf("filled %d + remaining %d, want 10", order.TakerFillCount, order.RemainingCount)
+	}
+}
+
+func TestFaults_ReorderWS(t *testing.T) {
+	x, _ := newTestExchange(t)
+	x.SetFaults(Faults{ReorderRate: 0.5})
+
+	var mu sync.Mutex
+	var seqs []int64
+	subscribed := make(chan struct{}, 1)
+
+	client := ws.New(ws.WithBaseURL(x.WSURL()))
+	client.SetMessageHandler(func(resp *ws.Response) {
+		switch resp.Type {
+		case ws.MessageTypeSubscribed:
+			subscribed <- struct{}{}
+		case ws.MessageTypeTicker:
+			mu.Lock()
+			seqs = append(seqs, resp.Seq)
+			mu.Unlock()
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer client.Close()
+	if _, err := client.Subscribe(ctx, testTicker, ws.ChannelTicker); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	select {
+	case <-subscribed:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for subscription")
+	}
+
+	const n = 50
+	for i := range n {
+		x.SetQuote(testTicker, 40+i%5, 45+i%5)
+	}
+	x.Flush()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mu.Lock()
+		got := len(seqs)
+		mu.Unlock()
+		if got == n || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seqs) != n {
+		t.Fatalf("received %d messages, want %d", len(seqs), n)
+	}
+	outOfOrder := 0
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] < seqs[i-1] {
+			outOfOrder++
+		}
+	}
+	if outOfOrder == 0 {
+		t.Error("no messages arrived out of order")
+	}
+	// Each held message lands one place late, except one flushed at the end
+	if got := x.FaultStats().ReorderedMsgs; got < outOfOrder || got > outOfOrder+1 {
+		t.Errorf("ReorderedMsgs = %d, want %d or %d", got, outOfOrder, outOfOrder+1)
+	}
+}
@@ -0,0 +1,361 @@
+package mockexchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+// restHandler serves the REST API under /trade-api/v2, behind fault
+// injection.
+func (x *Exchange) restHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /exchange/status", x.getExchangeStatus)
+	mux.HandleFunc("GET /markets", x.getMarkets)
+	mux.HandleFunc("GET /markets/{ticker}", x.getMarket)
+	mux.HandleFunc("GET /markets/{ticker}/orderbook", x.getOrderbook)
+	mux.HandleFunc("GET /portfolio/balance", x.getBalance)
+	mux.HandleFunc("GET /portfolio/positions", x.getPositions)
+	mux.HandleFunc("GET /portfolio/positions/{ticker}", x.getPosition)
+	mux.HandleFunc("GET /portfolio/orders", x.getOrders)
+	mux.HandleFunc("GET /portfolio/orders/{id}", x.getOrder)
+	mux.HandleFunc("POST /portfolio/orders", x.createOrder)
+	mux.HandleFunc("POST /portfolio/orders/{id}/amend", x.amendOrder)
+	mux.HandleFunc("DELETE /portfolio/orders/{id}", x.cancelOrder)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(x.faults.delay())
+
+		before, after := x.faults.fail()
+		if before {
+			writeError(w, http.StatusServiceUnavailable, "service_unavailable", "injected fault")
+			return
+		}
+		if after {
+			// Process the request but lose the response
+			mux.ServeHTTP(discard{header: make(http.Header)}, r)
+			writeError(w, http.StatusInternalServerError, "internal_server_error", "injected fault (request was processed)")
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (x *Exchange) getExchangeStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, rest.ExchangeStatus{ExchangeActive: true, TradingActive: true})
+}
+
+func (x *Exchange) getMarkets(w http.ResponseWriter, r *http.Request) {
+	event := r.URL.Query().Get("event_ticker")
+
+	x.mu.Lock()
+	var markets []rest.Market
+	for _, m := range x.markets {
+		if event == "" || m.EventTicker == event {
+			markets = append(markets, *m)
+		}
+	}
+	x.mu.Unlock()
+
+	sort.Slice(markets, func(i, j int) bool { return markets[i].Ticker < markets[j].Ticker })
+	writeJSON(w, rest.GetMarketsResponse{Markets: markets})
+}
+
+func (x *Exchange) getMarket(w http.ResponseWriter, r *http.Request) {
+	x.mu.Lock()
+	m, ok := x.markets[r.PathValue("ticker")]
+	var market rest.Market
+	if ok {
+		market = *m
+	}
+	x.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "market not found")
+		return
+	}
+	writeJSON(w, map[string]any{"market": market})
+}
+
+// getOrderbook returns the top of book as a single level per side (bids).
+func (x *Exchange) getOrderbook(w http.ResponseWriter, r *http.Request) {
+	x.mu.Lock()
+	m, ok := x.markets[r.PathValue("ticker")]
+	var book rest.Orderbook
+	if ok {
+		if m.YesBid > 0 {
+			book.Yes = [][2]int{{m.YesBid, 1000}}
+		}
+		if m.NoBid > 0 {
+			book.No = [][2]int{{m.NoBid, 1000}}
+		}
+	}
+	x.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "market not found")
+		return
+	}
+	writeJSON(w, map[string]any{"orderbook": book})
+}
+
+func (x *Exchange) getBalance(w http.ResponseWriter, r *http.Request) {
+	x.mu.Lock()
+	balance := x.balance
+	x.mu.Unlock()
+	writeJSON(w, rest.Balance{Balance: balance})
+}
+
+func (x *Exchange) getPositions(w http.ResponseWriter, r *http.Request) {
+	x.mu.Lock()
+	var positions []rest.Position
+	for _, p := range x.positions {
+		pos := *p
+		pos.RestingOrdersCount = len(x.restingOrders(p.Ticker))
+		positions = append(positions, pos)
+	}
+	x.mu.Unlock()
+
+	sort.Slice(positions, func(i, j int) bool { return positions[i].Ticker < positions[j].Ticker })
+	writeJSON(w, rest.GetPositionsResponse{Positions: positions})
+}
+
+func (x *Exchange) getPosition(w http.ResponseWriter, r *http.Request) {
+	ticker := r.PathValue("ticker")
+
+	x.mu.Lock()
+	pos := rest.Position{Ticker: ticker}
+	if p, ok := x.positions[ticker]; ok {
+		pos = *p
+	}
+	pos.RestingOrdersCount = len(x.restingOrders(ticker))
+	x.mu.Unlock()
+
+	writeJSON(w, map[string]any{"market_position": pos})
+}
+
+func (x *Exchange) getOrders(w http.ResponseWriter, r *http.Request) {
+	ticker := r.URL.Query().Get("ticker")
+	status := rest.OrderStatus(r.URL.Query().Get("status"))
+
+	var orders []rest.Order
+	for _, o := range x.Orders() {
+		if (ticker == "" || o.Ticker == ticker) && (status == "" || o.Status == status) {
+			orders = append(orders, o)
+		}
+	}
+	writeJSON(w, rest.GetOrdersResponse{Orders: orders})
+}
+
+func (x *Exchange) getOrder(w http.ResponseWriter, r *http.Request) {
+	x.mu.Lock()
+	o, ok := x.orders[r.PathValue("id")]
+	var order rest.Order
+	if ok {
+		order = *o
+	}
+	x.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "order not found")
+		return
+	}
+	writeJSON(w, map[string]any{"order": order})
+}
+
+func (x *Exchange) createOrder(w http.ResponseWriter, r *http.Request) {
+	var req rest.CreateOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "invalid JSON body")
+		return
+	}
+	if req.Count <= 0 || (req.Side != rest.SideYes && req.Side != rest.SideNo) ||
+		(req.Action != rest.OrderActionBuy && req.Action != rest.OrderActionSell) {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "invalid order")
+		return
+	}
+
+	x.mu.Lock()
+	m, ok := x.markets[req.Ticker]
+	if !ok {
+		x.mu.Unlock()
+		writeError(w, http.StatusNotFound, "market_not_found", "market not found")
+		return
+	}
+
+	// Reject prices off the market's tick grid, as the real exchange does
+	if req.Type != rest.OrderTypeMarket {
+		price := req.YesPrice
+		if req.Side == rest.SideNo {
+			price = req.NoPrice
+		}
+		if err := m.PriceGrid().Validate(price); err != nil {
+			x.mu.Unlock()
+			writeError(w, http.StatusBadRequest, "invalid_price", err.Error())
+			return
+		}
+	}
+
+	// Reject a duplicate client order ID, as the real exchange does
+	if req.ClientOrderID != "" {
+		for _, o := range x.orders {
+			if o.ClientOrderID == req.ClientOrderID {
+				x.mu.Unlock()
+				writeError(w, http.StatusConflict, "order_already_exists", "duplicate client_order_id")
+				return
+			}
+		}
+	}
+
+	x.orderSeq++
+	now := time.Now().UTC().Format(time.RFC3339)
+	o := &rest.Order{
+		OrderID:        fmt.Sprintf("mock-order-%06d", x.orderSeq),
+		Ticker:         req.Ticker,
+		Action:         req.Action,
+		Side:           req.Side,
+		Type:           rest.OrderTypeLimit,
+		Status:         rest.OrderStatusResting,
+		YesPrice:       req.YesPrice,
+		NoPrice:        req.NoPrice,
+		CreatedTime:    now,
+		LastUpdateTime: now,
+		ClientOrderID:  req.ClientOrderID,
+		PlaceCount:     req.Count,
+		RemainingCount: req.Count,
+	}
+	if req.Side == rest.SideYes && o.NoPrice == 0 && o.YesPrice > 0 {
+		o.NoPrice = 100 - o.YesPrice
+	}
+	if req.Side == rest.SideNo && o.YesPrice == 0 && o.NoPrice > 0 {
+		o.YesPrice = 100 - o.NoPrice
+	}
+	if req.Type == rest.OrderTypeMarket {
+		// Marketable at any price
+		o.Type = rest.OrderTypeMarket
+		if req.Action == rest.OrderActionBuy {
+			o.YesPrice, o.NoPrice = 99, 99
+		} else {
+			o.YesPrice, o.NoPrice = 1, 1
+		}
+	}
+	x.orders[o.OrderID] = o
+
+	fills := x.match(o, m, true)
+	if o.Type == rest.OrderTypeMarket && o.RemainingCount > 0 {
+		o.Status = rest.OrderStatusCanceled
+	}
+	order := *o
+	x.mu.Unlock()
+
+	x.publishFills(fills)
+	writeJSON(w, rest.CreateOrderResponse{Order: order})
+}
+
+func (x *Exchange) cancelOrder(w http.ResponseWriter, r *http.Request) {
+	x.mu.Lock()
+	o, ok := x.orders[r.PathValue("id")]
+	if !ok {
+		x.mu.Unlock()
+		writeError(w, http.StatusNotFound, "not_found", "order not found")
+		return
+	}
+	reduced := 0
+	if o.Status == rest.OrderStatusResting {
+		reduced = o.RemainingCount
+		o.DecreaseCount += reduced
+		o.RemainingCount = 0
+		o.Status = rest.OrderStatusCanceled
+		o.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	}
+	order := *o
+	x.mu.Unlock()
+
+	writeJSON(w, rest.CancelOrderResponse{Order: order, ReducedBy: reduced})
+}
+
+func (x *Exchange) amendOrder(w http.ResponseWriter, r *http.Request) {
+	var req rest.AmendOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "invalid JSON body")
+		return
+	}
+
+	x.mu.Lock()
+	o, ok := x.orders[r.PathValue("id")]
+	if !ok {
+		x.mu.Unlock()
+		writeError(w, http.StatusNotFound, "not_found", "order not found")
+		return
+	}
+	m := x.markets[o.Ticker]
+	filled := o.TakerFillCount + o.MakerFillCount
+	price := req.YesPrice
+	if o.Side == rest.SideNo {
+		price = req.NoPrice
+	}
+	switch {
+	case o.Status != rest.OrderStatusResting:
+		x.mu.Unlock()
+		writeError(w, http.StatusBadRequest, "order_not_resting", "order is not resting")
+		return
+	case req.Ticker != o.Ticker || req.Side != o.Side || req.Action != o.Action || req.Count <= filled:
+		x.mu.Unlock()
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "invalid amendment")
+		return
+	}
+	if err := m.PriceGrid().Validate(price); err != nil {
+		x.mu.Unlock()
+		writeError(w, http.StatusBadRequest, "invalid_price", err.Error())
+		return
+	}
+
+	old := *o
+	o.PlaceCount = req.Count
+	o.RemainingCount = req.Count - filled
+	if o.Side == rest.SideNo {
+		o.NoPrice, o.YesPrice = price, 100-price
+	} else {
+		o.YesPrice, o.NoPrice = price, 100-price
+	}
+	if req.UpdatedClientOrderID != "" {
+		o.ClientOrderID = req.UpdatedClientOrderID
+	}
+	o.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+
+	// An amended price that crosses the book fills as taker
+	fills := x.match(o, m, true)
+	order := *o
+	x.mu.Unlock()
+
+	x.publishFills(fills)
+	writeJSON(w, rest.AmendOrderResponse{OldOrder: old, Order: order})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	var resp rest.ErrorResponse
+	resp.Error.Code = code
+	resp.Error.Message = message
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// discard is a ResponseWriter that drops the response of a request whose
+// reply is "lost".
+type discard struct {
+	header http.Header
+}
+
+func (d discard) Header() http.Header         { return d.header }
+func (d discard) Write(b []byte) (int, error) { return len(b), nil }
+func (d discard) WriteHeader(int)             {}
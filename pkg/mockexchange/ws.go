@@ -0,0 +1,180 @@
+package mockexchange
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// hub fans published messages out to subscribed WebSocket connections.
+type hub struct {
+	faults   *injector
+	upgrader websocket.Upgrader
+
+	mu    sync.Mutex
+	conns map[*wsConn]struct{}
+}
+
+// wsConn is one client connection and its subscriptions.
+type wsConn struct {
+	conn *websocket.Conn
+
+	mu      sync.Mutex
+	subs    []*subscription
+	nextSID int64
+	held    *ws.Response // Message held back to be delivered out of order
+}
+
+type subscription struct {
+	sid     int64
+	channel ws.Channel
+	ticker  string // Empty for all markets
+	seq     int64
+}
+
+func newHub(faults *injector) *hub {
+	return &hub{faults: faults, conns: make(map[*wsConn]struct{})}
+}
+
+// serve upgrades a request and handles the connection's commands until it
+// closes.
+func (h *hub) serve(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &wsConn{conn: conn}
+
+	h.mu.Lock()
+	h.conns[c] = struct{}{}
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.conns, c)
+		h.mu.Unlock()
+		conn.Close()
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req ws.Request
+		if err := json.Unmarshal(data, &req); err != nil {
+			c.write(ws.Response{Type: ws.MessageTypeError, Msg: ws.ErrorMsg{Code: 1, Msg: "invalid request"}})
+			continue
+		}
+		c.handle(req)
+	}
+}
+
+// handle answers a subscribe or unsubscribe command.
+func (c *wsConn) handle(req ws.Request) {
+	switch req.Cmd {
+	case ws.CommandSubscribe:
+		var params ws.SubscribeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params.Channels) == 0 {
+			c.write(ws.Response{ID: req.ID, Type: ws.MessageTypeError, Msg: ws.ErrorMsg{Code: 2, Msg: "invalid params"}})
+			return
+		}
+		for _, ch := range params.Channels {
+			c.mu.Lock()
+			c.nextSID++
+			sub := &subscription{sid: c.nextSID, channel: ch, ticker: params.MarketTicker}
+			c.subs = append(c.subs, sub)
+			c.mu.Unlock()
+
+			c.write(ws.Response{ID: req.ID, Type: ws.MessageTypeSubscribed, Msg: ws.SubscribedMsg{Channel: ch, SID: sub.sid}})
+		}
+
+	case ws.CommandUnsubscribe:
+		var params ws.UnsubscribeParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			c.write(ws.Response{ID: req.ID, Type: ws.MessageTypeError, Msg: ws.ErrorMsg{Code: 2, Msg: "invalid params"}})
+			return
+		}
+		for _, sid := range params.SIDs {
+			c.mu.Lock()
+			for i, sub := range c.subs {
+				if sub.sid == sid {
+					c.subs = append(c.subs[:i], c.subs[i+1:]...)
+					break
+				}
+			}
+			c.mu.Unlock()
+
+			c.write(ws.Response{ID: req.ID, SID: sid, Type: ws.MessageTypeUnsubscribed})
+		}
+
+	default:
+		c.write(ws.Response{ID: req.ID, Type: ws.MessageTypeError, Msg: ws.ErrorMsg{Code: 5, Msg: "unsupported command"}})
+	}
+}
+
+// write sends one message, ignoring errors from a closing connection.
+func (c *wsConn) write(resp ws.Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.WriteJSON(resp)
+}
+
+// publish sends msg to every subscription on channel that covers ticker. A
+// message may be held back and sent after the next one.
+func (h *hub) publish(channel ws.Channel, ticker string, msg any) {
+	h.mu.Lock()
+	conns := make([]*wsConn, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	h.mu.Unlock()
+
+	for _, c := range conns {
+		c.mu.Lock()
+		for _, sub := range c.subs {
+			if sub.channel != channel || (sub.ticker != "" && sub.ticker != ticker) {
+				continue
+			}
+			sub.seq++
+			resp := ws.Response{SID: sub.sid, Seq: sub.seq, Type: ws.MessageType(channel), Msg: msg}
+
+			switch {
+			case c.held != nil:
+				c.conn.WriteJSON(resp)
+				c.conn.WriteJSON(*c.held)
+				c.held = nil
+			case h.faults.reorder():
+				c.held = &resp
+			default:
+				c.conn.WriteJSON(resp)
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+// flush sends any held-back messages.
+func (h *hub) flush() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.conns {
+		c.mu.Lock()
+		if c.held != nil {
+			c.conn.WriteJSON(*c.held)
+			c.held = nil
+		}
+		c.mu.Unlock()
+	}
+}
+
+func (h *hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.conns {
+		c.conn.Close()
+	}
+}
@@ -0,0 +1,301 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// calibrationPrior is the weight, in days, of the broader estimate a
+// regime's own days are shrunk towards: a regime with a handful of settled
+// days stays close to its station's calibration, and a station with few
+// days close to DefaultCalibration.
+const calibrationPrior = 5.0
+
+// defaultCalibrationSpread is the standard deviation in °F assumed for the
+// METAR to CLI difference until a regime has two settled days.
+const defaultCalibrationSpread = 1.0
+
+// SettledDay is a day whose official (CLI) value is known, with the METAR
+// extreme the traders saw.
+type SettledDay struct {
+	Station string `json:"station"` // Station code, e.g. LAX
+	Date    string `json:"date"`    // Local day, YYYY-MM-DD
+	Low     bool   `json:"low,omitempty"`
+	METAR   int    `json:"metar"` // Running METAR max, or min for a LOW day
+	CLI     int    `json:"cli"`   // Official high, or low
+}
+
+// offset returns the day's calibration: how far the official value is
+// outside the METAR extreme, positive when the CLI caught a more extreme
+// reading.
+func (d SettledDay) offset() float64 {
+	if d.Low {
+		return float64(d.METAR - d.CLI)
+	}
+	return float64(d.CLI - d.METAR)
+}
+
+// Regime is the part of a station's climate a calibration applies to: the
+// season, and the band of the METAR extreme in 10°F steps.
+type Regime struct {
+	Season string `json:"season"` // "winter", "spring", "summer" or "fall"
+	Band   int    `json:"band"`   // Lowest °F of the band, e.g. 60 for 60-69°F
+}
+
+// RegimeOf returns the regime of a day with a METAR extreme.
+func RegimeOf(date time.Time, metar int) Regime {
+	seasons := [...]string{"winter", "spring", "summer", "fall"}
+	return Regime{
+		Season: seasons[int(date.Month())%12/3],
+		Band:   int(math.Floor(float64(metar)/10)) * 10,
+	}
+}
+
+func (r Regime) String() string {
+	return fmt.Sprintf("%s %d-%d°F", r.Season, r.Band, r.Band+9)
+}
+
+// CalibrationEstimate is the learned calibration of a station and regime.
+type CalibrationEstimate struct {
+	Station string  `json:"station"`
+	Low     bool    `json:"low,omitempty"`
+	Regime  Regime  `json:"regime"`
+	Offset  float64 `json:"offset"` // °F to add to the METAR max, or subtract from the min
+	Lower   float64 `json:"lower"`  // 95% confidence interval of Offset
+	Upper   float64 `json:"upper"`
+	Days    int     `json:"days"` // Settled days of the regime
+}
+
+// Calibration learns the METAR to CLI calibration per station and regime
+// from settled days, in place of DefaultCalibration. Each regime's mean is
+// shrunk towards its station's, and the station's towards the default, so
+// a regime's first days move it gradually. It is safe for concurrent use.
+type Calibration struct {
+	mu     sync.RWMutex
+	days   map[string]SettledDay    // Station, kind and date -> day
+	closes map[string]ClosingLadder // Station, kind and date -> ladder at the close
+}
+
+// NewCalibration returns a calibration with no settled days, which
+// estimates DefaultCalibration everywhere.
+func NewCalibration() *Calibration {
+	return &Calibration{days: make(map[string]SettledDay), closes: make(map[string]ClosingLadder)}
+}
+
+func calibrationKey(station string, low bool, date string) string {
+	kind := "high"
+	if low {
+		kind = "low"
+	}
+	return station + "/" + kind + "/" + date
+}
+
+// Observe records a settled day. It reports false if the day was already
+// recorded with the same values, so ingesting a day twice is harmless.
+func (c *Calibration) Observe(d SettledDay) bool {
+	key := calibrationKey(d.Station, d.Low, d.Date)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if prev, ok := c.days[key]; ok && prev == d {
+		return false
+	}
+	c.days[key] = d
+	return true
+}
+
+// Has reports whether a station's day has been recorded.
+func (c *Calibration) Has(station string, low bool, date string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.days[calibrationKey(station, low, date)]
+	return ok
+}
+
+// Offset returns the calibration for a station's day with a METAR extreme,
+// for HighForecast and LowForecast.
+func (c *Calibration) Offset(station string, low bool, date time.Time, metar int) float64 {
+	return c.Estimate(station, low, RegimeOf(date, metar)).Offset
+}
+
+// Estimate returns the calibration of a station's regime.
+func (c *Calibration) Estimate(station string, low bool, regime Regime) CalibrationEstimate {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var all, own []float64
+	for _, d := range c.days {
+		if d.Station != station || d.Low != low {
+			continue
+		}
+		all = append(all, d.offset())
+		if t, err := time.Parse("2006-01-02", d.Date); err == nil && RegimeOf(t, d.METAR) == regime {
+			own = append(own, d.offset())
+		}
+	}
+	stationMean, _ := shrink(all, DefaultCalibration)
+	mean, stderr := shrink(own, stationMean)
+	return CalibrationEstimate{
+		Station: station,
+		Low:     low,
+		Regime:  regime,
+		Offset:  mean,
+		Lower:   mean - 1.96*stderr,
+		Upper:   mean + 1.96*stderr,
+		Days:    len(own),
+	}
+}
+
+// Estimates returns the calibration of every station and regime with a
+// settled day, by station, kind and regime.
+func (c *Calibration) Estimates() []CalibrationEstimate {
+	type group struct {
+		station string
+		low     bool
+		regime  Regime
+	}
+	c.mu.RLock()
+	seen := make(map[group]bool)
+	for _, d := range c.days {
+		if t, err := time.Parse("2006-01-02", d.Date); err == nil {
+			seen[group{d.Station, d.Low, RegimeOf(t, d.METAR)}] = true
+		}
+	}
+	c.mu.RUnlock()
+
+	estimates := make([]CalibrationEstimate, 0, len(seen))
+	for g := range seen {
+		estimates = append(estimates, c.Estimate(g.station, g.low, g.regime))
+	}
+	sort.Slice(estimates, func(i, j int) bool {
+		a, b := estimates[i], estimates[j]
+		if a.Station != b.Station {
+			return a.Station < b.Station
+		}
+		if a.Low != b.Low {
+			return !a.Low
+		}
+		if a.Regime.Season != b.Regime.Season {
+			return a.Regime.Season < b.Regime.Season
+		}
+		return a.Regime.Band < b.Regime.Band
+	})
+	return estimates
+}
+
+// shrink returns the mean of offsets shrunk towards prior by
+// calibrationPrior days, and its standard error.
+func shrink(offsets []float64, prior float64) (mean, stderr float64) {
+	n := float64(len(offsets))
+	sum := 0.0
+	for _, o := range offsets {
+		sum += o
+	}
+	mean = (sum + calibrationPrior*prior) / (n + calibrationPrior)
+
+	spread := defaultCalibrationSpread
+	if len(offsets) >= 2 {
+		sample := sum / n
+		ss := 0.0
+		for _, o := range offsets {
+			ss += (o - sample) * (o - sample)
+		}
+		spread = math.Sqrt(ss / (n - 1))
+	}
+	return mean, spread / math.Sqrt(n+calibrationPrior)
+}
+
+// calibrationFile is a saved calibration. Files saved before closing
+// ladders were recorded hold the settled days alone, as an array.
+type calibrationFile struct {
+	Days   []SettledDay    `json:"days"`
+	Closes []ClosingLadder `json:"closes,omitempty"`
+}
+
+// Save writes the settled days and closing ladders to path as JSON.
+func (c *Calibration) Save(path string) error {
+	c.mu.RLock()
+	file := calibrationFile{Days: make([]SettledDay, 0, len(c.days))}
+	for _, d := range c.days {
+		file.Days = append(file.Days, d)
+	}
+	for _, l := range c.closes {
+		file.Closes = append(file.Closes, l)
+	}
+	c.mu.RUnlock()
+	sort.Slice(file.Days, func(i, j int) bool {
+		a, b := file.Days[i], file.Days[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.Station != b.Station {
+			return a.Station < b.Station
+		}
+		return !a.Low && b.Low
+	})
+	sort.Slice(file.Closes, func(i, j int) bool {
+		a, b := file.Closes[i], file.Closes[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		if a.Station != b.Station {
+			return a.Station < b.Station
+		}
+		return !a.Low && b.Low
+	})
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal calibration: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create calibration directory: %w", err)
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write calibration: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write calibration: %w", err)
+	}
+	return nil
+}
+
+// LoadCalibration reads a calibration saved by Save, or an older file of
+// settled days alone. A missing file is an empty calibration.
+func LoadCalibration(path string) (*Calibration, error) {
+	c := NewCalibration()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read calibration: %w", err)
+	}
+	var file calibrationFile
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(data, &file.Days)
+	} else {
+		err = json.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse calibration %s: %w", path, err)
+	}
+	for _, d := range file.Days {
+		c.Observe(d)
+	}
+	for _, l := range file.Closes {
+		c.ObserveClose(l)
+	}
+	return c, nil
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRegimeOf(t *testing.T) {
+	tests := []struct {
+		date  string
+		metar int
+		want  Regime
+	}{
+		{"2025-12-05", 64, Regime{"winter", 60}},
+		{"2025-02-28", 70, Regime{"winter", 70}},
+		{"2025-03-01", 59, Regime{"spring", 50}},
+		{"2025-07-04", 101, Regime{"summer", 100}},
+		{"2025-11-30", 44, Regime{"fall", 40}},
+		{"2025-01-10", -3, Regime{"winter", -10}},
+	}
+	for _, tt := range tests {
+		date, _ := time.Parse("2006-01-02", tt.date)
+		if got := RegimeOf(date, tt.metar); got != tt.want {
+			t.Errorf("RegimeOf(%s, %d) = %v, want %v", tt.date, tt.metar, got, tt.want)
+		}
+	}
+}
+
+func TestCalibration_Estimate(t *testing.T) {
+	c := NewCalibration()
+	winter := Regime{"winter", 60}
+
+	// No settled days: the default, with the assumed spread
+	if e := c.Estimate("LAX", false, winter); e.Offset != DefaultCalibration || e.Days != 0 || e.Lower >= e.Offset || e.Upper <= e.Offset {
+		t.Errorf("empty Estimate = %+v, want %v with an interval around it", e, DefaultCalibration)
+	}
+
+	// LAX's CLI runs 2° over the METAR max in the winter 60s
+	for day := 1; day <= 20; day++ {
+		c.Observe(SettledDay{Station: "LAX", Date: fmt.Sprintf("2025-12-%02d", day), METAR: 64, CLI: 66})
+	}
+	e := c.Estimate("LAX", false, winter)
+	if e.Days != 20 || e.Offset <= 1.8 || e.Offset >= 2 {
+		t.Errorf("Estimate(LAX winter 60s) = %+v, want 20 days shrunk just under 2", e)
+	}
+	if e.Upper-e.Lower >= 1 {
+		t.Errorf("Estimate(LAX winter 60s) interval %.2f-%.2f, want narrower than 1°", e.Lower, e.Upper)
+	}
+
+	// A regime without days of its own follows the station
+	if got := c.Offset("LAX", false, time.Date(2025, 12, 28, 0, 0, 0, 0, time.UTC), 75); got <= DefaultCalibration || got >= e.Offset {
+		t.Errorf("Offset(LAX winter 70s) = %v, want between the default and the 60s' %v", got, e.Offset)
+	}
+
+	// Other stations and LOW days are separate
+	if got := c.Estimate("DEN", false, winter); got.Offset != DefaultCalibration {
+		t.Errorf("Estimate(DEN) = %v, want the default", got.Offset)
+	}
+	if got := c.Estimate("LAX", true, winter); got.Offset != DefaultCalibration {
+		t.Errorf("Estimate(LAX LOW) = %v, want the default", got.Offset)
+	}
+
+	// A LOW day's offset is how far the CLI low is below the METAR min
+	c.Observe(SettledDay{Station: "DEN", Date: "2025-12-05", Low: true, METAR: 22, CLI: 19})
+	if got := c.Estimate("DEN", true, Regime{"winter", 20}); got.Offset <= DefaultCalibration {
+		t.Errorf("Estimate(DEN LOW) = %v, want above the default", got.Offset)
+	}
+}
+
+func TestCalibration_Observe(t *testing.T) {
+	c := NewCalibration()
+	d := SettledDay{Station: "LAX", Date: "2025-12-05", METAR: 64, CLI: 66}
+	if !c.Observe(d) {
+		t.Error("Observe(new day) = false, want true")
+	}
+	if c.Observe(d) {
+		t.Error("Observe(same day) = true, want false")
+	}
+	if !c.Has("LAX", false, "2025-12-05") || c.Has("LAX", true, "2025-12-05") {
+		t.Error("Has() doesn't match the day observed")
+	}
+
+	// A corrected report replaces the day rather than counting twice
+	d.CLI = 65
+	if !c.Observe(d) {
+		t.Error("Observe(corrected day) = false, want true")
+	}
+	if e := c.Estimates(); len(e) != 1 || e[0].Days != 1 {
+		t.Errorf("Estimates() = %+v, want one regime with one day", e)
+	}
+}
+
+func TestCalibration_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.json")
+
+	empty, err := LoadCalibration(path)
+	if err != nil || len(empty.Estimates()) != 0 {
+		t.Fatalf("LoadCalibration(missing) = %v, %v, want empty", empty, err)
+	}
+
+	c := NewCalibration()
+	c.Observe(SettledDay{Station: "LAX", Date: "2025-12-05", METAR: 64, CLI: 66})
+	c.Observe(SettledDay{Station: "DEN", Date: "2025-12-05", Low: true, METAR: 22, CLI: 21})
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadCalibration(path)
+	if err != nil {
+		t.Fatalf("LoadCalibration() error = %v", err)
+	}
+	want, got := c.Estimates(), loaded.Estimates()
+	if len(got) != len(want) {
+		t.Fatalf("loaded %d estimates, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i].Offset-want[i].Offset) > 1e-9 || got[i].Station != want[i].Station {
+			t.Errorf("loaded estimate %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package model
+
+import (
+	"math"
+	"sort"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+)
+
+// ClosingLadder is an event's bracket ladder as our model and the market
+// priced it at the market's close, the last prices before settlement.
+type ClosingLadder struct {
+	Station  string          `json:"station"` // Station code, e.g. LAX
+	Date     string          `json:"date"`    // Local day, YYYY-MM-DD
+	Low      bool            `json:"low,omitempty"`
+	Brackets []LadderBracket `json:"brackets"`
+}
+
+// LadderBracket is one bracket of a closing ladder.
+type LadderBracket struct {
+	Floor  int     `json:"floor"`  // market.OpenFloor for the "below" tail
+	Cap    int     `json:"cap"`    // market.OpenCap for the "above" tail
+	Model  float64 `json:"model"`  // Our probability
+	Market float64 `json:"market"` // The market's, from its closing price
+}
+
+// NewClosingLadder returns the ladder of strikes with the model's
+// probabilities and the market's closing YES prices, in any unit. Both are
+// normalized to sum to 1, which takes the overround out of the prices.
+func NewClosingLadder(station, date string, low bool, strikes market.Strikes, probs, prices []float64) ClosingLadder {
+	l := ClosingLadder{Station: station, Date: date, Low: low}
+	var modelSum, marketSum float64
+	for i, s := range strikes {
+		modelSum += probs[i]
+		marketSum += prices[i]
+		l.Brackets = append(l.Brackets, LadderBracket{Floor: s.Floor, Cap: s.Cap, Model: probs[i], Market: prices[i]})
+	}
+	for i := range l.Brackets {
+		if modelSum > 0 {
+			l.Brackets[i].Model /= modelSum
+		}
+		if marketSum > 0 {
+			l.Brackets[i].Market /= marketSum
+		}
+	}
+	sort.Slice(l.Brackets, func(i, j int) bool { return l.Brackets[i].Floor < l.Brackets[j].Floor })
+	return l
+}
+
+// value returns the temperature a bracket stands for in the ladder's mean:
+// its midpoint, or a degree past the edge of an open tail.
+func (b LadderBracket) value() (float64, bool) {
+	switch {
+	case b.Floor == market.OpenFloor && b.Cap == market.OpenCap:
+		return 0, false
+	case b.Floor == market.OpenFloor:
+		return float64(b.Cap - 1), true
+	case b.Cap == market.OpenCap:
+		return float64(b.Floor + 1), true
+	}
+	return float64(b.Floor+b.Cap) / 2, true
+}
+
+// Means returns the expected official value under the model's ladder and
+// the market's.
+func (l ClosingLadder) Means() (model, mkt float64) {
+	var modelMass, marketMass float64
+	for _, b := range l.Brackets {
+		v, ok := b.value()
+		if !ok {
+			continue
+		}
+		model += b.Model * v
+		mkt += b.Market * v
+		modelMass += b.Model
+		marketMass += b.Market
+	}
+	if modelMass > 0 {
+		model /= modelMass
+	}
+	if marketMass > 0 {
+		mkt /= marketMass
+	}
+	return model, mkt
+}
+
+// Bias returns how many °F the model's expected value is above the
+// market's.
+func (l ClosingLadder) Bias() float64 {
+	model, mkt := l.Means()
+	return model - mkt
+}
+
+// Brier returns the Brier scores of the model's ladder and the market's
+// against the official value: the squared error of each bracket's
+// probability, summed. Lower is better.
+func (l ClosingLadder) Brier(official int) (model, mkt float64) {
+	for _, b := range l.Brackets {
+		outcome := 0.0
+		if (market.Strike{Floor: b.Floor, Cap: b.Cap}).Contains(official) {
+			outcome = 1
+		}
+		model += (b.Model - outcome) * (b.Model - outcome)
+		mkt += (b.Market - outcome) * (b.Market - outcome)
+	}
+	return model, mkt
+}
+
+// ClosingBias is how our model's closing ladders have differed from the
+// market's at a station. The market isn't the truth, so a bias is a
+// question for the model rather than a correction; the Brier scores of the
+// settled days say which side was closer.
+type ClosingBias struct {
+	Station     string  `json:"station"`
+	Low         bool    `json:"low,omitempty"`
+	Days        int     `json:"days"`  // Closing ladders recorded
+	Bias        float64 `json:"bias"`  // Mean °F the model's expected value is above the market's
+	Lower       float64 `json:"lower"` // 95% confidence interval of Bias
+	Upper       float64 `json:"upper"`
+	Settled     int     `json:"settled"`      // Days of them whose official value is known
+	ModelBrier  float64 `json:"model_brier"`  // Mean Brier score of the model over the settled days
+	MarketBrier float64 `json:"market_brier"` // And of the market
+}
+
+// Systematic reports whether the bias is distinguishable from none: its
+// interval excludes zero.
+func (b ClosingBias) Systematic() bool {
+	return b.Lower > 0 || b.Upper < 0
+}
+
+// ObserveClose records an event's closing ladder, replacing an earlier one
+// of the same day.
+func (c *Calibration) ObserveClose(l ClosingLadder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closes[calibrationKey(l.Station, l.Low, l.Date)] = l
+}
+
+// Close returns a station's closing ladder of a day, if one was recorded.
+func (c *Calibration) Close(station string, low bool, date string) (ClosingLadder, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	l, ok := c.closes[calibrationKey(station, low, date)]
+	return l, ok
+}
+
+// ClosingBias returns the bias of a station's closing ladders, scored
+// against the official values of the settled days.
+func (c *Calibration) ClosingBias(station string, low bool) ClosingBias {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	b := ClosingBias{Station: station, Low: low}
+	var biases []float64
+	for key, l := range c.closes {
+		if l.Station != station || l.Low != low {
+			continue
+		}
+		biases = append(biases, l.Bias())
+		if d, ok := c.days[key]; ok {
+			model, mkt := l.Brier(d.CLI)
+			b.ModelBrier += model
+			b.MarketBrier += mkt
+			b.Settled++
+		}
+	}
+	b.Days = len(biases)
+	if b.Settled > 0 {
+		b.ModelBrier /= float64(b.Settled)
+		b.MarketBrier /= float64(b.Settled)
+	}
+	if b.Days == 0 {
+		return b
+	}
+
+	n := float64(b.Days)
+	for _, v := range biases {
+		b.Bias += v / n
+	}
+	spread := defaultCalibrationSpread
+	if b.Days >= 2 {
+		ss := 0.0
+		for _, v := range biases {
+			ss += (v - b.Bias) * (v - b.Bias)
+		}
+		spread = math.Sqrt(ss / (n - 1))
+	}
+	stderr := spread / math.Sqrt(n)
+	b.Lower, b.Upper = b.Bias-1.96*stderr, b.Bias+1.96*stderr
+	return b
+}
+
+// ClosingBiases returns the closing bias of every station and kind with a
+// closing ladder, by station and kind.
+func (c *Calibration) ClosingBiases() []ClosingBias {
+	type group struct {
+		station string
+		low     bool
+	}
+	c.mu.RLock()
+	seen := make(map[group]bool)
+	for _, l := range c.closes {
+		seen[group{l.Station, l.Low}] = true
+	}
+	c.mu.RUnlock()
+
+	biases := make([]ClosingBias, 0, len(seen))
+	for g := range seen {
+		biases = append(biases, c.ClosingBias(g.station, g.low))
+	}
+	sort.Slice(biases, func(i, j int) bool {
+		if biases[i].Station != biases[j].Station {
+			return biases[i].Station < biases[j].Station
+		}
+		return !biases[i].Low && biases[j].Low
+	})
+	return biases
+}
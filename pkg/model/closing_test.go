@@ -0,0 +1,105 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+)
+
+// ladder returns LAX's closing ladder of a December day over 64° or below,
+// 65-66°, 67-68° and 69° or above
+func ladder(day int, probs, prices []float64) ClosingLadder {
+	strikes := market.Strikes{
+		{Floor: market.OpenFloor, Cap: 64},
+		{Floor: 65, Cap: 66},
+		{Floor: 67, Cap: 68},
+		{Floor: 69, Cap: market.OpenCap},
+	}
+	return NewClosingLadder("LAX", fmt.Sprintf("2025-12-%02d", day), false, strikes, probs, prices)
+}
+
+func TestClosingLadder(t *testing.T) {
+	// The market's prices sum to 110¢: 10¢ of overround
+	l := ladder(5, []float64{0, 0.2, 0.6, 0.2}, []float64{11, 55, 33, 11})
+	if b := l.Brackets[1]; b.Model != 0.2 || math.Abs(b.Market-0.5) > 1e-9 {
+		t.Errorf("65-66° bracket = %+v, want model 0.2 and market 0.5", b)
+	}
+
+	// Model: 0.2*65.5 + 0.6*67.5 + 0.2*70; market: 0.1*63 + 0.5*65.5 + 0.3*67.5 + 0.1*70
+	model, mkt := l.Means()
+	if math.Abs(model-67.6) > 1e-9 || math.Abs(mkt-66.3) > 1e-9 {
+		t.Errorf("Means() = %.2f, %.2f, want 67.60, 66.30", model, mkt)
+	}
+	if math.Abs(l.Bias()-1.3) > 1e-9 {
+		t.Errorf("Bias() = %.2f, want 1.30", l.Bias())
+	}
+
+	// Settled at 67°: the model had 60% on it, the market 30%
+	model, mkt = l.Brier(67)
+	if math.Abs(model-0.24) > 1e-9 || math.Abs(mkt-0.76) > 1e-9 {
+		t.Errorf("Brier(67) = %.2f, %.2f, want 0.24, 0.76", model, mkt)
+	}
+}
+
+func TestCalibration_ClosingBias(t *testing.T) {
+	c := NewCalibration()
+	if b := c.ClosingBias("LAX", false); b.Days != 0 || b.Systematic() {
+		t.Errorf("empty ClosingBias = %+v, want no days", b)
+	}
+
+	// The model leans two degrees warmer than the market every day
+	for day := 1; day <= 10; day++ {
+		probs := []float64{0, 0, 0.5, 0.5}
+		if day%2 == 0 {
+			probs = []float64{0, 0, 0.6, 0.4}
+		}
+		c.ObserveClose(ladder(day, probs, []float64{0, 50, 50, 0}))
+	}
+	c.Observe(SettledDay{Station: "LAX", Date: "2025-12-01", METAR: 66, CLI: 67})
+	c.Observe(SettledDay{Station: "LAX", Date: "2025-12-02", METAR: 64, CLI: 65})
+
+	b := c.ClosingBias("LAX", false)
+	if b.Days != 10 || math.Abs(b.Bias-2.125) > 1e-9 || !b.Systematic() {
+		t.Errorf("ClosingBias(LAX) = %+v, want 10 days at +2.125° and systematic", b)
+	}
+	// Day 1 settled in 67-68°, where both had half; day 2 in 65-66°, where
+	// the model had nothing
+	if b.Settled != 2 || math.Abs(b.ModelBrier-(0.5+1.52)/2) > 1e-9 || math.Abs(b.MarketBrier-0.5) > 1e-9 {
+		t.Errorf("ClosingBias(LAX) Brier = %d days, %.3f vs %.3f, want 2 days, 1.010 vs 0.500", b.Settled, b.ModelBrier, b.MarketBrier)
+	}
+
+	if all := c.ClosingBiases(); len(all) != 1 || all[0].Station != "LAX" {
+		t.Errorf("ClosingBiases() = %+v, want LAX alone", all)
+	}
+}
+
+func TestCalibration_SaveLoadCloses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calibration.json")
+	c := NewCalibration()
+	c.Observe(SettledDay{Station: "LAX", Date: "2025-12-05", METAR: 64, CLI: 66})
+	c.ObserveClose(ladder(5, []float64{0, 0.2, 0.6, 0.2}, []float64{11, 55, 33, 11}))
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadCalibration(path)
+	if err != nil {
+		t.Fatalf("LoadCalibration() error = %v", err)
+	}
+	if got, want := loaded.ClosingBias("LAX", false), c.ClosingBias("LAX", false); got != want {
+		t.Errorf("loaded ClosingBias = %+v, want %+v", got, want)
+	}
+
+	// Files of settled days alone, from before closing ladders, still load
+	old := `[{"station": "LAX", "date": "2025-12-05", "metar": 64, "cli": 66}]`
+	if err := os.WriteFile(path, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = LoadCalibration(path)
+	if err != nil || !loaded.Has("LAX", false, "2025-12-05") {
+		t.Errorf("LoadCalibration(days array) = %v, want the day", err)
+	}
+}
@@ -0,0 +1,281 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// DefaultResidualCutoff is the local time of day the morning prediction of
+// archived days is made at.
+const DefaultResidualCutoff = 10 * time.Hour
+
+// residualPrior is the weight, in days, of the normal distribution an
+// empirical shape is shrunk towards: a station-month with a handful of
+// archived days stays close to the normal, and one with a season of days
+// follows its own tails.
+const residualPrior = 10.0
+
+// Residuals holds histograms of the official high (or low) minus the
+// morning prediction, in whole °F, per station and calendar month. Their
+// shape replaces the normal distribution's: settlements cluster tighter
+// than a normal around the prediction but miss by more in the tails, which
+// is what the NO side of a far bracket is exposed to. It is safe for
+// concurrent use.
+type Residuals struct {
+	mu    sync.RWMutex
+	hists map[residualKey]map[int]int
+}
+
+type residualKey struct {
+	station string
+	low     bool
+	month   time.Month
+}
+
+// NewResiduals returns empty histograms, whose shape is the normal
+// everywhere.
+func NewResiduals() *Residuals {
+	return &Residuals{hists: make(map[residualKey]map[int]int)}
+}
+
+// Add records a settled day of a station and month whose official value
+// finished residual °F from the morning prediction.
+func (r *Residuals) Add(station string, low bool, month time.Month, residual int) {
+	k := residualKey{station, low, month}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hists[k] == nil {
+		r.hists[k] = make(map[int]int)
+	}
+	r.hists[k][residual]++
+}
+
+// Histogram returns the days per residual of a station and month.
+func (r *Residuals) Histogram(station string, low bool, month time.Month) map[int]int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hist := make(map[int]int, len(r.hists[residualKey{station, low, month}]))
+	for residual, n := range r.hists[residualKey{station, low, month}] {
+		hist[residual] = n
+	}
+	return hist
+}
+
+// Shape returns the standardized shape of a station and month's residuals:
+// each residual less their mean, over their standard deviation, so the
+// forecast it is applied to keeps its own center and spread.
+func (r *Residuals) Shape(station string, low bool, month time.Month) Shape {
+	hist := r.Histogram(station, low, month)
+	var n, sum float64
+	for residual, count := range hist {
+		n += float64(count)
+		sum += float64(residual * count)
+	}
+	if n < 2 {
+		return Shape{}
+	}
+	mean := sum / n
+	var ss float64
+	for residual, count := range hist {
+		ss += float64(count) * (float64(residual) - mean) * (float64(residual) - mean)
+	}
+	sd := math.Sqrt(ss / (n - 1))
+	if sd == 0 {
+		return Shape{}
+	}
+
+	// Gaussian kernels of Silverman's bandwidth, with the points pulled in
+	// so the smoothed shape keeps a unit variance
+	h := 1.06 * math.Pow(n, -0.2)
+	scale := 1 / math.Sqrt(1+h*h)
+	s := Shape{Days: int(n), bandwidth: h * scale}
+	residuals := make([]int, 0, len(hist))
+	for residual := range hist {
+		residuals = append(residuals, residual)
+	}
+	sort.Ints(residuals)
+	for _, residual := range residuals {
+		s.points = append(s.points, shapePoint{
+			z:     (float64(residual) - mean) / sd * scale,
+			count: float64(hist[residual]),
+		})
+	}
+	return s
+}
+
+// Shape is an empirical distribution of standardized residuals, smoothed
+// and shrunk towards the standard normal. The zero Shape is the standard
+// normal.
+type Shape struct {
+	Days      int // Archived days behind the shape
+	points    []shapePoint
+	bandwidth float64
+}
+
+type shapePoint struct {
+	z     float64
+	count float64
+}
+
+// CDF returns P(Z <= z) for a standardized residual Z.
+func (s Shape) CDF(z float64) float64 {
+	if s.Days == 0 {
+		return normalCDF(z)
+	}
+	var sum float64
+	for _, p := range s.points {
+		sum += p.count * normalCDF((z-p.z)/s.bandwidth)
+	}
+	return (sum + residualPrior*normalCDF(z)) / (float64(s.Days) + residualPrior)
+}
+
+// EmpiricalForecast is a forecast whose distribution around its mean has
+// an empirical shape in place of the normal's.
+type EmpiricalForecast struct {
+	Forecast
+	Shape Shape `json:"-"`
+}
+
+// Probability returns P(floor <= official <= cap) with the half-degree
+// continuity correction of Forecast.Probability.
+func (f EmpiricalForecast) Probability(floor, cap int) float64 {
+	lower, upper := math.Inf(-1), math.Inf(1)
+	if floor > market.OpenFloor {
+		lower = float64(floor) - 0.5
+	}
+	if cap < market.OpenCap {
+		upper = float64(cap) + 0.5
+	}
+	return f.Shape.CDF((upper-f.Mean)/f.StdDev) - f.Shape.CDF((lower-f.Mean)/f.StdDev)
+}
+
+// Empirical returns the forecast of a station's day with the shape of its
+// month's residuals. A nil Residuals leaves the shape normal.
+func (r *Residuals) Empirical(f Forecast, station string, low bool, date time.Time) EmpiricalForecast {
+	if r == nil {
+		return EmpiricalForecast{Forecast: f}
+	}
+	return EmpiricalForecast{Forecast: f, Shape: r.Shape(station, low, date.Month())}
+}
+
+// MorningPrediction returns the model's prediction of an archived day's
+// official value at cutoff: the running METAR extreme plus (minus) the
+// calibration. The archive has no NWS forecast, so the running extreme
+// stands in for it. ok is false if nothing was reported before the cutoff.
+func MorningPrediction(d backtest.Day, cutoff time.Duration, calibration float64) (prediction float64, ok bool) {
+	at := d.Time().Add(cutoff)
+	if len(d.METAR) == 0 || !d.METAR[0].Time.Before(at) {
+		return 0, false
+	}
+	hour := int(cutoff.Hours())
+	if d.MarketType() == weather.MarketTypeLow {
+		running := d.METARMinBefore(at)
+		return LowForecast(running, running, calibration, hour).Mean, true
+	}
+	running := d.METARMaxBefore(at)
+	return HighForecast(running, running, calibration, hour).Mean, true
+}
+
+// ResidualsFromDataset builds the histograms from archived days, each
+// day's settlement against its MorningPrediction.
+func ResidualsFromDataset(days []backtest.Day, cutoff time.Duration, calibration float64) *Residuals {
+	r := NewResiduals()
+	for _, d := range days {
+		prediction, ok := MorningPrediction(d, cutoff, calibration)
+		if !ok {
+			continue
+		}
+		low := d.MarketType() == weather.MarketTypeLow
+		r.Add(d.City, low, d.Time().Month(), d.Settlement-int(math.Round(prediction)))
+	}
+	return r
+}
+
+// residualHistogram is a station-month's histogram as saved.
+type residualHistogram struct {
+	Station string      `json:"station"`
+	Low     bool        `json:"low,omitempty"`
+	Month   time.Month  `json:"month"`
+	Days    map[int]int `json:"days"` // Residual °F -> days
+}
+
+// Save writes the histograms to path as JSON.
+func (r *Residuals) Save(path string) error {
+	r.mu.RLock()
+	hists := make([]residualHistogram, 0, len(r.hists))
+	for k, days := range r.hists {
+		h := residualHistogram{Station: k.station, Low: k.low, Month: k.month, Days: make(map[int]int, len(days))}
+		for residual, n := range days {
+			h.Days[residual] = n
+		}
+		hists = append(hists, h)
+	}
+	r.mu.RUnlock()
+	sort.Slice(hists, func(i, j int) bool {
+		a, b := hists[i], hists[j]
+		if a.Station != b.Station {
+			return a.Station < b.Station
+		}
+		if a.Low != b.Low {
+			return !a.Low
+		}
+		return a.Month < b.Month
+	})
+	data, err := json.MarshalIndent(hists, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal residuals: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create residuals directory: %w", err)
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write residuals: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write residuals: %w", err)
+	}
+	return nil
+}
+
+// LoadResiduals reads histograms saved by Save. A missing file is empty
+// histograms.
+func LoadResiduals(path string) (*Residuals, error) {
+	r := NewResiduals()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read residuals: %w", err)
+	}
+	var hists []residualHistogram
+	if err := json.Unmarshal(data, &hists); err != nil {
+		return nil, fmt.Errorf("parse residuals %s: %w", path, err)
+	}
+	for _, h := range hists {
+		k := residualKey{h.Station, h.Low, h.Month}
+		if r.hists[k] == nil {
+			r.hists[k] = make(map[int]int)
+		}
+		for residual, n := range h.Days {
+			r.hists[k][residual] += n
+		}
+	}
+	return r, nil
+}
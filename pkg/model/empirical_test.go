@@ -0,0 +1,189 @@
+package model
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+)
+
+// fatTails fills a station-month with residuals bunched at 0 but with a
+// few misses of 6°F either way.
+func fatTails(r *Residuals) {
+	for residual, n := range map[int]int{-6: 3, -1: 15, 0: 40, 1: 15, 6: 3} {
+		for range n {
+			r.Add("LAX", false, time.December, residual)
+		}
+	}
+}
+
+func TestShape_Normal(t *testing.T) {
+	var s Shape
+	for _, z := range []float64{-2, -0.5, 0, 1, 3} {
+		if got, want := s.CDF(z), normalCDF(z); got != want {
+			t.Errorf("zero Shape CDF(%v) = %v, want %v", z, got, want)
+		}
+	}
+
+	// One day, or days that all agree, have no shape
+	r := NewResiduals()
+	r.Add("LAX", false, time.December, 2)
+	if s := r.Shape("LAX", false, time.December); s.Days != 0 {
+		t.Errorf("one day Shape.Days = %d, want 0", s.Days)
+	}
+	r.Add("LAX", false, time.December, 2)
+	if s := r.Shape("LAX", false, time.December); s.Days != 0 {
+		t.Errorf("constant Shape.Days = %d, want 0", s.Days)
+	}
+}
+
+func TestShape_CDF(t *testing.T) {
+	r := NewResiduals()
+	fatTails(r)
+	s := r.Shape("LAX", false, time.December)
+	if s.Days != 76 {
+		t.Fatalf("Days = %d, want 76", s.Days)
+	}
+	if got := s.CDF(0); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("CDF(0) = %v, want 0.5 for a symmetric histogram", got)
+	}
+	prev := 0.0
+	for z := -5.0; z <= 5; z += 0.25 {
+		got := s.CDF(z)
+		if got < prev {
+			t.Fatalf("CDF(%v) = %v, below CDF at %v", z, got, z-0.25)
+		}
+		prev = got
+	}
+
+	// Tighter than a normal near the center, fatter in the tails
+	if got, normal := s.CDF(0.5)-s.CDF(-0.5), normalCDF(0.5)-normalCDF(-0.5); got <= normal {
+		t.Errorf("P(|Z| < 0.5) = %.3f, want more than the normal's %.3f", got, normal)
+	}
+	if got, normal := s.CDF(-2.5), normalCDF(-2.5); got <= normal {
+		t.Errorf("P(Z < -2.5) = %.4f, want more than the normal's %.4f", got, normal)
+	}
+
+	// Other months and stations stay normal
+	if s := r.Shape("LAX", false, time.July); s.Days != 0 {
+		t.Errorf("July Shape.Days = %d, want 0", s.Days)
+	}
+	if s := r.Shape("LAX", true, time.December); s.Days != 0 {
+		t.Errorf("LOW Shape.Days = %d, want 0", s.Days)
+	}
+}
+
+func TestEmpiricalForecast_Probability(t *testing.T) {
+	r := NewResiduals()
+	fatTails(r)
+	f := Forecast{Mean: 65, StdDev: 2}
+	date := time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)
+	e := r.Empirical(f, "LAX", false, date)
+
+	strikes := market.Strikes{
+		{Floor: market.OpenFloor, Cap: 58},
+		{Floor: 59, Cap: 60},
+		{Floor: 61, Cap: 62},
+		{Floor: 63, Cap: 64},
+		{Floor: 65, Cap: 66},
+		{Floor: 67, Cap: 68},
+		{Floor: 69, Cap: 70},
+		{Floor: 71, Cap: market.OpenCap},
+	}
+	var total float64
+	for _, s := range strikes {
+		total += e.Probability(s.Floor, s.Cap)
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("probabilities sum to %v, want 1", total)
+	}
+
+	// The far tail a NO seller is exposed to is likelier than the normal says
+	if got, normal := e.Probability(71, market.OpenCap), f.Probability(71, market.OpenCap); got <= normal {
+		t.Errorf("P(>= 71) = %.4f, want more than the normal's %.4f", got, normal)
+	}
+
+	// Without residuals the forecast is the normal
+	var none *Residuals
+	if got, want := none.Empirical(f, "LAX", false, date).Probability(63, 64), f.Probability(63, 64); math.Abs(got-want) > 1e-12 {
+		t.Errorf("nil Residuals Probability = %v, want %v", got, want)
+	}
+}
+
+func TestResidualsFromDataset(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Los_Angeles")
+	at := func(hour int, temp float64) backtest.Observation {
+		return backtest.Observation{Time: time.Date(2025, 12, 5, hour, 0, 0, 0, loc), TempF: temp}
+	}
+	days := []backtest.Day{
+		{
+			City: "LAX", Series: "KXHIGHLAX", Date: "2025-12-05", Timezone: "America/Los_Angeles",
+			METAR:      []backtest.Observation{at(6, 55), at(9, 61), at(14, 66)},
+			Settlement: 67,
+		},
+		{
+			City: "LAX", Series: "KXLOWTLAX", Date: "2025-12-05", Timezone: "America/Los_Angeles",
+			METAR:      []backtest.Observation{at(6, 52), at(9, 58), at(14, 66)},
+			Settlement: 50,
+		},
+		{
+			// Nothing reported before the cutoff
+			City: "LAX", Series: "KXHIGHLAX", Date: "2025-12-05", Timezone: "America/Los_Angeles",
+			METAR:      []backtest.Observation{at(14, 66)},
+			Settlement: 67,
+		},
+	}
+	r := ResidualsFromDataset(days, DefaultResidualCutoff, DefaultCalibration)
+
+	// High: 61 by 10am, plus the calibration, finished 5 above
+	if got := r.Histogram("LAX", false, time.December); len(got) != 1 || got[5] != 1 {
+		t.Errorf("HIGH histogram = %v, want {5: 1}", got)
+	}
+	// Low: 52 by 10am, less the calibration, finished 1 below
+	if got := r.Histogram("LAX", true, time.December); len(got) != 1 || got[-1] != 1 {
+		t.Errorf("LOW histogram = %v, want {-1: 1}", got)
+	}
+}
+
+func TestResiduals_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "residuals.json")
+	missing, err := LoadResiduals(path)
+	if err != nil {
+		t.Fatalf("LoadResiduals(missing) error = %v", err)
+	}
+	if got := missing.Histogram("LAX", false, time.December); len(got) != 0 {
+		t.Errorf("missing file histogram = %v, want empty", got)
+	}
+
+	r := NewResiduals()
+	fatTails(r)
+	r.Add("NYC", true, time.March, -2)
+	if err := r.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadResiduals(path)
+	if err != nil {
+		t.Fatalf("LoadResiduals() error = %v", err)
+	}
+	for _, k := range []struct {
+		station string
+		low     bool
+		month   time.Month
+	}{{"LAX", false, time.December}, {"NYC", true, time.March}} {
+		want := r.Histogram(k.station, k.low, k.month)
+		got := loaded.Histogram(k.station, k.low, k.month)
+		if len(got) != len(want) {
+			t.Errorf("loaded %v histogram = %v, want %v", k, got, want)
+			continue
+		}
+		for residual, n := range want {
+			if got[residual] != n {
+				t.Errorf("loaded %v histogram = %v, want %v", k, got, want)
+				break
+			}
+		}
+	}
+}
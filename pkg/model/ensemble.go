@@ -0,0 +1,320 @@
+package model
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// Sources of the ensemble's members.
+const (
+	SourceNWS        = "nws"        // NWS point forecast high or low
+	SourceGuidance   = "guidance"   // Gridded model guidance (NBM) for the day
+	SourceTrajectory = "trajectory" // METAR so far, projected along the guidance's hourly curve
+)
+
+// memberCorrelation is the correlation assumed between the members' errors:
+// the point forecast, the gridded guidance and the trajectory share the same
+// models, so three that agree are less certain than three independent
+// forecasts would be.
+const memberCorrelation = 0.5
+
+// Member is one source's forecast of the official high (or low) in °F.
+type Member struct {
+	Source string  `json:"source"`
+	Value  float64 `json:"value"`
+}
+
+// WeightedMember is a member as blended: debiased, with the spread of its
+// historical errors at the hour and its share of the blend.
+type WeightedMember struct {
+	Member
+	StdDev float64 `json:"std_dev"`
+	Weight float64 `json:"weight"`
+}
+
+// EnsembleInput is what the ensemble blends for a station-day at a local
+// hour.
+type EnsembleInput struct {
+	Low       bool     // Forecasting the official low
+	LocalHour int      // When the members were made, 0-23
+	Members   []Member // Absent sources are left out
+
+	// The running METAR max (min) of the day, which the official high
+	// (low) can't fall short of (HaveRunning = false before the first
+	// report).
+	Running     float64
+	HaveRunning bool
+}
+
+// Distribution is the ensemble's distribution over the official high (or
+// low): a normal distribution truncated at the running METAR extreme.
+type Distribution struct {
+	Forecast                   // Blend before truncation
+	Low       bool             `json:"low,omitempty"`
+	Bound     float64          `json:"bound"`   // Running METAR extreme the official value is truncated at
+	HaveBound bool             `json:"bounded"` // Bound applies
+	Members   []WeightedMember `json:"members"`
+}
+
+// Blend combines the members into a distribution. Each member is debiased
+// by its mean historical error at the hour and weighted by the inverse
+// variance of those errors, which shrink through the day as the high (or
+// low) sets; errs may be nil to use the default spreads. The result is
+// truncated at the running METAR extreme, so brackets the day has already
+// passed get no probability.
+func Blend(in EnsembleInput, errs *ErrorHistory) Distribution {
+	d := Distribution{Low: in.Low, Bound: in.Running, HaveBound: in.HaveRunning}
+	var total float64
+	for _, m := range in.Members {
+		sd := errs.StdDev(m.Source, in.Low, in.LocalHour)
+		m.Value += errs.Bias(m.Source, in.Low, in.LocalHour)
+		w := 1 / (sd * sd)
+		d.Members = append(d.Members, WeightedMember{Member: m, StdDev: sd, Weight: w})
+		total += w
+	}
+	if total == 0 {
+		// Nothing to blend: the running extreme with the widest spread
+		sd := StdDevAt(0)
+		if in.Low {
+			sd = LowStdDevAt(0)
+		}
+		d.Forecast = Forecast{Mean: in.Running, StdDev: sd}
+		return d
+	}
+
+	var variance float64
+	for i := range d.Members {
+		d.Members[i].Weight /= total
+		d.Forecast.Mean += d.Members[i].Weight * d.Members[i].Value
+	}
+	for _, a := range d.Members {
+		for _, b := range d.Members {
+			rho := memberCorrelation
+			if a.Source == b.Source {
+				rho = 1
+			}
+			variance += a.Weight * b.Weight * rho * a.StdDev * b.StdDev
+		}
+	}
+	d.Forecast.StdDev = math.Sqrt(variance)
+	return d
+}
+
+// Probability returns P(floor <= official <= cap) under the truncated
+// distribution, with the half-degree continuity correction of
+// Forecast.Probability.
+func (d Distribution) Probability(floor, cap int) float64 {
+	lower, upper := math.Inf(-1), math.Inf(1)
+	if floor > market.OpenFloor {
+		lower = float64(floor) - 0.5
+	}
+	if cap < market.OpenCap {
+		upper = float64(cap) + 0.5
+	}
+
+	// The official value is in [lo, hi]: at or above the running max for
+	// a high, at or below the running min for a low
+	lo, hi := math.Inf(-1), math.Inf(1)
+	if d.HaveBound && d.Low {
+		hi = math.Round(d.Bound) + 0.5
+	} else if d.HaveBound {
+		lo = math.Round(d.Bound) - 0.5
+	}
+	mass := d.cdf(hi) - d.cdf(lo)
+	lower, upper = math.Max(lower, lo), math.Min(upper, hi)
+	if upper <= lower {
+		return 0
+	}
+	if mass <= 0 {
+		// The blend is far past the bound: the official value is the bound
+		if (market.Strike{Floor: floor, Cap: cap}).Contains(int(math.Round(d.Bound))) {
+			return 1
+		}
+		return 0
+	}
+	return (d.cdf(upper) - d.cdf(lower)) / mass
+}
+
+func (d Distribution) cdf(x float64) float64 {
+	return normalCDF((x - d.Mean) / d.StdDev)
+}
+
+// Probabilities returns the probability of each bracket, which sums to 1
+// over an event's full set of brackets.
+func (d Distribution) Probabilities(strikes market.Strikes) []float64 {
+	probs := make([]float64, len(strikes))
+	for i, s := range strikes {
+		probs[i] = d.Probability(s.Floor, s.Cap)
+	}
+	return probs
+}
+
+// Trajectory projects the official high (low) from the day's METAR reports
+// so far along the guidance's hourly curve for the rest of the day, until
+// end: the curve is shifted by its error at the latest report, and the
+// projection is the most extreme of the running METAR value and the shifted
+// curve, plus (minus) the calibration. It returns false without a report.
+func Trajectory(obs []weather.Observation, hourly []weather.GridValue, end time.Time, low bool, calibration float64) (float64, bool) {
+	if len(obs) == 0 {
+		return 0, false
+	}
+	pick, sign := math.Max, 1.0
+	if low {
+		pick, sign = math.Min, -1.0
+	}
+	latest := obs[len(obs)-1]
+	running := obs[0].Temp
+	for _, o := range obs[1:] {
+		running = pick(running, o.Temp)
+	}
+
+	// Shift the curve by its miss at the latest report
+	var shift float64
+	for _, h := range hourly {
+		if !latest.Time.Before(h.Start) && latest.Time.Before(h.End) {
+			shift = latest.Temp - h.Value
+			break
+		}
+	}
+	projected := running
+	for _, h := range hourly {
+		if h.Start.After(latest.Time) && h.Start.Before(end) {
+			projected = pick(projected, h.Value+shift)
+		}
+	}
+	return math.Round(projected) + sign*calibration, true
+}
+
+// EnsembleMembers returns the members available for a station's local day
+// at now: the point forecast's high (low), the guidance's, and the
+// trajectory of the day's METAR reports so far along the guidance. point,
+// guidance and metar may each be nil.
+func EnsembleMembers(point *weather.Forecast, guidance *weather.Guidance, metar *weather.METARData, day, now time.Time, low bool, calibration float64) []Member {
+	var members []Member
+	if point != nil {
+		value := point.HighTemp
+		if low {
+			value = point.LowTemp
+		}
+		members = append(members, Member{Source: SourceNWS, Value: value})
+	}
+	var hourly []weather.GridValue
+	end := day.AddDate(0, 0, 1)
+	if guidance != nil {
+		forDate := guidance.HighForDate
+		if low {
+			forDate = guidance.LowForDate
+		}
+		if value, ok := forDate(day); ok {
+			members = append(members, Member{Source: SourceGuidance, Value: value})
+		}
+		hourly = guidance.HourlyBetween(day, end)
+	}
+	if metar != nil {
+		var obs []weather.Observation
+		for _, o := range metar.Observations {
+			if !o.Time.Before(day) && !o.Time.After(now) {
+				obs = append(obs, o)
+			}
+		}
+		if value, ok := Trajectory(obs, hourly, end, low, calibration); ok {
+			members = append(members, Member{Source: SourceTrajectory, Value: value})
+		}
+	}
+	return members
+}
+
+// ErrorHistory holds each source's historical errors (the official value
+// minus the member) by the local hour the member was made at. Their mean
+// debiases the member and their spread weights it, each shrunk towards the
+// defaults while an hour has few days. It is safe for concurrent use, and a
+// nil ErrorHistory has the defaults everywhere.
+type ErrorHistory struct {
+	mu     sync.RWMutex
+	errors map[errorKey][]float64
+}
+
+type errorKey struct {
+	source string
+	low    bool
+	hour   int
+}
+
+// NewErrorHistory returns an empty history.
+func NewErrorHistory() *ErrorHistory {
+	return &ErrorHistory{errors: make(map[errorKey][]float64)}
+}
+
+// Observe records the error of a source's member made at a local hour once
+// the official value is known.
+func (h *ErrorHistory) Observe(source string, low bool, localHour int, official, member float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	k := errorKey{source, low, localHour}
+	h.errors[k] = append(h.errors[k], official-member)
+}
+
+// Bias returns the mean error of the source at the hour, shrunk towards 0.
+func (h *ErrorHistory) Bias(source string, low bool, localHour int) float64 {
+	if h == nil {
+		return 0
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	mean, _ := shrink(h.errors[errorKey{source, low, localHour}], 0)
+	return mean
+}
+
+// StdDev returns the spread of the source's errors at the hour, shrunk
+// towards DefaultErrorStdDev.
+func (h *ErrorHistory) StdDev(source string, low bool, localHour int) float64 {
+	prior := DefaultErrorStdDev(source, low, localHour)
+	if h == nil {
+		return prior
+	}
+	h.mu.RLock()
+	errs := h.errors[errorKey{source, low, localHour}]
+	h.mu.RUnlock()
+	if len(errs) < 2 {
+		return prior
+	}
+	var mean, ss float64
+	for _, e := range errs {
+		mean += e
+	}
+	mean /= float64(len(errs))
+	for _, e := range errs {
+		ss += (e - mean) * (e - mean)
+	}
+	n := float64(len(errs) - 1)
+	return math.Sqrt((ss + calibrationPrior*prior*prior) / (n + calibrationPrior))
+}
+
+// DefaultErrorStdDev returns the spread in °F of a source's errors at a
+// local hour without history. The point forecast and the guidance are
+// issued hours ahead and improve little through the day; the trajectory
+// narrows as StdDevAt (LowStdDevAt) as the extreme sets.
+func DefaultErrorStdDev(source string, low bool, localHour int) float64 {
+	switch source {
+	case SourceNWS:
+		if localHour >= 12 {
+			return 2.0
+		}
+		return 2.5
+	case SourceGuidance:
+		if localHour >= 12 {
+			return 1.7
+		}
+		return 2.0
+	case SourceTrajectory:
+		if low {
+			return LowStdDevAt(localHour)
+		}
+		return StdDevAt(localHour)
+	}
+	return 3.0
+}
@@ -0,0 +1,164 @@
+package model
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+func TestBlend(t *testing.T) {
+	in := EnsembleInput{
+		LocalHour: 10,
+		Members: []Member{
+			{Source: SourceNWS, Value: 66},
+			{Source: SourceGuidance, Value: 64},
+		},
+	}
+	d := Blend(in, nil)
+
+	// The guidance's errors are smaller, so it gets the larger weight
+	if d.Mean <= 64 || d.Mean >= 65 {
+		t.Errorf("Blend().Mean = %v, want between 64 and 65, nearer the guidance", d.Mean)
+	}
+	if w := d.Members[1].Weight; w <= 0.5 || math.Abs(d.Members[0].Weight+w-1) > 1e-9 {
+		t.Errorf("weights = %v, %v, want the guidance's over half and a total of 1", d.Members[0].Weight, w)
+	}
+	// Correlated members narrow the blend less than independent ones would
+	independent := 1 / math.Sqrt(1/(2.5*2.5)+1/(2.0*2.0))
+	if d.StdDev <= independent || d.StdDev >= 2.0 {
+		t.Errorf("Blend().StdDev = %v, want between %v and 2", d.StdDev, independent)
+	}
+
+	// Late in the day the trajectory dominates and the spread narrows
+	in.LocalHour = 20
+	in.Members = append(in.Members, Member{Source: SourceTrajectory, Value: 63})
+	late := Blend(in, nil)
+	if late.StdDev >= d.StdDev || math.Abs(late.Mean-63) > 0.5 {
+		t.Errorf("Blend(20:00) = %+v, want narrower and near the trajectory's 63", late.Forecast)
+	}
+
+	// Nothing to blend: the running max
+	if d := Blend(EnsembleInput{Running: 61, HaveRunning: true}, nil); d.Mean != 61 || d.StdDev != StdDevAt(0) {
+		t.Errorf("Blend(no members) = %+v, want the running max with the widest spread", d.Forecast)
+	}
+}
+
+func TestDistribution_Probability(t *testing.T) {
+	strikes := market.Strikes{
+		{Floor: market.OpenFloor, Cap: 61}, {Floor: 62, Cap: 63}, {Floor: 64, Cap: 65}, {Floor: 66, Cap: market.OpenCap},
+	}
+	d := Distribution{Forecast: Forecast{Mean: 63, StdDev: 2}, Bound: 64, HaveBound: true}
+	probs := d.Probabilities(strikes)
+	if probs[0] != 0 || probs[1] != 0 {
+		t.Errorf("Probabilities() = %v, want none below the running max of 64", probs)
+	}
+	if total := probs[2] + probs[3]; math.Abs(total-1) > 1e-9 {
+		t.Errorf("Probabilities() total = %v, want 1", total)
+	}
+
+	// A LOW is truncated from above
+	low := Distribution{Forecast: Forecast{Mean: 63, StdDev: 2}, Low: true, Bound: 62, HaveBound: true}
+	if p := low.Probabilities(strikes); p[2] != 0 || p[3] != 0 || math.Abs(p[0]+p[1]-1) > 1e-9 {
+		t.Errorf("LOW Probabilities() = %v, want all at or below the running min of 62", p)
+	}
+
+	// A blend far past the bound puts everything on the bound
+	far := Distribution{Forecast: Forecast{Mean: 40, StdDev: 0.5}, Bound: 64, HaveBound: true}
+	if p := far.Probabilities(strikes); p[2] != 1 || p[3] != 0 {
+		t.Errorf("far Probabilities() = %v, want 1 on the bracket holding 64", p)
+	}
+
+	// Unbounded it is the plain normal
+	free := Distribution{Forecast: Forecast{Mean: 63, StdDev: 2}}
+	if got, want := free.Probability(62, 63), (Forecast{63, 2}).Probability(62, 63); math.Abs(got-want) > 1e-12 {
+		t.Errorf("unbounded Probability() = %v, want %v", got, want)
+	}
+}
+
+func TestTrajectory(t *testing.T) {
+	day := time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	obs := []weather.Observation{{Time: at(9), Temp: 58}, {Time: at(11), Temp: 62}}
+	hourly := []weather.GridValue{
+		{Start: at(11), End: at(12), Value: 60},
+		{Start: at(12), End: at(13), Value: 63},
+		{Start: at(14), End: at(15), Value: 65},
+		{Start: at(17), End: at(18), Value: 58},
+	}
+
+	// Running 2° warmer than the guidance at 11:00: 65 + 2 at 14:00, plus 1
+	if got, ok := Trajectory(obs, hourly, at(24), false, 1); !ok || got != 68 {
+		t.Errorf("Trajectory(high) = %v, %v, want 68", got, ok)
+	}
+	// Without guidance, the running max
+	if got, _ := Trajectory(obs, nil, at(24), false, 1); got != 63 {
+		t.Errorf("Trajectory(no guidance) = %v, want 63", got)
+	}
+	// A LOW takes the coldest point and subtracts the calibration
+	if got, _ := Trajectory(obs, hourly, at(24), true, 1); got != 57 {
+		t.Errorf("Trajectory(low) = %v, want 57", got)
+	}
+	if _, ok := Trajectory(nil, hourly, at(24), false, 1); ok {
+		t.Error("Trajectory(no reports) = ok, want false")
+	}
+}
+
+func TestErrorHistory(t *testing.T) {
+	var none *ErrorHistory
+	if none.StdDev(SourceNWS, false, 9) != DefaultErrorStdDev(SourceNWS, false, 9) || none.Bias(SourceNWS, false, 9) != 0 {
+		t.Error("nil ErrorHistory doesn't use the defaults")
+	}
+
+	// The NWS forecast ran 2-4° low at 09:00 for 30 days
+	h := NewErrorHistory()
+	for i := range 30 {
+		h.Observe(SourceNWS, false, 9, float64(66+i%3), 64)
+	}
+	if bias := h.Bias(SourceNWS, false, 9); bias <= 2.5 || bias >= 3 {
+		t.Errorf("Bias() = %v, want just under the mean error of 3", bias)
+	}
+	if sd := h.StdDev(SourceNWS, false, 9); sd >= DefaultErrorStdDev(SourceNWS, false, 9) || sd <= 0.8 {
+		t.Errorf("StdDev() = %v, want between the sample's 0.83 and the default", sd)
+	}
+	if h.StdDev(SourceNWS, false, 10) != DefaultErrorStdDev(SourceNWS, false, 10) {
+		t.Error("StdDev() of an hour without history isn't the default")
+	}
+
+	d := Blend(EnsembleInput{LocalHour: 9, Members: []Member{{Source: SourceNWS, Value: 64}}}, h)
+	if math.Abs(d.Mean-64-h.Bias(SourceNWS, false, 9)) > 1e-9 {
+		t.Errorf("Blend().Mean = %v, want the member debiased", d.Mean)
+	}
+}
+
+func TestEnsembleMembers(t *testing.T) {
+	lax := weather.Stations["LAX"]
+	day := time.Date(2025, 12, 5, 0, 0, 0, 0, lax.Location())
+	now := day.Add(11 * time.Hour)
+	point := &weather.Forecast{HighTemp: 66}
+	guidance := &weather.Guidance{
+		Station: lax,
+		Max:     []weather.GridValue{{Start: day.Add(7 * time.Hour), End: day.Add(19 * time.Hour), Value: 65}},
+		Hourly:  []weather.GridValue{{Start: day.Add(10 * time.Hour), End: day.Add(16 * time.Hour), Value: 63}},
+	}
+	metar := &weather.METARData{Observations: []weather.Observation{
+		{Time: day.Add(-time.Hour), Temp: 70}, // The day before
+		{Time: day.Add(10*time.Hour + 53*time.Minute), Temp: 62},
+	}}
+
+	members := EnsembleMembers(point, guidance, metar, day, now, false, 1)
+	want := []Member{{SourceNWS, 66}, {SourceGuidance, 65}, {SourceTrajectory, 63}}
+	if len(members) != len(want) {
+		t.Fatalf("EnsembleMembers() = %+v, want %+v", members, want)
+	}
+	for i := range want {
+		if members[i] != want[i] {
+			t.Errorf("EnsembleMembers()[%d] = %+v, want %+v", i, members[i], want[i])
+		}
+	}
+	if got := EnsembleMembers(nil, nil, nil, day, now, false, 1); len(got) != 0 {
+		t.Errorf("EnsembleMembers(nothing) = %+v, want none", got)
+	}
+}
@@ -0,0 +1,110 @@
+// Package model is the temperature probability model and EV calculator shared
+// by the traders, the examples and the model RPC server, so research code
+// prices brackets exactly as production does.
+package model
+
+import (
+	"math"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+)
+
+// DefaultCalibration is the adjustment in °F from the running METAR max to
+// the official (CLI) high. The CLI reads the same one-minute data METARs
+// sample, so it catches extremes between reports: its low is as far below
+// the METAR min, and LowForecast subtracts the calibration.
+const DefaultCalibration = 1.0
+
+// Forecast is a normal distribution over the official daily high (or low)
+// in °F.
+type Forecast struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+}
+
+// HighForecast returns the forecast of the official high from the running
+// METAR max and the NWS forecast high at a local hour. The expected high is
+// the larger of the two plus the calibration, in whole degrees as the CLI
+// reports it; the uncertainty narrows through the afternoon as the high sets.
+func HighForecast(runningMax, nwsForecast int, calibration float64, localHour int) Forecast {
+	expected := int(math.Max(float64(runningMax), float64(nwsForecast)) + calibration)
+	return Forecast{Mean: float64(expected), StdDev: StdDevAt(localHour)}
+}
+
+// LowForecast returns the forecast of the official low from the running
+// METAR min and the NWS forecast overnight low at a local hour. The
+// expected low is the smaller of the two minus the calibration; before the
+// day's first report, pass the forecast low as runningMin. The low usually
+// sets around dawn, so the uncertainty narrows through the morning.
+func LowForecast(runningMin, nwsForecast int, calibration float64, localHour int) Forecast {
+	expected := int(math.Min(float64(runningMin), float64(nwsForecast)) - calibration)
+	return Forecast{Mean: float64(expected), StdDev: LowStdDevAt(localHour)}
+}
+
+// LowStdDevAt returns the standard deviation in °F of the official low at a
+// local hour. A cold front can still set a lower low in the evening, so it
+// never narrows as far as the high's.
+func LowStdDevAt(localHour int) float64 {
+	switch {
+	case localHour >= 10:
+		return 1.0
+	case localHour >= 8:
+		return 1.5
+	default:
+		return 2.0
+	}
+}
+
+// StdDevAt returns the standard deviation in °F of the official high at a
+// local hour.
+func StdDevAt(localHour int) float64 {
+	switch {
+	case localHour >= 20:
+		return 0.5
+	case localHour >= 18:
+		return 1.0
+	case localHour >= 16:
+		return 1.5
+	default:
+		return 2.0
+	}
+}
+
+// Probability returns P(floor <= high <= cap) with a half-degree continuity
+// correction. Tail brackets use market.OpenFloor and market.OpenCap.
+func (f Forecast) Probability(floor, cap int) float64 {
+	lower, upper := math.Inf(-1), math.Inf(1)
+	if floor > market.OpenFloor {
+		lower = float64(floor) - 0.5
+	}
+	if cap < market.OpenCap {
+		upper = float64(cap) + 0.5
+	}
+	return normalCDF((upper-f.Mean)/f.StdDev) - normalCDF((lower-f.Mean)/f.StdDev)
+}
+
+func normalCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+// Value is the model's valuation of buying contracts at a price.
+type Value struct {
+	Probability float64 `json:"probability"` // Model win probability
+	Edge        float64 `json:"edge"`        // Probability minus the price as a probability
+	EV          float64 `json:"ev"`          // Expected P&L in dollars after fees
+	NetWin      float64 `json:"net_win"`     // P&L in dollars if the contracts win, after fees
+	NetLoss     float64 `json:"net_loss"`    // P&L in dollars if they lose, after fees
+}
+
+// Evaluate values buying contracts at priceCents that win with probability
+// prob under a fee rule, as the production EV gate does.
+func Evaluate(rule fees.Rule, liq fees.Liquidity, contracts float64, priceCents int, prob float64) Value {
+	return Value{
+		Probability: prob,
+		Edge:        prob - float64(priceCents)/100,
+		EV:          rule.ExpectedValue(liq, contracts, priceCents, prob),
+		NetWin:      rule.NetProfit(liq, contracts, priceCents, true),
+		NetLoss:     rule.NetProfit(liq, contracts, priceCents, false),
+	}
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+)
+
+func TestHighForecast(t *testing.T) {
+	tests := []struct {
+		running, nws, hour int
+		want               Forecast
+	}{
+		{60, 63, 10, Forecast{64, 2}},
+		{65, 63, 16, Forecast{66, 1.5}},
+		{65, 63, 18, Forecast{66, 1}},
+		{65, 63, 21, Forecast{66, 0.5}},
+	}
+	for _, tt := range tests {
+		if got := HighForecast(tt.running, tt.nws, DefaultCalibration, tt.hour); got != tt.want {
+			t.Errorf("HighForecast(%d, %d, %d) = %+v, want %+v", tt.running, tt.nws, tt.hour, got, tt.want)
+		}
+	}
+
+	// Fractional calibration truncates to whole degrees
+	if got := HighForecast(60, 58, 0.7, 12); got.Mean != 60 {
+		t.Errorf("HighForecast(calibration 0.7).Mean = %v, want 60", got.Mean)
+	}
+}
+
+func TestLowForecast(t *testing.T) {
+	tests := []struct {
+		running, nws, hour int
+		want               Forecast
+	}{
+		// Before dawn the forecast low is still below the running min
+		{44, 41, 5, Forecast{40, 2}},
+		{40, 43, 8, Forecast{39, 1.5}},
+		{40, 43, 13, Forecast{39, 1}},
+		// Denver in winter: below zero
+		{-3, 2, 11, Forecast{-4, 1}},
+	}
+	for _, tt := range tests {
+		if got := LowForecast(tt.running, tt.nws, DefaultCalibration, tt.hour); got != tt.want {
+			t.Errorf("LowForecast(%d, %d, %d) = %+v, want %+v", tt.running, tt.nws, tt.hour, got, tt.want)
+		}
+	}
+}
+
+func TestForecast_Probability(t *testing.T) {
+	f := Forecast{Mean: 62, StdDev: 2}
+	brackets := [][2]int{
+		{market.OpenFloor, 57}, {58, 59}, {60, 61}, {62, 63}, {64, 65}, {66, market.OpenCap},
+	}
+
+	var total float64
+	for _, b := range brackets {
+		total += f.Probability(b[0], b[1])
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("sum of bracket probabilities = %v, want 1", total)
+	}
+
+	// 61.5 to 63.5 is -0.25 to +0.75 standard deviations
+	if got := f.Probability(62, 63); math.Abs(got-0.3721) > 1e-4 {
+		t.Errorf("Probability(62, 63) = %.4f, want 0.3721", got)
+	}
+	if lower, upper := f.Probability(market.OpenFloor, 61), f.Probability(62, market.OpenCap); math.Abs(lower-0.4013) > 1e-4 || math.Abs(lower+upper-1) > 1e-9 {
+		t.Errorf("tails = %.4f, %.4f, want 0.4013 and complement", lower, upper)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	rule := fees.DefaultSchedule().RuleForTicker("KXHIGHLAX-25DEC27-B62.5", time.Now())
+
+	v := Evaluate(rule, fees.Maker, 10, 40, 0.5)
+	if math.Abs(v.Edge-0.1) > 1e-9 {
+		t.Errorf("Edge = %v, want 0.1", v.Edge)
+	}
+	if want := rule.ExpectedValue(fees.Maker, 10, 40, 0.5); v.EV != want {
+		t.Errorf("EV = %v, want %v (fees.Rule.ExpectedValue)", v.EV, want)
+	}
+	if math.Abs(v.NetLoss+4) > 1e-9 || v.NetWin >= 6 {
+		t.Errorf("NetWin, NetLoss = %v, %v, want < 6 after fees and -4", v.NetWin, v.NetLoss)
+	}
+	if math.Abs(0.5*v.NetWin+0.5*v.NetLoss-v.EV) > 1e-9 {
+		t.Errorf("EV = %v, want the probability-weighted net P&L", v.EV)
+	}
+}
@@ -0,0 +1,326 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// RPCServer serves the model and EV calculator over JSON-RPC 2.0 on HTTP POST,
+// so notebooks can call the production implementation instead of
+// reimplementing it. Batch requests are supported.
+//
+// Methods (params are by name):
+//
+//	model.forecast      {running_max, nws_forecast, hour, calibration?} -> Forecast
+//	model.low_forecast  {running_min, nws_forecast, hour, calibration?} -> Forecast
+//	model.probability   {mean, std_dev, floor?, cap?}                   -> float
+//	model.probabilities {mean, std_dev, brackets: [{floor?, cap?}]}     -> [float]
+//	model.ensemble      {members: [{source, value}], hour, low?, running?, brackets?} -> {distribution, probabilities}
+//	ev.evaluate         {ticker, price, contracts?, probability, liquidity?, at?} -> Value
+//
+// A missing floor or cap is an open tail. contracts defaults to 1, liquidity
+// to "maker" and at (RFC 3339) to now. model.ensemble blends with the
+// default error spreads, truncated at running (the METAR extreme so far) if
+// given.
+type RPCServer struct {
+	fees    *fees.Schedule
+	methods map[string]func(json.RawMessage) (any, error)
+}
+
+// NewRPCServer creates a server that prices fees with schedule.
+func NewRPCServer(schedule *fees.Schedule) *RPCServer {
+	s := &RPCServer{fees: schedule}
+	s.methods = map[string]func(json.RawMessage) (any, error){
+		"model.forecast":      s.forecast,
+		"model.low_forecast":  s.lowForecast,
+		"model.probability":   s.probability,
+		"model.probabilities": s.probabilities,
+		"model.ensemble":      s.ensemble,
+		"ev.evaluate":         s.evaluate,
+	}
+	return s
+}
+
+// errInvalidParams wraps errors in the params of a call.
+var errInvalidParams = errors.New("invalid params")
+
+type bracketParams struct {
+	Floor *int `json:"floor"`
+	Cap   *int `json:"cap"`
+}
+
+func (b bracketParams) bounds() (int, int) {
+	floor, cap := market.OpenFloor, market.OpenCap
+	if b.Floor != nil {
+		floor = *b.Floor
+	}
+	if b.Cap != nil {
+		cap = *b.Cap
+	}
+	return floor, cap
+}
+
+func (s *RPCServer) forecast(raw json.RawMessage) (any, error) {
+	var p struct {
+		RunningMax  *int     `json:"running_max"`
+		NWSForecast *int     `json:"nws_forecast"`
+		Hour        *int     `json:"hour"`
+		Calibration *float64 `json:"calibration"`
+	}
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, err
+	}
+	if p.RunningMax == nil || p.NWSForecast == nil || p.Hour == nil {
+		return nil, fmt.Errorf("%w: running_max, nws_forecast and hour are required", errInvalidParams)
+	}
+	calibration := DefaultCalibration
+	if p.Calibration != nil {
+		calibration = *p.Calibration
+	}
+	return HighForecast(*p.RunningMax, *p.NWSForecast, calibration, *p.Hour), nil
+}
+
+func (s *RPCServer) lowForecast(raw json.RawMessage) (any, error) {
+	var p struct {
+		RunningMin  *int     `json:"running_min"`
+		NWSForecast *int     `json:"nws_forecast"`
+		Hour        *int     `json:"hour"`
+		Calibration *float64 `json:"calibration"`
+	}
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, err
+	}
+	if p.RunningMin == nil || p.NWSForecast == nil || p.Hour == nil {
+		return nil, fmt.Errorf("%w: running_min, nws_forecast and hour are required", errInvalidParams)
+	}
+	calibration := DefaultCalibration
+	if p.Calibration != nil {
+		calibration = *p.Calibration
+	}
+	return LowForecast(*p.RunningMin, *p.NWSForecast, calibration, *p.Hour), nil
+}
+
+func (s *RPCServer) probability(raw json.RawMessage) (any, error) {
+	var p struct {
+		Forecast
+		bracketParams
+	}
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, err
+	}
+	if p.StdDev <= 0 {
+		return nil, fmt.Errorf("%w: std_dev must be positive", errInvalidParams)
+	}
+	return p.Forecast.Probability(p.bounds()), nil
+}
+
+func (s *RPCServer) probabilities(raw json.RawMessage) (any, error) {
+	var p struct {
+		Forecast
+		Brackets []bracketParams `json:"brackets"`
+	}
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, err
+	}
+	if p.StdDev <= 0 {
+		return nil, fmt.Errorf("%w: std_dev must be positive", errInvalidParams)
+	}
+	probs := make([]float64, len(p.Brackets))
+	for i, b := range p.Brackets {
+		probs[i] = p.Forecast.Probability(b.bounds())
+	}
+	return probs, nil
+}
+
+func (s *RPCServer) ensemble(raw json.RawMessage) (any, error) {
+	var p struct {
+		Members  []Member        `json:"members"`
+		Hour     *int            `json:"hour"`
+		Low      bool            `json:"low"`
+		Running  *float64        `json:"running"`
+		Brackets []bracketParams `json:"brackets"`
+	}
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, err
+	}
+	if p.Hour == nil || *p.Hour < 0 || *p.Hour > 23 {
+		return nil, fmt.Errorf("%w: hour 0-23 is required", errInvalidParams)
+	}
+	if len(p.Members) == 0 && p.Running == nil {
+		return nil, fmt.Errorf("%w: members or running is required", errInvalidParams)
+	}
+	in := EnsembleInput{Low: p.Low, LocalHour: *p.Hour, Members: p.Members}
+	if p.Running != nil {
+		in.Running, in.HaveRunning = *p.Running, true
+	}
+	d := Blend(in, nil)
+	strikes := make(market.Strikes, len(p.Brackets))
+	for i, b := range p.Brackets {
+		strikes[i].Floor, strikes[i].Cap = b.bounds()
+	}
+	return struct {
+		Distribution  Distribution `json:"distribution"`
+		Probabilities []float64    `json:"probabilities"`
+	}{d, d.Probabilities(strikes)}, nil
+}
+
+func (s *RPCServer) evaluate(raw json.RawMessage) (any, error) {
+	var p struct {
+		Ticker      string         `json:"ticker"`
+		Price       int            `json:"price"`
+		Contracts   float64        `json:"contracts"`
+		Probability float64        `json:"probability"`
+		Liquidity   fees.Liquidity `json:"liquidity"`
+		At          time.Time      `json:"at"`
+	}
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, err
+	}
+	if p.Price < 1 || p.Price > 99 {
+		return nil, fmt.Errorf("%w: price must be 1-99 cents", errInvalidParams)
+	}
+	if p.Probability < 0 || p.Probability > 1 {
+		return nil, fmt.Errorf("%w: probability must be between 0 and 1", errInvalidParams)
+	}
+	if p.Contracts == 0 {
+		p.Contracts = 1
+	}
+	if p.Liquidity == "" {
+		p.Liquidity = fees.Maker
+	}
+	if p.Liquidity != fees.Maker && p.Liquidity != fees.Taker {
+		return nil, fmt.Errorf("%w: liquidity must be %q or %q", errInvalidParams, fees.Maker, fees.Taker)
+	}
+	if p.At.IsZero() {
+		p.At = time.Now()
+	}
+	rule := s.fees.RuleForTicker(p.Ticker, p.At)
+	return Evaluate(rule, p.Liquidity, p.Contracts, p.Price, p.Probability), nil
+}
+
+func decodeParams(raw json.RawMessage, v any) error {
+	if len(raw) == 0 {
+		raw = []byte("{}")
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidParams, err)
+	}
+	return nil
+}
+
+// ServeHTTP handles a JSON-RPC request or batch.
+func (s *RPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "JSON-RPC requests must be POSTed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil || len(batch) == 0 {
+			enc.Encode(errorResponse(nil, codeParseError, "invalid batch"))
+			return
+		}
+		responses := make([]rpcResponse, 0, len(batch))
+		for _, raw := range batch {
+			if resp, ok := s.call(raw); ok {
+				responses = append(responses, resp)
+			}
+		}
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		enc.Encode(responses)
+		return
+	}
+
+	resp, ok := s.call(body)
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	enc.Encode(resp)
+}
+
+// call runs one request. Notifications (no id) get no response.
+func (s *RPCServer) call(raw json.RawMessage) (rpcResponse, bool) {
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return errorResponse(nil, codeParseError, err.Error()), true
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, `requests need "jsonrpc": "2.0" and a method`), true
+	}
+
+	method, ok := s.methods[req.Method]
+	if !ok {
+		return errorResponse(req.ID, codeMethodNotFound, "method not found: "+req.Method), req.ID != nil
+	}
+	result, err := method(req.Params)
+	if req.ID == nil {
+		return rpcResponse{}, false
+	}
+	if err != nil {
+		return errorResponse(req.ID, codeInvalidParams, err.Error()), true
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(req.ID, codeInvalidParams, err.Error()), true
+	}
+	return rpcResponse{JSONRPC: "2.0", Result: data, ID: req.ID}, true
+}
+
+func errorResponse(id json.RawMessage, code int, message string) rpcResponse {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return rpcResponse{JSONRPC: "2.0", Error: &rpcError{Code: code, Message: message}, ID: id}
+}
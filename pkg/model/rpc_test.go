@@ -0,0 +1,132 @@
+package model
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+)
+
+// post sends a JSON-RPC body and decodes the response into v
+func post(t *testing.T, srv *httptest.Server, body string, v any) int {
+	t.Helper()
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST error = %v", err)
+	}
+	defer resp.Body.Close()
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("decode error = %v", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+type testResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+	ID     int             `json:"id"`
+}
+
+func TestRPCServer_Methods(t *testing.T) {
+	srv := httptest.NewServer(NewRPCServer(fees.DefaultSchedule()))
+	defer srv.Close()
+
+	var forecast testResponse
+	post(t, srv, `{"jsonrpc":"2.0","id":1,"method":"model.forecast","params":{"running_max":60,"nws_forecast":63,"hour":16}}`, &forecast)
+	var f Forecast
+	if err := json.Unmarshal(forecast.Result, &f); err != nil || f != HighForecast(60, 63, DefaultCalibration, 16) {
+		t.Errorf("model.forecast = %s (%v), want %+v", forecast.Result, forecast.Error, HighForecast(60, 63, DefaultCalibration, 16))
+	}
+
+	var low testResponse
+	post(t, srv, `{"jsonrpc":"2.0","id":1,"method":"model.low_forecast","params":{"running_min":41,"nws_forecast":39,"hour":6}}`, &low)
+	if err := json.Unmarshal(low.Result, &f); err != nil || f != LowForecast(41, 39, DefaultCalibration, 6) {
+		t.Errorf("model.low_forecast = %s (%v), want %+v", low.Result, low.Error, LowForecast(41, 39, DefaultCalibration, 6))
+	}
+
+	var probs testResponse
+	post(t, srv, `{"jsonrpc":"2.0","id":2,"method":"model.probabilities","params":{"mean":62,"std_dev":2,"brackets":[{"cap":61},{"floor":62,"cap":63},{"floor":64}]}}`, &probs)
+	var ps []float64
+	if err := json.Unmarshal(probs.Result, &ps); err != nil || len(ps) != 3 {
+		t.Fatalf("model.probabilities = %s (%v), want 3 probabilities", probs.Result, probs.Error)
+	}
+	if want := (Forecast{62, 2}).Probability(62, 63); ps[1] != want || math.Abs(ps[0]+ps[1]+ps[2]-1) > 1e-9 {
+		t.Errorf("model.probabilities = %v, want %v in the middle and a total of 1", ps, want)
+	}
+
+	var ens testResponse
+	post(t, srv, `{"jsonrpc":"2.0","id":2,"method":"model.ensemble","params":{"members":[{"source":"nws","value":66},{"source":"trajectory","value":65}],"hour":15,"running":64,"brackets":[{"cap":63},{"floor":64,"cap":65},{"floor":66}]}}`, &ens)
+	var blended struct {
+		Distribution  Distribution `json:"distribution"`
+		Probabilities []float64    `json:"probabilities"`
+	}
+	if err := json.Unmarshal(ens.Result, &blended); err != nil || len(blended.Probabilities) != 3 {
+		t.Fatalf("model.ensemble = %s (%v), want 3 probabilities", ens.Result, ens.Error)
+	}
+	if ps := blended.Probabilities; ps[0] != 0 || math.Abs(ps[0]+ps[1]+ps[2]-1) > 1e-9 || len(blended.Distribution.Members) != 2 {
+		t.Errorf("model.ensemble = %s, want none below the running max and a total of 1", ens.Result)
+	}
+
+	var ev testResponse
+	post(t, srv, `{"jsonrpc":"2.0","id":3,"method":"ev.evaluate","params":{"ticker":"KXHIGHLAX-25DEC27-B62.5","price":40,"contracts":10,"probability":0.5}}`, &ev)
+	var v Value
+	rule := fees.DefaultSchedule().RuleForTicker("KXHIGHLAX-25DEC27-B62.5", time.Now())
+	if err := json.Unmarshal(ev.Result, &v); err != nil || v != Evaluate(rule, fees.Maker, 10, 40, 0.5) {
+		t.Errorf("ev.evaluate = %s (%v), want %+v", ev.Result, ev.Error, Evaluate(rule, fees.Maker, 10, 40, 0.5))
+	}
+}
+
+func TestRPCServer_Errors(t *testing.T) {
+	srv := httptest.NewServer(NewRPCServer(fees.DefaultSchedule()))
+	defer srv.Close()
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"jsonrpc":"2.0","id":1,"method":"model.unknown"}`, codeMethodNotFound},
+		{`{"jsonrpc":"2.0","id":1,"method":"model.probability","params":{"mean":62,"std_dev":0}}`, codeInvalidParams},
+		{`{"jsonrpc":"2.0","id":1,"method":"model.forecast","params":{"running_max":60}}`, codeInvalidParams},
+		{`{"jsonrpc":"2.0","id":1,"method":"ev.evaluate","params":{"price":40,"probability":0.5,"liquidity":"mid"}}`, codeInvalidParams},
+		{`{"jsonrpc":"2.0","id":1,"method":"model.probability","params":{"mean":62,"std_dev":2,"sd":2}}`, codeInvalidParams},
+		{`{"id":1,"method":"model.probability"}`, codeInvalidRequest},
+		{`{not json`, codeParseError},
+	}
+	for _, tt := range tests {
+		var resp testResponse
+		post(t, srv, tt.body, &resp)
+		if resp.Error == nil || resp.Error.Code != tt.code || resp.Result != nil {
+			t.Errorf("%s: error = %+v, result = %s, want code %d", tt.body, resp.Error, resp.Result, tt.code)
+		}
+	}
+}
+
+func TestRPCServer_BatchAndNotifications(t *testing.T) {
+	srv := httptest.NewServer(NewRPCServer(fees.DefaultSchedule()))
+	defer srv.Close()
+
+	var batch []testResponse
+	post(t, srv, `[
+		{"jsonrpc":"2.0","id":1,"method":"model.probability","params":{"mean":62,"std_dev":2,"floor":62,"cap":63}},
+		{"jsonrpc":"2.0","method":"model.probability","params":{"mean":62,"std_dev":2}},
+		{"jsonrpc":"2.0","id":2,"method":"model.probability","params":{"mean":62,"std_dev":2,"floor":90}}
+	]`, &batch)
+	if len(batch) != 2 || batch[0].ID != 1 || batch[1].ID != 2 {
+		t.Fatalf("batch = %+v, want responses to ids 1 and 2 only", batch)
+	}
+	// A probability of zero is still a result
+	if string(batch[1].Result) != "0" {
+		t.Errorf("batch[1].Result = %s, want 0", batch[1].Result)
+	}
+
+	if code := post(t, srv, `{"jsonrpc":"2.0","method":"model.probability","params":{"mean":62,"std_dev":2}}`, nil); code != http.StatusNoContent {
+		t.Errorf("notification status = %d, want %d", code, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Email sends messages by SMTP.
+type Email struct {
+	Addr string // SMTP server, host:port
+	From string
+	To   []string
+	Auth smtp.Auth // nil = no authentication
+
+	// send is smtp.SendMail, replaced in tests.
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmail returns an email sink using PLAIN authentication if username is
+// set, or nil if addr or to is empty so it can be passed straight to Multi.
+func NewEmail(addr, from string, to []string, username, password string) Notifier {
+	if addr == "" || len(to) == 0 {
+		return nil
+	}
+	e := &Email{Addr: addr, From: from, To: to}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		e.Auth = smtp.PlainAuth("", username, password, host)
+	}
+	return e
+}
+
+// Notify mails msg as plain text. SMTP has no context support; ctx is only
+// checked before sending.
+func (e *Email) Notify(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	subject := msg.Title
+	if subject == "" {
+		subject, _, _ = strings.Cut(msg.Text, "\n")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", e.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&b, "Subject: [%s] %s\r\n", msg.Kind, subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", msg.time().Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(msg.String(), "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	send := e.send
+	if send == nil {
+		send = smtp.SendMail
+	}
+	if err := send(e.Addr, e.Auth, e.From, e.To, []byte(b.String())); err != nil {
+		return fmt.Errorf("notify: email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+// Package notify sends the bots' alerts to chat channels and email: trade
+// executions, threshold crossings, errors and daily summaries.
+//
+// Each sink (Slack, Discord, Email) implements Notifier. Multi fans a message
+// out to several sinks, and a Throttle in front of them rate-limits and
+// deduplicates, so a flapping signal raises one alert rather than a stream:
+//
+//	n := notify.NewThrottle(notify.Multi(
+//		notify.NewSlack(os.Getenv("SLACK_WEBHOOK_URL")),
+//		notify.NewDiscord(os.Getenv("DISCORD_WEBHOOK_URL")),
+//	), notify.ThrottleConfig{Dedup: 15 * time.Minute, Burst: 10, Per: time.Hour})
+//	n.Notify(ctx, notify.Trade("LAX", "KXHIGHLAX-25DEC05-B60.5", "yes", 62, 480, 297.60, orderID))
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kind is what a message is about.
+type Kind string
+
+const (
+	KindTrade     Kind = "trade"     // An order was placed or filled
+	KindThreshold Kind = "threshold" // A watched value crossed a threshold
+	KindError     Kind = "error"     // Something failed or halted
+	KindSummary   Kind = "summary"   // A daily summary
+	KindInfo      Kind = "info"      // Anything else
+)
+
+// Field is a labeled value shown alongside a message.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Message is one alert.
+type Message struct {
+	Kind   Kind
+	Title  string
+	Text   string
+	Fields []Field
+	// Key identifies repeats of the same alert for deduplication ("" = the
+	// kind, title and text).
+	Key  string
+	Time time.Time // Default now
+}
+
+// DedupKey returns the key repeats of the message share.
+func (m Message) DedupKey() string {
+	if m.Key != "" {
+		return m.Key
+	}
+	return string(m.Kind) + "\x00" + m.Title + "\x00" + m.Text
+}
+
+// String renders the message as plain text.
+func (m Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Title)
+	if m.Text != "" {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(m.Text)
+	}
+	for _, f := range m.Fields {
+		fmt.Fprintf(&b, "\n%s: %s", f.Name, f.Value)
+	}
+	return b.String()
+}
+
+func (m Message) time() time.Time {
+	if m.Time.IsZero() {
+		return time.Now()
+	}
+	return m.Time
+}
+
+// Notifier delivers messages.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Func adapts a function to a Notifier.
+type Func func(ctx context.Context, msg Message) error
+
+// Notify calls f.
+func (f Func) Notify(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+type multi []Notifier
+
+// Multi returns a Notifier sending each message to every one of ns, skipping
+// nil ones. It returns the sinks' errors joined.
+func Multi(ns ...Notifier) Notifier {
+	var m multi
+	for _, n := range ns {
+		if n != nil {
+			m = append(m, n)
+		}
+	}
+	return m
+}
+
+func (m multi) Notify(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, n := range m {
+		errs = append(errs, n.Notify(ctx, msg))
+	}
+	return errors.Join(errs...)
+}
+
+// Trade returns the alert for an order placed on a bracket.
+func Trade(city, ticker, side string, price, quantity int, cost float64, orderID string) Message {
+	emoji := "📈"
+	if side == "no" {
+		emoji = "📉"
+	}
+	return Message{
+		Kind:  KindTrade,
+		Title: fmt.Sprintf("%s Trade Executed: %s", emoji, city),
+		Fields: []Field{
+			{"Ticker", ticker},
+			{"Side", side},
+			{"Price", fmt.Sprintf("%d¢", price)},
+			{"Quantity", fmt.Sprint(quantity)},
+			{"Cost", fmt.Sprintf("$%.2f", cost)},
+			{"Order ID", orderID},
+		},
+		Key: "trade\x00" + orderID,
+	}
+}
+
+// Threshold returns the alert for a value crossing a threshold. Repeats of the
+// same crossing share a key, so a value flapping around the threshold is
+// deduplicated by a Throttle.
+func Threshold(subject, name, value, threshold string) Message {
+	return Message{
+		Kind:  KindThreshold,
+		Title: fmt.Sprintf("🔔 %s: %s crossed %s", subject, name, threshold),
+		Fields: []Field{
+			{"Value", value},
+			{"Threshold", threshold},
+		},
+		Key: "threshold\x00" + subject + "\x00" + name + "\x00" + threshold,
+	}
+}
+
+// Error returns the alert for a failure in component.
+func Error(component, message string) Message {
+	return Message{
+		Kind:   KindError,
+		Title:  "🚨 Error Alert",
+		Text:   message,
+		Fields: []Field{{"Component", component}},
+	}
+}
+
+// Summary is a day's trading results.
+type Summary struct {
+	Day    string // Local date
+	Trades int
+	Wins   int
+	Cost   float64 // Dollars staked
+	PnL    float64 // Net dollars after fees
+	Notes  string  // Free-form detail, e.g. a per-city breakdown
+}
+
+// DailySummary returns the alert for a day's results.
+func DailySummary(s Summary) Message {
+	emoji := "📊"
+	if s.PnL < 0 {
+		emoji = "⚠️"
+	}
+	winRate := 0.0
+	if s.Trades > 0 {
+		winRate = float64(s.Wins) / float64(s.Trades) * 100
+	}
+	return Message{
+		Kind:  KindSummary,
+		Title: fmt.Sprintf("%s Daily Trading Summary %s", emoji, s.Day),
+		Text:  s.Notes,
+		Fields: []Field{
+			{"Trades", fmt.Sprint(s.Trades)},
+			{"Wins", fmt.Sprint(s.Wins)},
+			{"Win Rate", fmt.Sprintf("%.1f%%", winRate)},
+			{"Cost", fmt.Sprintf("$%.2f", s.Cost)},
+			{"Net P&L", fmt.Sprintf("$%.2f", s.PnL)},
+		},
+		Key: "summary\x00" + s.Day,
+	}
+}
+
+// Text returns an informational message.
+func Text(text string) Message {
+	return Message{Kind: KindInfo, Text: text}
+}
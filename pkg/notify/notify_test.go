@@ -0,0 +1,156 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlack(t *testing.T) {
+	var got slackMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	n := NewSlack(srv.URL)
+	if err := n.Notify(context.Background(), Trade("LAX", "KXHIGHLAX-25DEC05-B60.5", "yes", 62, 480, 297.60, "o1")); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if len(got.Attachments) != 1 {
+		t.Fatalf("attachments = %+v, want 1", got.Attachments)
+	}
+	a := got.Attachments[0]
+	if a.Color != "#36a64f" || a.Title != "📈 Trade Executed: LAX" || len(a.Fields) != 6 || a.Fields[2].Value != "62¢" {
+		t.Errorf("attachment = %+v", a)
+	}
+
+	if err := n.Notify(context.Background(), Text("hello")); err != nil || got.Text != "hello" {
+		t.Errorf("Notify(Text) = %v, text %q", err, got.Text)
+	}
+	if NewSlack("") != nil {
+		t.Error("NewSlack(\"\") != nil")
+	}
+}
+
+func TestDiscord(t *testing.T) {
+	var got discordMessage
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	n := NewDiscord(srv.URL)
+	if err := n.Notify(context.Background(), Error("RiskHalt", "Trading halted")); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if len(got.Embeds) != 1 || got.Embeds[0].Color != 0xe74c3c || got.Embeds[0].Description != "Trading halted" {
+		t.Errorf("embeds = %+v", got.Embeds)
+	}
+
+	status = http.StatusTooManyRequests
+	if err := n.Notify(context.Background(), Text("x")); err == nil {
+		t.Error("Notify() with status 429 error = nil")
+	}
+}
+
+func TestEmail(t *testing.T) {
+	var sent string
+	e := NewEmail("smtp.example.com:587", "bot@example.com", []string{"ops@example.com"}, "bot", "secret").(*Email)
+	e.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		if addr != "smtp.example.com:587" || a == nil || from != "bot@example.com" || len(to) != 1 {
+			t.Errorf("send(%s, %v, %s, %v)", addr, a, from, to)
+		}
+		sent = string(msg)
+		return nil
+	}
+
+	msg := DailySummary(Summary{Day: "2025-12-05", Trades: 4, Wins: 3, Cost: 600, PnL: 41.5})
+	if err := e.Notify(context.Background(), msg); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	for _, want := range []string{
+		"Subject: [summary] 📊 Daily Trading Summary 2025-12-05\r\n",
+		"Win Rate: 75.0%\r\n",
+		"Net P&L: $41.50\r\n",
+	} {
+		if !strings.Contains(sent, want) {
+			t.Errorf("mail missing %q:\n%s", want, sent)
+		}
+	}
+	if NewEmail("", "", nil, "", "") != nil {
+		t.Error("NewEmail() without a server != nil")
+	}
+}
+
+func TestMulti(t *testing.T) {
+	var calls int
+	ok := Func(func(context.Context, Message) error { calls++; return nil })
+	fail := Func(func(context.Context, Message) error { calls++; return io.ErrUnexpectedEOF })
+
+	n := Multi(ok, nil, fail, NewSlack(""))
+	if err := n.Notify(context.Background(), Text("x")); !errors.Is(err, io.ErrUnexpectedEOF) || calls != 2 {
+		t.Errorf("Notify() = %v after %d calls, want the failure after 2", err, calls)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	var sent []Message
+	rec := Func(func(_ context.Context, msg Message) error { sent = append(sent, msg); return nil })
+	th := NewThrottle(rec, ThrottleConfig{Dedup: 10 * time.Minute, Burst: 3, Per: time.Hour})
+	now := time.Date(2025, 12, 5, 9, 0, 0, 0, time.UTC)
+	th.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// A flapping threshold alerts once per dedup window
+	cross := Threshold("LAX", "running max", "61°F", "60.5°F")
+	if err := th.Notify(ctx, cross); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		now = now.Add(time.Minute)
+		if err := th.Notify(ctx, cross); !errors.Is(err, ErrSuppressed) {
+			t.Errorf("repeat %d: Notify() = %v, want ErrSuppressed", i, err)
+		}
+	}
+	now = now.Add(10 * time.Minute)
+	if err := th.Notify(ctx, cross); err != nil {
+		t.Fatalf("Notify() after the window error = %v", err)
+	}
+	if len(sent) != 2 {
+		t.Fatalf("sent %d, want 2", len(sent))
+	}
+	if f := sent[1].Fields[len(sent[1].Fields)-1]; f.Value != "3" {
+		t.Errorf("second alert's last field = %+v, want 3 repeats suppressed", f)
+	}
+	if len(cross.Fields) != 2 {
+		t.Errorf("caller's message modified: %+v", cross.Fields)
+	}
+
+	// Distinct messages are rate-limited to the burst
+	if err := th.Notify(ctx, Error("engine", "a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := th.Notify(ctx, Error("engine", "b")); !errors.Is(err, ErrSuppressed) {
+		t.Errorf("Notify() over the burst = %v, want ErrSuppressed", err)
+	}
+	now = now.Add(time.Hour)
+	if err := th.Notify(ctx, Error("engine", "c")); err != nil {
+		t.Fatal(err)
+	}
+	last := sent[len(sent)-1]
+	if f := last.Fields[len(last.Fields)-1]; f.Name != "Rate-limited" || !strings.HasPrefix(f.Value, "1 ") {
+		t.Errorf("alert after the limit: last field = %+v, want 1 dropped", f)
+	}
+}
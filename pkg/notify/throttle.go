@@ -0,0 +1,100 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrSuppressed is returned by a Throttle for a message it dropped.
+var ErrSuppressed = errors.New("notify: suppressed")
+
+// ThrottleConfig configures a Throttle.
+type ThrottleConfig struct {
+	// Dedup drops a message whose DedupKey was sent less than this long ago
+	// (0 = no deduplication).
+	Dedup time.Duration
+	// Burst messages may be sent in any window of Per; the rest are dropped
+	// (0 = no rate limit). Errors count against the limit too, so a failing
+	// loop can't flood the channel.
+	Burst int
+	Per   time.Duration
+}
+
+// Throttle rate-limits and deduplicates the messages sent to a Notifier. The
+// next message sent after some were dropped notes how many.
+type Throttle struct {
+	next Notifier
+	cfg  ThrottleConfig
+	now  func() time.Time
+
+	mu         sync.Mutex
+	lastSent   map[string]time.Time
+	sent       []time.Time // Within the last Per
+	suppressed map[string]int
+	dropped    int // Dropped by the rate limit since the last send
+}
+
+// NewThrottle returns a Throttle sending to next.
+func NewThrottle(next Notifier, cfg ThrottleConfig) *Throttle {
+	return &Throttle{
+		next:       next,
+		cfg:        cfg,
+		now:        time.Now,
+		lastSent:   make(map[string]time.Time),
+		suppressed: make(map[string]int),
+	}
+}
+
+// Notify sends msg unless it repeats one sent within the dedup window or the
+// rate limit is reached, returning ErrSuppressed then.
+func (t *Throttle) Notify(ctx context.Context, msg Message) error {
+	now := t.now()
+	key := msg.DedupKey()
+
+	t.mu.Lock()
+	for k, at := range t.lastSent {
+		if now.Sub(at) >= t.cfg.Dedup {
+			delete(t.lastSent, k)
+		}
+	}
+	if _, ok := t.lastSent[key]; ok {
+		t.suppressed[key]++
+		t.mu.Unlock()
+		return ErrSuppressed
+	}
+	if t.cfg.Burst > 0 {
+		recent := t.sent[:0]
+		for _, at := range t.sent {
+			if now.Sub(at) < t.cfg.Per {
+				recent = append(recent, at)
+			}
+		}
+		t.sent = recent
+		if len(t.sent) >= t.cfg.Burst {
+			t.dropped++
+			t.mu.Unlock()
+			return ErrSuppressed
+		}
+		t.sent = append(t.sent, now)
+	}
+	if t.cfg.Dedup > 0 {
+		t.lastSent[key] = now
+	}
+	repeats, dropped := t.suppressed[key], t.dropped
+	delete(t.suppressed, key)
+	t.dropped = 0
+	t.mu.Unlock()
+
+	// Don't append into the caller's array
+	msg.Fields = msg.Fields[:len(msg.Fields):len(msg.Fields)]
+	if repeats > 0 {
+		msg.Fields = append(msg.Fields, Field{"Repeats suppressed", fmt.Sprint(repeats)})
+	}
+	if dropped > 0 {
+		msg.Fields = append(msg.Fields, Field{"Rate-limited", fmt.Sprintf("%d earlier alerts dropped", dropped)})
+	}
+	return t.next.Notify(ctx, msg)
+}
@@ -0,0 +1,155 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Colors of each kind, as RGB.
+var colors = map[Kind]int{
+	KindTrade:     0x36a64f, // Green
+	KindThreshold: 0xf39c12, // Orange
+	KindError:     0xe74c3c, // Red
+	KindSummary:   0x3498db, // Blue
+	KindInfo:      0x95a5a6, // Gray
+}
+
+// Slack posts messages to a Slack incoming webhook.
+type Slack struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewSlack returns a Slack sink, or nil if url is empty so it can be passed
+// straight to Multi.
+func NewSlack(url string) Notifier {
+	if url == "" {
+		return nil
+	}
+	return &Slack{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type slackMessage struct {
+	Text        string            `json:"text,omitempty"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Color     string       `json:"color,omitempty"`
+	Title     string       `json:"title,omitempty"`
+	Text      string       `json:"text,omitempty"`
+	Footer    string       `json:"footer,omitempty"`
+	Fields    []slackField `json:"fields,omitempty"`
+	Timestamp int64        `json:"ts,omitempty"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// Notify posts msg, as plain text if it has only text.
+func (s *Slack) Notify(ctx context.Context, msg Message) error {
+	if msg.Title == "" && len(msg.Fields) == 0 {
+		return post(ctx, s.Client, s.URL, slackMessage{Text: msg.Text})
+	}
+	a := slackAttachment{
+		Color:     fmt.Sprintf("#%06x", colors[msg.Kind]),
+		Title:     msg.Title,
+		Text:      msg.Text,
+		Footer:    "Trading Bot - " + string(msg.Kind),
+		Timestamp: msg.time().Unix(),
+	}
+	for _, f := range msg.Fields {
+		a.Fields = append(a.Fields, slackField{Title: f.Name, Value: f.Value, Short: len(f.Value) < 40})
+	}
+	return post(ctx, s.Client, s.URL, slackMessage{Attachments: []slackAttachment{a}})
+}
+
+// Discord posts messages to a Discord webhook.
+type Discord struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewDiscord returns a Discord sink, or nil if url is empty so it can be
+// passed straight to Multi.
+func NewDiscord(url string) Notifier {
+	if url == "" {
+		return nil
+	}
+	return &Discord{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type discordMessage struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []discordEmbed `json:"embeds,omitempty"`
+}
+
+type discordEmbed struct {
+	Title       string              `json:"title,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Color       int                 `json:"color,omitempty"`
+	Fields      []discordEmbedField `json:"fields,omitempty"`
+	Footer      *discordEmbedFooter `json:"footer,omitempty"`
+	Timestamp   string              `json:"timestamp,omitempty"`
+}
+
+type discordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// Notify posts msg, as plain content if it has only text.
+func (d *Discord) Notify(ctx context.Context, msg Message) error {
+	if msg.Title == "" && len(msg.Fields) == 0 {
+		return post(ctx, d.Client, d.URL, discordMessage{Content: msg.Text})
+	}
+	e := discordEmbed{
+		Title:       msg.Title,
+		Description: msg.Text,
+		Color:       colors[msg.Kind],
+		Footer:      &discordEmbedFooter{Text: "Trading Bot - " + string(msg.Kind)},
+		Timestamp:   msg.time().UTC().Format(time.RFC3339),
+	}
+	for _, f := range msg.Fields {
+		e.Fields = append(e.Fields, discordEmbedField{Name: f.Name, Value: f.Value, Inline: len(f.Value) < 40})
+	}
+	return post(ctx, d.Client, d.URL, discordMessage{Embeds: []discordEmbed{e}})
+}
+
+// post sends payload as JSON, accepting any 2xx status (Slack answers 200,
+// Discord 204).
+func post(ctx context.Context, client *http.Client, url string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("notify: marshal message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("notify: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: send request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notify: webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,675 @@
+// Package paper simulates order execution for paper trading, so a bot run
+// without sending orders reports what it would plausibly have made.
+//
+// A Simulator fills orders the way the exchange would as far as public data
+// shows. An order that crosses the book on placement takes the resting
+// levels it reaches, as a taker, at their prices. What is left rests behind
+// the contracts already bid at its price, and fills as a maker only once the
+// public trade flow at that price has consumed the queue ahead of it, or
+// when trades print through its price. Partial fills follow the size of each
+// trade. Fills go to a portfolio.Portfolio, which charges fees by the
+// schedule, and the cash balance moves as an account's would:
+//
+//	sim := paper.New(fees.DefaultSchedule(), balance)
+//	book, _ := client.GetOrderbook(ticker, 0)
+//	order, err := sim.Place(&rest.CreateOrderRequest{...}, book, time.Now())
+//	// ... on each tick, fill resting orders from the trades since
+//	sim.Poll(client)
+//	// ... once the market settles
+//	sim.Settle(ticker, "yes", time.Now())
+//
+// The account persists with Save and Load, so a paper test can run for
+// weeks across restarts: the balance, resting orders and positions carry
+// over to settlement, and Days reports the P&L by the day markets closed,
+// comparable with a backtest's days.
+//
+// The queue is only ever shortened by trades: cancellations ahead of an
+// order are not public, so simulated fills come later, and less often, than
+// live ones. That errs on the side of paper results that live trading can
+// match.
+package paper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/portfolio"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// ErrUnknownOrder is returned by Cancel for an order that is not resting.
+var ErrUnknownOrder = errors.New("paper: no such resting order")
+
+// ErrInsufficientBalance is returned by Place for a buy the cash balance
+// can't cover, as the exchange would reject it.
+var ErrInsufficientBalance = errors.New("paper: insufficient balance")
+
+// order is a simulated order and where it sits in the book. Every order is a
+// bid on one side: selling YES at p bids for NO at 100-p.
+type order struct {
+	rest.Order
+	bid    rest.Side // Side of the book the order rests on
+	price  int       // Bid price on that side in cents
+	ahead  int       // Contracts queued ahead at price
+	placed time.Time
+	seq    int // Placement order, for orders placed at the same time
+}
+
+// Simulator is a paper trading account. It is safe for concurrent use.
+type Simulator struct {
+	book *portfolio.Portfolio
+
+	mu          sync.Mutex
+	start       int               // Starting balance in cents
+	orders      map[string]*order // Resting orders by ID
+	fills       []rest.Fill
+	settlements []rest.Settlement
+	trades      map[string]string    // Public trade IDs applied -> ticker, until it settles
+	lastSeen    map[string]time.Time // Ticker -> newest trade applied
+	seq         int
+	placed      int // Contracts ordered
+}
+
+// New creates a paper account holding balance cents. Fees are charged by
+// schedule, which may be nil.
+func New(schedule *fees.Schedule, balance int) *Simulator {
+	return &Simulator{
+		book:     portfolio.New(schedule),
+		start:    balance,
+		orders:   make(map[string]*order),
+		trades:   make(map[string]string),
+		lastSeen: make(map[string]time.Time),
+	}
+}
+
+// Place simulates placing req against book, the market's order book at
+// now. The part of the order that crosses the book fills at once; a limit
+// order's remainder rests until Poll or ApplyTrade fills it, and a market
+// order's is canceled. The returned order reports the fills so far.
+func (s *Simulator) Place(req *rest.CreateOrderRequest, book *rest.Orderbook, now time.Time) (*rest.Order, error) {
+	if req.Count <= 0 {
+		return nil, fmt.Errorf("paper: invalid count %d", req.Count)
+	}
+	price := limitPrice(req)
+	if price < 1 || price > 99 {
+		return nil, fmt.Errorf("paper: invalid price %d¢", price)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Action == rest.OrderActionBuy && req.Count*price > s.balance() {
+		return nil, fmt.Errorf("%w: %d @ %d¢ costs $%.2f, balance $%.2f",
+			ErrInsufficientBalance, req.Count, price, float64(req.Count*price)/100, float64(s.balance())/100)
+	}
+
+	s.seq++
+	s.placed += req.Count
+	o := &order{
+		Order: rest.Order{
+			OrderID:        fmt.Sprintf("PAPER-%d", s.seq),
+			Ticker:         req.Ticker,
+			Action:         req.Action,
+			Side:           req.Side,
+			Type:           req.Type,
+			Status:         rest.OrderStatusResting,
+			ClientOrderID:  req.ClientOrderID,
+			CreatedTime:    now.UTC().Format(time.RFC3339),
+			PlaceCount:     req.Count,
+			RemainingCount: req.Count,
+		},
+		bid:    req.Side,
+		price:  price,
+		placed: now,
+		seq:    s.seq,
+	}
+	if req.Action == rest.OrderActionSell {
+		o.bid, o.price = opposite(req.Side), 100-price
+	}
+	if o.Side == rest.SideYes {
+		o.YesPrice, o.NoPrice = price, 100-price
+	} else {
+		o.YesPrice, o.NoPrice = 100-price, price
+	}
+
+	// Take the opposite bids the order crosses, best first
+	if book != nil {
+		own, other := book.Yes, book.No
+		if o.bid == rest.SideNo {
+			own, other = book.No, book.Yes
+		}
+		levels := append([][2]int(nil), other...)
+		sort.Slice(levels, func(i, j int) bool { return levels[i][0] > levels[j][0] })
+		for _, l := range levels {
+			if o.RemainingCount == 0 || o.price+l[0] < 100 {
+				break
+			}
+			s.fill(o, min(o.RemainingCount, l[1]), 100-l[0], true, now)
+		}
+		for _, l := range own {
+			if l[0] == o.price {
+				o.ahead += l[1]
+			}
+		}
+	}
+
+	switch {
+	case o.RemainingCount == 0:
+		o.Status = rest.OrderStatusExecuted
+	case req.Type == rest.OrderTypeMarket:
+		o.Status = rest.OrderStatusCanceled
+	default:
+		s.orders[o.OrderID] = o
+	}
+	placed := o.Order
+	return &placed, nil
+}
+
+// fill records count contracts of o filled at bidPrice, its price on the
+// side it bids. Callers hold s.mu.
+func (s *Simulator) fill(o *order, count, bidPrice int, taker bool, at time.Time) {
+	if count <= 0 {
+		return
+	}
+	price := bidPrice
+	if o.Action == rest.OrderActionSell {
+		price = 100 - bidPrice
+	}
+	f := rest.Fill{
+		TradeID:     fmt.Sprintf("%s-%d", o.OrderID, len(s.fills)+1),
+		OrderID:     o.OrderID,
+		Ticker:      o.Ticker,
+		Side:        o.Side,
+		Action:      o.Action,
+		Count:       count,
+		YesPrice:    price,
+		NoPrice:     100 - price,
+		IsTaker:     taker,
+		CreatedTime: at,
+	}
+	if o.Side == rest.SideNo {
+		f.YesPrice, f.NoPrice = 100-price, price
+	}
+	s.fills = append(s.fills, f)
+	s.book.ApplyFill(f)
+
+	o.RemainingCount -= count
+	if taker {
+		o.TakerFillCount += count
+		o.TakerFillCost += count * price
+	} else {
+		o.MakerFillCount += count
+		o.MakerFillCost += count * price
+	}
+	if o.RemainingCount == 0 {
+		o.Status = rest.OrderStatusExecuted
+		delete(s.orders, o.OrderID)
+	}
+}
+
+// ApplyTrade fills resting orders from a public trade and reports whether
+// the trade was new. The trade hit bids on the side opposite its taker: at
+// an order's price it first takes the queue ahead of the order, and below it
+// the order would have been hit first. Trades from before an order was
+// placed don't fill it.
+func (s *Simulator) ApplyTrade(t rest.Trade) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.TradeID != "" {
+		if _, ok := s.trades[t.TradeID]; ok {
+			return false
+		}
+		s.trades[t.TradeID] = t.Ticker
+	}
+	if t.CreatedTime.After(s.lastSeen[t.Ticker]) {
+		s.lastSeen[t.Ticker] = t.CreatedTime
+	}
+
+	hit, price := rest.SideNo, t.NoPrice
+	if t.TakerSide == string(rest.SideNo) {
+		hit, price = rest.SideYes, t.YesPrice
+	}
+
+	left := t.Count
+	for _, o := range s.resting(t.Ticker) {
+		if left == 0 {
+			break
+		}
+		if o.bid != hit || o.price < price || !t.CreatedTime.After(o.placed) {
+			continue
+		}
+		if o.price == price {
+			queued := min(o.ahead, left)
+			o.ahead -= queued
+			left -= queued
+		} else {
+			o.ahead = 0
+		}
+		n := min(o.RemainingCount, left)
+		s.fill(o, n, o.price, false, t.CreatedTime)
+		left -= n
+	}
+	return true
+}
+
+// ApplyTradeMsg applies a trade received on the WebSocket trade channel.
+func (s *Simulator) ApplyTradeMsg(m ws.TradeMsg) bool {
+	return s.ApplyTrade(rest.Trade{
+		TradeID:     m.TradeID,
+		Ticker:      m.MarketTicker,
+		Count:       m.Count,
+		YesPrice:    m.YesPrice,
+		NoPrice:     m.NoPrice,
+		TakerSide:   m.TakerSide,
+		CreatedTime: m.Time(),
+	})
+}
+
+// Poll fetches the public trades of the markets with resting orders since
+// they were placed, or since the last trade seen, and applies them oldest
+// first.
+func (s *Simulator) Poll(client *rest.Client) error {
+	s.mu.Lock()
+	since := make(map[string]time.Time)
+	for _, o := range s.orders {
+		if t, ok := since[o.Ticker]; !ok || o.placed.Before(t) {
+			since[o.Ticker] = o.placed
+		}
+	}
+	for ticker, t := range since {
+		if seen := s.lastSeen[ticker]; seen.After(t) {
+			since[ticker] = seen
+		}
+	}
+	s.mu.Unlock()
+
+	for ticker, t := range since {
+		trades, err := client.GetAllTrades(rest.GetTradesParams{Ticker: ticker, MinTS: t})
+		if err != nil {
+			return fmt.Errorf("get trades for %s: %w", ticker, err)
+		}
+		for i := len(trades) - 1; i >= 0; i-- {
+			s.ApplyTrade(trades[i])
+		}
+	}
+	return nil
+}
+
+// Cancel cancels a resting order.
+func (s *Simulator) Cancel(orderID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders[orderID]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownOrder, orderID)
+	}
+	o.Status = rest.OrderStatusCanceled
+	delete(s.orders, orderID)
+	return nil
+}
+
+// Settle cancels a market's resting orders and settles its positions at
+// result ("yes" or "no").
+func (s *Simulator) Settle(ticker, result string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, o := range s.orders {
+		if o.Ticker == ticker {
+			o.Status = rest.OrderStatusCanceled
+			delete(s.orders, id)
+		}
+	}
+	// No order is left for the market's trades to fill
+	for id, t := range s.trades {
+		if t == ticker {
+			delete(s.trades, id)
+		}
+	}
+	delete(s.lastSeen, ticker)
+
+	st := rest.Settlement{Ticker: ticker, MarketResult: result, SettledTime: at}
+	for _, pos := range s.book.Positions() {
+		if pos.Ticker != ticker || pos.Settled {
+			continue
+		}
+		if pos.Side == rest.SideYes {
+			st.YesCount, st.YesTotalCost = pos.Count, pos.Cost
+		} else {
+			st.NoCount, st.NoTotalCost = pos.Count, pos.Cost
+		}
+	}
+	s.book.Settle(st)
+	if !s.settled(ticker) {
+		s.settlements = append(s.settlements, st)
+	}
+}
+
+// settled reports whether ticker has been settled. Callers hold s.mu.
+func (s *Simulator) settled(ticker string) bool {
+	for _, st := range s.settlements {
+		if st.Ticker == ticker {
+			return true
+		}
+	}
+	return false
+}
+
+// Held returns the tickers of unsettled positions and resting orders, the
+// markets still to be settled.
+func (s *Simulator) Held() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool)
+	for _, o := range s.orders {
+		seen[o.Ticker] = true
+	}
+	for _, pos := range s.book.Positions() {
+		if pos.Count > 0 && !pos.Settled {
+			seen[pos.Ticker] = true
+		}
+	}
+	tickers := make([]string, 0, len(seen))
+	for t := range seen {
+		tickers = append(tickers, t)
+	}
+	sort.Strings(tickers)
+	return tickers
+}
+
+// Orders returns the resting orders, oldest first.
+func (s *Simulator) Orders() []rest.Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var orders []rest.Order
+	for _, o := range s.resting("") {
+		orders = append(orders, o.Order)
+	}
+	return orders
+}
+
+// Fills returns every simulated fill, oldest first.
+func (s *Simulator) Fills() []rest.Fill {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]rest.Fill(nil), s.fills...)
+}
+
+// Summary is a paper account's activity and P&L.
+type Summary struct {
+	Start    int           `json:"start"`     // Starting balance in cents
+	Orders   int           `json:"orders"`    // Orders placed
+	Resting  int           `json:"resting"`   // Orders still resting
+	Placed   int           `json:"placed"`    // Contracts ordered
+	Filled   int           `json:"filled"`    // Contracts filled
+	Maker    int           `json:"maker"`     // Contracts filled resting
+	Balance  int           `json:"balance"`   // Cash in cents
+	PnL      portfolio.PnL `json:"pnl"`       // In cents
+	FillRate float64       `json:"fill_rate"` // Filled / Placed
+}
+
+// Summary returns the account's activity and P&L.
+func (s *Simulator) Summary() Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sum := Summary{
+		Start:   s.start,
+		Orders:  s.seq,
+		Resting: len(s.orders),
+		Placed:  s.placed,
+		Balance: s.balance(),
+		PnL:     s.book.Total(),
+	}
+	for _, f := range s.fills {
+		sum.Filled += f.Count
+		if !f.IsTaker {
+			sum.Maker += f.Count
+		}
+	}
+	if sum.Placed > 0 {
+		sum.FillRate = float64(sum.Filled) / float64(sum.Placed)
+	}
+	return sum
+}
+
+// Day is the paper account's P&L from the markets closed on one day.
+type Day struct {
+	Date     string `json:"date"`     // YYYY-MM-DD
+	Markets  int    `json:"markets"`  // Markets settled or sold out of
+	Fills    int    `json:"fills"`    // Fills in those markets
+	Fees     int    `json:"fees"`     // In cents
+	Realized int    `json:"realized"` // In cents, after fees
+	Bankroll int    `json:"bankroll"` // Starting balance plus the P&L through the day
+}
+
+// Days returns the P&L by the day, in loc, each market closed: when it
+// settled, or for a position sold out of before then, its last fill. Markets
+// still held or with resting orders are left out. Days are oldest first.
+func (s *Simulator) Days(loc *time.Location) []Day {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	closed := make(map[string]time.Time)
+	fills := make(map[string]int)
+	for _, f := range s.fills {
+		fills[f.Ticker]++
+		if f.CreatedTime.After(closed[f.Ticker]) {
+			closed[f.Ticker] = f.CreatedTime
+		}
+	}
+	for _, pos := range s.book.Positions() {
+		if pos.Count > 0 && !pos.Settled {
+			delete(closed, pos.Ticker)
+		}
+	}
+	for _, o := range s.orders {
+		delete(closed, o.Ticker)
+	}
+	for _, st := range s.settlements {
+		if _, ok := closed[st.Ticker]; ok {
+			closed[st.Ticker] = st.SettledTime
+		}
+	}
+
+	byDate := make(map[string]*Day)
+	for ticker, at := range closed {
+		date := at.In(loc).Format("2006-01-02")
+		d, ok := byDate[date]
+		if !ok {
+			d = &Day{Date: date}
+			byDate[date] = d
+		}
+		pnl := s.book.Ticker(ticker)
+		d.Markets++
+		d.Fills += fills[ticker]
+		d.Fees += pnl.Fees
+		d.Realized += pnl.Realized
+	}
+
+	days := make([]Day, 0, len(byDate))
+	for _, d := range byDate {
+		days = append(days, *d)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	bankroll := s.start
+	for i := range days {
+		bankroll += days[i].Realized
+		days[i].Bankroll = bankroll
+	}
+	return days
+}
+
+// Portfolio returns the positions and P&L built from the fills.
+func (s *Simulator) Portfolio() *portfolio.Portfolio {
+	return s.book
+}
+
+// Balance returns the cash balance in cents: the starting balance plus
+// realized P&L, less the cost of the contracts held and the cash reserved by
+// resting buys.
+func (s *Simulator) Balance() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.balance()
+}
+
+// balance returns the cash balance. Callers hold s.mu.
+func (s *Simulator) balance() int {
+	pnl := s.book.Total()
+	cash := s.start + pnl.Realized - pnl.Exposure
+	for _, o := range s.orders {
+		cash -= o.RestingCost()
+	}
+	return cash
+}
+
+// savedOrder is the on-disk form of a resting order.
+type savedOrder struct {
+	rest.Order
+	Bid    rest.Side `json:"bid"`
+	Price  int       `json:"price"`
+	Ahead  int       `json:"ahead"`
+	Placed time.Time `json:"placed"`
+	Seq    int       `json:"seq"`
+}
+
+// state is the on-disk form of a Simulator. Positions are rebuilt from the
+// fills and settlements.
+type state struct {
+	Start       int                  `json:"start"`
+	Seq         int                  `json:"seq"`
+	Placed      int                  `json:"placed"`
+	Orders      []savedOrder         `json:"orders"`
+	Fills       []rest.Fill          `json:"fills"`
+	Settlements []rest.Settlement    `json:"settlements"`
+	Trades      map[string]string    `json:"trades"`
+	LastSeen    map[string]time.Time `json:"last_seen"`
+}
+
+// Save writes the account to path as JSON: its starting balance, resting
+// orders, fills and settlements.
+func (s *Simulator) Save(path string) error {
+	s.mu.Lock()
+	st := state{
+		Start:       s.start,
+		Seq:         s.seq,
+		Placed:      s.placed,
+		Fills:       s.fills,
+		Settlements: s.settlements,
+		Trades:      s.trades,
+		LastSeen:    s.lastSeen,
+	}
+	for _, o := range s.resting("") {
+		st.Orders = append(st.Orders, savedOrder{Order: o.Order, Bid: o.bid, Price: o.price, Ahead: o.ahead, Placed: o.placed, Seq: o.seq})
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("marshal paper account: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create paper account directory: %w", err)
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write paper account: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write paper account: %w", err)
+	}
+	return nil
+}
+
+// Load reads an account saved by Save, replaying its fills and settlements
+// into a new portfolio. Fees are charged by schedule, which may be nil.
+func Load(path string, schedule *fees.Schedule) (*Simulator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read paper account: %w", err)
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("parse paper account %s: %w", path, err)
+	}
+
+	s := New(schedule, st.Start)
+	s.seq, s.placed = st.Seq, st.Placed
+	for _, o := range st.Orders {
+		s.orders[o.OrderID] = &order{Order: o.Order, bid: o.Bid, price: o.Price, ahead: o.Ahead, placed: o.Placed, seq: o.Seq}
+	}
+	s.fills = st.Fills
+	for _, f := range st.Fills {
+		s.book.ApplyFill(f)
+	}
+	// A market's fills all precede its settlement
+	s.settlements = st.Settlements
+	for _, set := range st.Settlements {
+		s.book.Settle(set)
+	}
+	for id, ticker := range st.Trades {
+		s.trades[id] = ticker
+	}
+	for ticker, t := range st.LastSeen {
+		s.lastSeen[ticker] = t
+	}
+	return s, nil
+}
+
+// resting returns the resting orders on ticker ("" = all) in placement
+// order. Callers hold s.mu.
+func (s *Simulator) resting(ticker string) []*order {
+	var orders []*order
+	for _, o := range s.orders {
+		if ticker == "" || o.Ticker == ticker {
+			orders = append(orders, o)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].placed.Equal(orders[j].placed) {
+			return orders[i].placed.Before(orders[j].placed)
+		}
+		return orders[i].seq < orders[j].seq
+	})
+	return orders
+}
+
+// limitPrice returns an order's limit price on its own side. A market order
+// without one is priced to take any level: 99¢ for a buy, 1¢ for a sell.
+func limitPrice(req *rest.CreateOrderRequest) int {
+	own, other := req.YesPrice, req.NoPrice
+	if req.Side == rest.SideNo {
+		own, other = req.NoPrice, req.YesPrice
+	}
+	switch {
+	case own > 0:
+		return own
+	case other > 0:
+		return 100 - other
+	case req.Action == rest.OrderActionSell:
+		return 1
+	}
+	return 99
+}
+
+func opposite(side rest.Side) rest.Side {
+	if side == rest.SideYes {
+		return rest.SideNo
+	}
+	return rest.SideYes
+}
@@ -0,0 +1,220 @@
+package paper
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+const ticker = "KXHIGHLAX-25DEC05-B62.5"
+
+var start = time.Date(2025, 12, 5, 15, 0, 0, 0, time.UTC)
+
+func buyYes(count, price int) *rest.CreateOrderRequest {
+	return &rest.CreateOrderRequest{
+		Ticker: ticker, Action: rest.OrderActionBuy, Side: rest.SideYes,
+		Type: rest.OrderTypeLimit, Count: count, YesPrice: price,
+	}
+}
+
+// trade returns a public trade whose taker sold YES (bought NO) at yesPrice,
+// hitting YES bids.
+func trade(id string, count, yesPrice int, at time.Time) rest.Trade {
+	return rest.Trade{TradeID: id, Ticker: ticker, Count: count, YesPrice: yesPrice, NoPrice: 100 - yesPrice, TakerSide: "no", CreatedTime: at}
+}
+
+func TestSimulator_CrossesBook(t *testing.T) {
+	sim := New(nil, 100000)
+	// YES asks at 42 (5) and 43 (10): NO bids at 58 and 57
+	book := &rest.Orderbook{Yes: [][2]int{{40, 20}}, No: [][2]int{{57, 10}, {58, 5}}}
+
+	o, err := sim.Place(buyYes(12, 43), book, start)
+	if err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if o.Status != rest.OrderStatusExecuted || o.TakerFillCount != 12 || o.TakerFillCost != 5*42+7*43 {
+		t.Errorf("Place() = %+v, want 12 filled for %d¢", o, 5*42+7*43)
+	}
+	if got := sim.Balance(); got != 100000-(5*42+7*43) {
+		t.Errorf("Balance() = %d, want %d", got, 100000-(5*42+7*43))
+	}
+
+	// A market order takes what the book has and cancels the rest
+	o, err = sim.Place(&rest.CreateOrderRequest{Ticker: ticker, Action: rest.OrderActionBuy, Side: rest.SideYes, Type: rest.OrderTypeMarket, Count: 20}, book, start)
+	if err != nil {
+		t.Fatalf("Place() market error = %v", err)
+	}
+	if o.Status != rest.OrderStatusCanceled || o.TakerFillCount != 15 || len(sim.Orders()) != 0 {
+		t.Errorf("market order = %+v, want the book's 15 filled and the rest canceled", o)
+	}
+}
+
+func TestSimulator_QueuePosition(t *testing.T) {
+	sim := New(nil, 100000)
+	book := &rest.Orderbook{Yes: [][2]int{{40, 30}, {39, 100}}, No: [][2]int{{55, 50}}}
+
+	o, err := sim.Place(buyYes(20, 40), book, start)
+	if err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if o.Status != rest.OrderStatusResting || o.RemainingCount != 20 {
+		t.Fatalf("Place() = %+v, want 20 resting", o)
+	}
+	if got := sim.Balance(); got != 100000-800 {
+		t.Errorf("Balance() = %d, want resting cost reserved", got)
+	}
+
+	sim.ApplyTrade(trade("old", 50, 40, start.Add(-time.Minute))) // Before the order
+	sim.ApplyTrade(trade("t1", 25, 40, start.Add(time.Minute)))   // Queue ahead 30 -> 5
+	sim.ApplyTrade(trade("t2", 5, 41, start.Add(2*time.Minute)))  // Above our bid
+	if fills := sim.Fills(); len(fills) != 0 {
+		t.Fatalf("filled while queued: %v", fills)
+	}
+
+	sim.ApplyTrade(trade("t3", 12, 40, start.Add(3*time.Minute))) // 5 ahead, 7 to us
+	if orders := sim.Orders(); len(orders) != 1 || orders[0].RemainingCount != 13 {
+		t.Fatalf("Orders() = %+v, want 13 remaining", orders)
+	}
+	if !sim.ApplyTrade(trade("t4", 1, 40, start.Add(4*time.Minute))) || sim.ApplyTrade(trade("t4", 1, 40, start.Add(4*time.Minute))) {
+		t.Error("ApplyTrade() did not dedupe trade IDs")
+	}
+
+	sim.ApplyTrade(trade("t5", 40, 38, start.Add(5*time.Minute))) // Through our price
+	if orders := sim.Orders(); len(orders) != 0 {
+		t.Fatalf("Orders() = %+v, want filled", orders)
+	}
+	fills := sim.Fills()
+	if len(fills) != 3 || fills[0].Count != 7 || fills[1].Count != 1 || fills[2].Count != 12 || fills[2].IsTaker || fills[2].YesPrice != 40 {
+		t.Errorf("Fills() = %+v, want maker fills of 7, 1, 12 at 40¢", fills)
+	}
+	if sum := sim.Summary(); sum.Filled != 20 || sum.Maker != 20 || sum.FillRate != 1 || sum.Balance != 100000-800 {
+		t.Errorf("Summary() = %+v, want 20 filled as maker for $8", sum)
+	}
+}
+
+func TestSimulator_SellAndSettle(t *testing.T) {
+	schedule := fees.DefaultSchedule()
+	sim := New(schedule, 10000)
+	book := &rest.Orderbook{Yes: [][2]int{{60, 10}}, No: [][2]int{{50, 10}}}
+
+	if _, err := sim.Place(buyYes(10, 50), book, start); err != nil {
+		t.Fatalf("Place() buy error = %v", err)
+	}
+	// Sell 4 YES into the 60¢ bid
+	sell := &rest.CreateOrderRequest{Ticker: ticker, Action: rest.OrderActionSell, Side: rest.SideYes, Type: rest.OrderTypeLimit, Count: 4, YesPrice: 60}
+	if o, err := sim.Place(sell, book, start); err != nil || o.TakerFillCount != 4 || o.TakerFillCost != 240 {
+		t.Fatalf("Place() sell = %+v, %v, want 4 filled at 60¢", o, err)
+	}
+
+	sim.Settle(ticker, "yes", start.Add(24*time.Hour))
+	if held := sim.Held(); len(held) != 0 {
+		t.Errorf("Held() = %v, want none after settlement", held)
+	}
+	pnl := sim.Portfolio().Total()
+	// 10 @ 50 bought, 4 sold @ 60, 6 paid out at $1, less fees
+	gross := -500 + 240 + 600
+	if pnl.Realized != gross-pnl.Fees || pnl.Fees <= 0 {
+		t.Errorf("Realized = %d, fees %d; want %d less positive fees", pnl.Realized, pnl.Fees, gross)
+	}
+	if got := sim.Balance(); got != 10000+pnl.Realized {
+		t.Errorf("Balance() = %d, want %d", got, 10000+pnl.Realized)
+	}
+}
+
+func TestSimulator_Rejects(t *testing.T) {
+	sim := New(nil, 1000)
+	if _, err := sim.Place(buyYes(30, 40), nil, start); !errors.Is(err, ErrInsufficientBalance) {
+		t.Errorf("Place() over balance error = %v, want ErrInsufficientBalance", err)
+	}
+	if _, err := sim.Place(buyYes(1, 100), nil, start); err == nil {
+		t.Error("Place() at 100¢ error = nil")
+	}
+	if err := sim.Cancel("PAPER-99"); !errors.Is(err, ErrUnknownOrder) {
+		t.Errorf("Cancel() error = %v, want ErrUnknownOrder", err)
+	}
+}
+
+func TestSimulator_Poll(t *testing.T) {
+	sim := New(nil, 100000)
+	o, err := sim.Place(buyYes(10, 40), &rest.Orderbook{Yes: [][2]int{{40, 5}}}, start)
+	if err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ticker") != ticker {
+			t.Errorf("ticker = %q, want %q", r.URL.Query().Get("ticker"), ticker)
+		}
+		// Newest first, as the API returns them
+		fmt.Fprintf(w, `{"trades":[
+			{"trade_id":"b","ticker":%[1]q,"count":6,"yes_price":40,"no_price":60,"taker_side":"no","created_time":%[2]q},
+			{"trade_id":"a","ticker":%[1]q,"count":4,"yes_price":40,"no_price":60,"taker_side":"no","created_time":%[3]q}
+		]}`, ticker, start.Add(2*time.Minute).Format(time.RFC3339), start.Add(time.Minute).Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	if err := sim.Poll(rest.NewPublic(rest.WithBaseURL(server.URL))); err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	// 5 queued ahead: 4 then 1 of the second trade, leaving 5 for us
+	orders := sim.Orders()
+	if len(orders) != 1 || orders[0].OrderID != o.OrderID || orders[0].RemainingCount != 5 {
+		t.Errorf("Orders() = %+v, want 5 remaining", orders)
+	}
+}
+
+func TestSimulator_SaveLoad(t *testing.T) {
+	schedule := fees.DefaultSchedule()
+	sim := New(schedule, 100000)
+	book := &rest.Orderbook{Yes: [][2]int{{40, 30}}, No: [][2]int{{58, 10}}}
+	other := "KXHIGHLAX-25DEC06-B64.5"
+
+	if _, err := sim.Place(buyYes(10, 42), book, start); err != nil { // Takes 10 at 42
+		t.Fatalf("Place() error = %v", err)
+	}
+	sim.Settle(ticker, "yes", start.Add(10*time.Hour))
+	resting, err := sim.Place(&rest.CreateOrderRequest{
+		Ticker: other, Action: rest.OrderActionBuy, Side: rest.SideYes,
+		Type: rest.OrderTypeLimit, Count: 5, YesPrice: 30,
+	}, &rest.Orderbook{Yes: [][2]int{{30, 8}}}, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+
+	path := t.TempDir() + "/paper.json"
+	if err := sim.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load(path, schedule)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got, want := loaded.Summary(), sim.Summary(); got != want {
+		t.Errorf("Summary() = %+v, want %+v", got, want)
+	}
+	if got := loaded.Held(); len(got) != 1 || got[0] != other {
+		t.Errorf("Held() = %v, want %s", got, other)
+	}
+	days := loaded.Days(time.UTC)
+	if len(days) != 1 || days[0].Date != "2025-12-06" || days[0].Markets != 1 || days[0].Bankroll != 100000+days[0].Realized {
+		t.Errorf("Days() = %+v, want the settled market on 2025-12-06", days)
+	}
+
+	// The resting order keeps its place in the queue and its ID sequence
+	trade := rest.Trade{TradeID: "t1", Ticker: other, Count: 10, YesPrice: 30, NoPrice: 70, TakerSide: "no", CreatedTime: start.Add(25 * time.Hour)}
+	loaded.ApplyTrade(trade)
+	if fills := loaded.Fills(); len(fills) != 2 || fills[1].OrderID != resting.OrderID || fills[1].Count != 2 {
+		t.Errorf("Fills() = %+v, want 2 of %s after the 8 ahead", fills, resting.OrderID)
+	}
+	o, err := loaded.Place(buyYes(1, 10), nil, start.Add(26*time.Hour))
+	if err != nil || o.OrderID != "PAPER-3" {
+		t.Errorf("Place() = %v, %v, want PAPER-3", o, err)
+	}
+}
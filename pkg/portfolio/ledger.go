@@ -0,0 +1,355 @@
+package portfolio
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// Untagged is the sub-ledger of fills from orders no strategy claimed, such
+// as manual trades.
+const Untagged = "untagged"
+
+// OrderTag returns a new client order ID tagged with strategy, e.g.
+// "dualside:3f9c2a7d41b0e8c6". Orders placed with it are attributed to the
+// strategy by Ledger.AssignOrders, even after a restart.
+func OrderTag(strategy string) string {
+	var b [8]byte
+	rand.Read(b[:])
+	return strategy + ":" + hex.EncodeToString(b[:])
+}
+
+// TagStrategy returns the strategy a client order ID was tagged with by
+// OrderTag, or "" for an untagged ID.
+func TagStrategy(clientOrderID string) string {
+	strategy, id, ok := strings.Cut(clientOrderID, ":")
+	if !ok || strategy == "" || len(id) != 16 {
+		return ""
+	}
+	return strategy
+}
+
+// Ledger splits one exchange account between the strategies trading it. Each
+// strategy keeps a sub-ledger, a Portfolio of the fills of its own orders, so
+// its P&L and exposure are reported in isolation. Check and Reconcile compare
+// the sub-ledgers' sum with the account. It is safe for concurrent use.
+//
+// Fills are attributed by order ID. Orders are assigned to a strategy as
+// they are placed (Assign) or from the tags of their client order IDs
+// (AssignOrders); fills that arrive before their order is assigned wait for
+// it, and go to Untagged at the next Sync or Reconcile if it never is.
+type Ledger struct {
+	fees *fees.Schedule
+
+	mu      sync.Mutex
+	orders  map[string]string      // Order ID -> strategy
+	books   map[string]*Portfolio  // Strategy -> sub-ledger
+	parked  map[string][]rest.Fill // Order ID -> fills waiting for the order's assignment
+	marks   map[string]int         // Ticker -> YES mark, for sub-ledgers created later
+	settled map[string]bool        // Tickers settled
+	found   []Discrepancy
+}
+
+// NewLedger creates an empty ledger. Fees are estimated from schedule, which
+// may be nil.
+func NewLedger(schedule *fees.Schedule) *Ledger {
+	return &Ledger{
+		fees:    schedule,
+		orders:  make(map[string]string),
+		books:   make(map[string]*Portfolio),
+		parked:  make(map[string][]rest.Fill),
+		marks:   make(map[string]int),
+		settled: make(map[string]bool),
+	}
+}
+
+// book returns a strategy's sub-ledger, creating it. Callers hold l.mu.
+func (l *Ledger) book(strategy string) *Portfolio {
+	b, ok := l.books[strategy]
+	if !ok {
+		b = New(l.fees)
+		for ticker, mark := range l.marks {
+			b.marks[ticker] = mark
+		}
+		l.books[strategy] = b
+	}
+	return b
+}
+
+// Assign attributes an order, and any of its fills already received, to a
+// strategy.
+func (l *Ledger) Assign(orderID, strategy string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.orders[orderID] = strategy
+	b := l.book(strategy)
+	for _, f := range l.parked[orderID] {
+		b.ApplyFill(f)
+	}
+	delete(l.parked, orderID)
+}
+
+// AssignOrders assigns orders by the strategy tags of their client order
+// IDs and returns how many were tagged. Untagged orders are left
+// unassigned.
+func (l *Ledger) AssignOrders(orders []rest.Order) int {
+	n := 0
+	for _, o := range orders {
+		if strategy := TagStrategy(o.ClientOrderID); strategy != "" {
+			l.Assign(o.OrderID, strategy)
+			n++
+		}
+	}
+	return n
+}
+
+// ApplyFill adds a fill to the sub-ledger of its order's strategy, or holds
+// it until the order is assigned. It reports whether the fill was applied.
+func (l *Ledger) ApplyFill(f rest.Fill) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	strategy, ok := l.orders[f.OrderID]
+	if !ok {
+		for _, p := range l.parked[f.OrderID] {
+			if f.TradeID != "" && p.TradeID == f.TradeID {
+				return false
+			}
+		}
+		l.parked[f.OrderID] = append(l.parked[f.OrderID], f)
+		return false
+	}
+	return l.book(strategy).ApplyFill(f)
+}
+
+// ApplyFills applies fills oldest first, as Portfolio.ApplyFills does.
+func (l *Ledger) ApplyFills(fills []rest.Fill) {
+	fills = append([]rest.Fill(nil), fills...)
+	sort.SliceStable(fills, func(i, j int) bool { return fills[i].CreatedTime.Before(fills[j].CreatedTime) })
+	for _, f := range fills {
+		l.ApplyFill(f)
+	}
+}
+
+// ApplyFillMsg applies a fill received on the WebSocket fill channel.
+func (l *Ledger) ApplyFillMsg(m ws.FillMsg) bool {
+	return l.ApplyFill(fillFromMsg(m))
+}
+
+// flush moves the fills of orders never assigned to Untagged.
+func (l *Ledger) flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var waiting []rest.Fill
+	for id, fills := range l.parked {
+		l.orders[id] = Untagged
+		waiting = append(waiting, fills...)
+	}
+	clear(l.parked)
+	sort.SliceStable(waiting, func(i, j int) bool { return waiting[i].CreatedTime.Before(waiting[j].CreatedTime) })
+	for _, f := range waiting {
+		l.book(Untagged).ApplyFill(f)
+	}
+}
+
+// Sync assigns the account's orders by their tags and applies its fills
+// since the given time. Fills of orders still unassigned go to Untagged.
+func (l *Ledger) Sync(client *rest.Client, since time.Time) error {
+	orders, err := client.GetOrders("", "")
+	if err != nil {
+		return fmt.Errorf("get orders: %w", err)
+	}
+	l.AssignOrders(orders)
+
+	fills, err := client.GetAllFills(rest.GetFillsParams{MinTS: since})
+	if err != nil {
+		return fmt.Errorf("get fills: %w", err)
+	}
+	l.ApplyFills(fills)
+	l.flush()
+	return nil
+}
+
+// Mark sets the YES price at which a market's positions are valued in every
+// sub-ledger.
+func (l *Ledger) Mark(ticker string, yesPrice int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.marks[ticker] = yesPrice
+	for _, b := range l.books {
+		b.Mark(ticker, yesPrice)
+	}
+}
+
+// Settle closes a market's positions in every sub-ledger at its settlement,
+// each at its own count and cost. When the sub-ledgers' counts do not add up
+// to the exchange's, the difference is returned as a discrepancy; unlike
+// Portfolio.Settle, no strategy's count is overwritten, since the exchange
+// cannot say whose contracts are missing. Settling a market twice has no
+// effect.
+func (l *Ledger) Settle(s rest.Settlement) []Discrepancy {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.settled[s.Ticker] {
+		return nil
+	}
+	l.settled[s.Ticker] = true
+
+	var yes, no int
+	for _, b := range l.books {
+		own := s
+		own.YesCount, own.YesTotalCost = b.open(s.Ticker, rest.SideYes)
+		own.NoCount, own.NoTotalCost = b.open(s.Ticker, rest.SideNo)
+		yes += own.YesCount
+		no += own.NoCount
+		b.Settle(own)
+	}
+
+	var found []Discrepancy
+	if yes != s.YesCount {
+		found = append(found, Discrepancy{Ticker: s.Ticker, Side: rest.SideYes, Local: yes, Exchange: s.YesCount})
+	}
+	if no != s.NoCount {
+		found = append(found, Discrepancy{Ticker: s.Ticker, Side: rest.SideNo, Local: no, Exchange: s.NoCount})
+	}
+	l.found = append(l.found, found...)
+	return found
+}
+
+// Check compares the sub-ledgers' open contracts with the account's
+// positions and returns each market side where they differ.
+func (l *Ledger) Check(positions []rest.Position) []Discrepancy {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	type key struct {
+		ticker string
+		side   rest.Side
+	}
+	local := make(map[key]int)
+	for _, b := range l.books {
+		for _, pos := range b.Positions() {
+			if pos.Count > 0 && !pos.Settled {
+				local[key{pos.Ticker, pos.Side}] += pos.Count
+			}
+		}
+	}
+	account := make(map[key]int)
+	for _, p := range positions {
+		if p.YesPosition != 0 {
+			account[key{p.Ticker, rest.SideYes}] = p.YesPosition
+		}
+		if p.NoPosition != 0 {
+			account[key{p.Ticker, rest.SideNo}] = p.NoPosition
+		}
+	}
+
+	var found []Discrepancy
+	for k, n := range account {
+		if local[k] != n {
+			found = append(found, Discrepancy{Ticker: k.ticker, Side: k.side, Local: local[k], Exchange: n})
+		}
+	}
+	for k, n := range local {
+		if _, ok := account[k]; !ok {
+			found = append(found, Discrepancy{Ticker: k.ticker, Side: k.side, Local: n})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Ticker != found[j].Ticker {
+			return found[i].Ticker < found[j].Ticker
+		}
+		return found[i].Side > found[j].Side
+	})
+	return found
+}
+
+// Reconcile settles the markets covered by the account's settlements since
+// the given time, then checks the open positions against the account. It
+// returns the discrepancies found by both.
+func (l *Ledger) Reconcile(client *rest.Client, since time.Time) ([]Discrepancy, error) {
+	l.flush()
+	settlements, err := client.GetAllSettlements(rest.GetSettlementsParams{MinTS: since})
+	if err != nil {
+		return nil, fmt.Errorf("get settlements: %w", err)
+	}
+	var found []Discrepancy
+	for _, s := range settlements {
+		if l.tracks(s.Ticker) {
+			found = append(found, l.Settle(s)...)
+		}
+	}
+
+	positions, err := client.GetAllPositions(rest.GetPositionsParams{SettlementStatus: "unsettled"})
+	if err != nil {
+		return found, fmt.Errorf("get positions: %w", err)
+	}
+	return append(found, l.Check(positions)...), nil
+}
+
+// tracks reports whether any sub-ledger has a position in ticker.
+func (l *Ledger) tracks(ticker string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, b := range l.books {
+		b.mu.Lock()
+		_, yes := b.positions[ticker+"/yes"]
+		_, no := b.positions[ticker+"/no"]
+		b.mu.Unlock()
+		if yes || no {
+			return true
+		}
+	}
+	return false
+}
+
+// Discrepancies returns every discrepancy found by Settle.
+func (l *Ledger) Discrepancies() []Discrepancy {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Discrepancy(nil), l.found...)
+}
+
+// Strategies returns the strategies with a sub-ledger, sorted.
+func (l *Ledger) Strategies() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	names := make([]string, 0, len(l.books))
+	for name := range l.books {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Strategy returns a strategy's sub-ledger, or nil if it has none.
+func (l *Ledger) Strategy(strategy string) *Portfolio {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.books[strategy]
+}
+
+// Total returns the P&L of the whole account: the sum of the sub-ledgers.
+func (l *Ledger) Total() PnL {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var total PnL
+	for _, b := range l.books {
+		total.add(b.Total())
+	}
+	return total
+}
@@ -0,0 +1,123 @@
+package portfolio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+func orderFill(id, orderID, ticker string, side rest.Side, count, price int) rest.Fill {
+	f := fill(id, ticker, side, rest.OrderActionBuy, count, price, 0)
+	f.OrderID = orderID
+	return f
+}
+
+func TestOrderTag(t *testing.T) {
+	tag := OrderTag("dualside")
+	if got := TagStrategy(tag); got != "dualside" {
+		t.Errorf("TagStrategy(%q) = %q, want dualside", tag, got)
+	}
+	if tag == OrderTag("dualside") {
+		t.Error("OrderTag() returned the same ID twice")
+	}
+	for _, id := range []string{"", "retry-1", "dualside:short", ":3f9c2a7d41b0e8c6"} {
+		if got := TagStrategy(id); got != "" {
+			t.Errorf("TagStrategy(%q) = %q, want untagged", id, got)
+		}
+	}
+}
+
+func TestLedger_Attribution(t *testing.T) {
+	l := NewLedger(nil)
+	l.Assign("o1", "dualside")
+	l.AssignOrders([]rest.Order{
+		{OrderID: "o2", ClientOrderID: OrderTag("threshold")},
+		{OrderID: "o3", ClientOrderID: "manual"},
+	})
+
+	l.ApplyFills([]rest.Fill{
+		orderFill("t1", "o1", tickerB, rest.SideYes, 10, 40),
+		orderFill("t2", "o2", tickerB, rest.SideYes, 5, 44),
+		orderFill("t3", "o2", tickerT, rest.SideNo, 10, 30),
+		orderFill("t4", "o3", tickerT, rest.SideNo, 2, 31),
+		orderFill("t5", "o4", tickerB, rest.SideYes, 3, 45), // Assigned late
+	})
+	l.Assign("o4", "dualside")
+	l.flush() // o3 was never assigned
+	l.Mark(tickerB, 50)
+
+	if got, want := l.Strategies(), []string{"dualside", "threshold", Untagged}; !slices.Equal(got, want) {
+		t.Fatalf("Strategies() = %v, want %v", got, want)
+	}
+	// dualside: 10 @ 40 + 3 @ 45 marked at 50
+	if got := l.Strategy("dualside").Total(); got.Exposure != 535 || got.Unrealized != 13*50-535 {
+		t.Errorf("dualside = %+v, want 535 exposure, %d unrealized", got, 13*50-535)
+	}
+	if got := l.Strategy("threshold").Ticker(tickerB); got.Exposure != 220 || got.Unrealized != 30 {
+		t.Errorf("threshold B = %+v, want 220 exposure, 30 unrealized", got)
+	}
+	if got := l.Strategy(Untagged).Total(); got.Exposure != 62 {
+		t.Errorf("untagged = %+v, want 62 exposure", got)
+	}
+	if got := l.Total(); got.Exposure != 535+520+62 {
+		t.Errorf("Total().Exposure = %d, want %d", got.Exposure, 535+520+62)
+	}
+
+	// The account holds 2 more YES in B than the strategies know of
+	found := l.Check([]rest.Position{
+		{Ticker: tickerB, YesPosition: 20},
+		{Ticker: tickerT, NoPosition: 12},
+	})
+	if len(found) != 1 || found[0] != (Discrepancy{Ticker: tickerB, Side: rest.SideYes, Local: 18, Exchange: 20}) {
+		t.Errorf("Check() = %v, want B yes: 18 vs 20", found)
+	}
+}
+
+func TestLedger_Reconcile(t *testing.T) {
+	l := NewLedger(nil)
+	l.Assign("o1", "dualside")
+	l.Assign("o2", "threshold")
+	l.ApplyFill(orderFill("t1", "o1", tickerB, rest.SideYes, 10, 40))
+	l.ApplyFill(orderFill("t2", "o2", tickerB, rest.SideYes, 5, 44))
+	l.ApplyFill(orderFill("t3", "o2", tickerT, rest.SideNo, 10, 30))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/portfolio/settlements":
+			// B settled YES for the 15 contracts held between the two
+			fmt.Fprintf(w, `{"settlements":[
+				{"ticker":%q,"market_result":"yes","yes_count":15,"yes_total_cost":620,"revenue":1500},
+				{"ticker":"KXHIGHNY-25DEC05-B50.5","market_result":"no","no_count":5,"no_total_cost":100,"revenue":500}
+			]}`, tickerB)
+		case "/portfolio/positions":
+			fmt.Fprintf(w, `{"market_positions":[{"ticker":%q,"no_position":10}]}`, tickerT)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	found, err := l.Reconcile(rest.NewPublic(rest.WithBaseURL(server.URL)), start.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("Reconcile() discrepancies = %v, want none", found)
+	}
+
+	// Each strategy is paid for its own contracts
+	if got := l.Strategy("dualside").Ticker(tickerB); got.Realized != 600 || got.Exposure != 0 {
+		t.Errorf("dualside B = %+v, want 600 realized", got)
+	}
+	if got := l.Strategy("threshold").Ticker(tickerB); got.Realized != 280 {
+		t.Errorf("threshold B = %+v, want 280 realized", got)
+	}
+	if got := l.Strategy("threshold").Ticker(tickerT); got.Exposure != 300 {
+		t.Errorf("threshold T = %+v, want 300 still open", got)
+	}
+}
@@ -0,0 +1,437 @@
+// Package portfolio tracks the account's positions and P&L from its fills:
+// the contracts held and their cost basis per market side, P&L realized by
+// sells and settlements, and unrealized P&L at the latest marks.
+//
+// After markets close, Reconcile settles the positions against the
+// exchange's settlements, so a bot reports what it actually made rather than
+// an estimate. State can be saved and loaded to carry a session across
+// restarts.
+package portfolio
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// Position is the holding in one side of a market. Amounts are in cents.
+type Position struct {
+	Ticker      string
+	EventTicker string
+	Side        rest.Side
+	Count       int  // Contracts held
+	Cost        int  // Cost basis of the contracts held
+	Realized    int  // P&L realized by sells and settlement, net of fees
+	Fees        int  // Fees paid, included in Realized
+	Settled     bool // Settled against the exchange; Count and Cost are 0
+}
+
+// AvgPrice returns the average price paid for the contracts held in cents,
+// or 0 if none are held.
+func (p Position) AvgPrice() float64 {
+	if p.Count == 0 {
+		return 0
+	}
+	return float64(p.Cost) / float64(p.Count)
+}
+
+// Unrealized returns the P&L of the contracts held if they were sold at
+// mark cents (on the position's side).
+func (p Position) Unrealized(mark int) int {
+	return p.Count*mark - p.Cost
+}
+
+// PnL is a P&L summary in cents.
+type PnL struct {
+	Realized   int // Net of fees
+	Unrealized int // At the latest marks; 0 for markets without one
+	Fees       int
+	Exposure   int // Cost basis of the contracts held
+}
+
+// Total returns realized plus unrealized P&L.
+func (p PnL) Total() int {
+	return p.Realized + p.Unrealized
+}
+
+func (p *PnL) add(q PnL) {
+	p.Realized += q.Realized
+	p.Unrealized += q.Unrealized
+	p.Fees += q.Fees
+	p.Exposure += q.Exposure
+}
+
+// Discrepancy is a settled position whose size differed from the exchange's
+// count, usually because fills were missed. The exchange's count and cost
+// are used for the settlement.
+type Discrepancy struct {
+	Ticker   string
+	Side     rest.Side
+	Local    int // Contracts tracked locally
+	Exchange int // Contracts the exchange settled
+}
+
+func (d Discrepancy) String() string {
+	return fmt.Sprintf("%s %s: tracked %d, exchange settled %d", d.Ticker, d.Side, d.Local, d.Exchange)
+}
+
+// Portfolio is the set of positions built from a session's fills. It is safe
+// for concurrent use.
+type Portfolio struct {
+	mu            sync.Mutex
+	fees          *fees.Schedule
+	positions     map[string]*Position // Ticker/side -> position
+	trades        map[string]bool      // Trade IDs applied
+	marks         map[string]int       // Ticker -> YES mark in cents
+	discrepancies []Discrepancy
+}
+
+// New creates an empty portfolio. Fees are estimated from schedule, which
+// may be nil to track P&L before fees.
+func New(schedule *fees.Schedule) *Portfolio {
+	return &Portfolio{
+		fees:      schedule,
+		positions: make(map[string]*Position),
+		trades:    make(map[string]bool),
+		marks:     make(map[string]int),
+	}
+}
+
+// position returns the position in one side of ticker, creating it. Callers
+// hold p.mu.
+func (p *Portfolio) position(ticker string, side rest.Side) *Position {
+	key := ticker + "/" + string(side)
+	pos, ok := p.positions[key]
+	if !ok {
+		pos = &Position{Ticker: ticker, EventTicker: EventTicker(ticker), Side: side}
+		p.positions[key] = pos
+	}
+	return pos
+}
+
+// rule returns the fee rule for ticker, or a rule charging nothing.
+func (p *Portfolio) rule(ticker string, at time.Time) fees.Rule {
+	if p.fees == nil {
+		return fees.Rule{}
+	}
+	return p.fees.RuleForTicker(ticker, at)
+}
+
+// ApplyFill adds a fill to its position and reports whether it was new; a
+// fill whose trade ID was already applied is ignored. A buy adds to the
+// cost basis. A sell closes contracts at their average cost and realizes the
+// difference; selling more than is held closes only what is held.
+func (p *Portfolio) ApplyFill(f rest.Fill) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if f.Count <= 0 || (f.TradeID != "" && p.trades[f.TradeID]) {
+		return false
+	}
+	if f.TradeID != "" {
+		p.trades[f.TradeID] = true
+	}
+
+	pos := p.position(f.Ticker, f.Side)
+	price := f.Price()
+	liq := fees.Maker
+	if f.IsTaker {
+		liq = fees.Taker
+	}
+	fee := cents(p.rule(f.Ticker, f.CreatedTime).EntryFee(liq, float64(f.Count), price))
+	pos.Fees += fee
+	pos.Realized -= fee
+
+	if f.Action == rest.OrderActionSell {
+		n := min(f.Count, pos.Count)
+		if n == 0 {
+			return true
+		}
+		basis := pos.Cost * n / pos.Count
+		pos.Realized += n*price - basis
+		pos.Cost -= basis
+		pos.Count -= n
+		return true
+	}
+	pos.Count += f.Count
+	pos.Cost += f.Count * price
+	return true
+}
+
+// ApplyFills applies fills oldest first, so sells follow the buys they
+// close, and returns how many were new. List endpoints return fills newest
+// first.
+func (p *Portfolio) ApplyFills(fills []rest.Fill) int {
+	fills = append([]rest.Fill(nil), fills...)
+	sort.SliceStable(fills, func(i, j int) bool { return fills[i].CreatedTime.Before(fills[j].CreatedTime) })
+	n := 0
+	for _, f := range fills {
+		if p.ApplyFill(f) {
+			n++
+		}
+	}
+	return n
+}
+
+// ApplyFillMsg applies a fill received on the WebSocket fill channel.
+func (p *Portfolio) ApplyFillMsg(m ws.FillMsg) bool {
+	return p.ApplyFill(fillFromMsg(m))
+}
+
+// fillFromMsg converts a fill channel message to a REST fill.
+func fillFromMsg(m ws.FillMsg) rest.Fill {
+	return rest.Fill{
+		TradeID:     m.TradeID,
+		OrderID:     m.OrderID,
+		Ticker:      m.MarketTicker,
+		Side:        rest.Side(m.Side),
+		Action:      rest.OrderAction(m.Action),
+		Count:       m.Count,
+		YesPrice:    m.YesPrice,
+		NoPrice:     m.NoPrice,
+		IsTaker:     m.IsTaker,
+		CreatedTime: m.Time(),
+	}
+}
+
+// Mark sets the YES price (e.g. the bid or last price) at which a market's
+// positions are valued for unrealized P&L. NO positions are valued at 100
+// minus the mark.
+func (p *Portfolio) Mark(ticker string, yesPrice int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.marks[ticker] = yesPrice
+}
+
+// Settle closes a market's positions at its settlement. The payout is taken
+// from the market result; when the exchange's contract count differs from the
+// tracked one, its count and cost are used instead and the difference is
+// returned as a discrepancy. Settling a market twice has no effect.
+func (p *Portfolio) Settle(s rest.Settlement) []Discrepancy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var found []Discrepancy
+	for _, side := range []rest.Side{rest.SideYes, rest.SideNo} {
+		count, cost := s.YesCount, s.YesTotalCost
+		if side == rest.SideNo {
+			count, cost = s.NoCount, s.NoTotalCost
+		}
+		pos, ok := p.positions[s.Ticker+"/"+string(side)]
+		if ok && pos.Settled {
+			continue
+		}
+		if !ok && count == 0 {
+			continue
+		}
+		if !ok {
+			pos = p.position(s.Ticker, side)
+		}
+
+		if pos.Count != count {
+			d := Discrepancy{Ticker: s.Ticker, Side: side, Local: pos.Count, Exchange: count}
+			found = append(found, d)
+			p.discrepancies = append(p.discrepancies, d)
+			pos.Count, pos.Cost = count, cost
+		}
+
+		won := string(side) == s.MarketResult
+		payout := 0
+		if won {
+			payout = pos.Count * 100
+		}
+		fee := cents(p.rule(s.Ticker, s.SettledTime).SettlementFee(fees.Taker, float64(pos.Count), int(math.Round(pos.AvgPrice())), won))
+		pos.Fees += fee
+		pos.Realized += payout - pos.Cost - fee
+		pos.Count, pos.Cost = 0, 0
+		pos.Settled = true
+	}
+	return found
+}
+
+// Reconcile fetches the account's settlements since the given time and
+// settles the tracked positions they cover. It returns any discrepancies
+// found.
+func (p *Portfolio) Reconcile(client *rest.Client, since time.Time) ([]Discrepancy, error) {
+	settlements, err := client.GetAllSettlements(rest.GetSettlementsParams{MinTS: since})
+	if err != nil {
+		return nil, fmt.Errorf("get settlements: %w", err)
+	}
+
+	var found []Discrepancy
+	for _, s := range settlements {
+		p.mu.Lock()
+		_, yes := p.positions[s.Ticker+"/yes"]
+		_, no := p.positions[s.Ticker+"/no"]
+		p.mu.Unlock()
+		if yes || no {
+			found = append(found, p.Settle(s)...)
+		}
+	}
+	return found, nil
+}
+
+// open returns the contracts held in one side of ticker and their cost, or
+// zeros once settled.
+func (p *Portfolio) open(ticker string, side rest.Side) (count, cost int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pos, ok := p.positions[ticker+"/"+string(side)]; ok && !pos.Settled {
+		return pos.Count, pos.Cost
+	}
+	return 0, 0
+}
+
+// Discrepancies returns every discrepancy found by Settle.
+func (p *Portfolio) Discrepancies() []Discrepancy {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Discrepancy(nil), p.discrepancies...)
+}
+
+// Positions returns every position, including closed and settled ones,
+// ordered by ticker and side.
+func (p *Portfolio) Positions() []Position {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	positions := make([]Position, 0, len(p.positions))
+	for _, pos := range p.positions {
+		positions = append(positions, *pos)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Ticker != positions[j].Ticker {
+			return positions[i].Ticker < positions[j].Ticker
+		}
+		return positions[i].Side > positions[j].Side // YES first
+	})
+	return positions
+}
+
+// pnl returns a position's P&L at its market's mark. Callers hold p.mu.
+func (p *Portfolio) pnl(pos *Position) PnL {
+	r := PnL{Realized: pos.Realized, Fees: pos.Fees, Exposure: pos.Cost}
+	if mark, ok := p.marks[pos.Ticker]; ok && pos.Count > 0 {
+		if pos.Side == rest.SideNo {
+			mark = 100 - mark
+		}
+		r.Unrealized = pos.Unrealized(mark)
+	}
+	return r
+}
+
+// sum returns the P&L of the positions matching keep.
+func (p *Portfolio) sum(keep func(*Position) bool) PnL {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var total PnL
+	for _, pos := range p.positions {
+		if keep(pos) {
+			total.add(p.pnl(pos))
+		}
+	}
+	return total
+}
+
+// Ticker returns the P&L of both sides of a market.
+func (p *Portfolio) Ticker(ticker string) PnL {
+	return p.sum(func(pos *Position) bool { return pos.Ticker == ticker })
+}
+
+// Event returns the P&L of an event's markets.
+func (p *Portfolio) Event(eventTicker string) PnL {
+	return p.sum(func(pos *Position) bool { return pos.EventTicker == eventTicker })
+}
+
+// Total returns the P&L of every position.
+func (p *Portfolio) Total() PnL {
+	return p.sum(func(*Position) bool { return true })
+}
+
+// EventTicker returns the event of a market ticker, e.g.
+// "KXHIGHLAX-25DEC05" for "KXHIGHLAX-25DEC05-B62.5".
+func EventTicker(ticker string) string {
+	if i := strings.LastIndex(ticker, "-"); i > 0 {
+		return ticker[:i]
+	}
+	return ticker
+}
+
+func cents(dollars float64) int {
+	return int(math.Round(dollars * 100))
+}
+
+// state is the on-disk form of a Portfolio.
+type state struct {
+	Positions     []Position
+	Trades        []string
+	Marks         map[string]int
+	Discrepancies []Discrepancy
+}
+
+// Save writes the portfolio to path as JSON.
+func (p *Portfolio) Save(path string) error {
+	p.mu.Lock()
+	st := state{Marks: p.marks, Discrepancies: p.discrepancies}
+	for _, pos := range p.positions {
+		st.Positions = append(st.Positions, *pos)
+	}
+	for id := range p.trades {
+		st.Trades = append(st.Trades, id)
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	p.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("marshal portfolio: %w", err)
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create portfolio directory: %w", err)
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write portfolio: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write portfolio: %w", err)
+	}
+	return nil
+}
+
+// Load reads a portfolio saved by Save. Fees are estimated from schedule.
+func Load(path string, schedule *fees.Schedule) (*Portfolio, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read portfolio: %w", err)
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("parse portfolio %s: %w", path, err)
+	}
+
+	p := New(schedule)
+	for _, pos := range st.Positions {
+		*p.position(pos.Ticker, pos.Side) = pos
+	}
+	for _, id := range st.Trades {
+		p.trades[id] = true
+	}
+	for ticker, mark := range st.Marks {
+		p.marks[ticker] = mark
+	}
+	p.discrepancies = st.Discrepancies
+	return p, nil
+}
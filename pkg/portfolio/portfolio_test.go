@@ -0,0 +1,140 @@
+package portfolio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+const (
+	tickerB = "KXHIGHLAX-25DEC05-B62.5"
+	tickerT = "KXHIGHLAX-25DEC05-T64"
+)
+
+var start = time.Date(2025, 12, 5, 16, 0, 0, 0, time.UTC)
+
+func fill(id, ticker string, side rest.Side, action rest.OrderAction, count, price int, at time.Duration) rest.Fill {
+	f := rest.Fill{TradeID: id, Ticker: ticker, Side: side, Action: action, Count: count, IsTaker: true, CreatedTime: start.Add(at)}
+	if side == rest.SideNo {
+		f.NoPrice, f.YesPrice = price, 100-price
+	} else {
+		f.YesPrice, f.NoPrice = price, 100-price
+	}
+	return f
+}
+
+func TestPortfolio_BuySellAndMarks(t *testing.T) {
+	p := New(nil)
+
+	// Newest first, as the fills endpoint returns them
+	n := p.ApplyFills([]rest.Fill{
+		fill("t3", tickerB, rest.SideYes, rest.OrderActionSell, 5, 50, 3*time.Minute),
+		fill("t2", tickerB, rest.SideYes, rest.OrderActionBuy, 10, 44, 2*time.Minute),
+		fill("t1", tickerB, rest.SideYes, rest.OrderActionBuy, 10, 40, time.Minute),
+	})
+	if n != 3 {
+		t.Fatalf("ApplyFills() = %d, want 3", n)
+	}
+	if p.ApplyFill(fill("t1", tickerB, rest.SideYes, rest.OrderActionBuy, 10, 40, time.Minute)) {
+		t.Error("ApplyFill(duplicate trade) = true, want false")
+	}
+
+	pos := p.Positions()[0]
+	// 20 @ 42¢ average; selling 5 @ 50¢ realizes 5 * 8¢
+	if pos.Count != 15 || pos.Cost != 630 || pos.Realized != 40 || pos.AvgPrice() != 42 {
+		t.Errorf("position = %+v, want 15 held at 630¢, 40¢ realized", pos)
+	}
+
+	p.ApplyFillMsg(ws.FillMsg{TradeID: "t4", MarketTicker: tickerT, Side: "no", Action: "buy", YesPrice: 70, NoPrice: 30, Count: 10, IsTaker: true, TS: start.Unix()})
+	p.Mark(tickerB, 45)
+	p.Mark(tickerT, 75)
+
+	if got := p.Ticker(tickerB); got.Unrealized != 15*45-630 || got.Exposure != 630 {
+		t.Errorf("Ticker(B) = %+v, want unrealized %d on 630 exposure", got, 15*45-630)
+	}
+	// NO is valued at 100 minus the YES mark: 10 * (25 - 30)
+	if got := p.Ticker(tickerT); got.Unrealized != -50 {
+		t.Errorf("Ticker(T).Unrealized = %d, want -50", got.Unrealized)
+	}
+	total := p.Event("KXHIGHLAX-25DEC05")
+	if total != p.Total() || total.Realized != 40 || total.Total() != 40+45-50 {
+		t.Errorf("Event() = %+v, Total() = %+v, want both 40 realized, 35 total", total, p.Total())
+	}
+}
+
+func TestPortfolio_SettleAndReconcile(t *testing.T) {
+	p := New(fees.DefaultSchedule())
+	p.ApplyFill(fill("t1", tickerB, rest.SideYes, rest.OrderActionBuy, 10, 40, 0))
+	p.ApplyFill(fill("t2", tickerT, rest.SideNo, rest.OrderActionBuy, 10, 30, 0))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/portfolio/settlements" {
+			http.NotFound(w, r)
+			return
+		}
+		// B settled YES as tracked; the exchange settled 12 NO in T, 2 more
+		// than the session saw
+		fmt.Fprintf(w, `{"settlements":[
+			{"ticker":%q,"market_result":"yes","yes_count":10,"yes_total_cost":400,"revenue":1000},
+			{"ticker":%q,"market_result":"yes","no_count":12,"no_total_cost":360,"revenue":0},
+			{"ticker":"KXHIGHNY-25DEC05-B50.5","market_result":"no","no_count":5,"no_total_cost":100,"revenue":500}
+		]}`, tickerB, tickerT)
+	}))
+	defer server.Close()
+
+	found, err := p.Reconcile(rest.NewPublic(rest.WithBaseURL(server.URL)), start)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(found) != 1 || found[0] != (Discrepancy{Ticker: tickerT, Side: rest.SideNo, Local: 10, Exchange: 12}) {
+		t.Errorf("Reconcile() discrepancies = %v, want T no: 10 vs 12", found)
+	}
+
+	// Winning 10 @ 40¢ pays $6 less 7% = 42¢ in fees; losing 12 NO costs 360¢
+	if got := p.Ticker(tickerB); got.Realized != 600-42 || got.Fees != 42 {
+		t.Errorf("Ticker(B) = %+v, want 558 realized, 42 fees", got)
+	}
+	if got := p.Ticker(tickerT); got.Realized != -360 || got.Exposure != 0 {
+		t.Errorf("Ticker(T) = %+v, want -360 realized, no exposure", got)
+	}
+	if positions := p.Positions(); len(positions) != 2 {
+		t.Errorf("Positions() = %d, want 2 (untracked markets are not settled)", len(positions))
+	}
+
+	// Settling again changes nothing
+	before := p.Total()
+	if _, err := p.Reconcile(rest.NewPublic(rest.WithBaseURL(server.URL)), start); err != nil {
+		t.Fatalf("Reconcile() again error = %v", err)
+	}
+	if p.Total() != before {
+		t.Errorf("Total() after a second Reconcile = %+v, want %+v", p.Total(), before)
+	}
+}
+
+func TestPortfolio_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session", "portfolio.json")
+	p := New(nil)
+	p.ApplyFill(fill("t1", tickerB, rest.SideYes, rest.OrderActionBuy, 10, 40, 0))
+	p.Mark(tickerB, 50)
+	if err := p.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path, nil)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Total() != p.Total() || loaded.Total().Unrealized != 100 {
+		t.Errorf("loaded Total() = %+v, want %+v", loaded.Total(), p.Total())
+	}
+	if loaded.ApplyFill(fill("t1", tickerB, rest.SideYes, rest.OrderActionBuy, 10, 40, 0)) {
+		t.Error("loaded ApplyFill(applied trade) = true, want false")
+	}
+}
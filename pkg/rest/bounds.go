@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOrderBounds is returned, wrapped with the bound exceeded, for an order
+// the client refuses to send.
+var ErrOrderBounds = errors.New("order outside client safety bounds")
+
+// OrderBounds are hard limits on every order the client sends, checked before
+// the request leaves the process. They are a last line of defense against a
+// sizing or pricing bug sending a catastrophic order, not a risk policy: set
+// them well above anything a strategy places on purpose. A zero bound is not
+// enforced.
+type OrderBounds struct {
+	MaxContracts int // Contracts per order or amendment
+	MaxPrice     int // Buy limit price in cents on the order's side
+	MaxNotional  int // Cents a buy can cost: contracts × limit price
+}
+
+// DefaultOrderBounds returns the bounds every client starts with: 2,000
+// contracts, 97¢ and $1,000 per order.
+func DefaultOrderBounds() OrderBounds {
+	return OrderBounds{
+		MaxContracts: 2000,
+		MaxPrice:     97,
+		MaxNotional:  100000,
+	}
+}
+
+// WithOrderBounds replaces the default order bounds. Pass OrderBounds{} to
+// disable them.
+func WithOrderBounds(bounds OrderBounds) Option {
+	return func(c *Client) {
+		c.bounds = bounds
+	}
+}
+
+// check returns an error wrapping ErrOrderBounds if an order of count
+// contracts would exceed the bounds. Sells are only bounded in count: they
+// reduce positions. A buy without a price (a market order) is costed at
+// maxCost if set, else at 99¢ a contract.
+func (b OrderBounds) check(action OrderAction, count, price, maxCost int) error {
+	if b.MaxContracts > 0 && count > b.MaxContracts {
+		return fmt.Errorf("%w: %d contracts, max %d", ErrOrderBounds, count, b.MaxContracts)
+	}
+	if action == OrderActionSell {
+		return nil
+	}
+	if b.MaxPrice > 0 && price > b.MaxPrice {
+		return fmt.Errorf("%w: price %d¢, max %d¢", ErrOrderBounds, price, b.MaxPrice)
+	}
+	if b.MaxNotional <= 0 {
+		return nil
+	}
+	cost := count * price
+	if price == 0 {
+		cost = count * 99
+		if maxCost > 0 {
+			cost = maxCost
+		}
+	}
+	if cost > b.MaxNotional {
+		return fmt.Errorf("%w: costs up to $%.2f, max $%.2f", ErrOrderBounds, float64(cost)/100, float64(b.MaxNotional)/100)
+	}
+	return nil
+}
+
+// sidePrice returns an order's limit price on the side it trades, which may
+// be given as the complement on the other side.
+func sidePrice(side Side, yesPrice, noPrice int) int {
+	own, other := yesPrice, noPrice
+	if side == SideNo {
+		own, other = noPrice, yesPrice
+	}
+	if own == 0 && other > 0 {
+		return 100 - other
+	}
+	return own
+}
@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestOrderBounds_Check(t *testing.T) {
+	b := DefaultOrderBounds()
+	tests := []struct {
+		name    string
+		action  OrderAction
+		count   int
+		price   int
+		maxCost int
+		ok      bool
+	}{
+		{"typical buy", OrderActionBuy, 500, 95, 0, true},
+		{"too many contracts", OrderActionBuy, 2001, 10, 0, false},
+		{"price too high", OrderActionBuy, 10, 98, 0, false},
+		{"notional too high", OrderActionBuy, 1100, 95, 0, false},
+		{"market buy costed at 99¢", OrderActionBuy, 1100, 0, 0, false},
+		{"market buy with max cost", OrderActionBuy, 1100, 0, 50000, true},
+		{"sell at any price", OrderActionSell, 2000, 99, 0, true},
+		{"sell too many", OrderActionSell, 5000, 50, 0, false},
+	}
+	for _, tt := range tests {
+		err := b.check(tt.action, tt.count, tt.price, tt.maxCost)
+		if tt.ok && err != nil {
+			t.Errorf("%s: check() error = %v, want nil", tt.name, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrOrderBounds) {
+			t.Errorf("%s: check() error = %v, want ErrOrderBounds", tt.name, err)
+		}
+	}
+
+	if err := (OrderBounds{}).check(OrderActionBuy, 1_000_000, 99, 0); err != nil {
+		t.Errorf("zero bounds: check() error = %v, want nil", err)
+	}
+}
+
+func TestCreateOrder_RefusesOutOfBounds(t *testing.T) {
+	client, _, last := newTestClient(t, `{"order":{"order_id":"o1"}}`)
+
+	// A NO buy priced as YES 1¢ is a 99¢ NO buy
+	_, err := client.CreateOrder(&CreateOrderRequest{
+		Ticker: "KXHIGHLAX-25DEC05-B62.5", Action: OrderActionBuy, Side: SideNo,
+		Type: OrderTypeLimit, Count: 10, YesPrice: 1,
+	})
+	if !errors.Is(err, ErrOrderBounds) {
+		t.Fatalf("CreateOrder() error = %v, want ErrOrderBounds", err)
+	}
+	if _, err := client.AmendOrder("o1", &AmendOrderRequest{Action: OrderActionBuy, Side: SideYes, Count: 5000, YesPrice: 40}); !errors.Is(err, ErrOrderBounds) {
+		t.Fatalf("AmendOrder() error = %v, want ErrOrderBounds", err)
+	}
+	if *last != nil {
+		t.Fatalf("refused order reached the server: %s %s", (*last).Method, (*last).URL.Path)
+	}
+
+	if _, err := client.BuyYes("KXHIGHLAX-25DEC05-B62.5", 10, 45); err != nil {
+		t.Fatalf("BuyYes() error = %v", err)
+	}
+	if *last == nil || (*last).Method != http.MethodPost {
+		t.Errorf("order within bounds was not sent")
+	}
+}
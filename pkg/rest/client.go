@@ -1,14 +1,15 @@
-// Package rest provides a REST API client for the Kalshi trading platform.
 package rest
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/brendanplayford/kalshi-go/pkg/ws"
@@ -29,6 +30,9 @@ type Client struct {
 	privateKey *rsa.PrivateKey
 	httpClient *http.Client
 	debug      bool
+	limiter    *rateLimiter    // Set by WithRateLimit; nil means unthrottled
+	bounds     OrderBounds     // Checked before every order is sent
+	ctx        context.Context // Set by withContext; nil means no deadline
 }
 
 // Option configures the client.
@@ -69,6 +73,7 @@ func New(apiKey string, privateKey *rsa.PrivateKey, opts ...Option) *Client {
 		apiKey:     apiKey,
 		privateKey: privateKey,
 		httpClient: &http.Client{Timeout: 30 * time.Second},
+		bounds:     DefaultOrderBounds(),
 	}
 
 	for _, opt := range opts {
@@ -78,78 +83,137 @@ func New(apiKey string, privateKey *rsa.PrivateKey, opts ...Option) *Client {
 	return c
 }
 
-// request makes an authenticated API request.
+// NewPublic creates a client for the public market data endpoints (exchange
+// status, series, events, markets, trades and order books), which need no
+// credentials. Portfolio endpoints return an authentication error.
+func NewPublic(opts ...Option) *Client {
+	return New("", nil, opts...)
+}
+
+// withContext returns a copy of the client whose requests are bound to ctx.
+func (c *Client) withContext(ctx context.Context) *Client {
+	bound := *c
+	bound.ctx = ctx
+	return &bound
+}
+
+// request makes an authenticated API request, waiting for rate limit budget
+// and retrying 429 responses if the client is rate limited.
 func (c *Client) request(method, path string, body any) ([]byte, error) {
-	var reqBody io.Reader
+	var data []byte
 	if body != nil {
-		data, err := json.Marshal(body)
+		var err error
+		data, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshal request body: %w", err)
 		}
+	}
+
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.wait(ctx, method); err != nil {
+			return nil, err
+		}
+
+		resp, respBody, err := c.send(ctx, method, path, data)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests && attempt < c.limiter.retries() {
+			delay := retryDelay(resp.Header.Get("Retry-After"), attempt)
+			if c.debug {
+				fmt.Printf("[DEBUG] Rate limited, retrying in %s\n", delay)
+			}
+			if err := sleep(ctx, delay); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			var errResp ErrorResponse
+			if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error.Message != "" {
+				return nil, &APIError{
+					StatusCode: resp.StatusCode,
+					Code:       errResp.Error.Code,
+					Message:    errResp.Error.Message,
+				}
+			}
+			return nil, &APIError{
+				StatusCode: resp.StatusCode,
+				Message:    string(respBody),
+			}
+		}
+
+		return respBody, nil
+	}
+}
+
+// send signs and executes one request, returning the response and its body.
+func (c *Client) send(ctx context.Context, method, path string, data []byte) (*http.Response, []byte, error) {
+	var reqBody io.Reader
+	if data != nil {
 		reqBody = bytes.NewReader(data)
 	}
 
 	url := c.baseURL + path
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, nil, fmt.Errorf("create request: %w", err)
 	}
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	// Add authentication headers
-	// The signature must include the full path: /trade-api/v2/...
-	fullPath := "/trade-api/v2" + path
-	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
-	signature, err := ws.GenerateSignature(c.privateKey, timestamp, method, fullPath)
-	if err != nil {
-		return nil, fmt.Errorf("generate signature: %w", err)
-	}
+	// Add authentication headers; public clients send unsigned requests
+	// The signature must include the full path (/trade-api/v2/...) without
+	// the query string
+	if c.privateKey != nil {
+		signPath, _, _ := strings.Cut(path, "?")
+		fullPath := "/trade-api/v2" + signPath
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		signature, err := ws.GenerateSignature(c.privateKey, timestamp, method, fullPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("generate signature: %w", err)
+		}
 
-	req.Header.Set("KALSHI-ACCESS-KEY", c.apiKey)
-	req.Header.Set("KALSHI-ACCESS-TIMESTAMP", timestamp)
-	req.Header.Set("KALSHI-ACCESS-SIGNATURE", signature)
+		req.Header.Set("KALSHI-ACCESS-KEY", c.apiKey)
+		req.Header.Set("KALSHI-ACCESS-TIMESTAMP", timestamp)
+		req.Header.Set("KALSHI-ACCESS-SIGNATURE", signature)
+
+		if c.debug {
+			fmt.Printf("[DEBUG] Sign path: %s\n", fullPath)
+			fmt.Printf("[DEBUG] Headers: KEY=%s, TS=%s\n", c.apiKey, timestamp)
+		}
+	}
 
 	if c.debug {
 		fmt.Printf("[DEBUG] %s %s\n", method, url)
-		fmt.Printf("[DEBUG] Sign path: %s\n", fullPath)
-		fmt.Printf("[DEBUG] Headers: KEY=%s, TS=%s\n", c.apiKey, timestamp)
 	}
 
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
+		return nil, nil, fmt.Errorf("execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return nil, nil, fmt.Errorf("read response: %w", err)
 	}
 
 	if c.debug {
 		fmt.Printf("[DEBUG] Response: %d %s\n", resp.StatusCode, string(respBody))
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error.Message != "" {
-			return nil, &APIError{
-				StatusCode: resp.StatusCode,
-				Code:       errResp.Error.Code,
-				Message:    errResp.Error.Message,
-			}
-		}
-		return nil, &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    string(respBody),
-		}
-	}
-
-	return respBody, nil
+	return resp, respBody, nil
 }
 
 // Get makes a GET request.
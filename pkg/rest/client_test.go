@@ -0,0 +1,144 @@
+package rest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client pointed at a test server that serves body
+// and records the last request.
+func newTestClient(t *testing.T, body string) (*Client, *rsa.PrivateKey, **http.Request) {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+
+	var last *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	client := New("test-key", privateKey, WithBaseURL(server.URL+"/trade-api/v2"))
+	return client, privateKey, &last
+}
+
+func TestRequest_SignsPathWithoutQuery(t *testing.T) {
+	client, privateKey, last := newTestClient(t, `{"events":[]}`)
+
+	if _, err := client.GetEvents(GetEventsParams{SeriesTicker: "KXHIGHLAX", Limit: 5}); err != nil {
+		t.Fatalf("GetEvents() error = %v", err)
+	}
+
+	req := *last
+	if got := req.URL.Query().Get("series_ticker"); got != "KXHIGHLAX" {
+		t.Errorf("series_ticker = %q, want KXHIGHLAX", got)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(req.Header.Get("KALSHI-ACCESS-SIGNATURE"))
+	if err != nil {
+		t.Fatalf("decode signature: %v", err)
+	}
+	message := req.Header.Get("KALSHI-ACCESS-TIMESTAMP") + "GET" + "/trade-api/v2/events"
+	hashed := sha256.Sum256([]byte(message))
+	if err := rsa.VerifyPSS(&privateKey.PublicKey, crypto.SHA256, hashed[:], sig, nil); err != nil {
+		t.Errorf("signature does not cover path without query: %v", err)
+	}
+}
+
+func TestGetExchangeSchedule(t *testing.T) {
+	client, _, last := newTestClient(t, `{"schedule":{
+		"standard_hours":[{"start_time":"2025-01-01T00:00:00Z","end_time":"2026-01-01T00:00:00Z",
+			"monday":[{"open_time":"00:00","close_time":"23:59"}]}],
+		"maintenance_windows":[{"start_datetime":"2025-12-28T08:00:00Z","end_datetime":"2025-12-28T10:00:00Z"}]}}`)
+
+	schedule, err := client.GetExchangeSchedule()
+	if err != nil {
+		t.Fatalf("GetExchangeSchedule() error = %v", err)
+	}
+	if (*last).URL.Path != "/trade-api/v2/exchange/schedule" {
+		t.Errorf("path = %s, want /trade-api/v2/exchange/schedule", (*last).URL.Path)
+	}
+	if len(schedule.StandardHours) != 1 || len(schedule.StandardHours[0].Monday) != 1 {
+		t.Fatalf("StandardHours = %+v, want one week with a Monday session", schedule.StandardHours)
+	}
+	if got := schedule.MaintenanceWindows[0].StartDatetime; got != "2025-12-28T08:00:00Z" {
+		t.Errorf("MaintenanceWindows[0].StartDatetime = %s, want 2025-12-28T08:00:00Z", got)
+	}
+}
+
+func TestGetSeries(t *testing.T) {
+	client, _, _ := newTestClient(t, `{"series":{"ticker":"KXHIGHLAX","frequency":"daily",
+		"settlement_sources":[{"name":"NWS","url":"https://www.weather.gov/"}],"fee_multiplier":1}}`)
+
+	series, err := client.GetSeries("KXHIGHLAX")
+	if err != nil {
+		t.Fatalf("GetSeries() error = %v", err)
+	}
+	if series.Frequency != "daily" || len(series.SettlementSources) != 1 {
+		t.Errorf("GetSeries() = %+v, want daily with one settlement source", series)
+	}
+}
+
+func TestGetMilestones(t *testing.T) {
+	client, _, last := newTestClient(t, `{"milestones":[{"id":"m1","primary_event_tickers":["E1"]}],"cursor":"next"}`)
+
+	resp, err := client.GetMilestones(GetMilestonesParams{
+		Category:         "sports",
+		MinimumStartDate: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("GetMilestones() error = %v", err)
+	}
+	if got := (*last).URL.Query().Get("minimum_start_date"); got != "2025-12-01T00:00:00Z" {
+		t.Errorf("minimum_start_date = %q, want 2025-12-01T00:00:00Z", got)
+	}
+	if len(resp.Milestones) != 1 || resp.Cursor != "next" {
+		t.Errorf("GetMilestones() = %+v, want one milestone and cursor", resp)
+	}
+}
+
+func TestGetTrades(t *testing.T) {
+	client, _, last := newTestClient(t, `{"trades":[{"trade_id":"t1","ticker":"X","count":3,"yes_price":55,
+		"taker_side":"yes","created_time":"2025-12-05T15:04:05Z"}],"cursor":""}`)
+
+	resp, err := client.GetTrades(GetTradesParams{Ticker: "X", Limit: 1000})
+	if err != nil {
+		t.Fatalf("GetTrades() error = %v", err)
+	}
+	if got := (*last).URL.Query().Get("limit"); got != "1000" {
+		t.Errorf("limit = %q, want 1000", got)
+	}
+	if len(resp.Trades) != 1 || resp.Trades[0].YesPrice != 55 || resp.Trades[0].CreatedTime.Hour() != 15 {
+		t.Errorf("GetTrades() = %+v, want one trade at 55¢", resp.Trades)
+	}
+}
+
+func TestGetOrderbook(t *testing.T) {
+	client, _, _ := newTestClient(t, `{"orderbook":{"yes":[[40,10],[42,5]],"no":[[55,7]]}}`)
+
+	book, err := client.GetOrderbook("X", 0)
+	if err != nil {
+		t.Fatalf("GetOrderbook() error = %v", err)
+	}
+	if len(book.Yes) != 2 || book.Yes[1] != [2]int{42, 5} || book.No[0][0] != 55 {
+		t.Errorf("GetOrderbook() = %+v", book)
+	}
+	if got := book.Depth("yes", 1); got != 5 {
+		t.Errorf("Depth(yes, 1) = %d, want the best level's 5", got)
+	}
+	if got, got2 := book.Depth("yes", 0), book.Depth("no", 3); got != 15 || got2 != 7 {
+		t.Errorf("Depth(yes, 0), Depth(no, 3) = %d, %d, want 15, 7", got, got2)
+	}
+}
@@ -0,0 +1,19 @@
+// Package rest provides a REST API client for the Kalshi trading platform.
+//
+// A Client signs requests with an API key and RSA private key (New), or
+// calls only the public endpoints (NewPublic). Methods are named after the
+// endpoints they call: GetMarket, GetEvent, CreateOrder, CancelOrder. Paged
+// endpoints come in three forms: one page (ListMarkets, GetTrades), every
+// page (GetAllMarkets) and an iterator over every page (IterMarkets).
+//
+// Prices are in cents. Orders are checked against OrderBounds and conformed
+// to the market's PriceGrid before they are sent, and requests are throttled
+// to the exchange's rate limits (WithRateLimit).
+//
+// # Stability
+//
+// rest is part of the module's public API and follows semantic versioning:
+// exported identifiers keep their meaning within a major version, and
+// renamed ones stay as deprecated wrappers until the next major release.
+// See CHANGELOG.md.
+package rest
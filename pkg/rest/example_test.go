@@ -0,0 +1,26 @@
+package rest_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+// Read a market's quotes from the public endpoints. The test server stands
+// in for the exchange; drop WithBaseURL to call Kalshi.
+func Example() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"market":{"ticker":"KXHIGHLAX-25DEC27-B62.5","status":"active","yes_bid":41,"yes_ask":43}}`)
+	}))
+	defer srv.Close()
+
+	client := rest.NewPublic(rest.WithBaseURL(srv.URL))
+	m, err := client.GetMarket("KXHIGHLAX-25DEC27-B62.5")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%s %s: %d¢ / %d¢\n", m.Ticker, m.Status, m.YesBid, m.YesAsk)
+	// Output: KXHIGHLAX-25DEC27-B62.5 active: 41¢ / 43¢
+}
@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ExchangeStatus represents whether the exchange is open for trading.
+type ExchangeStatus struct {
+	ExchangeActive              bool   `json:"exchange_active"`
+	TradingActive               bool   `json:"trading_active"`
+	ExchangeEstimatedResumeTime string `json:"exchange_estimated_resume_time,omitempty"`
+}
+
+// TradingSession is one open/close window within a day (times are ET, "HH:MM").
+type TradingSession struct {
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+}
+
+// WeeklySchedule represents the standard trading hours in effect between
+// StartTime and EndTime.
+type WeeklySchedule struct {
+	StartTime string           `json:"start_time"`
+	EndTime   string           `json:"end_time"`
+	Monday    []TradingSession `json:"monday"`
+	Tuesday   []TradingSession `json:"tuesday"`
+	Wednesday []TradingSession `json:"wednesday"`
+	Thursday  []TradingSession `json:"thursday"`
+	Friday    []TradingSession `json:"friday"`
+	Saturday  []TradingSession `json:"saturday"`
+	Sunday    []TradingSession `json:"sunday"`
+}
+
+// MaintenanceWindow represents a scheduled exchange maintenance period.
+type MaintenanceWindow struct {
+	StartDatetime string `json:"start_datetime"`
+	EndDatetime   string `json:"end_datetime"`
+}
+
+// ExchangeSchedule represents the exchange's trading hours and maintenance.
+type ExchangeSchedule struct {
+	StandardHours      []WeeklySchedule    `json:"standard_hours"`
+	MaintenanceWindows []MaintenanceWindow `json:"maintenance_windows"`
+}
+
+// Announcement represents an exchange-wide announcement.
+type Announcement struct {
+	Type         string `json:"type"`
+	Message      string `json:"message"`
+	DeliveryTime string `json:"delivery_time"`
+	Status       string `json:"status"`
+}
+
+// GetExchangeStatus retrieves the current exchange status.
+func (c *Client) GetExchangeStatus() (*ExchangeStatus, error) {
+	data, err := c.Get("/exchange/status")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ExchangeStatus
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetExchangeSchedule retrieves the exchange trading schedule.
+func (c *Client) GetExchangeSchedule() (*ExchangeSchedule, error) {
+	data, err := c.Get("/exchange/schedule")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Schedule ExchangeSchedule `json:"schedule"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp.Schedule, nil
+}
+
+// GetAnnouncements retrieves exchange-wide announcements.
+func (c *Client) GetAnnouncements() ([]Announcement, error) {
+	data, err := c.Get("/exchange/announcements")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Announcements []Announcement `json:"announcements"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return resp.Announcements, nil
+}
@@ -3,6 +3,10 @@ package rest
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Market represents a Kalshi market.
@@ -28,6 +32,8 @@ type Market struct {
 	Liquidity          int     `json:"liquidity"`
 	OpenInterest       int     `json:"open_interest"`
 	Result             string  `json:"result"`
+	ExpirationValue    string  `json:"expiration_value"` // Settlement value, e.g. the observed high
+	StrikeType         string  `json:"strike_type"`      // "between", "greater" or "less"
 	CapStrike          float64 `json:"cap_strike"`
 	FloorStrike        float64 `json:"floor_strike"`
 	ExpectedExpiryTime string  `json:"expected_expiration_time"`
@@ -37,6 +43,38 @@ type Market struct {
 	CloseTime          string  `json:"close_time"`
 	OpenTime           string  `json:"open_time"`
 	Category           string  `json:"category"`
+
+	// Price structure; see PriceGrid
+	TickSize    int          `json:"tick_size"`
+	PriceRanges []PriceRange `json:"price_ranges,omitempty"`
+}
+
+// Market statuses. A market stops trading once its outcome is determined,
+// which can happen before its scheduled close (early expiration); it is
+// finalized once settled.
+const (
+	MarketStatusUnopened   = "unopened"
+	MarketStatusActive     = "active"
+	MarketStatusClosed     = "closed"
+	MarketStatusDetermined = "determined"
+	MarketStatusFinalized  = "finalized"
+	MarketStatusSettled    = "settled"
+)
+
+// StatusDetermined reports whether a market status means the outcome is
+// known and the market no longer trades.
+func StatusDetermined(status string) bool {
+	switch status {
+	case MarketStatusDetermined, MarketStatusFinalized, MarketStatusSettled:
+		return true
+	}
+	return false
+}
+
+// Determined reports whether the market's outcome is known, so orders on it,
+// including exits, can no longer fill.
+func (m *Market) Determined() bool {
+	return StatusDetermined(m.Status) || m.Result != ""
 }
 
 // Event represents a Kalshi event (contains multiple markets).
@@ -77,10 +115,11 @@ type Position struct {
 	Fees               int    `json:"fees"`
 }
 
-// GetPositionsResponse represents a response from getting positions.
+// GetPositionsResponse represents a page of positions.
 type GetPositionsResponse struct {
-	Positions []Position `json:"market_positions"`
-	Cursor    string     `json:"cursor"`
+	Positions      []Position      `json:"market_positions"`
+	EventPositions []EventPosition `json:"event_positions"`
+	Cursor         string          `json:"cursor"`
 }
 
 // Balance represents account balance.
@@ -107,11 +146,126 @@ func (c *Client) GetMarket(ticker string) (*Market, error) {
 	return &resp.Market, nil
 }
 
-// GetMarkets retrieves markets for an event.
+// GetMarketsParams filters a ListMarkets request. Zero values are omitted.
+type GetMarketsParams struct {
+	EventTicker  string
+	SeriesTicker string
+	Status       string   // "unopened", "open", "closed" or "settled"
+	Tickers      []string // Specific markets
+	MinCloseTS   time.Time
+	MaxCloseTS   time.Time
+	Limit        int
+	Cursor       string
+}
+
+// GetMarkets retrieves all markets for an event.
 func (c *Client) GetMarkets(eventTicker string) ([]Market, error) {
-	path := "/markets"
-	if eventTicker != "" {
-		path += "?event_ticker=" + eventTicker
+	return c.GetAllMarkets(GetMarketsParams{EventTicker: eventTicker})
+}
+
+// ListMarkets retrieves a page of markets.
+func (c *Client) ListMarkets(params GetMarketsParams) (*GetMarketsResponse, error) {
+	q := url.Values{}
+	setQuery(q, "event_ticker", params.EventTicker)
+	setQuery(q, "series_ticker", params.SeriesTicker)
+	setQuery(q, "status", params.Status)
+	setQuery(q, "tickers", strings.Join(params.Tickers, ","))
+	setQuery(q, "cursor", params.Cursor)
+	setTime(q, "min_close_ts", params.MinCloseTS)
+	setTime(q, "max_close_ts", params.MaxCloseTS)
+	setLimit(q, params.Limit)
+
+	data, err := c.Get(withQuery("/markets", q))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp GetMarketsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// Trade represents a public trade print.
+type Trade struct {
+	TradeID     string    `json:"trade_id"`
+	Ticker      string    `json:"ticker"`
+	Count       int       `json:"count"`
+	YesPrice    int       `json:"yes_price"`
+	NoPrice     int       `json:"no_price"`
+	TakerSide   string    `json:"taker_side"`
+	CreatedTime time.Time `json:"created_time"`
+}
+
+// GetTradesParams filters a GetTrades request. Zero values are omitted.
+type GetTradesParams struct {
+	Ticker string
+	MinTS  time.Time
+	MaxTS  time.Time
+	Limit  int
+	Cursor string
+}
+
+// GetTradesResponse represents a page of trades.
+type GetTradesResponse struct {
+	Trades []Trade `json:"trades"`
+	Cursor string  `json:"cursor"`
+}
+
+// Orderbook represents resting bids on each side as [price, quantity] pairs,
+// ordered by ascending price.
+type Orderbook struct {
+	Yes [][2]int `json:"yes"`
+	No  [][2]int `json:"no"`
+}
+
+// Depth returns the contracts bid on side ("yes" or "no") in its best
+// levels price levels (0 = all).
+func (b Orderbook) Depth(side string, levels int) int {
+	bids := b.Yes
+	if side == "no" {
+		bids = b.No
+	}
+	n := 0
+	for i := len(bids) - 1; i >= 0; i-- {
+		if levels > 0 && len(bids)-i > levels {
+			break
+		}
+		n += bids[i][1]
+	}
+	return n
+}
+
+// GetTrades retrieves a page of public trades.
+func (c *Client) GetTrades(params GetTradesParams) (*GetTradesResponse, error) {
+	q := url.Values{}
+	setQuery(q, "ticker", params.Ticker)
+	setQuery(q, "cursor", params.Cursor)
+	setTime(q, "min_ts", params.MinTS)
+	setTime(q, "max_ts", params.MaxTS)
+	setLimit(q, params.Limit)
+
+	data, err := c.Get(withQuery("/markets/trades", q))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp GetTradesResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetOrderbook retrieves the order book of a market. A depth of 0 returns
+// all levels.
+func (c *Client) GetOrderbook(ticker string, depth int) (*Orderbook, error) {
+	path := fmt.Sprintf("/markets/%s/orderbook", ticker)
+	if depth > 0 {
+		path += "?depth=" + strconv.Itoa(depth)
 	}
 
 	data, err := c.Get(path)
@@ -119,12 +273,14 @@ func (c *Client) GetMarkets(eventTicker string) ([]Market, error) {
 		return nil, err
 	}
 
-	var resp GetMarketsResponse
+	var resp struct {
+		Orderbook Orderbook `json:"orderbook"`
+	}
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return nil, fmt.Errorf("unmarshal response: %w", err)
 	}
 
-	return resp.Markets, nil
+	return &resp.Orderbook, nil
 }
 
 // GetEvent retrieves an event and its markets.
@@ -144,17 +300,7 @@ func (c *Client) GetEvent(eventTicker string) (*Event, []Market, error) {
 
 // GetPositions retrieves all positions.
 func (c *Client) GetPositions() ([]Position, error) {
-	data, err := c.Get("/portfolio/positions")
-	if err != nil {
-		return nil, err
-	}
-
-	var resp GetPositionsResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, fmt.Errorf("unmarshal response: %w", err)
-	}
-
-	return resp.Positions, nil
+	return c.GetAllPositions(GetPositionsParams{})
 }
 
 // GetPosition retrieves position for a specific ticker.
@@ -0,0 +1,21 @@
+package rest
+
+import "testing"
+
+func TestMarket_Determined(t *testing.T) {
+	tests := []struct {
+		market Market
+		want   bool
+	}{
+		{Market{Status: MarketStatusActive}, false},
+		{Market{Status: MarketStatusClosed}, false},
+		{Market{Status: MarketStatusClosed, Result: "no"}, true},
+		{Market{Status: MarketStatusDetermined}, true},
+		{Market{Status: MarketStatusFinalized, Result: "yes"}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.market.Determined(); got != tt.want {
+			t.Errorf("Market{Status: %q, Result: %q}.Determined() = %v, want %v", tt.market.Status, tt.market.Result, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Milestone represents a real-world occurrence (e.g. a game or a data
+// release) that one or more events settle on.
+type Milestone struct {
+	ID                  string         `json:"id"`
+	Category            string         `json:"category"`
+	Type                string         `json:"type"`
+	Title               string         `json:"title"`
+	StartDate           string         `json:"start_date"`
+	EndDate             string         `json:"end_date"`
+	PrimaryEventTickers []string       `json:"primary_event_tickers"`
+	RelatedEventTickers []string       `json:"related_event_tickers"`
+	NotificationMessage string         `json:"notification_message"`
+	SourceID            string         `json:"source_id"`
+	Details             map[string]any `json:"details"`
+	LastUpdatedTS       string         `json:"last_updated_ts"`
+}
+
+// GetMilestonesParams filters a GetMilestones request. Zero values are omitted.
+type GetMilestonesParams struct {
+	Category         string
+	Type             string
+	MinimumStartDate time.Time
+	Limit            int
+	Cursor           string
+}
+
+// GetMilestonesResponse represents a page of milestones.
+type GetMilestonesResponse struct {
+	Milestones []Milestone `json:"milestones"`
+	Cursor     string      `json:"cursor"`
+}
+
+// GetMilestones retrieves a page of milestones.
+func (c *Client) GetMilestones(params GetMilestonesParams) (*GetMilestonesResponse, error) {
+	q := url.Values{}
+	setQuery(q, "category", params.Category)
+	setQuery(q, "type", params.Type)
+	setQuery(q, "cursor", params.Cursor)
+	if !params.MinimumStartDate.IsZero() {
+		q.Set("minimum_start_date", params.MinimumStartDate.UTC().Format(time.RFC3339))
+	}
+	if params.Limit > 0 {
+		q.Set("limit", strconv.Itoa(params.Limit))
+	}
+
+	data, err := c.Get(withQuery("/milestones", q))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp GetMilestonesResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetMilestone retrieves a milestone by ID.
+func (c *Client) GetMilestone(id string) (*Milestone, error) {
+	data, err := c.Get(fmt.Sprintf("/milestones/%s", id))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Milestone Milestone `json:"milestone"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp.Milestone, nil
+}
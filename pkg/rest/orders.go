@@ -1,8 +1,10 @@
 package rest
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 )
 
 // Side represents the order side.
@@ -46,8 +48,8 @@ type CreateOrderRequest struct {
 	Side            Side        `json:"side"`
 	Type            OrderType   `json:"type"`
 	Count           int         `json:"count"`
-	YesPrice        int         `json:"yes_price,omitempty"` // In cents (1-99)
-	NoPrice         int         `json:"no_price,omitempty"`  // In cents (1-99)
+	YesPrice        int         `json:"yes_price,omitempty"` // In cents, on the market's PriceGrid
+	NoPrice         int         `json:"no_price,omitempty"`  // In cents, on the market's PriceGrid
 	ClientOrderID   string      `json:"client_order_id,omitempty"`
 	Expiration      string      `json:"expiration_ts,omitempty"` // RFC3339 timestamp
 	SellPositionCap int         `json:"sell_position_floor,omitempty"`
@@ -80,6 +82,20 @@ type Order struct {
 	Ticker2        string      `json:"ticker_2,omitempty"`
 }
 
+// RestingCost returns the cents a resting buy order commits if it fills: the
+// unfilled count at the order's price on its side. Sells and orders that are
+// no longer resting commit nothing.
+func (o *Order) RestingCost() int {
+	if o.Status != OrderStatusResting || o.Action != OrderActionBuy {
+		return 0
+	}
+	price := o.YesPrice
+	if o.Side == SideNo {
+		price = o.NoPrice
+	}
+	return o.RemainingCount * price
+}
+
 // CreateOrderResponse represents a response from creating an order.
 type CreateOrderResponse struct {
 	Order Order `json:"order"`
@@ -97,8 +113,33 @@ type CancelOrderResponse struct {
 	ReducedBy int   `json:"reduced_by"`
 }
 
-// CreateOrder places a new order.
+// AmendOrderRequest represents a request to change the price or size of a
+// resting order.
+type AmendOrderRequest struct {
+	Ticker               string      `json:"ticker"`
+	Action               OrderAction `json:"action"`
+	Side                 Side        `json:"side"`
+	Count                int         `json:"count"`               // Total contracts, including those already filled
+	YesPrice             int         `json:"yes_price,omitempty"` // In cents, on the market's PriceGrid
+	NoPrice              int         `json:"no_price,omitempty"`  // In cents, on the market's PriceGrid
+	ClientOrderID        string      `json:"client_order_id,omitempty"`
+	UpdatedClientOrderID string      `json:"updated_client_order_id,omitempty"`
+}
+
+// AmendOrderResponse represents a response from amending an order.
+type AmendOrderResponse struct {
+	OldOrder Order `json:"old_order"`
+	Order    Order `json:"order"`
+}
+
+// CreateOrder places a new order. Orders outside the client's OrderBounds
+// are refused with ErrOrderBounds.
 func (c *Client) CreateOrder(req *CreateOrderRequest) (*Order, error) {
+	price := sidePrice(req.Side, req.YesPrice, req.NoPrice)
+	if err := c.bounds.check(req.Action, req.Count, price, req.BuyMaxCost); err != nil {
+		return nil, fmt.Errorf("%s %s %s: %w", req.Action, req.Side, req.Ticker, err)
+	}
+
 	data, err := c.Post("/portfolio/orders", req)
 	if err != nil {
 		return nil, err
@@ -129,39 +170,57 @@ func (c *Client) GetOrder(orderID string) (*Order, error) {
 	return &resp.Order, nil
 }
 
-// GetOrders retrieves all orders for a ticker.
+// GetOrders retrieves all orders for a ticker, following pagination.
 func (c *Client) GetOrders(ticker string, status OrderStatus) ([]Order, error) {
-	path := "/portfolio/orders"
-	if ticker != "" {
-		path += "?ticker=" + ticker
-		if status != "" {
-			path += "&status=" + string(status)
+	return collect(iterate(context.Background(), c, "", func(c *Client, cursor string) ([]Order, string, error) {
+		q := url.Values{}
+		setQuery(q, "ticker", ticker)
+		setQuery(q, "status", string(status))
+		setQuery(q, "cursor", cursor)
+
+		data, err := c.Get(withQuery("/portfolio/orders", q))
+		if err != nil {
+			return nil, "", err
 		}
-	} else if status != "" {
-		path += "?status=" + string(status)
-	}
 
-	data, err := c.Get(path)
+		var resp GetOrdersResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, "", fmt.Errorf("unmarshal response: %w", err)
+		}
+		return resp.Orders, resp.Cursor, nil
+	}))
+}
+
+// CancelOrder cancels an order.
+func (c *Client) CancelOrder(orderID string) (*Order, error) {
+	data, err := c.Delete(fmt.Sprintf("/portfolio/orders/%s", orderID))
 	if err != nil {
 		return nil, err
 	}
 
-	var resp GetOrdersResponse
+	var resp CancelOrderResponse
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return nil, fmt.Errorf("unmarshal response: %w", err)
 	}
 
-	return resp.Orders, nil
+	return &resp.Order, nil
 }
 
-// CancelOrder cancels an order.
-func (c *Client) CancelOrder(orderID string) (*Order, error) {
-	data, err := c.Delete(fmt.Sprintf("/portfolio/orders/%s", orderID))
+// AmendOrder changes the price or size of a resting order in place and
+// returns the amended order. Amendments outside the client's OrderBounds are
+// refused with ErrOrderBounds.
+func (c *Client) AmendOrder(orderID string, req *AmendOrderRequest) (*Order, error) {
+	price := sidePrice(req.Side, req.YesPrice, req.NoPrice)
+	if err := c.bounds.check(req.Action, req.Count, price, 0); err != nil {
+		return nil, fmt.Errorf("amend %s: %w", orderID, err)
+	}
+
+	data, err := c.Post(fmt.Sprintf("/portfolio/orders/%s/amend", orderID), req)
 	if err != nil {
 		return nil, err
 	}
 
-	var resp CancelOrderResponse
+	var resp AmendOrderResponse
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return nil, fmt.Errorf("unmarshal response: %w", err)
 	}
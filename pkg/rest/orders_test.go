@@ -0,0 +1,21 @@
+package rest
+
+import "testing"
+
+func TestOrder_RestingCost(t *testing.T) {
+	tests := []struct {
+		name  string
+		order Order
+		want  int
+	}{
+		{"resting yes buy", Order{Status: OrderStatusResting, Action: OrderActionBuy, Side: SideYes, YesPrice: 40, NoPrice: 60, RemainingCount: 5}, 200},
+		{"resting no buy", Order{Status: OrderStatusResting, Action: OrderActionBuy, Side: SideNo, YesPrice: 40, NoPrice: 60, RemainingCount: 5}, 300},
+		{"resting sell", Order{Status: OrderStatusResting, Action: OrderActionSell, Side: SideYes, YesPrice: 40, RemainingCount: 5}, 0},
+		{"executed buy", Order{Status: OrderStatusExecuted, Action: OrderActionBuy, Side: SideYes, YesPrice: 40}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.order.RestingCost(); got != tt.want {
+			t.Errorf("%s: RestingCost() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
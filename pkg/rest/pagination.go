@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"context"
+	"iter"
+)
+
+// List endpoints return one page at a time with a cursor for the next. The
+// Iter helpers follow the cursor from params.Cursor (usually empty) to the
+// last page, fetching each page as the previous one is consumed and using
+// params.Limit as the page size (0 for the server default):
+//
+//	for m, err := range client.IterMarkets(ctx, rest.GetMarketsParams{SeriesTicker: "KXHIGHLAX"}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// An error (including cancellation of ctx, which also aborts the request in
+// flight) is yielded once and ends the iteration. The GetAll helpers collect
+// every item, returning nothing on error rather than a partial result.
+
+// iterate yields the items of each page in turn, calling page with a client
+// bound to ctx, until a page returns an empty cursor.
+func iterate[T any](ctx context.Context, c *Client, cursor string, page func(c *Client, cursor string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		bound := c.withContext(ctx)
+		for {
+			if err := ctx.Err(); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			items, next, err := page(bound, cursor)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					err = ctxErr
+				}
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			// Also stop on a repeated cursor rather than loop forever
+			if next == "" || next == cursor {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// collect gathers every item of seq, or returns its first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var all []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, item)
+	}
+	return all, nil
+}
+
+// IterMarkets yields every market matching params.
+func (c *Client) IterMarkets(ctx context.Context, params GetMarketsParams) iter.Seq2[Market, error] {
+	return iterate(ctx, c, params.Cursor, func(c *Client, cursor string) ([]Market, string, error) {
+		params.Cursor = cursor
+		resp, err := c.ListMarkets(params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Markets, resp.Cursor, nil
+	})
+}
+
+// IterEvents yields every event matching params.
+func (c *Client) IterEvents(ctx context.Context, params GetEventsParams) iter.Seq2[EventWithMarkets, error] {
+	return iterate(ctx, c, params.Cursor, func(c *Client, cursor string) ([]EventWithMarkets, string, error) {
+		params.Cursor = cursor
+		resp, err := c.GetEvents(params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Events, resp.Cursor, nil
+	})
+}
+
+// IterTrades yields every public trade matching params, newest first.
+func (c *Client) IterTrades(ctx context.Context, params GetTradesParams) iter.Seq2[Trade, error] {
+	return iterate(ctx, c, params.Cursor, func(c *Client, cursor string) ([]Trade, string, error) {
+		params.Cursor = cursor
+		resp, err := c.GetTrades(params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Trades, resp.Cursor, nil
+	})
+}
+
+// IterPositions yields every market position matching params.
+func (c *Client) IterPositions(ctx context.Context, params GetPositionsParams) iter.Seq2[Position, error] {
+	return iterate(ctx, c, params.Cursor, func(c *Client, cursor string) ([]Position, string, error) {
+		params.Cursor = cursor
+		resp, err := c.ListPositions(params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Positions, resp.Cursor, nil
+	})
+}
+
+// IterFills yields every fill matching params, newest first.
+func (c *Client) IterFills(ctx context.Context, params GetFillsParams) iter.Seq2[Fill, error] {
+	return iterate(ctx, c, params.Cursor, func(c *Client, cursor string) ([]Fill, string, error) {
+		params.Cursor = cursor
+		resp, err := c.GetFills(params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Fills, resp.Cursor, nil
+	})
+}
+
+// IterSettlements yields every settlement matching params, newest first.
+func (c *Client) IterSettlements(ctx context.Context, params GetSettlementsParams) iter.Seq2[Settlement, error] {
+	return iterate(ctx, c, params.Cursor, func(c *Client, cursor string) ([]Settlement, string, error) {
+		params.Cursor = cursor
+		resp, err := c.GetSettlements(params)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Settlements, resp.Cursor, nil
+	})
+}
+
+// GetAllMarkets retrieves every market matching params.
+func (c *Client) GetAllMarkets(params GetMarketsParams) ([]Market, error) {
+	return collect(c.IterMarkets(context.Background(), params))
+}
+
+// GetAllEvents retrieves every event matching params.
+func (c *Client) GetAllEvents(params GetEventsParams) ([]EventWithMarkets, error) {
+	return collect(c.IterEvents(context.Background(), params))
+}
+
+// GetAllTrades retrieves every public trade matching params, newest first.
+func (c *Client) GetAllTrades(params GetTradesParams) ([]Trade, error) {
+	return collect(c.IterTrades(context.Background(), params))
+}
+
+// GetAllPositions retrieves every market position matching params.
+func (c *Client) GetAllPositions(params GetPositionsParams) ([]Position, error) {
+	return collect(c.IterPositions(context.Background(), params))
+}
+
+// GetAllFills retrieves every fill matching params, newest first.
+func (c *Client) GetAllFills(params GetFillsParams) ([]Fill, error) {
+	return collect(c.IterFills(context.Background(), params))
+}
+
+// GetAllSettlements retrieves every settlement matching params, newest first.
+func (c *Client) GetAllSettlements(params GetSettlementsParams) ([]Settlement, error) {
+	return collect(c.IterSettlements(context.Background(), params))
+}
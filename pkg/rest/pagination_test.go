@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAllMarkets_FollowsCursor(t *testing.T) {
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		if r.Header.Get("KALSHI-ACCESS-SIGNATURE") != "" {
+			t.Error("public client signed the request")
+		}
+		switch cursor {
+		case "":
+			fmt.Fprint(w, `{"markets":[{"ticker":"A"},{"ticker":"B"}],"cursor":"p2"}`)
+		case "p2":
+			fmt.Fprint(w, `{"markets":[{"ticker":"C","strike_type":"greater","expiration_value":"71"}],"cursor":""}`)
+		}
+	}))
+	defer server.Close()
+
+	client := NewPublic(WithBaseURL(server.URL))
+	markets, err := client.GetAllMarkets(GetMarketsParams{SeriesTicker: "KXHIGHLAX", Status: "settled", Limit: 2})
+	if err != nil {
+		t.Fatalf("GetAllMarkets() error = %v", err)
+	}
+	if len(markets) != 3 || markets[2].Ticker != "C" || markets[2].StrikeType != "greater" || markets[2].ExpirationValue != "71" {
+		t.Errorf("GetAllMarkets() = %+v, want A, B, C", markets)
+	}
+	if len(cursors) != 2 || cursors[1] != "p2" {
+		t.Errorf("cursors = %q, want [\"\" p2]", cursors)
+	}
+}
+
+func TestIterate_StopsOnRepeatedCursor(t *testing.T) {
+	calls := 0
+	items, err := collect(iterate(context.Background(), NewPublic(), "", func(*Client, string) ([]int, string, error) {
+		calls++
+		return []int{calls}, "same", nil
+	}))
+	if err != nil || len(items) != 2 || calls != 2 {
+		t.Errorf("collect(iterate()) = %v, %v after %d calls, want 2 items and 2 calls", items, err, calls)
+	}
+}
+
+func TestIterTrades(t *testing.T) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		fmt.Fprintf(w, `{"trades":[{"trade_id":"t%d-1"},{"trade_id":"t%d-2"}],"cursor":"p%d"}`, pages, pages, pages+1)
+	}))
+	defer server.Close()
+	client := NewPublic(WithBaseURL(server.URL))
+
+	// Breaking out stops fetching pages
+	var ids []string
+	for trade, err := range client.IterTrades(context.Background(), GetTradesParams{Ticker: "X"}) {
+		if err != nil {
+			t.Fatalf("IterTrades() error = %v", err)
+		}
+		ids = append(ids, trade.TradeID)
+		if len(ids) == 3 {
+			break
+		}
+	}
+	if len(ids) != 3 || ids[2] != "t2-1" || pages != 2 {
+		t.Errorf("IterTrades() = %v after %d pages, want 3 trades from 2 pages", ids, pages)
+	}
+
+	// Cancellation ends the iteration with the context's error
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var errs []error
+	n := 0
+	for _, err := range client.IterTrades(ctx, GetTradesParams{Ticker: "X"}) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if n++; n == 2 {
+			cancel()
+		}
+	}
+	if n != 2 || len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("after cancel: %d trades, errors %v, want 2 trades then context.Canceled", n, errs)
+	}
+}
+
+func TestGetFills(t *testing.T) {
+	client, _, last := newTestClient(t, `{"fills":[{"trade_id":"t1","order_id":"o1","ticker":"X","side":"no",
+		"action":"buy","count":4,"yes_price":35,"no_price":65,"is_taker":true,"created_time":"2025-12-05T15:04:05Z"}]}`)
+
+	resp, err := client.GetFills(GetFillsParams{Ticker: "X"})
+	if err != nil {
+		t.Fatalf("GetFills() error = %v", err)
+	}
+	if (*last).URL.Path != "/trade-api/v2/portfolio/fills" || (*last).URL.Query().Get("ticker") != "X" {
+		t.Errorf("request = %s, want /trade-api/v2/portfolio/fills?ticker=X", (*last).URL)
+	}
+	if len(resp.Fills) != 1 || resp.Fills[0].Price() != 65 || !resp.Fills[0].IsTaker {
+		t.Errorf("GetFills() = %+v, want one taker fill at 65¢", resp.Fills)
+	}
+}
+
+func TestGetSettlements(t *testing.T) {
+	client, _, _ := newTestClient(t, `{"settlements":[{"ticker":"X","market_result":"no","no_count":10,
+		"no_total_cost":650,"revenue":1000,"settled_time":"2025-12-06T08:00:00Z"}]}`)
+
+	resp, err := client.GetSettlements(GetSettlementsParams{})
+	if err != nil {
+		t.Fatalf("GetSettlements() error = %v", err)
+	}
+	if len(resp.Settlements) != 1 || resp.Settlements[0].Profit() != 350 {
+		t.Errorf("GetSettlements() = %+v, want one settlement with 350¢ profit", resp.Settlements)
+	}
+}
@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// EventPosition represents the aggregate position across an event's markets.
+type EventPosition struct {
+	EventTicker       string `json:"event_ticker"`
+	EventExposure     int    `json:"event_exposure"` // Cents at risk across the event
+	TotalCost         int    `json:"total_cost"`
+	RealizedPnl       int    `json:"realized_pnl"`
+	FeesPaid          int    `json:"fees_paid"`
+	RestingOrderCount int    `json:"resting_order_count"`
+}
+
+// GetPositionsParams filters a ListPositions request. Zero values are omitted.
+type GetPositionsParams struct {
+	Ticker           string
+	EventTicker      string
+	CountFilter      string // "position", "total_traded" or "resting_order_count"
+	SettlementStatus string // "all", "settled" or "unsettled"
+	Limit            int
+	Cursor           string
+}
+
+// Fill represents one execution of an order.
+type Fill struct {
+	TradeID     string      `json:"trade_id"`
+	OrderID     string      `json:"order_id"`
+	Ticker      string      `json:"ticker"`
+	Side        Side        `json:"side"`
+	Action      OrderAction `json:"action"`
+	Count       int         `json:"count"`
+	YesPrice    int         `json:"yes_price"`
+	NoPrice     int         `json:"no_price"`
+	IsTaker     bool        `json:"is_taker"`
+	CreatedTime time.Time   `json:"created_time"`
+}
+
+// Price returns the fill price in cents on the side that was traded.
+func (f *Fill) Price() int {
+	if f.Side == SideNo {
+		return f.NoPrice
+	}
+	return f.YesPrice
+}
+
+// GetFillsParams filters a GetFills request. Zero values are omitted.
+type GetFillsParams struct {
+	Ticker  string
+	OrderID string
+	MinTS   time.Time
+	MaxTS   time.Time
+	Limit   int
+	Cursor  string
+}
+
+// GetFillsResponse represents a page of fills.
+type GetFillsResponse struct {
+	Fills  []Fill `json:"fills"`
+	Cursor string `json:"cursor"`
+}
+
+// Settlement represents the payout of a settled market position.
+type Settlement struct {
+	Ticker       string    `json:"ticker"`
+	MarketResult string    `json:"market_result"` // "yes" or "no"
+	YesCount     int       `json:"yes_count"`
+	YesTotalCost int       `json:"yes_total_cost"`
+	NoCount      int       `json:"no_count"`
+	NoTotalCost  int       `json:"no_total_cost"`
+	Revenue      int       `json:"revenue"` // Payout in cents
+	SettledTime  time.Time `json:"settled_time"`
+}
+
+// Profit returns the settlement's revenue minus the cost of both sides, in
+// cents.
+func (s *Settlement) Profit() int {
+	return s.Revenue - s.YesTotalCost - s.NoTotalCost
+}
+
+// GetSettlementsParams filters a GetSettlements request. Zero values are
+// omitted.
+type GetSettlementsParams struct {
+	Ticker      string
+	EventTicker string
+	MinTS       time.Time
+	MaxTS       time.Time
+	Limit       int
+	Cursor      string
+}
+
+// GetSettlementsResponse represents a page of settlements.
+type GetSettlementsResponse struct {
+	Settlements []Settlement `json:"settlements"`
+	Cursor      string       `json:"cursor"`
+}
+
+// ListPositions retrieves a page of market and event positions.
+func (c *Client) ListPositions(params GetPositionsParams) (*GetPositionsResponse, error) {
+	q := url.Values{}
+	setQuery(q, "ticker", params.Ticker)
+	setQuery(q, "event_ticker", params.EventTicker)
+	setQuery(q, "count_filter", params.CountFilter)
+	setQuery(q, "settlement_status", params.SettlementStatus)
+	setQuery(q, "cursor", params.Cursor)
+	setLimit(q, params.Limit)
+
+	data, err := c.Get(withQuery("/portfolio/positions", q))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp GetPositionsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetFills retrieves a page of the account's fills.
+func (c *Client) GetFills(params GetFillsParams) (*GetFillsResponse, error) {
+	q := url.Values{}
+	setQuery(q, "ticker", params.Ticker)
+	setQuery(q, "order_id", params.OrderID)
+	setQuery(q, "cursor", params.Cursor)
+	setTime(q, "min_ts", params.MinTS)
+	setTime(q, "max_ts", params.MaxTS)
+	setLimit(q, params.Limit)
+
+	data, err := c.Get(withQuery("/portfolio/fills", q))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp GetFillsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetSettlements retrieves a page of the account's settlements.
+func (c *Client) GetSettlements(params GetSettlementsParams) (*GetSettlementsResponse, error) {
+	q := url.Values{}
+	setQuery(q, "ticker", params.Ticker)
+	setQuery(q, "event_ticker", params.EventTicker)
+	setQuery(q, "cursor", params.Cursor)
+	setTime(q, "min_ts", params.MinTS)
+	setTime(q, "max_ts", params.MaxTS)
+	setLimit(q, params.Limit)
+
+	data, err := c.Get(withQuery("/portfolio/settlements", q))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp GetSettlementsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,187 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ErrInvalidPrice is returned when an order price is outside a market's
+// bounds or not a multiple of its tick size.
+var ErrInvalidPrice = errors.New("invalid order price")
+
+// PriceRange is one band of a market's price structure, as reported in its
+// metadata. Bounds and step are in dollars (e.g. "0.0100").
+type PriceRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Step  string `json:"step"`
+}
+
+// PriceBand is a price range in cents with a fixed tick.
+type PriceBand struct {
+	Min  int
+	Max  int
+	Tick int
+}
+
+// PriceGrid is the set of valid order prices of a market, in cents.
+type PriceGrid struct {
+	Bands []PriceBand // Ascending, non-overlapping
+}
+
+// DefaultPriceGrid returns the classic 1-99¢ grid with a 1¢ tick.
+func DefaultPriceGrid() PriceGrid {
+	return PriceGrid{Bands: []PriceBand{{Min: 1, Max: 99, Tick: 1}}}
+}
+
+// PriceGrid returns the market's valid order prices from its price ranges,
+// or from its tick size if it reports none, falling back to
+// DefaultPriceGrid. Sub-cent steps are treated as 1¢ since order prices are
+// whole cents.
+func (m *Market) PriceGrid() PriceGrid {
+	if g, err := parsePriceRanges(m.PriceRanges); err == nil && len(g.Bands) > 0 {
+		return g
+	}
+	if m.TickSize > 0 && m.TickSize < 50 {
+		return PriceGrid{Bands: []PriceBand{{Min: m.TickSize, Max: 100 - m.TickSize, Tick: m.TickSize}}}
+	}
+	return DefaultPriceGrid()
+}
+
+func parsePriceRanges(ranges []PriceRange) (PriceGrid, error) {
+	var g PriceGrid
+	for _, r := range ranges {
+		start, err := dollarsToCents(r.Start)
+		if err != nil {
+			return PriceGrid{}, err
+		}
+		end, err := dollarsToCents(r.End)
+		if err != nil {
+			return PriceGrid{}, err
+		}
+		step, err := dollarsToCents(r.Step)
+		if err != nil {
+			return PriceGrid{}, err
+		}
+		step = max(step, 1)
+
+		// Adjacent ranges share their boundary; start after the previous one
+		if n := len(g.Bands); n > 0 && start <= g.Bands[n-1].Max {
+			start = g.Bands[n-1].Max + step
+		}
+		start = max(start, 1)
+		end = min(end, 99)
+		if start > end {
+			continue
+		}
+		g.Bands = append(g.Bands, PriceBand{Min: start, Max: end, Tick: step})
+	}
+	return g, nil
+}
+
+// dollarsToCents parses a dollar amount, rounding to the nearest cent.
+func dollarsToCents(s string) (int, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse price %q: %w", s, err)
+	}
+	return int(math.Round(v * 100)), nil
+}
+
+// Min returns the lowest valid price.
+func (g PriceGrid) Min() int {
+	if len(g.Bands) == 0 {
+		return 1
+	}
+	return g.Bands[0].Min
+}
+
+// Max returns the highest valid price.
+func (g PriceGrid) Max() int {
+	if len(g.Bands) == 0 {
+		return 99
+	}
+	return g.Bands[len(g.Bands)-1].Max
+}
+
+// Valid reports whether price is on the grid.
+func (g PriceGrid) Valid(price int) bool {
+	for _, b := range g.Bands {
+		if price >= b.Min && price <= b.Max && (price-b.Min)%b.Tick == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns an error wrapping ErrInvalidPrice if price is not on the
+// grid.
+func (g PriceGrid) Validate(price int) error {
+	if g.Valid(price) {
+		return nil
+	}
+	if price < g.Min() || price > g.Max() {
+		return fmt.Errorf("%w: %d¢ outside %d-%d¢", ErrInvalidPrice, price, g.Min(), g.Max())
+	}
+	return fmt.Errorf("%w: %d¢ is not on the tick grid", ErrInvalidPrice, price)
+}
+
+// RoundDown returns the highest valid price at or below price, or Min if
+// there is none.
+func (g PriceGrid) RoundDown(price int) int {
+	best := g.Min()
+	for _, b := range g.Bands {
+		if price < b.Min {
+			break
+		}
+		best = b.Min + (min(price, b.Max)-b.Min)/b.Tick*b.Tick
+	}
+	return best
+}
+
+// RoundUp returns the lowest valid price at or above price, or Max if there
+// is none.
+func (g PriceGrid) RoundUp(price int) int {
+	for _, b := range g.Bands {
+		if price > b.Max {
+			continue
+		}
+		if price <= b.Min {
+			return b.Min
+		}
+		up := b.Min + (price-b.Min+b.Tick-1)/b.Tick*b.Tick
+		if up <= b.Max {
+			return up
+		}
+	}
+	return g.Max()
+}
+
+// Conform rounds the request's limit price onto the grid in the direction
+// that never worsens it (down for buys, up for sells) and validates the
+// result. Market orders are left unchanged.
+func (r *CreateOrderRequest) Conform(g PriceGrid) error {
+	if r.Type == OrderTypeMarket {
+		return nil
+	}
+	round := g.RoundDown
+	if r.Action == OrderActionSell {
+		round = g.RoundUp
+	}
+
+	price := &r.YesPrice
+	if r.Side == SideNo {
+		price = &r.NoPrice
+	}
+	if *price == 0 {
+		return fmt.Errorf("%w: no %s price set", ErrInvalidPrice, r.Side)
+	}
+	rounded := round(*price)
+	if r.Action == OrderActionSell && rounded < *price || r.Action != OrderActionSell && rounded > *price {
+		return fmt.Errorf("%w: %d¢ outside %d-%d¢", ErrInvalidPrice, *price, g.Min(), g.Max())
+	}
+	*price = rounded
+	return g.Validate(*price)
+}
@@ -0,0 +1,85 @@
+package rest
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMarket_PriceGrid(t *testing.T) {
+	tests := []struct {
+		name   string
+		market Market
+		valid  []int
+		bad    []int
+	}{
+		{"default", Market{}, []int{1, 50, 99}, []int{0, 100}},
+		{"tick size", Market{TickSize: 5}, []int{5, 50, 95}, []int{1, 52, 99}},
+		{
+			"price ranges",
+			Market{TickSize: 1, PriceRanges: []PriceRange{
+				{Start: "0.0000", End: "0.1000", Step: "0.0010"},
+				{Start: "0.1000", End: "0.9000", Step: "0.0200"},
+				{Start: "0.9000", End: "1.0000", Step: "0.0010"},
+			}},
+			[]int{1, 7, 10, 12, 50, 90, 93, 99},
+			[]int{0, 11, 51, 89, 100},
+		},
+	}
+	for _, tt := range tests {
+		g := tt.market.PriceGrid()
+		for _, p := range tt.valid {
+			if err := g.Validate(p); err != nil {
+				t.Errorf("%s: Validate(%d) = %v, want nil", tt.name, p, err)
+			}
+		}
+		for _, p := range tt.bad {
+			if err := g.Validate(p); !errors.Is(err, ErrInvalidPrice) {
+				t.Errorf("%s: Validate(%d) = %v, want ErrInvalidPrice", tt.name, p, err)
+			}
+		}
+	}
+}
+
+func TestPriceGrid_Round(t *testing.T) {
+	g := PriceGrid{Bands: []PriceBand{{Min: 5, Max: 20, Tick: 5}, {Min: 22, Max: 90, Tick: 2}}}
+
+	tests := []struct {
+		price, down, up int
+	}{
+		{1, 5, 5},
+		{7, 5, 10},
+		{20, 20, 20},
+		{21, 20, 22},
+		{23, 22, 24},
+		{95, 90, 90},
+	}
+	for _, tt := range tests {
+		if got := g.RoundDown(tt.price); got != tt.down {
+			t.Errorf("RoundDown(%d) = %d, want %d", tt.price, got, tt.down)
+		}
+		if got := g.RoundUp(tt.price); got != tt.up {
+			t.Errorf("RoundUp(%d) = %d, want %d", tt.price, got, tt.up)
+		}
+	}
+}
+
+func TestCreateOrderRequest_Conform(t *testing.T) {
+	m := Market{TickSize: 5}
+	g := m.PriceGrid()
+
+	buy := &CreateOrderRequest{Action: OrderActionBuy, Side: SideYes, Type: OrderTypeLimit, YesPrice: 47}
+	if err := buy.Conform(g); err != nil || buy.YesPrice != 45 {
+		t.Errorf("buy Conform() = %v, price %d, want nil, 45", err, buy.YesPrice)
+	}
+
+	sell := &CreateOrderRequest{Action: OrderActionSell, Side: SideNo, Type: OrderTypeLimit, NoPrice: 47}
+	if err := sell.Conform(g); err != nil || sell.NoPrice != 50 {
+		t.Errorf("sell Conform() = %v, price %d, want nil, 50", err, sell.NoPrice)
+	}
+
+	// Rounding a buy below the minimum up would pay more than asked
+	cheap := &CreateOrderRequest{Action: OrderActionBuy, Side: SideYes, Type: OrderTypeLimit, YesPrice: 2}
+	if err := cheap.Conform(g); !errors.Is(err, ErrInvalidPrice) || cheap.YesPrice != 2 {
+		t.Errorf("Conform() below minimum = %v, price %d, want ErrInvalidPrice, unchanged", err, cheap.YesPrice)
+	}
+}
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimits are request budgets per endpoint class, in requests per second.
+// Kalshi's Basic tier allows 20 reads and 10 writes (order placement,
+// amendment and cancellation) per second; higher tiers allow more.
+type RateLimits struct {
+	Read       float64 // GET requests per second (0 = unthrottled)
+	Write      float64 // POST, PUT and DELETE requests per second (0 = unthrottled)
+	MaxRetries int     // Retries of a request answered 429 Too Many Requests
+}
+
+// DefaultRateLimits returns the Basic tier limits, retrying a 429 up to
+// three times.
+func DefaultRateLimits() RateLimits {
+	return RateLimits{Read: 20, Write: 10, MaxRetries: 3}
+}
+
+// WithRateLimit throttles requests to limits with a token bucket per endpoint
+// class, so tools no longer need to sleep between calls. Bursts of up to one
+// second's budget go through at once. A request answered 429 is retried after
+// its Retry-After delay, or an exponential backoff from one second without
+// one, up to limits.MaxRetries times.
+func WithRateLimit(limits RateLimits) Option {
+	return func(c *Client) {
+		c.limiter = &rateLimiter{
+			read:       newBucket(limits.Read),
+			write:      newBucket(limits.Write),
+			maxRetries: limits.MaxRetries,
+		}
+	}
+}
+
+// rateLimiter holds the client's buckets. A nil limiter allows everything
+// and never retries.
+type rateLimiter struct {
+	read, write *bucket
+	maxRetries  int
+}
+
+// wait blocks until the request's endpoint class has budget or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, method string) error {
+	if l == nil {
+		return nil
+	}
+	if method == http.MethodGet {
+		return l.read.wait(ctx)
+	}
+	return l.write.wait(ctx)
+}
+
+// retries returns how many times a 429 response may be retried.
+func (l *rateLimiter) retries() int {
+	if l == nil {
+		return 0
+	}
+	return l.maxRetries
+}
+
+// bucket is a token bucket refilled at rate tokens per second up to burst.
+// A nil bucket is unthrottled.
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate float64) *bucket {
+	if rate <= 0 {
+		return nil
+	}
+	burst := math.Max(1, rate)
+	return &bucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait takes a token, sleeping until it is due. Callers queue by reserving
+// tokens ahead (the balance may go negative); a cancelled wait returns its
+// token.
+func (b *bucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, delay); err != nil {
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// retryDelay returns how long to wait before retrying a 429 response: the
+// Retry-After header (seconds or an HTTP date) if present, otherwise one
+// second doubled for each earlier attempt.
+func retryDelay(retryAfter string, attempt int) time.Duration {
+	retryAfter = strings.TrimSpace(retryAfter)
+	if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(retryAfter); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return time.Second << attempt
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBucket_Throttles(t *testing.T) {
+	b := newBucket(50) // Burst of 50, then one every 20ms
+
+	start := time.Now()
+	for i := 0; i < 55; i++ {
+		if err := b.wait(context.Background()); err != nil {
+			t.Fatalf("wait() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("55 requests at 50/s took %s, want at least 80ms after the burst", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait(cancelled) = %v, want context.Canceled", err)
+	}
+}
+
+func TestWithRateLimit_RetriesTooManyRequests(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"exchange_active":true,"trading_active":true}`)
+	}))
+	defer server.Close()
+
+	client := NewPublic(WithBaseURL(server.URL), WithRateLimit(DefaultRateLimits()))
+	status, err := client.GetExchangeStatus()
+	if err != nil || !status.TradingActive || calls != 3 {
+		t.Errorf("GetExchangeStatus() = %+v, %v after %d calls, want success on the third", status, err, calls)
+	}
+
+	// Without a rate limit the 429 is returned
+	calls = 0
+	_, err = NewPublic(WithBaseURL(server.URL)).GetExchangeStatus()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || calls != 1 {
+		t.Errorf("unthrottled GetExchangeStatus() error = %v after %d calls, want one 429", err, calls)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		header  string
+		attempt int
+		want    time.Duration
+	}{
+		{"2", 0, 2 * time.Second},
+		{"", 0, time.Second},
+		{"", 2, 4 * time.Second},
+		{"soon", 1, 2 * time.Second},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, 0},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.header, tt.attempt); got != tt.want {
+			t.Errorf("retryDelay(%q, %d) = %s, want %s", tt.header, tt.attempt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Rejection classifies why the exchange refused an order. Unlike network
+// and server errors, retrying a rejected order unchanged fails the same way;
+// each class has a Remedy instead.
+type Rejection string
+
+const (
+	// RejectPrice: the price is outside the market's range or off its tick
+	// grid.
+	RejectPrice Rejection = "price_out_of_bounds"
+	// RejectMarketPaused: the market is paused, closed or not open yet.
+	RejectMarketPaused Rejection = "market_paused"
+	// RejectFunds: the balance doesn't cover the order.
+	RejectFunds Rejection = "insufficient_funds"
+	// RejectSize: the order, or the position it would open, is over a
+	// limit.
+	RejectSize Rejection = "size_too_large"
+)
+
+// Remedy is the automatic response to a class of rejection.
+type Remedy string
+
+const (
+	RemedyReprice Remedy = "reprice" // Refetch the market's price grid and retry at a conforming price
+	RemedySkip    Remedy = "skip"    // Stop trading the market for a while
+	RemedyReduce  Remedy = "reduce"  // Retry with fewer contracts
+	RemedyHalt    Remedy = "halt"    // Stop opening positions until the balance is refetched
+)
+
+// Remedy returns the automatic response to the rejection, or "" for an
+// unknown class.
+func (r Rejection) Remedy() Remedy {
+	switch r {
+	case RejectPrice:
+		return RemedyReprice
+	case RejectMarketPaused:
+		return RemedySkip
+	case RejectFunds:
+		return RemedyHalt
+	case RejectSize:
+		return RemedyReduce
+	}
+	return ""
+}
+
+// rejectionKeywords match the error codes and messages of each class, most
+// specific first: "insufficient balance for max position" is about funds.
+var rejectionKeywords = []struct {
+	class Rejection
+	words []string
+}{
+	{RejectFunds, []string{"insufficient", "balance", "funds"}},
+	{RejectMarketPaused, []string{"paused", "closed", "halted", "not_open", "not open", "inactive", "not_active", "settled"}},
+	{RejectSize, []string{"too_large", "too large", "position_limit", "position limit", "max_position", "exceeds", "count"}},
+	{RejectPrice, []string{"price", "tick"}},
+}
+
+// ClassifyRejection returns the class of an order rejection, and false for
+// errors that aren't one: network and server errors, rate limiting and
+// authentication failures, which may succeed on a retry or need an operator.
+// Orders refused locally (ErrOrderBounds, ErrInvalidPrice) never reached the
+// exchange and aren't rejections either.
+func ClassifyRejection(err error) (Rejection, bool) {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode < 400 || apiErr.StatusCode >= 500 {
+		return "", false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusTooManyRequests:
+		return "", false
+	}
+	text := strings.ToLower(apiErr.Code + " " + apiErr.Message)
+	for _, k := range rejectionKeywords {
+		for _, w := range k.words {
+			if strings.Contains(text, w) {
+				return k.class, true
+			}
+		}
+	}
+	return "", false
+}
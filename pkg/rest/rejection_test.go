@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifyRejection(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Rejection
+		ok   bool
+	}{
+		{"insufficient balance", &APIError{StatusCode: 400, Code: "insufficient_balance", Message: "insufficient balance"}, RejectFunds, true},
+		{"market closed", &APIError{StatusCode: 409, Code: "market_closed", Message: "market is closed"}, RejectMarketPaused, true},
+		{"trading paused", &APIError{StatusCode: 400, Code: "trading_paused"}, RejectMarketPaused, true},
+		{"position limit", &APIError{StatusCode: 400, Code: "max_position_exceeded", Message: "order exceeds the position limit"}, RejectSize, true},
+		{"price range", &APIError{StatusCode: 400, Code: "invalid_price", Message: "price must be between 1 and 99"}, RejectPrice, true},
+		{"conform", fmt.Errorf("%w: 0¢ outside 1-99¢", ErrInvalidPrice), "", false},
+		{"wrapped", fmt.Errorf("order failed: %w", &APIError{StatusCode: 400, Code: "market_closed"}), RejectMarketPaused, true},
+		{"failsafe", fmt.Errorf("%w: 500 contracts", ErrOrderBounds), "", false},
+		{"server error", &APIError{StatusCode: 503, Message: "exchange closed for maintenance"}, "", false},
+		{"rate limited", &APIError{StatusCode: 429, Message: "too many requests"}, "", false},
+		{"unknown", &APIError{StatusCode: 400, Code: "bad_request"}, "", false},
+		{"network", errors.New("connection reset"), "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ClassifyRejection(tt.err)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: ClassifyRejection() = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRejection_Remedy(t *testing.T) {
+	want := map[Rejection]Remedy{
+		RejectPrice:        RemedyReprice,
+		RejectMarketPaused: RemedySkip,
+		RejectFunds:        RemedyHalt,
+		RejectSize:         RemedyReduce,
+	}
+	for class, remedy := range want {
+		if got := class.Remedy(); got != remedy {
+			t.Errorf("%s.Remedy() = %s, want %s", class, got, remedy)
+		}
+	}
+	if got := Rejection("unknown").Remedy(); got != "" {
+		t.Errorf("unknown.Remedy() = %s, want none", got)
+	}
+}
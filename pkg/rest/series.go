@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SettlementSource represents a data source used to settle markets.
+type SettlementSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Series represents a Kalshi series (a template for recurring events).
+type Series struct {
+	Ticker            string             `json:"ticker"`
+	Title             string             `json:"title"`
+	Category          string             `json:"category"`
+	Frequency         string             `json:"frequency"`
+	Tags              []string           `json:"tags"`
+	SettlementSources []SettlementSource `json:"settlement_sources"`
+	ContractURL       string             `json:"contract_url"`
+	FeeType           string             `json:"fee_type"`
+	FeeMultiplier     float64            `json:"fee_multiplier"`
+}
+
+// EventMetadata represents supplementary event information.
+type EventMetadata struct {
+	ImageURL          string             `json:"image_url"`
+	SettlementSources []SettlementSource `json:"settlement_sources"`
+}
+
+// GetEventsParams filters a GetEvents request. Zero values are omitted.
+type GetEventsParams struct {
+	SeriesTicker      string
+	Status            string // "open", "closed" or "settled"
+	WithNestedMarkets bool
+	Limit             int
+	Cursor            string
+}
+
+// GetEventsResponse represents a page of events.
+type GetEventsResponse struct {
+	Events []EventWithMarkets `json:"events"`
+	Cursor string             `json:"cursor"`
+}
+
+// EventWithMarkets represents an event, optionally with its markets.
+type EventWithMarkets struct {
+	Event
+	Markets []Market `json:"markets,omitempty"`
+}
+
+// GetSeries retrieves a series by ticker.
+func (c *Client) GetSeries(seriesTicker string) (*Series, error) {
+	data, err := c.Get(fmt.Sprintf("/series/%s", seriesTicker))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Series Series `json:"series"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp.Series, nil
+}
+
+// GetSeriesList retrieves series, optionally filtered by category and tags.
+func (c *Client) GetSeriesList(category, tags string) ([]Series, error) {
+	q := url.Values{}
+	setQuery(q, "category", category)
+	setQuery(q, "tags", tags)
+
+	data, err := c.Get(withQuery("/series", q))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Series []Series `json:"series"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return resp.Series, nil
+}
+
+// GetEvents retrieves a page of events.
+func (c *Client) GetEvents(params GetEventsParams) (*GetEventsResponse, error) {
+	q := url.Values{}
+	setQuery(q, "series_ticker", params.SeriesTicker)
+	setQuery(q, "status", params.Status)
+	setQuery(q, "cursor", params.Cursor)
+	if params.WithNestedMarkets {
+		q.Set("with_nested_markets", "true")
+	}
+	setLimit(q, params.Limit)
+
+	data, err := c.Get(withQuery("/events", q))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp GetEventsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// GetEventMetadata retrieves supplementary metadata for an event.
+func (c *Client) GetEventMetadata(eventTicker string) (*EventMetadata, error) {
+	data, err := c.Get(fmt.Sprintf("/events/%s/metadata", eventTicker))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp EventMetadata
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// setQuery sets key if value is non-empty.
+func setQuery(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
+
+// setTime sets key to t as a Unix timestamp if t is non-zero.
+func setTime(q url.Values, key string, t time.Time) {
+	if !t.IsZero() {
+		q.Set(key, strconv.FormatInt(t.Unix(), 10))
+	}
+}
+
+// setLimit sets the page size if limit is positive.
+func setLimit(q url.Values, limit int) {
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+}
+
+// withQuery appends encoded query parameters to path.
+func withQuery(path string, q url.Values) string {
+	if len(q) == 0 {
+		return path
+	}
+	return path + "?" + q.Encode()
+}
@@ -0,0 +1,383 @@
+// Package risk enforces a bot's hard limits: a maximum daily loss, maximum
+// open exposure per event and per city, a maximum number of trades per day,
+// and a kill switch.
+//
+// The limits are a last line of defense, set above the bots' normal sizing.
+// When one is breached the Guard halts: it calls the halt hook (typically
+// canceling the account's resting orders with CancelOpenOrders) and rejects
+// every order until an operator calls Reset. The day's usage and the halt
+// are persisted, so a restart neither resets the counters nor resumes a
+// halted bot, and the kill switch is a file any process or operator can
+// create to halt every bot pointed at it. The kill switch is the only part
+// shared between bots: each Guard counts its own process's trades, loss and
+// exposure, so each needs its own StatePath (two guards saving to one file
+// overwrite each other's usage):
+//
+//	g, err := risk.New(risk.Config{
+//		Limits:     risk.Limits{MaxDailyLoss: 200, MaxEventExposure: 500, MaxTradesPerDay: 40},
+//		StatePath:  "data/risk-guard.json",
+//		KillSwitch: "data/KILL",
+//	})
+//	g.OnHalt(func(reason string) { risk.CancelOpenOrders(client) })
+//	if err := g.Check(risk.Order{Ticker: ticker, Cost: 12.50}); err != nil {
+//		return err // ErrHalted
+//	}
+//	// ... place the order, then
+//	g.Record(risk.Order{Ticker: ticker, Cost: 12.50})
+package risk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+// ErrHalted is returned by Check once trading is halted.
+var ErrHalted = errors.New("risk: trading halted")
+
+// Limits are the hard limits. A zero limit is not enforced.
+type Limits struct {
+	MaxDailyLoss     float64 // Dollars of realized loss in a day
+	MaxEventExposure float64 // Dollars of open cost in one event
+	MaxCityExposure  float64 // Dollars of open cost in one city's events on one day, HIGH and LOW together
+	MaxTradesPerDay  int     // Orders placed in a day
+}
+
+// Config configures a Guard.
+type Config struct {
+	Limits
+
+	// StatePath is the JSON file keeping the day's usage and any halt
+	// across restarts ("" = in memory only). It belongs to one guard.
+	StatePath string
+	// KillSwitch is a file whose presence halts trading, e.g. one path
+	// shared by every bot on the account ("" = none).
+	KillSwitch string
+	// Location sets the day boundaries of the daily limits (default
+	// America/New_York, the exchange's time zone).
+	Location *time.Location
+}
+
+// Order is an order about to be placed or just placed.
+type Order struct {
+	Ticker string  // Market ticker, e.g. "KXHIGHLAX-25DEC05-B62.5"
+	Cost   float64 // Dollars the order can cost if it fills
+}
+
+// Status is the guard's current state.
+type Status struct {
+	Limits   Limits             `json:"limits"`
+	Day      string             `json:"day"`
+	Trades   int                `json:"trades"`
+	PnL      float64            `json:"pnl"`      // Realized P&L today in dollars
+	Exposure map[string]float64 `json:"exposure"` // Event ticker -> open cost
+	Halted   bool               `json:"halted"`
+	Reason   string             `json:"reason,omitempty"`
+	Since    time.Time          `json:"since,omitzero"` // When the halt began
+}
+
+// Guard enforces Limits. It is safe for concurrent use.
+type Guard struct {
+	cfg Config
+
+	mu      sync.Mutex
+	st      Status
+	onHalt  func(reason string)
+	pending bool // Halted since onHalt was last called
+}
+
+// New creates a guard, loading its state from cfg.StatePath if present.
+func New(cfg Config) (*Guard, error) {
+	if cfg.Location == nil {
+		loc, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			loc = time.UTC
+		}
+		cfg.Location = loc
+	}
+	g := &Guard{cfg: cfg, st: Status{Exposure: make(map[string]float64)}}
+
+	if cfg.StatePath == "" {
+		return g, nil
+	}
+	data, err := os.ReadFile(cfg.StatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return g, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read risk state: %w", err)
+	}
+	if err := json.Unmarshal(data, &g.st); err != nil {
+		return nil, fmt.Errorf("parse risk state %s: %w", cfg.StatePath, err)
+	}
+	if g.st.Exposure == nil {
+		g.st.Exposure = make(map[string]float64)
+	}
+	return g, nil
+}
+
+// OnHalt sets the function called, once, when trading halts. It runs
+// without the guard's lock held, so it may call the guard.
+func (g *Guard) OnHalt(fn func(reason string)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onHalt = fn
+}
+
+// Check returns ErrHalted, wrapped with the reason, if trading is halted or
+// if placing o would breach a limit, in which case trading halts.
+func (g *Guard) Check(o Order) error {
+	g.mu.Lock()
+	g.rollover(time.Now())
+
+	if !g.st.Halted && g.killed() {
+		g.halt("kill switch " + g.cfg.KillSwitch)
+	}
+	if !g.st.Halted {
+		if reason := g.breach(o); reason != "" {
+			g.halt(reason)
+		}
+	}
+	halted, reason := g.st.Halted, g.st.Reason
+	g.mu.Unlock()
+
+	g.notify()
+	if halted {
+		return fmt.Errorf("%w: %s", ErrHalted, reason)
+	}
+	return nil
+}
+
+// breach returns the limit placing o would breach, or "". Callers hold g.mu.
+func (g *Guard) breach(o Order) string {
+	l := g.cfg.Limits
+	if l.MaxTradesPerDay > 0 && g.st.Trades+1 > l.MaxTradesPerDay {
+		return fmt.Sprintf("%d trades today, limit %d", g.st.Trades+1, l.MaxTradesPerDay)
+	}
+
+	event := eventTicker(o.Ticker)
+	if l.MaxEventExposure > 0 {
+		if e := g.st.Exposure[event] + o.Cost; e > l.MaxEventExposure {
+			return fmt.Sprintf("$%.2f open in %s, limit $%.2f", e, event, l.MaxEventExposure)
+		}
+	}
+	if l.MaxCityExposure > 0 {
+		city := CityDay(event)
+		total := o.Cost
+		for e, cost := range g.st.Exposure {
+			if CityDay(e) == city {
+				total += cost
+			}
+		}
+		if total > l.MaxCityExposure {
+			return fmt.Sprintf("$%.2f open in %s, limit $%.2f", total, city, l.MaxCityExposure)
+		}
+	}
+	return ""
+}
+
+// Record counts a placed order toward the day's trades and its event's
+// exposure.
+func (g *Guard) Record(o Order) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.rollover(time.Now())
+	g.st.Trades++
+	g.st.Exposure[eventTicker(o.Ticker)] += o.Cost
+	g.save()
+}
+
+// Release removes cost from an event's exposure, e.g. when orders are
+// canceled unfilled or positions are sold. Settled events are released with
+// Settle.
+func (g *Guard) Release(eventTicker string, cost float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if e := g.st.Exposure[eventTicker] - cost; e > 0.005 {
+		g.st.Exposure[eventTicker] = e
+	} else {
+		delete(g.st.Exposure, eventTicker)
+	}
+	g.save()
+}
+
+// Settle releases a settled event's exposure and adds its realized P&L in
+// dollars to the day's. A loss past MaxDailyLoss halts trading.
+func (g *Guard) Settle(eventTicker string, pnl float64) {
+	g.mu.Lock()
+	delete(g.st.Exposure, eventTicker)
+	g.addPnL(pnl)
+	g.mu.Unlock()
+	g.notify()
+}
+
+// RecordPnL adds realized P&L in dollars, e.g. from a sale, to the day's. A
+// loss past MaxDailyLoss halts trading.
+func (g *Guard) RecordPnL(pnl float64) {
+	g.mu.Lock()
+	g.addPnL(pnl)
+	g.mu.Unlock()
+	g.notify()
+}
+
+// addPnL adds realized P&L and halts on the loss limit. Callers hold g.mu.
+func (g *Guard) addPnL(pnl float64) {
+	g.rollover(time.Now())
+	g.st.PnL += pnl
+	if max := g.cfg.MaxDailyLoss; max > 0 && -g.st.PnL >= max && !g.st.Halted {
+		g.halt(fmt.Sprintf("lost $%.2f today, limit $%.2f", -g.st.PnL, max))
+	}
+	g.save()
+}
+
+// Halt halts trading for reason, e.g. from an operator command.
+func (g *Guard) Halt(reason string) {
+	g.mu.Lock()
+	if !g.st.Halted {
+		g.halt(reason)
+	}
+	g.mu.Unlock()
+	g.notify()
+}
+
+// Reset resumes trading after a halt and removes the kill switch file. The
+// day's usage is kept.
+func (g *Guard) Reset() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.cfg.KillSwitch != "" {
+		if err := os.Remove(g.cfg.KillSwitch); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove kill switch: %w", err)
+		}
+	}
+	g.st.Halted, g.st.Reason, g.st.Since = false, "", time.Time{}
+	g.save()
+	return nil
+}
+
+// Status returns the guard's current state.
+func (g *Guard) Status() Status {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.rollover(time.Now())
+	st := g.st
+	if !st.Halted && g.killed() {
+		st.Halted, st.Reason = true, "kill switch "+g.cfg.KillSwitch
+	}
+	st.Limits = g.cfg.Limits
+	st.Exposure = make(map[string]float64, len(g.st.Exposure))
+	for e, cost := range g.st.Exposure {
+		st.Exposure[e] = cost
+	}
+	return st
+}
+
+// halt halts trading. Callers hold g.mu and call notify after releasing it.
+func (g *Guard) halt(reason string) {
+	g.st.Halted, g.st.Reason, g.st.Since = true, reason, time.Now()
+	g.save()
+	g.pending = true
+}
+
+// notify calls the halt hook if trading halted since it was last called.
+// Callers must not hold g.mu.
+func (g *Guard) notify() {
+	g.mu.Lock()
+	fn, reason := g.onHalt, g.st.Reason
+	fire := g.pending && fn != nil
+	if fire {
+		g.pending = false
+	}
+	g.mu.Unlock()
+
+	if fire {
+		fn(reason)
+	}
+}
+
+// killed reports whether the kill switch file exists.
+func (g *Guard) killed() bool {
+	if g.cfg.KillSwitch == "" {
+		return false
+	}
+	_, err := os.Stat(g.cfg.KillSwitch)
+	return err == nil
+}
+
+// rollover starts a new day's trade count and P&L. Exposure carries over
+// until released. Callers hold g.mu.
+func (g *Guard) rollover(now time.Time) {
+	day := now.In(g.cfg.Location).Format(time.DateOnly)
+	if day != g.st.Day {
+		g.st.Day, g.st.Trades, g.st.PnL = day, 0, 0
+	}
+}
+
+// save writes the state to StatePath. Failures are ignored: the in-memory
+// state still protects this process. Callers hold g.mu.
+func (g *Guard) save() {
+	if g.cfg.StatePath == "" {
+		return
+	}
+	data, err := json.MarshalIndent(g.st, "", "  ")
+	if err != nil {
+		return
+	}
+	if dir := filepath.Dir(g.cfg.StatePath); dir != "" {
+		os.MkdirAll(dir, 0o755)
+	}
+	tmp := g.cfg.StatePath + ".tmp"
+	if os.WriteFile(tmp, data, 0o644) == nil {
+		os.Rename(tmp, g.cfg.StatePath)
+	}
+}
+
+// CancelOpenOrders cancels every resting order on the account and returns
+// how many were canceled.
+func CancelOpenOrders(client *rest.Client) (int, error) {
+	orders, err := client.GetOrders("", rest.OrderStatusResting)
+	if err != nil {
+		return 0, fmt.Errorf("get resting orders: %w", err)
+	}
+	n := 0
+	var errs []error
+	for _, o := range orders {
+		if _, err := client.CancelOrder(o.OrderID); err != nil {
+			errs = append(errs, fmt.Errorf("cancel %s: %w", o.OrderID, err))
+			continue
+		}
+		n++
+	}
+	return n, errors.Join(errs...)
+}
+
+// CityDay returns the city and date of an event, shared by its HIGH and LOW
+// markets: "LAX-25DEC05" for both "KXHIGHLAX-25DEC05" and "KXLOWTLAX-25DEC05".
+func CityDay(eventTicker string) string {
+	series, date, _ := strings.Cut(eventTicker, "-")
+	for _, prefix := range []string{"KXHIGH", "KXLOWT"} {
+		if city, ok := strings.CutPrefix(series, prefix); ok {
+			return city + "-" + date
+		}
+	}
+	return eventTicker
+}
+
+// eventTicker returns the event of a market ticker.
+func eventTicker(ticker string) string {
+	if i := strings.LastIndex(ticker, "-"); i > 0 && strings.Count(ticker, "-") > 1 {
+		return ticker[:i]
+	}
+	return ticker
+}
@@ -0,0 +1,196 @@
+package risk
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+)
+
+const (
+	laxHigh = "KXHIGHLAX-25DEC05-B62.5"
+	laxLow  = "KXLOWTLAX-25DEC05-B48.5"
+	nyHigh  = "KXHIGHNY-25DEC05-B50.5"
+)
+
+func newGuard(t *testing.T, limits Limits) (*Guard, *[]string) {
+	t.Helper()
+	g, err := New(Config{Limits: limits, Location: time.UTC})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	var halts []string
+	g.OnHalt(func(reason string) { halts = append(halts, reason) })
+	return g, &halts
+}
+
+func place(g *Guard, ticker string, cost float64) error {
+	o := Order{Ticker: ticker, Cost: cost}
+	if err := g.Check(o); err != nil {
+		return err
+	}
+	g.Record(o)
+	return nil
+}
+
+func TestGuard_Exposure(t *testing.T) {
+	g, halts := newGuard(t, Limits{MaxEventExposure: 100, MaxCityExposure: 150})
+
+	if err := place(g, laxHigh, 90); err != nil {
+		t.Fatalf("place() error = %v", err)
+	}
+	if err := place(g, nyHigh, 100); err != nil {
+		t.Fatalf("place() in another city error = %v", err)
+	}
+	// LAX's HIGH and LOW events count toward one city limit
+	if err := place(g, laxLow, 55); err != nil {
+		t.Fatalf("place() under the city limit error = %v", err)
+	}
+	if len(*halts) != 0 {
+		t.Fatalf("halted early: %v", *halts)
+	}
+
+	err := place(g, laxLow, 10)
+	if !errors.Is(err, ErrHalted) {
+		t.Fatalf("place() over the city limit error = %v, want ErrHalted", err)
+	}
+	if len(*halts) != 1 || !strings.Contains((*halts)[0], "LAX-25DEC05") {
+		t.Errorf("halts = %v, want one naming LAX-25DEC05", *halts)
+	}
+
+	// Halted until reset, even for orders within the limits
+	if err := place(g, nyHigh, 0); !errors.Is(err, ErrHalted) {
+		t.Errorf("place() while halted error = %v, want ErrHalted", err)
+	}
+	if len(*halts) != 1 {
+		t.Errorf("halt hook called %d times, want 1", len(*halts))
+	}
+
+	g.Release("KXLOWTLAX-25DEC05", 55)
+	if err := g.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if err := place(g, laxLow, 10); err != nil {
+		t.Errorf("place() after release and reset error = %v", err)
+	}
+	if err := place(g, laxHigh, 20); !errors.Is(err, ErrHalted) {
+		t.Errorf("place() over the event limit error = %v, want ErrHalted", err)
+	}
+}
+
+func TestGuard_TradesAndLoss(t *testing.T) {
+	g, halts := newGuard(t, Limits{MaxTradesPerDay: 2, MaxDailyLoss: 50})
+
+	for i := 0; i < 2; i++ {
+		if err := place(g, nyHigh, 10); err != nil {
+			t.Fatalf("trade %d error = %v", i+1, err)
+		}
+	}
+	if err := place(g, nyHigh, 10); !errors.Is(err, ErrHalted) {
+		t.Fatalf("third trade error = %v, want ErrHalted", err)
+	}
+	g.Reset()
+
+	g.Settle("KXHIGHNY-25DEC05", -30)
+	if st := g.Status(); st.Halted || st.Exposure["KXHIGHNY-25DEC05"] != 0 {
+		t.Fatalf("Status() = %+v, want not halted, event released", st)
+	}
+	g.RecordPnL(-20)
+	if st := g.Status(); !st.Halted || st.PnL != -50 {
+		t.Errorf("Status() = %+v, want halted at -$50", st)
+	}
+	if len(*halts) != 2 {
+		t.Errorf("halts = %v, want 2", *halts)
+	}
+}
+
+func TestGuard_KillSwitchAndState(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{
+		Limits:     Limits{MaxTradesPerDay: 5},
+		StatePath:  filepath.Join(dir, "limits.json"),
+		KillSwitch: filepath.Join(dir, "KILL"),
+		Location:   time.UTC,
+	}
+	g, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := place(g, laxHigh, 10); err != nil {
+		t.Fatalf("place() error = %v", err)
+	}
+
+	// Another bot, or an operator, pulls the switch
+	if err := os.WriteFile(cfg.KillSwitch, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Check(Order{Ticker: laxHigh, Cost: 1}); !errors.Is(err, ErrHalted) {
+		t.Fatalf("Check() with kill switch error = %v, want ErrHalted", err)
+	}
+
+	// A restart stays halted and keeps the day's usage
+	g, err = New(cfg)
+	if err != nil {
+		t.Fatalf("New() reload error = %v", err)
+	}
+	if st := g.Status(); !st.Halted || st.Trades != 1 || st.Exposure["KXHIGHLAX-25DEC05"] != 10 {
+		t.Errorf("reloaded Status() = %+v, want halted with 1 trade, $10 open", st)
+	}
+
+	if err := g.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if _, err := os.Stat(cfg.KillSwitch); !os.IsNotExist(err) {
+		t.Errorf("kill switch still present after Reset()")
+	}
+	if err := g.Check(Order{Ticker: laxHigh, Cost: 1}); err != nil {
+		t.Errorf("Check() after Reset() error = %v", err)
+	}
+}
+
+func TestCancelOpenOrders(t *testing.T) {
+	var canceled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/portfolio/orders":
+			if got := r.URL.Query().Get("status"); got != "resting" {
+				t.Errorf("status = %q, want resting", got)
+			}
+			w.Write([]byte(`{"orders":[{"order_id":"o1"},{"order_id":"o2"}]}`))
+		case r.Method == http.MethodDelete:
+			canceled = append(canceled, strings.TrimPrefix(r.URL.Path, "/portfolio/orders/"))
+			w.Write([]byte(`{"order":{}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	n, err := CancelOpenOrders(rest.NewPublic(rest.WithBaseURL(server.URL)))
+	if err != nil {
+		t.Fatalf("CancelOpenOrders() error = %v", err)
+	}
+	if n != 2 || len(canceled) != 2 || canceled[0] != "o1" || canceled[1] != "o2" {
+		t.Errorf("CancelOpenOrders() = %d, canceled %v, want o1 and o2", n, canceled)
+	}
+}
+
+func TestCityDay(t *testing.T) {
+	tests := map[string]string{
+		"KXHIGHLAX-25DEC05": "LAX-25DEC05",
+		"KXLOWTLAX-25DEC05": "LAX-25DEC05",
+		"KXHIGHCHI-25DEC06": "CHI-25DEC06",
+		"KXBTC-25DEC05":     "KXBTC-25DEC05",
+	}
+	for event, want := range tests {
+		if got := CityDay(event); got != want {
+			t.Errorf("CityDay(%q) = %q, want %q", event, got, want)
+		}
+	}
+}
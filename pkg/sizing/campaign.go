@@ -0,0 +1,109 @@
+package sizing
+
+import (
+	"math"
+	"time"
+)
+
+// Campaign spreads a budget over the days of a campaign, such as a week, by
+// how confident the forecast is in each day: the days it is sure of get
+// more, and the days below MinConfidence nothing, instead of every day
+// risking the same amount.
+//
+//	c := sizing.Campaign{Budget: 500, MinConfidence: 0.4, MaxDay: 150}
+//	plan := c.Plan([]sizing.CampaignDay{{Date: "2025-12-01", Confidence: 0.8}, ...}, 0)
+type Campaign struct {
+	Budget        float64 // Dollars to deploy over the campaign
+	MinConfidence float64 // Days less confident than this get nothing
+	Exponent      float64 // A day's weight is its confidence to this power (0 = 2); higher holds more back for the sure days
+	MaxDay        float64 // Dollars a day at most (0 = no cap)
+}
+
+// CampaignDay is one day left in a campaign.
+type CampaignDay struct {
+	Date       string  // YYYY-MM-DD
+	Confidence float64 // 0-1, e.g. the forecast's probability of its likeliest bracket
+}
+
+// DayBudget is a day's share of a campaign.
+type DayBudget struct {
+	Date       string  `json:"date"`
+	Confidence float64 `json:"confidence"`
+	Budget     float64 `json:"budget"` // Dollars of new positions
+}
+
+// CampaignPlan is how a campaign's budget is spread over its days.
+type CampaignPlan struct {
+	Budget float64     `json:"budget"` // Dollars left to deploy when planned
+	Days   []DayBudget `json:"days"`
+	Held   float64     `json:"held"` // Dollars no day was given: too few confident days, or all at MaxDay
+}
+
+// Plan spreads what is left of the budget after spent over days, in
+// proportion to each qualifying day's weight. A day over MaxDay is capped
+// and its excess spread over the others; what no day can take is held.
+// Replanning each day with the dollars spent so far moves what a quiet day
+// left unspent onto the days after it.
+func (c Campaign) Plan(days []CampaignDay, spent float64) CampaignPlan {
+	exp := c.Exponent
+	if exp <= 0 {
+		exp = 2
+	}
+	plan := CampaignPlan{Budget: max(c.Budget-spent, 0)}
+	weights := make([]float64, len(days))
+	for i, d := range days {
+		plan.Days = append(plan.Days, DayBudget{Date: d.Date, Confidence: d.Confidence})
+		if d.Confidence > 0 && d.Confidence >= c.MinConfidence {
+			weights[i] = math.Pow(d.Confidence, exp)
+		}
+	}
+
+	// Capping a day raises the others' shares, which may push another over
+	// the cap: repeat until no share is over it
+	left := plan.Budget
+	for left > 1e-9 {
+		total := 0.0
+		for _, w := range weights {
+			total += w
+		}
+		if total == 0 {
+			break
+		}
+		share := left / total
+		capped := false
+		for i, w := range weights {
+			if w > 0 && c.MaxDay > 0 && share*w > c.MaxDay {
+				plan.Days[i].Budget = c.MaxDay
+				left -= c.MaxDay
+				weights[i] = 0
+				capped = true
+			}
+		}
+		if capped {
+			continue
+		}
+		for i, w := range weights {
+			plan.Days[i].Budget += share * w
+		}
+		left = 0
+	}
+	plan.Held = left
+	return plan
+}
+
+// Day returns the budget planned for date.
+func (p CampaignPlan) Day(date string) (float64, bool) {
+	for _, d := range p.Days {
+		if d.Date == date {
+			return d.Budget, true
+		}
+	}
+	return 0, false
+}
+
+// WeekStart returns the midnight starting now's week, in now's location,
+// for weeks starting on start.
+func WeekStart(now time.Time, start time.Weekday) time.Time {
+	back := (int(now.Weekday()) - int(start) + 7) % 7
+	return time.Date(now.Year(), now.Month(), now.Day()-back, 0, 0, 0, 0, now.Location())
+}
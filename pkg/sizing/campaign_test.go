@@ -0,0 +1,77 @@
+package sizing
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCampaign_Plan(t *testing.T) {
+	days := func(confidences ...float64) []CampaignDay {
+		var d []CampaignDay
+		for i, c := range confidences {
+			d = append(d, CampaignDay{Date: time.Date(2025, 12, 1+i, 0, 0, 0, 0, time.UTC).Format(time.DateOnly), Confidence: c})
+		}
+		return d
+	}
+	tests := []struct {
+		name     string
+		campaign Campaign
+		days     []CampaignDay
+		spent    float64
+		want     []float64
+		held     float64
+	}{
+		{"even", Campaign{Budget: 300}, days(0.5, 0.5, 0.5), 0, []float64{100, 100, 100}, 0},
+		// Weights 0.16 and 0.64
+		{"confidence", Campaign{Budget: 300}, days(0.4, 0.8), 0, []float64{60, 240}, 0},
+		{"linear", Campaign{Budget: 300, Exponent: 1}, days(0.4, 0.8), 0, []float64{100, 200}, 0},
+		{"held back", Campaign{Budget: 300, MinConfidence: 0.5}, days(0.4, 0.8), 0, []float64{0, 300}, 0},
+		{"nothing confident", Campaign{Budget: 300, MinConfidence: 0.9}, days(0.4, 0.8), 0, []float64{0, 0}, 300},
+		// 0.81 of 1.31 is $185 over the cap; the $150 left splits evenly
+		{"capped", Campaign{Budget: 300, MaxDay: 150}, days(0.9, 0.5, 0.5), 0, []float64{150, 75, 75}, 0},
+		{"all capped", Campaign{Budget: 300, MinConfidence: 0.5, MaxDay: 200}, days(0.4, 0.8), 0, []float64{0, 200}, 100},
+		{"spent", Campaign{Budget: 300}, days(0.5, 0.5), 100, []float64{100, 100}, 0},
+		{"overspent", Campaign{Budget: 300}, days(0.5), 400, []float64{0}, 0},
+	}
+	for _, tt := range tests {
+		plan := tt.campaign.Plan(tt.days, tt.spent)
+		for i, want := range tt.want {
+			if got := plan.Days[i].Budget; math.Abs(got-want) > 1e-9 {
+				t.Errorf("%s: day %d budget = %.2f, want %.2f", tt.name, i, got, want)
+			}
+		}
+		if math.Abs(plan.Held-tt.held) > 1e-9 {
+			t.Errorf("%s: Held = %.2f, want %.2f", tt.name, plan.Held, tt.held)
+		}
+	}
+}
+
+func TestCampaignPlan_Day(t *testing.T) {
+	plan := Campaign{Budget: 100}.Plan([]CampaignDay{{Date: "2025-12-01", Confidence: 0.5}}, 0)
+	if b, ok := plan.Day("2025-12-01"); !ok || b != 100 {
+		t.Errorf("Day(2025-12-01) = %.2f, %v, want 100, true", b, ok)
+	}
+	if _, ok := plan.Day("2025-12-02"); ok {
+		t.Error("Day(2025-12-02) found a day outside the plan")
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	wed := time.Date(2025, 12, 3, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		now   time.Time
+		start time.Weekday
+		want  time.Time
+	}{
+		{wed, time.Monday, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{wed, time.Sunday, time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC)},
+		{wed, time.Wednesday, time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC)},
+		{wed, time.Thursday, time.Date(2025, 11, 27, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := WeekStart(tt.now, tt.start); !got.Equal(tt.want) {
+			t.Errorf("WeekStart(%s, %s) = %s, want %s", tt.now.Weekday(), tt.start, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package sizing
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestKellyFraction(t *testing.T) {
+	tests := []struct {
+		name string
+		bet  Bet
+		want float64
+	}{
+		// Even odds at 60%: 0.6 - 0.4
+		{"even odds", Bet{Prob: 0.6, Price: 50}, 0.2},
+		// 30¢ pays 7:3; (0.5 * 7/3 - 0.5) / (7/3)
+		{"long odds", Bet{Prob: 0.5, Price: 30}, 2.0 / 7},
+		{"no edge", Bet{Prob: 0.4, Price: 50}, 0},
+		// 2¢ of fees turn 55¢ at 56% into a losing bet
+		{"fees", Bet{Prob: 0.56, Price: 55, Fee: 2}, 0},
+		// 5¢ of a win's 50¢ go to fees: odds of 0.9
+		{"winnings fee", Bet{Prob: 0.6, Price: 50, WinFee: 5}, (0.6*0.9 - 0.4) / 0.9},
+		{"free", Bet{Prob: 0.9, Price: 0}, 0},
+	}
+	for _, tt := range tests {
+		if got := KellyFraction(tt.bet); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: KellyFraction() = %.4f, want %.4f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReturnPerDollarHour(t *testing.T) {
+	tests := []struct {
+		name  string
+		bet   Bet
+		hours float64
+		want  float64
+	}{
+		// 96% at 90¢: (0.96*10 - 0.04*90) / 90 = 6.7% over 14 hours
+		{"locked NO", Bet{Prob: 0.96, Price: 90}, 14, 6.0 / 90 / 14},
+		// 60% at 50¢: 20% over 4 hours
+		{"short YES", Bet{Prob: 0.6, Price: 50}, 4, 0.05},
+		{"under an hour", Bet{Prob: 0.6, Price: 50}, 0.25, 0.2},
+		{"losing", Bet{Prob: 0.4, Price: 50}, 2, -0.1},
+		{"free", Bet{Prob: 0.9, Price: 0}, 2, 0},
+	}
+	for _, tt := range tests {
+		if got := ReturnPerDollarHour(tt.bet, tt.hours); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: ReturnPerDollarHour() = %.5f, want %.5f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSizer_Contracts(t *testing.T) {
+	now := time.Date(2025, 12, 5, 10, 0, 0, 0, time.UTC)
+	bet := Bet{Prob: 0.6, Price: 50, Bankroll: 1000}
+
+	tests := []struct {
+		name   string
+		method Method
+		limits Limits
+		want   int
+	}{
+		{"full kelly", Kelly{}, Limits{}, 400},                  // $200
+		{"quarter kelly", Kelly{Fraction: 0.25}, Limits{}, 100}, // $50
+		{"fixed fraction", FixedRisk{Fraction: 0.02}, Limits{}, 40},
+		{"fixed dollars", FixedRisk{Dollars: 10}, Limits{}, 20},
+		{"trade cap", Kelly{}, Limits{MaxTrade: 30}, 60},
+		{"contract cap", Kelly{}, Limits{MaxContracts: 25}, 25},
+		{"bankroll", FixedRisk{Dollars: 5000}, Limits{}, 2000},
+	}
+	for _, tt := range tests {
+		if got := New(tt.method, tt.limits).Contracts(bet, now); got != tt.want {
+			t.Errorf("%s: Contracts() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSizer_DailyBudget(t *testing.T) {
+	now := time.Date(2025, 12, 5, 10, 0, 0, 0, time.UTC)
+	s := New(FixedRisk{Dollars: 40}, Limits{MaxDay: 100})
+	bet := Bet{Prob: 0.6, Price: 50, Bankroll: 1000}
+
+	for i, want := range []int{80, 80, 40, 0} {
+		n := s.Contracts(bet, now)
+		if n != want {
+			t.Errorf("bet %d: Contracts() = %d, want %d", i+1, n, want)
+		}
+		s.Record(float64(n*bet.Price)/100, now)
+	}
+	if s.Spent(now) != 100 {
+		t.Errorf("Spent() = %.2f, want 100", s.Spent(now))
+	}
+
+	// The budget resets the next day
+	tomorrow := now.Add(24 * time.Hour)
+	if n := s.Contracts(bet, tomorrow); n != 80 {
+		t.Errorf("Contracts() the next day = %d, want 80", n)
+	}
+}
+
+func TestParseMethod(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Method
+	}{
+		{"kelly", Kelly{Fraction: 1}},
+		{"kelly:0.25", Kelly{Fraction: 0.25}},
+		{"fixed:0.02", FixedRisk{Fraction: 0.02}},
+		{"fixed:$50", FixedRisk{Dollars: 50}},
+	}
+	for _, tt := range tests {
+		got, err := ParseMethod(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseMethod(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "kelly:2", "fixed", "fixed:$-5", "martingale"} {
+		if _, err := ParseMethod(in); err == nil {
+			t.Errorf("ParseMethod(%q) error = nil, want an error", in)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package stats
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+)
+
+// BootstrapConfig configures bootstrap resampling. Zero fields take the
+// values of DefaultBootstrapConfig.
+type BootstrapConfig struct {
+	Resamples int     // Resampled series drawn
+	Level     float64 // Confidence level of the intervals, e.g. 0.95
+	Seed      uint64  // Seed of the resampling, so reports are reproducible
+}
+
+// DefaultBootstrapConfig returns 95% intervals from 2,000 resamples.
+func DefaultBootstrapConfig() BootstrapConfig {
+	return BootstrapConfig{Resamples: 2000, Level: 0.95, Seed: 1}
+}
+
+// withDefaults fills the zero fields from DefaultBootstrapConfig.
+func (c BootstrapConfig) withDefaults() BootstrapConfig {
+	d := DefaultBootstrapConfig()
+	if c.Resamples <= 0 {
+		c.Resamples = d.Resamples
+	}
+	if c.Level <= 0 || c.Level >= 1 {
+		c.Level = d.Level
+	}
+	if c.Seed == 0 {
+		c.Seed = d.Seed
+	}
+	return c
+}
+
+// rng returns the resampling source for cfg.
+func (c BootstrapConfig) rng() *rand.Rand {
+	return rand.New(rand.NewPCG(c.Seed, c.Seed^0x9e3779b97f4a7c15))
+}
+
+// Interval is an estimate with its confidence interval.
+type Interval struct {
+	Estimate float64 // The statistic of the observed series
+	Lo, Hi   float64 // Bounds of the interval
+	Level    float64 // Confidence level, e.g. 0.95
+}
+
+// Format formats the estimate and bounds with verb, e.g. Format("%.1f%%")
+// gives "62.5% (95% CI 41.2% to 80.0%)".
+func (i Interval) Format(verb string) string {
+	return fmt.Sprintf(verb+" (%.0f%% CI "+verb+" to "+verb+")", i.Estimate, i.Level*100, i.Lo, i.Hi)
+}
+
+// String formats the interval to two decimals.
+func (i Interval) String() string {
+	return i.Format("%.2f")
+}
+
+// Bootstrap returns the percentile bootstrap interval of stat: stat of
+// values, bounded by the percentiles of stat over series resampled from
+// values with replacement. The interval is only as good as the assumption
+// that values are independent draws; with a handful of them it is wide,
+// which is the point.
+func Bootstrap(values []float64, stat func([]float64) float64, cfg BootstrapConfig) Interval {
+	cfg = cfg.withDefaults()
+	iv := Interval{Estimate: stat(values), Level: cfg.Level}
+	if len(values) == 0 {
+		iv.Lo, iv.Hi = iv.Estimate, iv.Estimate
+		return iv
+	}
+
+	rng := cfg.rng()
+	sample := make([]float64, len(values))
+	stats := make([]float64, cfg.Resamples)
+	for i := range stats {
+		for j := range sample {
+			sample[j] = values[rng.IntN(len(values))]
+		}
+		stats[i] = stat(sample)
+	}
+	sort.Float64s(stats)
+	tail := (1 - cfg.Level) / 2 * 100
+	iv.Lo, iv.Hi = Percentile(stats, tail), Percentile(stats, 100-tail)
+	return iv
+}
+
+// Sample is what a strategy's headline metrics are computed from.
+type Sample struct {
+	Profits        []float64 // P&L of each trade
+	Wins           int       // Trades won
+	Daily          []float64 // P&L of each traded day, in date order
+	PeriodsPerYear float64   // Days a year the Sharpe ratio is annualized over; 252 for trading days
+}
+
+// Summary is a strategy's headline metrics with their bootstrap intervals.
+type Summary struct {
+	Trades      int
+	WinRate     Interval // Percentage of winning trades
+	EV          Interval // Mean P&L per trade
+	Sharpe      Interval // Annualized over PeriodsPerYear
+	MaxDrawdown Interval // Of the cumulative daily P&L
+}
+
+// Bootstrap returns the sample's metrics with their intervals. The win
+// rate and EV resample trades, and the Sharpe ratio and drawdown resample
+// days; a resampled drawdown reorders the days, so its interval shows how
+// deep the drawdown could have been with the same days in another order.
+func (s Sample) Bootstrap(cfg BootstrapConfig) Summary {
+	outcomes := make([]float64, len(s.Profits))
+	for i := range min(s.Wins, len(outcomes)) {
+		outcomes[i] = 100
+	}
+	return Summary{
+		Trades:      len(s.Profits),
+		WinRate:     Bootstrap(outcomes, Mean, cfg),
+		EV:          Bootstrap(s.Profits, Mean, cfg),
+		Sharpe:      Bootstrap(s.Daily, func(v []float64) float64 { return Sharpe(v, s.PeriodsPerYear) }, cfg),
+		MaxDrawdown: Bootstrap(s.Daily, MaxDrawdown, cfg),
+	}
+}
@@ -0,0 +1,64 @@
+package stats
+
+import "testing"
+
+func TestBootstrap(t *testing.T) {
+	// 12 wins in 15 trades
+	outcomes := make([]float64, 15)
+	for i := range 12 {
+		outcomes[i] = 1
+	}
+	iv := Bootstrap(outcomes, Mean, BootstrapConfig{})
+	if iv.Estimate != 0.8 || iv.Level != 0.95 {
+		t.Errorf("Estimate, Level = %v, %v, want 0.8, 0.95", iv.Estimate, iv.Level)
+	}
+	if iv.Lo < 0.5 || iv.Lo > 0.7 || iv.Hi < 0.9 || iv.Hi > 1 {
+		t.Errorf("interval = [%v, %v], want about [0.6, 1]", iv.Lo, iv.Hi)
+	}
+	if again := Bootstrap(outcomes, Mean, DefaultBootstrapConfig()); again != iv {
+		t.Errorf("same seed gave %v, then %v", iv, again)
+	}
+
+	// Ten times the trades at the same win rate narrow the interval
+	var many []float64
+	for range 10 {
+		many = append(many, outcomes...)
+	}
+	if wide := Bootstrap(many, Mean, BootstrapConfig{}); wide.Hi-wide.Lo >= (iv.Hi-iv.Lo)/2 {
+		t.Errorf("150 trades: [%v, %v], want much narrower than 15 trades' [%v, %v]", wide.Lo, wide.Hi, iv.Lo, iv.Hi)
+	}
+
+	for _, values := range [][]float64{nil, {5, 5, 5}} {
+		iv := Bootstrap(values, Mean, BootstrapConfig{})
+		if iv.Lo != iv.Estimate || iv.Hi != iv.Estimate {
+			t.Errorf("Bootstrap(%v) = %v, want no spread", values, iv)
+		}
+	}
+}
+
+func TestInterval_Format(t *testing.T) {
+	iv := Interval{Estimate: 62.5, Lo: 41.25, Hi: 80, Level: 0.95}
+	if got, want := iv.Format("%.1f%%"), "62.5% (95% CI 41.2% to 80.0%)"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestSample_Bootstrap(t *testing.T) {
+	s := Sample{
+		Profits:        []float64{10, 10, -20, 10, 10},
+		Wins:           4,
+		Daily:          []float64{20, -20, 20},
+		PeriodsPerYear: 252,
+	}
+	sum := s.Bootstrap(BootstrapConfig{})
+	if sum.Trades != 5 || sum.WinRate.Estimate != 80 || sum.EV.Estimate != 4 || sum.MaxDrawdown.Estimate != 20 {
+		t.Errorf("Bootstrap() = %+v, want 5 trades, 80%% won, $4 EV, $20 drawdown", sum)
+	}
+	if sum.Sharpe.Estimate != Sharpe(s.Daily, 252) {
+		t.Errorf("Sharpe = %v, want %v", sum.Sharpe.Estimate, Sharpe(s.Daily, 252))
+	}
+	// Three losing days in a row are a possible order of the days
+	if sum.MaxDrawdown.Hi < 40 || sum.WinRate.Hi != 100 {
+		t.Errorf("MaxDrawdown.Hi, WinRate.Hi = %v, %v, want at least 40 and 100", sum.MaxDrawdown.Hi, sum.WinRate.Hi)
+	}
+}
@@ -0,0 +1,63 @@
+package stats
+
+// CUSUM is a one-sided (lower) cumulative sum control chart that detects a
+// sustained drop of a series below its expected mean.
+//
+// Observations are standardized against the expected mean and standard
+// deviation; Slack (k) and Threshold (h) are expressed in standard deviations.
+// Typical values are k=0.5 and h=4-5.
+type CUSUM struct {
+	Mean      float64
+	StdDev    float64
+	Slack     float64
+	Threshold float64
+
+	sum float64
+	n   int
+}
+
+// NewCUSUM creates a lower-sided CUSUM detector.
+func NewCUSUM(mean, stdDev, slack, threshold float64) *CUSUM {
+	return &CUSUM{
+		Mean:      mean,
+		StdDev:    stdDev,
+		Slack:     slack,
+		Threshold: threshold,
+	}
+}
+
+// Update adds an observation and reports whether the statistic has crossed
+// the alarm threshold.
+func (c *CUSUM) Update(x float64) bool {
+	c.n++
+	if c.StdDev <= 0 {
+		return false
+	}
+	z := (x - c.Mean) / c.StdDev
+	c.sum += -z - c.Slack
+	if c.sum < 0 {
+		c.sum = 0
+	}
+	return c.sum > c.Threshold
+}
+
+// Value returns the current cumulative statistic.
+func (c *CUSUM) Value() float64 {
+	return c.sum
+}
+
+// Count returns the number of observations seen since the last reset.
+func (c *CUSUM) Count() int {
+	return c.n
+}
+
+// Alarm reports whether the statistic is above the threshold.
+func (c *CUSUM) Alarm() bool {
+	return c.StdDev > 0 && c.sum > c.Threshold
+}
+
+// Reset clears the accumulated statistic.
+func (c *CUSUM) Reset() {
+	c.sum = 0
+	c.n = 0
+}
@@ -0,0 +1,63 @@
+package stats
+
+import "testing"
+
+func TestCUSUM_NoAlarmOnExpectedPerformance(t *testing.T) {
+	c := NewCUSUM(100, 50, 0.5, 5)
+
+	for i := 0; i < 30; i++ {
+		pnl := 100.0
+		if i%2 == 0 {
+			pnl = 50
+		} else {
+			pnl = 150
+		}
+		if c.Update(pnl) {
+			t.Fatalf("Update(%v) alarmed on day %d, want no alarm", pnl, i)
+		}
+	}
+}
+
+func TestCUSUM_AlarmOnSustainedLosses(t *testing.T) {
+	c := NewCUSUM(100, 50, 0.5, 5)
+
+	alarmDay := -1
+	for i := 0; i < 10; i++ {
+		if c.Update(-50) {
+			alarmDay = i
+			break
+		}
+	}
+
+	// Each day contributes (100+50)/50 - 0.5 = 2.5 sigma, so day 3 crosses 5.
+	if alarmDay != 2 {
+		t.Errorf("alarm on day %d, want 2", alarmDay)
+	}
+	if !c.Alarm() {
+		t.Error("Alarm() = false, want true")
+	}
+}
+
+func TestCUSUM_Reset(t *testing.T) {
+	c := NewCUSUM(0, 1, 0.5, 1)
+	c.Update(-10)
+	c.Reset()
+
+	if c.Value() != 0 || c.Count() != 0 {
+		t.Errorf("after Reset Value() = %v, Count() = %d, want 0, 0", c.Value(), c.Count())
+	}
+}
+
+func TestCUSUM_ZeroStdDev(t *testing.T) {
+	c := NewCUSUM(0, 0, 0.5, 1)
+	if c.Update(-1000) {
+		t.Error("Update() alarmed with zero standard deviation")
+	}
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	got := MaxDrawdown([]float64{10, -5, -10, 20, -3})
+	if got != 15 {
+		t.Errorf("MaxDrawdown() = %v, want 15", got)
+	}
+}
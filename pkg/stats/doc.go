@@ -0,0 +1,16 @@
+// Package stats provides the performance statistics shared by the backtests
+// and the live bots.
+//
+// Mean, StdDev, Sharpe, MaxDrawdown and Percentile summarize a series of
+// returns; CUSUM detects a drop in a strategy's daily P&L against its
+// expected mean. Bootstrap and Sample.Bootstrap put confidence intervals
+// around them, so a win rate from 15 trades reads as the range it is.
+// Paired compares two variants of a strategy traded on matched days.
+//
+// # Stability
+//
+// stats is part of the module's public API and follows semantic versioning:
+// exported identifiers keep their meaning within a major version, and
+// renamed ones stay as deprecated wrappers until the next major release.
+// See CHANGELOG.md.
+package stats
@@ -0,0 +1,44 @@
+package stats_test
+
+import (
+	"fmt"
+
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+)
+
+// Summarize a strategy's daily P&L.
+func Example() {
+	daily := []float64{120, -40, 85, 200, -150, 60}
+	fmt.Printf("mean $%.2f, max drawdown $%.2f\n", stats.Mean(daily), stats.MaxDrawdown(daily))
+	// Output: mean $45.83, max drawdown $150.00
+}
+
+// Watch daily P&L for a drop below the backtest's expectation.
+func ExampleCUSUM() {
+	guard := stats.NewCUSUM(268, 400, 0.5, 3)
+	for i, pnl := range []float64{300, -200, -500, -450, -600} {
+		if guard.Update(pnl) {
+			fmt.Printf("alarm on day %d\n", i+1)
+			break
+		}
+	}
+	// Output: alarm on day 4
+}
+
+// Put a win rate from a handful of trades in context.
+func ExampleSample_Bootstrap() {
+	s := stats.Sample{Profits: []float64{40, 35, -60, 42, 38, 41, -55, 39, 44, 37, 36, 40, -58, 41, 43}, Wins: 12}
+	sum := s.Bootstrap(stats.DefaultBootstrapConfig())
+	fmt.Println("win rate", sum.WinRate.Format("%.0f%%"))
+	// Output: win rate 80% (95% CI 60% to 100%)
+}
+
+// Compare a strategy change traded on one day of each pair of days with the
+// unchanged strategy on the other.
+func ExamplePaired() {
+	changed := []float64{140, -20, 95, 230, -95, 75}
+	unchanged := []float64{120, -40, 85, 200, -150, 60}
+	t := stats.Paired(changed, unchanged, stats.DefaultBootstrapConfig())
+	fmt.Printf("%d pairs, ahead in %d, mean difference $%.2f\n", t.Pairs, t.Wins, t.Diff.Estimate)
+	// Output: 6 pairs, ahead in 6, mean difference $25.00
+}
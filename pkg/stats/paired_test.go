@@ -0,0 +1,50 @@
+package stats
+
+import "testing"
+
+func TestPaired(t *testing.T) {
+	b := []float64{120, -40, 85, 200, -150, 60, 30, -80, 110, 15, -20, 90}
+	better := make([]float64, len(b))
+	for i, v := range b {
+		better[i] = v + 40 + float64(i%3*10)
+	}
+
+	tests := []struct {
+		name     string
+		a, b     []float64
+		pairs    int
+		wins     int
+		diff     float64
+		pBelow   float64
+		pAbove   float64
+		diffSign int // Sign the whole interval has (0 = straddles zero)
+	}{
+		{"a ahead", better, b, 12, 12, 50, 0.01, 0, 1},
+		{"b ahead", b, better, 12, 0, -50, 0.01, 0, -1},
+		{"no difference", []float64{10, -10, 20, -20, 5, -5}, []float64{0, 0, 0, 0, 0, 0}, 6, 3, 0, 1.01, 0.9, 0},
+		{"extra pairs ignored", []float64{5, 5, 5}, []float64{5}, 1, 0, 0, 1.01, 0.99, 0},
+		{"empty", nil, nil, 0, 0, 0, 1.01, 0.99, 0},
+	}
+	for _, tt := range tests {
+		got := Paired(tt.a, tt.b, BootstrapConfig{})
+		if got.Pairs != tt.pairs || got.Wins != tt.wins || got.Diff.Estimate != tt.diff {
+			t.Errorf("%s: Pairs, Wins, Diff = %d, %d, %v, want %d, %d, %v", tt.name, got.Pairs, got.Wins, got.Diff.Estimate, tt.pairs, tt.wins, tt.diff)
+		}
+		if got.P >= tt.pBelow || got.P < tt.pAbove {
+			t.Errorf("%s: P = %v, want in [%v, %v)", tt.name, got.P, tt.pAbove, tt.pBelow)
+		}
+		switch {
+		case tt.diffSign > 0 && got.Diff.Lo <= 0, tt.diffSign < 0 && got.Diff.Hi >= 0:
+			t.Errorf("%s: interval %v includes zero", tt.name, got.Diff)
+		case tt.diffSign == 0 && tt.pairs > 1 && (got.Diff.Lo > 0 || got.Diff.Hi < 0):
+			t.Errorf("%s: interval %v excludes zero", tt.name, got.Diff)
+		}
+	}
+
+	if got := Paired(better, b, BootstrapConfig{}); got.T <= 0 {
+		t.Errorf("T = %v, want positive when a is ahead", got.T)
+	}
+	if again, got := Paired(better, b, BootstrapConfig{}), Paired(better, b, BootstrapConfig{}); again != got {
+		t.Errorf("same seed gave %v, then %v", got, again)
+	}
+}
@@ -0,0 +1,69 @@
+package stats
+
+import "math"
+
+// Mean returns the arithmetic mean of values, or 0 for an empty slice.
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// StdDev returns the sample standard deviation of values.
+func StdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	mean := Mean(values)
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)-1))
+}
+
+// Sharpe returns the Sharpe ratio of per-period returns, annualized over
+// periodsPerYear (252 for daily trading results).
+func Sharpe(values []float64, periodsPerYear float64) float64 {
+	sd := StdDev(values)
+	if sd == 0 {
+		return 0
+	}
+	return Mean(values) / sd * math.Sqrt(periodsPerYear)
+}
+
+// MaxDrawdown returns the largest peak-to-trough decline of the cumulative
+// sum of values.
+func MaxDrawdown(values []float64) float64 {
+	cum, peak, maxDD := 0.0, 0.0, 0.0
+	for _, v := range values {
+		cum += v
+		if cum > peak {
+			peak = cum
+		}
+		if dd := peak - cum; dd > maxDD {
+			maxDD = dd
+		}
+	}
+	return maxDD
+}
+
+// Percentile returns the p-th percentile (0-100) of values sorted in
+// ascending order, interpolating linearly between the nearest ranks.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := min(max(p, 0), 100) / 100 * float64(len(sorted)-1)
+	lo := int(rank)
+	if lo >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	frac := rank - float64(lo)
+	return sorted[lo] + frac*(sorted[lo+1]-sorted[lo])
+}
@@ -0,0 +1,201 @@
+package strategy
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+)
+
+// ABUnit is what an A/B test randomizes between its variants
+type ABUnit string
+
+const (
+	// ABDays pairs consecutive days and gives one day of each pair to each
+	// variant at random, in every city at once
+	ABDays ABUnit = "day"
+	// ABCities splits the cities at random each day, half to each variant
+	ABCities ABUnit = "city"
+)
+
+// Variants of an A/B test: A is the strategy as configured, B the change
+// under test
+const (
+	VariantA = "A"
+	VariantB = "B"
+)
+
+// ParseABUnit parses an A/B test unit name ("" means ABDays)
+func ParseABUnit(s string) (ABUnit, error) {
+	switch u := ABUnit(s); u {
+	case "":
+		return ABDays, nil
+	case ABDays, ABCities:
+		return u, nil
+	}
+	return "", fmt.Errorf("unknown A/B test unit %q (want day or city)", s)
+}
+
+// ABTest assigns each city-day to variant A or B at random, so a change to
+// a live strategy can be judged against the unchanged one on the same kind
+// of days rather than on the days before it. The assignment only depends
+// on Seed, the unit and the date, so it survives restarts and can be
+// recomputed for a report
+type ABTest struct {
+	Unit   ABUnit
+	Seed   uint64
+	Cities []string // The cities ABCities splits; any other city gets a coin flip of its own
+}
+
+// ABOutcome is one city-day's realized P&L under the variant it traded
+type ABOutcome struct {
+	City    string  `json:"city"`
+	Date    string  `json:"date"` // Local trading day, YYYY-MM-DD
+	Variant string  `json:"variant"`
+	PnL     float64 `json:"pnl"`
+}
+
+// ABArm totals one variant's city-days
+type ABArm struct {
+	CityDays int     `json:"city_days"`
+	PnL      float64 `json:"pnl"`
+}
+
+// ABReport compares the variants of a test. Test is paired by unit: each
+// pair is the two days of a day pair (P&L summed over the cities), or one
+// day's A cities against its B cities (P&L averaged per city)
+type ABReport struct {
+	Unit ABUnit           `json:"unit"`
+	A    ABArm            `json:"a"`
+	B    ABArm            `json:"b"`
+	Test stats.PairedTest `json:"test"`
+}
+
+func (r ABReport) String() string {
+	return fmt.Sprintf("A $%.2f over %d city-days, B $%.2f over %d; by %s: %s",
+		r.A.PnL, r.A.CityDays, r.B.PnL, r.B.CityDays, r.Unit, r.Test)
+}
+
+// Assign returns the variant the city trades on day (its calendar date)
+func (t ABTest) Assign(city string, day time.Time) string {
+	n := dayNumber(day)
+	if t.Unit == ABCities {
+		cities := slices.Clone(t.Cities)
+		sort.Strings(cities)
+		cities = slices.Compact(cities)
+		i := slices.Index(cities, city)
+		if i < 0 {
+			return variant(t.coin(uint64(n), city))
+		}
+		// With an odd number of cities the spare one goes to A and B on
+		// alternate days
+		rng := rand.New(rand.NewPCG(t.Seed, uint64(n)))
+		half := (len(cities) + int(n&1)) / 2
+		return variant(slices.Index(rng.Perm(len(cities)), i) < half)
+	}
+
+	pair := n >> 1
+	first := t.coin(uint64(pair))
+	return variant((n&1 == 0) == first)
+}
+
+// Compare totals the outcomes by variant and tests the difference between
+// them, pairing outcomes as the unit does. Days with only one variant, as
+// when the bot was down for one day of a pair, are left out of the test
+func (t ABTest) Compare(outcomes []ABOutcome, cfg stats.BootstrapConfig) ABReport {
+	r := ABReport{Unit: t.Unit}
+	for _, o := range outcomes {
+		switch o.Variant {
+		case VariantA:
+			r.A.CityDays++
+			r.A.PnL += o.PnL
+		case VariantB:
+			r.B.CityDays++
+			r.B.PnL += o.PnL
+		}
+	}
+	a, b := t.Pairs(outcomes)
+	r.Test = stats.Paired(a, b, cfg)
+	return r
+}
+
+// Pairs returns the paired P&L of variants A and B, oldest pair first
+func (t ABTest) Pairs(outcomes []ABOutcome) (a, b []float64) {
+	type arms struct {
+		pnl   [2]float64
+		count [2]int
+	}
+	pairs := make(map[int64]*arms)
+	for _, o := range outcomes {
+		day, err := time.Parse("2006-01-02", o.Date)
+		if err != nil {
+			continue
+		}
+		arm := 0
+		switch o.Variant {
+		case VariantA:
+		case VariantB:
+			arm = 1
+		default:
+			continue
+		}
+		key := dayNumber(day)
+		if t.Unit != ABCities {
+			key >>= 1
+		}
+		p, ok := pairs[key]
+		if !ok {
+			p = &arms{}
+			pairs[key] = p
+		}
+		p.pnl[arm] += o.PnL
+		p.count[arm]++
+	}
+
+	keys := make([]int64, 0, len(pairs))
+	for k, p := range pairs {
+		if p.count[0] > 0 && p.count[1] > 0 {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		p := pairs[k]
+		if t.Unit == ABCities {
+			a = append(a, p.pnl[0]/float64(p.count[0]))
+			b = append(b, p.pnl[1]/float64(p.count[1]))
+			continue
+		}
+		a = append(a, p.pnl[0])
+		b = append(b, p.pnl[1])
+	}
+	return a, b
+}
+
+// coin flips a coin seeded by the test's seed and the given values
+func (t ABTest) coin(n uint64, extra ...string) bool {
+	h := fnv.New64a()
+	binary.Write(h, binary.LittleEndian, [2]uint64{t.Seed, n})
+	for _, s := range extra {
+		h.Write([]byte(s))
+	}
+	return h.Sum64()>>63 == 0
+}
+
+// dayNumber counts days from the Unix epoch to day's calendar date
+func dayNumber(day time.Time) int64 {
+	y, m, d := day.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+func variant(a bool) string {
+	if a {
+		return VariantA
+	}
+	return VariantB
+}
@@ -0,0 +1,177 @@
+package strategy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+)
+
+func TestABTest_AssignDays(t *testing.T) {
+	test := ABTest{Unit: ABDays, Seed: 7}
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) // Day 20454, the first of a pair
+	firstA := 0
+	for p := range 200 {
+		day := start.AddDate(0, 0, 2*p)
+		first, second := test.Assign("LAX", day), test.Assign("LAX", day.AddDate(0, 0, 1))
+		if first == second {
+			t.Fatalf("%s: both days of the pair are %s", day.Format("2006-01-02"), first)
+		}
+		if first == VariantA {
+			firstA++
+		}
+		for _, city := range []string{"NYC", "CHI", "MIA"} {
+			if got := test.Assign(city, day); got != first {
+				t.Errorf("%s: %s is %s, LAX %s; want every city on the same variant", day.Format("2006-01-02"), city, got, first)
+			}
+		}
+	}
+	if firstA < 70 || firstA > 130 {
+		t.Errorf("first day of the pair was A in %d of 200 pairs, want about half", firstA)
+	}
+
+	// The calendar date decides, not the instant
+	la, _ := time.LoadLocation("America/Los_Angeles")
+	if got, want := test.Assign("LAX", time.Date(2026, 1, 1, 23, 0, 0, 0, la)), test.Assign("LAX", start); got != want {
+		t.Errorf("late on Jan 1 in LA: %s, want %s", got, want)
+	}
+
+	changed := 0
+	other := ABTest{Unit: ABDays, Seed: 8}
+	for d := range 100 {
+		day := start.AddDate(0, 0, d)
+		if test.Assign("LAX", day) != other.Assign("LAX", day) {
+			changed++
+		}
+	}
+	if changed == 0 {
+		t.Error("another seed gave the same assignment")
+	}
+}
+
+func TestABTest_AssignCities(t *testing.T) {
+	cities := []string{"LAX", "NYC", "CHI", "MIA", "DEN"}
+	test := ABTest{Unit: ABCities, Seed: 3, Cities: cities}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cityA := make(map[string]int)
+	for d := range 300 {
+		day := start.AddDate(0, 0, d)
+		a := 0
+		for _, city := range cities {
+			if test.Assign(city, day) == VariantA {
+				a++
+				cityA[city]++
+			}
+		}
+		if a != 2 && a != 3 {
+			t.Errorf("%s: %d of 5 cities on A, want 2 or 3", day.Format("2006-01-02"), a)
+		}
+	}
+	for _, city := range cities {
+		if cityA[city] < 105 || cityA[city] > 195 {
+			t.Errorf("%s on A %d of 300 days, want about half", city, cityA[city])
+		}
+	}
+
+	// The order the cities are listed in doesn't matter
+	shuffled := ABTest{Unit: ABCities, Seed: 3, Cities: []string{"DEN", "MIA", "CHI", "NYC", "LAX", "LAX"}}
+	for d := range 30 {
+		day := start.AddDate(0, 0, d)
+		for _, city := range cities {
+			if got, want := shuffled.Assign(city, day), test.Assign(city, day); got != want {
+				t.Errorf("%s %s: %s with the cities reordered, want %s", day.Format("2006-01-02"), city, got, want)
+			}
+		}
+	}
+	if v := test.Assign("SEA", start); v != VariantA && v != VariantB {
+		t.Errorf("unlisted city: %q", v)
+	}
+}
+
+func TestABTest_Pairs(t *testing.T) {
+	tests := []struct {
+		name     string
+		unit     ABUnit
+		outcomes []ABOutcome
+		a, b     []float64
+	}{
+		{
+			name: "days",
+			unit: ABDays,
+			outcomes: []ABOutcome{
+				// 2026-01-01 and -02 are a pair, as are -03 and -04
+				{City: "LAX", Date: "2026-01-01", Variant: VariantA, PnL: 10},
+				{City: "NYC", Date: "2026-01-01", Variant: VariantA, PnL: 5},
+				{City: "LAX", Date: "2026-01-02", Variant: VariantB, PnL: -4},
+				{City: "LAX", Date: "2026-01-04", Variant: VariantA, PnL: 7},
+				{City: "NYC", Date: "2026-01-03", Variant: VariantB, PnL: 2},
+			},
+			a: []float64{15, 7},
+			b: []float64{-4, 2},
+		},
+		{
+			name: "day of a pair missing",
+			unit: ABDays,
+			outcomes: []ABOutcome{
+				{City: "LAX", Date: "2026-01-01", Variant: VariantA, PnL: 10},
+				{City: "LAX", Date: "2026-01-03", Variant: VariantB, PnL: 3},
+			},
+		},
+		{
+			name: "cities",
+			unit: ABCities,
+			outcomes: []ABOutcome{
+				{City: "LAX", Date: "2026-01-02", Variant: VariantA, PnL: 10},
+				{City: "NYC", Date: "2026-01-02", Variant: VariantA, PnL: 20},
+				{City: "CHI", Date: "2026-01-02", Variant: VariantB, PnL: 6},
+				{City: "LAX", Date: "2026-01-01", Variant: VariantB, PnL: 1},
+				{City: "NYC", Date: "2026-01-01", Variant: VariantA, PnL: -2},
+				{City: "MIA", Date: "2026-01-03", Variant: VariantA, PnL: 9},
+			},
+			a: []float64{-2, 15},
+			b: []float64{1, 6},
+		},
+		{
+			name: "no variant or bad date",
+			unit: ABCities,
+			outcomes: []ABOutcome{
+				{City: "LAX", Date: "2026-01-01", PnL: 10},
+				{City: "NYC", Date: "Jan 1", Variant: VariantB, PnL: 1},
+				{City: "CHI", Date: "2026-01-01", Variant: VariantA, PnL: 4},
+			},
+		},
+	}
+	for _, tt := range tests {
+		a, b := ABTest{Unit: tt.unit}.Pairs(tt.outcomes)
+		if !reflect.DeepEqual(a, tt.a) || !reflect.DeepEqual(b, tt.b) {
+			t.Errorf("%s: Pairs() = %v, %v, want %v, %v", tt.name, a, b, tt.a, tt.b)
+		}
+	}
+}
+
+func TestABTest_Compare(t *testing.T) {
+	outcomes := []ABOutcome{
+		{City: "LAX", Date: "2026-01-01", Variant: VariantA, PnL: 10},
+		{City: "LAX", Date: "2026-01-02", Variant: VariantB, PnL: -4},
+		{City: "LAX", Date: "2026-01-05", Variant: VariantB, PnL: 3},
+	}
+	r := ABTest{Unit: ABDays}.Compare(outcomes, stats.BootstrapConfig{})
+	if r.A != (ABArm{CityDays: 1, PnL: 10}) || r.B != (ABArm{CityDays: 2, PnL: -1}) {
+		t.Errorf("arms = %+v, %+v, want 1 city-day at $10 and 2 at -$1", r.A, r.B)
+	}
+	if r.Test.Pairs != 1 || r.Test.Diff.Estimate != 14 {
+		t.Errorf("test = %v, want 1 pair 14 apart", r.Test)
+	}
+}
+
+func TestParseABUnit(t *testing.T) {
+	for s, want := range map[string]ABUnit{"": ABDays, "day": ABDays, "city": ABCities} {
+		if got, err := ParseABUnit(s); got != want || err != nil {
+			t.Errorf("ParseABUnit(%q) = %q, %v, want %q", s, got, err, want)
+		}
+	}
+	if _, err := ParseABUnit("week"); err == nil {
+		t.Error("ParseABUnit(week) succeeded")
+	}
+}
@@ -0,0 +1,334 @@
+// Package dualside is the dual-side (YES + NO) strategy the production
+// dualside-bot trades: when the market favorite and the bracket of the
+// running METAR max agree, buy YES on the favorite and NO on the brackets
+// around it. LOW events are traded the same way on the running METAR min
+//
+// The live engine and cmd/weather-strategy/backtest-dualside both drive this
+// Strategy, so what is backtested is exactly what trades. Drivers own sizing
+// limits (cash reserve, EV gate, risk limits); the strategy decides which
+// contracts to buy, at what price and for how much
+package dualside
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// Config configures the strategy
+type Config struct {
+	BetYes      float64 // Dollars on the favorite's YES
+	BetNo       float64 // Dollars on each NO
+	MinYesPrice int     // Favorite YES price range (cents)
+	MaxYesPrice int
+	MinNoPrice  int // NO price range (cents)
+	MaxNoPrice  int
+	MaxNoTrades int // NO orders per event
+
+	// Local trading window: from TradingStartHour up to, not including,
+	// TradingEndHour
+	TradingStartHour int
+	TradingEndHour   int
+
+	// Share of the weighted vote (favorite, METAR and healthy external
+	// signals) that must back the favorite; 1 = unanimous
+	MinSignalAgreement float64
+
+	// What may be done in each lifecycle phase: polling cadence, entry
+	// order types and sizes, and exits (nil = strategy.DefaultPhaseBehaviors)
+	Phases strategy.PhaseBehaviors
+}
+
+// DefaultConfig returns the production defaults (from the optimizer backtest)
+func DefaultConfig() Config {
+	return Config{
+		BetYes:             500,
+		BetNo:              150,
+		MinYesPrice:        50,
+		MaxYesPrice:        95,
+		MinNoPrice:         40,
+		MaxNoPrice:         95,
+		MaxNoTrades:        4,
+		TradingStartHour:   7,
+		TradingEndHour:     14,
+		MinSignalAgreement: 1,
+		Phases:             strategy.DefaultPhaseBehaviors(),
+	}
+}
+
+// Strategy implements strategy.Strategy
+type Strategy struct {
+	config   Config
+	external *strategy.ExternalSignals
+	logf     func(format string, args ...any)
+
+	weather map[string]strategy.WeatherUpdate // City -> latest weather
+	markets map[string]strategy.MarketData    // EventTicker -> latest snapshot
+	traded  map[string]bool                   // EventTicker -> orders emitted
+}
+
+// New creates the strategy
+func New(config Config) *Strategy {
+	return &Strategy{
+		config:  config,
+		logf:    func(string, ...any) {},
+		weather: make(map[string]strategy.WeatherUpdate),
+		markets: make(map[string]strategy.MarketData),
+		traded:  make(map[string]bool),
+	}
+}
+
+// SetExternalSignals lets healthy external predictions vote on the favorite
+// (nil = favorite and METAR only)
+func (s *Strategy) SetExternalSignals(external *strategy.ExternalSignals) {
+	s.external = external
+}
+
+// SetLogger receives the strategy's reasoning for each decision
+func (s *Strategy) SetLogger(logf func(format string, args ...any)) {
+	s.logf = logf
+}
+
+// Schedule returns the station-day lifecycle of the trading window: entries
+// are intraday only, with no forecast entries the day before
+func (s *Strategy) Schedule() strategy.Schedule {
+	return strategy.Schedule{StartHour: s.config.TradingStartHour, EndHour: s.config.TradingEndHour}
+}
+
+// InWindow reports whether local is inside the trading window
+func (s *Strategy) InWindow(local time.Time) bool {
+	return s.Schedule().PhaseAt(local, local) == strategy.PhaseIntraday
+}
+
+// Behavior returns what the strategy may do in phase
+func (s *Strategy) Behavior(phase strategy.Phase) strategy.PhaseBehavior {
+	return s.Phases().For(phase)
+}
+
+// Phases returns the per-phase behaviors
+func (s *Strategy) Phases() strategy.PhaseBehaviors {
+	if s.config.Phases == nil {
+		return strategy.DefaultPhaseBehaviors()
+	}
+	return s.config.Phases
+}
+
+func (s *Strategy) Name() string { return "dualside" }
+
+func (s *Strategy) OnMarketData(data strategy.MarketData) {
+	s.markets[data.EventTicker] = data
+}
+
+func (s *Strategy) OnWeatherUpdate(update strategy.WeatherUpdate) {
+	s.weather[update.City] = update
+}
+
+// GenerateOrders returns the orders for every event whose signals agree,
+// once per event. Each snapshot is decided on once, so an event whose
+// markets were not refreshed since the last call is skipped rather than
+// traded on stale prices. Market data times are read as the city's local
+// time
+func (s *Strategy) GenerateOrders(now time.Time) []strategy.Order {
+	events := make([]string, 0, len(s.markets))
+	for event := range s.markets {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+
+	var orders []strategy.Order
+	for _, event := range events {
+		orders = append(orders, s.decide(s.markets[event], now)...)
+		delete(s.markets, event)
+	}
+	return orders
+}
+
+func (s *Strategy) decide(data strategy.MarketData, now time.Time) []strategy.Order {
+	if s.traded[data.EventTicker] {
+		return nil
+	}
+	// Entries are limit orders, so the phase must allow them
+	behavior := s.Behavior(s.Schedule().PhaseAt(data.Time, data.Time))
+	if !behavior.AllowsOrder(strategy.OrderTypeLimit) {
+		return nil
+	}
+
+	brackets := priced(data)
+	if len(brackets) == 0 {
+		s.logf("%s: No priced brackets", city(data))
+		return nil
+	}
+	favorite := brackets[0]
+
+	// HIGH events settle on the day's maximum, LOW events on its minimum
+	marketType, extreme := weather.MarketTypeHigh, "max"
+	if data.IsLow() {
+		marketType, extreme = weather.MarketTypeLow, "min"
+	}
+	w, ok := s.weather[data.City]
+	if !ok {
+		s.logf("%s: No METAR %s yet", city(data), extreme)
+		return nil
+	}
+
+	// The event's own strikes, not the usual 2° layout, decide which
+	// bracket the METAR extreme settles and how many NOs cover the others
+	strikes, layout := layoutOf(data)
+	if len(layout.Overlaps) > 0 {
+		s.logf("%s: Unexpected bracket layout (%s: %v), skipping", city(data), layout, layout.Err())
+		return nil
+	}
+	if !layout.Standard() {
+		s.logf("%s: Non-standard bracket layout (%s)", city(data), layout)
+	}
+	metarTemp := market.RoundNearest.Apply(w.Running(marketType))
+	i := strikes.Index(w.Running(marketType), market.RoundNearest)
+	if i < 0 {
+		s.logf("%s: METAR %s %d° settles no listed bracket (%v), skipping", city(data), extreme, metarTemp, layout.Err())
+		return nil
+	}
+	metarTicker := data.Quotes[i].Ticker
+
+	// The favorite and METAR must agree, and healthy external signals vote
+	// too, weighted by their configured weight and health
+	support, total := 2.0, 2.0
+	for _, m := range s.external.Members(data.City, marketType, data.Time.Format("2006-01-02"), now) {
+		p := m.Prediction
+		if !s.external.Healthy(m) {
+			s.logf("%s: External signal %s excluded (health %.2f %v)", city(data), p.Source, m.Health.Score, m.Health.Reasons)
+			continue
+		}
+		q := data.QuoteFor(int(math.Round(p.Temperature)))
+		weight := m.Weight * m.Health.Score
+		total += weight
+		if q != nil && q.Ticker == favorite.Ticker {
+			support += weight
+		}
+		s.logf("%s: External %s=%.1f°→%s (weight %.2f)", city(data), p.Source, p.Temperature, label(q), weight)
+	}
+	agree := favorite.Ticker == metarTicker && support/total >= s.config.MinSignalAgreement
+
+	s.logf("%s: Fav=%s@%d¢ METAR %s=%d°→%s Support=%.0f%% Agree=%v",
+		city(data), label(&favorite), favorite.YesBid, extreme, metarTemp, label(data.QuoteFor(metarTemp)), support/total*100, agree)
+
+	if !agree {
+		s.logf("%s: Signals don't agree, skipping", city(data))
+		return nil
+	}
+	if favorite.YesBid < s.config.MinYesPrice || favorite.YesBid > s.config.MaxYesPrice {
+		s.logf("%s: YES price %d¢ out of range [%d-%d]",
+			city(data), favorite.YesBid, s.config.MinYesPrice, s.config.MaxYesPrice)
+		return nil
+	}
+
+	s.traded[data.EventTicker] = true
+	reason := fmt.Sprintf("favorite and METAR %s %d° agree", extreme, metarTemp)
+	return s.orders(data, brackets, layout, behavior, reason)
+}
+
+// Plan returns the orders the strategy would place on the event in data if
+// the METAR and external signals backed the favorite now, at intraday
+// sizes, for a morning briefing; nil if the favorite's price is out of range
+// or its brackets overlap
+func (s *Strategy) Plan(data strategy.MarketData) []strategy.Order {
+	brackets := priced(data)
+	if len(brackets) == 0 {
+		return nil
+	}
+	_, layout := layoutOf(data)
+	if price := brackets[0].YesBid; len(layout.Overlaps) > 0 || price < s.config.MinYesPrice || price > s.config.MaxYesPrice {
+		return nil
+	}
+	return s.orders(data, brackets, layout, s.Behavior(strategy.PhaseIntraday), "morning plan")
+}
+
+// orders returns YES on the favorite, brackets[0], and NO on the brackets
+// it beats
+func (s *Strategy) orders(data strategy.MarketData, brackets []strategy.Quote, layout market.Layout, behavior strategy.PhaseBehavior, reason string) []strategy.Order {
+	favorite := brackets[0]
+
+	// 1. BUY YES on the favorite
+	orders := []strategy.Order{{
+		EventTicker: data.EventTicker,
+		Ticker:      favorite.Ticker,
+		Side:        "yes",
+		Action:      "buy",
+		Price:       favorite.YesBid,
+		Quantity:    behavior.Size(strategy.ContractsFor(s.config.BetYes, favorite.YesBid)),
+		Reason:      reason,
+	}}
+
+	// 2. BUY NO on the brackets it beats, as many as cover the degrees
+	// MaxNoTrades standard brackets would
+	maxNo := layout.Scale(s.config.MaxNoTrades)
+	for _, b := range brackets[1:] {
+		if len(orders)-1 >= maxNo {
+			break
+		}
+		noPrice := 100 - b.YesBid
+		if b.NoBid > 0 {
+			noPrice = b.NoBid
+		}
+		if noPrice < s.config.MinNoPrice || noPrice > s.config.MaxNoPrice {
+			continue
+		}
+		orders = append(orders, strategy.Order{
+			EventTicker: data.EventTicker,
+			Ticker:      b.Ticker,
+			Side:        "no",
+			Action:      "buy",
+			Price:       noPrice,
+			Quantity:    behavior.Size(strategy.ContractsFor(s.config.BetNo, noPrice)),
+			Reason:      reason,
+		})
+	}
+	return orders
+}
+
+// priced returns the brackets still trading with a YES bid, favorite first
+func priced(data strategy.MarketData) []strategy.Quote {
+	var brackets []strategy.Quote
+	for _, q := range data.Quotes {
+		if !q.Determined && q.YesBid > 0 {
+			brackets = append(brackets, q)
+		}
+	}
+	sort.SliceStable(brackets, func(i, j int) bool { return brackets[i].YesBid > brackets[j].YesBid })
+	return brackets
+}
+
+// layoutOf returns the strikes of the event's brackets, index-aligned with
+// its quotes, and their layout
+func layoutOf(data strategy.MarketData) (market.Strikes, market.Layout) {
+	strikes := make(market.Strikes, len(data.Quotes))
+	for i, q := range data.Quotes {
+		strikes[i] = market.Strike{Floor: q.Floor, Cap: q.Cap}
+	}
+	return strikes, market.InferLayout(strikes)
+}
+
+// city names an event's city in the log, e.g. "DEN" or "DEN LOW"
+func city(data strategy.MarketData) string {
+	if data.IsLow() {
+		return data.City + " LOW"
+	}
+	return data.City
+}
+
+// label formats a bracket as the bots log it, e.g. "62-63°"
+func label(q *strategy.Quote) string {
+	switch {
+	case q == nil:
+		return ""
+	case q.Floor == strategy.OpenFloor:
+		return fmt.Sprintf("≤%d°", q.Cap)
+	case q.Cap == strategy.OpenCap:
+		return fmt.Sprintf("≥%d°", q.Floor)
+	}
+	return fmt.Sprintf("%d-%d°", q.Floor, q.Cap)
+}
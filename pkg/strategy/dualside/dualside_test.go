@@ -0,0 +1,251 @@
+package dualside
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest/fixtures"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+var la, _ = time.LoadLocation("America/Los_Angeles")
+
+// snapshot quotes a LAX event with the favorite on 60-61°
+func snapshot(hour int) strategy.MarketData {
+	quote := func(ticker string, floor, cap, yesBid int) strategy.Quote {
+		return strategy.Quote{Ticker: ticker, Floor: floor, Cap: cap,
+			YesBid: yesBid, YesAsk: yesBid + 2, NoBid: 98 - yesBid, NoAsk: 100 - yesBid}
+	}
+	return strategy.MarketData{
+		Time:        time.Date(2025, 12, 5, hour, 0, 0, 0, la),
+		City:        "LAX",
+		EventTicker: "KXHIGHLAX-25DEC05",
+		Quotes: []strategy.Quote{
+			quote("T58", strategy.OpenFloor, 57, 2),
+			quote("B58.5", 58, 59, 10),
+			quote("B60.5", 60, 61, 70),
+			quote("B62.5", 62, 63, 15),
+			quote("T63", 64, strategy.OpenCap, 3),
+		},
+	}
+}
+
+func weatherAt(hour int, maxTemp float64) strategy.WeatherUpdate {
+	return strategy.WeatherUpdate{Time: time.Date(2025, 12, 5, hour, 0, 0, 0, la), City: "LAX", MaxTempF: maxTemp}
+}
+
+func TestStrategy_Agree(t *testing.T) {
+	s := New(DefaultConfig())
+	s.OnWeatherUpdate(weatherAt(10, 61))
+	s.OnMarketData(snapshot(10))
+
+	orders := s.GenerateOrders(snapshot(10).Time)
+	if len(orders) != 4 {
+		t.Fatalf("GenerateOrders() = %d orders, want YES + 3 NO: %+v", len(orders), orders)
+	}
+	if o := orders[0]; o.Ticker != "B60.5" || o.Side != "yes" || o.Price != 70 || o.Quantity != 714 {
+		t.Errorf("orders[0] = %+v, want YES B60.5 714 @ 70¢", o)
+	}
+	// NO on the others by YES price; T58's NO at 96¢ is too rich
+	if o := orders[1]; o.Ticker != "B62.5" || o.Side != "no" || o.Price != 83 || o.Quantity != 180 {
+		t.Errorf("orders[1] = %+v, want NO B62.5 180 @ 83¢", o)
+	}
+	if o := orders[2]; o.Ticker != "B58.5" || o.Side != "no" || o.Price != 88 {
+		t.Errorf("orders[2] = %+v, want NO B58.5 @ 88¢", o)
+	}
+	if o := orders[3]; o.Ticker != "T63" || o.Side != "no" || o.Price != 95 {
+		t.Errorf("orders[3] = %+v, want NO T63 @ 95¢", o)
+	}
+
+	// Once per event, even with fresh data
+	s.OnMarketData(snapshot(11))
+	if orders := s.GenerateOrders(snapshot(11).Time); len(orders) != 0 {
+		t.Errorf("second GenerateOrders() = %+v, want none", orders)
+	}
+}
+
+func TestStrategy_Low(t *testing.T) {
+	s := New(DefaultConfig())
+	w := weatherAt(10, 68)
+	w.MinTempF = 60.4
+	s.OnWeatherUpdate(w)
+
+	// The city's HIGH and LOW events are decided separately: the max of 68°
+	// misses the HIGH favorite, the min of 60° backs the LOW one
+	high := snapshot(10)
+	low := snapshot(10)
+	low.EventTicker, low.Type = "KXLOWTLAX-25DEC05", weather.MarketTypeLow
+	s.OnMarketData(high)
+	s.OnMarketData(low)
+
+	orders := s.GenerateOrders(low.Time)
+	if len(orders) != 4 {
+		t.Fatalf("GenerateOrders() = %d orders, want the LOW event's YES + 3 NO: %+v", len(orders), orders)
+	}
+	for _, o := range orders {
+		if o.EventTicker != low.EventTicker {
+			t.Errorf("order %+v, want only %s", o, low.EventTicker)
+		}
+	}
+	if o := orders[0]; o.Ticker != "B60.5" || o.Side != "yes" {
+		t.Errorf("orders[0] = %+v, want YES B60.5", o)
+	}
+}
+
+func TestStrategy_Skips(t *testing.T) {
+	tests := []struct {
+		name    string
+		hour    int
+		maxTemp float64
+		config  func(*Config)
+	}{
+		{"METAR disagrees", 10, 63, nil},
+		{"before the window", 6, 61, nil},
+		{"after the window", 14, 61, nil},
+		{"favorite too cheap", 10, 61, func(c *Config) { c.MinYesPrice = 75 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			if tt.config != nil {
+				tt.config(&cfg)
+			}
+			s := New(cfg)
+			s.OnWeatherUpdate(weatherAt(tt.hour, tt.maxTemp))
+			s.OnMarketData(snapshot(tt.hour))
+			if orders := s.GenerateOrders(snapshot(tt.hour).Time); len(orders) != 0 {
+				t.Errorf("GenerateOrders() = %+v, want none", orders)
+			}
+		})
+	}
+}
+
+func TestStrategy_StaleSnapshot(t *testing.T) {
+	s := New(DefaultConfig())
+	s.OnWeatherUpdate(weatherAt(10, 63)) // Disagrees
+	s.OnMarketData(snapshot(10))
+	s.GenerateOrders(snapshot(10).Time)
+
+	// The METAR catches up but the markets were not refreshed
+	s.OnWeatherUpdate(weatherAt(11, 61))
+	if orders := s.GenerateOrders(snapshot(11).Time); len(orders) != 0 {
+		t.Errorf("GenerateOrders() on a stale snapshot = %+v, want none", orders)
+	}
+}
+
+func TestStrategy_Plan(t *testing.T) {
+	// Before the window and without any METAR, the plan is what agreement
+	// would buy
+	s := New(DefaultConfig())
+	orders := s.Plan(snapshot(6))
+	if len(orders) != 4 || orders[0].Ticker != "B60.5" || orders[0].Quantity != 714 || orders[3].Ticker != "T63" {
+		t.Errorf("Plan() = %+v, want YES B60.5 714 @ 70¢ + 3 NO", orders)
+	}
+	// Planning decides nothing
+	s.OnWeatherUpdate(weatherAt(10, 61))
+	s.OnMarketData(snapshot(10))
+	if orders := s.GenerateOrders(snapshot(10).Time); len(orders) != 4 {
+		t.Errorf("GenerateOrders() after Plan() = %d orders, want 4", len(orders))
+	}
+
+	cfg := DefaultConfig()
+	cfg.MinYesPrice = 75
+	if orders := New(cfg).Plan(snapshot(6)); len(orders) != 0 {
+		t.Errorf("Plan() with the favorite too cheap = %+v, want none", orders)
+	}
+}
+
+func TestStrategy_Layouts(t *testing.T) {
+	// 4° brackets: two NOs cover what four would on 2° brackets
+	wide := snapshot(10)
+	wide.Quotes = []strategy.Quote{
+		{Ticker: "T56", Floor: strategy.OpenFloor, Cap: 55, YesBid: 2},
+		{Ticker: "B57.5", Floor: 56, Cap: 59, YesBid: 10},
+		{Ticker: "B61.5", Floor: 60, Cap: 63, YesBid: 70},
+		{Ticker: "B65.5", Floor: 64, Cap: 67, YesBid: 15},
+		{Ticker: "T67", Floor: 68, Cap: strategy.OpenCap, YesBid: 3},
+	}
+	s := New(DefaultConfig())
+	s.OnWeatherUpdate(weatherAt(10, 62))
+	s.OnMarketData(wide)
+	orders := s.GenerateOrders(wide.Time)
+	if len(orders) != 3 || orders[0].Ticker != "B61.5" || orders[1].Ticker != "B65.5" || orders[2].Ticker != "B57.5" {
+		t.Errorf("GenerateOrders() on 4° brackets = %+v, want YES B61.5 + NO B65.5, B57.5", orders)
+	}
+
+	// Overlapping brackets, or a METAR extreme no bracket contains, are
+	// skipped rather than misread
+	overlap := snapshot(10)
+	overlap.Quotes = append(overlap.Quotes, strategy.Quote{Ticker: "X", Floor: 61, Cap: 62, YesBid: 5})
+	gap := snapshot(10)
+	gap.Quotes = append(gap.Quotes[:3:3], gap.Quotes[4])
+	for _, tt := range []struct {
+		name    string
+		data    strategy.MarketData
+		maxTemp float64
+	}{{"overlap", overlap, 61}, {"gap", gap, 62}} {
+		s := New(DefaultConfig())
+		s.OnWeatherUpdate(weatherAt(10, tt.maxTemp))
+		s.OnMarketData(tt.data)
+		if orders := s.GenerateOrders(tt.data.Time); len(orders) != 0 {
+			t.Errorf("GenerateOrders() on %s = %+v, want none", tt.name, orders)
+		}
+	}
+}
+
+func TestStrategy_Backtest(t *testing.T) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := backtest.Run(ds, New(DefaultConfig()), backtest.DefaultConfig())
+	if len(r.Trades) == 0 {
+		t.Fatal("Run() made no trades")
+	}
+	days := make(map[string]bool)
+	for _, tr := range r.Trades {
+		if tr.Time.Hour() < 7 || tr.Time.Hour() >= 14 {
+			t.Errorf("trade at %s, outside the 7-14 window", tr.Time.Format("15:04"))
+		}
+		days[tr.EventTicker] = true
+	}
+	if yes := countSide(r.Trades, "yes"); yes != len(days) {
+		t.Errorf("%d YES trades over %d events, want one per event", yes, len(days))
+	}
+}
+
+func countSide(trades []backtest.Trade, side string) int {
+	n := 0
+	for _, t := range trades {
+		if t.Side == side {
+			n++
+		}
+	}
+	return n
+}
+
+func TestStrategy_PhaseBehaviors(t *testing.T) {
+	cfg := DefaultConfig()
+	phases, err := strategy.ParsePhaseBehaviors("INTRADAY:size=0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Phases = phases
+	s := New(cfg)
+	s.OnWeatherUpdate(weatherAt(10, 61))
+	s.OnMarketData(snapshot(10))
+	if orders := s.GenerateOrders(snapshot(10).Time); len(orders) == 0 || orders[0].Quantity != 357 {
+		t.Errorf("GenerateOrders() at half size = %+v, want YES 357", orders)
+	}
+
+	// A window phase without limit entries places nothing
+	cfg.Phases, _ = strategy.ParsePhaseBehaviors("INTRADAY:orders=market")
+	s = New(cfg)
+	s.OnWeatherUpdate(weatherAt(10, 61))
+	s.OnMarketData(snapshot(10))
+	if orders := s.GenerateOrders(snapshot(10).Time); len(orders) != 0 {
+		t.Errorf("GenerateOrders() without limit entries = %+v, want none", orders)
+	}
+}
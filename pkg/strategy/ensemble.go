@@ -15,6 +15,7 @@ type EnsembleConfig struct {
 	MaxBuyPrice   int     // Maximum price to buy at (cents)
 	MinBuyPrice   int     // Minimum price to buy at (cents)
 	BetSize       float64 // Position size in dollars
+	Health        *HealthConfig // Signal health thresholds (nil = DefaultHealthConfig)
 }
 
 // DefaultEnsembleConfig returns the default 3-signal ensemble configuration
@@ -34,7 +35,10 @@ type EnsembleResult struct {
 	MarketType    weather.MarketType
 	Date          time.Time
 	Signals       []*Signal
-	Agreement     map[string]int // Bracket -> count of signals
+	Agreement     map[string]int     // Bracket -> count of healthy signals
+	Weights       map[string]float64 // Bracket -> sum of healthy signals' health scores times their weights
+	Health        map[string]Health  // Signal name -> health
+	Excluded      []*Signal          // Signals too unhealthy to vote
 	Recommendation *TradeRecommendation
 }
 
@@ -72,26 +76,48 @@ func (e *Ensemble) Analyze(station *weather.Station, marketType weather.MarketTy
 		MarketType: marketType,
 		Date:       date,
 		Agreement:  make(map[string]int),
+		Weights:    make(map[string]float64),
+		Health:     make(map[string]Health),
 	}
 
-	// Generate signals from all sources
+	health := DefaultHealthConfig()
+	if e.Config.Health != nil {
+		health = *e.Config.Health
+	}
+	now := time.Now()
+
+	// Generate signals from all sources; unhealthy ones don't vote
+	var totalWeight float64
 	for _, source := range e.Config.SignalSources {
+		weight := sourceWeight(source)
+		totalWeight += weight
 		signal, err := source.Generate(station, marketType, date, tm)
 		if err != nil {
 			// Log but continue - some signals may fail
 			continue
 		}
 		result.Signals = append(result.Signals, signal)
+
+		h := health.Score(signal.Quality(now))
+		result.Health[signal.Name] = h
+		if !health.Healthy(h) {
+			result.Excluded = append(result.Excluded, signal)
+			continue
+		}
 		result.Agreement[signal.Bracket]++
+		result.Weights[signal.Bracket] += h.Score * weight
 	}
 
-	// Find the bracket with most agreement
+	// Find the bracket with most agreement, breaking ties by health
 	var bestBracket string
 	var bestCount int
+	var bestWeight float64
 	for bracket, count := range result.Agreement {
-		if count > bestCount {
+		weight := result.Weights[bracket]
+		if count > bestCount || (count == bestCount && weight > bestWeight) {
 			bestBracket = bracket
 			bestCount = count
+			bestWeight = weight
 		}
 	}
 
@@ -148,8 +174,9 @@ func (e *Ensemble) Analyze(station *weather.Station, marketType weather.MarketTy
 	}
 
 	// Calculate expected edge
-	// With N signals agreeing, our confidence is approximately N/total
-	confidence := float64(bestCount) / float64(len(e.Config.SignalSources))
+	// With N healthy signals agreeing, our confidence is approximately their
+	// summed weighted health over the total weight
+	confidence := bestWeight / totalWeight
 	expectedEdge := (confidence * 100) - float64(targetBracket.YesPrice)
 
 	// Calculate quantity
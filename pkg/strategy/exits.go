@@ -0,0 +1,205 @@
+package strategy
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ExitRules close held positions before settlement with sell orders at the
+// held side's bid. Zero fields turn a rule off
+type ExitRules struct {
+	TakeProfit  int     // Sell once the held side is bid at or above this (cents)
+	StopLoss    int     // Sell once the held side is bid at or below this (cents)
+	MinProb     float64 // Sell once the model gives the held side less than this (0-1)
+	FlattenHour int     // Sell whatever is still held from this local hour on
+}
+
+// Enabled reports whether any rule is on
+func (r ExitRules) Enabled() bool {
+	return r.TakeProfit > 0 || r.StopLoss > 0 || r.MinProb > 0 || r.FlattenHour > 0
+}
+
+// Check returns the first rule, of those the phase behavior b allows, that
+// closes a position on side of q at the local time now, with the bid to sell
+// at. prob is the model's probability that the held side wins (negative =
+// no view). Rules are checked in the order take-profit, stop-loss, model,
+// flatten. A determined market, or a side without a bid, can't be sold
+func (r ExitRules) Check(b PhaseBehavior, side string, q Quote, prob float64, now time.Time) (Exit, int, bool) {
+	bid := q.YesBid
+	if side == "no" {
+		bid = q.NoBid
+	}
+	if q.Determined || bid <= 0 {
+		return "", 0, false
+	}
+
+	switch {
+	case r.TakeProfit > 0 && bid >= r.TakeProfit && b.AllowsExit(ExitTakeProfit):
+		return ExitTakeProfit, bid, true
+	case r.StopLoss > 0 && bid <= r.StopLoss && b.AllowsExit(ExitStopLoss):
+		return ExitStopLoss, bid, true
+	case r.MinProb > 0 && prob >= 0 && prob < r.MinProb && b.AllowsExit(ExitModel):
+		return ExitModel, bid, true
+	case r.FlattenHour > 0 && now.Hour() >= r.FlattenHour && b.AllowsExit(ExitFlatten):
+		return ExitFlatten, bid, true
+	}
+	return "", 0, false
+}
+
+// ProbabilityFunc returns the model's probability that bracket q of the
+// event in data settles YES given the weather w, or a negative number when
+// the model has no view
+type ProbabilityFunc func(data MarketData, w WeatherUpdate, q Quote) float64
+
+// Phased is implemented by strategies whose behavior follows the
+// station-day lifecycle
+type Phased interface {
+	Schedule() Schedule
+	Behavior(phase Phase) PhaseBehavior
+}
+
+// Exits wraps a strategy with exit rules, so any buy-and-hold strategy can
+// be run live or backtested with them. It tracks the positions opened from
+// the driver's fills and, at each decision point, sells those a rule closes
+// ahead of the wrapped strategy's own orders. The phases of a Phased
+// strategy gate the rules as they gate its entries; otherwise every rule may
+// run at any time. A sell that has not filled by the next decision point is
+// sent again if the rule still holds
+type Exits struct {
+	Strategy
+	rules ExitRules
+	prob  ProbabilityFunc // nil = the model rule never fires
+
+	weather map[string]WeatherUpdate // City -> latest weather
+	markets map[string]MarketData    // EventTicker -> latest snapshot
+	held    map[string]*holding      // Ticker/side -> open contracts
+}
+
+// holding is the open contracts of one ticker and side
+type holding struct {
+	eventTicker string
+	ticker      string
+	side        string
+	quantity    int
+}
+
+// WithExits wraps s with rules, with prob for the model rule (nil = none)
+func WithExits(s Strategy, rules ExitRules, prob ProbabilityFunc) *Exits {
+	return &Exits{
+		Strategy: s,
+		rules:    rules,
+		prob:     prob,
+		weather:  make(map[string]WeatherUpdate),
+		markets:  make(map[string]MarketData),
+		held:     make(map[string]*holding),
+	}
+}
+
+// Name names the wrapped strategy with exits, e.g. "fade+exits"
+func (x *Exits) Name() string {
+	return x.Strategy.Name() + "+exits"
+}
+
+// Rules returns the exit rules
+func (x *Exits) Rules() ExitRules {
+	return x.rules
+}
+
+func (x *Exits) OnMarketData(data MarketData) {
+	x.markets[data.EventTicker] = data
+	x.Strategy.OnMarketData(data)
+}
+
+func (x *Exits) OnWeatherUpdate(update WeatherUpdate) {
+	x.weather[update.City] = update
+	x.Strategy.OnWeatherUpdate(update)
+}
+
+// OnFill tracks the position and passes the fill on to the wrapped strategy
+// if it observes fills, exit sells included, so it knows what it still holds
+func (x *Exits) OnFill(fill Fill) {
+	key := fill.Ticker + "/" + fill.Side
+	h := x.held[key]
+	if h == nil {
+		h = &holding{eventTicker: fill.EventTicker, ticker: fill.Ticker, side: fill.Side}
+		x.held[key] = h
+	}
+	if fill.Action == "sell" {
+		h.quantity -= fill.Quantity
+	} else {
+		h.quantity += fill.Quantity
+	}
+	if h.quantity <= 0 {
+		delete(x.held, key)
+	}
+
+	if o, ok := x.Strategy.(FillObserver); ok {
+		o.OnFill(fill)
+	}
+}
+
+// GenerateOrders returns the exit sells, then the wrapped strategy's orders
+func (x *Exits) GenerateOrders(now time.Time) []Order {
+	keys := make([]string, 0, len(x.held))
+	for key := range x.held {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var orders []Order
+	for _, key := range keys {
+		if o, ok := x.exit(x.held[key]); ok {
+			orders = append(orders, o)
+		}
+	}
+	return append(orders, x.Strategy.GenerateOrders(now)...)
+}
+
+// exit returns the sell closing h if a rule fires on the event's latest
+// snapshot, read at the snapshot's local time
+func (x *Exits) exit(h *holding) (Order, bool) {
+	data, ok := x.markets[h.eventTicker]
+	if !ok {
+		return Order{}, false
+	}
+	var q *Quote
+	for i := range data.Quotes {
+		if data.Quotes[i].Ticker == h.ticker {
+			q = &data.Quotes[i]
+		}
+	}
+	if q == nil {
+		return Order{}, false
+	}
+
+	b := PhaseBehavior{Exits: []Exit{ExitTakeProfit, ExitStopLoss, ExitModel, ExitFlatten}}
+	if p, ok := x.Strategy.(Phased); ok {
+		b = p.Behavior(p.Schedule().PhaseAt(data.Time, data.Time))
+	}
+
+	prob := -1.0
+	if w, ok := x.weather[data.City]; ok && x.prob != nil {
+		if prob = x.prob(data, w, *q); prob >= 0 && h.side == "no" {
+			prob = 1 - prob
+		}
+	}
+
+	exit, bid, ok := x.rules.Check(b, h.side, *q, prob, data.Time)
+	if !ok {
+		return Order{}, false
+	}
+	reason := fmt.Sprintf("%s exit at %d¢", exit, bid)
+	if exit == ExitModel {
+		reason = fmt.Sprintf("%s exit at %d¢, model %.0f%%", exit, bid, prob*100)
+	}
+	return Order{
+		EventTicker: h.eventTicker,
+		Ticker:      h.ticker,
+		Side:        h.side,
+		Action:      "sell",
+		Price:       bid,
+		Quantity:    h.quantity,
+		Reason:      reason,
+	}, true
+}
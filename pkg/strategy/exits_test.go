@@ -0,0 +1,117 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExitRules_Check(t *testing.T) {
+	rules := ExitRules{TakeProfit: 95, StopLoss: 20, MinProb: 0.3, FlattenHour: 16}
+	all := DefaultPhaseBehaviors().For(PhaseIntraday)
+	at := func(hour int) time.Time { return time.Date(2025, 12, 5, hour, 0, 0, 0, time.UTC) }
+	quote := func(yesBid int) Quote { return Quote{Ticker: "B", YesBid: yesBid, NoBid: 98 - yesBid} }
+
+	tests := []struct {
+		name    string
+		b       PhaseBehavior
+		side    string
+		q       Quote
+		prob    float64
+		now     time.Time
+		want    Exit
+		wantBid int
+	}{
+		{"take-profit", all, "yes", quote(96), 0.9, at(12), ExitTakeProfit, 96},
+		{"take-profit on NO", all, "no", quote(2), 0.9, at(12), ExitTakeProfit, 96},
+		{"stop-loss", all, "yes", quote(20), 0.5, at(12), ExitStopLoss, 20},
+		{"model", all, "yes", quote(45), 0.25, at(12), ExitModel, 45},
+		{"model without a view", all, "yes", quote(45), -1, at(12), "", 0},
+		{"flatten", all, "yes", quote(45), 0.5, at(16), ExitFlatten, 45},
+		{"nothing fires", all, "yes", quote(45), 0.5, at(12), "", 0},
+		{"not allowed in the phase", PhaseBehavior{Exits: []Exit{ExitTakeProfit}}, "yes", quote(20), 0.5, at(12), "", 0},
+		{"determined", all, "yes", Quote{YesBid: 96, Determined: true}, 0.9, at(12), "", 0},
+		{"no bid", all, "yes", Quote{}, 0.1, at(16), "", 0},
+	}
+	for _, tt := range tests {
+		exit, bid, ok := rules.Check(tt.b, tt.side, tt.q, tt.prob, tt.now)
+		if exit != tt.want || bid != tt.wantBid || ok != (tt.want != "") {
+			t.Errorf("%s: Check() = %q, %d, %v, want %q, %d", tt.name, exit, bid, ok, tt.want, tt.wantBid)
+		}
+	}
+
+	if (ExitRules{}).Enabled() || !(ExitRules{FlattenHour: 15}).Enabled() {
+		t.Error("Enabled() wrong")
+	}
+}
+
+// holder buys the first snapshot's B bracket once and observes its fills
+type holder struct {
+	bought bool
+	fills  []Fill
+}
+
+func (s *holder) Name() string                         { return "holder" }
+func (s *holder) OnMarketData(data MarketData)         {}
+func (s *holder) OnWeatherUpdate(update WeatherUpdate) {}
+func (s *holder) OnFill(fill Fill)                     { s.fills = append(s.fills, fill) }
+
+func (s *holder) GenerateOrders(now time.Time) []Order {
+	if s.bought {
+		return nil
+	}
+	s.bought = true
+	return []Order{{EventTicker: "E", Ticker: "B", Side: "no", Action: "buy", Price: 60, Quantity: 10}}
+}
+
+func TestExits(t *testing.T) {
+	h := &holder{}
+	prob := func(data MarketData, w WeatherUpdate, q Quote) float64 {
+		if w.MaxTempF >= 64 {
+			return 0.8 // YES on B is now likely: NO is at 20%
+		}
+		return 0.3
+	}
+	x := WithExits(h, ExitRules{MinProb: 0.5}, prob)
+	if x.Name() != "holder+exits" {
+		t.Errorf("Name() = %q", x.Name())
+	}
+	snapshot := func(hour int) MarketData {
+		return MarketData{Time: time.Date(2025, 12, 5, hour, 0, 0, 0, time.UTC), City: "LAX", EventTicker: "E",
+			Quotes: []Quote{{Ticker: "B", YesBid: 40, YesAsk: 42, NoBid: 58, NoAsk: 60}}}
+	}
+
+	x.OnWeatherUpdate(WeatherUpdate{City: "LAX", MaxTempF: 60})
+	x.OnMarketData(snapshot(10))
+	orders := x.GenerateOrders(snapshot(10).Time)
+	if len(orders) != 1 || orders[0].Action != "buy" {
+		t.Fatalf("first GenerateOrders() = %+v, want the entry", orders)
+	}
+	x.OnFill(Fill{EventTicker: "E", Ticker: "B", Side: "no", Action: "buy", Price: 60, Quantity: 10})
+
+	// The model backs NO at 70%: held
+	x.OnMarketData(snapshot(11))
+	if orders := x.GenerateOrders(snapshot(11).Time); len(orders) != 0 {
+		t.Errorf("GenerateOrders() = %+v, want none while the model backs the position", orders)
+	}
+
+	// Then gives it 20%: sold at the NO bid, and sent again until it fills
+	x.OnWeatherUpdate(WeatherUpdate{City: "LAX", MaxTempF: 64})
+	for range 2 {
+		x.OnMarketData(snapshot(12))
+		orders = x.GenerateOrders(snapshot(12).Time)
+		if len(orders) != 1 {
+			t.Fatalf("GenerateOrders() = %+v, want one sell", orders)
+		}
+		if o := orders[0]; o.Action != "sell" || o.Side != "no" || o.Price != 58 || o.Quantity != 10 {
+			t.Errorf("sell = %+v, want NO 10 @ 58", o)
+		}
+	}
+
+	x.OnFill(Fill{EventTicker: "E", Ticker: "B", Side: "no", Action: "sell", Price: 58, Quantity: 10})
+	if orders := x.GenerateOrders(snapshot(13).Time); len(orders) != 0 {
+		t.Errorf("GenerateOrders() after the sell = %+v, want none", orders)
+	}
+	if len(h.fills) != 2 {
+		t.Errorf("wrapped strategy saw %d fills, want 2", len(h.fills))
+	}
+}
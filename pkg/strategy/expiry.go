@@ -0,0 +1,120 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNearExpiry is returned for entries refused by an ExpiryGuard
+var ErrNearExpiry = errors.New("too close to market close")
+
+// ExpiryGuard keeps entries away from a market's close. In the last hours
+// the book thins out: spreads widen and an exit moves the price, so new
+// positions are refused within NoEntry of the close and positions still held
+// within ThinBook of it are flagged. The bots' trading windows end well
+// before the close; the guard holds when a window is configured late or a
+// market closes early
+type ExpiryGuard struct {
+	NoEntry  time.Duration // Refuse entries this close to the close (0 = never)
+	ThinBook time.Duration // Flag positions held this close to the close (0 = never)
+}
+
+// DefaultExpiryGuard returns the default guard: no entries in the last 30
+// minutes, positions flagged in the last 2 hours
+func DefaultExpiryGuard() ExpiryGuard {
+	return ExpiryGuard{NoEntry: 30 * time.Minute, ThinBook: 2 * time.Hour}
+}
+
+// CheckEntry returns an error wrapping ErrNearExpiry if a position may not be
+// opened at now in a market closing at closes. A zero closes (unknown) passes
+func (g ExpiryGuard) CheckEntry(closes, now time.Time) error {
+	if g.NoEntry <= 0 || closes.IsZero() {
+		return nil
+	}
+	if left := closes.Sub(now); left < g.NoEntry {
+		return fmt.Errorf("%w: %s left, entries stop %s before", ErrNearExpiry, roundLeft(left), g.NoEntry)
+	}
+	return nil
+}
+
+// InThinBook reports whether a position held at now in a market closing at
+// closes is in the thin end-of-day book (and the market still open)
+func (g ExpiryGuard) InThinBook(closes, now time.Time) bool {
+	if g.ThinBook <= 0 || closes.IsZero() {
+		return false
+	}
+	left := closes.Sub(now)
+	return left > 0 && left < g.ThinBook
+}
+
+// String describes the guard for banners
+func (g ExpiryGuard) String() string {
+	entries, thin := "off", "off"
+	if g.NoEntry > 0 {
+		entries = g.NoEntry.String()
+	}
+	if g.ThinBook > 0 {
+		thin = g.ThinBook.String()
+	}
+	return fmt.Sprintf("no entries %s before close, thin-book warning %s before", entries, thin)
+}
+
+// ParseCloseTime parses a market's close_time (RFC 3339); empty or
+// malformed times are zero, which the guard lets through
+func ParseCloseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func roundLeft(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d.Round(time.Second)
+}
+
+// ExpiryWatch flags each held market once as it enters the thin end-of-day
+// book. It is safe for concurrent use
+type ExpiryWatch struct {
+	guard ExpiryGuard
+
+	mu     sync.Mutex
+	warned map[string]time.Time // Ticker -> close time it was flagged for
+}
+
+// NewExpiryWatch creates a watch flagging positions by guard.ThinBook
+func NewExpiryWatch(guard ExpiryGuard) *ExpiryWatch {
+	return &ExpiryWatch{guard: guard, warned: make(map[string]time.Time)}
+}
+
+// Guard returns the guard the watch flags by
+func (w *ExpiryWatch) Guard() ExpiryGuard {
+	return w.guard
+}
+
+// Check reports whether a position in ticker, closing at closes, has just
+// entered the thin book at now: true the first time only. Markets past
+// their close are forgotten
+func (w *ExpiryWatch) Check(ticker string, closes, now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for t, c := range w.warned {
+		if !now.Before(c) {
+			delete(w.warned, t)
+		}
+	}
+	if !w.guard.InThinBook(closes, now) {
+		return false
+	}
+	if c, ok := w.warned[ticker]; ok && c.Equal(closes) {
+		return false
+	}
+	w.warned[ticker] = closes
+	return true
+}
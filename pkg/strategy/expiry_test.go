@@ -0,0 +1,61 @@
+package strategy
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestExpiryGuard_CheckEntry(t *testing.T) {
+	closes := time.Date(2025, 12, 6, 4, 59, 0, 0, time.UTC)
+	g := DefaultExpiryGuard()
+
+	tests := []struct {
+		name   string
+		guard  ExpiryGuard
+		closes time.Time
+		now    time.Time
+		refuse bool
+	}{
+		{"hours left", g, closes, closes.Add(-3 * time.Hour), false},
+		{"at the limit", g, closes, closes.Add(-30 * time.Minute), false},
+		{"inside the limit", g, closes, closes.Add(-29 * time.Minute), true},
+		{"after the close", g, closes, closes.Add(time.Minute), true},
+		{"unknown close", g, time.Time{}, closes, false},
+		{"off", ExpiryGuard{}, closes, closes.Add(-time.Minute), false},
+	}
+	for _, tt := range tests {
+		err := tt.guard.CheckEntry(tt.closes, tt.now)
+		if got := errors.Is(err, ErrNearExpiry); got != tt.refuse {
+			t.Errorf("%s: CheckEntry() = %v, want refused %v", tt.name, err, tt.refuse)
+		}
+	}
+}
+
+func TestExpiryWatch_Check(t *testing.T) {
+	closes := time.Date(2025, 12, 6, 4, 59, 0, 0, time.UTC)
+	w := NewExpiryWatch(DefaultExpiryGuard())
+
+	steps := []struct {
+		ticker string
+		now    time.Time
+		want   bool
+	}{
+		{"A", closes.Add(-3 * time.Hour), false},
+		{"A", closes.Add(-90 * time.Minute), true},
+		{"A", closes.Add(-60 * time.Minute), false}, // Flagged once
+		{"B", closes.Add(-60 * time.Minute), true},
+		{"A", closes.Add(time.Minute), false}, // Closed
+	}
+	for i, s := range steps {
+		if got := w.Check(s.ticker, closes, s.now); got != s.want {
+			t.Errorf("step %d: Check(%s) = %v, want %v", i, s.ticker, got, s.want)
+		}
+	}
+
+	// The next day's market with the same ticker is flagged again
+	next := closes.Add(24 * time.Hour)
+	if !w.Check("A", next, next.Add(-time.Hour)) {
+		t.Errorf("Check(A) the next day = false, want true")
+	}
+}
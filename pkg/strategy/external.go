@@ -0,0 +1,293 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// ErrUnknownSource is returned when a prediction is posted for a source that
+// hasn't been registered
+var ErrUnknownSource = errors.New("unknown external signal source")
+
+// ExternalPrediction is a temperature prediction posted by an external system
+// (e.g. a Python ML model)
+type ExternalPrediction struct {
+	Source      string             `json:"source"`
+	Station     string             `json:"station"`               // Station code (e.g. "LAX")
+	MarketType  weather.MarketType `json:"market_type,omitempty"` // HIGH (default) or LOW
+	Date        string             `json:"date"`                  // Market date, YYYY-MM-DD
+	Temperature float64            `json:"temperature"`           // Predicted °F
+	Confidence  float64            `json:"confidence,omitempty"`  // 0-1, optional
+	ObservedAt  time.Time          `json:"observed_at,omitempty"` // When the prediction was made (default: when received)
+}
+
+// ExternalMember is one source's prediction for a market, as an ensemble
+// member
+type ExternalMember struct {
+	Prediction ExternalPrediction
+	Weight     float64
+	Health     Health
+}
+
+// ExternalSourceStatus is a source's weight and health, for the control API
+type ExternalSourceStatus struct {
+	Source      string    `json:"source"`
+	Weight      float64   `json:"weight"`
+	Posts       int       `json:"posts"`
+	Rejected    int       `json:"rejected"`
+	LastPost    time.Time `json:"last_post,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	Predictions int       `json:"predictions"` // Predictions held, stale ones included
+	Health      Health    `json:"health"`      // Of the freshest prediction
+}
+
+type externalKey struct {
+	source     string
+	station    string
+	marketType weather.MarketType
+	date       string
+}
+
+type externalSource struct {
+	weight    float64
+	posts     int
+	rejected  int
+	lastPost  time.Time
+	lastError string
+}
+
+// ExternalSignals holds predictions posted by registered external sources.
+// Each source is an ensemble member with its own weight; its health decays as
+// its latest prediction ages, like any other signal
+type ExternalSignals struct {
+	mu      sync.RWMutex
+	health  HealthConfig
+	sources map[string]*externalSource
+	preds   map[externalKey]ExternalPrediction
+	now     func() time.Time
+}
+
+// NewExternalSignals registers sources by name with their ensemble weights
+// (1 counts as much as one built-in signal)
+func NewExternalSignals(weights map[string]float64, health HealthConfig) *ExternalSignals {
+	x := &ExternalSignals{
+		health:  health,
+		sources: make(map[string]*externalSource, len(weights)),
+		preds:   make(map[externalKey]ExternalPrediction),
+		now:     time.Now,
+	}
+	for name, w := range weights {
+		x.sources[name] = &externalSource{weight: w}
+	}
+	return x
+}
+
+// Post validates and stores a prediction, replacing the source's previous
+// prediction for the same market
+func (x *ExternalSignals) Post(p ExternalPrediction) (ExternalPrediction, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	src, ok := x.sources[p.Source]
+	if !ok {
+		return p, fmt.Errorf("%w %q", ErrUnknownSource, p.Source)
+	}
+
+	now := x.now()
+	p, err := x.normalize(p, now)
+	if err != nil {
+		src.rejected++
+		src.lastError = err.Error()
+		return p, err
+	}
+
+	x.preds[externalKey{p.Source, p.Station, p.MarketType, p.Date}] = p
+	src.posts++
+	src.lastPost = now
+	src.lastError = ""
+	x.prune(now)
+	return p, nil
+}
+
+func (x *ExternalSignals) normalize(p ExternalPrediction, now time.Time) (ExternalPrediction, error) {
+	p.Station = strings.ToUpper(strings.TrimSpace(p.Station))
+	if weather.StationByCode(p.Station) == nil {
+		return p, fmt.Errorf("unknown station %q", p.Station)
+	}
+
+	p.MarketType = weather.MarketType(strings.ToUpper(string(p.MarketType)))
+	if p.MarketType == "" {
+		p.MarketType = weather.MarketTypeHigh
+	}
+	if p.MarketType != weather.MarketTypeHigh && p.MarketType != weather.MarketTypeLow {
+		return p, fmt.Errorf("market_type must be %s or %s", weather.MarketTypeHigh, weather.MarketTypeLow)
+	}
+
+	if _, err := time.Parse("2006-01-02", p.Date); err != nil {
+		return p, fmt.Errorf("date must be YYYY-MM-DD: %q", p.Date)
+	}
+	if p.Temperature < -80 || p.Temperature > 140 {
+		return p, fmt.Errorf("temperature %.1f°F out of range", p.Temperature)
+	}
+	if p.Confidence < 0 || p.Confidence > 1 {
+		return p, fmt.Errorf("confidence %.2f must be between 0 and 1", p.Confidence)
+	}
+
+	if p.ObservedAt.IsZero() {
+		p.ObservedAt = now
+	} else if p.ObservedAt.After(now.Add(5 * time.Minute)) {
+		return p, fmt.Errorf("observed_at %s is in the future", p.ObservedAt.Format(time.RFC3339))
+	}
+	return p, nil
+}
+
+// prune drops predictions too old to vote. Callers hold the write lock
+func (x *ExternalSignals) prune(now time.Time) {
+	for k, p := range x.preds {
+		if x.health.MaxAge > 0 && now.Sub(p.ObservedAt) >= x.health.MaxAge {
+			delete(x.preds, k)
+		}
+	}
+}
+
+// Members returns every source's prediction for a market, health scored at
+// now and ordered by source. Unhealthy members are included; callers check
+// Healthy. Safe on a nil receiver
+func (x *ExternalSignals) Members(station string, marketType weather.MarketType, date string, now time.Time) []ExternalMember {
+	if x == nil {
+		return nil
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	var members []ExternalMember
+	for name, src := range x.sources {
+		p, ok := x.preds[externalKey{name, station, marketType, date}]
+		if !ok {
+			continue
+		}
+		members = append(members, ExternalMember{
+			Prediction: p,
+			Weight:     src.weight,
+			Health:     x.health.Score(SignalQuality{Age: now.Sub(p.ObservedAt)}),
+		})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Prediction.Source < members[j].Prediction.Source })
+	return members
+}
+
+// Healthy reports whether a member's health clears the minimum score
+func (x *ExternalSignals) Healthy(m ExternalMember) bool {
+	return x.health.Healthy(m.Health)
+}
+
+// Status reports each source's weight, post counts and health, ordered by
+// source. Safe on a nil receiver
+func (x *ExternalSignals) Status(now time.Time) []ExternalSourceStatus {
+	if x == nil {
+		return nil
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	status := make([]ExternalSourceStatus, 0, len(x.sources))
+	for name, src := range x.sources {
+		s := ExternalSourceStatus{
+			Source:    name,
+			Weight:    src.weight,
+			Posts:     src.posts,
+			Rejected:  src.rejected,
+			LastPost:  src.lastPost,
+			LastError: src.lastError,
+			Health:    Health{Score: 0, Reasons: []string{"no predictions"}},
+		}
+		var freshest time.Time
+		for k, p := range x.preds {
+			if k.source != name {
+				continue
+			}
+			s.Predictions++
+			if p.ObservedAt.After(freshest) {
+				freshest = p.ObservedAt
+			}
+		}
+		if !freshest.IsZero() {
+			s.Health = x.health.Score(SignalQuality{Age: now.Sub(freshest)})
+		}
+		status = append(status, s)
+	}
+	sort.Slice(status, func(i, j int) bool { return status[i].Source < status[j].Source })
+	return status
+}
+
+// SignalSources returns one SignalSource per registered source, for use as
+// Ensemble members
+func (x *ExternalSignals) SignalSources() []SignalSource {
+	names := make([]string, 0, len(x.sources))
+	for name := range x.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sources := make([]SignalSource, len(names))
+	for i, name := range names {
+		sources[i] = &ExternalSignal{signals: x, source: name}
+	}
+	return sources
+}
+
+// ExternalSignal is the SignalSource for one external source
+type ExternalSignal struct {
+	signals *ExternalSignals
+	source  string
+}
+
+func (s *ExternalSignal) Name() string { return "External:" + s.source }
+
+// Weight returns the source's configured ensemble weight
+func (s *ExternalSignal) Weight() float64 {
+	s.signals.mu.RLock()
+	defer s.signals.mu.RUnlock()
+	return s.signals.sources[s.source].weight
+}
+
+func (s *ExternalSignal) Generate(station *weather.Station, marketType weather.MarketType, date time.Time, tm *market.TempMarket) (*Signal, error) {
+	code := stationCode(station)
+	s.signals.mu.RLock()
+	p, ok := s.signals.preds[externalKey{s.source, code, marketType, date.Format("2006-01-02")}]
+	s.signals.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no prediction from %s for %s %s", s.source, code, date.Format("2006-01-02"))
+	}
+
+	bracket := tm.GetBracketForTemp(p.Temperature)
+	if bracket == nil {
+		return nil, fmt.Errorf("no bracket found for predicted temp %.1f°F", p.Temperature)
+	}
+
+	return &Signal{
+		Name:        s.Name(),
+		Bracket:     bracket.Description,
+		Ticker:      bracket.Ticker,
+		Temperature: p.Temperature,
+		Confidence:  p.Confidence,
+		ObservedAt:  p.ObservedAt,
+	}, nil
+}
+
+// stationCode returns the short code weather.Stations knows a station by
+func stationCode(station *weather.Station) string {
+	for code, s := range weather.Stations {
+		if s == station || s.ID == station.ID {
+			return code
+		}
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package strategy
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+func TestExternalSignals_Post(t *testing.T) {
+	now := time.Date(2025, 12, 27, 18, 0, 0, 0, time.UTC)
+	x := NewExternalSignals(map[string]float64{"ml": 1}, DefaultHealthConfig())
+	x.now = func() time.Time { return now }
+
+	valid := ExternalPrediction{Source: "ml", Station: "lax", Date: "2025-12-28", Temperature: 64.2}
+	p, err := x.Post(valid)
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if p.Station != "LAX" || p.MarketType != weather.MarketTypeHigh || !p.ObservedAt.Equal(now) {
+		t.Errorf("Post() = %+v, want LAX HIGH observed now", p)
+	}
+
+	if _, err := x.Post(ExternalPrediction{Source: "other", Station: "LAX", Date: "2025-12-28"}); !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("Post(unregistered) error = %v, want ErrUnknownSource", err)
+	}
+
+	invalid := []ExternalPrediction{
+		{Source: "ml", Station: "XYZ", Date: "2025-12-28", Temperature: 60},
+		{Source: "ml", Station: "LAX", MarketType: "MID", Date: "2025-12-28", Temperature: 60},
+		{Source: "ml", Station: "LAX", Date: "12/28/2025", Temperature: 60},
+		{Source: "ml", Station: "LAX", Date: "2025-12-28", Temperature: 600},
+		{Source: "ml", Station: "LAX", Date: "2025-12-28", Temperature: 60, Confidence: 1.5},
+		{Source: "ml", Station: "LAX", Date: "2025-12-28", Temperature: 60, ObservedAt: now.Add(time.Hour)},
+	}
+	for _, p := range invalid {
+		if _, err := x.Post(p); err == nil {
+			t.Errorf("Post(%+v) error = nil, want error", p)
+		}
+	}
+
+	status := x.Status(now)
+	if len(status) != 1 || status[0].Posts != 1 || status[0].Rejected != len(invalid) || status[0].LastError == "" {
+		t.Errorf("Status() = %+v, want 1 post and %d rejected", status, len(invalid))
+	}
+}
+
+func TestExternalSignals_MembersHealth(t *testing.T) {
+	now := time.Date(2025, 12, 27, 18, 0, 0, 0, time.UTC)
+	x := NewExternalSignals(map[string]float64{"ml": 2, "nn": 0.5}, DefaultHealthConfig())
+	x.now = func() time.Time { return now }
+
+	x.Post(ExternalPrediction{Source: "ml", Station: "LAX", Date: "2025-12-28", Temperature: 64, ObservedAt: now.Add(-30 * time.Minute)})
+	x.Post(ExternalPrediction{Source: "nn", Station: "LAX", Date: "2025-12-28", Temperature: 66, ObservedAt: now.Add(-5 * time.Hour)})
+	x.Post(ExternalPrediction{Source: "ml", Station: "NYC", Date: "2025-12-28", Temperature: 40})
+
+	members := x.Members("LAX", weather.MarketTypeHigh, "2025-12-28", now)
+	if len(members) != 2 {
+		t.Fatalf("Members() = %d, want 2", len(members))
+	}
+	if m := members[0]; m.Prediction.Source != "ml" || m.Weight != 2 || m.Health.Score != 1 || !x.Healthy(m) {
+		t.Errorf("members[0] = %+v, want healthy ml with weight 2", m)
+	}
+	if m := members[1]; m.Prediction.Source != "nn" || x.Healthy(m) {
+		t.Errorf("members[1] = %+v, want stale nn excluded", m)
+	}
+
+	if got := x.Members("LAX", weather.MarketTypeLow, "2025-12-28", now); len(got) != 0 {
+		t.Errorf("Members(LOW) = %d, want 0", len(got))
+	}
+	var nilSignals *ExternalSignals
+	if got := nilSignals.Members("LAX", weather.MarketTypeHigh, "2025-12-28", now); got != nil {
+		t.Errorf("nil Members() = %v, want nil", got)
+	}
+}
+
+func TestEnsemble_WeightsExternalSignal(t *testing.T) {
+	date := time.Now()
+	tm := &market.TempMarket{Brackets: []market.Bracket{
+		{Ticker: "A", Description: "60-61°F", LowerBound: 60, UpperBound: 61, YesPrice: 40, NoPrice: 58},
+		{Ticker: "B", Description: "62-63°F", LowerBound: 62, UpperBound: 63, YesPrice: 30, NoPrice: 68},
+	}}
+
+	x := NewExternalSignals(map[string]float64{"ml": 2}, DefaultHealthConfig())
+	if _, err := x.Post(ExternalPrediction{Source: "ml", Station: "LAX", Date: date.Format("2006-01-02"), Temperature: 62.4}); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+
+	config := DefaultEnsembleConfig()
+	config.MinAgreement = 1
+	config.SignalSources = append([]SignalSource{&fixedSignal{name: "fresh", bracket: "60-61°F"}}, x.SignalSources()...)
+
+	result, err := NewEnsembleWithConfig(config).Analyze(weather.StationByCode("LAX"), weather.MarketTypeHigh, date, tm)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	// One vote each, so the external signal's weight breaks the tie
+	rec := result.Recommendation
+	if rec.Action != "BUY" || rec.Ticker != "B" {
+		t.Fatalf("Recommendation = %+v, want BUY B", rec)
+	}
+	// 2 / (1 + 2) total weight
+	if math.Abs(rec.Confidence-2.0/3) > 1e-6 {
+		t.Errorf("Confidence = %v, want 0.667", rec.Confidence)
+	}
+	if _, ok := result.Health["External:ml"]; !ok {
+		t.Errorf("Health = %v, want External:ml tracked", result.Health)
+	}
+}
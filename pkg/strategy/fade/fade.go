@@ -0,0 +1,243 @@
+// Package fade is the contrarian fade-the-favorite strategy: when the
+// market favorite is overpriced against the probability model and the
+// runner-up bracket trades close behind it, buy YES on the runner-up
+//
+// lahigh-deep-analysis found the second-priced bracket settles often enough
+// to pay at its price on days the market leans too hard on the favorite.
+// Prices a few cents apart mean the market itself is unsure; the model,
+// centered on the running METAR extreme and the forecast, decides which
+// side of that doubt to take. LOW events are traded the same way on the
+// running METAR min
+package fade
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// Config configures the strategy
+type Config struct {
+	Bet float64 // Dollars on the runner-up's YES
+
+	// The favorite's YES bid must exceed its model probability by at least
+	// MinOverprice (0-1), and the runner-up's bid trail it by at most
+	// MaxGap cents
+	MinOverprice float64
+	MaxGap       int
+
+	// The runner-up's model probability must exceed its price by MinEdge
+	// (0-1), within the YES price range (cents)
+	MinEdge  float64
+	MinPrice int
+	MaxPrice int
+
+	// Model: METAR to CLI calibration, and without a forecast the expected
+	// rise of the high per hour until PeakHour
+	Calibration float64
+	RisePerHour float64
+	PeakHour    int
+
+	// Local trading window: from TradingStartHour up to, not including,
+	// TradingEndHour
+	TradingStartHour int
+	TradingEndHour   int
+
+	// What may be done in each lifecycle phase (nil =
+	// strategy.DefaultPhaseBehaviors)
+	Phases strategy.PhaseBehaviors
+}
+
+// DefaultConfig returns the reference configuration
+func DefaultConfig() Config {
+	return Config{
+		Bet:              100,
+		MinOverprice:     0.10,
+		MaxGap:           15,
+		MinEdge:          0.03,
+		MinPrice:         15,
+		MaxPrice:         50,
+		Calibration:      model.DefaultCalibration,
+		RisePerHour:      1.0,
+		PeakHour:         15,
+		TradingStartHour: 10,
+		TradingEndHour:   14,
+		Phases:           strategy.DefaultPhaseBehaviors(),
+	}
+}
+
+// Strategy implements strategy.Strategy
+type Strategy struct {
+	config Config
+	logf   func(format string, args ...any)
+
+	weather map[string]strategy.WeatherUpdate // City -> latest weather
+	markets map[string]strategy.MarketData    // EventTicker -> latest snapshot
+	traded  map[string]bool                   // EventTicker -> order emitted
+}
+
+// New creates the strategy
+func New(config Config) *Strategy {
+	return &Strategy{
+		config:  config,
+		logf:    func(string, ...any) {},
+		weather: make(map[string]strategy.WeatherUpdate),
+		markets: make(map[string]strategy.MarketData),
+		traded:  make(map[string]bool),
+	}
+}
+
+// SetLogger receives the strategy's reasoning for each decision
+func (s *Strategy) SetLogger(logf func(format string, args ...any)) {
+	s.logf = logf
+}
+
+// Schedule returns the station-day lifecycle of the trading window
+func (s *Strategy) Schedule() strategy.Schedule {
+	return strategy.Schedule{StartHour: s.config.TradingStartHour, EndHour: s.config.TradingEndHour}
+}
+
+// Behavior returns what the strategy may do in phase
+func (s *Strategy) Behavior(phase strategy.Phase) strategy.PhaseBehavior {
+	if s.config.Phases == nil {
+		return strategy.DefaultPhaseBehaviors().For(phase)
+	}
+	return s.config.Phases.For(phase)
+}
+
+func (s *Strategy) Name() string { return "fade" }
+
+func (s *Strategy) OnMarketData(data strategy.MarketData) {
+	s.markets[data.EventTicker] = data
+}
+
+func (s *Strategy) OnWeatherUpdate(update strategy.WeatherUpdate) {
+	s.weather[update.City] = update
+}
+
+// GenerateOrders returns the runner-up order for every event the entry
+// conditions hold on, once per event. Each snapshot is decided on once, and
+// market data times are read as the city's local time
+func (s *Strategy) GenerateOrders(now time.Time) []strategy.Order {
+	events := make([]string, 0, len(s.markets))
+	for event := range s.markets {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+
+	var orders []strategy.Order
+	for _, event := range events {
+		if o, ok := s.decide(s.markets[event]); ok {
+			orders = append(orders, o)
+		}
+		delete(s.markets, event)
+	}
+	return orders
+}
+
+func (s *Strategy) decide(data strategy.MarketData) (strategy.Order, bool) {
+	if s.traded[data.EventTicker] {
+		return strategy.Order{}, false
+	}
+	behavior := s.Behavior(s.Schedule().PhaseAt(data.Time, data.Time))
+	if !behavior.AllowsOrder(strategy.OrderTypeLimit) {
+		return strategy.Order{}, false
+	}
+
+	// Brackets still trading with a YES bid, favorite first
+	var brackets []strategy.Quote
+	for _, q := range data.Quotes {
+		if !q.Determined && q.YesBid > 0 {
+			brackets = append(brackets, q)
+		}
+	}
+	if len(brackets) < 2 {
+		return strategy.Order{}, false
+	}
+	sort.SliceStable(brackets, func(i, j int) bool { return brackets[i].YesBid > brackets[j].YesBid })
+	favorite, runnerUp := brackets[0], brackets[1]
+
+	w, ok := s.weather[data.City]
+	if !ok {
+		return strategy.Order{}, false
+	}
+	f := s.Forecast(w, data.Type, data.Time.Hour())
+	favProb := f.Probability(favorite.Floor, favorite.Cap)
+	runnerProb := f.Probability(runnerUp.Floor, runnerUp.Cap)
+
+	gap := favorite.YesBid - runnerUp.YesBid
+	overprice := float64(favorite.YesBid)/100 - favProb
+	price := runnerUp.YesBid
+	edge := runnerProb - float64(price)/100
+	s.logf("%s: Fav=%s@%d¢ (model %.0f%%) runner-up %s@%d¢ (model %.0f%%), model %.1f±%.1f°",
+		data.EventTicker, label(favorite), favorite.YesBid, favProb*100, label(runnerUp), price, runnerProb*100, f.Mean, f.StdDev)
+
+	switch {
+	case gap > s.config.MaxGap:
+		s.logf("%s: Runner-up %d¢ behind the favorite, more than %d¢", data.EventTicker, gap, s.config.MaxGap)
+		return strategy.Order{}, false
+	case overprice < s.config.MinOverprice:
+		s.logf("%s: Favorite overpriced by %.0f%%, below %.0f%%", data.EventTicker, overprice*100, s.config.MinOverprice*100)
+		return strategy.Order{}, false
+	case edge < s.config.MinEdge:
+		s.logf("%s: Runner-up edge %.0f%%, below %.0f%%", data.EventTicker, edge*100, s.config.MinEdge*100)
+		return strategy.Order{}, false
+	case price < s.config.MinPrice || price > s.config.MaxPrice:
+		s.logf("%s: Runner-up price %d¢ out of range [%d-%d]", data.EventTicker, price, s.config.MinPrice, s.config.MaxPrice)
+		return strategy.Order{}, false
+	}
+
+	s.traded[data.EventTicker] = true
+	return strategy.Order{
+		EventTicker: data.EventTicker,
+		Ticker:      runnerUp.Ticker,
+		Side:        "yes",
+		Action:      "buy",
+		Price:       price,
+		Quantity:    behavior.Size(strategy.ContractsFor(s.config.Bet, price)),
+		Reason: fmt.Sprintf("favorite %s overpriced %.0f%%, runner-up %d¢ behind with %.0f%% edge",
+			label(favorite), overprice*100, gap, edge*100),
+	}, true
+}
+
+// Forecast returns the model's distribution of the official high (low for
+// a LOW event) from the weather at a local hour. Without a forecast, the
+// high is projected to rise RisePerHour until PeakHour and the low is
+// taken as already set
+func (s *Strategy) Forecast(w strategy.WeatherUpdate, t weather.MarketType, hour int) model.Forecast {
+	running := int(math.Round(w.Running(t)))
+	if t == weather.MarketTypeLow {
+		forecast := running
+		if w.ForecastLowF != 0 {
+			forecast = int(math.Round(w.ForecastLowF))
+		}
+		return model.LowForecast(running, forecast, s.config.Calibration, hour)
+	}
+	forecast := running + int(math.Round(float64(max(s.config.PeakHour-hour, 0))*s.config.RisePerHour))
+	if w.ForecastHighF != 0 {
+		forecast = int(math.Round(w.ForecastHighF))
+	}
+	return model.HighForecast(running, forecast, s.config.Calibration, hour)
+}
+
+// Probability returns the model's probability that bracket q of the event
+// in data settles YES, for strategy.WithExits
+func (s *Strategy) Probability(data strategy.MarketData, w strategy.WeatherUpdate, q strategy.Quote) float64 {
+	return s.Forecast(w, data.Type, data.Time.Hour()).Probability(q.Floor, q.Cap)
+}
+
+// label formats a bracket as the bots log it, e.g. "62-63°"
+func label(q strategy.Quote) string {
+	switch {
+	case q.Floor == strategy.OpenFloor:
+		return fmt.Sprintf("≤%d°", q.Cap)
+	case q.Cap == strategy.OpenCap:
+		return fmt.Sprintf("≥%d°", q.Floor)
+	}
+	return fmt.Sprintf("%d-%d°", q.Floor, q.Cap)
+}
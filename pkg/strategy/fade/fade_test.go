@@ -0,0 +1,135 @@
+package fade
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/backtest"
+	"github.com/brendanplayford/kalshi-go/pkg/backtest/fixtures"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+var la, _ = time.LoadLocation("America/Los_Angeles")
+
+// snapshot quotes a LAX event with the favorite on 62-63° at favorite and
+// the runner-up on 64-65° at runnerUp
+func snapshot(hour, favorite, runnerUp int) strategy.MarketData {
+	quote := func(ticker string, floor, cap, yesBid int) strategy.Quote {
+		return strategy.Quote{Ticker: ticker, Floor: floor, Cap: cap,
+			YesBid: yesBid, YesAsk: yesBid + 2, NoBid: 98 - yesBid, NoAsk: 100 - yesBid}
+	}
+	return strategy.MarketData{
+		Time:        time.Date(2025, 12, 5, hour, 0, 0, 0, la),
+		City:        "LAX",
+		EventTicker: "KXHIGHLAX-25DEC05",
+		Quotes: []strategy.Quote{
+			quote("T60", strategy.OpenFloor, 59, 2),
+			quote("B60.5", 60, 61, 10),
+			quote("B62.5", 62, 63, favorite),
+			quote("B64.5", 64, 65, runnerUp),
+			quote("T65", 66, strategy.OpenCap, 5),
+		},
+	}
+}
+
+func weatherAt(hour int, maxTemp, forecast float64) strategy.WeatherUpdate {
+	return strategy.WeatherUpdate{
+		Time:          time.Date(2025, 12, 5, hour, 0, 0, 0, la),
+		City:          "LAX",
+		MaxTempF:      maxTemp,
+		ForecastHighF: forecast,
+	}
+}
+
+func TestStrategy_Fade(t *testing.T) {
+	// Model 64±2°: 30% on the 45¢ favorite, 37% on the 33¢ runner-up
+	s := New(DefaultConfig())
+	s.OnWeatherUpdate(weatherAt(13, 62, 63))
+	s.OnMarketData(snapshot(13, 45, 33))
+
+	orders := s.GenerateOrders(snapshot(13, 45, 33).Time)
+	if len(orders) != 1 {
+		t.Fatalf("GenerateOrders() = %+v, want one order", orders)
+	}
+	if o := orders[0]; o.Ticker != "B64.5" || o.Side != "yes" || o.Action != "buy" || o.Price != 33 || o.Quantity != 303 {
+		t.Errorf("order = %+v, want YES B64.5 303 @ 33¢", o)
+	}
+
+	// Once per event, even with fresh data
+	s.OnMarketData(snapshot(13, 45, 33))
+	if orders := s.GenerateOrders(snapshot(13, 45, 33).Time); len(orders) != 0 {
+		t.Errorf("second GenerateOrders() = %+v, want none", orders)
+	}
+}
+
+func TestStrategy_Conditions(t *testing.T) {
+	tests := []struct {
+		name     string
+		hour     int
+		w        strategy.WeatherUpdate
+		favorite int
+		runnerUp int
+	}{
+		{"gap too wide", 13, weatherAt(13, 62, 63), 55, 33},
+		{"favorite fairly priced", 13, weatherAt(13, 62, 62), 45, 33},
+		{"runner-up too rich", 13, weatherAt(13, 62, 63), 45, 40},
+		{"before the window", 9, weatherAt(9, 62, 63), 45, 33},
+	}
+	for _, tt := range tests {
+		s := New(DefaultConfig())
+		s.OnWeatherUpdate(tt.w)
+		data := snapshot(tt.hour, tt.favorite, tt.runnerUp)
+		s.OnMarketData(data)
+		if orders := s.GenerateOrders(data.Time); len(orders) != 0 {
+			t.Errorf("%s: GenerateOrders() = %+v, want none", tt.name, orders)
+		}
+	}
+
+	// No weather, no model
+	s := New(DefaultConfig())
+	s.OnMarketData(snapshot(13, 45, 33))
+	if orders := s.GenerateOrders(time.Now()); len(orders) != 0 {
+		t.Errorf("without weather GenerateOrders() = %+v, want none", orders)
+	}
+}
+
+func TestStrategy_Forecast(t *testing.T) {
+	s := New(DefaultConfig())
+
+	// Without a forecast the high rises 1°/h until 3pm: 60 + 3, plus the
+	// calibration
+	if f := s.Forecast(weatherAt(12, 60, 0), weather.MarketTypeHigh, 12); f.Mean != 64 {
+		t.Errorf("HIGH Forecast mean = %v, want 64", f.Mean)
+	}
+	if f := s.Forecast(weatherAt(12, 60, 66), weather.MarketTypeHigh, 12); f.Mean != 67 {
+		t.Errorf("HIGH Forecast with forecast mean = %v, want 67", f.Mean)
+	}
+	// The low is taken as set
+	w := weatherAt(12, 60, 0)
+	w.MinTempF = 52
+	if f := s.Forecast(w, weather.MarketTypeLow, 12); f.Mean != 51 {
+		t.Errorf("LOW Forecast mean = %v, want 51", f.Mean)
+	}
+}
+
+func TestStrategy_Backtest(t *testing.T) {
+	ds, err := fixtures.SyntheticLAXNYC()
+	if err != nil {
+		t.Fatalf("fixtures.SyntheticLAXNYC() error = %v", err)
+	}
+	r := backtest.Run(ds, New(DefaultConfig()), backtest.DefaultConfig())
+	for _, tr := range r.Trades {
+		if tr.Side != "yes" || tr.Price < 15 || tr.Price > 50 {
+			t.Errorf("trade %+v, want YES between 15¢ and 50¢", tr)
+		}
+	}
+	events := make(map[string]bool)
+	for _, tr := range r.Trades {
+		if events[tr.EventTicker] {
+			t.Errorf("second trade on %s", tr.EventTicker)
+		}
+		events[tr.EventTicker] = true
+	}
+	t.Logf("%d trades, win rate %.0f%%, profit $%.2f", len(r.Trades), r.WinRate, r.TotalProfit)
+}
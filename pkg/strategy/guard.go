@@ -0,0 +1,229 @@
+package strategy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/stats"
+)
+
+// Mode is the execution mode of a live strategy
+type Mode string
+
+const (
+	// ModeLive places real orders
+	ModeLive Mode = "live"
+	// ModeShadow evaluates and records decisions without placing orders
+	ModeShadow Mode = "shadow"
+)
+
+// Expectation is the daily P&L profile a strategy showed in backtest
+type Expectation struct {
+	MeanDailyPnL   float64 // Expected P&L per trading day (dollars)
+	StdDevDailyPnL float64 // Standard deviation of daily P&L (dollars)
+}
+
+// GuardConfig configures the deterioration detector
+type GuardConfig struct {
+	Slack     float64 // CUSUM slack in standard deviations (default: 0.5)
+	Threshold float64 // CUSUM alarm threshold in standard deviations (default: 5)
+	MinDays   int     // Minimum settled days before the guard may trip (default: 3)
+}
+
+// DefaultGuardConfig returns the default guard configuration
+func DefaultGuardConfig() GuardConfig {
+	return GuardConfig{
+		Slack:     0.5,
+		Threshold: 5,
+		MinDays:   3,
+	}
+}
+
+// GuardStatus is a snapshot of a guard's state
+type GuardStatus struct {
+	Strategy     string    `json:"strategy"`
+	Mode         Mode      `json:"mode"`
+	Days         int       `json:"days"`
+	CUSUM        float64   `json:"cusum"`
+	Threshold    float64   `json:"threshold"`
+	ExpectedMean float64   `json:"expected_mean"`
+	RealizedMean float64   `json:"realized_mean"`
+	DisabledAt   time.Time `json:"disabled_at,omitempty"`
+	Reason       string    `json:"reason,omitempty"`
+}
+
+// PerformanceGuard tracks a live strategy's daily P&L against its backtest
+// expectation and switches it to shadow mode when a CUSUM on daily P&L shows
+// sustained underperformance
+type PerformanceGuard struct {
+	name        string
+	expectation Expectation
+	config      GuardConfig
+
+	mu         sync.RWMutex
+	cusum      *stats.CUSUM
+	mode       Mode
+	history    []float64
+	disabledAt time.Time
+	reason     string
+	onDisable  func(status GuardStatus)
+	path       string // Where the state is kept across restarts ("" = memory only)
+}
+
+// guardState is what a guard keeps across restarts
+type guardState struct {
+	Mode       Mode      `json:"mode"`
+	History    []float64 `json:"history"`
+	DisabledAt time.Time `json:"disabled_at,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+}
+
+// NewPerformanceGuard creates a guard for the named strategy in live mode
+func NewPerformanceGuard(name string, expectation Expectation, config GuardConfig) *PerformanceGuard {
+	return &PerformanceGuard{
+		name:        name,
+		expectation: expectation,
+		config:      config,
+		cusum:       stats.NewCUSUM(expectation.MeanDailyPnL, expectation.StdDevDailyPnL, config.Slack, config.Threshold),
+		mode:        ModeLive,
+	}
+}
+
+// Restore loads the mode and daily history saved at path, if any, and keeps
+// them there from now on, so a restart doesn't return a strategy the guard
+// switched to shadow mode to live trading. A failed save after a recorded
+// day is ignored: the mode in memory still protects this process
+func (g *PerformanceGuard) Restore(path string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read guard state: %w", err)
+	}
+	var st guardState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("parse guard state %s: %w", path, err)
+	}
+	g.cusum.Reset()
+	for _, pnl := range st.History {
+		g.cusum.Update(pnl)
+	}
+	g.history = st.History
+	g.mode = ModeLive
+	if st.Mode == ModeShadow {
+		g.mode = ModeShadow
+	}
+	g.disabledAt, g.reason = st.DisabledAt, st.Reason
+	return nil
+}
+
+// save writes the state to the restore path. Callers hold g.mu
+func (g *PerformanceGuard) save() error {
+	if g.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(guardState{Mode: g.mode, History: g.history, DisabledAt: g.disabledAt, Reason: g.reason}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(g.path), 0o755); err != nil {
+		return err
+	}
+	tmp := g.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, g.path)
+}
+
+// OnDisable sets the callback invoked when the guard switches the strategy to shadow mode
+func (g *PerformanceGuard) OnDisable(fn func(status GuardStatus)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onDisable = fn
+}
+
+// RecordDay adds a settled day's P&L and returns true if this observation
+// caused the strategy to be disabled
+func (g *PerformanceGuard) RecordDay(date time.Time, pnl float64) bool {
+	g.mu.Lock()
+	g.history = append(g.history, pnl)
+	alarm := g.cusum.Update(pnl)
+
+	if !alarm || g.mode == ModeShadow || len(g.history) < g.config.MinDays {
+		g.save()
+		g.mu.Unlock()
+		return false
+	}
+
+	g.mode = ModeShadow
+	g.disabledAt = time.Now()
+	g.reason = fmt.Sprintf("CUSUM %.2f > %.2f after %s: realized $%.2f/day vs expected $%.2f/day over %d days",
+		g.cusum.Value(), g.config.Threshold, date.Format("2006-01-02"),
+		stats.Mean(g.history), g.expectation.MeanDailyPnL, len(g.history))
+	g.save()
+	status := g.statusLocked()
+	fn := g.onDisable
+	g.mu.Unlock()
+
+	if fn != nil {
+		fn(status)
+	}
+	return true
+}
+
+// Mode returns the current execution mode
+func (g *PerformanceGuard) Mode() Mode {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.mode
+}
+
+// IsLive returns true if the strategy may place real orders
+func (g *PerformanceGuard) IsLive() bool {
+	return g.Mode() == ModeLive
+}
+
+// Enable returns the strategy to live mode and resets the detector. The
+// error is from saving the state; the guard is live either way
+func (g *PerformanceGuard) Enable() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.mode = ModeLive
+	g.cusum.Reset()
+	g.history = nil
+	g.disabledAt = time.Time{}
+	g.reason = ""
+	return g.save()
+}
+
+// Status returns a snapshot of the guard
+func (g *PerformanceGuard) Status() GuardStatus {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.statusLocked()
+}
+
+func (g *PerformanceGuard) statusLocked() GuardStatus {
+	return GuardStatus{
+		Strategy:     g.name,
+		Mode:         g.mode,
+		Days:         len(g.history),
+		CUSUM:        g.cusum.Value(),
+		Threshold:    g.config.Threshold,
+		ExpectedMean: g.expectation.MeanDailyPnL,
+		RealizedMean: stats.Mean(g.history),
+		DisabledAt:   g.disabledAt,
+		Reason:       g.reason,
+	}
+}
@@ -0,0 +1,119 @@
+package strategy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPerformanceGuard_RecordDay(t *testing.T) {
+	expect := Expectation{MeanDailyPnL: 100, StdDevDailyPnL: 50}
+	tests := []struct {
+		name      string
+		config    GuardConfig
+		days      []float64
+		trippedOn int // 1-based day the guard trips on (0 = stays live)
+	}{
+		{"on expectation", DefaultGuardConfig(), []float64{120, 80, 100, 150, 60, 110}, 0},
+		{"one bad day", DefaultGuardConfig(), []float64{120, -100, 100, 150, 90, 110}, 0},
+		{"sustained losses", DefaultGuardConfig(), []float64{-50, -80, -60, -40, -90}, 3},
+		{"too few days", GuardConfig{Slack: 0.5, Threshold: 5, MinDays: 10}, []float64{-300, -300, -300}, 0},
+		{"losses after a good start", DefaultGuardConfig(), []float64{150, 120, -100, -150, -120}, 4},
+	}
+	for _, tt := range tests {
+		g := NewPerformanceGuard("test", expect, tt.config)
+		var disabled []GuardStatus
+		g.OnDisable(func(s GuardStatus) { disabled = append(disabled, s) })
+
+		day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		tripped := 0
+		for i, pnl := range tt.days {
+			if g.RecordDay(day.AddDate(0, 0, i), pnl) {
+				if tripped != 0 {
+					t.Errorf("%s: tripped again on day %d", tt.name, i+1)
+				}
+				tripped = i + 1
+			}
+		}
+		if tripped != tt.trippedOn {
+			t.Errorf("%s: tripped on day %d, want %d", tt.name, tripped, tt.trippedOn)
+		}
+		wantCallbacks := 0
+		if tt.trippedOn > 0 {
+			wantCallbacks = 1
+		}
+		if g.IsLive() != (tt.trippedOn == 0) || len(disabled) != wantCallbacks {
+			t.Errorf("%s: live = %v with %d callbacks, want live = %v", tt.name, g.IsLive(), len(disabled), tt.trippedOn == 0)
+		}
+		if st := g.Status(); st.Days != len(tt.days) || (tt.trippedOn > 0) != (st.Reason != "") {
+			t.Errorf("%s: status = %+v", tt.name, st)
+		}
+	}
+}
+
+func TestPerformanceGuard_Enable(t *testing.T) {
+	g := NewPerformanceGuard("test", Expectation{MeanDailyPnL: 100, StdDevDailyPnL: 50}, DefaultGuardConfig())
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 4 {
+		g.RecordDay(day.AddDate(0, 0, i), -100)
+	}
+	if g.IsLive() {
+		t.Fatal("guard didn't trip on four losing days")
+	}
+
+	if err := g.Enable(); err != nil {
+		t.Fatalf("Enable() = %v", err)
+	}
+	st := g.Status()
+	if !g.IsLive() || st.Days != 0 || st.CUSUM != 0 || st.Reason != "" || !st.DisabledAt.IsZero() {
+		t.Errorf("after Enable: %+v, want live with the detector reset", st)
+	}
+	// The detector starts over: it takes MinDays losing days to trip again
+	for i := range 2 {
+		if g.RecordDay(day.AddDate(0, 0, 10+i), -100) {
+			t.Errorf("tripped on day %d after Enable", i+1)
+		}
+	}
+}
+
+func TestPerformanceGuard_Restore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "guard.json")
+	expect := Expectation{MeanDailyPnL: 100, StdDevDailyPnL: 50}
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	g := NewPerformanceGuard("test", expect, DefaultGuardConfig())
+	if err := g.Restore(path); err != nil {
+		t.Fatalf("Restore() without a file = %v", err)
+	}
+	for i := range 4 {
+		g.RecordDay(day.AddDate(0, 0, i), -100)
+	}
+	before := g.Status()
+
+	// A restart comes back in shadow mode with the same detector
+	restarted := NewPerformanceGuard("test", expect, DefaultGuardConfig())
+	if err := restarted.Restore(path); err != nil {
+		t.Fatalf("Restore() = %v", err)
+	}
+	after := restarted.Status()
+	if after.Mode != ModeShadow || after.Days != before.Days || after.CUSUM != before.CUSUM || after.Reason != before.Reason {
+		t.Errorf("restored %+v, want %+v", after, before)
+	}
+
+	// Enabling is saved too
+	if err := restarted.Enable(); err != nil {
+		t.Fatalf("Enable() = %v", err)
+	}
+	again := NewPerformanceGuard("test", expect, DefaultGuardConfig())
+	if err := again.Restore(path); err != nil || !again.IsLive() || again.Status().Days != 0 {
+		t.Errorf("after Enable and restart: %+v, %v, want live with no history", again.Status(), err)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewPerformanceGuard("test", expect, DefaultGuardConfig()).Restore(path); err == nil {
+		t.Error("Restore() of a corrupt file succeeded")
+	}
+}
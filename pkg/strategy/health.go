@@ -0,0 +1,69 @@
+package strategy
+
+import (
+	"fmt"
+	"time"
+)
+
+// HealthConfig scores how far a signal's inputs can be trusted
+type HealthConfig struct {
+	StaleAfter time.Duration // Data age at which the score starts to decay
+	MaxAge     time.Duration // Data age at which the score reaches 0
+	MaxSpread  int           // Widest normal bid/ask spread (cents); wider scales the score down
+	MinScore   float64       // Signals scoring below this are excluded
+}
+
+// DefaultHealthConfig returns thresholds suited to hourly METARs and
+// liquid weather brackets
+func DefaultHealthConfig() HealthConfig {
+	return HealthConfig{
+		StaleAfter: 90 * time.Minute,
+		MaxAge:     6 * time.Hour,
+		MaxSpread:  10,
+		MinScore:   0.25,
+	}
+}
+
+// SignalQuality describes the inputs a signal was built from
+type SignalQuality struct {
+	Age      time.Duration // Age of the underlying data, 0 if unknown
+	Spread   int           // Bid/ask spread of the quote it reads (cents), 0 if not market-based
+	Degraded bool          // Built from a fallback source (e.g. climatology for a missing forecast)
+}
+
+// Health is a signal's trust score from 0 (unusable) to 1 (fully healthy)
+type Health struct {
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons,omitempty"` // Why the score was reduced
+}
+
+// Score rates a signal's quality
+func (c HealthConfig) Score(q SignalQuality) Health {
+	h := Health{Score: 1}
+
+	if q.Age > c.StaleAfter {
+		if q.Age >= c.MaxAge {
+			h.Score = 0
+		} else {
+			h.Score *= 1 - float64(q.Age-c.StaleAfter)/float64(c.MaxAge-c.StaleAfter)
+		}
+		h.Reasons = append(h.Reasons, fmt.Sprintf("stale (%s old)", q.Age.Round(time.Minute)))
+	}
+
+	if q.Degraded {
+		h.Score *= 0.5
+		h.Reasons = append(h.Reasons, "degraded source")
+	}
+
+	if c.MaxSpread > 0 && q.Spread > c.MaxSpread {
+		h.Score *= float64(c.MaxSpread) / float64(q.Spread)
+		h.Reasons = append(h.Reasons, fmt.Sprintf("wide spread (%d¢)", q.Spread))
+	}
+
+	return h
+}
+
+// Healthy reports whether h clears the config's minimum score
+func (c HealthConfig) Healthy(h Health) bool {
+	return h.Score > 0 && h.Score >= c.MinScore
+}
@@ -0,0 +1,90 @@
+package strategy
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+func TestHealthConfig_Score(t *testing.T) {
+	c := HealthConfig{StaleAfter: time.Hour, MaxAge: 3 * time.Hour, MaxSpread: 10, MinScore: 0.25}
+
+	tests := []struct {
+		name    string
+		quality SignalQuality
+		want    float64
+		reasons int
+	}{
+		{"fresh", SignalQuality{Age: 10 * time.Minute, Spread: 2}, 1, 0},
+		{"unknown age", SignalQuality{}, 1, 0},
+		{"half decayed", SignalQuality{Age: 2 * time.Hour}, 0.5, 1},
+		{"expired", SignalQuality{Age: 4 * time.Hour}, 0, 1},
+		{"degraded", SignalQuality{Degraded: true}, 0.5, 1},
+		{"wide spread", SignalQuality{Spread: 40}, 0.25, 1},
+		{"everything", SignalQuality{Age: 2 * time.Hour, Spread: 20, Degraded: true}, 0.125, 3},
+	}
+	for _, tt := range tests {
+		h := c.Score(tt.quality)
+		if math.Abs(h.Score-tt.want) > 1e-9 || len(h.Reasons) != tt.reasons {
+			t.Errorf("%s: Score() = %+v, want %v with %d reasons", tt.name, h, tt.want, tt.reasons)
+		}
+	}
+
+	if c.Healthy(Health{Score: 0.2}) || !c.Healthy(Health{Score: 0.25}) {
+		t.Errorf("Healthy() should require MinScore 0.25")
+	}
+}
+
+// fixedSignal votes for a bracket with the given inputs
+type fixedSignal struct {
+	name     string
+	bracket  string
+	age      time.Duration
+	degraded bool
+}
+
+func (s *fixedSignal) Name() string { return s.name }
+
+func (s *fixedSignal) Generate(station *weather.Station, marketType weather.MarketType, date time.Time, tm *market.TempMarket) (*Signal, error) {
+	return &Signal{
+		Name:       s.name,
+		Bracket:    s.bracket,
+		ObservedAt: time.Now().Add(-s.age),
+		Degraded:   s.degraded,
+	}, nil
+}
+
+func TestEnsemble_ExcludesUnhealthySignals(t *testing.T) {
+	tm := &market.TempMarket{Brackets: []market.Bracket{
+		{Ticker: "A", Description: "60-61°F", YesPrice: 40, NoPrice: 58},
+		{Ticker: "B", Description: "62-63°F", YesPrice: 30, NoPrice: 68},
+	}}
+	config := DefaultEnsembleConfig()
+	config.MinAgreement = 2
+	config.SignalSources = []SignalSource{
+		&fixedSignal{name: "fresh", bracket: "62-63°F"},
+		&fixedSignal{name: "degraded", bracket: "62-63°F", degraded: true},
+		&fixedSignal{name: "stale1", bracket: "60-61°F", age: 12 * time.Hour},
+		&fixedSignal{name: "stale2", bracket: "60-61°F", age: 12 * time.Hour},
+	}
+
+	result, err := NewEnsembleWithConfig(config).Analyze(nil, weather.MarketTypeHigh, time.Now(), tm)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if len(result.Excluded) != 2 || result.Agreement["60-61°F"] != 0 {
+		t.Errorf("Excluded = %d signals, Agreement = %v, want both stale signals excluded", len(result.Excluded), result.Agreement)
+	}
+	rec := result.Recommendation
+	if rec.Action != "BUY" || rec.Ticker != "B" {
+		t.Fatalf("Recommendation = %+v, want BUY B", rec)
+	}
+	// (1 + 0.5) / 4 sources
+	if math.Abs(rec.Confidence-0.375) > 1e-6 {
+		t.Errorf("Confidence = %v, want 0.375", rec.Confidence)
+	}
+}
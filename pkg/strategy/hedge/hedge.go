@@ -0,0 +1,245 @@
+// Package hedge turns a probability over an event's brackets into a
+// multi-bracket order set: YES on the thesis bracket, YES on the adjacent
+// bracket that protects it, and NO on brackets the model prices below the
+// market, within one budget
+//
+// lahigh-optimizer found a 70/30 split between the predicted bracket and
+// its neighbour gives up little EV for a set that still pays when the
+// prediction misses by one bracket. Build tries the optimizer's splits,
+// with and without the NO legs, and keeps the set with the highest
+// expected value among those that lose money with at most MaxLossProb
+package hedge
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/brendanplayford/kalshi-go/pkg/fees"
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// Role is what a leg does in the set
+type Role string
+
+const (
+	RoleThesis     Role = "thesis"     // YES on the most probable bracket
+	RoleProtection Role = "protection" // YES on its more probable neighbour
+	RoleNo         Role = "no"         // NO on a bracket the market overprices
+)
+
+// Config configures the order sets built
+type Config struct {
+	Budget float64 // Dollars across all legs
+
+	// Shares of the YES budget tried on the protection bracket; the rest
+	// goes to the thesis (nil = the optimizer's 0, 20, 30, 40 and 50%)
+	ProtectionShares []float64
+
+	// Share of the budget on NO legs, split evenly, when they improve the
+	// set; up to MaxNoLegs of them, each with at least MinEdge
+	NoShare   float64
+	MaxNoLegs int
+	MinEdge   float64 // Model probability less the price, for NO legs (0-1)
+
+	// Largest model probability that the set loses money (0 = no limit)
+	MaxLossProb float64
+
+	// Fees charged on each leg; legs lift the ask so the whole set fills
+	// together, paying the taker rate (zero = no fees)
+	Fees fees.Rule
+}
+
+// DefaultConfig returns a $200 set with the optimizer's splits and up to
+// three NO legs on 30% of the budget, losing money at most a third of the
+// time
+func DefaultConfig() Config {
+	return Config{
+		Budget:      200,
+		NoShare:     0.3,
+		MaxNoLegs:   3,
+		MinEdge:     0.03,
+		MaxLossProb: 1.0 / 3,
+	}
+}
+
+// Leg is one order of a set
+type Leg struct {
+	Role      Role
+	Ticker    string
+	Side      string // "yes" or "no"
+	Price     int    // Ask in cents
+	Contracts int
+	Prob      float64 // Model probability the leg wins
+}
+
+// Plan is the order set Build chose
+type Plan struct {
+	Legs       []Leg
+	Protection float64 // Share of the YES budget on the protection
+	Cost       float64 // Dollars, before fees
+	EV         float64 // Expected P&L in dollars, after fees
+	Worst      float64 // P&L in dollars of the worst outcome, after fees
+	LossProb   float64 // Model probability the set loses money
+}
+
+// Orders returns the plan's legs as buy orders on the event
+func (p Plan) Orders(eventTicker, reason string) []strategy.Order {
+	orders := make([]strategy.Order, len(p.Legs))
+	for i, l := range p.Legs {
+		orders[i] = strategy.Order{
+			EventTicker: eventTicker,
+			Ticker:      l.Ticker,
+			Side:        l.Side,
+			Action:      "buy",
+			Price:       l.Price,
+			Quantity:    l.Contracts,
+			Reason:      fmt.Sprintf("%s: %s %s (model %.0f%%)", reason, l.Role, l.Side, l.Prob*100),
+		}
+	}
+	return orders
+}
+
+// Build returns the set with the highest expected value within the budget.
+// probs[i] is the model probability that quotes[i] settles YES, over the
+// event's full set of brackets ordered by Floor. It fails when no bracket
+// can be bought, or no set has a positive expected value within
+// MaxLossProb
+func Build(quotes []strategy.Quote, probs []float64, cfg Config) (Plan, error) {
+	if len(quotes) != len(probs) {
+		return Plan{}, fmt.Errorf("%d probabilities for %d brackets", len(probs), len(quotes))
+	}
+	tradable := func(i int) bool { return !quotes[i].Determined && quotes[i].YesAsk > 0 && quotes[i].YesAsk < 100 }
+
+	// The thesis is the most probable bracket, protected by its more
+	// probable neighbour
+	thesis, protection := -1, -1
+	for i := range quotes {
+		if tradable(i) && (thesis < 0 || probs[i] > probs[thesis]) {
+			thesis = i
+		}
+	}
+	if thesis < 0 {
+		return Plan{}, errors.New("no bracket to buy")
+	}
+	for _, i := range []int{thesis - 1, thesis + 1} {
+		if i >= 0 && i < len(quotes) && tradable(i) && (protection < 0 || probs[i] > probs[protection]) {
+			protection = i
+		}
+	}
+
+	// NO legs on the other brackets, best return per dollar first
+	var nos []int
+	for i, q := range quotes {
+		if i == thesis || i == protection || q.Determined || noAsk(q) <= 0 || noAsk(q) >= 100 {
+			continue
+		}
+		if 1-probs[i]-float64(noAsk(q))/100 >= cfg.MinEdge {
+			nos = append(nos, i)
+		}
+	}
+	sort.SliceStable(nos, func(a, b int) bool {
+		return noReturn(quotes[nos[a]], probs[nos[a]]) > noReturn(quotes[nos[b]], probs[nos[b]])
+	})
+	if len(nos) > cfg.MaxNoLegs {
+		nos = nos[:cfg.MaxNoLegs]
+	}
+
+	shares := cfg.ProtectionShares
+	if shares == nil {
+		shares = []float64{0, 0.2, 0.3, 0.4, 0.5}
+	}
+	noShares := []float64{0}
+	if len(nos) > 0 && cfg.NoShare > 0 {
+		noShares = append(noShares, cfg.NoShare)
+	}
+
+	var best Plan
+	found := false
+	for _, noShare := range noShares {
+		for _, share := range shares {
+			if share > 0 && protection < 0 {
+				continue
+			}
+			yes := cfg.Budget * (1 - noShare)
+			var legs []Leg
+			legs = appendLeg(legs, RoleThesis, quotes[thesis], "yes", probs[thesis], yes*(1-share))
+			if share > 0 {
+				legs = appendLeg(legs, RoleProtection, quotes[protection], "yes", probs[protection], yes*share)
+			}
+			if noShare > 0 {
+				for _, i := range nos {
+					legs = appendLeg(legs, RoleNo, quotes[i], "no", 1-probs[i], cfg.Budget*noShare/float64(len(nos)))
+				}
+			}
+			p := evaluate(legs, quotes, probs, cfg.Fees)
+			p.Protection = share
+			if len(p.Legs) == 0 || (cfg.MaxLossProb > 0 && p.LossProb > cfg.MaxLossProb) {
+				continue
+			}
+			if !found || p.EV > best.EV {
+				best, found = p, true
+			}
+		}
+	}
+	switch {
+	case !found:
+		return Plan{}, fmt.Errorf("every set loses money more than %.0f%% of the time", cfg.MaxLossProb*100)
+	case best.EV <= 0:
+		return Plan{}, fmt.Errorf("best set's expected value is $%.2f", best.EV)
+	}
+	return best, nil
+}
+
+// appendLeg appends a leg buying what budget dollars buy at the ask, if any
+func appendLeg(legs []Leg, role Role, q strategy.Quote, side string, prob, budget float64) []Leg {
+	price := q.YesAsk
+	if side == "no" {
+		price = noAsk(q)
+	}
+	n := int(budget * 100 / float64(price))
+	if n <= 0 {
+		return legs
+	}
+	return append(legs, Leg{Role: role, Ticker: q.Ticker, Side: side, Price: price, Contracts: n, Prob: prob})
+}
+
+// evaluate prices legs in every outcome: bracket i settling YES, with
+// probability probs[i]
+func evaluate(legs []Leg, quotes []strategy.Quote, probs []float64, rule fees.Rule) Plan {
+	p := Plan{Legs: legs, Worst: math.Inf(1)}
+	for _, l := range legs {
+		p.Cost += float64(l.Contracts*l.Price) / 100
+	}
+	for i, q := range quotes {
+		var pnl float64
+		for _, l := range legs {
+			won := (l.Ticker == q.Ticker) == (l.Side == "yes")
+			pnl += rule.NetProfit(fees.Taker, float64(l.Contracts), l.Price, won)
+		}
+		p.EV += probs[i] * pnl
+		p.Worst = min(p.Worst, pnl)
+		if pnl < 0 {
+			p.LossProb += probs[i]
+		}
+	}
+	return p
+}
+
+// noAsk returns the NO ask in cents, implied by the YES bid without one
+func noAsk(q strategy.Quote) int {
+	if q.NoAsk > 0 {
+		return q.NoAsk
+	}
+	if q.YesBid > 0 {
+		return 100 - q.YesBid
+	}
+	return 0
+}
+
+// noReturn returns the expected return per dollar of NO on q, before fees
+func noReturn(q strategy.Quote, prob float64) float64 {
+	price := float64(noAsk(q)) / 100
+	return (1 - prob - price) / price
+}
@@ -0,0 +1,82 @@
+package hedge
+
+import (
+	"math"
+	"testing"
+
+	"github.com/brendanplayford/kalshi-go/pkg/strategy"
+)
+
+// event is five brackets with the model's favorite on 60-61° at 50¢ and
+// its more probable neighbour, 62-63°, slightly overpriced at 26¢
+func event() ([]strategy.Quote, []float64) {
+	quote := func(ticker string, floor, cap, yesBid int) strategy.Quote {
+		return strategy.Quote{Ticker: ticker, Floor: floor, Cap: cap,
+			YesBid: yesBid, YesAsk: yesBid + 2, NoBid: 98 - yesBid, NoAsk: 100 - yesBid}
+	}
+	return []strategy.Quote{
+			quote("T58", strategy.OpenFloor, 57, 1),
+			quote("B58.5", 58, 59, 12),
+			quote("B60.5", 60, 61, 48),
+			quote("B62.5", 62, 63, 24),
+			quote("T63", 64, strategy.OpenCap, 2),
+		},
+		[]float64{0.02, 0.15, 0.55, 0.25, 0.03}
+}
+
+func TestBuild(t *testing.T) {
+	quotes, probs := event()
+	cfg := Config{Budget: 100, MaxLossProb: 1.0 / 3}
+
+	// The thesis alone, or with under 30% on the protection, loses money
+	// whenever 60-61° misses; 70/30 is the best set that pays on 62-63° too
+	p, err := Build(quotes, probs, cfg)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(p.Legs) != 2 || p.Protection != 0.3 {
+		t.Fatalf("Build() = %+v, want thesis and protection at 70/30", p)
+	}
+	if l := p.Legs[0]; l.Role != RoleThesis || l.Ticker != "B60.5" || l.Price != 50 || l.Contracts != 140 {
+		t.Errorf("thesis = %+v, want 140 YES B60.5 @ 50¢", l)
+	}
+	if l := p.Legs[1]; l.Role != RoleProtection || l.Ticker != "B62.5" || l.Price != 26 || l.Contracts != 115 {
+		t.Errorf("protection = %+v, want 115 YES B62.5 @ 26¢", l)
+	}
+	if math.Abs(p.LossProb-0.2) > 1e-9 || math.Abs(p.EV-5.85) > 0.01 || math.Abs(p.Worst+99.9) > 0.01 {
+		t.Errorf("Build() EV = %.2f, worst %.2f, loss prob %.2f, want 5.85, -99.90, 0.20", p.EV, p.Worst, p.LossProb)
+	}
+
+	// Nothing pays on three outcomes in four
+	cfg.MaxLossProb = 0.1
+	if _, err := Build(quotes, probs, cfg); err == nil {
+		t.Error("Build() with MaxLossProb 10% error = nil")
+	}
+}
+
+func TestBuild_NoLegs(t *testing.T) {
+	quotes, probs := event()
+	// 58-59° trades at 30¢ against a model 15%: its NO at 70¢ has an edge
+	quotes[1].YesBid, quotes[1].YesAsk, quotes[1].NoAsk = 30, 32, 70
+
+	cfg := DefaultConfig()
+	cfg.Budget = 100
+	p, err := Build(quotes, probs, cfg)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(p.Legs) != 3 || p.Protection != 0.3 {
+		t.Fatalf("Build() = %+v, want thesis, protection and a NO leg", p)
+	}
+	if l := p.Legs[2]; l.Role != RoleNo || l.Ticker != "B58.5" || l.Side != "no" || l.Price != 70 || l.Contracts != 42 {
+		t.Errorf("NO leg = %+v, want 42 NO B58.5 @ 70¢", l)
+	}
+	if math.Abs(p.EV-10.4) > 0.01 {
+		t.Errorf("Build() EV = %.2f, want 10.40", p.EV)
+	}
+
+	orders := p.Orders("KXHIGHLAX-25DEC05", "signals agree")
+	if len(orders) != 3 || orders[2].Side != "no" || orders[2].Quantity != 42 || orders[2].Action != "buy" {
+		t.Errorf("Orders() = %+v", orders)
+	}
+}
@@ -0,0 +1,191 @@
+package strategy
+
+import (
+	"fmt"
+	"time"
+)
+
+// DepthLevels is how many price levels of each side of a book the depths of
+// a Quote count
+const DepthLevels = 3
+
+// Imbalance returns the bracket's book imbalance in [-1, 1]: YES depth less
+// NO depth over their sum. NO bids are offers to sell YES, so a negative
+// imbalance is sell pressure on YES; ok is false without a book
+func (q Quote) Imbalance() (float64, bool) {
+	total := q.YesDepth + q.NoDepth
+	if total == 0 {
+		return 0, false
+	}
+	return float64(q.YesDepth-q.NoDepth) / float64(total), true
+}
+
+// Pressure returns the order book pressure on the YES of the bracket with
+// ticker, in [-1, 1]: its own imbalance less half the mean imbalance of the
+// adjacent brackets with a book, whose YES buyers bet against it. ok is false
+// without the bracket's book
+func (m MarketData) Pressure(ticker string) (float64, bool) {
+	for i, q := range m.Quotes {
+		if q.Ticker != ticker {
+			continue
+		}
+		own, ok := q.Imbalance()
+		if !ok {
+			return 0, false
+		}
+		var sum float64
+		var n int
+		for _, j := range []int{i - 1, i + 1} {
+			if j < 0 || j >= len(m.Quotes) {
+				continue
+			}
+			if adj, ok := m.Quotes[j].Imbalance(); ok {
+				sum += adj
+				n++
+			}
+		}
+		if n > 0 {
+			own -= sum / float64(n) / 2
+		}
+		return max(-1, min(1, own)), true
+	}
+	return 0, false
+}
+
+// ImbalanceTiming defers buys while the book shows sell pressure on the side
+// bought, so entries lift the offer once the selling is exhausted rather
+// than into it
+type ImbalanceTiming struct {
+	MinPressure float64       // Buy once the pressure on the side bought is at least this (-1 to 1)
+	MaxWait     time.Duration // Buy anyway after waiting this long (0 = until the pressure eases)
+}
+
+// Imbalance wraps a strategy with ImbalanceTiming. Buys are held back until
+// the pressure on their side (Pressure, negated for NO) reaches MinPressure
+// or MaxWait passes, and are then sent once, at the current ask if that is
+// below their limit. Buys on a bracket without a book, and sells, go out at
+// once. Held buys are dropped at the end of their local day, or once their
+// market stops trading
+type Imbalance struct {
+	Strategy
+	timing ImbalanceTiming
+
+	markets map[string]MarketData // EventTicker -> latest snapshot
+	pending []deferred
+}
+
+// deferred is a buy held back by the timing
+type deferred struct {
+	order Order
+	since time.Time // Local time of the snapshot it was generated on
+}
+
+// WithImbalance wraps s with timing
+func WithImbalance(s Strategy, timing ImbalanceTiming) *Imbalance {
+	return &Imbalance{
+		Strategy: s,
+		timing:   timing,
+		markets:  make(map[string]MarketData),
+	}
+}
+
+// Name names the wrapped strategy with the timing, e.g. "fade+imbalance"
+func (t *Imbalance) Name() string {
+	return t.Strategy.Name() + "+imbalance"
+}
+
+func (t *Imbalance) OnMarketData(data MarketData) {
+	t.markets[data.EventTicker] = data
+	t.Strategy.OnMarketData(data)
+}
+
+// OnFill passes the fill on to the wrapped strategy if it observes fills
+func (t *Imbalance) OnFill(fill Fill) {
+	if o, ok := t.Strategy.(FillObserver); ok {
+		o.OnFill(fill)
+	}
+}
+
+// Waiting reports whether a buy on the event is being held back; it is
+// false for a nil Imbalance
+func (t *Imbalance) Waiting(eventTicker string) bool {
+	if t == nil {
+		return false
+	}
+	for _, d := range t.pending {
+		if d.order.EventTicker == eventTicker {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateOrders returns the wrapped strategy's orders the timing lets
+// through, and the held buys released since the last call
+func (t *Imbalance) GenerateOrders(now time.Time) []Order {
+	for _, o := range t.Strategy.GenerateOrders(now) {
+		since := now
+		if data, ok := t.markets[o.EventTicker]; ok {
+			since = data.Time
+		}
+		t.pending = append(t.pending, deferred{order: o, since: since})
+	}
+
+	var orders []Order
+	kept := t.pending[:0]
+	for _, d := range t.pending {
+		o, release, drop := t.check(d, now)
+		switch {
+		case release:
+			orders = append(orders, o)
+		case !drop:
+			kept = append(kept, d)
+		}
+	}
+	t.pending = kept
+	return orders
+}
+
+// check decides a held order on its event's latest snapshot: release it
+// (as o), keep holding it, or drop it
+func (t *Imbalance) check(d deferred, now time.Time) (o Order, release, drop bool) {
+	o = d.order
+	data, ok := t.markets[o.EventTicker]
+	if o.Action != "buy" || !ok {
+		return o, true, false
+	}
+	local := now.In(d.since.Location())
+	if local.YearDay() != d.since.YearDay() || local.Year() != d.since.Year() {
+		return o, false, true
+	}
+	var q *Quote
+	for i := range data.Quotes {
+		if data.Quotes[i].Ticker == o.Ticker {
+			q = &data.Quotes[i]
+		}
+	}
+	if q == nil || q.Determined {
+		return o, false, true
+	}
+
+	pressure, ok := data.Pressure(o.Ticker)
+	if !ok {
+		return o, true, false
+	}
+	ask := q.YesAsk
+	if o.Side == "no" {
+		pressure, ask = -pressure, q.NoAsk
+	}
+	waited := local.Sub(d.since)
+	if pressure < t.timing.MinPressure && (t.timing.MaxWait <= 0 || waited < t.timing.MaxWait) {
+		return o, false, false
+	}
+
+	if ask > 0 && ask < o.Price {
+		o.Price = ask
+	}
+	if waited > 0 {
+		o.Reason = fmt.Sprintf("%s; book pressure %+.2f after %s", o.Reason, pressure, waited.Round(time.Minute))
+	}
+	return o, true, false
+}
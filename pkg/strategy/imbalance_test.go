@@ -0,0 +1,109 @@
+package strategy
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarketData_Pressure(t *testing.T) {
+	m := MarketData{Quotes: []Quote{
+		{Ticker: "A", YesDepth: 300, NoDepth: 100}, // +0.5
+		{Ticker: "B", YesDepth: 100, NoDepth: 300}, // -0.5
+		{Ticker: "C"}, // No book
+		{Ticker: "D", YesDepth: 100, NoDepth: 100}, // 0
+	}}
+	tests := []struct {
+		ticker string
+		want   float64
+		ok     bool
+	}{
+		{"A", 0.75, true},  // 0.5 - (-0.5)/2
+		{"B", -0.75, true}, // -0.5 - 0.5/2, C has no book
+		{"C", 0, false},
+		{"D", 0, true},
+		{"X", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := m.Pressure(tt.ticker)
+		if ok != tt.ok || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Pressure(%s) = %v, %v, want %v, %v", tt.ticker, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// buyer buys B once, at 45¢
+type buyer struct{ done bool }
+
+func (s *buyer) Name() string                         { return "buyer" }
+func (s *buyer) OnMarketData(data MarketData)         {}
+func (s *buyer) OnWeatherUpdate(update WeatherUpdate) {}
+
+func (s *buyer) GenerateOrders(now time.Time) []Order {
+	if s.done {
+		return nil
+	}
+	s.done = true
+	return []Order{{EventTicker: "E", Ticker: "B", Side: "yes", Action: "buy", Price: 45, Quantity: 10, Reason: "entry"}}
+}
+
+func TestImbalance(t *testing.T) {
+	snapshot := func(minute, ask, yesDepth, noDepth int) MarketData {
+		return MarketData{
+			Time:        time.Date(2025, 12, 5, 11, minute, 0, 0, time.UTC),
+			EventTicker: "E",
+			Quotes:      []Quote{{Ticker: "B", YesBid: ask - 2, YesAsk: ask, YesDepth: yesDepth, NoDepth: noDepth}},
+		}
+	}
+	step := func(s *Imbalance, data MarketData) []Order {
+		s.OnMarketData(data)
+		return s.GenerateOrders(data.Time)
+	}
+
+	s := WithImbalance(&buyer{}, ImbalanceTiming{MinPressure: -0.2, MaxWait: time.Hour})
+
+	// Sellers outnumber buyers 3:1: held
+	if orders := step(s, snapshot(0, 45, 100, 300)); len(orders) != 0 {
+		t.Fatalf("GenerateOrders() under sell pressure = %+v, want none", orders)
+	}
+	if !s.Waiting("E") || s.Waiting("F") {
+		t.Error("Waiting() wrong while held")
+	}
+
+	// The selling eases and the ask has come in: lifted there
+	orders := step(s, snapshot(20, 43, 200, 250))
+	if len(orders) != 1 || orders[0].Price != 43 || !strings.Contains(orders[0].Reason, "after 20m") {
+		t.Fatalf("GenerateOrders() once eased = %+v, want one buy at 43¢", orders)
+	}
+	if s.Waiting("E") {
+		t.Error("Waiting() after release = true")
+	}
+
+	// MaxWait releases a buy the selling never lets up on
+	s = WithImbalance(&buyer{}, ImbalanceTiming{MinPressure: 0, MaxWait: time.Hour})
+	step(s, snapshot(0, 45, 100, 300))
+	if orders := step(s, snapshot(30, 47, 100, 300)); len(orders) != 0 {
+		t.Errorf("GenerateOrders() before MaxWait = %+v, want none", orders)
+	}
+	if orders := step(s, snapshot(59, 47, 100, 300)); len(orders) != 0 {
+		t.Errorf("GenerateOrders() before MaxWait = %+v, want none", orders)
+	}
+	s.OnMarketData(snapshot(0, 47, 100, 300))
+	if orders := s.GenerateOrders(time.Date(2025, 12, 5, 12, 0, 0, 0, time.UTC)); len(orders) != 1 || orders[0].Price != 45 {
+		t.Errorf("GenerateOrders() at MaxWait = %+v, want the buy at its 45¢ limit", orders)
+	}
+
+	// Without a book the timing has no view
+	s = WithImbalance(&buyer{}, ImbalanceTiming{MinPressure: 0.5})
+	if orders := step(s, snapshot(0, 45, 0, 0)); len(orders) != 1 {
+		t.Errorf("GenerateOrders() without a book = %+v, want the buy at once", orders)
+	}
+
+	// Held buys expire with their day
+	s = WithImbalance(&buyer{}, ImbalanceTiming{MinPressure: 0.5})
+	step(s, snapshot(0, 45, 100, 300))
+	if orders := s.GenerateOrders(time.Date(2025, 12, 6, 11, 0, 0, 0, time.UTC)); len(orders) != 0 || s.Waiting("E") {
+		t.Errorf("GenerateOrders() the next day = %+v, want the buy dropped", orders)
+	}
+}
@@ -0,0 +1,188 @@
+package strategy
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Phase is a stage of a station-day's trading lifecycle. Phases only move
+// forward, in the order declared
+type Phase string
+
+const (
+	// PhasePreMarket is before anything may be entered for the day
+	PhasePreMarket Phase = "PRE_MARKET"
+	// PhaseForecastEntry is the day before, when entries rest on the forecast
+	PhaseForecastEntry Phase = "FORECAST_ENTRY"
+	// PhaseIntraday is the target day's trading window, when entries rest on
+	// the running max
+	PhaseIntraday Phase = "INTRADAY"
+	// PhaseLockWindow is after the window: no new entries, positions are held
+	// or sold for profit as the high locks in
+	PhaseLockWindow Phase = "LOCK_WINDOW"
+	// PhaseClose is after the markets close, waiting for the result
+	PhaseClose Phase = "CLOSE"
+	// PhaseSettlement is once the day's positions have settled
+	PhaseSettlement Phase = "SETTLEMENT"
+)
+
+var phaseOrder = map[Phase]int{
+	PhasePreMarket:     0,
+	PhaseForecastEntry: 1,
+	PhaseIntraday:      2,
+	PhaseLockWindow:    3,
+	PhaseClose:         4,
+	PhaseSettlement:    5,
+}
+
+// CanEnter reports whether new positions may be opened in the phase
+func (p Phase) CanEnter() bool {
+	return p == PhaseForecastEntry || p == PhaseIntraday
+}
+
+// Schedule gives the local hours a station-day moves between phases
+type Schedule struct {
+	ForecastHour int // Hour the day before from which forecast entries open (0 = no forecast entries)
+	StartHour    int // Hour intraday entries open on the target day
+	EndHour      int // Hour entries stop and the lock window begins
+	CloseHour    int // Hour the markets close on the target day (0 = midnight)
+}
+
+// PhaseAt returns the phase of day's lifecycle at now, reading both in now's
+// time zone. Settlement can't be told from the clock; see Lifecycle.Settle
+func (s Schedule) PhaseAt(day, now time.Time) Phase {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, now.Location())
+	at := func(hour int) time.Time { return start.Add(time.Duration(hour) * time.Hour) }
+
+	closeHour := s.CloseHour
+	if closeHour == 0 {
+		closeHour = 24
+	}
+	switch {
+	case !now.Before(at(closeHour)):
+		return PhaseClose
+	case !now.Before(at(s.EndHour)):
+		return PhaseLockWindow
+	case !now.Before(at(s.StartHour)):
+		return PhaseIntraday
+	case s.ForecastHour > 0 && !now.Before(at(s.ForecastHour-24)):
+		return PhaseForecastEntry
+	}
+	return PhasePreMarket
+}
+
+// PhaseInfo is a station-day's current phase
+type PhaseInfo struct {
+	Event string    `json:"event"`
+	Phase Phase     `json:"phase"`
+	Since time.Time `json:"since"`
+}
+
+type lifecycleEntry struct {
+	day time.Time
+	PhaseInfo
+}
+
+// Lifecycle tracks the phase of each station-day, keyed by event ticker,
+// from the schedule and the clock. Transitions are logged; a phase never
+// moves backwards (a clock step or a schedule change mid-day holds it), and
+// only a closed day can settle
+type Lifecycle struct {
+	schedule Schedule
+
+	mu     sync.Mutex
+	events map[string]*lifecycleEntry
+	logf   func(format string, args ...any)
+}
+
+// lifecycleRetention is how long a station-day is tracked after its date
+const lifecycleRetention = 3 * 24 * time.Hour
+
+// NewLifecycle creates a lifecycle tracker for schedule
+func NewLifecycle(schedule Schedule) *Lifecycle {
+	return &Lifecycle{schedule: schedule, events: make(map[string]*lifecycleEntry)}
+}
+
+// SetLogger receives each transition
+func (l *Lifecycle) SetLogger(logf func(format string, args ...any)) {
+	l.logf = logf
+}
+
+// Schedule returns the schedule the phases follow
+func (l *Lifecycle) Schedule() Schedule {
+	return l.schedule
+}
+
+// Advance moves event, the station-day of day, to its phase at now and
+// returns it
+func (l *Lifecycle) Advance(event string, day, now time.Time) Phase {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, e := range l.events {
+		if now.Sub(e.day) > lifecycleRetention {
+			delete(l.events, key)
+		}
+	}
+
+	next := l.schedule.PhaseAt(day, now)
+	e, ok := l.events[event]
+	if !ok {
+		e = &lifecycleEntry{day: day, PhaseInfo: PhaseInfo{Event: event, Phase: PhasePreMarket, Since: now}}
+		l.events[event] = e
+	}
+	if phaseOrder[next] > phaseOrder[e.Phase] {
+		l.transition(e, next, now)
+	}
+	return e.Phase
+}
+
+// Settle moves event to SETTLEMENT once its markets have closed. It reports
+// whether the event is settled
+func (l *Lifecycle) Settle(event string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.events[event]
+	if !ok || phaseOrder[e.Phase] < phaseOrder[PhaseClose] {
+		if ok && l.logf != nil {
+			l.logf("%s: refusing to settle in %s", event, e.Phase)
+		}
+		return false
+	}
+	if e.Phase != PhaseSettlement {
+		l.transition(e, PhaseSettlement, now)
+	}
+	return true
+}
+
+// Phase returns event's current phase, PRE_MARKET if it isn't tracked
+func (l *Lifecycle) Phase(event string) Phase {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.events[event]; ok {
+		return e.Phase
+	}
+	return PhasePreMarket
+}
+
+// Phases returns every tracked station-day, by event ticker
+func (l *Lifecycle) Phases() []PhaseInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]PhaseInfo, 0, len(l.events))
+	for _, e := range l.events {
+		out = append(out, e.PhaseInfo)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Event < out[j].Event })
+	return out
+}
+
+func (l *Lifecycle) transition(e *lifecycleEntry, next Phase, now time.Time) {
+	if l.logf != nil {
+		l.logf("%s: %s → %s", e.Event, e.Phase, next)
+	}
+	e.Phase = next
+	e.Since = now
+}
@@ -0,0 +1,90 @@
+package strategy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSchedule_PhaseAt(t *testing.T) {
+	la, _ := time.LoadLocation("America/Los_Angeles")
+	day := time.Date(2025, 12, 5, 0, 0, 0, 0, la)
+	s := Schedule{ForecastHour: 16, StartHour: 7, EndHour: 14}
+	at := func(d, h, m int) time.Time { return time.Date(2025, 12, d, h, m, 0, 0, la) }
+
+	tests := []struct {
+		now  time.Time
+		want Phase
+	}{
+		{at(4, 15, 59), PhasePreMarket},
+		{at(4, 16, 0), PhaseForecastEntry},
+		{at(5, 6, 59), PhaseForecastEntry},
+		{at(5, 7, 0), PhaseIntraday},
+		{at(5, 13, 59), PhaseIntraday},
+		{at(5, 14, 0), PhaseLockWindow},
+		{at(5, 23, 59), PhaseLockWindow},
+		{at(6, 0, 0), PhaseClose},
+	}
+	for _, tt := range tests {
+		if got := s.PhaseAt(day, tt.now); got != tt.want {
+			t.Errorf("PhaseAt(%v) = %s, want %s", tt.now, got, tt.want)
+		}
+	}
+
+	// Without forecast entries the day before is pre-market; a day given in
+	// UTC is read in the station's zone
+	s.ForecastHour = 0
+	if got := s.PhaseAt(time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC), at(4, 20, 0)); got != PhasePreMarket {
+		t.Errorf("PhaseAt() without forecast entries = %s, want PRE_MARKET", got)
+	}
+}
+
+func TestLifecycle_Transitions(t *testing.T) {
+	la, _ := time.LoadLocation("America/Los_Angeles")
+	day := time.Date(2025, 12, 5, 0, 0, 0, 0, la)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	const event = "KXHIGHLAX-25DEC05"
+
+	l := NewLifecycle(Schedule{StartHour: 7, EndHour: 14})
+	var logged []string
+	l.SetLogger(func(format string, args ...any) { logged = append(logged, fmt.Sprintf(format, args...)) })
+
+	if got := l.Advance(event, day, at(5)); got != PhasePreMarket {
+		t.Fatalf("Advance() at 5:00 = %s, want PRE_MARKET", got)
+	}
+	if l.Settle(event, at(6)) {
+		t.Fatal("Settle() before the close succeeded")
+	}
+	// A bot started mid-window skips straight to INTRADAY
+	if got := l.Advance(event, day, at(9)); got != PhaseIntraday || !got.CanEnter() {
+		t.Fatalf("Advance() at 9:00 = %s, want INTRADAY", got)
+	}
+	// The clock stepping back doesn't undo the transition
+	if got := l.Advance(event, day, at(6)); got != PhaseIntraday {
+		t.Errorf("Advance() after a clock step = %s, want INTRADAY held", got)
+	}
+	if got := l.Advance(event, day, at(15)); got != PhaseLockWindow || got.CanEnter() {
+		t.Errorf("Advance() at 15:00 = %s, want LOCK_WINDOW", got)
+	}
+	l.Advance(event, day, at(25))
+	if !l.Settle(event, at(26)) || l.Phase(event) != PhaseSettlement {
+		t.Errorf("Settle() after the close: phase %s, want SETTLEMENT", l.Phase(event))
+	}
+
+	want := []string{
+		event + ": refusing to settle in PRE_MARKET",
+		event + ": PRE_MARKET → INTRADAY",
+		event + ": INTRADAY → LOCK_WINDOW",
+		event + ": LOCK_WINDOW → CLOSE",
+		event + ": CLOSE → SETTLEMENT",
+	}
+	if fmt.Sprint(logged) != fmt.Sprint(want) {
+		t.Errorf("logged %q, want %q", logged, want)
+	}
+
+	// Old station-days are dropped
+	l.Advance("KXHIGHLAX-25DEC09", day.AddDate(0, 0, 4), at(4*24+8))
+	if phases := l.Phases(); len(phases) != 1 || phases[0].Event != "KXHIGHLAX-25DEC09" {
+		t.Errorf("Phases() = %+v, want only the new day", phases)
+	}
+}
@@ -0,0 +1,190 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Order types a phase may allow entries with
+const (
+	OrderTypeLimit  = "limit"
+	OrderTypeMarket = "market"
+)
+
+// Exit is a rule that may close positions before settlement
+type Exit string
+
+const (
+	// ExitTakeProfit sells a held side once it is bid near $1
+	ExitTakeProfit Exit = "take_profit"
+	// ExitStopLoss sells a held side once its bid has fallen to the stop
+	ExitStopLoss Exit = "stop_loss"
+	// ExitModel sells a held side the model no longer backs
+	ExitModel Exit = "model"
+	// ExitFlatten sells whatever is still held late in the day
+	ExitFlatten Exit = "flatten"
+)
+
+var exits = map[Exit]bool{ExitTakeProfit: true, ExitStopLoss: true, ExitModel: true, ExitFlatten: true}
+
+// PhaseBehavior is what a strategy may do while a station-day is in one
+// phase. The zero value polls at the driver's default and does nothing
+type PhaseBehavior struct {
+	Poll       time.Duration // Polling cadence (0 = the driver's default)
+	OrderTypes []string      // Order types entries may use; none = no entries
+	SizeFactor float64       // Scales entry sizes (0 = 1)
+	Exits      []Exit        // Exit rules that may run
+}
+
+// Enters reports whether the phase allows new positions
+func (b PhaseBehavior) Enters() bool {
+	return len(b.OrderTypes) > 0
+}
+
+// AllowsOrder reports whether entries may use orderType ("" = limit)
+func (b PhaseBehavior) AllowsOrder(orderType string) bool {
+	if orderType == "" {
+		orderType = OrderTypeLimit
+	}
+	for _, t := range b.OrderTypes {
+		if t == orderType {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsExit reports whether the exit rule may run
+func (b PhaseBehavior) AllowsExit(exit Exit) bool {
+	for _, x := range b.Exits {
+		if x == exit {
+			return true
+		}
+	}
+	return false
+}
+
+// Size scales an entry of contracts by SizeFactor, to at least 1 contract
+func (b PhaseBehavior) Size(contracts int) int {
+	if b.SizeFactor <= 0 || b.SizeFactor == 1 {
+		return contracts
+	}
+	scaled := int(float64(contracts) * b.SizeFactor)
+	if scaled < 1 {
+		scaled = 1
+	}
+	return scaled
+}
+
+// PhaseBehaviors configures each phase; a phase not listed does nothing
+type PhaseBehaviors map[Phase]PhaseBehavior
+
+// DefaultPhaseBehaviors returns the behavior of an intraday strategy: limit
+// entries in the trading window, and exits then and in the lock window until
+// the close. Exits only run when their ExitRules are set
+func DefaultPhaseBehaviors() PhaseBehaviors {
+	all := []Exit{ExitTakeProfit, ExitStopLoss, ExitModel, ExitFlatten}
+	return PhaseBehaviors{
+		PhaseIntraday: {
+			OrderTypes: []string{OrderTypeLimit},
+			Exits:      all,
+		},
+		PhaseLockWindow: {
+			Exits: all,
+		},
+	}
+}
+
+// For returns the behavior of phase
+func (p PhaseBehaviors) For(phase Phase) PhaseBehavior {
+	return p[phase]
+}
+
+// Poll returns the shortest cadence configured for any of phases, or def
+// if none sets one
+func (p PhaseBehaviors) Poll(phases []Phase, def time.Duration) time.Duration {
+	poll := time.Duration(0)
+	for _, phase := range phases {
+		if d := p[phase].Poll; d > 0 && (poll == 0 || d < poll) {
+			poll = d
+		}
+	}
+	if poll == 0 {
+		return def
+	}
+	return poll
+}
+
+// ParsePhaseBehaviors applies phase rules to the defaults. Rules are
+// separated by semicolons, each a phase and its fields:
+//
+//	INTRADAY:poll=1m,orders=limit,size=0.5;LOCK_WINDOW:poll=10m,exits=take_profit|flatten
+//
+// Fields are poll (a duration), orders and exits (lists separated by |, or
+// none) and size; exits are take_profit, stop_loss, model and flatten.
+// Phases not named, and fields not given, keep their defaults
+func ParsePhaseBehaviors(s string) (PhaseBehaviors, error) {
+	behaviors := DefaultPhaseBehaviors()
+	for _, rule := range strings.Split(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		name, fields, _ := strings.Cut(rule, ":")
+		phase := Phase(strings.ToUpper(strings.TrimSpace(name)))
+		if _, ok := phaseOrder[phase]; !ok {
+			return nil, fmt.Errorf("unknown phase %q", name)
+		}
+
+		b := behaviors[phase]
+		for _, field := range strings.Split(fields, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			key, val, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("%s: field %q: want key=value", phase, field)
+			}
+			var err error
+			switch key {
+			case "poll":
+				b.Poll, err = time.ParseDuration(val)
+			case "size":
+				b.SizeFactor, err = strconv.ParseFloat(val, 64)
+			case "orders":
+				b.OrderTypes = nil
+				for _, t := range splitList(val) {
+					if t != OrderTypeLimit && t != OrderTypeMarket {
+						return nil, fmt.Errorf("%s: unknown order type %q", phase, t)
+					}
+					b.OrderTypes = append(b.OrderTypes, t)
+				}
+			case "exits":
+				b.Exits = nil
+				for _, x := range splitList(val) {
+					if !exits[Exit(x)] {
+						return nil, fmt.Errorf("%s: unknown exit %q", phase, x)
+					}
+					b.Exits = append(b.Exits, Exit(x))
+				}
+			default:
+				return nil, fmt.Errorf("%s: unknown field %q", phase, key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", phase, key, err)
+			}
+		}
+		behaviors[phase] = b
+	}
+	return behaviors, nil
+}
+
+func splitList(s string) []string {
+	if s == "none" || s == "" {
+		return nil
+	}
+	return strings.Split(s, "|")
+}
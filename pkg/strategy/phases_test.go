@@ -0,0 +1,42 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePhaseBehaviors(t *testing.T) {
+	p, err := ParsePhaseBehaviors("intraday:poll=1m,size=0.5; LOCK_WINDOW:poll=10m,orders=limit|market,exits=none;PRE_MARKET:poll=15m")
+	if err != nil {
+		t.Fatalf("ParsePhaseBehaviors() error = %v", err)
+	}
+
+	// Fields not given keep the defaults
+	in := p.For(PhaseIntraday)
+	if in.Poll != time.Minute || in.Size(100) != 50 || !in.AllowsOrder("") || in.AllowsOrder(OrderTypeMarket) || !in.AllowsExit(ExitTakeProfit) {
+		t.Errorf("INTRADAY = %+v", in)
+	}
+	lock := p.For(PhaseLockWindow)
+	if !lock.Enters() || !lock.AllowsOrder(OrderTypeMarket) || lock.AllowsExit(ExitTakeProfit) {
+		t.Errorf("LOCK_WINDOW = %+v", lock)
+	}
+	if b := p.For(PhaseClose); b.Enters() || len(b.Exits) != 0 || b.Poll != 0 {
+		t.Errorf("CLOSE = %+v, want the zero behavior", b)
+	}
+
+	if got := p.Poll([]Phase{PhasePreMarket, PhaseLockWindow}, time.Hour); got != 10*time.Minute {
+		t.Errorf("Poll() = %v, want the shortest configured 10m", got)
+	}
+	if got := p.Poll([]Phase{PhaseClose}, time.Hour); got != time.Hour {
+		t.Errorf("Poll() with none configured = %v, want the default", got)
+	}
+	if got := (PhaseBehavior{SizeFactor: 0.1}).Size(5); got != 1 {
+		t.Errorf("Size() = %d, want at least 1 contract", got)
+	}
+
+	for _, bad := range []string{"LUNCH:poll=1m", "INTRADAY:poll", "INTRADAY:orders=ioc", "INTRADAY:exits=stop", "INTRADAY:speed=1"} {
+		if _, err := ParsePhaseBehaviors(bad); err == nil {
+			t.Errorf("ParsePhaseBehaviors(%q) error = nil", bad)
+		}
+	}
+}
@@ -16,6 +16,20 @@ type Signal struct {
 	Ticker      string  // Bracket ticker
 	Temperature float64 // Predicted temperature (if applicable)
 	Confidence  float64 // Confidence level 0-1
+
+	// Inputs, for health scoring
+	ObservedAt time.Time // When the underlying data was observed, zero if unknown
+	Spread     int       // Bid/ask spread of the bracket read (cents), 0 if not market-based
+	Degraded   bool      // Built from a fallback source
+}
+
+// Quality returns the signal's inputs as of now
+func (s *Signal) Quality(now time.Time) SignalQuality {
+	q := SignalQuality{Spread: s.Spread, Degraded: s.Degraded}
+	if !s.ObservedAt.IsZero() {
+		q.Age = now.Sub(s.ObservedAt)
+	}
+	return q
 }
 
 // SignalSource is the interface for signal generators
@@ -24,6 +38,20 @@ type SignalSource interface {
 	Generate(station *weather.Station, marketType weather.MarketType, date time.Time, tempMarket *market.TempMarket) (*Signal, error)
 }
 
+// Weighted is implemented by signal sources whose vote counts for more or
+// less than a built-in signal's (weight 1)
+type Weighted interface {
+	Weight() float64
+}
+
+// sourceWeight returns a source's ensemble weight
+func sourceWeight(source SignalSource) float64 {
+	if w, ok := source.(Weighted); ok {
+		return w.Weight()
+	}
+	return 1
+}
+
 // MarketFavoriteSignal generates signals based on the market favorite bracket
 type MarketFavoriteSignal struct{}
 
@@ -41,6 +69,8 @@ func (s *MarketFavoriteSignal) Generate(station *weather.Station, marketType wea
 		Ticker:      fav.Ticker,
 		Temperature: (fav.LowerBound + fav.UpperBound) / 2,
 		Confidence:  float64(fav.YesPrice) / 100,
+		ObservedAt:  tm.FetchedAt,
+		Spread:      fav.Spread(),
 	}, nil
 }
 
@@ -61,6 +91,8 @@ func (s *SecondBestSignal) Generate(station *weather.Station, marketType weather
 		Ticker:      second.Ticker,
 		Temperature: (second.LowerBound + second.UpperBound) / 2,
 		Confidence:  float64(second.YesPrice) / 100,
+		ObservedAt:  tm.FetchedAt,
+		Spread:      second.Spread(),
 	}, nil
 }
 
@@ -71,17 +103,27 @@ func (s *NWSForecastSignal) Name() string { return "NWSForecast" }
 
 func (s *NWSForecastSignal) Generate(station *weather.Station, marketType weather.MarketType, date time.Time, tm *market.TempMarket) (*Signal, error) {
 	var temp float64
-	var err error
+	var issued time.Time
+	degraded := true
 
 	if marketType == weather.MarketTypeHigh {
-		temp, err = weather.FetchTomorrowHigh(station)
+		forecasts, err := weather.FetchNWSForecast(station)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch forecast: %w", err)
+		}
+		// First daytime period is tomorrow's high, as in weather.FetchTomorrowHigh
+		for _, f := range forecasts {
+			if f.IsDaytime && f.HighTemp > 0 {
+				temp, issued, degraded = f.HighTemp, f.Issued, false
+				break
+			}
+		}
+		if degraded {
+			temp = station.ClimatologyHigh(date.Month())
+		}
 	} else {
 		// For low temp, fetch tomorrow's low (simplified - using climatology for now)
-		temp = station.GetClimatologyLow(date.Month())
-	}
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch forecast: %w", err)
+		temp = station.ClimatologyLow(date.Month())
 	}
 
 	bracket := tm.GetBracketForTemp(temp)
@@ -95,6 +137,8 @@ func (s *NWSForecastSignal) Generate(station *weather.Station, marketType weathe
 		Ticker:      bracket.Ticker,
 		Temperature: temp,
 		Confidence:  0.7, // NWS forecast has moderate confidence
+		ObservedAt:  issued,
+		Degraded:    degraded,
 	}, nil
 }
 
@@ -107,9 +151,9 @@ func (s *ClimatologySignal) Generate(station *weather.Station, marketType weathe
 	var temp float64
 
 	if marketType == weather.MarketTypeHigh {
-		temp = station.GetClimatologyHigh(date.Month())
+		temp = station.ClimatologyHigh(date.Month())
 	} else {
-		temp = station.GetClimatologyLow(date.Month())
+		temp = station.ClimatologyLow(date.Month())
 	}
 
 	bracket := tm.GetBracketForTemp(temp)
@@ -173,6 +217,7 @@ func (s *METARCurrentSignal) Generate(station *weather.Station, marketType weath
 		Ticker:      bracket.Ticker,
 		Temperature: temp,
 		Confidence:  0.5,
+		ObservedAt:  obs.Time,
 	}, nil
 }
 
@@ -0,0 +1,159 @@
+package strategy
+
+import (
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// Open bracket bounds, matching pkg/backtest and pkg/market
+const (
+	OpenFloor = -999
+	OpenCap   = 999
+)
+
+// Quote is the current top of book of one bracket market (prices in cents,
+// 0 = no quote)
+type Quote struct {
+	Ticker string
+	Floor  int // Inclusive lower bound, OpenFloor for "below"
+	Cap    int // Inclusive upper bound, OpenCap for "above"
+	YesBid int
+	YesAsk int
+	NoBid  int
+	NoAsk  int
+
+	// Contracts bid on each side in the book's top DepthLevels levels
+	// (0 = no book); see Imbalance
+	YesDepth int
+	NoDepth  int
+
+	// Determined means the outcome is known and the market has stopped
+	// trading, possibly before its scheduled close: it has no prices and
+	// orders on it, exits included, cannot fill
+	Determined bool
+}
+
+// Contains reports whether temp falls inside the bracket
+func (q Quote) Contains(temp int) bool {
+	return temp >= q.Floor && temp <= q.Cap
+}
+
+// Spread returns the YES bid/ask spread in cents, or 100 when the book is
+// one-sided
+func (q Quote) Spread() int {
+	if q.YesBid <= 0 || q.YesAsk <= 0 {
+		return 100
+	}
+	return q.YesAsk - q.YesBid
+}
+
+// MarketData is a snapshot of one event's brackets
+type MarketData struct {
+	Time        time.Time
+	City        string // Station code, e.g. "LAX"
+	EventTicker string
+	Type        weather.MarketType // HIGH or LOW ("" = HIGH)
+	Quotes      []Quote            // Ordered by Floor
+}
+
+// IsLow reports whether the event settles on the daily low
+func (m MarketData) IsLow() bool {
+	return m.Type == weather.MarketTypeLow
+}
+
+// Favorite returns the quote with the highest YES bid, or nil
+func (m MarketData) Favorite() *Quote {
+	var fav *Quote
+	for i := range m.Quotes {
+		if m.Quotes[i].YesBid > 0 && (fav == nil || m.Quotes[i].YesBid > fav.YesBid) {
+			fav = &m.Quotes[i]
+		}
+	}
+	return fav
+}
+
+// QuoteFor returns the quote of the bracket containing temp, or nil
+func (m MarketData) QuoteFor(temp int) *Quote {
+	for i := range m.Quotes {
+		if m.Quotes[i].Contains(temp) {
+			return &m.Quotes[i]
+		}
+	}
+	return nil
+}
+
+// WeatherUpdate is a new observation for a city
+type WeatherUpdate struct {
+	Time          time.Time
+	City          string
+	TempF         float64 // Latest observation
+	MaxTempF      float64 // Running maximum since local midnight
+	MinTempF      float64 // Running minimum since local midnight
+	ForecastHighF float64 // Forecast high, 0 if unknown
+	ForecastLowF  float64 // Forecast overnight low, 0 if unknown
+
+	ForecastIssued time.Time // When the forecast was issued, zero if unknown
+}
+
+// Running returns the running extreme an event of type t settles on: the
+// minimum for LOW events, the maximum otherwise
+func (w WeatherUpdate) Running(t weather.MarketType) float64 {
+	if t == weather.MarketTypeLow {
+		return w.MinTempF
+	}
+	return w.MaxTempF
+}
+
+// Order is an order a strategy wants placed
+type Order struct {
+	EventTicker string
+	Ticker      string
+	Side        string // "yes" or "no"
+	Action      string // "buy" or "sell"
+	Price       int    // Limit price in cents
+	Quantity    int
+	Reason      string
+}
+
+// Strategy is a trading strategy that can be driven by either a live engine
+// or the backtester. The driver feeds market data and weather as they arrive
+// and asks for orders at each decision point; strategies must not re-issue
+// orders they have already emitted.
+type Strategy interface {
+	Name() string
+	OnMarketData(data MarketData)
+	OnWeatherUpdate(update WeatherUpdate)
+	GenerateOrders(now time.Time) []Order
+}
+
+// Fill is an executed order (or the executed part of one)
+type Fill struct {
+	Time        time.Time
+	EventTicker string
+	Ticker      string
+	Side        string // "yes" or "no"
+	Action      string // "buy" or "sell"
+	Price       int    // Execution price in cents
+	Quantity    int
+}
+
+// FillObserver is implemented by strategies that manage open positions
+// (profit harvesting, stop-losses, re-entries). Drivers call OnFill for every
+// fill of the strategy's orders before the next decision point
+type FillObserver interface {
+	OnFill(fill Fill)
+}
+
+// ContractsFor returns how many contracts a dollar budget buys at price
+// (at least 1)
+func ContractsFor(budget float64, price int) int {
+	if price <= 0 {
+		return 0
+	}
+	n := int(budget * 100 / float64(price))
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
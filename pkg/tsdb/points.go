@@ -0,0 +1,96 @@
+package tsdb
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/market"
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// Measurements written by the point builders.
+const (
+	MeasurementTemperature = "kalshi_temperature" // Station reports
+	MeasurementModel       = "kalshi_model"       // Model forecast of the official high
+	MeasurementBracket     = "kalshi_bracket"     // Model probability and market prices per bracket
+)
+
+// Temperature is a station report, tagged by station and source (e.g.
+// "metar"). Fields: temp_f, wind_speed_kt, wind_dir.
+func Temperature(station *weather.Station, source string, o weather.Observation) Point {
+	return Point{
+		Measurement: MeasurementTemperature,
+		Tags:        map[string]string{"station": station.ID, "source": source},
+		Fields: map[string]float64{
+			"temp_f":        o.Temp,
+			"wind_speed_kt": o.WindSpeed,
+			"wind_dir":      o.WindDir,
+		},
+		Time: o.Time,
+	}
+}
+
+// Forecast is the model's forecast of an event's official high and its
+// inputs, tagged by station and event. Fields: mean, std_dev, running_max and,
+// unless NaN, nws_forecast.
+func Forecast(station *weather.Station, eventTicker string, f model.Forecast, runningMax, nwsForecast float64, at time.Time) Point {
+	p := Point{
+		Measurement: MeasurementModel,
+		Tags:        map[string]string{"station": station.ID, "event": eventTicker},
+		Fields: map[string]float64{
+			"mean":        f.Mean,
+			"std_dev":     f.StdDev,
+			"running_max": runningMax,
+		},
+		Time: at,
+	}
+	if !math.IsNaN(nwsForecast) {
+		p.Fields["nws_forecast"] = nwsForecast
+	}
+	return p
+}
+
+// Bracket is one bracket market's model probability next to its prices,
+// tagged by station, event, ticker and bracket (e.g. "60-61", "<=57").
+// Fields: model_prob (0-1), yes_bid, yes_ask and last_price (cents) and
+// market_prob (0-1), the mid when both sides are quoted and the last price
+// otherwise, so model_prob and market_prob chart on one axis.
+func Bracket(station *weather.Station, m rest.Market, f model.Forecast, at time.Time) Point {
+	strike := market.NewStrike(int(m.FloorStrike), int(m.CapStrike))
+	p := Point{
+		Measurement: MeasurementBracket,
+		Tags: map[string]string{
+			"station": station.ID,
+			"event":   m.EventTicker,
+			"ticker":  m.Ticker,
+			"bracket": label(strike),
+		},
+		Fields: map[string]float64{
+			"model_prob": f.Probability(strike.Floor, strike.Cap),
+			"yes_bid":    float64(m.YesBid),
+			"yes_ask":    float64(m.YesAsk),
+			"last_price": float64(m.LastPrice),
+		},
+		Time: at,
+	}
+	switch {
+	case m.YesBid > 0 && m.YesAsk > 0:
+		p.Fields["market_prob"] = float64(m.YesBid+m.YesAsk) / 200
+	case m.LastPrice > 0:
+		p.Fields["market_prob"] = float64(m.LastPrice) / 100
+	}
+	return p
+}
+
+func label(s market.Strike) string {
+	switch {
+	case s.Floor == market.OpenFloor:
+		return fmt.Sprintf("<=%d", s.Cap)
+	case s.Cap == market.OpenCap:
+		return fmt.Sprintf(">=%d", s.Floor)
+	}
+	return fmt.Sprintf("%d-%d", s.Floor, s.Cap)
+}
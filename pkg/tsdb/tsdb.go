@@ -0,0 +1,147 @@
+// Package tsdb exports temperatures, model probabilities and market prices as
+// time series in the InfluxDB line protocol, which InfluxDB (v1 and v2) and
+// VictoriaMetrics both ingest, so an existing Grafana stack can chart the
+// model converging with the market through the day.
+package tsdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Point is one sample of a series: a measurement, its identifying tags and
+// the values recorded at Time.
+type Point struct {
+	Measurement string
+	Tags        map[string]string
+	Fields      map[string]float64
+	Time        time.Time
+}
+
+// Line encodes the point in the line protocol with a millisecond timestamp.
+// Tags and fields are sorted so the output is stable; empty tag values are
+// dropped, as the protocol does not allow them.
+func (p Point) Line() string {
+	var b strings.Builder
+	b.WriteString(escape(p.Measurement, ", "))
+
+	for _, k := range sortedKeys(p.Tags) {
+		if p.Tags[k] == "" {
+			continue
+		}
+		b.WriteByte(',')
+		b.WriteString(escape(k, ",= "))
+		b.WriteByte('=')
+		b.WriteString(escape(p.Tags[k], ",= "))
+	}
+
+	for i, k := range sortedKeys(p.Fields) {
+		if i == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(escape(k, ",= "))
+		b.WriteByte('=')
+		b.WriteString(strconv.FormatFloat(p.Fields[k], 'f', -1, 64))
+	}
+
+	b.WriteByte(' ')
+	b.WriteString(strconv.FormatInt(p.Time.UnixMilli(), 10))
+	return b.String()
+}
+
+func escape(s, special string) string {
+	if !strings.ContainsAny(s, special+`\`) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if r == '\\' || strings.ContainsRune(special, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Writer posts points to a line protocol write endpoint, e.g.
+//
+//	http://localhost:8428/write                                 (VictoriaMetrics)
+//	http://localhost:8086/write?db=kalshi                       (InfluxDB 1.x)
+//	http://localhost:8086/api/v2/write?org=me&bucket=kalshi     (InfluxDB 2.x)
+type Writer struct {
+	URL   string
+	Token string // Sent as "Authorization: Token ..." when set (InfluxDB 2.x)
+
+	HTTPClient *http.Client
+}
+
+// NewWriter creates a writer for the endpoint.
+func NewWriter(endpoint, token string) *Writer {
+	return &Writer{
+		URL:        endpoint,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Write posts the points in one request. Points without fields are skipped.
+func (w *Writer) Write(ctx context.Context, points []Point) error {
+	var body bytes.Buffer
+	for _, p := range points {
+		if len(p.Fields) == 0 {
+			continue
+		}
+		body.WriteString(p.Line())
+		body.WriteByte('\n')
+	}
+	if body.Len() == 0 {
+		return nil
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return fmt.Errorf("tsdb: invalid URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("precision", "ms")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if w.Token != "" {
+		req.Header.Set("Authorization", "Token "+w.Token)
+	}
+
+	resp, err := w.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("tsdb: write failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("tsdb: write failed: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
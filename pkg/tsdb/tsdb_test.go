@@ -0,0 +1,95 @@
+package tsdb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/model"
+	"github.com/brendanplayford/kalshi-go/pkg/rest"
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+var at = time.Date(2025, 12, 5, 18, 0, 0, 0, time.UTC)
+
+func TestPoint_Line(t *testing.T) {
+	p := Point{
+		Measurement: "temp, raw",
+		Tags:        map[string]string{"station": "KLAX", "source": "a=b,c d", "empty": ""},
+		Fields:      map[string]float64{"temp_f": 61.5, "count": 3},
+		Time:        at,
+	}
+	want := `temp\,\ raw,source=a\=b\,c\ d,station=KLAX count=3,temp_f=61.5 1764957600000`
+	if got := p.Line(); got != want {
+		t.Errorf("Line() = %s, want %s", got, want)
+	}
+}
+
+func TestBracket(t *testing.T) {
+	lax := weather.StationByCode("LAX")
+	f := model.Forecast{Mean: 61, StdDev: 1}
+
+	m := rest.Market{Ticker: "KXHIGHLAX-25DEC05-B60.5", EventTicker: "KXHIGHLAX-25DEC05",
+		FloorStrike: 60, CapStrike: 61, YesBid: 40, YesAsk: 44, LastPrice: 42}
+	p := Bracket(lax, m, f, at)
+	if p.Tags["bracket"] != "60-61" || p.Fields["market_prob"] != 0.42 {
+		t.Errorf("Bracket() = %+v, want 60-61 at 0.42", p)
+	}
+	if prob := p.Fields["model_prob"]; prob < 0.6 || prob > 0.7 {
+		t.Errorf("model_prob = %v, want P(59.5 < high < 61.5) ≈ 0.62", prob)
+	}
+
+	// One-sided book: the last price stands in
+	tail := rest.Market{Ticker: "KXHIGHLAX-25DEC05-T63", FloorStrike: 63, YesBid: 3, LastPrice: 4}
+	p = Bracket(lax, tail, f, at)
+	if p.Tags["bracket"] != ">=64" || p.Fields["market_prob"] != 0.04 {
+		t.Errorf("Bracket() = %+v, want >=64 at 0.04", p)
+	}
+}
+
+func TestWriter_Write(t *testing.T) {
+	var query, auth, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, auth = r.URL.RawQuery, r.Header.Get("Authorization")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	lax := weather.StationByCode("LAX")
+	w := NewWriter(server.URL+"/api/v2/write?org=me&bucket=kalshi", "secret")
+	points := []Point{
+		Temperature(lax, "metar", weather.Observation{Time: at, Temp: 61}),
+		{Measurement: "empty", Time: at}, // No fields, skipped
+	}
+	if err := w.Write(context.Background(), points); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if query != "bucket=kalshi&org=me&precision=ms" {
+		t.Errorf("query = %q, want the bucket and ms precision", query)
+	}
+	if auth != "Token secret" {
+		t.Errorf("Authorization = %q, want Token secret", auth)
+	}
+	if lines := strings.Split(strings.TrimSpace(body), "\n"); len(lines) != 1 || !strings.HasPrefix(lines[0], "kalshi_temperature,source=metar,station=KLAX ") {
+		t.Errorf("body = %q, want one temperature line", body)
+	}
+}
+
+func TestWriter_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bucket not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	p := Point{Measurement: "m", Fields: map[string]float64{"v": 1}, Time: at}
+	err := NewWriter(server.URL+"/write", "").Write(context.Background(), []Point{p})
+	if err == nil || !strings.Contains(err.Error(), "bucket not found") {
+		t.Errorf("Write() error = %v, want the server's message", err)
+	}
+}
@@ -0,0 +1,73 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// AWCMaxHours is how far back the Aviation Weather Center API serves
+// observations
+const AWCMaxHours = 360
+
+// awcMETAR is one observation in the AWC JSON response
+type awcMETAR struct {
+	ObsTime   int64    `json:"obsTime"` // Unix seconds
+	Temp      *float64 `json:"temp"`    // °C, null when missing
+	Wdir      any      `json:"wdir"`    // Degrees, or "VRB"
+	Wspd      float64  `json:"wspd"`    // Knots
+	MetarType string   `json:"metarType"`
+}
+
+// FetchAWCObservations fetches the METAR and SPECI observations for a station
+// from the last hours (at most AWCMaxHours) from aviationweather.gov, in time
+// order
+func FetchAWCObservations(station *Station, hours int) ([]Observation, error) {
+	obs, err := fetchAWCObservations(context.Background(), station, hours)
+	if err != nil {
+		return nil, err
+	}
+	obs, _ = SaneObservations(obs, time.Time{}, time.Now())
+	return obs, nil
+}
+
+func fetchAWCObservations(ctx context.Context, station *Station, hours int) ([]Observation, error) {
+	hours = min(max(hours, 1), AWCMaxHours)
+	url := "https://aviationweather.gov/api/data/metar?ids=" + station.ID +
+		"&hours=" + strconv.Itoa(hours) + "&format=json"
+
+	body, err := get(ctx, url, "AWC")
+	if err != nil {
+		return nil, err
+	}
+	return parseAWCObservations(station, body)
+}
+
+func parseAWCObservations(station *Station, body []byte) ([]Observation, error) {
+	var reports []awcMETAR
+	if err := json.Unmarshal(body, &reports); err != nil {
+		return nil, fmt.Errorf("failed to parse AWC response: %w", err)
+	}
+
+	loc := station.Location()
+	var obs []Observation
+	for _, r := range reports {
+		if r.Temp == nil {
+			continue
+		}
+		o := Observation{
+			Time:      time.Unix(r.ObsTime, 0).In(loc),
+			Temp:      *r.Temp*9/5 + 32,
+			WindSpeed: r.Wspd,
+		}
+		if dir, ok := r.Wdir.(float64); ok {
+			o.WindDir = dir
+		}
+		obs = append(obs, o)
+	}
+	sort.Slice(obs, func(i, j int) bool { return obs[i].Time.Before(obs[j].Time) })
+	return obs, nil
+}
@@ -0,0 +1,77 @@
+package weather
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheSettleDelay is how long after a local day ends its reports are still
+// refetched: corrected and late SPECIs keep reaching the archive for a while
+const CacheSettleDelay = 2 * time.Hour
+
+// Cache is a Provider that keeps another provider's reports per station-day.
+// A day fetched after it settled is kept for good; anything else is refetched
+// once older than the TTL, so a bot polling today's running max hits the
+// source at most once per TTL and a backtest fetches each past day once.
+// Callers must not modify the returned slices
+type Cache struct {
+	provider Provider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+type cacheKey struct {
+	station string
+	day     string
+}
+
+type cacheEntry struct {
+	obs     []Observation
+	fetched time.Time
+	final   bool
+}
+
+// NewCache wraps p, refetching unsettled days once older than ttl
+func NewCache(p Provider, ttl time.Duration) *Cache {
+	return &Cache{
+		provider: p,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[cacheKey]cacheEntry),
+	}
+}
+
+// Name returns the wrapped provider's name
+func (c *Cache) Name() string {
+	return c.provider.Name()
+}
+
+// Observations returns the cached reports for the station-day, fetching them
+// if missing or expired. Errors are not cached
+func (c *Cache) Observations(ctx context.Context, station *Station, date time.Time) ([]Observation, error) {
+	day := station.LocalDay(date)
+	key := cacheKey{station.ID, day.Format("2006-01-02")}
+	now := c.now()
+
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && (e.final || now.Sub(e.fetched) < c.ttl) {
+		return e.obs, nil
+	}
+
+	obs, err := c.provider.Observations(ctx, station, day)
+	if err != nil {
+		return nil, err
+	}
+
+	settled := now.After(day.AddDate(0, 0, 1).Add(CacheSettleDelay))
+	c.mu.Lock()
+	c.entries[key] = cacheEntry{obs: obs, fetched: now, final: settled && len(obs) > 0}
+	c.mu.Unlock()
+	return obs, nil
+}
@@ -0,0 +1,108 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// DailyMaxComparison is one station-day's maximum temperature as computed
+// from the Iowa State (IEM) ASOS archive and from the Aviation Weather Center
+// (AWC) feed. Backtests use IEM and several live bots use AWC, so a
+// systematic difference between the two biases every calibration
+type DailyMaxComparison struct {
+	Station    string    // Station code, e.g. "LAX"
+	Date       time.Time // Local midnight
+	IEMMax     float64   // °F, unrounded
+	AWCMax     float64   // °F, unrounded
+	IEMReports int
+	AWCReports int
+}
+
+// Diff returns AWC minus IEM in °F
+func (c DailyMaxComparison) Diff() float64 {
+	return c.AWCMax - c.IEMMax
+}
+
+// Mismatch reports whether the two maxima round to different whole degrees,
+// i.e. whether the sources would predict different brackets
+func (c DailyMaxComparison) Mismatch() bool {
+	return math.Round(c.AWCMax) != math.Round(c.IEMMax)
+}
+
+// CompareDailyMax computes the maximum of each source's observations within
+// the local day starting at date
+func CompareDailyMax(code string, date time.Time, iem, awc []Observation) (DailyMaxComparison, error) {
+	c := DailyMaxComparison{Station: code, Date: date}
+
+	var ok bool
+	if c.IEMMax, c.IEMReports, ok = dailyMax(iem, date); !ok {
+		return c, fmt.Errorf("%s %s: no IEM observations", code, date.Format("2006-01-02"))
+	}
+	if c.AWCMax, c.AWCReports, ok = dailyMax(awc, date); !ok {
+		return c, fmt.Errorf("%s %s: no AWC observations", code, date.Format("2006-01-02"))
+	}
+	return c, nil
+}
+
+// dailyMax returns the maximum temperature of observations in [date, date+1d)
+func dailyMax(obs []Observation, date time.Time) (float64, int, bool) {
+	end := date.AddDate(0, 0, 1)
+	maxTemp, n := math.Inf(-1), 0
+	for _, o := range obs {
+		if o.Time.Before(date) || !o.Time.Before(end) {
+			continue
+		}
+		n++
+		maxTemp = math.Max(maxTemp, o.Temp)
+	}
+	return maxTemp, n, n > 0
+}
+
+// SourceBias summarizes the AWC - IEM daily maximum differences of a station
+type SourceBias struct {
+	Station     string
+	Days        int
+	MeanDiff    float64 // Mean AWC - IEM (°F); the systematic offset
+	MeanAbsDiff float64
+	MaxAbsDiff  float64
+	Mismatches  int // Days whose rounded maxima differ
+}
+
+// MismatchRate returns the fraction of days whose rounded maxima differ
+func (b SourceBias) MismatchRate() float64 {
+	if b.Days == 0 {
+		return 0
+	}
+	return float64(b.Mismatches) / float64(b.Days)
+}
+
+// SummarizeBias aggregates comparisons per station, sorted by station code
+func SummarizeBias(comparisons []DailyMaxComparison) []SourceBias {
+	byStation := make(map[string]*SourceBias)
+	for _, c := range comparisons {
+		b, ok := byStation[c.Station]
+		if !ok {
+			b = &SourceBias{Station: c.Station}
+			byStation[c.Station] = b
+		}
+		d := c.Diff()
+		b.Days++
+		b.MeanDiff += d
+		b.MeanAbsDiff += math.Abs(d)
+		b.MaxAbsDiff = math.Max(b.MaxAbsDiff, math.Abs(d))
+		if c.Mismatch() {
+			b.Mismatches++
+		}
+	}
+
+	result := make([]SourceBias, 0, len(byStation))
+	for _, b := range byStation {
+		b.MeanDiff /= float64(b.Days)
+		b.MeanAbsDiff /= float64(b.Days)
+		result = append(result, *b)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Station < result[j].Station })
+	return result
+}
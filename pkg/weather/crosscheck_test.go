@@ -0,0 +1,77 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseAWCObservations(t *testing.T) {
+	body := []byte(`[
+		{"icaoId":"KLAX","obsTime":1764975180,"temp":18.3,"wdir":250,"wspd":12,"metarType":"METAR"},
+		{"icaoId":"KLAX","obsTime":1764971580,"temp":17.2,"wdir":"VRB","wspd":3,"metarType":"METAR"},
+		{"icaoId":"KLAX","obsTime":1764973000,"temp":null,"metarType":"SPECI"}
+	]`)
+
+	obs, err := parseAWCObservations(Stations["LAX"], body)
+	if err != nil {
+		t.Fatalf("parseAWCObservations() error = %v", err)
+	}
+	if len(obs) != 2 {
+		t.Fatalf("got %d observations, want 2 (null temp skipped)", len(obs))
+	}
+	if !obs[0].Time.Before(obs[1].Time) {
+		t.Error("observations not in time order")
+	}
+	if math.Abs(obs[1].Temp-64.94) > 1e-9 {
+		t.Errorf("Temp = %v, want 64.94", obs[1].Temp)
+	}
+	if obs[0].WindDir != 0 || obs[0].WindSpeed != 3 || obs[1].WindDir != 250 || obs[1].WindSpeed != 12 {
+		t.Errorf("wind = %v@%v, %v@%v, want VRB@3, 250@12",
+			obs[0].WindDir, obs[0].WindSpeed, obs[1].WindDir, obs[1].WindSpeed)
+	}
+}
+
+func TestCompareDailyMax(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Los_Angeles")
+	date := time.Date(2025, 12, 5, 0, 0, 0, 0, loc)
+	at := func(h int) time.Time { return date.Add(time.Duration(h) * time.Hour) }
+
+	iem := []Observation{{Time: at(12), Temp: 64.0}, {Time: at(14), Temp: 66.0}, {Time: at(25), Temp: 70.0}}
+	awc := []Observation{{Time: at(-2), Temp: 75.0}, {Time: at(13), Temp: 66.9}, {Time: at(14), Temp: 66.0}}
+
+	c, err := CompareDailyMax("LAX", date, iem, awc)
+	if err != nil {
+		t.Fatalf("CompareDailyMax() error = %v", err)
+	}
+	if c.IEMMax != 66 || c.AWCMax != 66.9 || c.IEMReports != 2 || c.AWCReports != 2 {
+		t.Errorf("CompareDailyMax() = %+v, want 66 vs 66.9 from 2 reports each", c)
+	}
+	if !c.Mismatch() {
+		t.Error("Mismatch() = false, want true (66 vs 67)")
+	}
+
+	if _, err := CompareDailyMax("LAX", date, iem, nil); err == nil {
+		t.Error("CompareDailyMax() with no AWC data: want error")
+	}
+}
+
+func TestSummarizeBias(t *testing.T) {
+	comparisons := []DailyMaxComparison{
+		{Station: "NYC", IEMMax: 50, AWCMax: 50},
+		{Station: "LAX", IEMMax: 64, AWCMax: 65},
+		{Station: "LAX", IEMMax: 66, AWCMax: 65.8},
+	}
+
+	summary := SummarizeBias(comparisons)
+	if len(summary) != 2 || summary[0].Station != "LAX" {
+		t.Fatalf("SummarizeBias() = %+v, want LAX then NYC", summary)
+	}
+	lax := summary[0]
+	if math.Abs(lax.MeanDiff-0.4) > 1e-9 || math.Abs(lax.MeanAbsDiff-0.6) > 1e-9 || lax.MaxAbsDiff != 1 {
+		t.Errorf("LAX = %+v, want mean 0.4, mean abs 0.6, max 1", lax)
+	}
+	if lax.Mismatches != 1 || lax.MismatchRate() != 0.5 {
+		t.Errorf("LAX mismatches = %d (%.2f), want 1 (0.50)", lax.Mismatches, lax.MismatchRate())
+	}
+}
@@ -0,0 +1,45 @@
+package weather
+
+import "time"
+
+// GetStation returns a station by its short code (LAX, MIA, DEN, CHI)
+//
+// Deprecated: Use StationByCode.
+func GetStation(code string) *Station {
+	return StationByCode(code)
+}
+
+// GetStationByMETAR returns a station by its METAR ID (KLAX, KMIA, etc.)
+//
+// Deprecated: Use StationByMETAR.
+func GetStationByMETAR(metarID string) *Station {
+	return StationByMETAR(metarID)
+}
+
+// GetStationByEventPrefix returns a station by its Kalshi event prefix
+//
+// Deprecated: Use StationByEventPrefix.
+func GetStationByEventPrefix(prefix string) *Station {
+	return StationByEventPrefix(prefix)
+}
+
+// GetClimatologyHigh returns the average high temperature for a given month
+//
+// Deprecated: Use ClimatologyHigh.
+func (s *Station) GetClimatologyHigh(month time.Month) float64 {
+	return s.ClimatologyHigh(month)
+}
+
+// GetClimatologyLow returns the average low temperature for a given month
+//
+// Deprecated: Use ClimatologyLow.
+func (s *Station) GetClimatologyLow(month time.Month) float64 {
+	return s.ClimatologyLow(month)
+}
+
+// EventTicker generates the Kalshi event ticker for HIGH temp markets
+//
+// Deprecated: Use HighEventTicker, or EventTickerForType for LOW markets.
+func (s *Station) EventTicker(date time.Time) string {
+	return s.HighEventTicker(date)
+}
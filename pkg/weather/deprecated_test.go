@@ -0,0 +1,36 @@
+package weather
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeprecatedStationLookups(t *testing.T) {
+	for code, s := range Stations {
+		if got, want := GetStation(code), StationByCode(code); got != want {
+			t.Errorf("GetStation(%q) = %v, want %v", code, got, want)
+		}
+		if got, want := GetStationByMETAR(s.ID), StationByMETAR(s.ID); got != want {
+			t.Errorf("GetStationByMETAR(%q) = %v, want %v", s.ID, got, want)
+		}
+		if got, want := GetStationByEventPrefix(s.EventPrefix), StationByEventPrefix(s.EventPrefix); got != want {
+			t.Errorf("GetStationByEventPrefix(%q) = %v, want %v", s.EventPrefix, got, want)
+		}
+	}
+	if GetStation("XYZ") != nil {
+		t.Error("GetStation(XYZ) found a station")
+	}
+}
+
+func TestDeprecatedStationMethods(t *testing.T) {
+	s := StationByCode("LAX")
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	if got, want := s.EventTicker(date), s.HighEventTicker(date); got != want {
+		t.Errorf("EventTicker() = %q, want %q", got, want)
+	}
+	for month := time.January; month <= time.December; month++ {
+		if s.GetClimatologyHigh(month) != s.ClimatologyHigh(month) || s.GetClimatologyLow(month) != s.ClimatologyLow(month) {
+			t.Errorf("%s climatology differs from ClimatologyHigh/Low", month)
+		}
+	}
+}
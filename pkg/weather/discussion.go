@@ -0,0 +1,103 @@
+package weather
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Discussion is an NWS Area Forecast Discussion (AFD): the forecaster's
+// free-text reasoning behind the forecast, including how confident they are
+// in the marine layer, frontal timing and the like. Worth reading next to a
+// day that settled far from the forecast
+type Discussion struct {
+	Office string    // Issuing NWS office (e.g. "LOX")
+	Issued time.Time // From the product's WMO header, to the minute
+	Text   string    // Full product text
+}
+
+// DiscussionURL returns the Iowa State (IEM) AFOS archive URL for the AFDs
+// the station's forecast office issued around date's calendar day
+func (s *Station) DiscussionURL(date time.Time) string {
+	day := s.LocalDay(date)
+	// The archive takes UTC dates; fetch a day either side and filter
+	from := day.UTC().AddDate(0, 0, -1)
+	to := day.AddDate(0, 0, 1).UTC().AddDate(0, 0, 1)
+	return "https://mesonet.agron.iastate.edu/cgi-bin/afos/retrieve.py?" +
+		"pil=AFD" + s.NWSOffice +
+		"&sdate=" + from.Format("2006-01-02") +
+		"&edate=" + to.Format("2006-01-02") +
+		"&fmt=text&limit=9999"
+}
+
+// FetchDiscussions returns the AFDs the station's forecast office issued on
+// date's calendar day (read in the station's time zone), in time order
+func FetchDiscussions(ctx context.Context, station *Station, date time.Time) ([]Discussion, error) {
+	day := station.LocalDay(date)
+	body, err := get(ctx, station.DiscussionURL(day), "AFOS archive")
+	if err != nil {
+		return nil, err
+	}
+
+	var discussions []Discussion
+	for _, d := range ParseDiscussions(string(body), station.NWSOffice, day) {
+		if !d.Issued.Before(day) && d.Issued.Before(day.AddDate(0, 0, 1)) {
+			discussions = append(discussions, d)
+		}
+	}
+	return discussions, nil
+}
+
+// wmoHeader matches the WMO abbreviated heading, e.g. "FXUS66 KLOX 051130"
+var wmoHeader = regexp.MustCompile(`(?m)^[A-Z]{4}\d{2} [A-Z]{4} (\d{2})(\d{2})(\d{2})`)
+
+// ParseDiscussions splits an AFOS archive response into products. The WMO
+// header only carries day-of-month and UTC time, so the issue time is read
+// as the nearest such instant to near. Products without a header are skipped
+func ParseDiscussions(body, office string, near time.Time) []Discussion {
+	var discussions []Discussion
+	// Products are framed by SOH (\x01) and ETX (\x03)
+	for _, product := range strings.Split(body, "\x03") {
+		text := strings.TrimSpace(strings.Trim(strings.TrimSpace(product), "\x01"))
+		m := wmoHeader.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		dd, _ := strconv.Atoi(m[1])
+		hh, _ := strconv.Atoi(m[2])
+		mm, _ := strconv.Atoi(m[3])
+		issued, ok := nearestDay(near.UTC(), dd, hh, mm)
+		if !ok {
+			continue
+		}
+		discussions = append(discussions, Discussion{
+			Office: office,
+			Issued: issued,
+			Text:   strings.ReplaceAll(text, "\r\n", "\n"),
+		})
+	}
+	sort.SliceStable(discussions, func(i, j int) bool {
+		return discussions[i].Issued.Before(discussions[j].Issued)
+	})
+	return discussions
+}
+
+// nearestDay returns the UTC instant on day-of-month dd at hh:mm closest to
+// near, looking one month either way
+func nearestDay(near time.Time, dd, hh, mm int) (time.Time, bool) {
+	var best time.Time
+	for _, months := range []int{-1, 0, 1} {
+		base := time.Date(near.Year(), near.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, months, 0)
+		t := time.Date(base.Year(), base.Month(), dd, hh, mm, 0, 0, time.UTC)
+		if t.Month() != base.Month() {
+			continue // e.g. day 31 in a 30-day month
+		}
+		if best.IsZero() || t.Sub(near).Abs() < best.Sub(near).Abs() {
+			best = t
+		}
+	}
+	return best, !best.IsZero()
+}
@@ -0,0 +1,53 @@
+package weather
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchDiscussions(t *testing.T) {
+	station := Stations["LAX"]
+	date := time.Date(2025, 12, 5, 0, 0, 0, 0, station.Location())
+
+	product := func(header, body string) string {
+		return "\x01\r\n000 \r\n" + header + "\r\nAFDLOX\r\n\r\nArea Forecast Discussion\r\n" + body + "\r\n\x03"
+	}
+	var url string
+	defer func(c *http.Client) { httpClient = c }(httpClient)
+	httpClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		url = r.URL.String()
+		body := product("FXUS66 KLOX 051130", "Marine layer 1500 ft deep.") + // 03:30 PST
+			product("FXUS66 KLOX 060455", "Low clouds may not clear.") + // 20:55 PST
+			product("FXUS66 KLOX 060930", "Next day.") // 01:30 PST Dec 6
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}
+
+	discussions, err := FetchDiscussions(context.Background(), station, date)
+	if err != nil {
+		t.Fatalf("FetchDiscussions() error = %v", err)
+	}
+	if !strings.Contains(url, "pil=AFDLOX") {
+		t.Errorf("fetched %s, want the LOX AFDs", url)
+	}
+	if len(discussions) != 2 {
+		t.Fatalf("FetchDiscussions() = %d discussions, want the 2 issued on Dec 5", len(discussions))
+	}
+	d := discussions[0]
+	if d.Office != "LOX" || !d.Issued.Equal(time.Date(2025, 12, 5, 11, 30, 0, 0, time.UTC)) ||
+		!strings.Contains(d.Text, "Marine layer") || strings.Contains(d.Text, "\r") {
+		t.Errorf("discussions[0] = %+v, want LOX issued 11:30Z with the marine layer text", d)
+	}
+}
+
+func TestNearestDay(t *testing.T) {
+	// A product issued on the 31st, read near the 1st of the next month
+	near := time.Date(2025, 11, 1, 6, 0, 0, 0, time.UTC)
+	got, ok := nearestDay(near, 31, 23, 0)
+	if want := time.Date(2025, 10, 31, 23, 0, 0, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("nearestDay() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,18 @@
+// Package weather provides weather data abstractions for multiple stations
+//
+// Stations is the registry of the stations Kalshi's daily temperature
+// markets settle on, with their timezones, event prefixes and NWS grid
+// points. Observations come from a Provider: the IEM ASOS archive (ASOS),
+// aviationweather.gov, a Cache or Fallback over them, or a Synthetic day
+// for tests. DailyMax and DailyMin reduce a station-day to the running
+// extreme the markets settle on; FetchForecastForDate and
+// FetchLowForecastForDate read the NWS forecast high and overnight low, and
+// FetchGuidance the gridded model guidance behind them, hour by hour.
+//
+// # Stability
+//
+// weather is part of the module's public API and follows semantic
+// versioning: exported identifiers keep their meaning within a major
+// version, and renamed ones stay as deprecated wrappers until the next
+// major release. See CHANGELOG.md.
+package weather
@@ -0,0 +1,25 @@
+package weather_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/weather"
+)
+
+// The running high and low of a station-day, as the markets settle them.
+// A synthetic day stands in for the IEM archive (weather.ASOS).
+func ExampleDailyMax() {
+	station := weather.StationByCode("LAX")
+	day := time.Date(2025, 12, 5, 0, 0, 0, 0, station.Location())
+	provider := weather.NewSynthetic(weather.Scenario{Low: 52, High: 68, Noise: -1})
+	provider.Now = func() time.Time { return day.Add(23 * time.Hour) }
+
+	data, err := weather.DailyMax(context.Background(), provider, station, day)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%s %s: high %.0f°F, low %.0f°F\n", station.ID, data.Date.Format("Jan 2"), data.MaxTemp, data.MinTemp)
+	// Output: KLAX Dec 5: high 68°F, low 52°F
+}
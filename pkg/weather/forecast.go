@@ -1,9 +1,9 @@
 package weather
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"time"
 )
 
@@ -15,14 +15,18 @@ type Forecast struct {
 	LowTemp     float64 // Forecasted low temperature in Fahrenheit
 	Description string  // Short forecast description
 	IsDaytime   bool
+	Issued      time.Time // When NWS last updated the forecast, zero if unknown
+	Start       time.Time // When the forecast period starts, zero if unknown
 }
 
 // NWSForecastResponse represents the NWS API forecast response
 type NWSForecastResponse struct {
 	Properties struct {
+		UpdateTime string `json:"updateTime"`
 		Periods []struct {
 			Number      int    `json:"number"`
 			Name        string `json:"name"`
+			StartTime   string `json:"startTime"`
 			IsDaytime   bool   `json:"isDaytime"`
 			Temperature int    `json:"temperature"`
 			ShortForecast string `json:"shortForecast"`
@@ -32,17 +36,13 @@ type NWSForecastResponse struct {
 
 // FetchNWSForecast fetches the NWS forecast for a station
 func FetchNWSForecast(station *Station) ([]Forecast, error) {
-	url := station.NWSForecastURL()
-
-	resp, err := httpClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch NWS forecast: %w", err)
-	}
-	defer resp.Body.Close()
+	return fetchNWSForecast(context.Background(), station)
+}
 
-	body, err := io.ReadAll(resp.Body)
+func fetchNWSForecast(ctx context.Context, station *Station) ([]Forecast, error) {
+	body, err := get(ctx, station.NWSForecastURL(), "NWS")
 	if err != nil {
-		return nil, fmt.Errorf("failed to read NWS response: %w", err)
+		return nil, err
 	}
 
 	var nwsResp NWSForecastResponse
@@ -52,6 +52,7 @@ func FetchNWSForecast(station *Station) ([]Forecast, error) {
 
 	var forecasts []Forecast
 	loc := station.Location()
+	issued, _ := time.Parse(time.RFC3339, nwsResp.Properties.UpdateTime)
 
 	for _, period := range nwsResp.Properties.Periods {
 		f := Forecast{
@@ -59,6 +60,10 @@ func FetchNWSForecast(station *Station) ([]Forecast, error) {
 			Date:        time.Now().In(loc),
 			Description: period.ShortForecast,
 			IsDaytime:   period.IsDaytime,
+			Issued:      issued,
+		}
+		if start, err := time.Parse(time.RFC3339, period.StartTime); err == nil {
+			f.Start = start.In(loc)
 		}
 
 		if period.IsDaytime {
@@ -88,7 +93,7 @@ func FetchTomorrowHigh(station *Station) (float64, error) {
 	}
 
 	// Fallback to climatology
-	return station.GetClimatologyHigh(time.Now().Month()), nil
+	return station.ClimatologyHigh(time.Now().Month()), nil
 }
 
 // FetchForecastForDate fetches the forecast for a specific date
@@ -97,9 +102,14 @@ func FetchForecastForDate(station *Station, targetDate time.Time) (*Forecast, er
 	if err != nil {
 		return nil, err
 	}
+	return forecastForDate(forecasts, station, targetDate, time.Now())
+}
 
+// forecastForDate picks targetDate's daytime period from forecasts fetched
+// at now
+func forecastForDate(forecasts []Forecast, station *Station, targetDate, now time.Time) (*Forecast, error) {
 	loc := station.Location()
-	today := time.Now().In(loc)
+	today := now.In(loc)
 	targetDay := targetDate.In(loc)
 
 	// Determine which forecast period to use based on date offset
@@ -122,4 +132,34 @@ func FetchForecastForDate(station *Station, targetDate time.Time) (*Forecast, er
 	return nil, fmt.Errorf("no forecast found for %s", targetDate.Format("2006-01-02"))
 }
 
+// FetchLowForecastForDate fetches the forecast overnight low of a specific
+// date: the night period ending that morning, when the daily minimum a LOW
+// market settles on usually sets
+func FetchLowForecastForDate(station *Station, targetDate time.Time) (*Forecast, error) {
+	forecasts, err := FetchNWSForecast(station)
+	if err != nil {
+		return nil, err
+	}
+	return lowForecastForDate(forecasts, station, targetDate)
+}
+
+// lowForecastForDate picks the night period running into targetDate's
+// morning: one starting the evening before, or after midnight on the day
+// itself ("Overnight"). Periods without a start time never match
+func lowForecastForDate(forecasts []Forecast, station *Station, targetDate time.Time) (*Forecast, error) {
+	day := station.LocalDay(targetDate.In(station.Location()))
+	for i, f := range forecasts {
+		if f.IsDaytime || f.Start.IsZero() {
+			continue
+		}
+		start := f.Start.In(station.Location())
+		eveningBefore := station.LocalDay(start).Equal(day.AddDate(0, 0, -1)) && start.Hour() >= 12
+		overnight := station.LocalDay(start).Equal(day) && start.Hour() < 12
+		if eveningBefore || overnight {
+			return &forecasts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no overnight low forecast for %s", targetDate.Format("2006-01-02"))
+}
+
 
@@ -0,0 +1,80 @@
+package weather
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMETARData_RunningMin(t *testing.T) {
+	station := Stations["DEN"]
+	loc := station.Location()
+	date := time.Date(2025, 12, 5, 0, 0, 0, 0, loc)
+	now := time.Date(2025, 12, 5, 12, 0, 0, 0, loc)
+
+	data := "station,valid,tmpf\n" +
+		"DEN,2025-12-05 00:53,28.04\n" +
+		"DEN,2025-12-05 03:53,24.98\n" +
+		"DEN,2025-12-05 06:53,21.92\n" +
+		"DEN,2025-12-05 09:53,30.02\n"
+	result, err := parseMETARData(station, date, data, now)
+	if err != nil {
+		t.Fatalf("parseMETARData() error = %v", err)
+	}
+	if result.MinTemp != 22 || result.MinTempTime.Hour() != 6 {
+		t.Errorf("MinTemp = %v at %v, want 22 at 06:53", result.MinTemp, result.MinTempTime)
+	}
+
+	steps := []struct {
+		hour int
+		want float64
+		ok   bool
+	}{
+		{0, 0, false},
+		{2, 28, true},
+		{5, 25, true},
+		{11, 22, true},
+	}
+	for _, s := range steps {
+		got, ok := result.MinBefore(date.Add(time.Duration(s.hour) * time.Hour))
+		if got != s.want || ok != s.ok {
+			t.Errorf("MinBefore(%02d:00) = %v, %v, want %v, %v", s.hour, got, ok, s.want, s.ok)
+		}
+	}
+}
+
+func TestLowForecastForDate(t *testing.T) {
+	station := Stations["CHI"]
+	loc := station.Location()
+	at := func(day, hour int) time.Time { return time.Date(2025, 12, day, hour, 0, 0, 0, loc) }
+
+	forecasts := []Forecast{
+		{IsDaytime: true, HighTemp: 35, Start: at(5, 6)},
+		{IsDaytime: false, LowTemp: 22, Start: at(5, 18)},
+		{IsDaytime: true, HighTemp: 31, Start: at(6, 6)},
+		{IsDaytime: false, LowTemp: 18, Start: at(6, 18)},
+	}
+	tests := []struct {
+		date time.Time
+		want float64
+	}{
+		{at(6, 0), 22}, // Tonight's low sets tomorrow morning
+		{at(7, 0), 18},
+	}
+	for _, tt := range tests {
+		f, err := lowForecastForDate(forecasts, station, tt.date)
+		if err != nil || f.LowTemp != tt.want {
+			t.Errorf("lowForecastForDate(%s) = %v, %v, want low %v", tt.date.Format("Jan 2"), f, err, tt.want)
+		}
+	}
+
+	// Fetched after midnight, the first period is the rest of the night
+	overnight := []Forecast{{IsDaytime: false, LowTemp: 20, Start: at(6, 2)}}
+	if f, err := lowForecastForDate(overnight, station, at(6, 0)); err != nil || f.LowTemp != 20 {
+		t.Errorf("lowForecastForDate(overnight) = %v, %v, want low 20", f, err)
+	}
+
+	// Today's low has passed once the forecast starts with today's day
+	if _, err := lowForecastForDate(forecasts, station, at(5, 0)); err == nil {
+		t.Error("lowForecastForDate(past night) error = nil, want an error")
+	}
+}
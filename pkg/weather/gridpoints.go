@@ -0,0 +1,206 @@
+package weather
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// nwsPointsURL is the NWS endpoint mapping a lat/lon to its forecast grid
+const nwsPointsURL = "https://api.weather.gov/points/"
+
+// GridPoint is the NWS forecast office and grid cell covering a station
+type GridPoint struct {
+	Office     string    `json:"office"`
+	X          int       `json:"x"`
+	Y          int       `json:"y"`
+	ResolvedAt time.Time `json:"resolved_at"`
+}
+
+// String formats the grid point as used in NWS gridpoint URLs (LOX/154,44)
+func (g GridPoint) String() string {
+	return g.Office + "/" + strconv.Itoa(g.X) + "," + strconv.Itoa(g.Y)
+}
+
+// GridChange records a station whose resolved NWS office differs from the
+// one it was previously using
+type GridChange struct {
+	Station string // Short code (e.g. "LAX")
+	Old     GridPoint
+	New     GridPoint
+}
+
+// String formats the change for logs and alerts
+func (c GridChange) String() string {
+	return fmt.Sprintf("%s NWS office changed: %s -> %s", c.Station, c.Old, c.New)
+}
+
+// nwsPointsResponse is the part of the NWS /points response we use
+type nwsPointsResponse struct {
+	Properties struct {
+		GridID string `json:"gridId"`
+		GridX  int    `json:"gridX"`
+		GridY  int    `json:"gridY"`
+	} `json:"properties"`
+}
+
+// FetchGridPoint resolves the station's forecast office and grid cell from
+// its lat/lon via the NWS /points endpoint
+func FetchGridPoint(station *Station) (GridPoint, error) {
+	url := nwsPointsURL + strconv.FormatFloat(station.Lat, 'f', 4, 64) + "," +
+		strconv.FormatFloat(station.Lon, 'f', 4, 64)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return GridPoint{}, err
+	}
+	// NWS rejects requests without a User-Agent
+	req.Header.Set("User-Agent", "kalshi-go")
+	req.Header.Set("Accept", "application/geo+json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return GridPoint{}, fmt.Errorf("failed to fetch NWS points: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return GridPoint{}, fmt.Errorf("failed to read NWS points response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return GridPoint{}, fmt.Errorf("NWS points returned HTTP %d", resp.StatusCode)
+	}
+
+	return parseGridPoint(body, time.Now())
+}
+
+func parseGridPoint(body []byte, now time.Time) (GridPoint, error) {
+	var points nwsPointsResponse
+	if err := json.Unmarshal(body, &points); err != nil {
+		return GridPoint{}, fmt.Errorf("failed to parse NWS points response: %w", err)
+	}
+	if points.Properties.GridID == "" {
+		return GridPoint{}, fmt.Errorf("NWS points response has no gridId")
+	}
+	return GridPoint{
+		Office:     points.Properties.GridID,
+		X:          points.Properties.GridX,
+		Y:          points.Properties.GridY,
+		ResolvedAt: now,
+	}, nil
+}
+
+// GridPoint returns the grid point the station currently uses
+func (s *Station) GridPoint() GridPoint {
+	return GridPoint{Office: s.NWSOffice, X: s.NWSGridX, Y: s.NWSGridY}
+}
+
+// SetGridPoint points the station's NWS requests at g
+func (s *Station) SetGridPoint(g GridPoint) {
+	s.NWSOffice, s.NWSGridX, s.NWSGridY = g.Office, g.X, g.Y
+}
+
+// ResolveGridPoints resolves each station's grid point from the NWS API and
+// updates the station in place, so a re-grid doesn't silently break
+// forecasts. Results are cached in a JSON file at cachePath (skipped if
+// empty); a station that can't be resolved falls back to its cached and then
+// its built-in grid point. The returned changes list stations whose office
+// differs from the one previously used, which callers should alert on. Fetch
+// errors are joined into the returned error but don't stop other stations
+func ResolveGridPoints(stations []*Station, cachePath string) ([]GridChange, error) {
+	return resolveGridPoints(stations, cachePath, FetchGridPoint)
+}
+
+func resolveGridPoints(stations []*Station, cachePath string, fetch func(*Station) (GridPoint, error)) ([]GridChange, error) {
+	cache, err := loadGridCache(cachePath)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := append([]*Station(nil), stations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	var changes []GridChange
+	var errs []error
+	for _, s := range sorted {
+		previous, cached := cache[s.ID]
+		if !cached {
+			previous = s.GridPoint()
+		}
+
+		resolved, err := fetch(s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.ID, err))
+			s.SetGridPoint(previous)
+			continue
+		}
+
+		if resolved.Office != previous.Office {
+			changes = append(changes, GridChange{Station: stationCode(s), Old: previous, New: resolved})
+		}
+		s.SetGridPoint(resolved)
+		cache[s.ID] = resolved
+	}
+
+	if err := saveGridCache(cachePath, cache); err != nil {
+		errs = append(errs, err)
+	}
+	return changes, errors.Join(errs...)
+}
+
+// stationCode returns the registry key of a station, or its METAR ID if it
+// isn't registered
+func stationCode(s *Station) string {
+	for code, registered := range Stations {
+		if registered == s || registered.ID == s.ID {
+			return code
+		}
+	}
+	return s.ID
+}
+
+// loadGridCache reads grid points keyed by METAR ID (a missing file is an
+// empty cache)
+func loadGridCache(path string) (map[string]GridPoint, error) {
+	cache := make(map[string]GridPoint)
+	if path == "" {
+		return cache, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("read grid point cache: %w", err)
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("parse grid point cache: %w", err)
+	}
+	return cache, nil
+}
+
+func saveGridCache(path string, cache map[string]GridPoint) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("write grid point cache: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("write grid point cache: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
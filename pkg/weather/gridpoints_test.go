@@ -0,0 +1,68 @@
+package weather
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseGridPoint(t *testing.T) {
+	body := []byte(`{"properties":{"gridId":"LOX","gridX":155,"gridY":45,"forecast":"https://api.weather.gov/gridpoints/LOX/155,45/forecast"}}`)
+	now := time.Date(2025, 12, 5, 8, 0, 0, 0, time.UTC)
+
+	g, err := parseGridPoint(body, now)
+	if err != nil {
+		t.Fatalf("parseGridPoint() error = %v", err)
+	}
+	if g.String() != "LOX/155,45" || !g.ResolvedAt.Equal(now) {
+		t.Errorf("parseGridPoint() = %v at %v, want LOX/155,45 at %v", g, g.ResolvedAt, now)
+	}
+
+	if _, err := parseGridPoint([]byte(`{"properties":{}}`), now); err == nil {
+		t.Error("parseGridPoint() with no gridId: want error")
+	}
+}
+
+func TestResolveGridPoints(t *testing.T) {
+	lax, den := *Stations["LAX"], *Stations["DEN"]
+	stations := []*Station{&lax, &den}
+	cache := filepath.Join(t.TempDir(), "gridpoints.json")
+
+	// LAX keeps its office on a new cell; DEN moves to another office
+	resolved := map[string]GridPoint{
+		"KLAX": {Office: "LOX", X: 155, Y: 45},
+		"KDEN": {Office: "GJT", X: 10, Y: 20},
+	}
+	fetch := func(s *Station) (GridPoint, error) { return resolved[s.ID], nil }
+
+	changes, err := resolveGridPoints(stations, cache, fetch)
+	if err != nil {
+		t.Fatalf("resolveGridPoints() error = %v", err)
+	}
+	if len(changes) != 1 || changes[0].Station != "DEN" || changes[0].Old.Office != "BOU" || changes[0].New.Office != "GJT" {
+		t.Fatalf("changes = %v, want DEN BOU -> GJT", changes)
+	}
+	if lax.NWSGridX != 155 || den.NWSOffice != "GJT" {
+		t.Errorf("stations not updated: %v, %v", lax.GridPoint(), den.GridPoint())
+	}
+	if Stations["DEN"].NWSOffice != "BOU" {
+		t.Error("registry station modified through a copy")
+	}
+
+	// The API is down: stations fall back to the cache, which already
+	// reflects the move, so nothing is reported as changed
+	lax2, den2 := *Stations["LAX"], *Stations["DEN"]
+	down := func(*Station) (GridPoint, error) { return GridPoint{}, errors.New("HTTP 503") }
+
+	changes, err = resolveGridPoints([]*Station{&lax2, &den2}, cache, down)
+	if err == nil {
+		t.Error("resolveGridPoints() with API down: want error")
+	}
+	if len(changes) != 0 {
+		t.Errorf("changes = %v, want none", changes)
+	}
+	if lax2.GridPoint().String() != "LOX/155,45" || den2.GridPoint().String() != "GJT/10,20" {
+		t.Errorf("fallback = %v, %v, want cached grid points", lax2.GridPoint(), den2.GridPoint())
+	}
+}
@@ -0,0 +1,195 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GridValue is a gridded forecast value over a period
+type GridValue struct {
+	Start time.Time
+	End   time.Time
+	Value float64 // °F
+}
+
+// Guidance is the NWS gridded forecast of a station's grid cell: the
+// National Blend of Models (NBM), which blends the HRRR, GFS and other
+// models' guidance with the forecasters' edits. Unlike the point forecast's
+// rounded day and night periods it has the hourly temperature curve
+type Guidance struct {
+	Station *Station
+	Issued  time.Time
+	Hourly  []GridValue // Temperature, hour by hour
+	Max     []GridValue // Daytime maximum temperature periods
+	Min     []GridValue // Overnight minimum temperature periods
+}
+
+// NWSGridDataURL returns the NWS API URL of the raw gridded forecast for
+// this station
+func (s *Station) NWSGridDataURL() string {
+	return "https://api.weather.gov/gridpoints/" + s.NWSOffice + "/" +
+		itoa(s.NWSGridX) + "," + itoa(s.NWSGridY)
+}
+
+// gridLayer is one quantity of the NWS gridpoint response
+type gridLayer struct {
+	UOM    string `json:"uom"`
+	Values []struct {
+		ValidTime string   `json:"validTime"`
+		Value     *float64 `json:"value"`
+	} `json:"values"`
+}
+
+// nwsGridResponse is the part of the NWS gridpoint response we use
+type nwsGridResponse struct {
+	Properties struct {
+		UpdateTime     string    `json:"updateTime"`
+		Temperature    gridLayer `json:"temperature"`
+		MaxTemperature gridLayer `json:"maxTemperature"`
+		MinTemperature gridLayer `json:"minTemperature"`
+	} `json:"properties"`
+}
+
+// FetchGuidance fetches the NWS gridded forecast for a station
+func FetchGuidance(ctx context.Context, station *Station) (*Guidance, error) {
+	body, err := get(ctx, station.NWSGridDataURL(), "NWS gridpoint")
+	if err != nil {
+		return nil, err
+	}
+	return parseGuidance(station, body)
+}
+
+func parseGuidance(station *Station, body []byte) (*Guidance, error) {
+	var resp nwsGridResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse NWS gridpoint response: %w", err)
+	}
+	g := &Guidance{Station: station}
+	g.Issued, _ = time.Parse(time.RFC3339, resp.Properties.UpdateTime)
+
+	var err error
+	if g.Hourly, err = resp.Properties.Temperature.values(station); err != nil {
+		return nil, fmt.Errorf("temperature: %w", err)
+	}
+	if g.Max, err = resp.Properties.MaxTemperature.values(station); err != nil {
+		return nil, fmt.Errorf("maxTemperature: %w", err)
+	}
+	if g.Min, err = resp.Properties.MinTemperature.values(station); err != nil {
+		return nil, fmt.Errorf("minTemperature: %w", err)
+	}
+	if len(g.Hourly) == 0 && len(g.Max) == 0 && len(g.Min) == 0 {
+		return nil, fmt.Errorf("NWS gridpoint response has no temperatures")
+	}
+	return g, nil
+}
+
+// values converts the layer to °F. A value valid over several hours (e.g.
+// "2025-12-05T14:00:00+00:00/PT3H") is one GridValue over the whole period
+func (l gridLayer) values(station *Station) ([]GridValue, error) {
+	celsius := strings.HasSuffix(l.UOM, "degC")
+	loc := station.Location()
+	var result []GridValue
+	for _, v := range l.Values {
+		if v.Value == nil {
+			continue
+		}
+		start, dur, ok := strings.Cut(v.ValidTime, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid validTime %q", v.ValidTime)
+		}
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validTime %q: %w", v.ValidTime, err)
+		}
+		d, err := parseISODuration(dur)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validTime %q: %w", v.ValidTime, err)
+		}
+		temp := *v.Value
+		if celsius {
+			temp = temp*9/5 + 32
+		}
+		result = append(result, GridValue{Start: t.In(loc), End: t.Add(d).In(loc), Value: temp})
+	}
+	return result, nil
+}
+
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?)?$`)
+
+// parseISODuration parses the ISO 8601 durations of NWS validTimes, e.g.
+// PT1H, PT13H or P1DT6H
+func parseISODuration(s string) (time.Duration, error) {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil || s == "P" || s == "PT" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+1] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[i+1])
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
+
+// HighForDate returns the forecast high of the station's local day: its
+// daytime max temperature period, else the warmest hour of the day
+func (g *Guidance) HighForDate(date time.Time) (float64, bool) {
+	day := g.Station.LocalDay(date)
+	next := day.AddDate(0, 0, 1)
+	for _, v := range g.Max {
+		if !v.Start.Before(day) && v.Start.Before(next) {
+			return v.Value, true
+		}
+	}
+	return g.hourlyExtreme(day, next, math.Max)
+}
+
+// LowForDate returns the forecast low of the station's local day: the
+// overnight min temperature period ending in it, else the coldest hour of
+// the day
+func (g *Guidance) LowForDate(date time.Time) (float64, bool) {
+	day := g.Station.LocalDay(date)
+	next := day.AddDate(0, 0, 1)
+	for _, v := range g.Min {
+		if v.End.After(day) && !v.End.After(next) {
+			return v.Value, true
+		}
+	}
+	return g.hourlyExtreme(day, next, math.Min)
+}
+
+// HourlyBetween returns the hourly temperatures starting in [from, to)
+func (g *Guidance) HourlyBetween(from, to time.Time) []GridValue {
+	var result []GridValue
+	for _, v := range g.Hourly {
+		// A value over several hours covers each of them
+		for t := v.Start; t.Before(v.End); t = t.Add(time.Hour) {
+			if !t.Before(from) && t.Before(to) {
+				result = append(result, GridValue{Start: t, End: t.Add(time.Hour), Value: v.Value})
+			}
+		}
+	}
+	return result
+}
+
+func (g *Guidance) hourlyExtreme(from, to time.Time, pick func(a, b float64) float64) (float64, bool) {
+	hours := g.HourlyBetween(from, to)
+	if len(hours) == 0 {
+		return 0, false
+	}
+	extreme := hours[0].Value
+	for _, h := range hours[1:] {
+		extreme = pick(extreme, h.Value)
+	}
+	return extreme, true
+}
@@ -0,0 +1,75 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseGuidance(t *testing.T) {
+	// LAX is UTC-8 in December: the day's max period starts at 07:00 local
+	body := []byte(`{"properties":{"updateTime":"2025-12-05T10:12:00+00:00",
+		"temperature":{"uom":"wmoUnit:degC","values":[
+			{"validTime":"2025-12-05T20:00:00+00:00/PT1H","value":17.8},
+			{"validTime":"2025-12-05T21:00:00+00:00/PT2H","value":19.4},
+			{"validTime":"2025-12-05T23:00:00+00:00/PT1H","value":null},
+			{"validTime":"2025-12-06T00:00:00+00:00/PT1H","value":18.3}]},
+		"maxTemperature":{"uom":"wmoUnit:degC","values":[
+			{"validTime":"2025-12-05T15:00:00+00:00/PT13H","value":20}]},
+		"minTemperature":{"uom":"wmoUnit:degC","values":[
+			{"validTime":"2025-12-05T02:00:00+00:00/PT14H","value":10}]}}}`)
+	lax := Stations["LAX"]
+	g, err := parseGuidance(lax, body)
+	if err != nil {
+		t.Fatalf("parseGuidance() error = %v", err)
+	}
+	if !g.Issued.Equal(time.Date(2025, 12, 5, 10, 12, 0, 0, time.UTC)) {
+		t.Errorf("Issued = %v", g.Issued)
+	}
+
+	day := time.Date(2025, 12, 5, 0, 0, 0, 0, lax.Location())
+	if high, ok := g.HighForDate(day); !ok || high != 68 {
+		t.Errorf("HighForDate() = %v, %v, want 68", high, ok)
+	}
+	if low, ok := g.LowForDate(day); !ok || low != 50 {
+		t.Errorf("LowForDate() = %v, %v, want 50", low, ok)
+	}
+
+	// The two-hour value covers 13:00 and 14:00 local
+	hours := g.HourlyBetween(day.Add(12*time.Hour), day.Add(24*time.Hour))
+	if len(hours) != 4 || hours[1].Start.Hour() != 13 || hours[2].Start.Hour() != 14 || math.Abs(hours[2].Value-66.92) > 0.01 {
+		t.Errorf("HourlyBetween() = %+v, want 12:00-16:00 with 13:00 and 14:00 at 66.9°F", hours)
+	}
+
+	// Without max/min periods the hourly curve stands in
+	g.Max, g.Min = nil, nil
+	if high, ok := g.HighForDate(day); !ok || math.Abs(high-66.92) > 0.01 {
+		t.Errorf("HighForDate(hourly) = %v, %v, want 66.92", high, ok)
+	}
+	if _, ok := g.HighForDate(day.AddDate(0, 0, 2)); ok {
+		t.Error("HighForDate(beyond the forecast) = ok, want false")
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"PT1H", time.Hour},
+		{"PT13H", 13 * time.Hour},
+		{"P1DT6H", 30 * time.Hour},
+		{"P2D", 48 * time.Hour},
+		{"PT30M", 30 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got, err := parseISODuration(tt.in); err != nil || got != tt.want {
+			t.Errorf("parseISODuration(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "P", "PT", "1H", "PT1S"} {
+		if _, err := parseISODuration(bad); err == nil {
+			t.Errorf("parseISODuration(%q) = nil error, want an error", bad)
+		}
+	}
+}
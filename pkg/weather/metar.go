@@ -1,6 +1,8 @@
 package weather
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -9,106 +11,119 @@ import (
 	"time"
 )
 
-// METARObservation represents a single METAR temperature observation
-type METARObservation struct {
+// Observation is a single temperature report (a routine METAR or a SPECI)
+type Observation struct {
 	Time time.Time
 	Temp float64 // Temperature in Fahrenheit
+
+	// Wind, where the source reports it: zero when calm or missing
+	WindSpeed float64 // Knots
+	WindDir   float64 // Degrees true the wind blows from (0 = calm or variable)
 }
 
 // METARData holds METAR data for a station/date
 type METARData struct {
 	Station      *Station
 	Date         time.Time
-	Observations []METARObservation
+	Observations []Observation
 	MaxTemp      float64 // Maximum temperature in Fahrenheit
 	MaxTempTime  time.Time
+	MinTemp      float64 // Minimum temperature in Fahrenheit, for LOW markets
+	MinTempTime  time.Time
 }
 
-var httpClient = &http.Client{Timeout: 15 * time.Second}
-
-// FetchMETARMax fetches the maximum METAR temperature for a station on a given date
-func FetchMETARMax(station *Station, date time.Time) (*METARData, error) {
-	url := station.METARHistoryURL(date)
-
-	resp, err := httpClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch METAR: %w", err)
+// MinBefore returns the running minimum of the reports strictly before t,
+// rounded to whole degrees, and false if there are none yet. Evaluated hour
+// by hour it traces the overnight low as it sets
+func (d *METARData) MinBefore(t time.Time) (float64, bool) {
+	minTemp := math.Inf(1)
+	for _, o := range d.Observations {
+		if !o.Time.Before(t) {
+			break
+		}
+		minTemp = math.Min(minTemp, o.Temp)
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read METAR response: %w", err)
+	if math.IsInf(minTemp, 1) {
+		return 0, false
 	}
-
-	return parseMETARData(station, date, string(body))
+	return math.Round(minTemp), true
 }
 
-func parseMETARData(station *Station, date time.Time, data string) (*METARData, error) {
-	result := &METARData{
-		Station: station,
-		Date:    date,
-	}
+var httpClient = &http.Client{Timeout: 15 * time.Second}
 
-	// Remove 'K' prefix for matching
-	stationCode := station.ID
-	if len(stationCode) > 1 && stationCode[0] == 'K' {
-		stationCode = stationCode[1:]
-	}
+// FetchMETARMax fetches the maximum METAR temperature for a station on a
+// given date from the ASOS archive
+func FetchMETARMax(station *Station, date time.Time) (*METARData, error) {
+	return DailyMax(context.Background(), ASOS, station, date)
+}
 
-	lines := strings.Split(data, "\n")
-	maxTemp := -999.0
-	var maxTime time.Time
+// FetchMETARMin fetches the minimum METAR temperature for a station on a
+// given date from the ASOS archive
+func FetchMETARMin(station *Station, date time.Time) (*METARData, error) {
+	return DailyMin(context.Background(), ASOS, station, date)
+}
 
-	loc := station.Location()
+func parseMETARData(station *Station, date time.Time, data string, now time.Time) (*METARData, error) {
+	obs := ParseIEMObservations(data, strings.TrimPrefix(station.ID, "K"), station.Location())
+	return extremesOf(station, station.LocalDay(date), obs, now)
+}
 
-	for _, line := range lines {
+// ParseIEMObservations parses an Iowa State ASOS CSV export
+// ("LAX,2025-12-26 14:53,64.00" lines, times in loc) for one station code,
+// skipping missing and malformed readings. Wind is read from the drct and
+// sknt columns when the header lists them
+func ParseIEMObservations(data, stationCode string, loc *time.Location) []Observation {
+	tempCol, dirCol, speedCol := 2, -1, -1
+	var obs []Observation
+	for _, line := range strings.Split(data, "\n") {
+		if strings.HasPrefix(line, "station,") {
+			for i, name := range strings.Split(strings.TrimSpace(line), ",") {
+				switch name {
+				case "tmpf":
+					tempCol = i
+				case "drct":
+					dirCol = i
+				case "sknt":
+					speedCol = i
+				}
+			}
+			continue
+		}
 		if !strings.HasPrefix(line, stationCode+",") {
 			continue
 		}
 
-		parts := strings.Split(line, ",")
-		if len(parts) < 3 {
+		parts := strings.Split(strings.TrimSpace(line), ",")
+		if len(parts) <= tempCol {
 			continue
 		}
 
 		// Parse timestamp (format: 2025-12-26 14:53)
-		timeStr := parts[1]
-		t, err := time.ParseInLocation("2006-01-02 15:04", timeStr, loc)
+		t, err := time.ParseInLocation("2006-01-02 15:04", parts[1], loc)
 		if err != nil {
 			continue
 		}
 
-		// Parse temperature
+		// Parse temperature ("M" = missing)
 		var temp float64
-		if _, err := fmt.Sscanf(parts[2], "%f", &temp); err != nil {
+		if _, err := fmt.Sscanf(parts[tempCol], "%f", &temp); err != nil {
 			continue
 		}
 
-		obs := METARObservation{
-			Time: t,
-			Temp: temp,
+		o := Observation{Time: t, Temp: temp}
+		if dirCol >= 0 && dirCol < len(parts) {
+			fmt.Sscanf(parts[dirCol], "%f", &o.WindDir)
 		}
-		result.Observations = append(result.Observations, obs)
-
-		if temp > maxTemp {
-			maxTemp = temp
-			maxTime = t
+		if speedCol >= 0 && speedCol < len(parts) {
+			fmt.Sscanf(parts[speedCol], "%f", &o.WindSpeed)
 		}
+		obs = append(obs, o)
 	}
-
-	if maxTemp == -999.0 {
-		return nil, fmt.Errorf("no METAR data found for %s on %s", station.ID, date.Format("2006-01-02"))
-	}
-
-	result.MaxTemp = math.Round(maxTemp)
-	result.MaxTempTime = maxTime
-
-	return result, nil
+	return obs
 }
 
 // FetchCurrentMETAR fetches the current METAR observation for a station
-func FetchCurrentMETAR(station *Station) (*METARObservation, error) {
+func FetchCurrentMETAR(station *Station) (*Observation, error) {
 	url := "https://aviationweather.gov/api/data/metar?ids=" + station.ID + "&format=json"
 
 	resp, err := httpClient.Get(url)
@@ -139,8 +154,21 @@ func FetchCurrentMETAR(station *Station) (*METARObservation, error) {
 	// Convert Celsius to Fahrenheit
 	tempF := tempC*9/5 + 32
 
-	return &METARObservation{
-		Time: time.Now().In(station.Location()),
+	// Report time (look for "obsTime": unix seconds), else assume fresh
+	obsTime := time.Now()
+	if idx := strings.Index(data, `"obsTime":`); idx != -1 {
+		var unix int64
+		if _, err := fmt.Sscanf(data[idx+10:], "%d", &unix); err == nil {
+			obsTime = time.Unix(unix, 0)
+		}
+	}
+
+	if err := CheckObservationTime(obsTime, time.Now()); errors.Is(err, ErrFutureObservation) {
+		return nil, err
+	}
+
+	return &Observation{
+		Time: obsTime.In(station.Location()),
 		Temp: math.Round(tempF),
 	}, nil
 }
@@ -0,0 +1,221 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Provider is a source of a station's temperature reports. Bots and
+// backtests take a Provider rather than fetching and parsing a feed
+// themselves, so every caller computes the daily max the same way
+type Provider interface {
+	// Name identifies the source in logs and errors (e.g. "asos")
+	Name() string
+
+	// Observations returns the station's reports for date's calendar day
+	// (read in the station's time zone), in time order
+	Observations(ctx context.Context, station *Station, date time.Time) ([]Observation, error)
+}
+
+// ForecastProvider is a source of a station's forecast highs
+type ForecastProvider interface {
+	Name() string
+
+	// Forecast returns the daytime forecast for date's calendar day
+	Forecast(ctx context.Context, station *Station, date time.Time) (*Forecast, error)
+}
+
+// Built-in providers
+var (
+	// ASOS serves the Iowa State (IEM) ASOS archive: every METAR and SPECI
+	// for any date, the history settlement is checked against. Reports reach
+	// the archive a few minutes after AWC publishes them
+	ASOS Provider = asosProvider{}
+
+	// METAR serves the Aviation Weather Center feed: the freshest reports,
+	// but only the last AWCMaxHours
+	METAR Provider = awcProvider{}
+
+	// NWS serves National Weather Service gridpoint forecasts
+	NWS ForecastProvider = nwsProvider{}
+)
+
+// ErrNoObservations means a provider has no reports for the station-day
+var ErrNoObservations = errors.New("no observations")
+
+// LocalDay returns local midnight of date's calendar day in the station's
+// time zone
+func (s *Station) LocalDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, s.Location())
+}
+
+// CLIReportDelay is how long after a station's local day ends its NWS
+// climate report (CLI), which Kalshi settles on, has typically posted. The
+// preliminary CLI goes out in the small hours; the delay leaves room for a
+// late issuance or a correction
+const CLIReportDelay = 8 * time.Hour
+
+// CLIReportTime returns when the CLI report for date's local day at the
+// station has typically posted: CLIReportDelay after the day ends. Work that
+// needs the day's settlement is scheduled from it rather than a fixed UTC
+// hour, as the stations' days end hours apart
+func (s *Station) CLIReportTime(date time.Time) time.Time {
+	return s.LocalDay(date.In(s.Location())).AddDate(0, 0, 1).Add(CLIReportDelay)
+}
+
+// DailyMax returns the station's maximum temperature so far on date's
+// calendar day from p. Future-dated and off-day reports are dropped first;
+// MaxTemp is rounded to whole degrees as the settlement source reports it
+func DailyMax(ctx context.Context, p Provider, station *Station, date time.Time) (*METARData, error) {
+	day := station.LocalDay(date)
+	obs, err := p.Observations(ctx, station, day)
+	if err != nil {
+		return nil, err
+	}
+	return extremesOf(station, day, obs, time.Now())
+}
+
+// DailyMin returns the station's minimum temperature so far on date's
+// calendar day from p, the running overnight low a LOW market settles on,
+// with the same filtering and rounding as DailyMax
+func DailyMin(ctx context.Context, p Provider, station *Station, date time.Time) (*METARData, error) {
+	return DailyMax(ctx, p, station, date)
+}
+
+// extremesOf returns the day's reports with their maximum and minimum
+func extremesOf(station *Station, day time.Time, obs []Observation, now time.Time) (*METARData, error) {
+	// Future-dated or off-day reports would corrupt the daily max
+	obs, _ = SaneObservations(obs, day, now)
+	if len(obs) == 0 {
+		return nil, fmt.Errorf("%w for %s on %s", ErrNoObservations, station.ID, day.Format("2006-01-02"))
+	}
+
+	result := &METARData{Station: station, Date: day, Observations: obs}
+	maxTemp, minTemp := math.Inf(-1), math.Inf(1)
+	for _, o := range obs {
+		if o.Temp > maxTemp {
+			maxTemp = o.Temp
+			result.MaxTempTime = o.Time
+		}
+		if o.Temp < minTemp {
+			minTemp = o.Temp
+			result.MinTempTime = o.Time
+		}
+	}
+	result.MaxTemp = math.Round(maxTemp)
+	result.MinTemp = math.Round(minTemp)
+	return result, nil
+}
+
+type asosProvider struct{}
+
+func (asosProvider) Name() string { return "asos" }
+
+func (asosProvider) Observations(ctx context.Context, station *Station, date time.Time) ([]Observation, error) {
+	day := station.LocalDay(date)
+	body, err := get(ctx, station.METARHistoryURL(day), "ASOS")
+	if err != nil {
+		return nil, err
+	}
+	obs := ParseIEMObservations(string(body), strings.TrimPrefix(station.ID, "K"), station.Location())
+	obs, _ = SaneObservations(obs, day, time.Now())
+	return obs, nil
+}
+
+type awcProvider struct{}
+
+func (awcProvider) Name() string { return "metar" }
+
+func (awcProvider) Observations(ctx context.Context, station *Station, date time.Time) ([]Observation, error) {
+	day := station.LocalDay(date)
+	hours := int(math.Ceil(time.Since(day).Hours()))
+	if hours > AWCMaxHours {
+		return nil, fmt.Errorf("AWC only serves the last %d hours, %s is older", AWCMaxHours, day.Format("2006-01-02"))
+	}
+	if hours < 1 {
+		return nil, nil
+	}
+
+	obs, err := fetchAWCObservations(ctx, station, hours)
+	if err != nil {
+		return nil, err
+	}
+	obs, _ = SaneObservations(obs, day, time.Now())
+	return obs, nil
+}
+
+type nwsProvider struct{}
+
+func (nwsProvider) Name() string { return "nws" }
+
+func (nwsProvider) Forecast(ctx context.Context, station *Station, date time.Time) (*Forecast, error) {
+	forecasts, err := fetchNWSForecast(ctx, station)
+	if err != nil {
+		return nil, err
+	}
+	return forecastForDate(forecasts, station, date, time.Now())
+}
+
+type fallback []Provider
+
+// Fallback returns a provider that asks each of providers in turn and
+// returns the first non-empty result, e.g. Fallback(METAR, ASOS) for the
+// freshest reports today and the archive for older days. If all fail, the
+// errors are joined
+func Fallback(providers ...Provider) Provider {
+	return fallback(providers)
+}
+
+func (f fallback) Name() string {
+	names := make([]string, len(f))
+	for i, p := range f {
+		names[i] = p.Name()
+	}
+	return strings.Join(names, "|")
+}
+
+func (f fallback) Observations(ctx context.Context, station *Station, date time.Time) ([]Observation, error) {
+	var errs []error
+	for _, p := range f {
+		obs, err := p.Observations(ctx, station, date)
+		if err == nil && len(obs) > 0 {
+			return obs, nil
+		}
+		if err == nil {
+			err = ErrNoObservations
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+	}
+	return nil, errors.Join(errs...)
+}
+
+// get fetches url, naming source in errors
+func get(ctx context.Context, url, source string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// NWS rejects requests without a User-Agent
+	req.Header.Set("User-Agent", "kalshi-go")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", source, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", source, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned HTTP %d", source, resp.StatusCode)
+	}
+	return body, nil
+}
@@ -0,0 +1,152 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeProvider serves fixed reports and counts fetches
+type fakeProvider struct {
+	name  string
+	obs   []Observation
+	err   error
+	calls int
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) Observations(ctx context.Context, station *Station, date time.Time) ([]Observation, error) {
+	p.calls++
+	return p.obs, p.err
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestASOS_DailyMax(t *testing.T) {
+	station := Stations["LAX"]
+	date := time.Date(2025, 12, 5, 0, 0, 0, 0, station.Location())
+
+	var url string
+	defer func(c *http.Client) { httpClient = c }(httpClient)
+	httpClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		url = r.URL.String()
+		body := "station,valid,tmpf,drct,sknt\n" +
+			"LAX,2025-12-04 23:53,70.00,250.00,10.00\n" + // previous day
+			"LAX,2025-12-05 08:53,60.98,M,0.00\n" +
+			"LAX,2025-12-05 13:53,64.54,260.00,14.00\n" +
+			"LAX,2025-12-05 14:53,M,260.00,12.00\n"
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}
+
+	data, err := DailyMax(context.Background(), ASOS, station, date)
+	if err != nil {
+		t.Fatalf("DailyMax() error = %v", err)
+	}
+	if !strings.Contains(url, "station=LAX") || !strings.Contains(url, "day1=5") {
+		t.Errorf("fetched %s, want the LAX archive for Dec 5", url)
+	}
+	if len(data.Observations) != 2 || data.MaxTemp != 65 || data.MaxTempTime.Hour() != 13 {
+		t.Errorf("DailyMax() = %d observations, max %v at %v, want 2, 65 at 13:53",
+			len(data.Observations), data.MaxTemp, data.MaxTempTime)
+	}
+	if o := data.Observations[len(data.Observations)-1]; o.WindDir != 260 || o.WindSpeed != 14 {
+		t.Errorf("13:53 wind = %v@%v, want 260@14", o.WindDir, o.WindSpeed)
+	}
+}
+
+func TestDailyMax_NoObservations(t *testing.T) {
+	p := &fakeProvider{name: "empty"}
+	if _, err := DailyMax(context.Background(), p, Stations["LAX"], time.Now()); !errors.Is(err, ErrNoObservations) {
+		t.Errorf("DailyMax() error = %v, want ErrNoObservations", err)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	station := Stations["LAX"]
+	now := time.Now()
+	failing := &fakeProvider{name: "metar", err: errors.New("HTTP 503")}
+	empty := &fakeProvider{name: "asos"}
+	good := &fakeProvider{name: "backup", obs: []Observation{{Time: now, Temp: 61}}}
+
+	p := Fallback(failing, empty, good)
+	if p.Name() != "metar|asos|backup" {
+		t.Errorf("Name() = %q, want metar|asos|backup", p.Name())
+	}
+	obs, err := p.Observations(context.Background(), station, now)
+	if err != nil || len(obs) != 1 {
+		t.Fatalf("Observations() = %v, %v, want the backup's report", obs, err)
+	}
+
+	_, err = Fallback(failing, empty).Observations(context.Background(), station, now)
+	if err == nil || !strings.Contains(err.Error(), "metar: HTTP 503") || !errors.Is(err, ErrNoObservations) {
+		t.Errorf("Observations() error = %v, want both providers' errors", err)
+	}
+}
+
+func TestCache(t *testing.T) {
+	station := Stations["LAX"]
+	loc := station.Location()
+	now := time.Date(2025, 12, 5, 14, 0, 0, 0, loc)
+	today, yesterday := now, now.AddDate(0, 0, -1)
+
+	p := &fakeProvider{name: "asos", obs: []Observation{{Time: now, Temp: 61}}}
+	c := NewCache(p, 5*time.Minute)
+	c.now = func() time.Time { return now }
+	fetch := func(date time.Time) {
+		t.Helper()
+		if _, err := c.Observations(context.Background(), station, date); err != nil {
+			t.Fatalf("Observations(%s) error = %v", date.Format("Jan 2"), err)
+		}
+	}
+
+	fetch(today)
+	fetch(today.Add(-3 * time.Hour)) // Same station-day
+	fetch(yesterday)
+	if p.calls != 2 {
+		t.Errorf("after three reads of two days: %d fetches, want 2", p.calls)
+	}
+
+	now = now.Add(10 * time.Minute)
+	fetch(today)
+	fetch(yesterday)
+	if p.calls != 3 {
+		t.Errorf("after the TTL: %d fetches, want 3 (only today refetched)", p.calls)
+	}
+
+	// Errors are not cached
+	p.err = errors.New("HTTP 503")
+	now = now.Add(10 * time.Minute)
+	if _, err := c.Observations(context.Background(), station, today); err == nil {
+		t.Error("Observations() after a failed fetch: want error")
+	}
+	p.err = nil
+	fetch(today)
+	if p.calls != 5 {
+		t.Errorf("after a failed fetch: %d fetches, want 5", p.calls)
+	}
+}
+
+func TestStation_CLIReportTime(t *testing.T) {
+	// 6pm UTC on Dec 5 is the same day in New York and Los Angeles, whose
+	// reports post three hours apart
+	at := time.Date(2025, 12, 5, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		code string
+		want time.Time
+	}{
+		{"NYC", time.Date(2025, 12, 6, 13, 0, 0, 0, time.UTC)},
+		{"LAX", time.Date(2025, 12, 6, 16, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := Stations[tt.code].CLIReportTime(at); !got.Equal(tt.want) {
+			t.Errorf("%s CLIReportTime() = %v, want %v", tt.code, got.UTC(), tt.want)
+		}
+	}
+}
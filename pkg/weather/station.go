@@ -1,4 +1,3 @@
-// Package weather provides weather data abstractions for multiple stations
 package weather
 
 import "time"
@@ -27,8 +26,10 @@ type Station struct {
 	// Kalshi Integration - prefix for high temp markets (e.g., "KXHIGHLAX")
 	// Low temp markets use different prefix (e.g., "KXLOWTLAX")
 	EventPrefix string
+	LowPrefix   string // LOW series ticker when not derived from EventPrefix ("" = KXLOWT + city)
 
-	// NWS Integration
+	// NWS Integration - built-in defaults; ResolveGridPoints refreshes them
+	// from the NWS API in case the station has been re-gridded
 	NWSOffice string // NWS office code (e.g., "LOX")
 	NWSGridX  int    // NWS grid X coordinate
 	NWSGridY  int    // NWS grid Y coordinate
@@ -178,13 +179,15 @@ var Stations = map[string]*Station{
 	},
 }
 
-// GetStation returns a station by its short code (LAX, MIA, DEN, CHI)
-func GetStation(code string) *Station {
+// StationByCode returns a station by its short code (LAX, MIA, DEN, CHI),
+// or nil
+func StationByCode(code string) *Station {
 	return Stations[code]
 }
 
-// GetStationByMETAR returns a station by its METAR ID (KLAX, KMIA, etc.)
-func GetStationByMETAR(metarID string) *Station {
+// StationByMETAR returns a station by its METAR ID (KLAX, KMIA, etc.), or
+// nil
+func StationByMETAR(metarID string) *Station {
 	for _, s := range Stations {
 		if s.ID == metarID {
 			return s
@@ -193,8 +196,9 @@ func GetStationByMETAR(metarID string) *Station {
 	return nil
 }
 
-// GetStationByEventPrefix returns a station by its Kalshi event prefix
-func GetStationByEventPrefix(prefix string) *Station {
+// StationByEventPrefix returns a station by its Kalshi HIGH event prefix
+// (KXHIGHLAX), or nil
+func StationByEventPrefix(prefix string) *Station {
 	for _, s := range Stations {
 		if s.EventPrefix == prefix {
 			return s
@@ -203,6 +207,20 @@ func GetStationByEventPrefix(prefix string) *Station {
 	return nil
 }
 
+// StationBySeries returns the code of the station whose HIGH or LOW series
+// ticker is series (see SeriesTicker), its station and the market type, or
+// a nil station
+func StationBySeries(series string) (string, *Station, MarketType) {
+	for code, s := range Stations {
+		for _, t := range []MarketType{MarketTypeHigh, MarketTypeLow} {
+			if s.SeriesTicker(t) == series {
+				return code, s, t
+			}
+		}
+	}
+	return "", nil, ""
+}
+
 // AllStations returns all registered stations
 func AllStations() []*Station {
 	result := make([]*Station, 0, len(Stations))
@@ -221,27 +239,22 @@ func (s *Station) Location() *time.Location {
 	return loc
 }
 
-// GetClimatologyHigh returns the average high temperature for a given month
-func (s *Station) GetClimatologyHigh(month time.Month) float64 {
+// ClimatologyHigh returns the average high temperature for a given month
+func (s *Station) ClimatologyHigh(month time.Month) float64 {
 	if avg, ok := s.MonthlyAvgHigh[month]; ok {
 		return avg
 	}
 	return 65 // Default fallback
 }
 
-// GetClimatologyLow returns the average low temperature for a given month
-func (s *Station) GetClimatologyLow(month time.Month) float64 {
+// ClimatologyLow returns the average low temperature for a given month
+func (s *Station) ClimatologyLow(month time.Month) float64 {
 	if avg, ok := s.MonthlyAvgLow[month]; ok {
 		return avg
 	}
 	return 45 // Default fallback
 }
 
-// EventTicker generates the Kalshi event ticker for a given date and market type
-func (s *Station) EventTicker(date time.Time) string {
-	return s.HighEventTicker(date)
-}
-
 // HighEventTicker generates the Kalshi event ticker for HIGH temp markets
 func (s *Station) HighEventTicker(date time.Time) string {
 	return s.EventPrefix + "-" + date.Format("06Jan02")
@@ -249,12 +262,7 @@ func (s *Station) HighEventTicker(date time.Time) string {
 
 // LowEventTicker generates the Kalshi event ticker for LOW temp markets
 func (s *Station) LowEventTicker(date time.Time) string {
-	// Convert KXHIGHLAX -> KXLOWTLAX, KXHIGHNY -> KXLOWTNY, etc.
-	prefix := s.EventPrefix
-	if len(prefix) > 6 && prefix[:6] == "KXHIGH" {
-		prefix = "KXLOWT" + prefix[6:]
-	}
-	return prefix + "-" + date.Format("06Jan02")
+	return s.SeriesTicker(MarketTypeLow) + "-" + date.Format("06Jan02")
 }
 
 // EventTickerForType generates the Kalshi event ticker for a given market type
@@ -265,6 +273,23 @@ func (s *Station) EventTickerForType(date time.Time, marketType MarketType) stri
 	return s.HighEventTicker(date)
 }
 
+// SeriesTicker returns the Kalshi series ticker of the station's HIGH or LOW
+// markets: EventPrefix, or for LOW markets LowPrefix, else EventPrefix with
+// KXHIGH replaced by KXLOWT (KXHIGHLAX -> KXLOWTLAX)
+func (s *Station) SeriesTicker(marketType MarketType) string {
+	if marketType != MarketTypeLow {
+		return s.EventPrefix
+	}
+	if s.LowPrefix != "" {
+		return s.LowPrefix
+	}
+	prefix := s.EventPrefix
+	if len(prefix) > 6 && prefix[:6] == "KXHIGH" {
+		prefix = "KXLOWT" + prefix[6:]
+	}
+	return prefix
+}
+
 // NWSForecastURL returns the NWS API forecast URL for this station
 func (s *Station) NWSForecastURL() string {
 	return "https://api.weather.gov/gridpoints/" + s.NWSOffice + "/" +
@@ -281,7 +306,7 @@ func (s *Station) METARHistoryURL(date time.Time) string {
 
 	return "https://mesonet.agron.iastate.edu/cgi-bin/request/asos.py?" +
 		"station=" + stationID +
-		"&data=tmpf" +
+		"&data=tmpf&data=drct&data=sknt" +
 		"&year1=" + itoa(date.Year()) +
 		"&month1=" + itoa(int(date.Month())) +
 		"&day1=" + itoa(date.Day()) +
@@ -0,0 +1,295 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Scenario describes a synthetic station-day: a diurnal temperature curve,
+// correlated noise and optional frontal passages. Zero fields take defaults;
+// Low and High default to the station's climatology for the month
+type Scenario struct {
+	Low      float64       // Temperature at the dawn minimum in °F
+	High     float64       // Temperature at the afternoon peak in °F
+	LowHour  float64       // Local hour of the minimum (default 6)
+	PeakHour float64       // Local hour of the peak (default 15)
+	Noise    float64       // Standard deviation of report noise in °F (default 0.8, negative = none)
+	Interval time.Duration // Time between reports (default 1h, routine METARs at :53)
+	Wind     float64       // Wind speed in knots before any front
+	WindDir  float64       // Degrees true the wind blows from before any front
+	Fronts   []Front
+	Seed     uint64 // Varies the noise: the same seed, station and day give the same reports
+}
+
+// Front is a frontal passage: from At the temperature moves by Change over
+// Duration and stays there, and the wind shifts
+type Front struct {
+	At       float64       // Local hour the front arrives
+	Change   float64       // Temperature change in °F, negative for a cold front
+	Duration time.Duration // Time the change takes (default 1h)
+	Wind     float64       // Wind speed in knots after passage (0 = unchanged)
+	WindDir  float64       // Wind direction after passage (0 = unchanged)
+}
+
+// Synthetic generates reports from a Scenario instead of fetching them, for
+// demos, UI development and regression tests without live data. It serves
+// a station-day's reports up to Now, so a bot polling it sees the day unfold
+// as it would live. Temperatures are reported in whole °C as METARs are
+type Synthetic struct {
+	Scenario Scenario
+	Now      func() time.Time // Clock reports are cut off at (nil = time.Now)
+}
+
+// NewSynthetic returns a synthetic provider for scenario on the system clock
+func NewSynthetic(scenario Scenario) *Synthetic {
+	return &Synthetic{Scenario: scenario}
+}
+
+func (s *Synthetic) Name() string { return "synthetic" }
+
+// Observations returns the station-day's reports no later than Now
+func (s *Synthetic) Observations(ctx context.Context, station *Station, date time.Time) ([]Observation, error) {
+	now := time.Now()
+	if s.Now != nil {
+		now = s.Now()
+	}
+	obs := s.Day(station, date)
+	n := 0
+	for n < len(obs) && !obs[n].Time.After(now) {
+		n++
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("%w for %s on %s yet", ErrNoObservations, station.ID, station.LocalDay(date).Format("2006-01-02"))
+	}
+	return obs[:n], nil
+}
+
+// Forecast returns the scenario's noiseless high and low for the day, a
+// forecast that knows about the fronts but not the noise
+func (s *Synthetic) Forecast(ctx context.Context, station *Station, date time.Time) (*Forecast, error) {
+	day := station.LocalDay(date)
+	sc := s.Scenario.withDefaults(station, day)
+	high, low := math.Inf(-1), math.Inf(1)
+	for m := 0; m < 24*60; m += 5 {
+		t := sc.temp(float64(m) / 60)
+		high = math.Max(high, t)
+		low = math.Min(low, t)
+	}
+
+	desc := "Synthetic"
+	for _, f := range sc.Fronts {
+		kind := "cold"
+		if f.Change > 0 {
+			kind = "warm"
+		}
+		desc += fmt.Sprintf(", %s front at %s", kind, hourClock(f.At))
+	}
+	return &Forecast{
+		Station:     station,
+		Date:        day,
+		HighTemp:    math.Round(high),
+		LowTemp:     math.Round(low),
+		Description: desc,
+		IsDaytime:   true,
+	}, nil
+}
+
+// Day returns every report of the station-day regardless of the clock
+func (s *Synthetic) Day(station *Station, date time.Time) []Observation {
+	day := station.LocalDay(date)
+	sc := s.Scenario.withDefaults(station, day)
+
+	h := fnv.New64a()
+	h.Write([]byte(station.ID + day.Format("2006-01-02")))
+	rng := rand.New(rand.NewPCG(sc.Seed, h.Sum64()))
+
+	// AR(1) noise: consecutive reports err in the same direction, as a
+	// real station's departures from the mean curve do
+	const phi = 0.6
+	noise := rng.NormFloat64() * sc.Noise
+
+	next := day.AddDate(0, 0, 1)
+	var obs []Observation
+	for t := day.Add(sc.Interval - 7*time.Minute); t.Before(next); t = t.Add(sc.Interval) {
+		hour := t.Sub(day).Hours()
+		tempC := math.Round((sc.temp(hour) + noise - 32) * 5 / 9)
+		speed, dir := sc.wind(hour)
+		obs = append(obs, Observation{
+			Time:      t,
+			Temp:      tempC*9/5 + 32,
+			WindSpeed: speed,
+			WindDir:   dir,
+		})
+		noise = phi*noise + math.Sqrt(1-phi*phi)*rng.NormFloat64()*sc.Noise
+	}
+	return obs
+}
+
+func (sc Scenario) withDefaults(station *Station, day time.Time) Scenario {
+	if sc.High == 0 && sc.Low == 0 {
+		sc.High = station.ClimatologyHigh(day.Month())
+		sc.Low = station.ClimatologyLow(day.Month())
+	}
+	if sc.LowHour == 0 {
+		sc.LowHour = 6
+	}
+	if sc.PeakHour <= sc.LowHour {
+		sc.PeakHour = 15
+	}
+	switch {
+	case sc.Noise == 0:
+		sc.Noise = 0.8
+	case sc.Noise < 0:
+		sc.Noise = 0
+	}
+	if sc.Interval <= 7*time.Minute {
+		sc.Interval = time.Hour
+	}
+	return sc
+}
+
+// temp returns the noiseless temperature at hour (local hours since
+// midnight): a half-cosine rise from the minimum to the peak and a slower
+// fall to the next minimum, plus the fronts passed so far
+func (sc Scenario) temp(hour float64) float64 {
+	h := hour
+	if h < sc.LowHour {
+		h += 24 // Still cooling from yesterday's peak
+	}
+	amp := sc.High - sc.Low
+	var t float64
+	if h <= sc.PeakHour {
+		t = sc.Low + amp*(1-math.Cos(math.Pi*(h-sc.LowHour)/(sc.PeakHour-sc.LowHour)))/2
+	} else {
+		t = sc.High - amp*(1-math.Cos(math.Pi*(h-sc.PeakHour)/(sc.LowHour+24-sc.PeakHour)))/2
+	}
+
+	for _, f := range sc.Fronts {
+		t += f.Change * f.progress(hour)
+	}
+	return t
+}
+
+func (sc Scenario) wind(hour float64) (speed, dir float64) {
+	speed, dir = sc.Wind, sc.WindDir
+	for _, f := range sc.Fronts {
+		if f.progress(hour) < 0.5 {
+			continue
+		}
+		if f.Wind != 0 {
+			speed = f.Wind
+		}
+		if f.WindDir != 0 {
+			dir = f.WindDir
+		}
+	}
+	return speed, dir
+}
+
+// progress returns how far through the front's passage hour is, 0 to 1
+func (f Front) progress(hour float64) float64 {
+	d := f.Duration.Hours()
+	if d <= 0 {
+		d = 1
+	}
+	return math.Max(0, math.Min(1, (hour-f.At)/d))
+}
+
+// ParseScenario parses a scenario from comma-separated key=value pairs, as
+// given in a flag or environment variable:
+//
+//	high=72,low=55,noise=1,front=14:-8
+//
+// Keys are high, low, lowhour, peak, noise, interval (a duration), wind,
+// dir, seed and front. A front is HOUR:CHANGE[:DURATION[:WIND[:DIR]]] and
+// may be repeated. An empty string or "default" is the default scenario
+func ParseScenario(s string) (Scenario, error) {
+	var sc Scenario
+	if strings.TrimSpace(s) == "default" {
+		return sc, nil
+	}
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return Scenario{}, fmt.Errorf("scenario field %q: want key=value", field)
+		}
+
+		var err error
+		switch strings.ToLower(key) {
+		case "high":
+			sc.High, err = strconv.ParseFloat(val, 64)
+		case "low":
+			sc.Low, err = strconv.ParseFloat(val, 64)
+		case "lowhour":
+			sc.LowHour, err = strconv.ParseFloat(val, 64)
+		case "peak":
+			sc.PeakHour, err = strconv.ParseFloat(val, 64)
+		case "noise":
+			sc.Noise, err = strconv.ParseFloat(val, 64)
+		case "interval":
+			sc.Interval, err = time.ParseDuration(val)
+		case "wind":
+			sc.Wind, err = strconv.ParseFloat(val, 64)
+		case "dir":
+			sc.WindDir, err = strconv.ParseFloat(val, 64)
+		case "seed":
+			sc.Seed, err = strconv.ParseUint(val, 10, 64)
+		case "front":
+			var f Front
+			f, err = parseFront(val)
+			sc.Fronts = append(sc.Fronts, f)
+		default:
+			return Scenario{}, fmt.Errorf("unknown scenario key %q", key)
+		}
+		if err != nil {
+			return Scenario{}, fmt.Errorf("scenario %s: %w", key, err)
+		}
+	}
+	return sc, nil
+}
+
+func parseFront(s string) (Front, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 5 {
+		return Front{}, fmt.Errorf("front %q: want HOUR:CHANGE[:DURATION[:WIND[:DIR]]]", s)
+	}
+	var f Front
+	var err error
+	if f.At, err = strconv.ParseFloat(parts[0], 64); err != nil {
+		return Front{}, err
+	}
+	if f.Change, err = strconv.ParseFloat(parts[1], 64); err != nil {
+		return Front{}, err
+	}
+	if len(parts) > 2 {
+		if f.Duration, err = time.ParseDuration(parts[2]); err != nil {
+			return Front{}, err
+		}
+	}
+	if len(parts) > 3 {
+		if f.Wind, err = strconv.ParseFloat(parts[3], 64); err != nil {
+			return Front{}, err
+		}
+	}
+	if len(parts) > 4 {
+		if f.WindDir, err = strconv.ParseFloat(parts[4], 64); err != nil {
+			return Front{}, err
+		}
+	}
+	return f, nil
+}
+
+func hourClock(hour float64) string {
+	m := int(math.Round(hour * 60))
+	return fmt.Sprintf("%02d:%02d", m/60%24, m%60)
+}
@@ -0,0 +1,117 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSynthetic_DiurnalCurve(t *testing.T) {
+	station := Stations["LAX"]
+	date := time.Date(2025, 12, 5, 0, 0, 0, 0, station.Location())
+	syn := NewSynthetic(Scenario{Low: 50, High: 68, Noise: -1})
+
+	obs := syn.Day(station, date)
+	if len(obs) != 24 || obs[0].Time.Format("15:04") != "00:53" || obs[23].Time.Format("15:04") != "23:53" {
+		t.Fatalf("Day() = %d reports from %v to %v, want 24 hourly at :53", len(obs), obs[0].Time, obs[len(obs)-1].Time)
+	}
+	max, maxAt := math.Inf(-1), 0
+	for i, o := range obs {
+		if o.Temp > max {
+			max, maxAt = o.Temp, i
+		}
+	}
+	// 68°F is 20°C, reported as 68°F, first reached in the early afternoon
+	if max != 68 || obs[maxAt].Time.Hour() < 12 || obs[maxAt].Time.Hour() > 14 {
+		t.Errorf("max = %.1f°F at %v, want 68°F by 14:53", max, obs[maxAt].Time)
+	}
+	if obs[5].Temp > 51 {
+		t.Errorf("dawn report = %.1f°F, want near the 50°F low", obs[5].Temp)
+	}
+
+	f, err := syn.Forecast(context.Background(), station, date)
+	if err != nil || f.HighTemp != 68 || f.LowTemp != 50 {
+		t.Errorf("Forecast() = %+v, %v, want 68/50", f, err)
+	}
+}
+
+func TestSynthetic_Front(t *testing.T) {
+	station := Stations["LAX"]
+	date := time.Date(2025, 12, 5, 0, 0, 0, 0, station.Location())
+	sc, err := ParseScenario("high=70,low=50,noise=-1,wind=5,dir=180,front=11:-12:2h:20:320")
+	if err != nil {
+		t.Fatalf("ParseScenario() error = %v", err)
+	}
+	syn := NewSynthetic(sc)
+
+	f, err := syn.Forecast(context.Background(), station, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The front caps the day before the afternoon peak
+	if f.HighTemp >= 66 || f.Description != "Synthetic, cold front at 11:00" {
+		t.Errorf("Forecast() = %+v, want a high under 66°F and the front described", f)
+	}
+
+	obs := syn.Day(station, date)
+	if obs[9].WindDir != 180 || obs[13].WindDir != 320 || obs[13].WindSpeed != 20 {
+		t.Errorf("wind at 09:53 = %v@%v, 13:53 = %v@%v; want the front to shift it", obs[9].WindDir, obs[9].WindSpeed, obs[13].WindDir, obs[13].WindSpeed)
+	}
+	if obs[14].Temp >= obs[10].Temp {
+		t.Errorf("14:53 = %.1f°F, want colder than 10:53's %.1f°F after the front", obs[14].Temp, obs[10].Temp)
+	}
+}
+
+func TestSynthetic_ClockAndSeed(t *testing.T) {
+	station := Stations["NYC"]
+	date := time.Date(2025, 7, 1, 0, 0, 0, 0, station.Location())
+	now := date.Add(10 * time.Hour)
+	syn := &Synthetic{Scenario: Scenario{Noise: 2, Seed: 1}, Now: func() time.Time { return now }}
+
+	obs, err := syn.Observations(context.Background(), station, date)
+	if err != nil {
+		t.Fatalf("Observations() error = %v", err)
+	}
+	if len(obs) != 10 || obs[len(obs)-1].Time.After(now) {
+		t.Errorf("Observations() = %d reports up to %v, want 10 up to %v", len(obs), obs[len(obs)-1].Time, now)
+	}
+
+	// Deterministic per seed, different across seeds
+	again := (&Synthetic{Scenario: Scenario{Noise: 2, Seed: 1}}).Day(station, date)
+	other := (&Synthetic{Scenario: Scenario{Noise: 2, Seed: 2}}).Day(station, date)
+	same, differ := true, false
+	for i := range obs {
+		same = same && obs[i].Temp == again[i].Temp && obs[i].Time.Equal(again[i].Time)
+		differ = differ || obs[i].Temp != other[i].Temp
+	}
+	if !same || !differ {
+		t.Errorf("seeded noise: same seed equal = %v, other seed differs = %v; want both", same, differ)
+	}
+
+	now = date.Add(-time.Hour)
+	if _, err := syn.Observations(context.Background(), station, date); !errors.Is(err, ErrNoObservations) {
+		t.Errorf("Observations() before the day error = %v, want ErrNoObservations", err)
+	}
+}
+
+func TestParseScenario(t *testing.T) {
+	sc, err := ParseScenario("high=72, low=55,interval=20m,seed=7,front=14:-8,front=20:3:30m")
+	if err != nil {
+		t.Fatalf("ParseScenario() error = %v", err)
+	}
+	if sc.High != 72 || sc.Low != 55 || sc.Interval != 20*time.Minute || sc.Seed != 7 || len(sc.Fronts) != 2 ||
+		sc.Fronts[0].Change != -8 || sc.Fronts[1].Duration != 30*time.Minute {
+		t.Errorf("ParseScenario() = %+v", sc)
+	}
+
+	for _, bad := range []string{"high", "hot=1", "front=14", "interval=soon"} {
+		if _, err := ParseScenario(bad); err == nil {
+			t.Errorf("ParseScenario(%q) error = nil", bad)
+		}
+	}
+	if sc, err := ParseScenario(""); err != nil || sc.High != 0 || len(sc.Fronts) != 0 {
+		t.Errorf("ParseScenario(\"\") = %+v, %v, want the zero scenario", sc, err)
+	}
+}
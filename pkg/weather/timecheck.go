@@ -0,0 +1,63 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Observation timestamp sanity limits
+const (
+	MaxClockSkew      = 10 * time.Minute // Reports timestamped further ahead of the system clock are rejected
+	MaxObservationAge = 3 * time.Hour    // A latest report older than this is stale (METARs are hourly)
+)
+
+var (
+	// ErrFutureObservation means a report is timestamped ahead of the system
+	// clock by more than MaxClockSkew (bad feed data or clock drift)
+	ErrFutureObservation = errors.New("observation timestamp is in the future")
+
+	// ErrStaleObservation means the latest report is older than
+	// MaxObservationAge (feed outage or clock drift)
+	ErrStaleObservation = errors.New("latest observation is stale")
+)
+
+// CheckObservationTime flags a report timestamp that is implausible relative
+// to now. Use it on the latest report of a feed; older reports in a day's
+// history are expected to be hours old
+func CheckObservationTime(t, now time.Time) error {
+	switch age := now.Sub(t); {
+	case age < -MaxClockSkew:
+		return fmt.Errorf("%w: %s is %s ahead of %s", ErrFutureObservation,
+			t.Format(time.RFC3339), (-age).Round(time.Minute), now.Format(time.RFC3339))
+	case age > MaxObservationAge:
+		return fmt.Errorf("%w: %s is %s old", ErrStaleObservation,
+			t.Format(time.RFC3339), age.Round(time.Minute))
+	}
+	return nil
+}
+
+// SaneObservations drops reports timestamped in the future (beyond
+// MaxClockSkew) or, if date is non-zero, on another calendar day than date's
+// (read in each report's own time zone), so they cannot corrupt running
+// maxima or hour windows. It returns the kept reports and how many were
+// dropped
+func SaneObservations(obs []Observation, date, now time.Time) ([]Observation, int) {
+	kept := obs[:0:0]
+	for _, o := range obs {
+		if o.Time.Sub(now) > MaxClockSkew {
+			continue
+		}
+		if !date.IsZero() && !sameDay(o.Time, date) {
+			continue
+		}
+		kept = append(kept, o)
+	}
+	return kept, len(obs) - len(kept)
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,51 @@
+package weather
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckObservationTime(t *testing.T) {
+	now := time.Date(2025, 12, 5, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		obs  time.Time
+		want error
+	}{
+		{now.Add(-7 * time.Minute), nil},
+		{now.Add(5 * time.Minute), nil}, // within clock skew
+		{now.Add(2 * time.Hour), ErrFutureObservation},
+		{now.Add(-5 * time.Hour), ErrStaleObservation},
+	}
+	for _, tt := range tests {
+		if err := CheckObservationTime(tt.obs, now); !errors.Is(err, tt.want) {
+			t.Errorf("CheckObservationTime(%v) = %v, want %v", tt.obs, err, tt.want)
+		}
+	}
+}
+
+func TestParseMETARData_DropsImplausibleTimestamps(t *testing.T) {
+	station := Stations["LAX"]
+	loc := station.Location()
+	date := time.Date(2025, 12, 5, 0, 0, 0, 0, loc)
+	now := time.Date(2025, 12, 5, 14, 30, 0, 0, loc)
+
+	data := "station,valid,tmpf\n" +
+		"LAX,2025-12-04 23:53,70.00\n" + // previous day
+		"LAX,2025-12-05 08:53,60.98\n" +
+		"LAX,2025-12-05 13:53,64.04\n" +
+		"LAX,2025-12-05 14:53,M\n" +
+		"LAX,2025-12-05 18:53,75.00\n" // hours in the future
+
+	result, err := parseMETARData(station, date, data, now)
+	if err != nil {
+		t.Fatalf("parseMETARData() error = %v", err)
+	}
+	if len(result.Observations) != 2 || result.MaxTemp != 64 {
+		t.Errorf("got %d observations, max %v, want 2, 64", len(result.Observations), result.MaxTemp)
+	}
+	if result.MaxTempTime.Hour() != 13 {
+		t.Errorf("MaxTempTime = %v, want 13:53", result.MaxTempTime)
+	}
+}
@@ -1,4 +1,3 @@
-// Package ws provides a WebSocket client for the Kalshi trading API.
 package ws
 
 // Channel represents a WebSocket subscription channel.
@@ -49,6 +49,15 @@ type Client struct {
 
 	// subscriptions tracks active subscriptions by SID.
 	subscriptions sync.Map
+
+	// trades fans out trade prints; created on first use.
+	trades atomic.Pointer[TradeFeed]
+
+	// snapshots conflates market data per ticker; created on first use.
+	snapshots atomic.Pointer[SnapshotStore]
+
+	// orderbooks maintains local books from orderbook deltas; created on first use.
+	orderbooks atomic.Pointer[OrderbookStore]
 }
 
 // New creates a new WebSocket client with the given options.
@@ -319,13 +328,20 @@ func (c *Client) readLoop() {
 			continue
 		}
 
-		// Track subscriptions.
-		if resp.Type == MessageTypeSubscribed {
+		// Track subscriptions and feed market data consumers.
+		switch resp.Type {
+		case MessageTypeSubscribed:
 			if subMsg, err := ParseSubscribedMsg(resp.Msg); err == nil {
 				c.subscriptions.Store(subMsg.SID, subMsg.Channel)
 			}
-		} else if resp.Type == MessageTypeUnsubscribed {
+		case MessageTypeUnsubscribed:
 			c.subscriptions.Delete(resp.SID)
+		case MessageTypeTrade:
+			c.publishTrade(resp)
+		case MessageTypeTicker:
+			c.applyTicker(resp)
+		case MessageTypeOrderbookSnapshot, MessageTypeOrderbookDelta:
+			c.applyOrderbook(resp)
 		}
 
 		c.mu.RLock()
@@ -370,8 +386,8 @@ func (c *Client) pingLoop() {
 	}
 }
 
-// GetActiveSubscriptions returns a map of active subscription SIDs to channels.
-func (c *Client) GetActiveSubscriptions() map[int64]Channel {
+// ActiveSubscriptions returns a map of active subscription SIDs to channels.
+func (c *Client) ActiveSubscriptions() map[int64]Channel {
 	result := make(map[int64]Channel)
 	c.subscriptions.Range(func(key, value any) bool {
 		if sid, ok := key.(int64); ok {
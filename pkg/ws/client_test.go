@@ -58,7 +58,7 @@ func TestClient_Subscribe_AuthRequired(t *testing.T) {
 
 func TestClient_Subscribe_AuthChannel_WithAuth(t *testing.T) {
 	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
-	client := New(WithAPIKeyOption("key", privateKey))
+	client := New(WithAPIKey("key", privateKey))
 
 	// Should fail with ErrNotConnected, not ErrAuthRequired.
 	_, err := client.Subscribe(context.Background(), "TEST", ChannelFill)
@@ -138,12 +138,12 @@ func TestClient_SetDataHandler(t *testing.T) {
 	}
 }
 
-func TestClient_GetActiveSubscriptions_Empty(t *testing.T) {
+func TestClient_ActiveSubscriptions_Empty(t *testing.T) {
 	client := New()
 
-	subs := client.GetActiveSubscriptions()
+	subs := client.ActiveSubscriptions()
 	if len(subs) != 0 {
-		t.Errorf("GetActiveSubscriptions() len = %d, want 0", len(subs))
+		t.Errorf("ActiveSubscriptions() len = %d, want 0", len(subs))
 	}
 }
 
@@ -0,0 +1,41 @@
+package ws
+
+import (
+	"crypto/rsa"
+	"time"
+)
+
+// WithAPIKeyOption returns an Option that sets the API key and private key.
+//
+// Deprecated: Use WithAPIKey.
+func WithAPIKeyOption(apiKey string, privateKey *rsa.PrivateKey) Option {
+	return WithAPIKey(apiKey, privateKey)
+}
+
+// WithBaseURLOption returns an Option that sets the base URL.
+//
+// Deprecated: Use WithBaseURL.
+func WithBaseURLOption(url string) Option {
+	return WithBaseURL(url)
+}
+
+// WithAutoReconnectOption returns an Option that configures auto-reconnect.
+//
+// Deprecated: Use WithAutoReconnect.
+func WithAutoReconnectOption(enabled bool, maxAttempts int) Option {
+	return WithAutoReconnect(enabled, maxAttempts)
+}
+
+// WithPingIntervalOption returns an Option that sets the ping interval.
+//
+// Deprecated: Use WithPingInterval.
+func WithPingIntervalOption(interval time.Duration) Option {
+	return WithPingInterval(interval)
+}
+
+// GetActiveSubscriptions returns a map of active subscription SIDs to channels.
+//
+// Deprecated: Use ActiveSubscriptions.
+func (c *Client) GetActiveSubscriptions() map[int64]Channel {
+	return c.ActiveSubscriptions()
+}
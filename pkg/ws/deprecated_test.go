@@ -0,0 +1,41 @@
+package ws
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDeprecatedOptions(t *testing.T) {
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	tests := []struct {
+		name     string
+		old, new Option
+	}{
+		{"WithAPIKeyOption", WithAPIKeyOption("key", privateKey), WithAPIKey("key", privateKey)},
+		{"WithBaseURLOption", WithBaseURLOption("wss://example.com"), WithBaseURL("wss://example.com")},
+		{"WithAutoReconnectOption", WithAutoReconnectOption(false, 3), WithAutoReconnect(false, 3)},
+		{"WithPingIntervalOption", WithPingIntervalOption(5 * time.Second), WithPingInterval(5 * time.Second)},
+	}
+	for _, tt := range tests {
+		got, want := DefaultOptions(), DefaultOptions()
+		tt.old(&got)
+		tt.new(&want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: options = %+v, want %+v", tt.name, got, want)
+		}
+	}
+}
+
+func TestClient_GetActiveSubscriptions(t *testing.T) {
+	client := New()
+	client.subscriptions.Store(int64(1), ChannelTicker)
+
+	got := client.GetActiveSubscriptions()
+	if want := map[int64]Channel{1: ChannelTicker}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetActiveSubscriptions() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,16 @@
+// Package ws provides a WebSocket client for the Kalshi trading API.
+//
+// A Client connects, authenticates (WithAPIKey) and subscribes to channels
+// per market. Raw messages go to a MessageHandler or DataHandler; the
+// typed views maintain state from them instead: SubscribeOrderbooks keeps
+// an OrderbookStore from snapshots and deltas, SubscribeSnapshots a
+// conflated SnapshotStore of ticker and trade updates, and SubscribeTrades
+// fans trades out per market on a TradeFeed.
+//
+// # Stability
+//
+// ws is part of the module's public API and follows semantic versioning:
+// exported identifiers keep their meaning within a major version, and
+// renamed ones stay as deprecated wrappers until the next major release.
+// See CHANGELOG.md.
+package ws
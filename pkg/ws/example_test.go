@@ -0,0 +1,30 @@
+package ws_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brendanplayford/kalshi-go/pkg/ws"
+)
+
+// Maintain a market's book from a snapshot and deltas, as
+// Client.SubscribeOrderbooks does with the messages it receives.
+func ExampleOrderbookStore() {
+	books := ws.NewOrderbookStore()
+	now := time.Now()
+
+	books.ApplySnapshot(1, 1, ws.OrderbookSnapshotMsg{
+		MarketTicker: "KXHIGHLAX-25DEC27-B62.5",
+		Yes:          [][2]int{{40, 100}, {41, 50}},
+		No:           [][2]int{{56, 80}},
+	}, now)
+	if err := books.ApplyDelta(1, 2, ws.OrderbookDeltaMsg{
+		MarketTicker: "KXHIGHLAX-25DEC27-B62.5", Price: 57, Delta: 20, Side: "no",
+	}, now); err != nil {
+		panic(err)
+	}
+
+	book, _ := books.Get("KXHIGHLAX-25DEC27-B62.5")
+	fmt.Printf("YES %d¢ bid / %d¢ ask, spread %d¢\n", book.YesBid(), book.YesAsk(), book.Spread())
+	// Output: YES 41¢ bid / 43¢ ask, spread 2¢
+}
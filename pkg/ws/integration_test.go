@@ -39,7 +39,7 @@ func TestIntegration_Connect(t *testing.T) {
 	apiKey, privateKey := getTestCredentials(t)
 
 	client := New(
-		WithAPIKeyOption(apiKey, privateKey),
+		WithAPIKey(apiKey, privateKey),
 	)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -81,7 +81,7 @@ func TestIntegration_SubscribeTicker(t *testing.T) {
 	var receivedMsg *Response
 
 	client := New(
-		WithAPIKeyOption(apiKey, privateKey),
+		WithAPIKey(apiKey, privateKey),
 		WithCallbacks(
 			func() { t.Log("connected") },
 			func(err error) { t.Logf("disconnected: %v", err) },
@@ -152,7 +152,7 @@ func TestIntegration_ListSubscriptions(t *testing.T) {
 	var messages []*Response
 
 	client := New(
-		WithAPIKeyOption(apiKey, privateKey),
+		WithAPIKey(apiKey, privateKey),
 	)
 
 	client.SetMessageHandler(func(msg *Response) {
@@ -205,7 +205,7 @@ func TestIntegration_SubscribeAndUnsubscribe(t *testing.T) {
 	var messages []*Response
 
 	client := New(
-		WithAPIKeyOption(apiKey, privateKey),
+		WithAPIKey(apiKey, privateKey),
 	)
 
 	client.SetMessageHandler(func(msg *Response) {
@@ -1,6 +1,9 @@
 package ws
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 // MessageType represents the type of WebSocket message.
 type MessageType string
@@ -12,6 +15,12 @@ const (
 	MessageTypeOK           MessageType = "ok"
 	MessageTypeError        MessageType = "error"
 	MessageTypeData         MessageType = "data"
+	MessageTypeTrade        MessageType = "trade"
+	MessageTypeTicker       MessageType = "ticker"
+	MessageTypeFill         MessageType = "fill"
+
+	MessageTypeOrderbookSnapshot MessageType = "orderbook_snapshot"
+	MessageTypeOrderbookDelta    MessageType = "orderbook_delta"
 )
 
 // Command represents a WebSocket command.
@@ -145,3 +154,140 @@ func ParseErrorMsg(msg any) (*ErrorMsg, error) {
 	}
 	return &result, nil
 }
+
+// TradeMsg represents the message payload of a public trade print.
+type TradeMsg struct {
+	TradeID      string `json:"trade_id"`
+	MarketTicker string `json:"market_ticker"`
+	YesPrice     int    `json:"yes_price"`
+	NoPrice      int    `json:"no_price"`
+	Count        int    `json:"count"`
+	TakerSide    string `json:"taker_side"`
+	TS           int64  `json:"ts"` // Unix seconds
+}
+
+// Time returns the trade timestamp.
+func (m TradeMsg) Time() time.Time {
+	return time.Unix(m.TS, 0)
+}
+
+// ParseTradeMsg parses the Msg field of a trade message.
+func ParseTradeMsg(msg any) (*TradeMsg, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var result TradeMsg
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TickerMsg represents the message payload of a market ticker update.
+type TickerMsg struct {
+	MarketTicker       string `json:"market_ticker"`
+	Price              int    `json:"price"`
+	YesBid             int    `json:"yes_bid"`
+	YesAsk             int    `json:"yes_ask"`
+	Volume             int    `json:"volume"`
+	OpenInterest       int    `json:"open_interest"`
+	DollarVolume       int    `json:"dollar_volume"`
+	DollarOpenInterest int    `json:"dollar_open_interest"`
+	TS                 int64  `json:"ts"` // Unix seconds
+}
+
+// Time returns the update timestamp.
+func (m TickerMsg) Time() time.Time {
+	return time.Unix(m.TS, 0)
+}
+
+// ParseTickerMsg parses the Msg field of a ticker message.
+func ParseTickerMsg(msg any) (*TickerMsg, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var result TickerMsg
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FillMsg represents the message payload of a fill of one of the account's
+// orders.
+type FillMsg struct {
+	TradeID      string `json:"trade_id"`
+	OrderID      string `json:"order_id"`
+	MarketTicker string `json:"market_ticker"`
+	IsTaker      bool   `json:"is_taker"`
+	Side         string `json:"side"`
+	Action       string `json:"action"`
+	YesPrice     int    `json:"yes_price"`
+	NoPrice      int    `json:"no_price"`
+	Count        int    `json:"count"`
+	TS           int64  `json:"ts"` // Unix seconds
+}
+
+// Time returns the fill timestamp.
+func (m FillMsg) Time() time.Time {
+	return time.Unix(m.TS, 0)
+}
+
+// ParseFillMsg parses the Msg field of a fill message.
+func ParseFillMsg(msg any) (*FillMsg, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var result FillMsg
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OrderbookSnapshotMsg represents the message payload of a full orderbook,
+// sent when an orderbook_delta subscription starts. Levels are [price, count]
+// pairs of resting YES and NO bids in cents.
+type OrderbookSnapshotMsg struct {
+	MarketTicker string   `json:"market_ticker"`
+	Yes          [][2]int `json:"yes"`
+	No           [][2]int `json:"no"`
+}
+
+// ParseOrderbookSnapshotMsg parses the Msg field of an orderbook snapshot.
+func ParseOrderbookSnapshotMsg(msg any) (*OrderbookSnapshotMsg, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var result OrderbookSnapshotMsg
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OrderbookDeltaMsg represents the message payload of a change in the count
+// resting at one price level.
+type OrderbookDeltaMsg struct {
+	MarketTicker string `json:"market_ticker"`
+	Price        int    `json:"price"`
+	Delta        int    `json:"delta"` // Contracts added (negative when removed)
+	Side         string `json:"side"`  // "yes" or "no"
+}
+
+// ParseOrderbookDeltaMsg parses the Msg field of an orderbook delta.
+func ParseOrderbookDeltaMsg(msg any) (*OrderbookDeltaMsg, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	var result OrderbookDeltaMsg
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
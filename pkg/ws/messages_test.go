@@ -240,3 +240,84 @@ func TestRequest_JSON(t *testing.T) {
 		t.Errorf("cmd = %v, want subscribe", result["cmd"])
 	}
 }
+
+func TestParseTradeMsg(t *testing.T) {
+	data := []byte(`{
+		"type": "trade",
+		"sid": 11,
+		"msg": {
+			"trade_id": "d91bc706",
+			"market_ticker": "KXHIGHNY-25DEC05-B45.5",
+			"yes_price": 36,
+			"no_price": 64,
+			"count": 136,
+			"taker_side": "no",
+			"ts": 1669149841
+		}
+	}`)
+
+	resp, err := ParseResponse(data)
+	if err != nil {
+		t.Fatalf("ParseResponse failed: %v", err)
+	}
+	if resp.Type != MessageTypeTrade {
+		t.Errorf("Type = %s, want %s", resp.Type, MessageTypeTrade)
+	}
+
+	trade, err := ParseTradeMsg(resp.Msg)
+	if err != nil {
+		t.Fatalf("ParseTradeMsg failed: %v", err)
+	}
+	if trade.MarketTicker != "KXHIGHNY-25DEC05-B45.5" || trade.YesPrice != 36 || trade.Count != 136 {
+		t.Errorf("ParseTradeMsg() = %+v", trade)
+	}
+	if trade.Time().Unix() != 1669149841 {
+		t.Errorf("Time() = %v, want unix 1669149841", trade.Time())
+	}
+}
+
+func TestParseTickerMsg(t *testing.T) {
+	msg := map[string]any{
+		"market_ticker": "KXHIGHNY-25DEC05-B45.5",
+		"price":         48,
+		"yes_bid":       45,
+		"yes_ask":       53,
+		"volume":        33896,
+		"open_interest": 20422,
+		"ts":            1669149841,
+	}
+
+	ticker, err := ParseTickerMsg(msg)
+	if err != nil {
+		t.Fatalf("ParseTickerMsg failed: %v", err)
+	}
+	if ticker.Price != 48 || ticker.YesBid != 45 || ticker.YesAsk != 53 || ticker.Volume != 33896 {
+		t.Errorf("ParseTickerMsg() = %+v", ticker)
+	}
+}
+
+func TestParseFillMsg(t *testing.T) {
+	msg := map[string]any{
+		"trade_id":      "d91bc706-ee49-470d-82d8-11418bda6fed",
+		"order_id":      "ee587a1c-8b87-4dcf-b721-9f6f790619fa",
+		"market_ticker": "KXHIGHNY-25DEC05-B45.5",
+		"is_taker":      true,
+		"side":          "yes",
+		"action":        "buy",
+		"yes_price":     53,
+		"no_price":      47,
+		"count":         10,
+		"ts":            1669149841,
+	}
+
+	fill, err := ParseFillMsg(msg)
+	if err != nil {
+		t.Fatalf("ParseFillMsg failed: %v", err)
+	}
+	if fill.OrderID != "ee587a1c-8b87-4dcf-b721-9f6f790619fa" || !fill.IsTaker || fill.YesPrice != 53 || fill.Count != 10 {
+		t.Errorf("ParseFillMsg() = %+v", fill)
+	}
+	if fill.Time().Unix() != 1669149841 {
+		t.Errorf("Time() = %v, want unix 1669149841", fill.Time())
+	}
+}
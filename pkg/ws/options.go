@@ -10,6 +10,9 @@ const (
 	// DefaultBaseURL is the default Kalshi WebSocket endpoint.
 	DefaultBaseURL = "wss://api.elections.kalshi.com/trade-api/ws/v2"
 
+	// DemoBaseURL is the demo/sandbox WebSocket endpoint.
+	DemoBaseURL = "wss://demo-api.kalshi.co/trade-api/ws/v2"
+
 	// DefaultPingInterval is the default interval for sending ping frames.
 	DefaultPingInterval = 10 * time.Second
 
@@ -105,31 +108,31 @@ func (o Options) IsAuthenticated() bool {
 // Option is a functional option for configuring the client.
 type Option func(*Options)
 
-// WithAPIKeyOption returns an Option that sets the API key and private key.
-func WithAPIKeyOption(apiKey string, privateKey *rsa.PrivateKey) Option {
+// WithAPIKey returns an Option that sets the API key and private key.
+func WithAPIKey(apiKey string, privateKey *rsa.PrivateKey) Option {
 	return func(o *Options) {
 		o.APIKey = apiKey
 		o.PrivateKey = privateKey
 	}
 }
 
-// WithBaseURLOption returns an Option that sets the base URL.
-func WithBaseURLOption(url string) Option {
+// WithBaseURL returns an Option that sets the base URL.
+func WithBaseURL(url string) Option {
 	return func(o *Options) {
 		o.BaseURL = url
 	}
 }
 
-// WithAutoReconnectOption returns an Option that configures auto-reconnect.
-func WithAutoReconnectOption(enabled bool, maxAttempts int) Option {
+// WithAutoReconnect returns an Option that configures auto-reconnect.
+func WithAutoReconnect(enabled bool, maxAttempts int) Option {
 	return func(o *Options) {
 		o.AutoReconnect = enabled
 		o.MaxReconnectAttempts = maxAttempts
 	}
 }
 
-// WithPingIntervalOption returns an Option that sets the ping interval.
-func WithPingIntervalOption(interval time.Duration) Option {
+// WithPingInterval returns an Option that sets the ping interval.
+func WithPingInterval(interval time.Duration) Option {
 	return func(o *Options) {
 		o.PingInterval = interval
 	}
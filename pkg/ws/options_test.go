@@ -90,29 +90,29 @@ func TestOptions_WithAutoReconnect(t *testing.T) {
 	}
 }
 
-func TestWithAPIKeyOption(t *testing.T) {
+func TestWithAPIKey(t *testing.T) {
 	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
 
 	opts := DefaultOptions()
-	WithAPIKeyOption("test-key", privateKey)(&opts)
+	WithAPIKey("test-key", privateKey)(&opts)
 
 	if opts.APIKey != "test-key" {
 		t.Errorf("APIKey = %s, want test-key", opts.APIKey)
 	}
 }
 
-func TestWithBaseURLOption(t *testing.T) {
+func TestWithBaseURL(t *testing.T) {
 	opts := DefaultOptions()
-	WithBaseURLOption("wss://custom.url")(&opts)
+	WithBaseURL("wss://custom.url")(&opts)
 
 	if opts.BaseURL != "wss://custom.url" {
 		t.Errorf("BaseURL = %s, want wss://custom.url", opts.BaseURL)
 	}
 }
 
-func TestWithAutoReconnectOption(t *testing.T) {
+func TestWithAutoReconnect(t *testing.T) {
 	opts := DefaultOptions()
-	WithAutoReconnectOption(false, 3)(&opts)
+	WithAutoReconnect(false, 3)(&opts)
 
 	if opts.AutoReconnect {
 		t.Error("AutoReconnect should be false")
@@ -122,9 +122,9 @@ func TestWithAutoReconnectOption(t *testing.T) {
 	}
 }
 
-func TestWithPingIntervalOption(t *testing.T) {
+func TestWithPingInterval(t *testing.T) {
 	opts := DefaultOptions()
-	WithPingIntervalOption(5 * time.Second)(&opts)
+	WithPingInterval(5 * time.Second)(&opts)
 
 	if opts.PingInterval != 5*time.Second {
 		t.Errorf("PingInterval = %v, want 5s", opts.PingInterval)
@@ -173,9 +173,9 @@ func TestNew_WithOptions(t *testing.T) {
 	privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
 
 	client := New(
-		WithAPIKeyOption("test-key", privateKey),
-		WithBaseURLOption("wss://custom.url"),
-		WithAutoReconnectOption(false, 5),
+		WithAPIKey("test-key", privateKey),
+		WithBaseURL("wss://custom.url"),
+		WithAutoReconnect(false, 5),
 	)
 
 	if client.opts.APIKey != "test-key" {
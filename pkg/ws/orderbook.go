@@ -0,0 +1,290 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrSequenceGap is returned when an orderbook delta does not directly follow
+// the last message of its subscription, so the local book may have missed a
+// change and must be rebuilt from a fresh snapshot.
+var ErrSequenceGap = errors.New("websocket: orderbook sequence gap")
+
+// Level is the count of contracts resting at one price.
+type Level struct {
+	Price    int // cents
+	Quantity int
+}
+
+// Orderbook is a market's resting bids. Kalshi books hold bids only: a NO
+// bid at p cents is an offer to sell YES at 100-p, and vice versa.
+type Orderbook struct {
+	Ticker    string
+	Yes       []Level // YES bids, best (highest) first
+	No        []Level // NO bids, best (highest) first
+	UpdatedAt time.Time
+}
+
+// YesBid returns the best YES bid in cents, or 0 if there is none.
+func (b Orderbook) YesBid() int { return best(b.Yes) }
+
+// NoBid returns the best NO bid in cents, or 0 if there is none.
+func (b Orderbook) NoBid() int { return best(b.No) }
+
+// YesAsk returns the cheapest YES offer in cents (100 minus the best NO
+// bid), or 0 if there is none.
+func (b Orderbook) YesAsk() int { return ask(b.No) }
+
+// NoAsk returns the cheapest NO offer in cents (100 minus the best YES bid),
+// or 0 if there is none.
+func (b Orderbook) NoAsk() int { return ask(b.Yes) }
+
+// Spread returns the YES bid/ask spread in cents, or 100 when the book is
+// one-sided.
+func (b Orderbook) Spread() int {
+	if len(b.Yes) == 0 || len(b.No) == 0 {
+		return 100
+	}
+	return b.YesAsk() - b.YesBid()
+}
+
+// Offered returns the contracts a buyer of side ("yes" or "no") could take
+// at limit cents or better: the opposite side's bids at 100-limit or more.
+func (b Orderbook) Offered(side string, limit int) int {
+	levels := b.No
+	if side == "no" {
+		levels = b.Yes
+	}
+	n := 0
+	for _, l := range levels {
+		if 100-l.Price > limit {
+			break
+		}
+		n += l.Quantity
+	}
+	return n
+}
+
+func best(levels []Level) int {
+	if len(levels) == 0 {
+		return 0
+	}
+	return levels[0].Price
+}
+
+func ask(opposite []Level) int {
+	if len(opposite) == 0 {
+		return 0
+	}
+	return 100 - opposite[0].Price
+}
+
+// book is the mutable state of one market's orderbook.
+type book struct {
+	sid       int64
+	yes, no   map[int]int // Price -> count
+	updatedAt time.Time
+	synced    bool
+}
+
+func (b *book) levels(side map[int]int) []Level {
+	levels := make([]Level, 0, len(side))
+	for price, qty := range side {
+		levels = append(levels, Level{Price: price, Quantity: qty})
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Price > levels[j].Price })
+	return levels
+}
+
+// OrderbookStore maintains local orderbooks from orderbook_delta
+// subscriptions: each snapshot replaces a market's book and each delta
+// adjusts one price level.
+//
+// Every message of a subscription carries a sequence number. When one is
+// skipped, the books of that subscription are marked out of sync, Get stops
+// returning them and deltas are ignored until a new snapshot arrives; the
+// client resubscribes to get one.
+type OrderbookStore struct {
+	mu    sync.RWMutex
+	books map[string]*book
+	seqs  map[int64]int64 // SID -> last sequence number applied
+	gaps  atomic.Int64
+}
+
+// NewOrderbookStore creates an empty orderbook store.
+func NewOrderbookStore() *OrderbookStore {
+	return &OrderbookStore{
+		books: make(map[string]*book),
+		seqs:  make(map[int64]int64),
+	}
+}
+
+// Get returns a copy of a market's orderbook. ok is false if no snapshot has
+// been received or the book is out of sync after a sequence gap.
+func (s *OrderbookStore) Get(ticker string) (Orderbook, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	b, ok := s.books[ticker]
+	if !ok || !b.synced {
+		return Orderbook{}, false
+	}
+	return Orderbook{
+		Ticker:    ticker,
+		Yes:       b.levels(b.yes),
+		No:        b.levels(b.no),
+		UpdatedAt: b.updatedAt,
+	}, true
+}
+
+// Gaps returns the number of sequence gaps detected.
+func (s *OrderbookStore) Gaps() int64 {
+	return s.gaps.Load()
+}
+
+// ApplySnapshot replaces a market's book with a snapshot received as message
+// seq of subscription sid.
+func (s *OrderbookStore) ApplySnapshot(sid, seq int64, msg OrderbookSnapshotMsg, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := &book{sid: sid, yes: make(map[int]int), no: make(map[int]int), updatedAt: at, synced: true}
+	for _, l := range msg.Yes {
+		if l[1] > 0 {
+			b.yes[l[0]] = l[1]
+		}
+	}
+	for _, l := range msg.No {
+		if l[1] > 0 {
+			b.no[l[0]] = l[1]
+		}
+	}
+	s.books[msg.MarketTicker] = b
+	s.seqs[sid] = seq
+}
+
+// ApplyDelta applies a delta received as message seq of subscription sid. It
+// returns ErrSequenceGap, and marks the subscription's books out of sync, if
+// seq does not follow the last message applied.
+func (s *OrderbookStore) ApplyDelta(sid, seq int64, msg OrderbookDeltaMsg, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last, ok := s.seqs[sid]
+	if !ok {
+		return nil // Out of sync: waiting for a snapshot
+	}
+	if seq != last+1 {
+		delete(s.seqs, sid)
+		for _, b := range s.books {
+			if b.sid == sid {
+				b.synced = false
+			}
+		}
+		s.gaps.Add(1)
+		return fmt.Errorf("%w: sid %d expected seq %d, got %d", ErrSequenceGap, sid, last+1, seq)
+	}
+	s.seqs[sid] = seq
+
+	b, ok := s.books[msg.MarketTicker]
+	if !ok || b.sid != sid {
+		return nil
+	}
+	side := b.yes
+	if msg.Side == "no" {
+		side = b.no
+	}
+	if qty := side[msg.Price] + msg.Delta; qty > 0 {
+		side[msg.Price] = qty
+	} else {
+		delete(side, msg.Price)
+	}
+	b.updatedAt = at
+	return nil
+}
+
+// tickers returns the markets whose books came from subscription sid.
+func (s *OrderbookStore) tickers(sid int64) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var tickers []string
+	for ticker, b := range s.books {
+		if b.sid == sid {
+			tickers = append(tickers, ticker)
+		}
+	}
+	sort.Strings(tickers)
+	return tickers
+}
+
+// Orderbooks returns the client's orderbook store.
+func (c *Client) Orderbooks() *OrderbookStore {
+	if store := c.orderbooks.Load(); store != nil {
+		return store
+	}
+	c.orderbooks.CompareAndSwap(nil, NewOrderbookStore())
+	return c.orderbooks.Load()
+}
+
+// SubscribeOrderbooks subscribes to the orderbook_delta channel for the given
+// markets and returns the client's orderbook store. Books become available
+// once each market's snapshot arrives.
+func (c *Client) SubscribeOrderbooks(ctx context.Context, marketTickers ...string) (*OrderbookStore, error) {
+	store := c.Orderbooks()
+	for _, ticker := range marketTickers {
+		if _, err := c.Subscribe(ctx, ticker, ChannelOrderbookDelta); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// applyOrderbook forwards an orderbook message to the orderbook store, if one
+// is in use, and resubscribes after a sequence gap.
+func (c *Client) applyOrderbook(resp *Response) {
+	store := c.orderbooks.Load()
+	if store == nil {
+		return
+	}
+
+	var err error
+	switch resp.Type {
+	case MessageTypeOrderbookSnapshot:
+		var msg *OrderbookSnapshotMsg
+		if msg, err = ParseOrderbookSnapshotMsg(resp.Msg); err == nil {
+			store.ApplySnapshot(resp.SID, resp.Seq, *msg, time.Now())
+		}
+	case MessageTypeOrderbookDelta:
+		var msg *OrderbookDeltaMsg
+		if msg, err = ParseOrderbookDeltaMsg(resp.Msg); err == nil {
+			err = store.ApplyDelta(resp.SID, resp.Seq, *msg, time.Now())
+		}
+		if errors.Is(err, ErrSequenceGap) {
+			go c.resyncOrderbooks(resp.SID, store.tickers(resp.SID))
+		}
+	}
+	if err != nil && c.opts.OnError != nil {
+		c.opts.OnError(err)
+	}
+}
+
+// resyncOrderbooks replaces subscription sid with a new subscription to its
+// markets, whose snapshots rebuild the books.
+func (c *Client) resyncOrderbooks(sid int64, tickers []string) {
+	ctx := context.Background()
+	if _, err := c.Unsubscribe(ctx, sid); err != nil {
+		if c.opts.OnError != nil {
+			c.opts.OnError(fmt.Errorf("orderbook resync: %w", err))
+		}
+		return
+	}
+	if _, err := c.SubscribeOrderbooks(ctx, tickers...); err != nil && c.opts.OnError != nil {
+		c.opts.OnError(fmt.Errorf("orderbook resync: %w", err))
+	}
+}
@@ -0,0 +1,109 @@
+package ws
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var bookTime = time.Unix(100, 0)
+
+func snapshotAB() OrderbookSnapshotMsg {
+	return OrderbookSnapshotMsg{
+		MarketTicker: "A",
+		Yes:          [][2]int{{40, 10}, {42, 5}, {30, 0}},
+		No:           [][2]int{{55, 20}, {50, 8}},
+	}
+}
+
+func TestOrderbookStore_Deltas(t *testing.T) {
+	s := NewOrderbookStore()
+	if _, ok := s.Get("A"); ok {
+		t.Fatal("Get() before the snapshot: ok = true, want false")
+	}
+
+	s.ApplySnapshot(1, 1, snapshotAB(), bookTime)
+	for seq, d := range []OrderbookDeltaMsg{
+		{MarketTicker: "A", Side: "yes", Price: 43, Delta: 3},  // New best bid
+		{MarketTicker: "A", Side: "yes", Price: 42, Delta: -5}, // Level emptied
+		{MarketTicker: "A", Side: "no", Price: 55, Delta: -4},
+	} {
+		if err := s.ApplyDelta(1, int64(seq+2), d, bookTime); err != nil {
+			t.Fatalf("ApplyDelta(seq %d) error = %v", seq+2, err)
+		}
+	}
+
+	b, ok := s.Get("A")
+	if !ok {
+		t.Fatal("Get() ok = false, want true")
+	}
+	if len(b.Yes) != 2 || b.Yes[0] != (Level{43, 3}) || b.Yes[1] != (Level{40, 10}) {
+		t.Errorf("Yes = %+v, want 43x3, 40x10", b.Yes)
+	}
+	if b.YesBid() != 43 || b.YesAsk() != 45 || b.NoBid() != 55 || b.NoAsk() != 57 || b.Spread() != 2 {
+		t.Errorf("bid/ask = %d/%d (NO %d/%d), spread %d, want 43/45 (NO 55/57), 2",
+			b.YesBid(), b.YesAsk(), b.NoBid(), b.NoAsk(), b.Spread())
+	}
+	// Buying YES at 50¢ takes the NO bids at 50¢ and up
+	if n := b.Offered("yes", 50); n != 24 {
+		t.Errorf("Offered(yes, 50) = %d, want 24", n)
+	}
+	if n := b.Offered("yes", 45); n != 16 {
+		t.Errorf("Offered(yes, 45) = %d, want 16", n)
+	}
+}
+
+func TestOrderbookStore_SequenceGap(t *testing.T) {
+	s := NewOrderbookStore()
+	s.ApplySnapshot(1, 1, snapshotAB(), bookTime)
+
+	err := s.ApplyDelta(1, 3, OrderbookDeltaMsg{MarketTicker: "A", Side: "yes", Price: 41, Delta: 1}, bookTime)
+	if !errors.Is(err, ErrSequenceGap) {
+		t.Fatalf("ApplyDelta(seq 3 after 1) error = %v, want ErrSequenceGap", err)
+	}
+	if _, ok := s.Get("A"); ok {
+		t.Error("Get() after a gap: ok = true, want false")
+	}
+	if s.Gaps() != 1 {
+		t.Errorf("Gaps() = %d, want 1", s.Gaps())
+	}
+
+	// Later deltas are ignored until a snapshot resyncs the book
+	if err := s.ApplyDelta(1, 4, OrderbookDeltaMsg{MarketTicker: "A", Side: "yes", Price: 41, Delta: 1}, bookTime); err != nil {
+		t.Errorf("ApplyDelta() while out of sync error = %v, want nil", err)
+	}
+	s.ApplySnapshot(2, 1, snapshotAB(), bookTime)
+	if b, ok := s.Get("A"); !ok || b.YesBid() != 42 {
+		t.Errorf("Get() after resync = %+v, %v, want the snapshot", b, ok)
+	}
+}
+
+func TestOrderbook_OneSided(t *testing.T) {
+	b := Orderbook{Yes: []Level{{40, 10}}}
+	if b.YesAsk() != 0 || b.Spread() != 100 || b.Offered("yes", 99) != 0 {
+		t.Errorf("one-sided book: ask %d, spread %d, want 0, 100", b.YesAsk(), b.Spread())
+	}
+}
+
+func TestClient_ApplyOrderbook(t *testing.T) {
+	c := New()
+
+	// No store in use: orderbook messages are ignored
+	c.applyOrderbook(&Response{Type: MessageTypeOrderbookSnapshot, SID: 1, Seq: 1, Msg: map[string]any{"market_ticker": "A"}})
+
+	store := c.Orderbooks()
+	c.applyOrderbook(&Response{Type: MessageTypeOrderbookSnapshot, SID: 1, Seq: 1, Msg: map[string]any{
+		"market_ticker": "A", "yes": [][2]int{{40, 10}}, "no": [][2]int{{55, 20}},
+	}})
+	c.applyOrderbook(&Response{Type: MessageTypeOrderbookDelta, SID: 1, Seq: 2, Msg: map[string]any{
+		"market_ticker": "A", "side": "yes", "price": 41, "delta": 7,
+	}})
+
+	b, ok := store.Get("A")
+	if !ok || b.YesBid() != 41 || b.YesAsk() != 45 {
+		t.Errorf("Get(A) = %+v, %v, want 41/45", b, ok)
+	}
+	if tickers := store.tickers(1); len(tickers) != 1 || tickers[0] != "A" {
+		t.Errorf("tickers(1) = %v, want [A]", tickers)
+	}
+}
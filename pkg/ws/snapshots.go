@@ -0,0 +1,191 @@
+package ws
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Snapshot is the latest conflated state of a market.
+type Snapshot struct {
+	Ticker       string
+	LastPrice    int // cents; last trade or ticker price
+	YesBid       int
+	YesAsk       int
+	Volume       int
+	OpenInterest int
+	UpdatedAt    time.Time
+
+	// Version increases every time any snapshot in the store changes; use it
+	// with SnapshotStore.Since to fetch only what changed.
+	Version uint64
+}
+
+// SnapshotStore conflates ticker and trade messages into the latest state per
+// market, so consumers such as dashboards can render current prices without
+// processing every update.
+//
+// Watchers are notified of changes through a channel with a buffer of one:
+// bursts of updates collapse into a single notification, after which the
+// watcher reads the current state with Since or All.
+type SnapshotStore struct {
+	mu       sync.RWMutex
+	snaps    map[string]Snapshot
+	version  uint64
+	watchers map[chan struct{}]struct{}
+}
+
+// NewSnapshotStore creates an empty snapshot store.
+func NewSnapshotStore() *SnapshotStore {
+	return &SnapshotStore{
+		snaps:    make(map[string]Snapshot),
+		watchers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// Get returns the snapshot for a market ticker.
+func (s *SnapshotStore) Get(ticker string) (Snapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snap, ok := s.snaps[ticker]
+	return snap, ok
+}
+
+// All returns every snapshot, sorted by ticker.
+func (s *SnapshotStore) All() []Snapshot {
+	snaps, _ := s.Since(0)
+	return snaps
+}
+
+// Since returns the snapshots changed after version, sorted by ticker, and
+// the store's current version.
+func (s *SnapshotStore) Since(version uint64) ([]Snapshot, uint64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var snaps []Snapshot
+	for _, snap := range s.snaps {
+		if snap.Version > version {
+			snaps = append(snaps, snap)
+		}
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Ticker < snaps[j].Ticker })
+	return snaps, s.version
+}
+
+// Version returns the store's current version.
+func (s *SnapshotStore) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+
+// Watch returns a channel that receives a value after snapshots change, and a
+// function that stops the notifications.
+func (s *SnapshotStore) Watch() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	s.watchers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.watchers, ch)
+			s.mu.Unlock()
+		})
+	}
+}
+
+// ApplyTicker updates a market's snapshot from a ticker message.
+func (s *SnapshotStore) ApplyTicker(msg TickerMsg) {
+	s.update(msg.MarketTicker, msg.Time(), func(snap *Snapshot) {
+		snap.LastPrice = msg.Price
+		snap.YesBid = msg.YesBid
+		snap.YesAsk = msg.YesAsk
+		snap.Volume = msg.Volume
+		snap.OpenInterest = msg.OpenInterest
+	})
+}
+
+// ApplyTrade updates a market's snapshot from a trade print. The print sets
+// the last price and adds to volume until the next ticker message.
+func (s *SnapshotStore) ApplyTrade(msg TradeMsg) {
+	s.update(msg.MarketTicker, msg.Time(), func(snap *Snapshot) {
+		snap.LastPrice = msg.YesPrice
+		snap.Volume += msg.Count
+	})
+}
+
+// update applies fn to a market's snapshot unless at is older than the
+// snapshot, then notifies watchers.
+func (s *SnapshotStore) update(ticker string, at time.Time, fn func(*Snapshot)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap := s.snaps[ticker]
+	if at.Before(snap.UpdatedAt) {
+		return
+	}
+	snap.Ticker = ticker
+	snap.UpdatedAt = at
+	fn(&snap)
+
+	s.version++
+	snap.Version = s.version
+	s.snaps[ticker] = snap
+
+	for ch := range s.watchers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Snapshots returns the client's snapshot store.
+func (c *Client) Snapshots() *SnapshotStore {
+	if store := c.snapshots.Load(); store != nil {
+		return store
+	}
+	c.snapshots.CompareAndSwap(nil, NewSnapshotStore())
+	return c.snapshots.Load()
+}
+
+// SubscribeSnapshots subscribes to the ticker and trade channels for the
+// given markets (or every market if none are given) and returns the client's
+// snapshot store.
+func (c *Client) SubscribeSnapshots(ctx context.Context, marketTickers ...string) (*SnapshotStore, error) {
+	store := c.Snapshots()
+
+	if len(marketTickers) == 0 {
+		marketTickers = []string{""}
+	}
+	for _, ticker := range marketTickers {
+		if _, err := c.Subscribe(ctx, ticker, ChannelTicker, ChannelTrade); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// applyTicker forwards a ticker message to the snapshot store, if one is in use.
+func (c *Client) applyTicker(resp *Response) {
+	store := c.snapshots.Load()
+	if store == nil {
+		return
+	}
+
+	msg, err := ParseTickerMsg(resp.Msg)
+	if err != nil {
+		if c.opts.OnError != nil {
+			c.opts.OnError(err)
+		}
+		return
+	}
+	store.ApplyTicker(*msg)
+}
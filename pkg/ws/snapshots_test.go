@@ -0,0 +1,96 @@
+package ws
+
+import "testing"
+
+func TestSnapshotStore_Conflates(t *testing.T) {
+	s := NewSnapshotStore()
+
+	s.ApplyTicker(TickerMsg{MarketTicker: "A", Price: 40, YesBid: 39, YesAsk: 41, Volume: 100, TS: 100})
+	s.ApplyTrade(TradeMsg{MarketTicker: "A", YesPrice: 42, Count: 5, TS: 101})
+	s.ApplyTicker(TickerMsg{MarketTicker: "B", Price: 70, YesBid: 69, YesAsk: 72, TS: 100})
+
+	a, ok := s.Get("A")
+	if !ok {
+		t.Fatal("Get(A) not found")
+	}
+	if a.LastPrice != 42 || a.YesBid != 39 || a.YesAsk != 41 || a.Volume != 105 {
+		t.Errorf("Get(A) = %+v, want last 42, 39/41, volume 105", a)
+	}
+	if a.UpdatedAt.Unix() != 101 {
+		t.Errorf("UpdatedAt = %v, want unix 101", a.UpdatedAt)
+	}
+
+	if all := s.All(); len(all) != 2 || all[0].Ticker != "A" || all[1].Ticker != "B" {
+		t.Errorf("All() = %+v, want A, B", all)
+	}
+}
+
+func TestSnapshotStore_IgnoresStaleUpdates(t *testing.T) {
+	s := NewSnapshotStore()
+
+	s.ApplyTicker(TickerMsg{MarketTicker: "A", Price: 40, TS: 200})
+	s.ApplyTicker(TickerMsg{MarketTicker: "A", Price: 10, TS: 100})
+
+	if a, _ := s.Get("A"); a.LastPrice != 40 {
+		t.Errorf("LastPrice = %d, want 40", a.LastPrice)
+	}
+	if s.Version() != 1 {
+		t.Errorf("Version() = %d, want 1", s.Version())
+	}
+}
+
+func TestSnapshotStore_Since(t *testing.T) {
+	s := NewSnapshotStore()
+
+	s.ApplyTicker(TickerMsg{MarketTicker: "A", Price: 40, TS: 100})
+	_, v := s.Since(0)
+	s.ApplyTicker(TickerMsg{MarketTicker: "B", Price: 70, TS: 100})
+
+	changed, latest := s.Since(v)
+	if len(changed) != 1 || changed[0].Ticker != "B" {
+		t.Errorf("Since(%d) = %+v, want only B", v, changed)
+	}
+	if latest != 2 {
+		t.Errorf("latest = %d, want 2", latest)
+	}
+}
+
+func TestSnapshotStore_WatchCollapsesBursts(t *testing.T) {
+	s := NewSnapshotStore()
+	ch, stop := s.Watch()
+
+	for i := 0; i < 10; i++ {
+		s.ApplyTicker(TickerMsg{MarketTicker: "A", Price: i, TS: int64(i)})
+	}
+	if len(ch) != 1 {
+		t.Errorf("pending notifications = %d, want 1", len(ch))
+	}
+	<-ch
+
+	stop()
+	stop()
+	s.ApplyTicker(TickerMsg{MarketTicker: "A", Price: 99, TS: 99})
+	if len(ch) != 0 {
+		t.Error("notified after stop")
+	}
+}
+
+func TestClient_ApplyTicker(t *testing.T) {
+	c := New()
+
+	// No store in use: ticker messages are ignored
+	c.applyTicker(&Response{Type: MessageTypeTicker, Msg: map[string]any{"market_ticker": "A"}})
+
+	store := c.Snapshots()
+	c.applyTicker(&Response{Type: MessageTypeTicker, Msg: map[string]any{
+		"market_ticker": "A", "price": 55, "yes_bid": 54, "yes_ask": 56, "ts": 100,
+	}})
+	c.publishTrade(&Response{Type: MessageTypeTrade, Msg: map[string]any{
+		"market_ticker": "A", "yes_price": 56, "count": 2, "ts": 101,
+	}})
+
+	a, ok := store.Get("A")
+	if !ok || a.LastPrice != 56 || a.YesBid != 54 || a.Volume != 2 {
+		t.Errorf("Get(A) = %+v, %v, want last 56, bid 54, volume 2", a, ok)
+	}
+}
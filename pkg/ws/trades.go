@@ -0,0 +1,139 @@
+package ws
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultTradeBuffer is the default buffer size of each trade stream.
+const DefaultTradeBuffer = 256
+
+// TradeFeed fans out public trade prints received on the trade channel to
+// per-market streams.
+//
+// Streams are buffered and never block the read loop: when a consumer falls
+// behind, prints for that stream are dropped and counted by Dropped. Streams
+// are not closed when the connection drops, so consumers should also select
+// on their own context.
+type TradeFeed struct {
+	mu      sync.RWMutex
+	buffer  int
+	markets map[string]chan TradeMsg
+	all     chan TradeMsg
+	dropped atomic.Int64
+}
+
+// NewTradeFeed creates a trade feed whose streams hold up to buffer prints.
+func NewTradeFeed(buffer int) *TradeFeed {
+	if buffer <= 0 {
+		buffer = DefaultTradeBuffer
+	}
+	return &TradeFeed{
+		buffer:  buffer,
+		markets: make(map[string]chan TradeMsg),
+	}
+}
+
+// Market returns the stream of prints for a market ticker.
+func (f *TradeFeed) Market(ticker string) <-chan TradeMsg {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch, ok := f.markets[ticker]
+	if !ok {
+		ch = make(chan TradeMsg, f.buffer)
+		f.markets[ticker] = ch
+	}
+	return ch
+}
+
+// All returns a stream of prints for every market.
+func (f *TradeFeed) All() <-chan TradeMsg {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.all == nil {
+		f.all = make(chan TradeMsg, f.buffer)
+	}
+	return f.all
+}
+
+// Dropped returns the number of prints dropped because a stream was full.
+func (f *TradeFeed) Dropped() int64 {
+	return f.dropped.Load()
+}
+
+// Publish delivers a print to its market stream and the all-markets stream.
+func (f *TradeFeed) Publish(trade TradeMsg) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if ch, ok := f.markets[trade.MarketTicker]; ok {
+		f.send(ch, trade)
+	}
+	if f.all != nil {
+		f.send(f.all, trade)
+	}
+}
+
+func (f *TradeFeed) send(ch chan TradeMsg, trade TradeMsg) {
+	select {
+	case ch <- trade:
+	default:
+		f.dropped.Add(1)
+	}
+}
+
+// Trades returns the client's trade feed.
+func (c *Client) Trades() *TradeFeed {
+	if feed := c.trades.Load(); feed != nil {
+		return feed
+	}
+	c.trades.CompareAndSwap(nil, NewTradeFeed(DefaultTradeBuffer))
+	return c.trades.Load()
+}
+
+// SubscribeTrades subscribes to the trade channel for the given markets (or
+// every market if none are given) and returns the client's trade feed.
+func (c *Client) SubscribeTrades(ctx context.Context, marketTickers ...string) (*TradeFeed, error) {
+	feed := c.Trades()
+
+	if len(marketTickers) == 0 {
+		if _, err := c.Subscribe(ctx, "", ChannelTrade); err != nil {
+			return nil, err
+		}
+		return feed, nil
+	}
+
+	for _, ticker := range marketTickers {
+		feed.Market(ticker)
+		if _, err := c.Subscribe(ctx, ticker, ChannelTrade); err != nil {
+			return nil, err
+		}
+	}
+	return feed, nil
+}
+
+// publishTrade forwards a trade message to the trade feed and snapshot store,
+// if either is in use.
+func (c *Client) publishTrade(resp *Response) {
+	feed, snapshots := c.trades.Load(), c.snapshots.Load()
+	if feed == nil && snapshots == nil {
+		return
+	}
+
+	trade, err := ParseTradeMsg(resp.Msg)
+	if err != nil {
+		if c.opts.OnError != nil {
+			c.opts.OnError(err)
+		}
+		return
+	}
+	if feed != nil {
+		feed.Publish(*trade)
+	}
+	if snapshots != nil {
+		snapshots.ApplyTrade(*trade)
+	}
+}
@@ -0,0 +1,61 @@
+package ws
+
+import "testing"
+
+func TestTradeFeed_RoutesByMarket(t *testing.T) {
+	feed := NewTradeFeed(4)
+	a := feed.Market("A")
+	all := feed.All()
+
+	feed.Publish(TradeMsg{MarketTicker: "A", YesPrice: 40})
+	feed.Publish(TradeMsg{MarketTicker: "B", YesPrice: 60})
+
+	if got := <-a; got.YesPrice != 40 {
+		t.Errorf("Market(A) got %+v, want yes_price 40", got)
+	}
+	if len(a) != 0 {
+		t.Errorf("Market(A) has %d extra prints, want 0", len(a))
+	}
+	if len(all) != 2 {
+		t.Errorf("All() has %d prints, want 2", len(all))
+	}
+	if feed.Market("A") != a {
+		t.Error("Market(A) returned a different stream on second call")
+	}
+}
+
+func TestTradeFeed_DropsWhenFull(t *testing.T) {
+	feed := NewTradeFeed(1)
+	a := feed.Market("A")
+
+	feed.Publish(TradeMsg{MarketTicker: "A", TradeID: "1"})
+	feed.Publish(TradeMsg{MarketTicker: "A", TradeID: "2"})
+
+	if feed.Dropped() != 1 {
+		t.Errorf("Dropped() = %d, want 1", feed.Dropped())
+	}
+	if got := <-a; got.TradeID != "1" {
+		t.Errorf("got trade %s, want 1 (oldest kept)", got.TradeID)
+	}
+}
+
+func TestClient_PublishTrade(t *testing.T) {
+	c := New()
+
+	// No feed in use: trade messages are ignored
+	c.publishTrade(&Response{Type: MessageTypeTrade, Msg: map[string]any{"market_ticker": "A"}})
+
+	a := c.Trades().Market("A")
+	c.publishTrade(&Response{Type: MessageTypeTrade, Msg: map[string]any{
+		"market_ticker": "A", "yes_price": 55, "count": 3,
+	}})
+
+	select {
+	case got := <-a:
+		if got.YesPrice != 55 || got.Count != 3 {
+			t.Errorf("got %+v, want 3 @ 55", got)
+		}
+	default:
+		t.Fatal("no trade delivered")
+	}
+}